@@ -68,6 +68,10 @@ type ProcessorCreateParams struct {
 
 	// ApplicationStartInfo can be used by components for informational purposes
 	ApplicationStartInfo ApplicationStartInfo
+
+	// PipelineName is the name of the pipeline the processor is created for, a
+	// processor used by several pipelines is created for each of them.
+	PipelineName string
 }
 
 // ProcessorFactory is factory interface for processors. This is the
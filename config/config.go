@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -52,6 +53,11 @@ const (
 	errMissingReceivers
 	errMissingExporters
 	errUnmarshalTopLevelStructureError
+	errInvalidTelemetryLogs
+	errInvalidTelemetryTraces
+	errInvalidTelemetryMetrics
+	errInvalidPipelineReference
+	errInvalidComponentConfig
 )
 
 const (
@@ -95,8 +101,9 @@ type configSettings struct {
 }
 
 type serviceSettings struct {
-	Extensions []string                    `mapstructure:"extensions"`
-	Pipelines  map[string]pipelineSettings `mapstructure:"pipelines"`
+	Extensions []string                      `mapstructure:"extensions"`
+	Pipelines  map[string]pipelineSettings   `mapstructure:"pipelines"`
+	Telemetry  configmodels.ServiceTelemetry `mapstructure:"telemetry"`
 }
 
 type pipelineSettings struct {
@@ -289,6 +296,7 @@ func loadExtensions(exts map[string]interface{}, factories map[configmodels.Type
 func loadService(rawService serviceSettings) (configmodels.Service, error) {
 	var ret configmodels.Service
 	ret.Extensions = rawService.Extensions
+	ret.Telemetry = rawService.Telemetry
 
 	// Process the pipelines first so in case of error on them it can be properly
 	// reported.
@@ -492,6 +500,10 @@ func ValidateConfig(cfg *configmodels.Config, _ *zap.Logger) error {
 		return err
 	}
 
+	if err := validateComponentConfigs(cfg); err != nil {
+		return err
+	}
+
 	if err := validateService(cfg); err != nil {
 		return err
 	}
@@ -504,9 +516,137 @@ func validateService(cfg *configmodels.Config) error {
 		return err
 	}
 
+	if err := validatePipelineReferences(cfg); err != nil {
+		return err
+	}
+
+	if err := validateServiceTelemetryLogs(cfg); err != nil {
+		return err
+	}
+
+	if err := validateServiceTelemetryTraces(cfg); err != nil {
+		return err
+	}
+
+	if err := validateServiceTelemetryMetrics(cfg); err != nil {
+		return err
+	}
+
 	return validateServiceExtensions(cfg)
 }
 
+func validateServiceTelemetryLogs(cfg *configmodels.Config) error {
+	logs := cfg.Service.Telemetry.Logs
+
+	if logs.Level != "" {
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(logs.Level)); err != nil {
+			return &configError{
+				code: errInvalidTelemetryLogs,
+				msg:  fmt.Sprintf("invalid telemetry logs level %q: %v", logs.Level, err),
+			}
+		}
+	}
+
+	switch logs.Encoding {
+	case "", "json", "console":
+	default:
+		return &configError{
+			code: errInvalidTelemetryLogs,
+			msg:  fmt.Sprintf("invalid telemetry logs encoding %q, must be \"json\" or \"console\"", logs.Encoding),
+		}
+	}
+
+	for key, levelStr := range logs.ComponentLevels {
+		items := strings.SplitN(key, typeAndNameSeparator, 2)
+		switch items[0] {
+		case "receiver", "processor", "exporter", "extension":
+		default:
+			return &configError{
+				code: errInvalidTelemetryLogs,
+				msg:  fmt.Sprintf("invalid telemetry logs component %q, must be in the \"<kind>/<name>\" format", key),
+			}
+		}
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(levelStr)); err != nil {
+			return &configError{
+				code: errInvalidTelemetryLogs,
+				msg:  fmt.Sprintf("invalid telemetry logs level %q for component %q: %v", levelStr, key, err),
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateServiceTelemetryTraces(cfg *configmodels.Config) error {
+	traces := cfg.Service.Telemetry.Traces
+
+	if traces.SamplingRatio < 0 || traces.SamplingRatio > 1 {
+		return &configError{
+			code: errInvalidTelemetryTraces,
+			msg:  fmt.Sprintf("invalid telemetry traces sampling_ratio %v, must be between 0 and 1", traces.SamplingRatio),
+		}
+	}
+
+	if traces.Pipeline == "" {
+		return nil
+	}
+
+	pipeline := cfg.Service.Pipelines[traces.Pipeline]
+	if pipeline == nil || pipeline.InputType != configmodels.TracesDataType {
+		return &configError{
+			code: errInvalidTelemetryTraces,
+			msg:  fmt.Sprintf("telemetry traces references pipeline %q which does not exist or is not a traces pipeline", traces.Pipeline),
+		}
+	}
+
+	// The exporters of the pipeline cannot be shared, otherwise exporting the
+	// collector's own spans would create more spans to export.
+	for _, other := range cfg.Service.Pipelines {
+		if other == pipeline {
+			continue
+		}
+		for _, ref := range other.Exporters {
+			for _, ownRef := range pipeline.Exporters {
+				if ref == ownRef {
+					return &configError{
+						code: errInvalidTelemetryTraces,
+						msg:  fmt.Sprintf("telemetry traces pipeline %q shares exporter %q with pipeline %q", pipeline.Name, ref, other.Name),
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateServiceTelemetryMetrics(cfg *configmodels.Config) error {
+	metrics := cfg.Service.Telemetry.Metrics
+
+	if metrics.Interval < 0 {
+		return &configError{
+			code: errInvalidTelemetryMetrics,
+			msg:  fmt.Sprintf("invalid telemetry metrics interval %v, must not be negative", metrics.Interval),
+		}
+	}
+
+	if metrics.Pipeline == "" {
+		return nil
+	}
+
+	pipeline := cfg.Service.Pipelines[metrics.Pipeline]
+	if pipeline == nil || pipeline.InputType != configmodels.MetricsDataType {
+		return &configError{
+			code: errInvalidTelemetryMetrics,
+			msg:  fmt.Sprintf("telemetry metrics references pipeline %q which does not exist or is not a metrics pipeline", metrics.Pipeline),
+		}
+	}
+
+	return nil
+}
+
 func validateServiceExtensions(cfg *configmodels.Config) error {
 	if len(cfg.Service.Extensions) == 0 {
 		return nil
@@ -541,6 +681,67 @@ func validatePipelines(cfg *configmodels.Config) error {
 	return nil
 }
 
+// validateComponentConfigs validates the settings of the configs of the components.
+func validateComponentConfigs(cfg *configmodels.Config) error {
+	return forEachComponentConfig(cfg, func(kind string, name string, componentCfg interface{}) error {
+		validator, ok := componentCfg.(configmodels.Validator)
+		if !ok {
+			return nil
+		}
+		if err := validator.Validate(); err != nil {
+			return &configError{
+				code: errInvalidComponentConfig,
+				msg:  fmt.Sprintf("%s %q has invalid configuration: %v", kind, name, err),
+			}
+		}
+		return nil
+	})
+}
+
+// validatePipelineReferences validates the pipelines referenced by the configs
+// of the components.
+func validatePipelineReferences(cfg *configmodels.Config) error {
+	return forEachComponentConfig(cfg, func(kind string, name string, componentCfg interface{}) error {
+		validator, ok := componentCfg.(configmodels.PipelinesValidator)
+		if !ok {
+			return nil
+		}
+		if err := validator.ValidatePipelines(cfg.Service.Pipelines); err != nil {
+			return &configError{
+				code: errInvalidPipelineReference,
+				msg:  fmt.Sprintf("%s %q has invalid pipeline reference: %v", kind, name, err),
+			}
+		}
+		return nil
+	})
+}
+
+// forEachComponentConfig calls f with the kind, name and config of each
+// component, and returns the first error returned by f.
+func forEachComponentConfig(cfg *configmodels.Config, f func(kind string, name string, componentCfg interface{}) error) error {
+	for name, receiverCfg := range cfg.Receivers {
+		if err := f("receiver", name, receiverCfg); err != nil {
+			return err
+		}
+	}
+	for name, processorCfg := range cfg.Processors {
+		if err := f("processor", name, processorCfg); err != nil {
+			return err
+		}
+	}
+	for name, exporterCfg := range cfg.Exporters {
+		if err := f("exporter", name, exporterCfg); err != nil {
+			return err
+		}
+	}
+	for name, extensionCfg := range cfg.Extensions {
+		if err := f("extension", name, extensionCfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validatePipeline(cfg *configmodels.Config, pipeline *configmodels.Pipeline) error {
 	if err := validatePipelineReceivers(cfg, pipeline); err != nil {
 		return err
@@ -558,7 +759,9 @@ func validatePipeline(cfg *configmodels.Config, pipeline *configmodels.Pipeline)
 }
 
 func validatePipelineReceivers(cfg *configmodels.Config, pipeline *configmodels.Pipeline) error {
-	if len(pipeline.Receivers) == 0 {
+	// The pipelines of the collector's own traces and metrics are fed internally.
+	telemetry := cfg.Service.Telemetry
+	if len(pipeline.Receivers) == 0 && pipeline.Name != telemetry.Traces.Pipeline && pipeline.Name != telemetry.Metrics.Pipeline {
 		return &configError{
 			code: errPipelineMustHaveReceiver,
 			msg:  fmt.Sprintf("pipeline %q must have at least one receiver", pipeline.Name),
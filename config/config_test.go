@@ -18,6 +18,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,6 +46,28 @@ func TestDecodeConfig(t *testing.T) {
 	assert.Equal(t, 2, len(config.Service.Extensions))
 	assert.Equal(t, "exampleextension/0", config.Service.Extensions[0])
 	assert.Equal(t, "exampleextension/1", config.Service.Extensions[1])
+	assert.Equal(t,
+		configmodels.ServiceTelemetry{
+			Logs: configmodels.ServiceTelemetryLogs{
+				Level:       "debug",
+				Encoding:    "json",
+				OutputPaths: []string{"stdout", "/var/log/otelcol.log"},
+				Rotation: &configmodels.LogsRotation{
+					MaxSizeMiB: 10,
+					MaxBackups: 3,
+					Compress:   true,
+				},
+				Sampling: &configmodels.LogsSampling{
+					Initial:    10,
+					Thereafter: 50,
+					Tick:       5 * time.Second,
+				},
+				ComponentLevels: map[string]string{
+					"exporter/exampleexporter": "warn",
+				},
+			},
+		},
+		config.Service.Telemetry)
 
 	// Verify receivers
 	assert.Equal(t, 2, len(config.Receivers), "Incorrect receivers count")
@@ -459,6 +482,56 @@ func TestDecodeConfig_MultiProto(t *testing.T) {
 		"Did not load receiver config correctly")
 }
 
+func TestDecodeConfig_TelemetryTraces(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	// Load the config
+	config, err := loadConfigFile(t, path.Join(".", "testdata", "telemetry-traces-config.yaml"), factories)
+	require.NoError(t, err, "Unable to load config")
+
+	assert.Equal(t,
+		configmodels.ServiceTelemetryTraces{
+			Pipeline:      "traces/telemetry",
+			SamplingRatio: 0.01,
+		},
+		config.Service.Telemetry.Traces)
+
+	// The pipeline of the collector's own spans doesn't need receivers.
+	assert.Equal(t,
+		&configmodels.Pipeline{
+			Name:      "traces/telemetry",
+			InputType: configmodels.TracesDataType,
+			Exporters: []string{"exampleexporter/telemetry"},
+		},
+		config.Service.Pipelines["traces/telemetry"])
+}
+
+func TestDecodeConfig_TelemetryMetrics(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	// Load the config
+	config, err := loadConfigFile(t, path.Join(".", "testdata", "telemetry-metrics-config.yaml"), factories)
+	require.NoError(t, err, "Unable to load config")
+
+	assert.Equal(t,
+		configmodels.ServiceTelemetryMetrics{
+			Pipeline: "metrics/telemetry",
+			Interval: 30 * time.Second,
+		},
+		config.Service.Telemetry.Metrics)
+
+	// The pipeline of the collector's own metrics doesn't need receivers.
+	assert.Equal(t,
+		&configmodels.Pipeline{
+			Name:      "metrics/telemetry",
+			InputType: configmodels.MetricsDataType,
+			Exporters: []string{"exampleexporter"},
+		},
+		config.Service.Pipelines["metrics/telemetry"])
+}
+
 func TestDecodeConfig_Invalid(t *testing.T) {
 
 	var testCases = []struct {
@@ -510,6 +583,15 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 		{name: "invalid-processor-sub-config", expected: errUnmarshalTopLevelStructureError},
 		{name: "invalid-receiver-sub-config", expected: errUnmarshalTopLevelStructureError},
 		{name: "invalid-pipeline-sub-config", expected: errUnmarshalTopLevelStructureError},
+		{name: "invalid-telemetry-logs-level", expected: errInvalidTelemetryLogs},
+		{name: "invalid-telemetry-logs-encoding", expected: errInvalidTelemetryLogs},
+		{name: "invalid-telemetry-logs-component", expected: errInvalidTelemetryLogs},
+		{name: "invalid-telemetry-logs-section", expected: errUnmarshalTopLevelStructureError, expectedMessage: "service"},
+		{name: "invalid-telemetry-traces-pipeline", expected: errInvalidTelemetryTraces},
+		{name: "invalid-telemetry-traces-exporter", expected: errInvalidTelemetryTraces},
+		{name: "invalid-telemetry-traces-sampling-ratio", expected: errInvalidTelemetryTraces},
+		{name: "invalid-telemetry-metrics-pipeline", expected: errInvalidTelemetryMetrics},
+		{name: "invalid-telemetry-metrics-interval", expected: errInvalidTelemetryMetrics},
 	}
 
 	factories, err := componenttest.ExampleComponents()
@@ -24,9 +24,10 @@ import (
 )
 
 var (
-	errNoOIDCProvided   = errors.New("no OIDC information provided")
-	errMetadataNotFound = errors.New("no request metadata found")
-	defaultAttribute    = "authorization"
+	errNoAuthenticatorProvided = errors.New("no OIDC or mTLS information provided")
+	errMultipleAuthenticators  = errors.New("only one of OIDC and mTLS can be provided")
+	errMetadataNotFound        = errors.New("no request metadata found")
+	defaultAttribute           = "authorization"
 )
 
 // Authenticator will authenticate the incoming request/RPC
@@ -52,8 +53,14 @@ type streamInterceptorFunc func(srv interface{}, stream grpc.ServerStream, info
 
 // NewAuthenticator creates an authenticator based on the given configuration
 func NewAuthenticator(cfg Authentication) (Authenticator, error) {
+	if cfg.OIDC != nil && cfg.MTLS != nil {
+		return nil, errMultipleAuthenticators
+	}
+	if cfg.MTLS != nil {
+		return newMTLSAuthenticator(cfg)
+	}
 	if cfg.OIDC == nil {
-		return nil, errNoOIDCProvided
+		return nil, errNoAuthenticatorProvided
 	}
 
 	if len(cfg.Attribute) == 0 {
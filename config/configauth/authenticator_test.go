@@ -44,7 +44,24 @@ func TestMissingOIDC(t *testing.T) {
 
 	// verify
 	assert.Nil(t, p)
-	assert.Equal(t, errNoOIDCProvided, err)
+	assert.Equal(t, errNoAuthenticatorProvided, err)
+}
+
+func TestMultipleAuthenticators(t *testing.T) {
+	// test
+	p, err := NewAuthenticator(Authentication{
+		OIDC: &OIDC{
+			Audience:  "some-audience",
+			IssuerURL: "http://example.com",
+		},
+		MTLS: &MTLS{
+			AllowedSubjects: []string{"client.example.com"},
+		},
+	})
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleAuthenticators, err)
 }
 
 func TestDefaultUnaryInterceptorAuthSucceeded(t *testing.T) {
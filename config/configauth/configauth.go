@@ -26,8 +26,12 @@ type Authentication struct {
 	Attribute string `mapstructure:"attribute"`
 
 	// OIDC configures this receiver to use the given OIDC provider as the backend for the authentication mechanism.
-	// Required.
+	// Either OIDC or MTLS is required.
 	OIDC *OIDC `mapstructure:"oidc"`
+
+	// MTLS configures this receiver to authenticate the clients with the certificate they presented during the TLS
+	// handshake. Either OIDC or MTLS is required.
+	MTLS *MTLS `mapstructure:"mtls"`
 }
 
 // OIDC defines the OpenID Connect properties for this processor
@@ -54,6 +58,14 @@ type OIDC struct {
 	GroupsClaim string `mapstructure:"groups_claim"`
 }
 
+// MTLS defines the mutual TLS properties for this receiver. The TLS settings of the server must verify the client
+// certificates, with a client_ca_file.
+type MTLS struct {
+	// AllowedSubjects are the common names or DNS names of the client certificates allowed to call the server.
+	// Required.
+	AllowedSubjects []string `mapstructure:"allowed_subjects"`
+}
+
 // ToServerOptions builds a set of server options ready to be used by the gRPC server
 func (a *Authentication) ToServerOptions() ([]grpc.ServerOption, error) {
 	auth, err := NewAuthenticator(*a)
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauth
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+type mtlsAuthenticator struct {
+	allowedSubjects map[string]struct{}
+
+	unaryInterceptor  unaryInterceptorFunc
+	streamInterceptor streamInterceptorFunc
+}
+
+var (
+	_ Authenticator = (*mtlsAuthenticator)(nil)
+
+	errNoAllowedSubjects     = errors.New("no allowed subjects provided for the mTLS configuration")
+	errNoVerifiedCertificate = errors.New("no verified client certificate found")
+	errSubjectNotAllowed     = errors.New("the subject of the client certificate isn't allowed")
+)
+
+func newMTLSAuthenticator(cfg Authentication) (*mtlsAuthenticator, error) {
+	if len(cfg.MTLS.AllowedSubjects) == 0 {
+		return nil, errNoAllowedSubjects
+	}
+
+	allowedSubjects := make(map[string]struct{}, len(cfg.MTLS.AllowedSubjects))
+	for _, s := range cfg.MTLS.AllowedSubjects {
+		allowedSubjects[s] = struct{}{}
+	}
+
+	return &mtlsAuthenticator{
+		allowedSubjects:   allowedSubjects,
+		unaryInterceptor:  defaultUnaryInterceptor,
+		streamInterceptor: defaultStreamInterceptor,
+	}, nil
+}
+
+// Authenticate checks the certificate verified during the TLS handshake of the connection, the headers are ignored.
+// The subject in the context is the common name of the certificate and the groups its organizational units.
+func (m *mtlsAuthenticator) Authenticate(ctx context.Context, _ map[string][]string) (context.Context, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx, errNoVerifiedCertificate
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ctx, errNoVerifiedCertificate
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	allowed := m.isAllowed(cert.Subject.CommonName)
+	for _, name := range cert.DNSNames {
+		allowed = allowed || m.isAllowed(name)
+	}
+	if !allowed {
+		return ctx, errSubjectNotAllowed
+	}
+
+	ctx = context.WithValue(ctx, subjectKey, cert.Subject.CommonName)
+	ctx = context.WithValue(ctx, groupsKey, cert.Subject.OrganizationalUnit)
+	return ctx, nil
+}
+
+func (m *mtlsAuthenticator) isAllowed(name string) bool {
+	if name == "" {
+		return false
+	}
+	_, ok := m.allowedSubjects[name]
+	return ok
+}
+
+func (m *mtlsAuthenticator) Start(context.Context) error {
+	return nil
+}
+
+func (m *mtlsAuthenticator) Close() error {
+	return nil
+}
+
+func (m *mtlsAuthenticator) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return m.unaryInterceptor(ctx, req, info, handler, m.Authenticate)
+}
+
+func (m *mtlsAuthenticator) StreamInterceptor(srv interface{}, str grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return m.streamInterceptor(srv, str, info, handler, m.Authenticate)
+}
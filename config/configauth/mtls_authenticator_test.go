@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func newPeerContext(cert *x509.Certificate) context.Context {
+	state := tls.ConnectionState{}
+	if cert != nil {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestMTLSAuthenticationSucceeded(t *testing.T) {
+	// prepare
+	p, err := NewAuthenticator(Authentication{
+		MTLS: &MTLS{AllowedSubjects: []string{"client", "other.example.com"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background()))
+
+	for _, cert := range []*x509.Certificate{
+		{Subject: pkix.Name{CommonName: "client", OrganizationalUnit: []string{"team-a"}}},
+		{Subject: pkix.Name{CommonName: "client", OrganizationalUnit: []string{"team-a"}}, DNSNames: []string{"other.example.com"}},
+	} {
+		// test
+		ctx, err := p.Authenticate(newPeerContext(cert), nil)
+
+		// verify
+		require.NoError(t, err)
+		subject, ok := SubjectFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "client", subject)
+		groups, ok := GroupsFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"team-a"}, groups)
+	}
+
+	// a DNS name is enough when the common name isn't allowed
+	_, err = p.Authenticate(newPeerContext(&x509.Certificate{DNSNames: []string{"other.example.com"}}), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Close())
+}
+
+func TestMTLSAuthenticationFailed(t *testing.T) {
+	// prepare
+	p, err := NewAuthenticator(Authentication{
+		MTLS: &MTLS{AllowedSubjects: []string{"client"}},
+	})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name        string
+		ctx         context.Context
+		expectedErr error
+	}{
+		{
+			name:        "noPeer",
+			ctx:         context.Background(),
+			expectedErr: errNoVerifiedCertificate,
+		},
+		{
+			name:        "noTLS",
+			ctx:         peer.NewContext(context.Background(), &peer.Peer{}),
+			expectedErr: errNoVerifiedCertificate,
+		},
+		{
+			name:        "noVerifiedCertificate",
+			ctx:         newPeerContext(nil),
+			expectedErr: errNoVerifiedCertificate,
+		},
+		{
+			name:        "subjectNotAllowed",
+			ctx:         newPeerContext(&x509.Certificate{Subject: pkix.Name{CommonName: "intruder"}, DNSNames: []string{"intruder.example.com"}}),
+			expectedErr: errSubjectNotAllowed,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// test
+			_, err := p.Authenticate(tt.ctx, nil)
+
+			// verify
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestMTLSNoAllowedSubjects(t *testing.T) {
+	// test
+	p, err := NewAuthenticator(Authentication{MTLS: &MTLS{}})
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errNoAllowedSubjects, err)
+}
@@ -16,12 +16,15 @@ package confighttp
 
 import (
 	"crypto/tls"
+	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/rs/cors"
 
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/internal/middleware"
 )
@@ -46,6 +49,10 @@ type HTTPClientSettings struct {
 	// Existing header values are overwritten if collision happens.
 	Headers map[string]string `mapstructure:"headers,omitempty"`
 
+	// ProxyURL is the URL of the HTTP(S) proxy the requests are sent through (e.g.: http://proxy.example.com:3128).
+	// If empty, the proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string `mapstructure:"proxy_url"`
+
 	// Custom Round Tripper to allow for individual components to intercept HTTP requests
 	CustomRoundTripper func(next http.RoundTripper) (http.RoundTripper, error)
 }
@@ -65,6 +72,16 @@ func (hcs *HTTPClientSettings) ToClient() (*http.Client, error) {
 	if hcs.WriteBufferSize > 0 {
 		transport.WriteBufferSize = hcs.WriteBufferSize
 	}
+	if hcs.ProxyURL != "" {
+		proxyURL, err := url.Parse(hcs.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy_url %q: the scheme and host are required", hcs.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
 
 	clientTransport := (http.RoundTripper)(transport)
 	if len(hcs.Headers) > 0 {
@@ -103,7 +120,8 @@ func (interceptor *headerRoundTripper) RoundTrip(req *http.Request) (*http.Respo
 }
 
 type HTTPServerSettings struct {
-	// Endpoint configures the listening address for the server.
+	// Endpoint configures the listening address for the server, "host:port" or "unix:///path/to/socket" for a Unix
+	// domain socket.
 	Endpoint string `mapstructure:"endpoint"`
 
 	// TLSSetting struct exposes TLS client configuration.
@@ -118,12 +136,14 @@ type HTTPServerSettings struct {
 	// CorsHeaders are the allowed CORS headers for HTTP/JSON requests to grpc-gateway adapter
 	// for the OTLP receiver. See github.com/rs/cors
 	// CORS needs to be enabled first by providing a non-empty list in CorsOrigins
-	// A wildcard (*) can be used to match any header.
+	// A wildcard (*) can be used to match any header. The Accept, Content-Type and
+	// X-Requested-With headers are always allowed.
 	CorsHeaders []string `mapstructure:"cors_allowed_headers"`
 }
 
 func (hss *HTTPServerSettings) ToListener() (net.Listener, error) {
-	listener, err := net.Listen("tcp", hss.Endpoint)
+	na := confignet.NetAddr{Endpoint: hss.Endpoint, Transport: "tcp"}
+	listener, err := na.Listen()
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +162,8 @@ func (hss *HTTPServerSettings) ToListener() (net.Listener, error) {
 // toServerOptions has options that change the behavior of the HTTP server
 // returned by HTTPServerSettings.ToServer().
 type toServerOptions struct {
-	errorHandler middleware.ErrorHandler
+	errorHandler       middleware.ErrorHandler
+	maxRequestBodySize int64
 }
 
 type ToServerOption func(opts *toServerOptions)
@@ -155,13 +176,30 @@ func WithErrorHandler(e middleware.ErrorHandler) ToServerOption {
 	}
 }
 
+// defaultCorsHeaders are the headers allowed in the CORS requests in addition to CorsHeaders.
+var defaultCorsHeaders = []string{"Accept", "Content-Type", "X-Requested-With"}
+
+// WithMaxRequestBodySize limits the size of the request bodies, after their decompression by
+// middleware.HTTPContentDecompressor, to n bytes. There is no limit if n is 0.
+func WithMaxRequestBodySize(n int64) ToServerOption {
+	return func(opts *toServerOptions) {
+		opts.maxRequestBodySize = n
+	}
+}
+
 func (hss *HTTPServerSettings) ToServer(handler http.Handler, opts ...ToServerOption) *http.Server {
 	serverOpts := &toServerOptions{}
 	for _, o := range opts {
 		o(serverOpts)
 	}
 	if len(hss.CorsOrigins) > 0 {
-		co := cors.Options{AllowedOrigins: hss.CorsOrigins, AllowedHeaders: hss.CorsHeaders}
+		allowedHeaders := hss.CorsHeaders
+		if len(allowedHeaders) > 0 {
+			// github.com/rs/cors only allows its default headers when no header is configured: keep allowing them,
+			// Content-Type in particular is required by the browsers sending JSON.
+			allowedHeaders = append(append([]string{}, defaultCorsHeaders...), allowedHeaders...)
+		}
+		co := cors.Options{AllowedOrigins: hss.CorsOrigins, AllowedHeaders: allowedHeaders}
 		handler = cors.New(co).Handler(handler)
 	}
 	// TODO: emit a warning when non-empty CorsHeaders and empty CorsOrigins.
@@ -169,6 +207,7 @@ func (hss *HTTPServerSettings) ToServer(handler http.Handler, opts ...ToServerOp
 	handler = middleware.HTTPContentDecompressor(
 		handler,
 		middleware.WithErrorHandler(serverOpts.errorHandler),
+		middleware.WithMaxBodySize(serverOpts.maxRequestBodySize),
 	)
 	return &http.Server{
 		Handler: handler,
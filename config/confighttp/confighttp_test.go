@@ -111,6 +111,18 @@ func TestHTTPClientSettingsError(t *testing.T) {
 				},
 			},
 		},
+		{
+			err: "^invalid proxy_url \"proxy.example.com:3128\": the scheme and host are required",
+			settings: HTTPClientSettings{
+				ProxyURL: "proxy.example.com:3128",
+			},
+		},
+		{
+			err: "^invalid proxy_url: ",
+			settings: HTTPClientSettings{
+				ProxyURL: "http://proxy.example.com:port",
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -481,3 +493,23 @@ func TestHttpHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPClientProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		w.WriteHeader(200)
+	}))
+	defer proxy.Close()
+
+	setting := HTTPClientSettings{
+		Endpoint: "http://backend.example.com:4318",
+		ProxyURL: proxy.URL,
+	}
+	client, err := setting.ToClient()
+	require.NoError(t, err)
+	resp, err := client.Get(setting.Endpoint + "/v1/traces")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "backend.example.com:4318", proxiedHost)
+}
@@ -29,6 +29,10 @@
 // the corresponding common settings struct (the easiest approach is to embed the common struct).
 package configmodels
 
+import (
+	"time"
+)
+
 // Config defines the configuration for the various elements of collector or agent.
 type Config struct {
 	Receivers
@@ -45,6 +49,121 @@ type Service struct {
 
 	// Pipelines is the set of data pipelines configured for the service.
 	Pipelines Pipelines
+
+	// Telemetry is the configuration for the collector's own telemetry.
+	Telemetry ServiceTelemetry
+}
+
+// ServiceTelemetry defines the configurable settings for the collector's own telemetry.
+type ServiceTelemetry struct {
+	// Logs is the configuration of the collector's own logs.
+	Logs ServiceTelemetryLogs `mapstructure:"logs"`
+
+	// Traces is the configuration of the collector's own traces.
+	Traces ServiceTelemetryTraces `mapstructure:"traces"`
+
+	// Metrics is the configuration of the collector's own metrics.
+	Metrics ServiceTelemetryMetrics `mapstructure:"metrics"`
+}
+
+// ServiceTelemetryTraces defines the configurable settings for the collector's own traces,
+// that measure the receiving, processing and exporting of the data by the pipelines.
+type ServiceTelemetryTraces struct {
+	// Pipeline is the name of the traces pipeline the collector's own spans are sent to.
+	// The pipeline doesn't need receivers, and its exporters cannot be used by other
+	// pipelines, so exporting the spans doesn't produce new spans. If not set the spans
+	// are only available to the zPages extension.
+	Pipeline string `mapstructure:"pipeline"`
+
+	// SamplingRatio is the fraction, between 0 and 1, of the operations that are traced
+	// when they don't have a sampled parent span. If not set the OpenCensus default of
+	// 1 in 10000 is used.
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+}
+
+// ServiceTelemetryMetrics defines the configurable settings for pushing the collector's
+// own metrics to a pipeline, in addition to serving them on the Prometheus endpoint.
+type ServiceTelemetryMetrics struct {
+	// Pipeline is the name of the metrics pipeline the collector's own metrics are
+	// periodically sent to. The pipeline doesn't need receivers. If not set the
+	// metrics are only served on the Prometheus endpoint.
+	Pipeline string `mapstructure:"pipeline"`
+
+	// Interval is the period between two pushes of the metrics to the pipeline.
+	// If not set the metrics are pushed every 10 seconds.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// ServiceTelemetryLogs defines the configurable settings for the collector's own logs.
+// Empty settings fall back to the values of the logging command line flags.
+type ServiceTelemetryLogs struct {
+	// Level is the minimum enabled logging level (debug, info, warn, error, dpanic, panic, fatal).
+	Level string `mapstructure:"level"`
+
+	// Development puts the logger in development mode, which takes stacktraces
+	// more liberally and makes DPanic-level logs panic.
+	Development bool `mapstructure:"development"`
+
+	// Encoding sets the logger's encoding, valid values are "json" and "console".
+	Encoding string `mapstructure:"encoding"`
+
+	// OutputPaths is a list of URLs or file paths to write logging output to.
+	// Defaults to "stderr".
+	OutputPaths []string `mapstructure:"output_paths"`
+
+	// ErrorOutputPaths is a list of URLs or file paths to write internal logger
+	// errors to. Defaults to "stderr".
+	ErrorOutputPaths []string `mapstructure:"error_output_paths"`
+
+	// Rotation enables the rotation of the files in OutputPaths. If not set
+	// the files grow unbounded.
+	Rotation *LogsRotation `mapstructure:"rotation"`
+
+	// Sampling overrides the sampling of repeated log entries. If not set the
+	// default of the logging profile is used.
+	Sampling *LogsSampling `mapstructure:"sampling"`
+
+	// ComponentLevels overrides the logging level for individual components.
+	// The keys are in the "<kind>/<name>" format, where kind is one of
+	// receiver, processor, exporter or extension, e.g. "exporter/otlp/2".
+	ComponentLevels map[string]string `mapstructure:"component_levels"`
+}
+
+// LogsRotation defines the rotation of the log files.
+type LogsRotation struct {
+	// MaxSizeMiB is the maximum size, in MiB, of a log file before it gets rotated.
+	// Defaults to 100 MiB.
+	MaxSizeMiB int `mapstructure:"max_size_mib"`
+
+	// MaxAgeDays is the maximum number of days to retain old log files.
+	// Defaults to zero, so old files are not removed based on their age.
+	MaxAgeDays int `mapstructure:"max_age_days"`
+
+	// MaxBackups is the maximum number of old log files to retain.
+	// Defaults to zero, so all old files are retained (MaxAgeDays may still remove them).
+	MaxBackups int `mapstructure:"max_backups"`
+
+	// LocalTime determines if the time used for formatting the timestamps in
+	// backup files is the local time. Defaults to UTC.
+	LocalTime bool `mapstructure:"localtime"`
+
+	// Compress determines if the rotated log files are compressed using gzip.
+	Compress bool `mapstructure:"compress"`
+}
+
+// LogsSampling defines the sampling of repeated log entries. In every Tick
+// the first Initial entries with the same level and message are logged, and
+// after that only every Thereafter-th entry is logged.
+type LogsSampling struct {
+	// Initial is the number of entries logged per Tick before sampling starts.
+	// Setting it to zero disables the sampling.
+	Initial int `mapstructure:"initial"`
+
+	// Thereafter is the sampling rate applied after Initial entries were logged.
+	Thereafter int `mapstructure:"thereafter"`
+
+	// Tick is the sampling period. Defaults to 1s.
+	Tick time.Duration `mapstructure:"tick"`
 }
 
 // Type is the component type as it is used in the config.
@@ -84,3 +203,16 @@ type Pipeline struct {
 
 // Pipelines is a map of names to Pipelines.
 type Pipelines map[string]*Pipeline
+
+// Validator is implemented by the configs of the components which validate
+// their settings, they are validated when the config is validated.
+type Validator interface {
+	Validate() error
+}
+
+// PipelinesValidator is implemented by the configs of the components which
+// reference pipelines by name, the references are validated against the
+// pipelines of the service when the config is validated.
+type PipelinesValidator interface {
+	ValidatePipelines(pipelines Pipelines) error
+}
@@ -16,8 +16,13 @@ package confignet
 
 import (
 	"net"
+	"os"
+	"strings"
 )
 
+// unixScheme prefixes the endpoints of Unix domain sockets, e.g. "unix:///var/run/otelcol.sock".
+const unixScheme = "unix://"
+
 // NetAddr represents a network endpoint address.
 type NetAddr struct {
 	// Endpoint configures the address for this network connection.
@@ -25,6 +30,7 @@ type NetAddr struct {
 	// or a host name that can be resolved to IP addresses. The port must be a literal port number or a service name.
 	// If the host is a literal IPv6 address it must be enclosed in square brackets, as in "[2001:db8::1]:80" or
 	// "[fe80::1%zone]:80". The zone specifies the scope of the literal IPv6 address as defined in RFC 4007.
+	// An endpoint of the form "unix:///path/to/socket" is the path of a Unix domain socket, whatever the Transport.
 	Endpoint string `mapstructure:"endpoint"`
 
 	// Transport to use. Known protocols are "tcp", "tcp4" (IPv4-only), "tcp6" (IPv6-only), "udp", "udp4" (IPv4-only),
@@ -33,11 +39,37 @@ type NetAddr struct {
 }
 
 func (na *NetAddr) Dial() (net.Conn, error) {
-	return net.Dial(na.Transport, na.Endpoint)
+	network, address := na.networkAddress()
+	return net.Dial(network, address)
 }
 
+// Listen listens on the address. For the Unix domain sockets, a socket file left by a process that did not close its
+// listener, e.g. because it crashed, is removed first, unless a process still accepts connections on it.
 func (na *NetAddr) Listen() (net.Listener, error) {
-	return net.Listen(na.Transport, na.Endpoint)
+	network, address := na.networkAddress()
+	if network == "unix" || network == "unixpacket" {
+		removeStaleSocket(network, address)
+	}
+	return net.Listen(network, address)
+}
+
+func (na *NetAddr) networkAddress() (string, string) {
+	if strings.HasPrefix(na.Endpoint, unixScheme) {
+		return "unix", strings.TrimPrefix(na.Endpoint, unixScheme)
+	}
+	return na.Transport, na.Endpoint
+}
+
+func removeStaleSocket(network, path string) {
+	fi, err := os.Stat(path)
+	if err != nil || fi.Mode()&os.ModeSocket == 0 {
+		return
+	}
+	if conn, err := net.Dial(network, path); err == nil {
+		_ = conn.Close()
+		return
+	}
+	_ = os.Remove(path)
 }
 
 // TCPAddr represents a tcp endpoint address.
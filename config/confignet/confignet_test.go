@@ -15,10 +15,15 @@
 package confignet
 
 import (
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNetAddr(t *testing.T) {
@@ -88,3 +93,64 @@ func TestTcpAddr(t *testing.T) {
 	<-done
 	assert.NoError(t, ln.Close())
 }
+
+func TestUnixSchemeEndpoint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	dir, err := ioutil.TempDir("", "confignet")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "otelcol.sock")
+
+	// The scheme takes precedence over the transport.
+	na := &NetAddr{
+		Endpoint:  "unix://" + socket,
+		Transport: "tcp",
+	}
+	ln, err := na.Listen()
+	require.NoError(t, err)
+	assert.Equal(t, "unix", ln.Addr().Network())
+	assert.Equal(t, socket, ln.Addr().String())
+	go func() {
+		conn, errGo := ln.Accept()
+		if errGo == nil {
+			_ = conn.Close()
+		}
+	}()
+
+	conn, err := na.Dial()
+	require.NoError(t, err)
+	assert.NoError(t, conn.Close())
+
+	// The socket of a listener still accepting connections is kept.
+	_, err = na.Listen()
+	assert.Error(t, err)
+	assert.NoError(t, ln.Close())
+}
+
+func TestListenRemovesStaleSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	dir, err := ioutil.TempDir("", "confignet")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "otelcol.sock")
+
+	// Simulate a process that exited without closing its listener.
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, ln.Close())
+	_, err = os.Stat(socket)
+	require.NoError(t, err)
+
+	na := &NetAddr{
+		Endpoint:  socket,
+		Transport: "unix",
+	}
+	ln, err = na.Listen()
+	require.NoError(t, err)
+	assert.NoError(t, ln.Close())
+}
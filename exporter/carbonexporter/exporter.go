@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// carbonExporter writes the metrics to a Carbon server over a TCP connection kept open between the exports.
+type carbonExporter struct {
+	config   *Config
+	template *template
+	logger   *zap.Logger
+	now      func() time.Time
+
+	// mu guards conn, and serializes the writes so that the lines of concurrent exports are not interleaved.
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newExporter(cfg *Config, t *template, logger *zap.Logger) *carbonExporter {
+	return &carbonExporter{
+		config:   cfg,
+		template: t,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+func (e *carbonExporter) pushMetricsData(ctx context.Context, md pdata.Metrics) (int, error) {
+	lines, dropped := metricsToLines(md, e.template, e.now())
+	if dropped > 0 {
+		e.logger.Debug("Dropped data points of metrics without a name.", zap.Int("dropped", dropped))
+	}
+	if len(lines) == 0 {
+		return 0, nil
+	}
+	if err := e.write(ctx, strings.Join(lines, "")); err != nil {
+		return md.MetricCount(), err
+	}
+	return 0, nil
+}
+
+// write writes the lines to the connection, opened if needed. The connection is closed when a write fails, a new one
+// is opened for the retry, the lines written before the failure are then written again.
+func (e *carbonExporter) write(ctx context.Context, lines string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", e.config.Endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", e.config.Endpoint, err)
+		}
+		e.conn = conn
+	}
+
+	// No deadline is set, the zero time, if the context has none.
+	deadline, _ := ctx.Deadline()
+	if err := e.conn.SetWriteDeadline(deadline); err != nil {
+		e.closeConn()
+		return err
+	}
+	if _, err := e.conn.Write([]byte(lines)); err != nil {
+		e.closeConn()
+		return fmt.Errorf("failed to write to %s: %w", e.config.Endpoint, err)
+	}
+	return nil
+}
+
+func (e *carbonExporter) closeConn() {
+	if err := e.conn.Close(); err != nil {
+		e.logger.Debug("Failed to close the connection.", zap.Error(err))
+	}
+	e.conn = nil
+}
+
+func (e *carbonExporter) shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
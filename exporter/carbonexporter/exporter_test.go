@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// carbonServer records the lines received by a Carbon plaintext listener.
+type carbonServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	lines    []string
+	conns    []net.Conn
+}
+
+func newCarbonServer(t *testing.T) *carbonServer {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := &carbonServer{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			srv.mu.Lock()
+			srv.conns = append(srv.conns, conn)
+			srv.mu.Unlock()
+			go func() {
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					srv.mu.Lock()
+					srv.lines = append(srv.lines, scanner.Text())
+					srv.mu.Unlock()
+				}
+			}()
+		}
+	}()
+	return srv
+}
+
+func (s *carbonServer) getLines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.lines...)
+}
+
+func (s *carbonServer) connCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// closeConns closes the accepted connections, as a restarting Carbon server would.
+func (s *carbonServer) closeConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+}
+
+func (s *carbonServer) close() {
+	s.listener.Close()
+	s.closeConns()
+}
+
+func newTestExporter(endpoint string) *carbonExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	exp := newExporter(cfg, nil, zap.NewNop())
+	exp.now = func() time.Time { return testNow }
+	return exp
+}
+
+func gaugeMetrics(value int64) pdata.Metrics {
+	return newTestMetrics(func(m pdata.Metric) {
+		m.SetName("threads")
+		m.SetDataType(pdata.MetricDataTypeIntGauge)
+		m.IntGauge().DataPoints().Resize(1)
+		p := m.IntGauge().DataPoints().At(0)
+		p.SetTimestamp(testTimestamp)
+		p.SetValue(value)
+	})
+}
+
+func TestPushMetricsData(t *testing.T) {
+	srv := newCarbonServer(t)
+	defer srv.close()
+	exp := newTestExporter(srv.listener.Addr().String())
+
+	dropped, err := exp.pushMetricsData(context.Background(), gaugeMetrics(1))
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	_, err = exp.pushMetricsData(context.Background(), gaugeMetrics(2))
+	require.NoError(t, err)
+
+	expected := []string{
+		"threads;host.name=web-1.example.com 1 1614834367",
+		"threads;host.name=web-1.example.com 2 1614834367",
+	}
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(expected, srv.getLines())
+	}, time.Second, 10*time.Millisecond)
+	// The connection is kept open between the exports.
+	assert.Equal(t, 1, srv.connCount())
+	assert.NoError(t, exp.shutdown(context.Background()))
+}
+
+func TestPushMetricsData_Reconnect(t *testing.T) {
+	srv := newCarbonServer(t)
+	defer srv.close()
+	exp := newTestExporter(srv.listener.Addr().String())
+
+	_, err := exp.pushMetricsData(context.Background(), gaugeMetrics(1))
+	require.NoError(t, err)
+	srv.closeConns()
+
+	// Writing to a connection closed by the peer may only fail after a few writes.
+	assert.Eventually(t, func() bool {
+		_, err = exp.pushMetricsData(context.Background(), gaugeMetrics(2))
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = exp.pushMetricsData(context.Background(), gaugeMetrics(3))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		lines := srv.getLines()
+		return len(lines) > 0 && lines[len(lines)-1] == "threads;host.name=web-1.example.com 3 1614834367"
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, srv.connCount())
+	assert.NoError(t, exp.shutdown(context.Background()))
+}
+
+func TestPushMetricsData_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	exp := newTestExporter(endpoint)
+
+	md := gaugeMetrics(1)
+	dropped, err := exp.pushMetricsData(context.Background(), md)
+	assert.Error(t, err)
+	assert.Equal(t, md.MetricCount(), dropped)
+	assert.NoError(t, exp.shutdown(context.Background()))
+}
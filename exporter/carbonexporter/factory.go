@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "carbon"
+
+	defaultEndpoint = "localhost:2003"
+)
+
+var errNoEndpoint = errors.New("endpoint must be specified")
+
+// NewFactory creates a factory for Carbon exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithMetrics(createMetricsExporter))
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: defaultEndpoint,
+		},
+		TimeoutSettings: exporterhelper.DefaultTimeoutSettings(),
+		RetrySettings:   exporterhelper.DefaultRetrySettings(),
+		QueueSettings:   exporterhelper.DefaultQueueSettings(),
+	}
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	cCfg := cfg.(*Config)
+	if cCfg.Endpoint == "" {
+		return nil, fmt.Errorf("error creating %q exporter: %w", cCfg.Name(), errNoEndpoint)
+	}
+	template, err := parseTemplate(cCfg.Template)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %q exporter: %w", cCfg.Name(), err)
+	}
+	exp := newExporter(cCfg, template, params.Logger)
+
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		params.Logger,
+		exp.pushMetricsData,
+		exporterhelper.WithShutdown(exp.shutdown),
+		exporterhelper.WithTimeout(cCfg.TimeoutSettings),
+		exporterhelper.WithRetry(cCfg.RetrySettings),
+		exporterhelper.WithQueue(cCfg.QueueSettings))
+}
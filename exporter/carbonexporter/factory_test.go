@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateMetricsExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Template = "servers.{host.name}.{name}"
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	me, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, me)
+	assert.NoError(t, me.Shutdown(context.Background()))
+}
+
+func TestCreateMetricsExporter_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    error
+	}{
+		{
+			name: "no_endpoint",
+			modify: func(cfg *Config) {
+				cfg.Endpoint = ""
+			},
+			err: errNoEndpoint,
+		},
+		{
+			name: "unclosed_placeholder",
+			modify: func(cfg *Config) {
+				cfg.Template = "servers.{host.name.{name}"
+			},
+			err: errUnclosedPlaceholder,
+		},
+		{
+			name: "empty_placeholder",
+			modify: func(cfg *Config) {
+				cfg.Template = "servers.{}.{name}"
+			},
+			err: errEmptyPlaceholder,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+			params := component.ExporterCreateParams{Logger: zap.NewNop()}
+			_, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+			assert.True(t, errors.Is(err, tt.err), err)
+		})
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// The data points are converted to lines of the Graphite plaintext protocol, "<path> <value> <timestamp>", see
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html. The histograms and the summaries are written as
+// several series, suffixing the name of the metric with ".count", ".sum", ".bucket.<upper bound>" ("inf" for the last
+// bucket, the counts being cumulative) and ".quantile.<quantile>", the dots of the bounds and the quantiles being
+// replaced with underscores.
+
+const (
+	namePlaceholder = "name"
+	// missingValue replaces the placeholders of the labels the data point does not have.
+	missingValue = "unknown"
+)
+
+var (
+	errUnclosedPlaceholder = errors.New("template has an unclosed placeholder")
+	errEmptyPlaceholder    = errors.New("template has an empty placeholder")
+)
+
+// template is a parsed Config.Template, an alternation of literal text and placeholders.
+type template struct {
+	parts []templatePart
+}
+
+type templatePart struct {
+	// literal is the text of the part if key is empty, key the key of the placeholder otherwise.
+	literal string
+	key     string
+}
+
+// parseTemplate parses the template, it returns nil if the template is empty.
+func parseTemplate(s string) (*template, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t := &template{}
+	for s != "" {
+		start := strings.IndexByte(s, '{')
+		if start < 0 {
+			t.parts = append(t.parts, templatePart{literal: s})
+			break
+		}
+		if start > 0 {
+			t.parts = append(t.parts, templatePart{literal: s[:start]})
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return nil, errUnclosedPlaceholder
+		}
+		key := s[start+1 : start+end]
+		if strings.IndexByte(key, '{') >= 0 {
+			return nil, errUnclosedPlaceholder
+		}
+		if key == "" {
+			return nil, errEmptyPlaceholder
+		}
+		t.parts = append(t.parts, templatePart{key: key})
+		s = s[start+end+1:]
+	}
+	return t, nil
+}
+
+// path returns the path of a series, the name placeholder being replaced with the name of the series and the other
+// placeholders with the values of the tags, their dots replaced with underscores so that they are a single node.
+func (t *template) path(name string, tags map[string]string) string {
+	var b strings.Builder
+	for _, part := range t.parts {
+		switch {
+		case part.key == "":
+			b.WriteString(part.literal)
+		case part.key == namePlaceholder:
+			b.WriteString(sanitize(name))
+		default:
+			value, ok := tags[part.key]
+			if !ok || value == "" {
+				value = missingValue
+			}
+			b.WriteString(strings.ReplaceAll(sanitize(value), ".", "_"))
+		}
+	}
+	return b.String()
+}
+
+// taggedPath returns the path of a tagged series, "<name>;<tag>=<value>;...", the tags being sorted by key.
+func taggedPath(name string, tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k, v := range tags {
+		// Graphite does not allow empty tag values.
+		if k != "" && v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(sanitize(name))
+	for _, k := range keys {
+		b.WriteByte(';')
+		b.WriteString(sanitize(k))
+		b.WriteByte('=')
+		b.WriteString(sanitize(tags[k]))
+	}
+	return b.String()
+}
+
+// sanitize replaces with underscores the whitespaces, which separate the fields of a line, and the characters
+// separating the tags and their values.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || strings.ContainsRune(";=!^~", r) {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// lineWriter converts the data points to lines of the plaintext protocol.
+type lineWriter struct {
+	template *template
+	now      time.Time
+	lines    []string
+}
+
+// metricsToLines converts the metrics to lines of the plaintext protocol, the data points without timestamp being
+// written with the given time, and returns the number of data points that could not be converted.
+func metricsToLines(md pdata.Metrics, t *template, now time.Time) ([]string, int) {
+	w := &lineWriter{template: t, now: now}
+	dropped := 0
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceTags := make(map[string]string, rm.Resource().Attributes().Len())
+		rm.Resource().Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+			resourceTags[k] = tracetranslator.AttributeValueToString(v, false)
+		})
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if metric.Name() == "" {
+					dropped += dataPointCount(metric)
+					continue
+				}
+				w.appendMetric(metric, resourceTags)
+			}
+		}
+	}
+	return w.lines, dropped
+}
+
+func (w *lineWriter) appendMetric(metric pdata.Metric, resourceTags map[string]string) {
+	name := metric.Name()
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		ps := metric.IntGauge().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			w.append(name, tags(resourceTags, p.LabelsMap()), float64(p.Value()), p.Timestamp())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		ps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			w.append(name, tags(resourceTags, p.LabelsMap()), p.Value(), p.Timestamp())
+		}
+	case pdata.MetricDataTypeIntSum:
+		ps := metric.IntSum().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			w.append(name, tags(resourceTags, p.LabelsMap()), float64(p.Value()), p.Timestamp())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		ps := metric.DoubleSum().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			w.append(name, tags(resourceTags, p.LabelsMap()), p.Value(), p.Timestamp())
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		ps := metric.IntHistogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			w.appendHistogram(name, tags(resourceTags, p.LabelsMap()), p.Count(), float64(p.Sum()),
+				p.ExplicitBounds(), p.BucketCounts(), p.Timestamp())
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		ps := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			w.appendHistogram(name, tags(resourceTags, p.LabelsMap()), p.Count(), p.Sum(),
+				p.ExplicitBounds(), p.BucketCounts(), p.Timestamp())
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		ps := metric.DoubleSummary().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			pointTags := tags(resourceTags, p.LabelsMap())
+			w.append(name+".count", pointTags, float64(p.Count()), p.Timestamp())
+			w.append(name+".sum", pointTags, p.Sum(), p.Timestamp())
+			qs := p.QuantileValues()
+			for j := 0; j < qs.Len(); j++ {
+				q := qs.At(j)
+				w.append(name+".quantile."+formatNode(q.Quantile()), pointTags, q.Value(), p.Timestamp())
+			}
+		}
+	}
+}
+
+func (w *lineWriter) appendHistogram(name string, tags map[string]string, count uint64, sum float64,
+	bounds []float64, counts []uint64, timestamp pdata.Timestamp) {
+	w.append(name+".count", tags, float64(count), timestamp)
+	w.append(name+".sum", tags, sum, timestamp)
+	var cumulative uint64
+	for i, bound := range bounds {
+		if i < len(counts) {
+			cumulative += counts[i]
+		}
+		w.append(name+".bucket."+formatNode(bound), tags, float64(cumulative), timestamp)
+	}
+	w.append(name+".bucket.inf", tags, float64(count), timestamp)
+}
+
+// append appends the line of a series, unless its value is NaN or infinite, which Graphite does not support.
+func (w *lineWriter) append(name string, tags map[string]string, value float64, timestamp pdata.Timestamp) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+	var path string
+	if w.template != nil {
+		path = w.template.path(name, tags)
+	} else {
+		path = taggedPath(name, tags)
+	}
+	seconds := w.now.Unix()
+	if timestamp != 0 {
+		seconds = timestamp.AsTime().Unix()
+	}
+	w.lines = append(w.lines, path+" "+strconv.FormatFloat(value, 'f', -1, 64)+" "+strconv.FormatInt(seconds, 10)+"\n")
+}
+
+// tags returns the resource attributes and the labels of a data point, the labels taking precedence.
+func tags(resourceTags map[string]string, labels pdata.StringMap) map[string]string {
+	m := make(map[string]string, len(resourceTags)+labels.Len())
+	for k, v := range resourceTags {
+		m[k] = v
+	}
+	labels.ForEach(func(k string, v string) {
+		m[k] = v
+	})
+	return m
+}
+
+// formatNode formats a bucket bound or a quantile as a single node of a path.
+func formatNode(v float64) string {
+	return strings.ReplaceAll(strconv.FormatFloat(v, 'g', -1, 64), ".", "_")
+}
+
+func dataPointCount(metric pdata.Metric) int {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		return metric.IntGauge().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleGauge:
+		return metric.DoubleGauge().DataPoints().Len()
+	case pdata.MetricDataTypeIntSum:
+		return metric.IntSum().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleSum:
+		return metric.DoubleSum().DataPoints().Len()
+	case pdata.MetricDataTypeIntHistogram:
+		return metric.IntHistogram().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleHistogram:
+		return metric.DoubleHistogram().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleSummary:
+		return metric.DoubleSummary().DataPoints().Len()
+	}
+	return 0
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var (
+	testTime      = time.Unix(1614834367, 0)
+	testTimestamp = pdata.TimestampFromTime(testTime)
+	testNow       = time.Unix(1614834400, 0)
+)
+
+// newTestMetrics returns metrics of a resource with the "host.name" attribute, the metrics being initialized by the
+// given functions.
+func newTestMetrics(inits ...func(m pdata.Metric)) pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("host.name", "web-1.example.com")
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(len(inits))
+	for i, init := range inits {
+		init(metrics.At(i))
+	}
+	return md
+}
+
+func allTypesMetrics() pdata.Metrics {
+	return newTestMetrics(
+		func(m pdata.Metric) {
+			m.SetName("cpu.load")
+			m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+			m.DoubleGauge().DataPoints().Resize(2)
+			p := m.DoubleGauge().DataPoints().At(0)
+			p.LabelsMap().Insert("cpu", "0")
+			p.SetTimestamp(testTimestamp)
+			p.SetValue(0.5)
+			p = m.DoubleGauge().DataPoints().At(1)
+			p.LabelsMap().Insert("cpu", "1")
+			p.SetValue(math.NaN())
+		},
+		func(m pdata.Metric) {
+			m.SetName("requests")
+			m.SetDataType(pdata.MetricDataTypeIntSum)
+			m.IntSum().DataPoints().Resize(1)
+			p := m.IntSum().DataPoints().At(0)
+			p.LabelsMap().Insert("path", "/a b;c")
+			p.LabelsMap().Insert("empty", "")
+			p.SetValue(42)
+		},
+		func(m pdata.Metric) {
+			m.SetName("latency")
+			m.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+			m.DoubleHistogram().DataPoints().Resize(1)
+			p := m.DoubleHistogram().DataPoints().At(0)
+			p.LabelsMap().Insert("host.name", "web-2")
+			p.SetTimestamp(testTimestamp)
+			p.SetCount(6)
+			p.SetSum(7.5)
+			p.SetExplicitBounds([]float64{0.5, 1})
+			p.SetBucketCounts([]uint64{1, 2, 3})
+		},
+		func(m pdata.Metric) {
+			m.SetName("duration")
+			m.SetDataType(pdata.MetricDataTypeDoubleSummary)
+			m.DoubleSummary().DataPoints().Resize(1)
+			p := m.DoubleSummary().DataPoints().At(0)
+			p.SetTimestamp(testTimestamp)
+			p.SetCount(10)
+			p.SetSum(20)
+			p.QuantileValues().Resize(1)
+			p.QuantileValues().At(0).SetQuantile(0.99)
+			p.QuantileValues().At(0).SetValue(4)
+		},
+		func(m pdata.Metric) {
+			m.SetDataType(pdata.MetricDataTypeIntGauge)
+			m.IntGauge().DataPoints().Resize(2)
+		},
+	)
+}
+
+func TestMetricsToLines_Tagged(t *testing.T) {
+	lines, dropped := metricsToLines(allTypesMetrics(), nil, testNow)
+	assert.Equal(t, 2, dropped)
+	assert.Equal(t, []string{
+		"cpu.load;cpu=0;host.name=web-1.example.com 0.5 1614834367\n",
+		"requests;host.name=web-1.example.com;path=/a_b_c 42 1614834400\n",
+		"latency.count;host.name=web-2 6 1614834367\n",
+		"latency.sum;host.name=web-2 7.5 1614834367\n",
+		"latency.bucket.0_5;host.name=web-2 1 1614834367\n",
+		"latency.bucket.1;host.name=web-2 3 1614834367\n",
+		"latency.bucket.inf;host.name=web-2 6 1614834367\n",
+		"duration.count;host.name=web-1.example.com 10 1614834367\n",
+		"duration.sum;host.name=web-1.example.com 20 1614834367\n",
+		"duration.quantile.0_99;host.name=web-1.example.com 4 1614834367\n",
+	}, lines)
+}
+
+func TestMetricsToLines_Template(t *testing.T) {
+	tmpl, err := parseTemplate("servers.{host.name}.{name}.{cpu}")
+	require.NoError(t, err)
+
+	lines, _ := metricsToLines(allTypesMetrics(), tmpl, testNow)
+	assert.Equal(t, []string{
+		"servers.web-1_example_com.cpu.load.0 0.5 1614834367\n",
+		"servers.web-1_example_com.requests.unknown 42 1614834400\n",
+		"servers.web-2.latency.count.unknown 6 1614834367\n",
+		"servers.web-2.latency.sum.unknown 7.5 1614834367\n",
+		"servers.web-2.latency.bucket.0_5.unknown 1 1614834367\n",
+		"servers.web-2.latency.bucket.1.unknown 3 1614834367\n",
+		"servers.web-2.latency.bucket.inf.unknown 6 1614834367\n",
+		"servers.web-1_example_com.duration.count.unknown 10 1614834367\n",
+		"servers.web-1_example_com.duration.sum.unknown 20 1614834367\n",
+		"servers.web-1_example_com.duration.quantile.0_99.unknown 4 1614834367\n",
+	}, lines)
+}
+
+func TestParseTemplate(t *testing.T) {
+	tmpl, err := parseTemplate("")
+	require.NoError(t, err)
+	assert.Nil(t, tmpl)
+
+	tmpl, err = parseTemplate("a.{b}{name}.c")
+	require.NoError(t, err)
+	assert.Equal(t, []templatePart{{literal: "a."}, {key: "b"}, {key: "name"}, {literal: ".c"}}, tmpl.parts)
+
+	_, err = parseTemplate("a.{b")
+	assert.Equal(t, errUnclosedPlaceholder, err)
+	_, err = parseTemplate("a.{b.{name}}")
+	assert.Equal(t, errUnclosedPlaceholder, err)
+	_, err = parseTemplate("a.{}")
+	assert.Equal(t, errEmptyPlaceholder, err)
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for ClickHouse exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// Database is the database of the tables.
+	Database string `mapstructure:"database"`
+	// Username and Password are the credentials of the ClickHouse user, the default user is used if Username is empty.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// TracesTableName is the table the spans are inserted into.
+	TracesTableName string `mapstructure:"traces_table_name"`
+	// LogsTableName is the table the log records are inserted into.
+	LogsTableName string `mapstructure:"logs_table_name"`
+
+	// CreateSchema indicates whether to create, when the exporter starts, the database and the tables if they do not
+	// exist. Disable it to insert into tables created beforehand with their own engine, ordering or codecs, the tables
+	// must then have the columns of the default schema.
+	CreateSchema bool `mapstructure:"create_schema"`
+	// TTLDays is the number of days the data is kept in the tables created by the exporter, 0 keeps it forever.
+	TTLDays uint `mapstructure:"ttl_days"`
+
+	// AsyncInsert enables the asynchronous inserts: ClickHouse buffers the inserted rows of the concurrent requests
+	// and writes them together, which avoids creating many small parts when the batches are small. The requests still
+	// wait for the rows to be written before returning.
+	AsyncInsert bool `mapstructure:"async_insert"`
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	headerUser               = "X-ClickHouse-User"
+	headerKey                = "X-ClickHouse-Key"
+	maxHTTPResponseReadBytes = 64 * 1024
+	// timestampLayout is the text format of the DateTime64(9) columns.
+	timestampLayout = "2006-01-02 15:04:05.000000000"
+)
+
+type clickhouseExporter struct {
+	config *Config
+	client *http.Client
+	url    string
+	// table is the quoted name of the table of the exported signal, and schema its schema.
+	table  string
+	schema string
+	logger *zap.Logger
+
+	// schemaMutex guards schemaCreated, set once the database and the table were created.
+	schemaMutex   sync.Mutex
+	schemaCreated bool
+}
+
+func newExporter(cfg *Config, logger *zap.Logger, tableName, schema string) (*clickhouseExporter, error) {
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &clickhouseExporter{
+		config: cfg,
+		client: client,
+		url:    strings.TrimSuffix(cfg.Endpoint, "/") + "/",
+		table:  quoteIdentifier(cfg.Database) + "." + quoteIdentifier(tableName),
+		schema: schema,
+		logger: logger,
+	}, nil
+}
+
+// start creates the database and the table when the schema is managed by the exporter. ClickHouse may not be reachable
+// yet when the collector starts, a failure is logged and the creation is tried again before the next insert.
+func (e *clickhouseExporter) start(ctx context.Context, _ component.Host) error {
+	if err := e.ensureSchema(ctx); err != nil {
+		e.logger.Warn("Failed to create the schema.", zap.String("table", e.table), zap.Error(err))
+	}
+	return nil
+}
+
+func (e *clickhouseExporter) ensureSchema(ctx context.Context) error {
+	if !e.config.CreateSchema {
+		return nil
+	}
+	e.schemaMutex.Lock()
+	defer e.schemaMutex.Unlock()
+	if e.schemaCreated {
+		return nil
+	}
+	if err := e.exec(ctx, "CREATE DATABASE IF NOT EXISTS "+quoteIdentifier(e.config.Database)); err != nil {
+		return fmt.Errorf("failed to create the database: %w", err)
+	}
+	if err := e.exec(ctx, createTableStatement(e.schema, e.table, e.config.TTLDays)); err != nil {
+		return fmt.Errorf("failed to create the table %s: %w", e.table, err)
+	}
+	e.schemaCreated = true
+	return nil
+}
+
+// exec executes a statement returning no data.
+func (e *clickhouseExporter) exec(ctx context.Context, statement string) error {
+	return e.do(ctx, url.Values{}, []byte(statement))
+}
+
+// insert inserts the rows, encoded in the JSONEachRow format, into the table of the exporter.
+func (e *clickhouseExporter) insert(ctx context.Context, rows []byte) error {
+	if err := e.ensureSchema(ctx); err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("query", "INSERT INTO "+e.table+" FORMAT JSONEachRow")
+	if e.config.AsyncInsert {
+		params.Set("async_insert", "1")
+		params.Set("wait_for_async_insert", "1")
+	}
+	return e.do(ctx, params, rows)
+}
+
+func (e *clickhouseExporter) do(ctx context.Context, params url.Values, body []byte) error {
+	requestURL := e.url
+	if len(params) > 0 {
+		requestURL += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	if e.config.Username != "" {
+		req.Header.Set(headerUser, e.config.Username)
+		req.Header.Set(headerKey, e.config.Password)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make an HTTP request: %w", err)
+	}
+	defer func() {
+		// Discard any remaining response body when we are done reading.
+		io.CopyN(ioutil.Discard, resp.Body, maxHTTPResponseReadBytes)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	return exporterhelper.NewHTTPResponseError(resp, e.url)
+}
+
+func formatTimestamp(ts pdata.Timestamp) string {
+	return time.Unix(0, int64(ts)).UTC().Format(timestampLayout)
+}
+
+// attributesToMap converts the attributes to the values of a Map(String, String) column.
+func attributesToMap(attributes pdata.AttributeMap) map[string]string {
+	m := make(map[string]string, attributes.Len())
+	attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = tracetranslator.AttributeValueToString(v, false)
+	})
+	return m
+}
+
+func serviceName(resource pdata.Resource) string {
+	if v, ok := resource.Attributes().Get(conventions.AttributeServiceName); ok {
+		return v.StringVal()
+	}
+	return ""
+}
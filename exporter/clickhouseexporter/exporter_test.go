@@ -0,0 +1,308 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+type request struct {
+	query   string
+	params  map[string]string
+	body    string
+	user    string
+	key     string
+	rows    []map[string]interface{}
+	isQuery bool
+}
+
+// clickhouseServer records the requests made to the ClickHouse HTTP interface.
+type clickhouseServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []request
+	status   int
+}
+
+func newClickhouseServer(t *testing.T) *clickhouseServer {
+	srv := &clickhouseServer{status: http.StatusOK}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := request{
+			params: map[string]string{},
+			user:   r.Header.Get(headerUser),
+			key:    r.Header.Get(headerKey),
+		}
+		for k := range r.URL.Query() {
+			req.params[k] = r.URL.Query().Get(k)
+		}
+		if query, ok := req.params["query"]; ok {
+			req.query = query
+			req.isQuery = true
+			scanner := bufio.NewScanner(strings.NewReader(string(body)))
+			scanner.Buffer(nil, 1024*1024)
+			for scanner.Scan() {
+				var row map[string]interface{}
+				assert.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+				req.rows = append(req.rows, row)
+			}
+		} else {
+			req.body = string(body)
+		}
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		srv.requests = append(srv.requests, req)
+		w.WriteHeader(srv.status)
+	}))
+	return srv
+}
+
+func (s *clickhouseServer) setStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *clickhouseServer) recorded() []request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]request(nil), s.requests...)
+}
+
+func newTestConfig(endpoint string) *Config {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Username = "otel"
+	cfg.Password = "secret"
+	return cfg
+}
+
+func TestPushTraceData(t *testing.T) {
+	srv := newClickhouseServer(t)
+	defer srv.Close()
+
+	cfg := newTestConfig(srv.URL)
+	cfg.TTLDays = 7
+	cfg.AsyncInsert = true
+	exp, err := newExporter(cfg, zap.NewNop(), cfg.TracesTableName, createTracesTableSQL)
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	dropped, err := exp.pushTraceData(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource())
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	requests := srv.recorded()
+	require.Len(t, requests, 3)
+	assert.Equal(t, "CREATE DATABASE IF NOT EXISTS `otel`", requests[0].body)
+	assert.True(t, strings.HasPrefix(requests[1].body, "CREATE TABLE IF NOT EXISTS `otel`.`otel_traces` ("))
+	assert.Contains(t, requests[1].body, "TTL toDateTime(Timestamp) + toIntervalDay(7)")
+
+	insert := requests[2]
+	assert.Equal(t, "INSERT INTO `otel`.`otel_traces` FORMAT JSONEachRow", insert.query)
+	assert.Equal(t, "1", insert.params["async_insert"])
+	assert.Equal(t, "1", insert.params["wait_for_async_insert"])
+	assert.Equal(t, "otel", insert.user)
+	assert.Equal(t, "secret", insert.key)
+	require.Len(t, insert.rows, 2)
+
+	row := insert.rows[0]
+	assert.Equal(t, "2020-02-11 20:26:12.000000321", row["Timestamp"])
+	assert.Equal(t, "operationA", row["SpanName"])
+	assert.Equal(t, "SPAN_KIND_UNSPECIFIED", row["SpanKind"])
+	assert.Equal(t, "STATUS_CODE_ERROR", row["StatusCode"])
+	assert.Equal(t, "status-cancelled", row["StatusMessage"])
+	assert.EqualValues(t, time.Second+468, row["Duration"])
+	assert.Equal(t, map[string]interface{}{"resource-attr": "resource-attr-val-1"}, row["ResourceAttributes"])
+	assert.Equal(t, []interface{}{"event-with-attr", "event"}, row["Events.Name"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"span-event-attr": "span-event-attr-val"},
+		map[string]interface{}{},
+	}, row["Events.Attributes"])
+	assert.Equal(t, []interface{}{}, row["Links.TraceId"])
+	assert.Equal(t, "operationB", insert.rows[1]["SpanName"])
+}
+
+func TestPushLogsData(t *testing.T) {
+	srv := newClickhouseServer(t)
+	defer srv.Close()
+
+	cfg := newTestConfig(srv.URL)
+	cfg.CreateSchema = false
+	exp, err := newExporter(cfg, zap.NewNop(), cfg.LogsTableName, createLogsTableSQL)
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	dropped, err := exp.pushLogsData(context.Background(), testdata.GenerateLogDataTwoLogsSameResourceOneDifferent())
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	requests := srv.recorded()
+	require.Len(t, requests, 1)
+	insert := requests[0]
+	assert.Equal(t, "INSERT INTO `otel`.`otel_logs` FORMAT JSONEachRow", insert.query)
+	assert.NotContains(t, insert.params, "async_insert")
+	require.Len(t, insert.rows, 3)
+
+	row := insert.rows[0]
+	assert.Equal(t, "2020-02-11 20:26:13.000000789", row["Timestamp"])
+	assert.Equal(t, "This is a log message", row["Body"])
+	assert.Equal(t, "Info", row["SeverityText"])
+	assert.EqualValues(t, 9, row["SeverityNumber"])
+	assert.Equal(t, "logA", row["Name"])
+	assert.Equal(t, "08040201000000000000000000000000", row["TraceId"])
+	assert.Equal(t, "0102040800000000", row["SpanId"])
+	assert.Equal(t, map[string]interface{}{"app": "server", "instance_num": "1"}, row["LogAttributes"])
+	assert.Equal(t, map[string]interface{}{"resource-attr": "resource-attr-val-2"}, insert.rows[2]["ResourceAttributes"])
+}
+
+func TestPushData_Empty(t *testing.T) {
+	exp, err := newExporter(newTestConfig("http://localhost:1"), zap.NewNop(), defaultLogsTableName, createLogsTableSQL)
+	require.NoError(t, err)
+
+	dropped, err := exp.pushTraceData(context.Background(), testdata.GenerateTraceDataEmpty())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	dropped, err = exp.pushLogsData(context.Background(), testdata.GenerateLogDataEmpty())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+}
+
+func TestCreateSchema_Unavailable(t *testing.T) {
+	srv := newClickhouseServer(t)
+	defer srv.Close()
+	srv.setStatus(http.StatusServiceUnavailable)
+
+	cfg := newTestConfig(srv.URL)
+	exp, err := newExporter(cfg, zap.NewNop(), cfg.LogsTableName, createLogsTableSQL)
+	require.NoError(t, err)
+	// The failure to create the schema does not prevent the collector from starting.
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	ld := testdata.GenerateLogDataOneLog()
+	dropped, err := exp.pushLogsData(context.Background(), ld)
+	require.Error(t, err)
+	assert.Equal(t, 1, dropped)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	// The schema is created before the first insert once ClickHouse is available.
+	srv.setStatus(http.StatusOK)
+	_, err = exp.pushLogsData(context.Background(), ld)
+	require.NoError(t, err)
+	_, err = exp.pushLogsData(context.Background(), ld)
+	require.NoError(t, err)
+
+	var statements []string
+	for _, req := range srv.recorded() {
+		if !req.isQuery {
+			statements = append(statements, req.body[:strings.Index(req.body, " IF NOT EXISTS")])
+		} else {
+			statements = append(statements, "INSERT")
+		}
+	}
+	assert.Equal(t, []string{
+		"CREATE DATABASE", "CREATE DATABASE", // start, first push
+		"CREATE DATABASE", "CREATE TABLE", "INSERT", // second push
+		"INSERT", // third push
+	}, statements)
+}
+
+func TestResponseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		permanent  bool
+		throttle   time.Duration
+	}{
+		{
+			name:       "too_many_requests",
+			status:     http.StatusTooManyRequests,
+			retryAfter: "10",
+			throttle:   10 * time.Second,
+		},
+		{
+			name:      "bad_request",
+			status:    http.StatusBadRequest,
+			permanent: true,
+		},
+		{
+			name:   "internal_server_error",
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte("Code: 62. DB::Exception: Syntax error"))
+			}))
+			defer srv.Close()
+
+			cfg := newTestConfig(srv.URL)
+			cfg.CreateSchema = false
+			exp, err := newExporter(cfg, zap.NewNop(), cfg.TracesTableName, createTracesTableSQL)
+			require.NoError(t, err)
+
+			td := testdata.GenerateTraceDataTwoSpansSameResource()
+			dropped, err := exp.pushTraceData(context.Background(), td)
+			require.Error(t, err)
+			assert.Equal(t, td.SpanCount(), dropped)
+			assert.Contains(t, err.Error(), "DB::Exception")
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+			if tt.throttle > 0 {
+				class, delay := exporterhelper.DefaultErrorClassifier(err)
+				assert.Equal(t, exporterhelper.ErrorClassThrottled, class)
+				assert.Equal(t, tt.throttle, delay)
+			}
+		})
+	}
+}
+
+func TestCreateTableStatement(t *testing.T) {
+	statement := createTableStatement(createLogsTableSQL, "`otel`.`logs`", 0)
+	assert.True(t, strings.HasPrefix(statement, "CREATE TABLE IF NOT EXISTS `otel`.`logs` ("))
+	assert.NotContains(t, statement, "TTL")
+	assert.Contains(t, statement, "PARTITION BY toDate(Timestamp)")
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, "`otel_logs`", quoteIdentifier("otel_logs"))
+	assert.Equal(t, "`a\\`b\\\\c`", quoteIdentifier("a`b\\c"))
+}
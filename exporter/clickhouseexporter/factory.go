@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "clickhouse"
+
+	defaultDatabase        = "otel"
+	defaultTracesTableName = "otel_traces"
+	defaultLogsTableName   = "otel_logs"
+)
+
+var (
+	errNoEndpoint  = errors.New("endpoint must be specified")
+	errNoDatabase  = errors.New("database must be specified")
+	errNoTableName = errors.New("traces_table_name and logs_table_name must be specified")
+)
+
+// NewFactory creates a factory for ClickHouse exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithTraces(createTraceExporter),
+		exporterhelper.WithLogs(createLogsExporter))
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		RetrySettings: exporterhelper.DefaultRetrySettings(),
+		QueueSettings: exporterhelper.DefaultQueueSettings(),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "",
+			Timeout:  30 * time.Second,
+			Headers:  map[string]string{},
+		},
+		Database:        defaultDatabase,
+		TracesTableName: defaultTracesTableName,
+		LogsTableName:   defaultLogsTableName,
+		CreateSchema:    true,
+	}
+}
+
+func createTraceExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.TracesExporter, error) {
+	cCfg := cfg.(*Config)
+	exp, err := createExporter(params, cCfg, cCfg.TracesTableName, createTracesTableSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewTraceExporter(
+		cfg,
+		params.Logger,
+		exp.pushTraceData,
+		exporterhelper.WithStart(exp.start),
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(cCfg.RetrySettings),
+		exporterhelper.WithQueue(cCfg.QueueSettings))
+}
+
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.LogsExporter, error) {
+	cCfg := cfg.(*Config)
+	exp, err := createExporter(params, cCfg, cCfg.LogsTableName, createLogsTableSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		params.Logger,
+		exp.pushLogsData,
+		exporterhelper.WithStart(exp.start),
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(cCfg.RetrySettings),
+		exporterhelper.WithQueue(cCfg.QueueSettings))
+}
+
+func createExporter(params component.ExporterCreateParams, cfg *Config, tableName, schema string) (*clickhouseExporter, error) {
+	if err := validateConfig(cfg); err != nil {
+		return nil, fmt.Errorf("error creating %q exporter: %w", cfg.Name(), err)
+	}
+	return newExporter(cfg, params.Logger, tableName, schema)
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.Endpoint == "" {
+		return errNoEndpoint
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return fmt.Errorf("endpoint must be a valid URL: %w", err)
+	}
+	if cfg.Database == "" {
+		return errNoDatabase
+	}
+	if cfg.TracesTableName == "" || cfg.LogsTableName == "" {
+		return errNoTableName
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// logRow is a row of the logs table, see createLogsTableSQL.
+type logRow struct {
+	Timestamp          string            `json:"Timestamp"`
+	TraceID            string            `json:"TraceId"`
+	SpanID             string            `json:"SpanId"`
+	TraceFlags         uint32            `json:"TraceFlags"`
+	SeverityText       string            `json:"SeverityText"`
+	SeverityNumber     int32             `json:"SeverityNumber"`
+	ServiceName        string            `json:"ServiceName"`
+	Name               string            `json:"Name"`
+	Body               string            `json:"Body"`
+	ResourceAttributes map[string]string `json:"ResourceAttributes"`
+	LogAttributes      map[string]string `json:"LogAttributes"`
+}
+
+func (e *clickhouseExporter) pushLogsData(ctx context.Context, ld pdata.Logs) (int, error) {
+	var rows bytes.Buffer
+	encoder := json.NewEncoder(&rows)
+	count := 0
+	now := pdata.TimestampFromTime(time.Now())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceAttributes := attributesToMap(rl.Resource().Attributes())
+		service := serviceName(rl.Resource())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				if err := encoder.Encode(newLogRow(logs.At(k), now, service, resourceAttributes)); err != nil {
+					return ld.LogRecordCount(), consumererror.Permanent(err)
+				}
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return 0, nil
+	}
+
+	if err := e.insert(ctx, rows.Bytes()); err != nil {
+		return count, err
+	}
+	return 0, nil
+}
+
+// newLogRow converts a log record to a row, the log records without timestamp are timestamped with the time of export.
+func newLogRow(record pdata.LogRecord, now pdata.Timestamp, service string, resourceAttributes map[string]string) *logRow {
+	timestamp := record.Timestamp()
+	if timestamp == 0 {
+		timestamp = now
+	}
+	body := ""
+	switch record.Body().Type() {
+	case pdata.AttributeValueNULL:
+	case pdata.AttributeValueSTRING:
+		body = record.Body().StringVal()
+	default:
+		body = tracetranslator.AttributeValueToString(record.Body(), false)
+	}
+	return &logRow{
+		Timestamp:          formatTimestamp(timestamp),
+		TraceID:            record.TraceID().HexString(),
+		SpanID:             record.SpanID().HexString(),
+		TraceFlags:         record.Flags(),
+		SeverityText:       record.SeverityText(),
+		SeverityNumber:     int32(record.SeverityNumber()),
+		ServiceName:        service,
+		Name:               record.Name(),
+		Body:               body,
+		ResourceAttributes: resourceAttributes,
+		LogAttributes:      attributesToMap(record.Attributes()),
+	}
+}
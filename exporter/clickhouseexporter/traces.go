@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// spanRow is a row of the traces table, see createTracesTableSQL.
+type spanRow struct {
+	Timestamp          string              `json:"Timestamp"`
+	TraceID            string              `json:"TraceId"`
+	SpanID             string              `json:"SpanId"`
+	ParentSpanID       string              `json:"ParentSpanId"`
+	TraceState         string              `json:"TraceState"`
+	SpanName           string              `json:"SpanName"`
+	SpanKind           string              `json:"SpanKind"`
+	ServiceName        string              `json:"ServiceName"`
+	ResourceAttributes map[string]string   `json:"ResourceAttributes"`
+	SpanAttributes     map[string]string   `json:"SpanAttributes"`
+	Duration           int64               `json:"Duration"`
+	StatusCode         string              `json:"StatusCode"`
+	StatusMessage      string              `json:"StatusMessage"`
+	EventsTimestamp    []string            `json:"Events.Timestamp"`
+	EventsName         []string            `json:"Events.Name"`
+	EventsAttributes   []map[string]string `json:"Events.Attributes"`
+	LinksTraceID       []string            `json:"Links.TraceId"`
+	LinksSpanID        []string            `json:"Links.SpanId"`
+	LinksTraceState    []string            `json:"Links.TraceState"`
+	LinksAttributes    []map[string]string `json:"Links.Attributes"`
+}
+
+func (e *clickhouseExporter) pushTraceData(ctx context.Context, td pdata.Traces) (int, error) {
+	var rows bytes.Buffer
+	encoder := json.NewEncoder(&rows)
+	count := 0
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceAttributes := attributesToMap(rs.Resource().Attributes())
+		service := serviceName(rs.Resource())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if err := encoder.Encode(newSpanRow(spans.At(k), service, resourceAttributes)); err != nil {
+					return td.SpanCount(), consumererror.Permanent(err)
+				}
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return 0, nil
+	}
+
+	if err := e.insert(ctx, rows.Bytes()); err != nil {
+		return count, err
+	}
+	return 0, nil
+}
+
+func newSpanRow(span pdata.Span, service string, resourceAttributes map[string]string) *spanRow {
+	events := span.Events()
+	links := span.Links()
+	row := &spanRow{
+		Timestamp:          formatTimestamp(span.StartTime()),
+		TraceID:            span.TraceID().HexString(),
+		SpanID:             span.SpanID().HexString(),
+		ParentSpanID:       span.ParentSpanID().HexString(),
+		TraceState:         string(span.TraceState()),
+		SpanName:           span.Name(),
+		SpanKind:           span.Kind().String(),
+		ServiceName:        service,
+		ResourceAttributes: resourceAttributes,
+		SpanAttributes:     attributesToMap(span.Attributes()),
+		Duration:           int64(span.EndTime()) - int64(span.StartTime()),
+		StatusCode:         span.Status().Code().String(),
+		StatusMessage:      span.Status().Message(),
+		EventsTimestamp:    make([]string, 0, events.Len()),
+		EventsName:         make([]string, 0, events.Len()),
+		EventsAttributes:   make([]map[string]string, 0, events.Len()),
+		LinksTraceID:       make([]string, 0, links.Len()),
+		LinksSpanID:        make([]string, 0, links.Len()),
+		LinksTraceState:    make([]string, 0, links.Len()),
+		LinksAttributes:    make([]map[string]string, 0, links.Len()),
+	}
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		row.EventsTimestamp = append(row.EventsTimestamp, formatTimestamp(event.Timestamp()))
+		row.EventsName = append(row.EventsName, event.Name())
+		row.EventsAttributes = append(row.EventsAttributes, attributesToMap(event.Attributes()))
+	}
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		row.LinksTraceID = append(row.LinksTraceID, link.TraceID().HexString())
+		row.LinksSpanID = append(row.LinksSpanID, link.SpanID().HexString())
+		row.LinksTraceState = append(row.LinksTraceState, string(link.TraceState()))
+		row.LinksAttributes = append(row.LinksAttributes, attributesToMap(link.Attributes()))
+	}
+	return row
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// resourceECSFields maps the resource attributes of the semantic conventions to their Elastic Common Schema fields.
+var resourceECSFields = map[string]string{
+	conventions.AttributeServiceName:           "service.name",
+	conventions.AttributeServiceVersion:        "service.version",
+	conventions.AttributeServiceInstance:       "service.node.name",
+	conventions.AttributeDeploymentEnvironment: "service.environment",
+	conventions.AttributeHostName:              "host.hostname",
+	conventions.AttributeHostID:                "host.id",
+	conventions.AttributeHostType:              "host.type",
+	conventions.AttributeOSType:                "host.os.platform",
+	conventions.AttributeOSDescription:         "host.os.full",
+	conventions.AttributeProcessID:             "process.pid",
+	conventions.AttributeProcessExecutableName: "process.name",
+	conventions.AttributeProcessExecutablePath: "process.executable",
+	conventions.AttributeProcessCommandLine:    "process.command_line",
+	conventions.AttributeContainerID:           "container.id",
+	conventions.AttributeContainerName:         "container.name",
+	conventions.AttributeContainerImage:        "container.image.name",
+	conventions.AttributeContainerTag:          "container.image.tag",
+	conventions.AttributeK8sPod:                "kubernetes.pod.name",
+	conventions.AttributeK8sPodUID:             "kubernetes.pod.uid",
+	conventions.AttributeK8sNamespace:          "kubernetes.namespace",
+	conventions.AttributeK8sNodeName:           "kubernetes.node.name",
+	conventions.AttributeCloudProvider:         "cloud.provider",
+	conventions.AttributeCloudRegion:           "cloud.region",
+	conventions.AttributeCloudZone:             "cloud.availability_zone",
+	conventions.AttributeCloudAccount:          "cloud.account.id",
+}
+
+// encodeLogRecord converts a log record to an Elasticsearch document following the Elastic Common Schema. The
+// resource attributes with an ECS equivalent are mapped to their ECS field, the other attributes are stored as
+// labels. The fields are named with dots, Elasticsearch expands them to objects.
+func encodeLogRecord(resource pdata.Resource, library pdata.InstrumentationLibrary, record pdata.LogRecord, timestamp time.Time) map[string]interface{} {
+	doc := map[string]interface{}{
+		"@timestamp": timestamp.UTC().Format(time.RFC3339Nano),
+	}
+
+	resource.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		if field, ok := resourceECSFields[k]; ok {
+			doc[field] = attributeValueToInterface(v)
+			return
+		}
+		doc[labelField(k)] = attributeValueToInterface(v)
+	})
+	// The attributes of the record take precedence over the ones of the resource.
+	record.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		doc[labelField(k)] = attributeValueToInterface(v)
+	})
+
+	switch record.Body().Type() {
+	case pdata.AttributeValueNULL:
+	case pdata.AttributeValueSTRING:
+		doc["message"] = record.Body().StringVal()
+	default:
+		doc["message"] = tracetranslator.AttributeValueToString(record.Body(), false)
+	}
+	if name := library.Name(); name != "" {
+		doc["log.logger"] = name
+	}
+	if record.SeverityText() != "" {
+		doc["log.level"] = record.SeverityText()
+	}
+	if record.SeverityNumber() != pdata.SeverityNumberUNDEFINED {
+		doc["event.severity"] = int32(record.SeverityNumber())
+	}
+	if record.Name() != "" {
+		doc["event.action"] = record.Name()
+	}
+	if traceID := record.TraceID(); !traceID.IsEmpty() {
+		doc["trace.id"] = traceID.HexString()
+	}
+	if spanID := record.SpanID(); !spanID.IsEmpty() {
+		doc["span.id"] = spanID.HexString()
+	}
+	return doc
+}
+
+// labelField returns the ECS field of a custom attribute, the dots of the key are replaced as the ECS labels are
+// not nested.
+func labelField(key string) string {
+	return "labels." + strings.ReplaceAll(key, ".", "_")
+}
+
+func attributeValueToInterface(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return v.IntVal()
+	case pdata.AttributeValueDOUBLE:
+		return v.DoubleVal()
+	case pdata.AttributeValueBOOL:
+		return v.BoolVal()
+	case pdata.AttributeValueMAP:
+		return tracetranslator.AttributeMapToMap(v.MapVal())
+	case pdata.AttributeValueARRAY:
+		return tracetranslator.AttributeArrayToSlice(v.ArrayVal())
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestEncodeLogRecord(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString(conventions.AttributeServiceName, "checkout")
+	resource.Attributes().InsertString(conventions.AttributeHostName, "node-1")
+	resource.Attributes().InsertString("team.name", "payments")
+
+	library := pdata.NewInstrumentationLibrary()
+	library.SetName("app.logger")
+
+	record := pdata.NewLogRecord()
+	record.SetName("purchase")
+	record.SetSeverityText("Error")
+	record.SetSeverityNumber(pdata.SeverityNumberERROR)
+	record.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	record.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	record.Body().SetStringVal("payment declined")
+	record.Attributes().InsertInt("http.status_code", 402)
+	record.Attributes().InsertString("team.name", "billing")
+
+	timestamp := time.Date(2021, 3, 1, 10, 20, 30, 123, time.FixedZone("CET", 3600))
+	assert.Equal(t, map[string]interface{}{
+		"@timestamp":              "2021-03-01T09:20:30.000000123Z",
+		"service.name":            "checkout",
+		"host.hostname":           "node-1",
+		"labels.team_name":        "billing",
+		"labels.http_status_code": int64(402),
+		"message":                 "payment declined",
+		"log.logger":              "app.logger",
+		"log.level":               "Error",
+		"event.severity":          int32(pdata.SeverityNumberERROR),
+		"event.action":            "purchase",
+		"trace.id":                "0102030405060708090a0b0c0d0e0f10",
+		"span.id":                 "0102030405060708",
+	}, encodeLogRecord(resource, library, record, timestamp))
+}
+
+func TestEncodeLogRecord_Minimal(t *testing.T) {
+	timestamp := time.Date(2021, 3, 1, 10, 20, 30, 0, time.UTC)
+	assert.Equal(t, map[string]interface{}{
+		"@timestamp": "2021-03-01T10:20:30Z",
+	}, encodeLogRecord(pdata.NewResource(), pdata.NewInstrumentationLibrary(), pdata.NewLogRecord(), timestamp))
+}
+
+func TestEncodeLogRecord_StructuredBody(t *testing.T) {
+	record := pdata.NewLogRecord()
+	body := pdata.NewAttributeValueMap()
+	body.MapVal().InsertString("user", "alice")
+	body.CopyTo(record.Body())
+
+	doc := encodeLogRecord(pdata.NewResource(), pdata.NewInstrumentationLibrary(), record, time.Unix(0, 0))
+	assert.Equal(t, `{"user":"alice"}`, doc["message"])
+}
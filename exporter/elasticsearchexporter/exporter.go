@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	maxHTTPResponseReadBytes = 64 * 1024
+	indexDateLayout          = "2006.01.02"
+)
+
+type elasticsearchExporter struct {
+	config      *Config
+	client      *http.Client
+	bulkURL     string
+	templateURL string
+	logger      *zap.Logger
+}
+
+// bulkItem locates a log record of the bulk request in the exported logs.
+type bulkItem struct {
+	resourceIndex, libraryIndex, recordIndex int
+}
+
+// bulkResponse is the part of the response of the bulk API used to find the failed items.
+type bulkResponse struct {
+	Errors bool                          `json:"errors"`
+	Items  []map[string]bulkItemResponse `json:"items"`
+}
+
+type bulkItemResponse struct {
+	Status int             `json:"status"`
+	Error  json.RawMessage `json:"error"`
+}
+
+func newExporter(cfg *Config, logger *zap.Logger) (*elasticsearchExporter, error) {
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/")
+	return &elasticsearchExporter{
+		config:      cfg,
+		client:      client,
+		bulkURL:     endpoint + "/_bulk",
+		templateURL: endpoint + "/_index_template/" + cfg.Index,
+		logger:      logger,
+	}, nil
+}
+
+// start installs the index template. Elasticsearch may not be reachable yet when the collector starts, a failure is
+// logged and the indices are then created with the dynamic mapping.
+func (e *elasticsearchExporter) start(ctx context.Context, _ component.Host) error {
+	if !e.config.ManageTemplate {
+		return nil
+	}
+	if err := e.putIndexTemplate(ctx); err != nil {
+		e.logger.Warn("Failed to install the index template.", zap.String("url", e.templateURL), zap.Error(err))
+	}
+	return nil
+}
+
+func (e *elasticsearchExporter) putIndexTemplate(ctx context.Context) error {
+	body, err := json.Marshal(indexTemplate(e.config.Index))
+	if err != nil {
+		return err
+	}
+	resp, err := e.do(ctx, http.MethodPut, e.templateURL, "application/json", body)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("request to %s responded with HTTP Status Code %d", e.templateURL, resp.StatusCode)
+	}
+	return nil
+}
+
+func (e *elasticsearchExporter) pushLogsData(ctx context.Context, ld pdata.Logs) (int, error) {
+	var body bytes.Buffer
+	var items []bulkItem
+	dropped := 0
+	now := time.Now()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				record := logs.At(k)
+				timestamp := now
+				if record.Timestamp() != 0 {
+					timestamp = time.Unix(0, int64(record.Timestamp()))
+				}
+				doc, err := json.Marshal(encodeLogRecord(rl.Resource(), ill.InstrumentationLibrary(), record, timestamp))
+				if err != nil {
+					dropped++
+					e.logger.Debug("Dropping the log record that cannot be encoded.", zap.Error(err))
+					continue
+				}
+				action, _ := json.Marshal(map[string]interface{}{
+					"create": map[string]string{"_index": e.config.Index + "-" + timestamp.UTC().Format(indexDateLayout)},
+				})
+				body.Write(action)
+				body.WriteByte('\n')
+				body.Write(doc)
+				body.WriteByte('\n')
+				items = append(items, bulkItem{resourceIndex: i, libraryIndex: j, recordIndex: k})
+			}
+		}
+	}
+	if len(items) == 0 {
+		if dropped > 0 {
+			return dropped, consumererror.Permanent(fmt.Errorf("failed to encode %d log records", dropped))
+		}
+		return 0, nil
+	}
+
+	resp, err := e.do(ctx, http.MethodPost, e.bulkURL, "application/x-ndjson", body.Bytes())
+	if err != nil {
+		return ld.LogRecordCount(), fmt.Errorf("failed to make an HTTP request: %w", err)
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode/100 != 2 {
+		return ld.LogRecordCount(), exporterhelper.NewHTTPResponseError(resp, e.bulkURL)
+	}
+
+	var bulkResp bulkResponse
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxBulkResponseReadBytes(len(items)))).Decode(&bulkResp); err != nil {
+		return ld.LogRecordCount(), fmt.Errorf("failed to decode the bulk response: %w", err)
+	}
+	if !bulkResp.Errors && dropped == 0 {
+		return 0, nil
+	}
+	return e.handleItemErrors(ld, items, bulkResp, dropped)
+}
+
+// handleItemErrors drops the log records rejected by Elasticsearch, and returns the ones rejected because it is
+// overloaded to be retried.
+func (e *elasticsearchExporter) handleItemErrors(ld pdata.Logs, items []bulkItem, bulkResp bulkResponse, dropped int) (int, error) {
+	var retryable []bulkItem
+	var lastErr string
+	for i, item := range bulkResp.Items {
+		if i >= len(items) {
+			break
+		}
+		for _, result := range item {
+			if result.Status/100 == 2 {
+				continue
+			}
+			if result.Status == http.StatusTooManyRequests || result.Status/100 == 5 {
+				retryable = append(retryable, items[i])
+			} else {
+				dropped++
+			}
+			lastErr = string(result.Error)
+		}
+	}
+
+	if len(retryable) > 0 {
+		err := fmt.Errorf("%d log records rejected by Elasticsearch, %d to retry, last error: %s", dropped+len(retryable), len(retryable), lastErr)
+		return dropped + len(retryable), consumererror.PartialLogsError(err, selectLogs(ld, retryable))
+	}
+	if dropped > 0 {
+		return dropped, consumererror.Permanent(fmt.Errorf("%d log records rejected by Elasticsearch, last error: %s", dropped, lastErr))
+	}
+	return 0, nil
+}
+
+func (e *elasticsearchExporter) do(ctx context.Context, method, url, contentType string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if e.config.User != "" {
+		req.SetBasicAuth(e.config.User, e.config.Password)
+	}
+	return e.client.Do(req)
+}
+
+// maxBulkResponseReadBytes bounds the size of the bulk response read, which has an item per indexed document.
+func maxBulkResponseReadBytes(items int) int64 {
+	return int64(maxHTTPResponseReadBytes + items*1024)
+}
+
+func closeResponse(resp *http.Response) {
+	// Discard any remaining response body when we are done reading.
+	io.CopyN(ioutil.Discard, resp.Body, maxHTTPResponseReadBytes)
+	resp.Body.Close()
+}
+
+// selectLogs returns a copy of the log records of the items, grouped by resource and instrumentation library.
+func selectLogs(ld pdata.Logs, items []bulkItem) pdata.Logs {
+	selected := pdata.NewLogs()
+	rls := selected.ResourceLogs()
+	resources := map[int]pdata.ResourceLogs{}
+	libraries := map[[2]int]pdata.InstrumentationLibraryLogs{}
+	for _, item := range items {
+		srcRL := ld.ResourceLogs().At(item.resourceIndex)
+		rl, ok := resources[item.resourceIndex]
+		if !ok {
+			rls.Resize(rls.Len() + 1)
+			rl = rls.At(rls.Len() - 1)
+			srcRL.Resource().CopyTo(rl.Resource())
+			resources[item.resourceIndex] = rl
+		}
+		srcILL := srcRL.InstrumentationLibraryLogs().At(item.libraryIndex)
+		key := [2]int{item.resourceIndex, item.libraryIndex}
+		ill, ok := libraries[key]
+		if !ok {
+			ills := rl.InstrumentationLibraryLogs()
+			ills.Resize(ills.Len() + 1)
+			ill = ills.At(ills.Len() - 1)
+			srcILL.InstrumentationLibrary().CopyTo(ill.InstrumentationLibrary())
+			libraries[key] = ill
+		}
+		logs := ill.Logs()
+		logs.Resize(logs.Len() + 1)
+		srcILL.Logs().At(item.recordIndex).CopyTo(logs.At(logs.Len() - 1))
+	}
+	return selected
+}
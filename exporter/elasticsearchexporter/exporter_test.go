@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func newTestExporter(t *testing.T, endpoint string) *elasticsearchExporter {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.User = "elastic"
+	cfg.Password = "changeme"
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	return exp
+}
+
+// readBulkRequest returns the actions and the documents of a bulk request.
+func readBulkRequest(t *testing.T, r *http.Request) ([]map[string]map[string]string, []map[string]interface{}) {
+	var actions []map[string]map[string]string
+	var docs []map[string]interface{}
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		var action map[string]map[string]string
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &action))
+		actions = append(actions, action)
+		require.True(t, scanner.Scan())
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &doc))
+		docs = append(docs, doc)
+	}
+	require.NoError(t, scanner.Err())
+	return actions, docs
+}
+
+func TestPushLogsData(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_bulk", r.URL.Path)
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "elastic", user)
+		assert.Equal(t, "changeme", password)
+
+		actions, docs := readBulkRequest(t, r)
+		require.Len(t, actions, 2)
+		for _, action := range actions {
+			assert.Equal(t, "otel-logs-2020.02.11", action["create"]["_index"])
+		}
+		assert.Equal(t, "This is a log message", docs[0]["message"])
+		assert.Equal(t, "something happened", docs[1]["message"])
+		assert.Equal(t, "2020-02-11T20:26:13.000000789Z", docs[0]["@timestamp"])
+		w.Write([]byte(`{"errors":false,"items":[{"create":{"status":201}},{"create":{"status":201}}]}`))
+	}))
+	defer srv.Close()
+
+	exp := newTestExporter(t, srv.URL)
+	dropped, err := exp.pushLogsData(context.Background(), testdata.GenerateLogDataTwoLogsSameResource())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+}
+
+func TestPushLogsData_Empty(t *testing.T) {
+	exp := newTestExporter(t, "http://localhost:1")
+	dropped, err := exp.pushLogsData(context.Background(), testdata.GenerateLogDataEmpty())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+}
+
+func TestPushLogsData_ResponseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		permanent  bool
+		throttle   time.Duration
+	}{
+		{
+			name:       "too_many_requests",
+			status:     http.StatusTooManyRequests,
+			retryAfter: "30",
+			throttle:   30 * time.Second,
+		},
+		{
+			name:      "bad_request",
+			status:    http.StatusBadRequest,
+			permanent: true,
+		},
+		{
+			name:   "service_unavailable",
+			status: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			exp := newTestExporter(t, srv.URL)
+			ld := testdata.GenerateLogDataTwoLogsSameResource()
+			dropped, err := exp.pushLogsData(context.Background(), ld)
+			require.Error(t, err)
+			assert.Equal(t, ld.LogRecordCount(), dropped)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+			class, delay := exporterhelper.DefaultErrorClassifier(err)
+			if tt.throttle > 0 {
+				assert.Equal(t, exporterhelper.ErrorClassThrottled, class)
+				assert.Equal(t, tt.throttle, delay)
+			}
+		})
+	}
+}
+
+func TestPushLogsData_ItemErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, docs := readBulkRequest(t, r)
+		require.Len(t, docs, 3)
+		w.Write([]byte(`{"errors":true,"items":[` +
+			`{"create":{"status":201}},` +
+			`{"create":{"status":429,"error":{"type":"es_rejected_execution_exception"}}},` +
+			`{"create":{"status":400,"error":{"type":"mapper_parsing_exception"}}}]}`))
+	}))
+	defer srv.Close()
+
+	exp := newTestExporter(t, srv.URL)
+	dropped, err := exp.pushLogsData(context.Background(), testdata.GenerateLogDataTwoLogsSameResourceOneDifferent())
+	require.Error(t, err)
+	assert.Equal(t, 2, dropped)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	var partialErr consumererror.PartialError
+	require.True(t, errors.As(err, &partialErr))
+	retry := partialErr.GetLogs()
+	require.Equal(t, 1, retry.LogRecordCount())
+	assert.Equal(t, "logB", retry.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
+}
+
+func TestPushLogsData_ItemErrorsPermanent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors":true,"items":[` +
+			`{"create":{"status":201}},` +
+			`{"create":{"status":400,"error":{"type":"mapper_parsing_exception"}}}]}`))
+	}))
+	defer srv.Close()
+
+	exp := newTestExporter(t, srv.URL)
+	dropped, err := exp.pushLogsData(context.Background(), testdata.GenerateLogDataTwoLogsSameResource())
+	require.Error(t, err)
+	assert.Equal(t, 1, dropped)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), "mapper_parsing_exception")
+}
+
+func TestStart_PutIndexTemplate(t *testing.T) {
+	var template map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/_index_template/otel-logs", r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(body, &template))
+		w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer srv.Close()
+
+	exp := newTestExporter(t, srv.URL)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NotNil(t, template)
+	assert.Equal(t, []interface{}{"otel-logs-*"}, template["index_patterns"])
+}
+
+func TestStart_ManageTemplateDisabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	exp := newTestExporter(t, srv.URL)
+	exp.config.ManageTemplate = false
+	assert.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestStart_Unavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	exp := newTestExporter(t, srv.URL)
+	assert.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+}
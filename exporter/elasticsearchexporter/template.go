@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+// indexTemplate returns the composable index template of the daily indices of the index prefix, mapping the
+// Elastic Common Schema fields set by the exporter. The labels are mapped as keywords when they are strings.
+func indexTemplate(index string) map[string]interface{} {
+	keyword := map[string]interface{}{"type": "keyword"}
+	return map[string]interface{}{
+		"index_patterns": []string{index + "-*"},
+		"template": map[string]interface{}{
+			"mappings": map[string]interface{}{
+				"dynamic_templates": []interface{}{
+					map[string]interface{}{
+						"labels": map[string]interface{}{
+							"path_match":         "labels.*",
+							"match_mapping_type": "string",
+							"mapping":            keyword,
+						},
+					},
+				},
+				"properties": map[string]interface{}{
+					"@timestamp":     map[string]interface{}{"type": "date"},
+					"message":        map[string]interface{}{"type": "text"},
+					"log.level":      keyword,
+					"log.logger":     keyword,
+					"event.action":   keyword,
+					"event.severity": map[string]interface{}{"type": "long"},
+					"trace.id":       keyword,
+					"span.id":        keyword,
+					"service.name":   keyword,
+					"host.hostname":  keyword,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// CircuitBreakerSettings defines configuration for failing fast while the destination keeps failing.
+type CircuitBreakerSettings struct {
+	// Enabled indicates whether to fail fast after consecutive failures to send the requests.
+	Enabled bool `mapstructure:"enabled"`
+	// FailureThreshold is the number of consecutive failed attempts to send the requests that opens the circuit.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// CoolDown is the time during which the requests fail fast once the circuit is open. After it, the circuit is
+	// half-open: a single request is sent to probe whether the destination recovered, closing the circuit if it did.
+	CoolDown time.Duration `mapstructure:"cool_down"`
+}
+
+// DefaultCircuitBreakerSettings returns the default settings for CircuitBreakerSettings.
+func DefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		Enabled:          false,
+		FailureThreshold: 5,
+		CoolDown:         30 * time.Second,
+	}
+}
+
+// CircuitBreakerState is the state of the circuit breaker of an exporter.
+type CircuitBreakerState int
+
+const (
+	// CircuitBreakerClosed is the state where the requests are sent.
+	CircuitBreakerClosed CircuitBreakerState = iota
+	// CircuitBreakerOpen is the state where the requests fail fast, until the end of the cool-down.
+	CircuitBreakerOpen
+	// CircuitBreakerHalfOpen is the state where a request is sent to probe whether the destination recovered.
+	CircuitBreakerHalfOpen
+)
+
+// String returns the name of the state, as used in the metrics.
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitBreakerClosed:
+		return "closed"
+	case CircuitBreakerOpen:
+		return "open"
+	case CircuitBreakerHalfOpen:
+		return "half_open"
+	}
+	return fmt.Sprintf("CircuitBreakerState(%d)", int(s))
+}
+
+// CircuitBreakerStatus is the state of the circuit breaker of an exporter for one data type.
+type CircuitBreakerStatus struct {
+	Exporter string
+	DataType configmodels.DataType
+	State    CircuitBreakerState
+}
+
+var errCircuitOpen = errors.New("circuit breaker is open, the destination keeps failing")
+
+// circuitBreakers are the circuit breakers of the started exporters.
+var circuitBreakers = struct {
+	sync.Mutex
+	m map[*circuitBreaker]struct{}
+}{m: map[*circuitBreaker]struct{}{}}
+
+// CircuitBreakerStatuses returns the state of the circuit breakers of the started exporters, sorted by exporter and
+// data type.
+func CircuitBreakerStatuses() []CircuitBreakerStatus {
+	circuitBreakers.Lock()
+	statuses := make([]CircuitBreakerStatus, 0, len(circuitBreakers.m))
+	for cb := range circuitBreakers.m {
+		statuses = append(statuses, cb.status())
+	}
+	circuitBreakers.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Exporter != statuses[j].Exporter {
+			return statuses[i].Exporter < statuses[j].Exporter
+		}
+		return statuses[i].DataType < statuses[j].DataType
+	})
+	return statuses
+}
+
+// circuitBreaker counts the consecutive failed attempts to send the requests, the errors classified as permanent
+// being caused by the data rather than by the destination.
+type circuitBreaker struct {
+	fullName   string
+	dataType   configmodels.DataType
+	cfg        CircuitBreakerSettings
+	classifier ErrorClassifier
+	logger     *zap.Logger
+	now        func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitBreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(fullName string, cfg CircuitBreakerSettings, classifier ErrorClassifier, logger *zap.Logger) *circuitBreaker {
+	return &circuitBreaker{
+		fullName:   fullName,
+		cfg:        cfg,
+		classifier: classifier,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+func (cb *circuitBreaker) start() {
+	circuitBreakers.Lock()
+	circuitBreakers.m[cb] = struct{}{}
+	circuitBreakers.Unlock()
+	recordCircuitBreakerState(cb.fullName, cb.dataType, cb.status().State)
+}
+
+func (cb *circuitBreaker) shutdown() {
+	circuitBreakers.Lock()
+	delete(circuitBreakers.m, cb)
+	circuitBreakers.Unlock()
+}
+
+func (cb *circuitBreaker) status() CircuitBreakerStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return CircuitBreakerStatus{Exporter: cb.fullName, DataType: cb.dataType, State: cb.state}
+}
+
+// rejecting returns whether the circuit is open and the cool-down is not over.
+func (cb *circuitBreaker) rejecting() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state == CircuitBreakerOpen && cb.now().Sub(cb.openedAt) < cb.cfg.CoolDown
+}
+
+// allow returns whether a request can be sent, half-opening the circuit at the end of the cool-down to let a single
+// request probe the destination.
+func (cb *circuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case CircuitBreakerOpen:
+		if cb.now().Sub(cb.openedAt) < cb.cfg.CoolDown {
+			return false
+		}
+		cb.setState(CircuitBreakerHalfOpen)
+	case CircuitBreakerHalfOpen:
+		if cb.probing {
+			return false
+		}
+	default:
+		return true
+	}
+	cb.probing = true
+	return true
+}
+
+// onResult updates the state of the circuit after an attempt to send a request.
+func (cb *circuitBreaker) onResult(err error) {
+	failed := false
+	if err != nil {
+		class, _ := cb.classifier(err)
+		failed = class != ErrorClassPermanent
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.probing = false
+	if !failed {
+		cb.failures = 0
+		if cb.state != CircuitBreakerClosed {
+			cb.setState(CircuitBreakerClosed)
+		}
+		return
+	}
+
+	cb.failures++
+	if cb.state == CircuitBreakerHalfOpen || (cb.state == CircuitBreakerClosed && cb.failures >= cb.cfg.FailureThreshold) {
+		cb.openedAt = cb.now()
+		cb.setState(CircuitBreakerOpen)
+	}
+}
+
+// setState changes the state of the circuit, it must be called with the lock held.
+func (cb *circuitBreaker) setState(state CircuitBreakerState) {
+	cb.state = state
+	switch state {
+	case CircuitBreakerOpen:
+		cb.logger.Warn(
+			"Circuit breaker opened, failing fast until the end of the cool-down.",
+			zap.Int("consecutive_failures", cb.failures),
+			zap.Duration("cool_down", cb.cfg.CoolDown),
+		)
+	default:
+		cb.logger.Info("Circuit breaker state changed.", zap.String("state", state.String()))
+	}
+	recordCircuitBreakerTransition(cb.fullName, cb.dataType, state)
+}
+
+// circuitBreakerSender fails fast while the circuit is open, and records the result of the attempts to send the
+// requests.
+type circuitBreakerSender struct {
+	breaker    *circuitBreaker
+	nextSender requestSender
+}
+
+// send implements the requestSender interface
+func (cbs *circuitBreakerSender) send(req request) (int, error) {
+	if !cbs.breaker.allow() {
+		return req.count(), errCircuitOpen
+	}
+	droppedItems, err := cbs.nextSender.send(req)
+	cbs.breaker.onResult(err)
+	return droppedItems, err
+}
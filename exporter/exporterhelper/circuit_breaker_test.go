@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestCircuitBreakerState_String(t *testing.T) {
+	assert.Equal(t, "closed", CircuitBreakerClosed.String())
+	assert.Equal(t, "open", CircuitBreakerOpen.String())
+	assert.Equal(t, "half_open", CircuitBreakerHalfOpen.String())
+	assert.Equal(t, "CircuitBreakerState(7)", CircuitBreakerState(7).String())
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cfg := CircuitBreakerSettings{Enabled: true, FailureThreshold: 3, CoolDown: time.Minute}
+	cb := newCircuitBreaker("test", cfg, DefaultErrorClassifier, zap.NewNop())
+	cb.now = func() time.Time { return now }
+	transientErr := errors.New("transient error")
+
+	// The permanent errors and the successes reset the consecutive failures.
+	for _, err := range []error{transientErr, transientErr, consumererror.Permanent(errors.New("bad data")), transientErr, transientErr, nil} {
+		require.True(t, cb.allow())
+		cb.onResult(err)
+	}
+	assert.Equal(t, CircuitBreakerClosed, cb.status().State)
+
+	for i := 0; i < 3; i++ {
+		require.True(t, cb.allow())
+		cb.onResult(transientErr)
+	}
+	assert.Equal(t, CircuitBreakerOpen, cb.status().State)
+	assert.True(t, cb.rejecting())
+	assert.False(t, cb.allow())
+
+	// A single request probes the destination after the cool-down, and opens the circuit again if it fails.
+	now = now.Add(time.Minute)
+	assert.False(t, cb.rejecting())
+	assert.True(t, cb.allow())
+	assert.Equal(t, CircuitBreakerHalfOpen, cb.status().State)
+	assert.False(t, cb.allow())
+	cb.onResult(transientErr)
+	assert.Equal(t, CircuitBreakerOpen, cb.status().State)
+	assert.False(t, cb.allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, cb.allow())
+	cb.onResult(nil)
+	assert.Equal(t, CircuitBreakerClosed, cb.status().State)
+	assert.True(t, cb.allow())
+	assert.True(t, cb.allow())
+}
+
+func TestQueuedRetry_CircuitBreaker(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	now := time.Unix(1000, 0)
+	rCfg := DefaultRetrySettings()
+	rCfg.Enabled = false
+	cbCfg := CircuitBreakerSettings{Enabled: true, FailureThreshold: 2, CoolDown: time.Minute}
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithCircuitBreaker(cbCfg))
+	be.setDataType(configmodels.TracesDataType)
+	be.breaker.now = func() time.Time { return now }
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, []CircuitBreakerStatus{{Exporter: "test", DataType: configmodels.TracesDataType, State: CircuitBreakerClosed}}, CircuitBreakerStatuses())
+
+	for i := 0; i < 2; i++ {
+		mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+		_, err := be.sender.send(mockR)
+		require.Error(t, err)
+		mockR.checkNumRequests(t, 1)
+	}
+	assert.Equal(t, CircuitBreakerOpen, CircuitBreakerStatuses()[0].State)
+
+	// The requests fail fast while the circuit is open.
+	mockR := newMockRequest(context.Background(), 2, nil)
+	droppedItems, err := be.sender.send(mockR)
+	assert.Equal(t, errCircuitOpen, err)
+	assert.Equal(t, 2, droppedItems)
+	mockR.checkNumRequests(t, 0)
+
+	now = now.Add(time.Minute)
+	droppedItems, err = be.sender.send(mockR)
+	require.NoError(t, err)
+	assert.Equal(t, 0, droppedItems)
+	mockR.checkNumRequests(t, 1)
+	assert.Equal(t, CircuitBreakerClosed, CircuitBreakerStatuses()[0].State)
+
+	rows, err := view.RetrieveData("exporter/circuit_breaker_transitions")
+	require.NoError(t, err)
+	got := map[string]float64{}
+	for _, row := range rows {
+		for _, tg := range row.Tags {
+			if tg.Key == tagStateKey {
+				got[tg.Value] = row.Data.(*view.SumData).Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"open": 1, "half_open": 1, "closed": 1}, got)
+
+	rows, err = view.RetrieveData("exporter/circuit_breaker_state")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(CircuitBreakerClosed), rows[0].Data.(*view.LastValueData).Value)
+
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.Empty(t, CircuitBreakerStatuses())
+}
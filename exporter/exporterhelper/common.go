@@ -50,6 +50,8 @@ type request interface {
 	onPartialError(consumererror.PartialError) request
 	// Returns the count of spans/metric points or log records.
 	count() int
+	// marshal serializes the data of the request, to store it in the persistent queue.
+	marshal() ([]byte, error)
 }
 
 // requestSender is an abstraction of a sender for a request independent of the type of the data (traces, metrics, logs).
@@ -76,7 +78,9 @@ type baseSettings struct {
 	TimeoutSettings
 	QueueSettings
 	RetrySettings
+	CircuitBreakerSettings
 	ResourceToTelemetrySettings
+	errorClassifier ErrorClassifier
 }
 
 // fromOptions returns the internal options starting from the default and applying all configured options.
@@ -89,7 +93,9 @@ func fromOptions(options []Option) *baseSettings {
 		QueueSettings: QueueSettings{Enabled: false},
 		// TODO: Enable retry by default (call DefaultRetrySettings)
 		RetrySettings:               RetrySettings{Enabled: false},
+		CircuitBreakerSettings:      CircuitBreakerSettings{Enabled: false},
 		ResourceToTelemetrySettings: defaultResourceToTelemetrySettings(),
+		errorClassifier:             DefaultErrorClassifier,
 	}
 
 	for _, op := range options {
@@ -142,6 +148,22 @@ func WithQueue(queueSettings QueueSettings) Option {
 	}
 }
 
+// WithCircuitBreaker overrides the default CircuitBreakerSettings for an exporter.
+// The default CircuitBreakerSettings is to disable the circuit breaker.
+func WithCircuitBreaker(circuitBreakerSettings CircuitBreakerSettings) Option {
+	return func(o *baseSettings) {
+		o.CircuitBreakerSettings = circuitBreakerSettings
+	}
+}
+
+// WithErrorClassifier overrides the default ErrorClassifier for an exporter, deciding how the export errors are retried.
+// The default ErrorClassifier is DefaultErrorClassifier.
+func WithErrorClassifier(classifier ErrorClassifier) Option {
+	return func(o *baseSettings) {
+		o.errorClassifier = classifier
+	}
+}
+
 // WithResourceToTelemetryConversion overrides the default ResourceToTelemetrySettings for an exporter.
 // The default ResourceToTelemetrySettings is to disable resource attributes to metric labels conversion.
 func WithResourceToTelemetryConversion(resourceToTelemetrySettings ResourceToTelemetrySettings) Option {
@@ -156,6 +178,7 @@ type baseExporter struct {
 	cfg                        configmodels.Exporter
 	sender                     requestSender
 	qrSender                   *queuedRetrySender
+	breaker                    *circuitBreaker
 	convertResourceToTelemetry bool
 }
 
@@ -167,7 +190,13 @@ func newBaseExporter(cfg configmodels.Exporter, logger *zap.Logger, options ...O
 		convertResourceToTelemetry: bs.ResourceToTelemetrySettings.Enabled,
 	}
 
-	be.qrSender = newQueuedRetrySender(cfg.Name(), bs.QueueSettings, bs.RetrySettings, &timeoutSender{cfg: bs.TimeoutSettings}, logger)
+	var nextSender requestSender = &timeoutSender{cfg: bs.TimeoutSettings}
+	if bs.CircuitBreakerSettings.Enabled {
+		be.breaker = newCircuitBreaker(cfg.Name(), bs.CircuitBreakerSettings, bs.errorClassifier, logger)
+		nextSender = &circuitBreakerSender{breaker: be.breaker, nextSender: nextSender}
+	}
+	be.qrSender = newQueuedRetrySender(cfg.Name(), bs.QueueSettings, bs.RetrySettings, bs.errorClassifier, nextSender, logger)
+	be.qrSender.breaker = be.breaker
 	be.sender = be.qrSender
 
 	return be
@@ -179,6 +208,19 @@ func (be *baseExporter) wrapConsumerSender(f func(consumer requestSender) reques
 	be.qrSender.consumerSender = f(be.qrSender.consumerSender)
 }
 
+// setRequestUnmarshaler sets the function rebuilding the requests stored in the persistent queue.
+func (be *baseExporter) setRequestUnmarshaler(unmarshaler requestUnmarshaler) {
+	be.qrSender.unmarshaler = unmarshaler
+}
+
+// setDataType sets the data type of the exporter, which tags the metrics of the sending queue.
+func (be *baseExporter) setDataType(dataType configmodels.DataType) {
+	be.qrSender.obsrep.dataType = dataType
+	if be.breaker != nil {
+		be.breaker.dataType = dataType
+	}
+}
+
 // Start all senders and exporter and is invoked during service start.
 func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 	// First start the wrapped exporter.
@@ -186,15 +228,21 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	if be.breaker != nil {
+		be.breaker.start()
+	}
+
 	// If no error then start the queuedRetrySender.
-	be.qrSender.start()
-	return nil
+	return be.qrSender.start()
 }
 
 // Shutdown all senders and exporter and is invoked during service shutdown.
 func (be *baseExporter) Shutdown(ctx context.Context) error {
 	// First shutdown the queued retry sender
 	be.qrSender.shutdown()
+	if be.breaker != nil {
+		be.breaker.shutdown()
+	}
 	// Last shutdown the wrapped exporter itself.
 	return be.Component.Shutdown(ctx)
 }
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+const (
+	headerRetryAfter = "Retry-After"
+	// maxErrorMessageBytes bounds the part of the response body included in the error.
+	maxErrorMessageBytes = 1024
+)
+
+// NewHTTPResponseError returns the error of a request to the url which failed with the response: a throttling error
+// honoring the Retry-After header for the HTTP 429 and 503, a permanent error for the other client errors, and a
+// retryable error for the other server errors. The error message includes the beginning of the response body.
+func NewHTTPResponseError(resp *http.Response, url string) error {
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorMessageBytes))
+	err := fmt.Errorf("request to %s responded with HTTP Status Code %d, Message=%s", url, resp.StatusCode, bytes.TrimSpace(msg))
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		// Fallback to 0 if the Retry-After header is not present, the retry then uses the throttling backoff.
+		retryAfter := 0
+		if val := resp.Header.Get(headerRetryAfter); val != "" {
+			if seconds, err2 := strconv.Atoi(val); err2 == nil {
+				retryAfter = seconds
+			}
+		}
+		return NewThrottleRetry(err, time.Duration(retryAfter)*time.Second)
+	case resp.StatusCode/100 == 4:
+		return consumererror.Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestNewHTTPResponseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		class      ErrorClass
+		delay      time.Duration
+	}{
+		{name: "too_many_requests", status: http.StatusTooManyRequests, retryAfter: "30", class: ErrorClassThrottled, delay: 30 * time.Second},
+		{name: "service_unavailable", status: http.StatusServiceUnavailable, class: ErrorClassThrottled},
+		{name: "invalid_retry_after", status: http.StatusServiceUnavailable, retryAfter: "soon", class: ErrorClassThrottled},
+		{name: "bad_request", status: http.StatusBadRequest, class: ErrorClassPermanent},
+		{name: "not_found", status: http.StatusNotFound, class: ErrorClassPermanent},
+		{name: "internal_server_error", status: http.StatusInternalServerError, class: ErrorClassTransient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(" rejected\n")),
+			}
+			if tt.retryAfter != "" {
+				resp.Header.Set(headerRetryAfter, tt.retryAfter)
+			}
+			err := NewHTTPResponseError(resp, "http://localhost/write")
+			assert.Contains(t, err.Error(), "request to http://localhost/write responded with HTTP Status Code "+
+				strconv.Itoa(tt.status)+", Message=rejected")
+			assert.Equal(t, tt.class == ErrorClassPermanent, consumererror.IsPermanent(err))
+			class, delay := DefaultErrorClassifier(err)
+			assert.Equal(t, tt.class, class)
+			assert.Equal(t, tt.delay, delay)
+		})
+	}
+}
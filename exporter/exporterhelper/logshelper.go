@@ -57,6 +57,21 @@ func (req *logsRequest) count() int {
 	return req.ld.LogRecordCount()
 }
 
+func (req *logsRequest) marshal() ([]byte, error) {
+	return req.ld.ToOtlpProtoBytes()
+}
+
+// newLogsRequestUnmarshaler returns the function rebuilding the requests stored in the persistent queue.
+func newLogsRequestUnmarshaler(exporterName string, pusher PushLogs) requestUnmarshaler {
+	return func(data []byte) (request, error) {
+		ld := pdata.NewLogs()
+		if err := ld.FromOtlpProtoBytes(data); err != nil {
+			return nil, err
+		}
+		return newLogsRequest(obsreport.ExporterContext(context.Background(), exporterName), ld, pusher), nil
+	}
+}
+
 type logsExporter struct {
 	*baseExporter
 	pusher PushLogs
@@ -88,6 +103,8 @@ func NewLogsExporter(
 	}
 
 	be := newBaseExporter(cfg, logger, options...)
+	be.setDataType(configmodels.LogsDataType)
+	be.setRequestUnmarshaler(newLogsRequestUnmarshaler(cfg.Name(), pusher))
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &logsExporterWithObservability{
 			obsrep:     obsreport.NewExporterObsReport(configtelemetry.GetMetricsLevelFlagValue(), cfg.Name()),
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	tagExporterKey   = tag.MustNewKey(obsreport.ExporterKey)
+	tagErrorClassKey = tag.MustNewKey("error_class")
+	tagDataTypeKey   = tag.MustNewKey(obsreport.DataTypeKey)
+	tagStateKey      = tag.MustNewKey("state")
+
+	statSendRetries = stats.Int64(
+		"exporter/send_retries",
+		"Number of retries of the requests that failed to be sent",
+		stats.UnitDimensionless)
+	statDroppedRequests = stats.Int64(
+		"exporter/dropped_requests",
+		"Number of requests dropped after failing to be sent",
+		stats.UnitDimensionless)
+	statCircuitBreakerState = stats.Int64(
+		"exporter/circuit_breaker_state",
+		"State of the circuit breaker, 0 for closed, 1 for open and 2 for half-open",
+		stats.UnitDimensionless)
+	statCircuitBreakerTransitions = stats.Int64(
+		"exporter/circuit_breaker_transitions",
+		"Number of changes of the state of the circuit breaker, tagged by the new state",
+		stats.UnitDimensionless)
+
+	// circuitBreakerStateAggregation keeps the state of the circuit breaker
+	// recorded after the last change.
+	circuitBreakerStateAggregation = view.LastValue()
+)
+
+// MetricViews returns the metrics views related to the retries of the exporters, tagged by the class of the errors,
+// and to their circuit breakers, tagged by the data type.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagExporterKey, tagErrorClassKey}
+	return []*view.View{
+		{
+			Name:        statSendRetries.Name(),
+			Measure:     statSendRetries,
+			Description: statSendRetries.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        statDroppedRequests.Name(),
+			Measure:     statDroppedRequests,
+			Description: statDroppedRequests.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        statCircuitBreakerState.Name(),
+			Measure:     statCircuitBreakerState,
+			Description: statCircuitBreakerState.Description(),
+			TagKeys:     []tag.Key{tagExporterKey, tagDataTypeKey},
+			Aggregation: circuitBreakerStateAggregation,
+		},
+		{
+			Name:        statCircuitBreakerTransitions.Name(),
+			Measure:     statCircuitBreakerTransitions,
+			Description: statCircuitBreakerTransitions.Description(),
+			TagKeys:     []tag.Key{tagExporterKey, tagDataTypeKey, tagStateKey},
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+func recordRetry(ctx context.Context, exporterName string, class ErrorClass) {
+	recordErrorClass(ctx, exporterName, class, statSendRetries)
+}
+
+func recordDroppedRequest(ctx context.Context, exporterName string, class ErrorClass) {
+	recordErrorClass(ctx, exporterName, class, statDroppedRequests)
+}
+
+func recordErrorClass(ctx context.Context, exporterName string, class ErrorClass, measure *stats.Int64Measure) {
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{
+			tag.Upsert(tagExporterKey, exporterName),
+			tag.Upsert(tagErrorClassKey, class.String()),
+		},
+		measure.M(1))
+}
+
+func recordCircuitBreakerState(exporterName string, dataType configmodels.DataType, state CircuitBreakerState) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{
+			tag.Upsert(tagExporterKey, exporterName),
+			tag.Upsert(tagDataTypeKey, string(dataType)),
+		},
+		statCircuitBreakerState.M(int64(state)))
+}
+
+func recordCircuitBreakerTransition(exporterName string, dataType configmodels.DataType, state CircuitBreakerState) {
+	recordCircuitBreakerState(exporterName, dataType, state)
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{
+			tag.Upsert(tagExporterKey, exporterName),
+			tag.Upsert(tagDataTypeKey, string(dataType)),
+			tag.Upsert(tagStateKey, state.String()),
+		},
+		statCircuitBreakerTransitions.M(1))
+}
+
+// queueObsReport records the metrics of the sending queue of an exporter, for the data type set by the helper creating
+// the exporter.
+type queueObsReport struct {
+	obsrep   *obsreport.ExporterObsReport
+	dataType configmodels.DataType
+}
+
+func newQueueObsReport(exporterName string) *queueObsReport {
+	return &queueObsReport{
+		obsrep: obsreport.NewExporterObsReport(configtelemetry.GetMetricsLevelFlagValue(), exporterName),
+	}
+}
+
+func (qor *queueObsReport) recordQueueSize(size, capacity int) {
+	qor.obsrep.RecordQueueSize(context.Background(), qor.dataType, size, capacity)
+}
+
+func (qor *queueObsReport) recordEnqueueFailure(ctx context.Context, numItems int) {
+	qor.obsrep.RecordEnqueueFailure(ctx, qor.dataType, numItems)
+}
+
+func (qor *queueObsReport) recordDroppedItems(ctx context.Context, numItems int) {
+	qor.obsrep.RecordDroppedItems(ctx, qor.dataType, numItems)
+}
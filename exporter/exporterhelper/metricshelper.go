@@ -58,6 +58,21 @@ func (req *metricsRequest) count() int {
 	return numPoints
 }
 
+func (req *metricsRequest) marshal() ([]byte, error) {
+	return req.md.ToOtlpProtoBytes()
+}
+
+// newMetricsRequestUnmarshaler returns the function rebuilding the requests stored in the persistent queue.
+func newMetricsRequestUnmarshaler(exporterName string, pusher PushMetrics) requestUnmarshaler {
+	return func(data []byte) (request, error) {
+		md := pdata.NewMetrics()
+		if err := md.FromOtlpProtoBytes(data); err != nil {
+			return nil, err
+		}
+		return newMetricsRequest(obsreport.ExporterContext(context.Background(), exporterName), md, pusher), nil
+	}
+}
+
 type metricsExporter struct {
 	*baseExporter
 	pusher PushMetrics
@@ -93,6 +108,8 @@ func NewMetricsExporter(
 	}
 
 	be := newBaseExporter(cfg, logger, options...)
+	be.setDataType(configmodels.MetricsDataType)
+	be.setRequestUnmarshaler(newMetricsRequestUnmarshaler(cfg.Name(), pusher))
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &metricsSenderWithObservability{
 			obsrep:     obsreport.NewExporterObsReport(configtelemetry.GetMetricsLevelFlagValue(), cfg.Name()),
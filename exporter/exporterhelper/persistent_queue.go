@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The write-ahead log storing the queued requests is made of segment files,
+// each holding a sequence of records. A put record holds a serialized request,
+// and an ack record marks the request with the same sequence number as done.
+// The segments are deleted from the oldest one once all their requests are
+// done, and a new segment is started when the active one is full or the log is
+// opened.
+const (
+	walSegmentSuffix = ".wal"
+	walSegmentSize   = 16 << 20
+
+	walPutRecord byte = 1
+	walAckRecord byte = 2
+
+	// walHeaderSize is the size of the record type, sequence number and payload
+	// length, followed by the CRC-32 of the header and the payload.
+	walHeaderSize = 1 + 8 + 4
+)
+
+var errWALClosed = errors.New("persistent queue is closed")
+
+// walRecord locates the payload of a put record.
+type walRecord struct {
+	seq     uint64
+	segment uint64
+	offset  int64
+	size    int
+}
+
+type walSegment struct {
+	id      uint64
+	path    string
+	pending int
+}
+
+// wal is a write-ahead log storing the queued requests in a directory, so that
+// they survive the collector restarts.
+type wal struct {
+	dir string
+
+	lock       sync.Mutex
+	segments   []*walSegment
+	active     *os.File
+	activeSize int64
+	nextSeq    uint64
+}
+
+// openWAL opens the log in the directory, creating it if needed, and returns
+// the put records which are not done, in the order they were written. The
+// records partially written when the collector stopped are ignored.
+func openWAL(dir string) (*wal, []walRecord, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w := &wal{dir: dir}
+	pending := make(map[uint64]walRecord)
+	for _, file := range files {
+		id, err := strconv.ParseUint(strings.TrimSuffix(file.Name(), walSegmentSuffix), 10, 64)
+		if err != nil || !strings.HasSuffix(file.Name(), walSegmentSuffix) {
+			continue
+		}
+		w.segments = append(w.segments, &walSegment{id: id, path: filepath.Join(dir, file.Name())})
+	}
+	sort.Slice(w.segments, func(i, j int) bool { return w.segments[i].id < w.segments[j].id })
+
+	segmentsByID := make(map[uint64]*walSegment, len(w.segments))
+	for _, segment := range w.segments {
+		segmentsByID[segment.id] = segment
+		if err := w.replaySegment(segment, pending); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, rec := range pending {
+		segmentsByID[rec.segment].pending++
+	}
+
+	nextSegment := uint64(1)
+	if len(w.segments) > 0 {
+		nextSegment = w.segments[len(w.segments)-1].id + 1
+	}
+	if err := w.startSegment(nextSegment); err != nil {
+		return nil, nil, err
+	}
+	if err := w.removeDoneSegments(); err != nil {
+		w.close()
+		return nil, nil, err
+	}
+
+	records := make([]walRecord, 0, len(pending))
+	for _, rec := range pending {
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].seq < records[j].seq })
+	return w, records, nil
+}
+
+// replaySegment adds the put records of the segment to the pending ones, and
+// removes the ones done.
+func (w *wal) replaySegment(segment *walSegment, pending map[uint64]walRecord) error {
+	f, err := os.Open(segment.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var offset int64
+	header := make([]byte, walHeaderSize+4)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil
+		}
+		seq := binary.BigEndian.Uint64(header[1:9])
+		size := int(binary.BigEndian.Uint32(header[9:13]))
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil
+		}
+		if binary.BigEndian.Uint32(header[walHeaderSize:]) != recordChecksum(header[:walHeaderSize], payload) {
+			return nil
+		}
+
+		switch header[0] {
+		case walPutRecord:
+			pending[seq] = walRecord{seq: seq, segment: segment.id, offset: offset + int64(len(header)), size: size}
+		case walAckRecord:
+			delete(pending, seq)
+		}
+		if seq >= w.nextSeq {
+			w.nextSeq = seq + 1
+		}
+		offset += int64(len(header) + size)
+	}
+}
+
+// put appends a put record with the payload, and syncs it to the disk.
+func (w *wal) put(payload []byte) (walRecord, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.active == nil {
+		return walRecord{}, errWALClosed
+	}
+
+	if w.activeSize >= walSegmentSize {
+		if err := w.startSegment(w.segments[len(w.segments)-1].id + 1); err != nil {
+			return walRecord{}, err
+		}
+		if err := w.removeDoneSegments(); err != nil {
+			return walRecord{}, err
+		}
+	}
+
+	segment := w.segments[len(w.segments)-1]
+	rec := walRecord{seq: w.nextSeq, segment: segment.id, offset: w.activeSize + walHeaderSize + 4, size: len(payload)}
+	if err := w.write(walPutRecord, rec.seq, payload); err != nil {
+		return walRecord{}, err
+	}
+	if err := w.active.Sync(); err != nil {
+		return walRecord{}, err
+	}
+	w.nextSeq++
+	segment.pending++
+	return rec, nil
+}
+
+// read returns the payload of the put record.
+func (w *wal) read(rec walRecord) ([]byte, error) {
+	w.lock.Lock()
+	path := ""
+	for _, segment := range w.segments {
+		if segment.id == rec.segment {
+			path = segment.path
+		}
+	}
+	w.lock.Unlock()
+	if path == "" {
+		return nil, fmt.Errorf("segment %d of the persistent queue not found", rec.segment)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	payload := make([]byte, rec.size)
+	if _, err := f.ReadAt(payload, rec.offset); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// ack appends an ack record marking the put record as done, and deletes the
+// oldest segments whose records are all done.
+func (w *wal) ack(rec walRecord) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.active == nil {
+		return errWALClosed
+	}
+
+	if err := w.write(walAckRecord, rec.seq, nil); err != nil {
+		return err
+	}
+	for _, segment := range w.segments {
+		if segment.id == rec.segment {
+			segment.pending--
+		}
+	}
+	return w.removeDoneSegments()
+}
+
+func (w *wal) close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.active == nil {
+		return nil
+	}
+	err := w.active.Close()
+	w.active = nil
+	return err
+}
+
+func (w *wal) write(recordType byte, seq uint64, payload []byte) error {
+	record := make([]byte, walHeaderSize+4+len(payload))
+	record[0] = recordType
+	binary.BigEndian.PutUint64(record[1:9], seq)
+	binary.BigEndian.PutUint32(record[9:13], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[walHeaderSize:], recordChecksum(record[:walHeaderSize], payload))
+	copy(record[walHeaderSize+4:], payload)
+	n, err := w.active.Write(record)
+	w.activeSize += int64(n)
+	return err
+}
+
+// startSegment closes the active segment and creates a new one.
+func (w *wal) startSegment(id uint64) error {
+	path := filepath.Join(w.dir, fmt.Sprintf("%020d%s", id, walSegmentSuffix))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if w.active != nil {
+		if err := w.active.Close(); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	w.active = f
+	w.activeSize = 0
+	w.segments = append(w.segments, &walSegment{id: id, path: path})
+	return nil
+}
+
+// removeDoneSegments deletes the oldest inactive segments whose put records
+// are all done. The segments are deleted in order, since their ack records may
+// mark the put records of the previous segments as done.
+func (w *wal) removeDoneSegments() error {
+	for len(w.segments) > 1 && w.segments[0].pending <= 0 {
+		if err := os.Remove(w.segments[0].path); err != nil {
+			return err
+		}
+		w.segments = w.segments[1:]
+	}
+	return nil
+}
+
+func recordChecksum(header, payload []byte) uint32 {
+	return crc32.Update(crc32.ChecksumIEEE(header), crc32.IEEETable, payload)
+}
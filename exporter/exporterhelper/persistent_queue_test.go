@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, records, err := openWAL(dir)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	first, err := w.put([]byte("first"))
+	require.NoError(t, err)
+	second, err := w.put([]byte("second"))
+	require.NoError(t, err)
+	third, err := w.put([]byte("third"))
+	require.NoError(t, err)
+
+	data, err := w.read(second)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+
+	require.NoError(t, w.ack(second))
+	require.NoError(t, w.close())
+	_, err = w.put([]byte("closed"))
+	assert.Equal(t, errWALClosed, err)
+
+	// The records which are not done are returned when the log is reopened, and
+	// new records are written to a new segment.
+	w, records, err = openWAL(dir)
+	require.NoError(t, err)
+	defer w.close()
+	require.Len(t, records, 2)
+	assert.Equal(t, first.seq, records[0].seq)
+	assert.Equal(t, third.seq, records[1].seq)
+	data, err = w.read(records[1])
+	require.NoError(t, err)
+	assert.Equal(t, []byte("third"), data)
+
+	fourth, err := w.put([]byte("fourth"))
+	require.NoError(t, err)
+	assert.Equal(t, third.seq+1, fourth.seq)
+	assert.Len(t, w.segments, 2)
+
+	// The old segment is deleted once its records are done.
+	require.NoError(t, w.ack(records[0]))
+	require.NoError(t, w.ack(records[1]))
+	assert.Len(t, w.segments, 1)
+	files, err := filepath.Glob(filepath.Join(dir, "*"+walSegmentSuffix))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestWAL_PartialRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, _, err := openWAL(dir)
+	require.NoError(t, err)
+	_, err = w.put([]byte("complete"))
+	require.NoError(t, err)
+	_, err = w.put([]byte("partial"))
+	require.NoError(t, err)
+	path := w.segments[0].path
+	size := w.activeSize
+	require.NoError(t, w.close())
+
+	// The record partially written when the collector stopped is ignored.
+	require.NoError(t, os.Truncate(path, size-3))
+	w, records, err := openWAL(dir)
+	require.NoError(t, err)
+	defer w.close()
+	require.Len(t, records, 1)
+	data, err := w.read(records[0])
+	require.NoError(t, err)
+	assert.Equal(t, []byte("complete"), data)
+}
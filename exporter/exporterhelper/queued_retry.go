@@ -18,6 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -38,6 +42,12 @@ type QueueSettings struct {
 	NumConsumers int `mapstructure:"num_consumers"`
 	// QueueSize is the maximum number of batches allowed in queue at a given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// Storage is where the queued batches are stored, "memory" or "file". The batches stored in files survive the
+	// collector restarts. Defaults to "memory".
+	Storage string `mapstructure:"storage"`
+	// Directory is the directory of the files storing the queued batches, each exporter stores them in its own
+	// sub-directory. Required with the "file" storage.
+	Directory string `mapstructure:"directory"`
 }
 
 // DefaultQueueSettings returns the default settings for QueueSettings.
@@ -66,25 +76,65 @@ type RetrySettings struct {
 	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch.
 	// Once this value is reached, the data is discarded.
 	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+	// RandomizationFactor is the jitter applied to the delays between retries, a delay is randomized by up to
+	// this fraction of its value so that the retries of the exporters failing together are spread out.
+	// 0 disables the jitter, it must be between 0 and 1.
+	RandomizationFactor float64 `mapstructure:"randomization_factor"`
+}
+
+// Validate checks that the randomization factor is between 0 and 1. It is
+// called when the config is validated, through the configs of the exporters
+// embedding the RetrySettings.
+func (rs *RetrySettings) Validate() error {
+	if rs.RandomizationFactor < 0 || rs.RandomizationFactor > 1 {
+		return fmt.Errorf("retry_on_failure randomization_factor must be between 0 and 1, got %v", rs.RandomizationFactor)
+	}
+	return nil
 }
 
 // DefaultRetrySettings returns the default settings for RetrySettings.
 func DefaultRetrySettings() RetrySettings {
 	return RetrySettings{
-		Enabled:         true,
-		InitialInterval: 5 * time.Second,
-		MaxInterval:     30 * time.Second,
-		MaxElapsedTime:  5 * time.Minute,
+		Enabled:             true,
+		InitialInterval:     5 * time.Second,
+		MaxInterval:         30 * time.Second,
+		MaxElapsedTime:      5 * time.Minute,
+		RandomizationFactor: backoff.DefaultRandomizationFactor,
 	}
 }
 
+const (
+	memoryStorage = "memory"
+	fileStorage   = "file"
+)
+
+// replayInterval is how often the batches left in the log which did not fit in
+// the queue are enqueued again.
+var replayInterval = 100 * time.Millisecond
+
+// requestUnmarshaler rebuilds a request from its serialized form.
+type requestUnmarshaler func(data []byte) (request, error)
+
 type queuedRetrySender struct {
+	fullName        string
 	cfg             QueueSettings
 	consumerSender  requestSender
 	queue           *queue.BoundedQueue
 	retryStopCh     chan struct{}
 	traceAttributes []trace.Attribute
 	logger          *zap.Logger
+	obsrep          *queueObsReport
+	// breaker rejects the requests while its circuit is open, nil if the
+	// circuit breaker is disabled.
+	breaker *circuitBreaker
+
+	// wal stores the queued requests with the "file" storage, the queue then
+	// holds the records of the requests in the log.
+	wal         *wal
+	unmarshaler requestUnmarshaler
+	// replayWG waits for the replay of the batches left in the log which did
+	// not fit in the queue when it started.
+	replayWG sync.WaitGroup
 }
 
 func createSampledLogger(logger *zap.Logger) *zap.Logger {
@@ -106,36 +156,165 @@ func createSampledLogger(logger *zap.Logger) *zap.Logger {
 	return logger.WithOptions(opts)
 }
 
-func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySettings, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
+func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySettings, classifier ErrorClassifier, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
 	traceAttr := trace.StringAttribute(obsreport.ExporterKey, fullName)
+	obsrep := newQueueObsReport(fullName)
 	return &queuedRetrySender{
-		cfg: qCfg,
+		fullName: fullName,
+		cfg:      qCfg,
 		consumerSender: &retrySender{
+			fullName:       fullName,
 			traceAttribute: traceAttr,
 			cfg:            rCfg,
+			classifier:     classifier,
 			nextSender:     nextSender,
 			stopCh:         retryStopCh,
 			logger:         sampledLogger,
+			obsrep:         obsrep,
 		},
 		queue:           queue.NewBoundedQueue(qCfg.QueueSize, func(item interface{}) {}),
 		retryStopCh:     retryStopCh,
 		traceAttributes: []trace.Attribute{traceAttr},
 		logger:          sampledLogger,
+		obsrep:          obsrep,
 	}
 }
 
 // start is invoked during service startup.
-func (qrs *queuedRetrySender) start() {
+func (qrs *queuedRetrySender) start() error {
+	switch qrs.cfg.Storage {
+	case "", memoryStorage:
+	case fileStorage:
+		if qrs.cfg.Enabled {
+			return qrs.startPersistentQueue()
+		}
+	default:
+		return fmt.Errorf("sending_queue storage must be %q or %q, got %q", memoryStorage, fileStorage, qrs.cfg.Storage)
+	}
+
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
+		qrs.recordQueueSize()
 		req := item.(request)
 		_, _ = qrs.consumerSender.send(req)
 	})
+	qrs.recordQueueSize()
+	return nil
+}
+
+// startPersistentQueue opens the log of the queued requests, and enqueues the
+// requests left by the previous runs of the collector.
+func (qrs *queuedRetrySender) startPersistentQueue() error {
+	if qrs.cfg.Directory == "" {
+		return errors.New("sending_queue directory is required with the file storage")
+	}
+	if qrs.unmarshaler == nil {
+		return errors.New("sending_queue file storage is not supported by the exporter")
+	}
+	dir := filepath.Join(qrs.cfg.Directory, strings.ReplaceAll(qrs.fullName, "/", "_"))
+	w, records, err := openWAL(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open the sending_queue in %q: %w", dir, err)
+	}
+	qrs.wal = w
+
+	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
+		qrs.recordQueueSize()
+		qrs.consumePersisted(item.(walRecord))
+	})
+	backlog := records
+	for len(backlog) > 0 && qrs.queue.Produce(backlog[0]) {
+		backlog = backlog[1:]
+	}
+	qrs.recordQueueSize()
+	if len(records) > 0 {
+		qrs.logger.Info("Resuming the sending of the batches left in the sending_queue.", zap.Int("batches", len(records)))
+	}
+	if len(backlog) > 0 {
+		qrs.logger.Warn(
+			"The sending_queue holds more batches than queue_size, enqueuing them as the queue drains.",
+			zap.Int("queue_size", qrs.queue.Capacity()),
+			zap.Int("batches", len(records)),
+		)
+		qrs.replayWG.Add(1)
+		go qrs.replay(backlog)
+	}
+	return nil
+}
+
+// replay enqueues the records left in the log which did not fit in the queue,
+// waiting for the queue to have room for them. The records not enqueued before
+// the shutdown stay in the log for the next start.
+func (qrs *queuedRetrySender) replay(records []walRecord) {
+	defer qrs.replayWG.Done()
+	ticker := time.NewTicker(replayInterval)
+	defer ticker.Stop()
+	for len(records) > 0 {
+		select {
+		case <-qrs.retryStopCh:
+			return
+		case <-ticker.C:
+		}
+		for len(records) > 0 && qrs.queue.Produce(records[0]) {
+			records = records[1:]
+		}
+		qrs.recordQueueSize()
+	}
+}
+
+// consumePersisted sends the request stored in the log, and marks it as done
+// unless the sending was interrupted by the shutdown, so that it is sent again
+// after the restart.
+func (qrs *queuedRetrySender) consumePersisted(rec walRecord) {
+	if qrs.stopped() {
+		return
+	}
+
+	data, err := qrs.wal.read(rec)
+	if err == nil {
+		var req request
+		if req, err = qrs.unmarshaler(data); err == nil {
+			_, err = qrs.consumerSender.send(req)
+			if err != nil && qrs.stopped() {
+				return
+			}
+			err = nil
+		}
+	}
+	if err != nil {
+		qrs.logger.Error("Failed to read a batch from the sending_queue. Dropping data.", zap.Error(err))
+	}
+	if err = qrs.wal.ack(rec); err != nil {
+		qrs.logger.Error("Failed to remove a batch from the sending_queue.", zap.Error(err))
+	}
+}
+
+// recordQueueSize records the current size of the queue, only when the
+// requests are queued.
+func (qrs *queuedRetrySender) recordQueueSize() {
+	if qrs.cfg.Enabled {
+		qrs.obsrep.recordQueueSize(qrs.queue.Size(), qrs.queue.Capacity())
+	}
+}
+
+func (qrs *queuedRetrySender) stopped() bool {
+	select {
+	case <-qrs.retryStopCh:
+		return true
+	default:
+		return false
+	}
 }
 
 // send implements the requestSender interface
 func (qrs *queuedRetrySender) send(req request) (int, error) {
+	if qrs.breaker != nil && qrs.breaker.rejecting() {
+		// Fail fast so that the backpressure reaches the receivers, rather than filling the queue.
+		trace.FromContext(req.context()).Annotate(qrs.traceAttributes, "Rejected item, circuit breaker is open.")
+		return req.count(), errCircuitOpen
+	}
+
 	if !qrs.cfg.Enabled {
 		n, err := qrs.consumerSender.send(req)
 		if err != nil {
@@ -152,15 +331,52 @@ func (qrs *queuedRetrySender) send(req request) (int, error) {
 	req.setContext(noCancellationContext{Context: req.context()})
 
 	span := trace.FromContext(req.context())
+	if qrs.wal != nil {
+		return qrs.sendPersisted(req, span)
+	}
 	if !qrs.queue.Produce(req) {
 		qrs.logger.Error(
 			"Dropping data because sending_queue is full. Try increasing queue_size.",
 			zap.Int("dropped_items", req.count()),
 		)
 		span.Annotate(qrs.traceAttributes, "Dropped item, sending_queue is full.")
+		qrs.obsrep.recordEnqueueFailure(req.context(), req.count())
 		return req.count(), errors.New("sending_queue is full")
 	}
 
+	qrs.recordQueueSize()
+	span.Annotate(qrs.traceAttributes, "Enqueued item.")
+	return 0, nil
+}
+
+// sendPersisted stores the request in the log before enqueuing it.
+func (qrs *queuedRetrySender) sendPersisted(req request, span *trace.Span) (int, error) {
+	data, err := req.marshal()
+	if err != nil {
+		qrs.logger.Error("Dropping data because it can't be serialized.", zap.Error(err), zap.Int("dropped_items", req.count()))
+		qrs.obsrep.recordEnqueueFailure(req.context(), req.count())
+		return req.count(), consumererror.Permanent(err)
+	}
+	rec, err := qrs.wal.put(data)
+	if err != nil {
+		qrs.logger.Error("Dropping data because it can't be stored in the sending_queue.", zap.Error(err), zap.Int("dropped_items", req.count()))
+		qrs.obsrep.recordEnqueueFailure(req.context(), req.count())
+		return req.count(), err
+	}
+	if !qrs.queue.Produce(rec) {
+		if err = qrs.wal.ack(rec); err != nil {
+			qrs.logger.Error("Failed to remove a batch from the sending_queue.", zap.Error(err))
+		}
+		qrs.logger.Error(
+			"Dropping data because sending_queue is full. Try increasing queue_size.",
+			zap.Int("dropped_items", req.count()),
+		)
+		span.Annotate(qrs.traceAttributes, "Dropped item, sending_queue is full.")
+		qrs.obsrep.recordEnqueueFailure(req.context(), req.count())
+		return req.count(), errors.New("sending_queue is full")
+	}
+
+	qrs.recordQueueSize()
 	span.Annotate(qrs.traceAttributes, "Enqueued item.")
 	return 0, nil
 }
@@ -169,10 +385,17 @@ func (qrs *queuedRetrySender) send(req request) (int, error) {
 func (qrs *queuedRetrySender) shutdown() {
 	// First stop the retry goroutines, so that unblocks the queue workers.
 	close(qrs.retryStopCh)
+	qrs.replayWG.Wait()
 
 	// Stop the queued sender, this will drain the queue and will call the retry (which is stopped) that will only
-	// try once every request.
+	// try once every request. The requests stored in files are not sent, they are kept for the next start.
 	qrs.queue.Stop()
+
+	if qrs.wal != nil {
+		if err := qrs.wal.close(); err != nil {
+			qrs.logger.Error("Failed to close the sending_queue.", zap.Error(err))
+		}
+	}
 }
 
 // TODO: Clean this by forcing all exporters to return an internal error type that always include the information about retries.
@@ -188,12 +411,76 @@ func NewThrottleRetry(err error, delay time.Duration) error {
 	}
 }
 
+// ErrorClass is the class of an export error, it decides how the failed request is retried.
+type ErrorClass int
+
+const (
+	// ErrorClassTransient is a temporary failure, the request is retried with the exponential backoff.
+	ErrorClassTransient ErrorClass = iota
+	// ErrorClassThrottled is a refusal of the backend asking to slow down, the request is retried after the
+	// delay given by the backend, or with an exponential backoff separate from the one of the transient errors.
+	ErrorClassThrottled
+	// ErrorClassPermanent is a failure that retrying cannot fix, the data is dropped.
+	ErrorClassPermanent
+)
+
+// String returns the name of the error class, as used in the metrics.
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassTransient:
+		return "transient"
+	case ErrorClassThrottled:
+		return "throttled"
+	case ErrorClassPermanent:
+		return "permanent"
+	}
+	return fmt.Sprintf("ErrorClass(%d)", int(c))
+}
+
+// ErrorClassifier returns the class of an export error and, for the throttled errors, the delay requested by the
+// backend before retrying, 0 if the backend did not request any.
+type ErrorClassifier func(err error) (ErrorClass, time.Duration)
+
+// DefaultErrorClassifier classifies the errors created with consumererror.Permanent as permanent, the errors created
+// with NewThrottleRetry as throttled with their delay, and all the other errors as transient.
+func DefaultErrorClassifier(err error) (ErrorClass, time.Duration) {
+	if consumererror.IsPermanent(err) {
+		return ErrorClassPermanent, 0
+	}
+	var throttleErr *throttleRetry
+	if errors.As(err, &throttleErr) {
+		return ErrorClassThrottled, throttleErr.delay
+	}
+	return ErrorClassTransient, 0
+}
+
 type retrySender struct {
+	fullName       string
 	traceAttribute trace.Attribute
 	cfg            RetrySettings
+	classifier     ErrorClassifier
 	nextSender     requestSender
 	stopCh         chan struct{}
 	logger         *zap.Logger
+	obsrep         *queueObsReport
+}
+
+// newBackOff returns an exponential backoff following the retry settings.
+func (rs *retrySender) newBackOff() *backoff.ExponentialBackOff {
+	// Do not use NewExponentialBackOff since it calls Reset and the code here must
+	// call Reset after changing the InitialInterval (this saves an unnecessary call to Now).
+	expBackoff := &backoff.ExponentialBackOff{
+		InitialInterval:     rs.cfg.InitialInterval,
+		RandomizationFactor: rs.cfg.RandomizationFactor,
+		Multiplier:          backoff.DefaultMultiplier,
+		MaxInterval:         rs.cfg.MaxInterval,
+		// The max elapsed time is checked by the sender, across all the error classes.
+		MaxElapsedTime: 0,
+		Stop:           backoff.Stop,
+		Clock:          backoff.SystemClock,
+	}
+	expBackoff.Reset()
+	return expBackoff
 }
 
 // send implements the requestSender interface
@@ -209,18 +496,10 @@ func (rs *retrySender) send(req request) (int, error) {
 		return n, err
 	}
 
-	// Do not use NewExponentialBackOff since it calls Reset and the code here must
-	// call Reset after changing the InitialInterval (this saves an unnecessary call to Now).
-	expBackoff := backoff.ExponentialBackOff{
-		InitialInterval:     rs.cfg.InitialInterval,
-		RandomizationFactor: backoff.DefaultRandomizationFactor,
-		Multiplier:          backoff.DefaultMultiplier,
-		MaxInterval:         rs.cfg.MaxInterval,
-		MaxElapsedTime:      rs.cfg.MaxElapsedTime,
-		Stop:                backoff.Stop,
-		Clock:               backoff.SystemClock,
-	}
-	expBackoff.Reset()
+	startTime := time.Now()
+	// Each retryable class has its own backoff, so that a throttling backend does not make the
+	// retries of the transient errors slower, and the other way around.
+	var transientBackoff, throttledBackoff *backoff.ExponentialBackOff
 	span := trace.FromContext(req.context())
 	retryNum := int64(0)
 	for {
@@ -235,13 +514,17 @@ func (rs *retrySender) send(req request) (int, error) {
 			return droppedItems, nil
 		}
 
+		class, serverDelay := rs.classifier(err)
+
 		// Immediately drop data on permanent errors.
-		if consumererror.IsPermanent(err) {
+		if class == ErrorClassPermanent {
 			rs.logger.Error(
 				"Exporting failed. The error is not retryable. Dropping data.",
 				zap.Error(err),
 				zap.Int("dropped_items", droppedItems),
 			)
+			recordDroppedRequest(req.context(), rs.fullName, class)
+			rs.obsrep.recordDroppedItems(req.context(), droppedItems)
 			return droppedItems, err
 		}
 
@@ -250,34 +533,51 @@ func (rs *retrySender) send(req request) (int, error) {
 			req = req.onPartialError(partialErr)
 		}
 
-		backoffDelay := expBackoff.NextBackOff()
-		if backoffDelay == backoff.Stop {
+		var backoffDelay time.Duration
+		switch {
+		case class == ErrorClassThrottled && serverDelay > 0:
+			backoffDelay = jitter(serverDelay, rs.cfg.RandomizationFactor)
+		case class == ErrorClassThrottled:
+			if throttledBackoff == nil {
+				throttledBackoff = rs.newBackOff()
+			}
+			backoffDelay = throttledBackoff.NextBackOff()
+		default:
+			if transientBackoff == nil {
+				transientBackoff = rs.newBackOff()
+			}
+			backoffDelay = transientBackoff.NextBackOff()
+		}
+
+		if rs.cfg.MaxElapsedTime != 0 && time.Since(startTime)+backoffDelay > rs.cfg.MaxElapsedTime {
 			// throw away the batch
 			err = fmt.Errorf("max elapsed time expired %w", err)
 			rs.logger.Error(
 				"Exporting failed. No more retries left. Dropping data.",
 				zap.Error(err),
+				zap.String("error_class", class.String()),
 				zap.Int("dropped_items", droppedItems),
 			)
+			recordDroppedRequest(req.context(), rs.fullName, class)
+			rs.obsrep.recordDroppedItems(req.context(), req.count())
 			return req.count(), err
 		}
 
-		if throttleErr, isThrottle := err.(*throttleRetry); isThrottle {
-			backoffDelay = max(backoffDelay, throttleErr.delay)
-		}
-
 		backoffDelayStr := backoffDelay.String()
 		span.Annotate(
 			[]trace.Attribute{
 				rs.traceAttribute,
 				trace.StringAttribute("interval", backoffDelayStr),
+				trace.StringAttribute("error_class", class.String()),
 				trace.StringAttribute("error", err.Error())},
 			"Exporting failed. Will retry the request after interval.")
 		rs.logger.Info(
 			"Exporting failed. Will retry the request after interval.",
 			zap.Error(err),
+			zap.String("error_class", class.String()),
 			zap.String("interval", backoffDelayStr),
 		)
+		recordRetry(req.context(), rs.fullName, class)
 		retryNum++
 
 		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
@@ -291,12 +591,13 @@ func (rs *retrySender) send(req request) (int, error) {
 	}
 }
 
-// max returns the larger of x or y.
-func max(x, y time.Duration) time.Duration {
-	if x < y {
-		return y
+// jitter returns the delay requested by the backend increased by a random amount of up to factor times the delay,
+// so that the clients throttled at the same time do not retry all at once. The result is never below the delay.
+func jitter(delay time.Duration, factor float64) time.Duration {
+	if factor <= 0 {
+		return delay
 	}
-	return x
+	return delay + time.Duration(rand.Float64()*factor*float64(delay))
 }
 
 type noCancellationContext struct {
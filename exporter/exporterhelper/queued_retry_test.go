@@ -17,6 +17,10 @@ package exporterhelper
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -24,10 +28,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -178,6 +185,26 @@ func TestQueuedRetry_DoNotPreserveCancellation(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestRetrySettings_Validate(t *testing.T) {
+	rCfg := DefaultRetrySettings()
+	assert.NoError(t, rCfg.Validate())
+	rCfg.RandomizationFactor = 0
+	assert.NoError(t, rCfg.Validate())
+	rCfg.RandomizationFactor = 1
+	assert.NoError(t, rCfg.Validate())
+	rCfg.RandomizationFactor = -0.1
+	assert.Error(t, rCfg.Validate())
+	rCfg.RandomizationFactor = 1.5
+	assert.Error(t, rCfg.Validate())
+
+	// The configs of the exporters embedding the settings are validated with
+	// the collector config.
+	var cfg interface{} = &struct{ RetrySettings }{rCfg}
+	validator, ok := cfg.(configmodels.Validator)
+	require.True(t, ok)
+	assert.Error(t, validator.Validate())
+}
+
 func TestQueuedRetry_MaxElapsedTime(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -253,6 +280,175 @@ func TestQueuedRetry_ThrottleError(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestQueuedRetry_ThrottleErrorBackoff(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	rCfg.MaxElapsedTime = 2 * time.Hour
+	// The throttled errors without a delay from the backend are retried with the exponential backoff.
+	classifier := func(err error) (ErrorClass, time.Duration) {
+		if err.Error() == "throttle error" {
+			return ErrorClassThrottled, 0
+		}
+		return ErrorClassTransient, 0
+	}
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg), WithErrorClassifier(classifier))
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(context.Background(), 2, errors.New("throttle error"))
+	start := time.Now()
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		droppedItems, err := be.sender.send(mockR)
+		require.NoError(t, err)
+		assert.Equal(t, 0, droppedItems)
+	})
+	ocs.awaitAsyncProcessing()
+
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
+func TestQueuedRetry_ErrorClassifier(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	classifier := func(err error) (ErrorClass, time.Duration) {
+		return ErrorClassPermanent, 0
+	}
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg), WithErrorClassifier(classifier))
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The transient error is classified as permanent, the data is dropped without retry.
+	mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		droppedItems, err := be.sender.send(mockR)
+		require.NoError(t, err)
+		assert.Equal(t, 0, droppedItems)
+	})
+	ocs.awaitAsyncProcessing()
+
+	mockR.checkNumRequests(t, 1)
+	ocs.checkSendItemsCount(t, 0)
+	ocs.checkDroppedItemsCount(t, 2)
+}
+
+func TestQueuedRetry_RetryMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = 0
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg))
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	for _, err := range []error{
+		errors.New("transient error"),
+		NewThrottleRetry(errors.New("throttle error"), time.Millisecond),
+		consumererror.Permanent(errors.New("bad data")),
+	} {
+		ocs.run(func() {
+			droppedItems, err := be.sender.send(newMockRequest(context.Background(), 2, err))
+			require.NoError(t, err)
+			assert.Equal(t, 0, droppedItems)
+		})
+		ocs.awaitAsyncProcessing()
+	}
+
+	assertClassCounts := func(name string, want map[string]float64) {
+		rows, err := view.RetrieveData(name)
+		require.NoError(t, err)
+		got := map[string]float64{}
+		for _, row := range rows {
+			for _, tg := range row.Tags {
+				if tg.Key == tagErrorClassKey {
+					got[tg.Value] = row.Data.(*view.SumData).Value
+				}
+			}
+		}
+		assert.Equal(t, want, got)
+	}
+	assertClassCounts("exporter/send_retries", map[string]float64{"transient": 1, "throttled": 1})
+	assertClassCounts("exporter/dropped_requests", map[string]float64{"permanent": 1})
+}
+
+func TestDefaultErrorClassifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantClass ErrorClass
+		wantDelay time.Duration
+	}{
+		{
+			name:      "transient",
+			err:       errors.New("transient error"),
+			wantClass: ErrorClassTransient,
+		},
+		{
+			name:      "throttled",
+			err:       NewThrottleRetry(errors.New("throttle error"), time.Minute),
+			wantClass: ErrorClassThrottled,
+			wantDelay: time.Minute,
+		},
+		{
+			name:      "wrapped_throttled",
+			err:       fmt.Errorf("export failed: %w", NewThrottleRetry(errors.New("throttle error"), time.Second)),
+			wantClass: ErrorClassThrottled,
+			wantDelay: time.Second,
+		},
+		{
+			name:      "permanent",
+			err:       consumererror.Permanent(errors.New("bad data")),
+			wantClass: ErrorClassPermanent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class, delay := DefaultErrorClassifier(tt.err)
+			assert.Equal(t, tt.wantClass, class)
+			assert.Equal(t, tt.wantDelay, delay)
+		})
+	}
+}
+
+func TestErrorClass_String(t *testing.T) {
+	assert.Equal(t, "transient", ErrorClassTransient.String())
+	assert.Equal(t, "throttled", ErrorClassThrottled.String())
+	assert.Equal(t, "permanent", ErrorClassPermanent.String())
+	assert.Equal(t, "ErrorClass(7)", ErrorClass(7).String())
+}
+
+func TestJitter(t *testing.T) {
+	assert.Equal(t, time.Second, jitter(time.Second, 0))
+	for i := 0; i < 100; i++ {
+		delay := jitter(time.Second, 0.5)
+		assert.GreaterOrEqual(t, int64(delay), int64(time.Second))
+		assert.LessOrEqual(t, int64(delay), int64(1500*time.Millisecond))
+	}
+}
+
 func TestQueuedRetry_RetryOnError(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -299,6 +495,49 @@ func TestQueuedRetry_DropOnFull(t *testing.T) {
 	assert.Equal(t, 2, droppedItems)
 }
 
+func TestQueuedRetry_QueueMetrics(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 5
+	rCfg := DefaultRetrySettings()
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg))
+	be.setDataType(configmodels.TracesDataType)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ocs.run(func() {
+		droppedItems, err := be.sender.send(newMockRequest(context.Background(), 2, consumererror.Permanent(errors.New("bad data"))))
+		require.NoError(t, err)
+		assert.Equal(t, 0, droppedItems)
+	})
+	ocs.awaitAsyncProcessing()
+	obsreporttest.CheckExporterDroppedViews(t, "test", "traces", 2)
+	obsreporttest.CheckExporterQueueSizeViews(t, "test", "traces", 0, 5)
+
+	qCfg.QueueSize = 0
+	fullCfg := &configmodels.ExporterSettings{TypeVal: "test", NameVal: "test/full"}
+	full := newBaseExporter(fullCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg))
+	full.setDataType(configmodels.LogsDataType)
+	require.NoError(t, full.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, full.Shutdown(context.Background()))
+	})
+
+	droppedItems, err := full.sender.send(newMockRequest(context.Background(), 3, nil))
+	require.Error(t, err)
+	assert.Equal(t, 3, droppedItems)
+	obsreporttest.CheckExporterEnqueueFailedViews(t, "test/full", "logs", 3)
+	obsreporttest.CheckExporterQueueSizeViews(t, "test/full", "logs", 0, 0)
+}
+
 func TestQueuedRetryHappyPath(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	require.NoError(t, err)
@@ -338,6 +577,123 @@ func TestQueuedRetryHappyPath(t *testing.T) {
 	ocs.checkDroppedItemsCount(t, 0)
 }
 
+func TestQueuedRetry_PersistentQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sending_queue")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.Storage = fileStorage
+	qCfg.Directory = dir
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = time.Hour
+	rCfg.MaxElapsedTime = 2 * time.Hour
+
+	// The batches which can't be sent before the shutdown are kept in the queue.
+	var attempts int64
+	te, err := NewTraceExporter(defaultExporterCfg, zap.NewNop(), func(context.Context, pdata.Traces) (int, error) {
+		atomic.AddInt64(&attempts, 1)
+		return 0, errors.New("transient error")
+	}, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource()))
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&attempts) == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	// They are sent once the exporter restarts.
+	var lock sync.Mutex
+	var received []pdata.Traces
+	te, err = NewTraceExporter(defaultExporterCfg, zap.NewNop(), func(_ context.Context, td pdata.Traces) (int, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, td)
+		return 0, nil
+	}, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(received) == 2
+	}, time.Second, time.Millisecond)
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Equal(t, testdata.GenerateTraceDataOneSpan(), received[0])
+	assert.Equal(t, testdata.GenerateTraceDataTwoSpansSameResource(), received[1])
+
+	// The sent batches are removed from the queue.
+	w, records, err := openWAL(filepath.Join(dir, "test"))
+	require.NoError(t, err)
+	defer w.close()
+	assert.Empty(t, records)
+}
+
+func TestQueuedRetry_PersistentQueueLargerThanQueueSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sending_queue")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 10
+	qCfg.Storage = fileStorage
+	qCfg.Directory = dir
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = time.Hour
+	rCfg.MaxElapsedTime = 2 * time.Hour
+
+	// Leave more batches in the log than the queue_size of the next start.
+	var attempts int64
+	te, err := NewTraceExporter(defaultExporterCfg, zap.NewNop(), func(context.Context, pdata.Traces) (int, error) {
+		atomic.AddInt64(&attempts, 1)
+		return 0, errors.New("transient error")
+	}, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	}
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&attempts) == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	// The queue keeps its queue_size, and all the batches are sent as it drains.
+	qCfg.QueueSize = 2
+	release := make(chan struct{})
+	var sent int64
+	te, err = NewTraceExporter(defaultExporterCfg, zap.NewNop(), func(context.Context, pdata.Traces) (int, error) {
+		<-release
+		atomic.AddInt64(&sent, 1)
+		return 0, nil
+	}, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	qrs := te.(*traceExporter).qrSender
+	assert.Equal(t, 2, qrs.queue.Capacity())
+	assert.LessOrEqual(t, qrs.queue.Size(), 2)
+	close(release)
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&sent) == 5 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, 2, qrs.queue.Capacity())
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	w, records, err := openWAL(filepath.Join(dir, "test"))
+	require.NoError(t, err)
+	defer w.close()
+	assert.Empty(t, records)
+}
+
+func TestQueuedRetry_InvalidStorage(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.Storage = "redis"
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithQueue(qCfg))
+	assert.EqualError(t, be.Start(context.Background(), componenttest.NewNopHost()), `sending_queue storage must be "memory" or "file", got "redis"`)
+
+	qCfg.Storage = fileStorage
+	be = newBaseExporter(defaultExporterCfg, zap.NewNop(), WithQueue(qCfg))
+	assert.EqualError(t, be.Start(context.Background(), componenttest.NewNopHost()), "sending_queue directory is required with the file storage")
+}
+
 func TestNoCancellationContext(t *testing.T) {
 	deadline := time.Now().Add(1 * time.Second)
 	ctx, cancelFunc := context.WithDeadline(context.Background(), deadline)
@@ -370,6 +726,10 @@ func (mer *mockErrorRequest) count() int {
 	return 7
 }
 
+func (mer *mockErrorRequest) marshal() ([]byte, error) {
+	return nil, errors.New("not serializable")
+}
+
 func newErrorRequest(ctx context.Context) request {
 	return &mockErrorRequest{
 		baseRequest: baseRequest{ctx: ctx},
@@ -416,6 +776,10 @@ func (m *mockRequest) count() int {
 	return m.cnt
 }
 
+func (m *mockRequest) marshal() ([]byte, error) {
+	return nil, errors.New("not serializable")
+}
+
 func newMockRequest(ctx context.Context, cnt int, consumeError error) *mockRequest {
 	return &mockRequest{
 		baseRequest:  baseRequest{ctx: ctx},
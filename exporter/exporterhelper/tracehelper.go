@@ -57,6 +57,21 @@ func (req *tracesRequest) count() int {
 	return req.td.SpanCount()
 }
 
+func (req *tracesRequest) marshal() ([]byte, error) {
+	return req.td.ToOtlpProtoBytes()
+}
+
+// newTracesRequestUnmarshaler returns the function rebuilding the requests stored in the persistent queue.
+func newTracesRequestUnmarshaler(exporterName string, pusher PushTraces) requestUnmarshaler {
+	return func(data []byte) (request, error) {
+		td := pdata.NewTraces()
+		if err := td.FromOtlpProtoBytes(data); err != nil {
+			return nil, err
+		}
+		return newTracesRequest(obsreport.ExporterContext(context.Background(), exporterName), td, pusher), nil
+	}
+}
+
 type traceExporter struct {
 	*baseExporter
 	pusher PushTraces
@@ -90,6 +105,8 @@ func NewTraceExporter(
 	}
 
 	be := newBaseExporter(cfg, logger, options...)
+	be.setDataType(configmodels.TracesDataType)
+	be.setRequestUnmarshaler(newTracesRequestUnmarshaler(cfg.Name(), pusher))
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &tracesExporterWithObservability{
 			obsrep:     obsreport.NewExporterObsReport(configtelemetry.GetMetricsLevelFlagValue(), cfg.Name()),
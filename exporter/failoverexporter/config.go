@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for Failover exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// Exporters is the list of the exporters the data is sent to by order of priority, each item maps the type[/name]
+	// of an exporter to its configuration, e.g. "otlp/primary: {endpoint: primary:4317}". The exporters are created
+	// by the failover exporter and must not be listed in the pipelines.
+	Exporters []map[string]interface{} `mapstructure:"exporters"`
+
+	// FailureThreshold is the number of consecutive failed exports after which the data is sent to the next exporter.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// RecoveryInterval is the time after which an exporter the data was switched away from is tried again, the data is
+	// sent to it again once an export succeeds.
+	RecoveryInterval time.Duration `mapstructure:"recovery_interval"`
+}
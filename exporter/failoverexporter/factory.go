@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "failover"
+
+	defaultFailureThreshold = 3
+	defaultRecoveryInterval = time.Minute
+)
+
+// NewFactory creates a factory for Failover exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithTraces(createTraceExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		RetrySettings:    exporterhelper.DefaultRetrySettings(),
+		QueueSettings:    exporterhelper.DefaultQueueSettings(),
+		FailureThreshold: defaultFailureThreshold,
+		RecoveryInterval: defaultRecoveryInterval,
+	}
+}
+
+func createTraceExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.TracesExporter, error) {
+	fCfg := cfg.(*Config)
+	f, err := newFailover(fCfg, params, configmodels.TracesDataType)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTraceExporter(
+		cfg,
+		params.Logger,
+		f.pushTraceData,
+		exporterhelper.WithStart(f.start),
+		exporterhelper.WithShutdown(f.shutdown),
+		// explicitly disable since the exporters enforce their own timeout.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(fCfg.RetrySettings),
+		exporterhelper.WithQueue(fCfg.QueueSettings))
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	fCfg := cfg.(*Config)
+	f, err := newFailover(fCfg, params, configmodels.MetricsDataType)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		params.Logger,
+		f.pushMetricsData,
+		exporterhelper.WithStart(f.start),
+		exporterhelper.WithShutdown(f.shutdown),
+		// explicitly disable since the exporters enforce their own timeout.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(fCfg.RetrySettings),
+		exporterhelper.WithQueue(fCfg.QueueSettings))
+}
+
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.LogsExporter, error) {
+	fCfg := cfg.(*Config)
+	f, err := newFailover(fCfg, params, configmodels.LogsDataType)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		params.Logger,
+		f.pushLogsData,
+		exporterhelper.WithStart(f.start),
+		exporterhelper.WithShutdown(f.shutdown),
+		// explicitly disable since the exporters enforce their own timeout.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(fCfg.RetrySettings),
+		exporterhelper.WithQueue(fCfg.QueueSettings))
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateExporters(t *testing.T) {
+	factory := NewFactory()
+	cfg := newTestConfig("otlp/primary", "otlp/standby")
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	te, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, te)
+
+	me, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, me)
+
+	le, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, le)
+}
+
+func TestCreateExporters_NoExporters(t *testing.T) {
+	factory := NewFactory()
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	_, err := factory.CreateTracesExporter(context.Background(), params, factory.CreateDefaultConfig())
+	assert.Equal(t, errNoExporters, err)
+}
@@ -0,0 +1,321 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+var (
+	errNoExporters             = errors.New("at least one exporter must be specified")
+	errInvalidFailureThreshold = errors.New("failure_threshold must be positive")
+	errInvalidRecoveryInterval = errors.New("recovery_interval must be positive")
+	errMemberQueueEnabled      = errors.New("sending_queue must be disabled, the failures would not be reported to the failover exporter")
+	errMemberRetryEnabled      = errors.New("retry_on_failure must be disabled, it would delay the switch to the next exporter")
+)
+
+// member is an exporter of the failover group.
+type member struct {
+	typeStr  configmodels.Type
+	name     string
+	config   map[string]interface{}
+	exporter component.Exporter
+
+	// failures is the number of consecutive failed exports.
+	failures int
+	// downSince is when the data was switched away from the exporter, or when it was last tried to recover, zero if
+	// the exporter is healthy.
+	downSince time.Time
+}
+
+// failover sends the data to the first healthy exporter of an ordered group. The data is switched to the next exporter
+// after failure_threshold consecutive failed exports, and back to a higher priority exporter once an export to it
+// succeeds, which is tried every recovery_interval.
+type failover struct {
+	config   *Config
+	params   component.ExporterCreateParams
+	dataType configmodels.DataType
+	logger   *zap.Logger
+	now      func() time.Time
+
+	mu      sync.Mutex
+	members []*member
+	// active is the index of the exporter the data is sent to.
+	active int
+}
+
+func newFailover(cfg *Config, params component.ExporterCreateParams, dataType configmodels.DataType) (*failover, error) {
+	if len(cfg.Exporters) == 0 {
+		return nil, errNoExporters
+	}
+	if cfg.FailureThreshold <= 0 {
+		return nil, errInvalidFailureThreshold
+	}
+	if cfg.RecoveryInterval <= 0 {
+		return nil, errInvalidRecoveryInterval
+	}
+
+	f := &failover{
+		config:   cfg,
+		params:   params,
+		dataType: dataType,
+		logger:   params.Logger,
+		now:      time.Now,
+	}
+	names := map[string]bool{}
+	for i, item := range cfg.Exporters {
+		if len(item) != 1 {
+			return nil, fmt.Errorf("the exporter #%d must be a single type[/name] key with the exporter configuration", i+1)
+		}
+		for key, value := range item {
+			typeStr, name, err := config.DecodeTypeAndName(key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exporter key %q: %w", key, err)
+			}
+			if names[name] {
+				return nil, fmt.Errorf("duplicate exporter %q", name)
+			}
+			names[name] = true
+			f.members = append(f.members, &member{
+				typeStr: typeStr,
+				name:    name,
+				config:  cast.ToStringMap(value),
+			})
+		}
+	}
+	return f, nil
+}
+
+// start creates and starts the exporters of the group.
+func (f *failover) start(ctx context.Context, host component.Host) error {
+	for _, m := range f.members {
+		exp, err := f.createExporter(ctx, host, m)
+		if err != nil {
+			return fmt.Errorf("failed to create the exporter %q: %w", m.name, err)
+		}
+		if err = exp.Start(ctx, host); err != nil {
+			return fmt.Errorf("failed to start the exporter %q: %w", m.name, err)
+		}
+		m.exporter = exp
+	}
+	return nil
+}
+
+func (f *failover) createExporter(ctx context.Context, host component.Host, m *member) (component.Exporter, error) {
+	factory, ok := host.GetFactory(component.KindExporter, m.typeStr).(component.ExporterFactory)
+	if !ok {
+		return nil, fmt.Errorf("unknown exporter type %q", m.typeStr)
+	}
+
+	// The configuration is decoded as the exporters of the configuration file.
+	cfg := factory.CreateDefaultConfig()
+	cfg.SetName(m.name)
+	// The exporters of the group must report their failures right away, so their queue and retries are disabled.
+	queueSettings, retrySettings := helperSettings(cfg)
+	if queueSettings != nil {
+		queueSettings.Enabled = false
+	}
+	if retrySettings != nil {
+		retrySettings.Enabled = false
+	}
+	v := config.NewViper()
+	if err := v.MergeConfigMap(m.config); err != nil {
+		return nil, err
+	}
+	unmarshal := v.UnmarshalExact
+	if fu, ok := factory.(component.ConfigUnmarshaler); ok {
+		unmarshal = func(intoCfg interface{}, _ ...viper.DecoderConfigOption) error {
+			return fu.Unmarshal(v, intoCfg)
+		}
+	}
+	if err := unmarshal(cfg); err != nil {
+		return nil, err
+	}
+	if queueSettings != nil && queueSettings.Enabled {
+		return nil, errMemberQueueEnabled
+	}
+	if retrySettings != nil && retrySettings.Enabled {
+		return nil, errMemberRetryEnabled
+	}
+
+	params := f.params
+	params.Logger = f.logger.With(zap.String("failover_exporter", m.name))
+	switch f.dataType {
+	case configmodels.TracesDataType:
+		return factory.CreateTracesExporter(ctx, params, cfg)
+	case configmodels.MetricsDataType:
+		return factory.CreateMetricsExporter(ctx, params, cfg)
+	default:
+		return factory.CreateLogsExporter(ctx, params, cfg)
+	}
+}
+
+// helperSettings returns the exporterhelper queue and retry settings embedded in the exporter configuration, nil if
+// the configuration does not have them.
+func helperSettings(cfg configmodels.Exporter) (*exporterhelper.QueueSettings, *exporterhelper.RetrySettings) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, nil
+	}
+	v = v.Elem()
+	var queueSettings *exporterhelper.QueueSettings
+	var retrySettings *exporterhelper.RetrySettings
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanAddr() || !field.Addr().CanInterface() {
+			continue
+		}
+		switch settings := field.Addr().Interface().(type) {
+		case *exporterhelper.QueueSettings:
+			queueSettings = settings
+		case *exporterhelper.RetrySettings:
+			retrySettings = settings
+		}
+	}
+	return queueSettings, retrySettings
+}
+
+// shutdown stops the exporters of the group.
+func (f *failover) shutdown(ctx context.Context) error {
+	var errs []error
+	for _, m := range f.members {
+		if m.exporter == nil {
+			continue
+		}
+		if err := m.exporter.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.CombineErrors(errs)
+}
+
+func (f *failover) pushTraceData(ctx context.Context, td pdata.Traces) (int, error) {
+	err := f.send(func(exp component.Exporter) error {
+		return exp.(component.TracesExporter).ConsumeTraces(ctx, td)
+	})
+	if err != nil {
+		return td.SpanCount(), err
+	}
+	return 0, nil
+}
+
+func (f *failover) pushMetricsData(ctx context.Context, md pdata.Metrics) (int, error) {
+	err := f.send(func(exp component.Exporter) error {
+		return exp.(component.MetricsExporter).ConsumeMetrics(ctx, md)
+	})
+	if err != nil {
+		_, dataPoints := md.MetricAndDataPointCount()
+		return dataPoints, err
+	}
+	return 0, nil
+}
+
+func (f *failover) pushLogsData(ctx context.Context, ld pdata.Logs) (int, error) {
+	err := f.send(func(exp component.Exporter) error {
+		return exp.(component.LogsExporter).ConsumeLogs(ctx, ld)
+	})
+	if err != nil {
+		return ld.LogRecordCount(), err
+	}
+	return 0, nil
+}
+
+// send exports the data with the exporter chosen by the state of the group, and with the next exporters if the failure
+// switches the data to them.
+func (f *failover) send(export func(exp component.Exporter) error) error {
+	idx := f.target()
+	for {
+		err := export(f.members[idx].exporter)
+		next, retry := f.report(idx, err)
+		if !retry {
+			return err
+		}
+		idx = next
+	}
+}
+
+// target returns the index of the exporter to export to: a higher priority exporter due to be tried again, or the
+// active exporter.
+func (f *failover) target() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.now()
+	for i := 0; i < f.active; i++ {
+		m := f.members[i]
+		if now.Sub(m.downSince) >= f.config.RecoveryInterval {
+			// Only one export tries the exporter per interval.
+			m.downSince = now
+			return i
+		}
+	}
+	return f.active
+}
+
+// report updates the state of the group with the result of an export to the exporter of the index, and returns the
+// index of the exporter to export to again if the data is switched to another exporter.
+func (f *failover) report(idx int, err error) (int, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m := f.members[idx]
+
+	if err == nil {
+		m.failures = 0
+		m.downSince = time.Time{}
+		if idx < f.active {
+			f.logger.Info("Switching back to the recovered exporter.", zap.String("exporter", m.name))
+			f.active = idx
+		}
+		return 0, false
+	}
+	// The data itself is invalid, another exporter would reject it as well.
+	if consumererror.IsPermanent(err) {
+		return 0, false
+	}
+
+	if idx < f.active {
+		// The recovery failed, the data is exported to the active exporter instead.
+		return f.active, true
+	}
+	m.failures++
+	if idx != f.active || m.failures < f.config.FailureThreshold || idx == len(f.members)-1 {
+		return 0, false
+	}
+
+	m.failures = 0
+	m.downSince = f.now()
+	f.active = idx + 1
+	f.logger.Warn("Switching to the next exporter after consecutive failures.",
+		zap.String("from", m.name),
+		zap.String("to", f.members[f.active].name),
+		zap.Int("failures", f.config.FailureThreshold),
+		zap.Error(err))
+	return f.active, true
+}
@@ -0,0 +1,487 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+const mockTypeStr = "mock"
+
+type mockConfig struct {
+	configmodels.ExporterSettings `mapstructure:",squash"`
+	Endpoint                      string `mapstructure:"endpoint"`
+}
+
+// mockExporter is an exporter of the group failing with err.
+type mockExporter struct {
+	config *mockConfig
+
+	mu      sync.Mutex
+	err     error
+	started bool
+	stopped bool
+	batches int
+}
+
+func (e *mockExporter) setError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+func (e *mockExporter) exported() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.batches
+}
+
+func (e *mockExporter) consume() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return e.err
+	}
+	e.batches++
+	return nil
+}
+
+func (e *mockExporter) Start(context.Context, component.Host) error {
+	e.started = true
+	return nil
+}
+
+func (e *mockExporter) Shutdown(context.Context) error {
+	e.stopped = true
+	return nil
+}
+
+func (e *mockExporter) ConsumeTraces(context.Context, pdata.Traces) error {
+	return e.consume()
+}
+
+func (e *mockExporter) ConsumeMetrics(context.Context, pdata.Metrics) error {
+	return e.consume()
+}
+
+func (e *mockExporter) ConsumeLogs(context.Context, pdata.Logs) error {
+	return e.consume()
+}
+
+// mockFactory creates the mock exporters and keeps them by name.
+type mockFactory struct {
+	component.ExporterFactory
+	exporters map[string]*mockExporter
+}
+
+func newMockFactory() *mockFactory {
+	f := &mockFactory{exporters: map[string]*mockExporter{}}
+	create := func(cfg configmodels.Exporter) *mockExporter {
+		exp := &mockExporter{config: cfg.(*mockConfig)}
+		f.exporters[cfg.Name()] = exp
+		return exp
+	}
+	f.ExporterFactory = exporterhelper.NewFactory(
+		mockTypeStr,
+		func() configmodels.Exporter {
+			return &mockConfig{ExporterSettings: configmodels.ExporterSettings{TypeVal: mockTypeStr, NameVal: mockTypeStr}}
+		},
+		exporterhelper.WithTraces(func(_ context.Context, _ component.ExporterCreateParams, cfg configmodels.Exporter) (component.TracesExporter, error) {
+			return create(cfg), nil
+		}),
+		exporterhelper.WithLogs(func(_ context.Context, _ component.ExporterCreateParams, cfg configmodels.Exporter) (component.LogsExporter, error) {
+			return create(cfg), nil
+		}))
+	return f
+}
+
+// factoryHost is a host providing the factories of the exporters.
+type factoryHost struct {
+	component.Host
+	factories map[configmodels.Type]component.ExporterFactory
+}
+
+func (h *factoryHost) GetFactory(kind component.Kind, componentType configmodels.Type) component.Factory {
+	if kind != component.KindExporter {
+		return nil
+	}
+	factory, ok := h.factories[componentType]
+	if !ok {
+		return nil
+	}
+	return factory
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestConfig(exporters ...string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	for _, name := range exporters {
+		cfg.Exporters = append(cfg.Exporters, map[string]interface{}{
+			name: map[interface{}]interface{}{"endpoint": name + ":4317"},
+		})
+	}
+	return cfg
+}
+
+func startFailover(t *testing.T, cfg *Config, dataType configmodels.DataType) (*failover, *mockFactory, *fakeClock) {
+	f, err := newFailover(cfg, component.ExporterCreateParams{Logger: zap.NewNop()}, dataType)
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	f.now = clock.Now
+
+	factory := newMockFactory()
+	host := &factoryHost{
+		Host:      componenttest.NewNopHost(),
+		factories: map[configmodels.Type]component.ExporterFactory{mockTypeStr: factory},
+	}
+	require.NoError(t, f.start(context.Background(), host))
+	return f, factory, clock
+}
+
+func TestFailover_Start(t *testing.T) {
+	f, factory, _ := startFailover(t, newTestConfig("mock/primary", "mock/standby"), configmodels.TracesDataType)
+
+	require.Len(t, factory.exporters, 2)
+	primary := factory.exporters["mock/primary"]
+	assert.Equal(t, "mock/primary:4317", primary.config.Endpoint)
+	assert.True(t, primary.started)
+	assert.True(t, factory.exporters["mock/standby"].started)
+
+	require.NoError(t, f.shutdown(context.Background()))
+	assert.True(t, primary.stopped)
+	assert.True(t, factory.exporters["mock/standby"].stopped)
+}
+
+func TestFailover_StartErrors(t *testing.T) {
+	host := &factoryHost{
+		Host:      componenttest.NewNopHost(),
+		factories: map[configmodels.Type]component.ExporterFactory{mockTypeStr: newMockFactory()},
+	}
+	tests := []struct {
+		name     string
+		cfg      *Config
+		dataType configmodels.DataType
+		err      string
+	}{
+		{
+			name:     "unknown_type",
+			cfg:      newTestConfig("mock/primary", "otlp/standby"),
+			dataType: configmodels.TracesDataType,
+			err:      `failed to create the exporter "otlp/standby": unknown exporter type "otlp"`,
+		},
+		{
+			name: "invalid_config",
+			cfg: &Config{
+				Exporters: []map[string]interface{}{{"mock": map[string]interface{}{"endpont": "typo"}}},
+			},
+			dataType: configmodels.LogsDataType,
+			err:      `failed to create the exporter "mock": 1 error(s) decoding:`,
+		},
+		{
+			name:     "unsupported_data_type",
+			cfg:      newTestConfig("mock"),
+			dataType: configmodels.MetricsDataType,
+			err:      `failed to create the exporter "mock": telemetry type is not supported`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.FailureThreshold = defaultFailureThreshold
+			tt.cfg.RecoveryInterval = defaultRecoveryInterval
+			f, err := newFailover(tt.cfg, component.ExporterCreateParams{Logger: zap.NewNop()}, tt.dataType)
+			require.NoError(t, err)
+			err = f.start(context.Background(), host)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestNewFailover_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "no_exporters",
+			modify: func(cfg *Config) { cfg.Exporters = nil },
+			err:    errNoExporters.Error(),
+		},
+		{
+			name:   "failure_threshold",
+			modify: func(cfg *Config) { cfg.FailureThreshold = 0 },
+			err:    errInvalidFailureThreshold.Error(),
+		},
+		{
+			name:   "recovery_interval",
+			modify: func(cfg *Config) { cfg.RecoveryInterval = 0 },
+			err:    errInvalidRecoveryInterval.Error(),
+		},
+		{
+			name: "several_keys",
+			modify: func(cfg *Config) {
+				cfg.Exporters = []map[string]interface{}{{"mock/a": nil, "mock/b": nil}}
+			},
+			err: "the exporter #1 must be a single type[/name] key with the exporter configuration",
+		},
+		{
+			name:   "invalid_key",
+			modify: func(cfg *Config) { cfg.Exporters = []map[string]interface{}{{"/a": nil}} },
+			err:    `invalid exporter key "/a": type/name key must have the type part`,
+		},
+		{
+			name: "duplicate",
+			modify: func(cfg *Config) {
+				cfg.Exporters = []map[string]interface{}{{"mock/a": nil}, {"mock/a": nil}}
+			},
+			err: `duplicate exporter "mock/a"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig("mock/primary")
+			tt.modify(cfg)
+			_, err := newFailover(cfg, component.ExporterCreateParams{Logger: zap.NewNop()}, configmodels.TracesDataType)
+			require.Error(t, err)
+			assert.Equal(t, tt.err, err.Error())
+		})
+	}
+}
+
+func TestFailover_SwitchAndRecover(t *testing.T) {
+	cfg := newTestConfig("mock/primary", "mock/standby")
+	cfg.FailureThreshold = 2
+	cfg.RecoveryInterval = time.Minute
+	f, factory, clock := startFailover(t, cfg, configmodels.TracesDataType)
+	primary := factory.exporters["mock/primary"]
+	standby := factory.exporters["mock/standby"]
+	td := testdata.GenerateTraceDataOneSpan()
+
+	dropped, err := f.pushTraceData(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, 1, primary.exported())
+
+	// The data is switched to the standby exporter on the second consecutive failure.
+	primary.setError(errors.New("unavailable"))
+	dropped, err = f.pushTraceData(context.Background(), td)
+	require.Error(t, err)
+	assert.Equal(t, 1, dropped)
+	_, err = f.pushTraceData(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 1, standby.exported())
+	assert.Equal(t, 1, f.active)
+
+	_, err = f.pushTraceData(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 2, standby.exported())
+
+	// The primary exporter is tried again after the recovery interval, the data goes to the standby one meanwhile.
+	clock.now = clock.now.Add(time.Minute)
+	_, err = f.pushTraceData(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 3, standby.exported())
+	assert.Equal(t, 1, f.active)
+
+	clock.now = clock.now.Add(30 * time.Second)
+	primary.setError(nil)
+	_, err = f.pushTraceData(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 4, standby.exported())
+
+	clock.now = clock.now.Add(30 * time.Second)
+	_, err = f.pushTraceData(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 2, primary.exported())
+	assert.Equal(t, 0, f.active)
+
+	_, err = f.pushTraceData(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 3, primary.exported())
+	assert.Equal(t, 4, standby.exported())
+}
+
+func TestFailover_FailuresAreConsecutive(t *testing.T) {
+	cfg := newTestConfig("mock/primary", "mock/standby")
+	cfg.FailureThreshold = 2
+	f, factory, _ := startFailover(t, cfg, configmodels.LogsDataType)
+	primary := factory.exporters["mock/primary"]
+	ld := testdata.GenerateLogDataOneLog()
+
+	for i := 0; i < 3; i++ {
+		primary.setError(errors.New("unavailable"))
+		dropped, err := f.pushLogsData(context.Background(), ld)
+		require.Error(t, err)
+		assert.Equal(t, 1, dropped)
+		primary.setError(nil)
+		_, err = f.pushLogsData(context.Background(), ld)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0, f.active)
+	assert.Equal(t, 0, factory.exporters["mock/standby"].exported())
+}
+
+func TestFailover_PermanentError(t *testing.T) {
+	cfg := newTestConfig("mock/primary", "mock/standby")
+	cfg.FailureThreshold = 1
+	f, factory, _ := startFailover(t, cfg, configmodels.TracesDataType)
+	factory.exporters["mock/primary"].setError(consumererror.Permanent(errors.New("invalid span")))
+
+	_, err := f.pushTraceData(context.Background(), testdata.GenerateTraceDataOneSpan())
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 0, f.active)
+	assert.Equal(t, 0, factory.exporters["mock/standby"].exported())
+}
+
+func TestFailover_LastExporter(t *testing.T) {
+	cfg := newTestConfig("mock/primary", "mock/standby")
+	cfg.FailureThreshold = 1
+	f, factory, _ := startFailover(t, cfg, configmodels.TracesDataType)
+	factory.exporters["mock/primary"].setError(errors.New("unavailable"))
+	factory.exporters["mock/standby"].setError(errors.New("unavailable"))
+
+	// The failure switches the data to the standby exporter, which fails as well.
+	_, err := f.pushTraceData(context.Background(), testdata.GenerateTraceDataOneSpan())
+	require.Error(t, err)
+	assert.Equal(t, 1, f.active)
+
+	// The data stays on the last exporter.
+	_, err = f.pushTraceData(context.Background(), testdata.GenerateTraceDataOneSpan())
+	require.Error(t, err)
+	assert.Equal(t, 1, f.active)
+}
+
+const helperTypeStr = "helper"
+
+// helperConfig is the configuration of an exporter built with exporterhelper, with a queue and retries enabled by
+// default.
+type helperConfig struct {
+	configmodels.ExporterSettings `mapstructure:",squash"`
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+}
+
+// newHelperFactory creates exporterhelper exporters failing with the error of their name, and sends the traces
+// exported successfully to the channel.
+func newHelperFactory(errs map[string]error, exported chan<- string) component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		helperTypeStr,
+		func() configmodels.Exporter {
+			return &helperConfig{
+				ExporterSettings: configmodels.ExporterSettings{TypeVal: helperTypeStr, NameVal: helperTypeStr},
+				QueueSettings:    exporterhelper.DefaultQueueSettings(),
+				RetrySettings:    exporterhelper.DefaultRetrySettings(),
+			}
+		},
+		exporterhelper.WithTraces(func(_ context.Context, params component.ExporterCreateParams, cfg configmodels.Exporter) (component.TracesExporter, error) {
+			hCfg := cfg.(*helperConfig)
+			return exporterhelper.NewTraceExporter(cfg, params.Logger, func(context.Context, pdata.Traces) (int, error) {
+				if err := errs[cfg.Name()]; err != nil {
+					return 0, err
+				}
+				exported <- cfg.Name()
+				return 0, nil
+			}, exporterhelper.WithQueue(hCfg.QueueSettings), exporterhelper.WithRetry(hCfg.RetrySettings))
+		}))
+}
+
+func TestFailover_HelperExporters(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.FailureThreshold = 1
+	cfg.Exporters = []map[string]interface{}{{"helper/primary": nil}, {"helper/standby": nil}}
+	f, err := newFailover(cfg, component.ExporterCreateParams{Logger: zap.NewNop()}, configmodels.TracesDataType)
+	require.NoError(t, err)
+
+	exported := make(chan string, 1)
+	errs := map[string]error{"helper/primary": errors.New("unavailable")}
+	host := &factoryHost{
+		Host:      componenttest.NewNopHost(),
+		factories: map[configmodels.Type]component.ExporterFactory{helperTypeStr: newHelperFactory(errs, exported)},
+	}
+	require.NoError(t, f.start(context.Background(), host))
+	defer f.shutdown(context.Background())
+
+	// The queue and the retries of the exporters are disabled, so the failure of the primary exporter switches the
+	// data to the standby exporter.
+	dropped, err := f.pushTraceData(context.Background(), testdata.GenerateTraceDataOneSpan())
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	select {
+	case name := <-exported:
+		assert.Equal(t, "helper/standby", name)
+	case <-time.After(time.Second):
+		t.Fatal("the traces were not exported to the standby exporter")
+	}
+	assert.Equal(t, 1, f.active)
+}
+
+func TestFailover_HelperExportersQueueOrRetryEnabled(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		err    error
+	}{
+		{
+			name:   "sending_queue",
+			config: map[string]interface{}{"sending_queue": map[string]interface{}{"enabled": true}},
+			err:    errMemberQueueEnabled,
+		},
+		{
+			name:   "retry_on_failure",
+			config: map[string]interface{}{"retry_on_failure": map[string]interface{}{"enabled": true}},
+			err:    errMemberRetryEnabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Exporters = []map[string]interface{}{{"helper": tt.config}}
+			f, err := newFailover(cfg, component.ExporterCreateParams{Logger: zap.NewNop()}, configmodels.TracesDataType)
+			require.NoError(t, err)
+			host := &factoryHost{
+				Host:      componenttest.NewNopHost(),
+				factories: map[configmodels.Type]component.ExporterFactory{helperTypeStr: newHelperFactory(nil, nil)},
+			}
+			err = f.start(context.Background(), host)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.err))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for InfluxDB exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// APIVersion is the version of the write API, "v1" for the /write endpoint of InfluxDB 1.x or "v2" for the
+	// /api/v2/write endpoint of InfluxDB 2.x and InfluxDB Cloud.
+	APIVersion string `mapstructure:"api_version"`
+
+	// Database and RetentionPolicy are the database and the retention policy the points are written to with the v1
+	// API, the default retention policy of the database is used if RetentionPolicy is empty.
+	Database        string `mapstructure:"database"`
+	RetentionPolicy string `mapstructure:"retention_policy"`
+	// Username and Password are the credentials of the InfluxDB user with the v1 API, the requests are not
+	// authenticated if Username is empty.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// Org and Bucket are the organization and the bucket the points are written to with the v2 API.
+	Org    string `mapstructure:"org"`
+	Bucket string `mapstructure:"bucket"`
+	// Token is the API token authenticating the requests with the v2 API.
+	Token string `mapstructure:"token"`
+
+	// MaxBatchSize is the maximum number of points written with a single request, the points of a larger batch of
+	// metrics are split across several requests.
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	headerAuthorization      = "Authorization"
+	headerContentType        = "Content-Type"
+	maxHTTPResponseReadBytes = 64 * 1024
+)
+
+type influxdbExporter struct {
+	config *Config
+	client *http.Client
+	// writeURL is the URL of the write endpoint of the configured API version, with the precision and the
+	// destination of the points as query parameters.
+	writeURL string
+	logger   *zap.Logger
+}
+
+func newExporter(cfg *Config, logger *zap.Logger) (*influxdbExporter, error) {
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &influxdbExporter{
+		config:   cfg,
+		client:   client,
+		writeURL: writeURL(cfg),
+		logger:   logger,
+	}, nil
+}
+
+func writeURL(cfg *Config) string {
+	params := url.Values{}
+	params.Set("precision", "ns")
+	path := "/api/v2/write"
+	if cfg.APIVersion == apiVersion1 {
+		path = "/write"
+		params.Set("db", cfg.Database)
+		if cfg.RetentionPolicy != "" {
+			params.Set("rp", cfg.RetentionPolicy)
+		}
+	} else {
+		params.Set("org", cfg.Org)
+		params.Set("bucket", cfg.Bucket)
+	}
+	return strings.TrimSuffix(cfg.Endpoint, "/") + path + "?" + params.Encode()
+}
+
+// pushMetricsData writes the data points in batches of at most MaxBatchSize points. Writing a point again overwrites
+// it, so the batches written before a failure are written again when the metrics are retried.
+func (e *influxdbExporter) pushMetricsData(ctx context.Context, md pdata.Metrics) (int, error) {
+	lines, dropped := metricsToLines(md)
+	if dropped > 0 {
+		e.logger.Debug("Dropped data points that cannot be converted to the line protocol.", zap.Int("dropped", dropped))
+	}
+	for start := 0; start < len(lines); start += e.config.MaxBatchSize {
+		end := start + e.config.MaxBatchSize
+		if end > len(lines) {
+			end = len(lines)
+		}
+		if err := e.write(ctx, lines[start:end]); err != nil {
+			return md.MetricCount(), err
+		}
+	}
+	return 0, nil
+}
+
+func (e *influxdbExporter) write(ctx context.Context, lines []string) error {
+	body := []byte(strings.Join(lines, "\n"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.writeURL, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set(headerContentType, "text/plain; charset=utf-8")
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, v)
+	}
+	switch {
+	case e.config.APIVersion == apiVersion2 && e.config.Token != "":
+		req.Header.Set(headerAuthorization, "Token "+e.config.Token)
+	case e.config.APIVersion == apiVersion1 && e.config.Username != "":
+		req.SetBasicAuth(e.config.Username, e.config.Password)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make an HTTP request: %w", err)
+	}
+	defer func() {
+		// Discard any remaining response body when we are done reading.
+		io.CopyN(ioutil.Discard, resp.Body, maxHTTPResponseReadBytes)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	return exporterhelper.NewHTTPResponseError(resp, e.writeURL)
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+type writeRequest struct {
+	path          string
+	params        map[string]string
+	authorization string
+	lines         []string
+}
+
+// influxdbServer records the requests made to the write API.
+type influxdbServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []writeRequest
+	status   int
+}
+
+func newInfluxdbServer(t *testing.T) *influxdbServer {
+	srv := &influxdbServer{status: http.StatusNoContent}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := writeRequest{
+			path:          r.URL.Path,
+			params:        map[string]string{},
+			authorization: r.Header.Get(headerAuthorization),
+			lines:         strings.Split(string(body), "\n"),
+		}
+		for k := range r.URL.Query() {
+			req.params[k] = r.URL.Query().Get(k)
+		}
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		srv.requests = append(srv.requests, req)
+		w.WriteHeader(srv.status)
+	}))
+	return srv
+}
+
+func (s *influxdbServer) setStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *influxdbServer) getRequests() []writeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func newTestExporter(t *testing.T, endpoint string, modify func(cfg *Config)) *influxdbExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Org = "example"
+	cfg.Bucket = "telemetry"
+	cfg.Token = "my-token"
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, validateConfig(cfg))
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	return exp
+}
+
+// newGaugeMetrics returns an int gauge with a data point per value.
+func newGaugeMetrics(values ...int64) pdata.Metrics {
+	return newTestMetrics(func(m pdata.Metric) {
+		m.SetName("threads")
+		m.SetDataType(pdata.MetricDataTypeIntGauge)
+		m.IntGauge().DataPoints().Resize(len(values))
+		for i, v := range values {
+			p := m.IntGauge().DataPoints().At(i)
+			p.SetTimestamp(testTimestamp)
+			p.SetValue(v)
+		}
+	})
+}
+
+func TestPushMetricsData_V2(t *testing.T) {
+	srv := newInfluxdbServer(t)
+	defer srv.Close()
+	exp := newTestExporter(t, srv.URL+"/", nil)
+
+	dropped, err := exp.pushMetricsData(context.Background(), newGaugeMetrics(1, 2))
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	requests := srv.getRequests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/api/v2/write", requests[0].path)
+	assert.Equal(t, map[string]string{"org": "example", "bucket": "telemetry", "precision": "ns"}, requests[0].params)
+	assert.Equal(t, "Token my-token", requests[0].authorization)
+	assert.Equal(t, []string{
+		`threads,service.name=my\ service gauge=1i 1614834367000000008`,
+		`threads,service.name=my\ service gauge=2i 1614834367000000008`,
+	}, requests[0].lines)
+}
+
+func TestPushMetricsData_V1(t *testing.T) {
+	srv := newInfluxdbServer(t)
+	defer srv.Close()
+	exp := newTestExporter(t, srv.URL, func(cfg *Config) {
+		cfg.APIVersion = apiVersion1
+		cfg.Database = "telemetry"
+		cfg.RetentionPolicy = "one_week"
+		cfg.Username = "otel"
+		cfg.Password = "secret"
+	})
+
+	_, err := exp.pushMetricsData(context.Background(), newGaugeMetrics(1))
+	require.NoError(t, err)
+
+	requests := srv.getRequests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/write", requests[0].path)
+	assert.Equal(t, map[string]string{"db": "telemetry", "rp": "one_week", "precision": "ns"}, requests[0].params)
+	assert.Equal(t, "Basic b3RlbDpzZWNyZXQ=", requests[0].authorization)
+	assert.Equal(t, []string{`threads,service.name=my\ service gauge=1i 1614834367000000008`}, requests[0].lines)
+}
+
+func TestPushMetricsData_Batches(t *testing.T) {
+	srv := newInfluxdbServer(t)
+	defer srv.Close()
+	exp := newTestExporter(t, srv.URL, func(cfg *Config) {
+		cfg.MaxBatchSize = 2
+	})
+
+	_, err := exp.pushMetricsData(context.Background(), newGaugeMetrics(1, 2, 3, 4, 5))
+	require.NoError(t, err)
+
+	requests := srv.getRequests()
+	require.Len(t, requests, 3)
+	assert.Len(t, requests[0].lines, 2)
+	assert.Len(t, requests[1].lines, 2)
+	assert.Equal(t, []string{`threads,service.name=my\ service gauge=5i 1614834367000000008`}, requests[2].lines)
+}
+
+func TestPushMetricsData_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		permanent bool
+		throttled bool
+	}{
+		{name: "bad_request", status: http.StatusBadRequest, permanent: true},
+		{name: "not_found", status: http.StatusNotFound, permanent: true},
+		{name: "too_many_requests", status: http.StatusTooManyRequests, throttled: true},
+		{name: "unavailable", status: http.StatusServiceUnavailable, throttled: true},
+		{name: "internal_error", status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newInfluxdbServer(t)
+			defer srv.Close()
+			srv.setStatus(tt.status)
+			exp := newTestExporter(t, srv.URL, nil)
+
+			md := newGaugeMetrics(1)
+			dropped, err := exp.pushMetricsData(context.Background(), md)
+			require.Error(t, err)
+			assert.Equal(t, md.MetricCount(), dropped)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+			class, _ := exporterhelper.DefaultErrorClassifier(err)
+			assert.Equal(t, tt.throttled, class == exporterhelper.ErrorClassThrottled, err)
+		})
+	}
+}
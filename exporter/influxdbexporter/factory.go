@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "influxdb"
+
+	apiVersion1 = "v1"
+	apiVersion2 = "v2"
+
+	defaultMaxBatchSize = 5000
+)
+
+var (
+	errNoEndpoint     = errors.New("endpoint must be specified")
+	errNoDatabase     = errors.New("database must be specified with the v1 API")
+	errNoOrgOrBucket  = errors.New("org and bucket must be specified with the v2 API")
+	errInvalidMaxSize = errors.New("max_batch_size must be positive")
+)
+
+// NewFactory creates a factory for InfluxDB exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithMetrics(createMetricsExporter))
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		RetrySettings: exporterhelper.DefaultRetrySettings(),
+		QueueSettings: exporterhelper.DefaultQueueSettings(),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "",
+			Timeout:  30 * time.Second,
+			Headers:  map[string]string{},
+		},
+		APIVersion:   apiVersion2,
+		MaxBatchSize: defaultMaxBatchSize,
+	}
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	iCfg := cfg.(*Config)
+	if err := validateConfig(iCfg); err != nil {
+		return nil, fmt.Errorf("error creating %q exporter: %w", iCfg.Name(), err)
+	}
+	exp, err := newExporter(iCfg, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		params.Logger,
+		exp.pushMetricsData,
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(iCfg.RetrySettings),
+		exporterhelper.WithQueue(iCfg.QueueSettings))
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.Endpoint == "" {
+		return errNoEndpoint
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return fmt.Errorf("endpoint must be a valid URL: %w", err)
+	}
+	switch cfg.APIVersion {
+	case apiVersion1:
+		if cfg.Database == "" {
+			return errNoDatabase
+		}
+	case apiVersion2:
+		if cfg.Org == "" || cfg.Bucket == "" {
+			return errNoOrgOrBucket
+		}
+	default:
+		return fmt.Errorf("unsupported api_version %q, must be %q or %q", cfg.APIVersion, apiVersion1, apiVersion2)
+	}
+	if cfg.MaxBatchSize <= 0 {
+		return errInvalidMaxSize
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// The metrics are converted to points of the InfluxDB line protocol, see
+// https://docs.influxdata.com/influxdb/v2.0/reference/syntax/line-protocol/. The measurement of a point is the name of
+// the metric and its tags are the resource attributes and the labels of the data point, the labels taking precedence.
+// The fields depend on the type of the metric:
+//   - gauges have a "gauge" field, as do the non-monotonic sums
+//   - monotonic sums have a "counter" field
+//   - histograms have "count" and "sum" fields, and a field per bucket named after its upper bound, "+Inf" for the
+//     last one, holding the cumulative count of the bucket
+//   - summaries have "count" and "sum" fields, and a field per quantile named after the quantile
+
+const (
+	fieldGauge   = "gauge"
+	fieldCounter = "counter"
+	fieldCount   = "count"
+	fieldSum     = "sum"
+	fieldInf     = "+Inf"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "\n", `\n`)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`)
+)
+
+// field is a field of a point, its value is formatted as an integer if isInt is set, and as a float otherwise.
+type field struct {
+	key      string
+	intVal   int64
+	floatVal float64
+	isInt    bool
+}
+
+func intField(key string, val int64) field {
+	return field{key: key, intVal: val, isInt: true}
+}
+
+func floatField(key string, val float64) field {
+	return field{key: key, floatVal: val}
+}
+
+// metricsToLines converts the metrics to lines of the line protocol, and returns the number of data points that could
+// not be converted.
+func metricsToLines(md pdata.Metrics) ([]string, int) {
+	var lines []string
+	dropped := 0
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceTags := make(map[string]string, rm.Resource().Attributes().Len())
+		rm.Resource().Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+			resourceTags[k] = tracetranslator.AttributeValueToString(v, false)
+		})
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				var n int
+				lines, n = appendMetricLines(lines, metrics.At(k), resourceTags)
+				dropped += n
+			}
+		}
+	}
+	return lines, dropped
+}
+
+func appendMetricLines(lines []string, metric pdata.Metric, resourceTags map[string]string) ([]string, int) {
+	dropped := 0
+	appendLine := func(labels pdata.StringMap, timestamp pdata.Timestamp, fields ...field) {
+		line, ok := formatLine(metric.Name(), resourceTags, labels, timestamp, fields)
+		if !ok {
+			dropped++
+			return
+		}
+		lines = append(lines, line)
+	}
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		ps := metric.IntGauge().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			appendLine(p.LabelsMap(), p.Timestamp(), intField(fieldGauge, p.Value()))
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		ps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			appendLine(p.LabelsMap(), p.Timestamp(), floatField(fieldGauge, p.Value()))
+		}
+	case pdata.MetricDataTypeIntSum:
+		key := sumFieldKey(metric.IntSum().IsMonotonic())
+		ps := metric.IntSum().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			appendLine(p.LabelsMap(), p.Timestamp(), intField(key, p.Value()))
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		key := sumFieldKey(metric.DoubleSum().IsMonotonic())
+		ps := metric.DoubleSum().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			appendLine(p.LabelsMap(), p.Timestamp(), floatField(key, p.Value()))
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		ps := metric.IntHistogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			fields := []field{intField(fieldCount, int64(p.Count())), intField(fieldSum, p.Sum())}
+			fields = appendBucketFields(fields, p.ExplicitBounds(), p.BucketCounts(), p.Count())
+			appendLine(p.LabelsMap(), p.Timestamp(), fields...)
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		ps := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			fields := []field{intField(fieldCount, int64(p.Count())), floatField(fieldSum, p.Sum())}
+			fields = appendBucketFields(fields, p.ExplicitBounds(), p.BucketCounts(), p.Count())
+			appendLine(p.LabelsMap(), p.Timestamp(), fields...)
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		ps := metric.DoubleSummary().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			fields := []field{intField(fieldCount, int64(p.Count())), floatField(fieldSum, p.Sum())}
+			qs := p.QuantileValues()
+			for j := 0; j < qs.Len(); j++ {
+				q := qs.At(j)
+				fields = append(fields, floatField(formatFloat(q.Quantile()), q.Value()))
+			}
+			appendLine(p.LabelsMap(), p.Timestamp(), fields...)
+		}
+	}
+	return lines, dropped
+}
+
+func sumFieldKey(monotonic bool) string {
+	if monotonic {
+		return fieldCounter
+	}
+	return fieldGauge
+}
+
+// appendBucketFields appends the cumulative counts of the buckets, the counts of the data point being per bucket.
+func appendBucketFields(fields []field, bounds []float64, counts []uint64, total uint64) []field {
+	var cumulative uint64
+	for i, bound := range bounds {
+		if i < len(counts) {
+			cumulative += counts[i]
+		}
+		fields = append(fields, intField(formatFloat(bound), int64(cumulative)))
+	}
+	return append(fields, intField(fieldInf, int64(total)))
+}
+
+// formatLine returns the line of a point, or false if the point has no measurement or no field with a valid value.
+func formatLine(measurement string, resourceTags map[string]string, labels pdata.StringMap, timestamp pdata.Timestamp, fields []field) (string, bool) {
+	if measurement == "" {
+		return "", false
+	}
+	tags := make(map[string]string, len(resourceTags)+labels.Len())
+	for k, v := range resourceTags {
+		tags[k] = v
+	}
+	labels.ForEach(func(k string, v string) {
+		tags[k] = v
+	})
+	keys := make([]string, 0, len(tags))
+	for k, v := range tags {
+		// The line protocol does not allow empty tag keys or values.
+		if k != "" && v != "" {
+			keys = append(keys, k)
+		}
+	}
+	// InfluxDB recommends sorting the tags by key for performance.
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(measurementEscaper.Replace(measurement))
+	for _, k := range keys {
+		b.WriteByte(',')
+		b.WriteString(keyEscaper.Replace(k))
+		b.WriteByte('=')
+		b.WriteString(keyEscaper.Replace(tags[k]))
+	}
+
+	sep := byte(' ')
+	for _, f := range fields {
+		// NaN and infinite values are not supported by the line protocol.
+		if !f.isInt && (math.IsNaN(f.floatVal) || math.IsInf(f.floatVal, 0)) {
+			continue
+		}
+		b.WriteByte(sep)
+		sep = ','
+		b.WriteString(keyEscaper.Replace(f.key))
+		b.WriteByte('=')
+		if f.isInt {
+			b.WriteString(strconv.FormatInt(f.intVal, 10))
+			b.WriteByte('i')
+		} else {
+			b.WriteString(formatFloat(f.floatVal))
+		}
+	}
+	if sep == ' ' {
+		return "", false
+	}
+
+	// The time of the InfluxDB server is used if the data point has no timestamp.
+	if timestamp != 0 {
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatUint(uint64(timestamp), 10))
+	}
+	return b.String(), true
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
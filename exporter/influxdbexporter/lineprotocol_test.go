@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const testTimestamp = pdata.Timestamp(1614834367000000008)
+
+// newTestMetrics returns metrics of a resource with the "service.name" attribute, the metrics being initialized by
+// the given functions.
+func newTestMetrics(inits ...func(m pdata.Metric)) pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("service.name", "my service")
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(len(inits))
+	for i, init := range inits {
+		init(metrics.At(i))
+	}
+	return md
+}
+
+func TestMetricsToLines(t *testing.T) {
+	md := newTestMetrics(
+		func(m pdata.Metric) {
+			m.SetName("cpu.load")
+			m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+			m.DoubleGauge().DataPoints().Resize(2)
+			p := m.DoubleGauge().DataPoints().At(0)
+			p.LabelsMap().Insert("cpu", "0")
+			p.SetTimestamp(testTimestamp)
+			p.SetValue(0.5)
+			p = m.DoubleGauge().DataPoints().At(1)
+			p.LabelsMap().Insert("cpu", "1")
+			p.SetValue(math.NaN())
+		},
+		func(m pdata.Metric) {
+			m.SetName("threads")
+			m.SetDataType(pdata.MetricDataTypeIntGauge)
+			m.IntGauge().DataPoints().Resize(1)
+			p := m.IntGauge().DataPoints().At(0)
+			p.LabelsMap().Insert("service.name", "overridden")
+			p.LabelsMap().Insert("empty", "")
+			p.SetValue(12)
+		},
+		func(m pdata.Metric) {
+			m.SetName("requests")
+			m.SetDataType(pdata.MetricDataTypeIntSum)
+			m.IntSum().SetIsMonotonic(true)
+			m.IntSum().DataPoints().Resize(1)
+			p := m.IntSum().DataPoints().At(0)
+			p.LabelsMap().Insert("path", "/a,b=c d")
+			p.SetTimestamp(testTimestamp)
+			p.SetValue(42)
+		},
+		func(m pdata.Metric) {
+			m.SetName("queue size")
+			m.SetDataType(pdata.MetricDataTypeDoubleSum)
+			m.DoubleSum().DataPoints().Resize(1)
+			p := m.DoubleSum().DataPoints().At(0)
+			p.SetTimestamp(testTimestamp)
+			p.SetValue(3)
+		},
+		func(m pdata.Metric) {
+			m.SetName("latency")
+			m.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+			m.DoubleHistogram().DataPoints().Resize(1)
+			p := m.DoubleHistogram().DataPoints().At(0)
+			p.SetTimestamp(testTimestamp)
+			p.SetCount(6)
+			p.SetSum(7.5)
+			p.SetExplicitBounds([]float64{0.5, 1})
+			p.SetBucketCounts([]uint64{1, 2, 3})
+		},
+		func(m pdata.Metric) {
+			m.SetName("size")
+			m.SetDataType(pdata.MetricDataTypeIntHistogram)
+			m.IntHistogram().DataPoints().Resize(1)
+			p := m.IntHistogram().DataPoints().At(0)
+			p.SetTimestamp(testTimestamp)
+			p.SetCount(3)
+			p.SetSum(300)
+			p.SetExplicitBounds([]float64{100})
+			p.SetBucketCounts([]uint64{1, 2})
+		},
+		func(m pdata.Metric) {
+			m.SetName("duration")
+			m.SetDataType(pdata.MetricDataTypeDoubleSummary)
+			m.DoubleSummary().DataPoints().Resize(1)
+			p := m.DoubleSummary().DataPoints().At(0)
+			p.SetTimestamp(testTimestamp)
+			p.SetCount(10)
+			p.SetSum(20)
+			p.QuantileValues().Resize(2)
+			p.QuantileValues().At(0).SetQuantile(0.5)
+			p.QuantileValues().At(0).SetValue(1.5)
+			p.QuantileValues().At(1).SetQuantile(0.99)
+			p.QuantileValues().At(1).SetValue(4)
+		},
+		func(m pdata.Metric) {
+			m.SetDataType(pdata.MetricDataTypeIntGauge)
+			m.IntGauge().DataPoints().Resize(1)
+		},
+	)
+
+	lines, dropped := metricsToLines(md)
+	assert.Equal(t, 2, dropped)
+	assert.Equal(t, []string{
+		`cpu.load,cpu=0,service.name=my\ service gauge=0.5 1614834367000000008`,
+		`threads,service.name=overridden gauge=12i`,
+		`requests,path=/a\,b\=c\ d,service.name=my\ service counter=42i 1614834367000000008`,
+		`queue\ size,service.name=my\ service gauge=3 1614834367000000008`,
+		`latency,service.name=my\ service count=6i,sum=7.5,0.5=1i,1=3i,+Inf=6i 1614834367000000008`,
+		`size,service.name=my\ service count=3i,sum=300i,100=1i,+Inf=3i 1614834367000000008`,
+		`duration,service.name=my\ service count=10i,sum=20,0.5=1.5,0.99=4 1614834367000000008`,
+	}, lines)
+}
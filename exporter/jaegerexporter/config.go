@@ -15,6 +15,8 @@
 package jaegerexporter
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -28,4 +30,26 @@ type Config struct {
 	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// PerRPCTimeout is the time limit of each PostSpans RPC, including its retries by gRPC, so that a slow
+	// collector replica does not use the whole timeout of the export. 0 means no limit other than the export timeout.
+	PerRPCTimeout time.Duration `mapstructure:"per_rpc_timeout"`
+
+	// RetryPolicy is the retry policy of the gRPC service config, gRPC retrying the failed RPCs, on another
+	// collector replica with the round_robin balancer, before the export fails. Disabled if nil.
+	RetryPolicy *RetryPolicy `mapstructure:"retry_policy"`
+}
+
+// RetryPolicy defines the retryPolicy of the gRPC service config, see
+// https://github.com/grpc/proposal/blob/master/A6-client-retries.md#retry-policy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of an RPC, including the original one. gRPC caps it at 5.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// InitialBackoff and MaxBackoff bound the randomized delay before a retry.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	// BackoffMultiplier is the factor applied to the backoff after each retry.
+	BackoffMultiplier float64 `mapstructure:"backoff_multiplier"`
+	// RetryableStatusCodes are the gRPC status codes, e.g. UNAVAILABLE, of the failed RPCs to retry.
+	RetryableStatusCodes []string `mapstructure:"retryable_status_codes"`
 }
@@ -57,10 +57,11 @@ func TestLoadConfig(t *testing.T) {
 				Timeout: 10 * time.Second,
 			},
 			RetrySettings: exporterhelper.RetrySettings{
-				Enabled:         true,
-				InitialInterval: 10 * time.Second,
-				MaxInterval:     1 * time.Minute,
-				MaxElapsedTime:  10 * time.Minute,
+				Enabled:             true,
+				InitialInterval:     10 * time.Second,
+				MaxInterval:         1 * time.Minute,
+				MaxElapsedTime:      10 * time.Minute,
+				RandomizationFactor: 0.5,
 			},
 			QueueSettings: exporterhelper.QueueSettings{
 				Enabled:      true,
@@ -72,6 +73,14 @@ func TestLoadConfig(t *testing.T) {
 				WriteBufferSize: 512 * 1024,
 				BalancerName:    "round_robin",
 			},
+			PerRPCTimeout: 2 * time.Second,
+			RetryPolicy: &RetryPolicy{
+				MaxAttempts:          3,
+				InitialBackoff:       100 * time.Millisecond,
+				MaxBackoff:           time.Second,
+				BackoffMultiplier:    2,
+				RetryableStatusCodes: []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+			},
 		})
 
 	params := component.ExporterCreateParams{Logger: zap.NewNop()}
@@ -17,9 +17,12 @@ package jaegerexporter
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/jaegertracing/jaeger/model"
 	jaegerproto "github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -45,6 +48,17 @@ func newTraceExporter(cfg *Config, logger *zap.Logger) (component.TracesExporter
 		return nil, err
 	}
 
+	sc, err := serviceConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if sc != "" {
+		opts = append(opts, grpc.WithDefaultServiceConfig(sc))
+		if !strings.EqualFold(os.Getenv("GRPC_GO_RETRY"), "on") {
+			logger.Warn("The retry_policy is ignored by gRPC unless the GRPC_GO_RETRY environment variable is set to \"on\".")
+		}
+	}
+
 	conn, err := grpc.Dial(cfg.GRPCClientSettings.Endpoint, opts...)
 	if err != nil {
 		return nil, err
@@ -56,6 +70,7 @@ func newTraceExporter(cfg *Config, logger *zap.Logger) (component.TracesExporter
 		collectorServiceClient,
 		metadata.New(cfg.GRPCClientSettings.Headers),
 		cfg.WaitForReady,
+		cfg.PerRPCTimeout,
 		conn,
 	)
 	exp, err := exporterhelper.NewTraceExporter(
@@ -78,6 +93,7 @@ type protoGRPCSender struct {
 	client       jaegerproto.CollectorServiceClient
 	metadata     metadata.MD
 	waitForReady bool
+	rpcTimeout   time.Duration
 
 	conn                      stateReporter
 	connStateReporterInterval time.Duration
@@ -88,13 +104,14 @@ type protoGRPCSender struct {
 	stopLock sync.Mutex
 }
 
-func newProtoGRPCSender(logger *zap.Logger, name string, cl jaegerproto.CollectorServiceClient, md metadata.MD, waitForReady bool, conn stateReporter) *protoGRPCSender {
+func newProtoGRPCSender(logger *zap.Logger, name string, cl jaegerproto.CollectorServiceClient, md metadata.MD, waitForReady bool, rpcTimeout time.Duration, conn stateReporter) *protoGRPCSender {
 	s := &protoGRPCSender{
 		name:         name,
 		logger:       logger,
 		client:       cl,
 		metadata:     md,
 		waitForReady: waitForReady,
+		rpcTimeout:   rpcTimeout,
 
 		conn:                      conn,
 		connStateReporterInterval: time.Second,
@@ -125,9 +142,7 @@ func (s *protoGRPCSender) pushTraceData(
 
 	var sentSpans int
 	for _, batch := range batches {
-		_, err = s.client.PostSpans(
-			ctx,
-			&jaegerproto.PostSpansRequest{Batch: *batch}, grpc.WaitForReady(s.waitForReady))
+		err = s.postSpans(ctx, batch)
 		if err != nil {
 			s.logger.Debug("failed to push trace data to Jaeger", zap.Error(err))
 			return td.SpanCount() - sentSpans, fmt.Errorf("failed to push trace data via Jaeger exporter: %w", err)
@@ -138,6 +153,16 @@ func (s *protoGRPCSender) pushTraceData(
 	return 0, nil
 }
 
+func (s *protoGRPCSender) postSpans(ctx context.Context, batch *model.Batch) error {
+	if s.rpcTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.rpcTimeout)
+		defer cancel()
+	}
+	_, err := s.client.PostSpans(ctx, &jaegerproto.PostSpansRequest{Batch: *batch}, grpc.WaitForReady(s.waitForReady))
+	return err
+}
+
 func (s *protoGRPCSender) shutdown(context.Context) error {
 	s.stopLock.Lock()
 	s.stopped = true
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 
@@ -155,6 +156,21 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "createExporterWithInvalidRetryPolicy",
+			args: args{
+				config: Config{
+					GRPCClientSettings: configgrpc.GRPCClientSettings{
+						Endpoint: "foo.bar",
+						TLSSetting: configtls.TLSClientSetting{
+							Insecure: true,
+						},
+					},
+					RetryPolicy: &RetryPolicy{MaxAttempts: 1},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -251,6 +267,47 @@ func TestMutualTLS(t *testing.T) {
 	assert.Equal(t, jTraceID, requestes[0].GetBatch().Spans[0].TraceID)
 }
 
+func TestPerRPCTimeout(t *testing.T) {
+	spanHandler := &mockSpanHandler{delay: time.Second}
+	server, serverAddr := initializeGRPCTestServer(t, func(server *grpc.Server) {
+		api_v2.RegisterCollectorServiceServer(server, spanHandler)
+	})
+	defer server.Stop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.Enabled = false
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint:     serverAddr.String(),
+		TLSSetting:   configtls.TLSClientSetting{Insecure: true},
+		BalancerName: "round_robin",
+	}
+	cfg.PerRPCTimeout = 50 * time.Millisecond
+	cfg.RetryPolicy = &RetryPolicy{
+		MaxAttempts:          2,
+		InitialBackoff:       10 * time.Millisecond,
+		MaxBackoff:           10 * time.Millisecond,
+		BackoffMultiplier:    1,
+		RetryableStatusCodes: []string{"UNAVAILABLE"},
+	}
+	exporter, err := factory.CreateTracesExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+	defer exporter.Shutdown(context.Background())
+
+	td := testdata.GenerateTraceDataOneSpan()
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.SetTraceID(pdata.NewTraceID([16]byte{1}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1}))
+	start := time.Now()
+	err = exporter.ConsumeTraces(context.Background(), td)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), codes.DeadlineExceeded.String())
+	// The export timeout is 5s, the RPC is abandoned well before.
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
 func TestConnectionStateChange(t *testing.T) {
 	var state connectivity.State
 
@@ -342,6 +399,8 @@ func initializeGRPCTestServer(t *testing.T, beforeServe func(server *grpc.Server
 type mockSpanHandler struct {
 	mux      sync.Mutex
 	requests []*api_v2.PostSpansRequest
+	// delay is the time the handler waits before responding, or until the RPC is canceled.
+	delay time.Duration
 }
 
 func (h *mockSpanHandler) getRequests() []*api_v2.PostSpansRequest {
@@ -350,7 +409,14 @@ func (h *mockSpanHandler) getRequests() []*api_v2.PostSpansRequest {
 	return h.requests
 }
 
-func (h *mockSpanHandler) PostSpans(_ context.Context, r *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+func (h *mockSpanHandler) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+	if h.delay > 0 {
+		select {
+		case <-time.After(h.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
 	h.mux.Lock()
 	defer h.mux.Unlock()
 	h.requests = append(h.requests, r)
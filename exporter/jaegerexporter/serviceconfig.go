@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerexporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// collectorServiceName is the full name of the gRPC service of the Jaeger collector.
+const collectorServiceName = "jaeger.api_v2.CollectorService"
+
+// serviceConfig returns the gRPC service config of the exporter, an empty string if the defaults of gRPC apply.
+// The balancer is repeated as the service config replaces the one set by the gRPC client settings.
+func serviceConfig(cfg *Config) (string, error) {
+	if cfg.RetryPolicy == nil {
+		return "", nil
+	}
+	policy, err := retryPolicyConfig(cfg.RetryPolicy)
+	if err != nil {
+		return "", fmt.Errorf("invalid retry_policy: %w", err)
+	}
+
+	sc := map[string]interface{}{
+		"methodConfig": []interface{}{
+			map[string]interface{}{
+				"name":        []interface{}{map[string]string{"service": collectorServiceName}},
+				"retryPolicy": policy,
+			},
+		},
+	}
+	if cfg.BalancerName != "" {
+		sc["loadBalancingPolicy"] = cfg.BalancerName
+	}
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func retryPolicyConfig(rp *RetryPolicy) (map[string]interface{}, error) {
+	if rp.MaxAttempts < 2 {
+		return nil, errors.New("max_attempts must be at least 2")
+	}
+	if rp.InitialBackoff <= 0 || rp.MaxBackoff <= 0 {
+		return nil, errors.New("initial_backoff and max_backoff must be positive")
+	}
+	if rp.BackoffMultiplier <= 0 {
+		return nil, errors.New("backoff_multiplier must be positive")
+	}
+	if len(rp.RetryableStatusCodes) == 0 {
+		return nil, errors.New("retryable_status_codes must not be empty")
+	}
+	retryableCodes := make([]string, 0, len(rp.RetryableStatusCodes))
+	for _, name := range rp.RetryableStatusCodes {
+		name = strings.ToUpper(name)
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil || code == codes.OK {
+			return nil, fmt.Errorf("invalid retryable status code %q", name)
+		}
+		retryableCodes = append(retryableCodes, name)
+	}
+	return map[string]interface{}{
+		"maxAttempts":          rp.MaxAttempts,
+		"initialBackoff":       durationString(rp.InitialBackoff),
+		"maxBackoff":           durationString(rp.MaxBackoff),
+		"backoffMultiplier":    rp.BackoffMultiplier,
+		"retryableStatusCodes": retryableCodes,
+	}, nil
+}
+
+// durationString formats a duration as the google.protobuf.Duration of the JSON service config, e.g. "0.1s".
+func durationString(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       100 * time.Millisecond,
+		MaxBackoff:           time.Second,
+		BackoffMultiplier:    1.5,
+		RetryableStatusCodes: []string{"unavailable", "RESOURCE_EXHAUSTED"},
+	}
+}
+
+func TestServiceConfig(t *testing.T) {
+	cfg := &Config{RetryPolicy: validRetryPolicy()}
+	cfg.BalancerName = "round_robin"
+
+	sc, err := serviceConfig(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"loadBalancingPolicy": "round_robin",
+		"methodConfig": [{
+			"name": [{"service": "jaeger.api_v2.CollectorService"}],
+			"retryPolicy": {
+				"maxAttempts": 3,
+				"initialBackoff": "0.1s",
+				"maxBackoff": "1s",
+				"backoffMultiplier": 1.5,
+				"retryableStatusCodes": ["UNAVAILABLE", "RESOURCE_EXHAUSTED"]
+			}
+		}]
+	}`, sc)
+}
+
+func TestServiceConfig_NoRetryPolicy(t *testing.T) {
+	cfg := &Config{}
+	cfg.BalancerName = "round_robin"
+
+	sc, err := serviceConfig(cfg)
+	require.NoError(t, err)
+	// The balancer is then set by the gRPC client settings.
+	assert.Equal(t, "", sc)
+}
+
+func TestServiceConfig_InvalidRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(rp *RetryPolicy)
+		err    string
+	}{
+		{
+			name:   "max_attempts",
+			modify: func(rp *RetryPolicy) { rp.MaxAttempts = 1 },
+			err:    "max_attempts must be at least 2",
+		},
+		{
+			name:   "initial_backoff",
+			modify: func(rp *RetryPolicy) { rp.InitialBackoff = 0 },
+			err:    "initial_backoff and max_backoff must be positive",
+		},
+		{
+			name:   "backoff_multiplier",
+			modify: func(rp *RetryPolicy) { rp.BackoffMultiplier = 0 },
+			err:    "backoff_multiplier must be positive",
+		},
+		{
+			name:   "no_status_codes",
+			modify: func(rp *RetryPolicy) { rp.RetryableStatusCodes = nil },
+			err:    "retryable_status_codes must not be empty",
+		},
+		{
+			name:   "unknown_status_code",
+			modify: func(rp *RetryPolicy) { rp.RetryableStatusCodes = []string{"UNAVAILABLE", "SLOW"} },
+			err:    `invalid retryable status code "SLOW"`,
+		},
+		{
+			name:   "ok_status_code",
+			modify: func(rp *RetryPolicy) { rp.RetryableStatusCodes = []string{"OK"} },
+			err:    `invalid retryable status code "OK"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := validRetryPolicy()
+			tt.modify(rp)
+			_, err := serviceConfig(&Config{RetryPolicy: rp})
+			require.Error(t, err)
+			assert.Equal(t, "invalid retry_policy: "+tt.err, err.Error())
+		})
+	}
+}
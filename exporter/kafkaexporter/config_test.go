@@ -48,10 +48,11 @@ func TestLoadConfig(t *testing.T) {
 			Timeout: 10 * time.Second,
 		},
 		RetrySettings: exporterhelper.RetrySettings{
-			Enabled:         true,
-			InitialInterval: 10 * time.Second,
-			MaxInterval:     1 * time.Minute,
-			MaxElapsedTime:  10 * time.Minute,
+			Enabled:             true,
+			InitialInterval:     10 * time.Second,
+			MaxInterval:         1 * time.Minute,
+			MaxElapsedTime:      10 * time.Minute,
+			RandomizationFactor: 0.5,
 		},
 		QueueSettings: exporterhelper.QueueSettings{
 			Enabled:      true,
@@ -30,4 +30,17 @@ type Config struct {
 
 	// SamplingThereafter defines the sampling rate after the initial samples are logged.
 	SamplingThereafter int `mapstructure:"sampling_thereafter"`
+
+	// Verbosity defines how much of the data is logged; options are basic (the number of items of each batch),
+	// normal (a line per item with its main fields) and detailed (all the fields of the items). If not set, it is
+	// detailed when LogLevel is debug and basic otherwise.
+	Verbosity string `mapstructure:"verbosity"`
+
+	// MaxItemsPerSecond is the maximum number of spans, metrics and log records logged each second with the normal and
+	// detailed verbosity, the other items are only counted. 0 means no limit.
+	MaxItemsPerSecond int `mapstructure:"max_items_per_second"`
+
+	// Encoding defines the format of the output; options are console and json. With json, the items are logged as
+	// structured fields of the entry of their batch.
+	Encoding string `mapstructure:"encoding"`
 }
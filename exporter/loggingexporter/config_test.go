@@ -50,5 +50,8 @@ func TestLoadConfig(t *testing.T) {
 			LogLevel:           "debug",
 			SamplingInitial:    10,
 			SamplingThereafter: 50,
+			Verbosity:          "normal",
+			MaxItemsPerSecond:  100,
+			Encoding:           "json",
 		})
 }
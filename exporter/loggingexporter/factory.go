@@ -16,6 +16,9 @@ package loggingexporter
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -51,40 +54,56 @@ func createDefaultConfig() configmodels.Exporter {
 		LogLevel:           "info",
 		SamplingInitial:    defaultSamplingInitial,
 		SamplingThereafter: defaultSamplingThereafter,
+		Encoding:           encodingConsole,
 	}
 }
 
 func createTraceExporter(_ context.Context, _ component.ExporterCreateParams, config configmodels.Exporter) (component.TracesExporter, error) {
 	cfg := config.(*Config)
 
+	settings, err := newOutputSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	exporterLogger, err := createLogger(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return newTraceExporter(config, cfg.LogLevel, exporterLogger)
+	return newTraceExporter(config, settings, exporterLogger)
 }
 
 func createMetricsExporter(_ context.Context, _ component.ExporterCreateParams, config configmodels.Exporter) (component.MetricsExporter, error) {
 	cfg := config.(*Config)
 
+	settings, err := newOutputSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	exporterLogger, err := createLogger(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return newMetricsExporter(config, cfg.LogLevel, exporterLogger)
+	return newMetricsExporter(config, settings, exporterLogger)
 }
 
 func createLogsExporter(_ context.Context, _ component.ExporterCreateParams, config configmodels.Exporter) (component.LogsExporter, error) {
 	cfg := config.(*Config)
 
+	settings, err := newOutputSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	exporterLogger, err := createLogger(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return newLogsExporter(config, cfg.LogLevel, exporterLogger)
+	return newLogsExporter(config, settings, exporterLogger)
 }
 
 func createLogger(cfg *Config) (*zap.Logger, error) {
@@ -102,6 +121,11 @@ func createLogger(cfg *Config) (*zap.Logger, error) {
 		Initial:    cfg.SamplingInitial,
 		Thereafter: cfg.SamplingThereafter,
 	}
+	if strings.ToLower(cfg.Encoding) == encodingJSON {
+		conf.Encoding = encodingJSON
+		conf.EncoderConfig = zap.NewProductionEncoderConfig()
+		conf.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	}
 
 	logginglogger, err := conf.Build()
 	if err != nil {
@@ -109,3 +133,35 @@ func createLogger(cfg *Config) (*zap.Logger, error) {
 	}
 	return logginglogger, nil
 }
+
+// newOutputSettings returns the settings of the output of the exporter, the verbosity defaulting to the one implied
+// by the log level.
+func newOutputSettings(cfg *Config) (outputSettings, error) {
+	settings := outputSettings{
+		verbosity:         strings.ToLower(cfg.Verbosity),
+		maxItemsPerSecond: cfg.MaxItemsPerSecond,
+	}
+	switch settings.verbosity {
+	case "":
+		settings.verbosity = verbosityBasic
+		if strings.ToLower(cfg.LogLevel) == "debug" {
+			settings.verbosity = verbosityDetailed
+		}
+	case verbosityBasic, verbosityNormal, verbosityDetailed:
+	default:
+		return settings, fmt.Errorf("invalid verbosity %q, must be %q, %q or %q", cfg.Verbosity, verbosityBasic, verbosityNormal, verbosityDetailed)
+	}
+
+	switch strings.ToLower(cfg.Encoding) {
+	case "", encodingConsole:
+	case encodingJSON:
+		settings.json = true
+	default:
+		return settings, fmt.Errorf("invalid encoding %q, must be %q or %q", cfg.Encoding, encodingConsole, encodingJSON)
+	}
+
+	if cfg.MaxItemsPerSecond < 0 {
+		return settings, errors.New("max_items_per_second must not be negative")
+	}
+	return settings, nil
+}
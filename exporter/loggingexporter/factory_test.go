@@ -58,3 +58,53 @@ func TestCreateLogsExporter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, te)
 }
+
+func TestCreateExporterInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errMsg string
+	}{
+		{
+			name:   "verbosity",
+			modify: func(cfg *Config) { cfg.Verbosity = "verbose" },
+			errMsg: `invalid verbosity "verbose", must be "basic", "normal" or "detailed"`,
+		},
+		{
+			name:   "encoding",
+			modify: func(cfg *Config) { cfg.Encoding = "logfmt" },
+			errMsg: `invalid encoding "logfmt", must be "console" or "json"`,
+		},
+		{
+			name:   "max_items_per_second",
+			modify: func(cfg *Config) { cfg.MaxItemsPerSecond = -1 },
+			errMsg: "max_items_per_second must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+
+			te, err := factory.CreateTracesExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+			assert.EqualError(t, err, tt.errMsg)
+			assert.Nil(t, te)
+		})
+	}
+}
+
+func TestNewOutputSettings(t *testing.T) {
+	settings, err := newOutputSettings(&Config{LogLevel: "info"})
+	assert.NoError(t, err)
+	assert.Equal(t, outputSettings{verbosity: verbosityBasic}, settings)
+
+	settings, err = newOutputSettings(&Config{LogLevel: "DEBUG"})
+	assert.NoError(t, err)
+	assert.Equal(t, outputSettings{verbosity: verbosityDetailed}, settings)
+
+	settings, err = newOutputSettings(&Config{LogLevel: "debug", Verbosity: "Normal", Encoding: "json", MaxItemsPerSecond: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, outputSettings{verbosity: verbosityNormal, json: true, maxItemsPerSecond: 10}, settings)
+}
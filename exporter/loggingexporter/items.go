@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingexporter
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// The items are the structured fields of the spans, metrics and log records logged with the json encoding. The normal
+// verbosity logs their main fields, the detailed verbosity all of them.
+
+func formatTimestamp(ts pdata.Timestamp) string {
+	return ts.AsTime().UTC().Format(time.RFC3339Nano)
+}
+
+func resourceFields(item map[string]interface{}, resource pdata.Resource, il pdata.InstrumentationLibrary) {
+	item["resource"] = tracetranslator.AttributeMapToMap(resource.Attributes())
+	item["instrumentation_library"] = map[string]interface{}{
+		"name":    il.Name(),
+		"version": il.Version(),
+	}
+}
+
+func spanItem(span pdata.Span, resource pdata.Resource, il pdata.InstrumentationLibrary, detailed bool) map[string]interface{} {
+	item := map[string]interface{}{
+		"trace_id":    span.TraceID().HexString(),
+		"span_id":     span.SpanID().HexString(),
+		"name":        span.Name(),
+		"kind":        span.Kind().String(),
+		"duration":    span.EndTime().AsTime().Sub(span.StartTime().AsTime()).String(),
+		"status_code": span.Status().Code().String(),
+	}
+	if !detailed {
+		return item
+	}
+
+	resourceFields(item, resource, il)
+	item["parent_span_id"] = span.ParentSpanID().HexString()
+	item["trace_state"] = string(span.TraceState())
+	item["start_time"] = formatTimestamp(span.StartTime())
+	item["end_time"] = formatTimestamp(span.EndTime())
+	item["status_message"] = span.Status().Message()
+	item["attributes"] = tracetranslator.AttributeMapToMap(span.Attributes())
+
+	events := make([]map[string]interface{}, 0, span.Events().Len())
+	for i := 0; i < span.Events().Len(); i++ {
+		e := span.Events().At(i)
+		events = append(events, map[string]interface{}{
+			"name":       e.Name(),
+			"timestamp":  formatTimestamp(e.Timestamp()),
+			"attributes": tracetranslator.AttributeMapToMap(e.Attributes()),
+		})
+	}
+	item["events"] = events
+
+	links := make([]map[string]interface{}, 0, span.Links().Len())
+	for i := 0; i < span.Links().Len(); i++ {
+		l := span.Links().At(i)
+		links = append(links, map[string]interface{}{
+			"trace_id":    l.TraceID().HexString(),
+			"span_id":     l.SpanID().HexString(),
+			"trace_state": string(l.TraceState()),
+			"attributes":  tracetranslator.AttributeMapToMap(l.Attributes()),
+		})
+	}
+	item["links"] = links
+	return item
+}
+
+func metricItem(metric pdata.Metric, resource pdata.Resource, il pdata.InstrumentationLibrary, detailed bool) map[string]interface{} {
+	item := map[string]interface{}{
+		"name":        metric.Name(),
+		"type":        metric.DataType().String(),
+		"data_points": metricDataPointCount(metric),
+	}
+	if !detailed {
+		return item
+	}
+
+	resourceFields(item, resource, il)
+	item["description"] = metric.Description()
+	item["unit"] = metric.Unit()
+	item["data_points"] = metricDataPoints(metric)
+	return item
+}
+
+func metricDataPointCount(metric pdata.Metric) int {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		return metric.IntGauge().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleGauge:
+		return metric.DoubleGauge().DataPoints().Len()
+	case pdata.MetricDataTypeIntSum:
+		return metric.IntSum().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleSum:
+		return metric.DoubleSum().DataPoints().Len()
+	case pdata.MetricDataTypeIntHistogram:
+		return metric.IntHistogram().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleHistogram:
+		return metric.DoubleHistogram().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleSummary:
+		return metric.DoubleSummary().DataPoints().Len()
+	}
+	return 0
+}
+
+func dataPointItem(labels pdata.StringMap, start, timestamp pdata.Timestamp) map[string]interface{} {
+	m := make(map[string]string, labels.Len())
+	labels.ForEach(func(k string, v string) {
+		m[k] = v
+	})
+	return map[string]interface{}{
+		"labels":     m,
+		"start_time": formatTimestamp(start),
+		"timestamp":  formatTimestamp(timestamp),
+	}
+}
+
+func metricDataPoints(metric pdata.Metric) []map[string]interface{} {
+	points := make([]map[string]interface{}, 0, metricDataPointCount(metric))
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		points = appendIntDataPoints(points, metric.IntGauge().DataPoints())
+	case pdata.MetricDataTypeDoubleGauge:
+		points = appendDoubleDataPoints(points, metric.DoubleGauge().DataPoints())
+	case pdata.MetricDataTypeIntSum:
+		points = appendIntDataPoints(points, metric.IntSum().DataPoints())
+	case pdata.MetricDataTypeDoubleSum:
+		points = appendDoubleDataPoints(points, metric.DoubleSum().DataPoints())
+	case pdata.MetricDataTypeIntHistogram:
+		ps := metric.IntHistogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			point := dataPointItem(p.LabelsMap(), p.StartTime(), p.Timestamp())
+			point["count"] = p.Count()
+			point["sum"] = p.Sum()
+			point["explicit_bounds"] = p.ExplicitBounds()
+			point["bucket_counts"] = p.BucketCounts()
+			points = append(points, point)
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		ps := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			point := dataPointItem(p.LabelsMap(), p.StartTime(), p.Timestamp())
+			point["count"] = p.Count()
+			point["sum"] = p.Sum()
+			point["explicit_bounds"] = p.ExplicitBounds()
+			point["bucket_counts"] = p.BucketCounts()
+			points = append(points, point)
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		ps := metric.DoubleSummary().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			point := dataPointItem(p.LabelsMap(), p.StartTime(), p.Timestamp())
+			point["count"] = p.Count()
+			point["sum"] = p.Sum()
+			quantiles := make([]map[string]float64, 0, p.QuantileValues().Len())
+			for j := 0; j < p.QuantileValues().Len(); j++ {
+				q := p.QuantileValues().At(j)
+				quantiles = append(quantiles, map[string]float64{"quantile": q.Quantile(), "value": q.Value()})
+			}
+			point["quantiles"] = quantiles
+			points = append(points, point)
+		}
+	}
+	return points
+}
+
+func appendIntDataPoints(points []map[string]interface{}, ps pdata.IntDataPointSlice) []map[string]interface{} {
+	for i := 0; i < ps.Len(); i++ {
+		p := ps.At(i)
+		point := dataPointItem(p.LabelsMap(), p.StartTime(), p.Timestamp())
+		point["value"] = p.Value()
+		points = append(points, point)
+	}
+	return points
+}
+
+func appendDoubleDataPoints(points []map[string]interface{}, ps pdata.DoubleDataPointSlice) []map[string]interface{} {
+	for i := 0; i < ps.Len(); i++ {
+		p := ps.At(i)
+		point := dataPointItem(p.LabelsMap(), p.StartTime(), p.Timestamp())
+		point["value"] = p.Value()
+		points = append(points, point)
+	}
+	return points
+}
+
+func logItem(lr pdata.LogRecord, resource pdata.Resource, il pdata.InstrumentationLibrary, detailed bool) map[string]interface{} {
+	item := map[string]interface{}{
+		"timestamp": formatTimestamp(lr.Timestamp()),
+		"severity":  lr.SeverityText(),
+		"body":      tracetranslator.AttributeValueToString(lr.Body(), false),
+	}
+	if !detailed {
+		return item
+	}
+
+	resourceFields(item, resource, il)
+	item["severity_number"] = int32(lr.SeverityNumber())
+	item["name"] = lr.Name()
+	item["trace_id"] = lr.TraceID().HexString()
+	item["span_id"] = lr.SpanID().HexString()
+	item["attributes"] = tracetranslator.AttributeMapToMap(lr.Attributes())
+	return item
+}
@@ -314,56 +314,114 @@ func attributeMapToString(av pdata.AttributeMap) string {
 	return b.String()
 }
 
+const (
+	verbosityBasic    = "basic"
+	verbosityNormal   = "normal"
+	verbosityDetailed = "detailed"
+
+	encodingConsole = "console"
+	encodingJSON    = "json"
+)
+
+// outputSettings defines what the exporter logs for each batch.
+type outputSettings struct {
+	verbosity         string
+	json              bool
+	maxItemsPerSecond int
+}
+
 type loggingExporter struct {
-	logger *zap.Logger
-	debug  bool
+	logger   *zap.Logger
+	settings outputSettings
+	sampler  *itemSampler
+}
+
+func newLoggingExporter(settings outputSettings, logger *zap.Logger) *loggingExporter {
+	return &loggingExporter{
+		logger:   logger,
+		settings: settings,
+		sampler:  newItemSampler(settings.maxItemsPerSecond),
+	}
+}
+
+// log logs the entry of a batch, followed by the console details of its items if any.
+func (s *loggingExporter) log(msg string, fields []zap.Field, sampledOut int, details string) {
+	if sampledOut > 0 {
+		fields = append(fields, zap.Int("#sampled_out", sampledOut))
+	}
+	s.logger.Info(msg, fields...)
+	if details != "" {
+		s.logger.Info(details)
+	}
 }
 
 func (s *loggingExporter) pushTraceData(
 	_ context.Context,
 	td pdata.Traces,
 ) (int, error) {
-
-	s.logger.Info("TracesExporter", zap.Int("#spans", td.SpanCount()))
-
-	if !s.debug {
+	fields := []zap.Field{zap.Int("#spans", td.SpanCount())}
+	if s.settings.verbosity == verbosityBasic {
+		s.logger.Info("TracesExporter", fields...)
 		return 0, nil
 	}
 
+	detailed := s.settings.verbosity == verbosityDetailed
+	sampledOut := 0
+	var items []map[string]interface{}
 	buf := logDataBuffer{}
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
-		buf.logEntry("ResourceSpans #%d", i)
 		rs := rss.At(i)
-		buf.logAttributeMap("Resource labels", rs.Resource().Attributes())
+		if detailed && !s.settings.json {
+			buf.logEntry("ResourceSpans #%d", i)
+			buf.logAttributeMap("Resource labels", rs.Resource().Attributes())
+		}
 		ilss := rs.InstrumentationLibrarySpans()
 		for j := 0; j < ilss.Len(); j++ {
-			buf.logEntry("InstrumentationLibrarySpans #%d", j)
 			ils := ilss.At(j)
-			buf.logInstrumentationLibrary(ils.InstrumentationLibrary())
+			if detailed && !s.settings.json {
+				buf.logEntry("InstrumentationLibrarySpans #%d", j)
+				buf.logInstrumentationLibrary(ils.InstrumentationLibrary())
+			}
 
 			spans := ils.Spans()
 			for k := 0; k < spans.Len(); k++ {
-				buf.logEntry("Span #%d", k)
 				span := spans.At(k)
-				buf.logAttr("Trace ID", span.TraceID().HexString())
-				buf.logAttr("Parent ID", span.ParentSpanID().HexString())
-				buf.logAttr("ID", span.SpanID().HexString())
-				buf.logAttr("Name", span.Name())
-				buf.logAttr("Kind", span.Kind().String())
-				buf.logAttr("Start time", span.StartTime().String())
-				buf.logAttr("End time", span.EndTime().String())
-
-				buf.logAttr("Status code", span.Status().Code().String())
-				buf.logAttr("Status message", span.Status().Message())
-
-				buf.logAttributeMap("Attributes", span.Attributes())
-				buf.logEvents("Events", span.Events())
-				buf.logLinks("Links", span.Links())
+				if !s.sampler.allow() {
+					sampledOut++
+					continue
+				}
+				switch {
+				case s.settings.json:
+					items = append(items, spanItem(span, rs.Resource(), ils.InstrumentationLibrary(), detailed))
+				case detailed:
+					buf.logEntry("Span #%d", k)
+					buf.logAttr("Trace ID", span.TraceID().HexString())
+					buf.logAttr("Parent ID", span.ParentSpanID().HexString())
+					buf.logAttr("ID", span.SpanID().HexString())
+					buf.logAttr("Name", span.Name())
+					buf.logAttr("Kind", span.Kind().String())
+					buf.logAttr("Start time", span.StartTime().String())
+					buf.logAttr("End time", span.EndTime().String())
+
+					buf.logAttr("Status code", span.Status().Code().String())
+					buf.logAttr("Status message", span.Status().Message())
+
+					buf.logAttributeMap("Attributes", span.Attributes())
+					buf.logEvents("Events", span.Events())
+					buf.logLinks("Links", span.Links())
+				default:
+					buf.logEntry("Span #%d trace_id=%s span_id=%s name=%q kind=%s status=%s duration=%s",
+						k, span.TraceID().HexString(), span.SpanID().HexString(), span.Name(), span.Kind().String(),
+						span.Status().Code().String(), span.EndTime().AsTime().Sub(span.StartTime().AsTime()))
+				}
 			}
 		}
 	}
-	s.logger.Debug(buf.str.String())
+	if items != nil {
+		fields = append(fields, zap.Any("spans", items))
+	}
+	s.log("TracesExporter", fields, sampledOut, buf.str.String())
 
 	return 0, nil
 }
@@ -372,45 +430,63 @@ func (s *loggingExporter) pushMetricsData(
 	_ context.Context,
 	md pdata.Metrics,
 ) (int, error) {
-	s.logger.Info("MetricsExporter", zap.Int("#metrics", md.MetricCount()))
-
-	if !s.debug {
+	fields := []zap.Field{zap.Int("#metrics", md.MetricCount())}
+	if s.settings.verbosity == verbosityBasic {
+		s.logger.Info("MetricsExporter", fields...)
 		return 0, nil
 	}
 
+	detailed := s.settings.verbosity == verbosityDetailed
+	sampledOut := 0
+	var items []map[string]interface{}
 	buf := logDataBuffer{}
 	rms := md.ResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
-		buf.logEntry("ResourceMetrics #%d", i)
 		rm := rms.At(i)
-		buf.logAttributeMap("Resource labels", rm.Resource().Attributes())
+		if detailed && !s.settings.json {
+			buf.logEntry("ResourceMetrics #%d", i)
+			buf.logAttributeMap("Resource labels", rm.Resource().Attributes())
+		}
 		ilms := rm.InstrumentationLibraryMetrics()
 		for j := 0; j < ilms.Len(); j++ {
-			buf.logEntry("InstrumentationLibraryMetrics #%d", j)
 			ilm := ilms.At(j)
-			buf.logInstrumentationLibrary(ilm.InstrumentationLibrary())
+			if detailed && !s.settings.json {
+				buf.logEntry("InstrumentationLibraryMetrics #%d", j)
+				buf.logInstrumentationLibrary(ilm.InstrumentationLibrary())
+			}
 			metrics := ilm.Metrics()
 			for k := 0; k < metrics.Len(); k++ {
-				buf.logEntry("Metric #%d", k)
 				metric := metrics.At(k)
-				buf.logMetricDescriptor(metric)
-				buf.logMetricDataPoints(metric)
+				if !s.sampler.allow() {
+					sampledOut++
+					continue
+				}
+				switch {
+				case s.settings.json:
+					items = append(items, metricItem(metric, rm.Resource(), ilm.InstrumentationLibrary(), detailed))
+				case detailed:
+					buf.logEntry("Metric #%d", k)
+					buf.logMetricDescriptor(metric)
+					buf.logMetricDataPoints(metric)
+				default:
+					buf.logEntry("Metric #%d name=%q type=%s data_points=%d",
+						k, metric.Name(), metric.DataType().String(), metricDataPointCount(metric))
+				}
 			}
 		}
 	}
-
-	s.logger.Debug(buf.str.String())
+	if items != nil {
+		fields = append(fields, zap.Any("metrics", items))
+	}
+	s.log("MetricsExporter", fields, sampledOut, buf.str.String())
 
 	return 0, nil
 }
 
 // newTraceExporter creates an exporter.TracesExporter that just drops the
 // received data and logs debugging messages.
-func newTraceExporter(config configmodels.Exporter, level string, logger *zap.Logger) (component.TracesExporter, error) {
-	s := &loggingExporter{
-		debug:  strings.ToLower(level) == "debug",
-		logger: logger,
-	}
+func newTraceExporter(config configmodels.Exporter, settings outputSettings, logger *zap.Logger) (component.TracesExporter, error) {
+	s := newLoggingExporter(settings, logger)
 
 	return exporterhelper.NewTraceExporter(
 		config,
@@ -426,11 +502,8 @@ func newTraceExporter(config configmodels.Exporter, level string, logger *zap.Lo
 
 // newMetricsExporter creates an exporter.MetricsExporter that just drops the
 // received data and logs debugging messages.
-func newMetricsExporter(config configmodels.Exporter, level string, logger *zap.Logger) (component.MetricsExporter, error) {
-	s := &loggingExporter{
-		debug:  strings.ToLower(level) == "debug",
-		logger: logger,
-	}
+func newMetricsExporter(config configmodels.Exporter, settings outputSettings, logger *zap.Logger) (component.MetricsExporter, error) {
+	s := newLoggingExporter(settings, logger)
 
 	return exporterhelper.NewMetricsExporter(
 		config,
@@ -446,11 +519,8 @@ func newMetricsExporter(config configmodels.Exporter, level string, logger *zap.
 
 // newLogsExporter creates an exporter.LogsExporter that just drops the
 // received data and logs debugging messages.
-func newLogsExporter(config configmodels.Exporter, level string, logger *zap.Logger) (component.LogsExporter, error) {
-	s := &loggingExporter{
-		debug:  strings.ToLower(level) == "debug",
-		logger: logger,
-	}
+func newLogsExporter(config configmodels.Exporter, settings outputSettings, logger *zap.Logger) (component.LogsExporter, error) {
+	s := newLoggingExporter(settings, logger)
 
 	return exporterhelper.NewLogsExporter(
 		config,
@@ -468,34 +538,55 @@ func (s *loggingExporter) pushLogData(
 	_ context.Context,
 	ld pdata.Logs,
 ) (int, error) {
-	s.logger.Info("LogsExporter", zap.Int("#logs", ld.LogRecordCount()))
-
-	if !s.debug {
+	fields := []zap.Field{zap.Int("#logs", ld.LogRecordCount())}
+	if s.settings.verbosity == verbosityBasic {
+		s.logger.Info("LogsExporter", fields...)
 		return 0, nil
 	}
 
+	detailed := s.settings.verbosity == verbosityDetailed
+	sampledOut := 0
+	var items []map[string]interface{}
 	buf := logDataBuffer{}
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
-		buf.logEntry("ResourceLog #%d", i)
 		rl := rls.At(i)
-		buf.logAttributeMap("Resource labels", rl.Resource().Attributes())
+		if detailed && !s.settings.json {
+			buf.logEntry("ResourceLog #%d", i)
+			buf.logAttributeMap("Resource labels", rl.Resource().Attributes())
+		}
 		ills := rl.InstrumentationLibraryLogs()
 		for j := 0; j < ills.Len(); j++ {
-			buf.logEntry("InstrumentationLibraryLogs #%d", j)
 			ils := ills.At(j)
-			buf.logInstrumentationLibrary(ils.InstrumentationLibrary())
+			if detailed && !s.settings.json {
+				buf.logEntry("InstrumentationLibraryLogs #%d", j)
+				buf.logInstrumentationLibrary(ils.InstrumentationLibrary())
+			}
 
 			logs := ils.Logs()
 			for k := 0; k < logs.Len(); k++ {
-				buf.logEntry("LogRecord #%d", k)
 				lr := logs.At(k)
-				buf.logLogRecord(lr)
+				if !s.sampler.allow() {
+					sampledOut++
+					continue
+				}
+				switch {
+				case s.settings.json:
+					items = append(items, logItem(lr, rl.Resource(), ils.InstrumentationLibrary(), detailed))
+				case detailed:
+					buf.logEntry("LogRecord #%d", k)
+					buf.logLogRecord(lr)
+				default:
+					buf.logEntry("LogRecord #%d timestamp=%s severity=%s body=%q",
+						k, formatTimestamp(lr.Timestamp()), lr.SeverityText(), attributeValueToString(lr.Body()))
+				}
 			}
 		}
 	}
-
-	s.logger.Debug(buf.str.String())
+	if items != nil {
+		fields = append(fields, zap.Any("logs", items))
+	}
+	s.log("LogsExporter", fields, sampledOut, buf.str.String())
 
 	return 0, nil
 }
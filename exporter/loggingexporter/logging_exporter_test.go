@@ -15,11 +15,15 @@ package loggingexporter
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -27,7 +31,7 @@ import (
 )
 
 func TestLoggingTraceExporterNoErrors(t *testing.T) {
-	lte, err := newTraceExporter(&configmodels.ExporterSettings{}, "Debug", zap.NewNop())
+	lte, err := newTraceExporter(&configmodels.ExporterSettings{}, outputSettings{verbosity: verbosityDetailed}, zap.NewNop())
 	require.NotNil(t, lte)
 	assert.NoError(t, err)
 
@@ -38,7 +42,7 @@ func TestLoggingTraceExporterNoErrors(t *testing.T) {
 }
 
 func TestLoggingMetricsExporterNoErrors(t *testing.T) {
-	lme, err := newMetricsExporter(&configmodels.ExporterSettings{}, "DEBUG", zap.NewNop())
+	lme, err := newMetricsExporter(&configmodels.ExporterSettings{}, outputSettings{verbosity: verbosityDetailed}, zap.NewNop())
 	require.NotNil(t, lme)
 	assert.NoError(t, err)
 
@@ -51,7 +55,7 @@ func TestLoggingMetricsExporterNoErrors(t *testing.T) {
 }
 
 func TestLoggingLogsExporterNoErrors(t *testing.T) {
-	lle, err := newLogsExporter(&configmodels.ExporterSettings{}, "debug", zap.NewNop())
+	lle, err := newLogsExporter(&configmodels.ExporterSettings{}, outputSettings{verbosity: verbosityDetailed}, zap.NewNop())
 	require.NotNil(t, lle)
 	assert.NoError(t, err)
 
@@ -97,3 +101,103 @@ func TestNestedMapSerializesCorrectly(t *testing.T) {
 	assert.Equal(t, 2, ava.MapVal().Len())
 	assert.Equal(t, expected, attributeValueToString(ava))
 }
+
+func TestLoggingExporterVerbosity(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings outputSettings
+		entries  int
+		details  string
+	}{
+		{
+			name:     "basic",
+			settings: outputSettings{verbosity: verbosityBasic},
+			entries:  1,
+		},
+		{
+			name:     "normal",
+			settings: outputSettings{verbosity: verbosityNormal},
+			entries:  2,
+			details:  "Span #0 trace_id=0102030405060708080706050403020a span_id=1112131415161718 name=\"operationA\"",
+		},
+		{
+			name:     "detailed",
+			settings: outputSettings{verbosity: verbosityDetailed},
+			entries:  2,
+			details:  "Status message : status-cancelled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			lte, err := newTraceExporter(&configmodels.ExporterSettings{}, tt.settings, zap.New(core))
+			require.NoError(t, err)
+
+			td := testdata.GenerateTraceDataOneSpan()
+			td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).SetTraceID(
+				pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 10}))
+			td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).SetSpanID(
+				pdata.NewSpanID([8]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18}))
+			require.NoError(t, lte.ConsumeTraces(context.Background(), td))
+
+			entries := logs.AllUntimed()
+			require.Len(t, entries, tt.entries)
+			assert.Equal(t, "TracesExporter", entries[0].Message)
+			assert.EqualValues(t, 1, entries[0].ContextMap()["#spans"])
+			if tt.details != "" {
+				assert.Contains(t, entries[1].Message, tt.details)
+			}
+		})
+	}
+}
+
+func TestLoggingExporterJSON(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	lle, err := newLogsExporter(&configmodels.ExporterSettings{}, outputSettings{verbosity: verbosityDetailed, json: true}, zap.New(core))
+	require.NoError(t, err)
+	require.NoError(t, lle.ConsumeLogs(context.Background(), testdata.GenerateLogDataTwoLogsSameResource()))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	items, ok := entries[0].ContextMap()["logs"].([]map[string]interface{})
+	require.True(t, ok)
+	require.Len(t, items, 2)
+	item := items[0]
+	assert.Equal(t, "2020-02-11T20:26:13.000000789Z", item["timestamp"])
+	assert.Equal(t, "Info", item["severity"])
+	assert.Equal(t, "This is a log message", item["body"])
+	assert.Equal(t, "logA", item["name"])
+	assert.Equal(t, map[string]interface{}{"resource-attr": "resource-attr-val-1"}, item["resource"])
+}
+
+func TestLoggingExporterMaxItemsPerSecond(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	lme, err := newMetricsExporter(&configmodels.ExporterSettings{}, outputSettings{verbosity: verbosityNormal, maxItemsPerSecond: 1}, zap.New(core))
+	require.NoError(t, err)
+	require.NoError(t, lme.ConsumeMetrics(context.Background(), testdata.GeneratMetricsAllTypesWithSampleDatapoints()))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	md := testdata.GeneratMetricsAllTypesWithSampleDatapoints()
+	assert.EqualValues(t, md.MetricCount()-1, entries[0].ContextMap()["#sampled_out"])
+	assert.Equal(t, 1, strings.Count(entries[1].Message, "\n"))
+}
+
+func TestItemSampler(t *testing.T) {
+	now := time.Unix(100, 0)
+	s := newItemSampler(2)
+	s.now = func() time.Time { return now }
+
+	assert.True(t, s.allow())
+	assert.True(t, s.allow())
+	assert.False(t, s.allow())
+
+	now = now.Add(time.Second)
+	assert.True(t, s.allow())
+
+	unlimited := newItemSampler(0)
+	for i := 0; i < 100; i++ {
+		assert.True(t, unlimited.allow())
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingexporter
+
+import (
+	"sync"
+	"time"
+)
+
+// itemSampler limits the number of items logged each second.
+type itemSampler struct {
+	max int
+	now func() time.Time
+
+	mu     sync.Mutex
+	second int64
+	count  int
+}
+
+func newItemSampler(max int) *itemSampler {
+	return &itemSampler{max: max, now: time.Now}
+}
+
+// allow returns whether the item can be logged, the items are allowed until the maximum of the current second is
+// reached.
+func (s *itemSampler) allow() bool {
+	if s.max <= 0 {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if second := s.now().Unix(); second != s.second {
+		s.second = second
+		s.count = 0
+	}
+	if s.count >= s.max {
+		return false
+	}
+	s.count++
+	return true
+}
@@ -55,16 +55,22 @@ func TestLoadConfig(t *testing.T) {
 				Timeout: 10 * time.Second,
 			},
 			RetrySettings: exporterhelper.RetrySettings{
-				Enabled:         true,
-				InitialInterval: 10 * time.Second,
-				MaxInterval:     1 * time.Minute,
-				MaxElapsedTime:  10 * time.Minute,
+				Enabled:             true,
+				InitialInterval:     10 * time.Second,
+				MaxInterval:         1 * time.Minute,
+				MaxElapsedTime:      10 * time.Minute,
+				RandomizationFactor: 0.5,
 			},
 			QueueSettings: exporterhelper.QueueSettings{
 				Enabled:      true,
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
+			CircuitBreakerSettings: exporterhelper.CircuitBreakerSettings{
+				Enabled:          true,
+				FailureThreshold: 10,
+				CoolDown:         time.Minute,
+			},
 			GRPCClientSettings: configgrpc.GRPCClientSettings{
 				Headers: map[string]string{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
@@ -93,3 +99,13 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestLoadConfig_InvalidRandomizationFactor(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factories.Exporters[typeStr] = NewFactory()
+	_, err = configtest.LoadConfigFile(t, path.Join(".", "testdata", "invalid-randomization-factor.yaml"), factories)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "randomization_factor must be between 0 and 1")
+}
@@ -18,6 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -36,6 +39,10 @@ import (
 	otlptrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
 )
 
+// headerRetryAfter is the response metadata key of the delay after which the
+// server asks to retry, in seconds or as an HTTP date.
+const headerRetryAfter = "retry-after"
+
 type exporterImp struct {
 	// Input configuration.
 	config *Config
@@ -138,18 +145,21 @@ func (gs *grpcSender) stop() error {
 }
 
 func (gs *grpcSender) exportTrace(ctx context.Context, request *otlptrace.ExportTraceServiceRequest) error {
-	_, err := gs.traceExporter.Export(gs.enhanceContext(ctx), request, grpc.WaitForReady(gs.waitForReady))
-	return processError(err)
+	var header, trailer metadata.MD
+	_, err := gs.traceExporter.Export(gs.enhanceContext(ctx), request, grpc.WaitForReady(gs.waitForReady), grpc.Header(&header), grpc.Trailer(&trailer))
+	return processError(err, metadata.Join(header, trailer))
 }
 
 func (gs *grpcSender) exportMetrics(ctx context.Context, request *otlpmetrics.ExportMetricsServiceRequest) error {
-	_, err := gs.metricExporter.Export(gs.enhanceContext(ctx), request, grpc.WaitForReady(gs.waitForReady))
-	return processError(err)
+	var header, trailer metadata.MD
+	_, err := gs.metricExporter.Export(gs.enhanceContext(ctx), request, grpc.WaitForReady(gs.waitForReady), grpc.Header(&header), grpc.Trailer(&trailer))
+	return processError(err, metadata.Join(header, trailer))
 }
 
 func (gs *grpcSender) exportLogs(ctx context.Context, request *otlplogs.ExportLogsServiceRequest) error {
-	_, err := gs.logExporter.Export(gs.enhanceContext(ctx), request, grpc.WaitForReady(gs.waitForReady))
-	return processError(err)
+	var header, trailer metadata.MD
+	_, err := gs.logExporter.Export(gs.enhanceContext(ctx), request, grpc.WaitForReady(gs.waitForReady), grpc.Header(&header), grpc.Trailer(&trailer))
+	return processError(err, metadata.Join(header, trailer))
 }
 
 func (gs *grpcSender) enhanceContext(ctx context.Context) context.Context {
@@ -161,8 +171,9 @@ func (gs *grpcSender) enhanceContext(ctx context.Context) context.Context {
 
 // Send a trace or metrics request to the server. "perform" function is expected to make
 // the actual gRPC unary call that sends the request. This function implements the
-// common OTLP logic around request handling such as retries and throttling.
-func processError(err error) error {
+// common OTLP logic around request handling such as retries and throttling, md being
+// the metadata of the response.
+func processError(err error, md metadata.MD) error {
 	if err == nil {
 		// Request is successful, we are done.
 		return nil
@@ -185,12 +196,22 @@ func processError(err error) error {
 
 	// Need to retry.
 
-	// Check if server returned throttling information.
+	// Check if server returned throttling information, in the status details
+	// or else in the response metadata.
 	throttleDuration := getThrottleDuration(st)
+	if throttleDuration == 0 {
+		throttleDuration = getRetryAfter(md, time.Now())
+	}
 	if throttleDuration != 0 {
 		return exporterhelper.NewThrottleRetry(err, throttleDuration)
 	}
 
+	if st.Code() == codes.ResourceExhausted {
+		// The server is overloaded without telling for how long, retry with
+		// the backoff of the throttled requests.
+		return exporterhelper.NewThrottleRetry(err, 0)
+	}
+
 	return err
 }
 
@@ -242,3 +263,24 @@ func getThrottleDuration(status *status.Status) time.Duration {
 	}
 	return 0
 }
+
+// getRetryAfter returns the delay of the retry-after response metadata, given
+// in seconds or as an HTTP date, 0 if it is absent or invalid.
+func getRetryAfter(md metadata.MD, now time.Time) time.Duration {
+	for _, val := range md.Get(headerRetryAfter) {
+		val = strings.TrimSpace(val)
+		if seconds, err := strconv.Atoi(val); err == nil {
+			if seconds > 0 {
+				return time.Duration(seconds) * time.Second
+			}
+			return 0
+		}
+		if date, err := http.ParseTime(val); err == nil {
+			if delay := date.Sub(now); delay > 0 {
+				return delay
+			}
+			return 0
+		}
+	}
+	return 0
+}
@@ -16,7 +16,9 @@ package otlpexporter
 
 import (
 	"context"
+	"errors"
 	"net"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -25,14 +27,20 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	otlplogs "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
 	otlpmetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
 	otlptraces "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
@@ -58,6 +66,9 @@ func (r *mockReceiver) GetMetadata() metadata.MD {
 type mockTraceReceiver struct {
 	mockReceiver
 	lastRequest *otlptraces.ExportTraceServiceRequest
+	// exportErr and trailer are returned by Export when set.
+	exportErr error
+	trailer   metadata.MD
 }
 
 func (r *mockTraceReceiver) Export(
@@ -76,6 +87,12 @@ func (r *mockTraceReceiver) Export(
 	defer r.mux.Unlock()
 	r.lastRequest = req
 	r.metadata, _ = metadata.FromIncomingContext(ctx)
+	if r.trailer != nil {
+		_ = grpc.SetTrailer(ctx, r.trailer)
+	}
+	if r.exportErr != nil {
+		return nil, r.exportErr
+	}
 	return &otlptraces.ExportTraceServiceResponse{}, nil
 }
 
@@ -533,3 +550,101 @@ func TestSendLogData(t *testing.T) {
 	assert.EqualValues(t, 2, atomic.LoadInt32(&rcv.totalItems))
 	assert.EqualValues(t, expectedOTLPReq, rcv.GetLastRequest())
 }
+
+func TestProcessError(t *testing.T) {
+	retryInfo, err := status.New(codes.Unavailable, "unavailable").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(1500 * time.Millisecond),
+	})
+	require.NoError(t, err)
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+	tests := []struct {
+		name      string
+		err       error
+		md        metadata.MD
+		wantClass exporterhelper.ErrorClass
+		wantDelay time.Duration
+	}{
+		{
+			name:      "permanent",
+			err:       status.Error(codes.InvalidArgument, "bad data"),
+			md:        metadata.Pairs(headerRetryAfter, "3"),
+			wantClass: exporterhelper.ErrorClassPermanent,
+		},
+		{
+			name:      "unavailable",
+			err:       status.Error(codes.Unavailable, "unavailable"),
+			wantClass: exporterhelper.ErrorClassTransient,
+		},
+		{
+			name:      "retry info",
+			err:       retryInfo.Err(),
+			md:        metadata.Pairs(headerRetryAfter, "3"),
+			wantClass: exporterhelper.ErrorClassThrottled,
+			wantDelay: 1500 * time.Millisecond,
+		},
+		{
+			name:      "retry-after seconds",
+			err:       status.Error(codes.Unavailable, "unavailable"),
+			md:        metadata.Pairs(headerRetryAfter, "3"),
+			wantClass: exporterhelper.ErrorClassThrottled,
+			wantDelay: 3 * time.Second,
+		},
+		{
+			name:      "invalid retry-after",
+			err:       status.Error(codes.Unavailable, "unavailable"),
+			md:        metadata.Pairs(headerRetryAfter, "soon"),
+			wantClass: exporterhelper.ErrorClassTransient,
+		},
+		{
+			name:      "resource exhausted",
+			err:       status.Error(codes.ResourceExhausted, "overloaded"),
+			wantClass: exporterhelper.ErrorClassThrottled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class, delay := exporterhelper.DefaultErrorClassifier(processError(tt.err, tt.md))
+			assert.Equal(t, tt.wantClass, class)
+			assert.Equal(t, tt.wantDelay, delay)
+		})
+	}
+
+	assert.NoError(t, processError(nil, nil))
+	assert.True(t, consumererror.IsPermanent(processError(errors.New("not a status"), nil)))
+
+	// The delay of an HTTP date is relative to the current time.
+	_, delay := exporterhelper.DefaultErrorClassifier(
+		processError(status.Error(codes.ResourceExhausted, "overloaded"), metadata.Pairs(headerRetryAfter, date)))
+	assert.InDelta(t, time.Hour.Seconds(), delay.Seconds(), 5)
+}
+
+func TestSendTraceDataThrottled(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv := otlpTraceReceiverOnGRPCServer(ln)
+	defer rcv.srv.GracefulStop()
+	rcv.exportErr = status.Error(codes.Unavailable, "overloaded")
+	rcv.trailer = metadata.Pairs(headerRetryAfter, "2")
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	}()
+
+	_, err = exp.pushTraceData(context.Background(), testdata.GenerateTraceDataOneSpan())
+	require.Error(t, err)
+	class, delay := exporterhelper.DefaultErrorClassifier(err)
+	assert.Equal(t, exporterhelper.ErrorClassThrottled, class)
+	assert.Equal(t, 2*time.Second, delay)
+}
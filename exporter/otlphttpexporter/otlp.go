@@ -52,6 +52,8 @@ type exporterImp struct {
 const (
 	headerRetryAfter         = "Retry-After"
 	maxHTTPResponseReadBytes = 64 * 1024
+
+	compressionZstd = "zstd"
 )
 
 // Crete new exporter.
@@ -70,12 +72,14 @@ func newExporter(cfg configmodels.Exporter, logger *zap.Logger) (*exporterImp, e
 		return nil, err
 	}
 
-	if oCfg.Compression != "" {
-		if strings.ToLower(oCfg.Compression) == configgrpc.CompressionGzip {
-			client.Transport = middleware.NewCompressRoundTripper(client.Transport)
-		} else {
-			return nil, fmt.Errorf("unsupported compression type %q", oCfg.Compression)
-		}
+	switch strings.ToLower(oCfg.Compression) {
+	case "":
+	case configgrpc.CompressionGzip:
+		client.Transport = middleware.NewCompressRoundTripper(client.Transport)
+	case compressionZstd:
+		client.Transport = middleware.NewZstdCompressRoundTripper(client.Transport)
+	default:
+		return nil, fmt.Errorf("unsupported compression type %q", oCfg.Compression)
 	}
 
 	return &exporterImp{
@@ -149,6 +149,11 @@ func TestCompressionOptions(t *testing.T) {
 			baseURL:     fmt.Sprintf("http://%s", addr),
 			compression: "gzip",
 		},
+		{
+			name:        "zstd",
+			baseURL:     fmt.Sprintf("http://%s", addr),
+			compression: "zstd",
+		},
 		{
 			name:        "incorrect compression",
 			baseURL:     fmt.Sprintf("http://%s", addr),
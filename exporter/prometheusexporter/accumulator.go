@@ -51,6 +51,9 @@ type lastValueAccumulator struct {
 	// metricExpiration contains duration for which metric
 	// should be served after it was stored
 	metricExpiration time.Duration
+
+	// now returns the current time, replaced in the tests.
+	now func() time.Time
 }
 
 // NewAccumulator returns LastValueAccumulator
@@ -58,6 +61,7 @@ func newAccumulator(logger *zap.Logger, metricExpiration time.Duration) accumula
 	return &lastValueAccumulator{
 		logger:           logger,
 		metricExpiration: metricExpiration,
+		now:              time.Now,
 	}
 }
 
@@ -110,7 +114,7 @@ func (a *lastValueAccumulator) accumulateIntGauge(metric pdata.Metric, il pdata.
 		if !ok {
 			m := createMetric(metric)
 			m.IntGauge().DataPoints().Append(ip)
-			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 			n++
 			continue
 		}
@@ -123,7 +127,7 @@ func (a *lastValueAccumulator) accumulateIntGauge(metric pdata.Metric, il pdata.
 
 		m := createMetric(metric)
 		m.IntGauge().DataPoints().Append(ip)
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 		n++
 	}
 	return
@@ -141,7 +145,7 @@ func (a *lastValueAccumulator) accumulateDoubleGauge(metric pdata.Metric, il pda
 		if !ok {
 			m := createMetric(metric)
 			m.DoubleGauge().DataPoints().Append(ip)
-			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 			n++
 			continue
 		}
@@ -154,7 +158,7 @@ func (a *lastValueAccumulator) accumulateDoubleGauge(metric pdata.Metric, il pda
 
 		m := createMetric(metric)
 		m.DoubleGauge().DataPoints().Append(ip)
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 		n++
 	}
 	return
@@ -181,7 +185,7 @@ func (a *lastValueAccumulator) accumulateIntSum(metric pdata.Metric, il pdata.In
 			m.IntSum().SetIsMonotonic(metric.IntSum().IsMonotonic())
 			m.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 			m.IntSum().DataPoints().Append(ip)
-			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 			n++
 			continue
 		}
@@ -196,7 +200,7 @@ func (a *lastValueAccumulator) accumulateIntSum(metric pdata.Metric, il pdata.In
 		m.IntSum().SetIsMonotonic(metric.IntSum().IsMonotonic())
 		m.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		m.IntSum().DataPoints().Append(ip)
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 		n++
 	}
 	return
@@ -223,7 +227,7 @@ func (a *lastValueAccumulator) accumulateDoubleSum(metric pdata.Metric, il pdata
 			m.DoubleSum().SetIsMonotonic(metric.DoubleSum().IsMonotonic())
 			m.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 			m.DoubleSum().DataPoints().Append(ip)
-			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 			n++
 			continue
 		}
@@ -238,7 +242,7 @@ func (a *lastValueAccumulator) accumulateDoubleSum(metric pdata.Metric, il pdata
 		m.DoubleSum().SetIsMonotonic(metric.DoubleSum().IsMonotonic())
 		m.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		m.DoubleSum().DataPoints().Append(ip)
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 		n++
 	}
 	return
@@ -263,7 +267,7 @@ func (a *lastValueAccumulator) accumulateIntHistogram(metric pdata.Metric, il pd
 		if !ok {
 			m := createMetric(metric)
 			m.IntHistogram().DataPoints().Append(ip)
-			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 			n++
 			continue
 		}
@@ -277,7 +281,7 @@ func (a *lastValueAccumulator) accumulateIntHistogram(metric pdata.Metric, il pd
 		m := createMetric(metric)
 		m.IntHistogram().DataPoints().Append(ip)
 		m.IntHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 		n++
 	}
 	return
@@ -302,7 +306,7 @@ func (a *lastValueAccumulator) accumulateDoubleHistogram(metric pdata.Metric, il
 		if !ok {
 			m := createMetric(metric)
 			m.DoubleHistogram().DataPoints().Append(ip)
-			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 			n++
 			continue
 		}
@@ -316,7 +320,7 @@ func (a *lastValueAccumulator) accumulateDoubleHistogram(metric pdata.Metric, il
 		m := createMetric(metric)
 		m.DoubleHistogram().DataPoints().Append(ip)
 		m.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: time.Now()})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, instrumentationLibrary: il, stored: a.now()})
 		n++
 	}
 	return
@@ -328,9 +332,10 @@ func (a *lastValueAccumulator) Collect() []pdata.Metric {
 
 	res := make([]pdata.Metric, 0)
 
+	now := a.now()
 	a.registeredMetrics.Range(func(key, value interface{}) bool {
 		v := value.(*accumulatedValue)
-		if time.Now().After(v.stored.Add(a.metricExpiration)) {
+		if now.After(v.stored.Add(a.metricExpiration)) {
 			a.logger.Debug(fmt.Sprintf("metric expired: %s", v.value.Name()))
 			a.registeredMetrics.Delete(key)
 			return true
@@ -33,6 +33,42 @@ func TestInvalidDataType(t *testing.T) {
 	require.Zero(t, n)
 }
 
+func TestCollectExpiredMetrics(t *testing.T) {
+	a := newAccumulator(zap.NewNop(), 5*time.Minute).(*lastValueAccumulator)
+	now := time.Unix(1600000000, 0)
+	a.now = func() time.Time { return now }
+
+	gauge := func(name string) pdata.ResourceMetrics {
+		rm := pdata.NewResourceMetrics()
+		rm.InstrumentationLibraryMetrics().Resize(1)
+		ms := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+		ms.Resize(1)
+		m := ms.At(0)
+		m.SetName(name)
+		m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		m.DoubleGauge().DataPoints().Resize(1)
+		m.DoubleGauge().DataPoints().At(0).SetValue(1)
+		m.DoubleGauge().DataPoints().At(0).SetTimestamp(pdata.TimestampFromTime(now))
+		return rm
+	}
+
+	require.Equal(t, 1, a.Accumulate(gauge("updated")))
+	require.Equal(t, 1, a.Accumulate(gauge("stale")))
+	require.Len(t, a.Collect(), 2)
+
+	now = now.Add(4 * time.Minute)
+	require.Equal(t, 1, a.Accumulate(gauge("updated")))
+	require.Len(t, a.Collect(), 2)
+
+	now = now.Add(2 * time.Minute)
+	metrics := a.Collect()
+	require.Len(t, metrics, 1)
+	require.Equal(t, "updated", metrics[0].Name())
+
+	now = now.Add(5 * time.Minute)
+	require.Empty(t, a.Collect())
+}
+
 func TestAccumulateDeltaAggregation(t *testing.T) {
 	tests := []struct {
 		name   string
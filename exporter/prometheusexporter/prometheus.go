@@ -41,13 +41,19 @@ type prometheusExporter struct {
 	obsrep       *obsreport.ExporterObsReport
 }
 
-var errBlankPrometheusAddress = errors.New("expecting a non-blank address to run the Prometheus metrics handler")
+var (
+	errBlankPrometheusAddress = errors.New("expecting a non-blank address to run the Prometheus metrics handler")
+	errNonPositiveExpiration  = errors.New("expecting a positive metric_expiration")
+)
 
 func newPrometheusExporter(config *Config, logger *zap.Logger) (*prometheusExporter, error) {
 	addr := strings.TrimSpace(config.Endpoint)
 	if strings.TrimSpace(config.Endpoint) == "" {
 		return nil, errBlankPrometheusAddress
 	}
+	if config.MetricExpiration <= 0 {
+		return nil, errNonPositiveExpiration
+	}
 
 	obsrep := obsreport.NewExporterObsReport(configtelemetry.GetMetricsLevelFlagValue(), config.Name())
 
@@ -54,7 +54,8 @@ func TestPrometheusExporter(t *testing.T) {
 		},
 		{
 			config: &Config{
-				Endpoint: ":88999",
+				Endpoint:         ":88999",
+				MetricExpiration: 60 * time.Second,
 			},
 			wantStartErr: "listen tcp: address 88999: invalid port",
 		},
@@ -62,6 +63,12 @@ func TestPrometheusExporter(t *testing.T) {
 			config:  &Config{},
 			wantErr: "expecting a non-blank address to run the Prometheus metrics handler",
 		},
+		{
+			config: &Config{
+				Endpoint: ":8999",
+			},
+			wantErr: "expecting a positive metric_expiration",
+		},
 	}
 
 	factory := NewFactory()
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	common "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
+	otlp "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"
+)
+
+// accumulatedSeriesExpiration is how long the state of a delta series is kept after its last point.
+const accumulatedSeriesExpiration = 15 * time.Minute
+
+// deltaAccumulator converts the delta sums and histograms to the cumulative temporality expected by Prometheus, by
+// adding up the points of each series since the first one received.
+type deltaAccumulator struct {
+	mu     sync.Mutex
+	series map[string]*accumulatedSeries
+}
+
+// accumulatedSeries is the cumulative state of a delta series.
+type accumulatedSeries struct {
+	startTime uint64
+	lastTime  uint64
+	lastSeen  time.Time
+
+	// value and intValue are the cumulative value of a sum.
+	value    float64
+	intValue int64
+	// count, sum, intSum, bucketCounts and explicitBounds are the cumulative state of a histogram.
+	count          uint64
+	sum            float64
+	intSum         int64
+	bucketCounts   []uint64
+	explicitBounds []float64
+}
+
+func newDeltaAccumulator() *deltaAccumulator {
+	return &deltaAccumulator{series: map[string]*accumulatedSeries{}}
+}
+
+// next returns the state of the series the point belongs to, creating it for the first point of the series, or false
+// if the point is not after the last point of the series.
+func (a *deltaAccumulator) next(metric *otlp.Metric, labels []common.StringKeyValue, startTime, time uint64, now time.Time) (*accumulatedSeries, bool) {
+	key := accumulatedSeriesKey(metric, labels)
+	s, ok := a.series[key]
+	if !ok {
+		if startTime == 0 {
+			startTime = time
+		}
+		s = &accumulatedSeries{startTime: startTime}
+		a.series[key] = s
+	} else if time <= s.lastTime {
+		return nil, false
+	}
+	s.lastTime = time
+	s.lastSeen = now
+	return s, true
+}
+
+// accumulateIntSum returns a copy of the delta point with the cumulative value of its series, or false if the point
+// is out of order.
+func (a *deltaAccumulator) accumulateIntSum(metric *otlp.Metric, pt *otlp.IntDataPoint, now time.Time) (*otlp.IntDataPoint, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.next(metric, pt.Labels, pt.StartTimeUnixNano, pt.TimeUnixNano, now)
+	if !ok {
+		return nil, false
+	}
+	s.intValue += pt.Value
+	cumulative := *pt
+	cumulative.StartTimeUnixNano = s.startTime
+	cumulative.Value = s.intValue
+	return &cumulative, true
+}
+
+// accumulateDoubleSum returns a copy of the delta point with the cumulative value of its series, or false if the
+// point is out of order.
+func (a *deltaAccumulator) accumulateDoubleSum(metric *otlp.Metric, pt *otlp.DoubleDataPoint, now time.Time) (*otlp.DoubleDataPoint, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.next(metric, pt.Labels, pt.StartTimeUnixNano, pt.TimeUnixNano, now)
+	if !ok {
+		return nil, false
+	}
+	s.value += pt.Value
+	cumulative := *pt
+	cumulative.StartTimeUnixNano = s.startTime
+	cumulative.Value = s.value
+	return &cumulative, true
+}
+
+// accumulateIntHistogram returns a copy of the delta point with the cumulative counts of its series, or false if the
+// point is out of order.
+func (a *deltaAccumulator) accumulateIntHistogram(metric *otlp.Metric, pt *otlp.IntHistogramDataPoint, now time.Time) (*otlp.IntHistogramDataPoint, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.next(metric, pt.Labels, pt.StartTimeUnixNano, pt.TimeUnixNano, now)
+	if !ok {
+		return nil, false
+	}
+	s.addHistogram(pt.StartTimeUnixNano, pt.Count, pt.BucketCounts, pt.ExplicitBounds)
+	s.intSum += pt.Sum
+	cumulative := *pt
+	cumulative.StartTimeUnixNano = s.startTime
+	cumulative.Count = s.count
+	cumulative.Sum = s.intSum
+	cumulative.BucketCounts = append([]uint64(nil), s.bucketCounts...)
+	return &cumulative, true
+}
+
+// accumulateDoubleHistogram returns a copy of the delta point with the cumulative counts of its series, or false if
+// the point is out of order.
+func (a *deltaAccumulator) accumulateDoubleHistogram(metric *otlp.Metric, pt *otlp.DoubleHistogramDataPoint, now time.Time) (*otlp.DoubleHistogramDataPoint, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.next(metric, pt.Labels, pt.StartTimeUnixNano, pt.TimeUnixNano, now)
+	if !ok {
+		return nil, false
+	}
+	s.addHistogram(pt.StartTimeUnixNano, pt.Count, pt.BucketCounts, pt.ExplicitBounds)
+	s.sum += pt.Sum
+	cumulative := *pt
+	cumulative.StartTimeUnixNano = s.startTime
+	cumulative.Count = s.count
+	cumulative.Sum = s.sum
+	cumulative.BucketCounts = append([]uint64(nil), s.bucketCounts...)
+	return &cumulative, true
+}
+
+// addHistogram adds the counts of a delta histogram point, the series restarts at the start time of the point if its
+// buckets changed. The sum is added by the caller, as it is an integer or a float.
+func (s *accumulatedSeries) addHistogram(startTime, count uint64, bucketCounts []uint64, explicitBounds []float64) {
+	if s.bucketCounts != nil && !sameBuckets(s.explicitBounds, explicitBounds, s.bucketCounts, bucketCounts) {
+		s.startTime = startTime
+		s.count, s.sum, s.intSum, s.bucketCounts = 0, 0, 0, nil
+	}
+	if s.bucketCounts == nil {
+		s.bucketCounts = make([]uint64, len(bucketCounts))
+		s.explicitBounds = append([]float64(nil), explicitBounds...)
+	}
+	s.count += count
+	for i, c := range bucketCounts {
+		s.bucketCounts[i] += c
+	}
+}
+
+// sameBuckets returns whether two histogram points have the same buckets.
+func sameBuckets(bounds1, bounds2 []float64, counts1, counts2 []uint64) bool {
+	if len(bounds1) != len(bounds2) || len(counts1) != len(counts2) {
+		return false
+	}
+	for i := range bounds1 {
+		if bounds1[i] != bounds2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// purge removes the series without points since the expiration.
+func (a *deltaAccumulator) purge(now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, s := range a.series {
+		if now.Sub(s.lastSeen) > accumulatedSeriesExpiration {
+			delete(a.series, key)
+		}
+	}
+}
+
+// accumulatedSeriesKey identifies a series by the name and type of its metric and by its sorted labels.
+func accumulatedSeriesKey(metric *otlp.Metric, labels []common.StringKeyValue) string {
+	sorted := make([]common.StringKeyValue, len(labels))
+	copy(sorted, labels)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+	b := strings.Builder{}
+	b.WriteString(metric.GetName())
+	b.WriteByte(0)
+	b.WriteString(getTypeString(metric))
+	for _, l := range sorted {
+		b.WriteByte(0)
+		b.WriteString(l.Key)
+		b.WriteByte('=')
+		b.WriteString(l.Value)
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	common "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
+	otlp "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"
+)
+
+func TestDeltaAccumulator_Sum(t *testing.T) {
+	a := newDeltaAccumulator()
+	metric := &otlp.Metric{Name: "requests", Data: &otlp.Metric_IntSum{}}
+	labels := []common.StringKeyValue{{Key: "method", Value: "GET"}, {Key: "code", Value: "200"}}
+	now := time.Now()
+
+	pt, ok := a.accumulateIntSum(metric, &otlp.IntDataPoint{Labels: labels, StartTimeUnixNano: 100, TimeUnixNano: 200, Value: 3}, now)
+	require.True(t, ok)
+	assert.Equal(t, &otlp.IntDataPoint{Labels: labels, StartTimeUnixNano: 100, TimeUnixNano: 200, Value: 3}, pt)
+
+	// The order of the labels does not change the series.
+	reordered := []common.StringKeyValue{labels[1], labels[0]}
+	in := &otlp.IntDataPoint{Labels: reordered, StartTimeUnixNano: 200, TimeUnixNano: 300, Value: 4}
+	pt, ok = a.accumulateIntSum(metric, in, now)
+	require.True(t, ok)
+	assert.Equal(t, uint64(100), pt.StartTimeUnixNano)
+	assert.Equal(t, int64(7), pt.Value)
+	// The input point is not modified.
+	assert.Equal(t, int64(4), in.Value)
+
+	// The points not after the last one are skipped.
+	_, ok = a.accumulateIntSum(metric, &otlp.IntDataPoint{Labels: labels, StartTimeUnixNano: 200, TimeUnixNano: 300, Value: 4}, now)
+	assert.False(t, ok)
+
+	// The other series are accumulated separately.
+	dpt, ok := a.accumulateDoubleSum(&otlp.Metric{Name: "requests", Data: &otlp.Metric_DoubleSum{}},
+		&otlp.DoubleDataPoint{Labels: labels, TimeUnixNano: 300, Value: 1.5}, now)
+	require.True(t, ok)
+	assert.Equal(t, uint64(300), dpt.StartTimeUnixNano)
+	assert.Equal(t, 1.5, dpt.Value)
+}
+
+func TestDeltaAccumulator_Histogram(t *testing.T) {
+	a := newDeltaAccumulator()
+	metric := &otlp.Metric{Name: "latency", Data: &otlp.Metric_DoubleHistogram{}}
+	now := time.Now()
+
+	pt, ok := a.accumulateDoubleHistogram(metric, &otlp.DoubleHistogramDataPoint{
+		StartTimeUnixNano: 100, TimeUnixNano: 200, Count: 3, Sum: 6, BucketCounts: []uint64{1, 2}, ExplicitBounds: []float64{5},
+	}, now)
+	require.True(t, ok)
+	assert.Equal(t, []uint64{1, 2}, pt.BucketCounts)
+
+	pt, ok = a.accumulateDoubleHistogram(metric, &otlp.DoubleHistogramDataPoint{
+		StartTimeUnixNano: 200, TimeUnixNano: 300, Count: 2, Sum: 1.5, BucketCounts: []uint64{2, 0}, ExplicitBounds: []float64{5},
+	}, now)
+	require.True(t, ok)
+	assert.Equal(t, &otlp.DoubleHistogramDataPoint{
+		StartTimeUnixNano: 100, TimeUnixNano: 300, Count: 5, Sum: 7.5, BucketCounts: []uint64{3, 2}, ExplicitBounds: []float64{5},
+	}, pt)
+
+	// The series restarts when its buckets change.
+	pt, ok = a.accumulateDoubleHistogram(metric, &otlp.DoubleHistogramDataPoint{
+		StartTimeUnixNano: 300, TimeUnixNano: 400, Count: 1, Sum: 20, BucketCounts: []uint64{0, 0, 1}, ExplicitBounds: []float64{5, 10},
+	}, now)
+	require.True(t, ok)
+	assert.Equal(t, &otlp.DoubleHistogramDataPoint{
+		StartTimeUnixNano: 300, TimeUnixNano: 400, Count: 1, Sum: 20, BucketCounts: []uint64{0, 0, 1}, ExplicitBounds: []float64{5, 10},
+	}, pt)
+
+	ipt, ok := a.accumulateIntHistogram(&otlp.Metric{Name: "latency", Data: &otlp.Metric_IntHistogram{}}, &otlp.IntHistogramDataPoint{
+		StartTimeUnixNano: 100, TimeUnixNano: 200, Count: 1, Sum: 4, BucketCounts: []uint64{1, 0}, ExplicitBounds: []float64{5},
+	}, now)
+	require.True(t, ok)
+	assert.Equal(t, int64(4), ipt.Sum)
+}
+
+func TestDeltaAccumulator_Purge(t *testing.T) {
+	a := newDeltaAccumulator()
+	metric := &otlp.Metric{Name: "requests", Data: &otlp.Metric_IntSum{}}
+	now := time.Now()
+
+	_, ok := a.accumulateIntSum(metric, &otlp.IntDataPoint{TimeUnixNano: 200, Value: 3}, now)
+	require.True(t, ok)
+	a.purge(now.Add(accumulatedSeriesExpiration))
+	assert.Len(t, a.series, 1)
+
+	a.purge(now.Add(accumulatedSeriesExpiration + time.Second))
+	assert.Empty(t, a.series)
+	// The series starts again from zero.
+	pt, ok := a.accumulateIntSum(metric, &otlp.IntDataPoint{TimeUnixNano: 300, Value: 1}, now)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), pt.Value)
+}
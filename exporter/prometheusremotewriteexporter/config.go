@@ -15,6 +15,8 @@
 package prometheusremotewriteexporter
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -35,5 +37,9 @@ type Config struct {
 	// ExternalLabels defines a map of label keys and values that are allowed to start with reserved prefix "__"
 	ExternalLabels map[string]string `mapstructure:"external_labels"`
 
+	// StaleAfter is the duration after which the series without samples are marked as stale, with a Prometheus
+	// staleness marker sent with the next export. 0 disables the staleness markers.
+	StaleAfter time.Duration `mapstructure:"stale_after"`
+
 	HTTPClientSettings confighttp.HTTPClientSettings `mapstructure:",squash"`
 }
@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -50,11 +51,15 @@ type PrwExporter struct {
 	client         *http.Client
 	wg             *sync.WaitGroup
 	closeChan      chan struct{}
+	deltas         *deltaAccumulator
+	// staleness is nil if the series are not marked as stale.
+	staleness *stalenessTracker
 }
 
 // NewPrwExporter initializes a new PrwExporter instance and sets fields accordingly.
-// client parameter cannot be nil.
-func NewPrwExporter(namespace string, endpoint string, client *http.Client, externalLabels map[string]string) (*PrwExporter, error) {
+// client parameter cannot be nil. The series without samples for longer than staleAfter are marked as stale, 0
+// disables the staleness markers.
+func NewPrwExporter(namespace string, endpoint string, client *http.Client, externalLabels map[string]string, staleAfter time.Duration) (*PrwExporter, error) {
 	if client == nil {
 		return nil, errors.New("http client cannot be nil")
 	}
@@ -69,14 +74,23 @@ func NewPrwExporter(namespace string, endpoint string, client *http.Client, exte
 		return nil, errors.New("invalid endpoint")
 	}
 
-	return &PrwExporter{
+	if staleAfter < 0 {
+		return nil, errors.New("stale_after cannot be negative")
+	}
+
+	prwe := &PrwExporter{
 		namespace:      namespace,
 		externalLabels: sanitizedLabels,
 		endpointURL:    endpointURL,
 		client:         client,
 		wg:             new(sync.WaitGroup),
 		closeChan:      make(chan struct{}),
-	}, nil
+		deltas:         newDeltaAccumulator(),
+	}
+	if staleAfter > 0 {
+		prwe.staleness = newStalenessTracker(staleAfter)
+	}
+	return prwe, nil
 }
 
 // Shutdown stops the exporter from accepting incoming calls(and return error), and wait for current export operations
@@ -148,6 +162,12 @@ func (prwe *PrwExporter) PushMetrics(ctx context.Context, md pdata.Metrics) (int
 			}
 		}
 
+		now := time.Now()
+		prwe.deltas.purge(now)
+		if prwe.staleness != nil {
+			prwe.staleness.update(tsMap, now)
+		}
+
 		if exportErrors := prwe.export(ctx, tsMap); len(exportErrors) != 0 {
 			dropped = md.MetricCount()
 			errs = append(errs, exportErrors...)
@@ -204,14 +224,30 @@ func (prwe *PrwExporter) handleScalarMetric(tsMap map[string]*prompb.TimeSeries,
 		if metric.GetDoubleSum().GetDataPoints() == nil {
 			return fmt.Errorf("nil data point. %s is dropped", metric.GetName())
 		}
+		isDelta := metric.GetDoubleSum().GetAggregationTemporality() == otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+		now := time.Now()
 		for _, pt := range metric.GetDoubleSum().GetDataPoints() {
+			if isDelta && pt != nil {
+				var ok bool
+				if pt, ok = prwe.deltas.accumulateDoubleSum(metric, pt, now); !ok {
+					continue
+				}
+			}
 			addSingleDoubleDataPoint(pt, metric, prwe.namespace, tsMap, prwe.externalLabels)
 		}
 	case *otlp.Metric_IntSum:
 		if metric.GetIntSum().GetDataPoints() == nil {
 			return fmt.Errorf("nil data point. %s is dropped", metric.GetName())
 		}
+		isDelta := metric.GetIntSum().GetAggregationTemporality() == otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+		now := time.Now()
 		for _, pt := range metric.GetIntSum().GetDataPoints() {
+			if isDelta && pt != nil {
+				var ok bool
+				if pt, ok = prwe.deltas.accumulateIntSum(metric, pt, now); !ok {
+					continue
+				}
+			}
 			addSingleIntDataPoint(pt, metric, prwe.namespace, tsMap, prwe.externalLabels)
 		}
 	}
@@ -227,14 +263,30 @@ func (prwe *PrwExporter) handleHistogramMetric(tsMap map[string]*prompb.TimeSeri
 		if metric.GetIntHistogram().GetDataPoints() == nil {
 			return fmt.Errorf("nil data point. %s is dropped", metric.GetName())
 		}
+		isDelta := metric.GetIntHistogram().GetAggregationTemporality() == otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+		now := time.Now()
 		for _, pt := range metric.GetIntHistogram().GetDataPoints() {
+			if isDelta && pt != nil {
+				var ok bool
+				if pt, ok = prwe.deltas.accumulateIntHistogram(metric, pt, now); !ok {
+					continue
+				}
+			}
 			addSingleIntHistogramDataPoint(pt, metric, prwe.namespace, tsMap, prwe.externalLabels)
 		}
 	case *otlp.Metric_DoubleHistogram:
 		if metric.GetDoubleHistogram().GetDataPoints() == nil {
 			return fmt.Errorf("nil data point. %s is dropped", metric.GetName())
 		}
+		isDelta := metric.GetDoubleHistogram().GetAggregationTemporality() == otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+		now := time.Now()
 		for _, pt := range metric.GetDoubleHistogram().GetDataPoints() {
+			if isDelta && pt != nil {
+				var ok bool
+				if pt, ok = prwe.deltas.accumulateDoubleHistogram(metric, pt, now); !ok {
+					continue
+				}
+			}
 			addSingleDoubleHistogramDataPoint(pt, metric, prwe.namespace, tsMap, prwe.externalLabels)
 		}
 	}
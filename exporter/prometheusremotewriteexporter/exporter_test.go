@@ -107,7 +107,7 @@ func Test_NewPrwExporter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			prwe, err := NewPrwExporter(tt.namespace, tt.endpoint, tt.client, tt.externalLabels)
+			prwe, err := NewPrwExporter(tt.namespace, tt.endpoint, tt.client, tt.externalLabels, 0)
 			if tt.returnError {
 				assert.Error(t, err)
 				return
@@ -246,7 +246,7 @@ func runExportPipeline(ts *prompb.TimeSeries, endpoint *url.URL) []error {
 
 	HTTPClient := http.DefaultClient
 	// after this, instantiate a CortexExporter with the current HTTP client and endpoint set to passed in endpoint
-	prwe, err := NewPrwExporter("test", endpoint.String(), HTTPClient, map[string]string{})
+	prwe, err := NewPrwExporter("test", endpoint.String(), HTTPClient, map[string]string{}, 0)
 	if err != nil {
 		errs = append(errs, err)
 		return errs
@@ -690,7 +690,7 @@ func Test_PushMetrics(t *testing.T) {
 			// c, err := config.HTTPClientSettings.ToClient()
 			// assert.Nil(t, err)
 			c := http.DefaultClient
-			prwe, nErr := NewPrwExporter(config.Namespace, serverURL.String(), c, map[string]string{})
+			prwe, nErr := NewPrwExporter(config.Namespace, serverURL.String(), c, map[string]string{}, 0)
 			require.NoError(t, nErr)
 			numDroppedTimeSeries, err := prwe.PushMetrics(context.Background(), *tt.md)
 			assert.Equal(t, tt.numDroppedTimeSeries, numDroppedTimeSeries)
@@ -703,6 +703,53 @@ func Test_PushMetrics(t *testing.T) {
 	}
 }
 
+// Test_PushMetrics_Delta checks that the delta sums are exported as cumulative series.
+func Test_PushMetrics_Delta(t *testing.T) {
+	var values []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		dest, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		wr := &prompb.WriteRequest{}
+		require.NoError(t, proto.Unmarshal(dest, wr))
+		require.Len(t, wr.Timeseries, 1)
+		for _, sample := range wr.Timeseries[0].Samples {
+			values = append(values, sample.Value)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	prwe, err := NewPrwExporter("", server.URL, http.DefaultClient, map[string]string{}, 0)
+	require.NoError(t, err)
+
+	deltaSum := func(start, end uint64, value int64) pdata.Metrics {
+		return pdata.MetricsFromOtlp([]*otlp.ResourceMetrics{{
+			InstrumentationLibraryMetrics: []*otlp.InstrumentationLibraryMetrics{{
+				Metrics: []*otlp.Metric{{
+					Name: "requests",
+					Data: &otlp.Metric_IntSum{IntSum: &otlp.IntSum{
+						IsMonotonic:            true,
+						AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+						DataPoints: []*otlp.IntDataPoint{
+							{StartTimeUnixNano: start, TimeUnixNano: end, Value: value},
+						},
+					}},
+				}},
+			}},
+		}})
+	}
+	dropped, err := prwe.PushMetrics(context.Background(), deltaSum(1e9, 2e9, 3))
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	dropped, err = prwe.PushMetrics(context.Background(), deltaSum(2e9, 3e9, 4))
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	assert.Equal(t, []float64{3, 7}, values)
+}
+
 func Test_validateAndSanitizeExternalLabels(t *testing.T) {
 	tests := []struct {
 		name           string
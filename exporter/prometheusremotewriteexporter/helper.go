@@ -51,7 +51,7 @@ func (a ByLabelName) Less(i, j int) bool { return a[i].Name < a[j].Name }
 func (a ByLabelName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
 // validateMetrics returns a bool representing whether the metric has a valid type and temporality combination and a
-// matching metric type and field
+// matching metric type and field. The delta sums and histograms are valid, they are converted to cumulative ones.
 func validateMetrics(metric *otlp.Metric) bool {
 	if metric == nil || metric.Data == nil {
 		return false
@@ -62,23 +62,25 @@ func validateMetrics(metric *otlp.Metric) bool {
 	case *otlp.Metric_IntGauge:
 		return metric.GetIntGauge() != nil
 	case *otlp.Metric_DoubleSum:
-		return metric.GetDoubleSum() != nil && metric.GetDoubleSum().GetAggregationTemporality() ==
-			otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+		return metric.GetDoubleSum() != nil && validTemporality(metric.GetDoubleSum().GetAggregationTemporality())
 	case *otlp.Metric_IntSum:
-		return metric.GetIntSum() != nil && metric.GetIntSum().GetAggregationTemporality() ==
-			otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+		return metric.GetIntSum() != nil && validTemporality(metric.GetIntSum().GetAggregationTemporality())
 	case *otlp.Metric_DoubleHistogram:
-		return metric.GetDoubleHistogram() != nil && metric.GetDoubleHistogram().GetAggregationTemporality() ==
-			otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+		return metric.GetDoubleHistogram() != nil && validTemporality(metric.GetDoubleHistogram().GetAggregationTemporality())
 	case *otlp.Metric_IntHistogram:
-		return metric.GetIntHistogram() != nil && metric.GetIntHistogram().GetAggregationTemporality() ==
-			otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+		return metric.GetIntHistogram() != nil && validTemporality(metric.GetIntHistogram().GetAggregationTemporality())
 	case *otlp.Metric_DoubleSummary:
 		return metric.GetDoubleSummary() != nil
 	}
 	return false
 }
 
+// validTemporality returns whether the temporality of a sum or histogram can be exported.
+func validTemporality(temporality otlp.AggregationTemporality) bool {
+	return temporality == otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE ||
+		temporality == otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+}
+
 // addSample finds a TimeSeries in tsMap that corresponds to the label set labels, and add sample to the TimeSeries; it
 // creates a new TimeSeries in the map if not found. tsMap is unmodified if either of its parameters is nil.
 func addSample(tsMap map[string]*prompb.TimeSeries, sample *prompb.Sample, labels []prompb.Label,
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/pkg/value"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// stalenessTracker remembers the series exported, to mark as stale the series without samples for longer than
+// staleAfter, as Prometheus does for the series that disappear from a scrape target.
+type stalenessTracker struct {
+	staleAfter time.Duration
+
+	mu     sync.Mutex
+	series map[string]*trackedSeries
+}
+
+// trackedSeries is a series exported and not yet marked as stale.
+type trackedSeries struct {
+	labels        []prompb.Label
+	lastTimestamp int64
+	lastSeen      time.Time
+}
+
+func newStalenessTracker(staleAfter time.Duration) *stalenessTracker {
+	return &stalenessTracker{
+		staleAfter: staleAfter,
+		series:     map[string]*trackedSeries{},
+	}
+}
+
+// update records the series of tsMap, and adds to it a staleness marker for each series without samples since
+// staleAfter. The staleness markers are added at now, or just after the last sample of the series if it is later.
+func (st *stalenessTracker) update(tsMap map[string]*prompb.TimeSeries, now time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for sig, ts := range tsMap {
+		s, ok := st.series[sig]
+		if !ok {
+			s = &trackedSeries{labels: ts.Labels}
+			st.series[sig] = s
+		}
+		for _, sample := range ts.Samples {
+			if sample.Timestamp > s.lastTimestamp {
+				s.lastTimestamp = sample.Timestamp
+			}
+		}
+		s.lastSeen = now
+	}
+
+	nowTimestamp := now.UnixNano() / int64(time.Millisecond)
+	for sig, s := range st.series {
+		if now.Sub(s.lastSeen) <= st.staleAfter {
+			continue
+		}
+		timestamp := nowTimestamp
+		if timestamp <= s.lastTimestamp {
+			timestamp = s.lastTimestamp + 1
+		}
+		tsMap[sig] = &prompb.TimeSeries{
+			Labels:  s.labels,
+			Samples: []prompb.Sample{{Value: math.Float64frombits(value.StaleNaN), Timestamp: timestamp}},
+		}
+		delete(st.series, sig)
+	}
+}
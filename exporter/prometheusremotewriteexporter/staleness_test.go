@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/pkg/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStalenessTracker(t *testing.T) {
+	st := newStalenessTracker(time.Minute)
+	now := time.Unix(1000, 0)
+	labels1 := []prompb.Label{{Name: nameStr, Value: "up"}, {Name: "instance", Value: "host1"}}
+	labels2 := []prompb.Label{{Name: nameStr, Value: "up"}, {Name: "instance", Value: "host2"}}
+
+	tsMap := map[string]*prompb.TimeSeries{
+		"host1": {Labels: labels1, Samples: []prompb.Sample{{Value: 1, Timestamp: 999000}}},
+		"host2": {Labels: labels2, Samples: []prompb.Sample{{Value: 1, Timestamp: 999000}}},
+	}
+	st.update(tsMap, now)
+	assert.Len(t, tsMap, 2)
+
+	// host2 is not stale before stale_after.
+	tsMap = map[string]*prompb.TimeSeries{
+		"host1": {Labels: labels1, Samples: []prompb.Sample{{Value: 1, Timestamp: 1059000}}},
+	}
+	st.update(tsMap, now.Add(time.Minute))
+	assert.Len(t, tsMap, 1)
+
+	tsMap = map[string]*prompb.TimeSeries{
+		"host1": {Labels: labels1, Samples: []prompb.Sample{{Value: 1, Timestamp: 1060000}}},
+	}
+	st.update(tsMap, now.Add(time.Minute+time.Second))
+	require.Len(t, tsMap, 2)
+	stale := tsMap["host2"]
+	assert.Equal(t, labels2, stale.Labels)
+	require.Len(t, stale.Samples, 1)
+	assert.Equal(t, value.StaleNaN, math.Float64bits(stale.Samples[0].Value))
+	assert.Equal(t, int64(1061000), stale.Samples[0].Timestamp)
+
+	// The stale series is marked only once.
+	tsMap = map[string]*prompb.TimeSeries{}
+	st.update(tsMap, now.Add(time.Minute+2*time.Second))
+	assert.Empty(t, tsMap)
+}
+
+func TestStalenessTracker_MarkerAfterLastSample(t *testing.T) {
+	st := newStalenessTracker(time.Minute)
+	now := time.Unix(1000, 0)
+	labels := []prompb.Label{{Name: nameStr, Value: "up"}}
+
+	// The last sample is timestamped in the future of the collector clock.
+	st.update(map[string]*prompb.TimeSeries{
+		"up": {Labels: labels, Samples: []prompb.Sample{{Value: 1, Timestamp: 2000000}}},
+	}, now)
+	tsMap := map[string]*prompb.TimeSeries{}
+	st.update(tsMap, now.Add(2*time.Minute))
+	require.Len(t, tsMap, 1)
+	assert.Equal(t, int64(2000001), tsMap["up"].Samples[0].Timestamp)
+}
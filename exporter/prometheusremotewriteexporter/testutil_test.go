@@ -379,7 +379,7 @@ var (
 			Name: invalidIntSum,
 			Data: &otlp.Metric_IntSum{
 				IntSum: &otlp.IntSum{
-					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED,
 				},
 			},
 		},
@@ -387,7 +387,7 @@ var (
 			Name: invalidDoubleSum,
 			Data: &otlp.Metric_DoubleSum{
 				DoubleSum: &otlp.DoubleSum{
-					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED,
 				},
 			},
 		},
@@ -395,7 +395,7 @@ var (
 			Name: invalidIntHistogram,
 			Data: &otlp.Metric_IntHistogram{
 				IntHistogram: &otlp.IntHistogram{
-					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED,
 				},
 			},
 		},
@@ -403,7 +403,7 @@ var (
 			Name: invalidDoubleHistogram,
 			Data: &otlp.Metric_DoubleHistogram{
 				DoubleHistogram: &otlp.DoubleHistogram{
-					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED,
 				},
 			},
 		},
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisexporter
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for Redis exporter.
+type Config struct {
+	configmodels.ExporterSettings  `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	confignet.TCPAddr              `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// TLS configures the TLS connection to Redis, the connection is not encrypted if TLS is not set.
+	TLS *configtls.TLSClientSetting `mapstructure:"tls"`
+	// Username and Password authenticate the connection, Username requires Redis 6 ACLs and the default user is used
+	// if Username is empty. The connection is not authenticated if Password is empty.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// DB is the number of the database of the stream.
+	DB int `mapstructure:"db"`
+
+	// Stream is the key of the stream the log records are added to.
+	Stream string `mapstructure:"stream"`
+	// MaxLen is the length the stream is trimmed to when adding the log records, 0 disables the trimming.
+	MaxLen int64 `mapstructure:"max_len"`
+	// ApproximateTrimming lets Redis trim the stream only when it can remove a whole node, which is much more efficient,
+	// the stream may then have a few tens of entries more than MaxLen. Disable it to trim the stream exactly.
+	ApproximateTrimming bool `mapstructure:"approximate_trimming"`
+}
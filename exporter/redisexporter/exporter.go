@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisexporter
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// The fields of the stream entries.
+const (
+	fieldTimestamp      = "timestamp"
+	fieldSeverityText   = "severity_text"
+	fieldSeverityNumber = "severity_number"
+	fieldName           = "name"
+	fieldTraceID        = "trace_id"
+	fieldSpanID         = "span_id"
+	fieldBody           = "body"
+	fieldAttributes     = "attributes"
+	fieldResource       = "resource"
+)
+
+// retryableErrors are the kinds of the error replies that may succeed when retried, the other error replies are
+// permanent, e.g. WRONGTYPE when the key of the stream holds another type.
+var retryableErrors = map[string]bool{
+	"OOM":         true,
+	"LOADING":     true,
+	"BUSY":        true,
+	"TRYAGAIN":    true,
+	"READONLY":    true,
+	"MASTERDOWN":  true,
+	"CLUSTERDOWN": true,
+}
+
+// redisExporter adds the log records to a Redis stream over a connection kept open between the exports.
+type redisExporter struct {
+	config    *Config
+	tlsConfig *tls.Config
+	logger    *zap.Logger
+
+	// mu guards conn, and serializes the exports sharing it.
+	mu   sync.Mutex
+	conn *redisConn
+}
+
+// redisConn is a connection to Redis, the commands are pipelined: all the commands of an export are written before
+// their replies are read.
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+func newExporter(cfg *Config, logger *zap.Logger) (*redisExporter, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLS != nil {
+		var err error
+		if tlsConfig, err = cfg.TLS.LoadTLSConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return &redisExporter{
+		config:    cfg,
+		tlsConfig: tlsConfig,
+		logger:    logger,
+	}, nil
+}
+
+func (e *redisExporter) pushLogsData(ctx context.Context, ld pdata.Logs) (int, error) {
+	commands, err := e.xaddCommands(ld)
+	if err != nil {
+		return ld.LogRecordCount(), consumererror.Permanent(err)
+	}
+	if len(commands) == 0 {
+		return 0, nil
+	}
+
+	replies, err := e.do(ctx, commands)
+	if err != nil {
+		return ld.LogRecordCount(), err
+	}
+
+	// The whole batch is retried if an entry failed with a retryable error, the entries already added are then added
+	// again.
+	var errs []error
+	retryable := false
+	for _, reply := range replies {
+		if redisErr, ok := reply.(redisError); ok {
+			errs = append(errs, redisErr)
+			retryable = retryable || retryableErrors[redisErr.prefix()]
+		}
+	}
+	if len(errs) == 0 {
+		return 0, nil
+	}
+	err = fmt.Errorf("failed to add %d log records to the stream %q: %w", len(errs), e.config.Stream, consumererror.CombineErrors(errs))
+	if retryable {
+		return ld.LogRecordCount(), err
+	}
+	return len(errs), consumererror.Permanent(err)
+}
+
+// xaddCommands returns an XADD command per log record.
+func (e *redisExporter) xaddCommands(ld pdata.Logs) ([][]string, error) {
+	commands := make([][]string, 0, ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource, err := json.Marshal(tracetranslator.AttributeMapToMap(rl.Resource().Attributes()))
+		if err != nil {
+			return nil, err
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				command, err := e.xaddCommand(logs.At(k), resource)
+				if err != nil {
+					return nil, err
+				}
+				commands = append(commands, command)
+			}
+		}
+	}
+	return commands, nil
+}
+
+func (e *redisExporter) xaddCommand(lr pdata.LogRecord, resource []byte) ([]string, error) {
+	body, err := json.Marshal(attributeValueToRaw(lr.Body()))
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := json.Marshal(tracetranslator.AttributeMapToMap(lr.Attributes()))
+	if err != nil {
+		return nil, err
+	}
+
+	command := []string{"XADD", e.config.Stream}
+	if e.config.MaxLen > 0 {
+		command = append(command, "MAXLEN")
+		if e.config.ApproximateTrimming {
+			command = append(command, "~")
+		}
+		command = append(command, strconv.FormatInt(e.config.MaxLen, 10))
+	}
+	// Redis generates the ID of the entry.
+	command = append(command, "*")
+
+	if lr.Timestamp() != 0 {
+		command = append(command, fieldTimestamp, lr.Timestamp().AsTime().UTC().Format(time.RFC3339Nano))
+	}
+	command = append(command,
+		fieldSeverityText, lr.SeverityText(),
+		fieldSeverityNumber, strconv.Itoa(int(lr.SeverityNumber())),
+		fieldName, lr.Name())
+	if !lr.TraceID().IsEmpty() {
+		command = append(command, fieldTraceID, lr.TraceID().HexString())
+	}
+	if !lr.SpanID().IsEmpty() {
+		command = append(command, fieldSpanID, lr.SpanID().HexString())
+	}
+	return append(command,
+		fieldBody, string(body),
+		fieldAttributes, string(attributes),
+		fieldResource, string(resource)), nil
+}
+
+// attributeValueToRaw converts the value to the types encoded to JSON.
+func attributeValueToRaw(v pdata.AttributeValue) interface{} {
+	m := pdata.NewAttributeMap()
+	m.Insert(fieldBody, v)
+	return tracetranslator.AttributeMapToMap(m)[fieldBody]
+}
+
+// do sends the commands and returns their replies, some of them may be error replies. The connection is opened if
+// needed, and closed if the commands cannot be sent or their replies read, a new one is opened for the retry.
+func (e *redisExporter) do(ctx context.Context, commands [][]string) ([]interface{}, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		conn, err := e.connect(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to %s: %w", e.config.Endpoint, err)
+		}
+		e.conn = conn
+	}
+	replies, err := e.conn.do(ctx, commands)
+	if err != nil {
+		e.closeConn()
+		return nil, fmt.Errorf("failed to send the commands to %s: %w", e.config.Endpoint, err)
+	}
+	return replies, nil
+}
+
+// connect opens a connection, authenticated and using the configured database.
+func (e *redisExporter) connect(ctx context.Context) (*redisConn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", e.config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if e.tlsConfig != nil {
+		tlsConfig := e.tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			if host, _, err := net.SplitHostPort(e.config.Endpoint); err == nil {
+				tlsConfig.ServerName = host
+			}
+		}
+		conn = tls.Client(conn, tlsConfig)
+	}
+	c := &redisConn{conn: conn, r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}
+
+	var commands [][]string
+	switch {
+	case e.config.Password != "" && e.config.Username != "":
+		commands = append(commands, []string{"AUTH", e.config.Username, e.config.Password})
+	case e.config.Password != "":
+		commands = append(commands, []string{"AUTH", e.config.Password})
+	}
+	if e.config.DB != 0 {
+		commands = append(commands, []string{"SELECT", strconv.Itoa(e.config.DB)})
+	}
+	if len(commands) == 0 {
+		return c, nil
+	}
+	replies, err := c.do(ctx, commands)
+	if err == nil {
+		for i, reply := range replies {
+			if redisErr, ok := reply.(redisError); ok {
+				err = fmt.Errorf("%s failed: %w", commands[i][0], redisErr)
+				break
+			}
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *redisConn) do(ctx context.Context, commands [][]string) ([]interface{}, error) {
+	// No deadline is set, the zero time, if the context has none.
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	for _, command := range commands {
+		if err := writeCommand(c.w, command); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+	replies := make([]interface{}, len(commands))
+	for i := range replies {
+		reply, err := readReply(c.r)
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = reply
+	}
+	return replies, nil
+}
+
+func (e *redisExporter) closeConn() {
+	if err := e.conn.conn.Close(); err != nil {
+		e.logger.Debug("Failed to close the connection.", zap.Error(err))
+	}
+	e.conn = nil
+}
+
+func (e *redisExporter) shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.conn.Close()
+	e.conn = nil
+	return err
+}
@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisexporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+// redisServer records the commands received by a fake Redis server, and replies to them with the reply function.
+type redisServer struct {
+	listener net.Listener
+	reply    func(command []string) string
+	mu       sync.Mutex
+	commands [][]string
+	conns    int
+}
+
+func newRedisServer(t *testing.T, reply func(command []string) string) *redisServer {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := &redisServer{listener: listener, reply: reply}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			srv.mu.Lock()
+			srv.conns++
+			srv.mu.Unlock()
+			go srv.serve(conn)
+		}
+	}()
+	return srv
+}
+
+func (s *redisServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		request, err := readReply(r)
+		if err != nil {
+			return
+		}
+		elements := request.([]interface{})
+		command := make([]string, len(elements))
+		for i, element := range elements {
+			command[i] = element.(string)
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, command)
+		s.mu.Unlock()
+		if _, err := conn.Write([]byte(s.reply(command))); err != nil {
+			return
+		}
+	}
+}
+
+func (s *redisServer) getCommands() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commands
+}
+
+func (s *redisServer) connCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+func replyOK(command []string) string {
+	if command[0] == "XADD" {
+		return "$15\r\n1614834367000-0\r\n"
+	}
+	return "+OK\r\n"
+}
+
+func newTestExporter(t *testing.T, endpoint string, modify func(cfg *Config)) *redisExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, validateConfig(cfg))
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	return exp
+}
+
+func testLogs() pdata.Logs {
+	ld := testdata.GenerateLogDataTwoLogsSameResource()
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	lr.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	lr.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	return ld
+}
+
+// entryFields returns the fields of the entry added by an XADD command, and the arguments before the fields.
+func entryFields(t *testing.T, command []string) ([]string, map[string]string) {
+	for i, arg := range command {
+		if arg == "*" {
+			fields := map[string]string{}
+			require.Equal(t, 0, (len(command)-i-1)%2)
+			for j := i + 1; j < len(command); j += 2 {
+				fields[command[j]] = command[j+1]
+			}
+			return command[:i+1], fields
+		}
+	}
+	t.Fatalf("no ID in %v", command)
+	return nil, nil
+}
+
+func TestPushLogsData(t *testing.T) {
+	srv := newRedisServer(t, replyOK)
+	defer srv.listener.Close()
+	exp := newTestExporter(t, srv.listener.Addr().String(), func(cfg *Config) {
+		cfg.Stream = "edge-logs"
+		cfg.MaxLen = 1000
+		cfg.Username = "otel"
+		cfg.Password = "secret"
+		cfg.DB = 2
+	})
+
+	ld := testLogs()
+	dropped, err := exp.pushLogsData(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	_, err = exp.pushLogsData(context.Background(), ld)
+	require.NoError(t, err)
+	assert.NoError(t, exp.shutdown(context.Background()))
+
+	commands := srv.getCommands()
+	require.Len(t, commands, 6)
+	assert.Equal(t, []string{"AUTH", "otel", "secret"}, commands[0])
+	assert.Equal(t, []string{"SELECT", "2"}, commands[1])
+	// The connection is kept open between the exports.
+	assert.Equal(t, 1, srv.connCount())
+
+	args, fields := entryFields(t, commands[2])
+	assert.Equal(t, []string{"XADD", "edge-logs", "MAXLEN", "~", "1000", "*"}, args)
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, lr.Timestamp().AsTime().UTC().Format("2006-01-02T15:04:05.999999999Z07:00"), fields[fieldTimestamp])
+	assert.Equal(t, lr.SeverityText(), fields[fieldSeverityText])
+	assert.Equal(t, "9", fields[fieldSeverityNumber])
+	assert.Equal(t, lr.Name(), fields[fieldName])
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", fields[fieldTraceID])
+	assert.Equal(t, "0102030405060708", fields[fieldSpanID])
+	var body interface{}
+	require.NoError(t, json.Unmarshal([]byte(fields[fieldBody]), &body))
+	assert.Equal(t, lr.Body().StringVal(), body)
+	var attributes map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(fields[fieldAttributes]), &attributes))
+	assert.Len(t, attributes, lr.Attributes().Len())
+	var resource map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(fields[fieldResource]), &resource))
+	assert.Equal(t, map[string]interface{}{"resource-attr": "resource-attr-val-1"}, resource)
+
+	_, fields = entryFields(t, commands[3])
+	assert.NotContains(t, fields, fieldTraceID)
+	assert.NotContains(t, fields, fieldSpanID)
+}
+
+func TestPushLogsData_ExactTrimming(t *testing.T) {
+	srv := newRedisServer(t, replyOK)
+	defer srv.listener.Close()
+	exp := newTestExporter(t, srv.listener.Addr().String(), func(cfg *Config) {
+		cfg.MaxLen = 10
+		cfg.ApproximateTrimming = false
+	})
+
+	_, err := exp.pushLogsData(context.Background(), testLogs())
+	require.NoError(t, err)
+	assert.NoError(t, exp.shutdown(context.Background()))
+
+	commands := srv.getCommands()
+	require.Len(t, commands, 2)
+	args, _ := entryFields(t, commands[0])
+	assert.Equal(t, []string{"XADD", "otel-logs", "MAXLEN", "10", "*"}, args)
+}
+
+func TestPushLogsData_ErrorReplies(t *testing.T) {
+	tests := []struct {
+		name      string
+		reply     string
+		permanent bool
+		dropped   int
+	}{
+		{name: "wrong_type", reply: "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n", permanent: true, dropped: 1},
+		{name: "out_of_memory", reply: "-OOM command not allowed when used memory > 'maxmemory'\r\n", dropped: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := 0
+			srv := newRedisServer(t, func(command []string) string {
+				count++
+				if count == 2 {
+					return tt.reply
+				}
+				return replyOK(command)
+			})
+			defer srv.listener.Close()
+			exp := newTestExporter(t, srv.listener.Addr().String(), nil)
+
+			dropped, err := exp.pushLogsData(context.Background(), testLogs())
+			require.Error(t, err)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+			assert.Equal(t, tt.dropped, dropped)
+			assert.NoError(t, exp.shutdown(context.Background()))
+		})
+	}
+}
+
+func TestPushLogsData_AuthFailure(t *testing.T) {
+	srv := newRedisServer(t, func(command []string) string {
+		if command[0] == "AUTH" {
+			return "-WRONGPASS invalid username-password pair\r\n"
+		}
+		return replyOK(command)
+	})
+	defer srv.listener.Close()
+	exp := newTestExporter(t, srv.listener.Addr().String(), func(cfg *Config) {
+		cfg.Password = "wrong"
+	})
+
+	ld := testLogs()
+	dropped, err := exp.pushLogsData(context.Background(), ld)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WRONGPASS")
+	assert.Equal(t, ld.LogRecordCount(), dropped)
+	assert.Len(t, srv.getCommands(), 1)
+}
+
+func TestPushLogsData_Reconnect(t *testing.T) {
+	var mu sync.Mutex
+	failed := false
+	srv := newRedisServer(t, func(command []string) string {
+		mu.Lock()
+		defer mu.Unlock()
+		if !failed {
+			failed = true
+			// An invalid reply, the connection is then closed by the exporter.
+			return "?\r\n"
+		}
+		return replyOK(command)
+	})
+	defer srv.listener.Close()
+	exp := newTestExporter(t, srv.listener.Addr().String(), nil)
+
+	_, err := exp.pushLogsData(context.Background(), testLogs())
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	_, err = exp.pushLogsData(context.Background(), testLogs())
+	require.NoError(t, err)
+	assert.Equal(t, 2, srv.connCount())
+	assert.NoError(t, exp.shutdown(context.Background()))
+}
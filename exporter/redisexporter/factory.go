@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "redis"
+
+	defaultEndpoint = "localhost:6379"
+	defaultStream   = "otel-logs"
+)
+
+var (
+	errNoEndpoint     = errors.New("endpoint must be specified")
+	errNoStream       = errors.New("stream must be specified")
+	errNegativeMaxLen = errors.New("max_len must not be negative")
+	errNegativeDB     = errors.New("db must not be negative")
+)
+
+// NewFactory creates a factory for Redis exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithLogs(createLogsExporter))
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: defaultEndpoint,
+		},
+		TimeoutSettings:     exporterhelper.DefaultTimeoutSettings(),
+		RetrySettings:       exporterhelper.DefaultRetrySettings(),
+		QueueSettings:       exporterhelper.DefaultQueueSettings(),
+		Stream:              defaultStream,
+		ApproximateTrimming: true,
+	}
+}
+
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.LogsExporter, error) {
+	rCfg := cfg.(*Config)
+	if err := validateConfig(rCfg); err != nil {
+		return nil, fmt.Errorf("error creating %q exporter: %w", rCfg.Name(), err)
+	}
+	exp, err := newExporter(rCfg, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		params.Logger,
+		exp.pushLogsData,
+		exporterhelper.WithShutdown(exp.shutdown),
+		exporterhelper.WithTimeout(rCfg.TimeoutSettings),
+		exporterhelper.WithRetry(rCfg.RetrySettings),
+		exporterhelper.WithQueue(rCfg.QueueSettings))
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.Endpoint == "" {
+		return errNoEndpoint
+	}
+	if cfg.Stream == "" {
+		return errNoStream
+	}
+	if cfg.MaxLen < 0 {
+		return errNegativeMaxLen
+	}
+	if cfg.DB < 0 {
+		return errNegativeDB
+	}
+	return nil
+}
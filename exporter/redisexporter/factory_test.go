@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateLogsExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	le, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, le)
+	assert.NoError(t, le.Shutdown(context.Background()))
+}
+
+func TestCreateLogsExporter_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    error
+	}{
+		{
+			name: "no_endpoint",
+			modify: func(cfg *Config) {
+				cfg.Endpoint = ""
+			},
+			err: errNoEndpoint,
+		},
+		{
+			name: "no_stream",
+			modify: func(cfg *Config) {
+				cfg.Stream = ""
+			},
+			err: errNoStream,
+		},
+		{
+			name: "negative_max_len",
+			modify: func(cfg *Config) {
+				cfg.MaxLen = -1
+			},
+			err: errNegativeMaxLen,
+		},
+		{
+			name: "negative_db",
+			modify: func(cfg *Config) {
+				cfg.DB = -1
+			},
+			err: errNegativeDB,
+		},
+		{
+			name: "invalid_tls",
+			modify: func(cfg *Config) {
+				cfg.TLS = &configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{CAFile: "/nonexistent/ca.pem"},
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+			params := component.ExporterCreateParams{Logger: zap.NewNop()}
+			_, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+			require.Error(t, err)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisexporter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The commands are sent and their replies read with the Redis serialization protocol (RESP), see
+// https://redis.io/topics/protocol. Only the subset needed by the exporter is implemented.
+
+var errInvalidReply = errors.New("invalid reply")
+
+// redisError is an error reply, e.g. "ERR unknown command" or "WRONGTYPE Operation against a key holding the wrong
+// kind of value".
+type redisError string
+
+func (e redisError) Error() string {
+	return string(e)
+}
+
+// prefix returns the first word of the error, its kind by convention.
+func (e redisError) prefix() string {
+	s := string(e)
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// writeCommand writes a command as an array of bulk strings.
+func writeCommand(w *bufio.Writer, args []string) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if _, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readReply reads a reply: a string for the simple and the bulk strings, nil for the null bulk string and the null
+// array, an int64 for the integers, an []interface{} for the arrays and a redisError for the errors.
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if line == "" {
+		return nil, errInvalidReply
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return redisError(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errInvalidReply
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errInvalidReply
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		elements := make([]interface{}, n)
+		for i := range elements {
+			if elements[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return elements, nil
+	}
+	return nil, errInvalidReply
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(line, "\r\n") {
+		return "", errInvalidReply
+	}
+	return line[:len(line)-2], nil
+}
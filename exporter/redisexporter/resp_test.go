@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisexporter
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCommand(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	require.NoError(t, writeCommand(w, []string{"XADD", "logs", "*", "body", "a\r\nb"}))
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "*5\r\n$4\r\nXADD\r\n$4\r\nlogs\r\n$1\r\n*\r\n$4\r\nbody\r\n$4\r\na\r\nb\r\n", buf.String())
+}
+
+func TestReadReply(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected interface{}
+		err      bool
+	}{
+		{name: "simple_string", input: "+OK\r\n", expected: "OK"},
+		{name: "error", input: "-WRONGTYPE Operation against a key\r\n", expected: redisError("WRONGTYPE Operation against a key")},
+		{name: "integer", input: ":42\r\n", expected: int64(42)},
+		{name: "bulk_string", input: "$15\r\n1614834367000-0\r\n", expected: "1614834367000-0"},
+		{name: "null_bulk_string", input: "$-1\r\n", expected: nil},
+		{name: "array", input: "*2\r\n$1\r\na\r\n:1\r\n", expected: []interface{}{"a", int64(1)}},
+		{name: "null_array", input: "*-1\r\n", expected: nil},
+		{name: "missing_cr", input: "+OK\n", err: true},
+		{name: "unknown_type", input: "?OK\r\n", err: true},
+		{name: "truncated", input: "$15\r\n1614", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply, err := readReply(bufio.NewReader(strings.NewReader(tt.input)))
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, reply)
+		})
+	}
+}
+
+func TestRedisErrorPrefix(t *testing.T) {
+	assert.Equal(t, "OOM", redisError("OOM command not allowed when used memory > 'maxmemory'").prefix())
+	assert.Equal(t, "ERR", redisError("ERR").prefix())
+}
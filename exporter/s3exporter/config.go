@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3exporter
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for S3 exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// Region is the AWS region of the bucket, it is also used to sign the requests made to an S3-compatible storage.
+	Region string `mapstructure:"region"`
+	// Bucket is the bucket the objects are written to.
+	Bucket string `mapstructure:"bucket"`
+	// Prefix is prepended to the keys of the objects.
+	Prefix string `mapstructure:"prefix"`
+	// PartitionFormat is the Go time layout of the part of the keys partitioning the objects by the time they are
+	// written at, in UTC.
+	PartitionFormat string `mapstructure:"partition_format"`
+
+	// AccessKeyID, SecretAccessKey and SessionToken are the AWS credentials the requests are signed with. The
+	// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables are used if AccessKeyID
+	// is empty.
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	SessionToken    string `mapstructure:"session_token"`
+
+	// Marshaler is the encoding of the objects, "proto" for the OTLP protobuf or "json" for the OTLP JSON.
+	Marshaler string `mapstructure:"marshaler"`
+	// Compression is the compression of the objects, "none" or "gzip".
+	Compression string `mapstructure:"compression"`
+
+	// RolloverInterval is how often the batches buffered in the next object are written. Each batch is written as its
+	// own object if 0, the batches are otherwise acknowledged before they are written and lost if the collector
+	// crashes.
+	RolloverInterval time.Duration `mapstructure:"rollover_interval"`
+	// MaxObjectSizeMiB is the size of the buffered batches, encoded in protobuf before compression, the object is
+	// written at before the rollover interval elapses.
+	MaxObjectSizeMiB uint64 `mapstructure:"max_object_size_mib"`
+}
@@ -0,0 +1,352 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3exporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal"
+	otlplogs "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
+	otlptrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
+)
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+
+	headerContentType        = "Content-Type"
+	maxHTTPResponseReadBytes = 64 * 1024
+
+	envAccessKeyID     = "AWS_ACCESS_KEY_ID"
+	envSecretAccessKey = "AWS_SECRET_ACCESS_KEY"
+	envSessionToken    = "AWS_SESSION_TOKEN"
+)
+
+// Marshaler configuration used for marshaling Protobuf to JSON. Use default config.
+var jsonMarshaler = &jsonpb.Marshaler{}
+
+// s3Exporter writes the batches of a signal as objects of the bucket, each batch as its own object or, if the
+// objects are rolled over, the batches buffered until the rollover interval elapses or the maximum object size is
+// reached.
+type s3Exporter struct {
+	config *Config
+	client *http.Client
+	// baseURL is the URL of the bucket, the keys of the objects are appended to its path.
+	baseURL url.URL
+	signal  string
+	// newRequest returns an empty export request of the signal, the buffered batches are decoded into.
+	newRequest func() proto.Message
+	creds      credentials
+	logger     *zap.Logger
+	now        func() time.Time
+
+	// maxObjectSize is the size in bytes of the buffered batches they are written at.
+	maxObjectSize int
+	// mu guards pending, the concatenated protobuf export requests of the batches buffered in the next object, which
+	// decode as a single request holding all of them.
+	mu      sync.Mutex
+	pending []byte
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newExporter(cfg *Config, logger *zap.Logger, signal string) (*s3Exporter, error) {
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := bucketURL(cfg)
+	if err != nil {
+		return nil, err
+	}
+	creds := credentials{
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		sessionToken:    cfg.SessionToken,
+	}
+	if creds.accessKeyID == "" {
+		creds = credentials{
+			accessKeyID:     os.Getenv(envAccessKeyID),
+			secretAccessKey: os.Getenv(envSecretAccessKey),
+			sessionToken:    os.Getenv(envSessionToken),
+		}
+	}
+	var newRequest func() proto.Message
+	switch signal {
+	case signalTraces:
+		newRequest = func() proto.Message { return &otlptrace.ExportTraceServiceRequest{} }
+	case signalMetrics:
+		newRequest = func() proto.Message { return &otlpmetrics.ExportMetricsServiceRequest{} }
+	case signalLogs:
+		newRequest = func() proto.Message { return &otlplogs.ExportLogsServiceRequest{} }
+	}
+	return &s3Exporter{
+		config:        cfg,
+		client:        client,
+		baseURL:       *baseURL,
+		signal:        signal,
+		newRequest:    newRequest,
+		creds:         creds,
+		logger:        logger,
+		now:           time.Now,
+		maxObjectSize: int(cfg.MaxObjectSizeMiB) << 20,
+		done:          make(chan struct{}),
+	}, nil
+}
+
+// bucketURL returns the virtual-hosted-style URL of the bucket on AWS, or the path-style URL of the bucket on the
+// S3-compatible storage of the endpoint.
+func bucketURL(cfg *Config) (*url.URL, error) {
+	if cfg.Endpoint == "" {
+		return url.Parse("https://" + cfg.Bucket + ".s3." + cfg.Region + ".amazonaws.com")
+	}
+	u, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	u.Path += "/" + cfg.Bucket
+	u.RawPath = ""
+	return u, nil
+}
+
+func (e *s3Exporter) pushTraceData(ctx context.Context, td pdata.Traces) (int, error) {
+	request := otlptrace.ExportTraceServiceRequest{
+		ResourceSpans: pdata.TracesToOtlp(td),
+	}
+	if err := e.export(ctx, &request); err != nil {
+		return td.SpanCount(), err
+	}
+	return 0, nil
+}
+
+func (e *s3Exporter) pushMetricsData(ctx context.Context, md pdata.Metrics) (int, error) {
+	request := otlpmetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: pdata.MetricsToOtlp(md),
+	}
+	if err := e.export(ctx, &request); err != nil {
+		return md.MetricCount(), err
+	}
+	return 0, nil
+}
+
+func (e *s3Exporter) pushLogsData(ctx context.Context, ld pdata.Logs) (int, error) {
+	request := otlplogs.ExportLogsServiceRequest{
+		ResourceLogs: internal.LogsToOtlp(ld.InternalRep()),
+	}
+	if err := e.export(ctx, &request); err != nil {
+		return ld.LogRecordCount(), err
+	}
+	return 0, nil
+}
+
+// start rolls the objects over at the configured interval.
+func (e *s3Exporter) start(context.Context, component.Host) error {
+	if e.config.RolloverInterval > 0 {
+		e.wg.Add(1)
+		go e.rollover()
+	}
+	return nil
+}
+
+// shutdown stops the rollover and writes the buffered batches.
+func (e *s3Exporter) shutdown(ctx context.Context) error {
+	close(e.done)
+	e.wg.Wait()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.flush(ctx)
+}
+
+func (e *s3Exporter) rollover() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.config.RolloverInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.mu.Lock()
+			if err := e.flush(context.Background()); err != nil {
+				e.logger.Error("Failed to write the buffered batches", zap.String("signal", e.signal), zap.Error(err))
+			}
+			e.mu.Unlock()
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// export writes the export request as a new object of the bucket, or buffers it in the next object if the objects
+// are rolled over. The object is then written as soon as it reaches the maximum size, and the request is removed from
+// it if the write fails, the exporter helper retrying the request.
+func (e *s3Exporter) export(ctx context.Context, request proto.Message) error {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	if e.config.RolloverInterval <= 0 {
+		return e.putObject(ctx, data)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	size := len(e.pending)
+	e.pending = append(e.pending, data...)
+	if len(e.pending) < e.maxObjectSize {
+		return nil
+	}
+	if err := e.putObject(ctx, e.pending); err != nil {
+		e.pending = e.pending[:size]
+		return err
+	}
+	e.pending = nil
+	return nil
+}
+
+// flush writes the buffered batches, they are kept for the next rollover if the write can be retried. It must be
+// called with mu held.
+func (e *s3Exporter) flush(ctx context.Context) error {
+	if len(e.pending) == 0 {
+		return nil
+	}
+	err := e.putObject(ctx, e.pending)
+	if err == nil || consumererror.IsPermanent(err) {
+		e.pending = nil
+	}
+	return err
+}
+
+// putObject writes the protobuf export request as a new object of the bucket.
+func (e *s3Exporter) putObject(ctx context.Context, data []byte) error {
+	body, err := e.encode(data)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	objectURL := e.baseURL
+	objectURL.Path += "/" + e.objectKey(e.now())
+	objectURL.RawPath = escapePath(objectURL.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set(headerContentType, e.contentType())
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, v)
+	}
+	signRequest(req, body, e.creds, e.config.Region, e.now())
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make an HTTP request: %w", err)
+	}
+	defer func() {
+		// Discard any remaining response body when we are done reading.
+		io.CopyN(ioutil.Discard, resp.Body, maxHTTPResponseReadBytes)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	return exporterhelper.NewHTTPResponseError(resp, objectURL.String())
+}
+
+// objectKey returns a new key of the form <prefix>/<signal>/<partition>/<unix nanoseconds>_<uuid>.<extension>, the
+// partition being the time formatted with the configured layout.
+func (e *s3Exporter) objectKey(t time.Time) string {
+	t = t.UTC()
+	var parts []string
+	if prefix := strings.Trim(e.config.Prefix, "/"); prefix != "" {
+		parts = append(parts, prefix)
+	}
+	parts = append(parts, e.signal)
+	if e.config.PartitionFormat != "" {
+		parts = append(parts, t.Format(e.config.PartitionFormat))
+	}
+	parts = append(parts, strconv.FormatInt(t.UnixNano(), 10)+"_"+uuid.New().String()+e.extension())
+	return strings.Join(parts, "/")
+}
+
+// encode converts the protobuf export request to the configured encoding and compression.
+func (e *s3Exporter) encode(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gz *gzip.Writer
+	if e.config.Compression == compressionGzip {
+		gz = gzip.NewWriter(&buf)
+		w = gz
+	}
+	if e.config.Marshaler == marshalerJSON {
+		request := e.newRequest()
+		if err := proto.Unmarshal(data, request); err != nil {
+			return nil, err
+		}
+		if err := jsonMarshaler.Marshal(w, request); err != nil {
+			return nil, err
+		}
+	} else if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (e *s3Exporter) extension() string {
+	ext := ".pb"
+	if e.config.Marshaler == marshalerJSON {
+		ext = ".json"
+	}
+	if e.config.Compression == compressionGzip {
+		ext += ".gz"
+	}
+	return ext
+}
+
+func (e *s3Exporter) contentType() string {
+	switch {
+	case e.config.Compression == compressionGzip:
+		return "application/gzip"
+	case e.config.Marshaler == marshalerJSON:
+		return "application/json"
+	}
+	return "application/x-protobuf"
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "s3"
+
+	defaultRegion           = "us-east-1"
+	defaultPartitionFormat  = "year=2006/month=01/day=02/hour=15"
+	defaultMaxObjectSizeMiB = 64
+
+	marshalerProto  = "proto"
+	marshalerJSON   = "json"
+	compressionNone = "none"
+	compressionGzip = "gzip"
+)
+
+var (
+	errNoBucket        = errors.New("bucket must be specified")
+	errNoRegion        = errors.New("region must be specified")
+	errNoMaxObjectSize = errors.New("max_object_size_mib must be positive when rollover_interval is set")
+)
+
+// NewFactory creates a factory for S3 exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithTraces(createTraceExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		RetrySettings: exporterhelper.DefaultRetrySettings(),
+		QueueSettings: exporterhelper.DefaultQueueSettings(),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "",
+			Timeout:  30 * time.Second,
+			Headers:  map[string]string{},
+		},
+		Region:           defaultRegion,
+		PartitionFormat:  defaultPartitionFormat,
+		Marshaler:        marshalerProto,
+		Compression:      compressionGzip,
+		MaxObjectSizeMiB: defaultMaxObjectSizeMiB,
+	}
+}
+
+func createTraceExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.TracesExporter, error) {
+	sCfg := cfg.(*Config)
+	exp, err := createExporter(params, sCfg, signalTraces)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewTraceExporter(
+		cfg,
+		params.Logger,
+		exp.pushTraceData,
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(sCfg.RetrySettings),
+		exporterhelper.WithQueue(sCfg.QueueSettings),
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown))
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	sCfg := cfg.(*Config)
+	exp, err := createExporter(params, sCfg, signalMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		params.Logger,
+		exp.pushMetricsData,
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(sCfg.RetrySettings),
+		exporterhelper.WithQueue(sCfg.QueueSettings),
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown))
+}
+
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.LogsExporter, error) {
+	sCfg := cfg.(*Config)
+	exp, err := createExporter(params, sCfg, signalLogs)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		params.Logger,
+		exp.pushLogsData,
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(sCfg.RetrySettings),
+		exporterhelper.WithQueue(sCfg.QueueSettings),
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown))
+}
+
+func createExporter(params component.ExporterCreateParams, cfg *Config, signal string) (*s3Exporter, error) {
+	if err := validateConfig(cfg); err != nil {
+		return nil, fmt.Errorf("error creating %q exporter: %w", cfg.Name(), err)
+	}
+	return newExporter(cfg, params.Logger, signal)
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.Bucket == "" {
+		return errNoBucket
+	}
+	if cfg.Region == "" {
+		return errNoRegion
+	}
+	if cfg.Endpoint != "" {
+		if _, err := url.Parse(cfg.Endpoint); err != nil {
+			return fmt.Errorf("endpoint must be a valid URL: %w", err)
+		}
+	}
+	if cfg.Marshaler != marshalerProto && cfg.Marshaler != marshalerJSON {
+		return fmt.Errorf("unsupported marshaler %q, must be %q or %q", cfg.Marshaler, marshalerProto, marshalerJSON)
+	}
+	if cfg.Compression != compressionNone && cfg.Compression != compressionGzip {
+		return fmt.Errorf("unsupported compression %q, must be %q or %q", cfg.Compression, compressionNone, compressionGzip)
+	}
+	if cfg.RolloverInterval > 0 && cfg.MaxObjectSizeMiB == 0 {
+		return errNoMaxObjectSize
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3exporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateExporters(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Bucket = "telemetry"
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	te, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, te)
+
+	me, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, me)
+
+	le, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, le)
+}
+
+func TestCreateExporters_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    error
+	}{
+		{
+			name:   "no_bucket",
+			modify: func(cfg *Config) {},
+			err:    errNoBucket,
+		},
+		{
+			name: "no_region",
+			modify: func(cfg *Config) {
+				cfg.Bucket = "telemetry"
+				cfg.Region = ""
+			},
+			err: errNoRegion,
+		},
+		{
+			name: "invalid_endpoint",
+			modify: func(cfg *Config) {
+				cfg.Bucket = "telemetry"
+				cfg.Endpoint = "http://minio:9000\n"
+			},
+		},
+		{
+			name: "invalid_marshaler",
+			modify: func(cfg *Config) {
+				cfg.Bucket = "telemetry"
+				cfg.Marshaler = "otlp_json"
+			},
+		},
+		{
+			name: "invalid_compression",
+			modify: func(cfg *Config) {
+				cfg.Bucket = "telemetry"
+				cfg.Compression = "zstd"
+			},
+		},
+		{
+			name: "no_max_object_size",
+			modify: func(cfg *Config) {
+				cfg.Bucket = "telemetry"
+				cfg.RolloverInterval = time.Minute
+				cfg.MaxObjectSizeMiB = 0
+			},
+			err: errNoMaxObjectSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+			params := component.ExporterCreateParams{Logger: zap.NewNop()}
+			_, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+			require.Error(t, err)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+			}
+			_, err = factory.CreateLogsExporter(context.Background(), params, cfg)
+			require.Error(t, err)
+		})
+	}
+}
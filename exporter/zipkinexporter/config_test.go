@@ -58,10 +58,11 @@ func TestLoadConfig(t *testing.T) {
 			TypeVal: "zipkin",
 		},
 		RetrySettings: exporterhelper.RetrySettings{
-			Enabled:         true,
-			InitialInterval: 10 * time.Second,
-			MaxInterval:     1 * time.Minute,
-			MaxElapsedTime:  10 * time.Minute,
+			Enabled:             true,
+			InitialInterval:     10 * time.Second,
+			MaxInterval:         1 * time.Minute,
+			MaxElapsedTime:      10 * time.Minute,
+			RandomizationFactor: 0.5,
 		},
 		QueueSettings: exporterhelper.QueueSettings{
 			Enabled:      true,
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adminextension
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// pipelinesController is implemented by the hosts that can pause and resume
+// their pipelines and receivers.
+type pipelinesController interface {
+	SetPipelinePaused(name string, paused bool) error
+	SetReceiverPaused(name string, paused bool) error
+	GetPausedStates() (pipelines map[string]bool, receivers map[string]bool)
+}
+
+// pausedStates is the response of the admin API, it tells whether each pipeline
+// and each receiver is paused.
+type pausedStates struct {
+	Pipelines map[string]bool `json:"pipelines"`
+	Receivers map[string]bool `json:"receivers"`
+}
+
+type adminExtension struct {
+	config     Config
+	logger     *zap.Logger
+	controller pipelinesController
+	server     http.Server
+}
+
+func (ae *adminExtension) Start(_ context.Context, host component.Host) error {
+	controller, ok := host.(pipelinesController)
+	if !ok {
+		return errors.New("the host doesn't support pausing pipelines and receivers")
+	}
+	ae.controller = controller
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", ae.handleStatus)
+	mux.HandleFunc("/pipelines/pause", ae.handlePause(controller.SetPipelinePaused, true))
+	mux.HandleFunc("/pipelines/resume", ae.handlePause(controller.SetPipelinePaused, false))
+	mux.HandleFunc("/receivers/pause", ae.handlePause(controller.SetReceiverPaused, true))
+	mux.HandleFunc("/receivers/resume", ae.handlePause(controller.SetReceiverPaused, false))
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := net.Listen("tcp", ae.config.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	ae.logger.Info("Starting admin extension", zap.String("endpoint", ae.config.Endpoint))
+	ae.server = http.Server{Handler: ae.authenticate(mux)}
+	go func() {
+		if err := ae.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			host.ReportFatalError(err)
+		}
+	}()
+
+	return nil
+}
+
+func (ae *adminExtension) Shutdown(context.Context) error {
+	return ae.server.Close()
+}
+
+// authenticate only lets through the requests with the configured bearer token.
+func (ae *adminExtension) authenticate(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + ae.config.AuthToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (ae *adminExtension) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ae.writeStatus(w)
+}
+
+// handlePause returns a handler that pauses or resumes the pipeline or receiver
+// given by the "name" query parameter, and responds with the new status.
+func (ae *adminExtension) handlePause(setPaused func(name string, paused bool) error, paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			http.Error(w, "the \"name\" query parameter is required", http.StatusBadRequest)
+			return
+		}
+		if err := setPaused(name, paused); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		ae.logger.Info("Paused state changed", zap.String("path", r.URL.Path), zap.String("name", name))
+		ae.writeStatus(w)
+	}
+}
+
+func (ae *adminExtension) writeStatus(w http.ResponseWriter) {
+	var states pausedStates
+	states.Pipelines, states.Receivers = ae.controller.GetPausedStates()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(states); err != nil {
+		ae.logger.Warn("Failed to write the admin status", zap.Error(err))
+	}
+}
+
+func newServer(config Config, logger *zap.Logger) *adminExtension {
+	return &adminExtension{
+		config: config,
+		logger: logger,
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adminextension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+type testHost struct {
+	component.Host
+	pipelines map[string]bool
+	receivers map[string]bool
+}
+
+func newTestHost() *testHost {
+	return &testHost{
+		Host:      componenttest.NewNopHost(),
+		pipelines: map[string]bool{"traces": false, "logs": false},
+		receivers: map[string]bool{"otlp": false},
+	}
+}
+
+func (th *testHost) SetPipelinePaused(name string, paused bool) error {
+	if _, ok := th.pipelines[name]; !ok {
+		return fmt.Errorf("pipeline %q does not exist", name)
+	}
+	th.pipelines[name] = paused
+	return nil
+}
+
+func (th *testHost) SetReceiverPaused(name string, paused bool) error {
+	if _, ok := th.receivers[name]; !ok {
+		return fmt.Errorf("receiver %q does not exist", name)
+	}
+	th.receivers[name] = paused
+	return nil
+}
+
+func (th *testHost) GetPausedStates() (map[string]bool, map[string]bool) {
+	return th.pipelines, th.receivers
+}
+
+func TestAdminExtension(t *testing.T) {
+	config := Config{
+		Endpoint:  testutil.GetAvailableLocalAddress(t),
+		AuthToken: "token",
+	}
+	adminExt := newServer(config, zap.NewNop())
+	host := newTestHost()
+	require.NoError(t, adminExt.Start(context.Background(), host))
+	defer adminExt.Shutdown(context.Background())
+
+	do := func(method, path, token string) (int, pausedStates) {
+		req, err := http.NewRequest(method, "http://"+config.Endpoint+path, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var states pausedStates
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&states))
+		}
+		return resp.StatusCode, states
+	}
+
+	status, _ := do(http.MethodGet, "/status", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = do(http.MethodGet, "/status", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, states := do(http.MethodGet, "/status", "token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, pausedStates{Pipelines: host.pipelines, Receivers: host.receivers}, states)
+
+	status, states = do(http.MethodPost, "/pipelines/pause?name=logs", "token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]bool{"traces": false, "logs": true}, states.Pipelines)
+
+	status, states = do(http.MethodPost, "/receivers/pause?name=otlp", "token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]bool{"otlp": true}, states.Receivers)
+
+	status, states = do(http.MethodPost, "/receivers/resume?name=otlp", "token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]bool{"otlp": false}, states.Receivers)
+
+	status, _ = do(http.MethodPost, "/pipelines/resume?name=metrics", "token")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = do(http.MethodPost, "/pipelines/resume", "token")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = do(http.MethodGet, "/pipelines/resume?name=logs", "token")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+	assert.True(t, host.pipelines["logs"])
+}
+
+func TestAdminExtensionUnsupportedHost(t *testing.T) {
+	config := Config{
+		Endpoint:  testutil.GetAvailableLocalAddress(t),
+		AuthToken: "token",
+	}
+	adminExt := newServer(config, zap.NewNop())
+	require.Error(t, adminExt.Start(context.Background(), componenttest.NewNopHost()))
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adminextension
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config has the configuration for the admin extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// Endpoint is the address and port in which the admin API will be listening to.
+	// Use localhost:<port> to make it available only locally, or ":<port>" to
+	// make it available on all network interfaces.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// AuthToken is the token the requests must present in the "Authorization: Bearer <token>"
+	// header. Use an environment variable to avoid storing it in the configuration file.
+	AuthToken string `mapstructure:"auth_token"`
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ballastextension
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config has the configuration for the memory ballast extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// SizeMiB is the size, in MiB, of the memory ballast to allocate.
+	// It has a higher precedence than SizeInPercentage.
+	SizeMiB uint64 `mapstructure:"size_mib"`
+
+	// SizeInPercentage is the size of the memory ballast as a percentage,
+	// from 1 to 100, of the total memory available to the process. On Linux
+	// the total memory honors the cgroup memory limit, if one is set.
+	SizeInPercentage uint64 `mapstructure:"size_in_percentage"`
+
+	// ResizeInterval is the time between re-evaluations of the available
+	// memory. When the available memory changes the ballast is reallocated
+	// to match. Only used with SizeInPercentage. Defaults to zero, so the
+	// ballast is sized once on start.
+	ResizeInterval time.Duration `mapstructure:"resize_interval"`
+}
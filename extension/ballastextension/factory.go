@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ballastextension
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/extension/extensionhelper"
+	"go.opentelemetry.io/collector/internal/iruntime"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "memory_ballast"
+)
+
+var (
+	errSizeNotSet             = errors.New("\"size_mib\" or \"size_in_percentage\" is required when using the \"memory_ballast\" extension")
+	errPercentageOutOfRange   = errors.New("\"size_in_percentage\" must be greater than zero and less than or equal to hundred")
+	errResizeWithoutPercent   = errors.New("\"resize_interval\" can only be used with \"size_in_percentage\"")
+	errResizeIntervalNegative = errors.New("\"resize_interval\" must not be negative")
+	errMultipleBallastCreate  = errors.New("only a single memory_ballast extension instance can be created per process")
+)
+
+// make it overridable by tests
+var getTotalMemoryFn = iruntime.TotalMemory
+
+// NewFactory creates a factory for the memory ballast extension.
+func NewFactory() component.ExtensionFactory {
+	return extensionhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		createExtension)
+}
+
+func createDefaultConfig() configmodels.Extension {
+	return &Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+	}
+}
+
+func createExtension(_ context.Context, params component.ExtensionCreateParams, cfg configmodels.Extension) (component.Extension, error) {
+	config := cfg.(*Config)
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	// The ballast is accounted for by the process metrics of the whole
+	// collector, so only a single instance can be created via the factory.
+	if !atomic.CompareAndSwapInt32(&instanceState, instanceNotCreated, instanceCreated) {
+		return nil, errMultipleBallastCreate
+	}
+
+	return newMemoryBallast(config, params.Logger, getTotalMemoryFn), nil
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.SizeMiB == 0 && cfg.SizeInPercentage == 0 {
+		return errSizeNotSet
+	}
+	if cfg.SizeInPercentage > 100 {
+		return errPercentageOutOfRange
+	}
+	if cfg.ResizeInterval < 0 {
+		return errResizeIntervalNegative
+	}
+	if cfg.ResizeInterval > 0 && cfg.SizeMiB != 0 {
+		return errResizeWithoutPercent
+	}
+	return nil
+}
+
+// See comment in createExtension how these are used.
+var instanceState int32
+
+const (
+	instanceNotCreated int32 = 0
+	instanceCreated    int32 = 1
+)
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ballastextension
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			NameVal: typeStr,
+			TypeVal: typeStr,
+		},
+	},
+		cfg)
+
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	ext, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	assert.Equal(t, errSizeNotSet, err)
+	assert.Nil(t, ext)
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SizeMiB = 1
+
+	ext, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	// Restore instance tracking from factory, for other tests.
+	atomic.StoreInt32(&instanceState, instanceNotCreated)
+}
+
+func TestFactory_CreateExtensionOnlyOnce(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SizeMiB = 1
+
+	ext, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	ext1, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	require.Error(t, err)
+	require.Nil(t, ext1)
+
+	// Restore instance tracking from factory, for other tests.
+	atomic.StoreInt32(&instanceState, instanceNotCreated)
+}
+
+func TestFactory_CreateExtensionAfterShutdown(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SizeMiB = 1
+
+	ext, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	// The collector restarting in the same process creates the extension again.
+	ext, err = createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+	require.NoError(t, ext.Shutdown(context.Background()))
+	assert.Equal(t, instanceNotCreated, atomic.LoadInt32(&instanceState))
+}
+
+func TestFactory_CreateExtensionInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  error
+	}{
+		{
+			name: "percentage_out_of_range",
+			cfg:  &Config{SizeInPercentage: 101},
+			err:  errPercentageOutOfRange,
+		},
+		{
+			name: "negative_resize_interval",
+			cfg:  &Config{SizeInPercentage: 10, ResizeInterval: -time.Second},
+			err:  errResizeIntervalNegative,
+		},
+		{
+			name: "resize_with_fixed_size",
+			cfg:  &Config{SizeMiB: 10, ResizeInterval: time.Second},
+			err:  errResizeWithoutPercent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, tt.cfg)
+			assert.Equal(t, tt.err, err)
+			assert.Nil(t, ext)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ballastextension
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const megaBytes = 1024 * 1024
+
+// MemoryBallast is the memory ballast extension. The current size of the
+// ballast can be queried at any time with GetBallastSize.
+type MemoryBallast struct {
+	cfg              *Config
+	logger           *zap.Logger
+	getTotalMemoryFn func() (int64, error)
+
+	// ballastSizeBytes is used atomically since it is read by the process
+	// metrics of the collector while the ballast may be resized.
+	ballastSizeBytes uint64
+
+	mu      sync.Mutex
+	ballast []byte
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ component.Extension = (*MemoryBallast)(nil)
+
+func newMemoryBallast(cfg *Config, logger *zap.Logger, getTotalMemoryFn func() (int64, error)) *MemoryBallast {
+	return &MemoryBallast{
+		cfg:              cfg,
+		logger:           logger,
+		getTotalMemoryFn: getTotalMemoryFn,
+		done:             make(chan struct{}),
+	}
+}
+
+// Start allocates the ballast and, if configured, starts resizing it
+// periodically according to the available memory.
+func (m *MemoryBallast) Start(context.Context, component.Host) error {
+	size, err := m.targetSize()
+	if err != nil {
+		return err
+	}
+	m.resize(size)
+
+	if m.cfg.ResizeInterval > 0 {
+		m.wg.Add(1)
+		go m.startResizing()
+	}
+	return nil
+}
+
+// Shutdown stops resizing and releases the ballast, so that another instance
+// can be created, e.g. when the collector restarts in the same process.
+func (m *MemoryBallast) Shutdown(context.Context) error {
+	close(m.done)
+	m.wg.Wait()
+	m.resize(0)
+	atomic.StoreInt32(&instanceState, instanceNotCreated)
+	return nil
+}
+
+// GetBallastSize returns the current size of the ballast in bytes.
+func (m *MemoryBallast) GetBallastSize() uint64 {
+	return atomic.LoadUint64(&m.ballastSizeBytes)
+}
+
+func (m *MemoryBallast) startResizing() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(m.cfg.ResizeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			size, err := m.targetSize()
+			if err != nil {
+				m.logger.Warn("Failed to get total memory, keeping current ballast size", zap.Error(err))
+				continue
+			}
+			if size != m.GetBallastSize() {
+				m.resize(size)
+			}
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// targetSize returns the size, in bytes, the ballast should have according to
+// the configuration and the currently available memory.
+func (m *MemoryBallast) targetSize() (uint64, error) {
+	if m.cfg.SizeMiB > 0 {
+		return m.cfg.SizeMiB * megaBytes, nil
+	}
+	totalMemory, err := m.getTotalMemoryFn()
+	if err != nil {
+		return 0, err
+	}
+	return m.cfg.SizeInPercentage * uint64(totalMemory) / 100, nil
+}
+
+func (m *MemoryBallast) resize(size uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Drop the reference to the previous ballast before allocating the new
+	// one, so both are never kept alive at the same time.
+	m.ballast = nil
+	if size > 0 {
+		m.ballast = make([]byte, size)
+		m.logger.Info("Using memory ballast", zap.Uint64("MiBs", size/megaBytes))
+	}
+	atomic.StoreUint64(&m.ballastSizeBytes, size)
+}
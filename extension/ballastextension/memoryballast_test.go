@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ballastextension
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestMemoryBallast_FixedSize(t *testing.T) {
+	mb := newMemoryBallast(&Config{SizeMiB: 13}, zap.NewNop(), nil)
+	require.NoError(t, mb.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, uint64(13*megaBytes), mb.GetBallastSize())
+	assert.Len(t, mb.ballast, 13*megaBytes)
+
+	require.NoError(t, mb.Shutdown(context.Background()))
+	assert.Equal(t, uint64(0), mb.GetBallastSize())
+	assert.Nil(t, mb.ballast)
+}
+
+func TestMemoryBallast_Percentage(t *testing.T) {
+	totalMemory := func() (int64, error) {
+		return 100 * megaBytes, nil
+	}
+	mb := newMemoryBallast(&Config{SizeInPercentage: 20}, zap.NewNop(), totalMemory)
+	require.NoError(t, mb.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, uint64(20*megaBytes), mb.GetBallastSize())
+	require.NoError(t, mb.Shutdown(context.Background()))
+}
+
+func TestMemoryBallast_TotalMemoryError(t *testing.T) {
+	errTotalMemory := errors.New("my error")
+	totalMemory := func() (int64, error) {
+		return 0, errTotalMemory
+	}
+	mb := newMemoryBallast(&Config{SizeInPercentage: 20}, zap.NewNop(), totalMemory)
+	assert.Equal(t, errTotalMemory, mb.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestMemoryBallast_Resize(t *testing.T) {
+	total := int64(100 * megaBytes)
+	totalMemory := func() (int64, error) {
+		return atomic.LoadInt64(&total), nil
+	}
+	mb := newMemoryBallast(&Config{SizeInPercentage: 10, ResizeInterval: time.Millisecond}, zap.NewNop(), totalMemory)
+	require.NoError(t, mb.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, uint64(10*megaBytes), mb.GetBallastSize())
+
+	atomic.StoreInt64(&total, 200*megaBytes)
+	assert.Eventually(t, func() bool {
+		return mb.GetBallastSize() == 20*megaBytes
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, mb.Shutdown(context.Background()))
+	assert.Equal(t, uint64(0), mb.GetBallastSize())
+}
@@ -26,4 +26,8 @@ type Config struct {
 	// Port is the port used to publish the health check status.
 	// The default value is 13133.
 	Port uint16 `mapstructure:"port"`
+
+	// CheckCircuitBreakers makes the health check report the service as
+	// unavailable while the circuit breaker of an exporter is open.
+	CheckCircuitBreakers bool `mapstructure:"check_circuit_breakers"`
 }
@@ -47,7 +47,8 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "health_check",
 				NameVal: "health_check/1",
 			},
-			Port: 13,
+			Port:                 13,
+			CheckCircuitBreakers: true,
 		},
 		ext1)
 
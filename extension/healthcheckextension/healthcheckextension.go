@@ -16,6 +16,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"strconv"
@@ -24,6 +25,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
 type healthCheckExtension struct {
@@ -48,7 +50,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 	}
 
 	// Mount HC handler
-	hc.server.Handler = hc.state.Handler()
+	hc.server.Handler = hc.handler()
 
 	go func() {
 		// The listener ownership goes to the server.
@@ -74,6 +76,34 @@ func (hc *healthCheckExtension) NotReady() error {
 	return nil
 }
 
+// handler returns the handler of the health check, reporting the open circuit
+// breakers of the exporters if configured to.
+func (hc *healthCheckExtension) handler() http.Handler {
+	stateHandler := hc.state.Handler()
+	if !hc.config.CheckCircuitBreakers {
+		return stateHandler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var open []string
+		for _, status := range exporterhelper.CircuitBreakerStatuses() {
+			if status.State == exporterhelper.CircuitBreakerOpen {
+				open = append(open, status.Exporter+"/"+string(status.DataType))
+			}
+		}
+		if len(open) == 0 {
+			stateHandler.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":                "Circuit breaker open",
+			"open_circuit_breakers": open,
+		})
+	})
+}
+
 func newServer(config Config, logger *zap.Logger) *healthCheckExtension {
 	hc := &healthCheckExtension{
 		config: config,
@@ -16,6 +16,8 @@ package healthcheckextension
 
 import (
 	"context"
+	"errors"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"runtime"
@@ -27,6 +29,10 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/testutil"
 )
 
@@ -65,6 +71,51 @@ func TestHealthCheckExtensionUsage(t *testing.T) {
 	require.Equal(t, http.StatusServiceUnavailable, resp2.StatusCode)
 }
 
+func TestHealthCheckExtensionCircuitBreakers(t *testing.T) {
+	config := Config{
+		Port:                 testutil.GetAvailablePort(t),
+		CheckCircuitBreakers: true,
+	}
+
+	hcExt := newServer(config, zap.NewNop())
+	require.NotNil(t, hcExt)
+
+	require.NoError(t, hcExt.Start(context.Background(), componenttest.NewNopHost()))
+	defer hcExt.Shutdown(context.Background())
+	hcExt.Ready()
+
+	exp, err := exporterhelper.NewTraceExporter(
+		&configmodels.ExporterSettings{TypeVal: "test", NameVal: "test"},
+		zap.NewNop(),
+		func(context.Context, pdata.Traces) (int, error) {
+			return 0, errors.New("unavailable")
+		},
+		exporterhelper.WithCircuitBreaker(exporterhelper.CircuitBreakerSettings{
+			Enabled:          true,
+			FailureThreshold: 1,
+			CoolDown:         time.Hour,
+		}))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer exp.Shutdown(context.Background())
+
+	client := &http.Client{}
+	url := "http://localhost:" + strconv.Itoa(int(config.Port))
+	resp0, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp0.Body.Close()
+	require.Equal(t, http.StatusOK, resp0.StatusCode)
+
+	require.Error(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	resp1, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp1.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp1.StatusCode)
+	body, err := ioutil.ReadAll(resp1.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"status":"Circuit breaker open","open_circuit_breakers":["test/traces"]}`, string(body))
+}
+
 func TestHealthCheckExtensionPortAlreadyInUse(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 	_, portStr, err := net.SplitHostPort(endpoint)
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// apiVersion is the version of the Docker Engine API used, supported since Docker 1.12.
+const apiVersion = "v1.24"
+
+// container is a container of the Docker Engine API list of containers.
+type container struct {
+	ID              string            `json:"Id"`
+	Names           []string          `json:"Names"`
+	Image           string            `json:"Image"`
+	Command         string            `json:"Command"`
+	Labels          map[string]string `json:"Labels"`
+	Ports           []containerPort   `json:"Ports"`
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress string `json:"IPAddress"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// containerPort is a port exposed by a container, and where it is published
+// on the host if PublicPort isn't 0.
+type containerPort struct {
+	IP          string `json:"IP"`
+	PrivatePort uint16 `json:"PrivatePort"`
+	PublicPort  uint16 `json:"PublicPort"`
+	Type        string `json:"Type"`
+}
+
+// dockerClient is a client of the Docker Engine API.
+type dockerClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newDockerClient(endpoint string, timeout time.Duration) (*dockerClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	transport := &http.Transport{}
+	var baseURL string
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		}
+		baseURL = "http://docker"
+	case "tcp", "http":
+		baseURL = "http://" + u.Host
+	default:
+		return nil, fmt.Errorf("invalid endpoint %q, expecting unix:// or tcp://", endpoint)
+	}
+
+	return &dockerClient{
+		baseURL: baseURL,
+		client:  &http.Client{Transport: transport, Timeout: timeout},
+	}, nil
+}
+
+// listContainers lists the running containers.
+func (c *dockerClient) listContainers() ([]container, error) {
+	resp, err := c.client.Get(c.baseURL + "/" + apiVersion + "/containers/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list the containers: %s", resp.Status)
+	}
+	var containers []container
+	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+		return nil, fmt.Errorf("failed to decode the containers: %w", err)
+	}
+	return containers, nil
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the Docker observer.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// Endpoint is the address of the Docker daemon, either "unix:///path/to/docker.sock" or "tcp://host:port".
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Timeout is the timeout of the requests to the Docker daemon.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// RefreshInterval is the interval at which the containers are listed.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
+	// UseHostBindings makes the endpoints target the ports published on the host, instead of the ports of the
+	// containers on their network. The ports which aren't published are then ignored.
+	UseHostBindings bool `mapstructure:"use_host_bindings"`
+}
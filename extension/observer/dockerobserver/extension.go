@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+// dockerObserver discovers the ports of the running containers.
+type dockerObserver struct {
+	observer.EndpointsWatcher
+}
+
+var _ component.Extension = (*dockerObserver)(nil)
+var _ observer.Observable = (*dockerObserver)(nil)
+
+func newObserver(config *Config, logger *zap.Logger) (*dockerObserver, error) {
+	client, err := newDockerClient(config.Endpoint, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &dockerObserver{
+		EndpointsWatcher: observer.EndpointsWatcher{
+			Endpoints: &dockerEndpointsLister{
+				config: config,
+				client: client,
+			},
+			RefreshInterval: config.RefreshInterval,
+			Logger:          logger,
+		},
+	}, nil
+}
+
+func (d *dockerObserver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (d *dockerObserver) Shutdown(context.Context) error {
+	d.StopListAndWatch()
+	return nil
+}
+
+type dockerEndpointsLister struct {
+	config *Config
+	client *dockerClient
+}
+
+// ListEndpoints implements observer.EndpointsLister.
+func (d *dockerEndpointsLister) ListEndpoints() ([]observer.Endpoint, error) {
+	containers, err := d.client.listContainers()
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []observer.Endpoint
+	for _, c := range containers {
+		endpoints = append(endpoints, d.containerEndpoints(c)...)
+	}
+	return endpoints, nil
+}
+
+// containerEndpoints returns the endpoints of the ports of a container.
+func (d *dockerEndpointsLister) containerEndpoints(c container) []observer.Endpoint {
+	var name string
+	if len(c.Names) > 0 {
+		name = strings.TrimPrefix(c.Names[0], "/")
+	}
+	containerIP := containerIP(c)
+
+	seen := make(map[string]bool)
+	var endpoints []observer.Endpoint
+	for _, p := range c.Ports {
+		transport := observer.ProtocolTCP
+		if p.Type == "udp" {
+			transport = observer.ProtocolUDP
+		}
+		id := observer.EndpointID(fmt.Sprintf("%s/%s:%d/%s", d.config.Name(), c.ID, p.PrivatePort, p.Type))
+		if seen[string(id)] {
+			// The published ports are listed for each address of the host, e.g. IPv4 and IPv6.
+			continue
+		}
+
+		details := &observer.Container{
+			Name:        name,
+			Image:       c.Image,
+			ContainerID: c.ID,
+			Command:     c.Command,
+			Transport:   transport,
+			Labels:      c.Labels,
+		}
+		if d.config.UseHostBindings {
+			if p.PublicPort == 0 {
+				continue
+			}
+			details.Host = hostIP(p.IP)
+			details.Port = p.PublicPort
+			details.AlternatePort = p.PrivatePort
+		} else {
+			if containerIP == "" {
+				continue
+			}
+			details.Host = containerIP
+			details.Port = p.PrivatePort
+			details.AlternatePort = p.PublicPort
+		}
+
+		seen[string(id)] = true
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:      id,
+			Target:  net.JoinHostPort(details.Host, strconv.Itoa(int(details.Port))),
+			Details: details,
+		})
+	}
+	return endpoints
+}
+
+// containerIP returns the IP address of the container on the first of its
+// networks, by name, which assigned it one.
+func containerIP(c container) string {
+	var networks []string
+	for network := range c.NetworkSettings.Networks {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	for _, network := range networks {
+		if ip := c.NetworkSettings.Networks[network].IPAddress; ip != "" {
+			return ip
+		}
+	}
+	return ""
+}
+
+// hostIP returns the address the ports published on ip can be reached on, the
+// loopback address when they are published on all the addresses.
+func hostIP(ip string) string {
+	if parsed := net.ParseIP(ip); parsed == nil || parsed.IsUnspecified() {
+		return "127.0.0.1"
+	}
+	return ip
+}
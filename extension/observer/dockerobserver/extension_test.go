@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+func newDockerServer(t *testing.T) *httptest.Server {
+	containers, err := ioutil.ReadFile(path.Join(".", "testdata", "containers.json"))
+	require.NoError(t, err)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.24/containers/json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(containers)
+	}))
+}
+
+func newLister(t *testing.T, endpoint string, useHostBindings bool) *dockerEndpointsLister {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.UseHostBindings = useHostBindings
+	d, err := newObserver(cfg, zap.NewNop())
+	require.NoError(t, err)
+	return d.Endpoints.(*dockerEndpointsLister)
+}
+
+func TestListEndpoints(t *testing.T) {
+	server := newDockerServer(t)
+	defer server.Close()
+	endpoint := strings.Replace(server.URL, "http://", "tcp://", 1)
+
+	endpoints, err := newLister(t, endpoint, false).ListEndpoints()
+	require.NoError(t, err)
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     "docker_observer/8dfafdbc3a40:6379/tcp",
+			Target: "172.17.0.2:6379",
+			Details: &observer.Container{
+				Name:          "redis",
+				Image:         "redis:6",
+				ContainerID:   "8dfafdbc3a40",
+				Command:       "docker-entrypoint.sh redis-server",
+				Host:          "172.17.0.2",
+				Port:          6379,
+				AlternatePort: 16379,
+				Transport:     observer.ProtocolTCP,
+				Labels:        map[string]string{"app": "redis"},
+			},
+		},
+		{
+			ID:     "docker_observer/9cd87474be90:80/tcp",
+			Target: "172.18.0.3:80",
+			Details: &observer.Container{
+				Name:        "nginx",
+				Image:       "nginx:1.19",
+				ContainerID: "9cd87474be90",
+				Command:     "nginx -g 'daemon off;'",
+				Host:        "172.18.0.3",
+				Port:        80,
+				Transport:   observer.ProtocolTCP,
+				Labels:      map[string]string{},
+			},
+		},
+	}, endpoints)
+
+	// Only the published ports are endpoints with the host bindings.
+	endpoints, err = newLister(t, endpoint, true).ListEndpoints()
+	require.NoError(t, err)
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     "docker_observer/8dfafdbc3a40:6379/tcp",
+			Target: "127.0.0.1:16379",
+			Details: &observer.Container{
+				Name:          "redis",
+				Image:         "redis:6",
+				ContainerID:   "8dfafdbc3a40",
+				Command:       "docker-entrypoint.sh redis-server",
+				Host:          "127.0.0.1",
+				Port:          16379,
+				AlternatePort: 6379,
+				Transport:     observer.ProtocolTCP,
+				Labels:        map[string]string{"app": "redis"},
+			},
+		},
+	}, endpoints)
+}
+
+func TestListEndpointsUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the Docker daemon listens on a named pipe on Windows")
+	}
+	dir, err := ioutil.TempDir("", "docker")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := path.Join(dir, "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := newDockerServer(t)
+	server.Close()
+	go func() {
+		_ = http.Serve(listener, server.Config.Handler)
+	}()
+	defer listener.Close()
+
+	endpoints, err := newLister(t, "unix://"+socket, false).ListEndpoints()
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 2)
+}
+
+func TestListEndpointsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "daemon unavailable", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := newLister(t, server.URL, false).ListEndpoints()
+	assert.EqualError(t, err, "failed to list the containers: 500 Internal Server Error")
+}
+
+type endpointsNotify struct {
+	added chan []observer.Endpoint
+}
+
+func (n *endpointsNotify) OnAdd(added []observer.Endpoint) {
+	n.added <- added
+}
+
+func (n *endpointsNotify) OnRemove([]observer.Endpoint) {}
+
+func (n *endpointsNotify) OnChange([]observer.Endpoint) {}
+
+func TestObserver(t *testing.T) {
+	server := newDockerServer(t)
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	d, err := newObserver(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, d.Start(context.Background(), componenttest.NewNopHost()))
+
+	notify := &endpointsNotify{added: make(chan []observer.Endpoint, 1)}
+	d.ListAndWatch(notify)
+	select {
+	case added := <-notify.added:
+		assert.Len(t, added, 2)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "no endpoint added")
+	}
+
+	require.NoError(t, d.Shutdown(context.Background()))
+}
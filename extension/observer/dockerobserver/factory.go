@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/extension/extensionhelper"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "docker_observer"
+
+	defaultEndpoint        = "unix:///var/run/docker.sock"
+	defaultTimeout         = 5 * time.Second
+	defaultRefreshInterval = 10 * time.Second
+)
+
+var (
+	errNonPositiveTimeout         = errors.New("timeout must be positive")
+	errNonPositiveRefreshInterval = errors.New("refresh_interval must be positive")
+)
+
+// NewFactory creates a factory for the Docker observer.
+func NewFactory() component.ExtensionFactory {
+	return extensionhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		createExtension)
+}
+
+func createDefaultConfig() configmodels.Extension {
+	return &Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Endpoint:        defaultEndpoint,
+		Timeout:         defaultTimeout,
+		RefreshInterval: defaultRefreshInterval,
+	}
+}
+
+func createExtension(_ context.Context, params component.ExtensionCreateParams, cfg configmodels.Extension) (component.Extension, error) {
+	config := cfg.(*Config)
+	if config.Timeout <= 0 {
+		return nil, errNonPositiveTimeout
+	}
+	if config.RefreshInterval <= 0 {
+		return nil, errNonPositiveRefreshInterval
+	}
+	return newObserver(config, params.Logger)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+
+	ext, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
+
+func TestFactory_CreateExtensionInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "timeout",
+			modify:  func(cfg *Config) { cfg.Timeout = 0 },
+			wantErr: errNonPositiveTimeout.Error(),
+		},
+		{
+			name:    "refresh interval",
+			modify:  func(cfg *Config) { cfg.RefreshInterval = 0 },
+			wantErr: errNonPositiveRefreshInterval.Error(),
+		},
+		{
+			name:    "endpoint",
+			modify:  func(cfg *Config) { cfg.Endpoint = "npipe:////./pipe/docker_engine" },
+			wantErr: `invalid endpoint "npipe:////./pipe/docker_engine", expecting unix:// or tcp://`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			ext, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, ext)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observer
+
+// EndpointType is the type of an endpoint, as it can be matched by the rules
+// of the receiver creator.
+type EndpointType string
+
+const (
+	// PodType is the type of the Pod endpoints.
+	PodType EndpointType = "pod"
+	// PortType is the type of the Port endpoints.
+	PortType EndpointType = "port"
+	// HostPortType is the type of the HostPort endpoints.
+	HostPortType EndpointType = "hostport"
+	// ContainerType is the type of the Container endpoints.
+	ContainerType EndpointType = "container"
+)
+
+// Transport is the transport protocol of a port.
+type Transport string
+
+const (
+	// ProtocolTCP is the TCP transport.
+	ProtocolTCP Transport = "TCP"
+	// ProtocolUDP is the UDP transport.
+	ProtocolUDP Transport = "UDP"
+)
+
+// EndpointID uniquely identifies an endpoint of an observer.
+type EndpointID string
+
+// EndpointEnv is the environment of an endpoint, made of its details, which
+// the rules and the templated configurations of the receiver creator use.
+type EndpointEnv map[string]interface{}
+
+// EndpointDetails are the type specific details of an endpoint.
+type EndpointDetails interface {
+	// Type is the type of the endpoint.
+	Type() EndpointType
+	// Env returns the details as environment variables.
+	Env() EndpointEnv
+}
+
+// Endpoint is a discovered endpoint.
+type Endpoint struct {
+	// ID uniquely identifies the endpoint.
+	ID EndpointID
+	// Target is the address of the endpoint, e.g. "10.0.0.1:6379" or "10.0.0.1"
+	// for a pod.
+	Target string
+	// Details are the type specific details of the endpoint.
+	Details EndpointDetails
+}
+
+// Env returns the environment of the endpoint: its details, and its "type",
+// "endpoint" (the target) and "id".
+func (e Endpoint) Env() EndpointEnv {
+	env := e.Details.Env()
+	env["type"] = string(e.Details.Type())
+	env["endpoint"] = e.Target
+	env["id"] = string(e.ID)
+	return env
+}
+
+// Pod is a Kubernetes pod.
+type Pod struct {
+	// Name of the pod.
+	Name string
+	// UID of the pod.
+	UID string
+	// Namespace of the pod.
+	Namespace string
+	// Labels of the pod.
+	Labels map[string]string
+	// Annotations of the pod.
+	Annotations map[string]string
+}
+
+var _ EndpointDetails = (*Pod)(nil)
+
+// Type implements EndpointDetails.
+func (p *Pod) Type() EndpointType {
+	return PodType
+}
+
+// Env implements EndpointDetails.
+func (p *Pod) Env() EndpointEnv {
+	return EndpointEnv{
+		"name":        p.Name,
+		"uid":         p.UID,
+		"namespace":   p.Namespace,
+		"labels":      p.Labels,
+		"annotations": p.Annotations,
+	}
+}
+
+// Port is a port of a container of a Kubernetes pod.
+type Port struct {
+	// Name of the port, which may be empty.
+	Name string
+	// Pod the port belongs to.
+	Pod Pod
+	// Port number.
+	Port uint16
+	// Transport protocol of the port.
+	Transport Transport
+}
+
+var _ EndpointDetails = (*Port)(nil)
+
+// Type implements EndpointDetails.
+func (p *Port) Type() EndpointType {
+	return PortType
+}
+
+// Env implements EndpointDetails.
+func (p *Port) Env() EndpointEnv {
+	return EndpointEnv{
+		"name":      p.Name,
+		"port":      p.Port,
+		"transport": string(p.Transport),
+		"pod":       p.Pod.Env(),
+	}
+}
+
+// HostPort is a port listened on by a process of the host.
+type HostPort struct {
+	// ProcessName is the name of the process listening on the port.
+	ProcessName string
+	// Command is the command line of the process.
+	Command string
+	// Port number.
+	Port uint16
+	// Transport protocol of the port.
+	Transport Transport
+	// IsIPv6 is whether the port is listened on an IPv6 address.
+	IsIPv6 bool
+}
+
+var _ EndpointDetails = (*HostPort)(nil)
+
+// Type implements EndpointDetails.
+func (h *HostPort) Type() EndpointType {
+	return HostPortType
+}
+
+// Env implements EndpointDetails.
+func (h *HostPort) Env() EndpointEnv {
+	return EndpointEnv{
+		"process_name": h.ProcessName,
+		"command":      h.Command,
+		"port":         h.Port,
+		"transport":    string(h.Transport),
+		"is_ipv6":      h.IsIPv6,
+	}
+}
+
+// Container is a port exposed by a Docker container.
+type Container struct {
+	// Name of the container.
+	Name string
+	// Image of the container.
+	Image string
+	// ContainerID is the ID of the container.
+	ContainerID string
+	// Command is the command of the container.
+	Command string
+	// Host is the address of the container, or of the host if the port is
+	// published on the host.
+	Host string
+	// Port number, in the container or on the host.
+	Port uint16
+	// AlternatePort is the port published on the host when Port is the port in
+	// the container, and conversely. It is 0 if the port isn't published.
+	AlternatePort uint16
+	// Transport protocol of the port.
+	Transport Transport
+	// Labels of the container.
+	Labels map[string]string
+}
+
+var _ EndpointDetails = (*Container)(nil)
+
+// Type implements EndpointDetails.
+func (c *Container) Type() EndpointType {
+	return ContainerType
+}
+
+// Env implements EndpointDetails.
+func (c *Container) Env() EndpointEnv {
+	return EndpointEnv{
+		"name":           c.Name,
+		"image":          c.Image,
+		"container_id":   c.ContainerID,
+		"command":        c.Command,
+		"host":           c.Host,
+		"port":           c.Port,
+		"alternate_port": c.AlternatePort,
+		"transport":      string(c.Transport),
+		"labels":         c.Labels,
+	}
+}
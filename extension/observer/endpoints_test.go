@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointEnv(t *testing.T) {
+	pod := Pod{
+		Name:        "redis-0",
+		UID:         "uid-1",
+		Namespace:   "default",
+		Labels:      map[string]string{"app": "redis"},
+		Annotations: map[string]string{"scrape": "true"},
+	}
+	tests := []struct {
+		name     string
+		endpoint Endpoint
+		want     EndpointEnv
+	}{
+		{
+			name:     "pod",
+			endpoint: Endpoint{ID: "pod-1", Target: "10.0.0.1", Details: &pod},
+			want: EndpointEnv{
+				"type":        "pod",
+				"endpoint":    "10.0.0.1",
+				"id":          "pod-1",
+				"name":        "redis-0",
+				"uid":         "uid-1",
+				"namespace":   "default",
+				"labels":      map[string]string{"app": "redis"},
+				"annotations": map[string]string{"scrape": "true"},
+			},
+		},
+		{
+			name: "port",
+			endpoint: Endpoint{ID: "port-1", Target: "10.0.0.1:6379", Details: &Port{
+				Name: "redis", Pod: pod, Port: 6379, Transport: ProtocolTCP,
+			}},
+			want: EndpointEnv{
+				"type":      "port",
+				"endpoint":  "10.0.0.1:6379",
+				"id":        "port-1",
+				"name":      "redis",
+				"port":      uint16(6379),
+				"transport": "TCP",
+				"pod": EndpointEnv{
+					"name":        "redis-0",
+					"uid":         "uid-1",
+					"namespace":   "default",
+					"labels":      map[string]string{"app": "redis"},
+					"annotations": map[string]string{"scrape": "true"},
+				},
+			},
+		},
+		{
+			name: "host port",
+			endpoint: Endpoint{ID: "hostport-1", Target: "127.0.0.1:80", Details: &HostPort{
+				ProcessName: "nginx", Command: "nginx -g daemon off;", Port: 80, Transport: ProtocolTCP,
+			}},
+			want: EndpointEnv{
+				"type":         "hostport",
+				"endpoint":     "127.0.0.1:80",
+				"id":           "hostport-1",
+				"process_name": "nginx",
+				"command":      "nginx -g daemon off;",
+				"port":         uint16(80),
+				"transport":    "TCP",
+				"is_ipv6":      false,
+			},
+		},
+		{
+			name: "container",
+			endpoint: Endpoint{ID: "container-1", Target: "172.17.0.2:6379", Details: &Container{
+				Name: "redis", Image: "redis:6", ContainerID: "abc", Command: "redis-server",
+				Host: "172.17.0.2", Port: 6379, AlternatePort: 16379, Transport: ProtocolTCP,
+				Labels: map[string]string{"app": "redis"},
+			}},
+			want: EndpointEnv{
+				"type":           "container",
+				"endpoint":       "172.17.0.2:6379",
+				"id":             "container-1",
+				"name":           "redis",
+				"image":          "redis:6",
+				"container_id":   "abc",
+				"command":        "redis-server",
+				"host":           "172.17.0.2",
+				"port":           uint16(6379),
+				"alternate_port": uint16(16379),
+				"transport":      "TCP",
+				"labels":         map[string]string{"app": "redis"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.endpoint.Env())
+		})
+	}
+}
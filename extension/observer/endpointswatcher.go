@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observer
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// EndpointsLister lists the current endpoints of an observer.
+type EndpointsLister interface {
+	ListEndpoints() ([]Endpoint, error)
+}
+
+// EndpointsWatcher implements Observable for the observers which list their
+// endpoints periodically: the endpoints are listed every RefreshInterval for
+// each subscriber, which is notified of the changes since the previous list.
+// The endpoints are unchanged when they can't be listed.
+type EndpointsWatcher struct {
+	Endpoints       EndpointsLister
+	RefreshInterval time.Duration
+	Logger          *zap.Logger
+
+	mu          sync.Mutex
+	subscribers map[Notify]*subscriber
+}
+
+type subscriber struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+var _ Observable = (*EndpointsWatcher)(nil)
+
+// ListAndWatch implements Observable.
+func (ew *EndpointsWatcher) ListAndWatch(notify Notify) {
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+
+	if ew.subscribers == nil {
+		ew.subscribers = make(map[Notify]*subscriber)
+	}
+	if _, ok := ew.subscribers[notify]; ok {
+		return
+	}
+	s := &subscriber{stop: make(chan struct{}), done: make(chan struct{})}
+	ew.subscribers[notify] = s
+	go ew.watch(notify, s)
+}
+
+// Unsubscribe implements Observable. The subscriber is no longer notified once
+// it returns, so it must not be called from a notification.
+func (ew *EndpointsWatcher) Unsubscribe(notify Notify) {
+	ew.mu.Lock()
+	s, ok := ew.subscribers[notify]
+	delete(ew.subscribers, notify)
+	ew.mu.Unlock()
+
+	if ok {
+		close(s.stop)
+		<-s.done
+	}
+}
+
+// StopListAndWatch unsubscribes all the subscribers, when the observer is
+// shut down.
+func (ew *EndpointsWatcher) StopListAndWatch() {
+	ew.mu.Lock()
+	subscribers := ew.subscribers
+	ew.subscribers = nil
+	ew.mu.Unlock()
+
+	for _, s := range subscribers {
+		close(s.stop)
+		<-s.done
+	}
+}
+
+func (ew *EndpointsWatcher) watch(notify Notify, s *subscriber) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(ew.RefreshInterval)
+	defer ticker.Stop()
+
+	existing := map[EndpointID]Endpoint{}
+	for {
+		existing = ew.refresh(notify, existing)
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh notifies the subscriber of the differences between the existing
+// endpoints and the current ones, which it returns.
+func (ew *EndpointsWatcher) refresh(notify Notify, existing map[EndpointID]Endpoint) map[EndpointID]Endpoint {
+	endpoints, err := ew.Endpoints.ListEndpoints()
+	if err != nil {
+		ew.Logger.Warn("Failed to list the endpoints", zap.Error(err))
+		return existing
+	}
+
+	current := make(map[EndpointID]Endpoint)
+	for _, e := range endpoints {
+		current[e.ID] = e
+	}
+
+	var removed, added, changed []Endpoint
+	for id, e := range existing {
+		if _, ok := current[id]; !ok {
+			removed = append(removed, e)
+		}
+	}
+	for id, e := range current {
+		if previous, ok := existing[id]; !ok {
+			added = append(added, e)
+		} else if !reflect.DeepEqual(previous, e) {
+			changed = append(changed, e)
+		}
+	}
+
+	if len(removed) > 0 {
+		notify.OnRemove(removed)
+	}
+	if len(added) > 0 {
+		notify.OnAdd(added)
+	}
+	if len(changed) > 0 {
+		notify.OnChange(changed)
+	}
+	return current
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockLister struct {
+	mu        sync.Mutex
+	endpoints []Endpoint
+	err       error
+}
+
+func (m *mockLister) ListEndpoints() ([]Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.endpoints, m.err
+}
+
+func (m *mockLister) set(endpoints ...Endpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoints = endpoints
+}
+
+func (m *mockLister) setError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+type notification struct {
+	kind      string
+	endpoints []Endpoint
+}
+
+type mockNotify struct {
+	ch chan notification
+}
+
+func (m *mockNotify) OnAdd(added []Endpoint) {
+	m.ch <- notification{"add", added}
+}
+
+func (m *mockNotify) OnRemove(removed []Endpoint) {
+	m.ch <- notification{"remove", removed}
+}
+
+func (m *mockNotify) OnChange(changed []Endpoint) {
+	m.ch <- notification{"change", changed}
+}
+
+func (m *mockNotify) next(t *testing.T) notification {
+	select {
+	case n := <-m.ch:
+		return n
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "no notification")
+		return notification{}
+	}
+}
+
+func TestEndpointsWatcher(t *testing.T) {
+	redis := Endpoint{ID: "redis", Target: "127.0.0.1:6379", Details: &HostPort{ProcessName: "redis-server", Port: 6379}}
+	nginx := Endpoint{ID: "nginx", Target: "127.0.0.1:80", Details: &HostPort{ProcessName: "nginx", Port: 80}}
+	nginxRestarted := Endpoint{ID: "nginx", Target: "127.0.0.1:80", Details: &HostPort{ProcessName: "nginx", Command: "nginx", Port: 80}}
+
+	lister := &mockLister{}
+	lister.set(redis)
+	ew := &EndpointsWatcher{Endpoints: lister, RefreshInterval: 10 * time.Millisecond, Logger: zap.NewNop()}
+	notify := &mockNotify{ch: make(chan notification, 10)}
+
+	ew.ListAndWatch(notify)
+	assert.Equal(t, notification{"add", []Endpoint{redis}}, notify.next(t))
+
+	lister.set(redis, nginx)
+	assert.Equal(t, notification{"add", []Endpoint{nginx}}, notify.next(t))
+
+	lister.set(nginxRestarted)
+	assert.Equal(t, notification{"remove", []Endpoint{redis}}, notify.next(t))
+	assert.Equal(t, notification{"change", []Endpoint{nginxRestarted}}, notify.next(t))
+
+	// A second subscriber is notified of all the current endpoints.
+	other := &mockNotify{ch: make(chan notification, 10)}
+	ew.ListAndWatch(other)
+	assert.Equal(t, notification{"add", []Endpoint{nginxRestarted}}, other.next(t))
+
+	// The endpoints are unchanged when they can't be listed.
+	lister.setError(errors.New("failed"))
+	lister.set()
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, notify.ch, 0)
+	assert.Len(t, other.ch, 0)
+
+	ew.Unsubscribe(notify)
+	lister.setError(nil)
+	assert.Equal(t, notification{"remove", []Endpoint{nginxRestarted}}, other.next(t))
+
+	ew.StopListAndWatch()
+	lister.set(redis)
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, notify.ch, 0)
+	assert.Len(t, other.ch, 0)
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostobserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"syscall"
+
+	psnet "github.com/shirou/gopsutil/net"
+	"github.com/shirou/gopsutil/process"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+// hostObserver discovers the ports listened on by the processes of the host.
+type hostObserver struct {
+	observer.EndpointsWatcher
+}
+
+var _ component.Extension = (*hostObserver)(nil)
+var _ observer.Observable = (*hostObserver)(nil)
+
+func newObserver(config *Config, logger *zap.Logger) *hostObserver {
+	return &hostObserver{
+		EndpointsWatcher: observer.EndpointsWatcher{
+			Endpoints: &hostEndpointsLister{
+				logger:             logger,
+				observerName:       config.Name(),
+				collectConnections: collectConnections,
+				processDetails:     processDetails,
+			},
+			RefreshInterval: config.RefreshInterval,
+			Logger:          logger,
+		},
+	}
+}
+
+func (h *hostObserver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (h *hostObserver) Shutdown(context.Context) error {
+	h.StopListAndWatch()
+	return nil
+}
+
+type hostEndpointsLister struct {
+	logger       *zap.Logger
+	observerName string
+
+	// collectConnections returns the sockets of the host.
+	collectConnections func() ([]psnet.ConnectionStat, error)
+	// processDetails returns the name and the command line of a process.
+	processDetails func(pid int32) (string, string, error)
+}
+
+func collectConnections() ([]psnet.ConnectionStat, error) {
+	return psnet.Connections("inet")
+}
+
+func processDetails(pid int32) (string, string, error) {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return "", "", err
+	}
+	name, err := p.Name()
+	if err != nil {
+		return "", "", err
+	}
+	cmdline, err := p.Cmdline()
+	if err != nil {
+		return "", "", err
+	}
+	return name, cmdline, nil
+}
+
+// ListEndpoints implements observer.EndpointsLister: the endpoints are the
+// listening TCP sockets and the unconnected UDP sockets.
+func (h *hostEndpointsLister) ListEndpoints() ([]observer.Endpoint, error) {
+	conns, err := h.collectConnections()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the sockets of the host: %w", err)
+	}
+
+	seen := make(map[observer.EndpointID]bool)
+	var endpoints []observer.Endpoint
+	for _, c := range conns {
+		var transport observer.Transport
+		switch {
+		case c.Type == syscall.SOCK_STREAM && c.Status == "LISTEN":
+			transport = observer.ProtocolTCP
+		case c.Type == syscall.SOCK_DGRAM && c.Raddr.Port == 0:
+			transport = observer.ProtocolUDP
+		default:
+			continue
+		}
+
+		id := observer.EndpointID(fmt.Sprintf("(%s)%s-%d-%s-%d", h.observerName, c.Laddr.IP, c.Laddr.Port, transport, c.Pid))
+		if seen[id] {
+			// The processes such as nginx share their sockets between their workers.
+			continue
+		}
+		seen[id] = true
+
+		isIPv6 := c.Family == syscall.AF_INET6
+		details := &observer.HostPort{
+			Port:      uint16(c.Laddr.Port),
+			Transport: transport,
+			IsIPv6:    isIPv6,
+		}
+		if c.Pid > 0 {
+			if details.ProcessName, details.Command, err = h.processDetails(c.Pid); err != nil {
+				h.logger.Debug("Failed to get the details of the process", zap.Int32("pid", c.Pid), zap.Error(err))
+			}
+		}
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:      id,
+			Target:  net.JoinHostPort(targetHost(c.Laddr.IP, isIPv6), fmt.Sprint(c.Laddr.Port)),
+			Details: details,
+		})
+	}
+	return endpoints, nil
+}
+
+// targetHost returns the host the processes listening on ip can be reached on,
+// the loopback address when they listen on all the addresses.
+func targetHost(ip string, isIPv6 bool) string {
+	if parsed := net.ParseIP(ip); parsed == nil || !parsed.IsUnspecified() {
+		return ip
+	}
+	if isIPv6 {
+		return "::1"
+	}
+	return "127.0.0.1"
+}
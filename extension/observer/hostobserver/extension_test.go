@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostobserver
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	psnet "github.com/shirou/gopsutil/net"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+func TestListEndpoints(t *testing.T) {
+	conns := []psnet.ConnectionStat{
+		{
+			Family: syscall.AF_INET, Type: syscall.SOCK_STREAM, Status: "LISTEN", Pid: 10,
+			Laddr: psnet.Addr{IP: "0.0.0.0", Port: 80},
+		},
+		// A socket shared by a worker.
+		{
+			Family: syscall.AF_INET, Type: syscall.SOCK_STREAM, Status: "LISTEN", Pid: 10,
+			Laddr: psnet.Addr{IP: "0.0.0.0", Port: 80},
+		},
+		{
+			Family: syscall.AF_INET6, Type: syscall.SOCK_STREAM, Status: "LISTEN", Pid: 11,
+			Laddr: psnet.Addr{IP: "::", Port: 6379},
+		},
+		{
+			Family: syscall.AF_INET, Type: syscall.SOCK_DGRAM, Status: "NONE", Pid: 12,
+			Laddr: psnet.Addr{IP: "127.0.0.1", Port: 8125},
+		},
+		// The connections aren't endpoints.
+		{
+			Family: syscall.AF_INET, Type: syscall.SOCK_STREAM, Status: "ESTABLISHED", Pid: 10,
+			Laddr: psnet.Addr{IP: "10.0.0.1", Port: 80}, Raddr: psnet.Addr{IP: "10.0.0.2", Port: 50000},
+		},
+		{
+			Family: syscall.AF_INET, Type: syscall.SOCK_DGRAM, Status: "NONE", Pid: 12,
+			Laddr: psnet.Addr{IP: "10.0.0.1", Port: 50001}, Raddr: psnet.Addr{IP: "10.0.0.3", Port: 53},
+		},
+	}
+	processes := map[int32][2]string{
+		10: {"nginx", "nginx: master process"},
+		11: {"redis-server", "redis-server *:6379"},
+	}
+
+	lister := &hostEndpointsLister{
+		logger:       zap.NewNop(),
+		observerName: "host_observer",
+		collectConnections: func() ([]psnet.ConnectionStat, error) {
+			return conns, nil
+		},
+		processDetails: func(pid int32) (string, string, error) {
+			p, ok := processes[pid]
+			if !ok {
+				return "", "", errors.New("permission denied")
+			}
+			return p[0], p[1], nil
+		},
+	}
+
+	endpoints, err := lister.ListEndpoints()
+	require.NoError(t, err)
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     "(host_observer)0.0.0.0-80-TCP-10",
+			Target: "127.0.0.1:80",
+			Details: &observer.HostPort{
+				ProcessName: "nginx",
+				Command:     "nginx: master process",
+				Port:        80,
+				Transport:   observer.ProtocolTCP,
+			},
+		},
+		{
+			ID:     "(host_observer)::-6379-TCP-11",
+			Target: "[::1]:6379",
+			Details: &observer.HostPort{
+				ProcessName: "redis-server",
+				Command:     "redis-server *:6379",
+				Port:        6379,
+				Transport:   observer.ProtocolTCP,
+				IsIPv6:      true,
+			},
+		},
+		{
+			ID:     "(host_observer)127.0.0.1-8125-UDP-12",
+			Target: "127.0.0.1:8125",
+			Details: &observer.HostPort{
+				Port:      8125,
+				Transport: observer.ProtocolUDP,
+			},
+		},
+	}, endpoints)
+
+	lister.collectConnections = func() ([]psnet.ConnectionStat, error) {
+		return nil, errors.New("failed")
+	}
+	_, err = lister.ListEndpoints()
+	assert.EqualError(t, err, "failed to list the sockets of the host: failed")
+}
+
+type endpointsNotify struct {
+	added chan []observer.Endpoint
+}
+
+func (n *endpointsNotify) OnAdd(added []observer.Endpoint) {
+	n.added <- added
+}
+
+func (n *endpointsNotify) OnRemove([]observer.Endpoint) {}
+
+func (n *endpointsNotify) OnChange([]observer.Endpoint) {}
+
+func TestObserver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	h := newObserver(cfg, zap.NewNop())
+	h.Endpoints.(*hostEndpointsLister).collectConnections = func() ([]psnet.ConnectionStat, error) {
+		return []psnet.ConnectionStat{{
+			Family: syscall.AF_INET, Type: syscall.SOCK_STREAM, Status: "LISTEN",
+			Laddr: psnet.Addr{IP: "127.0.0.1", Port: 8080},
+		}}, nil
+	}
+	require.NoError(t, h.Start(context.Background(), componenttest.NewNopHost()))
+
+	notify := &endpointsNotify{added: make(chan []observer.Endpoint, 1)}
+	h.ListAndWatch(notify)
+	select {
+	case added := <-notify.added:
+		require.Len(t, added, 1)
+		assert.Equal(t, "127.0.0.1:8080", added[0].Target)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "no endpoint added")
+	}
+
+	require.NoError(t, h.Shutdown(context.Background()))
+}
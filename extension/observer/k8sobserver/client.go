@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	authTypeServiceAccount = "serviceAccount"
+	authTypeKubeConfig     = "kubeConfig"
+)
+
+// makeClient creates a client of the Kubernetes API authenticated with authType.
+type makeClient func(authType string) (kubernetes.Interface, error)
+
+func validateAuthType(authType string) error {
+	if authType != authTypeServiceAccount && authType != authTypeKubeConfig {
+		return fmt.Errorf("invalid auth_type %q, expecting %q or %q", authType, authTypeServiceAccount, authTypeKubeConfig)
+	}
+	return nil
+}
+
+func newClient(authType string) (kubernetes.Interface, error) {
+	var restConfig *rest.Config
+	var err error
+	if authType == authTypeKubeConfig {
+		restConfig, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{}).ClientConfig()
+	} else {
+		restConfig, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the Kubernetes API configuration: %w", err)
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the Kubernetes observer.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// AuthType is how the observer authenticates to the Kubernetes API: "serviceAccount", with the service account of
+	// the pod it runs in, or "kubeConfig", with the kubeconfig files of the KUBECONFIG environment variable, or
+	// ~/.kube/config if it is not set.
+	AuthType string `mapstructure:"auth_type"`
+
+	// Node is the name of the node whose pods are observed, typically set from an environment variable of the pod
+	// of the collector with the downward API, e.g. ${K8S_NODE_NAME}. The pods of all the nodes are observed if it is
+	// not set.
+	Node string `mapstructure:"node"`
+}
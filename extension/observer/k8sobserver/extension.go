@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+// k8sObserver discovers the pods and the ports of their containers.
+type k8sObserver struct {
+	config     *Config
+	logger     *zap.Logger
+	makeClient makeClient
+	stopCh     chan struct{}
+
+	// mu serializes the updates of the endpoints and the notifications.
+	mu          sync.Mutex
+	endpoints   map[types.UID][]observer.Endpoint
+	subscribers map[observer.Notify]bool
+}
+
+var _ component.Extension = (*k8sObserver)(nil)
+var _ observer.Observable = (*k8sObserver)(nil)
+
+func newObserver(config *Config, logger *zap.Logger, makeClient makeClient) *k8sObserver {
+	return &k8sObserver{
+		config:      config,
+		logger:      logger,
+		makeClient:  makeClient,
+		endpoints:   make(map[types.UID][]observer.Endpoint),
+		subscribers: make(map[observer.Notify]bool),
+	}
+}
+
+func (k *k8sObserver) Start(context.Context, component.Host) error {
+	client, err := k.makeClient(k.config.AuthType)
+	if err != nil {
+		return err
+	}
+
+	var options []informers.SharedInformerOption
+	if k.config.Node != "" {
+		options = append(options, informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", k.config.Node).String()
+		}))
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0, options...)
+	factory.Core().V1().Pods().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				k.updatePod(pod.UID, k.podEndpoints(pod))
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if pod, ok := newObj.(*corev1.Pod); ok {
+				k.updatePod(pod.UID, k.podEndpoints(pod))
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if deleted, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = deleted.Obj
+			}
+			if pod, ok := obj.(*corev1.Pod); ok {
+				k.updatePod(pod.UID, nil)
+			}
+		},
+	})
+
+	k.stopCh = make(chan struct{})
+	factory.Start(k.stopCh)
+	return nil
+}
+
+func (k *k8sObserver) Shutdown(context.Context) error {
+	if k.stopCh != nil {
+		close(k.stopCh)
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.subscribers = make(map[observer.Notify]bool)
+	return nil
+}
+
+// ListAndWatch implements observer.Observable.
+func (k *k8sObserver) ListAndWatch(notify observer.Notify) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.subscribers[notify] {
+		return
+	}
+	k.subscribers[notify] = true
+
+	var all []observer.Endpoint
+	for _, endpoints := range k.endpoints {
+		all = append(all, endpoints...)
+	}
+	if len(all) > 0 {
+		notify.OnAdd(all)
+	}
+}
+
+// Unsubscribe implements observer.Observable. It must not be called from a
+// notification.
+func (k *k8sObserver) Unsubscribe(notify observer.Notify) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.subscribers, notify)
+}
+
+// updatePod replaces the endpoints of a pod, and notifies the subscribers of
+// the differences.
+func (k *k8sObserver) updatePod(uid types.UID, endpoints []observer.Endpoint) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	previous := make(map[observer.EndpointID]observer.Endpoint)
+	for _, e := range k.endpoints[uid] {
+		previous[e.ID] = e
+	}
+	if len(endpoints) > 0 {
+		k.endpoints[uid] = endpoints
+	} else {
+		delete(k.endpoints, uid)
+	}
+
+	var removed, added, changed []observer.Endpoint
+	current := make(map[observer.EndpointID]bool)
+	for _, e := range endpoints {
+		current[e.ID] = true
+		if p, ok := previous[e.ID]; !ok {
+			added = append(added, e)
+		} else if !reflect.DeepEqual(p, e) {
+			changed = append(changed, e)
+		}
+	}
+	for id, e := range previous {
+		if !current[id] {
+			removed = append(removed, e)
+		}
+	}
+
+	for notify := range k.subscribers {
+		if len(removed) > 0 {
+			notify.OnRemove(removed)
+		}
+		if len(added) > 0 {
+			notify.OnAdd(added)
+		}
+		if len(changed) > 0 {
+			notify.OnChange(changed)
+		}
+	}
+}
+
+// podEndpoints returns the endpoints of a pod and of the ports of its
+// containers, none until it has an IP address or once it terminated.
+func (k *k8sObserver) podEndpoints(pod *corev1.Pod) []observer.Endpoint {
+	if pod.Status.PodIP == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return nil
+	}
+
+	podID := observer.EndpointID(fmt.Sprintf("%s/%s", k.config.Name(), pod.UID))
+	podDetails := observer.Pod{
+		Name:        pod.Name,
+		UID:         string(pod.UID),
+		Namespace:   pod.Namespace,
+		Labels:      pod.Labels,
+		Annotations: pod.Annotations,
+	}
+	endpoints := []observer.Endpoint{{
+		ID:      podID,
+		Target:  pod.Status.PodIP,
+		Details: &podDetails,
+	}}
+
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			transport := observer.ProtocolTCP
+			if port.Protocol == corev1.ProtocolUDP {
+				transport = observer.ProtocolUDP
+			}
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:     observer.EndpointID(fmt.Sprintf("%s/%s(%d)", podID, port.Name, port.ContainerPort)),
+				Target: net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port.ContainerPort))),
+				Details: &observer.Port{
+					Name:      port.Name,
+					Pod:       podDetails,
+					Port:      uint16(port.ContainerPort),
+					Transport: transport,
+				},
+			})
+		}
+	}
+	return endpoints
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+func fakeClient(objects ...runtime.Object) (*fake.Clientset, makeClient) {
+	client := fake.NewSimpleClientset(objects...)
+	return client, func(string) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+type notification struct {
+	kind      string
+	endpoints []observer.Endpoint
+}
+
+type mockNotify struct {
+	ch chan notification
+}
+
+func (m *mockNotify) OnAdd(added []observer.Endpoint) {
+	m.ch <- notification{"add", added}
+}
+
+func (m *mockNotify) OnRemove(removed []observer.Endpoint) {
+	m.ch <- notification{"remove", removed}
+}
+
+func (m *mockNotify) OnChange(changed []observer.Endpoint) {
+	m.ch <- notification{"change", changed}
+}
+
+func (m *mockNotify) next(t *testing.T) notification {
+	select {
+	case n := <-m.ch:
+		return n
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "no notification")
+		return notification{}
+	}
+}
+
+func newPod(name string, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID("uid-" + name),
+			Labels:    map[string]string{"app": "redis"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "redis",
+				Ports: []corev1.ContainerPort{
+					{Name: "redis", ContainerPort: 6379, Protocol: corev1.ProtocolTCP},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: ip,
+		},
+	}
+}
+
+func TestPodEndpoints(t *testing.T) {
+	k := newObserver(createDefaultConfig().(*Config), zap.NewNop(), nil)
+
+	pod := newPod("redis-0", "10.0.0.1")
+	podDetails := observer.Pod{
+		Name:      "redis-0",
+		UID:       "uid-redis-0",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "redis"},
+	}
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:      "k8s_observer/uid-redis-0",
+			Target:  "10.0.0.1",
+			Details: &podDetails,
+		},
+		{
+			ID:     "k8s_observer/uid-redis-0/redis(6379)",
+			Target: "10.0.0.1:6379",
+			Details: &observer.Port{
+				Name:      "redis",
+				Pod:       podDetails,
+				Port:      6379,
+				Transport: observer.ProtocolTCP,
+			},
+		},
+	}, k.podEndpoints(pod))
+
+	pod.Status.Phase = corev1.PodSucceeded
+	assert.Empty(t, k.podEndpoints(pod))
+
+	assert.Empty(t, k.podEndpoints(newPod("redis-1", "")))
+}
+
+func TestObserver(t *testing.T) {
+	client, makeClient := fakeClient(newPod("redis-0", "10.0.0.1"))
+	k := newObserver(createDefaultConfig().(*Config), zap.NewNop(), makeClient)
+	require.NoError(t, k.Start(context.Background(), componenttest.NewNopHost()))
+	defer k.Shutdown(context.Background())
+
+	// The endpoints are listed once the pods are synchronized.
+	notify := &mockNotify{ch: make(chan notification, 10)}
+	require.Eventually(t, func() bool {
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		return len(k.endpoints) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	k.ListAndWatch(notify)
+	n := notify.next(t)
+	assert.Equal(t, "add", n.kind)
+	assert.Len(t, n.endpoints, 2)
+
+	// A new pod is added once it has an IP address.
+	pod := newPod("redis-1", "")
+	pod, err := client.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+	pod.Status.PodIP = "10.0.0.2"
+	pod, err = client.CoreV1().Pods("default").Update(context.Background(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	n = notify.next(t)
+	assert.Equal(t, "add", n.kind)
+	require.Len(t, n.endpoints, 2)
+	assert.Equal(t, "10.0.0.2", n.endpoints[0].Target)
+
+	// The endpoints of a pod whose labels changed are changed.
+	pod.Labels = map[string]string{"app": "redis", "version": "6"}
+	pod, err = client.CoreV1().Pods("default").Update(context.Background(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	n = notify.next(t)
+	assert.Equal(t, "change", n.kind)
+	require.Len(t, n.endpoints, 2)
+	assert.Equal(t, "6", n.endpoints[0].Details.(*observer.Pod).Labels["version"])
+
+	require.NoError(t, client.CoreV1().Pods("default").Delete(context.Background(), pod.Name, metav1.DeleteOptions{}))
+	n = notify.next(t)
+	assert.Equal(t, "remove", n.kind)
+	assert.Len(t, n.endpoints, 2)
+
+	// The unsubscribed subscribers are no longer notified.
+	k.Unsubscribe(notify)
+	require.NoError(t, client.CoreV1().Pods("default").Delete(context.Background(), "redis-0", metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		return len(k.endpoints) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Len(t, notify.ch, 0)
+}
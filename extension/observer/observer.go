@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observer
+
+// Notify is the interface of the subscribers of an Observable, notified of the
+// changes of its endpoints.
+type Notify interface {
+	// OnAdd is called when the endpoints are discovered.
+	OnAdd(added []Endpoint)
+	// OnRemove is called when the endpoints are no longer available.
+	OnRemove(removed []Endpoint)
+	// OnChange is called when the details of the endpoints changed.
+	OnChange(changed []Endpoint)
+}
+
+// Observable is the interface implemented by the observer extensions.
+type Observable interface {
+	// ListAndWatch notifies the subscriber of all the current endpoints, with
+	// OnAdd, and of their changes afterward, until it is unsubscribed or the
+	// observer is shut down. The notifications of a subscriber are sequential.
+	ListAndWatch(notify Notify)
+
+	// Unsubscribe stops notifying the subscriber.
+	Unsubscribe(notify Notify)
+}
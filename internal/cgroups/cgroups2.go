@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package cgroups
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// _cgroup2FSType is the Linux CGroup v2 file system type used in
+	// `/proc/$PID/mountinfo`.
+	_cgroup2FSType = "cgroup2"
+
+	// _cgroup2CPUMax is the file name for the CGroup v2 CPU bandwidth limit.
+	_cgroup2CPUMax = "cpu.max"
+	// _cgroup2MemoryMax is the file name for the CGroup v2 memory limit.
+	_cgroup2MemoryMax = "memory.max"
+	// _cgroup2MemoryCurrent is the file name for the CGroup v2 memory usage.
+	_cgroup2MemoryCurrent = "memory.current"
+
+	// _cgroup2Max is the value of the CGroup v2 limits when no limit is set.
+	_cgroup2Max = "max"
+)
+
+// CGroups2 is the control group of a process in the cgroup v2 unified hierarchy.
+type CGroups2 struct {
+	cgroup *CGroup
+}
+
+// NewCGroups2 returns a new *CGroups2 from given `mountinfo` and `cgroup`
+// files. It returns `(nil, false, nil)` if the process does not only belong
+// to the cgroup v2 unified hierarchy, or if this hierarchy is not mounted.
+func NewCGroups2(procPathMountInfo, procPathCGroup string) (*CGroups2, bool, error) {
+	cgroupSubsystems, err := parseCGroupSubsystems(procPathCGroup)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the unified hierarchy has the ID 0 and no subsystems
+	subsys, exists := cgroupSubsystems[""]
+	if !exists || subsys.ID != 0 || len(cgroupSubsystems) != 1 {
+		return nil, false, nil
+	}
+
+	var cgroups2 *CGroups2
+	newMountPoint := func(mp *MountPoint) error {
+		if mp.FSType != _cgroup2FSType {
+			return nil
+		}
+
+		cgroupPath, err := mp.Translate(subsys.Name)
+		if err != nil {
+			return err
+		}
+		cgroups2 = &CGroups2{cgroup: NewCGroup(cgroupPath)}
+		return nil
+	}
+
+	if err := parseMountInfo(procPathMountInfo, newMountPoint); err != nil {
+		return nil, false, err
+	}
+	return cgroups2, cgroups2 != nil, nil
+}
+
+// CPUQuota returns the CPU quota applied with the CPU controller.
+// It is a result of the `cpu.max` quota divided by its period. If no quota is
+// set or the CPU controller is not enabled, the method returns `(-1, false, nil)`.
+func (cg *CGroups2) CPUQuota() (float64, bool, error) {
+	text, err := cg.cgroup.readFirstLine(_cgroup2CPUMax)
+	if os.IsNotExist(err) {
+		return -1, false, nil
+	}
+	if err != nil {
+		return -1, false, err
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) != 2 {
+		return -1, false, fmt.Errorf("invalid format for %s: %q", _cgroup2CPUMax, text)
+	}
+	if fields[0] == _cgroup2Max {
+		return -1, false, nil
+	}
+
+	quota, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return -1, false, err
+	}
+	period, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return -1, false, err
+	}
+	return float64(quota) / float64(period), true, nil
+}
+
+// CPUThrottling returns the CPU throttling statistics of the CPU controller
+// from `cpu.stat`. If the CPU controller is not enabled, the method returns
+// `(CPUThrottling{}, false, nil)`.
+func (cg *CGroups2) CPUThrottling() (CPUThrottling, bool, error) {
+	stat, err := cg.cgroup.readKeyValues(_cgroupCPUStat)
+	if os.IsNotExist(err) {
+		return CPUThrottling{}, false, nil
+	}
+	if err != nil {
+		return CPUThrottling{}, false, err
+	}
+
+	// the throttling statistics are only reported when the CPU controller is enabled
+	periods, defined := stat["nr_periods"]
+	if !defined {
+		return CPUThrottling{}, false, nil
+	}
+	return CPUThrottling{
+		Periods:          periods,
+		ThrottledPeriods: stat["nr_throttled"],
+		ThrottledTime:    time.Duration(stat["throttled_usec"]) * time.Microsecond,
+	}, true, nil
+}
+
+// MemoryQuota returns the memory limit applied with the memory controller.
+// It is a result of `memory.max`. If no limit is set or the memory controller
+// is not enabled, the method returns `(-1, false, nil)`.
+func (cg *CGroups2) MemoryQuota() (int64, bool, error) {
+	text, err := cg.cgroup.readFirstLine(_cgroup2MemoryMax)
+	if os.IsNotExist(err) {
+		return -1, false, nil
+	}
+	if err != nil {
+		return -1, false, err
+	}
+	if text == _cgroup2Max {
+		return -1, false, nil
+	}
+
+	limit, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return -1, false, err
+	}
+	return limit, true, nil
+}
+
+// MemoryUsage returns the memory used by the control group.
+// It is a result of `memory.current`. If the memory controller is not
+// enabled, the method returns `(-1, false, nil)`.
+func (cg *CGroups2) MemoryUsage() (int64, bool, error) {
+	text, err := cg.cgroup.readFirstLine(_cgroup2MemoryCurrent)
+	if os.IsNotExist(err) {
+		return -1, false, nil
+	}
+	if err != nil {
+		return -1, false, err
+	}
+
+	usage, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return -1, false, err
+	}
+	return usage, true, nil
+}
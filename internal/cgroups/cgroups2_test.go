@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package cgroups
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCGroups2(t *testing.T) {
+	cgroups2, isV2, err := NewCGroups2(
+		filepath.Join(testDataProcPath, "cgroups2", "mountinfo"),
+		filepath.Join(testDataProcPath, "cgroups2", "cgroup"),
+	)
+	require.NoError(t, err)
+	require.True(t, isV2)
+	assert.Equal(t, "/sys/fs/cgroup/large", cgroups2.cgroup.path)
+
+	// the process belongs to cgroup v1 hierarchies
+	cgroups2, isV2, err = NewCGroups2(
+		filepath.Join(testDataProcPath, "cgroups", "mountinfo"),
+		filepath.Join(testDataProcPath, "cgroups", "cgroup"),
+	)
+	require.NoError(t, err)
+	assert.False(t, isV2)
+	assert.Nil(t, cgroups2)
+
+	// the unified hierarchy is not mounted
+	cgroups2, isV2, err = NewCGroups2(
+		filepath.Join(testDataProcPath, "cgroups", "mountinfo"),
+		filepath.Join(testDataProcPath, "cgroups2", "cgroup"),
+	)
+	require.NoError(t, err)
+	assert.False(t, isV2)
+	assert.Nil(t, cgroups2)
+
+	_, _, err = NewCGroups2("/dev/null", filepath.Join(testDataProcPath, "invalid-cgroup", "cgroup"))
+	assert.Error(t, err)
+}
+
+func TestCGroups2CPUQuota(t *testing.T) {
+	testTable := []struct {
+		name            string
+		expectedQuota   float64
+		expectedDefined bool
+	}{
+		{name: "v2", expectedQuota: 2.0, expectedDefined: true},
+		{name: "v2-unlimited", expectedQuota: -1.0, expectedDefined: false},
+		{name: "nonexistent", expectedQuota: -1.0, expectedDefined: false},
+	}
+
+	for _, tt := range testTable {
+		cgroups2 := &CGroups2{cgroup: NewCGroup(filepath.Join(testDataCGroupsPath, tt.name))}
+
+		quota, defined, err := cgroups2.CPUQuota()
+		assert.Equal(t, tt.expectedQuota, quota, tt.name)
+		assert.Equal(t, tt.expectedDefined, defined, tt.name)
+		assert.NoError(t, err, tt.name)
+	}
+}
+
+func TestCGroups2CPUThrottling(t *testing.T) {
+	testTable := []struct {
+		name               string
+		expectedThrottling CPUThrottling
+		expectedDefined    bool
+	}{
+		{
+			name:               "v2",
+			expectedThrottling: CPUThrottling{Periods: 100, ThrottledPeriods: 10, ThrottledTime: 1500 * time.Millisecond},
+			expectedDefined:    true,
+		},
+		{name: "v2-unlimited", expectedDefined: false},
+		{name: "nonexistent", expectedDefined: false},
+	}
+
+	for _, tt := range testTable {
+		cgroups2 := &CGroups2{cgroup: NewCGroup(filepath.Join(testDataCGroupsPath, tt.name))}
+
+		throttling, defined, err := cgroups2.CPUThrottling()
+		assert.Equal(t, tt.expectedThrottling, throttling, tt.name)
+		assert.Equal(t, tt.expectedDefined, defined, tt.name)
+		assert.NoError(t, err, tt.name)
+	}
+}
+
+func TestCGroups2Memory(t *testing.T) {
+	testTable := []struct {
+		name            string
+		expectedQuota   int64
+		expectedDefined bool
+		expectedUsage   int64
+	}{
+		{name: "v2", expectedQuota: 1073741824, expectedDefined: true, expectedUsage: 536870912},
+		{name: "nonexistent", expectedQuota: -1, expectedDefined: false, expectedUsage: -1},
+	}
+
+	for _, tt := range testTable {
+		cgroups2 := &CGroups2{cgroup: NewCGroup(filepath.Join(testDataCGroupsPath, tt.name))}
+
+		quota, defined, err := cgroups2.MemoryQuota()
+		assert.Equal(t, tt.expectedQuota, quota, tt.name)
+		assert.Equal(t, tt.expectedDefined, defined, tt.name)
+		assert.NoError(t, err, tt.name)
+
+		usage, defined, err := cgroups2.MemoryUsage()
+		assert.Equal(t, tt.expectedUsage, usage, tt.name)
+		assert.Equal(t, tt.expectedDefined, defined, tt.name)
+		assert.NoError(t, err, tt.name)
+	}
+
+	// no memory limit is set
+	cgroups2 := &CGroups2{cgroup: NewCGroup(filepath.Join(testDataCGroupsPath, "v2-unlimited"))}
+	quota, defined, err := cgroups2.MemoryQuota()
+	assert.Equal(t, int64(-1), quota)
+	assert.False(t, defined)
+	assert.NoError(t, err)
+}
@@ -135,3 +135,53 @@ func TestCGroupsCPUQuota(t *testing.T) {
 		}
 	}
 }
+
+func TestCGroupsCPUQuotaFromCFS(t *testing.T) {
+	testTable := []struct {
+		name            string
+		expectedQuota   float64
+		expectedDefined bool
+		shouldHaveError bool
+	}{
+		{
+			name:            "cpu",
+			expectedQuota:   6.0,
+			expectedDefined: true,
+			shouldHaveError: false,
+		},
+		{
+			name:            "undefined",
+			expectedQuota:   -1.0,
+			expectedDefined: false,
+			shouldHaveError: false,
+		},
+		{
+			name:            "undefined-period",
+			expectedQuota:   -1.0,
+			expectedDefined: false,
+			shouldHaveError: true,
+		},
+	}
+
+	cgroups := make(CGroups)
+
+	quota, defined, err := cgroups.CPUQuota()
+	assert.Equal(t, -1.0, quota, "nonexistent")
+	assert.False(t, defined, "nonexistent")
+	assert.NoError(t, err, "nonexistent")
+
+	for _, tt := range testTable {
+		cgroupPath := filepath.Join(testDataCGroupsPath, tt.name)
+		cgroups[_cgroupSubsysCPU] = NewCGroup(cgroupPath)
+
+		quota, defined, err := cgroups.CPUQuota()
+		assert.Equal(t, tt.expectedQuota, quota, tt.name)
+		assert.Equal(t, tt.expectedDefined, defined, tt.name)
+
+		if tt.shouldHaveError {
+			assert.Error(t, err, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package cgroups
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// _cgroupMemoryUsageBytes is the file name for the CGroup memory usage.
+	_cgroupMemoryUsageBytes = "memory.usage_in_bytes"
+	// _cgroupCPUStat is the file name for the CGroup CPU statistics.
+	_cgroupCPUStat = "cpu.stat"
+)
+
+// CPUThrottling is the CPU bandwidth throttling statistics of a control group.
+type CPUThrottling struct {
+	// Periods is the number of enforcement periods elapsed.
+	Periods int64
+	// ThrottledPeriods is the number of periods the control group was throttled in.
+	ThrottledPeriods int64
+	// ThrottledTime is the total time the control group was throttled for.
+	ThrottledTime time.Duration
+}
+
+// Stats reads the resource limits and usage of the control groups of a process.
+// Each method also returns whether the value is defined for the control groups.
+type Stats interface {
+	// CPUQuota returns the number of CPUs the control groups are allowed to use.
+	CPUQuota() (float64, bool, error)
+	// CPUThrottling returns the CPU throttling statistics of the control groups.
+	CPUThrottling() (CPUThrottling, bool, error)
+	// MemoryQuota returns the memory limit of the control groups in bytes.
+	MemoryQuota() (int64, bool, error)
+	// MemoryUsage returns the memory used by the control groups in bytes.
+	MemoryUsage() (int64, bool, error)
+}
+
+// NewStats returns the Stats of the control groups of a process from given
+// `mountinfo` and `cgroup` files. The cgroup v2 unified hierarchy is used when
+// the process only belongs to it, the cgroup v1 hierarchies otherwise.
+func NewStats(procPathMountInfo, procPathCGroup string) (Stats, error) {
+	cgroups2, isV2, err := NewCGroups2(procPathMountInfo, procPathCGroup)
+	if err != nil {
+		return nil, err
+	}
+	if isV2 {
+		return cgroups2, nil
+	}
+	return NewCGroups(procPathMountInfo, procPathCGroup)
+}
+
+// NewStatsForCurrentProcess returns the Stats of the control groups of the
+// current process.
+func NewStatsForCurrentProcess() (Stats, error) {
+	return NewStats(_procPathMountInfo, _procPathCGroup)
+}
+
+// MemoryUsage returns the memory used by the memory cgroup controller.
+// It is a result of `memory.usage_in_bytes`. If the memory cgroup controller
+// is not mounted, the method returns `(-1, false, nil)`.
+func (cg CGroups) MemoryUsage() (int64, bool, error) {
+	memoryCGroup, exists := cg[_cgroupSubsysMemory]
+	if !exists {
+		return -1, false, nil
+	}
+
+	usage, err := memoryCGroup.readInt(_cgroupMemoryUsageBytes)
+	if err != nil {
+		return -1, false, err
+	}
+	return int64(usage), true, nil
+}
+
+// CPUThrottling returns the CPU throttling statistics of the CPU cgroup
+// controller from `cpu.stat`. If the CPU cgroup controller is not mounted,
+// the method returns `(CPUThrottling{}, false, nil)`.
+func (cg CGroups) CPUThrottling() (CPUThrottling, bool, error) {
+	cpuCGroup, exists := cg[_cgroupSubsysCPU]
+	if !exists {
+		return CPUThrottling{}, false, nil
+	}
+
+	stat, err := cpuCGroup.readKeyValues(_cgroupCPUStat)
+	if err != nil {
+		return CPUThrottling{}, false, err
+	}
+	return CPUThrottling{
+		Periods:          stat["nr_periods"],
+		ThrottledPeriods: stat["nr_throttled"],
+		ThrottledTime:    time.Duration(stat["throttled_time"]),
+	}, true, nil
+}
+
+// readKeyValues reads a file made of `<key> <value>` lines, like `cpu.stat`.
+func (cg *CGroup) readKeyValues(param string) (map[string]int64, error) {
+	paramFile, err := os.Open(cg.ParamPath(param))
+	if err != nil {
+		return nil, err
+	}
+	defer paramFile.Close()
+
+	values := make(map[string]int64)
+	scanner := bufio.NewScanner(paramFile)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid format for %s: %q", param, scanner.Text())
+		}
+
+		value, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[fields[0]] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
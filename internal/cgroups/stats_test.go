@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package cgroups
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStats(t *testing.T) {
+	stats, err := NewStats(
+		filepath.Join(testDataProcPath, "cgroups", "mountinfo"),
+		filepath.Join(testDataProcPath, "cgroups", "cgroup"),
+	)
+	require.NoError(t, err)
+	assert.IsType(t, CGroups{}, stats)
+
+	stats, err = NewStats(
+		filepath.Join(testDataProcPath, "cgroups2", "mountinfo"),
+		filepath.Join(testDataProcPath, "cgroups2", "cgroup"),
+	)
+	require.NoError(t, err)
+	require.IsType(t, &CGroups2{}, stats)
+	assert.Equal(t, "/sys/fs/cgroup/large", stats.(*CGroups2).cgroup.path)
+
+	_, err = NewStats("/dev/null", "non-existing-file")
+	assert.Error(t, err)
+}
+
+func TestCGroupsMemoryUsage(t *testing.T) {
+	cgroups := make(CGroups)
+
+	usage, defined, err := cgroups.MemoryUsage()
+	assert.Equal(t, int64(-1), usage, "nonexistent")
+	assert.False(t, defined, "nonexistent")
+	assert.NoError(t, err, "nonexistent")
+
+	cgroups[_cgroupSubsysMemory] = NewCGroup(filepath.Join(testDataCGroupsPath, "memory"))
+	usage, defined, err = cgroups.MemoryUsage()
+	assert.Equal(t, int64(536870912), usage)
+	assert.True(t, defined)
+	assert.NoError(t, err)
+}
+
+func TestCGroupsCPUThrottling(t *testing.T) {
+	cgroups := make(CGroups)
+
+	_, defined, err := cgroups.CPUThrottling()
+	assert.False(t, defined, "nonexistent")
+	assert.NoError(t, err, "nonexistent")
+
+	cgroups[_cgroupSubsysCPU] = NewCGroup(filepath.Join(testDataCGroupsPath, "cpu"))
+	throttling, defined, err := cgroups.CPUThrottling()
+	assert.Equal(t, CPUThrottling{Periods: 100, ThrottledPeriods: 10, ThrottledTime: 1500 * time.Millisecond}, throttling)
+	assert.True(t, defined)
+	assert.NoError(t, err)
+
+	cgroups[_cgroupSubsysCPU] = NewCGroup(filepath.Join(testDataCGroupsPath, "invalid"))
+	_, _, err = cgroups.CPUThrottling()
+	assert.Error(t, err)
+}
@@ -149,12 +149,16 @@ func (tid TraceID) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON inflates trace id from hex string, possibly enclosed in quotes.
 // Called by Protobuf JSON deserialization.
 func (tid *TraceID) UnmarshalJSON(data []byte) error {
-	if len(data) != traceIDSize {
+	*tid = TraceID{}
+	src := data
+	if l := len(src); l >= 2 && src[0] == '"' && src[l-1] == '"' {
+		src = src[1 : l-1]
+	}
+	// The length is the one of the decoded ID, not of its hex representation.
+	if n := hex.DecodedLen(len(src)); n != traceIDSize && n != 0 {
 		tid.useIdSlice = true
-		tid.idSlice = make([]byte, len(data))
-		return unmarshalJSON(tid.idSlice[:], data)
-	} else {
-		tid.id = [16]byte{}
-		return unmarshalJSON(tid.id[:], data)
+		tid.idSlice = make([]byte, n)
+		return unmarshalJSON(tid.idSlice, data)
 	}
+	return unmarshalJSON(tid.id[:], data)
 }
@@ -116,6 +116,15 @@ func TestTraceIDUnmarshalJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, tidBytes, tid.id)
 
+	err = tid.UnmarshalJSON([]byte(`"1234567812345678"`))
+	assert.NoError(t, err)
+	assert.Equal(t, NewTraceIDWithUnlimitedSize([]byte{0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78}), tid)
+
+	err = tid.UnmarshalJSON([]byte(`""`))
+	assert.NoError(t, err)
+	assert.True(t, tid.IsEmpty())
+	assert.Equal(t, TraceID{}, tid)
+
 	err = tid.UnmarshalJSON([]byte(`"nothex"`))
 	assert.Error(t, err)
 
@@ -0,0 +1,30 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package iruntime
+
+import "go.opentelemetry.io/collector/internal/cgroups"
+
+// CPUQuota returns the number of CPUs the process is allowed to use.
+// This implementation is meant for linux and uses cgroups v1 or v2 to determine the CPU quota.
+// If no CPU quota is defined, it returns `(-1, false, nil)`.
+func CPUQuota() (float64, bool, error) {
+	cgroups, err := cgroups.NewStatsForCurrentProcess()
+	if err != nil {
+		return -1, false, err
+	}
+	return cgroups.CPUQuota()
+}
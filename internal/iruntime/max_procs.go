@@ -0,0 +1,48 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iruntime
+
+import (
+	"math"
+	"os"
+	"runtime"
+)
+
+const maxProcsEnvKey = "GOMAXPROCS"
+
+// make it overridable by tests
+var cpuQuotaFn = CPUQuota
+
+// SetMaxProcs sets GOMAXPROCS to match the CPU quota of the process, so that
+// inside of a container the number of CPUs of the container is used instead of
+// the number of CPUs of the host. The GOMAXPROCS environment variable, if set,
+// takes precedence over the CPU quota. It returns the resulting value of GOMAXPROCS.
+func SetMaxProcs() (int, error) {
+	if _, exists := os.LookupEnv(maxProcsEnvKey); exists {
+		return runtime.GOMAXPROCS(0), nil
+	}
+
+	quota, defined, err := cpuQuotaFn()
+	if err != nil || !defined {
+		return runtime.GOMAXPROCS(0), err
+	}
+
+	maxProcs := int(math.Floor(quota))
+	if maxProcs < 1 {
+		maxProcs = 1
+	}
+	runtime.GOMAXPROCS(maxProcs)
+	return maxProcs, nil
+}
@@ -0,0 +1,75 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iruntime
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMaxProcs(t *testing.T) {
+	prevMaxProcs := runtime.GOMAXPROCS(0)
+	prevEnv, envExists := os.LookupEnv(maxProcsEnvKey)
+	require.NoError(t, os.Unsetenv(maxProcsEnvKey))
+	t.Cleanup(func() {
+		cpuQuotaFn = CPUQuota
+		runtime.GOMAXPROCS(prevMaxProcs)
+		if envExists {
+			os.Setenv(maxProcsEnvKey, prevEnv)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		quota    float64
+		defined  bool
+		err      error
+		expected int
+	}{
+		{name: "quota", quota: 2.5, defined: true, expected: 2},
+		{name: "quota_below_one", quota: 0.5, defined: true, expected: 1},
+		{name: "undefined", quota: -1, defined: false, expected: prevMaxProcs},
+		{name: "error", quota: -1, defined: false, err: errors.New("my error"), expected: prevMaxProcs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime.GOMAXPROCS(prevMaxProcs)
+			cpuQuotaFn = func() (float64, bool, error) {
+				return tt.quota, tt.defined, tt.err
+			}
+			maxProcs, err := SetMaxProcs()
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, maxProcs)
+			assert.Equal(t, tt.expected, runtime.GOMAXPROCS(0))
+		})
+	}
+
+	t.Run("env_precedence", func(t *testing.T) {
+		runtime.GOMAXPROCS(prevMaxProcs)
+		require.NoError(t, os.Setenv(maxProcsEnvKey, "3"))
+		defer os.Unsetenv(maxProcsEnvKey)
+		cpuQuotaFn = func() (float64, bool, error) {
+			return 2, true, nil
+		}
+		maxProcs, err := SetMaxProcs()
+		assert.NoError(t, err)
+		assert.Equal(t, prevMaxProcs, maxProcs)
+	})
+}
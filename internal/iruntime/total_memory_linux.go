@@ -16,18 +16,27 @@
 
 package iruntime
 
-import "go.opentelemetry.io/collector/processor/memorylimiter/internal/cgroups"
+import "go.opentelemetry.io/collector/internal/cgroups"
 
 // TotalMemory returns total available memory.
-// This implementation is meant for linux and uses cgroups to determine available memory.
+// This implementation is meant for linux and uses cgroups v1 or v2 to determine available memory.
+// If no cgroup memory limit is defined, or the limit exceeds the host memory,
+// the total memory of the host is returned.
 func TotalMemory() (int64, error) {
-	cgroups, err := cgroups.NewCGroupsForCurrentProcess()
+	hostMemory, err := readMemInfo()
+	if err != nil {
+		return 0, err
+	}
+	cgroups, err := cgroups.NewStatsForCurrentProcess()
 	if err != nil {
 		return 0, err
 	}
 	memoryQuota, defined, err := cgroups.MemoryQuota()
-	if err != nil || !defined {
+	if err != nil {
 		return 0, err
 	}
+	if !defined || memoryQuota <= 0 || memoryQuota > hostMemory {
+		return hostMemory, nil
+	}
 	return memoryQuota, nil
 }
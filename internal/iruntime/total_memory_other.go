@@ -16,14 +16,8 @@
 
 package iruntime
 
-import (
-	"fmt"
-)
-
-var errTotalMemoryNotAvailable = fmt.Errorf("reading cgroups total memory is available only on linux")
-
 // TotalMemory returns total available memory.
-// This is non-Linux version that returns -1 and errTotalMemoryNotAvailable.
+// This is non-Linux version that returns the total memory of the host.
 func TotalMemory() (int64, error) {
-	return -1, errTotalMemoryNotAvailable
+	return readMemInfo()
 }
@@ -25,6 +25,6 @@ import (
 
 func TestTotalMemory(t *testing.T) {
 	totalMemory, err := TotalMemory()
-	require.Error(t, err)
-	assert.Equal(t, int64(-1), totalMemory)
+	require.NoError(t, err)
+	assert.True(t, totalMemory > 0)
 }
@@ -20,20 +20,45 @@ import (
 	"compress/zlib"
 	"io"
 	"net/http"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 const (
 	headerContentEncoding = "Content-Encoding"
 	headerValueGZIP       = "gzip"
+	headerValueZstd       = "zstd"
+
+	// zstdMaxWindowSize is the largest window the decoders must support per RFC 8878, the frames requiring a larger
+	// window, up to the maximum body size, are rejected so that a small frame can't allocate a huge window.
+	zstdMaxWindowSize = 8 << 20
 )
 
 type CompressRoundTripper struct {
 	http.RoundTripper
+	encoding  string
+	newWriter func(w io.Writer) (io.WriteCloser, error)
 }
 
+// NewCompressRoundTripper returns a RoundTripper compressing the request bodies with gzip.
 func NewCompressRoundTripper(rt http.RoundTripper) *CompressRoundTripper {
 	return &CompressRoundTripper{
 		RoundTripper: rt,
+		encoding:     headerValueGZIP,
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		},
+	}
+}
+
+// NewZstdCompressRoundTripper returns a RoundTripper compressing the request bodies with zstd.
+func NewZstdCompressRoundTripper(rt http.RoundTripper) *CompressRoundTripper {
+	return &CompressRoundTripper{
+		RoundTripper: rt,
+		encoding:     headerValueZstd,
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		},
 	}
 }
 
@@ -47,13 +72,16 @@ func (r *CompressRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 		return r.RoundTripper.RoundTrip(req)
 	}
 
-	// Gzip the body.
+	// Compress the body.
 	buf := bytes.NewBuffer([]byte{})
-	gzipWriter := gzip.NewWriter(buf)
-	_, copyErr := io.Copy(gzipWriter, req.Body)
+	compressWriter, err := r.newWriter(buf)
+	if err != nil {
+		return nil, err
+	}
+	_, copyErr := io.Copy(compressWriter, req.Body)
 	closeErr := req.Body.Close()
 
-	if err := gzipWriter.Close(); err != nil {
+	if err = compressWriter.Close(); err != nil {
 		return nil, err
 	}
 
@@ -71,9 +99,9 @@ func (r *CompressRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 		return nil, err
 	}
 
-	// Clone the headers and add gzip encoding header.
+	// Clone the headers and add the encoding header.
 	cReq.Header = req.Header.Clone()
-	cReq.Header.Add(headerContentEncoding, headerValueGZIP)
+	cReq.Header.Add(headerContentEncoding, r.encoding)
 
 	return r.RoundTripper.RoundTrip(cReq)
 }
@@ -82,6 +110,7 @@ type ErrorHandler func(w http.ResponseWriter, r *http.Request, errorMsg string,
 
 type decompressor struct {
 	errorHandler ErrorHandler
+	maxBodySize  int64
 }
 
 type DecompressorOption func(d *decompressor)
@@ -92,10 +121,18 @@ func WithErrorHandler(e ErrorHandler) DecompressorOption {
 	}
 }
 
+// WithMaxBodySize limits the size of the request bodies, after their decompression, to n bytes: the handlers get an
+// error when reading more. There is no limit if n is 0.
+func WithMaxBodySize(n int64) DecompressorOption {
+	return func(d *decompressor) {
+		d.maxBodySize = n
+	}
+}
+
 // HTTPContentDecompressor is a middleware that offloads the task of handling compressed
 // HTTP requests by identifying the compression format in the "Content-Encoding" header and re-writing
 // request body so that the handlers further in the chain can work on decompressed data.
-// It supports gzip and deflate/zlib compression.
+// It supports gzip, deflate/zlib and zstd compression.
 func HTTPContentDecompressor(h http.Handler, opts ...DecompressorOption) http.Handler {
 	d := &decompressor{}
 	for _, o := range opts {
@@ -109,7 +146,7 @@ func HTTPContentDecompressor(h http.Handler, opts ...DecompressorOption) http.Ha
 
 func (d *decompressor) wrap(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		newBody, err := newBodyReader(r)
+		newBody, err := d.newBodyReader(r)
 		if err != nil {
 			d.errorHandler(w, r, err.Error(), http.StatusBadRequest)
 			return
@@ -124,11 +161,14 @@ func (d *decompressor) wrap(h http.Handler) http.Handler {
 			r.ContentLength = -1
 			r.Body = newBody
 		}
+		if d.maxBodySize > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, d.maxBodySize)
+		}
 		h.ServeHTTP(w, r)
 	})
 }
 
-func newBodyReader(r *http.Request) (io.ReadCloser, error) {
+func (d *decompressor) newBodyReader(r *http.Request) (io.ReadCloser, error) {
 	switch r.Header.Get("Content-Encoding") {
 	case "gzip":
 		gr, err := gzip.NewReader(r.Body)
@@ -142,6 +182,16 @@ func newBodyReader(r *http.Request) (io.ReadCloser, error) {
 			return nil, err
 		}
 		return zr, nil
+	case "zstd":
+		maxMemory := uint64(zstdMaxWindowSize)
+		if d.maxBodySize > zstdMaxWindowSize {
+			maxMemory = uint64(d.maxBodySize)
+		}
+		zr, err := zstd.NewReader(r.Body, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(maxMemory))
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
 	}
 	return nil, nil
 }
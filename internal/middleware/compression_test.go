@@ -20,11 +20,14 @@ import (
 	"compress/zlib"
 	"fmt"
 	"io/ioutil"
+	"math/bits"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -34,6 +37,7 @@ import (
 func TestHTTPClientCompression(t *testing.T) {
 	testBody := []byte("uncompressed_text")
 	compressedBody, _ := compressGzip(testBody)
+	zstdCompressedBody, _ := compressZstd(testBody)
 
 	tests := []struct {
 		name     string
@@ -50,6 +54,11 @@ func TestHTTPClientCompression(t *testing.T) {
 			encoding: "gzip",
 			reqBody:  compressedBody.Bytes(),
 		},
+		{
+			name:     "ValidZstd",
+			encoding: "zstd",
+			reqBody:  zstdCompressedBody.Bytes(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -79,8 +88,11 @@ func TestHTTPClientCompression(t *testing.T) {
 			require.NoError(t, err, "failed to create request to test handler")
 
 			client := http.Client{}
-			if tt.encoding == "gzip" {
+			switch tt.encoding {
+			case "gzip":
 				client.Transport = NewCompressRoundTripper(http.DefaultTransport)
+			case "zstd":
+				client.Transport = NewZstdCompressRoundTripper(http.DefaultTransport)
 			}
 			res, err := client.Do(req)
 			require.NoError(t, err)
@@ -125,6 +137,14 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 			},
 			respCode: 200,
 		},
+		{
+			name:     "ValidZstd",
+			encoding: "zstd",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressZstd(testBody)
+			},
+			respCode: 200,
+		},
 		{
 			name:     "InvalidGzip",
 			encoding: "gzip",
@@ -189,6 +209,106 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 	}
 }
 
+func TestHTTPContentDecompressionMaxBodySize(t *testing.T) {
+	testBody := bytes.Repeat([]byte("a"), 100)
+	tests := []struct {
+		name        string
+		maxBodySize int64
+		wantErr     bool
+	}{
+		{
+			name: "NoLimit",
+		},
+		{
+			name:        "BelowLimit",
+			maxBodySize: 100,
+		},
+		{
+			name:        "AboveLimit",
+			maxBodySize: 99,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readErr error
+			handler := HTTPContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, readErr = ioutil.ReadAll(r.Body)
+			}), WithMaxBodySize(tt.maxBodySize))
+
+			// The limit applies to the decompressed body.
+			body, err := compressGzip(testBody)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set("Content-Encoding", "gzip")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if tt.wantErr {
+				assert.Error(t, readErr)
+			} else {
+				assert.NoError(t, readErr)
+			}
+		})
+	}
+}
+
+func TestHTTPContentDecompressionZstdWindowSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		windowSize  int
+		maxBodySize int64
+		wantErr     bool
+	}{
+		{
+			name:       "DefaultWindow",
+			windowSize: zstdMaxWindowSize,
+		},
+		{
+			name:       "LargeWindow",
+			windowSize: 4 * zstdMaxWindowSize,
+			wantErr:    true,
+		},
+		{
+			name:        "LargeWindowBelowMaxBodySize",
+			windowSize:  4 * zstdMaxWindowSize,
+			maxBodySize: 4 * zstdMaxWindowSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readErr error
+			handler := HTTPContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, readErr = ioutil.ReadAll(r.Body)
+			}), WithMaxBodySize(tt.maxBodySize))
+
+			// The window size is declared by the frame header, whatever the size of the body.
+			body := zstdFrame(tt.windowSize, []byte("test body"))
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			req.Header.Set("Content-Encoding", "zstd")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if tt.wantErr {
+				assert.Error(t, readErr)
+			} else {
+				assert.NoError(t, readErr)
+			}
+		})
+	}
+}
+
+// zstdFrame returns a zstd frame declaring the window size, a power of two, with the data in a raw block.
+func zstdFrame(windowSize int, data []byte) []byte {
+	windowLog := bits.Len(uint(windowSize)) - 1
+	blockHeader := 1 | len(data)<<3 // Last block, raw.
+	frame := []byte{
+		0x28, 0xb5, 0x2f, 0xfd, // Magic number.
+		0x00,                    // Frame header descriptor: no content size, not a single segment.
+		byte(windowLog-10) << 3, // Window descriptor.
+		byte(blockHeader), byte(blockHeader >> 8), byte(blockHeader >> 16),
+	}
+	return append(frame, data...)
+}
+
 func compressGzip(body []byte) (*bytes.Buffer, error) {
 	var buf bytes.Buffer
 
@@ -216,3 +336,20 @@ func compressZlib(body []byte) (*bytes.Buffer, error) {
 
 	return &buf, nil
 }
+
+func compressZstd(body []byte) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	defer zw.Close()
+
+	_, err = zw.Write(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
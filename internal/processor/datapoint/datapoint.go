@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datapoint provides helpers handling the data points of the metrics
+// whatever their data type.
+package datapoint
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// ForEachLabels calls f with the labels of each data point of the metric,
+// whatever its data type.
+func ForEachLabels(metric pdata.Metric, f func(labels pdata.StringMap)) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		dps := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		dps := metric.DoubleSummary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	}
+}
+
+// Filter removes the data points of the metric for which keep returns false,
+// keep is called with the labels of each data point and may modify them.
+func Filter(metric pdata.Metric, keep func(labels pdata.StringMap) bool) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		filterIntDataPoints(metric.IntGauge().DataPoints(), keep)
+	case pdata.MetricDataTypeDoubleGauge:
+		filterDoubleDataPoints(metric.DoubleGauge().DataPoints(), keep)
+	case pdata.MetricDataTypeIntSum:
+		filterIntDataPoints(metric.IntSum().DataPoints(), keep)
+	case pdata.MetricDataTypeDoubleSum:
+		filterDoubleDataPoints(metric.DoubleSum().DataPoints(), keep)
+	case pdata.MetricDataTypeIntHistogram:
+		filterIntHistogramDataPoints(metric.IntHistogram().DataPoints(), keep)
+	case pdata.MetricDataTypeDoubleHistogram:
+		filterDoubleHistogramDataPoints(metric.DoubleHistogram().DataPoints(), keep)
+	case pdata.MetricDataTypeDoubleSummary:
+		filterDoubleSummaryDataPoints(metric.DoubleSummary().DataPoints(), keep)
+	}
+}
+
+func filterIntDataPoints(dps pdata.IntDataPointSlice, keep func(labels pdata.StringMap) bool) {
+	kept := pdata.NewIntDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		if keep(dps.At(i).LabelsMap()) {
+			kept.Append(dps.At(i))
+		}
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func filterDoubleDataPoints(dps pdata.DoubleDataPointSlice, keep func(labels pdata.StringMap) bool) {
+	kept := pdata.NewDoubleDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		if keep(dps.At(i).LabelsMap()) {
+			kept.Append(dps.At(i))
+		}
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func filterIntHistogramDataPoints(dps pdata.IntHistogramDataPointSlice, keep func(labels pdata.StringMap) bool) {
+	kept := pdata.NewIntHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		if keep(dps.At(i).LabelsMap()) {
+			kept.Append(dps.At(i))
+		}
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func filterDoubleHistogramDataPoints(dps pdata.DoubleHistogramDataPointSlice, keep func(labels pdata.StringMap) bool) {
+	kept := pdata.NewDoubleHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		if keep(dps.At(i).LabelsMap()) {
+			kept.Append(dps.At(i))
+		}
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func filterDoubleSummaryDataPoints(dps pdata.DoubleSummaryDataPointSlice, keep func(labels pdata.StringMap) bool) {
+	kept := pdata.NewDoubleSummaryDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		if keep(dps.At(i).LabelsMap()) {
+			kept.Append(dps.At(i))
+		}
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datapoint
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var dataTypes = []pdata.MetricDataType{
+	pdata.MetricDataTypeIntGauge,
+	pdata.MetricDataTypeDoubleGauge,
+	pdata.MetricDataTypeIntSum,
+	pdata.MetricDataTypeDoubleSum,
+	pdata.MetricDataTypeIntHistogram,
+	pdata.MetricDataTypeDoubleHistogram,
+	pdata.MetricDataTypeDoubleSummary,
+}
+
+func newMetric(dataType pdata.MetricDataType, points int) pdata.Metric {
+	metric := pdata.NewMetric()
+	metric.SetDataType(dataType)
+	switch dataType {
+	case pdata.MetricDataTypeIntGauge:
+		metric.IntGauge().DataPoints().Resize(points)
+	case pdata.MetricDataTypeDoubleGauge:
+		metric.DoubleGauge().DataPoints().Resize(points)
+	case pdata.MetricDataTypeIntSum:
+		metric.IntSum().DataPoints().Resize(points)
+	case pdata.MetricDataTypeDoubleSum:
+		metric.DoubleSum().DataPoints().Resize(points)
+	case pdata.MetricDataTypeIntHistogram:
+		metric.IntHistogram().DataPoints().Resize(points)
+	case pdata.MetricDataTypeDoubleHistogram:
+		metric.DoubleHistogram().DataPoints().Resize(points)
+	case pdata.MetricDataTypeDoubleSummary:
+		metric.DoubleSummary().DataPoints().Resize(points)
+	}
+	return metric
+}
+
+func TestForEachLabels(t *testing.T) {
+	for _, dataType := range dataTypes {
+		t.Run(dataType.String(), func(t *testing.T) {
+			metric := newMetric(dataType, 2)
+			count := 0
+			ForEachLabels(metric, func(pdata.StringMap) { count++ })
+			assert.Equal(t, 2, count)
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	for _, dataType := range dataTypes {
+		t.Run(dataType.String(), func(t *testing.T) {
+			metric := newMetric(dataType, 3)
+			i := 0
+			ForEachLabels(metric, func(labels pdata.StringMap) {
+				labels.Insert("index", strconv.Itoa(i))
+				i++
+			})
+
+			Filter(metric, func(labels pdata.StringMap) bool {
+				v, _ := labels.Get("index")
+				return v != "1"
+			})
+
+			var indexes []string
+			ForEachLabels(metric, func(labels pdata.StringMap) {
+				v, _ := labels.Get("index")
+				indexes = append(indexes, v)
+			})
+			assert.Equal(t, []string{"0", "2"}, indexes)
+		})
+	}
+}
@@ -74,8 +74,11 @@ type MatchProperties struct {
 	// Note: For spans, one of Services, SpanNames, Attributes, Resources or Libraries must be specified with a
 	// non-empty value for a valid configuration.
 
-	// For logs, one of LogNames, Attributes, Resources or Libraries must be specified with a
-	// non-empty value for a valid configuration.
+	// For logs, one of LogNames, SeverityTexts, Attributes, Resources or Libraries must be specified
+	// with a non-empty value for a valid configuration.
+
+	// For metric data points, one of MetricNames, Attributes, Resources or Libraries must be
+	// specified with a non-empty value for a valid configuration.
 
 	// Services specify the list of of items to match service name against.
 	// A match occurs if the span's service name matches at least one item in this list.
@@ -91,6 +94,14 @@ type MatchProperties struct {
 	// against.
 	LogNames []string `mapstructure:"log_names"`
 
+	// SeverityTexts is a list of strings that the LogRecord's severity text
+	// field must match against.
+	SeverityTexts []string `mapstructure:"severity_texts"`
+
+	// MetricNames is a list of strings that the name of the metric holding
+	// the data point must match against.
+	MetricNames []string `mapstructure:"metric_names"`
+
 	// Attributes specifies the list of attributes to match against.
 	// All of these attributes must match exactly for a match to occur.
 	// Only match_type=strict is allowed if "attributes" are specified.
@@ -113,6 +124,14 @@ func (mp *MatchProperties) ValidateForSpans() error {
 		return errors.New("log_names should not be specified for trace spans")
 	}
 
+	if len(mp.SeverityTexts) > 0 {
+		return errors.New("severity_texts should not be specified for trace spans")
+	}
+
+	if len(mp.MetricNames) > 0 {
+		return errors.New("metric_names should not be specified for trace spans")
+	}
+
 	if len(mp.Services) == 0 && len(mp.SpanNames) == 0 && len(mp.Attributes) == 0 &&
 		len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
 		return errors.New(`at least one of "services", "span_names", "attributes", "libraries" or "resources" field must be specified`)
@@ -126,8 +145,25 @@ func (mp *MatchProperties) ValidateForLogs() error {
 		return errors.New("neither services nor span_names should be specified for log records")
 	}
 
-	if len(mp.LogNames) == 0 && len(mp.Attributes) == 0 && len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
-		return errors.New(`at least one of "log_names", "attributes", "libraries" or "resources" field must be specified`)
+	if len(mp.MetricNames) > 0 {
+		return errors.New("metric_names should not be specified for log records")
+	}
+
+	if len(mp.LogNames) == 0 && len(mp.SeverityTexts) == 0 && len(mp.Attributes) == 0 &&
+		len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
+		return errors.New(`at least one of "log_names", "severity_texts", "attributes", "libraries" or "resources" field must be specified`)
+	}
+
+	return nil
+}
+
+func (mp *MatchProperties) ValidateForMetrics() error {
+	if len(mp.SpanNames) > 0 || len(mp.Services) > 0 || len(mp.LogNames) > 0 || len(mp.SeverityTexts) > 0 {
+		return errors.New("neither services, span_names, log_names nor severity_texts should be specified for metric data points")
+	}
+
+	if len(mp.MetricNames) == 0 && len(mp.Attributes) == 0 && len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
+		return errors.New(`at least one of "metric_names", "attributes", "libraries" or "resources" field must be specified`)
 	}
 
 	return nil
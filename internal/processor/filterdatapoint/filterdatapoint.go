@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterdatapoint
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filtermatcher"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+// Matcher is an interface that allows matching a metric data point against a
+// configuration of a match.
+type Matcher interface {
+	MatchDataPoint(metric pdata.Metric, labels pdata.AttributeMap, resource pdata.Resource, library pdata.InstrumentationLibrary) bool
+}
+
+// propertiesMatcher allows matching a metric data point against various data point properties.
+type propertiesMatcher struct {
+	filtermatcher.PropertiesMatcher
+
+	// metric names to compare to.
+	nameFilters filterset.FilterSet
+}
+
+// NewMatcher creates a data point Matcher that matches based on the given MatchProperties.
+func NewMatcher(mp *filterconfig.MatchProperties) (Matcher, error) {
+	if mp == nil {
+		return nil, nil
+	}
+
+	if err := mp.ValidateForMetrics(); err != nil {
+		return nil, err
+	}
+
+	rm, err := filtermatcher.NewMatcher(mp)
+	if err != nil {
+		return nil, err
+	}
+
+	var nameFS filterset.FilterSet = nil
+	if len(mp.MetricNames) > 0 {
+		nameFS, err = filterset.CreateFilterSet(mp.MetricNames, &mp.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating metric name filters: %v", err)
+		}
+	}
+
+	return &propertiesMatcher{
+		PropertiesMatcher: rm,
+		nameFilters:       nameFS,
+	}, nil
+}
+
+// MatchDataPoint matches a metric data point to a set of properties.
+// The name of the metric holding the data point is matched, if specified.
+// The labels of the data point, given as attributes, are then checked
+// together with the resource and the instrumentation library, if specified.
+// All specified properties must evaluate to true for a match to occur.
+func (mp *propertiesMatcher) MatchDataPoint(metric pdata.Metric, labels pdata.AttributeMap, resource pdata.Resource, library pdata.InstrumentationLibrary) bool {
+	if mp.nameFilters != nil && !mp.nameFilters.Matches(metric.Name()) {
+		return false
+	}
+
+	return mp.PropertiesMatcher.Match(labels, resource, library)
+}
@@ -37,6 +37,9 @@ type propertiesMatcher struct {
 
 	// log names to compare to.
 	nameFilters filterset.FilterSet
+
+	// severity texts to compare to.
+	severityFilters filterset.FilterSet
 }
 
 // NewMatcher creates a LogRecord Matcher that matches based on the given MatchProperties.
@@ -62,17 +65,27 @@ func NewMatcher(mp *filterconfig.MatchProperties) (Matcher, error) {
 		}
 	}
 
+	var severityFS filterset.FilterSet = nil
+	if len(mp.SeverityTexts) > 0 {
+		severityFS, err = filterset.CreateFilterSet(mp.SeverityTexts, &mp.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating log record severity filters: %v", err)
+		}
+	}
+
 	return &propertiesMatcher{
 		PropertiesMatcher: rm,
 		nameFilters:       nameFS,
+		severityFilters:   severityFS,
 	}, nil
 }
 
 // MatchLogRecord matches a log record to a set of properties.
-// There are 3 sets of properties to match against.
+// There are 4 sets of properties to match against.
 // The log record names are matched, if specified.
+// The log record severity texts are matched, if specified.
 // The attributes are then checked, if specified.
-// At least one of log record names or attributes must be specified. It is
+// At least one of log record names, severity texts or attributes must be specified. It is
 // supported to have more than one of these specified, and all specified must
 // evaluate to true for a match to occur.
 func (mp *propertiesMatcher) MatchLogRecord(lr pdata.LogRecord, resource pdata.Resource, library pdata.InstrumentationLibrary) bool {
@@ -80,5 +93,9 @@ func (mp *propertiesMatcher) MatchLogRecord(lr pdata.LogRecord, resource pdata.R
 		return false
 	}
 
+	if mp.severityFilters != nil && !mp.severityFilters.Matches(lr.SeverityText()) {
+		return false
+	}
+
 	return mp.PropertiesMatcher.Match(lr.Attributes(), resource, library)
 }
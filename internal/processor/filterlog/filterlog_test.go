@@ -40,14 +40,14 @@ func TestLogRecord_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 		{
 			name:        "empty_property",
 			property:    filterconfig.MatchProperties{},
-			errorString: "at least one of \"log_names\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
+			errorString: "at least one of \"log_names\", \"severity_texts\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
 		},
 		{
 			name: "empty_log_names_and_attributes",
 			property: filterconfig.MatchProperties{
 				LogNames: []string{},
 			},
-			errorString: "at least one of \"log_names\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
+			errorString: "at least one of \"log_names\", \"severity_texts\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
 		},
 		{
 			name: "span_properties",
@@ -181,3 +181,51 @@ func TestLogRecord_Matching_True(t *testing.T) {
 		})
 	}
 }
+
+func TestLogRecord_MatchingSeverity(t *testing.T) {
+	testcases := []struct {
+		name       string
+		properties *filterconfig.MatchProperties
+		expected   bool
+	}{
+		{
+			name: "severity_match",
+			properties: &filterconfig.MatchProperties{
+				Config:        *createConfig(filterset.Strict),
+				SeverityTexts: []string{"WARN", "DEBUG"},
+			},
+			expected: true,
+		},
+		{
+			name: "severity_doesnt_match",
+			properties: &filterconfig.MatchProperties{
+				Config:        *createConfig(filterset.Regexp),
+				SeverityTexts: []string{"^ERR.*"},
+			},
+			expected: false,
+		},
+		{
+			name: "name_match_severity_doesnt_match",
+			properties: &filterconfig.MatchProperties{
+				Config:        *createConfig(filterset.Strict),
+				LogNames:      []string{"logName"},
+				SeverityTexts: []string{"INFO"},
+			},
+			expected: false,
+		},
+	}
+
+	lr := pdata.NewLogRecord()
+	lr.SetName("logName")
+	lr.SetSeverityText("DEBUG")
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mp, err := NewMatcher(tc.properties)
+			require.NoError(t, err)
+			require.NotNil(t, mp)
+
+			assert.Equal(t, tc.expected, mp.MatchLogRecord(lr, pdata.Resource{}, pdata.InstrumentationLibrary{}))
+		})
+	}
+}
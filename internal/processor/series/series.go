@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package series provides helpers for the processors keeping a state per series
+// of data points, or aggregating the data points of the same series.
+package series
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// AttributesKey returns a key identifying the attributes whatever their order.
+func AttributesKey(attrs pdata.AttributeMap) string {
+	pairs := make([]string, 0, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		pairs = append(pairs, k+"\x01"+tracetranslator.AttributeValueToString(v, false))
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x00")
+}
+
+// LabelsKey returns a key identifying the labels whatever their order.
+func LabelsKey(labels pdata.StringMap) string {
+	pairs := make([]string, 0, labels.Len())
+	labels.ForEach(func(k string, v string) {
+		pairs = append(pairs, k+"\x01"+v)
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x00")
+}
+
+// AddBucketCounts returns a new slice with the sums of the bucket counts, the
+// bucket counts may be shared with other data points or states and are not
+// modified. The slices may have different lengths, the missing counts are 0.
+func AddBucketCounts(a, b []uint64) []uint64 {
+	if len(b) > len(a) {
+		a, b = b, a
+	}
+	sums := make([]uint64, len(a))
+	copy(sums, a)
+	for i, c := range b {
+		sums[i] += c
+	}
+	return sums
+}
+
+// Sweeper schedules the removal of the series not seen for longer than a max
+// staleness, at most once per max staleness period.
+type Sweeper struct {
+	maxStaleness time.Duration
+	lastSweep    time.Time
+}
+
+// NewSweeper creates a Sweeper, the series never go stale if maxStaleness is
+// not positive.
+func NewSweeper(maxStaleness time.Duration) *Sweeper {
+	return &Sweeper{maxStaleness: maxStaleness}
+}
+
+// StaleBefore returns the time before which the series last seen are stale and
+// must be removed, or false if no sweep is due at now.
+func (s *Sweeper) StaleBefore(now time.Time) (time.Time, bool) {
+	if s.maxStaleness <= 0 || now.Sub(s.lastSweep) < s.maxStaleness {
+		return time.Time{}, false
+	}
+	s.lastSweep = now
+	return now.Add(-s.maxStaleness), true
+}
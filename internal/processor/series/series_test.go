@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package series
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestAttributesKey(t *testing.T) {
+	a := pdata.NewAttributeMap()
+	a.InsertString("service", "a")
+	a.InsertInt("port", 80)
+	b := pdata.NewAttributeMap()
+	b.InsertInt("port", 80)
+	b.InsertString("service", "a")
+	assert.Equal(t, AttributesKey(a), AttributesKey(b))
+
+	b.UpdateInt("port", 8080)
+	assert.NotEqual(t, AttributesKey(a), AttributesKey(b))
+	assert.Equal(t, "", AttributesKey(pdata.NewAttributeMap()))
+}
+
+func TestLabelsKey(t *testing.T) {
+	a := pdata.NewStringMap().InitFromMap(map[string]string{"host": "a", "state": "idle"})
+	b := pdata.NewStringMap()
+	b.Insert("state", "idle")
+	b.Insert("host", "a")
+	assert.Equal(t, LabelsKey(a), LabelsKey(b))
+
+	b.Update("state", "busy")
+	assert.NotEqual(t, LabelsKey(a), LabelsKey(b))
+	assert.Equal(t, "", LabelsKey(pdata.NewStringMap()))
+}
+
+func TestAddBucketCounts(t *testing.T) {
+	a := []uint64{1, 2, 3}
+	b := []uint64{10, 20}
+	assert.Equal(t, []uint64{11, 22, 3}, AddBucketCounts(a, b))
+	assert.Equal(t, []uint64{11, 22, 3}, AddBucketCounts(b, a))
+	assert.Equal(t, []uint64{1, 2, 3}, AddBucketCounts(nil, a))
+	// the bucket counts are not modified
+	assert.Equal(t, []uint64{1, 2, 3}, a)
+	assert.Equal(t, []uint64{10, 20}, b)
+}
+
+func TestSweeper(t *testing.T) {
+	start := time.Unix(1000, 0)
+	sweeper := NewSweeper(time.Minute)
+
+	staleBefore, ok := sweeper.StaleBefore(start)
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(-time.Minute), staleBefore)
+
+	// at most one sweep per max staleness period
+	_, ok = sweeper.StaleBefore(start.Add(30 * time.Second))
+	assert.False(t, ok)
+	staleBefore, ok = sweeper.StaleBefore(start.Add(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, start, staleBefore)
+
+	_, ok = NewSweeper(0).StaleBefore(start)
+	assert.False(t, ok)
+}
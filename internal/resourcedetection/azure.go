@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// azureEndpoint is the endpoint of the Azure instance metadata service.
+var azureEndpoint = "http://169.254.169.254"
+
+// azureCompute is the compute metadata of an Azure virtual machine.
+type azureCompute struct {
+	Location       string `json:"location"`
+	Name           string `json:"name"`
+	SubscriptionID string `json:"subscriptionId"`
+	VMID           string `json:"vmId"`
+	VMSize         string `json:"vmSize"`
+}
+
+func detectAzure(ctx context.Context, client *http.Client, attributes pdata.AttributeMap) error {
+	var compute azureCompute
+	err := getMetadataJSON(ctx, client, azureEndpoint+"/metadata/instance/compute?api-version=2020-09-01&format=json",
+		map[string]string{"Metadata": "true"}, &compute)
+	if err != nil {
+		return err
+	}
+
+	attributes.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
+	attributes.InsertString(conventions.AttributeCloudInfrastructureService, conventions.AttributeCloudProviderAzureVM)
+	insertString(attributes, conventions.AttributeCloudAccount, compute.SubscriptionID)
+	insertString(attributes, conventions.AttributeCloudRegion, compute.Location)
+	insertString(attributes, conventions.AttributeHostID, compute.VMID)
+	insertString(attributes, conventions.AttributeHostName, compute.Name)
+	insertString(attributes, conventions.AttributeHostType, compute.VMSize)
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcedetection detects the resource attributes of the host the
+// collector runs on, e.g. its name, operating system and cloud instance or
+// container task.
+package resourcedetection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	// SystemDetector detects the host name, id and operating system.
+	SystemDetector = "system"
+	// EC2Detector detects the AWS EC2 instance from its metadata service.
+	EC2Detector = "ec2"
+	// GCEDetector detects the GCP Compute Engine instance from its metadata service.
+	GCEDetector = "gce"
+	// AzureDetector detects the Azure virtual machine from its metadata service.
+	AzureDetector = "azure"
+	// ECSDetector detects the AWS ECS task from its task metadata endpoint.
+	ECSDetector = "ecs"
+)
+
+// detector inserts the attributes it detects, the client is used for the
+// requests to the cloud metadata services.
+type detector func(ctx context.Context, client *http.Client, attributes pdata.AttributeMap) error
+
+var detectors = map[string]detector{
+	SystemDetector: detectSystem,
+	EC2Detector:    detectEC2,
+	GCEDetector:    detectGCE,
+	AzureDetector:  detectAzure,
+	ECSDetector:    detectECS,
+}
+
+// Validate returns an error if one of the detectors is unknown.
+func Validate(names []string) error {
+	for _, name := range names {
+		if _, ok := detectors[name]; !ok {
+			return fmt.Errorf("unknown resource detector %q", name)
+		}
+	}
+	return nil
+}
+
+// Detect runs the detectors in order and returns the detected attributes, the
+// attributes detected by the first detectors take precedence. The attributes
+// detected are returned along with the errors of the detectors that failed.
+func Detect(ctx context.Context, names []string, timeout time.Duration) (pdata.AttributeMap, error) {
+	attributes := pdata.NewAttributeMap()
+	client := &http.Client{Timeout: timeout}
+
+	var errs []error
+	for _, name := range names {
+		detect, ok := detectors[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown resource detector %q", name))
+			continue
+		}
+
+		if err := detect(ctx, client, attributes); err != nil {
+			errs = append(errs, fmt.Errorf("error detecting %s resource attributes: %w", name, err))
+		}
+	}
+
+	return attributes, consumererror.CombineErrors(errs)
+}
+
+// insertString inserts the attribute unless its value is empty.
+func insertString(attributes pdata.AttributeMap, key string, value string) {
+	if value != "" {
+		attributes.InsertString(key, value)
+	}
+}
+
+// getMetadata requests the metadata service and returns the response body.
+func getMetadata(ctx context.Context, client *http.Client, method string, url string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s returned status %d", method, url, resp.StatusCode)
+	}
+	return body, nil
+}
+
+// getMetadataJSON requests the metadata service and decodes the JSON response into v.
+func getMetadataJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, v interface{}) error {
+	body, err := getMetadata(ctx, client, http.MethodGet, url, headers)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
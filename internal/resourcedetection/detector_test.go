@@ -0,0 +1,293 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate([]string{SystemDetector, EC2Detector, GCEDetector, AzureDetector, ECSDetector}))
+	assert.EqualError(t, Validate([]string{SystemDetector, "unknown"}), `unknown resource detector "unknown"`)
+}
+
+func TestDetect_System(t *testing.T) {
+	defer func(f func(context.Context) (*host.InfoStat, error)) { hostInfo = f }(hostInfo)
+	hostInfo = func(context.Context) (*host.InfoStat, error) {
+		return &host.InfoStat{
+			Hostname:        "host1",
+			HostID:          "3f6d5ab5-2b2c-4b71-a0b4-3b1c7d0d5e9a",
+			OS:              "linux",
+			Platform:        "ubuntu",
+			PlatformVersion: "20.04",
+			KernelVersion:   "5.4.0-66-generic",
+		}, nil
+	}
+
+	attributes, err := Detect(context.Background(), []string{SystemDetector}, time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		conventions.AttributeHostName:      "host1",
+		conventions.AttributeHostID:        "3f6d5ab5-2b2c-4b71-a0b4-3b1c7d0d5e9a",
+		conventions.AttributeOSType:        getOSType(runtime.GOOS),
+		conventions.AttributeOSDescription: "ubuntu 20.04 (Linux 5.4.0-66-generic)",
+	}, attributesToMap(attributes))
+}
+
+func TestDetect_SystemError(t *testing.T) {
+	defer func(f func(context.Context) (*host.InfoStat, error)) { hostInfo = f }(hostInfo)
+	hostInfo = func(context.Context) (*host.InfoStat, error) { return nil, errors.New("err1") }
+
+	attributes, err := Detect(context.Background(), []string{SystemDetector}, time.Second)
+	assert.EqualError(t, err, "error detecting system resource attributes: err1")
+	assert.Equal(t, 0, attributes.Len())
+}
+
+func TestGetOSDescription(t *testing.T) {
+	assert.Equal(t, "Microsoft Windows Server 2019 Datacenter 10.0.17763 Build 17763 (Windows 10.0.17763 Build 17763)", getOSDescription(&host.InfoStat{
+		OS:              "windows",
+		Platform:        "Microsoft Windows Server 2019 Datacenter",
+		PlatformVersion: "10.0.17763 Build 17763",
+		KernelVersion:   "10.0.17763 Build 17763",
+	}))
+	assert.Equal(t, "Linux 5.4.0", getOSDescription(&host.InfoStat{OS: "linux", KernelVersion: "5.4.0"}))
+	assert.Equal(t, "alpine 3.13.2", getOSDescription(&host.InfoStat{OS: "linux", Platform: "alpine", PlatformVersion: "3.13.2"}))
+	assert.Equal(t, "LINUX", getOSType("linux"))
+	assert.Equal(t, "DRAGONFLYBSD", getOSType("dragonfly"))
+	assert.Equal(t, "PLAN9", getOSType("plan9"))
+}
+
+func TestDetect_EC2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+			assert.Equal(t, "60", r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds"))
+			w.Write([]byte("token1"))
+		case r.Method == http.MethodGet && r.URL.Path == "/latest/dynamic/instance-identity/document":
+			if r.Header.Get("X-aws-ec2-metadata-token") != "token1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{
+				"accountId": "123456789012",
+				"architecture": "x86_64",
+				"availabilityZone": "us-west-2b",
+				"imageId": "ami-5fb8c835",
+				"instanceId": "i-1234567890abcdef0",
+				"instanceType": "t2.micro",
+				"region": "us-west-2"
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	defer func(endpoint string) { ec2Endpoint = endpoint }(ec2Endpoint)
+	ec2Endpoint = server.URL
+
+	attributes, err := Detect(context.Background(), []string{EC2Detector}, time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		conventions.AttributeCloudProvider:              conventions.AttributeCloudProviderAWS,
+		conventions.AttributeCloudInfrastructureService: conventions.AttributeCloudProviderAWSEC2,
+		conventions.AttributeCloudAccount:               "123456789012",
+		conventions.AttributeCloudRegion:                "us-west-2",
+		conventions.AttributeCloudZone:                  "us-west-2b",
+		conventions.AttributeHostID:                     "i-1234567890abcdef0",
+		conventions.AttributeHostType:                   "t2.micro",
+		conventions.AttributeHostImageID:                "ami-5fb8c835",
+	}, attributesToMap(attributes))
+}
+
+func TestDetect_GCE(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/computeMetadata/v1/" || r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{
+			"instance": {
+				"id": 4520031799277581759,
+				"machineType": "projects/123456789/machineTypes/n1-standard-1",
+				"name": "instance-1",
+				"zone": "projects/123456789/zones/us-central1-a"
+			},
+			"project": {
+				"numericProjectId": 123456789,
+				"projectId": "project-1"
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	defer func(endpoint string) { gceEndpoint = endpoint }(gceEndpoint)
+	gceEndpoint = server.URL
+
+	attributes, err := Detect(context.Background(), []string{GCEDetector}, time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		conventions.AttributeCloudProvider:              conventions.AttributeCloudProviderGCP,
+		conventions.AttributeCloudInfrastructureService: conventions.AttributeCloudProviderGCPComputeEngine,
+		conventions.AttributeCloudAccount:               "project-1",
+		conventions.AttributeCloudRegion:                "us-central1",
+		conventions.AttributeCloudZone:                  "us-central1-a",
+		conventions.AttributeHostID:                     "4520031799277581759",
+		conventions.AttributeHostName:                   "instance-1",
+		conventions.AttributeHostType:                   "n1-standard-1",
+	}, attributesToMap(attributes))
+}
+
+func TestDetect_Azure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/instance/compute" || r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{
+			"location": "westeurope",
+			"name": "vm1",
+			"subscriptionId": "8d10da13-8125-4ba9-a717-bf7490507b3d",
+			"vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+			"vmSize": "Standard_D2s_v3"
+		}`))
+	}))
+	defer server.Close()
+
+	defer func(endpoint string) { azureEndpoint = endpoint }(azureEndpoint)
+	azureEndpoint = server.URL
+
+	attributes, err := Detect(context.Background(), []string{AzureDetector}, time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		conventions.AttributeCloudProvider:              conventions.AttributeCloudProviderAzure,
+		conventions.AttributeCloudInfrastructureService: conventions.AttributeCloudProviderAzureVM,
+		conventions.AttributeCloudAccount:               "8d10da13-8125-4ba9-a717-bf7490507b3d",
+		conventions.AttributeCloudRegion:                "westeurope",
+		conventions.AttributeHostID:                     "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+		conventions.AttributeHostName:                   "vm1",
+		conventions.AttributeHostType:                   "Standard_D2s_v3",
+	}, attributesToMap(attributes))
+}
+
+func TestDetect_ECS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/task" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{
+			"Cluster": "default",
+			"TaskARN": "arn:aws:ecs:us-west-2:123456789012:task/default/158d1c8083dd49d6b527399fd6414f5c",
+			"Family": "checkout",
+			"Revision": "5",
+			"AvailabilityZone": "us-west-2d",
+			"LaunchType": "FARGATE"
+		}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("ECS_CONTAINER_METADATA_URI_V4", server.URL+"/v4")
+	defer os.Unsetenv("ECS_CONTAINER_METADATA_URI_V4")
+
+	attributes, err := Detect(context.Background(), []string{ECSDetector}, time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		conventions.AttributeCloudProvider:              conventions.AttributeCloudProviderAWS,
+		conventions.AttributeCloudInfrastructureService: conventions.AttributeCloudProviderAWSECS,
+		conventions.AttributeCloudAccount:               "123456789012",
+		conventions.AttributeCloudRegion:                "us-west-2",
+		conventions.AttributeCloudZone:                  "us-west-2d",
+		"aws.ecs.cluster.arn":                           "arn:aws:ecs:us-west-2:123456789012:cluster/default",
+		"aws.ecs.task.arn":                              "arn:aws:ecs:us-west-2:123456789012:task/default/158d1c8083dd49d6b527399fd6414f5c",
+		"aws.ecs.task.family":                           "checkout",
+		"aws.ecs.task.revision":                         "5",
+		"aws.ecs.launchtype":                            "fargate",
+	}, attributesToMap(attributes))
+}
+
+func TestDetect_NotECS(t *testing.T) {
+	for _, envVar := range ecsMetadataEnvVars {
+		defer os.Setenv(envVar, os.Getenv(envVar))
+		os.Unsetenv(envVar)
+	}
+
+	attributes, err := Detect(context.Background(), []string{ECSDetector}, time.Second)
+	assert.EqualError(t, err, "error detecting ecs resource attributes: "+errNotECS.Error())
+	assert.Equal(t, 0, attributes.Len())
+}
+
+func TestDetect_Precedence(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"location": "westeurope", "vmId": "vm-id"}`))
+	}))
+	defer server.Close()
+
+	defer func(endpoint string) { azureEndpoint = endpoint }(azureEndpoint)
+	azureEndpoint = server.URL
+	defer func(f func(context.Context) (*host.InfoStat, error)) { hostInfo = f }(hostInfo)
+	hostInfo = func(context.Context) (*host.InfoStat, error) {
+		return &host.InfoStat{Hostname: "host1", HostID: "machine-id"}, nil
+	}
+
+	// the attributes detected by the first detectors take precedence
+	attributes, err := Detect(context.Background(), []string{AzureDetector, SystemDetector}, time.Second)
+	require.NoError(t, err)
+
+	attributesMap := attributesToMap(attributes)
+	assert.Equal(t, "vm-id", attributesMap[conventions.AttributeHostID])
+	assert.Equal(t, "host1", attributesMap[conventions.AttributeHostName])
+}
+
+func TestDetect_CloudError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	defer func(endpoint string) { gceEndpoint = endpoint }(gceEndpoint)
+	gceEndpoint = server.URL
+
+	attributes, err := Detect(context.Background(), []string{GCEDetector}, time.Second)
+	assert.EqualError(t, err, "error detecting gce resource attributes: GET "+server.URL+"/computeMetadata/v1/?recursive=true returned status 404")
+	assert.Equal(t, 0, attributes.Len())
+}
+
+func attributesToMap(attributes pdata.AttributeMap) map[string]string {
+	attributesMap := map[string]string{}
+	attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		attributesMap[k] = v.StringVal()
+	})
+	return attributesMap
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// ec2Endpoint is the endpoint of the EC2 instance metadata service.
+var ec2Endpoint = "http://169.254.169.254"
+
+// ec2Identity is the instance identity document of an EC2 instance.
+type ec2Identity struct {
+	AccountID        string `json:"accountId"`
+	AvailabilityZone string `json:"availabilityZone"`
+	ImageID          string `json:"imageId"`
+	InstanceID       string `json:"instanceId"`
+	InstanceType     string `json:"instanceType"`
+	Region           string `json:"region"`
+}
+
+func detectEC2(ctx context.Context, client *http.Client, attributes pdata.AttributeMap) error {
+	// a session token is required when the instance enforces IMDSv2, the
+	// identity document is requested without token if it cannot be obtained
+	headers := map[string]string{}
+	token, err := getMetadata(ctx, client, http.MethodPut, ec2Endpoint+"/latest/api/token",
+		map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": "60"})
+	if err == nil {
+		headers["X-aws-ec2-metadata-token"] = string(token)
+	}
+
+	var identity ec2Identity
+	err = getMetadataJSON(ctx, client, ec2Endpoint+"/latest/dynamic/instance-identity/document", headers, &identity)
+	if err != nil {
+		return err
+	}
+
+	attributes.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	attributes.InsertString(conventions.AttributeCloudInfrastructureService, conventions.AttributeCloudProviderAWSEC2)
+	insertString(attributes, conventions.AttributeCloudAccount, identity.AccountID)
+	insertString(attributes, conventions.AttributeCloudRegion, identity.Region)
+	insertString(attributes, conventions.AttributeCloudZone, identity.AvailabilityZone)
+	insertString(attributes, conventions.AttributeHostID, identity.InstanceID)
+	insertString(attributes, conventions.AttributeHostType, identity.InstanceType)
+	insertString(attributes, conventions.AttributeHostImageID, identity.ImageID)
+	return nil
+}
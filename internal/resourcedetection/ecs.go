@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	attributeECSClusterARN   = "aws.ecs.cluster.arn"
+	attributeECSTaskARN      = "aws.ecs.task.arn"
+	attributeECSTaskFamily   = "aws.ecs.task.family"
+	attributeECSTaskRevision = "aws.ecs.task.revision"
+	attributeECSLaunchType   = "aws.ecs.launchtype"
+)
+
+// ecsMetadataEnvVars are the environment variables set by the ECS agent to
+// the endpoint of the task metadata, by order of preference.
+var ecsMetadataEnvVars = []string{"ECS_CONTAINER_METADATA_URI_V4", "ECS_CONTAINER_METADATA_URI"}
+
+var errNotECS = errors.New("the ECS task metadata endpoint is not set, the collector is not running in an ECS task")
+
+// ecsTaskMetadata is the subset of the metadata of an ECS task used for the
+// resource attributes.
+type ecsTaskMetadata struct {
+	Cluster          string `json:"Cluster"`
+	TaskARN          string `json:"TaskARN"`
+	Family           string `json:"Family"`
+	Revision         string `json:"Revision"`
+	AvailabilityZone string `json:"AvailabilityZone"`
+	LaunchType       string `json:"LaunchType"`
+}
+
+func detectECS(ctx context.Context, client *http.Client, attributes pdata.AttributeMap) error {
+	var endpoint string
+	for _, envVar := range ecsMetadataEnvVars {
+		if endpoint = os.Getenv(envVar); endpoint != "" {
+			break
+		}
+	}
+	if endpoint == "" {
+		return errNotECS
+	}
+
+	var task ecsTaskMetadata
+	if err := getMetadataJSON(ctx, client, endpoint+"/task", nil, &task); err != nil {
+		return err
+	}
+
+	// the task ARN has the format arn:aws:ecs:<region>:<account>:task/...
+	var region, account string
+	if fields := strings.SplitN(task.TaskARN, ":", 6); len(fields) == 6 {
+		region, account = fields[3], fields[4]
+	}
+
+	// the cluster is reported either by name or by ARN
+	clusterARN := task.Cluster
+	if clusterARN != "" && !strings.HasPrefix(clusterARN, "arn:") && region != "" {
+		clusterARN = "arn:aws:ecs:" + region + ":" + account + ":cluster/" + clusterARN
+	}
+
+	attributes.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	attributes.InsertString(conventions.AttributeCloudInfrastructureService, conventions.AttributeCloudProviderAWSECS)
+	insertString(attributes, conventions.AttributeCloudAccount, account)
+	insertString(attributes, conventions.AttributeCloudRegion, region)
+	insertString(attributes, conventions.AttributeCloudZone, task.AvailabilityZone)
+	insertString(attributes, attributeECSClusterARN, clusterARN)
+	insertString(attributes, attributeECSTaskARN, task.TaskARN)
+	insertString(attributes, attributeECSTaskFamily, task.Family)
+	insertString(attributes, attributeECSTaskRevision, task.Revision)
+	insertString(attributes, attributeECSLaunchType, strings.ToLower(task.LaunchType))
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// gceEndpoint is the endpoint of the Compute Engine metadata server.
+var gceEndpoint = "http://metadata.google.internal"
+
+// gceMetadata is the subset of the metadata of a Compute Engine instance used
+// for the resource attributes.
+type gceMetadata struct {
+	Instance struct {
+		ID          json.Number `json:"id"`
+		MachineType string      `json:"machineType"`
+		Name        string      `json:"name"`
+		Zone        string      `json:"zone"`
+	} `json:"instance"`
+	Project struct {
+		ProjectID string `json:"projectId"`
+	} `json:"project"`
+}
+
+func detectGCE(ctx context.Context, client *http.Client, attributes pdata.AttributeMap) error {
+	var metadata gceMetadata
+	err := getMetadataJSON(ctx, client, gceEndpoint+"/computeMetadata/v1/?recursive=true",
+		map[string]string{"Metadata-Flavor": "Google"}, &metadata)
+	if err != nil {
+		return err
+	}
+
+	// the zone and the machine type are returned as resource paths, e.g.
+	// "projects/123456789/zones/us-central1-a"
+	zone := path.Base(metadata.Instance.Zone)
+	var region string
+	if i := strings.LastIndexByte(zone, '-'); i > 0 {
+		region = zone[:i]
+	}
+
+	attributes.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderGCP)
+	attributes.InsertString(conventions.AttributeCloudInfrastructureService, conventions.AttributeCloudProviderGCPComputeEngine)
+	insertString(attributes, conventions.AttributeCloudAccount, metadata.Project.ProjectID)
+	insertString(attributes, conventions.AttributeCloudRegion, region)
+	insertString(attributes, conventions.AttributeCloudZone, zone)
+	insertString(attributes, conventions.AttributeHostID, metadata.Instance.ID.String())
+	insertString(attributes, conventions.AttributeHostName, metadata.Instance.Name)
+	if metadata.Instance.MachineType != "" {
+		attributes.InsertString(conventions.AttributeHostType, path.Base(metadata.Instance.MachineType))
+	}
+	return nil
+}
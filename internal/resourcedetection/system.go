@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/shirou/gopsutil/host"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// for mocking
+var hostInfo = host.InfoWithContext
+
+// osTypes maps the GOOS values to the os.type values of the semantic conventions.
+var osTypes = map[string]string{
+	"aix":       "AIX",
+	"darwin":    "DARWIN",
+	"dragonfly": "DRAGONFLYBSD",
+	"freebsd":   "FREEBSD",
+	"illumos":   "SOLARIS",
+	"linux":     "LINUX",
+	"netbsd":    "NETBSD",
+	"openbsd":   "OPENBSD",
+	"solaris":   "SOLARIS",
+	"windows":   "WINDOWS",
+	"zos":       "Z_OS",
+}
+
+func detectSystem(ctx context.Context, _ *http.Client, attributes pdata.AttributeMap) error {
+	info, err := hostInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	insertString(attributes, conventions.AttributeHostName, info.Hostname)
+	insertString(attributes, conventions.AttributeHostID, info.HostID)
+	insertString(attributes, conventions.AttributeOSType, getOSType(runtime.GOOS))
+	insertString(attributes, conventions.AttributeOSDescription, getOSDescription(info))
+	return nil
+}
+
+func getOSType(goos string) string {
+	if osType, ok := osTypes[goos]; ok {
+		return osType
+	}
+	return strings.ToUpper(goos)
+}
+
+// getOSDescription returns the name and version of the operating system along
+// with the version of its kernel, e.g. "ubuntu 20.04 (Linux 5.4.0-66-generic)".
+func getOSDescription(info *host.InfoStat) string {
+	description := strings.TrimSpace(info.Platform + " " + info.PlatformVersion)
+	if info.KernelVersion == "" {
+		return description
+	}
+
+	kernel := info.KernelVersion
+	if info.OS != "" {
+		kernel = strings.ToUpper(info.OS[:1]) + info.OS[1:] + " " + kernel
+	}
+	if description == "" {
+		return kernel
+	}
+	return description + " (" + kernel + ")"
+}
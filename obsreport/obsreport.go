@@ -17,25 +17,130 @@ package obsreport
 import (
 	"context"
 	"strings"
+	"sync"
 
-	"go.opencensus.io/stats"
-	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
 const (
 	nameSep = "/"
+
+	// instrumentationName is the name of the Meter the instruments of the
+	// obsreport package are created with.
+	instrumentationName = "go.opentelemetry.io/collector/obsreport"
 )
 
 var (
 	gLevel = configtelemetry.LevelBasic
 
 	okStatus = trace.Status{Code: trace.StatusCodeOK}
+
+	gInstrumentsMu sync.RWMutex
+	gInstruments   = newInstruments(metric.NoopMeterProvider{})
 )
 
+// instruments are the OpenTelemetry instruments the obsreport functions
+// record with, all created from the same MeterProvider.
+type instruments struct {
+	receiver  receiverInstruments
+	scraper   scraperInstruments
+	exporter  exporterInstruments
+	processor processorInstruments
+}
+
+func newInstruments(mp metric.MeterProvider) *instruments {
+	meter := metric.Must(mp.Meter(instrumentationName))
+	return &instruments{
+		receiver:  newReceiverInstruments(meter),
+		scraper:   newScraperInstruments(meter),
+		exporter:  newExporterInstruments(meter),
+		processor: newProcessorInstruments(meter),
+	}
+}
+
+// SetMeterProvider sets the MeterProvider the instruments used to record the
+// metrics of the obsreport package are created from. Until it is called the
+// metrics are not recorded. The values of the previous instruments are not
+// carried over.
+func SetMeterProvider(mp metric.MeterProvider) {
+	insts := newInstruments(mp)
+
+	gInstrumentsMu.Lock()
+	defer gInstrumentsMu.Unlock()
+	gInstruments = insts
+}
+
+// currentInstruments returns the instruments created by the last call to
+// SetMeterProvider.
+func currentInstruments() *instruments {
+	gInstrumentsMu.RLock()
+	defer gInstrumentsMu.RUnlock()
+	return gInstruments
+}
+
+// AggregatorSelector returns the selector of the aggregations of the metrics
+// recorded by the obsreport package, to be used by the controller of the
+// MeterProvider given to SetMeterProvider: the durations of the scrapes are
+// distributed in buckets bounded in milliseconds, the counters are summed and
+// the sizes of the queues keep their last value.
+func AggregatorSelector() export.AggregatorSelector {
+	return simple.NewWithHistogramDistribution(histogram.WithExplicitBoundaries(scrapeDurationBoundaries))
+}
+
+// contextLabels returns the labels for the tags with the given keys set in the
+// context by ReceiverContext, ScraperContext or ExporterContext. The tags with
+// an empty value are skipped.
+func contextLabels(ctx context.Context, keys ...tag.Key) []attribute.KeyValue {
+	tags := tag.FromContext(ctx)
+	labels := make([]attribute.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		if value, ok := tags.Value(key); ok && value != "" {
+			labels = append(labels, attribute.String(key.Name(), value))
+		}
+	}
+	return labels
+}
+
+// lastValues keeps the last value recorded for each set of labels of a gauge,
+// reported when the ValueObserver of the gauge is observed.
+type lastValues struct {
+	mu     sync.Mutex
+	values map[attribute.Distinct]labeledValue
+}
+
+type labeledValue struct {
+	labels []attribute.KeyValue
+	value  int64
+}
+
+func newLastValues() *lastValues {
+	return &lastValues{values: make(map[attribute.Distinct]labeledValue)}
+}
+
+func (lv *lastValues) record(value int64, labels ...attribute.KeyValue) {
+	set := attribute.NewSet(labels...)
+
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+	lv.values[set.Equivalent()] = labeledValue{labels: labels, value: value}
+}
+
+func (lv *lastValues) observe(_ context.Context, result metric.Int64ObserverResult) {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+	for _, v := range lv.values {
+		result.Observe(v.value, v.labels...)
+	}
+}
+
 // setParentLink tries to retrieve a span from parentCtx and if one exists
 // sets its SpanID, TraceID as a link to the given child Span.
 // It returns true only if it retrieved a parent span from the context.
@@ -61,16 +166,14 @@ func setParentLink(parentCtx context.Context, childSpan *trace.Span) bool {
 }
 
 // Configure is used to control the settings that will be used by the obsreport
-// package.
-func Configure(level configtelemetry.Level) (views []*view.View) {
+// package. The metrics are recorded with the instruments created from the
+// MeterProvider given to SetMeterProvider.
+func Configure(level configtelemetry.Level) {
 	gLevel = level
 
 	if gLevel != configtelemetry.LevelNone {
 		gProcessorObsReport.level = level
-		views = append(views, AllViews()...)
 	}
-
-	return views
 }
 
 func buildComponentPrefix(componentPrefix, configType string) string {
@@ -83,78 +186,6 @@ func buildComponentPrefix(componentPrefix, configType string) string {
 	return componentPrefix + configType + nameSep
 }
 
-// AllViews return the list of all views that needs to be configured.
-func AllViews() (views []*view.View) {
-	// Receiver views.
-	measures := []*stats.Int64Measure{
-		mReceiverAcceptedSpans,
-		mReceiverRefusedSpans,
-		mReceiverAcceptedMetricPoints,
-		mReceiverRefusedMetricPoints,
-		mReceiverAcceptedLogRecords,
-		mReceiverRefusedLogRecords,
-	}
-	tagKeys := []tag.Key{
-		tagKeyReceiver, tagKeyTransport,
-	}
-	views = append(views, genViews(measures, tagKeys, view.Sum())...)
-
-	// Scraper views.
-	measures = []*stats.Int64Measure{
-		mScraperScrapedMetricPoints,
-		mScraperErroredMetricPoints,
-	}
-	tagKeys = []tag.Key{tagKeyReceiver, tagKeyScraper}
-	views = append(views, genViews(measures, tagKeys, view.Sum())...)
-
-	// Exporter views.
-	measures = []*stats.Int64Measure{
-		mExporterSentSpans,
-		mExporterFailedToSendSpans,
-		mExporterSentMetricPoints,
-		mExporterFailedToSendMetricPoints,
-		mExporterSentLogRecords,
-		mExporterFailedToSendLogRecords,
-	}
-	tagKeys = []tag.Key{tagKeyExporter}
-	views = append(views, genViews(measures, tagKeys, view.Sum())...)
-
-	// Processor views.
-	measures = []*stats.Int64Measure{
-		mProcessorAcceptedSpans,
-		mProcessorRefusedSpans,
-		mProcessorDroppedSpans,
-		mProcessorAcceptedMetricPoints,
-		mProcessorRefusedMetricPoints,
-		mProcessorDroppedMetricPoints,
-		mProcessorAcceptedLogRecords,
-		mProcessorRefusedLogRecords,
-		mProcessorDroppedLogRecords,
-	}
-	tagKeys = []tag.Key{tagKeyProcessor}
-	views = append(views, genViews(measures, tagKeys, view.Sum())...)
-
-	return views
-}
-
-func genViews(
-	measures []*stats.Int64Measure,
-	tagKeys []tag.Key,
-	aggregation *view.Aggregation,
-) []*view.View {
-	views := make([]*view.View, 0, len(measures))
-	for _, measure := range measures {
-		views = append(views, &view.View{
-			Name:        measure.Name(),
-			Description: measure.Description(),
-			TagKeys:     tagKeys,
-			Measure:     measure,
-			Aggregation: aggregation,
-		})
-	}
-	return views
-}
-
 func errToStatus(err error) trace.Status {
 	if err != nil {
 		return trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()}
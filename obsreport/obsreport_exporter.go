@@ -17,10 +17,13 @@ package obsreport
 import (
 	"context"
 
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/unit"
 
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
@@ -42,6 +45,20 @@ const (
 	SentLogRecordsKey = "sent_log_records"
 	// Key used to track logs that failed to be sent by exporters.
 	FailedToSendLogRecordsKey = "send_failed_log_records"
+
+	// Key used to identify the data type of the sending queue of exporters.
+	DataTypeKey = "data_type"
+	// Key used to track the number of batches in the sending queue of exporters.
+	QueueSizeKey = "queue_size"
+	// Key used to track the capacity of the sending queue of exporters.
+	QueueCapacityKey = "queue_capacity"
+
+	// Key used to track spans that failed to be added to the sending queue of exporters.
+	FailedToEnqueueSpansKey = "enqueue_failed_spans"
+	// Key used to track metric points that failed to be added to the sending queue of exporters.
+	FailedToEnqueueMetricPointsKey = "enqueue_failed_metric_points"
+	// Key used to track log records that failed to be added to the sending queue of exporters.
+	FailedToEnqueueLogRecordsKey = "enqueue_failed_log_records"
 )
 
 var (
@@ -57,32 +74,64 @@ var (
 	// on backend and exporter are expected to be the same. Translation issues
 	// that result in a different number of elements should be reported in a
 	// separate way.
-	mExporterSentSpans = stats.Int64(
-		exporterPrefix+SentSpansKey,
-		"Number of spans successfully sent to destination.",
-		stats.UnitDimensionless)
-	mExporterFailedToSendSpans = stats.Int64(
-		exporterPrefix+FailedToSendSpansKey,
-		"Number of spans in failed attempts to send to destination.",
-		stats.UnitDimensionless)
-	mExporterSentMetricPoints = stats.Int64(
-		exporterPrefix+SentMetricPointsKey,
-		"Number of metric points successfully sent to destination.",
-		stats.UnitDimensionless)
-	mExporterFailedToSendMetricPoints = stats.Int64(
-		exporterPrefix+FailedToSendMetricPointsKey,
-		"Number of metric points in failed attempts to send to destination.",
-		stats.UnitDimensionless)
-	mExporterSentLogRecords = stats.Int64(
-		exporterPrefix+SentLogRecordsKey,
-		"Number of log record successfully sent to destination.",
-		stats.UnitDimensionless)
-	mExporterFailedToSendLogRecords = stats.Int64(
-		exporterPrefix+FailedToSendLogRecordsKey,
-		"Number of log records in failed attempts to send to destination.",
-		stats.UnitDimensionless)
 )
 
+// exporterInstruments are the instruments of the exporter metrics.
+type exporterInstruments struct {
+	sentSpans                   metric.Int64Counter
+	failedToSendSpans           metric.Int64Counter
+	sentMetricPoints            metric.Int64Counter
+	failedToSendMetricPoints    metric.Int64Counter
+	sentLogRecords              metric.Int64Counter
+	failedToSendLogRecords      metric.Int64Counter
+	failedToEnqueueSpans        metric.Int64Counter
+	failedToEnqueueMetricPoints metric.Int64Counter
+	failedToEnqueueLogRecords   metric.Int64Counter
+	droppedSpans                metric.Int64Counter
+	droppedMetricPoints         metric.Int64Counter
+	droppedLogRecords           metric.Int64Counter
+	// queueSize and queueCapacity keep the last size and capacity recorded
+	// for the sending queue of each exporter and data type.
+	queueSize     *lastValues
+	queueCapacity *lastValues
+}
+
+func newExporterInstruments(meter metric.MeterMust) exporterInstruments {
+	counter := func(key, description string) metric.Int64Counter {
+		return meter.NewInt64Counter(
+			exporterPrefix+key,
+			metric.WithDescription(description),
+			metric.WithUnit(unit.Dimensionless))
+	}
+	insts := exporterInstruments{
+		sentSpans:                   counter(SentSpansKey, "Number of spans successfully sent to destination."),
+		failedToSendSpans:           counter(FailedToSendSpansKey, "Number of spans in failed attempts to send to destination."),
+		sentMetricPoints:            counter(SentMetricPointsKey, "Number of metric points successfully sent to destination."),
+		failedToSendMetricPoints:    counter(FailedToSendMetricPointsKey, "Number of metric points in failed attempts to send to destination."),
+		sentLogRecords:              counter(SentLogRecordsKey, "Number of log record successfully sent to destination."),
+		failedToSendLogRecords:      counter(FailedToSendLogRecordsKey, "Number of log records in failed attempts to send to destination."),
+		failedToEnqueueSpans:        counter(FailedToEnqueueSpansKey, "Number of spans that failed to be added to the sending queue."),
+		failedToEnqueueMetricPoints: counter(FailedToEnqueueMetricPointsKey, "Number of metric points that failed to be added to the sending queue."),
+		failedToEnqueueLogRecords:   counter(FailedToEnqueueLogRecordsKey, "Number of log records that failed to be added to the sending queue."),
+		droppedSpans:                counter(DroppedSpansKey, "Number of spans dropped after failing to be sent to destination."),
+		droppedMetricPoints:         counter(DroppedMetricPointsKey, "Number of metric points dropped after failing to be sent to destination."),
+		droppedLogRecords:           counter(DroppedLogRecordsKey, "Number of log records dropped after failing to be sent to destination."),
+		queueSize:                   newLastValues(),
+		queueCapacity:               newLastValues(),
+	}
+	meter.NewInt64ValueObserver(
+		exporterPrefix+QueueSizeKey,
+		insts.queueSize.observe,
+		metric.WithDescription("Current number of batches in the sending queue."),
+		metric.WithUnit(unit.Dimensionless))
+	meter.NewInt64ValueObserver(
+		exporterPrefix+QueueCapacityKey,
+		insts.queueCapacity.observe,
+		metric.WithDescription("Maximum number of batches in the sending queue."),
+		metric.WithUnit(unit.Dimensionless))
+	return insts
+}
+
 // ExporterContext adds the keys used when recording observability metrics to
 // the given context returning the newly created context. This context should
 // be used in related calls to the obsreport functions so metrics are properly
@@ -95,14 +144,14 @@ func ExporterContext(ctx context.Context, exporterName string) context.Context {
 type ExporterObsReport struct {
 	level        configtelemetry.Level
 	exporterName string
-	mutators     []tag.Mutator
+	labels       []attribute.KeyValue
 }
 
 func NewExporterObsReport(level configtelemetry.Level, exporterName string) *ExporterObsReport {
 	return &ExporterObsReport{
 		level:        level,
 		exporterName: exporterName,
-		mutators:     []tag.Mutator{tag.Upsert(tagKeyProcessor, exporterName, tag.WithTTL(tag.TTLNoPropagation))},
+		labels:       []attribute.KeyValue{attribute.String(ExporterKey, exporterName)},
 	}
 }
 
@@ -116,7 +165,8 @@ func (eor *ExporterObsReport) StartTracesExportOp(ctx context.Context) context.C
 // EndTracesExportOp completes the export operation that was started with StartTracesExportOp.
 func (eor *ExporterObsReport) EndTracesExportOp(ctx context.Context, numSpans int, err error) {
 	numSent, numFailedToSend := toNumItems(numSpans, err)
-	recordMetrics(ctx, numSent, numFailedToSend, mExporterSentSpans, mExporterFailedToSendSpans)
+	insts := currentInstruments().exporter
+	eor.recordMetrics(ctx, numSent, numFailedToSend, insts.sentSpans, insts.failedToSendSpans)
 	endSpan(ctx, err, numSent, numFailedToSend, SentSpansKey, FailedToSendSpansKey)
 }
 
@@ -131,7 +181,8 @@ func (eor *ExporterObsReport) StartMetricsExportOp(ctx context.Context) context.
 // StartMetricsExportOp.
 func (eor *ExporterObsReport) EndMetricsExportOp(ctx context.Context, numMetricPoints int, err error) {
 	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
-	recordMetrics(ctx, numSent, numFailedToSend, mExporterSentMetricPoints, mExporterFailedToSendMetricPoints)
+	insts := currentInstruments().exporter
+	eor.recordMetrics(ctx, numSent, numFailedToSend, insts.sentMetricPoints, insts.failedToSendMetricPoints)
 	endSpan(ctx, err, numSent, numFailedToSend, SentMetricPointsKey, FailedToSendMetricPointsKey)
 }
 
@@ -145,10 +196,64 @@ func (eor *ExporterObsReport) StartLogsExportOp(ctx context.Context) context.Con
 // EndLogsExportOp completes the export operation that was started with StartLogsExportOp.
 func (eor *ExporterObsReport) EndLogsExportOp(ctx context.Context, numLogRecords int, err error) {
 	numSent, numFailedToSend := toNumItems(numLogRecords, err)
-	recordMetrics(ctx, numSent, numFailedToSend, mExporterSentLogRecords, mExporterFailedToSendLogRecords)
+	insts := currentInstruments().exporter
+	eor.recordMetrics(ctx, numSent, numFailedToSend, insts.sentLogRecords, insts.failedToSendLogRecords)
 	endSpan(ctx, err, numSent, numFailedToSend, SentLogRecordsKey, FailedToSendLogRecordsKey)
 }
 
+// RecordQueueSize records the number of batches in the sending queue of the
+// given data type and the capacity of the queue.
+func (eor *ExporterObsReport) RecordQueueSize(ctx context.Context, dataType configmodels.DataType, size, capacity int) {
+	if gLevel == configtelemetry.LevelNone {
+		return
+	}
+	insts := currentInstruments().exporter
+	labels := append([]attribute.KeyValue{attribute.String(DataTypeKey, string(dataType))}, eor.labels...)
+	insts.queueSize.record(int64(size), labels...)
+	insts.queueCapacity.record(int64(capacity), labels...)
+}
+
+// RecordEnqueueFailure records the items of the given data type that failed
+// to be added to the sending queue.
+func (eor *ExporterObsReport) RecordEnqueueFailure(ctx context.Context, dataType configmodels.DataType, numItems int) {
+	insts := currentInstruments().exporter
+	if counter, ok := itemsCounter(dataType,
+		insts.failedToEnqueueSpans, insts.failedToEnqueueMetricPoints, insts.failedToEnqueueLogRecords); ok {
+		eor.recordItems(ctx, numItems, counter)
+	}
+}
+
+// RecordDroppedItems records the items of the given data type dropped after
+// failing to be sent, when the retries are exhausted or the error is permanent.
+func (eor *ExporterObsReport) RecordDroppedItems(ctx context.Context, dataType configmodels.DataType, numItems int) {
+	insts := currentInstruments().exporter
+	if counter, ok := itemsCounter(dataType,
+		insts.droppedSpans, insts.droppedMetricPoints, insts.droppedLogRecords); ok {
+		eor.recordItems(ctx, numItems, counter)
+	}
+}
+
+func (eor *ExporterObsReport) recordItems(ctx context.Context, numItems int, counter metric.Int64Counter) {
+	if gLevel == configtelemetry.LevelNone {
+		return
+	}
+	counter.Add(ctx, int64(numItems), eor.labels...)
+}
+
+// itemsCounter returns the counter of the items of the data type, false if the
+// data type is unknown.
+func itemsCounter(dataType configmodels.DataType, spans, metricPoints, logRecords metric.Int64Counter) (metric.Int64Counter, bool) {
+	switch dataType {
+	case configmodels.TracesDataType:
+		return spans, true
+	case configmodels.MetricsDataType:
+		return metricPoints, true
+	case configmodels.LogsDataType:
+		return logRecords, true
+	}
+	return metric.Int64Counter{}, false
+}
+
 // startSpan creates the span used to trace the operation. Returning
 // the updated context and the created span.
 func (eor *ExporterObsReport) startSpan(ctx context.Context, operationSuffix string) context.Context {
@@ -157,14 +262,12 @@ func (eor *ExporterObsReport) startSpan(ctx context.Context, operationSuffix str
 	return ctx
 }
 
-func recordMetrics(ctx context.Context, numSent, numFailedToSend int64, sentMeasure, failedToSendMeasure *stats.Int64Measure) {
+func (eor *ExporterObsReport) recordMetrics(ctx context.Context, numSent, numFailedToSend int64, sentCounter, failedToSendCounter metric.Int64Counter) {
 	if gLevel == configtelemetry.LevelNone {
 		return
 	}
-	stats.Record(
-		ctx,
-		sentMeasure.M(numSent),
-		failedToSendMeasure.M(numFailedToSend))
+	sentCounter.Add(ctx, numSent, eor.labels...)
+	failedToSendCounter.Add(ctx, numFailedToSend, eor.labels...)
 }
 
 func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
@@ -17,9 +17,10 @@ package obsreport
 import (
 	"context"
 
-	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/unit"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 )
@@ -39,50 +40,45 @@ const (
 )
 
 var (
-	tagKeyProcessor, _ = tag.NewKey(ProcessorKey)
-
 	processorPrefix = ProcessorKey + nameSep
 
 	// Processor metrics. Any count of data items below is in the internal format
 	// of the collector since processors only deal with internal format.
-	mProcessorAcceptedSpans = stats.Int64(
-		processorPrefix+AcceptedSpansKey,
-		"Number of spans successfully pushed into the next component in the pipeline.",
-		stats.UnitDimensionless)
-	mProcessorRefusedSpans = stats.Int64(
-		processorPrefix+RefusedSpansKey,
-		"Number of spans that were rejected by the next component in the pipeline.",
-		stats.UnitDimensionless)
-	mProcessorDroppedSpans = stats.Int64(
-		processorPrefix+DroppedSpansKey,
-		"Number of spans that were dropped.",
-		stats.UnitDimensionless)
-	mProcessorAcceptedMetricPoints = stats.Int64(
-		processorPrefix+AcceptedMetricPointsKey,
-		"Number of metric points successfully pushed into the next component in the pipeline.",
-		stats.UnitDimensionless)
-	mProcessorRefusedMetricPoints = stats.Int64(
-		processorPrefix+RefusedMetricPointsKey,
-		"Number of metric points that were rejected by the next component in the pipeline.",
-		stats.UnitDimensionless)
-	mProcessorDroppedMetricPoints = stats.Int64(
-		processorPrefix+DroppedMetricPointsKey,
-		"Number of metric points that were dropped.",
-		stats.UnitDimensionless)
-	mProcessorAcceptedLogRecords = stats.Int64(
-		processorPrefix+AcceptedLogRecordsKey,
-		"Number of log records successfully pushed into the next component in the pipeline.",
-		stats.UnitDimensionless)
-	mProcessorRefusedLogRecords = stats.Int64(
-		processorPrefix+RefusedLogRecordsKey,
-		"Number of log records that were rejected by the next component in the pipeline.",
-		stats.UnitDimensionless)
-	mProcessorDroppedLogRecords = stats.Int64(
-		processorPrefix+DroppedLogRecordsKey,
-		"Number of log records that were dropped.",
-		stats.UnitDimensionless)
 )
 
+// processorInstruments are the instruments of the processor metrics.
+type processorInstruments struct {
+	acceptedSpans        metric.Int64Counter
+	refusedSpans         metric.Int64Counter
+	droppedSpans         metric.Int64Counter
+	acceptedMetricPoints metric.Int64Counter
+	refusedMetricPoints  metric.Int64Counter
+	droppedMetricPoints  metric.Int64Counter
+	acceptedLogRecords   metric.Int64Counter
+	refusedLogRecords    metric.Int64Counter
+	droppedLogRecords    metric.Int64Counter
+}
+
+func newProcessorInstruments(meter metric.MeterMust) processorInstruments {
+	counter := func(key, description string) metric.Int64Counter {
+		return meter.NewInt64Counter(
+			processorPrefix+key,
+			metric.WithDescription(description),
+			metric.WithUnit(unit.Dimensionless))
+	}
+	return processorInstruments{
+		acceptedSpans:        counter(AcceptedSpansKey, "Number of spans successfully pushed into the next component in the pipeline."),
+		refusedSpans:         counter(RefusedSpansKey, "Number of spans that were rejected by the next component in the pipeline."),
+		droppedSpans:         counter(DroppedSpansKey, "Number of spans that were dropped."),
+		acceptedMetricPoints: counter(AcceptedMetricPointsKey, "Number of metric points successfully pushed into the next component in the pipeline."),
+		refusedMetricPoints:  counter(RefusedMetricPointsKey, "Number of metric points that were rejected by the next component in the pipeline."),
+		droppedMetricPoints:  counter(DroppedMetricPointsKey, "Number of metric points that were dropped."),
+		acceptedLogRecords:   counter(AcceptedLogRecordsKey, "Number of log records successfully pushed into the next component in the pipeline."),
+		refusedLogRecords:    counter(RefusedLogRecordsKey, "Number of log records that were rejected by the next component in the pipeline."),
+		droppedLogRecords:    counter(DroppedLogRecordsKey, "Number of log records that were dropped."),
+	}
+}
+
 // BuildProcessorCustomMetricName is used to be build a metric name following
 // the standards used in the Collector. The configType should be the same
 // value used to identify the type on the config.
@@ -117,130 +113,103 @@ func ProcessorMetricViews(configType string, legacyViews []*view.View) []*view.V
 var gProcessorObsReport = &ProcessorObsReport{level: configtelemetry.LevelNone}
 
 type ProcessorObsReport struct {
-	level    configtelemetry.Level
-	mutators []tag.Mutator
+	level  configtelemetry.Level
+	labels []attribute.KeyValue
 }
 
 func NewProcessorObsReport(level configtelemetry.Level, processorName string) *ProcessorObsReport {
 	return &ProcessorObsReport{
-		level:    level,
-		mutators: []tag.Mutator{tag.Upsert(tagKeyProcessor, processorName, tag.WithTTL(tag.TTLNoPropagation))},
+		level:  level,
+		labels: []attribute.KeyValue{attribute.String(ProcessorKey, processorName)},
 	}
 }
 
 // TracesAccepted reports that the trace data was accepted.
 func (por *ProcessorObsReport) TracesAccepted(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
-		stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			mProcessorAcceptedSpans.M(int64(numSpans)),
-			mProcessorRefusedSpans.M(0),
-			mProcessorDroppedSpans.M(0),
-		)
+		insts := currentInstruments().processor
+		insts.acceptedSpans.Add(ctx, int64(numSpans), por.labels...)
+		insts.refusedSpans.Add(ctx, 0, por.labels...)
+		insts.droppedSpans.Add(ctx, 0, por.labels...)
 	}
 }
 
 // TracesRefused reports that the trace data was refused.
 func (por *ProcessorObsReport) TracesRefused(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
-		stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			mProcessorAcceptedSpans.M(0),
-			mProcessorRefusedSpans.M(int64(numSpans)),
-			mProcessorDroppedSpans.M(0),
-		)
+		insts := currentInstruments().processor
+		insts.acceptedSpans.Add(ctx, 0, por.labels...)
+		insts.refusedSpans.Add(ctx, int64(numSpans), por.labels...)
+		insts.droppedSpans.Add(ctx, 0, por.labels...)
 	}
 }
 
 // TracesDropped reports that the trace data was dropped.
 func (por *ProcessorObsReport) TracesDropped(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
-		stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			mProcessorAcceptedSpans.M(0),
-			mProcessorRefusedSpans.M(0),
-			mProcessorDroppedSpans.M(int64(numSpans)),
-		)
+		insts := currentInstruments().processor
+		insts.acceptedSpans.Add(ctx, 0, por.labels...)
+		insts.refusedSpans.Add(ctx, 0, por.labels...)
+		insts.droppedSpans.Add(ctx, int64(numSpans), por.labels...)
 	}
 }
 
 // MetricsAccepted reports that the metrics were accepted.
 func (por *ProcessorObsReport) MetricsAccepted(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
-		stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			mProcessorAcceptedMetricPoints.M(int64(numPoints)),
-			mProcessorRefusedMetricPoints.M(0),
-			mProcessorDroppedMetricPoints.M(0),
-		)
+		insts := currentInstruments().processor
+		insts.acceptedMetricPoints.Add(ctx, int64(numPoints), por.labels...)
+		insts.refusedMetricPoints.Add(ctx, 0, por.labels...)
+		insts.droppedMetricPoints.Add(ctx, 0, por.labels...)
 	}
 }
 
 // MetricsRefused reports that the metrics were refused.
 func (por *ProcessorObsReport) MetricsRefused(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
-		stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			mProcessorAcceptedMetricPoints.M(0),
-			mProcessorRefusedMetricPoints.M(int64(numPoints)),
-			mProcessorDroppedMetricPoints.M(0),
-		)
+		insts := currentInstruments().processor
+		insts.acceptedMetricPoints.Add(ctx, 0, por.labels...)
+		insts.refusedMetricPoints.Add(ctx, int64(numPoints), por.labels...)
+		insts.droppedMetricPoints.Add(ctx, 0, por.labels...)
 	}
 }
 
 // MetricsDropped reports that the metrics were dropped.
 func (por *ProcessorObsReport) MetricsDropped(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
-		stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			mProcessorAcceptedMetricPoints.M(0),
-			mProcessorRefusedMetricPoints.M(0),
-			mProcessorDroppedMetricPoints.M(int64(numPoints)),
-		)
+		insts := currentInstruments().processor
+		insts.acceptedMetricPoints.Add(ctx, 0, por.labels...)
+		insts.refusedMetricPoints.Add(ctx, 0, por.labels...)
+		insts.droppedMetricPoints.Add(ctx, int64(numPoints), por.labels...)
 	}
 }
 
 // LogsAccepted reports that the logs were accepted.
 func (por *ProcessorObsReport) LogsAccepted(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
-		stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			mProcessorAcceptedLogRecords.M(int64(numRecords)),
-			mProcessorRefusedLogRecords.M(0),
-			mProcessorDroppedLogRecords.M(0),
-		)
+		insts := currentInstruments().processor
+		insts.acceptedLogRecords.Add(ctx, int64(numRecords), por.labels...)
+		insts.refusedLogRecords.Add(ctx, 0, por.labels...)
+		insts.droppedLogRecords.Add(ctx, 0, por.labels...)
 	}
 }
 
 // LogsRefused reports that the logs were refused.
 func (por *ProcessorObsReport) LogsRefused(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
-		stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			mProcessorAcceptedLogRecords.M(0),
-			mProcessorRefusedLogRecords.M(int64(numRecords)),
-			mProcessorDroppedMetricPoints.M(0),
-		)
+		insts := currentInstruments().processor
+		insts.acceptedLogRecords.Add(ctx, 0, por.labels...)
+		insts.refusedLogRecords.Add(ctx, int64(numRecords), por.labels...)
+		insts.droppedLogRecords.Add(ctx, 0, por.labels...)
 	}
 }
 
 // LogsDropped reports that the logs were dropped.
 func (por *ProcessorObsReport) LogsDropped(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
-		stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			mProcessorAcceptedLogRecords.M(0),
-			mProcessorRefusedLogRecords.M(0),
-			mProcessorDroppedLogRecords.M(int64(numRecords)),
-		)
+		insts := currentInstruments().processor
+		insts.acceptedLogRecords.Add(ctx, 0, por.labels...)
+		insts.refusedLogRecords.Add(ctx, 0, por.labels...)
+		insts.droppedLogRecords.Add(ctx, int64(numRecords), por.labels...)
 	}
 }
@@ -17,9 +17,10 @@ package obsreport
 import (
 	"context"
 
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/unit"
 
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
@@ -59,38 +60,51 @@ var (
 	receiveTraceDataOperationSuffix = nameSep + "TraceDataReceived"
 	receiverMetricsOperationSuffix  = nameSep + "MetricsReceived"
 	receiverLogsOperationSuffix     = nameSep + "LogsReceived"
-
-	// Receiver metrics. Any count of data items below is in the original format
-	// that they were received, reasoning: reconciliation is easier if measurements
-	// on clients and receiver are expected to be the same. Translation issues
-	// that result in a different number of elements should be reported in a
-	// separate way.
-	mReceiverAcceptedSpans = stats.Int64(
-		receiverPrefix+AcceptedSpansKey,
-		"Number of spans successfully pushed into the pipeline.",
-		stats.UnitDimensionless)
-	mReceiverRefusedSpans = stats.Int64(
-		receiverPrefix+RefusedSpansKey,
-		"Number of spans that could not be pushed into the pipeline.",
-		stats.UnitDimensionless)
-	mReceiverAcceptedMetricPoints = stats.Int64(
-		receiverPrefix+AcceptedMetricPointsKey,
-		"Number of metric points successfully pushed into the pipeline.",
-		stats.UnitDimensionless)
-	mReceiverRefusedMetricPoints = stats.Int64(
-		receiverPrefix+RefusedMetricPointsKey,
-		"Number of metric points that could not be pushed into the pipeline.",
-		stats.UnitDimensionless)
-	mReceiverAcceptedLogRecords = stats.Int64(
-		receiverPrefix+AcceptedLogRecordsKey,
-		"Number of log records successfully pushed into the pipeline.",
-		stats.UnitDimensionless)
-	mReceiverRefusedLogRecords = stats.Int64(
-		receiverPrefix+RefusedLogRecordsKey,
-		"Number of log records that could not be pushed into the pipeline.",
-		stats.UnitDimensionless)
 )
 
+// receiverInstruments are the instruments of the receiver metrics. Any count
+// of data items below is in the original format that they were received,
+// reasoning: reconciliation is easier if measurements on clients and receiver
+// are expected to be the same. Translation issues that result in a different
+// number of elements should be reported in a separate way.
+type receiverInstruments struct {
+	acceptedSpans        metric.Int64Counter
+	refusedSpans         metric.Int64Counter
+	acceptedMetricPoints metric.Int64Counter
+	refusedMetricPoints  metric.Int64Counter
+	acceptedLogRecords   metric.Int64Counter
+	refusedLogRecords    metric.Int64Counter
+}
+
+func newReceiverInstruments(meter metric.MeterMust) receiverInstruments {
+	return receiverInstruments{
+		acceptedSpans: meter.NewInt64Counter(
+			receiverPrefix+AcceptedSpansKey,
+			metric.WithDescription("Number of spans successfully pushed into the pipeline."),
+			metric.WithUnit(unit.Dimensionless)),
+		refusedSpans: meter.NewInt64Counter(
+			receiverPrefix+RefusedSpansKey,
+			metric.WithDescription("Number of spans that could not be pushed into the pipeline."),
+			metric.WithUnit(unit.Dimensionless)),
+		acceptedMetricPoints: meter.NewInt64Counter(
+			receiverPrefix+AcceptedMetricPointsKey,
+			metric.WithDescription("Number of metric points successfully pushed into the pipeline."),
+			metric.WithUnit(unit.Dimensionless)),
+		refusedMetricPoints: meter.NewInt64Counter(
+			receiverPrefix+RefusedMetricPointsKey,
+			metric.WithDescription("Number of metric points that could not be pushed into the pipeline."),
+			metric.WithUnit(unit.Dimensionless)),
+		acceptedLogRecords: meter.NewInt64Counter(
+			receiverPrefix+AcceptedLogRecordsKey,
+			metric.WithDescription("Number of log records successfully pushed into the pipeline."),
+			metric.WithUnit(unit.Dimensionless)),
+		refusedLogRecords: meter.NewInt64Counter(
+			receiverPrefix+RefusedLogRecordsKey,
+			metric.WithDescription("Number of log records that could not be pushed into the pipeline."),
+			metric.WithUnit(unit.Dimensionless)),
+	}
+}
+
 // StartReceiveOptions has the options related to starting a receive operation.
 type StartReceiveOptions struct {
 	// LongLivedCtx when true indicates that the context passed in the call
@@ -110,33 +124,32 @@ type StartReceiveOption func(*StartReceiveOptions)
 //
 // Example:
 //
-//    func (r *receiver) ClientConnect(ctx context.Context, rcvChan <-chan pdata.Traces) {
-//        longLivedCtx := obsreport.ReceiverContext(ctx, r.config.Name(), r.transport, "")
-//        for {
-//            // Since the context outlives the individual receive operations call obsreport using
-//            // WithLongLivedCtx().
-//            ctx := obsreport.StartTraceDataReceiveOp(
-//                longLivedCtx,
-//                r.config.Name(),
-//                r.transport,
-//                obsreport.WithLongLivedCtx())
-//
-//            td, ok := <-rcvChan
-//            var err error
-//            if ok {
-//                err = r.nextConsumer.ConsumeTraces(ctx, td)
-//            }
-//            obsreport.EndTraceDataReceiveOp(
-//                ctx,
-//                r.format,
-//                len(td.Spans),
-//                err)
-//            if !ok {
-//                break
-//            }
-//        }
-//    }
+//	func (r *receiver) ClientConnect(ctx context.Context, rcvChan <-chan pdata.Traces) {
+//	    longLivedCtx := obsreport.ReceiverContext(ctx, r.config.Name(), r.transport, "")
+//	    for {
+//	        // Since the context outlives the individual receive operations call obsreport using
+//	        // WithLongLivedCtx().
+//	        ctx := obsreport.StartTraceDataReceiveOp(
+//	            longLivedCtx,
+//	            r.config.Name(),
+//	            r.transport,
+//	            obsreport.WithLongLivedCtx())
 //
+//	        td, ok := <-rcvChan
+//	        var err error
+//	        if ok {
+//	            err = r.nextConsumer.ConsumeTraces(ctx, td)
+//	        }
+//	        obsreport.EndTraceDataReceiveOp(
+//	            ctx,
+//	            r.format,
+//	            len(td.Spans),
+//	            err)
+//	        if !ok {
+//	            break
+//	        }
+//	    }
+//	}
 func WithLongLivedCtx() StartReceiveOption {
 	return func(opts *StartReceiveOptions) {
 		opts.LongLivedCtx = true
@@ -316,23 +329,23 @@ func endReceiveOp(
 	span := trace.FromContext(receiverCtx)
 
 	if gLevel != configtelemetry.LevelNone {
-		var acceptedMeasure, refusedMeasure *stats.Int64Measure
+		insts := currentInstruments().receiver
+		var acceptedCounter, refusedCounter metric.Int64Counter
 		switch dataType {
 		case configmodels.TracesDataType:
-			acceptedMeasure = mReceiverAcceptedSpans
-			refusedMeasure = mReceiverRefusedSpans
+			acceptedCounter = insts.acceptedSpans
+			refusedCounter = insts.refusedSpans
 		case configmodels.MetricsDataType:
-			acceptedMeasure = mReceiverAcceptedMetricPoints
-			refusedMeasure = mReceiverRefusedMetricPoints
+			acceptedCounter = insts.acceptedMetricPoints
+			refusedCounter = insts.refusedMetricPoints
 		case configmodels.LogsDataType:
-			acceptedMeasure = mReceiverAcceptedLogRecords
-			refusedMeasure = mReceiverRefusedLogRecords
+			acceptedCounter = insts.acceptedLogRecords
+			refusedCounter = insts.refusedLogRecords
 		}
 
-		stats.Record(
-			receiverCtx,
-			acceptedMeasure.M(int64(numAccepted)),
-			refusedMeasure.M(int64(numRefused)))
+		labels := contextLabels(receiverCtx, tagKeyReceiver, tagKeyTransport)
+		acceptedCounter.Add(receiverCtx, int64(numAccepted), labels...)
+		refusedCounter.Add(receiverCtx, int64(numRefused), labels...)
 	}
 
 	// end span according to errors
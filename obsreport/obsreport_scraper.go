@@ -16,10 +16,12 @@ package obsreport
 
 import (
 	"context"
+	"time"
 
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/unit"
 
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
@@ -36,6 +38,11 @@ const (
 	// ErroredMetricPointsKey used to identify metric points errored (i.e.
 	// unable to be scraped) by the Collector.
 	ErroredMetricPointsKey = "errored_metric_points"
+	// ScrapeDurationKey used to identify the duration of the scrapes.
+	ScrapeDurationKey = "scrape_duration"
+	// ConsecutiveFailuresKey used to identify the number of consecutive
+	// scrapes that failed.
+	ConsecutiveFailuresKey = "consecutive_failures"
 )
 
 const (
@@ -46,16 +53,49 @@ const (
 var (
 	tagKeyScraper, _ = tag.NewKey(ScraperKey)
 
-	mScraperScrapedMetricPoints = stats.Int64(
-		scraperPrefix+ScrapedMetricPointsKey,
-		"Number of metric points successfully scraped.",
-		stats.UnitDimensionless)
-	mScraperErroredMetricPoints = stats.Int64(
-		scraperPrefix+ErroredMetricPointsKey,
-		"Number of metric points that were unable to be scraped.",
-		stats.UnitDimensionless)
+	// scrapeDurationBoundaries are the bounds, in milliseconds, of the buckets
+	// the scrape durations are distributed in.
+	scrapeDurationBoundaries = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
 )
 
+// scraperInstruments are the instruments of the scraper metrics.
+type scraperInstruments struct {
+	scrapedMetricPoints metric.Int64Counter
+	erroredMetricPoints metric.Int64Counter
+	scrapeDuration      metric.Float64ValueRecorder
+	// consecutiveFailures keeps the number of consecutive failures recorded
+	// after the last scrape.
+	consecutiveFailures *lastValues
+}
+
+func newScraperInstruments(meter metric.MeterMust) scraperInstruments {
+	insts := scraperInstruments{
+		scrapedMetricPoints: meter.NewInt64Counter(
+			scraperPrefix+ScrapedMetricPointsKey,
+			metric.WithDescription("Number of metric points successfully scraped."),
+			metric.WithUnit(unit.Dimensionless)),
+		erroredMetricPoints: meter.NewInt64Counter(
+			scraperPrefix+ErroredMetricPointsKey,
+			metric.WithDescription("Number of metric points that were unable to be scraped."),
+			metric.WithUnit(unit.Dimensionless)),
+		scrapeDuration: meter.NewFloat64ValueRecorder(
+			scraperPrefix+ScrapeDurationKey,
+			metric.WithDescription("Duration of the scrapes."),
+			metric.WithUnit(unit.Milliseconds)),
+		consecutiveFailures: newLastValues(),
+	}
+	meter.NewInt64ValueObserver(
+		scraperPrefix+ConsecutiveFailuresKey,
+		insts.consecutiveFailures.observe,
+		metric.WithDescription("Number of consecutive scrapes that returned an error, reset by a successful scrape."),
+		metric.WithUnit(unit.Dimensionless))
+	return insts
+}
+
+// scrapeStartTimeKey is the key of the start time of the scrape operation in
+// the context returned by StartMetricsScrapeOp.
+type scrapeStartTimeKey struct{}
+
 // ScraperContext adds the keys used when recording observability metrics to
 // the given context returning the newly created context. This context should
 // be used in related calls to the obsreport functions so metrics are properly
@@ -88,7 +128,7 @@ func StartMetricsScrapeOp(
 
 	spanName := scraperPrefix + scraperName + scraperMetricsOperationSuffix
 	ctx, _ := trace.StartSpan(scraperCtx, spanName)
-	return ctx
+	return context.WithValue(ctx, scrapeStartTimeKey{}, time.Now())
 }
 
 // EndMetricsScrapeOp completes the scrape operation that was started with
@@ -111,10 +151,15 @@ func EndMetricsScrapeOp(
 	span := trace.FromContext(scraperCtx)
 
 	if gLevel != configtelemetry.LevelNone {
-		stats.Record(
-			scraperCtx,
-			mScraperScrapedMetricPoints.M(int64(numScrapedMetrics)),
-			mScraperErroredMetricPoints.M(int64(numErroredMetrics)))
+		insts := currentInstruments().scraper
+		labels := contextLabels(scraperCtx, tagKeyReceiver, tagKeyScraper)
+		insts.scrapedMetricPoints.Add(scraperCtx, int64(numScrapedMetrics), labels...)
+		insts.erroredMetricPoints.Add(scraperCtx, int64(numErroredMetrics), labels...)
+
+		if start, ok := scraperCtx.Value(scrapeStartTimeKey{}).(time.Time); ok {
+			duration := time.Since(start)
+			insts.scrapeDuration.Record(scraperCtx, float64(duration)/float64(time.Millisecond), labels...)
+		}
 	}
 
 	// end span according to errors
@@ -130,3 +175,18 @@ func EndMetricsScrapeOp(
 
 	span.End()
 }
+
+// RecordConsecutiveScrapeFailures records the number of consecutive scrapes
+// of the scraper that returned an error, zero after a successful scrape. It
+// should be called with the context returned by ScraperContext after the
+// scrape operation ended.
+func RecordConsecutiveScrapeFailures(
+	scraperCtx context.Context,
+	consecutiveFailures int,
+) {
+	if gLevel != configtelemetry.LevelNone {
+		currentInstruments().scraper.consecutiveFailures.record(
+			int64(consecutiveFailures),
+			contextLabels(scraperCtx, tagKeyReceiver, tagKeyScraper)...)
+	}
+}
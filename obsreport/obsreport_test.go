@@ -27,7 +27,12 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processorbasic "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
@@ -54,10 +59,11 @@ type receiveTestParams struct {
 }
 
 func TestConfigure(t *testing.T) {
+	receiverMetrics := []string{"receiver/accepted_spans", "receiver/refused_spans"}
 	tests := []struct {
 		name      string
 		level     configtelemetry.Level
-		wantViews []*view.View
+		wantNames []string
 	}{
 		{
 			name:  "none",
@@ -66,23 +72,39 @@ func TestConfigure(t *testing.T) {
 		{
 			name:      "basic",
 			level:     configtelemetry.LevelBasic,
-			wantViews: obsreport.AllViews(),
+			wantNames: receiverMetrics,
 		},
 		{
 			name:      "normal",
 			level:     configtelemetry.LevelNormal,
-			wantViews: obsreport.AllViews(),
+			wantNames: receiverMetrics,
 		},
 		{
 			name:      "detailed",
 			level:     configtelemetry.LevelDetailed,
-			wantViews: obsreport.AllViews(),
+			wantNames: receiverMetrics,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotViews := obsreport.Configure(tt.level)
-			assert.Equal(t, tt.wantViews, gotViews)
+			ctrl := controller.New(
+				processorbasic.New(obsreport.AggregatorSelector(), export.CumulativeExportKindSelector()),
+				controller.WithCollectPeriod(0))
+			obsreport.SetMeterProvider(ctrl.MeterProvider())
+			defer obsreport.SetMeterProvider(metric.NoopMeterProvider{})
+			obsreport.Configure(tt.level)
+
+			receiverCtx := obsreport.ReceiverContext(context.Background(), receiver, transport)
+			ctx := obsreport.StartTraceDataReceiveOp(receiverCtx, receiver, transport)
+			obsreport.EndTraceDataReceiveOp(ctx, format, 7, nil)
+
+			require.NoError(t, ctrl.Collect(context.Background()))
+			var gotNames []string
+			require.NoError(t, ctrl.ForEach(export.CumulativeExportKindSelector(), func(record export.Record) error {
+				gotNames = append(gotNames, record.Descriptor().Name())
+				return nil
+			}))
+			assert.ElementsMatch(t, tt.wantNames, gotNames)
 		})
 	}
 }
@@ -292,6 +314,7 @@ func TestScrapeMetricsDataOp(t *testing.T) {
 			ctx,
 			scrapedMetricPts[i],
 			err)
+		obsreport.RecordConsecutiveScrapeFailures(receiverCtx, len(errParams)-i-1)
 	}
 
 	spans := ss.PullAllSpans()
@@ -323,6 +346,8 @@ func TestScrapeMetricsDataOp(t *testing.T) {
 	}
 
 	obsreporttest.CheckScraperMetricsViews(t, receiver, scraper, int64(scrapedMetricPoints), int64(erroredMetricPoints))
+	obsreporttest.CheckScraperDurationViews(t, receiver, scraper, int64(len(errParams)))
+	obsreporttest.CheckScraperConsecutiveFailuresViews(t, receiver, scraper, 0)
 }
 
 func TestExportTraceDataOp(t *testing.T) {
@@ -473,6 +498,37 @@ func TestExportLogsOp(t *testing.T) {
 	obsreporttest.CheckExporterLogsViews(t, exporter, int64(sentLogRecords), int64(failedToSendLogRecords))
 }
 
+func TestExporterQueueMetrics(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	ctx := context.Background()
+	obsrep := obsreport.NewExporterObsReport(configtelemetry.LevelNormal, exporter)
+
+	obsrep.RecordQueueSize(ctx, configmodels.TracesDataType, 3, 10)
+	obsrep.RecordQueueSize(ctx, configmodels.TracesDataType, 2, 10)
+	obsrep.RecordQueueSize(ctx, configmodels.LogsDataType, 5, 20)
+	obsreporttest.CheckExporterQueueSizeViews(t, exporter, "traces", 2, 10)
+	obsreporttest.CheckExporterQueueSizeViews(t, exporter, "logs", 5, 20)
+
+	obsrep.RecordEnqueueFailure(ctx, configmodels.TracesDataType, 7)
+	obsrep.RecordDroppedItems(ctx, configmodels.TracesDataType, 3)
+	obsrep.RecordDroppedItems(ctx, configmodels.TracesDataType, 4)
+	obsreporttest.CheckExporterEnqueueFailedViews(t, exporter, "traces", 7)
+	obsreporttest.CheckExporterDroppedViews(t, exporter, "traces", 7)
+
+	obsrep.RecordEnqueueFailure(ctx, configmodels.MetricsDataType, 11)
+	obsrep.RecordDroppedItems(ctx, configmodels.MetricsDataType, 13)
+	obsreporttest.CheckExporterEnqueueFailedViews(t, exporter, "metrics", 11)
+	obsreporttest.CheckExporterDroppedViews(t, exporter, "metrics", 13)
+
+	obsrep.RecordEnqueueFailure(ctx, configmodels.LogsDataType, 17)
+	obsrep.RecordDroppedItems(ctx, configmodels.LogsDataType, 19)
+	obsreporttest.CheckExporterEnqueueFailedViews(t, exporter, "logs", 17)
+	obsreporttest.CheckExporterDroppedViews(t, exporter, "logs", 19)
+}
+
 func TestReceiveWithLongLivedCtx(t *testing.T) {
 	ss := &spanStore{}
 	trace.RegisterExporter(ss)
@@ -15,13 +15,20 @@
 package obsreporttest
 
 import (
+	"context"
+	"errors"
 	"reflect"
-	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
-	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/resource"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/obsreport"
@@ -39,20 +46,37 @@ var (
 	transportTag, _ = tag.NewKey("transport")
 	exporterTag, _  = tag.NewKey("exporter")
 	processorTag, _ = tag.NewKey("processor")
+	dataTypeTag, _  = tag.NewKey("data_type")
+
+	// itemsNames are the names of the items of each data type in the metric names.
+	itemsNames = map[string]string{
+		"traces":  "spans",
+		"metrics": "metric_points",
+		"logs":    "log_records",
+	}
+
+	// recordedMetrics is the controller of the MeterProvider set up by
+	// SetupRecordedMetricsTest, the metrics are checked against its records.
+	recordedMetrics *controller.Controller
+
+	errRecordFound = errors.New("record found")
 )
 
 // SetupRecordedMetricsTest does setup the testing environment to check the metrics recorded by receivers, producers or exporters.
 // The returned function should be deferred.
 func SetupRecordedMetricsTest() (func(), error) {
-	views := obsreport.Configure(configtelemetry.LevelNormal)
-	err := view.Register(views...)
-	if err != nil {
-		return nil, err
-	}
+	obsreport.Configure(configtelemetry.LevelNormal)
+
+	recordedMetrics = controller.New(
+		processor.New(obsreport.AggregatorSelector(), export.CumulativeExportKindSelector(), processor.WithMemory(true)),
+		controller.WithResource(resource.Empty()),
+		controller.WithCollectPeriod(0))
+	obsreport.SetMeterProvider(recordedMetrics.MeterProvider())
 
 	return func() {
-		view.Unregister(views...)
-	}, err
+		obsreport.SetMeterProvider(metric.NoopMeterProvider{})
+		recordedMetrics = nil
+	}, nil
 }
 
 // CheckExporterTracesViews checks that for the current exported values for trace exporter views match given values.
@@ -79,6 +103,32 @@ func CheckExporterLogsViews(t *testing.T, exporter string, acceptedLogRecords, d
 	CheckValueForView(t, exporterTags, droppedLogRecords, "exporter/send_failed_log_records")
 }
 
+// CheckExporterEnqueueFailedViews checks that for the current exported value for the items of the given data type that
+// failed to be added to the sending queue of an exporter match given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterEnqueueFailedViews(t *testing.T, exporter, dataType string, enqueueFailedItems int64) {
+	CheckValueForView(t, tagsForExporterView(exporter), enqueueFailedItems, "exporter/enqueue_failed_"+itemsNames[dataType])
+}
+
+// CheckExporterDroppedViews checks that for the current exported value for the items of the given data type dropped by
+// an exporter after failing to be sent match given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterDroppedViews(t *testing.T, exporter, dataType string, droppedItems int64) {
+	CheckValueForView(t, tagsForExporterView(exporter), droppedItems, "exporter/dropped_"+itemsNames[dataType])
+}
+
+// CheckExporterQueueSizeViews checks that for the current exported values for the size and the capacity of the sending queue
+// of an exporter for the given data type match given values.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterQueueSizeViews(t *testing.T, exporter, dataType string, queueSize, queueCapacity int64) {
+	queueTags := []tag.Tag{
+		{Key: exporterTag, Value: exporter},
+		{Key: dataTypeTag, Value: dataType},
+	}
+	checkLastValueForView(t, queueTags, queueSize, "exporter/queue_size")
+	checkLastValueForView(t, queueTags, queueCapacity, "exporter/queue_capacity")
+}
+
 // CheckProcessorTracesViews checks that for the current exported values for trace exporter views match given values.
 // When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
 func CheckProcessorTracesViews(t *testing.T, processor string, acceptedSpans, refusedSpans, droppedSpans int64) {
@@ -138,26 +188,83 @@ func CheckScraperMetricsViews(t *testing.T, receiver, scraper string, scrapedMet
 	CheckValueForView(t, scraperTags, erroredMetricPoints, "scraper/errored_metric_points")
 }
 
+// CheckScraperDurationViews checks that for the current exported values for scrape duration views match given number of scrapes.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckScraperDurationViews(t *testing.T, receiver, scraper string, scrapes int64) {
+	scraperTags := tagsForScraperView(receiver, scraper)
+	agg := retrieveDataForView(t, scraperTags, "scraper/scrape_duration")
+	histogram, ok := agg.(aggregation.Histogram)
+	require.True(t, ok, "unexpected aggregation %s", agg.Kind())
+	count, err := histogram.Count()
+	require.NoError(t, err)
+	require.Equal(t, scrapes, int64(count))
+}
+
+// CheckScraperConsecutiveFailuresViews checks that for the current exported value for consecutive failures view match given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckScraperConsecutiveFailuresViews(t *testing.T, receiver, scraper string, consecutiveFailures int64) {
+	scraperTags := tagsForScraperView(receiver, scraper)
+	checkLastValueForView(t, scraperTags, consecutiveFailures, "scraper/consecutive_failures")
+}
+
 // CheckValueForView checks that for the current exported value in the view with the given name
 // for {LegacyTagKeyReceiver: receiverName} is equal to "value".
 func CheckValueForView(t *testing.T, wantTags []tag.Tag, value int64, vName string) {
-	// Make sure the tags slice is sorted by tag keys.
-	sortTags(wantTags)
+	agg := retrieveDataForView(t, wantTags, vName)
+	sum, ok := agg.(aggregation.Sum)
+	require.True(t, ok, "unexpected aggregation %s", agg.Kind())
+	got, err := sum.Sum()
+	require.NoError(t, err)
+	require.Equal(t, value, got.AsInt64())
+}
 
-	rows, err := view.RetrieveData(vName)
+// checkLastValueForView checks that the current exported last value in the view with the given name for the given tags
+// is equal to "value".
+func checkLastValueForView(t *testing.T, wantTags []tag.Tag, value int64, vName string) {
+	agg := retrieveDataForView(t, wantTags, vName)
+	lastValue, ok := agg.(aggregation.LastValue)
+	require.True(t, ok, "unexpected aggregation %s", agg.Kind())
+	got, _, err := lastValue.LastValue()
 	require.NoError(t, err)
+	require.Equal(t, value, got.AsInt64())
+}
+
+// retrieveDataForView returns the aggregation of the metric with the given
+// name recorded for the labels matching the given tags.
+func retrieveDataForView(t *testing.T, wantTags []tag.Tag, vName string) aggregation.Aggregation {
+	require.NotNil(t, recordedMetrics, "SetupRecordedMetricsTest must be called first")
+	require.NoError(t, recordedMetrics.Collect(context.Background()))
 
-	for _, row := range rows {
-		// Make sure the tags slice is sorted by tag keys.
-		sortTags(row.Tags)
-		if reflect.DeepEqual(wantTags, row.Tags) {
-			sum := row.Data.(*view.SumData)
-			require.Equal(t, float64(value), sum.Value)
-			return
+	want := make(map[string]string, len(wantTags))
+	for _, wantTag := range wantTags {
+		want[wantTag.Key.Name()] = wantTag.Value
+	}
+
+	var found aggregation.Aggregation
+	var recorded []string
+	err := recordedMetrics.ForEach(export.CumulativeExportKindSelector(), func(record export.Record) error {
+		if record.Descriptor().Name() != vName {
+			return nil
+		}
+		labels := make(map[string]string, record.Labels().Len())
+		for iter := record.Labels().Iter(); iter.Next(); {
+			label := iter.Label()
+			labels[string(label.Key)] = label.Value.Emit()
 		}
+		if !reflect.DeepEqual(want, labels) {
+			recorded = append(recorded, record.Labels().Encoded(attribute.DefaultEncoder()))
+			return nil
+		}
+		found = record.Aggregation()
+		return errRecordFound
+	})
+	if found != nil {
+		return found
 	}
+	require.NoError(t, err)
 
-	require.Failf(t, "could not find tags", "wantTags: %s in rows %v", wantTags, rows)
+	require.Failf(t, "could not find tags", "wantTags: %s in records %v", wantTags, recorded)
+	return nil
 }
 
 // tagsForReceiverView returns the tags that are needed for the receiver views.
@@ -197,9 +304,3 @@ func tagsForExporterView(exporter string) []tag.Tag {
 		{Key: exporterTag, Value: exporter},
 	}
 }
-
-func sortTags(tags []tag.Tag) {
-	sort.SliceStable(tags, func(i, j int) bool {
-		return tags[i].Key.Name() < tags[j].Key.Name()
-	})
-}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alwayssampleprocessor
+
+import (
+	"time"
+)
+
+type cacheEntry struct {
+	traceID [16]byte
+	added   time.Time
+}
+
+// traceCache remembers the forced traces, in the order they were forced.
+type traceCache struct {
+	rememberFor time.Duration
+	maxTraces   int
+	traces      map[[16]byte]struct{}
+	entries     []cacheEntry
+}
+
+func newTraceCache(rememberFor time.Duration, maxTraces int) *traceCache {
+	return &traceCache{
+		rememberFor: rememberFor,
+		maxTraces:   maxTraces,
+		traces:      make(map[[16]byte]struct{}),
+	}
+}
+
+// add remembers the forced trace.
+func (c *traceCache) add(traceID [16]byte, now time.Time) {
+	if _, ok := c.traces[traceID]; ok {
+		return
+	}
+	if len(c.entries) >= c.maxTraces {
+		c.evict()
+	}
+	c.traces[traceID] = struct{}{}
+	c.entries = append(c.entries, cacheEntry{traceID: traceID, added: now})
+}
+
+func (c *traceCache) contains(traceID [16]byte) bool {
+	_, ok := c.traces[traceID]
+	return ok
+}
+
+// expire forgets the traces forced before the remember period.
+func (c *traceCache) expire(now time.Time) {
+	for len(c.entries) > 0 && now.Sub(c.entries[0].added) >= c.rememberFor {
+		c.evict()
+	}
+}
+
+// evict forgets the oldest trace.
+func (c *traceCache) evict() {
+	delete(c.traces, c.entries[0].traceID)
+	c.entries[0] = cacheEntry{}
+	c.entries = c.entries[1:]
+}
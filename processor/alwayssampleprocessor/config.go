@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alwayssampleprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Always Sample processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Attribute is the key of the root span attribute forcing the sampling of
+	// the trace, e.g. "debug". If not set, only the sampling priority is used.
+	Attribute string `mapstructure:"attribute"`
+
+	// Values are the values of the attribute forcing the sampling of the trace,
+	// compared with the string representation of the attribute. If not set, any
+	// value forces the sampling.
+	Values []string `mapstructure:"values"`
+
+	// SamplingPriority forces the sampling of the traces whose root span has a
+	// positive "sampling.priority" attribute.
+	SamplingPriority bool `mapstructure:"sampling_priority"`
+
+	// RememberFor is how long the forced traces are remembered after their root
+	// span is received, so that their spans received later are forced too.
+	RememberFor time.Duration `mapstructure:"remember_for"`
+
+	// MaxTraces is the maximum number of forced traces remembered, the oldest
+	// ones are forgotten early when it is reached.
+	MaxTraces int `mapstructure:"max_traces"`
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alwayssampleprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "alwayssample"
+
+	defaultAttribute   = "debug"
+	defaultValue       = "true"
+	defaultRememberFor = time.Minute
+	defaultMaxTraces   = 10000
+)
+
+var (
+	errNoCondition          = errors.New("either \"attribute\" or \"sampling_priority\" must be set")
+	errNegativeRememberFor  = errors.New("remember_for must not be negative")
+	errNonPositiveMaxTraces = errors.New("max_traces must be positive")
+)
+
+// NewFactory returns a new factory for the Always Sample processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Attribute:        defaultAttribute,
+		Values:           []string{defaultValue},
+		SamplingPriority: true,
+		RememberFor:      defaultRememberFor,
+		MaxTraces:        defaultMaxTraces,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.TracesConsumer) (component.TracesProcessor, error) {
+	oCfg := cfg.(*Config)
+	if err := validateConfig(oCfg); err != nil {
+		return nil, fmt.Errorf("error creating %q processor: %w", cfg.Name(), err)
+	}
+	return processorhelper.NewTraceProcessor(
+		cfg,
+		nextConsumer,
+		newAlwaysSampleProcessor(oCfg),
+		processorhelper.WithCapabilities(component.ProcessorCapabilities{MutatesConsumedData: true}))
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.Attribute == "" && !cfg.SamplingPriority {
+		return errNoCondition
+	}
+	if cfg.RememberFor < 0 {
+		return errNegativeRememberFor
+	}
+	if cfg.MaxTraces <= 0 {
+		return errNonPositiveMaxTraces
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alwayssampleprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	assert.NotNil(t, tp)
+	assert.True(t, tp.GetCapabilities().MutatesConsumedData)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	assert.Nil(t, mp)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	assert.Nil(t, lp)
+}
+
+func TestCreateProcessors_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    error
+	}{
+		{
+			name: "no condition",
+			modify: func(cfg *Config) {
+				cfg.Attribute = ""
+				cfg.SamplingPriority = false
+			},
+			err: errNoCondition,
+		},
+		{
+			name:   "negative remember for",
+			modify: func(cfg *Config) { cfg.RememberFor = -1 },
+			err:    errNegativeRememberFor,
+		},
+		{
+			name:   "no max traces",
+			modify: func(cfg *Config) { cfg.MaxTraces = 0 },
+			err:    errNonPositiveMaxTraces,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+
+			tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewTracesNop())
+			assert.True(t, errors.Is(err, tt.err))
+			assert.EqualError(t, err, `error creating "alwayssample" processor: `+tt.err.Error())
+			assert.Nil(t, tp)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alwayssampleprocessor
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// samplingPriorityAttribute is the OpenTracing semantic convention honored by
+// the samplers, see
+// https://github.com/opentracing/specification/blob/main/semantic_conventions.md#span-tags-table
+const samplingPriorityAttribute = "sampling.priority"
+
+type alwaysSampleProcessor struct {
+	attribute        string
+	values           map[string]struct{}
+	samplingPriority bool
+	remember         bool
+
+	lock  sync.Mutex
+	cache *traceCache
+	now   func() time.Time
+}
+
+func newAlwaysSampleProcessor(cfg *Config) *alwaysSampleProcessor {
+	var values map[string]struct{}
+	if len(cfg.Values) > 0 {
+		values = make(map[string]struct{}, len(cfg.Values))
+		for _, v := range cfg.Values {
+			values[v] = struct{}{}
+		}
+	}
+	return &alwaysSampleProcessor{
+		attribute:        cfg.Attribute,
+		values:           values,
+		samplingPriority: cfg.SamplingPriority,
+		remember:         cfg.RememberFor > 0,
+		cache:            newTraceCache(cfg.RememberFor, cfg.MaxTraces),
+		now:              time.Now,
+	}
+}
+
+// ProcessTraces sets the sampling priority of the spans of the forced traces,
+// i.e. the traces whose root span is in the batch and forces the sampling, and
+// the ones remembered from the previous batches.
+func (p *alwaysSampleProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	forced := make(map[[16]byte]struct{})
+	forEachSpan(td, func(span pdata.Span) {
+		if span.ParentSpanID().IsEmpty() && p.forcesSampling(span) {
+			forced[span.TraceID().Bytes()] = struct{}{}
+		}
+	})
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := p.now()
+	p.cache.expire(now)
+	if p.remember {
+		for traceID := range forced {
+			p.cache.add(traceID, now)
+		}
+	}
+
+	forEachSpan(td, func(span pdata.Span) {
+		traceID := span.TraceID().Bytes()
+		if _, ok := forced[traceID]; ok || p.cache.contains(traceID) {
+			span.Attributes().UpsertInt(samplingPriorityAttribute, 1)
+		}
+	})
+	return td, nil
+}
+
+// forcesSampling returns whether the root span forces the sampling of its
+// trace.
+func (p *alwaysSampleProcessor) forcesSampling(span pdata.Span) bool {
+	attrs := span.Attributes()
+	if p.attribute != "" {
+		if v, ok := attrs.Get(p.attribute); ok {
+			if p.values == nil {
+				return true
+			}
+			if _, ok := p.values[tracetranslator.AttributeValueToString(v, false)]; ok {
+				return true
+			}
+		}
+	}
+	if p.samplingPriority {
+		if v, ok := attrs.Get(samplingPriorityAttribute); ok {
+			return positivePriority(v)
+		}
+	}
+	return false
+}
+
+// positivePriority returns whether the sampling priority is positive, it may
+// be a number or a string depending on the client libraries.
+func positivePriority(v pdata.AttributeValue) bool {
+	switch v.Type() {
+	case pdata.AttributeValueINT:
+		return v.IntVal() > 0
+	case pdata.AttributeValueDOUBLE:
+		return v.DoubleVal() > 0
+	case pdata.AttributeValueSTRING:
+		value, err := strconv.ParseFloat(v.StringVal(), 64)
+		return err == nil && value > 0
+	}
+	return false
+}
+
+func forEachSpan(td pdata.Traces, f func(span pdata.Span)) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				f(spans.At(k))
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alwayssampleprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// testSpan is a span of the trace with the given ID, a root span when it has
+// no parent.
+type testSpan struct {
+	traceID    byte
+	parent     byte
+	attributes map[string]pdata.AttributeValue
+}
+
+func newTestTraces(spans ...testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	td.ResourceSpans().At(0).InstrumentationLibrarySpans().Resize(1)
+	ss := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	ss.Resize(len(spans))
+	for i, s := range spans {
+		span := ss.At(i)
+		span.SetTraceID(pdata.NewTraceID([16]byte{s.traceID}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{byte(i + 1)}))
+		if s.parent != 0 {
+			span.SetParentSpanID(pdata.NewSpanID([8]byte{s.parent}))
+		}
+		if s.attributes != nil {
+			span.Attributes().InitFromMap(s.attributes)
+		}
+	}
+	return td
+}
+
+// forcedSpans returns whether the spans of the batch have their sampling
+// priority set to 1.
+func forcedSpans(td pdata.Traces) []bool {
+	var forced []bool
+	forEachSpan(td, func(span pdata.Span) {
+		v, ok := span.Attributes().Get(samplingPriorityAttribute)
+		forced = append(forced, ok && v.Type() == pdata.AttributeValueINT && v.IntVal() == 1)
+	})
+	return forced
+}
+
+func newTestProcessor(cfg *Config, now *time.Time) *alwaysSampleProcessor {
+	cfg.ProcessorSettings = configmodels.ProcessorSettings{TypeVal: typeStr, NameVal: typeStr}
+	p := newAlwaysSampleProcessor(cfg)
+	p.now = func() time.Time { return *now }
+	return p
+}
+
+func TestProcessTraces(t *testing.T) {
+	now := time.Unix(1614587400, 0)
+	p := newTestProcessor(NewFactory().CreateDefaultConfig().(*Config), &now)
+
+	td, err := p.ProcessTraces(context.Background(), newTestTraces(
+		testSpan{traceID: 1, attributes: map[string]pdata.AttributeValue{"debug": pdata.NewAttributeValueBool(true)}},
+		testSpan{traceID: 1, parent: 1, attributes: map[string]pdata.AttributeValue{samplingPriorityAttribute: pdata.NewAttributeValueInt(0)}},
+		testSpan{traceID: 2, attributes: map[string]pdata.AttributeValue{"debug": pdata.NewAttributeValueString("false")}},
+		testSpan{traceID: 2, parent: 3},
+		testSpan{traceID: 3, attributes: map[string]pdata.AttributeValue{samplingPriorityAttribute: pdata.NewAttributeValueString("2")}},
+		testSpan{traceID: 3, parent: 5},
+		// Only the root spans force the sampling.
+		testSpan{traceID: 4, parent: 9, attributes: map[string]pdata.AttributeValue{"debug": pdata.NewAttributeValueBool(true)}},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false, false, true, true, false}, forcedSpans(td))
+
+	// The spans of the forced traces received later are forced too.
+	now = now.Add(30 * time.Second)
+	td, err = p.ProcessTraces(context.Background(), newTestTraces(
+		testSpan{traceID: 1, parent: 2},
+		testSpan{traceID: 2, parent: 3},
+		testSpan{traceID: 3, parent: 5},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, forcedSpans(td))
+
+	// The forced traces are forgotten after the remember period.
+	now = now.Add(30 * time.Second)
+	td, err = p.ProcessTraces(context.Background(), newTestTraces(testSpan{traceID: 1, parent: 2}))
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false}, forcedSpans(td))
+}
+
+func TestProcessTraces_Values(t *testing.T) {
+	now := time.Unix(1614587400, 0)
+	p := newTestProcessor(&Config{
+		Attribute: "x-debug",
+		Values:    []string{"1", "on"},
+		MaxTraces: 10,
+	}, &now)
+
+	td, err := p.ProcessTraces(context.Background(), newTestTraces(
+		testSpan{traceID: 1, attributes: map[string]pdata.AttributeValue{"x-debug": pdata.NewAttributeValueInt(1)}},
+		testSpan{traceID: 2, attributes: map[string]pdata.AttributeValue{"x-debug": pdata.NewAttributeValueString("on")}},
+		testSpan{traceID: 3, attributes: map[string]pdata.AttributeValue{"x-debug": pdata.NewAttributeValueString("off")}},
+		testSpan{traceID: 4, attributes: map[string]pdata.AttributeValue{samplingPriorityAttribute: pdata.NewAttributeValueInt(2)}},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false, false}, forcedSpans(td))
+
+	// The forced traces are not remembered without a remember period.
+	td, err = p.ProcessTraces(context.Background(), newTestTraces(testSpan{traceID: 1, parent: 1}))
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false}, forcedSpans(td))
+}
+
+func TestTraceCache_MaxTraces(t *testing.T) {
+	now := time.Unix(1614587400, 0)
+	c := newTraceCache(time.Minute, 2)
+	c.add([16]byte{1}, now)
+	c.add([16]byte{2}, now)
+	c.add([16]byte{1}, now)
+	c.add([16]byte{3}, now)
+
+	assert.False(t, c.contains([16]byte{1}))
+	assert.True(t, c.contains([16]byte{2}))
+	assert.True(t, c.contains([16]byte{3}))
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anonymizationprocessor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/datapoint"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// anonymizer replaces the values of the configured keys with their hashes, in
+// the attributes of the spans, the log records and the resources, and in the
+// labels of the data points.
+type anonymizer struct {
+	keys    []string
+	salt    []byte
+	hmacKey []byte
+}
+
+func newAnonymizer(cfg *Config) (*anonymizer, error) {
+	if len(cfg.Keys) == 0 {
+		return nil, errors.New("at least one key must be specified")
+	}
+	if cfg.Salt != "" && cfg.HMACKey != "" {
+		return nil, errors.New("salt and hmac_key can't be both specified")
+	}
+	return &anonymizer{
+		keys:    cfg.Keys,
+		salt:    []byte(cfg.Salt),
+		hmacKey: []byte(cfg.HMACKey),
+	}, nil
+}
+
+// ProcessTraces hashes the attributes of the resources and the spans.
+func (a *anonymizer) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		a.processAttributes(rs.Resource().Attributes())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				a.processAttributes(spans.At(k).Attributes())
+			}
+		}
+	}
+	return td, nil
+}
+
+// ProcessLogs hashes the attributes of the resources and the log records.
+func (a *anonymizer) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		a.processAttributes(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				a.processAttributes(logs.At(k).Attributes())
+			}
+		}
+	}
+	return ld, nil
+}
+
+// ProcessMetrics hashes the attributes of the resources and the labels of the
+// data points.
+func (a *anonymizer) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		a.processAttributes(rm.Resource().Attributes())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				datapoint.ForEachLabels(metrics.At(k), a.processLabels)
+			}
+		}
+	}
+	return md, nil
+}
+
+// processAttributes hashes the string representation of the values, so that
+// an identifier has the same hash whatever its type and in the labels.
+func (a *anonymizer) processAttributes(attributes pdata.AttributeMap) {
+	for _, key := range a.keys {
+		v, ok := attributes.Get(key)
+		if !ok || v.Type() == pdata.AttributeValueNULL {
+			continue
+		}
+		hashed := a.hash(tracetranslator.AttributeValueToString(v, false))
+		if v.Type() == pdata.AttributeValueSTRING {
+			v.SetStringVal(hashed)
+		} else {
+			attributes.UpsertString(key, hashed)
+		}
+	}
+}
+
+func (a *anonymizer) processLabels(labels pdata.StringMap) {
+	for _, key := range a.keys {
+		if v, ok := labels.Get(key); ok {
+			labels.Update(key, a.hash(v))
+		}
+	}
+}
+
+// hash returns the hex encoded HMAC-SHA256 of the value if a HMAC key is
+// configured, or its salted SHA-256 hash otherwise.
+func (a *anonymizer) hash(value string) string {
+	var h hash.Hash
+	if len(a.hmacKey) > 0 {
+		h = hmac.New(sha256.New, a.hmacKey)
+	} else {
+		h = sha256.New()
+		h.Write(a.salt)
+	}
+	h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil))
+}
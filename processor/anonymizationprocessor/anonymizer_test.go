@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anonymizationprocessor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestProcessTraces(t *testing.T) {
+	a, err := newAnonymizer(&Config{Keys: []string{"enduser.id", "user_id"}, Salt: "salt"})
+	require.NoError(t, err)
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().Attributes().InsertString("user_id", "alice")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(1)
+	spans.At(0).Attributes().InsertString("enduser.id", "alice")
+	spans.At(0).Attributes().InsertInt("user_id", 42)
+	spans.At(0).Attributes().InsertString("http.method", "GET")
+
+	td, err = a.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	v, _ := rs.Resource().Attributes().Get("user_id")
+	assert.Equal(t, sha256Hex("saltalice"), v.StringVal())
+	attrs := spans.At(0).Attributes()
+	v, _ = attrs.Get("enduser.id")
+	assert.Equal(t, sha256Hex("saltalice"), v.StringVal())
+	v, _ = attrs.Get("user_id")
+	assert.Equal(t, pdata.AttributeValueSTRING, v.Type())
+	assert.Equal(t, sha256Hex("salt42"), v.StringVal())
+	v, _ = attrs.Get("http.method")
+	assert.Equal(t, "GET", v.StringVal())
+}
+
+func TestProcessLogs_HMAC(t *testing.T) {
+	a, err := newAnonymizer(&Config{Keys: []string{"enduser.id"}, HMACKey: "secret"})
+	require.NoError(t, err)
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	ld.ResourceLogs().At(0).InstrumentationLibraryLogs().Resize(1)
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+	logs.At(0).Attributes().InsertString("enduser.id", "alice")
+
+	ld, err = a.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("alice"))
+	v, _ := logs.At(0).Attributes().Get("enduser.id")
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), v.StringVal())
+	assert.Equal(t, 0, logs.At(1).Attributes().Len())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	a, err := newAnonymizer(&Config{Keys: []string{"user_id"}})
+	require.NoError(t, err)
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Resize(1)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(1)
+	metrics.At(0).SetDataType(pdata.MetricDataTypeIntSum)
+	dps := metrics.At(0).IntSum().DataPoints()
+	dps.Resize(1)
+	dps.At(0).LabelsMap().InitFromMap(map[string]string{"user_id": "42", "region": "eu"})
+
+	md, err = a.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	v, _ := dps.At(0).LabelsMap().Get("user_id")
+	assert.Equal(t, sha256Hex("42"), v)
+	v, _ = dps.At(0).LabelsMap().Get("region")
+	assert.Equal(t, "eu", v)
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anonymizationprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Anonymization processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Keys are the keys of the attributes and labels whose values are hashed.
+	Keys []string `mapstructure:"keys"`
+
+	// Salt is prepended to the values before hashing them with SHA-256, to
+	// prevent the hashes of the known identifiers from being precomputed.
+	Salt string `mapstructure:"salt"`
+
+	// HMACKey is the secret key the HMAC-SHA256 of the values is computed with,
+	// instead of their salted SHA-256 hash. It can't be used with Salt.
+	HMACKey string `mapstructure:"hmac_key"`
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anonymizationprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Keys = []string{"enduser.id"}
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	assert.NotNil(t, tp)
+	assert.True(t, tp.GetCapabilities().MutatesConsumedData)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, mp)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, lp)
+}
+
+func TestCreateProcessors_InvalidConfig(t *testing.T) {
+	factory := NewFactory()
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+	assert.EqualError(t, err, `error creating "anonymization" processor: at least one key must be specified`)
+	assert.Nil(t, tp)
+
+	cfg.Keys = []string{"enduser.id"}
+	cfg.Salt = "salt"
+	cfg.HMACKey = "key"
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.EqualError(t, err, `error creating "anonymization" processor: salt and hmac_key can't be both specified`)
+	assert.Nil(t, lp)
+}
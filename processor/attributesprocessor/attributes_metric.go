@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/datapoint"
+	"go.opentelemetry.io/collector/internal/processor/filterdatapoint"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+type metricAttributesProcessor struct {
+	attrProc *processorhelper.AttrProc
+	include  filterdatapoint.Matcher
+	exclude  filterdatapoint.Matcher
+}
+
+// newMetricAttributesProcessor returns a processor that modifies the labels of
+// the metric data points. To construct the attributes processors, the use of
+// the factory methods are required in order to validate the inputs.
+func newMetricAttributesProcessor(attrProc *processorhelper.AttrProc, include, exclude filterdatapoint.Matcher) *metricAttributesProcessor {
+	return &metricAttributesProcessor{
+		attrProc: attrProc,
+		include:  include,
+		exclude:  exclude,
+	}
+}
+
+// ProcessMetrics implements the MProcessor
+func (a *metricAttributesProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.InstrumentationLibraryMetrics()
+		resource := rm.Resource()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			metrics := ilm.Metrics()
+			library := ilm.InstrumentationLibrary()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				datapoint.ForEachLabels(metric, func(labels pdata.StringMap) {
+					a.processLabels(metric, labels, resource, library)
+				})
+			}
+		}
+	}
+	return md, nil
+}
+
+// processLabels applies the actions to the labels of a data point. The labels
+// are converted to attributes for the actions to be applied, the resulting
+// attributes are then written back as labels with their values converted to
+// strings.
+func (a *metricAttributesProcessor) processLabels(metric pdata.Metric, labels pdata.StringMap, resource pdata.Resource, library pdata.InstrumentationLibrary) {
+	attrs := pdata.NewAttributeMap()
+	attrs.InitEmptyWithCapacity(labels.Len())
+	labels.ForEach(func(k string, v string) {
+		attrs.InsertString(k, v)
+	})
+
+	if a.skipDataPoint(metric, attrs, resource, library) {
+		return
+	}
+
+	a.attrProc.Process(attrs)
+
+	labels.InitEmptyWithCapacity(attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		labels.Insert(k, tracetranslator.AttributeValueToString(v, false))
+	})
+}
+
+// skipDataPoint determines if a data point should be processed.
+// True is returned when a data point should be skipped.
+// False is returned when a data point should not be skipped.
+// The logic determining if a data point should be processed is set
+// in the attribute configuration with the include and exclude settings.
+// Include properties are checked before exclude settings are checked.
+func (a *metricAttributesProcessor) skipDataPoint(metric pdata.Metric, labels pdata.AttributeMap, resource pdata.Resource, library pdata.InstrumentationLibrary) bool {
+	if a.include != nil {
+		// A false returned in this case means the data point should not be processed.
+		if include := a.include.MatchDataPoint(metric, labels, resource, library); !include {
+			return true
+		}
+	}
+
+	if a.exclude != nil {
+		// A true returned in this case means the data point should not be processed.
+		if exclude := a.exclude.MatchDataPoint(metric, labels, resource, library); exclude {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/datapoint"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// Common structure for all the Tests
+type metricTestCase struct {
+	name           string
+	inputLabels    map[string]string
+	expectedLabels map[string]string
+}
+
+// runIndividualMetricTestCase is the common logic of passing metric data through a configured attributes processor.
+func runIndividualMetricTestCase(t *testing.T, tt metricTestCase, mp component.MetricsProcessor) {
+	t.Run(tt.name, func(t *testing.T) {
+		md := generateMetricData(tt.name, tt.inputLabels)
+		assert.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+		// Ensure that the modified `md` has the labels sorted:
+		sortMetricLabels(md)
+		require.Equal(t, generateMetricData(tt.name, tt.expectedLabels), md)
+	})
+}
+
+func generateMetricData(metricName string, labels map[string]string) pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ilm := rm.InstrumentationLibraryMetrics().At(0)
+	metrics := ilm.Metrics()
+	metrics.Resize(1)
+	metric := metrics.At(0)
+	metric.SetName(metricName)
+	metric.SetDataType(pdata.MetricDataTypeIntSum)
+	metric.IntSum().DataPoints().Resize(1)
+	metric.IntSum().DataPoints().At(0).LabelsMap().InitFromMap(labels).Sort()
+	return md
+}
+
+func sortMetricLabels(md pdata.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				datapoint.ForEachLabels(metrics.At(k), func(labels pdata.StringMap) {
+					labels.Sort()
+				})
+			}
+		}
+	}
+}
+
+func TestMetricProcessor_NilEmptyData(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Settings.Actions = []processorhelper.ActionKeyValue{
+		{Key: "label1", Action: processorhelper.INSERT, Value: "value1"},
+		{Key: "label1", Action: processorhelper.DELETE},
+	}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, oCfg, consumertest.NewMetricsNop())
+	require.Nil(t, err)
+	require.NotNil(t, mp)
+
+	md := pdata.NewMetrics()
+	assert.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	assert.EqualValues(t, pdata.NewMetrics(), md)
+}
+
+func TestMetricAttributes_Actions(t *testing.T) {
+	testCases := []metricTestCase{
+		{
+			name: "insert_update_delete",
+			inputLabels: map[string]string{
+				"host":     "localhost",
+				"password": "secret",
+			},
+			expectedLabels: map[string]string{
+				"host":        "localhost.localdomain",
+				"environment": "production",
+				"port":        "8080",
+			},
+		},
+		{
+			name: "extract",
+			inputLabels: map[string]string{
+				"http.url": "http://example.com/path/user/123",
+			},
+			expectedLabels: map[string]string{
+				"http.url":    "http://example.com/path/user/123",
+				"environment": "production",
+				"port":        "8080",
+				"host":        "example.com",
+				"id":          "123",
+			},
+		},
+		{
+			name: "hash",
+			inputLabels: map[string]string{
+				"user.email": "john.doe@example.com",
+			},
+			expectedLabels: map[string]string{
+				"user.email":  "73ec53c4ba1747d485ae2a0d7bfafa6cda80a5a9",
+				"environment": "production",
+				"port":        "8080",
+			},
+		},
+	}
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []processorhelper.ActionKeyValue{
+		{Key: "environment", Action: processorhelper.INSERT, Value: "production"},
+		{Key: "port", Action: processorhelper.UPSERT, Value: 8080},
+		{Key: "host", Action: processorhelper.UPDATE, Value: "localhost.localdomain"},
+		{Key: "password", Action: processorhelper.DELETE},
+		{Key: "http.url", Action: processorhelper.EXTRACT, RegexPattern: `^(?P<scheme>.*):\/\/(?P<host>[^\/]*)\/path\/user\/(?P<id>.*)$`},
+		{Key: "scheme", Action: processorhelper.DELETE},
+		{Key: "user.email", Action: processorhelper.HASH},
+	}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewMetricsNop())
+	require.Nil(t, err)
+	require.NotNil(t, mp)
+
+	for _, tt := range testCases {
+		runIndividualMetricTestCase(t, tt, mp)
+	}
+}
+
+func TestMetricAttributes_Filter(t *testing.T) {
+	testCases := []metricTestCase{
+		{
+			name:        "http.server.duration",
+			inputLabels: map[string]string{},
+			expectedLabels: map[string]string{
+				"label1": "value1",
+			},
+		},
+		{
+			name: "http.client.duration",
+			inputLabels: map[string]string{
+				"NoModification": "false",
+			},
+			expectedLabels: map[string]string{
+				"label1":         "value1",
+				"NoModification": "false",
+			},
+		},
+		{
+			name:           "rpc.server.duration",
+			inputLabels:    map[string]string{},
+			expectedLabels: map[string]string{},
+		},
+		{
+			name: "http.server.requests",
+			inputLabels: map[string]string{
+				"NoModification": "true",
+			},
+			expectedLabels: map[string]string{
+				"NoModification": "true",
+			},
+		},
+	}
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []processorhelper.ActionKeyValue{
+		{Key: "label1", Action: processorhelper.INSERT, Value: "value1"},
+	}
+	oCfg.Include = &filterconfig.MatchProperties{
+		MetricNames: []string{"^http\\..*"},
+		Config:      *createConfig(filterset.Regexp),
+	}
+	oCfg.Exclude = &filterconfig.MatchProperties{
+		Attributes: []filterconfig.Attribute{
+			{Key: "NoModification", Value: "true"},
+		},
+		Config: *createConfig(filterset.Strict),
+	}
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewMetricsNop())
+	require.Nil(t, err)
+	require.NotNil(t, mp)
+
+	for _, tt := range testCases {
+		runIndividualMetricTestCase(t, tt, mp)
+	}
+}
@@ -234,4 +234,29 @@ func TestLoadingConfig(t *testing.T) {
 		},
 	})
 
+	p11 := cfg.Processors["attributes/metrics"]
+	assert.Equal(t, p11, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "attributes/metrics",
+			TypeVal: typeStr,
+		},
+		MatchConfig: filterconfig.MatchConfig{
+			Include: &filterconfig.MatchProperties{
+				Config:      *createConfig(filterset.Regexp),
+				MetricNames: []string{`http\..*`},
+			},
+			Exclude: &filterconfig.MatchProperties{
+				Config: *createConfig(filterset.Strict),
+				Attributes: []filterconfig.Attribute{
+					{Key: "internal", Value: "true"},
+				},
+			},
+		},
+		Settings: processorhelper.Settings{
+			Actions: []processorhelper.ActionKeyValue{
+				{Key: "http.url", Action: processorhelper.EXTRACT, RegexPattern: `^(?P<http_scheme>.*):\/\/(?P<http_host>[^\/]*)(?P<http_path>\/[^?]*)`},
+				{Key: "http.url", Action: processorhelper.DELETE},
+			},
+		},
+	})
 }
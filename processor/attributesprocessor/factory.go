@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/processor/filterdatapoint"
 	"go.opentelemetry.io/collector/internal/processor/filterlog"
 	"go.opentelemetry.io/collector/internal/processor/filterspan"
 	"go.opentelemetry.io/collector/processor/processorhelper"
@@ -39,6 +40,7 @@ func NewFactory() component.ProcessorFactory {
 		typeStr,
 		createDefaultConfig,
 		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
 		processorhelper.WithLogs(createLogProcessor))
 }
 
@@ -82,6 +84,36 @@ func createTraceProcessor(
 		processorhelper.WithCapabilities(processorCapabilities))
 }
 
+func createMetricsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.MetricsConsumer,
+) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if len(oCfg.Actions) == 0 {
+		return nil, fmt.Errorf("error creating \"attributes\" processor due to missing required field \"actions\" of processor %q", cfg.Name())
+	}
+	attrProc, err := processorhelper.NewAttrProc(&oCfg.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("error creating \"attributes\" processor: %w of processor %q", err, cfg.Name())
+	}
+	include, err := filterdatapoint.NewMatcher(oCfg.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := filterdatapoint.NewMatcher(oCfg.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		nextConsumer,
+		newMetricAttributesProcessor(attrProc, include, exclude),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
 func createLogProcessor(
 	_ context.Context,
 	_ component.ProcessorCreateParams,
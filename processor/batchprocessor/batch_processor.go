@@ -37,6 +37,7 @@ import (
 //
 // Batches are sent out with any of the following conditions:
 // - batch size reaches cfg.SendBatchSize
+// - batch size in bytes reaches cfg.SendBatchBytes
 // - cfg.Timeout is elapsed since the timestamp when the previous batch was sent out.
 type batchProcessor struct {
 	name           string
@@ -47,6 +48,15 @@ type batchProcessor struct {
 	timeout          time.Duration
 	sendBatchMaxSize uint32
 
+	sendBatchBytes    int
+	sendBatchMaxBytes int
+	// batchBytes is the approximate size in bytes of the current batch, only
+	// computed when batching on the size in bytes.
+	batchBytes int
+
+	// exportCtx is the context the batches are exported with.
+	exportCtx context.Context
+
 	timer   *time.Timer
 	done    chan struct{}
 	newItem chan interface{}
@@ -92,6 +102,10 @@ func newBatchProcessor(params component.ProcessorCreateParams, cfg *Config, batc
 		batch:            batch,
 		ctx:              ctx,
 		cancel:           cancel,
+
+		sendBatchBytes:    int(cfg.SendBatchBytes),
+		sendBatchMaxBytes: int(cfg.SendBatchMaxBytes),
+		exportCtx:         context.Background(),
 	}
 }
 
@@ -175,14 +189,58 @@ func (bp *batchProcessor) processItem(item interface{}) {
 		}
 	}
 
+	if bp.sendBatchMaxBytes > 0 {
+		item = bp.splitItemBytes(item)
+	}
+	if (bp.sendBatchBytes > 0 || bp.sendBatchMaxBytes > 0) && itemCount(item) > 0 {
+		bp.batchBytes += itemSizeBytes(item)
+	}
+
 	bp.batch.add(item)
 	if bp.batch.itemCount() >= bp.sendBatchSize {
 		bp.timer.Stop()
 		bp.sendItems(statBatchSizeTriggerSend)
 		bp.resetTimer()
+	} else if bp.sendBatchBytes > 0 && bp.batchBytes >= bp.sendBatchBytes {
+		bp.timer.Stop()
+		bp.sendItems(statBatchBytesTriggerSend)
+		bp.resetTimer()
 	}
 }
 
+// splitItemBytes returns the part of the item which can be added to the current batch
+// without exceeding sendBatchMaxBytes, and sends the rest back to be processed later.
+// The current batch is sent first if the item doesn't fit in it.
+func (bp *batchProcessor) splitItemBytes(item interface{}) interface{} {
+	itemBytes := itemSizeBytes(item)
+	if bp.batchBytes+itemBytes <= bp.sendBatchMaxBytes {
+		return item
+	}
+	if bp.batch.itemCount() > 0 {
+		bp.timer.Stop()
+		bp.sendItems(statBatchBytesTriggerSend)
+		bp.resetTimer()
+		if itemBytes <= bp.sendBatchMaxBytes {
+			return item
+		}
+	}
+
+	// Assume the items have similar sizes to find how many fit in a batch.
+	count := itemCount(item)
+	size := int(int64(count) * int64(bp.sendBatchMaxBytes) / int64(itemBytes))
+	if size < 1 {
+		size = 1
+	}
+	if size >= count {
+		return item
+	}
+	itemRemainSize := splitItem(size, item)
+	go func() {
+		bp.newItem <- item
+	}()
+	return itemRemainSize
+}
+
 func (bp *batchProcessor) resetTimer() {
 	bp.timer.Reset(bp.timeout)
 }
@@ -196,10 +254,52 @@ func (bp *batchProcessor) sendItems(measure *stats.Int64Measure) {
 		_ = stats.RecordWithTags(context.Background(), statsTags, statBatchSendSizeBytes.M(int64(bp.batch.size())))
 	}
 
-	if err := bp.batch.export(context.Background()); err != nil {
+	if err := bp.batch.export(bp.exportCtx); err != nil {
 		bp.logger.Warn("Sender failed", zap.Error(err))
 	}
 	bp.batch.reset()
+	bp.batchBytes = 0
+}
+
+// itemCount returns the number of spans, metrics or log records of the item.
+func itemCount(item interface{}) int {
+	switch data := item.(type) {
+	case pdata.Traces:
+		return data.SpanCount()
+	case pdata.Metrics:
+		return data.MetricCount()
+	case pdata.Logs:
+		return data.LogRecordCount()
+	}
+	return 0
+}
+
+// itemSizeBytes returns the size in bytes of the item. The sum of the sizes of
+// the items added to a batch approximates the size of the batch.
+func itemSizeBytes(item interface{}) int {
+	switch data := item.(type) {
+	case pdata.Traces:
+		return data.Size()
+	case pdata.Metrics:
+		return data.Size()
+	case pdata.Logs:
+		return data.SizeBytes()
+	}
+	return 0
+}
+
+// splitItem removes the given number of spans, metrics or log records from the
+// item and returns them.
+func splitItem(size int, item interface{}) interface{} {
+	switch data := item.(type) {
+	case pdata.Traces:
+		return splitTrace(size, data)
+	case pdata.Metrics:
+		return splitMetrics(size, data)
+	case pdata.Logs:
+		return splitLogs(size, data)
+	}
+	return item
 }
 
 // ConsumeTraces implements TracesProcessor
@@ -115,6 +115,78 @@ func TestBatchProcessorSpansDeliveredEnforceBatchSize(t *testing.T) {
 	assert.Equal(t, (requestCount*spansPerRequest)%int(cfg.SendBatchSize), sink.AllTraces()[len(sink.AllTraces())-1].SpanCount())
 }
 
+func TestBatchProcessorSpansDeliveredEnforceBatchBytes(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	spansPerRequest := 100
+	requestSize := testdata.GenerateTraceDataManySpansSameResource(spansPerRequest).Size()
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchMaxBytes = uint32(requestSize / 2)
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 100
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		td := testdata.GenerateTraceDataManySpansSameResource(spansPerRequest)
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	}
+
+	// wait for all spans to be reported
+	for {
+		if sink.SpansCount() == requestCount*spansPerRequest {
+			break
+		}
+		<-time.After(cfg.Timeout)
+	}
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, requestCount*spansPerRequest, sink.SpansCount())
+	for _, td := range sink.AllTraces() {
+		assert.LessOrEqual(t, td.SpanCount(), spansPerRequest/2)
+	}
+}
+
+func TestBatchProcessorSentByBytes(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	sink := new(consumertest.TracesSink)
+	spansPerRequest := 10
+	requestSize := testdata.GenerateTraceDataManySpansSameResource(spansPerRequest).Size()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 500 * time.Millisecond
+	cfg.SendBatchBytes = uint32(10 * requestSize)
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 100
+	start := time.Now()
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		td := testdata.GenerateTraceDataManySpansSameResource(spansPerRequest)
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	elapsed := time.Since(start)
+	require.LessOrEqual(t, elapsed.Nanoseconds(), cfg.Timeout.Nanoseconds())
+
+	require.Equal(t, requestCount*spansPerRequest, sink.SpansCount())
+	receivedTraces := sink.AllTraces()
+	require.Equal(t, requestCount/10, len(receivedTraces))
+	for _, td := range receivedTraces {
+		assert.Equal(t, 10*spansPerRequest, td.SpanCount())
+		assert.Equal(t, 10*requestSize, td.Size())
+	}
+
+	viewData, err := view.RetrieveData("processor/batch/" + statBatchBytesTriggerSend.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	assert.Equal(t, float64(requestCount/10), viewData[0].Data.(*view.SumData).Value)
+}
+
 func TestBatchProcessorSentBySize(t *testing.T) {
 	views := MetricViews()
 	require.NoError(t, view.Register(views...))
@@ -596,6 +668,38 @@ func TestBatchLogProcessor_BatchSize(t *testing.T) {
 	assert.Equal(t, size, int(distData.Sum()))
 }
 
+func TestBatchLogProcessor_BatchBytes(t *testing.T) {
+	logsPerRequest := 5
+	requestSize := testdata.GenerateLogDataManyLogsSameResource(logsPerRequest).SizeBytes()
+	cfg := Config{
+		Timeout:           100 * time.Millisecond,
+		SendBatchSize:     8192,
+		SendBatchBytes:    uint32(4 * requestSize),
+		SendBatchMaxBytes: uint32(5 * requestSize / 2),
+	}
+
+	requestCount := 100
+	sink := new(consumertest.LogsSink)
+
+	createParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchLogsProcessor(createParams, sink, &cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		ld := testdata.GenerateLogDataManyLogsSameResource(logsPerRequest)
+		assert.NoError(t, batcher.ConsumeLogs(context.Background(), ld))
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	// The maximum size in bytes is reached before the size in bytes.
+	require.Equal(t, requestCount*logsPerRequest, sink.LogRecordsCount())
+	receivedLds := sink.AllLogs()
+	require.Equal(t, requestCount/2, len(receivedLds))
+	for _, ld := range receivedLds {
+		assert.Equal(t, 2*logsPerRequest, ld.LogRecordCount())
+	}
+}
+
 func TestBatchLogsProcessor_Timeout(t *testing.T) {
 	cfg := Config{
 		Timeout:       100 * time.Millisecond,
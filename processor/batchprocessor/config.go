@@ -33,4 +33,27 @@ type Config struct {
 	// SendBatchMaxSize is the maximum size of a batch. Larger batches are split into smaller units.
 	// Default value is 0, that means no maximum size.
 	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size,omitempty"`
+
+	// SendBatchBytes is the approximate size in bytes of a batch which after hit, will trigger it to be sent.
+	// Default value is 0, that means batches are only sent based on their number of items.
+	SendBatchBytes uint32 `mapstructure:"send_batch_bytes,omitempty"`
+
+	// SendBatchMaxBytes is the approximate maximum size in bytes of a batch. Larger batches are split into smaller units.
+	// Default value is 0, that means no maximum size in bytes.
+	SendBatchMaxBytes uint32 `mapstructure:"send_batch_max_bytes,omitempty"`
+
+	// MetadataKeys are the keys of the metadata of the inbound requests whose values are batched separately,
+	// e.g. a tenant header. The batches are exported with a context holding these metadata.
+	// Default value is empty, that means the data of all the requests is batched together.
+	MetadataKeys []string `mapstructure:"metadata_keys,omitempty"`
+
+	// MetadataCardinalityLimit is the maximum number of combinations of the values of the metadata keys
+	// batched separately. The data with new combinations is refused when the limit is reached.
+	// Value 0 means no limit.
+	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit,omitempty"`
+
+	// MetadataIdleTimeout is the time after which the batching of a combination of values of the metadata keys
+	// which received no data is stopped, its current batch being sent, so that it no longer counts toward the
+	// cardinality limit. Value 0 means the batching of a combination of values is never stopped.
+	MetadataIdleTimeout time.Duration `mapstructure:"metadata_idle_timeout,omitempty"`
 }
@@ -53,8 +53,39 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "batch",
 				NameVal: "batch/2",
 			},
-			SendBatchSize:    sendBatchSize,
-			SendBatchMaxSize: sendBatchMaxSize,
-			Timeout:          timeout,
+			SendBatchSize:            sendBatchSize,
+			SendBatchMaxSize:         sendBatchMaxSize,
+			Timeout:                  timeout,
+			MetadataCardinalityLimit: defaultMetadataCardinalityLimit,
+			MetadataIdleTimeout:      defaultMetadataIdleTimeout,
+		})
+
+	p2 := cfg.Processors["batch/bytes"]
+	assert.Equal(t, p2,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "batch",
+				NameVal: "batch/bytes",
+			},
+			SendBatchSize:            defaultSendBatchSize,
+			Timeout:                  defaultTimeout,
+			SendBatchBytes:           1048576,
+			SendBatchMaxBytes:        4194304,
+			MetadataCardinalityLimit: defaultMetadataCardinalityLimit,
+			MetadataIdleTimeout:      defaultMetadataIdleTimeout,
+		})
+
+	p3 := cfg.Processors["batch/tenant"]
+	assert.Equal(t, p3,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "batch",
+				NameVal: "batch/tenant",
+			},
+			SendBatchSize:            defaultSendBatchSize,
+			Timeout:                  defaultTimeout,
+			MetadataKeys:             []string{"x-tenant"},
+			MetadataCardinalityLimit: 100,
+			MetadataIdleTimeout:      time.Minute,
 		})
 }
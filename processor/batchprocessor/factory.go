@@ -29,8 +29,10 @@ const (
 	// The value of "type" key in configuration.
 	typeStr = "batch"
 
-	defaultSendBatchSize = uint32(8192)
-	defaultTimeout       = 200 * time.Millisecond
+	defaultSendBatchSize            = uint32(8192)
+	defaultTimeout                  = 200 * time.Millisecond
+	defaultMetadataCardinalityLimit = uint32(1000)
+	defaultMetadataIdleTimeout      = 5 * time.Minute
 )
 
 // NewFactory returns a new factory for the Batch processor.
@@ -49,8 +51,10 @@ func createDefaultConfig() configmodels.Processor {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
-		SendBatchSize: defaultSendBatchSize,
-		Timeout:       defaultTimeout,
+		SendBatchSize:            defaultSendBatchSize,
+		Timeout:                  defaultTimeout,
+		MetadataCardinalityLimit: defaultMetadataCardinalityLimit,
+		MetadataIdleTimeout:      defaultMetadataIdleTimeout,
 	}
 }
 
@@ -62,6 +66,9 @@ func createTraceProcessor(
 ) (component.TracesProcessor, error) {
 	oCfg := cfg.(*Config)
 	level := configtelemetry.GetMetricsLevelFlagValue()
+	if len(oCfg.MetadataKeys) > 0 {
+		return newMetadataBatchProcessor(params, oCfg, func() batch { return newBatchTraces(nextConsumer) }, level), nil
+	}
 	return newBatchTracesProcessor(params, nextConsumer, oCfg, level), nil
 }
 
@@ -73,6 +80,9 @@ func createMetricsProcessor(
 ) (component.MetricsProcessor, error) {
 	oCfg := cfg.(*Config)
 	level := configtelemetry.GetMetricsLevelFlagValue()
+	if len(oCfg.MetadataKeys) > 0 {
+		return newMetadataBatchProcessor(params, oCfg, func() batch { return newBatchMetrics(nextConsumer) }, level), nil
+	}
 	return newBatchMetricsProcessor(params, nextConsumer, oCfg, level), nil
 }
 
@@ -84,5 +94,8 @@ func createLogsProcessor(
 ) (component.LogsProcessor, error) {
 	oCfg := cfg.(*Config)
 	level := configtelemetry.GetMetricsLevelFlagValue()
+	if len(oCfg.MetadataKeys) > 0 {
+		return newMetadataBatchProcessor(params, oCfg, func() batch { return newBatchLogs(nextConsumer) }, level), nil
+	}
 	return newBatchLogsProcessor(params, nextConsumer, oCfg, level), nil
 }
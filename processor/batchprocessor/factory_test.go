@@ -50,3 +50,22 @@ func TestCreateProcessor(t *testing.T) {
 	assert.NotNil(t, lp)
 	assert.NoError(t, err, "cannot create logs processor")
 }
+
+func TestCreateProcessor_MetadataKeys(t *testing.T) {
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.MetadataKeys = []string{"x-tenant"}
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	tp, err := factory.CreateTracesProcessor(context.Background(), creationParams, cfg, nil)
+	assert.NoError(t, err, "cannot create trace processor")
+	assert.IsType(t, &metadataBatchProcessor{}, tp)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), creationParams, cfg, nil)
+	assert.NoError(t, err, "cannot create metric processor")
+	assert.IsType(t, &metadataBatchProcessor{}, mp)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), creationParams, cfg, nil)
+	assert.NoError(t, err, "cannot create logs processor")
+	assert.IsType(t, &metadataBatchProcessor{}, lp)
+}
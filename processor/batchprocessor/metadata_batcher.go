@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// errTooManyBatchers is returned when the data has a new combination of metadata values
+// while the cardinality limit is reached.
+var errTooManyBatchers = errors.New("too many batcher metadata-value combinations")
+
+// metadataBatchProcessor is a batch processor batching separately the data of the inbound
+// requests having different values for the configured metadata keys. Each combination of
+// values has its own batchProcessor, exporting the batches with a context holding these values.
+// The batchProcessors not used for metadata_idle_timeout are shut down, so the cardinality
+// limit bounds the number of the combinations of values active at the same time.
+type metadataBatchProcessor struct {
+	params         component.ProcessorCreateParams
+	cfg            *Config
+	telemetryLevel configtelemetry.Level
+
+	// newBatch creates the batch of a new batchProcessor.
+	newBatch func() batch
+
+	metadataKeys     []string
+	cardinalityLimit int
+	idleTimeout      time.Duration
+	now              func() time.Time
+
+	lock     sync.Mutex
+	batchers map[string]*metadataBatcher
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// metadataBatcher is the batchProcessor of a combination of metadata values.
+type metadataBatcher struct {
+	*batchProcessor
+	// inFlight is the number of requests being added to the batcher, which is not
+	// evicted until they are added.
+	inFlight int
+	lastUsed time.Time
+}
+
+var _ consumer.TracesConsumer = (*metadataBatchProcessor)(nil)
+var _ consumer.MetricsConsumer = (*metadataBatchProcessor)(nil)
+var _ consumer.LogsConsumer = (*metadataBatchProcessor)(nil)
+
+func newMetadataBatchProcessor(params component.ProcessorCreateParams, cfg *Config, newBatch func() batch, telemetryLevel configtelemetry.Level) *metadataBatchProcessor {
+	// The metadata keys of the inbound requests are lower case.
+	metadataKeys := make([]string, len(cfg.MetadataKeys))
+	for i, key := range cfg.MetadataKeys {
+		metadataKeys[i] = strings.ToLower(key)
+	}
+	return &metadataBatchProcessor{
+		params:           params,
+		cfg:              cfg,
+		telemetryLevel:   telemetryLevel,
+		newBatch:         newBatch,
+		metadataKeys:     metadataKeys,
+		cardinalityLimit: int(cfg.MetadataCardinalityLimit),
+		idleTimeout:      cfg.MetadataIdleTimeout,
+		now:              time.Now,
+		batchers:         make(map[string]*metadataBatcher),
+		done:             make(chan struct{}),
+	}
+}
+
+func (mbp *metadataBatchProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: true}
+}
+
+// Start is invoked during service startup. The batchers are started when they are created.
+func (mbp *metadataBatchProcessor) Start(context.Context, component.Host) error {
+	if mbp.idleTimeout > 0 {
+		mbp.wg.Add(1)
+		go mbp.startEvicting()
+	}
+	return nil
+}
+
+// Shutdown is invoked during service shutdown.
+func (mbp *metadataBatchProcessor) Shutdown(ctx context.Context) error {
+	close(mbp.done)
+	mbp.wg.Wait()
+
+	mbp.lock.Lock()
+	defer mbp.lock.Unlock()
+
+	for _, mb := range mbp.batchers {
+		if err := mb.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startEvicting shuts down the idle batchers every idle timeout.
+func (mbp *metadataBatchProcessor) startEvicting() {
+	defer mbp.wg.Done()
+	ticker := time.NewTicker(mbp.idleTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			mbp.evictIdle()
+		case <-mbp.done:
+			return
+		}
+	}
+}
+
+// evictIdle shuts down the batchers not used for the idle timeout, exporting their
+// current batch.
+func (mbp *metadataBatchProcessor) evictIdle() {
+	var idle []*metadataBatcher
+	mbp.lock.Lock()
+	now := mbp.now()
+	for key, mb := range mbp.batchers {
+		if mb.inFlight == 0 && now.Sub(mb.lastUsed) >= mbp.idleTimeout {
+			idle = append(idle, mb)
+			delete(mbp.batchers, key)
+		}
+	}
+	mbp.lock.Unlock()
+
+	for _, mb := range idle {
+		_ = mb.Shutdown(context.Background())
+	}
+}
+
+// batcher returns the batcher of the metadata values of the inbound request, creating
+// it on the first request with these values. The batcher must be released once the data
+// is added to it.
+func (mbp *metadataBatchProcessor) batcher(ctx context.Context) (*metadataBatcher, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	exportMd := metadata.MD{}
+	var key strings.Builder
+	for _, k := range mbp.metadataKeys {
+		values := md.Get(k)
+		if len(values) > 0 {
+			exportMd[k] = values
+		}
+		// Separate the keys and the values with characters which are not allowed in metadata.
+		key.WriteString(k)
+		for _, v := range values {
+			key.WriteByte(0)
+			key.WriteString(v)
+		}
+		key.WriteByte('\n')
+	}
+
+	mbp.lock.Lock()
+	defer mbp.lock.Unlock()
+
+	mb, ok := mbp.batchers[key.String()]
+	if ok {
+		mb.inFlight++
+		return mb, nil
+	}
+	if mbp.cardinalityLimit > 0 && len(mbp.batchers) >= mbp.cardinalityLimit {
+		return nil, errTooManyBatchers
+	}
+	bp := newBatchProcessor(mbp.params, mbp.cfg, mbp.newBatch(), mbp.telemetryLevel)
+	bp.exportCtx = metadata.NewIncomingContext(context.Background(), exportMd)
+	if err := bp.Start(ctx, nil); err != nil {
+		return nil, err
+	}
+	mb = &metadataBatcher{batchProcessor: bp, inFlight: 1}
+	mbp.batchers[key.String()] = mb
+	return mb, nil
+}
+
+// release marks the data as added to the batcher.
+func (mbp *metadataBatchProcessor) release(mb *metadataBatcher) {
+	mbp.lock.Lock()
+	defer mbp.lock.Unlock()
+	mb.inFlight--
+	mb.lastUsed = mbp.now()
+}
+
+// ConsumeTraces implements TracesProcessor
+func (mbp *metadataBatchProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	mb, err := mbp.batcher(ctx)
+	if err != nil {
+		return err
+	}
+	defer mbp.release(mb)
+	return mb.ConsumeTraces(ctx, td)
+}
+
+// ConsumeMetrics implements MetricsProcessor
+func (mbp *metadataBatchProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	mb, err := mbp.batcher(ctx)
+	if err != nil {
+		return err
+	}
+	defer mbp.release(mb)
+	return mb.ConsumeMetrics(ctx, md)
+}
+
+// ConsumeLogs implements LogsProcessor
+func (mbp *metadataBatchProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	mb, err := mbp.batcher(ctx)
+	if err != nil {
+		return err
+	}
+	defer mbp.release(mb)
+	return mb.ConsumeLogs(ctx, ld)
+}
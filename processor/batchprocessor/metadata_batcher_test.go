@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+// tenantTracesSink stores the number of spans received for each value of the "x-tenant"
+// metadata of the export context.
+type tenantTracesSink struct {
+	mu      sync.Mutex
+	batches int
+	spans   map[string]int
+}
+
+func (s *tenantTracesSink) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tenant := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-tenant")) > 0 {
+		tenant = md.Get("x-tenant")[0]
+	}
+	s.batches++
+	s.spans[tenant] += td.SpanCount()
+	return nil
+}
+
+func newTenantContext(tenant string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", tenant, "x-other", tenant))
+}
+
+func TestMetadataBatchProcessor(t *testing.T) {
+	sink := &tenantTracesSink{spans: make(map[string]int)}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 100
+	cfg.MetadataKeys = []string{"X-Tenant"}
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newMetadataBatchProcessor(creationParams, cfg, func() batch { return newBatchTraces(sink) }, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 100
+	spansPerRequest := 10
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		ctx := newTenantContext("tenant-a")
+		if requestNum%4 == 0 {
+			ctx = newTenantContext("tenant-b")
+		}
+		assert.NoError(t, batcher.ConsumeTraces(ctx, testdata.GenerateTraceDataManySpansSameResource(spansPerRequest)))
+	}
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(spansPerRequest)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Len(t, batcher.batchers, 3)
+	assert.Equal(t, map[string]int{
+		"tenant-a": 750,
+		"tenant-b": 250,
+		"":         10,
+	}, sink.spans)
+	// tenant-a has 7 full batches and 1 partial one, tenant-b has 2 full batches and 1 partial one.
+	assert.Equal(t, 12, sink.batches)
+}
+
+func TestMetadataBatchProcessor_CardinalityLimit(t *testing.T) {
+	sink := &tenantTracesSink{spans: make(map[string]int)}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Second
+	cfg.MetadataKeys = []string{"x-tenant"}
+	cfg.MetadataCardinalityLimit = 1
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newMetadataBatchProcessor(creationParams, cfg, func() batch { return newBatchTraces(sink) }, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.NoError(t, batcher.ConsumeTraces(newTenantContext("tenant-a"), testdata.GenerateTraceDataManySpansSameResource(1)))
+	assert.NoError(t, batcher.ConsumeTraces(newTenantContext("tenant-a"), testdata.GenerateTraceDataManySpansSameResource(1)))
+	assert.Equal(t, errTooManyBatchers, batcher.ConsumeTraces(newTenantContext("tenant-b"), testdata.GenerateTraceDataManySpansSameResource(1)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Equal(t, map[string]int{"tenant-a": 2}, sink.spans)
+	assert.Equal(t, 1, sink.batches)
+}
+
+func TestMetadataBatchProcessor_IdleTimeout(t *testing.T) {
+	sink := &tenantTracesSink{spans: make(map[string]int)}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Hour
+	cfg.MetadataKeys = []string{"x-tenant"}
+	cfg.MetadataCardinalityLimit = 1
+	cfg.MetadataIdleTimeout = time.Minute
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newMetadataBatchProcessor(creationParams, cfg, func() batch { return newBatchTraces(sink) }, configtelemetry.LevelDetailed)
+	now := time.Unix(1000, 0)
+	batcher.now = func() time.Time { return now }
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.NoError(t, batcher.ConsumeTraces(newTenantContext("tenant-a"), testdata.GenerateTraceDataManySpansSameResource(1)))
+	assert.Equal(t, errTooManyBatchers, batcher.ConsumeTraces(newTenantContext("tenant-b"), testdata.GenerateTraceDataManySpansSameResource(1)))
+
+	// tenant-a is not idle yet.
+	now = now.Add(59 * time.Second)
+	batcher.evictIdle()
+	assert.Len(t, batcher.batchers, 1)
+	assert.Equal(t, errTooManyBatchers, batcher.ConsumeTraces(newTenantContext("tenant-b"), testdata.GenerateTraceDataManySpansSameResource(1)))
+
+	// Once idle, the batch of tenant-a is sent and tenant-b is accepted.
+	now = now.Add(time.Second)
+	batcher.evictIdle()
+	assert.Len(t, batcher.batchers, 0)
+	sink.mu.Lock()
+	assert.Equal(t, map[string]int{"tenant-a": 1}, sink.spans)
+	sink.mu.Unlock()
+	assert.NoError(t, batcher.ConsumeTraces(newTenantContext("tenant-b"), testdata.GenerateTraceDataManySpansSameResource(1)))
+
+	// The batcher of tenant-a is created again once tenant-b is idle.
+	now = now.Add(time.Minute)
+	batcher.evictIdle()
+	assert.NoError(t, batcher.ConsumeTraces(newTenantContext("tenant-a"), testdata.GenerateTraceDataManySpansSameResource(1)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Equal(t, map[string]int{"tenant-a": 2, "tenant-b": 1}, sink.spans)
+	assert.Equal(t, 3, sink.batches)
+}
+
+func TestMetadataBatchProcessor_InFlightNotEvicted(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetadataKeys = []string{"x-tenant"}
+	cfg.MetadataIdleTimeout = time.Minute
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	sink := &tenantTracesSink{spans: make(map[string]int)}
+	batcher := newMetadataBatchProcessor(creationParams, cfg, func() batch { return newBatchTraces(sink) }, configtelemetry.LevelDetailed)
+	now := time.Unix(1000, 0)
+	batcher.now = func() time.Time { return now }
+
+	// The data being added to the batcher keeps it, however long it takes.
+	mb, err := batcher.batcher(newTenantContext("tenant-a"))
+	require.NoError(t, err)
+	now = now.Add(time.Hour)
+	batcher.evictIdle()
+	assert.Len(t, batcher.batchers, 1)
+
+	batcher.release(mb)
+	batcher.evictIdle()
+	assert.Len(t, batcher.batchers, 1)
+	now = now.Add(time.Minute)
+	batcher.evictIdle()
+	assert.Len(t, batcher.batchers, 0)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
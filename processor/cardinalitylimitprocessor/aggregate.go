@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/series"
+)
+
+// dataPointKey identifies the data points aggregated together: the data points
+// with the same labels and timestamp.
+func dataPointKey(labels pdata.StringMap, timestamp pdata.Timestamp) string {
+	return series.LabelsKey(labels) + "\x00" + strconv.FormatUint(uint64(timestamp), 10)
+}
+
+// aggregateDataPoints sums the data points which have the same labels and
+// timestamp after their labels were replaced or stripped. The histograms are
+// summed if they have the same buckets, and the summaries are left unchanged.
+func aggregateDataPoints(metric pdata.Metric) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		aggregateIntDataPoints(metric.IntGauge().DataPoints())
+	case pdata.MetricDataTypeDoubleGauge:
+		aggregateDoubleDataPoints(metric.DoubleGauge().DataPoints())
+	case pdata.MetricDataTypeIntSum:
+		aggregateIntDataPoints(metric.IntSum().DataPoints())
+	case pdata.MetricDataTypeDoubleSum:
+		aggregateDoubleDataPoints(metric.DoubleSum().DataPoints())
+	case pdata.MetricDataTypeIntHistogram:
+		aggregateIntHistogramDataPoints(metric.IntHistogram().DataPoints())
+	case pdata.MetricDataTypeDoubleHistogram:
+		aggregateDoubleHistogramDataPoints(metric.DoubleHistogram().DataPoints())
+	}
+}
+
+func aggregateIntDataPoints(dps pdata.IntDataPointSlice) {
+	index := make(map[string]int, dps.Len())
+	aggregated := pdata.NewIntDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := dataPointKey(dp.LabelsMap(), dp.Timestamp())
+		j, ok := index[key]
+		if !ok {
+			index[key] = aggregated.Len()
+			aggregated.Append(dp)
+			continue
+		}
+
+		agg := aggregated.At(j)
+		agg.SetValue(agg.Value() + dp.Value())
+		if dp.StartTime() < agg.StartTime() {
+			agg.SetStartTime(dp.StartTime())
+		}
+	}
+	dps.Resize(0)
+	aggregated.MoveAndAppendTo(dps)
+}
+
+func aggregateDoubleDataPoints(dps pdata.DoubleDataPointSlice) {
+	index := make(map[string]int, dps.Len())
+	aggregated := pdata.NewDoubleDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := dataPointKey(dp.LabelsMap(), dp.Timestamp())
+		j, ok := index[key]
+		if !ok {
+			index[key] = aggregated.Len()
+			aggregated.Append(dp)
+			continue
+		}
+
+		agg := aggregated.At(j)
+		agg.SetValue(agg.Value() + dp.Value())
+		if dp.StartTime() < agg.StartTime() {
+			agg.SetStartTime(dp.StartTime())
+		}
+	}
+	dps.Resize(0)
+	aggregated.MoveAndAppendTo(dps)
+}
+
+func aggregateIntHistogramDataPoints(dps pdata.IntHistogramDataPointSlice) {
+	index := make(map[string]int, dps.Len())
+	aggregated := pdata.NewIntHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := dataPointKey(dp.LabelsMap(), dp.Timestamp()) + fmt.Sprint(dp.ExplicitBounds())
+		j, ok := index[key]
+		if !ok {
+			index[key] = aggregated.Len()
+			aggregated.Append(dp)
+			continue
+		}
+
+		agg := aggregated.At(j)
+		agg.SetCount(agg.Count() + dp.Count())
+		agg.SetSum(agg.Sum() + dp.Sum())
+		agg.SetBucketCounts(series.AddBucketCounts(agg.BucketCounts(), dp.BucketCounts()))
+		if dp.StartTime() < agg.StartTime() {
+			agg.SetStartTime(dp.StartTime())
+		}
+	}
+	dps.Resize(0)
+	aggregated.MoveAndAppendTo(dps)
+}
+
+func aggregateDoubleHistogramDataPoints(dps pdata.DoubleHistogramDataPointSlice) {
+	index := make(map[string]int, dps.Len())
+	aggregated := pdata.NewDoubleHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := dataPointKey(dp.LabelsMap(), dp.Timestamp()) + fmt.Sprint(dp.ExplicitBounds())
+		j, ok := index[key]
+		if !ok {
+			index[key] = aggregated.Len()
+			aggregated.Append(dp)
+			continue
+		}
+
+		agg := aggregated.At(j)
+		agg.SetCount(agg.Count() + dp.Count())
+		agg.SetSum(agg.Sum() + dp.Sum())
+		agg.SetBucketCounts(series.AddBucketCounts(agg.BucketCounts(), dp.BucketCounts()))
+		if dp.StartTime() < agg.StartTime() {
+			agg.SetStartTime(dp.StartTime())
+		}
+	}
+	dps.Resize(0)
+	aggregated.MoveAndAppendTo(dps)
+}
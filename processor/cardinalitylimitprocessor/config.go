@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Action is what the processor does with the data points of the new series
+// beyond the limit.
+type Action string
+
+const (
+	// DropAction drops the data points.
+	DropAction Action = "drop"
+	// OverflowAction replaces the labels of the data points with the overflow
+	// label, aggregating them into a single overflow series per resource.
+	OverflowAction Action = "overflow"
+	// StripLabelsAction removes the configured labels from the data points,
+	// aggregating them into the series without the high cardinality labels.
+	StripLabelsAction Action = "strip_labels"
+)
+
+// Config defines configuration for Cardinality Limit processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Limit is the maximum number of active series of each metric. The series
+	// are identified by the resource attributes and the labels of their data
+	// points.
+	Limit int `mapstructure:"limit"`
+
+	// Action is "drop", "overflow" or "strip_labels". If not set, the data points
+	// of the series beyond the limit are dropped.
+	Action Action `mapstructure:"action"`
+
+	// OverflowLabel is the key of the label set to "true" on the overflow series,
+	// used by the "overflow" action.
+	OverflowLabel string `mapstructure:"overflow_label"`
+
+	// StripLabels are the keys of the labels removed by the "strip_labels" action.
+	StripLabels []string `mapstructure:"strip_labels"`
+
+	// ExpireAfter is the period after which the series which haven't received
+	// data points are no longer active.
+	ExpireAfter time.Duration `mapstructure:"expire_after"`
+}
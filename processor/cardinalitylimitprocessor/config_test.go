@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "cardinalitylimit",
+			NameVal: "cardinalitylimit",
+		},
+		Limit:         1000,
+		Action:        DropAction,
+		OverflowLabel: "otel_metric_overflow",
+		ExpireAfter:   5 * time.Minute,
+	}, cfg.Processors["cardinalitylimit"])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "cardinalitylimit",
+			NameVal: "cardinalitylimit/overflow",
+		},
+		Limit:         500,
+		Action:        OverflowAction,
+		OverflowLabel: "overflow",
+		ExpireAfter:   10 * time.Minute,
+	}, cfg.Processors["cardinalitylimit/overflow"])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "cardinalitylimit",
+			NameVal: "cardinalitylimit/strip",
+		},
+		Limit:         100,
+		Action:        StripLabelsAction,
+		OverflowLabel: "otel_metric_overflow",
+		StripLabels:   []string{"user_id", "http.url"},
+		ExpireAfter:   5 * time.Minute,
+	}, cfg.Processors["cardinalitylimit/strip"])
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "cardinalitylimit"
+
+	defaultOverflowLabel = "otel_metric_overflow"
+	defaultExpireAfter   = 5 * time.Minute
+)
+
+var (
+	errNonPositiveLimit       = errors.New("limit must be positive")
+	errInvalidAction          = errors.New("action must be \"drop\", \"overflow\" or \"strip_labels\"")
+	errNoOverflowLabel        = errors.New("missing required field \"overflow_label\"")
+	errNoStripLabels          = errors.New("missing required field \"strip_labels\"")
+	errNonPositiveExpireAfter = errors.New("expire_after must be positive")
+)
+
+// NewFactory returns a new factory for the Cardinality Limit processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithMetrics(createMetricsProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Action:        DropAction,
+		OverflowLabel: defaultOverflowLabel,
+		ExpireAfter:   defaultExpireAfter,
+	}
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if err := validateConfig(oCfg); err != nil {
+		return nil, fmt.Errorf("error creating %q processor: %w", cfg.Name(), err)
+	}
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		nextConsumer,
+		newCardinalityLimitProcessor(oCfg),
+		processorhelper.WithCapabilities(component.ProcessorCapabilities{MutatesConsumedData: true}))
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.Limit <= 0 {
+		return errNonPositiveLimit
+	}
+	switch cfg.Action {
+	case DropAction:
+	case OverflowAction:
+		if cfg.OverflowLabel == "" {
+			return errNoOverflowLabel
+		}
+	case StripLabelsAction:
+		if len(cfg.StripLabels) == 0 {
+			return errNoStripLabels
+		}
+	default:
+		return errInvalidAction
+	}
+	if cfg.ExpireAfter <= 0 {
+		return errNonPositiveExpireAfter
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Limit = 100
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, mp)
+	assert.True(t, mp.GetCapabilities().MutatesConsumedData)
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	assert.Nil(t, tp)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	assert.Nil(t, lp)
+}
+
+func TestCreateProcessors_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    error
+	}{
+		{
+			name:   "no limit",
+			modify: func(cfg *Config) { cfg.Limit = 0 },
+			err:    errNonPositiveLimit,
+		},
+		{
+			name:   "invalid action",
+			modify: func(cfg *Config) { cfg.Action = "sample" },
+			err:    errInvalidAction,
+		},
+		{
+			name: "no overflow label",
+			modify: func(cfg *Config) {
+				cfg.Action = OverflowAction
+				cfg.OverflowLabel = ""
+			},
+			err: errNoOverflowLabel,
+		},
+		{
+			name:   "no strip labels",
+			modify: func(cfg *Config) { cfg.Action = StripLabelsAction },
+			err:    errNoStripLabels,
+		},
+		{
+			name:   "no expiration",
+			modify: func(cfg *Config) { cfg.ExpireAfter = 0 },
+			err:    errNonPositiveExpireAfter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Limit = 100
+			tt.modify(cfg)
+
+			mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewMetricsNop())
+			assert.True(t, errors.Is(err, tt.err))
+			assert.EqualError(t, err, `error creating "cardinalitylimit" processor: `+tt.err.Error())
+			assert.Nil(t, mp)
+		})
+	}
+}
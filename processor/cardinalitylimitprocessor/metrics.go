@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/processor"
+)
+
+var (
+	tagMetricKey, _ = tag.NewKey("metric")
+	tagActionKey, _ = tag.NewKey("action")
+
+	statLimitedDataPoints = stats.Int64("limited_data_points", "Number of data points of the series beyond the cardinality limit", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to cardinality limiting
+func MetricViews() []*view.View {
+	limitedDataPointsView := &view.View{
+		Name:        statLimitedDataPoints.Name(),
+		Measure:     statLimitedDataPoints,
+		Description: statLimitedDataPoints.Description(),
+		TagKeys:     []tag.Key{processor.TagProcessorNameKey, tagMetricKey, tagActionKey},
+		Aggregation: view.Sum(),
+	}
+
+	return obsreport.ProcessorMetricViews(typeStr, []*view.View{limitedDataPointsView})
+}
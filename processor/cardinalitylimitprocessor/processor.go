@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/datapoint"
+	"go.opentelemetry.io/collector/internal/processor/series"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type cardinalityLimitProcessor struct {
+	name          string
+	action        Action
+	overflowLabel string
+	stripLabels   []string
+	obsrep        *obsreport.ProcessorObsReport
+
+	lock    sync.Mutex
+	tracker *seriesTracker
+	now     func() time.Time
+}
+
+func newCardinalityLimitProcessor(cfg *Config) *cardinalityLimitProcessor {
+	return &cardinalityLimitProcessor{
+		name:          cfg.Name(),
+		action:        cfg.Action,
+		overflowLabel: cfg.OverflowLabel,
+		stripLabels:   cfg.StripLabels,
+		obsrep:        obsreport.NewProcessorObsReport(configtelemetry.GetMetricsLevelFlagValue(), cfg.Name()),
+		tracker:       newSeriesTracker(cfg.Limit, cfg.ExpireAfter, time.Now()),
+		now:           time.Now,
+	}
+}
+
+// ProcessMetrics applies the action to the data points of the new series of
+// the metrics which already have the maximum number of active series.
+func (p *cardinalityLimitProcessor) ProcessMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	_, numPoints := md.MetricAndDataPointCount()
+
+	p.lock.Lock()
+	now := p.now()
+	p.tracker.expire(now)
+	dropped := 0
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := resourceKey(rm.Resource())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			kept := pdata.NewMetricSlice()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				limited, droppedPoints := p.limitMetric(metric, resource, now)
+				if limited > 0 {
+					p.recordLimited(ctx, metric.Name(), limited)
+				}
+				dropped += droppedPoints
+				if droppedPoints == 0 || hasDataPoints(metric) {
+					kept.Append(metric)
+				}
+			}
+			if kept.Len() < metrics.Len() {
+				metrics.Resize(0)
+				kept.MoveAndAppendTo(metrics)
+			}
+		}
+	}
+	p.lock.Unlock()
+
+	p.obsrep.MetricsAccepted(ctx, numPoints-dropped)
+	if dropped > 0 {
+		p.obsrep.MetricsDropped(ctx, dropped)
+		if dropped == numPoints {
+			return md, processorhelper.ErrSkipProcessingData
+		}
+	}
+	return md, nil
+}
+
+// limitMetric applies the action to the data points of the new series of the
+// metric beyond the limit, and returns the number of such data points and
+// the number of them which were dropped.
+func (p *cardinalityLimitProcessor) limitMetric(metric pdata.Metric, resource string, now time.Time) (int, int) {
+	name := metric.Name()
+	limited, dropped := 0, 0
+	switch p.action {
+	case OverflowAction:
+		datapoint.ForEachLabels(metric, func(labels pdata.StringMap) {
+			if p.tracker.observe(name, resource+series.LabelsKey(labels), now) {
+				return
+			}
+			limited++
+			labels.InitFromMap(map[string]string{p.overflowLabel: "true"})
+		})
+	case StripLabelsAction:
+		datapoint.ForEachLabels(metric, func(labels pdata.StringMap) {
+			if p.tracker.observe(name, resource+series.LabelsKey(labels), now) {
+				return
+			}
+			limited++
+			for _, key := range p.stripLabels {
+				labels.Delete(key)
+			}
+		})
+	default:
+		datapoint.Filter(metric, func(labels pdata.StringMap) bool {
+			if p.tracker.observe(name, resource+series.LabelsKey(labels), now) {
+				return true
+			}
+			limited++
+			dropped++
+			return false
+		})
+	}
+
+	if limited > dropped {
+		aggregateDataPoints(metric)
+	}
+	return limited, dropped
+}
+
+func (p *cardinalityLimitProcessor) recordLimited(ctx context.Context, metric string, limited int) {
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{
+			tag.Insert(processor.TagProcessorNameKey, p.name),
+			tag.Insert(tagMetricKey, metric),
+			tag.Insert(tagActionKey, string(p.action)),
+		},
+		statLimitedDataPoints.M(int64(limited)))
+}
+
+// resourceKey identifies the resource attributes of the series, followed by a
+// separator from the labels.
+func resourceKey(resource pdata.Resource) string {
+	return series.AttributesKey(resource.Attributes()) + "\x02"
+}
+
+func hasDataPoints(metric pdata.Metric) bool {
+	found := false
+	datapoint.ForEachLabels(metric, func(pdata.StringMap) {
+		found = true
+	})
+	return found
+}
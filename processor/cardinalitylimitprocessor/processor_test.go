@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func newTestProcessor(modify func(cfg *Config)) *cardinalityLimitProcessor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Limit = 2
+	if modify != nil {
+		modify(cfg)
+	}
+	return newCardinalityLimitProcessor(cfg)
+}
+
+// generateMetrics returns an int sum with a data point for each value of the
+// user label, and a gauge without labels.
+func generateMetrics(host string, users ...string) pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("host.name", host)
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(2)
+
+	metrics.At(0).SetName("requests")
+	metrics.At(0).SetDataType(pdata.MetricDataTypeIntSum)
+	dps := metrics.At(0).IntSum().DataPoints()
+	dps.Resize(len(users))
+	for i, user := range users {
+		dps.At(i).LabelsMap().InitFromMap(map[string]string{"user": user, "method": "GET"})
+		dps.At(i).SetTimestamp(pdata.Timestamp(1000))
+		dps.At(i).SetValue(int64(i + 1))
+	}
+
+	metrics.At(1).SetName("connections")
+	metrics.At(1).SetDataType(pdata.MetricDataTypeIntGauge)
+	metrics.At(1).IntGauge().DataPoints().Resize(1)
+	return md
+}
+
+type dataPoint struct {
+	labels map[string]string
+	value  int64
+}
+
+func requestDataPoints(md pdata.Metrics) []dataPoint {
+	var points []dataPoint
+	metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	if metric.Name() != "requests" {
+		return nil
+	}
+	dps := metric.IntSum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		labels := map[string]string{}
+		dps.At(i).LabelsMap().ForEach(func(k string, v string) {
+			labels[k] = v
+		})
+		points = append(points, dataPoint{labels: labels, value: dps.At(i).Value()})
+	}
+	return points
+}
+
+func TestProcessMetrics_Drop(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	p := newTestProcessor(nil)
+	ctx := context.Background()
+
+	md, err := p.ProcessMetrics(ctx, generateMetrics("host1", "alice", "bob", "carol"))
+	require.NoError(t, err)
+	assert.Equal(t, []dataPoint{
+		{labels: map[string]string{"user": "alice", "method": "GET"}, value: 1},
+		{labels: map[string]string{"user": "bob", "method": "GET"}, value: 2},
+	}, requestDataPoints(md))
+	assert.Equal(t, 2, md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+
+	// The active series are still accepted.
+	md, err = p.ProcessMetrics(ctx, generateMetrics("host1", "bob", "dave"))
+	require.NoError(t, err)
+	assert.Equal(t, []dataPoint{
+		{labels: map[string]string{"user": "bob", "method": "GET"}, value: 1},
+	}, requestDataPoints(md))
+	// The limit is per metric, and the series of the resources are distinct.
+	md, err = p.ProcessMetrics(ctx, generateMetrics("host2", "bob"))
+	require.NoError(t, err)
+	assert.Nil(t, requestDataPoints(md))
+	assert.Equal(t, 1, md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntGauge().DataPoints().Len())
+
+	// The metrics without data points left are removed.
+	md, err = p.ProcessMetrics(ctx, generateMetrics("host1", "erin"))
+	require.NoError(t, err)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, "connections", metrics.At(0).Name())
+
+	rows, err := view.RetrieveData(obsreport.BuildProcessorCustomMetricName(typeStr, "limited_data_points"))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 4.0, rows[0].Data.(*view.SumData).Value)
+}
+
+func TestProcessMetrics_DropAll(t *testing.T) {
+	p := newTestProcessor(func(cfg *Config) { cfg.Limit = 1 })
+	_, err := p.ProcessMetrics(context.Background(), generateMetrics("host1", "alice"))
+	require.NoError(t, err)
+
+	md, err := p.ProcessMetrics(context.Background(), generateMetrics("host2", "bob"))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+	assert.Equal(t, 0, md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+}
+
+func TestProcessMetrics_Overflow(t *testing.T) {
+	p := newTestProcessor(func(cfg *Config) { cfg.Action = OverflowAction })
+
+	md, err := p.ProcessMetrics(context.Background(), generateMetrics("host1", "alice", "bob", "carol", "dave"))
+	require.NoError(t, err)
+	assert.Equal(t, []dataPoint{
+		{labels: map[string]string{"user": "alice", "method": "GET"}, value: 1},
+		{labels: map[string]string{"user": "bob", "method": "GET"}, value: 2},
+		{labels: map[string]string{"otel_metric_overflow": "true"}, value: 7},
+	}, requestDataPoints(md))
+}
+
+func TestProcessMetrics_StripLabels(t *testing.T) {
+	p := newTestProcessor(func(cfg *Config) {
+		cfg.Action = StripLabelsAction
+		cfg.StripLabels = []string{"user"}
+	})
+
+	md, err := p.ProcessMetrics(context.Background(), generateMetrics("host1", "alice", "bob", "carol", "dave"))
+	require.NoError(t, err)
+	assert.Equal(t, []dataPoint{
+		{labels: map[string]string{"user": "alice", "method": "GET"}, value: 1},
+		{labels: map[string]string{"user": "bob", "method": "GET"}, value: 2},
+		{labels: map[string]string{"method": "GET"}, value: 7},
+	}, requestDataPoints(md))
+}
+
+func TestProcessMetrics_Expiration(t *testing.T) {
+	p := newTestProcessor(func(cfg *Config) { cfg.ExpireAfter = time.Minute })
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	md, err := p.ProcessMetrics(context.Background(), generateMetrics("host1", "alice", "bob", "carol"))
+	require.NoError(t, err)
+	assert.Len(t, requestDataPoints(md), 2)
+
+	now = now.Add(2 * time.Minute)
+	md, err = p.ProcessMetrics(context.Background(), generateMetrics("host1", "carol"))
+	require.NoError(t, err)
+	assert.Len(t, requestDataPoints(md), 1)
+}
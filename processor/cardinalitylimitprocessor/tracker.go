@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"time"
+)
+
+// seriesTracker tracks the active series of each metric.
+type seriesTracker struct {
+	limit       int
+	expireAfter time.Duration
+	// series are the times the active series of each metric last received a
+	// data point at.
+	series    map[string]map[string]time.Time
+	lastSweep time.Time
+}
+
+func newSeriesTracker(limit int, expireAfter time.Duration, now time.Time) *seriesTracker {
+	return &seriesTracker{
+		limit:       limit,
+		expireAfter: expireAfter,
+		series:      make(map[string]map[string]time.Time),
+		lastSweep:   now,
+	}
+}
+
+// observe records a data point of the series of the metric, and returns false
+// if the series is not active and the metric already has the maximum number of
+// active series.
+func (t *seriesTracker) observe(metric, series string, now time.Time) bool {
+	active, ok := t.series[metric]
+	if !ok {
+		active = make(map[string]time.Time)
+		t.series[metric] = active
+	}
+	if _, ok = active[series]; !ok && len(active) >= t.limit {
+		return false
+	}
+	active[series] = now
+	return true
+}
+
+// expire forgets the series which haven't received data points for the
+// expiration period. The series are checked at most every quarter of the
+// period, so that they expire within 1.25 times the period.
+func (t *seriesTracker) expire(now time.Time) {
+	if now.Sub(t.lastSweep) < t.expireAfter/4 {
+		return
+	}
+	t.lastSweep = now
+	for metric, active := range t.series {
+		for series, lastSeen := range active {
+			if now.Sub(lastSeen) >= t.expireAfter {
+				delete(active, series)
+			}
+		}
+		if len(active) == 0 {
+			delete(t.series, metric)
+		}
+	}
+}
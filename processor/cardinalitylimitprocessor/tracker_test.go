@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimitprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeriesTracker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := newSeriesTracker(2, time.Minute, now)
+
+	assert.True(t, tracker.observe("requests", "a", now))
+	assert.True(t, tracker.observe("requests", "b", now))
+	assert.False(t, tracker.observe("requests", "c", now))
+	assert.True(t, tracker.observe("requests", "a", now))
+	assert.True(t, tracker.observe("latency", "c", now))
+
+	// "b" expires, "a" received a data point since.
+	now = now.Add(30 * time.Second)
+	assert.True(t, tracker.observe("requests", "a", now))
+	now = now.Add(40 * time.Second)
+	tracker.expire(now)
+	assert.True(t, tracker.observe("requests", "c", now))
+	assert.False(t, tracker.observe("requests", "b", now))
+
+	// The series are checked at most every quarter of the expiration period.
+	now = now.Add(70 * time.Second)
+	tracker.expire(now)
+	assert.Empty(t, tracker.series)
+	tracker.observe("requests", "a", now)
+	now = now.Add(time.Minute)
+	tracker.expire(now.Add(-time.Second))
+	tracker.expire(now)
+	assert.Len(t, tracker.series["requests"], 1)
+}
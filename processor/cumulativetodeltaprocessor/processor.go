@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/series"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// seriesState is the previous cumulative data point of a series.
+type seriesState struct {
+	startTime pdata.Timestamp
+	timestamp pdata.Timestamp
+	lastSeen  time.Time
+
+	intValue     int64
+	doubleValue  float64
+	count        uint64
+	bucketCounts []uint64
+	bounds       []float64
+}
+
+type deltaProcessor struct {
+	metrics map[string]struct{}
+	sweeper *series.Sweeper
+
+	lock   sync.Mutex
+	series map[string]*seriesState
+	now    func() time.Time
+}
+
+func newDeltaProcessor(cfg *Config) *deltaProcessor {
+	var metrics map[string]struct{}
+	if len(cfg.Metrics) > 0 {
+		metrics = make(map[string]struct{}, len(cfg.Metrics))
+		for _, name := range cfg.Metrics {
+			metrics[name] = struct{}{}
+		}
+	}
+	return &deltaProcessor{
+		metrics: metrics,
+		sweeper: series.NewSweeper(cfg.MaxStaleness),
+		series:  make(map[string]*seriesState),
+		now:     time.Now,
+	}
+}
+
+// ProcessMetrics converts the cumulative sums and histograms to delta
+// temporality. The first data point of a series only initializes its state and
+// is dropped, as are the data points older than the previous one of their
+// series. The metrics left without data points are removed.
+func (p *deltaProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	p.removeStaleSeries(now)
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := series.AttributesKey(rm.Resource().Attributes())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			library := ilm.InstrumentationLibrary()
+			libraryKey := resourceKey + "\x00" + library.Name() + "\x00" + library.Version()
+
+			metrics := ilm.Metrics()
+			kept := pdata.NewMetricSlice()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if p.convertMetric(libraryKey+"\x00"+metric.Name(), metric, now) {
+					kept.Append(metric)
+				}
+			}
+			metrics.Resize(0)
+			kept.MoveAndAppendTo(metrics)
+		}
+	}
+
+	if md.MetricCount() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// removeStaleSeries evicts the series not seen for longer than the max
+// staleness.
+func (p *deltaProcessor) removeStaleSeries(now time.Time) {
+	staleBefore, ok := p.sweeper.StaleBefore(now)
+	if !ok {
+		return
+	}
+	for key, state := range p.series {
+		if state.lastSeen.Before(staleBefore) {
+			delete(p.series, key)
+		}
+	}
+}
+
+// convertMetric converts the metric if it is a selected cumulative sum or
+// histogram, and returns whether the metric still has data points.
+func (p *deltaProcessor) convertMetric(key string, metric pdata.Metric, now time.Time) bool {
+	if p.metrics != nil {
+		if _, ok := p.metrics[metric.Name()]; !ok {
+			return true
+		}
+	}
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		sum := metric.IntSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return true
+		}
+		p.convertIntDataPoints(key, sum.DataPoints(), sum.IsMonotonic(), now)
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		return sum.DataPoints().Len() > 0
+	case pdata.MetricDataTypeDoubleSum:
+		sum := metric.DoubleSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return true
+		}
+		p.convertDoubleDataPoints(key, sum.DataPoints(), sum.IsMonotonic(), now)
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		return sum.DataPoints().Len() > 0
+	case pdata.MetricDataTypeIntHistogram:
+		histogram := metric.IntHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return true
+		}
+		p.convertIntHistogramDataPoints(key, histogram.DataPoints(), now)
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		return histogram.DataPoints().Len() > 0
+	case pdata.MetricDataTypeDoubleHistogram:
+		histogram := metric.DoubleHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return true
+		}
+		p.convertDoubleHistogramDataPoints(key, histogram.DataPoints(), now)
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		return histogram.DataPoints().Len() > 0
+	}
+	return true
+}
+
+// previous returns the state of the series and whether the data point must be
+// converted, and records the data point start time and timestamp as the new
+// state. A data point is not converted when it is the first of its series or
+// when it is older than the previous one.
+func (p *deltaProcessor) previous(key string, startTime, timestamp pdata.Timestamp, now time.Time) (*seriesState, *seriesState, bool) {
+	prev, ok := p.series[key]
+	if ok && timestamp <= prev.timestamp {
+		return nil, nil, false
+	}
+	current := &seriesState{startTime: startTime, timestamp: timestamp, lastSeen: now}
+	p.series[key] = current
+	return prev, current, ok
+}
+
+// deltaStartTime returns the start time of the delta data point: the timestamp
+// of the previous data point, or the start time of the series when it restarted.
+func deltaStartTime(prev *seriesState, startTime pdata.Timestamp, reset bool) pdata.Timestamp {
+	if reset && startTime != prev.startTime {
+		return startTime
+	}
+	return prev.timestamp
+}
+
+func (p *deltaProcessor) convertIntDataPoints(key string, dps pdata.IntDataPointSlice, monotonic bool, now time.Time) {
+	kept := pdata.NewIntDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		prev, current, ok := p.previous(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), now)
+		if current != nil {
+			current.intValue = dp.Value()
+		}
+		if !ok {
+			continue
+		}
+
+		reset := dp.StartTime() != prev.startTime || (monotonic && dp.Value() < prev.intValue)
+		dp.SetStartTime(deltaStartTime(prev, dp.StartTime(), reset))
+		if !reset {
+			dp.SetValue(dp.Value() - prev.intValue)
+		}
+		kept.Append(dp)
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func (p *deltaProcessor) convertDoubleDataPoints(key string, dps pdata.DoubleDataPointSlice, monotonic bool, now time.Time) {
+	kept := pdata.NewDoubleDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		prev, current, ok := p.previous(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), now)
+		if current != nil {
+			current.doubleValue = dp.Value()
+		}
+		if !ok {
+			continue
+		}
+
+		reset := dp.StartTime() != prev.startTime || (monotonic && dp.Value() < prev.doubleValue)
+		dp.SetStartTime(deltaStartTime(prev, dp.StartTime(), reset))
+		if !reset {
+			dp.SetValue(dp.Value() - prev.doubleValue)
+		}
+		kept.Append(dp)
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func (p *deltaProcessor) convertIntHistogramDataPoints(key string, dps pdata.IntHistogramDataPointSlice, now time.Time) {
+	kept := pdata.NewIntHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		prev, current, ok := p.previous(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), now)
+		if current != nil {
+			current.intValue = dp.Sum()
+			current.count = dp.Count()
+			current.bucketCounts = dp.BucketCounts()
+			current.bounds = dp.ExplicitBounds()
+		}
+		if !ok {
+			continue
+		}
+
+		reset := dp.StartTime() != prev.startTime || histogramReset(prev, dp.Count(), dp.BucketCounts(), dp.ExplicitBounds())
+		dp.SetStartTime(deltaStartTime(prev, dp.StartTime(), reset))
+		if !reset {
+			dp.SetCount(dp.Count() - prev.count)
+			dp.SetSum(dp.Sum() - prev.intValue)
+			dp.SetBucketCounts(subtractBucketCounts(dp.BucketCounts(), prev.bucketCounts))
+		}
+		kept.Append(dp)
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func (p *deltaProcessor) convertDoubleHistogramDataPoints(key string, dps pdata.DoubleHistogramDataPointSlice, now time.Time) {
+	kept := pdata.NewDoubleHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		prev, current, ok := p.previous(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), now)
+		if current != nil {
+			current.doubleValue = dp.Sum()
+			current.count = dp.Count()
+			current.bucketCounts = dp.BucketCounts()
+			current.bounds = dp.ExplicitBounds()
+		}
+		if !ok {
+			continue
+		}
+
+		reset := dp.StartTime() != prev.startTime || histogramReset(prev, dp.Count(), dp.BucketCounts(), dp.ExplicitBounds())
+		dp.SetStartTime(deltaStartTime(prev, dp.StartTime(), reset))
+		if !reset {
+			dp.SetCount(dp.Count() - prev.count)
+			dp.SetSum(dp.Sum() - prev.doubleValue)
+			dp.SetBucketCounts(subtractBucketCounts(dp.BucketCounts(), prev.bucketCounts))
+		}
+		kept.Append(dp)
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+// histogramReset returns whether the histogram restarted: its count or one of
+// its bucket counts decreased, or its buckets changed.
+func histogramReset(prev *seriesState, count uint64, bucketCounts []uint64, bounds []float64) bool {
+	if count < prev.count || len(bucketCounts) != len(prev.bucketCounts) || len(bounds) != len(prev.bounds) {
+		return true
+	}
+	for i, b := range bounds {
+		if b != prev.bounds[i] {
+			return true
+		}
+	}
+	for i, c := range bucketCounts {
+		if c < prev.bucketCounts[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// subtractBucketCounts returns a new slice with the differences of the bucket
+// counts, the bucket counts are kept as the state of the series.
+func subtractBucketCounts(counts, prev []uint64) []uint64 {
+	deltas := make([]uint64, len(counts))
+	for i, c := range counts {
+		deltas[i] = c - prev[i]
+	}
+	return deltas
+}
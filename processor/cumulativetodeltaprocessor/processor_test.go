@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// testPoint is a data point of a sum.
+type testPoint struct {
+	labels    map[string]string
+	startTime pdata.Timestamp
+	timestamp pdata.Timestamp
+	value     float64
+}
+
+// newSum returns a batch with a single cumulative sum for the given host.
+func newSum(host string, name string, dataType pdata.MetricDataType, monotonic bool, points ...testPoint) pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("host.name", host)
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ms := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	ms.Resize(1)
+	m := ms.At(0)
+	m.SetName(name)
+	m.SetDataType(dataType)
+	switch dataType {
+	case pdata.MetricDataTypeIntSum:
+		m.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		m.IntSum().SetIsMonotonic(monotonic)
+		dps := m.IntSum().DataPoints()
+		dps.Resize(len(points))
+		for i, tp := range points {
+			dps.At(i).LabelsMap().InitFromMap(tp.labels)
+			dps.At(i).SetStartTime(tp.startTime)
+			dps.At(i).SetTimestamp(tp.timestamp)
+			dps.At(i).SetValue(int64(tp.value))
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		m.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		m.DoubleSum().SetIsMonotonic(monotonic)
+		dps := m.DoubleSum().DataPoints()
+		dps.Resize(len(points))
+		for i, tp := range points {
+			dps.At(i).LabelsMap().InitFromMap(tp.labels)
+			dps.At(i).SetStartTime(tp.startTime)
+			dps.At(i).SetTimestamp(tp.timestamp)
+			dps.At(i).SetValue(tp.value)
+		}
+	}
+	return md
+}
+
+// sumPoints returns the data points of the first metric of the batch, or nil
+// when the batch has no metrics.
+func sumPoints(md pdata.Metrics) []testPoint {
+	if md.MetricCount() == 0 {
+		return nil
+	}
+	m := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	var points []testPoint
+	switch m.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		dps := m.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			points = append(points, testPoint{labels: labelsToMap(dp.LabelsMap()), startTime: dp.StartTime(), timestamp: dp.Timestamp(), value: float64(dp.Value())})
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := m.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			points = append(points, testPoint{labels: labelsToMap(dp.LabelsMap()), startTime: dp.StartTime(), timestamp: dp.Timestamp(), value: dp.Value()})
+		}
+	}
+	return points
+}
+
+func labelsToMap(labels pdata.StringMap) map[string]string {
+	m := make(map[string]string, labels.Len())
+	labels.ForEach(func(k string, v string) {
+		m[k] = v
+	})
+	return m
+}
+
+func TestProcessMetrics_Sums(t *testing.T) {
+	for _, dataType := range []pdata.MetricDataType{pdata.MetricDataTypeIntSum, pdata.MetricDataTypeDoubleSum} {
+		t.Run(dataType.String(), func(t *testing.T) {
+			p := newDeltaProcessor(&Config{})
+			get := map[string]string{"method": "GET"}
+			post := map[string]string{"method": "POST"}
+
+			// The first data points only initialize the series.
+			_, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType, true,
+				testPoint{labels: get, startTime: 1, timestamp: 10, value: 10},
+				testPoint{labels: post, startTime: 1, timestamp: 10, value: 2}))
+			assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+
+			md, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType, true,
+				testPoint{labels: get, startTime: 1, timestamp: 20, value: 15},
+				testPoint{labels: post, startTime: 1, timestamp: 20, value: 2}))
+			require.NoError(t, err)
+			sum := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+			if dataType == pdata.MetricDataTypeIntSum {
+				assert.Equal(t, pdata.AggregationTemporalityDelta, sum.IntSum().AggregationTemporality())
+			} else {
+				assert.Equal(t, pdata.AggregationTemporalityDelta, sum.DoubleSum().AggregationTemporality())
+			}
+			assert.Equal(t, []testPoint{
+				{labels: get, startTime: 10, timestamp: 20, value: 5},
+				{labels: post, startTime: 10, timestamp: 20, value: 0},
+			}, sumPoints(md))
+
+			// The same series of another resource is a new series.
+			_, err = p.ProcessMetrics(context.Background(), newSum("web-2", "requests", dataType, true,
+				testPoint{labels: get, startTime: 1, timestamp: 20, value: 100}))
+			assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+
+			// A decreasing value of a monotonic sum is a restart.
+			md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType, true,
+				testPoint{labels: get, startTime: 1, timestamp: 30, value: 3}))
+			require.NoError(t, err)
+			assert.Equal(t, []testPoint{{labels: get, startTime: 20, timestamp: 30, value: 3}}, sumPoints(md))
+
+			// A new start time is a restart.
+			md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType, true,
+				testPoint{labels: get, startTime: 35, timestamp: 40, value: 4}))
+			require.NoError(t, err)
+			assert.Equal(t, []testPoint{{labels: get, startTime: 35, timestamp: 40, value: 4}}, sumPoints(md))
+
+			// The data points older than the previous one are dropped.
+			_, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType, true,
+				testPoint{labels: get, startTime: 35, timestamp: 40, value: 8}))
+			assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+		})
+	}
+}
+
+func TestProcessMetrics_NonMonotonicSum(t *testing.T) {
+	p := newDeltaProcessor(&Config{})
+
+	_, err := p.ProcessMetrics(context.Background(), newSum("web-1", "connections", pdata.MetricDataTypeIntSum, false,
+		testPoint{startTime: 1, timestamp: 10, value: 10}))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+
+	md, err := p.ProcessMetrics(context.Background(), newSum("web-1", "connections", pdata.MetricDataTypeIntSum, false,
+		testPoint{startTime: 1, timestamp: 20, value: 4}))
+	require.NoError(t, err)
+	assert.Equal(t, []testPoint{{labels: map[string]string{}, startTime: 10, timestamp: 20, value: -6}}, sumPoints(md))
+}
+
+func TestProcessMetrics_Selection(t *testing.T) {
+	p := newDeltaProcessor(&Config{Metrics: []string{"requests"}})
+
+	// The metrics which are not selected are left unchanged.
+	in := newSum("web-1", "errors", pdata.MetricDataTypeIntSum, true, testPoint{startTime: 1, timestamp: 10, value: 10})
+	md, err := p.ProcessMetrics(context.Background(), in)
+	require.NoError(t, err)
+	assert.Equal(t, []testPoint{{labels: map[string]string{}, startTime: 1, timestamp: 10, value: 10}}, sumPoints(md))
+
+	// The delta sums are left unchanged.
+	in = newSum("web-1", "requests", pdata.MetricDataTypeIntSum, true, testPoint{startTime: 1, timestamp: 10, value: 10})
+	in.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	md, err = p.ProcessMetrics(context.Background(), in)
+	require.NoError(t, err)
+	assert.Equal(t, []testPoint{{labels: map[string]string{}, startTime: 1, timestamp: 10, value: 10}}, sumPoints(md))
+}
+
+func TestProcessMetrics_Histograms(t *testing.T) {
+	newHistogram := func(startTime, timestamp pdata.Timestamp, count uint64, sum float64, bucketCounts []uint64) pdata.Metrics {
+		md := pdata.NewMetrics()
+		md.ResourceMetrics().Resize(1)
+		md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Resize(1)
+		ms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+		ms.Resize(1)
+		m := ms.At(0)
+		m.SetName("duration")
+		m.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+		m.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		m.DoubleHistogram().DataPoints().Resize(1)
+		dp := m.DoubleHistogram().DataPoints().At(0)
+		dp.SetStartTime(startTime)
+		dp.SetTimestamp(timestamp)
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		dp.SetBucketCounts(bucketCounts)
+		dp.SetExplicitBounds([]float64{1})
+		return md
+	}
+	histogramPoint := func(md pdata.Metrics) pdata.DoubleHistogramDataPoint {
+		return md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleHistogram().DataPoints().At(0)
+	}
+
+	p := newDeltaProcessor(&Config{})
+	_, err := p.ProcessMetrics(context.Background(), newHistogram(1, 10, 3, 4.5, []uint64{1, 2}))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+
+	md, err := p.ProcessMetrics(context.Background(), newHistogram(1, 20, 5, 6, []uint64{2, 3}))
+	require.NoError(t, err)
+	dp := histogramPoint(md)
+	assert.Equal(t, pdata.Timestamp(10), dp.StartTime())
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, 1.5, dp.Sum())
+	assert.Equal(t, []uint64{1, 1}, dp.BucketCounts())
+
+	// A decreasing bucket count is a restart.
+	md, err = p.ProcessMetrics(context.Background(), newHistogram(1, 30, 6, 6.5, []uint64{1, 5}))
+	require.NoError(t, err)
+	dp = histogramPoint(md)
+	assert.Equal(t, pdata.Timestamp(20), dp.StartTime())
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, []uint64{1, 5}, dp.BucketCounts())
+}
+
+func TestProcessMetrics_MaxStaleness(t *testing.T) {
+	p := newDeltaProcessor(&Config{MaxStaleness: time.Minute})
+	now := time.Unix(1000, 0)
+	p.now = func() time.Time { return now }
+
+	_, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum, true,
+		testPoint{startTime: 1, timestamp: 10, value: 10}))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+	assert.Len(t, p.series, 1)
+
+	now = now.Add(30 * time.Second)
+	_, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum, true,
+		testPoint{startTime: 1, timestamp: 20, value: 15}))
+	require.NoError(t, err)
+
+	// The series is evicted once it is not seen for longer than the max staleness,
+	// and starts over.
+	now = now.Add(2 * time.Minute)
+	_, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum, true,
+		testPoint{startTime: 1, timestamp: 30, value: 20}))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+	assert.Len(t, p.series, 1)
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Delta to Cumulative processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Metrics are the names of the metrics converted to cumulative temporality. If
+	// not set, all the delta sums and histograms are converted.
+	Metrics []string `mapstructure:"metrics"`
+
+	// MaxStaleness is how long the running total of a series is kept after its last
+	// data point. A series seen again after being evicted restarts from zero with a
+	// new start time. If not set, the running totals are never evicted.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+}
@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/series"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// seriesState is the running total of a series.
+type seriesState struct {
+	startTime pdata.Timestamp
+	timestamp pdata.Timestamp
+	lastSeen  time.Time
+
+	intValue     int64
+	doubleValue  float64
+	count        uint64
+	bucketCounts []uint64
+	bounds       []float64
+}
+
+type cumulativeProcessor struct {
+	metrics map[string]struct{}
+	sweeper *series.Sweeper
+
+	lock   sync.Mutex
+	series map[string]*seriesState
+	now    func() time.Time
+}
+
+func newCumulativeProcessor(cfg *Config) *cumulativeProcessor {
+	var metrics map[string]struct{}
+	if len(cfg.Metrics) > 0 {
+		metrics = make(map[string]struct{}, len(cfg.Metrics))
+		for _, name := range cfg.Metrics {
+			metrics[name] = struct{}{}
+		}
+	}
+	return &cumulativeProcessor{
+		metrics: metrics,
+		sweeper: series.NewSweeper(cfg.MaxStaleness),
+		series:  make(map[string]*seriesState),
+		now:     time.Now,
+	}
+}
+
+// ProcessMetrics converts the delta sums and histograms to cumulative
+// temporality. The data points which are not newer than the previous one of
+// their series are dropped, and the metrics left without data points are
+// removed.
+func (p *cumulativeProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	p.removeStaleSeries(now)
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := series.AttributesKey(rm.Resource().Attributes())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			library := ilm.InstrumentationLibrary()
+			libraryKey := resourceKey + "\x00" + library.Name() + "\x00" + library.Version()
+
+			metrics := ilm.Metrics()
+			kept := pdata.NewMetricSlice()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if p.convertMetric(libraryKey+"\x00"+metric.Name(), metric, now) {
+					kept.Append(metric)
+				}
+			}
+			metrics.Resize(0)
+			kept.MoveAndAppendTo(metrics)
+		}
+	}
+
+	if md.MetricCount() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// removeStaleSeries evicts the series not seen for longer than the max
+// staleness.
+func (p *cumulativeProcessor) removeStaleSeries(now time.Time) {
+	staleBefore, ok := p.sweeper.StaleBefore(now)
+	if !ok {
+		return
+	}
+	for key, state := range p.series {
+		if state.lastSeen.Before(staleBefore) {
+			delete(p.series, key)
+		}
+	}
+}
+
+// convertMetric converts the metric if it is a selected delta sum or
+// histogram, and returns whether the metric still has data points.
+func (p *cumulativeProcessor) convertMetric(key string, metric pdata.Metric, now time.Time) bool {
+	if p.metrics != nil {
+		if _, ok := p.metrics[metric.Name()]; !ok {
+			return true
+		}
+	}
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		sum := metric.IntSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return true
+		}
+		p.accumulateIntDataPoints(key, sum.DataPoints(), now)
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		return sum.DataPoints().Len() > 0
+	case pdata.MetricDataTypeDoubleSum:
+		sum := metric.DoubleSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return true
+		}
+		p.accumulateDoubleDataPoints(key, sum.DataPoints(), now)
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		return sum.DataPoints().Len() > 0
+	case pdata.MetricDataTypeIntHistogram:
+		histogram := metric.IntHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return true
+		}
+		p.accumulateIntHistogramDataPoints(key, histogram.DataPoints(), now)
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		return histogram.DataPoints().Len() > 0
+	case pdata.MetricDataTypeDoubleHistogram:
+		histogram := metric.DoubleHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return true
+		}
+		p.accumulateDoubleHistogramDataPoints(key, histogram.DataPoints(), now)
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		return histogram.DataPoints().Len() > 0
+	}
+	return true
+}
+
+// state returns the state of the series the data point is added to, or false
+// when the data point is not newer than the previous one of its series. A new
+// series starts at the start time of its first data point, or at its timestamp
+// when the start time is not set.
+func (p *cumulativeProcessor) state(key string, startTime, timestamp pdata.Timestamp, now time.Time) (*seriesState, bool) {
+	state, ok := p.series[key]
+	if !ok {
+		if startTime == 0 {
+			startTime = timestamp
+		}
+		state = &seriesState{startTime: startTime}
+		p.series[key] = state
+	} else if timestamp <= state.timestamp {
+		return nil, false
+	}
+	state.timestamp = timestamp
+	state.lastSeen = now
+	return state, true
+}
+
+func (p *cumulativeProcessor) accumulateIntDataPoints(key string, dps pdata.IntDataPointSlice, now time.Time) {
+	kept := pdata.NewIntDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		state, ok := p.state(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), now)
+		if !ok {
+			continue
+		}
+
+		state.intValue += dp.Value()
+		dp.SetValue(state.intValue)
+		dp.SetStartTime(state.startTime)
+		kept.Append(dp)
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func (p *cumulativeProcessor) accumulateDoubleDataPoints(key string, dps pdata.DoubleDataPointSlice, now time.Time) {
+	kept := pdata.NewDoubleDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		state, ok := p.state(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), now)
+		if !ok {
+			continue
+		}
+
+		state.doubleValue += dp.Value()
+		dp.SetValue(state.doubleValue)
+		dp.SetStartTime(state.startTime)
+		kept.Append(dp)
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func (p *cumulativeProcessor) accumulateIntHistogramDataPoints(key string, dps pdata.IntHistogramDataPointSlice, now time.Time) {
+	kept := pdata.NewIntHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := key + "\x00" + series.LabelsKey(dp.LabelsMap())
+		restartOnBucketsChange(p.series, key, dp.BucketCounts(), dp.ExplicitBounds())
+		state, ok := p.state(key, dp.StartTime(), dp.Timestamp(), now)
+		if !ok {
+			continue
+		}
+
+		state.intValue += dp.Sum()
+		state.count += dp.Count()
+		state.bucketCounts = series.AddBucketCounts(state.bucketCounts, dp.BucketCounts())
+		state.bounds = dp.ExplicitBounds()
+		dp.SetSum(state.intValue)
+		dp.SetCount(state.count)
+		dp.SetBucketCounts(state.bucketCounts)
+		dp.SetStartTime(state.startTime)
+		kept.Append(dp)
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func (p *cumulativeProcessor) accumulateDoubleHistogramDataPoints(key string, dps pdata.DoubleHistogramDataPointSlice, now time.Time) {
+	kept := pdata.NewDoubleHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := key + "\x00" + series.LabelsKey(dp.LabelsMap())
+		restartOnBucketsChange(p.series, key, dp.BucketCounts(), dp.ExplicitBounds())
+		state, ok := p.state(key, dp.StartTime(), dp.Timestamp(), now)
+		if !ok {
+			continue
+		}
+
+		state.doubleValue += dp.Sum()
+		state.count += dp.Count()
+		state.bucketCounts = series.AddBucketCounts(state.bucketCounts, dp.BucketCounts())
+		state.bounds = dp.ExplicitBounds()
+		dp.SetSum(state.doubleValue)
+		dp.SetCount(state.count)
+		dp.SetBucketCounts(state.bucketCounts)
+		dp.SetStartTime(state.startTime)
+		kept.Append(dp)
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+// restartOnBucketsChange removes the series of the histogram when its buckets
+// changed, so that it restarts from the data point.
+func restartOnBucketsChange(series map[string]*seriesState, key string, bucketCounts []uint64, bounds []float64) {
+	state, ok := series[key]
+	if !ok {
+		return
+	}
+	changed := len(bucketCounts) != len(state.bucketCounts) || len(bounds) != len(state.bounds)
+	for i := 0; !changed && i < len(bounds); i++ {
+		changed = bounds[i] != state.bounds[i]
+	}
+	if changed {
+		delete(series, key)
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// testPoint is a data point of a sum.
+type testPoint struct {
+	labels    map[string]string
+	startTime pdata.Timestamp
+	timestamp pdata.Timestamp
+	value     float64
+}
+
+// newSum returns a batch with a single delta sum for the given host.
+func newSum(host string, name string, dataType pdata.MetricDataType, points ...testPoint) pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("host.name", host)
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ms := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	ms.Resize(1)
+	m := ms.At(0)
+	m.SetName(name)
+	m.SetDataType(dataType)
+	switch dataType {
+	case pdata.MetricDataTypeIntSum:
+		m.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		m.IntSum().SetIsMonotonic(true)
+		dps := m.IntSum().DataPoints()
+		dps.Resize(len(points))
+		for i, tp := range points {
+			dps.At(i).LabelsMap().InitFromMap(tp.labels)
+			dps.At(i).SetStartTime(tp.startTime)
+			dps.At(i).SetTimestamp(tp.timestamp)
+			dps.At(i).SetValue(int64(tp.value))
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		m.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		m.DoubleSum().SetIsMonotonic(true)
+		dps := m.DoubleSum().DataPoints()
+		dps.Resize(len(points))
+		for i, tp := range points {
+			dps.At(i).LabelsMap().InitFromMap(tp.labels)
+			dps.At(i).SetStartTime(tp.startTime)
+			dps.At(i).SetTimestamp(tp.timestamp)
+			dps.At(i).SetValue(tp.value)
+		}
+	}
+	return md
+}
+
+// sumPoints returns the data points of the first metric of the batch, or nil
+// when the batch has no metrics.
+func sumPoints(md pdata.Metrics) []testPoint {
+	if md.MetricCount() == 0 {
+		return nil
+	}
+	m := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	var points []testPoint
+	switch m.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		dps := m.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			points = append(points, testPoint{labels: labelsToMap(dp.LabelsMap()), startTime: dp.StartTime(), timestamp: dp.Timestamp(), value: float64(dp.Value())})
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := m.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			points = append(points, testPoint{labels: labelsToMap(dp.LabelsMap()), startTime: dp.StartTime(), timestamp: dp.Timestamp(), value: dp.Value()})
+		}
+	}
+	return points
+}
+
+func labelsToMap(labels pdata.StringMap) map[string]string {
+	m := make(map[string]string, labels.Len())
+	labels.ForEach(func(k string, v string) {
+		m[k] = v
+	})
+	return m
+}
+
+func TestProcessMetrics_Sums(t *testing.T) {
+	for _, dataType := range []pdata.MetricDataType{pdata.MetricDataTypeIntSum, pdata.MetricDataTypeDoubleSum} {
+		t.Run(dataType.String(), func(t *testing.T) {
+			p := newCumulativeProcessor(&Config{})
+			get := map[string]string{"method": "GET"}
+			post := map[string]string{"method": "POST"}
+
+			md, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType,
+				testPoint{labels: get, startTime: 1, timestamp: 10, value: 10},
+				testPoint{labels: post, timestamp: 10, value: 2}))
+			require.NoError(t, err)
+			sum := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+			if dataType == pdata.MetricDataTypeIntSum {
+				assert.Equal(t, pdata.AggregationTemporalityCumulative, sum.IntSum().AggregationTemporality())
+			} else {
+				assert.Equal(t, pdata.AggregationTemporalityCumulative, sum.DoubleSum().AggregationTemporality())
+			}
+			// A series without start time starts at its first timestamp.
+			assert.Equal(t, []testPoint{
+				{labels: get, startTime: 1, timestamp: 10, value: 10},
+				{labels: post, startTime: 10, timestamp: 10, value: 2},
+			}, sumPoints(md))
+
+			md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType,
+				testPoint{labels: get, startTime: 10, timestamp: 20, value: 5},
+				testPoint{labels: post, startTime: 10, timestamp: 20, value: 1}))
+			require.NoError(t, err)
+			assert.Equal(t, []testPoint{
+				{labels: get, startTime: 1, timestamp: 20, value: 15},
+				{labels: post, startTime: 10, timestamp: 20, value: 3},
+			}, sumPoints(md))
+
+			// The same series of another resource is a new series.
+			md, err = p.ProcessMetrics(context.Background(), newSum("web-2", "requests", dataType,
+				testPoint{labels: get, startTime: 10, timestamp: 20, value: 7}))
+			require.NoError(t, err)
+			assert.Equal(t, []testPoint{{labels: get, startTime: 10, timestamp: 20, value: 7}}, sumPoints(md))
+
+			// The data points which are not newer than the previous one are dropped.
+			_, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType,
+				testPoint{labels: get, startTime: 10, timestamp: 20, value: 5}))
+			assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+		})
+	}
+}
+
+func TestProcessMetrics_Selection(t *testing.T) {
+	p := newCumulativeProcessor(&Config{Metrics: []string{"requests"}})
+
+	// The metrics which are not selected are left unchanged.
+	for i := 0; i < 2; i++ {
+		md, err := p.ProcessMetrics(context.Background(), newSum("web-1", "errors", pdata.MetricDataTypeIntSum,
+			testPoint{startTime: 1, timestamp: 10, value: 10}))
+		require.NoError(t, err)
+		assert.Equal(t, []testPoint{{labels: map[string]string{}, startTime: 1, timestamp: 10, value: 10}}, sumPoints(md))
+	}
+
+	// The cumulative sums are left unchanged.
+	in := newSum("web-1", "requests", pdata.MetricDataTypeIntSum, testPoint{startTime: 1, timestamp: 10, value: 10})
+	in.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	md, err := p.ProcessMetrics(context.Background(), in)
+	require.NoError(t, err)
+	assert.Equal(t, []testPoint{{labels: map[string]string{}, startTime: 1, timestamp: 10, value: 10}}, sumPoints(md))
+	assert.Empty(t, p.series)
+}
+
+func TestProcessMetrics_Histograms(t *testing.T) {
+	newHistogram := func(startTime, timestamp pdata.Timestamp, count uint64, sum float64, bucketCounts []uint64, bounds []float64) pdata.Metrics {
+		md := pdata.NewMetrics()
+		md.ResourceMetrics().Resize(1)
+		md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Resize(1)
+		ms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+		ms.Resize(1)
+		m := ms.At(0)
+		m.SetName("duration")
+		m.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+		m.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		m.DoubleHistogram().DataPoints().Resize(1)
+		dp := m.DoubleHistogram().DataPoints().At(0)
+		dp.SetStartTime(startTime)
+		dp.SetTimestamp(timestamp)
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		dp.SetBucketCounts(bucketCounts)
+		dp.SetExplicitBounds(bounds)
+		return md
+	}
+	histogramPoint := func(md pdata.Metrics) pdata.DoubleHistogramDataPoint {
+		return md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleHistogram().DataPoints().At(0)
+	}
+
+	p := newCumulativeProcessor(&Config{})
+	md, err := p.ProcessMetrics(context.Background(), newHistogram(1, 10, 3, 4.5, []uint64{1, 2}, []float64{1}))
+	require.NoError(t, err)
+	dp := histogramPoint(md)
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleHistogram().AggregationTemporality())
+	assert.Equal(t, uint64(3), dp.Count())
+
+	md, err = p.ProcessMetrics(context.Background(), newHistogram(10, 20, 2, 1.5, []uint64{1, 1}, []float64{1}))
+	require.NoError(t, err)
+	dp = histogramPoint(md)
+	assert.Equal(t, pdata.Timestamp(1), dp.StartTime())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 6.0, dp.Sum())
+	assert.Equal(t, []uint64{2, 3}, dp.BucketCounts())
+
+	// A change of the buckets restarts the series.
+	md, err = p.ProcessMetrics(context.Background(), newHistogram(20, 30, 1, 2, []uint64{1, 0}, []float64{5}))
+	require.NoError(t, err)
+	dp = histogramPoint(md)
+	assert.Equal(t, pdata.Timestamp(20), dp.StartTime())
+	assert.Equal(t, uint64(1), dp.Count())
+	assert.Equal(t, []uint64{1, 0}, dp.BucketCounts())
+}
+
+func TestProcessMetrics_MaxStaleness(t *testing.T) {
+	p := newCumulativeProcessor(&Config{MaxStaleness: time.Minute})
+	now := time.Unix(1000, 0)
+	p.now = func() time.Time { return now }
+
+	_, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{startTime: 1, timestamp: 10, value: 10}))
+	require.NoError(t, err)
+
+	now = now.Add(30 * time.Second)
+	md, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{startTime: 10, timestamp: 20, value: 5}))
+	require.NoError(t, err)
+	assert.Equal(t, []testPoint{{labels: map[string]string{}, startTime: 1, timestamp: 20, value: 15}}, sumPoints(md))
+
+	// The series is evicted once it is not seen for longer than the max staleness,
+	// and restarts from zero.
+	now = now.Add(2 * time.Minute)
+	md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{startTime: 20, timestamp: 30, value: 2}))
+	require.NoError(t, err)
+	assert.Equal(t, []testPoint{{labels: map[string]string{}, startTime: 20, timestamp: 30, value: 2}}, sumPoints(md))
+	assert.Len(t, p.series, 1)
+}
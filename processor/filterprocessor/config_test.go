@@ -24,7 +24,9 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
 	"go.opentelemetry.io/collector/internal/processor/filtermetric"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
 	fsregexp "go.opentelemetry.io/collector/internal/processor/filterset/regexp"
 )
 
@@ -311,3 +313,65 @@ func TestLoadingConfigExpr(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadingConfigTracesLogs(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	factory := NewFactory()
+	factories.Processors[configmodels.Type(typeStr)] = factory
+	config, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config_traces_logs.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, config)
+
+	tests := []struct {
+		filterName string
+		expCfg     configmodels.Processor
+	}{
+		{
+			filterName: "filter/spans",
+			expCfg: &Config{
+				ProcessorSettings: configmodels.ProcessorSettings{
+					NameVal: "filter/spans",
+					TypeVal: typeStr,
+				},
+				Spans: SpanFilters{
+					Exclude: &filterconfig.MatchProperties{
+						Config:    filterset.Config{MatchType: filterset.Regexp},
+						SpanNames: []string{"^/health.*"},
+						Attributes: []filterconfig.Attribute{
+							{Key: "http.method", Value: "GET"},
+						},
+					},
+				},
+			},
+		},
+		{
+			filterName: "filter/logs",
+			expCfg: &Config{
+				ProcessorSettings: configmodels.ProcessorSettings{
+					NameVal: "filter/logs",
+					TypeVal: typeStr,
+				},
+				Logs: LogFilters{
+					Include: &filterconfig.MatchProperties{
+						Config:        filterset.Config{MatchType: filterset.Strict},
+						SeverityTexts: []string{"WARN", "ERROR"},
+						Resources: []filterconfig.Attribute{
+							{Key: "service.name", Value: "checkout"},
+						},
+					},
+					Exclude: &filterconfig.MatchProperties{
+						Config:   filterset.Config{MatchType: filterset.Regexp},
+						LogNames: []string{".*healthcheck.*"},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.filterName, func(t *testing.T) {
+			cfg := config.Processors[test.filterName]
+			assert.Equal(t, test.expCfg, cfg)
+		})
+	}
+}
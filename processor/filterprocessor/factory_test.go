@@ -61,6 +61,9 @@ func TestCreateProcessors(t *testing.T) {
 		}, {
 			configName: "config_strict.yaml",
 			succeed:    true,
+		}, {
+			configName: "config_traces_logs.yaml",
+			succeed:    true,
 		}, {
 			configName: "config_invalid.yaml",
 			succeed:    false,
@@ -80,10 +83,14 @@ func TestCreateProcessors(t *testing.T) {
 			t.Run(fmt.Sprintf("%s/%s", test.configName, name), func(t *testing.T) {
 				factory := NewFactory()
 
+				// The configurations do not filter spans nor log records
 				tp, tErr := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewTracesNop())
-				// Not implemented error
-				assert.NotNil(t, tErr)
-				assert.Nil(t, tp)
+				assert.NoError(t, tErr)
+				assert.NotNil(t, tp)
+
+				lp, lErr := factory.CreateLogsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewLogsNop())
+				assert.NoError(t, lErr)
+				assert.NotNil(t, lp)
 
 				mp, mErr := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewMetricsNop())
 				assert.Equal(t, test.succeed, mp != nil)
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authorl
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type filterLogProcessor struct {
+	include filterlog.Matcher
+	exclude filterlog.Matcher
+}
+
+func newFilterLogProcessor(logger *zap.Logger, cfg *Config) (*filterLogProcessor, error) {
+	inc, err := filterlog.NewMatcher(cfg.Logs.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exc, err := filterlog.NewMatcher(cfg.Logs.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(
+		"Log filter configured",
+		zap.Any("include", cfg.Logs.Include),
+		zap.Any("exclude", cfg.Logs.Exclude),
+	)
+
+	return &filterLogProcessor{
+		include: inc,
+		exclude: exc,
+	}, nil
+}
+
+// ProcessLogs filters the given log records based off the filterLogProcessor's filters.
+// The resources and the instrumentation libraries left without any log record are dropped.
+func (flp *filterLogProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	out := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource := rl.Resource()
+
+		rlOut := pdata.NewResourceLogs()
+		illsIn := rl.InstrumentationLibraryLogs()
+		for j := 0; j < illsIn.Len(); j++ {
+			ill := illsIn.At(j)
+			library := ill.InstrumentationLibrary()
+
+			illOut := pdata.NewInstrumentationLibraryLogs()
+			lrs := ill.Logs()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				if flp.shouldKeepLogRecord(lr, resource, library) {
+					illOut.Logs().Append(lr)
+				}
+			}
+
+			if illOut.Logs().Len() > 0 {
+				library.CopyTo(illOut.InstrumentationLibrary())
+				rlOut.InstrumentationLibraryLogs().Append(illOut)
+			}
+		}
+
+		if rlOut.InstrumentationLibraryLogs().Len() > 0 {
+			resource.CopyTo(rlOut.Resource())
+			out.ResourceLogs().Append(rlOut)
+		}
+	}
+
+	if out.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return out, nil
+}
+
+func (flp *filterLogProcessor) shouldKeepLogRecord(lr pdata.LogRecord, resource pdata.Resource, library pdata.InstrumentationLibrary) bool {
+	if flp.include != nil && !flp.include.MatchLogRecord(lr, resource, library) {
+		return false
+	}
+
+	if flp.exclude != nil && flp.exclude.MatchLogRecord(lr, resource, library) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+type logSeverity struct {
+	name     string
+	severity string
+}
+
+type logSeverityTest struct {
+	name   string
+	inc    *filterconfig.MatchProperties
+	exc    *filterconfig.MatchProperties
+	inLR   [][]logSeverity // input log records, per resource
+	outLR  [][]logSeverity // output log records, per resource
+	allOut bool
+}
+
+func TestFilterLogProcessor(t *testing.T) {
+	tests := []logSeverityTest{
+		{
+			name: "includeSeverities",
+			inc: &filterconfig.MatchProperties{
+				Config:        filterset.Config{MatchType: filterset.Strict},
+				SeverityTexts: []string{"WARN", "ERROR"},
+			},
+			inLR: [][]logSeverity{
+				{{"login", "INFO"}, {"login", "ERROR"}},
+				{{"checkout", "DEBUG"}},
+				{{"checkout", "WARN"}},
+			},
+			outLR: [][]logSeverity{
+				{{"login", "ERROR"}},
+				{{"checkout", "WARN"}},
+			},
+		},
+		{
+			name: "excludeNames",
+			exc: &filterconfig.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Regexp},
+				LogNames: []string{".*healthcheck.*"},
+			},
+			inLR: [][]logSeverity{
+				{{"healthcheck", "INFO"}, {"login", "INFO"}, {"db-healthcheck", "ERROR"}},
+			},
+			outLR: [][]logSeverity{
+				{{"login", "INFO"}},
+			},
+		},
+		{
+			name: "allFiltered",
+			inc: &filterconfig.MatchProperties{
+				Config:        filterset.Config{MatchType: filterset.Regexp},
+				SeverityTexts: []string{"^FATAL$"},
+			},
+			inLR: [][]logSeverity{
+				{{"login", "INFO"}, {"login", "ERROR"}},
+			},
+			allOut: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := new(consumertest.LogsSink)
+			cfg := &Config{
+				Logs: LogFilters{
+					Include: test.inc,
+					Exclude: test.exc,
+				},
+			}
+			factory := NewFactory()
+			fp, err := factory.CreateLogsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, next)
+			require.NoError(t, err)
+			require.NotNil(t, fp)
+
+			require.NoError(t, fp.ConsumeLogs(context.Background(), logsWithSeverities(test.inLR)))
+
+			if test.allOut {
+				assert.Empty(t, next.AllLogs())
+				return
+			}
+			require.Len(t, next.AllLogs(), 1)
+			assert.Equal(t, logsWithSeverities(test.outLR), next.AllLogs()[0])
+		})
+	}
+}
+
+func TestFilterLogProcessor_InvalidConfig(t *testing.T) {
+	cfg := &Config{
+		Logs: LogFilters{
+			Include: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Strict},
+				SpanNames: []string{"span"},
+			},
+		},
+	}
+	fp, err := NewFactory().CreateLogsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewLogsNop())
+	assert.Error(t, err)
+	assert.Nil(t, fp)
+}
+
+func logsWithSeverities(records [][]logSeverity) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(len(records))
+	for i, resourceRecords := range records {
+		rl := ld.ResourceLogs().At(i)
+		rl.InstrumentationLibraryLogs().Resize(1)
+		ill := rl.InstrumentationLibraryLogs().At(0)
+		ill.Logs().Resize(len(resourceRecords))
+		for j, record := range resourceRecords {
+			lr := ill.Logs().At(j)
+			lr.SetName(record.name)
+			lr.SetSeverityText(record.severity)
+		}
+	}
+	return ld
+}
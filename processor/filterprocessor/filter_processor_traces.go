@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type filterSpanProcessor struct {
+	include filterspan.Matcher
+	exclude filterspan.Matcher
+}
+
+func newFilterSpanProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	inc, err := filterspan.NewMatcher(cfg.Spans.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exc, err := filterspan.NewMatcher(cfg.Spans.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(
+		"Span filter configured",
+		zap.Any("include", cfg.Spans.Include),
+		zap.Any("exclude", cfg.Spans.Exclude),
+	)
+
+	return &filterSpanProcessor{
+		include: inc,
+		exclude: exc,
+	}, nil
+}
+
+// ProcessTraces filters the given spans based off the filterSpanProcessor's filters.
+// The resources and the instrumentation libraries left without any span are dropped.
+func (fsp *filterSpanProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	out := pdata.NewTraces()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := rs.Resource()
+
+		rsOut := pdata.NewResourceSpans()
+		ilssIn := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilssIn.Len(); j++ {
+			ils := ilssIn.At(j)
+			library := ils.InstrumentationLibrary()
+
+			ilsOut := pdata.NewInstrumentationLibrarySpans()
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if fsp.shouldKeepSpan(span, resource, library) {
+					ilsOut.Spans().Append(span)
+				}
+			}
+
+			if ilsOut.Spans().Len() > 0 {
+				library.CopyTo(ilsOut.InstrumentationLibrary())
+				rsOut.InstrumentationLibrarySpans().Append(ilsOut)
+			}
+		}
+
+		if rsOut.InstrumentationLibrarySpans().Len() > 0 {
+			resource.CopyTo(rsOut.Resource())
+			out.ResourceSpans().Append(rsOut)
+		}
+	}
+
+	if out.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return out, nil
+}
+
+func (fsp *filterSpanProcessor) shouldKeepSpan(span pdata.Span, resource pdata.Resource, library pdata.InstrumentationLibrary) bool {
+	if fsp.include != nil && !fsp.include.MatchSpan(span, resource, library) {
+		return false
+	}
+
+	if fsp.exclude != nil && fsp.exclude.MatchSpan(span, resource, library) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+type spanNameTest struct {
+	name   string
+	inc    *filterconfig.MatchProperties
+	exc    *filterconfig.MatchProperties
+	inSN   [][]string // input span names, per resource
+	outSN  [][]string // output span names, per resource
+	allOut bool
+}
+
+func TestFilterSpanProcessor(t *testing.T) {
+	tests := []spanNameTest{
+		{
+			name: "excludeHealthChecks",
+			exc: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				SpanNames: []string{"^/health.*"},
+			},
+			inSN:  [][]string{{"/healthz", "/checkout", "/health/ready"}, {"/health"}},
+			outSN: [][]string{{"/checkout"}},
+		},
+		{
+			name: "includeStrict",
+			inc: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Strict},
+				SpanNames: []string{"/checkout"},
+			},
+			inSN:  [][]string{{"/healthz", "/checkout"}, {"/cart", "/checkout"}},
+			outSN: [][]string{{"/checkout"}, {"/checkout"}},
+		},
+		{
+			name: "includeExclude",
+			inc: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				SpanNames: []string{"^/c.*"},
+			},
+			exc: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Strict},
+				SpanNames: []string{"/cart"},
+			},
+			inSN:  [][]string{{"/healthz", "/checkout", "/cart"}},
+			outSN: [][]string{{"/checkout"}},
+		},
+		{
+			name: "allFiltered",
+			exc: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				SpanNames: []string{".*"},
+			},
+			inSN:   [][]string{{"/healthz", "/checkout"}},
+			allOut: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := new(consumertest.TracesSink)
+			cfg := &Config{
+				Spans: SpanFilters{
+					Include: test.inc,
+					Exclude: test.exc,
+				},
+			}
+			factory := NewFactory()
+			fp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, next)
+			require.NoError(t, err)
+			require.NotNil(t, fp)
+
+			require.NoError(t, fp.ConsumeTraces(context.Background(), tracesWithSpanNames(test.inSN)))
+
+			if test.allOut {
+				assert.Empty(t, next.AllTraces())
+				return
+			}
+			require.Len(t, next.AllTraces(), 1)
+			assert.Equal(t, tracesWithSpanNames(test.outSN), next.AllTraces()[0])
+		})
+	}
+}
+
+func TestFilterSpanProcessor_Resources(t *testing.T) {
+	cfg := &Config{
+		Spans: SpanFilters{
+			Exclude: &filterconfig.MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				Resources: []filterconfig.Attribute{
+					{Key: "service.name", Value: "healthchecker"},
+				},
+			},
+		},
+	}
+	next := new(consumertest.TracesSink)
+	fp, err := NewFactory().CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, next)
+	require.NoError(t, err)
+
+	td := tracesWithSpanNames([][]string{{"/healthz"}, {"/checkout"}})
+	td.ResourceSpans().At(0).Resource().Attributes().InsertString("service.name", "healthchecker")
+	require.NoError(t, fp.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, next.AllTraces(), 1)
+	assert.Equal(t, tracesWithSpanNames([][]string{{"/checkout"}}), next.AllTraces()[0])
+}
+
+func tracesWithSpanNames(names [][]string) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(len(names))
+	for i, spanNames := range names {
+		rs := td.ResourceSpans().At(i)
+		rs.InstrumentationLibrarySpans().Resize(1)
+		ils := rs.InstrumentationLibrarySpans().At(0)
+		ils.Spans().Resize(len(spanNames))
+		for j, name := range spanNames {
+			ils.Spans().At(j).SetName(name)
+		}
+	}
+	return td
+}
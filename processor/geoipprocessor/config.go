@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for GeoIP processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// IPAttribute is the key of the attribute holding the IP address to look up.
+	// It is looked up in the attributes of the resources, the spans and the log
+	// records, the location is added next to the IP address.
+	IPAttribute string `mapstructure:"ip_attribute"`
+
+	// CityDatabase is the path of the MaxMind database the country, region and
+	// city are looked up in, e.g. GeoLite2-City.mmdb.
+	CityDatabase string `mapstructure:"city_database"`
+
+	// ASNDatabase is the path of the MaxMind database the autonomous system is
+	// looked up in, e.g. GeoLite2-ASN.mmdb.
+	ASNDatabase string `mapstructure:"asn_database"`
+
+	// RefreshInterval is the interval the database files are checked for
+	// modifications at, they are reloaded when their modification time changes.
+	// The files are never reloaded if it is 0.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
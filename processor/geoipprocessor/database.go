@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// geoDatabase is a MaxMind database file, reloaded when it is modified.
+type geoDatabase struct {
+	path string
+
+	lock    sync.RWMutex
+	reader  *mmdbReader
+	modTime time.Time
+}
+
+func newGeoDatabase(path string) *geoDatabase {
+	return &geoDatabase{path: path}
+}
+
+// load reads the database file if it was modified since it was last read, and
+// returns whether it was. The previous database is kept if the file is invalid.
+func (db *geoDatabase) load() (bool, error) {
+	info, err := os.Stat(db.path)
+	if err != nil {
+		return false, err
+	}
+	db.lock.RLock()
+	unchanged := db.reader != nil && info.ModTime().Equal(db.modTime)
+	db.lock.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	buf, err := ioutil.ReadFile(db.path)
+	if err != nil {
+		return false, err
+	}
+	reader, err := newMMDBReader(buf)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", db.path, err)
+	}
+
+	db.lock.Lock()
+	db.reader = reader
+	db.modTime = info.ModTime()
+	db.lock.Unlock()
+	return true, nil
+}
+
+// lookup returns the record of the IP address, or nil if it is not found.
+func (db *geoDatabase) lookup(ip net.IP) (map[string]interface{}, error) {
+	db.lock.RLock()
+	reader := db.reader
+	db.lock.RUnlock()
+	if reader == nil {
+		return nil, nil
+	}
+	return reader.lookup(ip)
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "geoip"
+
+	defaultIPAttribute     = "client.ip"
+	defaultRefreshInterval = time.Hour
+)
+
+var (
+	errNoIPAttribute           = errors.New("missing required field \"ip_attribute\"")
+	errNoDatabase              = errors.New("at least one of \"city_database\" and \"asn_database\" must be specified")
+	errNegativeRefreshInterval = errors.New("refresh_interval can't be negative")
+)
+
+var processorCapabilities = component.ProcessorCapabilities{MutatesConsumedData: true}
+
+// NewFactory returns a new factory for the GeoIP processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		IPAttribute:     defaultIPAttribute,
+		RefreshInterval: defaultRefreshInterval,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.TracesConsumer) (component.TracesProcessor, error) {
+	gp, err := createGeoIPProcessor(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewTraceProcessor(
+		cfg,
+		nextConsumer,
+		gp,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(gp.start),
+		processorhelper.WithShutdown(gp.shutdown))
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.LogsConsumer) (component.LogsProcessor, error) {
+	gp, err := createGeoIPProcessor(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		gp,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(gp.start),
+		processorhelper.WithShutdown(gp.shutdown))
+}
+
+func createGeoIPProcessor(params component.ProcessorCreateParams, cfg configmodels.Processor) (*geoIPProcessor, error) {
+	oCfg := cfg.(*Config)
+	var err error
+	switch {
+	case oCfg.IPAttribute == "":
+		err = errNoIPAttribute
+	case oCfg.CityDatabase == "" && oCfg.ASNDatabase == "":
+		err = errNoDatabase
+	case oCfg.RefreshInterval < 0:
+		err = errNegativeRefreshInterval
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating %q processor: %w", cfg.Name(), err)
+	}
+	return newGeoIPProcessor(params.Logger, oCfg), nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.CityDatabase = "GeoLite2-City.mmdb"
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	assert.NotNil(t, tp)
+	assert.True(t, tp.GetCapabilities().MutatesConsumedData)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, lp)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+}
+
+func TestCreateProcessors_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "no IP attribute",
+			modify: func(cfg *Config) { cfg.IPAttribute = "" },
+			err:    `error creating "geoip" processor: missing required field "ip_attribute"`,
+		},
+		{
+			name:   "no database",
+			modify: func(cfg *Config) { cfg.CityDatabase = "" },
+			err:    `error creating "geoip" processor: at least one of "city_database" and "asn_database" must be specified`,
+		},
+		{
+			name:   "negative refresh interval",
+			modify: func(cfg *Config) { cfg.RefreshInterval = -1 },
+			err:    `error creating "geoip" processor: refresh_interval can't be negative`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.CityDatabase = "GeoLite2-City.mmdb"
+			tt.modify(cfg)
+			params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+			tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+			assert.EqualError(t, err, tt.err)
+			assert.Nil(t, tp)
+
+			lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+			assert.EqualError(t, err, tt.err)
+			assert.Nil(t, lp)
+		})
+	}
+}
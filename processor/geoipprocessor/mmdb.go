@@ -0,0 +1,354 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+)
+
+// The MaxMind DB format is described in
+// https://maxmind.github.io/MaxMind-DB/. The files are made of a binary
+// search tree on the bits of the IP addresses, followed by a data section
+// holding the records of the networks, and by the metadata of the database.
+
+var metadataMarker = []byte("\xab\xcd\xefMaxMind.com")
+
+const (
+	// dataSectionSeparatorSize is the size of the zeros between the search
+	// tree and the data section.
+	dataSectionSeparatorSize = 16
+	// maxDecodingDepth limits the nesting of the decoded values, protecting
+	// against the corrupted files with pointer loops.
+	maxDecodingDepth = 32
+)
+
+// The types of the fields of the data section.
+const (
+	mmdbExtended = iota
+	mmdbPointer
+	mmdbString
+	mmdbDouble
+	mmdbBytes
+	mmdbUint16
+	mmdbUint32
+	mmdbMap
+	mmdbInt32
+	mmdbUint64
+	mmdbUint128
+	mmdbArray
+	mmdbContainer
+	mmdbEndMarker
+	mmdbBool
+	mmdbFloat
+)
+
+var errInvalidDatabase = errors.New("invalid MaxMind database")
+
+// mmdbReader looks up the IP addresses in a MaxMind database loaded in memory.
+type mmdbReader struct {
+	tree         []byte
+	data         mmdbDecoder
+	nodeCount    uint
+	recordSize   uint
+	ipVersion    uint
+	databaseType string
+	// ipv4Start is the node of the IPv4 addresses in the IPv6 databases,
+	// reached after the 96 leading zero bits of the IPv4-compatible addresses.
+	ipv4Start uint
+}
+
+// newMMDBReader parses the metadata of the database in buf.
+func newMMDBReader(buf []byte) (*mmdbReader, error) {
+	markerIdx := bytes.LastIndex(buf, metadataMarker)
+	if markerIdx < 0 {
+		return nil, fmt.Errorf("%w: metadata not found", errInvalidDatabase)
+	}
+	metadataStart := markerIdx + len(metadataMarker)
+	value, _, err := mmdbDecoder{buf: buf[metadataStart:]}.decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidDatabase, err)
+	}
+	metadata, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", errInvalidDatabase)
+	}
+
+	r := &mmdbReader{}
+	r.nodeCount, _ = metadataUint(metadata, "node_count")
+	r.recordSize, _ = metadataUint(metadata, "record_size")
+	r.ipVersion, _ = metadataUint(metadata, "ip_version")
+	r.databaseType, _ = metadata["database_type"].(string)
+	if r.recordSize != 24 && r.recordSize != 28 && r.recordSize != 32 {
+		return nil, fmt.Errorf("%w: unsupported record size %d", errInvalidDatabase, r.recordSize)
+	}
+	if r.ipVersion != 4 && r.ipVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported IP version %d", errInvalidDatabase, r.ipVersion)
+	}
+
+	treeSize := r.nodeCount * r.recordSize / 4
+	if treeSize+dataSectionSeparatorSize > uint(markerIdx) {
+		return nil, fmt.Errorf("%w: search tree larger than the file", errInvalidDatabase)
+	}
+	r.tree = buf[:treeSize]
+	r.data = mmdbDecoder{buf: buf[treeSize+dataSectionSeparatorSize : markerIdx]}
+
+	if r.ipVersion == 6 {
+		for i := 0; i < 96 && r.ipv4Start < r.nodeCount; i++ {
+			r.ipv4Start = r.readNode(r.ipv4Start, 0)
+		}
+	}
+	return r, nil
+}
+
+func metadataUint(metadata map[string]interface{}, key string) (uint, bool) {
+	v, ok := metadata[key].(uint)
+	return v, ok
+}
+
+// lookup returns the record of the network of the IP address, or nil if the
+// address is not in the database.
+func (r *mmdbReader) lookup(ip net.IP) (map[string]interface{}, error) {
+	node := uint(0)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		if r.ipVersion == 6 {
+			node = r.ipv4Start
+		}
+	} else if r.ipVersion == 4 {
+		return nil, nil
+	}
+
+	bitCount := len(ip) * 8
+	for i := 0; i < bitCount && node < r.nodeCount; i++ {
+		bit := (ip[i>>3] >> (7 - uint(i&7))) & 1
+		node = r.readNode(node, bit)
+	}
+	switch {
+	case node == r.nodeCount:
+		return nil, nil
+	case node < r.nodeCount:
+		return nil, fmt.Errorf("%w: search tree deeper than the addresses", errInvalidDatabase)
+	}
+
+	value, _, err := r.data.decode(node-r.nodeCount-dataSectionSeparatorSize, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidDatabase, err)
+	}
+	record, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: record is not a map", errInvalidDatabase)
+	}
+	return record, nil
+}
+
+// readNode returns the left (bit 0) or right (bit 1) record of the node.
+func (r *mmdbReader) readNode(node uint, bit byte) uint {
+	switch r.recordSize {
+	case 24:
+		off := node*6 + uint(bit)*3
+		return uint(r.tree[off])<<16 | uint(r.tree[off+1])<<8 | uint(r.tree[off+2])
+	case 28:
+		off := node * 7
+		if bit == 0 {
+			return uint(r.tree[off+3]&0xf0)<<20 | uint(r.tree[off])<<16 | uint(r.tree[off+1])<<8 | uint(r.tree[off+2])
+		}
+		return uint(r.tree[off+3]&0x0f)<<24 | uint(r.tree[off+4])<<16 | uint(r.tree[off+5])<<8 | uint(r.tree[off+6])
+	default:
+		off := node*8 + uint(bit)*4
+		return uint(r.tree[off])<<24 | uint(r.tree[off+1])<<16 | uint(r.tree[off+2])<<8 | uint(r.tree[off+3])
+	}
+}
+
+// mmdbDecoder decodes the fields of a data section, the pointers are offsets
+// from the start of the section.
+type mmdbDecoder struct {
+	buf []byte
+}
+
+// decode returns the value at the offset, and the offset following it.
+func (d mmdbDecoder) decode(offset uint, depth int) (interface{}, uint, error) {
+	if depth > maxDecodingDepth {
+		return nil, 0, errors.New("maximum data nesting depth exceeded")
+	}
+	typ, size, offset, err := d.decodeControl(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch typ {
+	case mmdbPointer:
+		value, _, err := d.decode(size, depth+1)
+		return value, offset, err
+	case mmdbMap:
+		if err = d.checkCount(offset, size); err != nil {
+			return nil, 0, err
+		}
+		m := make(map[string]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			var key, value interface{}
+			if key, offset, err = d.decode(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, 0, errors.New("map key is not a string")
+			}
+			if value, offset, err = d.decode(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			m[k] = value
+		}
+		return m, offset, nil
+	case mmdbArray:
+		if err = d.checkCount(offset, size); err != nil {
+			return nil, 0, err
+		}
+		a := make([]interface{}, 0, size)
+		for i := uint(0); i < size; i++ {
+			var value interface{}
+			if value, offset, err = d.decode(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			a = append(a, value)
+		}
+		return a, offset, nil
+	case mmdbBool:
+		return size != 0, offset, nil
+	}
+
+	b, err := d.bytes(offset, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += size
+	switch typ {
+	case mmdbString:
+		return string(b), offset, nil
+	case mmdbBytes:
+		return append([]byte(nil), b...), offset, nil
+	case mmdbDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("invalid double size %d", size)
+		}
+		return math.Float64frombits(uint64(decodeUint(b))), offset, nil
+	case mmdbFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("invalid float size %d", size)
+		}
+		return math.Float32frombits(uint32(decodeUint(b))), offset, nil
+	case mmdbUint16, mmdbUint32, mmdbUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("invalid integer size %d", size)
+		}
+		return decodeUint(b), offset, nil
+	case mmdbInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("invalid integer size %d", size)
+		}
+		return int32(uint32(decodeUint(b))), offset, nil
+	case mmdbUint128:
+		return new(big.Int).SetBytes(b), offset, nil
+	default:
+		return nil, 0, fmt.Errorf("unexpected data type %d", typ)
+	}
+}
+
+// decodeControl returns the type and the size of the field at the offset, and
+// the offset of its payload. The size of the pointers is the offset they
+// point to.
+func (d mmdbDecoder) decodeControl(offset uint) (uint, uint, uint, error) {
+	b, err := d.bytes(offset, 1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	ctrl := b[0]
+	offset++
+
+	typ := uint(ctrl >> 5)
+	if typ == mmdbExtended {
+		if b, err = d.bytes(offset, 1); err != nil {
+			return 0, 0, 0, err
+		}
+		typ = 7 + uint(b[0])
+		offset++
+	}
+
+	if typ == mmdbPointer {
+		n := uint(ctrl>>3)&0x3 + 1
+		if b, err = d.bytes(offset, n); err != nil {
+			return 0, 0, 0, err
+		}
+		offset += n
+		v := decodeUint(b)
+		switch n {
+		case 1:
+			return typ, uint(ctrl&0x7)<<8 | v, offset, nil
+		case 2:
+			return typ, uint(ctrl&0x7)<<16 | v + 2048, offset, nil
+		case 3:
+			return typ, uint(ctrl&0x7)<<24 | v + 526336, offset, nil
+		default:
+			return typ, v, offset, nil
+		}
+	}
+
+	size := uint(ctrl & 0x1f)
+	if size >= 29 {
+		n := size - 28
+		if b, err = d.bytes(offset, n); err != nil {
+			return 0, 0, 0, err
+		}
+		offset += n
+		switch n {
+		case 1:
+			size = 29 + decodeUint(b)
+		case 2:
+			size = 285 + decodeUint(b)
+		default:
+			size = 65821 + decodeUint(b)
+		}
+	}
+	return typ, size, offset, nil
+}
+
+// checkCount returns an error if the number of entries of a map or an array
+// at the offset can't fit in the rest of the data, each entry taking at least
+// a byte, so that a corrupted count can't cause a huge allocation.
+func (d mmdbDecoder) checkCount(offset, count uint) error {
+	if count > uint(len(d.buf))-offset {
+		return fmt.Errorf("%d entries exceed the %d bytes of remaining data", count, uint(len(d.buf))-offset)
+	}
+	return nil
+}
+
+func (d mmdbDecoder) bytes(offset, size uint) ([]byte, error) {
+	if offset+size > uint(len(d.buf)) || offset+size < offset {
+		return nil, errors.New("unexpected end of data")
+	}
+	return d.buf[offset : offset+size], nil
+}
+
+func decodeUint(b []byte) uint {
+	v := uint(0)
+	for _, c := range b {
+		v = v<<8 | uint(c)
+	}
+	return v
+}
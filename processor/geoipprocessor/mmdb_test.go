@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"math/rand"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNetwork struct {
+	cidr   string
+	record map[string]interface{}
+}
+
+// buildTestDatabase returns an IPv6 MaxMind database holding the networks,
+// the IPv4 networks are stored as IPv4-compatible IPv6 networks.
+func buildTestDatabase(t *testing.T, recordSize uint, databaseType string, networks ...testNetwork) []byte {
+	// The records of the nodes are 0 when empty, the index of the child node
+	// when positive, and -(index of the data + 1) when negative.
+	nodes := [][2]int{{0, 0}}
+	for i, network := range networks {
+		_, ipNet, err := net.ParseCIDR(network.cidr)
+		require.NoError(t, err)
+		ip := ipNet.IP.To16()
+		ones, _ := ipNet.Mask.Size()
+		if ipNet.IP.To4() != nil {
+			ip = append(make(net.IP, 12), ipNet.IP.To4()...)
+			ones += 96
+		}
+		node := 0
+		for b := 0; b < ones; b++ {
+			bit := (ip[b/8] >> (7 - uint(b%8))) & 1
+			if b == ones-1 {
+				nodes[node][bit] = -(i + 1)
+				break
+			}
+			if nodes[node][bit] <= 0 {
+				nodes = append(nodes, [2]int{0, 0})
+				nodes[node][bit] = len(nodes) - 1
+			}
+			node = nodes[node][bit]
+		}
+	}
+
+	var data []byte
+	offsets := make([]int, len(networks))
+	for i, network := range networks {
+		offsets[i] = len(data)
+		data = append(data, encodeTestValue(network.record)...)
+	}
+
+	nodeCount := len(nodes)
+	value := func(record int) uint {
+		switch {
+		case record == 0:
+			return uint(nodeCount)
+		case record > 0:
+			return uint(record)
+		default:
+			return uint(nodeCount + dataSectionSeparatorSize + offsets[-record-1])
+		}
+	}
+	var buf []byte
+	for _, node := range nodes {
+		left, right := value(node[0]), value(node[1])
+		switch recordSize {
+		case 24:
+			buf = append(buf, byte(left>>16), byte(left>>8), byte(left), byte(right>>16), byte(right>>8), byte(right))
+		case 28:
+			buf = append(buf, byte(left>>16), byte(left>>8), byte(left), byte(left>>24)<<4|byte(right>>24),
+				byte(right>>16), byte(right>>8), byte(right))
+		default:
+			buf = append(buf, byte(left>>24), byte(left>>16), byte(left>>8), byte(left),
+				byte(right>>24), byte(right>>16), byte(right>>8), byte(right))
+		}
+	}
+	buf = append(buf, make([]byte, dataSectionSeparatorSize)...)
+	buf = append(buf, data...)
+	buf = append(buf, metadataMarker...)
+	return append(buf, encodeTestValue(map[string]interface{}{
+		"node_count":    uint(nodeCount),
+		"record_size":   recordSize,
+		"ip_version":    uint(6),
+		"database_type": databaseType,
+	})...)
+}
+
+// encodeTestControl encodes the control byte of the fields of less than 285
+// bytes.
+func encodeTestControl(typ, size int) []byte {
+	var buf []byte
+	sizeBits := size
+	if size >= 29 {
+		sizeBits = 29
+	}
+	if typ <= 7 {
+		buf = []byte{byte(typ<<5 | sizeBits)}
+	} else {
+		buf = []byte{byte(sizeBits), byte(typ - 7)}
+	}
+	if size >= 29 {
+		buf = append(buf, byte(size-29))
+	}
+	return buf
+}
+
+func encodeTestValue(value interface{}) []byte {
+	switch v := value.(type) {
+	case string:
+		return append(encodeTestControl(mmdbString, len(v)), v...)
+	case uint:
+		b := []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+		return append(encodeTestControl(mmdbUint32, len(b)), b...)
+	case []interface{}:
+		buf := encodeTestControl(mmdbArray, len(v))
+		for _, e := range v {
+			buf = append(buf, encodeTestValue(e)...)
+		}
+		return buf
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf := encodeTestControl(mmdbMap, len(v))
+		for _, k := range keys {
+			buf = append(buf, encodeTestValue(k)...)
+			buf = append(buf, encodeTestValue(v[k])...)
+		}
+		return buf
+	default:
+		panic("unsupported test value")
+	}
+}
+
+func TestMMDBReader(t *testing.T) {
+	networks := []testNetwork{
+		{cidr: "81.2.69.0/24", record: map[string]interface{}{"name": "v4"}},
+		{cidr: "81.2.70.0/23", record: map[string]interface{}{"name": "v4 wider"}},
+		{cidr: "2001:db8::/32", record: map[string]interface{}{"name": "v6"}},
+	}
+	for _, recordSize := range []uint{24, 28, 32} {
+		r, err := newMMDBReader(buildTestDatabase(t, recordSize, "Test", networks...))
+		require.NoError(t, err)
+		assert.Equal(t, "Test", r.databaseType)
+		assert.Equal(t, uint(6), r.ipVersion)
+
+		for ip, expected := range map[string]interface{}{
+			"81.2.69.142":      "v4",
+			"81.2.71.1":        "v4 wider",
+			"::ffff:81.2.69.1": "v4",
+			"2001:db8::1":      "v6",
+			"81.2.72.1":        nil,
+			"2001:db9::1":      nil,
+		} {
+			record, err := r.lookup(net.ParseIP(ip))
+			require.NoError(t, err)
+			if expected == nil {
+				assert.Nil(t, record, ip)
+			} else {
+				assert.Equal(t, expected, record["name"], ip)
+			}
+		}
+	}
+}
+
+func TestMMDBReader_Invalid(t *testing.T) {
+	_, err := newMMDBReader([]byte("not a database"))
+	assert.EqualError(t, err, "invalid MaxMind database: metadata not found")
+
+	buf := append(append([]byte(nil), metadataMarker...), encodeTestValue(map[string]interface{}{
+		"node_count":  uint(1),
+		"record_size": uint(20),
+		"ip_version":  uint(6),
+	})...)
+	_, err = newMMDBReader(buf)
+	assert.EqualError(t, err, "invalid MaxMind database: unsupported record size 20")
+
+	buf = append(append([]byte(nil), metadataMarker...), encodeTestValue(map[string]interface{}{
+		"node_count":  uint(1000),
+		"record_size": uint(24),
+		"ip_version":  uint(6),
+	})...)
+	_, err = newMMDBReader(buf)
+	assert.EqualError(t, err, "invalid MaxMind database: search tree larger than the file")
+}
+
+func TestMMDBDecoder(t *testing.T) {
+	buf := []byte{
+		// 0: "en"
+		0x42, 'e', 'n',
+		// 3: {pointer to "en": true}
+		0xe1, 0x20, 0x00, 0x01, 0x07,
+		// 8: int32 -1
+		0x04, 0x01, 0xff, 0xff, 0xff, 0xff,
+		// 14: double 1.5
+		0x68, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0,
+		// 23: 30 bytes string
+		0x5d, 0x01,
+	}
+	long := "abcdefghijklmnopqrstuvwxyz0123"
+	buf = append(buf, long...)
+	d := mmdbDecoder{buf: buf}
+
+	value, next, err := d.decode(3, 0)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"en": true}, value)
+	assert.Equal(t, uint(8), next)
+
+	value, next, err = d.decode(next, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(-1), value)
+
+	value, next, err = d.decode(next, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, value)
+
+	value, next, err = d.decode(next, 0)
+	require.NoError(t, err)
+	assert.Equal(t, long, value)
+	assert.Equal(t, uint(len(buf)), next)
+
+	_, _, err = mmdbDecoder{buf: buf[:20]}.decode(14, 0)
+	assert.EqualError(t, err, "unexpected end of data")
+
+	loop := []byte{0x20, 0x00}
+	_, _, err = mmdbDecoder{buf: loop}.decode(0, 0)
+	assert.EqualError(t, err, "maximum data nesting depth exceeded")
+}
+
+func TestMMDBDecoder_CorruptedCount(t *testing.T) {
+	tests := []struct {
+		name string
+		buf  []byte
+		err  string
+	}{
+		{
+			name: "map",
+			// Map of 65821+0xffffff entries, followed by a single entry.
+			buf: []byte{0xff, 0xff, 0xff, 0xff, 0x41, 'a', 0x41, 'b'},
+			err: "16843036 entries exceed the 4 bytes of remaining data",
+		},
+		{
+			name: "array",
+			// Array of 285+0xffff entries, followed by a single entry.
+			buf: []byte{0x1e, 0x04, 0xff, 0xff, 0x41, 'a'},
+			err: "65820 entries exceed the 2 bytes of remaining data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := mmdbDecoder{buf: tt.buf}.decode(0, 0)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestMMDBReader_CorruptedDatabase(t *testing.T) {
+	db := buildTestDatabase(t, 24, "Test",
+		testNetwork{cidr: "81.2.69.0/24", record: map[string]interface{}{"names": []interface{}{"a", "b"}}},
+		testNetwork{cidr: "2001:db8::/32", record: map[string]interface{}{"name": "v6"}})
+	ips := []net.IP{net.ParseIP("81.2.69.142"), net.ParseIP("2001:db8::1"), net.ParseIP("81.2.72.1")}
+
+	// The corrupted databases are rejected or looked up without panics nor
+	// huge allocations.
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		buf := append([]byte(nil), db...)
+		for j := rnd.Intn(4); j >= 0; j-- {
+			buf[rnd.Intn(len(buf))] = byte(rnd.Intn(256))
+		}
+		r, err := newMMDBReader(buf)
+		if err != nil {
+			continue
+		}
+		for _, ip := range ips {
+			_, _ = r.lookup(ip)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// The attributes added to the spans and the log records.
+const (
+	attributeCountryISOCode = "geo.country.iso_code"
+	attributeCountryName    = "geo.country.name"
+	attributeRegionISOCode  = "geo.region.iso_code"
+	attributeRegionName     = "geo.region.name"
+	attributeCityName       = "geo.city.name"
+	attributeASNumber       = "as.number"
+	attributeASOrganization = "as.organization.name"
+)
+
+// geoIPProcessor adds the location of the IP address of the configured
+// attribute to the attributes holding it.
+type geoIPProcessor struct {
+	logger          *zap.Logger
+	ipAttribute     string
+	cityDB          *geoDatabase
+	asnDB           *geoDatabase
+	refreshInterval time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newGeoIPProcessor(logger *zap.Logger, cfg *Config) *geoIPProcessor {
+	gp := &geoIPProcessor{
+		logger:          logger,
+		ipAttribute:     cfg.IPAttribute,
+		refreshInterval: cfg.RefreshInterval,
+	}
+	if cfg.CityDatabase != "" {
+		gp.cityDB = newGeoDatabase(cfg.CityDatabase)
+	}
+	if cfg.ASNDatabase != "" {
+		gp.asnDB = newGeoDatabase(cfg.ASNDatabase)
+	}
+	return gp
+}
+
+func (gp *geoIPProcessor) databases() []*geoDatabase {
+	var dbs []*geoDatabase
+	for _, db := range []*geoDatabase{gp.cityDB, gp.asnDB} {
+		if db != nil {
+			dbs = append(dbs, db)
+		}
+	}
+	return dbs
+}
+
+// start loads the databases, and starts checking them for modifications.
+func (gp *geoIPProcessor) start(context.Context, component.Host) error {
+	for _, db := range gp.databases() {
+		if _, err := db.load(); err != nil {
+			return err
+		}
+	}
+	if gp.refreshInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		gp.cancel = cancel
+		gp.done = make(chan struct{})
+		go gp.refreshLoop(ctx)
+	}
+	return nil
+}
+
+func (gp *geoIPProcessor) shutdown(context.Context) error {
+	if gp.cancel == nil {
+		return nil
+	}
+	gp.cancel()
+	<-gp.done
+	return nil
+}
+
+func (gp *geoIPProcessor) refreshLoop(ctx context.Context) {
+	defer close(gp.done)
+
+	ticker := time.NewTicker(gp.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			gp.refresh()
+		}
+	}
+}
+
+func (gp *geoIPProcessor) refresh() {
+	for _, db := range gp.databases() {
+		reloaded, err := db.load()
+		if err != nil {
+			gp.logger.Warn("Failed to reload GeoIP database, keeping the previous one",
+				zap.String("path", db.path), zap.Error(err))
+		} else if reloaded {
+			gp.logger.Info("GeoIP database reloaded", zap.String("path", db.path))
+		}
+	}
+}
+
+// ProcessTraces adds the location to the resources and the spans.
+func (gp *geoIPProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		gp.processAttributes(rs.Resource().Attributes())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				gp.processAttributes(spans.At(k).Attributes())
+			}
+		}
+	}
+	return td, nil
+}
+
+// ProcessLogs adds the location to the resources and the log records.
+func (gp *geoIPProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		gp.processAttributes(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				gp.processAttributes(logs.At(k).Attributes())
+			}
+		}
+	}
+	return ld, nil
+}
+
+// processAttributes adds the location of the IP address attribute to the
+// attributes, if they hold a valid IP address found in the databases.
+func (gp *geoIPProcessor) processAttributes(attributes pdata.AttributeMap) {
+	v, ok := attributes.Get(gp.ipAttribute)
+	if !ok || v.Type() != pdata.AttributeValueSTRING {
+		return
+	}
+	ip := net.ParseIP(v.StringVal())
+	if ip == nil {
+		return
+	}
+
+	if record := gp.lookup(gp.cityDB, ip); record != nil {
+		country := nestedMap(record, "country")
+		upsertString(attributes, attributeCountryISOCode, country["iso_code"])
+		upsertString(attributes, attributeCountryName, englishName(country))
+		if subdivisions, ok := record["subdivisions"].([]interface{}); ok && len(subdivisions) > 0 {
+			region, _ := subdivisions[0].(map[string]interface{})
+			upsertString(attributes, attributeRegionISOCode, region["iso_code"])
+			upsertString(attributes, attributeRegionName, englishName(region))
+		}
+		upsertString(attributes, attributeCityName, englishName(nestedMap(record, "city")))
+	}
+	if record := gp.lookup(gp.asnDB, ip); record != nil {
+		if number, ok := record["autonomous_system_number"].(uint); ok {
+			attributes.UpsertInt(attributeASNumber, int64(number))
+		}
+		upsertString(attributes, attributeASOrganization, record["autonomous_system_organization"])
+	}
+}
+
+func (gp *geoIPProcessor) lookup(db *geoDatabase, ip net.IP) map[string]interface{} {
+	if db == nil {
+		return nil
+	}
+	record, err := db.lookup(ip)
+	if err != nil {
+		gp.logger.Debug("Failed to look up IP address", zap.String("path", db.path), zap.Error(err))
+	}
+	return record
+}
+
+func nestedMap(record map[string]interface{}, key string) map[string]interface{} {
+	m, _ := record[key].(map[string]interface{})
+	return m
+}
+
+// englishName returns the English name of a country, region or city.
+func englishName(record map[string]interface{}) interface{} {
+	return nestedMap(record, "names")["en"]
+}
+
+func upsertString(attributes pdata.AttributeMap, key string, value interface{}) {
+	if s, ok := value.(string); ok && s != "" {
+		attributes.UpsertString(key, s)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func cityRecord(country, region, city string) map[string]interface{} {
+	return map[string]interface{}{
+		"country": map[string]interface{}{
+			"iso_code": country,
+			"names":    map[string]interface{}{"en": country + " name", "fr": "nom"},
+		},
+		"subdivisions": []interface{}{
+			map[string]interface{}{
+				"iso_code": region,
+				"names":    map[string]interface{}{"en": region + " name"},
+			},
+		},
+		"city": map[string]interface{}{
+			"names": map[string]interface{}{"en": city},
+		},
+	}
+}
+
+func writeTestDatabase(t *testing.T, path string, networks ...testNetwork) {
+	require.NoError(t, ioutil.WriteFile(path, buildTestDatabase(t, 24, "Test", networks...), 0600))
+}
+
+func newTestDatabases(t *testing.T) (string, *Config) {
+	dir, err := ioutil.TempDir("", "geoip")
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.CityDatabase = filepath.Join(dir, "city.mmdb")
+	cfg.ASNDatabase = filepath.Join(dir, "asn.mmdb")
+	writeTestDatabase(t, cfg.CityDatabase,
+		testNetwork{cidr: "81.2.69.0/24", record: cityRecord("GB", "ENG", "London")},
+		testNetwork{cidr: "2001:db8::/32", record: map[string]interface{}{
+			"country": map[string]interface{}{"iso_code": "SE"},
+		}})
+	writeTestDatabase(t, cfg.ASNDatabase,
+		testNetwork{cidr: "81.2.0.0/16", record: map[string]interface{}{
+			"autonomous_system_number":       uint(20712),
+			"autonomous_system_organization": "Andrews & Arnold Ltd",
+		}})
+	return dir, cfg
+}
+
+func TestProcessTraces(t *testing.T) {
+	dir, cfg := newTestDatabases(t)
+	defer os.RemoveAll(dir)
+
+	sink := new(consumertest.TracesSink)
+	tp, err := NewFactory().CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, tp.Shutdown(context.Background())) }()
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().Attributes().InsertString("client.ip", "2001:db8::1")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(4)
+	spans.At(0).Attributes().InsertString("client.ip", "81.2.69.142")
+	spans.At(1).Attributes().InsertString("client.ip", "10.0.0.1")
+	spans.At(2).Attributes().InsertString("client.ip", "not an IP")
+	spans.At(3).Attributes().InsertInt("client.ip", 42)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, sink.AllTraces(), 1)
+	rs = sink.AllTraces()[0].ResourceSpans().At(0)
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"client.ip":            pdata.NewAttributeValueString("2001:db8::1"),
+		"geo.country.iso_code": pdata.NewAttributeValueString("SE"),
+	}, attributesAsMap(rs.Resource().Attributes()))
+
+	spans = rs.InstrumentationLibrarySpans().At(0).Spans()
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"client.ip":            pdata.NewAttributeValueString("81.2.69.142"),
+		"geo.country.iso_code": pdata.NewAttributeValueString("GB"),
+		"geo.country.name":     pdata.NewAttributeValueString("GB name"),
+		"geo.region.iso_code":  pdata.NewAttributeValueString("ENG"),
+		"geo.region.name":      pdata.NewAttributeValueString("ENG name"),
+		"geo.city.name":        pdata.NewAttributeValueString("London"),
+		"as.number":            pdata.NewAttributeValueInt(20712),
+		"as.organization.name": pdata.NewAttributeValueString("Andrews & Arnold Ltd"),
+	}, attributesAsMap(spans.At(0).Attributes()))
+	for i := 1; i < spans.Len(); i++ {
+		assert.Equal(t, 1, spans.At(i).Attributes().Len())
+	}
+}
+
+func TestProcessLogs(t *testing.T) {
+	dir, cfg := newTestDatabases(t)
+	defer os.RemoveAll(dir)
+	cfg.IPAttribute = "net.peer.ip"
+	cfg.CityDatabase = ""
+
+	sink := new(consumertest.LogsSink)
+	lp, err := NewFactory().CreateLogsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, lp.Shutdown(context.Background())) }()
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	ld.ResourceLogs().At(0).InstrumentationLibraryLogs().Resize(1)
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(1)
+	logs.At(0).Attributes().InsertString("net.peer.ip", "81.2.69.142")
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, sink.AllLogs(), 1)
+	logs = sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"net.peer.ip":          pdata.NewAttributeValueString("81.2.69.142"),
+		"as.number":            pdata.NewAttributeValueInt(20712),
+		"as.organization.name": pdata.NewAttributeValueString("Andrews & Arnold Ltd"),
+	}, attributesAsMap(logs.At(0).Attributes()))
+}
+
+func TestStart_InvalidDatabase(t *testing.T) {
+	dir, cfg := newTestDatabases(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(cfg.CityDatabase, []byte("not a database"), 0600))
+
+	tp, err := NewFactory().CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	assert.EqualError(t, tp.Start(context.Background(), componenttest.NewNopHost()),
+		cfg.CityDatabase+": invalid MaxMind database: metadata not found")
+
+	cfg.CityDatabase = filepath.Join(dir, "missing.mmdb")
+	tp, err = NewFactory().CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	assert.Error(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestRefresh(t *testing.T) {
+	dir, cfg := newTestDatabases(t)
+	defer os.RemoveAll(dir)
+	cfg.ASNDatabase = ""
+	cfg.RefreshInterval = 10 * time.Millisecond
+
+	gp := newGeoIPProcessor(zap.NewNop(), cfg)
+	require.NoError(t, gp.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, gp.shutdown(context.Background())) }()
+
+	countryOf := func(ip string) interface{} {
+		attributes := pdata.NewAttributeMap()
+		attributes.InsertString("client.ip", ip)
+		gp.processAttributes(attributes)
+		if v, ok := attributes.Get(attributeCountryISOCode); ok {
+			return v.StringVal()
+		}
+		return nil
+	}
+	assert.Equal(t, "GB", countryOf("81.2.69.142"))
+
+	// An invalid file keeps the previous database.
+	require.NoError(t, ioutil.WriteFile(cfg.CityDatabase, []byte("partially written"), 0600))
+	require.NoError(t, os.Chtimes(cfg.CityDatabase, time.Now(), time.Now().Add(time.Minute)))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "GB", countryOf("81.2.69.142"))
+
+	writeTestDatabase(t, cfg.CityDatabase,
+		testNetwork{cidr: "81.2.69.0/24", record: cityRecord("FR", "IDF", "Paris")})
+	require.NoError(t, os.Chtimes(cfg.CityDatabase, time.Now(), time.Now().Add(2*time.Minute)))
+	assert.Eventually(t, func() bool {
+		return countryOf("81.2.69.142") == "FR"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func attributesAsMap(attributes pdata.AttributeMap) map[string]pdata.AttributeValue {
+	m := make(map[string]pdata.AttributeValue, attributes.Len())
+	attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = v
+	})
+	return m
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Log Deduplication processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Interval is the time window the identical log records are collapsed in, the
+	// collapsed records are sent at the end of each interval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MatchAttributes are the keys of the attributes identifying the duplicated log
+	// records, in addition to their body, name and severity. The other attributes are
+	// removed from the collapsed records.
+	MatchAttributes []string `mapstructure:"match_attributes"`
+
+	// CountAttribute is the attribute of the collapsed records holding the number of
+	// log records they replace.
+	CountAttribute string `mapstructure:"count_attribute"`
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "logdedup"
+
+	defaultInterval       = 10 * time.Second
+	defaultCountAttribute = "log_count"
+)
+
+var (
+	errNonPositiveInterval = errors.New("interval must be positive")
+	errNoCountAttribute    = errors.New("missing required field \"count_attribute\"")
+)
+
+// NewFactory returns a new factory for the Log Deduplication processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Interval:       defaultInterval,
+		CountAttribute: defaultCountAttribute,
+	}
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.LogsConsumer) (component.LogsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if oCfg.Interval <= 0 {
+		return nil, fmt.Errorf("error creating %q processor: %w", cfg.Name(), errNonPositiveInterval)
+	}
+	if oCfg.CountAttribute == "" {
+		return nil, fmt.Errorf("error creating %q processor: %w", cfg.Name(), errNoCountAttribute)
+	}
+	return newLogDedupProcessor(params.Logger, oCfg, nextConsumer), nil
+}
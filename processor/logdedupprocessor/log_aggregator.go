@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/series"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// aggregatedRecord is a collapsed log record and its number of occurrences.
+type aggregatedRecord struct {
+	record pdata.LogRecord
+	count  int64
+}
+
+// logAggregator collapses the identical log records of the same resource and
+// instrumentation library, building the logs sent at the end of the interval.
+type logAggregator struct {
+	matchAttributes []string
+	countAttribute  string
+
+	logs      pdata.Logs
+	resources map[string]pdata.ResourceLogs
+	libraries map[string]pdata.LogSlice
+	records   map[string]*aggregatedRecord
+}
+
+func newLogAggregator(matchAttributes []string, countAttribute string) *logAggregator {
+	a := &logAggregator{
+		matchAttributes: matchAttributes,
+		countAttribute:  countAttribute,
+	}
+	a.reset()
+	return a
+}
+
+func (a *logAggregator) reset() {
+	a.logs = pdata.NewLogs()
+	a.resources = make(map[string]pdata.ResourceLogs)
+	a.libraries = make(map[string]pdata.LogSlice)
+	a.records = make(map[string]*aggregatedRecord)
+}
+
+// add collapses the log records into the aggregated ones.
+func (a *logAggregator) add(ld pdata.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceKey := series.AttributesKey(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			library := ill.InstrumentationLibrary()
+			libraryKey := resourceKey + "\x00" + library.Name() + "\x00" + library.Version()
+
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				recordKey := libraryKey + "\x00" + a.recordKey(lr)
+				if agg, ok := a.records[recordKey]; ok {
+					agg.count++
+					continue
+				}
+
+				records := a.libraryLogs(libraryKey, resourceKey, rl.Resource(), library)
+				a.records[recordKey] = &aggregatedRecord{record: a.collapsedRecord(lr, records), count: 1}
+			}
+		}
+	}
+}
+
+// libraryLogs returns the log records of the instrumentation library, adding
+// the resource and the library to the logs if they are new.
+func (a *logAggregator) libraryLogs(libraryKey, resourceKey string, resource pdata.Resource, library pdata.InstrumentationLibrary) pdata.LogSlice {
+	if records, ok := a.libraries[libraryKey]; ok {
+		return records
+	}
+
+	rl, ok := a.resources[resourceKey]
+	if !ok {
+		rl = pdata.NewResourceLogs()
+		resource.CopyTo(rl.Resource())
+		a.logs.ResourceLogs().Append(rl)
+		a.resources[resourceKey] = rl
+	}
+
+	ill := pdata.NewInstrumentationLibraryLogs()
+	library.CopyTo(ill.InstrumentationLibrary())
+	rl.InstrumentationLibraryLogs().Append(ill)
+	a.libraries[libraryKey] = ill.Logs()
+	return ill.Logs()
+}
+
+// collapsedRecord appends the record replacing the occurrences of the log
+// record, which only keeps the fields identifying it.
+func (a *logAggregator) collapsedRecord(lr pdata.LogRecord, records pdata.LogSlice) pdata.LogRecord {
+	collapsed := pdata.NewLogRecord()
+	collapsed.SetTimestamp(lr.Timestamp())
+	collapsed.SetName(lr.Name())
+	collapsed.SetSeverityNumber(lr.SeverityNumber())
+	collapsed.SetSeverityText(lr.SeverityText())
+	lr.Body().CopyTo(collapsed.Body())
+	collapsed.Attributes().InitEmptyWithCapacity(len(a.matchAttributes) + 1)
+	for _, key := range a.matchAttributes {
+		if v, ok := lr.Attributes().Get(key); ok {
+			collapsed.Attributes().Insert(key, v)
+		}
+	}
+	records.Append(collapsed)
+	return collapsed
+}
+
+// recordKey returns a key identifying the log record by its body, name,
+// severity and matched attributes.
+func (a *logAggregator) recordKey(lr pdata.LogRecord) string {
+	parts := make([]string, 0, len(a.matchAttributes)+4)
+	parts = append(parts,
+		tracetranslator.AttributeValueToString(lr.Body(), false),
+		lr.Name(),
+		strconv.Itoa(int(lr.SeverityNumber())),
+		lr.SeverityText())
+	for _, key := range a.matchAttributes {
+		if v, ok := lr.Attributes().Get(key); ok {
+			parts = append(parts, "\x01"+tracetranslator.AttributeValueToString(v, false))
+		} else {
+			parts = append(parts, "")
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// export returns the collapsed log records with their counts, and resets the
+// aggregator.
+func (a *logAggregator) export() pdata.Logs {
+	for _, agg := range a.records {
+		agg.record.Attributes().UpsertInt(a.countAttribute, agg.count)
+	}
+	ld := a.logs
+	a.reset()
+	return ld
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// logDedupProcessor collapses the identical log records received within the
+// interval, and sends the collapsed records to the next consumer at the end of
+// each interval.
+type logDedupProcessor struct {
+	logger       *zap.Logger
+	nextConsumer consumer.LogsConsumer
+	interval     time.Duration
+
+	lock       sync.Mutex
+	aggregator *logAggregator
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ component.LogsProcessor = (*logDedupProcessor)(nil)
+
+func newLogDedupProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.LogsConsumer) *logDedupProcessor {
+	return &logDedupProcessor{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		interval:     cfg.Interval,
+		aggregator:   newLogAggregator(cfg.MatchAttributes, cfg.CountAttribute),
+		done:         make(chan struct{}),
+	}
+}
+
+func (p *logDedupProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// Start starts sending the collapsed log records at the end of each interval.
+func (p *logDedupProcessor) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go p.exportLoop(ctx)
+	return nil
+}
+
+// Shutdown sends the log records collapsed in the current interval.
+func (p *logDedupProcessor) Shutdown(context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+	<-p.done
+	return nil
+}
+
+// ConsumeLogs collapses the log records, they are sent at the end of the interval.
+func (p *logDedupProcessor) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
+	p.lock.Lock()
+	p.aggregator.add(ld)
+	p.lock.Unlock()
+	return nil
+}
+
+func (p *logDedupProcessor) exportLoop(ctx context.Context) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			p.export()
+			return
+		case <-ticker.C:
+			p.export()
+		}
+	}
+}
+
+func (p *logDedupProcessor) export() {
+	p.lock.Lock()
+	ld := p.aggregator.export()
+	p.lock.Unlock()
+
+	if ld.ResourceLogs().Len() == 0 {
+		return
+	}
+	if err := p.nextConsumer.ConsumeLogs(context.Background(), ld); err != nil {
+		p.logger.Warn("Failed to send the collapsed log records", zap.Error(err))
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// testRecord is a log record of a service.
+type testRecord struct {
+	service    string
+	body       string
+	severity   pdata.SeverityNumber
+	timestamp  pdata.Timestamp
+	attributes map[string]string
+}
+
+func newTestLogs(records ...testRecord) pdata.Logs {
+	ld := pdata.NewLogs()
+	for _, r := range records {
+		rl := pdata.NewResourceLogs()
+		rl.Resource().Attributes().InsertString("service.name", r.service)
+		ill := pdata.NewInstrumentationLibraryLogs()
+		lr := pdata.NewLogRecord()
+		lr.Body().SetStringVal(r.body)
+		lr.SetSeverityNumber(r.severity)
+		lr.SetTimestamp(r.timestamp)
+		for k, v := range r.attributes {
+			lr.Attributes().InsertString(k, v)
+		}
+		ill.Logs().Append(lr)
+		rl.InstrumentationLibraryLogs().Append(ill)
+		ld.ResourceLogs().Append(rl)
+	}
+	return ld
+}
+
+// collapsedRecord is a collapsed log record of a service.
+type collapsedRecord struct {
+	service    string
+	body       string
+	timestamp  pdata.Timestamp
+	attributes map[string]interface{}
+}
+
+func toCollapsedRecords(ld pdata.Logs) []collapsedRecord {
+	var records []collapsedRecord
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		service, _ := rls.At(i).Resource().Attributes().Get("service.name")
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				attributes := make(map[string]interface{})
+				lr.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+					if v.Type() == pdata.AttributeValueINT {
+						attributes[k] = v.IntVal()
+					} else {
+						attributes[k] = v.StringVal()
+					}
+				})
+				records = append(records, collapsedRecord{
+					service:    service.StringVal(),
+					body:       lr.Body().StringVal(),
+					timestamp:  lr.Timestamp(),
+					attributes: attributes,
+				})
+			}
+		}
+	}
+	return records
+}
+
+func TestLogAggregator(t *testing.T) {
+	a := newLogAggregator([]string{"exception.type"}, "log_count")
+
+	a.add(newTestLogs(
+		testRecord{service: "checkout", body: "connection refused", severity: pdata.SeverityNumberERROR, timestamp: 1,
+			attributes: map[string]string{"exception.type": "IOError", "thread": "1"}},
+		testRecord{service: "checkout", body: "connection refused", severity: pdata.SeverityNumberERROR, timestamp: 2,
+			attributes: map[string]string{"exception.type": "IOError", "thread": "2"}},
+		// A different matched attribute, severity or service is a different record.
+		testRecord{service: "checkout", body: "connection refused", severity: pdata.SeverityNumberERROR, timestamp: 3,
+			attributes: map[string]string{"exception.type": "TimeoutError"}},
+		testRecord{service: "checkout", body: "connection refused", severity: pdata.SeverityNumberWARN, timestamp: 4},
+		testRecord{service: "payment", body: "connection refused", severity: pdata.SeverityNumberERROR, timestamp: 5,
+			attributes: map[string]string{"exception.type": "IOError"}},
+	))
+	a.add(newTestLogs(
+		testRecord{service: "checkout", body: "connection refused", severity: pdata.SeverityNumberERROR, timestamp: 6,
+			attributes: map[string]string{"exception.type": "IOError"}},
+	))
+
+	assert.Equal(t, []collapsedRecord{
+		{service: "checkout", body: "connection refused", timestamp: 1, attributes: map[string]interface{}{"exception.type": "IOError", "log_count": int64(3)}},
+		{service: "checkout", body: "connection refused", timestamp: 3, attributes: map[string]interface{}{"exception.type": "TimeoutError", "log_count": int64(1)}},
+		{service: "checkout", body: "connection refused", timestamp: 4, attributes: map[string]interface{}{"log_count": int64(1)}},
+		{service: "payment", body: "connection refused", timestamp: 5, attributes: map[string]interface{}{"exception.type": "IOError", "log_count": int64(1)}},
+	}, toCollapsedRecords(a.export()))
+
+	// The aggregator is reset by the export.
+	assert.Equal(t, 0, a.export().ResourceLogs().Len())
+}
+
+func TestLogDedupProcessor(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	p := newLogDedupProcessor(zap.NewNop(), &Config{Interval: 10 * time.Millisecond, CountAttribute: "log_count"}, sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.ConsumeLogs(context.Background(), newTestLogs(testRecord{service: "checkout", body: "crash"})))
+	}
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordsCount() > 0
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	var count int64
+	for _, ld := range sink.AllLogs() {
+		for _, r := range toCollapsedRecords(ld) {
+			count += r.attributes["log_count"].(int64)
+		}
+	}
+	assert.Equal(t, int64(3), count)
+}
+
+func TestLogDedupProcessor_Shutdown(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	p := newLogDedupProcessor(zap.NewNop(), &Config{Interval: time.Hour, CountAttribute: "log_count"}, sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), newTestLogs(
+		testRecord{service: "checkout", body: "crash"},
+		testRecord{service: "checkout", body: "crash"})))
+	assert.Equal(t, 0, sink.LogRecordsCount())
+
+	// The records collapsed in the current interval are sent on shutdown.
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, []collapsedRecord{
+		{service: "checkout", body: "crash", attributes: map[string]interface{}{"log_count": int64(2)}},
+	}, toCollapsedRecords(sink.AllLogs()[0]))
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// ParserType is the format of the bodies of the log records.
+type ParserType string
+
+const (
+	// JSONParser parses the bodies as JSON objects, whose fields become the
+	// attributes.
+	JSONParser ParserType = "json"
+	// RegexParser parses the bodies with a regular expression, whose named
+	// capturing groups become the attributes.
+	RegexParser ParserType = "regex"
+)
+
+// ErrorMode is what the processor does with the log records which can't be
+// parsed.
+type ErrorMode string
+
+const (
+	// SendOnError sends the log records unchanged.
+	SendOnError ErrorMode = "send"
+	// DropOnError drops the log records.
+	DropOnError ErrorMode = "drop"
+)
+
+// Config defines configuration for Log Parser processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Parser is "json" or "regex".
+	Parser ParserType `mapstructure:"parser"`
+
+	// Regex is the regular expression of the "regex" parser, e.g.
+	// `^(?P<time>[^ ]+) (?P<level>[A-Z]+) (?P<message>.*)$`. The bodies which
+	// don't match it can't be parsed.
+	Regex string `mapstructure:"regex"`
+
+	// Timestamp extracts the timestamp of the log records from a parsed field.
+	Timestamp *TimestampConfig `mapstructure:"timestamp"`
+
+	// Severity extracts the severity of the log records from a parsed field.
+	Severity *SeverityConfig `mapstructure:"severity"`
+
+	// OnError is "send" or "drop". If not set, the log records which can't be
+	// parsed are sent unchanged.
+	OnError ErrorMode `mapstructure:"on_error"`
+}
+
+// TimestampConfig defines the extraction of the timestamp of the log records.
+type TimestampConfig struct {
+	// Field is the parsed field holding the timestamp, it is removed from the
+	// attributes once extracted.
+	Field string `mapstructure:"field"`
+
+	// Layout is the Go time layout of the timestamp, e.g. "2006-01-02 15:04:05",
+	// or "epoch_s", "epoch_ms", "epoch_us" or "epoch_ns" for the numbers of
+	// seconds, milliseconds, microseconds or nanoseconds since the epoch.
+	Layout string `mapstructure:"layout"`
+
+	// Location is the IANA time zone of the timestamps without time zone, e.g.
+	// "Asia/Shanghai". If not set, they are in UTC.
+	Location string `mapstructure:"location"`
+}
+
+// SeverityConfig defines the extraction of the severity of the log records.
+type SeverityConfig struct {
+	// Field is the parsed field holding the severity, it is removed from the
+	// attributes once extracted. Its value is the severity text of the records.
+	Field string `mapstructure:"field"`
+
+	// Mapping maps the custom values of the field to the severities, e.g.
+	// "W: warn". The other values are matched with the severity names, e.g.
+	// "INFO", "warning" or "err", or are severity numbers.
+	Mapping map[string]string `mapstructure:"mapping"`
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// The layouts of the numeric timestamps, mapped to their units.
+var epochLayouts = map[string]time.Duration{
+	"epoch_s":  time.Second,
+	"epoch_ms": time.Millisecond,
+	"epoch_us": time.Microsecond,
+	"epoch_ns": time.Nanosecond,
+}
+
+// timestampExtractor parses the timestamp of a parsed field.
+type timestampExtractor struct {
+	field    string
+	layout   string
+	epoch    time.Duration
+	location *time.Location
+}
+
+func newTimestampExtractor(cfg *TimestampConfig) (*timestampExtractor, error) {
+	if cfg.Field == "" {
+		return nil, errNoTimestampField
+	}
+	if cfg.Layout == "" {
+		return nil, errNoTimestampLayout
+	}
+	location := time.UTC
+	if cfg.Location != "" {
+		var err error
+		if location, err = time.LoadLocation(cfg.Location); err != nil {
+			return nil, fmt.Errorf("invalid timestamp location: %w", err)
+		}
+	}
+	return &timestampExtractor{
+		field:    cfg.Field,
+		layout:   cfg.Layout,
+		epoch:    epochLayouts[cfg.Layout],
+		location: location,
+	}, nil
+}
+
+// extract returns the timestamp of the field, and false if the field is
+// missing.
+func (e *timestampExtractor) extract(attributes pdata.AttributeMap) (pdata.Timestamp, bool, error) {
+	v, ok := attributes.Get(e.field)
+	if !ok {
+		return 0, false, nil
+	}
+
+	if e.epoch == 0 {
+		if v.Type() != pdata.AttributeValueSTRING {
+			return 0, true, fmt.Errorf("timestamp field %q is not a string", e.field)
+		}
+		t, err := time.ParseInLocation(e.layout, v.StringVal(), e.location)
+		if err != nil {
+			return 0, true, fmt.Errorf("invalid timestamp: %w", err)
+		}
+		return pdata.TimestampFromTime(t), true, nil
+	}
+
+	var number float64
+	switch v.Type() {
+	case pdata.AttributeValueINT:
+		number = float64(v.IntVal())
+	case pdata.AttributeValueDOUBLE:
+		number = v.DoubleVal()
+	case pdata.AttributeValueSTRING:
+		var err error
+		if number, err = strconv.ParseFloat(v.StringVal(), 64); err != nil {
+			return 0, true, fmt.Errorf("invalid timestamp %q", v.StringVal())
+		}
+	default:
+		return 0, true, fmt.Errorf("timestamp field %q is not a number", e.field)
+	}
+	if number < 0 || number*float64(e.epoch) > math.MaxInt64 {
+		return 0, true, fmt.Errorf("timestamp %v out of range", number)
+	}
+	// The integer timestamps are converted exactly, the float64 precision is
+	// only enough for the microseconds of the current dates.
+	if v.Type() == pdata.AttributeValueINT {
+		return pdata.Timestamp(v.IntVal() * int64(e.epoch)), true, nil
+	}
+	return pdata.Timestamp(number * float64(e.epoch)), true, nil
+}
+
+// The severity names, in lower case.
+var severityNames = map[string]pdata.SeverityNumber{
+	"trace":       pdata.SeverityNumberTRACE,
+	"trace2":      pdata.SeverityNumberTRACE2,
+	"trace3":      pdata.SeverityNumberTRACE3,
+	"trace4":      pdata.SeverityNumberTRACE4,
+	"debug":       pdata.SeverityNumberDEBUG,
+	"debug2":      pdata.SeverityNumberDEBUG2,
+	"debug3":      pdata.SeverityNumberDEBUG3,
+	"debug4":      pdata.SeverityNumberDEBUG4,
+	"info":        pdata.SeverityNumberINFO,
+	"information": pdata.SeverityNumberINFO,
+	"info2":       pdata.SeverityNumberINFO2,
+	"notice":      pdata.SeverityNumberINFO2,
+	"info3":       pdata.SeverityNumberINFO3,
+	"info4":       pdata.SeverityNumberINFO4,
+	"warn":        pdata.SeverityNumberWARN,
+	"warning":     pdata.SeverityNumberWARN,
+	"warn2":       pdata.SeverityNumberWARN2,
+	"warn3":       pdata.SeverityNumberWARN3,
+	"warn4":       pdata.SeverityNumberWARN4,
+	"error":       pdata.SeverityNumberERROR,
+	"err":         pdata.SeverityNumberERROR,
+	"error2":      pdata.SeverityNumberERROR2,
+	"error3":      pdata.SeverityNumberERROR3,
+	"error4":      pdata.SeverityNumberERROR4,
+	"fatal":       pdata.SeverityNumberFATAL,
+	"critical":    pdata.SeverityNumberFATAL,
+	"crit":        pdata.SeverityNumberFATAL,
+	"fatal2":      pdata.SeverityNumberFATAL2,
+	"alert":       pdata.SeverityNumberFATAL2,
+	"fatal3":      pdata.SeverityNumberFATAL3,
+	"emergency":   pdata.SeverityNumberFATAL3,
+	"emerg":       pdata.SeverityNumberFATAL3,
+	"panic":       pdata.SeverityNumberFATAL3,
+	"fatal4":      pdata.SeverityNumberFATAL4,
+}
+
+// severityExtractor maps the value of a parsed field to a severity.
+type severityExtractor struct {
+	field   string
+	mapping map[string]pdata.SeverityNumber
+}
+
+func newSeverityExtractor(cfg *SeverityConfig) (*severityExtractor, error) {
+	if cfg.Field == "" {
+		return nil, errNoSeverityField
+	}
+	mapping := make(map[string]pdata.SeverityNumber, len(cfg.Mapping))
+	for value, name := range cfg.Mapping {
+		severity, ok := severityNames[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("invalid severity %q", name)
+		}
+		mapping[strings.ToLower(value)] = severity
+	}
+	return &severityExtractor{field: cfg.Field, mapping: mapping}, nil
+}
+
+// extract returns the severity text and number of the field, and false if the
+// field is missing. The number is undefined if the value is not a known
+// severity.
+func (e *severityExtractor) extract(attributes pdata.AttributeMap) (string, pdata.SeverityNumber, bool) {
+	v, ok := attributes.Get(e.field)
+	if !ok {
+		return "", pdata.SeverityNumberUNDEFINED, false
+	}
+	var text string
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		text = v.StringVal()
+	case pdata.AttributeValueINT:
+		text = strconv.FormatInt(v.IntVal(), 10)
+	default:
+		return "", pdata.SeverityNumberUNDEFINED, false
+	}
+
+	value := strings.ToLower(strings.TrimSpace(text))
+	if severity, ok := e.mapping[value]; ok {
+		return text, severity, true
+	}
+	if severity, ok := severityNames[value]; ok {
+		return text, severity, true
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= int(pdata.SeverityNumberTRACE) && n <= int(pdata.SeverityNumberFATAL4) {
+		return text, pdata.SeverityNumber(n), true
+	}
+	return text, pdata.SeverityNumberUNDEFINED, true
+}
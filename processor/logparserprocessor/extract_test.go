@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestTimestampExtractor(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      TimestampConfig
+		value    pdata.AttributeValue
+		expected time.Time
+		err      string
+	}{
+		{
+			name:     "layout",
+			cfg:      TimestampConfig{Layout: time.RFC3339Nano},
+			value:    pdata.NewAttributeValueString("2021-03-01T08:30:00.123+01:00"),
+			expected: time.Date(2021, 3, 1, 7, 30, 0, 123000000, time.UTC),
+		},
+		{
+			name:     "layout with location",
+			cfg:      TimestampConfig{Layout: "2006-01-02 15:04:05", Location: "Asia/Shanghai"},
+			value:    pdata.NewAttributeValueString("2021-03-01 08:30:00"),
+			expected: time.Date(2021, 3, 1, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "epoch seconds",
+			cfg:      TimestampConfig{Layout: "epoch_s"},
+			value:    pdata.NewAttributeValueDouble(1614587400.5),
+			expected: time.Date(2021, 3, 1, 8, 30, 0, 500000000, time.UTC),
+		},
+		{
+			name:     "epoch milliseconds",
+			cfg:      TimestampConfig{Layout: "epoch_ms"},
+			value:    pdata.NewAttributeValueInt(1614587400123),
+			expected: time.Date(2021, 3, 1, 8, 30, 0, 123000000, time.UTC),
+		},
+		{
+			name:     "epoch string",
+			cfg:      TimestampConfig{Layout: "epoch_ns"},
+			value:    pdata.NewAttributeValueString("1614587400000000001"),
+			expected: time.Date(2021, 3, 1, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "invalid layout value",
+			cfg:   TimestampConfig{Layout: time.RFC3339},
+			value: pdata.NewAttributeValueString("yesterday"),
+			err:   `invalid timestamp: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+		{
+			name:  "invalid epoch value",
+			cfg:   TimestampConfig{Layout: "epoch_s"},
+			value: pdata.NewAttributeValueBool(true),
+			err:   `timestamp field "time" is not a number`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Field = "time"
+			e, err := newTimestampExtractor(&tt.cfg)
+			require.NoError(t, err)
+			attributes := pdata.NewAttributeMap()
+			attributes.Insert("time", tt.value)
+
+			timestamp, ok, err := e.extract(attributes)
+			assert.True(t, ok)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, pdata.TimestampFromTime(tt.expected), timestamp)
+		})
+	}
+}
+
+func TestSeverityExtractor(t *testing.T) {
+	e, err := newSeverityExtractor(&SeverityConfig{Field: "level", Mapping: map[string]string{"w": "WARN"}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		value    pdata.AttributeValue
+		severity pdata.SeverityNumber
+	}{
+		{value: pdata.NewAttributeValueString("INFO"), severity: pdata.SeverityNumberINFO},
+		{value: pdata.NewAttributeValueString("warning"), severity: pdata.SeverityNumberWARN},
+		{value: pdata.NewAttributeValueString("W"), severity: pdata.SeverityNumberWARN},
+		{value: pdata.NewAttributeValueString("Err"), severity: pdata.SeverityNumberERROR},
+		{value: pdata.NewAttributeValueInt(21), severity: pdata.SeverityNumberFATAL},
+		{value: pdata.NewAttributeValueString("verbose"), severity: pdata.SeverityNumberUNDEFINED},
+	}
+	for _, tt := range tests {
+		attributes := pdata.NewAttributeMap()
+		attributes.Insert("level", tt.value)
+		text, severity, ok := e.extract(attributes)
+		assert.True(t, ok)
+		assert.Equal(t, tt.severity, severity, text)
+	}
+
+	_, _, ok := e.extract(pdata.NewAttributeMap())
+	assert.False(t, ok)
+}
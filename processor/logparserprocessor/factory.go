@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "logparser"
+)
+
+var (
+	errInvalidParser     = errors.New("parser must be \"json\" or \"regex\"")
+	errNoRegex           = errors.New("missing required field \"regex\"")
+	errInvalidErrorMode  = errors.New("on_error must be \"send\" or \"drop\"")
+	errNoTimestampField  = errors.New("missing required field \"timestamp.field\"")
+	errNoTimestampLayout = errors.New("missing required field \"timestamp.layout\"")
+	errNoSeverityField   = errors.New("missing required field \"severity.field\"")
+)
+
+// NewFactory returns a new factory for the Log Parser processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		OnError: SendOnError,
+	}
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.LogsConsumer) (component.LogsProcessor, error) {
+	p, err := newLogParserProcessor(cfg.(*Config))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %q processor: %w", cfg.Name(), err)
+	}
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		p,
+		processorhelper.WithCapabilities(component.ProcessorCapabilities{MutatesConsumedData: true}))
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Parser = JSONParser
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, lp)
+	assert.True(t, lp.GetCapabilities().MutatesConsumedData)
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	assert.Nil(t, tp)
+}
+
+func TestCreateProcessors_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{
+			name: "no parser",
+			cfg:  Config{OnError: SendOnError},
+			err:  `parser must be "json" or "regex"`,
+		},
+		{
+			name: "no regex",
+			cfg:  Config{Parser: RegexParser, OnError: SendOnError},
+			err:  `missing required field "regex"`,
+		},
+		{
+			name: "invalid regex",
+			cfg:  Config{Parser: RegexParser, Regex: "(?P<a>", OnError: SendOnError},
+			err:  "invalid regex: error parsing regexp: missing closing ): `(?P<a>`",
+		},
+		{
+			name: "regex without named group",
+			cfg:  Config{Parser: RegexParser, Regex: "^(.*)$", OnError: SendOnError},
+			err:  "regex has no named capturing group",
+		},
+		{
+			name: "invalid error mode",
+			cfg:  Config{Parser: JSONParser, OnError: "retry"},
+			err:  `on_error must be "send" or "drop"`,
+		},
+		{
+			name: "no timestamp field",
+			cfg:  Config{Parser: JSONParser, OnError: SendOnError, Timestamp: &TimestampConfig{Layout: "epoch_s"}},
+			err:  `missing required field "timestamp.field"`,
+		},
+		{
+			name: "no timestamp layout",
+			cfg:  Config{Parser: JSONParser, OnError: SendOnError, Timestamp: &TimestampConfig{Field: "time"}},
+			err:  `missing required field "timestamp.layout"`,
+		},
+		{
+			name: "invalid location",
+			cfg:  Config{Parser: JSONParser, OnError: SendOnError, Timestamp: &TimestampConfig{Field: "time", Layout: "epoch_s", Location: "Mars/Olympus"}},
+			err:  "invalid timestamp location: unknown time zone Mars/Olympus",
+		},
+		{
+			name: "no severity field",
+			cfg:  Config{Parser: JSONParser, OnError: SendOnError, Severity: &SeverityConfig{}},
+			err:  `missing required field "severity.field"`,
+		},
+		{
+			name: "invalid severity",
+			cfg:  Config{Parser: JSONParser, OnError: SendOnError, Severity: &SeverityConfig{Field: "level", Mapping: map[string]string{"w": "warning5"}}},
+			err:  `invalid severity "warning5"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := tt.cfg
+			cfg.ProcessorSettings = factory.CreateDefaultConfig().(*Config).ProcessorSettings
+
+			lp, err := factory.CreateLogsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, &cfg, consumertest.NewLogsNop())
+			assert.EqualError(t, err, `error creating "logparser" processor: `+tt.err)
+			assert.Nil(t, lp)
+		})
+	}
+}
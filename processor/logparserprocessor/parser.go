@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var (
+	errNotJSONObject = errors.New("body is not a JSON object")
+	errNoMatch       = errors.New("body doesn't match the regular expression")
+)
+
+// parser parses the body of a log record into its attributes.
+type parser interface {
+	parse(body string, attributes pdata.AttributeMap) error
+}
+
+// jsonParser upserts the fields of the JSON object of the body, the nested
+// objects and arrays become map and array attributes.
+type jsonParser struct{}
+
+func (jsonParser) parse(body string, attributes pdata.AttributeMap) error {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil || fields == nil {
+		return errNotJSONObject
+	}
+	if decoder.More() {
+		return errNotJSONObject
+	}
+	upsertFields(attributes, fields)
+	return nil
+}
+
+// upsertFields upserts the fields in the order of their keys.
+func upsertFields(attributes pdata.AttributeMap, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attributes.Upsert(k, jsonToAttributeValue(fields[k]))
+	}
+}
+
+func jsonToAttributeValue(value interface{}) pdata.AttributeValue {
+	switch v := value.(type) {
+	case string:
+		return pdata.NewAttributeValueString(v)
+	case bool:
+		return pdata.NewAttributeValueBool(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return pdata.NewAttributeValueInt(i)
+		}
+		f, _ := v.Float64()
+		return pdata.NewAttributeValueDouble(f)
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		for _, e := range v {
+			av.ArrayVal().Append(jsonToAttributeValue(e))
+		}
+		return av
+	case map[string]interface{}:
+		av := pdata.NewAttributeValueMap()
+		upsertFields(av.MapVal(), v)
+		return av
+	default:
+		return pdata.NewAttributeValueNull()
+	}
+}
+
+// regexParser upserts the named capturing groups of the regular expression
+// which matched a part of the body as string attributes.
+type regexParser struct {
+	regex *regexp.Regexp
+}
+
+func newRegexParser(expr string) (*regexParser, error) {
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %w", err)
+	}
+	named := false
+	for _, name := range regex.SubexpNames() {
+		named = named || name != ""
+	}
+	if !named {
+		return nil, errors.New("regex has no named capturing group")
+	}
+	return &regexParser{regex: regex}, nil
+}
+
+func (p *regexParser) parse(body string, attributes pdata.AttributeMap) error {
+	match := p.regex.FindStringSubmatchIndex(body)
+	if match == nil {
+		return errNoMatch
+	}
+	for i, name := range p.regex.SubexpNames() {
+		if name == "" || match[2*i] < 0 {
+			continue
+		}
+		attributes.UpsertString(name, body[match[2*i]:match[2*i+1]])
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestJSONParser(t *testing.T) {
+	attributes := pdata.NewAttributeMap()
+	err := jsonParser{}.parse(`{"msg":"done","count":3,"ratio":0.5,"ok":true,"none":null,"tags":["a",1],"http":{"status":200}}`, attributes)
+	require.NoError(t, err)
+
+	tags := pdata.NewAttributeValueArray()
+	tags.ArrayVal().Append(pdata.NewAttributeValueString("a"))
+	tags.ArrayVal().Append(pdata.NewAttributeValueInt(1))
+	http := pdata.NewAttributeValueMap()
+	http.MapVal().InsertInt("status", 200)
+	expected := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"msg":   pdata.NewAttributeValueString("done"),
+		"count": pdata.NewAttributeValueInt(3),
+		"ratio": pdata.NewAttributeValueDouble(0.5),
+		"ok":    pdata.NewAttributeValueBool(true),
+		"none":  pdata.NewAttributeValueNull(),
+		"tags":  tags,
+		"http":  http,
+	})
+	assert.Equal(t, expected.Sort(), attributes.Sort())
+
+	for _, body := range []string{"plain text", `["a"]`, "null", `{"a":1} trailing`, `{"a":`} {
+		assert.Equal(t, errNotJSONObject, jsonParser{}.parse(body, pdata.NewAttributeMap()), body)
+	}
+}
+
+func TestRegexParser(t *testing.T) {
+	p, err := newRegexParser(`^(?P<level>[A-Z]+) (?:\[(?P<thread>[^\]]+)\] )?(?P<message>.*)$`)
+	require.NoError(t, err)
+
+	attributes := pdata.NewAttributeMap()
+	require.NoError(t, p.parse("INFO [main] started", attributes))
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"level":   pdata.NewAttributeValueString("INFO"),
+		"thread":  pdata.NewAttributeValueString("main"),
+		"message": pdata.NewAttributeValueString("started"),
+	}).Sort(), attributes.Sort())
+
+	// The optional groups which didn't participate in the match are skipped.
+	attributes = pdata.NewAttributeMap()
+	require.NoError(t, p.parse("WARN low disk", attributes))
+	assert.Equal(t, 2, attributes.Len())
+
+	assert.Equal(t, errNoMatch, p.parse("lowercase", pdata.NewAttributeMap()))
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+var errBodyNotString = errors.New("body is not a string")
+
+type logParserProcessor struct {
+	parser    parser
+	timestamp *timestampExtractor
+	severity  *severityExtractor
+	drop      bool
+	obsrep    *obsreport.ProcessorObsReport
+}
+
+func newLogParserProcessor(cfg *Config) (*logParserProcessor, error) {
+	p := &logParserProcessor{
+		drop:   cfg.OnError == DropOnError,
+		obsrep: obsreport.NewProcessorObsReport(configtelemetry.GetMetricsLevelFlagValue(), cfg.Name()),
+	}
+
+	var err error
+	switch cfg.Parser {
+	case JSONParser:
+		p.parser = jsonParser{}
+	case RegexParser:
+		if cfg.Regex == "" {
+			return nil, errNoRegex
+		}
+		if p.parser, err = newRegexParser(cfg.Regex); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errInvalidParser
+	}
+	if cfg.OnError != SendOnError && cfg.OnError != DropOnError {
+		return nil, errInvalidErrorMode
+	}
+	if cfg.Timestamp != nil {
+		if p.timestamp, err = newTimestampExtractor(cfg.Timestamp); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Severity != nil {
+		if p.severity, err = newSeverityExtractor(cfg.Severity); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// ProcessLogs parses the log records, and drops the ones which can't be parsed
+// with the drop error mode.
+func (p *logParserProcessor) ProcessLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	total, dropped := 0, 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			kept := pdata.NewLogSlice()
+			for k := 0; k < logs.Len(); k++ {
+				if err := p.parseRecord(logs.At(k)); err != nil && p.drop {
+					continue
+				}
+				kept.Append(logs.At(k))
+			}
+			total += logs.Len()
+			if kept.Len() < logs.Len() {
+				dropped += logs.Len() - kept.Len()
+				logs.Resize(0)
+				kept.MoveAndAppendTo(logs)
+			}
+		}
+	}
+
+	p.obsrep.LogsAccepted(ctx, total-dropped)
+	if dropped > 0 {
+		p.obsrep.LogsDropped(ctx, dropped)
+		if dropped == total {
+			return ld, processorhelper.ErrSkipProcessingData
+		}
+	}
+	return ld, nil
+}
+
+// parseRecord parses the body of the log record into its attributes and sets
+// its timestamp and severity. The record is left unchanged if it can't be
+// parsed.
+func (p *logParserProcessor) parseRecord(lr pdata.LogRecord) error {
+	if lr.Body().Type() != pdata.AttributeValueSTRING {
+		return errBodyNotString
+	}
+	parsed := pdata.NewAttributeMap()
+	if err := p.parser.parse(lr.Body().StringVal(), parsed); err != nil {
+		return err
+	}
+
+	var timestamp pdata.Timestamp
+	hasTimestamp := false
+	if p.timestamp != nil {
+		var err error
+		if timestamp, hasTimestamp, err = p.timestamp.extract(parsed); err != nil {
+			return err
+		}
+	}
+	if hasTimestamp {
+		lr.SetTimestamp(timestamp)
+		parsed.Delete(p.timestamp.field)
+	}
+	if p.severity != nil {
+		if text, number, ok := p.severity.extract(parsed); ok {
+			lr.SetSeverityText(text)
+			lr.SetSeverityNumber(number)
+			parsed.Delete(p.severity.field)
+		}
+	}
+
+	attributes := lr.Attributes()
+	parsed.ForEach(func(k string, v pdata.AttributeValue) {
+		attributes.Upsert(k, v)
+	})
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func newTestLogs(bodies ...pdata.AttributeValue) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	ld.ResourceLogs().At(0).InstrumentationLibraryLogs().Resize(1)
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(len(bodies))
+	for i, body := range bodies {
+		logs.At(i).SetName("log")
+		logs.At(i).Attributes().InsertString("source", "kafka")
+		body.CopyTo(logs.At(i).Body())
+	}
+	return ld
+}
+
+func newTestConfig() *Config {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{TypeVal: typeStr, NameVal: typeStr},
+		Parser:            RegexParser,
+		Regex:             `^(?P<time>\S+ \S+) (?P<level>[A-Z]+) (?P<message>.*)$`,
+		Timestamp: &TimestampConfig{
+			Field:    "time",
+			Layout:   "2006-01-02 15:04:05.000",
+			Location: "Asia/Shanghai",
+		},
+		Severity: &SeverityConfig{
+			Field:   "level",
+			Mapping: map[string]string{"w": "warn"},
+		},
+		OnError: SendOnError,
+	}
+}
+
+func TestProcessLogs(t *testing.T) {
+	p, err := newLogParserProcessor(newTestConfig())
+	require.NoError(t, err)
+
+	ld, err := p.ProcessLogs(context.Background(), newTestLogs(
+		pdata.NewAttributeValueString("2021-03-01 08:30:00.123 W disk is almost full"),
+		pdata.NewAttributeValueString("not a log line"),
+		pdata.NewAttributeValueInt(1),
+	))
+	require.NoError(t, err)
+
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 3, logs.Len())
+
+	parsed := logs.At(0)
+	assert.Equal(t, pdata.TimestampFromTime(time.Date(2021, 3, 1, 0, 30, 0, 123000000, time.UTC)), parsed.Timestamp())
+	assert.Equal(t, "W", parsed.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberWARN, parsed.SeverityNumber())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"source":  pdata.NewAttributeValueString("kafka"),
+		"message": pdata.NewAttributeValueString("disk is almost full"),
+	}).Sort(), parsed.Attributes().Sort())
+
+	// The records which can't be parsed are sent unchanged.
+	expected := newTestLogs(pdata.NewAttributeValueString("not a log line"), pdata.NewAttributeValueInt(1))
+	expectedLogs := expected.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	assert.Equal(t, expectedLogs.At(0), logs.At(1))
+	assert.Equal(t, expectedLogs.At(1), logs.At(2))
+}
+
+func TestProcessLogs_InvalidTimestamp(t *testing.T) {
+	p, err := newLogParserProcessor(newTestConfig())
+	require.NoError(t, err)
+
+	ld, err := p.ProcessLogs(context.Background(), newTestLogs(pdata.NewAttributeValueString("yesterday 08:30 INFO started")))
+	require.NoError(t, err)
+
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.SeverityNumberUNDEFINED, lr.SeverityNumber())
+	assert.Equal(t, 1, lr.Attributes().Len())
+}
+
+func TestProcessLogs_Drop(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.OnError = DropOnError
+	p, err := newLogParserProcessor(cfg)
+	require.NoError(t, err)
+
+	ld, err := p.ProcessLogs(context.Background(), newTestLogs(
+		pdata.NewAttributeValueString("not a log line"),
+		pdata.NewAttributeValueString("2021-03-01 08:30:00.000 INFO started"),
+		pdata.NewAttributeValueInt(1),
+	))
+	require.NoError(t, err)
+
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "INFO", logs.At(0).SeverityText())
+
+	_, err = p.ProcessLogs(context.Background(), newTestLogs(pdata.NewAttributeValueString("not a log line")))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
+
+func TestProcessLogs_JSON(t *testing.T) {
+	p, err := newLogParserProcessor(&Config{
+		ProcessorSettings: configmodels.ProcessorSettings{TypeVal: typeStr, NameVal: typeStr},
+		Parser:            JSONParser,
+		Timestamp:         &TimestampConfig{Field: "ts", Layout: "epoch_ms"},
+		Severity:          &SeverityConfig{Field: "severity"},
+		OnError:           SendOnError,
+	})
+	require.NoError(t, err)
+
+	ld, err := p.ProcessLogs(context.Background(), newTestLogs(
+		pdata.NewAttributeValueString(`{"ts":1614587400123,"severity":"error","msg":"failed","source":"app"}`),
+	))
+	require.NoError(t, err)
+
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampFromTime(time.Date(2021, 3, 1, 8, 30, 0, 123000000, time.UTC)), lr.Timestamp())
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"source": pdata.NewAttributeValueString("app"),
+		"msg":    pdata.NewAttributeValueString("failed"),
+	}).Sort(), lr.Attributes().Sort())
+}
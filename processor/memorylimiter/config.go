@@ -18,11 +18,25 @@
 package memorylimiter
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
 )
 
+// ThrottleBehavior is what the processor does with the data received while the
+// memory usage is above the soft limit.
+type ThrottleBehavior string
+
+const (
+	// RefuseBehavior refuses the data with an error, so that the receivers ask
+	// their clients to retry later.
+	RefuseBehavior ThrottleBehavior = "refuse"
+	// DropBehavior drops the data without returning an error.
+	DropBehavior ThrottleBehavior = "drop"
+)
+
 // Config defines configuration for memory memoryLimiter processor.
 type Config struct {
 	configmodels.ProcessorSettings `mapstructure:",squash"`
@@ -41,7 +55,8 @@ type Config struct {
 	MemorySpikeLimitMiB uint32 `mapstructure:"spike_limit_mib"`
 
 	// BallastSizeMiB is the size, in MiB, of the ballast size being used by the
-	// process.
+	// process. Only used with the deprecated --mem-ballast-size-mib flag, the
+	// current size of the memory_ballast extension is used when it is configured.
 	BallastSizeMiB uint32 `mapstructure:"ballast_size_mib"`
 
 	// MemoryLimitPercentage is the maximum amount of memory, in %, targeted to be
@@ -50,6 +65,71 @@ type Config struct {
 	// MemorySpikePercentage is the maximum, in percents against the total memory,
 	// spike expected between the measurements of memory usage.
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+
+	// ThrottleBehavior is "refuse" to refuse the data with an error while the
+	// memory usage is above the soft limit, or "drop" to drop it silently.
+	ThrottleBehavior ThrottleBehavior `mapstructure:"throttle_behavior"`
+
+	// Pipelines overrides the limits and the throttle behavior for the pipelines
+	// with the given names, e.g. to start dropping the metrics before refusing
+	// the traces. The pipelines must use the processor.
+	Pipelines map[string]*PipelineLimits `mapstructure:"pipelines"`
+}
+
+var _ configmodels.PipelinesValidator = (*Config)(nil)
+
+// PipelineLimits are the limits and the throttle behavior of a pipeline. The
+// limits replace all the limits of the processor when any of them is set.
+type PipelineLimits struct {
+	MemoryLimitMiB        uint32           `mapstructure:"limit_mib"`
+	MemorySpikeLimitMiB   uint32           `mapstructure:"spike_limit_mib"`
+	MemoryLimitPercentage uint32           `mapstructure:"limit_percentage"`
+	MemorySpikePercentage uint32           `mapstructure:"spike_limit_percentage"`
+	ThrottleBehavior      ThrottleBehavior `mapstructure:"throttle_behavior"`
+}
+
+// ValidatePipelines checks that the overridden pipelines exist and use the processor.
+func (cfg *Config) ValidatePipelines(pipelines configmodels.Pipelines) error {
+	names := make([]string, 0, len(cfg.Pipelines))
+	for name := range cfg.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pipeline, ok := pipelines[name]
+		if !ok {
+			return fmt.Errorf("pipelines references pipeline %q which does not exist", name)
+		}
+		used := false
+		for _, ref := range pipeline.Processors {
+			used = used || ref == cfg.Name()
+		}
+		if !used {
+			return fmt.Errorf("pipelines references pipeline %q which does not use the processor", name)
+		}
+	}
+	return nil
+}
+
+// pipelineConfig returns the config of the pipeline with the given name, with
+// the overrides of the pipeline applied.
+func (cfg *Config) pipelineConfig(pipelineName string) *Config {
+	pCfg := *cfg
+	limits, ok := cfg.Pipelines[pipelineName]
+	if !ok || limits == nil {
+		return &pCfg
+	}
+	if limits.MemoryLimitMiB != 0 || limits.MemoryLimitPercentage != 0 {
+		pCfg.MemoryLimitMiB = limits.MemoryLimitMiB
+		pCfg.MemorySpikeLimitMiB = limits.MemorySpikeLimitMiB
+		pCfg.MemoryLimitPercentage = limits.MemoryLimitPercentage
+		pCfg.MemorySpikePercentage = limits.MemorySpikePercentage
+	}
+	if limits.ThrottleBehavior != "" {
+		pCfg.ThrottleBehavior = limits.ThrottleBehavior
+	}
+	return &pCfg
 }
 
 // Name of BallastSizeMiB config option.
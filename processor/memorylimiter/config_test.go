@@ -49,6 +49,7 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "memory_limiter",
 				NameVal: "memory_limiter",
 			},
+			ThrottleBehavior: RefuseBehavior,
 		})
 
 	p1 := cfg.Processors["memory_limiter/with-settings"]
@@ -62,5 +63,38 @@ func TestLoadConfig(t *testing.T) {
 			MemoryLimitMiB:      4000,
 			MemorySpikeLimitMiB: 500,
 			BallastSizeMiB:      2000,
+			ThrottleBehavior:    RefuseBehavior,
 		})
+
+	p2 := cfg.Processors["memory_limiter/per-pipeline"]
+	assert.Equal(t, p2,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "memory_limiter",
+				NameVal: "memory_limiter/per-pipeline",
+			},
+			CheckInterval:    time.Second,
+			ThrottleBehavior: RefuseBehavior,
+			Pipelines: map[string]*PipelineLimits{
+				"metrics": {
+					MemoryLimitPercentage: 50,
+					MemorySpikePercentage: 10,
+					ThrottleBehavior:      DropBehavior,
+				},
+			},
+		})
+}
+
+func TestLoadConfig_InvalidPipelines(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	factories.Processors[typeStr] = NewFactory()
+
+	_, err = configtest.LoadConfigFile(t, path.Join(".", "testdata", "config-unknown-pipeline.yaml"), factories)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `pipeline "metrics/2" which does not exist`)
+
+	_, err = configtest.LoadConfigFile(t, path.Join(".", "testdata", "config-unused-pipeline.yaml"), factories)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `pipeline "traces" which does not use the processor`)
 }
@@ -48,6 +48,7 @@ func createDefaultConfig() configmodels.Processor {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
+		ThrottleBehavior: RefuseBehavior,
 	}
 }
 
@@ -57,7 +58,7 @@ func createTraceProcessor(
 	cfg configmodels.Processor,
 	nextConsumer consumer.TracesConsumer,
 ) (component.TracesProcessor, error) {
-	ml, err := newMemoryLimiter(params.Logger, cfg.(*Config))
+	ml, err := newMemoryLimiter(params.Logger, cfg.(*Config), params.PipelineName)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +67,7 @@ func createTraceProcessor(
 		nextConsumer,
 		ml,
 		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(ml.start),
 		processorhelper.WithShutdown(ml.shutdown))
 }
 
@@ -75,7 +77,7 @@ func createMetricsProcessor(
 	cfg configmodels.Processor,
 	nextConsumer consumer.MetricsConsumer,
 ) (component.MetricsProcessor, error) {
-	ml, err := newMemoryLimiter(params.Logger, cfg.(*Config))
+	ml, err := newMemoryLimiter(params.Logger, cfg.(*Config), params.PipelineName)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +86,7 @@ func createMetricsProcessor(
 		nextConsumer,
 		ml,
 		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(ml.start),
 		processorhelper.WithShutdown(ml.shutdown))
 }
 
@@ -93,7 +96,7 @@ func createLogsProcessor(
 	cfg configmodels.Processor,
 	nextConsumer consumer.LogsConsumer,
 ) (component.LogsProcessor, error) {
-	ml, err := newMemoryLimiter(params.Logger, cfg.(*Config))
+	ml, err := newMemoryLimiter(params.Logger, cfg.(*Config), params.PipelineName)
 	if err != nil {
 		return nil, err
 	}
@@ -102,5 +105,6 @@ func createLogsProcessor(
 		nextConsumer,
 		ml,
 		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(ml.start),
 		processorhelper.WithShutdown(ml.shutdown))
 }
@@ -25,11 +25,13 @@ import (
 	"go.opencensus.io/stats"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/iruntime"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor"
-	"go.opentelemetry.io/collector/processor/memorylimiter/internal/iruntime"
+	"go.opentelemetry.io/collector/processor/processorhelper"
 )
 
 const (
@@ -55,20 +57,39 @@ var (
 	errPercentageLimitOutOfRange = errors.New(
 		"memoryLimitPercentage and memorySpikePercentage must be greater than zero and less than or equal to hundred",
 	)
+
+	errInvalidThrottleBehavior = errors.New(
+		"throttleBehavior must be \"refuse\" or \"drop\"")
 )
 
 // make it overridable by tests
 var getMemoryFn = iruntime.TotalMemory
 
+// make it overridable by tests
+var getMemoryQuotaFn = iruntime.MemoryQuota
+
+// cgroupLimitPercentage is the percentage of the cgroup memory limit used as the
+// limit when no limit is configured.
+const cgroupLimitPercentage = 80
+
 type memoryLimiter struct {
 	usageChecker memUsageChecker
 
 	memCheckWait time.Duration
-	ballastSize  uint64
+	// ballastSize is the size of the ballast set by ballast_size_mib, used
+	// when the memory_ballast extension is not configured.
+	ballastSize uint64
+	// ballast holds the ballastSizer of the memory_ballast extension, if
+	// configured, read on every check since the ballast may be resized.
+	ballast atomic.Value
 
 	// forceDrop is used atomically to indicate when data should be dropped.
 	forceDrop int64
 
+	// dropData is true when the data is dropped instead of refused above the
+	// soft limit.
+	dropData bool
+
 	ticker *time.Ticker
 
 	lastGCDone time.Time
@@ -89,15 +110,23 @@ type memoryLimiter struct {
 // do GCs too frequently since it is a CPU-heavy operation.
 const minGCIntervalWhenSoftLimited = 10 * time.Second
 
-// newMemoryLimiter returns a new memorylimiter processor.
-func newMemoryLimiter(logger *zap.Logger, cfg *Config) (*memoryLimiter, error) {
+// newMemoryLimiter returns a new memorylimiter processor for the pipeline with
+// the given name.
+func newMemoryLimiter(logger *zap.Logger, cfg *Config, pipelineName string) (*memoryLimiter, error) {
+	cfg = cfg.pipelineConfig(pipelineName)
 	ballastSize := uint64(cfg.BallastSizeMiB) * mibBytes
 
 	if cfg.CheckInterval <= 0 {
 		return nil, errCheckIntervalOutOfRange
 	}
-	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
-		return nil, errLimitOutOfRange
+
+	var dropData bool
+	switch cfg.ThrottleBehavior {
+	case "", RefuseBehavior:
+	case DropBehavior:
+		dropData = true
+	default:
+		return nil, errInvalidThrottleBehavior
 	}
 
 	usageChecker, err := getMemUsageChecker(cfg, logger)
@@ -106,14 +135,17 @@ func newMemoryLimiter(logger *zap.Logger, cfg *Config) (*memoryLimiter, error) {
 	}
 
 	logger.Info("Memory limiter configured",
+		zap.String("pipeline", pipelineName),
 		zap.Uint64("limit_mib", usageChecker.memAllocLimit),
 		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit),
-		zap.Duration("check_interval", cfg.CheckInterval))
+		zap.Duration("check_interval", cfg.CheckInterval),
+		zap.Bool("drop_data", dropData))
 
 	ml := &memoryLimiter{
 		usageChecker:   *usageChecker,
 		memCheckWait:   cfg.CheckInterval,
 		ballastSize:    ballastSize,
+		dropData:       dropData,
 		ticker:         time.NewTicker(cfg.CheckInterval),
 		readMemStatsFn: runtime.ReadMemStats,
 		procName:       cfg.Name(),
@@ -132,6 +164,9 @@ func getMemUsageChecker(cfg *Config, logger *zap.Logger) (*memUsageChecker, erro
 	if cfg.MemoryLimitMiB != 0 {
 		return newFixedMemUsageChecker(memAllocLimit, memSpikeLimit)
 	}
+	if cfg.MemoryLimitPercentage == 0 {
+		return getCGroupMemUsageChecker(logger)
+	}
 	totalMemory, err := getMemoryFn()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total memory, use fixed memory settings (limit_mib): %w", err)
@@ -143,6 +178,44 @@ func getMemUsageChecker(cfg *Config, logger *zap.Logger) (*memUsageChecker, erro
 	return newPercentageMemUsageChecker(totalMemory, int64(cfg.MemoryLimitPercentage), int64(cfg.MemorySpikePercentage))
 }
 
+// getCGroupMemUsageChecker derives the limit from the cgroup memory limit when
+// no limit is configured.
+func getCGroupMemUsageChecker(logger *zap.Logger) (*memUsageChecker, error) {
+	memoryQuota, defined, err := getMemoryQuotaFn()
+	if err != nil || !defined || memoryQuota <= 0 {
+		return nil, errLimitOutOfRange
+	}
+	logger.Info("Using cgroup memory limiter",
+		zap.Int64("cgroup_memory_limit", memoryQuota),
+		zap.Uint32("limit_percentage", cgroupLimitPercentage))
+	return newFixedMemUsageChecker(uint64(memoryQuota)*cgroupLimitPercentage/100, 0)
+}
+
+// ballastSizer is implemented by the memory_ballast extension.
+type ballastSizer interface {
+	GetBallastSize() uint64
+}
+
+// start looks up the memory_ballast extension, whose current size is then
+// subtracted from the memory usage instead of ballast_size_mib.
+func (ml *memoryLimiter) start(_ context.Context, host component.Host) error {
+	for _, ext := range host.GetExtensions() {
+		if b, ok := ext.(ballastSizer); ok {
+			ml.ballast.Store(b)
+			break
+		}
+	}
+	return nil
+}
+
+// currentBallastSize returns the current size of the memory ballast, in bytes.
+func (ml *memoryLimiter) currentBallastSize() uint64 {
+	if b, ok := ml.ballast.Load().(ballastSizer); ok {
+		return b.GetBallastSize()
+	}
+	return ml.ballastSize
+}
+
 func (ml *memoryLimiter) shutdown(context.Context) error {
 	ml.ticker.Stop()
 	return nil
@@ -157,6 +230,11 @@ func (ml *memoryLimiter) ProcessTraces(ctx context.Context, td pdata.Traces) (pd
 			processor.StatDroppedSpanCount.M(int64(numSpans)),
 			processor.StatTraceBatchesDroppedCount.M(1))
 
+		if ml.dropData {
+			ml.obsrep.TracesDropped(ctx, numSpans)
+			return td, processorhelper.ErrSkipProcessingData
+		}
+
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -177,6 +255,11 @@ func (ml *memoryLimiter) ProcessTraces(ctx context.Context, td pdata.Traces) (pd
 func (ml *memoryLimiter) ProcessMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
 	_, numDataPoints := md.MetricAndDataPointCount()
 	if ml.forcingDrop() {
+		if ml.dropData {
+			ml.obsrep.MetricsDropped(ctx, numDataPoints)
+			return md, processorhelper.ErrSkipProcessingData
+		}
+
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -197,6 +280,11 @@ func (ml *memoryLimiter) ProcessMetrics(ctx context.Context, md pdata.Metrics) (
 func (ml *memoryLimiter) ProcessLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
 	numRecords := ld.LogRecordCount()
 	if ml.forcingDrop() {
+		if ml.dropData {
+			ml.obsrep.LogsDropped(ctx, numRecords)
+			return ld, processorhelper.ErrSkipProcessingData
+		}
+
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -216,15 +304,15 @@ func (ml *memoryLimiter) ProcessLogs(ctx context.Context, ld pdata.Logs) (pdata.
 func (ml *memoryLimiter) readMemStats() *runtime.MemStats {
 	ms := &runtime.MemStats{}
 	ml.readMemStatsFn(ms)
-	// If proper configured ms.Alloc should be at least ml.ballastSize but since
-	// a misconfiguration is possible check for that here.
-	if ms.Alloc >= ml.ballastSize {
-		ms.Alloc -= ml.ballastSize
+	// If proper configured ms.Alloc should be at least the ballast size but
+	// since a misconfiguration is possible check for that here.
+	if ballastSize := ml.currentBallastSize(); ms.Alloc >= ballastSize {
+		ms.Alloc -= ballastSize
 	} else if !ml.configMismatchedLogged {
 		// This indicates misconfiguration. Log it once.
 		ml.configMismatchedLogged = true
 		ml.logger.Warn(typeStr + " is likely incorrectly configured. " + ballastSizeMibKey +
-			" must be set equal to --mem-ballast-size-mib command line option.")
+			" must be set equal to the size of the ballast allocated with the --mem-ballast-size-mib flag.")
 	}
 
 	return ms
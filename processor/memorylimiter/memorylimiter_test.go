@@ -17,6 +17,7 @@ package memorylimiter
 import (
 	"context"
 	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -24,13 +25,15 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/iruntime"
 	"go.opentelemetry.io/collector/obsreport"
-	"go.opentelemetry.io/collector/processor/memorylimiter/internal/iruntime"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 )
 
@@ -81,13 +84,19 @@ func TestNew(t *testing.T) {
 			},
 		},
 	}
+	t.Cleanup(func() {
+		getMemoryQuotaFn = iruntime.MemoryQuota
+	})
+	getMemoryQuotaFn = func() (int64, bool, error) {
+		return -1, false, nil
+	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := createDefaultConfig().(*Config)
 			cfg.CheckInterval = tt.args.checkInterval
 			cfg.MemoryLimitMiB = tt.args.memoryLimitMiB
 			cfg.MemorySpikeLimitMiB = tt.args.memorySpikeLimitMiB
-			got, err := newMemoryLimiter(zap.NewNop(), cfg)
+			got, err := newMemoryLimiter(zap.NewNop(), cfg, "traces")
 			if err != tt.wantErr {
 				t.Errorf("newMemoryLimiter() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -311,6 +320,123 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
 }
 
+func TestNewWithPipelineLimits(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CheckInterval = 100 * time.Millisecond
+	cfg.MemoryLimitMiB = 1024
+	cfg.Pipelines = map[string]*PipelineLimits{
+		"metrics": {
+			MemoryLimitMiB:   512,
+			ThrottleBehavior: DropBehavior,
+		},
+		"logs/1": {
+			ThrottleBehavior: "block",
+		},
+	}
+
+	ml, err := newMemoryLimiter(zap.NewNop(), cfg, "traces")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1024*mibBytes), ml.usageChecker.memAllocLimit)
+	assert.False(t, ml.dropData)
+	assert.NoError(t, ml.shutdown(context.Background()))
+
+	ml, err = newMemoryLimiter(zap.NewNop(), cfg, "metrics")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(512*mibBytes), ml.usageChecker.memAllocLimit)
+	assert.Equal(t, uint64(512*mibBytes/5), ml.usageChecker.memSpikeLimit)
+	assert.True(t, ml.dropData)
+	assert.NoError(t, ml.shutdown(context.Background()))
+
+	ml, err = newMemoryLimiter(zap.NewNop(), cfg, "logs/1")
+	assert.Equal(t, errInvalidThrottleBehavior, err)
+	assert.Nil(t, ml)
+}
+
+func TestPipelineConfig(t *testing.T) {
+	cfg := &Config{
+		MemoryLimitMiB:      1024,
+		MemorySpikeLimitMiB: 256,
+		ThrottleBehavior:    RefuseBehavior,
+		Pipelines: map[string]*PipelineLimits{
+			"metrics": {
+				MemoryLimitPercentage: 50,
+				MemorySpikePercentage: 10,
+			},
+			"logs/1": {
+				ThrottleBehavior: DropBehavior,
+			},
+		},
+	}
+
+	assert.Equal(t, cfg, cfg.pipelineConfig("traces"))
+
+	metricsCfg := cfg.pipelineConfig("metrics")
+	assert.Equal(t, uint32(0), metricsCfg.MemoryLimitMiB)
+	assert.Equal(t, uint32(0), metricsCfg.MemorySpikeLimitMiB)
+	assert.Equal(t, uint32(50), metricsCfg.MemoryLimitPercentage)
+	assert.Equal(t, uint32(10), metricsCfg.MemorySpikePercentage)
+	assert.Equal(t, RefuseBehavior, metricsCfg.ThrottleBehavior)
+
+	logsCfg := cfg.pipelineConfig("logs/1")
+	assert.Equal(t, uint32(1024), logsCfg.MemoryLimitMiB)
+	assert.Equal(t, uint32(256), logsCfg.MemorySpikeLimitMiB)
+	assert.Equal(t, DropBehavior, logsCfg.ThrottleBehavior)
+}
+
+// TestDropMemoryPressureResponse checks that the data is dropped without error
+// with the drop behavior.
+func TestDropMemoryPressureResponse(t *testing.T) {
+	var currentMemAlloc uint64
+	ml := &memoryLimiter{
+		usageChecker: memUsageChecker{
+			memAllocLimit: 1024,
+		},
+		dropData: true,
+		readMemStatsFn: func(ms *runtime.MemStats) {
+			ms.Alloc = currentMemAlloc
+		},
+		obsrep: obsreport.NewProcessorObsReport(configtelemetry.LevelNone, ""),
+		logger: zap.NewNop(),
+	}
+	cfg := &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+	}
+	tracesSink := new(consumertest.TracesSink)
+	tp, err := processorhelper.NewTraceProcessor(cfg, tracesSink, ml)
+	require.NoError(t, err)
+	metricsSink := new(consumertest.MetricsSink)
+	mp, err := processorhelper.NewMetricsProcessor(cfg, metricsSink, ml)
+	require.NoError(t, err)
+	logsSink := new(consumertest.LogsSink)
+	lp, err := processorhelper.NewLogsProcessor(cfg, logsSink, ml)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Below memAllocLimit.
+	currentMemAlloc = 800
+	ml.checkMemLimits()
+	assert.NoError(t, tp.ConsumeTraces(ctx, pdata.NewTraces()))
+	assert.NoError(t, mp.ConsumeMetrics(ctx, pdata.NewMetrics()))
+	assert.NoError(t, lp.ConsumeLogs(ctx, pdata.NewLogs()))
+	assert.Len(t, tracesSink.AllTraces(), 1)
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Len(t, logsSink.AllLogs(), 1)
+
+	// Above memAllocLimit.
+	currentMemAlloc = 1800
+	ml.checkMemLimits()
+	assert.NoError(t, tp.ConsumeTraces(ctx, pdata.NewTraces()))
+	assert.NoError(t, mp.ConsumeMetrics(ctx, pdata.NewMetrics()))
+	assert.NoError(t, lp.ConsumeLogs(ctx, pdata.NewLogs()))
+	assert.Len(t, tracesSink.AllTraces(), 1)
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Len(t, logsSink.AllLogs(), 1)
+}
+
 func TestGetDecision(t *testing.T) {
 	t.Run("fixed_limit", func(t *testing.T) {
 		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 100, MemorySpikeLimitMiB: 20}, zap.NewNop())
@@ -328,6 +454,7 @@ func TestGetDecision(t *testing.T) {
 
 	t.Cleanup(func() {
 		getMemoryFn = iruntime.TotalMemory
+		getMemoryQuotaFn = iruntime.MemoryQuota
 	})
 	getMemoryFn = func() (int64, error) {
 		return 100 * mibBytes, nil
@@ -340,6 +467,25 @@ func TestGetDecision(t *testing.T) {
 			memSpikeLimit: 10 * mibBytes,
 		}, d)
 	})
+	t.Run("cgroup_limit", func(t *testing.T) {
+		getMemoryQuotaFn = func() (int64, bool, error) {
+			return 100 * mibBytes, true, nil
+		}
+		d, err := getMemUsageChecker(&Config{}, zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, &memUsageChecker{
+			memAllocLimit: 80 * mibBytes,
+			memSpikeLimit: 16 * mibBytes,
+		}, d)
+	})
+	t.Run("cgroup_limit_undefined", func(t *testing.T) {
+		getMemoryQuotaFn = func() (int64, bool, error) {
+			return -1, false, nil
+		}
+		d, err := getMemUsageChecker(&Config{}, zap.NewNop())
+		assert.Equal(t, errLimitOutOfRange, err)
+		assert.Nil(t, d)
+	})
 	t.Run("percentage_limit_error", func(t *testing.T) {
 		d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 101, MemorySpikePercentage: 10}, zap.NewNop())
 		require.Error(t, err)
@@ -408,3 +554,49 @@ func TestDropDecision(t *testing.T) {
 		})
 	}
 }
+
+// ballastHost is a host with a memory_ballast extension of the given size.
+type ballastHost struct {
+	component.Host
+	ballast *fakeBallast
+}
+
+func (h *ballastHost) GetExtensions() map[configmodels.NamedEntity]component.Extension {
+	return map[configmodels.NamedEntity]component.Extension{
+		&configmodels.ExtensionSettings{TypeVal: "memory_ballast", NameVal: "memory_ballast"}: h.ballast,
+	}
+}
+
+type fakeBallast struct {
+	component.Extension
+	size uint64
+}
+
+func (b *fakeBallast) GetBallastSize() uint64 {
+	return atomic.LoadUint64(&b.size)
+}
+
+func TestBallastExtension(t *testing.T) {
+	var currentMemAlloc uint64
+	ml := &memoryLimiter{
+		usageChecker: memUsageChecker{
+			memAllocLimit: 1024,
+		},
+		ballastSize: 100,
+		readMemStatsFn: func(ms *runtime.MemStats) {
+			ms.Alloc = currentMemAlloc
+		},
+		logger: zap.NewNop(),
+	}
+
+	// ballast_size_mib is used without the extension.
+	currentMemAlloc = 1000
+	assert.Equal(t, uint64(900), ml.readMemStats().Alloc)
+
+	// The current size of the extension is used instead, following its resizes.
+	ballast := &fakeBallast{size: 500}
+	require.NoError(t, ml.start(context.Background(), &ballastHost{Host: componenttest.NewNopHost(), ballast: ballast}))
+	assert.Equal(t, uint64(500), ml.readMemStats().Alloc)
+	atomic.StoreUint64(&ballast.size, 800)
+	assert.Equal(t, uint64(200), ml.readMemStats().Alloc)
+}
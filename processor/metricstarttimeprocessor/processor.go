@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstarttimeprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/series"
+)
+
+// seriesState is what is known of a series to detect its resets.
+type seriesState struct {
+	// startTime is the adjusted start time of the series.
+	startTime pdata.Timestamp
+	// reportedStartTime is the start time of the previous data point, as
+	// received.
+	reportedStartTime pdata.Timestamp
+	timestamp         pdata.Timestamp
+	value             float64
+	lastSeen          time.Time
+}
+
+type startTimeProcessor struct {
+	metrics map[string]struct{}
+	sweeper *series.Sweeper
+
+	lock   sync.Mutex
+	series map[string]*seriesState
+	now    func() time.Time
+}
+
+func newStartTimeProcessor(cfg *Config) *startTimeProcessor {
+	var metrics map[string]struct{}
+	if len(cfg.Metrics) > 0 {
+		metrics = make(map[string]struct{}, len(cfg.Metrics))
+		for _, name := range cfg.Metrics {
+			metrics[name] = struct{}{}
+		}
+	}
+	return &startTimeProcessor{
+		metrics: metrics,
+		sweeper: series.NewSweeper(cfg.MaxStaleness),
+		series:  make(map[string]*seriesState),
+		now:     time.Now,
+	}
+}
+
+// ProcessMetrics sets the start times of the data points of the cumulative
+// monotonic sums, histograms and summaries to the adjusted start times of their
+// series.
+func (p *startTimeProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	p.removeStaleSeries(now)
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := series.AttributesKey(rm.Resource().Attributes())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			library := ilm.InstrumentationLibrary()
+			libraryKey := resourceKey + "\x00" + library.Name() + "\x00" + library.Version()
+
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				p.adjustMetric(libraryKey+"\x00"+metric.Name(), metric, now)
+			}
+		}
+	}
+	return md, nil
+}
+
+// removeStaleSeries evicts the series not seen for longer than the max
+// staleness.
+func (p *startTimeProcessor) removeStaleSeries(now time.Time) {
+	staleBefore, ok := p.sweeper.StaleBefore(now)
+	if !ok {
+		return
+	}
+	for key, state := range p.series {
+		if state.lastSeen.Before(staleBefore) {
+			delete(p.series, key)
+		}
+	}
+}
+
+// adjustMetric adjusts the start times of the data points of the metric if it
+// is a selected cumulative monotonic sum, histogram or summary. The resets of
+// the sums are detected from their values, and the ones of the histograms and
+// summaries from their counts.
+func (p *startTimeProcessor) adjustMetric(key string, metric pdata.Metric, now time.Time) {
+	if p.metrics != nil {
+		if _, ok := p.metrics[metric.Name()]; !ok {
+			return
+		}
+	}
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		sum := metric.IntSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityCumulative || !sum.IsMonotonic() {
+			return
+		}
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTime(p.adjust(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), float64(dp.Value()), now))
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		sum := metric.DoubleSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityCumulative || !sum.IsMonotonic() {
+			return
+		}
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTime(p.adjust(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), dp.Value(), now))
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		histogram := metric.IntHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return
+		}
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTime(p.adjust(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), float64(dp.Count()), now))
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		histogram := metric.DoubleHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return
+		}
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTime(p.adjust(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), float64(dp.Count()), now))
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		dps := metric.DoubleSummary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTime(p.adjust(key+"\x00"+series.LabelsKey(dp.LabelsMap()), dp.StartTime(), dp.Timestamp(), float64(dp.Count()), now))
+		}
+	}
+}
+
+// adjust returns the adjusted start time of the data point of the series.
+//
+// A new series starts at the start time of its first data point, or at its
+// timestamp when its start time is not set. The series is reset when the value
+// decreases or the start time increases, and restarts at the new start time
+// if it is after the previous data point, or else at the timestamp of the
+// previous data point, the latest time the reset is known not to have happened
+// before. The data points which are not newer than the previous one of their
+// series get the current start time without updating the series.
+func (p *startTimeProcessor) adjust(key string, startTime, timestamp pdata.Timestamp, value float64, now time.Time) pdata.Timestamp {
+	state, ok := p.series[key]
+	if !ok {
+		state = &seriesState{startTime: startTime}
+		if startTime == 0 || startTime > timestamp {
+			state.startTime = timestamp
+		}
+		p.series[key] = state
+	} else if timestamp <= state.timestamp {
+		return state.startTime
+	} else if value < state.value || startTime > state.reportedStartTime {
+		if startTime > state.timestamp && startTime <= timestamp {
+			state.startTime = startTime
+		} else {
+			state.startTime = state.timestamp
+		}
+	}
+	state.reportedStartTime = startTime
+	state.timestamp = timestamp
+	state.value = value
+	state.lastSeen = now
+	return state.startTime
+}
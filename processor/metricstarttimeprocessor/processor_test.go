@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstarttimeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// testPoint is a data point of a sum.
+type testPoint struct {
+	startTime pdata.Timestamp
+	timestamp pdata.Timestamp
+	value     float64
+}
+
+// newSum returns a batch with a single cumulative sum for the given host.
+func newSum(host string, name string, dataType pdata.MetricDataType, points ...testPoint) pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("host.name", host)
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ms := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	ms.Resize(1)
+	m := ms.At(0)
+	m.SetName(name)
+	m.SetDataType(dataType)
+	switch dataType {
+	case pdata.MetricDataTypeIntSum:
+		m.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		m.IntSum().SetIsMonotonic(true)
+		dps := m.IntSum().DataPoints()
+		dps.Resize(len(points))
+		for i, tp := range points {
+			dps.At(i).SetStartTime(tp.startTime)
+			dps.At(i).SetTimestamp(tp.timestamp)
+			dps.At(i).SetValue(int64(tp.value))
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		m.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		m.DoubleSum().SetIsMonotonic(true)
+		dps := m.DoubleSum().DataPoints()
+		dps.Resize(len(points))
+		for i, tp := range points {
+			dps.At(i).SetStartTime(tp.startTime)
+			dps.At(i).SetTimestamp(tp.timestamp)
+			dps.At(i).SetValue(tp.value)
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		m.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		dps := m.DoubleHistogram().DataPoints()
+		dps.Resize(len(points))
+		for i, tp := range points {
+			dps.At(i).SetStartTime(tp.startTime)
+			dps.At(i).SetTimestamp(tp.timestamp)
+			dps.At(i).SetCount(uint64(tp.value))
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		dps := m.DoubleSummary().DataPoints()
+		dps.Resize(len(points))
+		for i, tp := range points {
+			dps.At(i).SetStartTime(tp.startTime)
+			dps.At(i).SetTimestamp(tp.timestamp)
+			dps.At(i).SetCount(uint64(tp.value))
+		}
+	}
+	return md
+}
+
+// startTimes returns the start times of the data points of the first metric of
+// the batch.
+func startTimes(md pdata.Metrics) []pdata.Timestamp {
+	m := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	var times []pdata.Timestamp
+	switch m.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		dps := m.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			times = append(times, dps.At(i).StartTime())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := m.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			times = append(times, dps.At(i).StartTime())
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		dps := m.DoubleHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			times = append(times, dps.At(i).StartTime())
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		dps := m.DoubleSummary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			times = append(times, dps.At(i).StartTime())
+		}
+	}
+	return times
+}
+
+func TestProcessMetrics_Resets(t *testing.T) {
+	dataTypes := []pdata.MetricDataType{
+		pdata.MetricDataTypeIntSum,
+		pdata.MetricDataTypeDoubleSum,
+		pdata.MetricDataTypeDoubleHistogram,
+		pdata.MetricDataTypeDoubleSummary,
+	}
+	for _, dataType := range dataTypes {
+		t.Run(dataType.String(), func(t *testing.T) {
+			p := newStartTimeProcessor(&Config{})
+
+			// The series without start time starts at its first data point.
+			md, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType,
+				testPoint{timestamp: 10, value: 5},
+				testPoint{timestamp: 20, value: 8}))
+			require.NoError(t, err)
+			assert.Equal(t, []pdata.Timestamp{10, 10}, startTimes(md))
+
+			// The value decreased after a restart, the series restarts at the previous
+			// data point.
+			md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType,
+				testPoint{timestamp: 30, value: 2},
+				testPoint{timestamp: 40, value: 6}))
+			require.NoError(t, err)
+			assert.Equal(t, []pdata.Timestamp{20, 20}, startTimes(md))
+
+			// The data points which are not newer keep the current start time.
+			md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", dataType,
+				testPoint{timestamp: 15, value: 7}))
+			require.NoError(t, err)
+			assert.Equal(t, []pdata.Timestamp{20}, startTimes(md))
+
+			// The other series are independent.
+			md, err = p.ProcessMetrics(context.Background(), newSum("web-2", "requests", dataType,
+				testPoint{timestamp: 40, value: 1}))
+			require.NoError(t, err)
+			assert.Equal(t, []pdata.Timestamp{40}, startTimes(md))
+		})
+	}
+}
+
+func TestProcessMetrics_ReportedStartTimes(t *testing.T) {
+	p := newStartTimeProcessor(&Config{})
+
+	md, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{startTime: 1, timestamp: 10, value: 5},
+		testPoint{startTime: 1, timestamp: 20, value: 8}))
+	require.NoError(t, err)
+	assert.Equal(t, []pdata.Timestamp{1, 1}, startTimes(md))
+
+	// The reported start time of the restart is kept when it is after the
+	// previous data point.
+	md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{startTime: 25, timestamp: 30, value: 2}))
+	require.NoError(t, err)
+	assert.Equal(t, []pdata.Timestamp{25}, startTimes(md))
+
+	// The value didn't decrease, but the start time increased.
+	md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{startTime: 35, timestamp: 40, value: 4}))
+	require.NoError(t, err)
+	assert.Equal(t, []pdata.Timestamp{35}, startTimes(md))
+
+	// The start time of a reset which is not after the previous data point is
+	// replaced with the timestamp of the previous data point.
+	md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{startTime: 1, timestamp: 50, value: 1}))
+	require.NoError(t, err)
+	assert.Equal(t, []pdata.Timestamp{40}, startTimes(md))
+}
+
+func TestProcessMetrics_Selection(t *testing.T) {
+	p := newStartTimeProcessor(&Config{Metrics: []string{"requests"}})
+
+	md, err := p.ProcessMetrics(context.Background(), newSum("web-1", "errors", pdata.MetricDataTypeIntSum,
+		testPoint{timestamp: 10, value: 5}))
+	require.NoError(t, err)
+	assert.Equal(t, []pdata.Timestamp{0}, startTimes(md))
+
+	// The non monotonic sums are not adjusted, their values can decrease.
+	md = newSum("web-1", "requests", pdata.MetricDataTypeIntSum, testPoint{timestamp: 10, value: 5})
+	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().SetIsMonotonic(false)
+	md, err = p.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, []pdata.Timestamp{0}, startTimes(md))
+	assert.Empty(t, p.series)
+}
+
+func TestProcessMetrics_MaxStaleness(t *testing.T) {
+	p := newStartTimeProcessor(&Config{MaxStaleness: time.Minute})
+	now := time.Unix(1000, 0)
+	p.now = func() time.Time { return now }
+
+	_, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{timestamp: 10, value: 10}))
+	require.NoError(t, err)
+
+	now = now.Add(30 * time.Second)
+	md, err := p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{timestamp: 20, value: 15}))
+	require.NoError(t, err)
+	assert.Equal(t, []pdata.Timestamp{10}, startTimes(md))
+
+	// The series is evicted once it is not seen for longer than the max staleness,
+	// and starts over.
+	now = now.Add(2 * time.Minute)
+	md, err = p.ProcessMetrics(context.Background(), newSum("web-1", "requests", pdata.MetricDataTypeIntSum,
+		testPoint{timestamp: 30, value: 2}))
+	require.NoError(t, err)
+	assert.Equal(t, []pdata.Timestamp{30}, startTimes(md))
+	assert.Len(t, p.series, 1)
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Metrics Transform processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Transforms are the transformations applied in order to the metrics.
+	Transforms []Transform `mapstructure:"transforms"`
+}
+
+// MatchType is how the metric names are matched.
+type MatchType string
+
+const (
+	// StrictMatchType matches the metrics whose name is equal to the include field.
+	StrictMatchType MatchType = "strict"
+	// RegexpMatchType matches the metrics whose name matches the regular expression of the include field.
+	RegexpMatchType MatchType = "regexp"
+)
+
+// ConfigAction is what a transform does with the matched metrics.
+type ConfigAction string
+
+const (
+	// Update modifies the matched metrics in place.
+	Update ConfigAction = "update"
+	// Insert adds a modified copy of the matched metrics, the matched metrics are left unchanged.
+	Insert ConfigAction = "insert"
+)
+
+// OperationAction is the kind of an operation.
+type OperationAction string
+
+const (
+	// UpdateLabel renames a label and/or its values.
+	UpdateLabel OperationAction = "update_label"
+	// AddLabel sets a label to a value on all the data points.
+	AddLabel OperationAction = "add_label"
+	// DeleteLabel removes a label, aggregating the data points which become identical.
+	DeleteLabel OperationAction = "delete_label"
+	// AggregateLabelValues replaces some values of a label with a new value, aggregating
+	// the data points which become identical.
+	AggregateLabelValues OperationAction = "aggregate_label_values"
+	// ScaleValue multiplies the values of the data points by a factor.
+	ScaleValue OperationAction = "scale_value"
+)
+
+// AggregationType is how the values of the aggregated data points are combined.
+type AggregationType string
+
+const (
+	// Sum adds the values.
+	Sum AggregationType = "sum"
+	// Mean averages the values.
+	Mean AggregationType = "mean"
+	// Max keeps the largest value.
+	Max AggregationType = "max"
+	// Min keeps the smallest value.
+	Min AggregationType = "min"
+)
+
+// Transform is a transformation of the metrics matching a name.
+type Transform struct {
+	// Include is the name of the metrics to transform, or a regular expression
+	// matching their names.
+	Include string `mapstructure:"include"`
+
+	// MatchType is how Include is matched, "strict" or "regexp". If not set, the
+	// names are matched strictly.
+	MatchType MatchType `mapstructure:"match_type"`
+
+	// Action is "update" to modify the matched metrics or "insert" to add modified
+	// copies of them.
+	Action ConfigAction `mapstructure:"action"`
+
+	// NewName is the new name of the metrics, required by the insert action. With
+	// the regexp match type, it can reference the capture groups of Include, e.g. "$1".
+	NewName string `mapstructure:"new_name"`
+
+	// Operations are applied in order to the data points of the metrics.
+	Operations []Operation `mapstructure:"operations"`
+}
+
+// Operation is an operation on the data points of a metric.
+type Operation struct {
+	// Action is the kind of the operation.
+	Action OperationAction `mapstructure:"action"`
+
+	// Label is the label the operation applies to, used by update_label,
+	// delete_label and aggregate_label_values.
+	Label string `mapstructure:"label"`
+
+	// NewLabel is the new name of the label for update_label, or the label
+	// set by add_label.
+	NewLabel string `mapstructure:"new_label"`
+
+	// NewValue is the value set by add_label, or the value replacing the
+	// aggregated values for aggregate_label_values.
+	NewValue string `mapstructure:"new_value"`
+
+	// ValueActions are the label values renamed by update_label.
+	ValueActions []ValueAction `mapstructure:"value_actions"`
+
+	// AggregationType is how delete_label and aggregate_label_values combine
+	// the data points. If not set, the values are summed.
+	AggregationType AggregationType `mapstructure:"aggregation_type"`
+
+	// AggregatedValues are the label values replaced by aggregate_label_values.
+	AggregatedValues []string `mapstructure:"aggregated_values"`
+
+	// Scale is the factor the values are multiplied by for scale_value.
+	Scale float64 `mapstructure:"scale"`
+}
+
+// ValueAction renames a label value.
+type ValueAction struct {
+	// Value is the current label value.
+	Value string `mapstructure:"value"`
+
+	// NewValue is the label value replacing it.
+	NewValue string `mapstructure:"new_value"`
+}
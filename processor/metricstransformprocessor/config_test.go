@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "metricstransform",
+			NameVal: "metricstransform",
+		},
+		Transforms: []Transform{
+			{
+				Include: "system.cpu.usage",
+				Action:  Update,
+				NewName: "system.cpu.utilization",
+				Operations: []Operation{
+					{
+						Action:       UpdateLabel,
+						Label:        "state",
+						NewLabel:     "cpu_state",
+						ValueActions: []ValueAction{{Value: "idle", NewValue: "free"}},
+					},
+				},
+			},
+			{
+				Include:   `^system\.(.*)$`,
+				MatchType: RegexpMatchType,
+				Action:    Insert,
+				NewName:   "host.$1",
+				Operations: []Operation{
+					{Action: DeleteLabel, Label: "cpu", AggregationType: Max},
+					{Action: ScaleValue, Scale: 1000},
+				},
+			},
+			{
+				Include: "system.memory.usage",
+				Action:  Update,
+				Operations: []Operation{
+					{Action: AddLabel, NewLabel: "unit", NewValue: "bytes"},
+					{
+						Action:           AggregateLabelValues,
+						Label:            "state",
+						AggregatedValues: []string{"user", "system"},
+						NewValue:         "used",
+						AggregationType:  Sum,
+					},
+				},
+			},
+		},
+	}, cfg.Processors["metricstransform"])
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.Empty(t, cfg.(*Config).Transforms)
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Transforms = []Transform{{Include: "system.cpu.usage", Action: Update, NewName: "system.cpu.utilization"}}
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, mp)
+	assert.True(t, mp.GetCapabilities().MutatesConsumedData)
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	assert.Nil(t, tp)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	assert.Nil(t, lp)
+}
+
+func TestCreateProcessors_InvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name          string
+		transform     Transform
+		expectedError string
+	}{
+		{
+			name:          "missing include",
+			transform:     Transform{Action: Update},
+			expectedError: `invalid transform for "": missing required field "include"`,
+		},
+		{
+			name:          "invalid match_type",
+			transform:     Transform{Include: "m", MatchType: "glob", Action: Update},
+			expectedError: `invalid transform for "m": invalid match_type "glob", must be "strict" or "regexp"`,
+		},
+		{
+			name:          "invalid regexp",
+			transform:     Transform{Include: "(", MatchType: RegexpMatchType, Action: Update},
+			expectedError: "invalid transform for \"(\": error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:          "invalid action",
+			transform:     Transform{Include: "m", Action: "upsert"},
+			expectedError: `invalid transform for "m": invalid action "upsert", must be "update" or "insert"`,
+		},
+		{
+			name:          "insert without new_name",
+			transform:     Transform{Include: "m", Action: Insert},
+			expectedError: `invalid transform for "m": new_name must be specified for the insert action`,
+		},
+		{
+			name:          "invalid operation",
+			transform:     Transform{Include: "m", Action: Update, Operations: []Operation{{Action: "toggle"}}},
+			expectedError: `invalid transform for "m": invalid operation action "toggle"`,
+		},
+		{
+			name:          "update_label without changes",
+			transform:     Transform{Include: "m", Action: Update, Operations: []Operation{{Action: UpdateLabel, Label: "l"}}},
+			expectedError: `invalid transform for "m": new_label or value_actions must be specified for the update_label operation`,
+		},
+		{
+			name:          "add_label without value",
+			transform:     Transform{Include: "m", Action: Update, Operations: []Operation{{Action: AddLabel, NewLabel: "l"}}},
+			expectedError: `invalid transform for "m": new_label and new_value must be specified for the add_label operation`,
+		},
+		{
+			name:          "delete_label without label",
+			transform:     Transform{Include: "m", Action: Update, Operations: []Operation{{Action: DeleteLabel}}},
+			expectedError: `invalid transform for "m": label must be specified for the delete_label operation`,
+		},
+		{
+			name:          "aggregate_label_values without values",
+			transform:     Transform{Include: "m", Action: Update, Operations: []Operation{{Action: AggregateLabelValues, Label: "l", NewValue: "v"}}},
+			expectedError: `invalid transform for "m": label, new_value and aggregated_values must be specified for the aggregate_label_values operation`,
+		},
+		{
+			name:          "scale_value without scale",
+			transform:     Transform{Include: "m", Action: Update, Operations: []Operation{{Action: ScaleValue}}},
+			expectedError: `invalid transform for "m": a non-zero scale must be specified for the scale_value operation`,
+		},
+		{
+			name:          "invalid aggregation_type",
+			transform:     Transform{Include: "m", Action: Update, Operations: []Operation{{Action: DeleteLabel, Label: "l", AggregationType: "median"}}},
+			expectedError: `invalid transform for "m": invalid aggregation_type "median", must be one of "sum", "mean", "max" or "min"`,
+		},
+	}
+
+	factory := NewFactory()
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Transforms = []Transform{test.transform}
+
+			mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, consumertest.NewMetricsNop())
+			assert.EqualError(t, err, `error creating "metricstransform" processor: `+test.expectedError)
+			assert.Nil(t, mp)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var errMissingInclude = errors.New("missing required field \"include\"")
+
+// internalTransform is a transform whose name matcher is compiled.
+type internalTransform struct {
+	Transform
+	regexp *regexp.Regexp
+}
+
+type metricsTransformProcessor struct {
+	transforms []internalTransform
+}
+
+func newMetricsTransformProcessor(cfg *Config) (*metricsTransformProcessor, error) {
+	transforms := make([]internalTransform, 0, len(cfg.Transforms))
+	for _, t := range cfg.Transforms {
+		it, err := newInternalTransform(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform for %q: %w", t.Include, err)
+		}
+		transforms = append(transforms, it)
+	}
+	return &metricsTransformProcessor{transforms: transforms}, nil
+}
+
+func newInternalTransform(t Transform) (internalTransform, error) {
+	it := internalTransform{Transform: t}
+	if t.Include == "" {
+		return it, errMissingInclude
+	}
+
+	switch t.MatchType {
+	case "", StrictMatchType:
+	case RegexpMatchType:
+		re, err := regexp.Compile(t.Include)
+		if err != nil {
+			return it, err
+		}
+		it.regexp = re
+	default:
+		return it, fmt.Errorf("invalid match_type %q, must be %q or %q", t.MatchType, StrictMatchType, RegexpMatchType)
+	}
+
+	switch t.Action {
+	case Update:
+	case Insert:
+		if t.NewName == "" {
+			return it, errors.New("new_name must be specified for the insert action")
+		}
+	default:
+		return it, fmt.Errorf("invalid action %q, must be %q or %q", t.Action, Update, Insert)
+	}
+
+	for i := range t.Operations {
+		if err := validateOperation(&t.Operations[i]); err != nil {
+			return it, err
+		}
+	}
+	return it, nil
+}
+
+func validateOperation(op *Operation) error {
+	switch op.Action {
+	case UpdateLabel:
+		if op.Label == "" {
+			return fmt.Errorf("label must be specified for the %s operation", op.Action)
+		}
+		if op.NewLabel == "" && len(op.ValueActions) == 0 {
+			return fmt.Errorf("new_label or value_actions must be specified for the %s operation", op.Action)
+		}
+	case AddLabel:
+		if op.NewLabel == "" || op.NewValue == "" {
+			return fmt.Errorf("new_label and new_value must be specified for the %s operation", op.Action)
+		}
+	case DeleteLabel:
+		if op.Label == "" {
+			return fmt.Errorf("label must be specified for the %s operation", op.Action)
+		}
+	case AggregateLabelValues:
+		if op.Label == "" || op.NewValue == "" || len(op.AggregatedValues) == 0 {
+			return fmt.Errorf("label, new_value and aggregated_values must be specified for the %s operation", op.Action)
+		}
+	case ScaleValue:
+		if op.Scale == 0 {
+			return fmt.Errorf("a non-zero scale must be specified for the %s operation", op.Action)
+		}
+	default:
+		return fmt.Errorf("invalid operation action %q", op.Action)
+	}
+
+	switch op.AggregationType {
+	case "", Sum, Mean, Max, Min:
+	default:
+		return fmt.Errorf("invalid aggregation_type %q, must be one of %q, %q, %q or %q", op.AggregationType, Sum, Mean, Max, Min)
+	}
+	return nil
+}
+
+// newName returns the new name of the metric, or false when the metric does not match.
+func (t *internalTransform) newName(name string) (string, bool) {
+	if t.regexp == nil {
+		if name != t.Include {
+			return "", false
+		}
+		if t.NewName == "" {
+			return name, true
+		}
+		return t.NewName, true
+	}
+
+	submatches := t.regexp.FindStringSubmatchIndex(name)
+	if submatches == nil {
+		return "", false
+	}
+	if t.NewName == "" {
+		return name, true
+	}
+	return string(t.regexp.ExpandString(nil, t.NewName, name, submatches)), true
+}
+
+// ProcessMetrics applies the transforms in order to the metrics, the metrics
+// inserted by a transform are seen by the following ones.
+func (p *metricsTransformProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := range p.transforms {
+				p.transforms[k].apply(metrics)
+			}
+		}
+	}
+	return md, nil
+}
+
+func (t *internalTransform) apply(metrics pdata.MetricSlice) {
+	n := metrics.Len()
+	for i := 0; i < n; i++ {
+		metric := metrics.At(i)
+		name, ok := t.newName(metric.Name())
+		if !ok {
+			continue
+		}
+
+		if t.Action == Insert {
+			inserted := pdata.NewMetric()
+			metric.CopyTo(inserted)
+			metrics.Append(inserted)
+			metric = inserted
+		}
+		metric.SetName(name)
+		for j := range t.Operations {
+			applyOperation(metric, &t.Operations[j])
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// testPoint is a data point of a gauge or a sum.
+type testPoint struct {
+	labels    map[string]string
+	value     float64
+	startTime pdata.Timestamp
+	timestamp pdata.Timestamp
+}
+
+// testMetric is a gauge or a sum metric.
+type testMetric struct {
+	name     string
+	dataType pdata.MetricDataType
+	points   []testPoint
+}
+
+func newTestMetrics(metrics ...testMetric) pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	ms := ilms.At(0).Metrics()
+	ms.Resize(len(metrics))
+	for i, tm := range metrics {
+		m := ms.At(i)
+		m.SetName(tm.name)
+		m.SetDataType(tm.dataType)
+		switch tm.dataType {
+		case pdata.MetricDataTypeIntGauge, pdata.MetricDataTypeIntSum:
+			dps := pdata.NewIntDataPointSlice()
+			if tm.dataType == pdata.MetricDataTypeIntGauge {
+				dps = m.IntGauge().DataPoints()
+			} else {
+				dps = m.IntSum().DataPoints()
+			}
+			dps.Resize(len(tm.points))
+			for j, tp := range tm.points {
+				dps.At(j).LabelsMap().InitFromMap(tp.labels)
+				dps.At(j).SetValue(int64(tp.value))
+				dps.At(j).SetStartTime(tp.startTime)
+				dps.At(j).SetTimestamp(tp.timestamp)
+			}
+		case pdata.MetricDataTypeDoubleGauge, pdata.MetricDataTypeDoubleSum:
+			dps := pdata.NewDoubleDataPointSlice()
+			if tm.dataType == pdata.MetricDataTypeDoubleGauge {
+				dps = m.DoubleGauge().DataPoints()
+			} else {
+				dps = m.DoubleSum().DataPoints()
+			}
+			dps.Resize(len(tm.points))
+			for j, tp := range tm.points {
+				dps.At(j).LabelsMap().InitFromMap(tp.labels)
+				dps.At(j).SetValue(tp.value)
+				dps.At(j).SetStartTime(tp.startTime)
+				dps.At(j).SetTimestamp(tp.timestamp)
+			}
+		}
+	}
+	return md
+}
+
+func labelsToMap(labels pdata.StringMap) map[string]string {
+	m := make(map[string]string, labels.Len())
+	labels.ForEach(func(k string, v string) {
+		m[k] = v
+	})
+	return m
+}
+
+// toTestMetrics converts back the gauges and sums of the first instrumentation library.
+func toTestMetrics(md pdata.Metrics) []testMetric {
+	ms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	var metrics []testMetric
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		tm := testMetric{name: m.Name(), dataType: m.DataType()}
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge, pdata.MetricDataTypeIntSum:
+			dps := pdata.NewIntDataPointSlice()
+			if m.DataType() == pdata.MetricDataTypeIntGauge {
+				dps = m.IntGauge().DataPoints()
+			} else {
+				dps = m.IntSum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dp := dps.At(j)
+				tm.points = append(tm.points, testPoint{labelsToMap(dp.LabelsMap()), float64(dp.Value()), dp.StartTime(), dp.Timestamp()})
+			}
+		case pdata.MetricDataTypeDoubleGauge, pdata.MetricDataTypeDoubleSum:
+			dps := pdata.NewDoubleDataPointSlice()
+			if m.DataType() == pdata.MetricDataTypeDoubleGauge {
+				dps = m.DoubleGauge().DataPoints()
+			} else {
+				dps = m.DoubleSum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dp := dps.At(j)
+				tm.points = append(tm.points, testPoint{labelsToMap(dp.LabelsMap()), dp.Value(), dp.StartTime(), dp.Timestamp()})
+			}
+		}
+		metrics = append(metrics, tm)
+	}
+	return metrics
+}
+
+func TestMetricsTransformProcessor(t *testing.T) {
+	cpuPoints := []testPoint{
+		{labels: map[string]string{"cpu": "0", "state": "idle"}, value: 10, startTime: 2, timestamp: 10},
+		{labels: map[string]string{"cpu": "1", "state": "idle"}, value: 30, startTime: 1, timestamp: 10},
+		{labels: map[string]string{"cpu": "0", "state": "user"}, value: 5, startTime: 1, timestamp: 10},
+		{labels: map[string]string{"cpu": "0", "state": "user"}, value: 7, startTime: 1, timestamp: 20},
+	}
+
+	testCases := []struct {
+		name       string
+		transforms []Transform
+		in         []testMetric
+		expected   []testMetric
+	}{
+		{
+			name:       "rename metric",
+			transforms: []Transform{{Include: "system.cpu.time", Action: Update, NewName: "system.cpu.seconds"}},
+			in:         []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeIntSum}, {name: "other", dataType: pdata.MetricDataTypeIntSum}},
+			expected:   []testMetric{{name: "system.cpu.seconds", dataType: pdata.MetricDataTypeIntSum}, {name: "other", dataType: pdata.MetricDataTypeIntSum}},
+		},
+		{
+			name:       "rename metric with regexp",
+			transforms: []Transform{{Include: `^system\.(.*)\.time$`, MatchType: RegexpMatchType, Action: Update, NewName: "host.$1.seconds"}},
+			in:         []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeIntSum}, {name: "system.cpu.usage", dataType: pdata.MetricDataTypeIntSum}},
+			expected:   []testMetric{{name: "host.cpu.seconds", dataType: pdata.MetricDataTypeIntSum}, {name: "system.cpu.usage", dataType: pdata.MetricDataTypeIntSum}},
+		},
+		{
+			name: "insert metric",
+			transforms: []Transform{{
+				Include:    "system.cpu.time",
+				Action:     Insert,
+				NewName:    "system.cpu.time.ms",
+				Operations: []Operation{{Action: ScaleValue, Scale: 1000}},
+			}},
+			in: []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeDoubleSum, points: []testPoint{{value: 1.5}}}},
+			expected: []testMetric{
+				{name: "system.cpu.time", dataType: pdata.MetricDataTypeDoubleSum, points: []testPoint{{labels: map[string]string{}, value: 1.5}}},
+				{name: "system.cpu.time.ms", dataType: pdata.MetricDataTypeDoubleSum, points: []testPoint{{labels: map[string]string{}, value: 1500}}},
+			},
+		},
+		{
+			name: "update label",
+			transforms: []Transform{{
+				Include: "system.cpu.time",
+				Action:  Update,
+				Operations: []Operation{{
+					Action:       UpdateLabel,
+					Label:        "state",
+					NewLabel:     "cpu_state",
+					ValueActions: []ValueAction{{Value: "idle", NewValue: "free"}},
+				}},
+			}},
+			in: []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeIntSum, points: cpuPoints[:3]}},
+			expected: []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeIntSum, points: []testPoint{
+				{labels: map[string]string{"cpu": "0", "cpu_state": "free"}, value: 10, startTime: 2, timestamp: 10},
+				{labels: map[string]string{"cpu": "1", "cpu_state": "free"}, value: 30, startTime: 1, timestamp: 10},
+				{labels: map[string]string{"cpu": "0", "cpu_state": "user"}, value: 5, startTime: 1, timestamp: 10},
+			}}},
+		},
+		{
+			name: "add label",
+			transforms: []Transform{{
+				Include:    "system.cpu.time",
+				Action:     Update,
+				Operations: []Operation{{Action: AddLabel, NewLabel: "host", NewValue: "web-1"}},
+			}},
+			in: []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeIntGauge, points: cpuPoints[:1]}},
+			expected: []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeIntGauge, points: []testPoint{
+				{labels: map[string]string{"cpu": "0", "state": "idle", "host": "web-1"}, value: 10, startTime: 2, timestamp: 10},
+			}}},
+		},
+		{
+			name: "delete label summing the data points",
+			transforms: []Transform{{
+				Include:    "system.cpu.time",
+				Action:     Update,
+				Operations: []Operation{{Action: DeleteLabel, Label: "cpu"}},
+			}},
+			in: []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeIntSum, points: cpuPoints}},
+			expected: []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeIntSum, points: []testPoint{
+				{labels: map[string]string{"state": "idle"}, value: 40, startTime: 1, timestamp: 10},
+				{labels: map[string]string{"state": "user"}, value: 5, startTime: 1, timestamp: 10},
+				{labels: map[string]string{"state": "user"}, value: 7, startTime: 1, timestamp: 20},
+			}}},
+		},
+		{
+			name: "delete label averaging the data points",
+			transforms: []Transform{{
+				Include:    "system.cpu.utilization",
+				Action:     Update,
+				Operations: []Operation{{Action: DeleteLabel, Label: "cpu", AggregationType: Mean}},
+			}},
+			in: []testMetric{{name: "system.cpu.utilization", dataType: pdata.MetricDataTypeDoubleGauge, points: cpuPoints[:2]}},
+			expected: []testMetric{{name: "system.cpu.utilization", dataType: pdata.MetricDataTypeDoubleGauge, points: []testPoint{
+				{labels: map[string]string{"state": "idle"}, value: 20, startTime: 1, timestamp: 10},
+			}}},
+		},
+		{
+			name: "aggregate label values",
+			transforms: []Transform{{
+				Include: "system.cpu.time",
+				Action:  Update,
+				Operations: []Operation{{
+					Action:           AggregateLabelValues,
+					Label:            "cpu",
+					AggregatedValues: []string{"0", "1"},
+					NewValue:         "all",
+					AggregationType:  Max,
+				}},
+			}},
+			in: []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeDoubleSum, points: cpuPoints[:2]}},
+			expected: []testMetric{{name: "system.cpu.time", dataType: pdata.MetricDataTypeDoubleSum, points: []testPoint{
+				{labels: map[string]string{"cpu": "all", "state": "idle"}, value: 30, startTime: 1, timestamp: 10},
+			}}},
+		},
+		{
+			name: "scale int values",
+			transforms: []Transform{{
+				Include:    "system.disk.io",
+				Action:     Update,
+				Operations: []Operation{{Action: ScaleValue, Scale: 0.25}},
+			}},
+			in: []testMetric{{name: "system.disk.io", dataType: pdata.MetricDataTypeIntSum, points: []testPoint{{value: 10}}}},
+			expected: []testMetric{{name: "system.disk.io", dataType: pdata.MetricDataTypeIntSum, points: []testPoint{
+				{labels: map[string]string{}, value: 3},
+			}}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := newMetricsTransformProcessor(&Config{Transforms: test.transforms})
+			require.NoError(t, err)
+
+			md, err := p.ProcessMetrics(context.Background(), newTestMetrics(test.in...))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, toTestMetrics(md))
+		})
+	}
+}
+
+func TestMetricsTransformProcessor_Histograms(t *testing.T) {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	ms := ilms.At(0).Metrics()
+	ms.Resize(2)
+
+	histogram := ms.At(0)
+	histogram.SetName("http.server.duration")
+	histogram.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	dps := histogram.DoubleHistogram().DataPoints()
+	dps.Resize(3)
+	for i, route := range []string{"/a", "/b", "/c"} {
+		dp := dps.At(i)
+		dp.LabelsMap().InitFromMap(map[string]string{"http.route": route})
+		dp.SetCount(3)
+		dp.SetSum(1.5)
+		dp.SetBucketCounts([]uint64{1, 2})
+		bounds := []float64{0.5}
+		if route == "/c" {
+			bounds = []float64{1}
+		}
+		dp.SetExplicitBounds(bounds)
+	}
+
+	summary := ms.At(1)
+	summary.SetName("http.server.latency")
+	summary.SetDataType(pdata.MetricDataTypeDoubleSummary)
+	summary.DoubleSummary().DataPoints().Resize(1)
+	sdp := summary.DoubleSummary().DataPoints().At(0)
+	sdp.SetSum(2)
+	sdp.QuantileValues().Resize(1)
+	sdp.QuantileValues().At(0).SetQuantile(0.99)
+	sdp.QuantileValues().At(0).SetValue(0.5)
+
+	p, err := newMetricsTransformProcessor(&Config{Transforms: []Transform{
+		{
+			Include:   `^http\.server\.(.*)$`,
+			MatchType: RegexpMatchType,
+			Action:    Update,
+			Operations: []Operation{
+				{Action: DeleteLabel, Label: "http.route"},
+				{Action: ScaleValue, Scale: 1000},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	_, err = p.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	// The histograms with the same buckets are merged.
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, uint64(6), dps.At(0).Count())
+	assert.Equal(t, 3000.0, dps.At(0).Sum())
+	assert.Equal(t, []uint64{2, 4}, dps.At(0).BucketCounts())
+	assert.Equal(t, []float64{500}, dps.At(0).ExplicitBounds())
+	assert.Equal(t, uint64(3), dps.At(1).Count())
+	assert.Equal(t, []float64{1000}, dps.At(1).ExplicitBounds())
+
+	assert.Equal(t, 2000.0, sdp.Sum())
+	assert.Equal(t, 0.99, sdp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 500.0, sdp.QuantileValues().At(0).Value())
+}
@@ -0,0 +1,316 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/datapoint"
+	"go.opentelemetry.io/collector/internal/processor/series"
+)
+
+func applyOperation(metric pdata.Metric, op *Operation) {
+	switch op.Action {
+	case UpdateLabel:
+		datapoint.ForEachLabels(metric, func(labels pdata.StringMap) {
+			updateLabel(labels, op)
+		})
+	case AddLabel:
+		datapoint.ForEachLabels(metric, func(labels pdata.StringMap) {
+			labels.Upsert(op.NewLabel, op.NewValue)
+		})
+	case DeleteLabel:
+		datapoint.ForEachLabels(metric, func(labels pdata.StringMap) {
+			labels.Delete(op.Label)
+		})
+		aggregateDataPoints(metric, op.AggregationType)
+	case AggregateLabelValues:
+		aggregated := make(map[string]struct{}, len(op.AggregatedValues))
+		for _, v := range op.AggregatedValues {
+			aggregated[v] = struct{}{}
+		}
+		datapoint.ForEachLabels(metric, func(labels pdata.StringMap) {
+			if v, ok := labels.Get(op.Label); ok {
+				if _, ok := aggregated[v]; ok {
+					labels.Update(op.Label, op.NewValue)
+				}
+			}
+		})
+		aggregateDataPoints(metric, op.AggregationType)
+	case ScaleValue:
+		scaleDataPoints(metric, op.Scale)
+	}
+}
+
+// updateLabel renames the value of the label according to the value actions,
+// then renames the label itself.
+func updateLabel(labels pdata.StringMap, op *Operation) {
+	v, ok := labels.Get(op.Label)
+	if !ok {
+		return
+	}
+	for _, va := range op.ValueActions {
+		if va.Value == v {
+			v = va.NewValue
+			break
+		}
+	}
+
+	if op.NewLabel == "" {
+		labels.Update(op.Label, v)
+		return
+	}
+	labels.Delete(op.Label)
+	labels.Upsert(op.NewLabel, v)
+}
+
+// dataPointKey identifies the data points aggregated together: the data points
+// with the same labels and timestamp.
+func dataPointKey(labels pdata.StringMap, timestamp pdata.Timestamp) string {
+	pairs := make([]string, 0, labels.Len()+1)
+	labels.ForEach(func(k string, v string) {
+		pairs = append(pairs, k+"\x01"+v)
+	})
+	sort.Strings(pairs)
+	pairs = append(pairs, strconv.FormatUint(uint64(timestamp), 10))
+	return strings.Join(pairs, "\x00")
+}
+
+// aggregateDataPoints merges the data points which have the same labels and
+// timestamp. The values of gauges and sums are combined according to the
+// aggregation type, the histograms with the same buckets are always summed and
+// the summaries are left unchanged.
+func aggregateDataPoints(metric pdata.Metric, aggType AggregationType) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		aggregateIntDataPoints(metric.IntGauge().DataPoints(), aggType)
+	case pdata.MetricDataTypeDoubleGauge:
+		aggregateDoubleDataPoints(metric.DoubleGauge().DataPoints(), aggType)
+	case pdata.MetricDataTypeIntSum:
+		aggregateIntDataPoints(metric.IntSum().DataPoints(), aggType)
+	case pdata.MetricDataTypeDoubleSum:
+		aggregateDoubleDataPoints(metric.DoubleSum().DataPoints(), aggType)
+	case pdata.MetricDataTypeIntHistogram:
+		aggregateIntHistogramDataPoints(metric.IntHistogram().DataPoints())
+	case pdata.MetricDataTypeDoubleHistogram:
+		aggregateDoubleHistogramDataPoints(metric.DoubleHistogram().DataPoints())
+	}
+}
+
+func aggregateIntDataPoints(dps pdata.IntDataPointSlice, aggType AggregationType) {
+	index := make(map[string]int, dps.Len())
+	var counts []int64
+	aggregated := pdata.NewIntDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := dataPointKey(dp.LabelsMap(), dp.Timestamp())
+		j, ok := index[key]
+		if !ok {
+			index[key] = aggregated.Len()
+			aggregated.Append(dp)
+			counts = append(counts, 1)
+			continue
+		}
+
+		agg := aggregated.At(j)
+		counts[j]++
+		switch aggType {
+		case Max:
+			if dp.Value() > agg.Value() {
+				agg.SetValue(dp.Value())
+			}
+		case Min:
+			if dp.Value() < agg.Value() {
+				agg.SetValue(dp.Value())
+			}
+		default:
+			agg.SetValue(agg.Value() + dp.Value())
+		}
+		if dp.StartTime() < agg.StartTime() {
+			agg.SetStartTime(dp.StartTime())
+		}
+	}
+
+	if aggType == Mean {
+		for j := 0; j < aggregated.Len(); j++ {
+			agg := aggregated.At(j)
+			agg.SetValue(agg.Value() / counts[j])
+		}
+	}
+	dps.Resize(0)
+	aggregated.MoveAndAppendTo(dps)
+}
+
+func aggregateDoubleDataPoints(dps pdata.DoubleDataPointSlice, aggType AggregationType) {
+	index := make(map[string]int, dps.Len())
+	var counts []float64
+	aggregated := pdata.NewDoubleDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := dataPointKey(dp.LabelsMap(), dp.Timestamp())
+		j, ok := index[key]
+		if !ok {
+			index[key] = aggregated.Len()
+			aggregated.Append(dp)
+			counts = append(counts, 1)
+			continue
+		}
+
+		agg := aggregated.At(j)
+		counts[j]++
+		switch aggType {
+		case Max:
+			agg.SetValue(math.Max(agg.Value(), dp.Value()))
+		case Min:
+			agg.SetValue(math.Min(agg.Value(), dp.Value()))
+		default:
+			agg.SetValue(agg.Value() + dp.Value())
+		}
+		if dp.StartTime() < agg.StartTime() {
+			agg.SetStartTime(dp.StartTime())
+		}
+	}
+
+	if aggType == Mean {
+		for j := 0; j < aggregated.Len(); j++ {
+			agg := aggregated.At(j)
+			agg.SetValue(agg.Value() / counts[j])
+		}
+	}
+	dps.Resize(0)
+	aggregated.MoveAndAppendTo(dps)
+}
+
+func aggregateIntHistogramDataPoints(dps pdata.IntHistogramDataPointSlice) {
+	index := make(map[string]int, dps.Len())
+	aggregated := pdata.NewIntHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := dataPointKey(dp.LabelsMap(), dp.Timestamp()) + fmt.Sprint(dp.ExplicitBounds())
+		j, ok := index[key]
+		if !ok {
+			index[key] = aggregated.Len()
+			aggregated.Append(dp)
+			continue
+		}
+
+		agg := aggregated.At(j)
+		agg.SetCount(agg.Count() + dp.Count())
+		agg.SetSum(agg.Sum() + dp.Sum())
+		agg.SetBucketCounts(series.AddBucketCounts(agg.BucketCounts(), dp.BucketCounts()))
+		if dp.StartTime() < agg.StartTime() {
+			agg.SetStartTime(dp.StartTime())
+		}
+	}
+	dps.Resize(0)
+	aggregated.MoveAndAppendTo(dps)
+}
+
+func aggregateDoubleHistogramDataPoints(dps pdata.DoubleHistogramDataPointSlice) {
+	index := make(map[string]int, dps.Len())
+	aggregated := pdata.NewDoubleHistogramDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := dataPointKey(dp.LabelsMap(), dp.Timestamp()) + fmt.Sprint(dp.ExplicitBounds())
+		j, ok := index[key]
+		if !ok {
+			index[key] = aggregated.Len()
+			aggregated.Append(dp)
+			continue
+		}
+
+		agg := aggregated.At(j)
+		agg.SetCount(agg.Count() + dp.Count())
+		agg.SetSum(agg.Sum() + dp.Sum())
+		agg.SetBucketCounts(series.AddBucketCounts(agg.BucketCounts(), dp.BucketCounts()))
+		if dp.StartTime() < agg.StartTime() {
+			agg.SetStartTime(dp.StartTime())
+		}
+	}
+	dps.Resize(0)
+	aggregated.MoveAndAppendTo(dps)
+}
+
+// scaleDataPoints multiplies the values of the data points by the scale, the
+// values of the int data points are rounded to the nearest integer.
+func scaleDataPoints(metric pdata.Metric, scale float64) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		scaleIntDataPoints(metric.IntGauge().DataPoints(), scale)
+	case pdata.MetricDataTypeDoubleGauge:
+		scaleDoubleDataPoints(metric.DoubleGauge().DataPoints(), scale)
+	case pdata.MetricDataTypeIntSum:
+		scaleIntDataPoints(metric.IntSum().DataPoints(), scale)
+	case pdata.MetricDataTypeDoubleSum:
+		scaleDoubleDataPoints(metric.DoubleSum().DataPoints(), scale)
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetSum(scaleInt(dp.Sum(), scale))
+			dp.SetExplicitBounds(scaleBounds(dp.ExplicitBounds(), scale))
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		dps := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetSum(dp.Sum() * scale)
+			dp.SetExplicitBounds(scaleBounds(dp.ExplicitBounds(), scale))
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		dps := metric.DoubleSummary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetSum(dp.Sum() * scale)
+			quantiles := dp.QuantileValues()
+			for j := 0; j < quantiles.Len(); j++ {
+				quantiles.At(j).SetValue(quantiles.At(j).Value() * scale)
+			}
+		}
+	}
+}
+
+func scaleIntDataPoints(dps pdata.IntDataPointSlice, scale float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetValue(scaleInt(dp.Value(), scale))
+	}
+}
+
+func scaleDoubleDataPoints(dps pdata.DoubleDataPointSlice, scale float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetValue(dp.Value() * scale)
+	}
+}
+
+func scaleInt(v int64, scale float64) int64 {
+	return int64(math.Round(float64(v) * scale))
+}
+
+// scaleBounds returns a new slice with the scaled bounds, the bounds may be
+// shared with other data points.
+func scaleBounds(bounds []float64, scale float64) []float64 {
+	scaled := make([]float64, len(bounds))
+	for i, b := range bounds {
+		scaled[i] = b * scale
+	}
+	return scaled
+}
@@ -16,7 +16,7 @@ package probabilisticsamplerprocessor
 
 import "go.opentelemetry.io/collector/config/configmodels"
 
-// Config has the configuration guiding the trace sampler processor.
+// Config has the configuration guiding the trace and log sampler processors.
 type Config struct {
 	configmodels.ProcessorSettings `mapstructure:",squash"`
 	// SamplingPercentage is the percentage rate at which traces are going to be sampled. Defaults to zero, i.e.: no sample.
@@ -26,4 +26,7 @@ type Config struct {
 	// have different sampling rates: if they use the same seed all passing one layer may pass the other even if they have
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
+	// FromAttribute is the log record attribute hashed to sample the log records without a trace ID. The log records
+	// with neither a trace ID nor this attribute are always sampled. Only used by the logs pipelines.
+	FromAttribute string `mapstructure:"from_attribute"`
 }
@@ -49,6 +49,17 @@ func TestLoadConfig(t *testing.T) {
 			HashSeed:           22,
 		})
 
+	p1 := cfg.Processors["probabilistic_sampler/logs"]
+	assert.Equal(t, p1,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "probabilistic_sampler",
+				NameVal: "probabilistic_sampler/logs",
+			},
+			SamplingPercentage: 15.3,
+			HashSeed:           22,
+			FromAttribute:      "request.id",
+		})
 }
 
 func TestLoadConfigEmpty(t *testing.T) {
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+type logsamplerprocessor struct {
+	scaledSamplingRate uint32
+	hashSeed           uint32
+	fromAttribute      string
+}
+
+// newLogsProcessor returns a processor.LogsProcessor that will perform head sampling of the log records according to
+// the given configuration.
+func newLogsProcessor(nextConsumer consumer.LogsConsumer, cfg *Config) (component.LogsProcessor, error) {
+	lsp := &logsamplerprocessor{
+		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
+		hashSeed:           cfg.HashSeed,
+		fromAttribute:      cfg.FromAttribute,
+	}
+
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		lsp,
+		processorhelper.WithCapabilities(component.ProcessorCapabilities{MutatesConsumedData: true}))
+}
+
+func (lsp *logsamplerprocessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			sampled := pdata.NewLogSlice()
+			for k := 0; k < logs.Len(); k++ {
+				if lr := logs.At(k); lsp.isSampled(lr) {
+					sampled.Append(lr)
+				}
+			}
+			logs.Resize(0)
+			sampled.MoveAndAppendTo(logs)
+		}
+	}
+
+	if ld.LogRecordCount() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// isSampled hashes the trace ID of the log record, so that the logs of the sampled traces are kept when the traces
+// are sampled with the same percentage and hash seed, or the value of the configured attribute when there is no
+// trace ID.
+func (lsp *logsamplerprocessor) isSampled(lr pdata.LogRecord) bool {
+	var key []byte
+	if tid := lr.TraceID(); !tid.IsEmpty() {
+		tidBytes := tid.Bytes()
+		key = tidBytes[:]
+	} else if lsp.fromAttribute != "" {
+		if v, ok := lr.Attributes().Get(lsp.fromAttribute); ok {
+			key = []byte(tracetranslator.AttributeValueToString(v, false))
+		}
+	}
+	if key == nil {
+		return true
+	}
+	return hash(key, lsp.hashSeed)&bitMaskHashBuckets < lsp.scaledSamplingRate
+}
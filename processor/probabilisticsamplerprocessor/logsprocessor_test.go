@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+func newTestLogsConfig(samplingPercentage float32, fromAttribute string) *Config {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		SamplingPercentage: samplingPercentage,
+		HashSeed:           22,
+		FromAttribute:      fromAttribute,
+	}
+}
+
+// genRandomTestLogs generates pdata.Logs with numLogs log records, each with a different trace ID when withTraceID
+// is true, and a different "request.id" attribute otherwise.
+func genRandomTestLogs(numLogs int, withTraceID bool) pdata.Logs {
+	r := rand.New(rand.NewSource(1))
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	ld.ResourceLogs().At(0).InstrumentationLibraryLogs().Resize(1)
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(numLogs)
+	for i := 0; i < numLogs; i++ {
+		lr := logs.At(i)
+		if withTraceID {
+			lr.SetTraceID(tracetranslator.UInt64ToTraceID(r.Uint64(), r.Uint64()))
+		} else {
+			lr.Attributes().InsertString("request.id", strconv.FormatUint(r.Uint64(), 16))
+		}
+	}
+	return ld
+}
+
+func TestNewLogsProcessor(t *testing.T) {
+	lp, err := newLogsProcessor(nil, newTestLogsConfig(15.5, ""))
+	assert.Error(t, err)
+	assert.Nil(t, lp)
+
+	lp, err = newLogsProcessor(consumertest.NewLogsNop(), newTestLogsConfig(15.5, ""))
+	require.NoError(t, err)
+	assert.True(t, lp.GetCapabilities().MutatesConsumedData)
+}
+
+func Test_logsamplerprocessor_SamplingPercentageRange(t *testing.T) {
+	tests := []struct {
+		name               string
+		samplingPercentage float32
+		withTraceID        bool
+	}{
+		{
+			name:               "trace_id_5",
+			samplingPercentage: 5,
+			withTraceID:        true,
+		},
+		{
+			name:               "trace_id_50",
+			samplingPercentage: 50,
+			withTraceID:        true,
+		},
+		{
+			name:               "attribute_5",
+			samplingPercentage: 5,
+		},
+		{
+			name:               "attribute_50",
+			samplingPercentage: 50,
+		},
+	}
+	const numLogs = 10000
+	const acceptableDelta = 0.1
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			lp, err := newLogsProcessor(sink, newTestLogsConfig(tt.samplingPercentage, "request.id"))
+			require.NoError(t, err)
+
+			require.NoError(t, lp.ConsumeLogs(context.Background(), genRandomTestLogs(numLogs, tt.withTraceID)))
+			require.Len(t, sink.AllLogs(), 1)
+			actualPercentage := float32(sink.LogRecordsCount()) / numLogs * 100
+			assert.InDelta(t, tt.samplingPercentage, actualPercentage, acceptableDelta*float64(tt.samplingPercentage))
+		})
+	}
+}
+
+func Test_logsamplerprocessor_NoKey(t *testing.T) {
+	// The log records without trace ID are sampled when the attribute is not configured.
+	sink := new(consumertest.LogsSink)
+	lp, err := newLogsProcessor(sink, newTestLogsConfig(0, ""))
+	require.NoError(t, err)
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genRandomTestLogs(10, false)))
+	assert.Equal(t, 10, sink.LogRecordsCount())
+
+	// The log records without the attribute are sampled too.
+	sink.Reset()
+	lp, err = newLogsProcessor(sink, newTestLogsConfig(0, "session.id"))
+	require.NoError(t, err)
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genRandomTestLogs(10, false)))
+	assert.Equal(t, 10, sink.LogRecordsCount())
+}
+
+func Test_logsamplerprocessor_ConsistentWithTraces(t *testing.T) {
+	cfg := newTestLogsConfig(30, "")
+	logsSink := new(consumertest.LogsSink)
+	lp, err := newLogsProcessor(logsSink, cfg)
+	require.NoError(t, err)
+	tracesSink := new(consumertest.TracesSink)
+	tp, err := newTraceProcessor(tracesSink, *cfg)
+	require.NoError(t, err)
+
+	ld := genRandomTestLogs(1000, true)
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	td.ResourceSpans().At(0).InstrumentationLibrarySpans().Resize(1)
+	spans := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	spans.Resize(logs.Len())
+	for i := 0; i < logs.Len(); i++ {
+		spans.At(i).SetTraceID(logs.At(i).TraceID())
+	}
+
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	sampledTraceIDs := make(map[[16]byte]bool)
+	sampledSpans := tracesSink.AllTraces()[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	for i := 0; i < sampledSpans.Len(); i++ {
+		sampledTraceIDs[sampledSpans.At(i).TraceID().Bytes()] = true
+	}
+	sampledLogs := logsSink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, len(sampledTraceIDs), sampledLogs.Len())
+	for i := 0; i < sampledLogs.Len(); i++ {
+		assert.True(t, sampledTraceIDs[sampledLogs.At(i).TraceID().Bytes()])
+	}
+}
+
+func Test_logsamplerprocessor_AllDropped(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lp, err := newLogsProcessor(sink, newTestLogsConfig(0, "request.id"))
+	require.NoError(t, err)
+
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genRandomTestLogs(10, true)))
+	assert.Empty(t, sink.AllLogs())
+}
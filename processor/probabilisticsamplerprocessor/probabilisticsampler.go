@@ -205,7 +205,7 @@ func hash(key []byte, seed uint32) (hash uint32) {
 	}
 
 	// TraceId and SpanId have lengths that are multiple of 4 so the code below is never expected to
-	// be hit when sampling traces. However, it is preserved here to keep it as a correct murmur3 implementation.
+	// be hit when sampling traces, but it is when hashing the attribute values of log records.
 	// This is enforced via tests.
 	var remainingBytes uint32
 	switch len(key) - iByte {
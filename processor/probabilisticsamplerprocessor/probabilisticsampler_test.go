@@ -418,7 +418,7 @@ func getSpanWithAttributes(key string, value pdata.AttributeValue) pdata.Span {
 }
 
 // Test_hash ensures that the hash function supports different key lengths even if in
-// practice it is only expected to receive keys with length 16 (trace id length in OC proto).
+// practice it mostly receives keys with length 16 (trace id length in OC proto).
 func Test_hash(t *testing.T) {
 	// Statistically a random selection of such small number of keys should not result in
 	// collisions, but, of course it is possible that they happen, a different random source
@@ -29,7 +29,7 @@ import (
 	"go.opentelemetry.io/collector/obsreport"
 )
 
-// ErrSkipProcessingData is a sentinel value to indicate when traces or metrics should intentionally be dropped
+// ErrSkipProcessingData is a sentinel value to indicate when traces, metrics or logs should intentionally be dropped
 // from further processing in the pipeline because the data is determined to be irrelevant. A processor can return this error
 // to stop further processing without propagating an error back up the pipeline to logs.
 var ErrSkipProcessingData = errors.New("sentinel error to skip processing data from the remainder of the pipeline")
@@ -106,6 +106,7 @@ func fromOptions(options []Option) *baseSettings {
 type baseProcessor struct {
 	component.Component
 	fullName        string
+	spanName        string
 	capabilities    component.ProcessorCapabilities
 	traceAttributes []trace.Attribute
 }
@@ -116,6 +117,7 @@ func newBaseProcessor(fullName string, options ...Option) baseProcessor {
 	be := baseProcessor{
 		Component:    componenthelper.NewComponent(bs.ComponentSettings),
 		fullName:     fullName,
+		spanName:     obsreport.ProcessorKey + "/" + fullName,
 		capabilities: bs.capabilities,
 		traceAttributes: []trace.Attribute{
 			trace.StringAttribute(obsreport.ProcessorKey, fullName),
@@ -129,6 +131,28 @@ func (bp *baseProcessor) GetCapabilities() component.ProcessorCapabilities {
 	return bp.capabilities
 }
 
+// startSpan starts a span for the processing of the data, as a child of the span in
+// the context. If the span in the context is not sampled no span is started and the
+// span in the context is returned instead, so the processing is only annotated.
+func (bp *baseProcessor) startSpan(ctx context.Context, dataType configmodels.DataType) (context.Context, *trace.Span) {
+	span := trace.FromContext(ctx)
+	if !span.IsRecordingEvents() {
+		return ctx, span
+	}
+	return trace.StartSpan(ctx, bp.spanName+"/"+string(dataType))
+}
+
+// endSpan ends the span returned by startSpan, if it was started for the processing.
+func endSpan(ctx context.Context, span *trace.Span, err error) {
+	if span == trace.FromContext(ctx) {
+		return
+	}
+	if err != nil && err != ErrSkipProcessingData {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+	span.End()
+}
+
 type tracesProcessor struct {
 	baseProcessor
 	processor    TProcessor
@@ -136,12 +160,16 @@ type tracesProcessor struct {
 }
 
 func (tp *tracesProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
-	span := trace.FromContext(ctx)
+	processCtx, span := tp.startSpan(ctx, configmodels.TracesDataType)
 	span.Annotate(tp.traceAttributes, "Start processing.")
 	var err error
-	td, err = tp.processor.ProcessTraces(ctx, td)
+	td, err = tp.processor.ProcessTraces(processCtx, td)
 	span.Annotate(tp.traceAttributes, "End processing.")
+	endSpan(ctx, span, err)
 	if err != nil {
+		if err == ErrSkipProcessingData {
+			return nil
+		}
 		return err
 	}
 	return tp.nextConsumer.ConsumeTraces(ctx, td)
@@ -177,11 +205,12 @@ type metricsProcessor struct {
 }
 
 func (mp *metricsProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
-	span := trace.FromContext(ctx)
+	processCtx, span := mp.startSpan(ctx, configmodels.MetricsDataType)
 	span.Annotate(mp.traceAttributes, "Start processing.")
 	var err error
-	md, err = mp.processor.ProcessMetrics(ctx, md)
+	md, err = mp.processor.ProcessMetrics(processCtx, md)
 	span.Annotate(mp.traceAttributes, "End processing.")
+	endSpan(ctx, span, err)
 	if err != nil {
 		if err == ErrSkipProcessingData {
 			return nil
@@ -221,12 +250,16 @@ type logProcessor struct {
 }
 
 func (lp *logProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
-	span := trace.FromContext(ctx)
+	processCtx, span := lp.startSpan(ctx, configmodels.LogsDataType)
 	span.Annotate(lp.traceAttributes, "Start processing.")
 	var err error
-	ld, err = lp.processor.ProcessLogs(ctx, ld)
+	ld, err = lp.processor.ProcessLogs(processCtx, ld)
 	span.Annotate(lp.traceAttributes, "End processing.")
+	endSpan(ctx, span, err)
 	if err != nil {
+		if err == ErrSkipProcessingData {
+			return nil
+		}
 		return err
 	}
 	return lp.nextConsumer.ConsumeLogs(ctx, ld)
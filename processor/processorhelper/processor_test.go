@@ -17,10 +17,12 @@ package processorhelper
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
@@ -80,6 +82,12 @@ func TestNewTraceExporter_ProcessTraceError(t *testing.T) {
 	assert.Equal(t, want, me.ConsumeTraces(context.Background(), testdata.GenerateTraceDataEmpty()))
 }
 
+func TestNewTraceExporter_ProcessTraceErrSkipProcessingData(t *testing.T) {
+	me, err := NewTraceProcessor(testCfg, consumertest.NewTracesNop(), newTestTProcessor(ErrSkipProcessingData))
+	require.NoError(t, err)
+	assert.Equal(t, nil, me.ConsumeTraces(context.Background(), testdata.GenerateTraceDataEmpty()))
+}
+
 func TestNewMetricsExporter(t *testing.T) {
 	me, err := NewMetricsProcessor(testCfg, consumertest.NewMetricsNop(), newTestMProcessor(nil))
 	require.NoError(t, err)
@@ -134,6 +142,65 @@ func TestNewLogsExporter_ProcessLogError(t *testing.T) {
 	assert.Equal(t, want, me.ConsumeLogs(context.Background(), testdata.GenerateLogDataEmpty()))
 }
 
+func TestNewLogsExporter_ProcessLogsErrSkipProcessingData(t *testing.T) {
+	me, err := NewLogsProcessor(testCfg, consumertest.NewLogsNop(), newTestLProcessor(ErrSkipProcessingData))
+	require.NoError(t, err)
+	assert.Equal(t, nil, me.ConsumeLogs(context.Background(), testdata.GenerateLogDataEmpty()))
+}
+
+func TestProcessorSpans(t *testing.T) {
+	ss := &spanStore{}
+	trace.RegisterExporter(ss)
+	defer trace.UnregisterExporter(ss)
+
+	want := errors.New("my_error")
+	tp, err := NewTraceProcessor(testCfg, consumertest.NewTracesNop(), newTestTProcessor(want))
+	require.NoError(t, err)
+	mp, err := NewMetricsProcessor(testCfg, consumertest.NewMetricsNop(), newTestMProcessor(nil))
+	require.NoError(t, err)
+
+	// No spans are started without a sampled parent span.
+	assert.Equal(t, want, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataEmpty()))
+	_, unsampledSpan := trace.StartSpan(context.Background(), t.Name(), trace.WithSampler(trace.NeverSample()))
+	assert.NoError(t, mp.ConsumeMetrics(trace.NewContext(context.Background(), unsampledSpan), testdata.GenerateMetricsEmpty()))
+	unsampledSpan.End()
+	assert.Len(t, ss.PullAllSpans(), 0)
+
+	parentCtx, parentSpan := trace.StartSpan(context.Background(), t.Name(), trace.WithSampler(trace.AlwaysSample()))
+	assert.Equal(t, want, tp.ConsumeTraces(parentCtx, testdata.GenerateTraceDataEmpty()))
+	assert.NoError(t, mp.ConsumeMetrics(parentCtx, testdata.GenerateMetricsEmpty()))
+	parentSpan.End()
+
+	spans := ss.PullAllSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "processor/"+testFullName+"/traces", spans[0].Name)
+	assert.Equal(t, parentSpan.SpanContext().SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, trace.Status{Code: trace.StatusCodeUnknown, Message: want.Error()}, spans[0].Status)
+	assert.Equal(t, "processor/"+testFullName+"/metrics", spans[1].Name)
+	assert.Equal(t, parentSpan.SpanContext().SpanID, spans[1].ParentSpanID)
+	assert.Equal(t, int32(trace.StatusCodeOK), spans[1].Status.Code)
+	assert.Equal(t, t.Name(), spans[2].Name)
+}
+
+type spanStore struct {
+	sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (ss *spanStore) ExportSpan(sd *trace.SpanData) {
+	ss.Lock()
+	ss.spans = append(ss.spans, sd)
+	ss.Unlock()
+}
+
+func (ss *spanStore) PullAllSpans() []*trace.SpanData {
+	ss.Lock()
+	capturedSpans := ss.spans
+	ss.spans = nil
+	ss.Unlock()
+	return capturedSpans
+}
+
 type testTProcessor struct {
 	retError error
 }
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// ThrottleBehavior is what the processor does with the data exceeding the limit.
+type ThrottleBehavior string
+
+const (
+	// DropBehavior drops the data exceeding the limit.
+	DropBehavior ThrottleBehavior = "drop"
+	// BackpressureBehavior refuses the data exceeding the limit with an error, so
+	// that the receivers ask their clients to retry later.
+	BackpressureBehavior ThrottleBehavior = "backpressure"
+)
+
+// Config defines configuration for Rate Limit processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Traces is the limit of the spans, required by the traces pipelines.
+	Traces *Limit `mapstructure:"traces"`
+
+	// Metrics is the limit of the data points, required by the metrics pipelines.
+	Metrics *Limit `mapstructure:"metrics"`
+
+	// Logs is the limit of the log records, required by the logs pipelines.
+	Logs *Limit `mapstructure:"logs"`
+
+	// ResourceAttribute is the resource attribute whose values have separate limits,
+	// e.g. "service.name". If not set, the limit applies to all the data.
+	ResourceAttribute string `mapstructure:"resource_attribute"`
+
+	// ThrottleBehavior is "drop" to drop the data exceeding the limit, or
+	// "backpressure" to refuse it with an error. If not set, the data is dropped.
+	ThrottleBehavior ThrottleBehavior `mapstructure:"throttle_behavior"`
+}
+
+// Limit is the rate limit of the items of a signal.
+type Limit struct {
+	// Rate is the number of items per second.
+	Rate int `mapstructure:"rate"`
+
+	// Burst is the number of items which can be accepted at once after an idle
+	// period. If not set, it is equal to the rate.
+	Burst int `mapstructure:"burst"`
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "ratelimit"
+)
+
+var processorCapabilities = component.ProcessorCapabilities{MutatesConsumedData: false}
+
+// NewFactory returns a new factory for the Rate Limit processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+// Note: This isn't a valid configuration because the processor has no limits.
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		ThrottleBehavior: DropBehavior,
+	}
+}
+
+// validateConfig checks the throttle behavior and the limit of the signal.
+func validateConfig(cfg *Config, signal string, limit *Limit) error {
+	switch cfg.ThrottleBehavior {
+	case "", DropBehavior, BackpressureBehavior:
+	default:
+		return fmt.Errorf("error creating %q processor: invalid throttle_behavior %q, must be %q or %q",
+			cfg.Name(), cfg.ThrottleBehavior, DropBehavior, BackpressureBehavior)
+	}
+	if limit == nil || limit.Rate <= 0 {
+		return fmt.Errorf("error creating %q processor: a positive %s rate must be specified", cfg.Name(), signal)
+	}
+	if limit.Burst < 0 {
+		return fmt.Errorf("error creating %q processor: the %s burst must not be negative", cfg.Name(), signal)
+	}
+	return nil
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.TracesConsumer) (component.TracesProcessor, error) {
+	oCfg := cfg.(*Config)
+	if err := validateConfig(oCfg, "traces", oCfg.Traces); err != nil {
+		return nil, err
+	}
+	return processorhelper.NewTraceProcessor(
+		cfg,
+		nextConsumer,
+		newRateLimitProcessor(oCfg, oCfg.Traces),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if err := validateConfig(oCfg, "metrics", oCfg.Metrics); err != nil {
+		return nil, err
+	}
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		nextConsumer,
+		newRateLimitProcessor(oCfg, oCfg.Metrics),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.LogsConsumer) (component.LogsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if err := validateConfig(oCfg, "logs", oCfg.Logs); err != nil {
+		return nil, err
+	}
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		newRateLimitProcessor(oCfg, oCfg.Logs),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"time"
+)
+
+// bucket is the token bucket of a limited key.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// limiter is a set of token buckets refilled at the rate up to the burst. A
+// batch is admitted as long as its bucket is not empty, and takes all its
+// items from the bucket which can go negative, so that the batches larger
+// than the burst are admitted while the average rate is still enforced.
+type limiter struct {
+	rate  float64
+	burst float64
+
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newLimiter(limit *Limit) *limiter {
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = limit.Rate
+	}
+	return &limiter{
+		rate:    float64(limit.Rate),
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// refillDuration is the time an empty bucket takes to be full.
+func (l *limiter) refillDuration() time.Duration {
+	return time.Duration(l.burst / l.rate * float64(time.Second))
+}
+
+// available returns whether the bucket of the key is not empty.
+func (l *limiter) available(key string, now time.Time) bool {
+	return l.bucket(key, now).tokens > 0
+}
+
+// take removes the items from the bucket of the key.
+func (l *limiter) take(key string, items int, now time.Time) {
+	l.bucket(key, now).tokens -= float64(items)
+}
+
+// bucket returns the refilled bucket of the key.
+func (l *limiter) bucket(key string, now time.Time) *bucket {
+	l.removeFullBuckets(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+		return b
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+		b.last = now
+	}
+	return b
+}
+
+// removeFullBuckets removes the buckets which were refilled to the burst since
+// they were last used, as they are equivalent to new buckets. The buckets are
+// checked at most once per refill duration.
+func (l *limiter) removeFullBuckets(now time.Time) {
+	refill := l.refillDuration()
+	if now.Sub(l.lastSweep) < refill {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(&Limit{Rate: 10, Burst: 20})
+	now := time.Unix(1000, 0)
+
+	// The burst is available at once, and a batch can take more than the bucket.
+	assert.True(t, l.available("a", now))
+	l.take("a", 15, now)
+	assert.True(t, l.available("a", now))
+	l.take("a", 15, now)
+	assert.False(t, l.available("a", now))
+
+	// The keys have separate buckets.
+	assert.True(t, l.available("b", now))
+
+	// The bucket is refilled at the rate: -10 + 10/s * 1.5s = 5.
+	now = now.Add(time.Second)
+	assert.False(t, l.available("a", now))
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, l.available("a", now))
+	assert.InDelta(t, 5, l.buckets["a"].tokens, 1e-9)
+
+	// The bucket is refilled up to the burst.
+	now = now.Add(time.Minute)
+	assert.True(t, l.available("a", now))
+	assert.InDelta(t, 20, l.buckets["a"].tokens, 1e-9)
+}
+
+func TestLimiter_DefaultBurst(t *testing.T) {
+	l := newLimiter(&Limit{Rate: 10})
+	assert.Equal(t, 10.0, l.burst)
+	assert.Equal(t, time.Second, l.refillDuration())
+}
+
+func TestLimiter_RemoveFullBuckets(t *testing.T) {
+	l := newLimiter(&Limit{Rate: 10})
+	now := time.Unix(1000, 0)
+	l.take("a", 5, now)
+	l.take("b", 5, now)
+
+	now = now.Add(2 * time.Second)
+	l.take("b", 5, now)
+	assert.Len(t, l.buckets, 1)
+	assert.Contains(t, l.buckets, "b")
+}
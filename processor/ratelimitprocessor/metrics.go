@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/processor"
+)
+
+var (
+	tagLimitKey, _ = tag.NewKey("limit_key")
+
+	statThrottledItems = stats.Int64("throttled_items", "Number of items dropped or refused because of the rate limit", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to rate limiting
+func MetricViews() []*view.View {
+	throttledItemsView := &view.View{
+		Name:        statThrottledItems.Name(),
+		Measure:     statThrottledItems,
+		Description: statThrottledItems.Description(),
+		TagKeys:     []tag.Key{processor.TagProcessorNameKey, tagLimitKey},
+		Aggregation: view.Sum(),
+	}
+
+	return obsreport.ProcessorMetricViews(typeStr, []*view.View{throttledItemsView})
+}
@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/datapoint"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// errRateLimited is returned to the callers when the data is refused by the
+// backpressure behavior.
+var errRateLimited = errors.New("data refused due to rate limiting")
+
+type rateLimitProcessor struct {
+	name              string
+	resourceAttribute string
+	backpressure      bool
+	obsrep            *obsreport.ProcessorObsReport
+
+	lock    sync.Mutex
+	limiter *limiter
+	now     func() time.Time
+}
+
+func newRateLimitProcessor(cfg *Config, limit *Limit) *rateLimitProcessor {
+	return &rateLimitProcessor{
+		name:              cfg.Name(),
+		resourceAttribute: cfg.ResourceAttribute,
+		backpressure:      cfg.ThrottleBehavior == BackpressureBehavior,
+		obsrep:            obsreport.NewProcessorObsReport(configtelemetry.GetMetricsLevelFlagValue(), cfg.Name()),
+		limiter:           newLimiter(limit),
+		now:               time.Now,
+	}
+}
+
+// resourceKey returns the value of the resource attribute the limit applies
+// to, the resources without the attribute share the same limit.
+func (p *rateLimitProcessor) resourceKey(resource pdata.Resource) string {
+	if p.resourceAttribute == "" {
+		return ""
+	}
+	v, ok := resource.Attributes().Get(p.resourceAttribute)
+	if !ok {
+		return ""
+	}
+	return tracetranslator.AttributeValueToString(v, false)
+}
+
+// admit returns whether the items of each resource of the batch are admitted.
+// With the backpressure behavior, either all the resources are admitted or
+// errRateLimited is returned.
+func (p *rateLimitProcessor) admit(ctx context.Context, keys []string, counts []int) ([]bool, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	admitted := make([]bool, len(keys))
+	if p.backpressure {
+		for _, key := range keys {
+			if !p.limiter.available(key, now) {
+				p.recordThrottled(ctx, keys, counts, admitted)
+				return admitted, errRateLimited
+			}
+		}
+		for i, key := range keys {
+			p.limiter.take(key, counts[i], now)
+			admitted[i] = true
+		}
+		return admitted, nil
+	}
+
+	for i, key := range keys {
+		if p.limiter.available(key, now) {
+			p.limiter.take(key, counts[i], now)
+			admitted[i] = true
+		}
+	}
+	p.recordThrottled(ctx, keys, counts, admitted)
+	return admitted, nil
+}
+
+func (p *rateLimitProcessor) recordThrottled(ctx context.Context, keys []string, counts []int, admitted []bool) {
+	for i, key := range keys {
+		if admitted[i] || counts[i] == 0 {
+			continue
+		}
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Insert(processor.TagProcessorNameKey, p.name), tag.Insert(tagLimitKey, key)},
+			statThrottledItems.M(int64(counts[i])))
+	}
+}
+
+// ProcessTraces drops or refuses the spans of the resources exceeding their limit.
+func (p *rateLimitProcessor) ProcessTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	keys := make([]string, rss.Len())
+	counts := make([]int, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		keys[i] = p.resourceKey(rs.Resource())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			counts[i] += ilss.At(j).Spans().Len()
+		}
+	}
+
+	admitted, err := p.admit(ctx, keys, counts)
+	if err != nil {
+		p.obsrep.TracesRefused(ctx, td.SpanCount())
+		return td, err
+	}
+
+	kept := pdata.NewTraces()
+	accepted, dropped := 0, 0
+	for i := 0; i < rss.Len(); i++ {
+		if admitted[i] {
+			kept.ResourceSpans().Append(rss.At(i))
+			accepted += counts[i]
+		} else {
+			dropped += counts[i]
+		}
+	}
+	p.obsrep.TracesAccepted(ctx, accepted)
+	if dropped > 0 {
+		p.obsrep.TracesDropped(ctx, dropped)
+	}
+	if kept.ResourceSpans().Len() == 0 {
+		return kept, processorhelper.ErrSkipProcessingData
+	}
+	return kept, nil
+}
+
+// ProcessMetrics drops or refuses the data points of the resources exceeding their limit.
+func (p *rateLimitProcessor) ProcessMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	keys := make([]string, rms.Len())
+	counts := make([]int, rms.Len())
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		keys[i] = p.resourceKey(rm.Resource())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				datapoint.ForEachLabels(metrics.At(k), func(pdata.StringMap) {
+					counts[i]++
+				})
+			}
+		}
+	}
+
+	admitted, err := p.admit(ctx, keys, counts)
+	if err != nil {
+		_, numPoints := md.MetricAndDataPointCount()
+		p.obsrep.MetricsRefused(ctx, numPoints)
+		return md, err
+	}
+
+	kept := pdata.NewMetrics()
+	accepted, dropped := 0, 0
+	for i := 0; i < rms.Len(); i++ {
+		if admitted[i] {
+			kept.ResourceMetrics().Append(rms.At(i))
+			accepted += counts[i]
+		} else {
+			dropped += counts[i]
+		}
+	}
+	p.obsrep.MetricsAccepted(ctx, accepted)
+	if dropped > 0 {
+		p.obsrep.MetricsDropped(ctx, dropped)
+	}
+	if kept.ResourceMetrics().Len() == 0 {
+		return kept, processorhelper.ErrSkipProcessingData
+	}
+	return kept, nil
+}
+
+// ProcessLogs drops or refuses the log records of the resources exceeding their limit.
+func (p *rateLimitProcessor) ProcessLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	keys := make([]string, rls.Len())
+	counts := make([]int, rls.Len())
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		keys[i] = p.resourceKey(rl.Resource())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			counts[i] += ills.At(j).Logs().Len()
+		}
+	}
+
+	admitted, err := p.admit(ctx, keys, counts)
+	if err != nil {
+		p.obsrep.LogsRefused(ctx, ld.LogRecordCount())
+		return ld, err
+	}
+
+	kept := pdata.NewLogs()
+	accepted, dropped := 0, 0
+	for i := 0; i < rls.Len(); i++ {
+		if admitted[i] {
+			kept.ResourceLogs().Append(rls.At(i))
+			accepted += counts[i]
+		} else {
+			dropped += counts[i]
+		}
+	}
+	p.obsrep.LogsAccepted(ctx, accepted)
+	if dropped > 0 {
+		p.obsrep.LogsDropped(ctx, dropped)
+	}
+	if kept.ResourceLogs().Len() == 0 {
+		return kept, processorhelper.ErrSkipProcessingData
+	}
+	return kept, nil
+}
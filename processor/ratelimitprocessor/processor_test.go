@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// newTestProcessor returns a processor whose clock is stopped at the returned time.
+func newTestProcessor(behavior ThrottleBehavior, resourceAttribute string, limit *Limit) *rateLimitProcessor {
+	cfg := &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{TypeVal: typeStr, NameVal: typeStr},
+		ResourceAttribute: resourceAttribute,
+		ThrottleBehavior:  behavior,
+	}
+	p := newRateLimitProcessor(cfg, limit)
+	now := time.Unix(1000, 0)
+	p.now = func() time.Time { return now }
+	return p
+}
+
+// newTestTraces returns traces with a resource of the given service for each count of spans.
+func newTestTraces(services []string, counts []int) pdata.Traces {
+	td := pdata.NewTraces()
+	for i, service := range services {
+		rs := pdata.NewResourceSpans()
+		rs.Resource().Attributes().InsertString("service.name", service)
+		ils := pdata.NewInstrumentationLibrarySpans()
+		ils.Spans().Resize(counts[i])
+		rs.InstrumentationLibrarySpans().Append(ils)
+		td.ResourceSpans().Append(rs)
+	}
+	return td
+}
+
+func TestProcessTraces_Drop(t *testing.T) {
+	p := newTestProcessor(DropBehavior, "service.name", &Limit{Rate: 10})
+
+	// Both services are within their limit.
+	td, err := p.ProcessTraces(context.Background(), newTestTraces([]string{"checkout", "payment"}, []int{15, 5}))
+	require.NoError(t, err)
+	assert.Equal(t, 20, td.SpanCount())
+
+	// The checkout limit is exceeded, its spans are dropped.
+	td, err = p.ProcessTraces(context.Background(), newTestTraces([]string{"checkout", "payment"}, []int{1, 2}))
+	require.NoError(t, err)
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	service, _ := td.ResourceSpans().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "payment", service.StringVal())
+
+	// All the spans are dropped.
+	_, err = p.ProcessTraces(context.Background(), newTestTraces([]string{"checkout"}, []int{1}))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
+
+func TestProcessTraces_Backpressure(t *testing.T) {
+	p := newTestProcessor(BackpressureBehavior, "service.name", &Limit{Rate: 10})
+
+	_, err := p.ProcessTraces(context.Background(), newTestTraces([]string{"checkout"}, []int{15}))
+	require.NoError(t, err)
+
+	// The whole batch is refused when a resource exceeds its limit, the payment
+	// limit is left untouched.
+	td := newTestTraces([]string{"payment", "checkout"}, []int{10, 1})
+	out, err := p.ProcessTraces(context.Background(), td)
+	assert.Equal(t, errRateLimited, err)
+	assert.Equal(t, td, out)
+	assert.InDelta(t, 10, p.limiter.buckets["payment"].tokens, 1e-9)
+}
+
+func TestProcessTraces_SharedLimit(t *testing.T) {
+	p := newTestProcessor(DropBehavior, "", &Limit{Rate: 10})
+
+	td, err := p.ProcessTraces(context.Background(), newTestTraces([]string{"checkout", "payment", "cart"}, []int{8, 5, 1}))
+	require.NoError(t, err)
+	assert.Equal(t, 13, td.SpanCount())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p := newTestProcessor(DropBehavior, "service.name", &Limit{Rate: 2})
+
+	newMetrics := func(service string, points int) pdata.Metrics {
+		md := pdata.NewMetrics()
+		md.ResourceMetrics().Resize(1)
+		rm := md.ResourceMetrics().At(0)
+		rm.Resource().Attributes().InsertString("service.name", service)
+		rm.InstrumentationLibraryMetrics().Resize(1)
+		ms := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+		ms.Resize(1)
+		ms.At(0).SetDataType(pdata.MetricDataTypeDoubleGauge)
+		ms.At(0).DoubleGauge().DataPoints().Resize(points)
+		return md
+	}
+
+	md, err := p.ProcessMetrics(context.Background(), newMetrics("checkout", 3))
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.MetricCount())
+	assert.InDelta(t, -1, p.limiter.buckets["checkout"].tokens, 1e-9)
+
+	_, err = p.ProcessMetrics(context.Background(), newMetrics("checkout", 1))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
+
+func TestProcessLogs(t *testing.T) {
+	p := newTestProcessor(BackpressureBehavior, "", &Limit{Rate: 2})
+
+	newLogs := func(records int) pdata.Logs {
+		ld := pdata.NewLogs()
+		ld.ResourceLogs().Resize(1)
+		ld.ResourceLogs().At(0).InstrumentationLibraryLogs().Resize(1)
+		ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().Resize(records)
+		return ld
+	}
+
+	ld, err := p.ProcessLogs(context.Background(), newLogs(2))
+	require.NoError(t, err)
+	assert.Equal(t, 2, ld.LogRecordCount())
+
+	_, err = p.ProcessLogs(context.Background(), newLogs(1))
+	assert.Equal(t, errRateLimited, err)
+}
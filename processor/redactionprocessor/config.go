@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// DebugSummary adds the redacted and masked keys and their counts to the summary attributes.
+	DebugSummary = "debug"
+	// InfoSummary adds the counts of redacted and masked keys to the summary attributes.
+	InfoSummary = "info"
+	// SilentSummary adds no summary attributes.
+	SilentSummary = "silent"
+)
+
+// Config defines configuration for Redaction processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// AllowAllKeys disables the removal of the attributes whose keys are not
+	// in AllowedKeys, only the values matching BlockedValues are masked.
+	AllowAllKeys bool `mapstructure:"allow_all_keys"`
+
+	// AllowedKeys are the keys of the attributes which are kept, the other
+	// attributes are removed.
+	AllowedKeys []string `mapstructure:"allowed_keys"`
+
+	// BlockedValues are the regular expressions matching the values masked in
+	// the kept attributes, e.g. credit card numbers, emails or tokens.
+	BlockedValues []string `mapstructure:"blocked_values"`
+
+	// Summary controls the attributes recording what was redacted, one of
+	// debug, info and silent.
+	Summary string `mapstructure:"summary"`
+}
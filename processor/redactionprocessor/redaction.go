@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/datapoint"
+)
+
+const (
+	// mask replaces the parts of the values matching the blocked values.
+	mask = "****"
+
+	redactedKeysAttribute  = "redaction.redacted.keys"
+	redactedCountAttribute = "redaction.redacted.count"
+	maskedKeysAttribute    = "redaction.masked.keys"
+	maskedCountAttribute   = "redaction.masked.count"
+)
+
+// redaction removes the attributes whose keys are not allowed and masks the
+// values matching the blocked values, in the attributes of the spans, the log
+// records and the resources, and in the labels of the data points.
+type redaction struct {
+	allowAllKeys  bool
+	allowedKeys   map[string]bool
+	blockedValues []*regexp.Regexp
+	summary       string
+}
+
+func newRedaction(cfg *Config) (*redaction, error) {
+	switch cfg.Summary {
+	case DebugSummary, InfoSummary, SilentSummary:
+	default:
+		return nil, fmt.Errorf("invalid summary %q, must be one of %q, %q or %q", cfg.Summary, DebugSummary, InfoSummary, SilentSummary)
+	}
+
+	allowedKeys := make(map[string]bool, len(cfg.AllowedKeys))
+	for _, key := range cfg.AllowedKeys {
+		allowedKeys[key] = true
+	}
+
+	blockedValues := make([]*regexp.Regexp, 0, len(cfg.BlockedValues))
+	for _, pattern := range cfg.BlockedValues {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blocked value %q: %w", pattern, err)
+		}
+		blockedValues = append(blockedValues, re)
+	}
+
+	return &redaction{
+		allowAllKeys:  cfg.AllowAllKeys,
+		allowedKeys:   allowedKeys,
+		blockedValues: blockedValues,
+		summary:       cfg.Summary,
+	}, nil
+}
+
+// ProcessTraces redacts the attributes of the resources and the spans.
+func (r *redaction) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		r.processAttributes(rs.Resource().Attributes())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				r.processAttributes(spans.At(k).Attributes())
+			}
+		}
+	}
+	return td, nil
+}
+
+// ProcessLogs redacts the attributes of the resources and the log records.
+func (r *redaction) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		r.processAttributes(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				r.processAttributes(logs.At(k).Attributes())
+			}
+		}
+	}
+	return ld, nil
+}
+
+// ProcessMetrics redacts the attributes of the resources and the labels of
+// the data points.
+func (r *redaction) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		r.processAttributes(rm.Resource().Attributes())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				datapoint.ForEachLabels(metrics.At(k), r.processLabels)
+			}
+		}
+	}
+	return md, nil
+}
+
+func (r *redaction) processAttributes(attributes pdata.AttributeMap) {
+	var redactedKeys []string
+	maskedValues := map[string]string{}
+	attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		if !r.isAllowedKey(k) {
+			redactedKeys = append(redactedKeys, k)
+			return
+		}
+		if v.Type() != pdata.AttributeValueSTRING {
+			return
+		}
+		if masked, ok := r.maskValue(v.StringVal()); ok {
+			maskedValues[k] = masked
+		}
+	})
+
+	for _, k := range redactedKeys {
+		attributes.Delete(k)
+	}
+	for k, v := range maskedValues {
+		attributes.UpdateString(k, v)
+	}
+	for k, v := range r.summaryAttributes(redactedKeys, maskedValues) {
+		if count, ok := v.(int); ok {
+			attributes.UpsertInt(k, int64(count))
+		} else {
+			attributes.UpsertString(k, v.(string))
+		}
+	}
+}
+
+func (r *redaction) processLabels(labels pdata.StringMap) {
+	var redactedKeys []string
+	maskedValues := map[string]string{}
+	labels.ForEach(func(k string, v string) {
+		if !r.isAllowedKey(k) {
+			redactedKeys = append(redactedKeys, k)
+			return
+		}
+		if masked, ok := r.maskValue(v); ok {
+			maskedValues[k] = masked
+		}
+	})
+
+	for _, k := range redactedKeys {
+		labels.Delete(k)
+	}
+	for k, v := range maskedValues {
+		labels.Update(k, v)
+	}
+	for k, v := range r.summaryAttributes(redactedKeys, maskedValues) {
+		if count, ok := v.(int); ok {
+			labels.Upsert(k, strconv.Itoa(count))
+		} else {
+			labels.Upsert(k, v.(string))
+		}
+	}
+}
+
+func (r *redaction) isAllowedKey(key string) bool {
+	return r.allowAllKeys || r.allowedKeys[key]
+}
+
+// maskValue masks the parts of the value matching the blocked values, false
+// is returned if none matches.
+func (r *redaction) maskValue(value string) (string, bool) {
+	masked := false
+	for _, re := range r.blockedValues {
+		if re.MatchString(value) {
+			value = re.ReplaceAllString(value, mask)
+			masked = true
+		}
+	}
+	return value, masked
+}
+
+// summaryAttributes returns the attributes recording the redacted and masked
+// keys according to the summary level, the counts are ints and the lists of
+// keys are comma separated strings.
+func (r *redaction) summaryAttributes(redactedKeys []string, maskedValues map[string]string) map[string]interface{} {
+	if r.summary == SilentSummary {
+		return nil
+	}
+
+	summary := map[string]interface{}{}
+	if len(redactedKeys) > 0 {
+		summary[redactedCountAttribute] = len(redactedKeys)
+		if r.summary == DebugSummary {
+			summary[redactedKeysAttribute] = joinSorted(redactedKeys)
+		}
+	}
+	if len(maskedValues) > 0 {
+		summary[maskedCountAttribute] = len(maskedValues)
+		if r.summary == DebugSummary {
+			maskedKeys := make([]string, 0, len(maskedValues))
+			for k := range maskedValues {
+				maskedKeys = append(maskedKeys, k)
+			}
+			summary[maskedKeysAttribute] = joinSorted(maskedKeys)
+		}
+	}
+	return summary
+}
+
+func joinSorted(keys []string) string {
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
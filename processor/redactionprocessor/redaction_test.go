@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newTestRedaction(t *testing.T, summary string) *redaction {
+	r, err := newRedaction(&Config{
+		AllowedKeys: []string{"http.method", "http.url", "user.email", "card"},
+		BlockedValues: []string{
+			`\b4[0-9]{12}(?:[0-9]{3})?\b`,
+			`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`,
+		},
+		Summary: summary,
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func TestProcessTraces(t *testing.T) {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().Attributes().InsertString("host.name", "node-1")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(1)
+	attrs := spans.At(0).Attributes()
+	attrs.InsertString("http.method", "GET")
+	attrs.InsertString("http.url", "/users?email=john.doe@example.com")
+	attrs.InsertString("card", "4111111111111111")
+	attrs.InsertString("password", "secret")
+	attrs.InsertInt("retries", 3)
+
+	td, err := newTestRedaction(t, DebugSummary).ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		redactedCountAttribute: pdata.NewAttributeValueInt(1),
+		redactedKeysAttribute:  pdata.NewAttributeValueString("host.name"),
+	}, attributesToMap(td.ResourceSpans().At(0).Resource().Attributes()))
+
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"http.method":          pdata.NewAttributeValueString("GET"),
+		"http.url":             pdata.NewAttributeValueString("/users?email=****"),
+		"card":                 pdata.NewAttributeValueString("****"),
+		redactedCountAttribute: pdata.NewAttributeValueInt(2),
+		redactedKeysAttribute:  pdata.NewAttributeValueString("password,retries"),
+		maskedCountAttribute:   pdata.NewAttributeValueInt(2),
+		maskedKeysAttribute:    pdata.NewAttributeValueString("card,http.url"),
+	}, attributesToMap(td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()))
+}
+
+func TestProcessLogs(t *testing.T) {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+	logs.At(0).Attributes().InsertString("user.email", "jane@example.org")
+	logs.At(1).Attributes().InsertString("http.method", "POST")
+
+	ld, err := newTestRedaction(t, InfoSummary).ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"user.email":         pdata.NewAttributeValueString("****"),
+		maskedCountAttribute: pdata.NewAttributeValueInt(1),
+	}, attributesToMap(logs.At(0).Attributes()))
+	// nothing redacted, no summary
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"http.method": pdata.NewAttributeValueString("POST"),
+	}, attributesToMap(logs.At(1).Attributes()))
+}
+
+func TestProcessMetrics(t *testing.T) {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(1)
+	metric := metrics.At(0)
+	metric.SetDataType(pdata.MetricDataTypeIntSum)
+	metric.IntSum().DataPoints().Resize(1)
+	labels := metric.IntSum().DataPoints().At(0).LabelsMap()
+	labels.InitFromMap(map[string]string{
+		"http.method": "GET",
+		"user.email":  "john.doe@example.com",
+		"user.id":     "1234",
+	})
+
+	md, err := newTestRedaction(t, InfoSummary).ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"http.method":          "GET",
+		"user.email":           "****",
+		redactedCountAttribute: "1",
+		maskedCountAttribute:   "1",
+	}, labelsToMap(labels))
+}
+
+func TestAllowAllKeys_SilentSummary(t *testing.T) {
+	r, err := newRedaction(&Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"token=[^&]+"},
+		Summary:       SilentSummary,
+	})
+	require.NoError(t, err)
+
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("http.url", "/login?user=john&token=abc123&lang=en")
+	attrs.InsertString("password", "secret")
+	r.processAttributes(attrs)
+
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"http.url": pdata.NewAttributeValueString("/login?user=john&****&lang=en"),
+		"password": pdata.NewAttributeValueString("secret"),
+	}, attributesToMap(attrs))
+}
+
+func attributesToMap(am pdata.AttributeMap) map[string]pdata.AttributeValue {
+	m := map[string]pdata.AttributeValue{}
+	am.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = v
+	})
+	return m
+}
+
+func labelsToMap(sm pdata.StringMap) map[string]string {
+	m := map[string]string{}
+	sm.ForEach(func(k string, v string) {
+		m[k] = v
+	})
+	return m
+}
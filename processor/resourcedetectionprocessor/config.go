@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Resource Detection processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Detectors are the detectors of the resource attributes, run in order: system, ec2, gce,
+	// azure or ecs. The attributes detected by the first detectors take precedence.
+	Detectors []string `mapstructure:"detectors"`
+
+	// Timeout is the timeout of the requests to the metadata services of the cloud providers.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Override replaces the attributes already set in the resources with the detected values
+	// when true, otherwise the attributes already set are kept.
+	Override bool `mapstructure:"override"`
+}
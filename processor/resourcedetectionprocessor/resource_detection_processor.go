@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/resourcedetection"
+)
+
+// detect detects the resource attributes, for mocking.
+var detect = resourcedetection.Detect
+
+// resourceDetectionProcessor adds the resource attributes detected when the
+// processor starts to the resources of all the data.
+type resourceDetectionProcessor struct {
+	logger *zap.Logger
+	config *Config
+
+	attributes pdata.AttributeMap
+}
+
+func newResourceDetectionProcessor(params component.ProcessorCreateParams, cfg *Config) (*resourceDetectionProcessor, error) {
+	if err := resourcedetection.Validate(cfg.Detectors); err != nil {
+		return nil, fmt.Errorf("error creating %q processor: %w", cfg.Name(), err)
+	}
+
+	return &resourceDetectionProcessor{
+		logger:     params.Logger,
+		config:     cfg,
+		attributes: pdata.NewAttributeMap(),
+	}, nil
+}
+
+// start detects the resource attributes, the processor starts with the
+// attributes that could be detected when some detectors fail.
+func (rdp *resourceDetectionProcessor) start(ctx context.Context, _ component.Host) error {
+	attributes, err := detect(ctx, rdp.config.Detectors, rdp.config.Timeout)
+	if err != nil {
+		rdp.logger.Warn("Failed to detect some resource attributes", zap.Error(err))
+	}
+	rdp.attributes = attributes
+	return nil
+}
+
+// ProcessTraces adds the detected attributes to the resources of the spans.
+func (rdp *resourceDetectionProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rdp.addAttributes(rss.At(i).Resource())
+	}
+	return td, nil
+}
+
+// ProcessMetrics adds the detected attributes to the resources of the metrics.
+func (rdp *resourceDetectionProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rdp.addAttributes(rms.At(i).Resource())
+	}
+	return md, nil
+}
+
+// ProcessLogs adds the detected attributes to the resources of the logs.
+func (rdp *resourceDetectionProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rdp.addAttributes(rls.At(i).Resource())
+	}
+	return ld, nil
+}
+
+func (rdp *resourceDetectionProcessor) addAttributes(resource pdata.Resource) {
+	resourceAttributes := resource.Attributes()
+	rdp.attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		if rdp.config.Override {
+			resourceAttributes.Upsert(k, v)
+		} else {
+			resourceAttributes.Insert(k, v)
+		}
+	})
+}
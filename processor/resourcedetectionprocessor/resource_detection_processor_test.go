@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func mockDetect(t *testing.T, err error) func() {
+	previous := detect
+	detect = func(_ context.Context, names []string, timeout time.Duration) (pdata.AttributeMap, error) {
+		assert.Equal(t, []string{"ec2", "system"}, names)
+		assert.Equal(t, 2*time.Second, timeout)
+
+		attributes := pdata.NewAttributeMap()
+		attributes.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+		attributes.InsertString(conventions.AttributeHostName, "host1")
+		return attributes, err
+	}
+	return func() { detect = previous }
+}
+
+func TestProcessor(t *testing.T) {
+	type testCase struct {
+		name               string
+		override           bool
+		detectErr          error
+		expectedAttributes map[string]string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Insert",
+			expectedAttributes: map[string]string{
+				conventions.AttributeCloudProvider: conventions.AttributeCloudProviderAWS,
+				conventions.AttributeHostName:      "existing",
+				conventions.AttributeServiceName:   "checkout",
+			},
+		},
+		{
+			name:     "Override",
+			override: true,
+			expectedAttributes: map[string]string{
+				conventions.AttributeCloudProvider: conventions.AttributeCloudProviderAWS,
+				conventions.AttributeHostName:      "host1",
+				conventions.AttributeServiceName:   "checkout",
+			},
+		},
+		{
+			// the attributes that could be detected are added when some detectors fail
+			name:      "Detect Error",
+			detectErr: errors.New("err1"),
+			expectedAttributes: map[string]string{
+				conventions.AttributeCloudProvider: conventions.AttributeCloudProviderAWS,
+				conventions.AttributeHostName:      "existing",
+				conventions.AttributeServiceName:   "checkout",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			defer mockDetect(t, test.detectErr)()
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Detectors = []string{"ec2", "system"}
+			cfg.Timeout = 2 * time.Second
+			cfg.Override = test.override
+			params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+			tracesSink := new(consumertest.TracesSink)
+			tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, tracesSink)
+			require.NoError(t, err)
+			require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+
+			td := pdata.NewTraces()
+			td.ResourceSpans().Resize(1)
+			initializeResource(td.ResourceSpans().At(0).Resource())
+			require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+			assert.Equal(t, test.expectedAttributes, attributesToMap(tracesSink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes()))
+
+			metricsSink := new(consumertest.MetricsSink)
+			mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, metricsSink)
+			require.NoError(t, err)
+			require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+
+			md := pdata.NewMetrics()
+			md.ResourceMetrics().Resize(1)
+			initializeResource(md.ResourceMetrics().At(0).Resource())
+			require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+			assert.Equal(t, test.expectedAttributes, attributesToMap(metricsSink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes()))
+
+			logsSink := new(consumertest.LogsSink)
+			lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, logsSink)
+			require.NoError(t, err)
+			require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+
+			ld := pdata.NewLogs()
+			ld.ResourceLogs().Resize(1)
+			initializeResource(ld.ResourceLogs().At(0).Resource())
+			require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+			assert.Equal(t, test.expectedAttributes, attributesToMap(logsSink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes()))
+		})
+	}
+}
+
+func initializeResource(resource pdata.Resource) {
+	resource.Attributes().InsertString(conventions.AttributeServiceName, "checkout")
+	resource.Attributes().InsertString(conventions.AttributeHostName, "existing")
+}
+
+func attributesToMap(attributes pdata.AttributeMap) map[string]string {
+	attributesMap := map[string]string{}
+	attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		attributesMap[k] = v.StringVal()
+	})
+	return attributesMap
+}
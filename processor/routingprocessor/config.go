@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// ResourceAttributeSource reads the routing value from the attributes of the resources,
+	// the data of a batch is split per resource.
+	ResourceAttributeSource = "resource"
+	// ContextAttributeSource reads the routing value from the metadata of the inbound gRPC
+	// request, the whole batch is sent to the same route.
+	ContextAttributeSource = "context"
+)
+
+// Config defines configuration for Routing processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// FromAttribute is the resource attribute or the request metadata key holding the
+	// value the data is routed by, e.g. "X-Tenant".
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// AttributeSource is where the routing value is read from, either "resource" or
+	// "context". If not set, the resource attributes are used.
+	AttributeSource string `mapstructure:"attribute_source"`
+
+	// DefaultExporters are the names of the exporters the data is sent to when the routing
+	// value is missing or does not match any route of the table.
+	DefaultExporters []string `mapstructure:"default_exporters"`
+
+	// Table is the list of the routes.
+	Table []RoutingTableItem `mapstructure:"table"`
+}
+
+// RoutingTableItem is a route sending the data with a given routing value to exporters.
+type RoutingTableItem struct {
+	// Value is the routing value matched by the route.
+	Value string `mapstructure:"value"`
+
+	// Exporters are the names of the exporters the matched data is sent to, the exporters
+	// must be used by a pipeline of the same data type.
+	Exporters []string `mapstructure:"exporters"`
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.Equal(t, ResourceAttributeSource, cfg.(*Config).AttributeSource)
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.FromAttribute = "tenant"
+	cfg.Table = []RoutingTableItem{{Value: "acme", Exporters: []string{"exampleexporter"}}}
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	assert.NotNil(t, tp)
+	assert.False(t, tp.GetCapabilities().MutatesConsumedData)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, mp)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, lp)
+}
+
+func TestCreateProcessors_InvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name          string
+		modify        func(cfg *Config)
+		expectedError string
+	}{
+		{
+			name:          "missing from_attribute",
+			modify:        func(cfg *Config) { cfg.FromAttribute = "" },
+			expectedError: `error creating "routing" processor: missing required field "from_attribute"`,
+		},
+		{
+			name:          "invalid attribute_source",
+			modify:        func(cfg *Config) { cfg.AttributeSource = "header" },
+			expectedError: `error creating "routing" processor: invalid attribute_source "header", must be "resource" or "context"`,
+		},
+		{
+			name:          "empty table",
+			modify:        func(cfg *Config) { cfg.Table = nil },
+			expectedError: `error creating "routing" processor: the routing table must have at least one route`,
+		},
+		{
+			name:          "route without exporters",
+			modify:        func(cfg *Config) { cfg.Table = []RoutingTableItem{{Value: "acme"}} },
+			expectedError: `error creating "routing" processor: the route for value "acme" has no exporters`,
+		},
+	}
+
+	factory := NewFactory()
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.FromAttribute = "tenant"
+			cfg.Table = []RoutingTableItem{{Value: "acme", Exporters: []string{"exampleexporter"}}}
+			test.modify(cfg)
+
+			tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+			assert.EqualError(t, err, test.expectedError)
+			assert.Nil(t, tp)
+		})
+	}
+}
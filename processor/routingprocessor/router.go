@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+var (
+	errNoFromAttribute = errors.New("missing required field \"from_attribute\"")
+	errNoTable         = errors.New("the routing table must have at least one route")
+)
+
+// route is a set of exporters the data is sent to.
+type route struct {
+	exporters []component.Exporter
+}
+
+// router sends the data of a pipeline to the exporters of the route matching
+// its routing value, the data is not passed to the next consumer.
+type router struct {
+	config   *Config
+	dataType configmodels.DataType
+
+	// routes are the routes of the table by routing value, resolved on start.
+	routes       map[string]*route
+	defaultRoute *route
+}
+
+func newRouter(cfg *Config, dataType configmodels.DataType) (*router, error) {
+	if cfg.FromAttribute == "" {
+		return nil, errNoFromAttribute
+	}
+	switch cfg.AttributeSource {
+	case "", ResourceAttributeSource, ContextAttributeSource:
+	default:
+		return nil, fmt.Errorf("invalid attribute_source %q, must be %q or %q", cfg.AttributeSource, ResourceAttributeSource, ContextAttributeSource)
+	}
+	if len(cfg.Table) == 0 {
+		return nil, errNoTable
+	}
+	for _, item := range cfg.Table {
+		if len(item.Exporters) == 0 {
+			return nil, fmt.Errorf("the route for value %q has no exporters", item.Value)
+		}
+	}
+
+	return &router{
+		config:   cfg,
+		dataType: dataType,
+	}, nil
+}
+
+// start looks up the exporters of the routes.
+func (r *router) start(_ context.Context, host component.Host) error {
+	available := host.GetExporters()[r.dataType]
+
+	var err error
+	if r.defaultRoute, err = r.newRoute(available, r.config.DefaultExporters); err != nil {
+		return err
+	}
+	r.routes = make(map[string]*route, len(r.config.Table))
+	for _, item := range r.config.Table {
+		if r.routes[item.Value], err = r.newRoute(available, item.Exporters); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *router) newRoute(available map[configmodels.NamedEntity]component.Exporter, names []string) (*route, error) {
+	rt := &route{}
+	for _, name := range names {
+		exporter, err := r.findExporter(available, name)
+		if err != nil {
+			return nil, err
+		}
+		rt.exporters = append(rt.exporters, exporter)
+	}
+	return rt, nil
+}
+
+func (r *router) findExporter(available map[configmodels.NamedEntity]component.Exporter, name string) (component.Exporter, error) {
+	for entity, exporter := range available {
+		if entity.Name() != name {
+			continue
+		}
+
+		var ok bool
+		switch r.dataType {
+		case configmodels.TracesDataType:
+			_, ok = exporter.(component.TracesExporter)
+		case configmodels.MetricsDataType:
+			_, ok = exporter.(component.MetricsExporter)
+		case configmodels.LogsDataType:
+			_, ok = exporter.(component.LogsExporter)
+		}
+		if !ok {
+			return nil, fmt.Errorf("exporter %q is not a %s exporter", name, r.dataType)
+		}
+		return exporter, nil
+	}
+
+	return nil, fmt.Errorf("exporter %q not found, it must be used by a %s pipeline", name, r.dataType)
+}
+
+// routeFor returns the route of the given routing value, or the default route
+// when the value is missing or unknown.
+func (r *router) routeFor(value string, found bool) *route {
+	if found {
+		if rt, ok := r.routes[value]; ok {
+			return rt
+		}
+	}
+	return r.defaultRoute
+}
+
+// contextRoute returns the route of the value of the metadata of the inbound request.
+func (r *router) contextRoute(ctx context.Context) *route {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return r.defaultRoute
+	}
+	values := md.Get(r.config.FromAttribute)
+	if len(values) == 0 {
+		return r.defaultRoute
+	}
+	return r.routeFor(values[0], true)
+}
+
+// resourceRoute returns the route of the value of the resource attribute.
+func (r *router) resourceRoute(resource pdata.Resource) *route {
+	v, ok := resource.Attributes().Get(r.config.FromAttribute)
+	if !ok {
+		return r.defaultRoute
+	}
+	return r.routeFor(tracetranslator.AttributeValueToString(v, false), true)
+}
+
+func (r *router) useContext() bool {
+	return r.config.AttributeSource == ContextAttributeSource
+}
+
+// ProcessTraces sends the spans to the exporters of their routes.
+func (r *router) ProcessTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
+	var groups map[*route]pdata.Traces
+	if r.useContext() {
+		groups = map[*route]pdata.Traces{r.contextRoute(ctx): td}
+	} else {
+		groups = make(map[*route]pdata.Traces)
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rs := rss.At(i)
+			rt := r.resourceRoute(rs.Resource())
+			group, ok := groups[rt]
+			if !ok {
+				group = pdata.NewTraces()
+				groups[rt] = group
+			}
+			group.ResourceSpans().Append(rs)
+		}
+	}
+
+	var errs []error
+	for rt, group := range groups {
+		for _, exporter := range rt.exporters {
+			if err := exporter.(component.TracesExporter).ConsumeTraces(ctx, group); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return td, routingResult(errs)
+}
+
+// ProcessMetrics sends the metrics to the exporters of their routes.
+func (r *router) ProcessMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	var groups map[*route]pdata.Metrics
+	if r.useContext() {
+		groups = map[*route]pdata.Metrics{r.contextRoute(ctx): md}
+	} else {
+		groups = make(map[*route]pdata.Metrics)
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rm := rms.At(i)
+			rt := r.resourceRoute(rm.Resource())
+			group, ok := groups[rt]
+			if !ok {
+				group = pdata.NewMetrics()
+				groups[rt] = group
+			}
+			group.ResourceMetrics().Append(rm)
+		}
+	}
+
+	var errs []error
+	for rt, group := range groups {
+		for _, exporter := range rt.exporters {
+			if err := exporter.(component.MetricsExporter).ConsumeMetrics(ctx, group); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return md, routingResult(errs)
+}
+
+// ProcessLogs sends the log records to the exporters of their routes.
+func (r *router) ProcessLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	var groups map[*route]pdata.Logs
+	if r.useContext() {
+		groups = map[*route]pdata.Logs{r.contextRoute(ctx): ld}
+	} else {
+		groups = make(map[*route]pdata.Logs)
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			rl := rls.At(i)
+			rt := r.resourceRoute(rl.Resource())
+			group, ok := groups[rt]
+			if !ok {
+				group = pdata.NewLogs()
+				groups[rt] = group
+			}
+			group.ResourceLogs().Append(rl)
+		}
+	}
+
+	var errs []error
+	for rt, group := range groups {
+		for _, exporter := range rt.exporters {
+			if err := exporter.(component.LogsExporter).ConsumeLogs(ctx, group); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return ld, routingResult(errs)
+}
+
+// routingResult returns the error of the exporters, or ErrSkipProcessingData
+// so the routed data is not passed to the next consumer.
+func routingResult(errs []error) error {
+	if len(errs) > 0 {
+		return consumererror.CombineErrors(errs)
+	}
+	return processorhelper.ErrSkipProcessingData
+}
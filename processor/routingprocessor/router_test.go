@@ -0,0 +1,330 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+var errSkip = processorhelper.ErrSkipProcessingData
+
+// exporter is an exporter of all the signals passing the data to sinks.
+type exporter struct {
+	component.Component
+	traces  *consumertest.TracesSink
+	metrics *consumertest.MetricsSink
+	logs    *consumertest.LogsSink
+	err     error
+}
+
+var (
+	_ consumer.TracesConsumer  = (*exporter)(nil)
+	_ consumer.MetricsConsumer = (*exporter)(nil)
+	_ consumer.LogsConsumer    = (*exporter)(nil)
+)
+
+func newExporter() *exporter {
+	return &exporter{
+		Component: componenthelper.NewComponent(componenthelper.DefaultComponentSettings()),
+		traces:    new(consumertest.TracesSink),
+		metrics:   new(consumertest.MetricsSink),
+		logs:      new(consumertest.LogsSink),
+	}
+}
+
+func (e *exporter) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.traces.ConsumeTraces(ctx, td)
+}
+
+func (e *exporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return e.metrics.ConsumeMetrics(ctx, md)
+}
+
+func (e *exporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return e.logs.ConsumeLogs(ctx, ld)
+}
+
+// exportersHost is a host providing the given exporters for all the data types.
+type exportersHost struct {
+	component.Host
+	exporters map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter
+}
+
+func newExportersHost(exporters map[string]component.Exporter) component.Host {
+	named := make(map[configmodels.NamedEntity]component.Exporter, len(exporters))
+	for name, exp := range exporters {
+		named[&configmodels.ExporterSettings{TypeVal: "exampleexporter", NameVal: name}] = exp
+	}
+	return &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter{
+			configmodels.TracesDataType:  named,
+			configmodels.MetricsDataType: named,
+			configmodels.LogsDataType:    named,
+		},
+	}
+}
+
+func (h *exportersHost) GetExporters() map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter {
+	return h.exporters
+}
+
+func newTestConfig(source string) *Config {
+	return &Config{
+		FromAttribute:    "X-Tenant",
+		AttributeSource:  source,
+		DefaultExporters: []string{"default"},
+		Table: []RoutingTableItem{
+			{Value: "acme", Exporters: []string{"acme"}},
+			{Value: "globex", Exporters: []string{"globex", "default"}},
+		},
+	}
+}
+
+func TestStart(t *testing.T) {
+	testCases := []struct {
+		name          string
+		exporters     map[string]component.Exporter
+		expectedError string
+	}{
+		{
+			name: "all exporters",
+			exporters: map[string]component.Exporter{
+				"default": newExporter(),
+				"acme":    newExporter(),
+				"globex":  newExporter(),
+			},
+		},
+		{
+			name: "missing exporter",
+			exporters: map[string]component.Exporter{
+				"default": newExporter(),
+				"acme":    newExporter(),
+			},
+			expectedError: `exporter "globex" not found, it must be used by a traces pipeline`,
+		},
+		{
+			name: "not a traces exporter",
+			exporters: map[string]component.Exporter{
+				"default": componenthelper.NewComponent(componenthelper.DefaultComponentSettings()),
+				"acme":    newExporter(),
+				"globex":  newExporter(),
+			},
+			expectedError: `exporter "default" is not a traces exporter`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := newRouter(newTestConfig(ResourceAttributeSource), configmodels.TracesDataType)
+			require.NoError(t, err)
+			err = r.start(context.Background(), newExportersHost(test.exporters))
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// startRouter returns a started router of the given data type and its exporters.
+func startRouter(t *testing.T, source string, dataType configmodels.DataType) (*router, map[string]*exporter) {
+	exporters := map[string]*exporter{
+		"default": newExporter(),
+		"acme":    newExporter(),
+		"globex":  newExporter(),
+	}
+	hostExporters := make(map[string]component.Exporter, len(exporters))
+	for name, exp := range exporters {
+		hostExporters[name] = exp
+	}
+
+	r, err := newRouter(newTestConfig(source), dataType)
+	require.NoError(t, err)
+	require.NoError(t, r.start(context.Background(), newExportersHost(hostExporters)))
+	return r, exporters
+}
+
+// tenantResources calls add with a new resource for each tenant, an empty tenant
+// leaves the routing attribute unset.
+func tenantResources(tenants []string, add func() pdata.Resource) {
+	for _, tenant := range tenants {
+		res := add()
+		res.Attributes().InitEmptyWithCapacity(1)
+		if tenant != "" {
+			res.Attributes().InsertString("X-Tenant", tenant)
+		}
+	}
+}
+
+func newTenantTraces(tenants ...string) pdata.Traces {
+	td := pdata.NewTraces()
+	tenantResources(tenants, func() pdata.Resource {
+		rss := td.ResourceSpans()
+		rss.Resize(rss.Len() + 1)
+		return rss.At(rss.Len() - 1).Resource()
+	})
+	return td
+}
+
+func newTenantMetrics(tenants ...string) pdata.Metrics {
+	md := pdata.NewMetrics()
+	tenantResources(tenants, func() pdata.Resource {
+		rms := md.ResourceMetrics()
+		rms.Resize(rms.Len() + 1)
+		return rms.At(rms.Len() - 1).Resource()
+	})
+	return md
+}
+
+func newTenantLogs(tenants ...string) pdata.Logs {
+	ld := pdata.NewLogs()
+	tenantResources(tenants, func() pdata.Resource {
+		rls := ld.ResourceLogs()
+		rls.Resize(rls.Len() + 1)
+		return rls.At(rls.Len() - 1).Resource()
+	})
+	return ld
+}
+
+func tenantContext(tenant string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", tenant))
+}
+
+func TestProcessTraces_Resource(t *testing.T) {
+	r, exporters := startRouter(t, ResourceAttributeSource, configmodels.TracesDataType)
+
+	_, err := r.ProcessTraces(context.Background(), newTenantTraces("acme", "globex", "", "initech", "acme"))
+	assert.Equal(t, errSkip, err)
+
+	acme := exporters["acme"].traces.AllTraces()
+	require.Len(t, acme, 1)
+	assert.Equal(t, 2, acme[0].ResourceSpans().Len())
+
+	globex := exporters["globex"].traces.AllTraces()
+	require.Len(t, globex, 1)
+	assert.Equal(t, 1, globex[0].ResourceSpans().Len())
+
+	// The default exporter receives the unmatched resources, and the globex
+	// resource as part of its route.
+	assert.Equal(t, 3, countResourceSpans(exporters["default"].traces.AllTraces()))
+}
+
+func countResourceSpans(tds []pdata.Traces) int {
+	count := 0
+	for _, td := range tds {
+		count += td.ResourceSpans().Len()
+	}
+	return count
+}
+
+func TestProcessTraces_Context(t *testing.T) {
+	r, exporters := startRouter(t, ContextAttributeSource, configmodels.TracesDataType)
+
+	td := newTenantTraces("globex", "")
+	_, err := r.ProcessTraces(tenantContext("acme"), td)
+	assert.Equal(t, errSkip, err)
+	assert.Equal(t, []pdata.Traces{td}, exporters["acme"].traces.AllTraces())
+	assert.Empty(t, exporters["globex"].traces.AllTraces())
+	assert.Empty(t, exporters["default"].traces.AllTraces())
+
+	_, err = r.ProcessTraces(context.Background(), td)
+	assert.Equal(t, errSkip, err)
+	assert.Equal(t, []pdata.Traces{td}, exporters["default"].traces.AllTraces())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	r, exporters := startRouter(t, ResourceAttributeSource, configmodels.MetricsDataType)
+
+	_, err := r.ProcessMetrics(context.Background(), newTenantMetrics("acme", "", "globex"))
+	assert.Equal(t, errSkip, err)
+	require.Len(t, exporters["acme"].metrics.AllMetrics(), 1)
+	require.Len(t, exporters["globex"].metrics.AllMetrics(), 1)
+	assert.Len(t, exporters["default"].metrics.AllMetrics(), 2)
+
+	r, exporters = startRouter(t, ContextAttributeSource, configmodels.MetricsDataType)
+	md := newTenantMetrics("acme")
+	_, err = r.ProcessMetrics(tenantContext("globex"), md)
+	assert.Equal(t, errSkip, err)
+	assert.Empty(t, exporters["acme"].metrics.AllMetrics())
+	assert.Equal(t, []pdata.Metrics{md}, exporters["globex"].metrics.AllMetrics())
+	assert.Equal(t, []pdata.Metrics{md}, exporters["default"].metrics.AllMetrics())
+}
+
+func TestProcessLogs(t *testing.T) {
+	r, exporters := startRouter(t, ResourceAttributeSource, configmodels.LogsDataType)
+
+	_, err := r.ProcessLogs(context.Background(), newTenantLogs("initech", "acme"))
+	assert.Equal(t, errSkip, err)
+	assert.Len(t, exporters["acme"].logs.AllLogs(), 1)
+	assert.Empty(t, exporters["globex"].logs.AllLogs())
+	assert.Len(t, exporters["default"].logs.AllLogs(), 1)
+
+	r, exporters = startRouter(t, ContextAttributeSource, configmodels.LogsDataType)
+	ld := newTenantLogs("acme")
+	_, err = r.ProcessLogs(tenantContext("initech"), ld)
+	assert.Equal(t, errSkip, err)
+	assert.Empty(t, exporters["acme"].logs.AllLogs())
+	assert.Equal(t, []pdata.Logs{ld}, exporters["default"].logs.AllLogs())
+}
+
+func TestProcessTraces_ExporterError(t *testing.T) {
+	r, exporters := startRouter(t, ContextAttributeSource, configmodels.TracesDataType)
+	exporters["globex"].err = assert.AnError
+
+	_, err := r.ProcessTraces(tenantContext("globex"), newTenantTraces("globex"))
+	assert.Equal(t, assert.AnError, err)
+	// The other exporters of the route still receive the data.
+	assert.Len(t, exporters["default"].traces.AllTraces(), 1)
+}
+
+func TestRoutingProcessor_NextConsumer(t *testing.T) {
+	cfg := newTestConfig(ResourceAttributeSource)
+	cfg.ProcessorSettings = configmodels.ProcessorSettings{TypeVal: typeStr, NameVal: typeStr}
+	next := new(consumertest.TracesSink)
+
+	tp, err := NewFactory().CreateTracesProcessor(
+		context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, next)
+	require.NoError(t, err)
+
+	host := newExportersHost(map[string]component.Exporter{
+		"default": newExporter(),
+		"acme":    newExporter(),
+		"globex":  newExporter(),
+	})
+	require.NoError(t, tp.Start(context.Background(), host))
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), newTenantTraces("acme")))
+	assert.Empty(t, next.AllTraces())
+	require.NoError(t, tp.Shutdown(context.Background()))
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Schema processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// SchemaFile is the path of the schema file describing the changes between
+	// the versions of the schema, e.g. a copy of
+	// https://opentelemetry.io/schemas/1.7.0.
+	SchemaFile string `mapstructure:"schema_file"`
+
+	// TargetVersion is the version of the schema the telemetry is translated to.
+	TargetVersion string `mapstructure:"target_version"`
+
+	// SourceVersion is the version of the schema of the telemetry whose resource
+	// doesn't declare its schema URL.
+	SourceVersion string `mapstructure:"source_version"`
+
+	// SchemaURLAttribute is the key of the resource attribute declaring the
+	// schema URL of the telemetry, whose last path segment is the version. It is
+	// set to the URL of the target version after the translation.
+	SchemaURLAttribute string `mapstructure:"schema_url_attribute"`
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SchemaFile = "./testdata/schema.yaml"
+	cfg.TargetVersion = "1.2.0"
+	cfg.SourceVersion = "1.0.0"
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	assert.NotNil(t, tp)
+	assert.True(t, tp.GetCapabilities().MutatesConsumedData)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, mp)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewLogsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, lp)
+}
+
+func TestCreateProcessors_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "no schema file",
+			modify: func(cfg *Config) { cfg.SchemaFile = "" },
+			err:    `missing required field "schema_file"`,
+		},
+		{
+			name:   "no target version",
+			modify: func(cfg *Config) { cfg.TargetVersion = "" },
+			err:    `missing required field "target_version"`,
+		},
+		{
+			name:   "no source version",
+			modify: func(cfg *Config) { cfg.SourceVersion = "" },
+			err:    `at least one of "source_version" and "schema_url_attribute" must be specified`,
+		},
+		{
+			name:   "unknown target version",
+			modify: func(cfg *Config) { cfg.TargetVersion = "2.0.0" },
+			err:    `unknown target version "2.0.0"`,
+		},
+		{
+			name:   "unknown source version",
+			modify: func(cfg *Config) { cfg.SourceVersion = "0.1.0" },
+			err:    `unknown source version "0.1.0"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.SchemaFile = "./testdata/schema.yaml"
+			cfg.TargetVersion = "1.2.0"
+			cfg.SourceVersion = "1.0.0"
+			tt.modify(cfg)
+			params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+			tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewTracesNop())
+			assert.EqualError(t, err, `error creating "schema" processor: `+tt.err)
+			assert.Nil(t, tp)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/datapoint"
+)
+
+var (
+	errNoSchemaFile    = errors.New("missing required field \"schema_file\"")
+	errNoTargetVersion = errors.New("missing required field \"target_version\"")
+	errNoSourceVersion = errors.New("at least one of \"source_version\" and \"schema_url_attribute\" must be specified")
+)
+
+// schemaProcessor translates the telemetry from the version of the schema of
+// their resource to the target version.
+type schemaProcessor struct {
+	schema             *schema
+	targetVersion      string
+	sourceVersion      string
+	schemaURLAttribute string
+	// translations are the changes translating the telemetry of each version of
+	// the schema to the target version.
+	translations map[string]changes
+}
+
+func newSchemaProcessor(cfg *Config) (*schemaProcessor, error) {
+	switch {
+	case cfg.SchemaFile == "":
+		return nil, errNoSchemaFile
+	case cfg.TargetVersion == "":
+		return nil, errNoTargetVersion
+	case cfg.SourceVersion == "" && cfg.SchemaURLAttribute == "":
+		return nil, errNoSourceVersion
+	}
+	s, err := loadSchema(cfg.SchemaFile)
+	if err != nil {
+		return nil, err
+	}
+	if s.versionIndex(cfg.TargetVersion) < 0 {
+		return nil, fmt.Errorf("unknown target version %q", cfg.TargetVersion)
+	}
+	if cfg.SourceVersion != "" && s.versionIndex(cfg.SourceVersion) < 0 {
+		return nil, fmt.Errorf("unknown source version %q", cfg.SourceVersion)
+	}
+
+	sp := &schemaProcessor{
+		schema:             s,
+		targetVersion:      cfg.TargetVersion,
+		sourceVersion:      cfg.SourceVersion,
+		schemaURLAttribute: cfg.SchemaURLAttribute,
+		translations:       make(map[string]changes, len(s.versions)),
+	}
+	for _, version := range s.versions {
+		if sp.translations[version], err = s.translation(version, cfg.TargetVersion); err != nil {
+			return nil, err
+		}
+	}
+	return sp, nil
+}
+
+// resourceTranslation returns the changes translating the telemetry of the
+// resource, and sets its schema URL to the target version. It returns false if
+// the version of the resource is unknown, its telemetry is then left unchanged.
+func (sp *schemaProcessor) resourceTranslation(resource pdata.Resource) (changes, bool) {
+	version := sp.sourceVersion
+	if sp.schemaURLAttribute != "" {
+		if v, ok := resource.Attributes().Get(sp.schemaURLAttribute); ok && v.Type() == pdata.AttributeValueSTRING {
+			if version, ok = sp.schema.urlVersion(v.StringVal()); !ok {
+				return changes{}, false
+			}
+		}
+	}
+	t, ok := sp.translations[version]
+	if !ok {
+		return changes{}, false
+	}
+
+	renameAttributes(resource.Attributes(), t.resources, "")
+	if sp.schemaURLAttribute != "" {
+		resource.Attributes().UpsertString(sp.schemaURLAttribute, sp.schema.family+sp.targetVersion)
+	}
+	return t, true
+}
+
+// ProcessTraces renames the attributes of the resources, the spans and the span
+// events.
+func (sp *schemaProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		t, ok := sp.resourceTranslation(rs.Resource())
+		if !ok {
+			continue
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				renameAttributes(span.Attributes(), t.spans, span.Name())
+				events := span.Events()
+				for l := 0; l < events.Len(); l++ {
+					renameAttributes(events.At(l).Attributes(), t.spanEvents, "")
+				}
+			}
+		}
+	}
+	return td, nil
+}
+
+// ProcessMetrics renames the attributes of the resources, and the metrics and
+// their labels.
+func (sp *schemaProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		t, ok := sp.resourceTranslation(rm.Resource())
+		if !ok {
+			continue
+		}
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				renameMetric(metrics.At(k), t.metrics)
+			}
+		}
+	}
+	return md, nil
+}
+
+// ProcessLogs renames the attributes of the resources and the log records.
+func (sp *schemaProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		t, ok := sp.resourceTranslation(rl.Resource())
+		if !ok {
+			continue
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				renameAttributes(logs.At(k).Attributes(), t.logs, "")
+			}
+		}
+	}
+	return ld, nil
+}
+
+// renameAttributes applies the attribute renames of the changes applying to the
+// named item. A renamed attribute replaces the attribute with the new key.
+func renameAttributes(attributes pdata.AttributeMap, changes []renameChange, name string) {
+	for i := range changes {
+		c := &changes[i]
+		if !c.appliesTo(name) {
+			continue
+		}
+		for from, to := range c.attributes {
+			if v, ok := attributes.Get(from); ok {
+				attributes.Upsert(to, v)
+				attributes.Delete(from)
+			}
+		}
+	}
+}
+
+// renameMetric applies the changes to the metric name and the labels of its
+// data points, the changes applying to the metric are matched with its name at
+// the time they are applied.
+func renameMetric(metric pdata.Metric, changes []renameChange) {
+	for i := range changes {
+		c := &changes[i]
+		if to, ok := c.metrics[metric.Name()]; ok {
+			metric.SetName(to)
+		}
+		if len(c.attributes) == 0 || !c.appliesTo(metric.Name()) {
+			continue
+		}
+		datapoint.ForEachLabels(metric, func(labels pdata.StringMap) {
+			for from, to := range c.attributes {
+				if v, ok := labels.Get(from); ok {
+					labels.Upsert(to, v)
+					labels.Delete(from)
+				}
+			}
+		})
+	}
+}
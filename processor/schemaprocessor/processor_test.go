@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newTestProcessor(t *testing.T, targetVersion, sourceVersion string) *schemaProcessor {
+	sp, err := newSchemaProcessor(&Config{
+		SchemaFile:         "./testdata/schema.yaml",
+		TargetVersion:      targetVersion,
+		SourceVersion:      sourceVersion,
+		SchemaURLAttribute: "telemetry.schema_url",
+	})
+	require.NoError(t, err)
+	return sp
+}
+
+func TestProcessTraces(t *testing.T) {
+	sp := newTestProcessor(t, "1.2.0", "1.0.0")
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(3)
+	td.ResourceSpans().At(1).Resource().Attributes().InsertString("telemetry.schema_url", "https://opentelemetry.io/schemas/1.2.0")
+	td.ResourceSpans().At(2).Resource().Attributes().InsertString("telemetry.schema_url", "https://example.com/schemas/1.0.0")
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		rs.Resource().Attributes().InsertString("telemetry.auto.version", "0.1.0")
+		rs.InstrumentationLibrarySpans().Resize(1)
+		spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+		spans.Resize(2)
+		spans.At(0).SetName("SELECT")
+		spans.At(1).SetName("INSERT")
+		for j := 0; j < spans.Len(); j++ {
+			spans.At(j).Attributes().InsertString("net.peer.ip", "10.0.0.1")
+			spans.At(j).Attributes().InsertString("db.cassandra.keyspace", "users")
+		}
+		spans.At(0).Events().Resize(1)
+		spans.At(0).Events().At(0).Attributes().InsertString("net.peer.ip", "10.0.0.2")
+	}
+
+	td, err := sp.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	// Translated from the source version.
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"telemetry.auto_version": pdata.NewAttributeValueString("0.1.0"),
+		"telemetry.schema_url":   pdata.NewAttributeValueString("https://opentelemetry.io/schemas/1.2.0"),
+	}, attributesAsMap(rs.Resource().Attributes()))
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"net.sock.peer.addr": pdata.NewAttributeValueString("10.0.0.1"),
+		"db.name":            pdata.NewAttributeValueString("users"),
+	}, attributesAsMap(spans.At(0).Attributes()))
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"net.sock.peer.addr":    pdata.NewAttributeValueString("10.0.0.1"),
+		"db.cassandra.keyspace": pdata.NewAttributeValueString("users"),
+	}, attributesAsMap(spans.At(1).Attributes()))
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"net.sock.peer.addr": pdata.NewAttributeValueString("10.0.0.2"),
+	}, attributesAsMap(spans.At(0).Events().At(0).Attributes()))
+
+	// Already in the target version.
+	rs = td.ResourceSpans().At(1)
+	_, ok := rs.Resource().Attributes().Get("telemetry.auto.version")
+	assert.True(t, ok)
+	_, ok = rs.InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes().Get("net.peer.ip")
+	assert.True(t, ok)
+
+	// Unknown schema.
+	rs = td.ResourceSpans().At(2)
+	v, _ := rs.Resource().Attributes().Get("telemetry.schema_url")
+	assert.Equal(t, "https://example.com/schemas/1.0.0", v.StringVal())
+	_, ok = rs.InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes().Get("net.peer.ip")
+	assert.True(t, ok)
+}
+
+func TestProcessMetrics_Downgrade(t *testing.T) {
+	sp := newTestProcessor(t, "1.0.0", "")
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(2)
+	md.ResourceMetrics().At(0).Resource().Attributes().InsertString("telemetry.schema_url", "https://opentelemetry.io/schemas/1.2.0")
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		rm.InstrumentationLibraryMetrics().Resize(1)
+		metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+		metrics.Resize(2)
+		metrics.At(0).SetName("process.runtime.jvm.gc.duration")
+		metrics.At(1).SetName("process.runtime.jvm.memory.usage")
+		for j := 0; j < metrics.Len(); j++ {
+			metrics.At(j).SetDataType(pdata.MetricDataTypeDoubleGauge)
+			metrics.At(j).DoubleGauge().DataPoints().Resize(1)
+			metrics.At(j).DoubleGauge().DataPoints().At(0).LabelsMap().InitFromMap(map[string]string{
+				"pool":               "eden",
+				"net.sock.peer.addr": "10.0.0.1",
+			})
+		}
+	}
+
+	md, err := sp.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Equal(t, "process.runtime.jvm.gc.time", metrics.At(0).Name())
+	assert.Equal(t, map[string]string{"type": "eden", "net.peer.ip": "10.0.0.1"},
+		labelsAsMap(metrics.At(0).DoubleGauge().DataPoints().At(0).LabelsMap()))
+	assert.Equal(t, "process.runtime.jvm.memory.usage", metrics.At(1).Name())
+	assert.Equal(t, map[string]string{"pool": "eden", "net.peer.ip": "10.0.0.1"},
+		labelsAsMap(metrics.At(1).DoubleGauge().DataPoints().At(0).LabelsMap()))
+	v, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get("telemetry.schema_url")
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.0.0", v.StringVal())
+
+	// Without source version nor schema URL.
+	metrics = md.ResourceMetrics().At(1).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Equal(t, "process.runtime.jvm.gc.duration", metrics.At(0).Name())
+	assert.Equal(t, 0, md.ResourceMetrics().At(1).Resource().Attributes().Len())
+}
+
+func TestProcessLogs(t *testing.T) {
+	sp := newTestProcessor(t, "1.1.0", "1.0.0")
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	ld.ResourceLogs().At(0).InstrumentationLibraryLogs().Resize(1)
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(1)
+	logs.At(0).Attributes().InsertString("exception.msg", "timeout")
+	logs.At(0).Attributes().InsertString("net.peer.ip", "10.0.0.1")
+
+	ld, err := sp.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"exception.message":  pdata.NewAttributeValueString("timeout"),
+		"net.sock.peer.addr": pdata.NewAttributeValueString("10.0.0.1"),
+	}, attributesAsMap(logs.At(0).Attributes()))
+}
+
+func attributesAsMap(attributes pdata.AttributeMap) map[string]pdata.AttributeValue {
+	m := make(map[string]pdata.AttributeValue, attributes.Len())
+	attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = v
+	})
+	return m
+}
+
+func labelsAsMap(labels pdata.StringMap) map[string]string {
+	m := make(map[string]string, labels.Len())
+	labels.ForEach(func(k string, v string) {
+		m[k] = v
+	})
+	return m
+}
@@ -0,0 +1,301 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// schemaFile is the content of a schema file in the file format 1.0.0, see
+// https://github.com/open-telemetry/oteps/blob/main/text/0152-telemetry-schemas.md.
+// Only the attribute and metric renames are supported.
+type schemaFile struct {
+	FileFormat string                 `yaml:"file_format"`
+	SchemaURL  string                 `yaml:"schema_url"`
+	Versions   map[string]versionFile `yaml:"versions"`
+}
+
+type versionFile struct {
+	All       sectionFile `yaml:"all"`
+	Resources sectionFile `yaml:"resources"`
+	Spans     sectionFile `yaml:"spans"`
+	Metrics   sectionFile `yaml:"metrics"`
+	Logs      sectionFile `yaml:"logs"`
+}
+
+type sectionFile struct {
+	Changes []changeFile `yaml:"changes"`
+}
+
+type changeFile struct {
+	RenameAttributes *renameAttributesFile `yaml:"rename_attributes"`
+	RenameMetrics    map[string]string     `yaml:"rename_metrics"`
+}
+
+type renameAttributesFile struct {
+	AttributeMap   map[string]string `yaml:"attribute_map"`
+	ApplyToSpans   []string          `yaml:"apply_to_spans"`
+	ApplyToMetrics []string          `yaml:"apply_to_metrics"`
+}
+
+// renameChange renames the attributes or labels, or the metrics.
+type renameChange struct {
+	// attributes maps the renamed attributes or labels to their new keys.
+	attributes map[string]string
+	// applyTo are the names of the spans or metrics the attributes are renamed
+	// for, they are renamed for all of them if it is nil.
+	applyTo map[string]bool
+	// metrics maps the renamed metrics to their new names.
+	metrics map[string]string
+}
+
+func (c *renameChange) appliesTo(name string) bool {
+	return c.applyTo == nil || c.applyTo[name]
+}
+
+// reversed returns the change renaming back the new names to the old ones.
+// The names it applies to are the same, the spans and metrics the attributes
+// are renamed for keep their names when their attributes are renamed.
+func (c *renameChange) reversed() renameChange {
+	return renameChange{
+		attributes: reverseMap(c.attributes),
+		applyTo:    c.applyTo,
+		metrics:    reverseMap(c.metrics),
+	}
+}
+
+// changes are the changes applied to each kind of telemetry item, in order.
+type changes struct {
+	resources  []renameChange
+	spans      []renameChange
+	spanEvents []renameChange
+	metrics    []renameChange
+	logs       []renameChange
+}
+
+func (c *changes) append(other changes) {
+	c.resources = append(c.resources, other.resources...)
+	c.spans = append(c.spans, other.spans...)
+	c.spanEvents = append(c.spanEvents, other.spanEvents...)
+	c.metrics = append(c.metrics, other.metrics...)
+	c.logs = append(c.logs, other.logs...)
+}
+
+// reversed returns the changes undoing the changes.
+func (c *changes) reversed() changes {
+	return changes{
+		resources:  reverseChanges(c.resources),
+		spans:      reverseChanges(c.spans),
+		spanEvents: reverseChanges(c.spanEvents),
+		metrics:    reverseChanges(c.metrics),
+		logs:       reverseChanges(c.logs),
+	}
+}
+
+// schema holds the changes introduced by each version of a schema.
+type schema struct {
+	// family is the schema URL without the version.
+	family string
+	// versions are sorted in ascending order.
+	versions []string
+	changes  map[string]changes
+}
+
+// loadSchema reads a schema file.
+func loadSchema(path string) (*schema, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file schemaFile
+	if err = yaml.UnmarshalStrict(buf, &file); err != nil {
+		return nil, fmt.Errorf("invalid schema file %s: %w", path, err)
+	}
+	if !strings.HasPrefix(file.FileFormat, "1.") {
+		return nil, fmt.Errorf("invalid schema file %s: unsupported file format %q", path, file.FileFormat)
+	}
+	if file.SchemaURL == "" {
+		return nil, fmt.Errorf("invalid schema file %s: missing schema_url", path)
+	}
+
+	s := &schema{
+		family:  file.SchemaURL[:strings.LastIndex(file.SchemaURL, "/")+1],
+		changes: make(map[string]changes, len(file.Versions)),
+	}
+	for version, v := range file.Versions {
+		if _, err = parseVersion(version); err != nil {
+			return nil, fmt.Errorf("invalid schema file %s: %w", path, err)
+		}
+		s.versions = append(s.versions, version)
+		s.changes[version] = compileVersion(v)
+	}
+	sort.Slice(s.versions, func(i, j int) bool {
+		return compareVersions(s.versions[i], s.versions[j]) < 0
+	})
+	return s, nil
+}
+
+// compileVersion returns the changes of a version applied to each kind of
+// item, the changes applying to all of them being applied first.
+func compileVersion(v versionFile) changes {
+	var all []renameChange
+	for _, c := range v.All.Changes {
+		if c.RenameAttributes != nil {
+			all = append(all, renameChange{attributes: c.RenameAttributes.AttributeMap})
+		}
+	}
+
+	c := changes{
+		resources:  append([]renameChange(nil), all...),
+		spans:      append([]renameChange(nil), all...),
+		spanEvents: append([]renameChange(nil), all...),
+		metrics:    append([]renameChange(nil), all...),
+		logs:       append([]renameChange(nil), all...),
+	}
+	for _, change := range v.Resources.Changes {
+		if change.RenameAttributes != nil {
+			c.resources = append(c.resources, renameChange{attributes: change.RenameAttributes.AttributeMap})
+		}
+	}
+	for _, change := range v.Spans.Changes {
+		if change.RenameAttributes != nil {
+			c.spans = append(c.spans, renameChange{
+				attributes: change.RenameAttributes.AttributeMap,
+				applyTo:    toSet(change.RenameAttributes.ApplyToSpans),
+			})
+		}
+	}
+	for _, change := range v.Metrics.Changes {
+		if change.RenameMetrics != nil {
+			c.metrics = append(c.metrics, renameChange{metrics: change.RenameMetrics})
+		}
+		if change.RenameAttributes != nil {
+			c.metrics = append(c.metrics, renameChange{
+				attributes: change.RenameAttributes.AttributeMap,
+				applyTo:    toSet(change.RenameAttributes.ApplyToMetrics),
+			})
+		}
+	}
+	for _, change := range v.Logs.Changes {
+		if change.RenameAttributes != nil {
+			c.logs = append(c.logs, renameChange{attributes: change.RenameAttributes.AttributeMap})
+		}
+	}
+	return c
+}
+
+// translation returns the changes translating the telemetry from a version to
+// another: the changes of the versions after the source version up to the
+// target version for an upgrade, and the reversed changes of the versions
+// after the target version up to the source version for a downgrade.
+func (s *schema) translation(from, to string) (changes, error) {
+	fromIdx, toIdx := s.versionIndex(from), s.versionIndex(to)
+	if fromIdx < 0 {
+		return changes{}, fmt.Errorf("unknown schema version %q", from)
+	}
+	if toIdx < 0 {
+		return changes{}, fmt.Errorf("unknown schema version %q", to)
+	}
+
+	var t changes
+	for i := fromIdx + 1; i <= toIdx; i++ {
+		t.append(s.changes[s.versions[i]])
+	}
+	for i := fromIdx; i > toIdx; i-- {
+		c := s.changes[s.versions[i]]
+		t.append(c.reversed())
+	}
+	return t, nil
+}
+
+func (s *schema) versionIndex(version string) int {
+	for i, v := range s.versions {
+		if v == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseVersion parses a version made of dot separated numbers.
+func parseVersion(version string) ([]int, error) {
+	parts := strings.Split(version, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
+
+// compareVersions compares two valid versions.
+func compareVersions(a, b string) int {
+	va, _ := parseVersion(a)
+	vb, _ := parseVersion(b)
+	for i := 0; i < len(va) && i < len(vb); i++ {
+		if va[i] != vb[i] {
+			return va[i] - vb[i]
+		}
+	}
+	return len(va) - len(vb)
+}
+
+// urlVersion returns the version of a schema URL, it returns false if the URL
+// is not a URL of the schema family.
+func (s *schema) urlVersion(url string) (string, bool) {
+	if !strings.HasPrefix(url, s.family) {
+		return "", false
+	}
+	return url[len(s.family):], true
+}
+
+func reverseChanges(changes []renameChange) []renameChange {
+	reversed := make([]renameChange, len(changes))
+	for i, c := range changes {
+		reversed[len(changes)-1-i] = c.reversed()
+	}
+	return reversed
+}
+
+func reverseMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	reversed := make(map[string]string, len(m))
+	for k, v := range m {
+		reversed[v] = k
+	}
+	return reversed
+}
+
+func toSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSchema(t *testing.T) {
+	s, err := loadSchema(path.Join(".", "testdata", "schema.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://opentelemetry.io/schemas/", s.family)
+	assert.Equal(t, []string{"1.0.0", "1.1.0", "1.2.0"}, s.versions)
+
+	version, ok := s.urlVersion("https://opentelemetry.io/schemas/1.1.0")
+	assert.True(t, ok)
+	assert.Equal(t, "1.1.0", version)
+	_, ok = s.urlVersion("https://example.com/schemas/1.1.0")
+	assert.False(t, ok)
+}
+
+func TestLoadSchema_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "unsupported file format",
+			content: "file_format: 2.0.0\nschema_url: https://opentelemetry.io/schemas/1.0.0",
+			err:     `unsupported file format "2.0.0"`,
+		},
+		{
+			name:    "no schema URL",
+			content: "file_format: 1.0.0",
+			err:     "missing schema_url",
+		},
+		{
+			name:    "invalid version",
+			content: "file_format: 1.0.0\nschema_url: https://opentelemetry.io/schemas/1.0.0\nversions:\n  latest:",
+			err:     `invalid version "latest"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", "schema")
+			require.NoError(t, err)
+			defer os.Remove(f.Name())
+			_, err = f.WriteString(tt.content)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			_, err = loadSchema(f.Name())
+			assert.EqualError(t, err, "invalid schema file "+f.Name()+": "+tt.err)
+		})
+	}
+
+	_, err := loadSchema(path.Join(".", "testdata", "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestTranslation(t *testing.T) {
+	s, err := loadSchema(path.Join(".", "testdata", "schema.yaml"))
+	require.NoError(t, err)
+
+	upgrade, err := s.translation("1.0.0", "1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, []renameChange{
+		{attributes: map[string]string{"net.peer.ip": "net.sock.peer.addr"}},
+		{attributes: map[string]string{"telemetry.auto.version": "telemetry.auto_version"}},
+	}, upgrade.resources)
+	assert.Equal(t, []renameChange{
+		{attributes: map[string]string{"net.peer.ip": "net.sock.peer.addr"}},
+		{attributes: map[string]string{"db.cassandra.keyspace": "db.name"}, applyTo: map[string]bool{"SELECT": true}},
+	}, upgrade.spans)
+
+	downgrade, err := s.translation("1.2.0", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []renameChange{
+		{attributes: map[string]string{"pool": "type"}, applyTo: map[string]bool{"process.runtime.jvm.gc.duration": true}},
+		{metrics: map[string]string{"process.runtime.jvm.gc.duration": "process.runtime.jvm.gc.time"}},
+		{attributes: map[string]string{"net.sock.peer.addr": "net.peer.ip"}},
+	}, downgrade.metrics)
+
+	same, err := s.translation("1.1.0", "1.1.0")
+	require.NoError(t, err)
+	assert.Empty(t, same.spans)
+
+	_, err = s.translation("0.9.0", "1.1.0")
+	assert.EqualError(t, err, `unknown schema version "0.9.0"`)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.True(t, compareVersions("1.2.0", "1.10.0") < 0)
+	assert.True(t, compareVersions("1.10.0", "1.9.1") > 0)
+	assert.True(t, compareVersions("1.1", "1.1.0") < 0)
+	assert.Equal(t, 0, compareVersions("1.4.0", "1.4.0"))
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"time"
+)
+
+// spanKey identifies a span by its IDs and the hash of its contents.
+type spanKey struct {
+	traceID [16]byte
+	spanID  [8]byte
+	hash    uint64
+}
+
+type cacheEntry struct {
+	key  spanKey
+	seen time.Time
+}
+
+// spanCache remembers the spans received within the window, in the order they
+// were first received.
+type spanCache struct {
+	window   time.Duration
+	maxSpans int
+	seen     map[spanKey]struct{}
+	entries  []cacheEntry
+}
+
+func newSpanCache(window time.Duration, maxSpans int) *spanCache {
+	return &spanCache{
+		window:   window,
+		maxSpans: maxSpans,
+		seen:     make(map[spanKey]struct{}),
+	}
+}
+
+// add remembers the span, and returns false if it was already received within
+// the window.
+func (c *spanCache) add(key spanKey, now time.Time) bool {
+	if _, ok := c.seen[key]; ok {
+		return false
+	}
+	if len(c.entries) >= c.maxSpans {
+		c.evict()
+	}
+	c.seen[key] = struct{}{}
+	c.entries = append(c.entries, cacheEntry{key: key, seen: now})
+	return true
+}
+
+// expire forgets the spans first received before the window.
+func (c *spanCache) expire(now time.Time) {
+	for len(c.entries) > 0 && now.Sub(c.entries[0].seen) >= c.window {
+		c.evict()
+	}
+}
+
+// evict forgets the oldest span.
+func (c *spanCache) evict() {
+	delete(c.seen, c.entries[0].key)
+	c.entries[0] = cacheEntry{}
+	c.entries = c.entries[1:]
+}
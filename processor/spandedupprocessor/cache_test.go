@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpanCache(t *testing.T) {
+	now := time.Unix(1614587400, 0)
+	c := newSpanCache(time.Minute, 3)
+	first := spanKey{spanID: [8]byte{1}}
+	second := spanKey{spanID: [8]byte{2}}
+
+	assert.True(t, c.add(first, now))
+	assert.False(t, c.add(first, now.Add(10*time.Second)))
+	assert.True(t, c.add(second, now.Add(30*time.Second)))
+
+	// The spans are remembered for the window after they are first received.
+	c.expire(now.Add(time.Minute))
+	assert.True(t, c.add(first, now.Add(time.Minute)))
+	assert.False(t, c.add(second, now.Add(time.Minute)))
+
+	// The oldest spans are forgotten when the cache is full.
+	assert.True(t, c.add(spanKey{spanID: [8]byte{3}}, now.Add(time.Minute)))
+	assert.True(t, c.add(spanKey{spanID: [8]byte{4}}, now.Add(time.Minute)))
+	assert.Len(t, c.seen, 3)
+	assert.True(t, c.add(second, now.Add(time.Minute)))
+}
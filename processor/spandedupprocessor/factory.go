@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "spandedup"
+
+	defaultWindow   = time.Minute
+	defaultMaxSpans = 100000
+)
+
+var (
+	errNonPositiveWindow   = errors.New("window must be positive")
+	errNonPositiveMaxSpans = errors.New("max_spans must be positive")
+)
+
+// NewFactory returns a new factory for the Span Deduplication processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Window:   defaultWindow,
+		MaxSpans: defaultMaxSpans,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.TracesConsumer) (component.TracesProcessor, error) {
+	oCfg := cfg.(*Config)
+	if oCfg.Window <= 0 {
+		return nil, fmt.Errorf("error creating %q processor: %w", cfg.Name(), errNonPositiveWindow)
+	}
+	if oCfg.MaxSpans <= 0 {
+		return nil, fmt.Errorf("error creating %q processor: %w", cfg.Name(), errNonPositiveMaxSpans)
+	}
+	return processorhelper.NewTraceProcessor(
+		cfg,
+		nextConsumer,
+		newSpanDedupProcessor(oCfg),
+		processorhelper.WithCapabilities(component.ProcessorCapabilities{MutatesConsumedData: true}))
+}
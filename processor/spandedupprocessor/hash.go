@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"sort"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// spanHasher hashes the contents of the spans with FNV-1a.
+type spanHasher struct {
+	h   hash.Hash64
+	buf [8]byte
+}
+
+func newSpanHasher() *spanHasher {
+	return &spanHasher{h: fnv.New64a()}
+}
+
+// resourceHash returns the hash of the resource and the instrumentation
+// library of the spans, which seeds the hashes of the spans.
+func (s *spanHasher) resourceHash(resource pdata.Resource, library pdata.InstrumentationLibrary) uint64 {
+	s.h.Reset()
+	s.writeAttributes(resource.Attributes())
+	s.writeString(library.Name())
+	s.writeString(library.Version())
+	return s.h.Sum64()
+}
+
+// spanHash returns the hash of the contents of the span, including the
+// resource and the instrumentation library it belongs to.
+func (s *spanHasher) spanHash(seed uint64, span pdata.Span) uint64 {
+	s.h.Reset()
+	s.writeUint64(seed)
+	s.writeString(string(span.TraceState()))
+	s.writeSpanID(span.ParentSpanID())
+	s.writeString(span.Name())
+	s.writeUint64(uint64(span.Kind()))
+	s.writeUint64(uint64(span.StartTime()))
+	s.writeUint64(uint64(span.EndTime()))
+	s.writeAttributes(span.Attributes())
+	s.writeUint64(uint64(span.DroppedAttributesCount()))
+
+	events := span.Events()
+	s.writeUint64(uint64(events.Len()))
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		s.writeUint64(uint64(event.Timestamp()))
+		s.writeString(event.Name())
+		s.writeAttributes(event.Attributes())
+		s.writeUint64(uint64(event.DroppedAttributesCount()))
+	}
+	s.writeUint64(uint64(span.DroppedEventsCount()))
+
+	links := span.Links()
+	s.writeUint64(uint64(links.Len()))
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		s.writeTraceID(link.TraceID())
+		s.writeSpanID(link.SpanID())
+		s.writeString(string(link.TraceState()))
+		s.writeAttributes(link.Attributes())
+		s.writeUint64(uint64(link.DroppedAttributesCount()))
+	}
+	s.writeUint64(uint64(span.DroppedLinksCount()))
+
+	s.writeUint64(uint64(span.Status().Code()))
+	s.writeString(span.Status().Message())
+	return s.h.Sum64()
+}
+
+// writeAttributes writes the attributes sorted by key, so that the hash
+// doesn't depend on their order.
+func (s *spanHasher) writeAttributes(attrs pdata.AttributeMap) {
+	keys := make([]string, 0, attrs.Len())
+	attrs.ForEach(func(k string, _ pdata.AttributeValue) {
+		keys = append(keys, k)
+	})
+	sort.Strings(keys)
+	s.writeUint64(uint64(len(keys)))
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		s.writeString(k)
+		s.writeUint64(uint64(v.Type()))
+		s.writeString(tracetranslator.AttributeValueToString(v, false))
+	}
+}
+
+// writeString writes the length of the string before it, so that the
+// concatenations of different strings have different hashes.
+func (s *spanHasher) writeString(str string) {
+	s.writeUint64(uint64(len(str)))
+	_, _ = s.h.Write([]byte(str))
+}
+
+func (s *spanHasher) writeTraceID(id pdata.TraceID) {
+	b := id.Bytes()
+	_, _ = s.h.Write(b[:])
+}
+
+func (s *spanHasher) writeSpanID(id pdata.SpanID) {
+	b := id.Bytes()
+	_, _ = s.h.Write(b[:])
+}
+
+func (s *spanHasher) writeUint64(v uint64) {
+	binary.LittleEndian.PutUint64(s.buf[:], v)
+	_, _ = s.h.Write(s.buf[:])
+}
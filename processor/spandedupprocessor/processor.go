@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type spanDedupProcessor struct {
+	obsrep *obsreport.ProcessorObsReport
+
+	lock   sync.Mutex
+	cache  *spanCache
+	hasher *spanHasher
+	now    func() time.Time
+}
+
+func newSpanDedupProcessor(cfg *Config) *spanDedupProcessor {
+	return &spanDedupProcessor{
+		obsrep: obsreport.NewProcessorObsReport(configtelemetry.GetMetricsLevelFlagValue(), cfg.Name()),
+		cache:  newSpanCache(cfg.Window, cfg.MaxSpans),
+		hasher: newSpanHasher(),
+		now:    time.Now,
+	}
+}
+
+// ProcessTraces drops the spans which were already received within the window.
+func (p *spanDedupProcessor) ProcessTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
+	numSpans := td.SpanCount()
+
+	p.lock.Lock()
+	now := p.now()
+	p.cache.expire(now)
+	dropped := 0
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			seed := p.hasher.resourceHash(rs.Resource(), ils.InstrumentationLibrary())
+			spans := ils.Spans()
+			kept := pdata.NewSpanSlice()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				key := spanKey{
+					traceID: span.TraceID().Bytes(),
+					spanID:  span.SpanID().Bytes(),
+					hash:    p.hasher.spanHash(seed, span),
+				}
+				if p.cache.add(key, now) {
+					kept.Append(span)
+				}
+			}
+			if kept.Len() < spans.Len() {
+				dropped += spans.Len() - kept.Len()
+				spans.Resize(0)
+				kept.MoveAndAppendTo(spans)
+			}
+		}
+	}
+	p.lock.Unlock()
+
+	p.obsrep.TracesAccepted(ctx, numSpans-dropped)
+	if dropped > 0 {
+		p.obsrep.TracesDropped(ctx, dropped)
+		if dropped == numSpans {
+			return td, processorhelper.ErrSkipProcessingData
+		}
+	}
+	return td, nil
+}
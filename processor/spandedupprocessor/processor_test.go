@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func newTestProcessor(now *time.Time) *spanDedupProcessor {
+	p := newSpanDedupProcessor(&Config{
+		ProcessorSettings: configmodels.ProcessorSettings{TypeVal: typeStr, NameVal: typeStr},
+		Window:            time.Minute,
+		MaxSpans:          100,
+	})
+	p.now = func() time.Time { return *now }
+	return p
+}
+
+func newTestTraces(spanIDs ...byte) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(len(spanIDs))
+	for i, id := range spanIDs {
+		span := spans.At(i)
+		span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{id}))
+		span.SetName("GET /cart")
+		span.SetStartTime(1614587400000000000)
+		span.SetEndTime(1614587400100000000)
+		span.Attributes().InsertString("http.method", "GET")
+		span.Attributes().InsertInt("http.status_code", 200)
+		span.Events().Resize(1)
+		span.Events().At(0).SetName("retry")
+	}
+	return td
+}
+
+func spanIDs(td pdata.Traces) []byte {
+	var ids []byte
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				ids = append(ids, spans.At(k).SpanID().Bytes()[0])
+			}
+		}
+	}
+	return ids
+}
+
+func TestProcessTraces(t *testing.T) {
+	now := time.Unix(1614587400, 0)
+	p := newTestProcessor(&now)
+
+	td, err := p.ProcessTraces(context.Background(), newTestTraces(1, 2, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, spanIDs(td))
+
+	now = now.Add(30 * time.Second)
+	td, err = p.ProcessTraces(context.Background(), newTestTraces(2, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3}, spanIDs(td))
+
+	_, err = p.ProcessTraces(context.Background(), newTestTraces(1, 3))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+
+	// The spans first received before the window are no longer duplicates.
+	now = now.Add(40 * time.Second)
+	td, err = p.ProcessTraces(context.Background(), newTestTraces(1, 2, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, spanIDs(td))
+}
+
+func TestProcessTraces_Contents(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(td pdata.Traces)
+		duplicate bool
+	}{
+		{
+			name: "attributes in a different order",
+			modify: func(td pdata.Traces) {
+				span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+				span.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+					"http.status_code": pdata.NewAttributeValueInt(200),
+					"http.method":      pdata.NewAttributeValueString("GET"),
+				})
+			},
+			duplicate: true,
+		},
+		{
+			name: "different attribute type",
+			modify: func(td pdata.Traces) {
+				span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+				span.Attributes().UpdateString("http.status_code", "200")
+			},
+		},
+		{
+			name: "different end time",
+			modify: func(td pdata.Traces) {
+				span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+				span.SetEndTime(1614587400200000000)
+			},
+		},
+		{
+			name: "different event",
+			modify: func(td pdata.Traces) {
+				span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+				span.Events().At(0).SetName("timeout")
+			},
+		},
+		{
+			name: "different status",
+			modify: func(td pdata.Traces) {
+				span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+				span.Status().SetCode(pdata.StatusCodeError)
+			},
+		},
+		{
+			name: "different resource",
+			modify: func(td pdata.Traces) {
+				td.ResourceSpans().At(0).Resource().Attributes().UpsertString("service.name", "cart")
+			},
+		},
+		{
+			name: "different instrumentation library",
+			modify: func(td pdata.Traces) {
+				td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).InstrumentationLibrary().SetName("otelhttp")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1614587400, 0)
+			p := newTestProcessor(&now)
+			_, err := p.ProcessTraces(context.Background(), newTestTraces(1))
+			require.NoError(t, err)
+
+			td := newTestTraces(1)
+			tt.modify(td)
+			_, err = p.ProcessTraces(context.Background(), td)
+			if tt.duplicate {
+				assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
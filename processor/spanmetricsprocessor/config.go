@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Span Metrics processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// MetricsExporter is the name of the exporter the metrics derived from the spans are sent to,
+	// the exporter must be used by a metrics pipeline.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// LatencyHistogramBuckets are the upper bounds of the buckets of the latency histogram.
+	// If not set, the default buckets ranging from 2ms to 15s are used.
+	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
+
+	// Dimensions are the span or resource attributes added as labels to the metrics, in
+	// addition to the service name, operation, span kind and status code.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+}
+
+// Dimension is a span or resource attribute added as a label to the metrics.
+type Dimension struct {
+	// Name is the key of the attribute, the attributes of the span take precedence over
+	// the attributes of the resource.
+	Name string `mapstructure:"name"`
+
+	// Default is the value of the label when neither the span nor its resource have the
+	// attribute. If not set, the label is omitted.
+	Default *string `mapstructure:"default"`
+}
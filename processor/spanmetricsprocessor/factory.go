@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "spanmetrics"
+)
+
+var (
+	processorCapabilities = component.ProcessorCapabilities{MutatesConsumedData: false}
+
+	// defaultLatencyHistogramBuckets are the buckets of the latency histogram when
+	// they are not configured.
+	defaultLatencyHistogramBuckets = []time.Duration{
+		2 * time.Millisecond, 4 * time.Millisecond, 6 * time.Millisecond, 8 * time.Millisecond, 10 * time.Millisecond,
+		50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond,
+		time.Second, 1400 * time.Millisecond, 2 * time.Second, 5 * time.Second, 10 * time.Second, 15 * time.Second,
+	}
+
+	errMissingMetricsExporter = errors.New("missing required field \"metrics_exporter\"")
+)
+
+// NewFactory returns a new factory for the Span Metrics processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor))
+}
+
+// Note: This isn't a valid configuration because the processor has no metrics exporter.
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.TracesConsumer) (component.TracesProcessor, error) {
+	oCfg := cfg.(*Config)
+	if oCfg.MetricsExporter == "" {
+		return nil, errMissingMetricsExporter
+	}
+
+	sp := newSpanMetricsProcessor(params.Logger, oCfg)
+	return processorhelper.NewTraceProcessor(
+		cfg,
+		nextConsumer,
+		sp,
+		processorhelper.WithStart(sp.start),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	callsMetricName   = "calls_total"
+	errorsMetricName  = "errors_total"
+	latencyMetricName = "latency"
+
+	serviceNameLabel = conventions.AttributeServiceName
+	operationLabel   = "operation"
+	spanKindLabel    = "span.kind"
+	statusCodeLabel  = "status.code"
+)
+
+// label is a label of the metrics derived from the spans.
+type label struct {
+	name  string
+	value string
+}
+
+// series holds the cumulative metrics of the spans sharing the same labels.
+type series struct {
+	labels []label
+
+	calls  int64
+	errors int64
+
+	latencyCount        uint64
+	latencySum          float64
+	latencyBucketCounts []uint64
+}
+
+// spanMetricsProcessor aggregates the calls, errors and latencies of the spans by
+// their labels, and sends the cumulative metrics to the metrics exporter after
+// each batch of spans.
+type spanMetricsProcessor struct {
+	logger *zap.Logger
+	config *Config
+
+	// latencyBounds are the upper bounds of the latency histogram buckets, in milliseconds.
+	latencyBounds []float64
+	startTime     pdata.Timestamp
+
+	metricsExporter component.MetricsExporter
+
+	lock   sync.Mutex
+	series map[string]*series
+	// keys are the keys of the series, in the order they were first seen.
+	keys []string
+}
+
+func newSpanMetricsProcessor(logger *zap.Logger, config *Config) *spanMetricsProcessor {
+	buckets := config.LatencyHistogramBuckets
+	if len(buckets) == 0 {
+		buckets = defaultLatencyHistogramBuckets
+	}
+
+	latencyBounds := make([]float64, len(buckets))
+	for i, bucket := range buckets {
+		latencyBounds[i] = durationToMillis(bucket)
+	}
+	sort.Float64s(latencyBounds)
+
+	return &spanMetricsProcessor{
+		logger:        logger,
+		config:        config,
+		latencyBounds: latencyBounds,
+		startTime:     pdata.TimestampFromTime(time.Now()),
+		series:        map[string]*series{},
+	}
+}
+
+// start looks up the metrics exporter the metrics are sent to.
+func (p *spanMetricsProcessor) start(_ context.Context, host component.Host) error {
+	exporters := host.GetExporters()[configmodels.MetricsDataType]
+	for entity, exporter := range exporters {
+		if entity.Name() != p.config.MetricsExporter {
+			continue
+		}
+
+		metricsExporter, ok := exporter.(component.MetricsExporter)
+		if !ok {
+			return fmt.Errorf("exporter %q is not a metrics exporter", p.config.MetricsExporter)
+		}
+		p.metricsExporter = metricsExporter
+		return nil
+	}
+
+	return fmt.Errorf("metrics exporter %q not found, it must be used by a metrics pipeline", p.config.MetricsExporter)
+}
+
+// ProcessTraces aggregates the spans and sends the updated metrics to the
+// metrics exporter, the spans are passed unchanged to the next consumer.
+func (p *spanMetricsProcessor) ProcessTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
+	p.lock.Lock()
+	p.aggregate(td)
+	md := p.buildMetrics()
+	p.lock.Unlock()
+
+	if err := p.metricsExporter.ConsumeMetrics(ctx, md); err != nil {
+		// the spans are still passed to the next consumer when the metrics cannot be exported
+		p.logger.Warn("Failed to export the span metrics", zap.Error(err))
+	}
+	return td, nil
+}
+
+func (p *spanMetricsProcessor) aggregate(td pdata.Traces) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceAttr := rs.Resource().Attributes()
+		serviceName := ""
+		if attr, ok := resourceAttr.Get(conventions.AttributeServiceName); ok {
+			serviceName = attr.StringVal()
+		}
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				p.aggregateSpan(serviceName, resourceAttr, spans.At(k))
+			}
+		}
+	}
+}
+
+func (p *spanMetricsProcessor) aggregateSpan(serviceName string, resourceAttr pdata.AttributeMap, span pdata.Span) {
+	labels := p.spanLabels(serviceName, resourceAttr, span)
+	key := seriesKey(labels)
+
+	s, ok := p.series[key]
+	if !ok {
+		s = &series{labels: labels, latencyBucketCounts: make([]uint64, len(p.latencyBounds)+1)}
+		p.series[key] = s
+		p.keys = append(p.keys, key)
+	}
+
+	s.calls++
+	if span.Status().Code() == pdata.StatusCodeError {
+		s.errors++
+	}
+
+	var latency float64
+	if span.EndTime() > span.StartTime() {
+		latency = durationToMillis(time.Duration(span.EndTime() - span.StartTime()))
+	}
+	s.latencyCount++
+	s.latencySum += latency
+	// the buckets include their upper bound
+	s.latencyBucketCounts[sort.SearchFloat64s(p.latencyBounds, latency)]++
+}
+
+// spanLabels returns the labels of the metrics of the span, the configured
+// dimensions without a value nor a default value are omitted.
+func (p *spanMetricsProcessor) spanLabels(serviceName string, resourceAttr pdata.AttributeMap, span pdata.Span) []label {
+	labels := make([]label, 0, 4+len(p.config.Dimensions))
+	labels = append(labels,
+		label{name: serviceNameLabel, value: serviceName},
+		label{name: operationLabel, value: span.Name()},
+		label{name: spanKindLabel, value: span.Kind().String()},
+		label{name: statusCodeLabel, value: span.Status().Code().String()},
+	)
+
+	spanAttr := span.Attributes()
+	for _, dimension := range p.config.Dimensions {
+		if attr, ok := spanAttr.Get(dimension.Name); ok {
+			labels = append(labels, label{name: dimension.Name, value: tracetranslator.AttributeValueToString(attr, false)})
+		} else if attr, ok := resourceAttr.Get(dimension.Name); ok {
+			labels = append(labels, label{name: dimension.Name, value: tracetranslator.AttributeValueToString(attr, false)})
+		} else if dimension.Default != nil {
+			labels = append(labels, label{name: dimension.Name, value: *dimension.Default})
+		}
+	}
+	return labels
+}
+
+// seriesKey returns the key identifying the series with the given labels.
+func seriesKey(labels []label) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.name)
+		b.WriteByte(0)
+		b.WriteString(l.value)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// buildMetrics returns the cumulative metrics of all the series.
+func (p *spanMetricsProcessor) buildMetrics() pdata.Metrics {
+	md := pdata.NewMetrics()
+	rms := md.ResourceMetrics()
+	rms.Resize(1)
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+	metrics.Resize(3)
+
+	now := pdata.TimestampFromTime(time.Now())
+
+	callsMetric := metrics.At(0)
+	callsMetric.SetName(callsMetricName)
+	callsMetric.SetDescription("Number of spans.")
+	callsMetric.SetUnit("1")
+	callsMetric.SetDataType(pdata.MetricDataTypeIntSum)
+	callsMetric.IntSum().SetIsMonotonic(true)
+	callsMetric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	callsDps := callsMetric.IntSum().DataPoints()
+
+	errorsMetric := metrics.At(1)
+	errorsMetric.SetName(errorsMetricName)
+	errorsMetric.SetDescription("Number of spans with an error status.")
+	errorsMetric.SetUnit("1")
+	errorsMetric.SetDataType(pdata.MetricDataTypeIntSum)
+	errorsMetric.IntSum().SetIsMonotonic(true)
+	errorsMetric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	errorsDps := errorsMetric.IntSum().DataPoints()
+
+	latencyMetric := metrics.At(2)
+	latencyMetric.SetName(latencyMetricName)
+	latencyMetric.SetDescription("Duration of the spans.")
+	latencyMetric.SetUnit("ms")
+	latencyMetric.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	latencyMetric.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	latencyDps := latencyMetric.DoubleHistogram().DataPoints()
+
+	callsDps.Resize(len(p.keys))
+	latencyDps.Resize(len(p.keys))
+	for i, key := range p.keys {
+		s := p.series[key]
+
+		callsDp := callsDps.At(i)
+		initializeLabels(callsDp.LabelsMap(), s.labels)
+		callsDp.SetStartTime(p.startTime)
+		callsDp.SetTimestamp(now)
+		callsDp.SetValue(s.calls)
+
+		latencyDp := latencyDps.At(i)
+		initializeLabels(latencyDp.LabelsMap(), s.labels)
+		latencyDp.SetStartTime(p.startTime)
+		latencyDp.SetTimestamp(now)
+		latencyDp.SetCount(s.latencyCount)
+		latencyDp.SetSum(s.latencySum)
+		latencyDp.SetBucketCounts(append([]uint64(nil), s.latencyBucketCounts...))
+		latencyDp.SetExplicitBounds(p.latencyBounds)
+
+		if s.errors == 0 {
+			continue
+		}
+		errorsDps.Resize(errorsDps.Len() + 1)
+		errorsDp := errorsDps.At(errorsDps.Len() - 1)
+		initializeLabels(errorsDp.LabelsMap(), s.labels)
+		errorsDp.SetStartTime(p.startTime)
+		errorsDp.SetTimestamp(now)
+		errorsDp.SetValue(s.errors)
+	}
+
+	return md
+}
+
+func initializeLabels(labelsMap pdata.StringMap, labels []label) {
+	labelsMap.InitEmptyWithCapacity(len(labels))
+	for _, l := range labels {
+		labelsMap.Insert(l.name, l.value)
+	}
+}
+
+func durationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
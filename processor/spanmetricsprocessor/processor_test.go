@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// metricsExporter is a metrics exporter passing the metrics to a consumer.
+type metricsExporter struct {
+	component.Component
+	consumer.MetricsConsumer
+}
+
+func newMetricsExporter(next consumer.MetricsConsumer) component.MetricsExporter {
+	return &metricsExporter{
+		Component:       componenthelper.NewComponent(componenthelper.DefaultComponentSettings()),
+		MetricsConsumer: next,
+	}
+}
+
+// exportersHost is a host providing the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter
+}
+
+func newExportersHost(dataType configmodels.DataType, name string, exporter component.Exporter) component.Host {
+	return &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter{
+			dataType: {&configmodels.ExporterSettings{TypeVal: "exampleexporter", NameVal: name}: exporter},
+		},
+	}
+}
+
+func (h *exportersHost) GetExporters() map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter {
+	return h.exporters
+}
+
+func TestStart(t *testing.T) {
+	type testCase struct {
+		name          string
+		host          component.Host
+		expectedError string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Metrics Exporter",
+			host: newExportersHost(configmodels.MetricsDataType, "exampleexporter", newMetricsExporter(consumertest.NewMetricsNop())),
+		},
+		{
+			name:          "Missing Exporter",
+			host:          newExportersHost(configmodels.MetricsDataType, "otherexporter", newMetricsExporter(consumertest.NewMetricsNop())),
+			expectedError: `metrics exporter "exampleexporter" not found, it must be used by a metrics pipeline`,
+		},
+		{
+			name:          "Traces Exporter",
+			host:          newExportersHost(configmodels.TracesDataType, "exampleexporter", newMetricsExporter(consumertest.NewMetricsNop())),
+			expectedError: `metrics exporter "exampleexporter" not found, it must be used by a metrics pipeline`,
+		},
+		{
+			name:          "Not Metrics Exporter",
+			host:          newExportersHost(configmodels.MetricsDataType, "exampleexporter", componenthelper.NewComponent(componenthelper.DefaultComponentSettings())),
+			expectedError: `exporter "exampleexporter" is not a metrics exporter`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			p := newSpanMetricsProcessor(zap.NewNop(), &Config{MetricsExporter: "exampleexporter"})
+			err := p.start(context.Background(), test.host)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProcessTraces(t *testing.T) {
+	defaultEnvironment := "production"
+	cfg := &Config{
+		MetricsExporter:         "exampleexporter",
+		LatencyHistogramBuckets: []time.Duration{100 * time.Millisecond, 10 * time.Millisecond},
+		Dimensions: []Dimension{
+			{Name: "http.method"},
+			{Name: "deployment.environment", Default: &defaultEnvironment},
+		},
+	}
+
+	p := newSpanMetricsProcessor(zap.NewNop(), cfg)
+	sink := new(consumertest.MetricsSink)
+	require.NoError(t, p.start(context.Background(), newExportersHost(configmodels.MetricsDataType, "exampleexporter", newMetricsExporter(sink))))
+
+	td := newTraces("checkout", map[string]string{"deployment.environment": "staging"}, []testSpan{
+		{name: "GET /cart", duration: 5 * time.Millisecond, attributes: map[string]string{"http.method": "GET"}},
+		{name: "GET /cart", duration: 10 * time.Millisecond, attributes: map[string]string{"http.method": "GET"}},
+		{name: "GET /cart", duration: 50 * time.Millisecond, status: pdata.StatusCodeError, attributes: map[string]string{"http.method": "GET"}},
+	})
+	otherTd := newTraces("payment", nil, []testSpan{
+		{name: "charge", duration: time.Second, status: pdata.StatusCodeError},
+	})
+	otherTd.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+
+	processed, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, td, processed)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+
+	checkoutOK := map[string]string{
+		"service.name":           "checkout",
+		"operation":              "GET /cart",
+		"span.kind":              "SPAN_KIND_SERVER",
+		"status.code":            "STATUS_CODE_UNSET",
+		"http.method":            "GET",
+		"deployment.environment": "staging",
+	}
+	checkoutError := map[string]string{
+		"service.name":           "checkout",
+		"operation":              "GET /cart",
+		"span.kind":              "SPAN_KIND_SERVER",
+		"status.code":            "STATUS_CODE_ERROR",
+		"http.method":            "GET",
+		"deployment.environment": "staging",
+	}
+	paymentError := map[string]string{
+		"service.name":           "payment",
+		"operation":              "charge",
+		"span.kind":              "SPAN_KIND_SERVER",
+		"status.code":            "STATUS_CODE_ERROR",
+		"deployment.environment": "production",
+	}
+
+	calls := metrics.At(0)
+	assert.Equal(t, "calls_total", calls.Name())
+	callsDps := calls.IntSum().DataPoints()
+	require.Equal(t, 3, callsDps.Len())
+	assertLabels(t, checkoutOK, callsDps.At(0).LabelsMap())
+	assert.EqualValues(t, 2, callsDps.At(0).Value())
+	assertLabels(t, checkoutError, callsDps.At(1).LabelsMap())
+	assert.EqualValues(t, 1, callsDps.At(1).Value())
+	assertLabels(t, paymentError, callsDps.At(2).LabelsMap())
+	assert.EqualValues(t, 1, callsDps.At(2).Value())
+
+	errs := metrics.At(1)
+	assert.Equal(t, "errors_total", errs.Name())
+	errsDps := errs.IntSum().DataPoints()
+	require.Equal(t, 2, errsDps.Len())
+	assertLabels(t, checkoutError, errsDps.At(0).LabelsMap())
+	assert.EqualValues(t, 1, errsDps.At(0).Value())
+	assertLabels(t, paymentError, errsDps.At(1).LabelsMap())
+	assert.EqualValues(t, 1, errsDps.At(1).Value())
+
+	latency := metrics.At(2)
+	assert.Equal(t, "latency", latency.Name())
+	latencyDps := latency.DoubleHistogram().DataPoints()
+	require.Equal(t, 3, latencyDps.Len())
+	assertLabels(t, checkoutOK, latencyDps.At(0).LabelsMap())
+	assert.Equal(t, []float64{10, 100}, latencyDps.At(0).ExplicitBounds())
+	assert.Equal(t, []uint64{2, 0, 0}, latencyDps.At(0).BucketCounts())
+	assert.EqualValues(t, 2, latencyDps.At(0).Count())
+	assert.Equal(t, 15.0, latencyDps.At(0).Sum())
+	assert.Equal(t, []uint64{0, 1, 0}, latencyDps.At(1).BucketCounts())
+	assert.Equal(t, []uint64{0, 0, 1}, latencyDps.At(2).BucketCounts())
+
+	// the metrics are cumulative
+	_, err = p.ProcessTraces(context.Background(), newTraces("payment", nil, []testSpan{
+		{name: "charge", duration: time.Millisecond},
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, sink.AllMetrics(), 2)
+	metrics = sink.AllMetrics()[1].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	callsDps = metrics.At(0).IntSum().DataPoints()
+	require.Equal(t, 4, callsDps.Len())
+	assert.EqualValues(t, 2, callsDps.At(0).Value())
+	assert.EqualValues(t, 1, callsDps.At(3).Value())
+	assert.Equal(t, 2, metrics.At(1).IntSum().DataPoints().Len())
+	assert.Equal(t, []uint64{2, 0, 0}, metrics.At(2).DoubleHistogram().DataPoints().At(0).BucketCounts())
+}
+
+func TestProcessTraces_ExportError(t *testing.T) {
+	p := newSpanMetricsProcessor(zap.NewNop(), &Config{MetricsExporter: "exampleexporter"})
+	exporter := newMetricsExporter(consumertest.NewMetricsErr(errors.New("err1")))
+	require.NoError(t, p.start(context.Background(), newExportersHost(configmodels.MetricsDataType, "exampleexporter", exporter)))
+
+	// the spans are passed to the next consumer even if the metrics cannot be exported
+	td := newTraces("checkout", nil, []testSpan{{name: "GET /cart", duration: time.Millisecond}})
+	processed, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, td, processed)
+}
+
+type testSpan struct {
+	name       string
+	duration   time.Duration
+	status     pdata.StatusCode
+	attributes map[string]string
+}
+
+func newTraces(serviceName string, resourceAttributes map[string]string, spans []testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	rss := td.ResourceSpans()
+	rss.Resize(1)
+	rs := rss.At(0)
+
+	resourceAttr := rs.Resource().Attributes()
+	resourceAttr.InsertString(conventions.AttributeServiceName, serviceName)
+	for k, v := range resourceAttributes {
+		resourceAttr.InsertString(k, v)
+	}
+
+	ilss := rs.InstrumentationLibrarySpans()
+	ilss.Resize(1)
+	ilsSpans := ilss.At(0).Spans()
+	ilsSpans.Resize(len(spans))
+
+	start := time.Date(2021, 2, 1, 10, 0, 0, 0, time.UTC)
+	for i, s := range spans {
+		span := ilsSpans.At(i)
+		span.SetName(s.name)
+		span.SetKind(pdata.SpanKindSERVER)
+		span.SetStartTime(pdata.TimestampFromTime(start))
+		span.SetEndTime(pdata.TimestampFromTime(start.Add(s.duration)))
+		span.Status().SetCode(s.status)
+		for k, v := range s.attributes {
+			span.Attributes().InsertString(k, v)
+		}
+	}
+
+	return td
+}
+
+func assertLabels(t *testing.T, expected map[string]string, labelsMap pdata.StringMap) {
+	labels := map[string]string{}
+	labelsMap.ForEach(func(k string, v string) {
+		labels[k] = v
+	})
+	assert.Equal(t, expected, labels)
+}
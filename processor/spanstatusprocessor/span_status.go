@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstatusprocessor
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+type spanStatusProcessor struct {
+	errorAttribute string
+}
+
+func newSpanStatusProcessor(cfg *Config) *spanStatusProcessor {
+	return &spanStatusProcessor{errorAttribute: cfg.ErrorAttribute}
+}
+
+// ProcessTraces sets the status of the spans whose status is unset.
+func (ssp *spanStatusProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				ssp.processSpan(spans.At(k))
+			}
+		}
+	}
+	return td, nil
+}
+
+func (ssp *spanStatusProcessor) processSpan(span pdata.Span) {
+	if span.Status().Code() != pdata.StatusCodeUnset || !isError(span) {
+		return
+	}
+	span.Status().SetCode(pdata.StatusCodeError)
+	if ssp.errorAttribute != "" {
+		span.Attributes().UpsertBool(ssp.errorAttribute, true)
+	}
+}
+
+// isError returns whether the status codes of the span attributes are errors.
+// Any gRPC status code other than OK is an error. The HTTP status codes of the
+// 5xx class and outside of the valid range are errors, and the ones of the 4xx
+// class are errors except for the server spans. The gRPC status code takes
+// precedence over the HTTP status code.
+func isError(span pdata.Span) bool {
+	attrs := span.Attributes()
+	if code, ok := intAttribute(attrs, conventions.AttributeRPCGRPCStatusCode); ok {
+		return code != 0
+	}
+	if code, ok := intAttribute(attrs, conventions.AttributeHTTPStatusCode); ok {
+		switch {
+		case code < 100 || code >= 500:
+			return true
+		case code >= 400:
+			return span.Kind() != pdata.SpanKindSERVER
+		}
+	}
+	return false
+}
+
+// intAttribute returns the integer value of the attribute, which may have been
+// converted to a string or a double by some instrumentations or translations.
+func intAttribute(attrs pdata.AttributeMap, key string) (int64, bool) {
+	v, ok := attrs.Get(key)
+	if !ok {
+		return 0, false
+	}
+	switch v.Type() {
+	case pdata.AttributeValueINT:
+		return v.IntVal(), true
+	case pdata.AttributeValueDOUBLE:
+		return int64(v.DoubleVal()), true
+	case pdata.AttributeValueSTRING:
+		if code, err := strconv.ParseInt(v.StringVal(), 10, 64); err == nil {
+			return code, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstatusprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestProcessTraces(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       pdata.SpanKind
+		status     pdata.StatusCode
+		attributes map[string]pdata.AttributeValue
+		want       pdata.StatusCode
+	}{
+		{
+			name: "no_status_code",
+			kind: pdata.SpanKindCLIENT,
+			want: pdata.StatusCodeUnset,
+		},
+		{
+			name:       "http_ok",
+			kind:       pdata.SpanKindCLIENT,
+			attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(200)},
+			want:       pdata.StatusCodeUnset,
+		},
+		{
+			name:       "http_client_error_client_span",
+			kind:       pdata.SpanKindCLIENT,
+			attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(404)},
+			want:       pdata.StatusCodeError,
+		},
+		{
+			name:       "http_client_error_server_span",
+			kind:       pdata.SpanKindSERVER,
+			attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(404)},
+			want:       pdata.StatusCodeUnset,
+		},
+		{
+			name:       "http_server_error",
+			kind:       pdata.SpanKindSERVER,
+			attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(503)},
+			want:       pdata.StatusCodeError,
+		},
+		{
+			name:       "http_invalid",
+			kind:       pdata.SpanKindSERVER,
+			attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(42)},
+			want:       pdata.StatusCodeError,
+		},
+		{
+			name:       "http_string",
+			kind:       pdata.SpanKindSERVER,
+			attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueString("500")},
+			want:       pdata.StatusCodeError,
+		},
+		{
+			name:       "http_double",
+			kind:       pdata.SpanKindSERVER,
+			attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueDouble(502)},
+			want:       pdata.StatusCodeError,
+		},
+		{
+			name:       "http_not_a_number",
+			kind:       pdata.SpanKindSERVER,
+			attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueString("error")},
+			want:       pdata.StatusCodeUnset,
+		},
+		{
+			name:       "http_status_set",
+			kind:       pdata.SpanKindSERVER,
+			status:     pdata.StatusCodeOk,
+			attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(500)},
+			want:       pdata.StatusCodeOk,
+		},
+		{
+			name:       "grpc_ok",
+			kind:       pdata.SpanKindSERVER,
+			attributes: map[string]pdata.AttributeValue{"rpc.grpc.status_code": pdata.NewAttributeValueInt(0)},
+			want:       pdata.StatusCodeUnset,
+		},
+		{
+			name:       "grpc_error",
+			kind:       pdata.SpanKindSERVER,
+			attributes: map[string]pdata.AttributeValue{"rpc.grpc.status_code": pdata.NewAttributeValueInt(14)},
+			want:       pdata.StatusCodeError,
+		},
+		{
+			name: "grpc_precedence",
+			kind: pdata.SpanKindCLIENT,
+			attributes: map[string]pdata.AttributeValue{
+				"rpc.grpc.status_code": pdata.NewAttributeValueInt(0),
+				"http.status_code":     pdata.NewAttributeValueInt(500),
+			},
+			want: pdata.StatusCodeUnset,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newTraces(tt.kind, tt.status, tt.attributes)
+			ssp := newSpanStatusProcessor(&Config{ErrorAttribute: "error"})
+			td, err := ssp.ProcessTraces(context.Background(), td)
+			require.NoError(t, err)
+
+			span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.want, span.Status().Code())
+			errorAttr, ok := span.Attributes().Get("error")
+			if tt.want == pdata.StatusCodeError {
+				require.True(t, ok)
+				assert.True(t, errorAttr.BoolVal())
+			} else {
+				assert.False(t, ok)
+			}
+		})
+	}
+}
+
+func TestProcessTraces_NoErrorAttribute(t *testing.T) {
+	td := newTraces(pdata.SpanKindSERVER, pdata.StatusCodeUnset, map[string]pdata.AttributeValue{
+		"http.status_code": pdata.NewAttributeValueInt(500),
+	})
+	td, err := newSpanStatusProcessor(&Config{}).ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, 1, span.Attributes().Len())
+}
+
+func newTraces(kind pdata.SpanKind, status pdata.StatusCode, attributes map[string]pdata.AttributeValue) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	td.ResourceSpans().At(0).InstrumentationLibrarySpans().Resize(1)
+	spans := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(1)
+	spans.At(0).SetKind(kind)
+	spans.At(0).Status().SetCode(status)
+	spans.At(0).Attributes().InitFromMap(attributes)
+	return td
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Transform processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Traces are the statements applied to each span.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// Metrics are the statements applied to each data point.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Logs are the statements applied to each log record.
+	Logs SignalConfig `mapstructure:"logs"`
+}
+
+// SignalConfig defines the statements applied to the telemetry items of a signal.
+type SignalConfig struct {
+	// Statements are applied in order, e.g.
+	//   set(attributes["environment"], "production") where resource.attributes["service.name"] == "checkout"
+	Statements []string `mapstructure:"statements"`
+}
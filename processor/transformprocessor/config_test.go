@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Processors["transform"])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "transform",
+			NameVal: "transform/shaping",
+		},
+		Traces: SignalConfig{
+			Statements: []string{
+				`set(status.code, 1) where attributes["http.path"] == "/health"`,
+				`replace_pattern(name, "^/users/[0-9]+$", "/users/{id}")`,
+				`delete(attributes, "http.user_agent", "net.peer.ip")`,
+				`limit(attributes, 64)`,
+			},
+		},
+		Metrics: SignalConfig{
+			Statements: []string{
+				`set(labels["environment"], resource.attributes["deployment.environment"])`,
+				`delete(labels["container.id"]) where metric.name == "http.server.duration"`,
+			},
+		},
+		Logs: SignalConfig{
+			Statements: []string{
+				`set(severity_text, "WARN") where severity_number == 13`,
+				`replace_pattern(body, "password=\\S+", "password=***")`,
+			},
+		},
+	}, cfg.Processors["transform/shaping"])
+}
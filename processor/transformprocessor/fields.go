@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// getter returns the value of a field of the telemetry item held by the context.
+type getter func(ctx interface{}) interface{}
+
+// setter sets the value of a field of the telemetry item held by the context,
+// values of an unexpected type are ignored.
+type setter func(ctx interface{}, val interface{})
+
+type getSetter struct {
+	get getter
+	set setter
+}
+
+// pathResolver resolves the path to a field of the telemetry items of a signal.
+type pathResolver func(p *fieldPath) (getSetter, error)
+
+// telemetryContext is implemented by the contexts of all the signals, which
+// all give access to the resource and the instrumentation library.
+type telemetryContext interface {
+	getResource() pdata.Resource
+	getInstrumentationLibrary() pdata.InstrumentationLibrary
+}
+
+func unsupportedPath(p *fieldPath) error {
+	return fmt.Errorf("unsupported path %q", p.String())
+}
+
+// resolveCommonPath resolves the resource and instrumentation library paths,
+// false is returned if the path does not refer to either of them.
+func resolveCommonPath(p *fieldPath) (getSetter, bool, error) {
+	switch p.fields[0] {
+	case "resource":
+		if len(p.fields) != 2 || p.fields[1] != "attributes" {
+			return getSetter{}, true, unsupportedPath(p)
+		}
+		return attributesGetSetter(func(ctx interface{}) pdata.AttributeMap {
+			return ctx.(telemetryContext).getResource().Attributes()
+		}, p.key), true, nil
+	case "instrumentation_library":
+		if len(p.fields) != 2 || p.key != nil {
+			return getSetter{}, true, unsupportedPath(p)
+		}
+		switch p.fields[1] {
+		case "name":
+			return stringGetSetter(
+				func(ctx interface{}) string { return ctx.(telemetryContext).getInstrumentationLibrary().Name() },
+				func(ctx interface{}, s string) { ctx.(telemetryContext).getInstrumentationLibrary().SetName(s) },
+			), true, nil
+		case "version":
+			return stringGetSetter(
+				func(ctx interface{}) string { return ctx.(telemetryContext).getInstrumentationLibrary().Version() },
+				func(ctx interface{}, s string) { ctx.(telemetryContext).getInstrumentationLibrary().SetVersion(s) },
+			), true, nil
+		}
+		return getSetter{}, true, unsupportedPath(p)
+	}
+	return getSetter{}, false, nil
+}
+
+func stringGetSetter(get func(ctx interface{}) string, set func(ctx interface{}, s string)) getSetter {
+	return getSetter{
+		get: func(ctx interface{}) interface{} {
+			return get(ctx)
+		},
+		set: func(ctx interface{}, val interface{}) {
+			if s, ok := val.(string); ok {
+				set(ctx, s)
+			}
+		},
+	}
+}
+
+func intGetSetter(get func(ctx interface{}) int64, set func(ctx interface{}, i int64)) getSetter {
+	return getSetter{
+		get: func(ctx interface{}) interface{} {
+			return get(ctx)
+		},
+		set: func(ctx interface{}, val interface{}) {
+			if i, ok := val.(int64); ok {
+				set(ctx, i)
+			}
+		},
+	}
+}
+
+// attributesGetSetter gives access to an attribute map, or to one of its
+// attributes when the key is set.
+func attributesGetSetter(attributes func(ctx interface{}) pdata.AttributeMap, key *string) getSetter {
+	if key == nil {
+		return getSetter{
+			get: func(ctx interface{}) interface{} {
+				return attributes(ctx)
+			},
+			set: func(ctx interface{}, val interface{}) {
+				if m, ok := val.(pdata.AttributeMap); ok {
+					m.CopyTo(attributes(ctx))
+				}
+			},
+		}
+	}
+
+	return getSetter{
+		get: func(ctx interface{}) interface{} {
+			v, ok := attributes(ctx).Get(*key)
+			if !ok {
+				return nil
+			}
+			return attributeValueToInterface(v)
+		},
+		set: func(ctx interface{}, val interface{}) {
+			upsertAttribute(attributes(ctx), *key, val)
+		},
+	}
+}
+
+// labelsGetSetter gives access to the labels of a data point, or to one of
+// them when the key is set. The values set to a label are converted to strings.
+func labelsGetSetter(labels func(ctx interface{}) pdata.StringMap, key *string) getSetter {
+	if key == nil {
+		return getSetter{
+			get: func(ctx interface{}) interface{} {
+				return labels(ctx)
+			},
+			set: func(ctx interface{}, val interface{}) {
+				if m, ok := val.(pdata.StringMap); ok {
+					m.CopyTo(labels(ctx))
+				}
+			},
+		}
+	}
+
+	return getSetter{
+		get: func(ctx interface{}) interface{} {
+			v, ok := labels(ctx).Get(*key)
+			if !ok {
+				return nil
+			}
+			return v
+		},
+		set: func(ctx interface{}, val interface{}) {
+			if s, ok := valueToString(val); ok {
+				labels(ctx).Upsert(*key, s)
+			}
+		},
+	}
+}
+
+// attributeValueToInterface converts the scalar attribute values to the Go
+// types used by the literals of the statements.
+func attributeValueToInterface(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return v.IntVal()
+	case pdata.AttributeValueDOUBLE:
+		return v.DoubleVal()
+	case pdata.AttributeValueBOOL:
+		return v.BoolVal()
+	case pdata.AttributeValueNULL:
+		return nil
+	}
+	return v
+}
+
+func upsertAttribute(attributes pdata.AttributeMap, key string, val interface{}) {
+	switch v := val.(type) {
+	case string:
+		attributes.UpsertString(key, v)
+	case int64:
+		attributes.UpsertInt(key, v)
+	case float64:
+		attributes.UpsertDouble(key, v)
+	case bool:
+		attributes.UpsertBool(key, v)
+	case pdata.AttributeValue:
+		attributes.Upsert(key, v)
+	}
+}
+
+func valueToString(val interface{}) (string, bool) {
+	switch v := val.(type) {
+	case string:
+		return v, true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// exprFunc applies a function to the telemetry item held by the context.
+type exprFunc func(ctx interface{})
+
+// functionFactory validates the arguments of a function and creates it.
+type functionFactory func(args []value, resolve pathResolver) (exprFunc, error)
+
+var functions = map[string]functionFactory{
+	"set":             newSetFunc,
+	"delete":          newDeleteFunc,
+	"replace_pattern": newReplacePatternFunc,
+	"limit":           newLimitFunc,
+}
+
+// newSetFunc creates set(target, value), setting the target field to the value.
+func newSetFunc(args []value, resolve pathResolver) (exprFunc, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("set expects 2 arguments, got %d", len(args))
+	}
+	target, err := pathArgument(args[0], resolve)
+	if err != nil {
+		return nil, err
+	}
+	val, err := valueGetter(args[1], resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx interface{}) {
+		target.set(ctx, val(ctx))
+	}, nil
+}
+
+// newDeleteFunc creates delete(map, key...), deleting the keys from the map of
+// attributes or labels, or delete(map["key"]) deleting a single key.
+func newDeleteFunc(args []value, resolve pathResolver) (exprFunc, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("delete expects at least 1 argument")
+	}
+	if args[0].path == nil {
+		return nil, fmt.Errorf("the first argument of delete must be a path")
+	}
+
+	mapPath := *args[0].path
+	var keys []string
+	if mapPath.key != nil {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("delete expects 1 argument when deleting %q", mapPath.String())
+		}
+		keys = []string{*mapPath.key}
+		mapPath.key = nil
+	} else {
+		if len(args) == 1 {
+			return nil, fmt.Errorf("delete expects the keys to delete from %q", mapPath.String())
+		}
+		for _, arg := range args[1:] {
+			key, ok := arg.literal.(string)
+			if !ok {
+				return nil, fmt.Errorf("the keys to delete must be strings")
+			}
+			keys = append(keys, key)
+		}
+	}
+
+	target, err := resolve(&mapPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx interface{}) {
+		switch m := target.get(ctx).(type) {
+		case pdata.AttributeMap:
+			for _, key := range keys {
+				m.Delete(key)
+			}
+		case pdata.StringMap:
+			for _, key := range keys {
+				m.Delete(key)
+			}
+		}
+	}, nil
+}
+
+// newReplacePatternFunc creates replace_pattern(target, pattern, replacement),
+// replacing the matches of the regular expression in the target string field.
+// The replacement can refer to the submatches with $1, ${name}...
+func newReplacePatternFunc(args []value, resolve pathResolver) (exprFunc, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("replace_pattern expects 3 arguments, got %d", len(args))
+	}
+	target, err := pathArgument(args[0], resolve)
+	if err != nil {
+		return nil, err
+	}
+	pattern, ok := args[1].literal.(string)
+	if !ok {
+		return nil, fmt.Errorf("the pattern of replace_pattern must be a string")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern of replace_pattern: %w", err)
+	}
+	replacement, ok := args[2].literal.(string)
+	if !ok {
+		return nil, fmt.Errorf("the replacement of replace_pattern must be a string")
+	}
+
+	return func(ctx interface{}) {
+		if s, ok := target.get(ctx).(string); ok {
+			target.set(ctx, re.ReplaceAllString(s, replacement))
+		}
+	}, nil
+}
+
+// newLimitFunc creates limit(map, n), keeping the first n entries of the map of
+// attributes or labels.
+func newLimitFunc(args []value, resolve pathResolver) (exprFunc, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("limit expects 2 arguments, got %d", len(args))
+	}
+	target, err := pathArgument(args[0], resolve)
+	if err != nil {
+		return nil, err
+	}
+	limit, ok := args[1].literal.(int64)
+	if !ok || limit < 0 {
+		return nil, fmt.Errorf("the limit must be a positive integer")
+	}
+
+	return func(ctx interface{}) {
+		var dropped []string
+		i := int64(0)
+		switch m := target.get(ctx).(type) {
+		case pdata.AttributeMap:
+			m.ForEach(func(k string, _ pdata.AttributeValue) {
+				if i >= limit {
+					dropped = append(dropped, k)
+				}
+				i++
+			})
+			for _, k := range dropped {
+				m.Delete(k)
+			}
+		case pdata.StringMap:
+			m.ForEach(func(k string, _ string) {
+				if i >= limit {
+					dropped = append(dropped, k)
+				}
+				i++
+			})
+			for _, k := range dropped {
+				m.Delete(k)
+			}
+		}
+	}, nil
+}
+
+func pathArgument(arg value, resolve pathResolver) (getSetter, error) {
+	if arg.path == nil {
+		return getSetter{}, fmt.Errorf("expected a path, got %v", arg.literal)
+	}
+	return resolve(arg.path)
+}
+
+func valueGetter(arg value, resolve pathResolver) (getter, error) {
+	if arg.path == nil {
+		literal := arg.literal
+		return func(interface{}) interface{} { return literal }, nil
+	}
+	gs, err := resolve(arg.path)
+	if err != nil {
+		return nil, err
+	}
+	return gs.get, nil
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type logContext struct {
+	log      pdata.LogRecord
+	library  pdata.InstrumentationLibrary
+	resource pdata.Resource
+}
+
+func (ctx logContext) getResource() pdata.Resource {
+	return ctx.resource
+}
+
+func (ctx logContext) getInstrumentationLibrary() pdata.InstrumentationLibrary {
+	return ctx.library
+}
+
+// resolveLogPath resolves the paths to the fields of the log records: name,
+// body, severity_text, severity_number, attributes, and the common resource
+// and instrumentation library paths.
+func resolveLogPath(p *fieldPath) (getSetter, error) {
+	if gs, ok, err := resolveCommonPath(p); ok {
+		return gs, err
+	}
+
+	if p.fields[0] == "attributes" && len(p.fields) == 1 {
+		return attributesGetSetter(func(ctx interface{}) pdata.AttributeMap {
+			return ctx.(logContext).log.Attributes()
+		}, p.key), nil
+	}
+	if p.key != nil {
+		return getSetter{}, unsupportedPath(p)
+	}
+
+	switch p.String() {
+	case "name":
+		return stringGetSetter(
+			func(ctx interface{}) string { return ctx.(logContext).log.Name() },
+			func(ctx interface{}, s string) { ctx.(logContext).log.SetName(s) },
+		), nil
+	case "body":
+		return getSetter{
+			get: func(ctx interface{}) interface{} {
+				return attributeValueToInterface(ctx.(logContext).log.Body())
+			},
+			set: func(ctx interface{}, val interface{}) {
+				body := ctx.(logContext).log.Body()
+				switch v := val.(type) {
+				case string:
+					body.SetStringVal(v)
+				case int64:
+					body.SetIntVal(v)
+				case float64:
+					body.SetDoubleVal(v)
+				case bool:
+					body.SetBoolVal(v)
+				}
+			},
+		}, nil
+	case "severity_text":
+		return stringGetSetter(
+			func(ctx interface{}) string { return ctx.(logContext).log.SeverityText() },
+			func(ctx interface{}, s string) { ctx.(logContext).log.SetSeverityText(s) },
+		), nil
+	case "severity_number":
+		return intGetSetter(
+			func(ctx interface{}) int64 { return int64(ctx.(logContext).log.SeverityNumber()) },
+			func(ctx interface{}, i int64) { ctx.(logContext).log.SetSeverityNumber(pdata.SeverityNumber(i)) },
+		), nil
+	}
+	return getSetter{}, unsupportedPath(p)
+}
+
+// ProcessLogs applies the statements to each log record.
+func (tp *transformProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				tp.apply(logContext{
+					log:      logs.At(k),
+					library:  ill.InstrumentationLibrary(),
+					resource: rl.Resource(),
+				})
+			}
+		}
+	}
+	return ld, nil
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/datapoint"
+)
+
+type dataPointContext struct {
+	metric   pdata.Metric
+	labels   pdata.StringMap
+	library  pdata.InstrumentationLibrary
+	resource pdata.Resource
+}
+
+func (ctx dataPointContext) getResource() pdata.Resource {
+	return ctx.resource
+}
+
+func (ctx dataPointContext) getInstrumentationLibrary() pdata.InstrumentationLibrary {
+	return ctx.library
+}
+
+// resolveDataPointPath resolves the paths to the fields of the metric data
+// points: labels, metric.name, metric.description, metric.unit, and the common
+// resource and instrumentation library paths.
+func resolveDataPointPath(p *fieldPath) (getSetter, error) {
+	if gs, ok, err := resolveCommonPath(p); ok {
+		return gs, err
+	}
+
+	if p.fields[0] == "labels" && len(p.fields) == 1 {
+		return labelsGetSetter(func(ctx interface{}) pdata.StringMap {
+			return ctx.(dataPointContext).labels
+		}, p.key), nil
+	}
+	if p.key != nil {
+		return getSetter{}, unsupportedPath(p)
+	}
+
+	switch p.String() {
+	case "metric.name":
+		return stringGetSetter(
+			func(ctx interface{}) string { return ctx.(dataPointContext).metric.Name() },
+			func(ctx interface{}, s string) { ctx.(dataPointContext).metric.SetName(s) },
+		), nil
+	case "metric.description":
+		return stringGetSetter(
+			func(ctx interface{}) string { return ctx.(dataPointContext).metric.Description() },
+			func(ctx interface{}, s string) { ctx.(dataPointContext).metric.SetDescription(s) },
+		), nil
+	case "metric.unit":
+		return stringGetSetter(
+			func(ctx interface{}) string { return ctx.(dataPointContext).metric.Unit() },
+			func(ctx interface{}, s string) { ctx.(dataPointContext).metric.SetUnit(s) },
+		), nil
+	}
+	return getSetter{}, unsupportedPath(p)
+}
+
+// ProcessMetrics applies the statements to each data point.
+func (tp *transformProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				datapoint.ForEachLabels(metric, func(labels pdata.StringMap) {
+					tp.apply(dataPointContext{
+						metric:   metric,
+						labels:   labels,
+						library:  ilm.InstrumentationLibrary(),
+						resource: rm.Resource(),
+					})
+				})
+			}
+		}
+	}
+	return md, nil
+}
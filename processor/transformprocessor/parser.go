@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// statement is the parsed form of a statement such as
+//
+//	set(attributes["environment"], "production") where resource.attributes["service.name"] == "checkout"
+type statement struct {
+	invocation invocation
+	// conditions are the comparisons of the where clause, all of them must be
+	// true for the function to be invoked.
+	conditions []condition
+}
+
+// invocation is a call of a function with its arguments.
+type invocation struct {
+	function  string
+	arguments []value
+}
+
+// condition is a comparison of two values with the == or != operator.
+type condition struct {
+	left     value
+	operator string
+	right    value
+}
+
+// value is either a literal or a path to a field of the telemetry.
+type value struct {
+	literal interface{}
+	path    *fieldPath
+}
+
+// fieldPath is a dot separated list of field names, optionally followed by a map
+// key, e.g. resource.attributes["service.name"].
+type fieldPath struct {
+	fields []string
+	key    *string
+}
+
+func (p *fieldPath) String() string {
+	s := strings.Join(p.fields, ".")
+	if p.key != nil {
+		s += "[" + strconv.Quote(*p.key) + "]"
+	}
+	return s
+}
+
+const (
+	tokenIdentifier = iota
+	tokenString
+	tokenNumber
+	tokenPunctuation
+	tokenOperator
+)
+
+type token struct {
+	kind int
+	text string
+}
+
+// tokenize splits a statement into identifiers, quoted strings, numbers,
+// punctuation (. , ( ) [ ]) and comparison operators.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string starting at offset %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(runes[i : j+1])})
+			i = j + 1
+		case r == '=' || r == '!':
+			if i+1 >= len(runes) || runes[i+1] != '=' {
+				return nil, fmt.Errorf("unexpected character %q at offset %d", r, i)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: string(runes[i : i+2])})
+			i += 2
+		case strings.ContainsRune(".,()[]", r):
+			tokens = append(tokens, token{kind: tokenPunctuation, text: string(r)})
+			i++
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for ; j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.'); j++ {
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for ; j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_'); j++ {
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: string(runes[i:j])})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", r, i)
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser of the statements.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseStatement parses a statement of the grammar
+//
+//	statement  = invocation [ "where" condition { "and" condition } ]
+//	invocation = identifier "(" [ value { "," value } ] ")"
+//	condition  = value ( "==" | "!=" ) value
+//	value      = string | number | "true" | "false" | "nil" | path
+//	path       = identifier { "." identifier } [ "[" string "]" ]
+func parseStatement(input string) (*statement, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	st := &statement{}
+	if st.invocation, err = p.parseInvocation(); err != nil {
+		return nil, err
+	}
+
+	if p.peekIdentifier("where") {
+		p.pos++
+		for {
+			cond, err := p.parseCondition()
+			if err != nil {
+				return nil, err
+			}
+			st.conditions = append(st.conditions, cond)
+			if !p.peekIdentifier("and") {
+				break
+			}
+			p.pos++
+		}
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q after the end of the statement", p.tokens[p.pos].text)
+	}
+	return st, nil
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *parser) peekIdentifier(text string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokenIdentifier && t.text == text
+}
+
+func (p *parser) peekPunctuation(text string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokenPunctuation && t.text == text
+}
+
+func (p *parser) expect(kind int, text string) (token, error) {
+	t := p.peek()
+	if t == nil {
+		return token{}, fmt.Errorf("unexpected end of statement, expected %q", text)
+	}
+	if t.kind != kind || (kind == tokenPunctuation && t.text != text) {
+		return token{}, fmt.Errorf("unexpected %q, expected %s", t.text, text)
+	}
+	p.pos++
+	return *t, nil
+}
+
+func (p *parser) parseInvocation() (invocation, error) {
+	name, err := p.expect(tokenIdentifier, "function name")
+	if err != nil {
+		return invocation{}, err
+	}
+	if _, err = p.expect(tokenPunctuation, "("); err != nil {
+		return invocation{}, err
+	}
+
+	inv := invocation{function: name.text}
+	if p.peekPunctuation(")") {
+		p.pos++
+		return inv, nil
+	}
+	for {
+		arg, err := p.parseValue()
+		if err != nil {
+			return invocation{}, err
+		}
+		inv.arguments = append(inv.arguments, arg)
+		if p.peekPunctuation(",") {
+			p.pos++
+			continue
+		}
+		if _, err = p.expect(tokenPunctuation, ")"); err != nil {
+			return invocation{}, err
+		}
+		return inv, nil
+	}
+}
+
+func (p *parser) parseCondition() (condition, error) {
+	left, err := p.parseValue()
+	if err != nil {
+		return condition{}, err
+	}
+	op, err := p.expect(tokenOperator, "== or !=")
+	if err != nil {
+		return condition{}, err
+	}
+	right, err := p.parseValue()
+	if err != nil {
+		return condition{}, err
+	}
+	return condition{left: left, operator: op.text, right: right}, nil
+}
+
+func (p *parser) parseValue() (value, error) {
+	t := p.peek()
+	if t == nil {
+		return value{}, fmt.Errorf("unexpected end of statement, expected a value")
+	}
+
+	switch t.kind {
+	case tokenString:
+		p.pos++
+		s, err := strconv.Unquote(t.text)
+		if err != nil {
+			return value{}, fmt.Errorf("invalid string %s: %w", t.text, err)
+		}
+		return value{literal: s}, nil
+	case tokenNumber:
+		p.pos++
+		if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return value{literal: i}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return value{}, fmt.Errorf("invalid number %s", t.text)
+		}
+		return value{literal: f}, nil
+	case tokenIdentifier:
+		switch t.text {
+		case "true", "false":
+			p.pos++
+			return value{literal: t.text == "true"}, nil
+		case "nil":
+			p.pos++
+			return value{}, nil
+		}
+		pth, err := p.parsePath()
+		if err != nil {
+			return value{}, err
+		}
+		return value{path: pth}, nil
+	}
+	return value{}, fmt.Errorf("unexpected %q, expected a value", t.text)
+}
+
+func (p *parser) parsePath() (*fieldPath, error) {
+	pth := &fieldPath{}
+	for {
+		field, err := p.expect(tokenIdentifier, "field name")
+		if err != nil {
+			return nil, err
+		}
+		pth.fields = append(pth.fields, field.text)
+		if !p.peekPunctuation(".") {
+			break
+		}
+		p.pos++
+	}
+
+	if p.peekPunctuation("[") {
+		p.pos++
+		t := p.peek()
+		if t == nil || t.kind != tokenString {
+			return nil, fmt.Errorf("expected a quoted map key after %q", pth.String()+"[")
+		}
+		p.pos++
+		key, err := strconv.Unquote(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s: %w", t.text, err)
+		}
+		pth.key = &key
+		if _, err = p.expect(tokenPunctuation, "]"); err != nil {
+			return nil, err
+		}
+	}
+	return pth, nil
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strp(s string) *string {
+	return &s
+}
+
+func TestParseStatement(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		expected  *statement
+	}{
+		{
+			name:      "no_arguments",
+			statement: `noop()`,
+			expected:  &statement{invocation: invocation{function: "noop"}},
+		},
+		{
+			name:      "literals",
+			statement: `f("a \"quoted\" string", 12, -3.5, true, false, nil)`,
+			expected: &statement{invocation: invocation{
+				function: "f",
+				arguments: []value{
+					{literal: `a "quoted" string`},
+					{literal: int64(12)},
+					{literal: -3.5},
+					{literal: true},
+					{literal: false},
+					{},
+				},
+			}},
+		},
+		{
+			name:      "paths",
+			statement: `set(resource.attributes["service.name"], name)`,
+			expected: &statement{invocation: invocation{
+				function: "set",
+				arguments: []value{
+					{path: &fieldPath{fields: []string{"resource", "attributes"}, key: strp("service.name")}},
+					{path: &fieldPath{fields: []string{"name"}}},
+				},
+			}},
+		},
+		{
+			name:      "where",
+			statement: `limit(attributes, 10) where name != "GET /" and status.code == 2`,
+			expected: &statement{
+				invocation: invocation{
+					function: "limit",
+					arguments: []value{
+						{path: &fieldPath{fields: []string{"attributes"}}},
+						{literal: int64(10)},
+					},
+				},
+				conditions: []condition{
+					{
+						left:     value{path: &fieldPath{fields: []string{"name"}}},
+						operator: "!=",
+						right:    value{literal: "GET /"},
+					},
+					{
+						left:     value{path: &fieldPath{fields: []string{"status", "code"}}},
+						operator: "==",
+						right:    value{literal: int64(2)},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			st, err := parseStatement(test.statement)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, st)
+		})
+	}
+}
+
+func TestParseStatement_Errors(t *testing.T) {
+	tests := []struct {
+		statement string
+		err       string
+	}{
+		{statement: ``, err: `unexpected end of statement, expected "function name"`},
+		{statement: `set(name, "unterminated)`, err: `unterminated string starting at offset 10`},
+		{statement: `set(name "value")`, err: `unexpected "\"value\"", expected )`},
+		{statement: `set(name, "value"`, err: `unexpected end of statement, expected ")"`},
+		{statement: `set(name, "value") where name = "a"`, err: `unexpected character '=' at offset 30`},
+		{statement: `set(name, "value") where name "a"`, err: `unexpected "\"a\"", expected == or !=`},
+		{statement: `set(name, "value") extra`, err: `unexpected "extra" after the end of the statement`},
+		{statement: `set(attributes[key], "value")`, err: `expected a quoted map key after "attributes["`},
+		{statement: `set(name, 1.2.3)`, err: `invalid number 1.2.3`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.statement, func(t *testing.T) {
+			_, err := parseStatement(test.statement)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
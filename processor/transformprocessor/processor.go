@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+// transformProcessor applies the statements configured for a signal to each
+// span, log record or data point, in order.
+type transformProcessor struct {
+	statements []compiledStatement
+}
+
+func newTransformProcessor(statements []string, resolve pathResolver) (*transformProcessor, error) {
+	compiled, err := compileStatements(statements, resolve)
+	if err != nil {
+		return nil, err
+	}
+	return &transformProcessor{statements: compiled}, nil
+}
+
+func (tp *transformProcessor) apply(ctx interface{}) {
+	for i := range tp.statements {
+		tp.statements[i].apply(ctx)
+	}
+}
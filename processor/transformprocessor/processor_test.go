@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func generateTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	ils.InstrumentationLibrary().SetName("otelhttp")
+	ils.Spans().Resize(2)
+
+	health := ils.Spans().At(0)
+	health.SetName("/health")
+	health.Attributes().InsertString("http.path", "/health")
+	health.Attributes().InsertString("http.user_agent", "kube-probe/1.19")
+
+	user := ils.Spans().At(1)
+	user.SetName("/users/123")
+	user.Attributes().InsertString("http.path", "/users/123")
+	user.Attributes().InsertInt("http.status_code", 500)
+	user.Attributes().InsertString("net.peer.ip", "10.0.0.1")
+	return td
+}
+
+func TestProcessTraces(t *testing.T) {
+	tp, err := newTransformProcessor([]string{
+		`set(status.code, 1) where attributes["http.path"] == "/health"`,
+		`set(status.message, "server error") where attributes["http.status_code"] == 500.0`,
+		`replace_pattern(name, "^/users/[0-9]+$", "/users/{id}")`,
+		`delete(attributes, "http.user_agent", "net.peer.ip")`,
+		`set(attributes["service"], resource.attributes["service.name"]) where instrumentation_library.name == "otelhttp"`,
+		`limit(attributes, 2)`,
+	}, resolveSpanPath)
+	require.NoError(t, err)
+
+	td, err := tp.ProcessTraces(context.Background(), generateTraces())
+	require.NoError(t, err)
+
+	spans := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	health := spans.At(0)
+	assert.Equal(t, "/health", health.Name())
+	assert.Equal(t, pdata.StatusCode(1), health.Status().Code())
+	assert.Equal(t, "", health.Status().Message())
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"http.path": pdata.NewAttributeValueString("/health"),
+		"service":   pdata.NewAttributeValueString("checkout"),
+	}, attributesToMap(health.Attributes()))
+
+	user := spans.At(1)
+	assert.Equal(t, "/users/{id}", user.Name())
+	assert.Equal(t, pdata.StatusCode(0), user.Status().Code())
+	assert.Equal(t, "server error", user.Status().Message())
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"http.path":        pdata.NewAttributeValueString("/users/123"),
+		"http.status_code": pdata.NewAttributeValueInt(500),
+	}, attributesToMap(user.Attributes()))
+}
+
+func TestProcessMetrics(t *testing.T) {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("deployment.environment", "production")
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(2)
+
+	duration := metrics.At(0)
+	duration.SetName("http.server.duration")
+	duration.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	duration.DoubleHistogram().DataPoints().Resize(1)
+	duration.DoubleHistogram().DataPoints().At(0).LabelsMap().InitFromMap(map[string]string{"container.id": "abc", "http.method": "GET"})
+
+	requests := metrics.At(1)
+	requests.SetName("http.server.requests")
+	requests.SetDataType(pdata.MetricDataTypeIntSum)
+	requests.IntSum().DataPoints().Resize(1)
+	requests.IntSum().DataPoints().At(0).LabelsMap().InitFromMap(map[string]string{"container.id": "abc"})
+
+	tp, err := newTransformProcessor([]string{
+		`set(labels["environment"], resource.attributes["deployment.environment"])`,
+		`delete(labels["container.id"]) where metric.name == "http.server.duration"`,
+		`set(labels["retries"], 3)`,
+		`set(metric.unit, "ms") where metric.name == "http.server.duration"`,
+	}, resolveDataPointPath)
+	require.NoError(t, err)
+
+	md, err = tp.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ms", duration.Unit())
+	assert.Equal(t, map[string]string{
+		"http.method": "GET",
+		"environment": "production",
+		"retries":     "3",
+	}, labelsToMap(duration.DoubleHistogram().DataPoints().At(0).LabelsMap()))
+
+	assert.Equal(t, "", requests.Unit())
+	assert.Equal(t, map[string]string{
+		"container.id": "abc",
+		"environment":  "production",
+		"retries":      "3",
+	}, labelsToMap(requests.IntSum().DataPoints().At(0).LabelsMap()))
+}
+
+func TestProcessLogs(t *testing.T) {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+
+	login := logs.At(0)
+	login.SetSeverityNumber(pdata.SeverityNumberWARN)
+	login.Body().SetStringVal("login failed user=john password=secret")
+
+	logout := logs.At(1)
+	logout.SetSeverityNumber(pdata.SeverityNumberINFO)
+	logout.SetSeverityText("INFO")
+	logout.Body().SetStringVal("logout user=john")
+
+	tp, err := newTransformProcessor([]string{
+		`set(severity_text, "WARN") where severity_number == 13`,
+		`replace_pattern(body, "password=\\S+", "password=***")`,
+		`set(attributes["user"], "john") where severity_text != "INFO"`,
+	}, resolveLogPath)
+	require.NoError(t, err)
+
+	ld, err = tp.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	assert.Equal(t, "WARN", login.SeverityText())
+	assert.Equal(t, "login failed user=john password=***", login.Body().StringVal())
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"user": pdata.NewAttributeValueString("john"),
+	}, attributesToMap(login.Attributes()))
+
+	assert.Equal(t, "INFO", logout.SeverityText())
+	assert.Equal(t, "logout user=john", logout.Body().StringVal())
+	assert.Equal(t, 0, logout.Attributes().Len())
+}
+
+func TestFunctions_InvalidArguments(t *testing.T) {
+	tests := []struct {
+		statement string
+		err       string
+	}{
+		{statement: `set(name)`, err: `set expects 2 arguments, got 1`},
+		{statement: `set("name", "value")`, err: `expected a path, got name`},
+		{statement: `delete()`, err: `delete expects at least 1 argument`},
+		{statement: `delete("attributes", "key")`, err: `the first argument of delete must be a path`},
+		{statement: `delete(attributes)`, err: `delete expects the keys to delete from "attributes"`},
+		{statement: `delete(attributes["a"], "b")`, err: `delete expects 1 argument when deleting "attributes[\"a\"]"`},
+		{statement: `delete(attributes, 1)`, err: `the keys to delete must be strings`},
+		{statement: `replace_pattern(name, "[", "")`, err: "invalid pattern of replace_pattern: error parsing regexp: missing closing ]: `[`"},
+		{statement: `replace_pattern(name, 1, "")`, err: `the pattern of replace_pattern must be a string`},
+		{statement: `limit(attributes, -1)`, err: `the limit must be a positive integer`},
+		{statement: `set(attributes["a"], "b") where unknown == 1`, err: `unsupported path "unknown"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.statement, func(t *testing.T) {
+			_, err := compileStatement(test.statement, resolveSpanPath)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func attributesToMap(am pdata.AttributeMap) map[string]pdata.AttributeValue {
+	m := map[string]pdata.AttributeValue{}
+	am.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = v
+	})
+	return m
+}
+
+func labelsToMap(sm pdata.StringMap) map[string]string {
+	m := map[string]string{}
+	sm.ForEach(func(k string, v string) {
+		m[k] = v
+	})
+	return m
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"fmt"
+)
+
+// compiledStatement is a statement ready to be applied to the telemetry items
+// of a signal.
+type compiledStatement struct {
+	function   exprFunc
+	conditions []conditionFunc
+}
+
+// conditionFunc evaluates a comparison of the where clause of a statement.
+type conditionFunc func(ctx interface{}) bool
+
+// compileStatements parses the statements and resolves their paths with the
+// path resolver of the signal.
+func compileStatements(statements []string, resolve pathResolver) ([]compiledStatement, error) {
+	compiled := make([]compiledStatement, 0, len(statements))
+	for _, s := range statements {
+		cs, err := compileStatement(s, resolve)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing statement %q: %w", s, err)
+		}
+		compiled = append(compiled, cs)
+	}
+	return compiled, nil
+}
+
+func compileStatement(s string, resolve pathResolver) (compiledStatement, error) {
+	st, err := parseStatement(s)
+	if err != nil {
+		return compiledStatement{}, err
+	}
+
+	factory, ok := functions[st.invocation.function]
+	if !ok {
+		return compiledStatement{}, fmt.Errorf("unknown function %q", st.invocation.function)
+	}
+	function, err := factory(st.invocation.arguments, resolve)
+	if err != nil {
+		return compiledStatement{}, err
+	}
+
+	cs := compiledStatement{function: function}
+	for _, cond := range st.conditions {
+		left, err := valueGetter(cond.left, resolve)
+		if err != nil {
+			return compiledStatement{}, err
+		}
+		right, err := valueGetter(cond.right, resolve)
+		if err != nil {
+			return compiledStatement{}, err
+		}
+		equal := cond.operator == "=="
+		cs.conditions = append(cs.conditions, func(ctx interface{}) bool {
+			return valuesEqual(left(ctx), right(ctx)) == equal
+		})
+	}
+	return cs, nil
+}
+
+// apply invokes the function of the statement if all the conditions of its
+// where clause are true.
+func (cs *compiledStatement) apply(ctx interface{}) {
+	for _, cond := range cs.conditions {
+		if !cond(ctx) {
+			return
+		}
+	}
+	cs.function(ctx)
+}
+
+// valuesEqual compares two values, the integers and the floats are compared
+// as numbers.
+func valuesEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case int64:
+		if bv, ok := b.(float64); ok {
+			return float64(av) == bv
+		}
+	case float64:
+		if bv, ok := b.(int64); ok {
+			return av == float64(bv)
+		}
+	}
+	return a == b
+}
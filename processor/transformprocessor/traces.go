@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type spanContext struct {
+	span     pdata.Span
+	library  pdata.InstrumentationLibrary
+	resource pdata.Resource
+}
+
+func (ctx spanContext) getResource() pdata.Resource {
+	return ctx.resource
+}
+
+func (ctx spanContext) getInstrumentationLibrary() pdata.InstrumentationLibrary {
+	return ctx.library
+}
+
+// resolveSpanPath resolves the paths to the fields of the spans: name, kind,
+// status.code, status.message, attributes, and the common resource and
+// instrumentation library paths.
+func resolveSpanPath(p *fieldPath) (getSetter, error) {
+	if gs, ok, err := resolveCommonPath(p); ok {
+		return gs, err
+	}
+
+	if p.fields[0] == "attributes" && len(p.fields) == 1 {
+		return attributesGetSetter(func(ctx interface{}) pdata.AttributeMap {
+			return ctx.(spanContext).span.Attributes()
+		}, p.key), nil
+	}
+	if p.key != nil {
+		return getSetter{}, unsupportedPath(p)
+	}
+
+	switch p.String() {
+	case "name":
+		return stringGetSetter(
+			func(ctx interface{}) string { return ctx.(spanContext).span.Name() },
+			func(ctx interface{}, s string) { ctx.(spanContext).span.SetName(s) },
+		), nil
+	case "kind":
+		return intGetSetter(
+			func(ctx interface{}) int64 { return int64(ctx.(spanContext).span.Kind()) },
+			func(ctx interface{}, i int64) { ctx.(spanContext).span.SetKind(pdata.SpanKind(i)) },
+		), nil
+	case "status.code":
+		return intGetSetter(
+			func(ctx interface{}) int64 { return int64(ctx.(spanContext).span.Status().Code()) },
+			func(ctx interface{}, i int64) { ctx.(spanContext).span.Status().SetCode(pdata.StatusCode(i)) },
+		), nil
+	case "status.message":
+		return stringGetSetter(
+			func(ctx interface{}) string { return ctx.(spanContext).span.Status().Message() },
+			func(ctx interface{}, s string) { ctx.(spanContext).span.Status().SetMessage(s) },
+		), nil
+	}
+	return getSetter{}, unsupportedPath(p)
+}
+
+// ProcessTraces applies the statements to each span.
+func (tp *transformProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				tp.apply(spanContext{
+					span:     spans.At(k),
+					library:  ils.InstrumentationLibrary(),
+					resource: rs.Resource(),
+				})
+			}
+		}
+	}
+	return td, nil
+}
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqpreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	transport = "amqp"
+)
+
+var (
+	errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
+	errNonPositivePrefetch  = errors.New("prefetch_count must be positive")
+	errNonPositiveReconnect = errors.New("reconnect_interval must be positive")
+)
+
+// connection is the subset of *amqp.Connection used by the receiver.
+type connection interface {
+	channel() (channel, error)
+	Close() error
+}
+
+// channel is the subset of *amqp.Channel used by the receiver.
+type channel interface {
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	Close() error
+}
+
+type amqpConnection struct {
+	*amqp.Connection
+}
+
+func (c amqpConnection) channel() (channel, error) {
+	return c.Channel()
+}
+
+// amqpConsumer consumes and acknowledges the messages of an AMQP queue once handled by the next consumer.
+type amqpConsumer struct {
+	name         string
+	config       Config
+	dial         func() (connection, error)
+	unmarshaller unmarshaller
+	nextConsumer consumer.LogsConsumer
+
+	// The connection of the consumption, only accessed by the consume loop once started.
+	conn connection
+
+	cancelConsumeLoop context.CancelFunc
+	done              sync.WaitGroup
+
+	logger *zap.Logger
+}
+
+var _ component.Receiver = (*amqpConsumer)(nil)
+
+func newReceiver(config Config, params component.ReceiverCreateParams, nextConsumer consumer.LogsConsumer) (*amqpConsumer, error) {
+	var unmarshaller unmarshaller
+	switch config.Encoding {
+	case encodingOTLPProto:
+		unmarshaller = unmarshalOTLPProto
+	case encodingJSON:
+		unmarshaller = unmarshalJSON
+	default:
+		return nil, errUnrecognizedEncoding
+	}
+	if config.PrefetchCount <= 0 {
+		return nil, errNonPositivePrefetch
+	}
+	if config.ReconnectInterval <= 0 {
+		return nil, errNonPositiveReconnect
+	}
+	tlsCfg, err := config.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &amqpConsumer{
+		name:   config.Name(),
+		config: config,
+		dial: func() (connection, error) {
+			conn, err := amqp.DialTLS(config.Endpoint, tlsCfg)
+			if err != nil {
+				return nil, err
+			}
+			return amqpConnection{conn}, nil
+		},
+		unmarshaller: unmarshaller,
+		nextConsumer: nextConsumer,
+		logger:       params.Logger,
+	}, nil
+}
+
+func (c *amqpConsumer) Start(context.Context, component.Host) error {
+	deliveries, err := c.subscribe()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancelConsumeLoop = cancel
+	c.done.Add(1)
+	go c.consumeLoop(ctx, deliveries)
+	return nil
+}
+
+// subscribe connects to the broker, and starts consuming the messages of the queue.
+func (c *amqpConsumer) subscribe() (<-chan amqp.Delivery, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the broker: %w", err)
+	}
+	ch, err := conn.channel()
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to open a channel: %w", err)
+	}
+	if err = ch.Qos(c.config.PrefetchCount, 0, false); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to set the prefetch count: %w", err)
+	}
+	deliveries, err := ch.Consume(c.config.Queue, c.config.ConsumerTag, false, false, false, false, nil)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to consume from %s: %w", c.config.Queue, err)
+	}
+	c.conn = conn
+	return deliveries, nil
+}
+
+func (c *amqpConsumer) consumeLoop(ctx context.Context, deliveries <-chan amqp.Delivery) {
+	defer c.done.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery, ok := <-deliveries:
+			if ok {
+				c.handle(ctx, &delivery)
+				continue
+			}
+			// The channel or the connection was closed, e.g. by the broker: the unacknowledged messages are
+			// redelivered after the consumption is resumed.
+			c.logger.Warn("Consumer channel closed, reconnecting", zap.String("queue", c.config.Queue))
+			_ = c.conn.Close()
+			c.conn = nil
+			if deliveries = c.resubscribe(ctx); deliveries == nil {
+				return
+			}
+		}
+	}
+}
+
+// resubscribe subscribes to the queue every reconnect interval until it succeeds, or the context is cancelled.
+func (c *amqpConsumer) resubscribe(ctx context.Context) <-chan amqp.Delivery {
+	ticker := time.NewTicker(c.config.ReconnectInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		deliveries, err := c.subscribe()
+		if err == nil {
+			c.logger.Info("Consumer reconnected", zap.String("queue", c.config.Queue))
+			return deliveries
+		}
+		c.logger.Warn("Failed to reconnect", zap.Error(err))
+	}
+}
+
+// handle passes the logs of a message to the next consumer, and acknowledges the message once consumed. The
+// messages which cannot be deserialized are rejected without being requeued, to be dead-lettered if the queue is
+// configured so, while the other failures requeue them.
+func (c *amqpConsumer) handle(ctx context.Context, delivery *amqp.Delivery) {
+	c.logger.Debug("AMQP message received",
+		zap.String("exchange", delivery.Exchange),
+		zap.String("routing_key", delivery.RoutingKey),
+		zap.Bool("redelivered", delivery.Redelivered))
+
+	ctx = obsreport.ReceiverContext(ctx, c.name, transport)
+	ctx = obsreport.StartLogsReceiveOp(ctx, c.name, transport)
+	logs, err := c.unmarshaller(delivery)
+	if err != nil {
+		obsreport.EndLogsReceiveOp(ctx, c.config.Encoding, 0, err)
+		c.logger.Error("failed to unmarshall message", zap.Error(err))
+		c.acknowledge(delivery.Reject(false))
+		return
+	}
+
+	err = c.nextConsumer.ConsumeLogs(ctx, logs)
+	obsreport.EndLogsReceiveOp(ctx, c.config.Encoding, logs.LogRecordCount(), err)
+	if err != nil {
+		c.logger.Debug("Failed to consume message, requeuing it", zap.Error(err))
+		c.acknowledge(delivery.Nack(false, true))
+		return
+	}
+	c.acknowledge(delivery.Ack(false))
+}
+
+func (c *amqpConsumer) acknowledge(err error) {
+	if err != nil {
+		// The message is redelivered once the channel is reopened.
+		c.logger.Warn("Failed to acknowledge message", zap.Error(err))
+	}
+}
+
+func (c *amqpConsumer) Shutdown(context.Context) error {
+	if c.cancelConsumeLoop == nil {
+		return nil
+	}
+	c.cancelConsumeLoop()
+	c.done.Wait()
+	if c.conn == nil {
+		return nil
+	}
+	// Closing the connection closes its channels, the messages not acknowledged yet being requeued by the broker.
+	return c.conn.Close()
+}
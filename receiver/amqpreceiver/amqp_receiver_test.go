@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqpreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+// fakeBroker records the subscriptions and the acknowledgments of the messages, by delivery tag.
+type fakeBroker struct {
+	mu            sync.Mutex
+	dialErr       error
+	subscriptions int
+	deliveries    chan amqp.Delivery
+	prefetchCount int
+	queue         string
+	acks          map[uint64]string
+	closedConns   int
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{acks: make(map[uint64]string)}
+}
+
+func (b *fakeBroker) dial() (connection, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.dialErr != nil {
+		return nil, b.dialErr
+	}
+	return &fakeConnection{broker: b}, nil
+}
+
+func (b *fakeBroker) Ack(tag uint64, _ bool) error {
+	return b.acknowledge(tag, "ack")
+}
+
+func (b *fakeBroker) Nack(tag uint64, _ bool, requeue bool) error {
+	if requeue {
+		return b.acknowledge(tag, "nack requeue")
+	}
+	return b.acknowledge(tag, "nack")
+}
+
+func (b *fakeBroker) Reject(tag uint64, requeue bool) error {
+	if requeue {
+		return b.acknowledge(tag, "reject requeue")
+	}
+	return b.acknowledge(tag, "reject")
+}
+
+func (b *fakeBroker) acknowledge(tag uint64, ack string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.acks[tag] = ack
+	return nil
+}
+
+func (b *fakeBroker) ack(tag uint64) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.acks[tag]
+}
+
+// deliver delivers a message on the current subscription.
+func (b *fakeBroker) deliver(tag uint64, body []byte) {
+	b.mu.Lock()
+	deliveries := b.deliveries
+	b.mu.Unlock()
+	deliveries <- amqp.Delivery{Acknowledger: b, DeliveryTag: tag, Body: body}
+}
+
+type fakeConnection struct {
+	broker *fakeBroker
+}
+
+func (c *fakeConnection) channel() (channel, error) {
+	return &fakeChannel{broker: c.broker}, nil
+}
+
+func (c *fakeConnection) Close() error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.broker.closedConns++
+	return nil
+}
+
+type fakeChannel struct {
+	broker *fakeBroker
+}
+
+func (c *fakeChannel) Qos(prefetchCount, _ int, _ bool) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.broker.prefetchCount = prefetchCount
+	return nil
+}
+
+func (c *fakeChannel) Consume(queue, _ string, autoAck, _, _, _ bool, _ amqp.Table) (<-chan amqp.Delivery, error) {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	if autoAck {
+		return nil, errors.New("unexpected auto acknowledgment")
+	}
+	c.broker.queue = queue
+	c.broker.subscriptions++
+	c.broker.deliveries = make(chan amqp.Delivery)
+	return c.broker.deliveries, nil
+}
+
+func (c *fakeChannel) Close() error {
+	return nil
+}
+
+func newTestReceiver(t *testing.T, broker *fakeBroker, encoding string, next consumer.LogsConsumer) *amqpConsumer {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Encoding = encoding
+	cfg.ReconnectInterval = 10 * time.Millisecond
+	r, err := newReceiver(*cfg, component.ReceiverCreateParams{Logger: zap.NewNop()}, next)
+	require.NoError(t, err)
+	r.dial = broker.dial
+	return r
+}
+
+func TestReceiveLogs(t *testing.T) {
+	broker := newFakeBroker()
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, broker, encodingOTLPProto, sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, defaultQueue, broker.queue)
+	assert.Equal(t, defaultPrefetchCount, broker.prefetchCount)
+
+	body, err := testdata.GenerateLogDataOneLog().ToOtlpProtoBytes()
+	require.NoError(t, err)
+	broker.deliver(1, body)
+	broker.deliver(2, []byte("invalid"))
+	broker.deliver(3, body)
+
+	require.Eventually(t, func() bool {
+		return broker.ack(3) != ""
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "ack", broker.ack(1))
+	// The invalid message is dead-lettered, if the queue has a dead letter exchange.
+	assert.Equal(t, "reject", broker.ack(2))
+	assert.Equal(t, "ack", broker.ack(3))
+	assert.Equal(t, 2, sink.LogRecordsCount())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, 1, broker.closedConns)
+}
+
+func TestConsumeError(t *testing.T) {
+	broker := newFakeBroker()
+	r := newTestReceiver(t, broker, encodingJSON, consumertest.NewLogsErr(errors.New("queue is full")))
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	broker.deliver(1, []byte(`{"message":"started"}`))
+	require.Eventually(t, func() bool {
+		return broker.ack(1) == "nack requeue"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReconnect(t *testing.T) {
+	broker := newFakeBroker()
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, broker, encodingJSON, sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The broker closes the channel and refuses the first attempt to reconnect.
+	broker.mu.Lock()
+	broker.dialErr = errors.New("connection refused")
+	close(broker.deliveries)
+	broker.mu.Unlock()
+	time.Sleep(50 * time.Millisecond)
+	broker.mu.Lock()
+	broker.dialErr = nil
+	broker.mu.Unlock()
+
+	require.Eventually(t, func() bool {
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		return broker.subscriptions == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	broker.deliver(1, []byte(`{"message":"resumed"}`))
+	require.Eventually(t, func() bool {
+		return broker.ack(1) == "ack"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, sink.LogRecordsCount())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, 2, broker.closedConns)
+}
+
+func TestStartError(t *testing.T) {
+	broker := newFakeBroker()
+	broker.dialErr = errors.New("connection refused")
+	r := newTestReceiver(t, broker, encodingOTLPProto, consumertest.NewLogsNop())
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()),
+		"failed to connect to the broker: connection refused")
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqpreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.Equal(t, defaultEndpoint, cfg.Endpoint)
+	assert.Equal(t, defaultQueue, cfg.Queue)
+	assert.Equal(t, defaultPrefetchCount, cfg.PrefetchCount)
+}
+
+func TestCreateLogsReceiver(t *testing.T) {
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	r, err := NewFactory().CreateLogsReceiver(context.Background(), params, createDefaultConfig(), consumertest.NewLogsNop())
+	require.NoError(t, err)
+	assert.NotNil(t, r)
+}
+
+func TestCreateLogsReceiver_error(t *testing.T) {
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "encoding",
+			modify:  func(cfg *Config) { cfg.Encoding = "zipkin_json" },
+			wantErr: errUnrecognizedEncoding.Error(),
+		},
+		{
+			name:    "prefetch_count",
+			modify:  func(cfg *Config) { cfg.PrefetchCount = 0 },
+			wantErr: errNonPositivePrefetch.Error(),
+		},
+		{
+			name:    "reconnect_interval",
+			modify:  func(cfg *Config) { cfg.ReconnectInterval = 0 },
+			wantErr: errNonPositiveReconnect.Error(),
+		},
+		{
+			name:    "tls_settings",
+			modify:  func(cfg *Config) { cfg.TLSSetting.CAFile = "/nonexistent/ca.pem" },
+			wantErr: "failed to load TLS config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			r, err := NewFactory().CreateLogsReceiver(context.Background(), params, cfg, consumertest.NewLogsNop())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Nil(t, r)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqpreceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/streadway/amqp"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	encodingOTLPProto = "otlp_proto"
+	encodingJSON      = "json"
+
+	attributeExchange   = "amqp.exchange"
+	attributeRoutingKey = "amqp.routing_key"
+)
+
+var errNotJSONObject = errors.New("payload is neither a JSON object nor an array of JSON objects")
+
+// unmarshaller deserializes the body of a message into logs.
+type unmarshaller func(delivery *amqp.Delivery) (pdata.Logs, error)
+
+// unmarshalOTLPProto deserializes the body of a message into the logs of an OTLP ExportLogsServiceRequest.
+func unmarshalOTLPProto(delivery *amqp.Delivery) (pdata.Logs, error) {
+	logs := pdata.NewLogs()
+	err := logs.FromOtlpProtoBytes(delivery.Body)
+	return logs, err
+}
+
+// unmarshalJSON deserializes the body of a message, a JSON object or an array of JSON objects, into log records
+// whose body is the map of the fields of the objects. The records are timestamped with the timestamp of the
+// message, or the time it is received, and have the exchange and routing key of the message as attributes.
+func unmarshalJSON(delivery *amqp.Delivery) (pdata.Logs, error) {
+	decoder := json.NewDecoder(bytes.NewReader(delivery.Body))
+	decoder.UseNumber()
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return pdata.NewLogs(), err
+	}
+	if decoder.More() {
+		return pdata.NewLogs(), errNotJSONObject
+	}
+
+	var objects []map[string]interface{}
+	switch v := payload.(type) {
+	case map[string]interface{}:
+		objects = append(objects, v)
+	case []interface{}:
+		for _, e := range v {
+			object, ok := e.(map[string]interface{})
+			if !ok {
+				return pdata.NewLogs(), errNotJSONObject
+			}
+			objects = append(objects, object)
+		}
+	default:
+		return pdata.NewLogs(), errNotJSONObject
+	}
+
+	timestamp := delivery.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	logs := pdata.NewLogs()
+	rls := logs.ResourceLogs()
+	rls.Resize(1)
+	ills := rls.At(0).InstrumentationLibraryLogs()
+	ills.Resize(1)
+	logSlice := ills.At(0).Logs()
+	logSlice.Resize(len(objects))
+	for i, object := range objects {
+		lr := logSlice.At(i)
+		lr.SetTimestamp(pdata.TimestampFromTime(timestamp))
+		jsonToAttributeValue(object).CopyTo(lr.Body())
+		lr.Attributes().InsertString(attributeExchange, delivery.Exchange)
+		lr.Attributes().InsertString(attributeRoutingKey, delivery.RoutingKey)
+	}
+	return logs, nil
+}
+
+// insertFields inserts the fields in the order of their keys.
+func insertFields(attributes pdata.AttributeMap, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attributes.Insert(k, jsonToAttributeValue(fields[k]))
+	}
+}
+
+func jsonToAttributeValue(value interface{}) pdata.AttributeValue {
+	switch v := value.(type) {
+	case string:
+		return pdata.NewAttributeValueString(v)
+	case bool:
+		return pdata.NewAttributeValueBool(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return pdata.NewAttributeValueInt(i)
+		}
+		f, _ := v.Float64()
+		return pdata.NewAttributeValueDouble(f)
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		for _, e := range v {
+			av.ArrayVal().Append(jsonToAttributeValue(e))
+		}
+		return av
+	case map[string]interface{}:
+		av := pdata.NewAttributeValueMap()
+		insertFields(av.MapVal(), v)
+		return av
+	default:
+		return pdata.NewAttributeValueNull()
+	}
+}
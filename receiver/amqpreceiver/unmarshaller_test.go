@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqpreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestUnmarshalOTLPProto(t *testing.T) {
+	expected := testdata.GenerateLogDataOneLog()
+	body, err := expected.ToOtlpProtoBytes()
+	require.NoError(t, err)
+
+	logs, err := unmarshalOTLPProto(&amqp.Delivery{Body: body})
+	require.NoError(t, err)
+	assert.Equal(t, expected, logs)
+
+	_, err = unmarshalOTLPProto(&amqp.Delivery{Body: []byte("invalid")})
+	assert.Error(t, err)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	timestamp := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	logs, err := unmarshalJSON(&amqp.Delivery{
+		Exchange:   "events",
+		RoutingKey: "orders.created",
+		Timestamp:  timestamp,
+		Body:       []byte(`[{"id":42,"amount":9.99,"paid":true,"tags":["new"],"customer":{"name":"alice"},"note":null},{"id":43}]`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.LogRecordCount())
+
+	records := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	lr := records.At(0)
+	assert.Equal(t, pdata.TimestampFromTime(timestamp), lr.Timestamp())
+	assert.Equal(t, pdata.AttributeValueMAP, lr.Body().Type())
+	body := lr.Body().MapVal()
+	for name, want := range map[string]pdata.AttributeValue{
+		"id":     pdata.NewAttributeValueInt(42),
+		"amount": pdata.NewAttributeValueDouble(9.99),
+		"paid":   pdata.NewAttributeValueBool(true),
+		"note":   pdata.NewAttributeValueNull(),
+	} {
+		value, ok := body.Get(name)
+		require.True(t, ok, name)
+		assert.True(t, want.Equal(value), name)
+	}
+	tags, _ := body.Get("tags")
+	assert.Equal(t, "new", tags.ArrayVal().At(0).StringVal())
+	customer, _ := body.Get("customer")
+	name, _ := customer.MapVal().Get("name")
+	assert.Equal(t, "alice", name.StringVal())
+
+	for attribute, want := range map[string]string{attributeExchange: "events", attributeRoutingKey: "orders.created"} {
+		value, ok := lr.Attributes().Get(attribute)
+		require.True(t, ok)
+		assert.Equal(t, want, value.StringVal())
+	}
+
+	id, _ := records.At(1).Body().MapVal().Get("id")
+	assert.Equal(t, int64(43), id.IntVal())
+}
+
+func TestUnmarshalJSONWithoutTimestamp(t *testing.T) {
+	before := time.Now()
+	logs, err := unmarshalJSON(&amqp.Delivery{Body: []byte(`{"message":"started"}`)})
+	require.NoError(t, err)
+	lr := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.GreaterOrEqual(t, int64(lr.Timestamp()), before.UnixNano())
+}
+
+func TestUnmarshalJSON_error(t *testing.T) {
+	for _, body := range []string{`"started"`, `[{"id":1},2]`, `{"id":1}{"id":2}`, `{"id":`} {
+		t.Run(body, func(t *testing.T) {
+			_, err := unmarshalJSON(&amqp.Delivery{Body: []byte(body)})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the file log receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Include is the glob patterns, as supported by filepath.Match, of the files to read.
+	Include []string `mapstructure:"include"`
+
+	// Exclude is the glob patterns of the files matching Include not to read.
+	Exclude []string `mapstructure:"exclude"`
+
+	// StartAt is where the files found at startup, whose offset was not persisted, are read from: "end" or
+	// "beginning". The files found later are always read from the beginning.
+	StartAt string `mapstructure:"start_at"`
+
+	// PollInterval is the interval at which the files are searched for and read.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// Encoding is the IANA name of the encoding of the files, e.g. "utf-16le". The log records are converted to UTF-8.
+	Encoding string `mapstructure:"encoding"`
+
+	// Multiline configures the stitching of several lines into one log record. Each line is a log record if nil.
+	Multiline *MultilineConfig `mapstructure:"multiline"`
+
+	// MaxLogSize is the maximum size in bytes of the log records, read from the files. The longer records are split.
+	MaxLogSize int `mapstructure:"max_log_size"`
+
+	// OffsetsFile is the path of the file where the read offsets are persisted, so that the files are read from where
+	// they were left after a restart. The offsets are not persisted if empty.
+	OffsetsFile string `mapstructure:"offsets_file"`
+}
+
+// MultilineConfig defines configuration for the stitching of lines into log records.
+type MultilineConfig struct {
+	// LineStartPattern is the regular expression matching the first line of the log records. The following lines
+	// not matching it are appended to the record, e.g. the lines of a stack trace.
+	LineStartPattern string `mapstructure:"line_start_pattern"`
+}
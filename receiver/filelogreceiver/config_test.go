@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	assert.Equal(t, cfg.Receivers["filelog"], factory.CreateDefaultConfig())
+
+	assert.Equal(t, cfg.Receivers["filelog/custom"], &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: typeStr,
+			NameVal: "filelog/custom",
+		},
+		Include:      []string{"/var/log/app/*.log", "/var/log/app/*.log.1"},
+		Exclude:      []string{"/var/log/app/debug.log"},
+		StartAt:      startAtBeginning,
+		PollInterval: time.Second,
+		Encoding:     "utf-16le",
+		Multiline: &MultilineConfig{
+			LineStartPattern: `^\d{4}-\d{2}-\d{2}`,
+		},
+		MaxLogSize:  65536,
+		OffsetsFile: "/var/lib/otelcol/filelog.offsets",
+	})
+}
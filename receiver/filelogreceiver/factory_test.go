@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateReceiver(t *testing.T) {
+	factory := NewFactory()
+	require.Equal(t, configmodels.Type("filelog"), factory.Type())
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "valid",
+			modify: func(cfg *Config) {
+				cfg.Include = []string{"/var/log/*.log"}
+				cfg.Encoding = "UTF-16BE"
+				cfg.Multiline = &MultilineConfig{LineStartPattern: `^\S`}
+			},
+		},
+		{
+			name:    "no include",
+			modify:  func(cfg *Config) {},
+			wantErr: errNoInclude.Error(),
+		},
+		{
+			name: "invalid pattern",
+			modify: func(cfg *Config) {
+				cfg.Include = []string{"/var/log/*.log"}
+				cfg.Exclude = []string{"/var/log/[.log"}
+			},
+			wantErr: `invalid pattern "/var/log/[.log": syntax error in pattern`,
+		},
+		{
+			name: "invalid start_at",
+			modify: func(cfg *Config) {
+				cfg.Include = []string{"/var/log/*.log"}
+				cfg.StartAt = "middle"
+			},
+			wantErr: `invalid start_at "middle", expecting "end" or "beginning"`,
+		},
+		{
+			name: "invalid poll_interval",
+			modify: func(cfg *Config) {
+				cfg.Include = []string{"/var/log/*.log"}
+				cfg.PollInterval = 0
+			},
+			wantErr: errNonPositiveInterval.Error(),
+		},
+		{
+			name: "invalid max_log_size",
+			modify: func(cfg *Config) {
+				cfg.Include = []string{"/var/log/*.log"}
+				cfg.MaxLogSize = -1
+			},
+			wantErr: errNonPositiveLogSize.Error(),
+		},
+		{
+			name: "unknown encoding",
+			modify: func(cfg *Config) {
+				cfg.Include = []string{"/var/log/*.log"}
+				cfg.Encoding = "klingon"
+			},
+			wantErr: `unsupported encoding "klingon"`,
+		},
+		{
+			name: "invalid line_start_pattern",
+			modify: func(cfg *Config) {
+				cfg.Include = []string{"/var/log/*.log"}
+				cfg.Multiline = &MultilineConfig{LineStartPattern: `(`}
+			},
+			wantErr: "invalid line_start_pattern: error parsing regexp: missing closing ): `(`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+			r, err := factory.CreateLogsReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewLogsNop())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, r)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// fileOffset is the persisted offset of a file.
+type fileOffset struct {
+	Path        string `json:"path"`
+	Fingerprint []byte `json:"fingerprint"`
+	Offset      int64  `json:"offset"`
+}
+
+// loadOffsets returns the readers of the offsets persisted in the file at path, none if it does not exist.
+func loadOffsets(path string) ([]*reader, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var offsets []fileOffset
+	if err = json.Unmarshal(data, &offsets); err != nil {
+		return nil, err
+	}
+	readers := make([]*reader, 0, len(offsets))
+	for _, offset := range offsets {
+		readers = append(readers, &reader{
+			path:        offset.Path,
+			fingerprint: offset.Fingerprint,
+			offset:      offset.Offset,
+			lastSize:    -1,
+		})
+	}
+	return readers, nil
+}
+
+// saveOffsets persists the offsets of the readers in the file at path, replacing it atomically.
+func saveOffsets(path string, readers []*reader) error {
+	offsets := make([]fileOffset, 0, len(readers))
+	for _, rd := range readers {
+		offsets = append(offsets, fileOffset{
+			Path:        rd.path,
+			Fingerprint: rd.fingerprint,
+			Offset:      rd.offset,
+		})
+	}
+	data, err := json.Marshal(offsets)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// fingerprintSize is the number of first bytes of a file identifying it, whatever its path, so that it is still read
+// from its offset after it is renamed by a rotation.
+const fingerprintSize = 1000
+
+// reader is the state of the reading of a file.
+type reader struct {
+	path        string
+	fingerprint []byte
+	// offset is the offset of the first byte of the file not converted to a log record yet.
+	offset int64
+	// pending is the lines of the multiline log record being read, which starts at offset, and pendingSize is their
+	// size in the file.
+	pending     []string
+	pendingSize int64
+	// lastSize is the size of the file at the previous poll, -1 if the file was not polled yet.
+	lastSize int64
+}
+
+// polledFile is a file found by a poll.
+type polledFile struct {
+	path        string
+	file        *os.File
+	size        int64
+	fingerprint []byte
+	reader      *reader
+}
+
+// openFile opens the file at path, nil if it cannot be read yet.
+func (r *fileLogReceiver) openFile(path string) *polledFile {
+	file, err := os.Open(path)
+	if err != nil {
+		r.logger.Debug("Failed to open log file", zap.String("path", path), zap.Error(err))
+		return nil
+	}
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		_ = file.Close()
+		return nil
+	}
+	fingerprint := make([]byte, fingerprintSize)
+	n, err := file.ReadAt(fingerprint, 0)
+	if (err != nil && err != io.EOF) || n == 0 {
+		// An empty file cannot be identified yet.
+		_ = file.Close()
+		return nil
+	}
+	return &polledFile{path: path, file: file, size: info.Size(), fingerprint: fingerprint[:n]}
+}
+
+// matchReaders sets the readers of the files from the readers of the previous poll whose fingerprint starts the
+// fingerprint of the file: the one of the same path, or else the one of another path, from which the file was renamed.
+// The other files get a new reader.
+func (r *fileLogReceiver) matchReaders(files []*polledFile) {
+	claimed := make(map[*reader]bool, len(r.readers))
+	match := func(f *polledFile, samePath bool) {
+		for _, rd := range r.readers {
+			if !claimed[rd] && (rd.path == f.path) == samePath && bytes.HasPrefix(f.fingerprint, rd.fingerprint) {
+				claimed[rd] = true
+				f.reader = rd
+				return
+			}
+		}
+	}
+	for _, f := range files {
+		match(f, true)
+	}
+	for _, f := range files {
+		if f.reader == nil {
+			match(f, false)
+		}
+	}
+
+	for _, f := range files {
+		if f.reader == nil {
+			f.reader = &reader{lastSize: -1}
+			if r.firstPoll && r.config.StartAt == startAtEnd {
+				f.reader.offset = f.size
+			}
+		}
+		f.reader.path = f.path
+		if len(f.reader.fingerprint) < fingerprintSize {
+			f.reader.fingerprint = f.fingerprint
+		}
+	}
+}
+
+// readFile converts the lines of the file following the offset of the reader to log records. The incomplete last line
+// and the pending multiline record are only converted once the file stops growing.
+func (r *fileLogReceiver) readFile(ctx context.Context, file *os.File, size int64, rd *reader) {
+	if size < rd.offset+rd.pendingSize {
+		// The file was truncated.
+		rd.offset, rd.pending, rd.pendingSize = 0, nil, 0
+	}
+	idle := size == rd.lastSize
+	rd.lastSize = size
+
+	pos := rd.offset + rd.pendingSize
+	if _, err := file.Seek(pos, io.SeekStart); err != nil {
+		r.logger.Debug("Failed to read log file", zap.String("path", rd.path), zap.Error(err))
+		return
+	}
+
+	// committed is the offset following the last line converted to a log record.
+	committed := rd.offset
+	var records []string
+	flush := func() bool {
+		if len(records) > 0 {
+			err := r.consume(ctx, rd.path, records)
+			records = records[:0]
+			if err != nil {
+				// The lines are read again at the next poll.
+				rd.pending, rd.pendingSize = nil, 0
+				return false
+			}
+		}
+		rd.offset = committed
+		return true
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 4096), r.config.MaxLogSize+len(r.newline))
+	scanner.Split(r.splitLines(idle))
+	for scanner.Scan() {
+		token := scanner.Bytes()
+		pos += int64(len(token))
+		line, err := r.decoder.Bytes(bytes.TrimSuffix(token, r.newline))
+		if err != nil {
+			line = token
+		}
+		text := strings.TrimSuffix(string(line), "\r")
+
+		if r.lineStart == nil {
+			records = append(records, text)
+			committed = pos
+		} else {
+			if len(rd.pending) > 0 && r.lineStart.MatchString(text) {
+				records = append(records, strings.Join(rd.pending, "\n"))
+				committed = pos - int64(len(token))
+				rd.pending = nil
+			}
+			rd.pending = append(rd.pending, text)
+			if pos-committed >= int64(r.config.MaxLogSize) {
+				records = append(records, strings.Join(rd.pending, "\n"))
+				committed = pos
+				rd.pending = nil
+			}
+		}
+
+		if len(records) >= maxBatchSize && !flush() {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		r.logger.Debug("Failed to read log file", zap.String("path", rd.path), zap.Error(err))
+	}
+
+	if idle && len(rd.pending) > 0 {
+		records = append(records, strings.Join(rd.pending, "\n"))
+		committed = pos
+		rd.pending = nil
+	}
+	if flush() {
+		rd.pendingSize = pos - rd.offset
+	}
+}
+
+// splitLines splits the content of the files on the encoded newlines, and the lines longer than the maximum size of
+// the log records. The incomplete last line is only returned if flushPartial is set.
+func (r *fileLogReceiver) splitLines(flushPartial bool) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.Index(data, r.newline); i >= 0 && i <= r.config.MaxLogSize {
+			end := i + len(r.newline)
+			return end, data[:end], nil
+		}
+		if len(data) >= r.config.MaxLogSize {
+			return r.config.MaxLogSize, data[:r.config.MaxLogSize], nil
+		}
+		if atEOF && flushPartial && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
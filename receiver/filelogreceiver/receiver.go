@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	transport = "file"
+	format    = "text"
+
+	attributeFileName = "log.file.name"
+	attributeFilePath = "log.file.path"
+
+	// maxBatchSize is the maximum number of log records sent at once to the next consumer.
+	maxBatchSize = 100
+)
+
+var (
+	errNoInclude           = errors.New("at least one include pattern must be configured")
+	errNonPositiveInterval = errors.New("poll_interval must be positive")
+	errNonPositiveLogSize  = errors.New("max_log_size must be positive")
+)
+
+type fileLogReceiver struct {
+	config    *Config
+	logger    *zap.Logger
+	next      consumer.LogsConsumer
+	decoder   *encoding.Decoder
+	newline   []byte
+	lineStart *regexp.Regexp
+
+	// The state of the polls, only accessed by the polling goroutine once started.
+	readers   []*reader
+	firstPoll bool
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func newFileLogReceiver(logger *zap.Logger, config *Config, next consumer.LogsConsumer) (component.LogsReceiver, error) {
+	if len(config.Include) == 0 {
+		return nil, errNoInclude
+	}
+	for _, patterns := range [][]string{config.Include, config.Exclude} {
+		for _, pattern := range patterns {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	if config.StartAt != startAtEnd && config.StartAt != startAtBeginning {
+		return nil, fmt.Errorf("invalid start_at %q, expecting %q or %q", config.StartAt, startAtEnd, startAtBeginning)
+	}
+	if config.PollInterval <= 0 {
+		return nil, errNonPositiveInterval
+	}
+	if config.MaxLogSize <= 0 {
+		return nil, errNonPositiveLogSize
+	}
+
+	enc, err := ianaindex.IANA.Encoding(config.Encoding)
+	if err != nil || enc == nil {
+		return nil, fmt.Errorf("unsupported encoding %q", config.Encoding)
+	}
+	newline, err := enc.NewEncoder().Bytes([]byte("\n"))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported encoding %q: %w", config.Encoding, err)
+	}
+
+	r := &fileLogReceiver{
+		config:  config,
+		logger:  logger,
+		next:    next,
+		decoder: enc.NewDecoder(),
+		newline: newline,
+	}
+	if config.Multiline != nil {
+		if r.lineStart, err = regexp.Compile(config.Multiline.LineStartPattern); err != nil {
+			return nil, fmt.Errorf("invalid line_start_pattern: %w", err)
+		}
+	}
+	return r, nil
+}
+
+func (r *fileLogReceiver) Start(_ context.Context, _ component.Host) error {
+	if r.config.OffsetsFile != "" {
+		readers, err := loadOffsets(r.config.OffsetsFile)
+		if err != nil {
+			return fmt.Errorf("failed to load the offsets: %w", err)
+		}
+		r.readers = readers
+	}
+	r.firstPoll = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done.Add(1)
+	go func() {
+		defer r.done.Done()
+		ticker := time.NewTicker(r.config.PollInterval)
+		defer ticker.Stop()
+		for {
+			r.poll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *fileLogReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.done.Wait()
+	return nil
+}
+
+// poll reads the files matching the include patterns and persists their offsets.
+func (r *fileLogReceiver) poll(ctx context.Context) {
+	var files []*polledFile
+	for _, path := range r.findFiles() {
+		if f := r.openFile(path); f != nil {
+			files = append(files, f)
+		}
+	}
+	r.matchReaders(files)
+
+	readers := make([]*reader, 0, len(files))
+	for _, f := range files {
+		if ctx.Err() == nil {
+			r.readFile(ctx, f.file, f.size, f.reader)
+		}
+		_ = f.file.Close()
+		readers = append(readers, f.reader)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	r.readers = readers
+	r.firstPoll = false
+
+	if r.config.OffsetsFile != "" {
+		if err := saveOffsets(r.config.OffsetsFile, r.readers); err != nil {
+			r.logger.Error("Failed to save the offsets of the log files", zap.Error(err))
+		}
+	}
+}
+
+// findFiles returns the paths of the files matching the include patterns and none of the exclude patterns.
+func (r *fileLogReceiver) findFiles() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, include := range r.config.Include {
+		// The patterns are validated at creation.
+		matches, _ := filepath.Glob(include)
+		for _, path := range matches {
+			if !seen[path] && !r.excluded(path) {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+func (r *fileLogReceiver) excluded(path string) bool {
+	for _, exclude := range r.config.Exclude {
+		if matched, _ := filepath.Match(exclude, path); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// consume sends the log records read from the file at path to the next consumer.
+func (r *fileLogReceiver) consume(ctx context.Context, path string, records []string) error {
+	logs := pdata.NewLogs()
+	rls := logs.ResourceLogs()
+	rls.Resize(1)
+	ills := rls.At(0).InstrumentationLibraryLogs()
+	ills.Resize(1)
+	logSlice := ills.At(0).Logs()
+	logSlice.Resize(len(records))
+
+	now := pdata.TimestampFromTime(time.Now())
+	name := filepath.Base(path)
+	for i, record := range records {
+		lr := logSlice.At(i)
+		lr.SetTimestamp(now)
+		lr.Body().SetStringVal(record)
+		lr.Attributes().InsertString(attributeFileName, name)
+		lr.Attributes().InsertString(attributeFilePath, path)
+	}
+
+	obsCtx := obsreport.ReceiverContext(ctx, r.config.Name(), transport)
+	obsCtx = obsreport.StartLogsReceiveOp(obsCtx, r.config.Name(), transport)
+	err := r.next.ConsumeLogs(obsCtx, logs)
+	obsreport.EndLogsReceiveOp(obsCtx, format, len(records), err)
+	if err != nil {
+		r.logger.Debug("Failed to consume log records", zap.String("path", path), zap.Error(err))
+	}
+	return err
+}
@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/text/encoding/unicode"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func newTestReceiver(t *testing.T, dir string, modify func(cfg *Config)) (*fileLogReceiver, *consumertest.LogsSink) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(dir, "*.log")}
+	cfg.StartAt = startAtBeginning
+	modify(cfg)
+	next := new(consumertest.LogsSink)
+	r, err := newFileLogReceiver(zap.NewNop(), cfg, next)
+	require.NoError(t, err)
+	r.(*fileLogReceiver).firstPoll = true
+	return r.(*fileLogReceiver), next
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "filelog")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
+
+// bodies returns the bodies of the log records received by next, and their file paths.
+func bodies(next *consumertest.LogsSink) ([]string, []string) {
+	var bodies, paths []string
+	for _, logs := range next.AllLogs() {
+		lrs := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+		for i := 0; i < lrs.Len(); i++ {
+			bodies = append(bodies, lrs.At(i).Body().StringVal())
+			path, _ := lrs.At(i).Attributes().Get(attributeFilePath)
+			paths = append(paths, path.StringVal())
+		}
+	}
+	return bodies, paths
+}
+
+func TestStartAt(t *testing.T) {
+	for _, startAt := range []string{startAtBeginning, startAtEnd} {
+		t.Run(startAt, func(t *testing.T) {
+			dir := tempDir(t)
+			writeFile(t, filepath.Join(dir, "first.log"), "a\nb\n")
+			r, next := newTestReceiver(t, dir, func(cfg *Config) {
+				cfg.StartAt = startAt
+			})
+
+			r.poll(context.Background())
+			writeFile(t, filepath.Join(dir, "first.log"), "c\n")
+			// The files found after the first poll are read from the beginning.
+			writeFile(t, filepath.Join(dir, "second.log"), "d\n")
+			r.poll(context.Background())
+
+			got, _ := bodies(next)
+			if startAt == startAtBeginning {
+				assert.Equal(t, []string{"a", "b", "c", "d"}, got)
+			} else {
+				assert.Equal(t, []string{"c", "d"}, got)
+			}
+		})
+	}
+}
+
+func TestIncludeExclude(t *testing.T) {
+	dir := tempDir(t)
+	writeFile(t, filepath.Join(dir, "a.log"), "a\n")
+	writeFile(t, filepath.Join(dir, "b.log"), "b\n")
+	writeFile(t, filepath.Join(dir, "c.txt"), "c\n")
+	r, next := newTestReceiver(t, dir, func(cfg *Config) {
+		cfg.Exclude = []string{filepath.Join(dir, "b.*")}
+	})
+
+	r.poll(context.Background())
+	got, paths := bodies(next)
+	assert.Equal(t, []string{"a"}, got)
+	assert.Equal(t, []string{filepath.Join(dir, "a.log")}, paths)
+	name, _ := next.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes().Get(attributeFileName)
+	assert.Equal(t, "a.log", name.StringVal())
+}
+
+func TestPartialLine(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "a\r\nb")
+	r, next := newTestReceiver(t, dir, func(cfg *Config) {})
+
+	r.poll(context.Background())
+	got, _ := bodies(next)
+	assert.Equal(t, []string{"a"}, got)
+
+	// The file did not grow: the incomplete line is complete.
+	r.poll(context.Background())
+	got, _ = bodies(next)
+	assert.Equal(t, []string{"a", "b"}, got)
+}
+
+func TestMultiline(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "  orphan\n2021-03-01 first\n  at a\n  at b\n2021-03-01 second\n")
+	r, next := newTestReceiver(t, dir, func(cfg *Config) {
+		cfg.Multiline = &MultilineConfig{LineStartPattern: `^\d{4}-\d{2}-\d{2}`}
+	})
+
+	r.poll(context.Background())
+	got, _ := bodies(next)
+	assert.Equal(t, []string{"  orphan", "2021-03-01 first\n  at a\n  at b"}, got)
+
+	writeFile(t, path, "  at c\n")
+	r.poll(context.Background())
+	got, _ = bodies(next)
+	assert.Len(t, got, 2)
+
+	// The pending record is complete once the file stops growing.
+	r.poll(context.Background())
+	got, _ = bodies(next)
+	assert.Equal(t, []string{"  orphan", "2021-03-01 first\n  at a\n  at b", "2021-03-01 second\n  at c"}, got)
+	assert.Equal(t, int64(len("  orphan\n2021-03-01 first\n  at a\n  at b\n2021-03-01 second\n  at c\n")), r.readers[0].offset)
+}
+
+func TestEncoding(t *testing.T) {
+	dir := tempDir(t)
+	content, err := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder().String("héllo\nwörld\n")
+	require.NoError(t, err)
+	writeFile(t, filepath.Join(dir, "app.log"), content)
+	r, next := newTestReceiver(t, dir, func(cfg *Config) {
+		cfg.Encoding = "utf-16le"
+	})
+
+	r.poll(context.Background())
+	got, _ := bodies(next)
+	assert.Equal(t, []string{"héllo", "wörld"}, got)
+}
+
+func TestMaxLogSize(t *testing.T) {
+	dir := tempDir(t)
+	writeFile(t, filepath.Join(dir, "app.log"), "abcdefgh\nij\n")
+	r, next := newTestReceiver(t, dir, func(cfg *Config) {
+		cfg.MaxLogSize = 4
+	})
+
+	r.poll(context.Background())
+	got, _ := bodies(next)
+	assert.Equal(t, []string{"abcd", "efgh", "ij"}, got)
+}
+
+func TestRotation(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "a\n")
+	r, next := newTestReceiver(t, dir, func(cfg *Config) {
+		cfg.Include = []string{filepath.Join(dir, "*.log*")}
+	})
+	r.poll(context.Background())
+
+	// The file is renamed and still read from its offset.
+	writeFile(t, path, "b\n")
+	require.NoError(t, os.Rename(path, path+".1"))
+	writeFile(t, path, "c\n")
+	r.poll(context.Background())
+
+	got, paths := bodies(next)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, got)
+	assert.ElementsMatch(t, []string{path, path + ".1", path}, paths)
+
+	// The file is copied and truncated.
+	require.NoError(t, os.Remove(path+".1"))
+	require.NoError(t, os.Truncate(path, 0))
+	writeFile(t, path, "d\n")
+	r.poll(context.Background())
+	got, _ = bodies(next)
+	assert.ElementsMatch(t, []string{"a", "b", "c", "d"}, got)
+}
+
+func TestOffsetsFile(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "app.log")
+	offsetsFile := filepath.Join(dir, "offsets.json")
+	writeFile(t, path, "a\nb\n")
+	r, next := newTestReceiver(t, dir, func(cfg *Config) {
+		cfg.OffsetsFile = offsetsFile
+	})
+	r.poll(context.Background())
+	got, _ := bodies(next)
+	assert.Equal(t, []string{"a", "b"}, got)
+
+	// A restarted receiver reads the files from their persisted offsets.
+	writeFile(t, path, "c\n")
+	r, next = newTestReceiver(t, dir, func(cfg *Config) {
+		cfg.OffsetsFile = offsetsFile
+	})
+	r.readers, _ = loadOffsets(offsetsFile)
+	require.Len(t, r.readers, 1)
+	r.poll(context.Background())
+	got, _ = bodies(next)
+	assert.Equal(t, []string{"c"}, got)
+}
+
+func TestConsumeError(t *testing.T) {
+	dir := tempDir(t)
+	writeFile(t, filepath.Join(dir, "app.log"), "a\nb\n")
+	r, next := newTestReceiver(t, dir, func(cfg *Config) {})
+	r.next = consumertest.NewLogsErr(errors.New("refused"))
+	r.poll(context.Background())
+
+	// The refused records are read again.
+	r.next = next
+	r.poll(context.Background())
+	got, _ := bodies(next)
+	assert.Equal(t, []string{"a", "b"}, got)
+}
+
+func TestStartShutdown(t *testing.T) {
+	dir := tempDir(t)
+	offsetsFile := filepath.Join(dir, "offsets.json")
+	r, next := newTestReceiver(t, dir, func(cfg *Config) {
+		cfg.PollInterval = 10 * time.Millisecond
+		cfg.OffsetsFile = offsetsFile
+	})
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	writeFile(t, filepath.Join(dir, "app.log"), "a\n")
+	require.Eventually(t, func() bool {
+		return next.LogRecordsCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	readers, err := loadOffsets(offsetsFile)
+	require.NoError(t, err)
+	require.Len(t, readers, 1)
+	assert.Equal(t, int64(2), readers[0].offset)
+}
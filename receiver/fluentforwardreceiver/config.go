@@ -16,6 +16,7 @@ package fluentforwardreceiver
 
 import (
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 // Config defines configuration for the SignalFx receiver.
@@ -26,4 +27,15 @@ type Config struct {
 	// of the form `<ip addr>:<port>` (TCP) or `unix://<socket_path>` (Unix
 	// domain socket).
 	ListenAddress string `mapstructure:"endpoint"`
+
+	// TLSSetting configures the TLS termination of the connections. They are not encrypted if nil.
+	TLSSetting *configtls.TLSServerSetting `mapstructure:"tls_settings"`
+
+	// SharedKey is the key the clients must prove they know with the handshake of the forward protocol before sending
+	// events. No handshake is performed if empty.
+	SharedKey string `mapstructure:"shared_key"`
+
+	// SelfHostname is the hostname of the receiver sent to the clients during the handshake, the hostname of the
+	// machine if empty.
+	SelfHostname string `mapstructure:"self_hostname"`
 }
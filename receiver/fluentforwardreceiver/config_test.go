@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -39,9 +40,26 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 1)
+	assert.Equal(t, len(cfg.Receivers), 2)
 
 	r0 := cfg.Receivers["fluentforward"]
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
 
+	r1 := cfg.Receivers["fluentforward/secure"]
+	assert.Equal(t, r1, &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: typeStr,
+			NameVal: "fluentforward/secure",
+		},
+		ListenAddress: "0.0.0.0:24224",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "/etc/otel/server.crt",
+				KeyFile:  "/etc/otel/server.key",
+			},
+		},
+		SharedKey:    "secret",
+		SelfHostname: "collector.example.com",
+	})
+
 }
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// The time a client has to complete the handshake after connecting.
+const handshakeTimeout = 30 * time.Second
+
+var errSharedKeyMismatch = errors.New("shared key mismatch")
+
+// handshake authenticates the clients with the shared key of the handshake of the forward protocol, see
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1#handshake-messages. The user
+// authentication is not supported: the HELO message asks for none.
+type handshake struct {
+	sharedKey    string
+	selfHostname string
+}
+
+// ping is the PING message sent by the client in response to the HELO message.
+type ping struct {
+	hostname        []byte
+	sharedKeySalt   []byte
+	sharedKeyDigest []byte
+}
+
+// perform sends the HELO message to the client, checks its PING message and responds with the PONG message. The
+// reader must be the one the events are read with afterwards, since it buffers the data of the connection.
+func (h *handshake) perform(conn net.Conn, reader *msgp.Reader) error {
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return err
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	writer := msgp.NewWriter(conn)
+	if err := writeHelo(writer, nonce); err != nil {
+		return fmt.Errorf("failed to send HELO: %v", err)
+	}
+
+	p, err := readPing(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read PING: %v", err)
+	}
+
+	expected := sharedKeyDigest(p.sharedKeySalt, p.hostname, nonce, h.sharedKey)
+	if subtle.ConstantTimeCompare([]byte(expected), p.sharedKeyDigest) != 1 {
+		// The error of the PONG message is not relevant, the connection is closed anyway.
+		_ = writePong(writer, false, errSharedKeyMismatch.Error(), "", "")
+		return errSharedKeyMismatch
+	}
+
+	digest := sharedKeyDigest(p.sharedKeySalt, []byte(h.selfHostname), nonce, h.sharedKey)
+	if err := writePong(writer, true, "", h.selfHostname, digest); err != nil {
+		return fmt.Errorf("failed to send PONG: %v", err)
+	}
+
+	// The events are read without deadline.
+	return conn.SetDeadline(time.Time{})
+}
+
+// sharedKeyDigest returns the hex-encoded SHA-512 digest of the concatenation of the salt, the hostname, the nonce
+// and the key.
+func sharedKeyDigest(salt, hostname, nonce []byte, key string) string {
+	h := sha512.New()
+	h.Write(salt)
+	h.Write(hostname)
+	h.Write(nonce)
+	h.Write([]byte(key))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeHelo writes ["HELO", {"nonce": nonce, "auth": "", "keepalive": true}].
+func writeHelo(w *msgp.Writer, nonce []byte) error {
+	if err := w.WriteArrayHeader(2); err != nil {
+		return err
+	}
+	if err := w.WriteString("HELO"); err != nil {
+		return err
+	}
+	if err := w.WriteMapHeader(3); err != nil {
+		return err
+	}
+	if err := w.WriteString("nonce"); err != nil {
+		return err
+	}
+	if err := w.WriteBytes(nonce); err != nil {
+		return err
+	}
+	// An empty salt means that no user authentication is required.
+	if err := w.WriteString("auth"); err != nil {
+		return err
+	}
+	if err := w.WriteBytes([]byte{}); err != nil {
+		return err
+	}
+	if err := w.WriteString("keepalive"); err != nil {
+		return err
+	}
+	if err := w.WriteBool(true); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// readPing reads ["PING", hostname, shared key salt, shared key digest, username, password digest].
+func readPing(r *msgp.Reader) (*ping, error) {
+	size, err := r.ReadArrayHeader()
+	if err != nil {
+		return nil, err
+	}
+	if size != 6 {
+		return nil, fmt.Errorf("expected 6 elements, got %d", size)
+	}
+
+	msgType, err := readStrOrBin(r)
+	if err != nil {
+		return nil, err
+	}
+	if string(msgType) != "PING" {
+		return nil, fmt.Errorf("unexpected message type %q", msgType)
+	}
+
+	p := &ping{}
+	if p.hostname, err = readStrOrBin(r); err != nil {
+		return nil, msgp.WrapError(err, "hostname")
+	}
+	if p.sharedKeySalt, err = readStrOrBin(r); err != nil {
+		return nil, msgp.WrapError(err, "shared_key_salt")
+	}
+	if p.sharedKeyDigest, err = readStrOrBin(r); err != nil {
+		return nil, msgp.WrapError(err, "shared_key_hexdigest")
+	}
+	// The username and the password digest are ignored since no user authentication is required.
+	for i := 0; i < 2; i++ {
+		if err = r.Skip(); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// readStrOrBin reads a string or a binary value, the clients encoding the fields of the PING message either way.
+func readStrOrBin(r *msgp.Reader) ([]byte, error) {
+	t, err := r.NextType()
+	if err != nil {
+		return nil, err
+	}
+	if t == msgp.BinType {
+		return r.ReadBytes(nil)
+	}
+	return r.ReadStringAsBytes(nil)
+}
+
+// writePong writes ["PONG", authenticated, reason, hostname, shared key digest].
+func writePong(w *msgp.Writer, authenticated bool, reason, hostname, digest string) error {
+	if err := w.WriteArrayHeader(5); err != nil {
+		return err
+	}
+	if err := w.WriteString("PONG"); err != nil {
+		return err
+	}
+	if err := w.WriteBool(authenticated); err != nil {
+		return err
+	}
+	if err := w.WriteString(reason); err != nil {
+		return err
+	}
+	if err := w.WriteString(hostname); err != nil {
+		return err
+	}
+	if err := w.WriteString(digest); err != nil {
+		return err
+	}
+	return w.Flush()
+}
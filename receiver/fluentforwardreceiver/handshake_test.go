@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// clientHandshake performs the client side of the handshake and returns the PONG message.
+func clientHandshake(t *testing.T, conn net.Conn, sharedKey string) []interface{} {
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+	reader := msgp.NewReader(conn)
+
+	helo, err := reader.ReadIntf()
+	require.NoError(t, err)
+	heloArr := helo.([]interface{})
+	require.Len(t, heloArr, 2)
+	assert.Equal(t, "HELO", heloArr[0])
+	options := heloArr[1].(map[string]interface{})
+	nonce := options["nonce"].([]byte)
+	assert.Len(t, nonce, 16)
+	assert.Empty(t, options["auth"])
+	assert.Equal(t, true, options["keepalive"])
+
+	salt := "salt"
+	writer := msgp.NewWriter(conn)
+	require.NoError(t, writer.WriteIntf([]interface{}{
+		"PING",
+		"client",
+		salt,
+		sharedKeyDigest([]byte(salt), []byte("client"), nonce, sharedKey),
+		"",
+		"",
+	}))
+	require.NoError(t, writer.Flush())
+
+	pong, err := reader.ReadIntf()
+	require.NoError(t, err)
+	pongArr := pong.([]interface{})
+	require.Len(t, pongArr, 5)
+	assert.Equal(t, "PONG", pongArr[0])
+	if pongArr[1] == true {
+		assert.Equal(t, "collector", pongArr[3])
+		assert.Equal(t, sharedKeyDigest([]byte(salt), []byte("collector"), nonce, "secret"), pongArr[4])
+	}
+	require.NoError(t, conn.SetDeadline(time.Time{}))
+	return pongArr
+}
+
+func TestHandshake(t *testing.T) {
+	connect, next, _, cancel := setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+		SharedKey:     "secret",
+		SelfHostname:  "collector",
+	})
+	defer cancel()
+
+	conn := connect()
+	pong := clientHandshake(t, conn, "secret")
+	assert.Equal(t, true, pong[1])
+	assert.Equal(t, "", pong[2])
+
+	eventBytes := parseHexDump("testdata/message-event")
+	_, err := conn.Write(eventBytes)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool {
+		return len(next.AllLogs()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHandshakeSharedKeyMismatch(t *testing.T) {
+	connect, next, observedLogs, cancel := setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+		SharedKey:     "secret",
+		SelfHostname:  "collector",
+	})
+	defer cancel()
+
+	conn := connect()
+	pong := clientHandshake(t, conn, "wrong")
+	assert.Equal(t, false, pong[1])
+	assert.Equal(t, "shared key mismatch", pong[2])
+
+	waitForConnectionClose(t, conn)
+	require.Eventually(t, func() bool {
+		return len(observedLogs.FilterMessageSnippet("Unexpected").All()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, next.AllLogs(), 0)
+}
+
+func TestHandshakeEventsWithoutPing(t *testing.T) {
+	connect, next, _, cancel := setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+		SharedKey:     "secret",
+		SelfHostname:  "collector",
+	})
+	defer cancel()
+
+	conn := connect()
+	_, err := conn.Write(parseHexDump("testdata/message-event"))
+	require.NoError(t, err)
+
+	// The event is not a PING message: the connection is closed after the HELO message.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = msgp.NewReader(conn).ReadIntf()
+	require.NoError(t, err)
+	waitForConnectionClose(t, conn)
+	assert.Len(t, next.AllLogs(), 0)
+}
+
+func TestTLS(t *testing.T) {
+	connect, next, _, cancel := setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "../../config/configtls/testdata/test-cert.pem",
+				KeyFile:  "../../config/configtls/testdata/test-key.pem",
+			},
+		},
+		SharedKey:    "secret",
+		SelfHostname: "collector",
+	})
+	defer cancel()
+
+	conn := tls.Client(connect(), &tls.Config{InsecureSkipVerify: true})
+	pong := clientHandshake(t, conn, "secret")
+	assert.Equal(t, true, pong[1])
+
+	_, err := conn.Write(parseHexDump("testdata/message-event"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool {
+		return len(next.AllLogs()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -16,7 +16,9 @@ package fluentforwardreceiver
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
+	"os"
 	"strings"
 
 	"go.uber.org/zap"
@@ -43,7 +45,22 @@ func newFluentReceiver(logger *zap.Logger, conf *Config, next consumer.LogsConsu
 
 	collector := newCollector(eventCh, next, logger)
 
-	server := newServer(eventCh, logger)
+	var h *handshake
+	if conf.SharedKey != "" {
+		h = &handshake{
+			sharedKey:    conf.SharedKey,
+			selfHostname: conf.SelfHostname,
+		}
+		if h.selfHostname == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return nil, err
+			}
+			h.selfHostname = hostname
+		}
+	}
+
+	server := newServer(eventCh, logger, h)
 
 	return &fluentReceiver{
 		collector: collector,
@@ -54,6 +71,14 @@ func newFluentReceiver(logger *zap.Logger, conf *Config, next consumer.LogsConsu
 }
 
 func (r *fluentReceiver) Start(ctx context.Context, _ component.Host) error {
+	var tlsCfg *tls.Config
+	if r.conf.TLSSetting != nil {
+		var err error
+		if tlsCfg, err = r.conf.TLSSetting.LoadTLSConfig(); err != nil {
+			return err
+		}
+	}
+
 	receiverCtx, cancel := context.WithCancel(ctx)
 	r.cancel = cancel
 
@@ -77,6 +102,9 @@ func (r *fluentReceiver) Start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 
+	if tlsCfg != nil {
+		listener = tls.NewListener(listener, tlsCfg)
+	}
 	r.listener = listener
 
 	r.server.Start(receiverCtx, listener)
@@ -37,16 +37,18 @@ import (
 )
 
 func setupServer(t *testing.T) (func() net.Conn, *consumertest.LogsSink, *observer.ObservedLogs, context.CancelFunc) {
+	return setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+	})
+}
+
+func setupServerWithConfig(t *testing.T, conf *Config) (func() net.Conn, *consumertest.LogsSink, *observer.ObservedLogs, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	next := new(consumertest.LogsSink)
 	logCore, logObserver := observer.New(zap.DebugLevel)
 	logger := zap.New(logCore)
 
-	conf := &Config{
-		ListenAddress: "127.0.0.1:0",
-	}
-
 	receiver, err := newFluentReceiver(logger, conf, next)
 	require.NoError(t, err)
 	require.NoError(t, receiver.Start(ctx, nil))
@@ -37,12 +37,15 @@ const readBufferSize = 10 * 1024
 type server struct {
 	outCh  chan<- Event
 	logger *zap.Logger
+	// handshake authenticates the connections, they are not if nil.
+	handshake *handshake
 }
 
-func newServer(outCh chan<- Event, logger *zap.Logger) *server {
+func newServer(outCh chan<- Event, logger *zap.Logger, handshake *handshake) *server {
 	return &server{
-		outCh:  outCh,
-		logger: logger,
+		outCh:     outCh,
+		logger:    logger,
+		handshake: handshake,
 	}
 }
 
@@ -91,6 +94,12 @@ func (s *server) handleConnections(ctx context.Context, listener net.Listener) {
 func (s *server) handleConn(ctx context.Context, conn net.Conn) error {
 	reader := msgp.NewReaderSize(conn, readBufferSize)
 
+	if s.handshake != nil {
+		if err := s.handshake.perform(conn, reader); err != nil {
+			return fmt.Errorf("handshake failed: %v", err)
+		}
+	}
+
 	for {
 		mode, err := DetermineNextEventMode(reader.R)
 		if err != nil {
@@ -15,6 +15,8 @@
 package hostmetricsreceiver
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
@@ -23,4 +25,17 @@ import (
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	Scrapers                                map[string]internal.Config `mapstructure:"-"`
+	ResourceAttributes                      ResourceAttributesConfig   `mapstructure:"resource_attributes"`
+}
+
+// ResourceAttributesConfig defines the detection of the resource attributes of
+// the host, that are attached to all the metrics scraped by the receiver.
+type ResourceAttributesConfig struct {
+	// Detectors lists the detectors of the resource attributes, among "system",
+	// "ec2", "gce" and "azure". The attributes detected by the first detectors
+	// take precedence. No attributes are detected if the list is empty.
+	Detectors []string `mapstructure:"detectors"`
+
+	// Timeout of the requests to the metadata services of the cloud providers.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
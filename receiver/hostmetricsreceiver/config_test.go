@@ -27,15 +27,19 @@ import (
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/loadscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/memoryscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/uptimescraper"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
@@ -70,11 +74,15 @@ func TestLoadConfig(t *testing.T) {
 			CollectionInterval: 30 * time.Second,
 		},
 		Scrapers: map[string]internal.Config{
-			cpuscraper.TypeStr:        &cpuscraper.Config{},
-			diskscraper.TypeStr:       &diskscraper.Config{},
-			loadscraper.TypeStr:       &loadscraper.Config{},
-			filesystemscraper.TypeStr: &filesystemscraper.Config{},
-			memoryscraper.TypeStr:     &memoryscraper.Config{},
+			cgroupscraper.TypeStr: &cgroupscraper.Config{},
+			cpuscraper.TypeStr:    &cpuscraper.Config{},
+			diskscraper.TypeStr:   &diskscraper.Config{},
+			loadscraper.TypeStr:   &loadscraper.Config{PerCPU: true},
+			filesystemscraper.TypeStr: &filesystemscraper.Config{
+				ConfigSettings: internal.ConfigSettings{CollectionIntervalVal: 5 * time.Minute},
+			},
+			gpuscraper.TypeStr:    &gpuscraper.Config{},
+			memoryscraper.TypeStr: &memoryscraper.Config{},
 			networkscraper.TypeStr: &networkscraper.Config{
 				Include: networkscraper.MatchConfig{
 					Interfaces: []string{"test1"},
@@ -83,13 +91,34 @@ func TestLoadConfig(t *testing.T) {
 			},
 			processesscraper.TypeStr: &processesscraper.Config{},
 			pagingscraper.TypeStr:    &pagingscraper.Config{},
+			sensorsscraper.TypeStr:   &sensorsscraper.Config{},
+			uptimescraper.TypeStr:    &uptimescraper.Config{},
 			processscraper.TypeStr: &processscraper.Config{
 				Include: processscraper.MatchConfig{
 					Names:  []string{"test2", "test3"},
 					Config: filterset.Config{MatchType: "regexp"},
 				},
+				Exclude: processscraper.MatchConfig{
+					CommandLines: []string{"--debug"},
+					Pids:         []int32{1},
+					Users:        []string{"root"},
+					Config:       filterset.Config{MatchType: "regexp"},
+				},
+				RedactCommandLines: []processscraper.RedactConfig{
+					{Pattern: `(--password=)\S+`, Replacement: "${1}***"},
+				},
+				Top: processscraper.TopConfig{
+					Count:  10,
+					SortBy: "memory",
+				},
+				TolerateMetadataErrors: true,
+				EnvironmentVariables:   []string{"DEPLOYMENT_ID", "SERVICE_NAME"},
 			},
 		},
+		ResourceAttributes: ResourceAttributesConfig{
+			Detectors: []string{"ec2", "system"},
+			Timeout:   2 * time.Second,
+		},
 	}
 
 	assert.Equal(t, expectedConfig, r1)
@@ -18,6 +18,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -26,16 +28,21 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/resourcedetection"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/loadscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/memoryscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/uptimescraper"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
@@ -50,14 +57,18 @@ const (
 
 var (
 	scraperFactories = map[string]internal.ScraperFactory{
+		cgroupscraper.TypeStr:     &cgroupscraper.Factory{},
 		cpuscraper.TypeStr:        &cpuscraper.Factory{},
 		diskscraper.TypeStr:       &diskscraper.Factory{},
 		loadscraper.TypeStr:       &loadscraper.Factory{},
 		filesystemscraper.TypeStr: &filesystemscraper.Factory{},
+		gpuscraper.TypeStr:        &gpuscraper.Factory{},
 		memoryscraper.TypeStr:     &memoryscraper.Factory{},
 		networkscraper.TypeStr:    &networkscraper.Factory{},
 		pagingscraper.TypeStr:     &pagingscraper.Factory{},
 		processesscraper.TypeStr:  &processesscraper.Factory{},
+		sensorsscraper.TypeStr:    &sensorsscraper.Factory{},
+		uptimescraper.TypeStr:     &uptimescraper.Factory{},
 	}
 
 	resourceScraperFactories = map[string]internal.ResourceScraperFactory{
@@ -137,7 +148,13 @@ func getScraperFactory(key string) (internal.BaseFactory, bool) {
 
 // createDefaultConfig creates the default configuration for receiver.
 func createDefaultConfig() configmodels.Receiver {
-	return &Config{ScraperControllerSettings: scraperhelper.DefaultScraperControllerSettings(typeStr)}
+	return &Config{
+		ScraperControllerSettings: scraperhelper.DefaultScraperControllerSettings(typeStr),
+		ResourceAttributes: ResourceAttributesConfig{
+			Detectors: []string{resourcedetection.SystemDetector},
+			Timeout:   5 * time.Second,
+		},
+	}
 }
 
 // createMetricsReceiver creates a metrics receiver based on provided config.
@@ -149,17 +166,81 @@ func createMetricsReceiver(
 ) (component.MetricsReceiver, error) {
 	oCfg := cfg.(*Config)
 
-	addScraperOptions, err := createAddScraperOptions(ctx, params.Logger, oCfg, scraperFactories, resourceScraperFactories)
-	if err != nil {
+	if err := resourcedetection.Validate(oCfg.ResourceAttributes.Detectors); err != nil {
 		return nil, err
 	}
+	if len(oCfg.ResourceAttributes.Detectors) > 0 {
+		consumer = newResourceConsumer(consumer, params.Logger, oCfg.ResourceAttributes)
+	}
 
-	return scraperhelper.NewScraperControllerReceiver(
-		&oCfg.ScraperControllerSettings,
-		params.Logger,
-		consumer,
-		addScraperOptions...,
-	)
+	// the scrapers sharing the same collection interval are called by the same
+	// scraper controller, one controller is created for each interval
+	intervalConfigs := splitConfigByCollectionInterval(oCfg)
+	receivers := make([]component.Receiver, 0, len(intervalConfigs))
+	for _, intervalConfig := range intervalConfigs {
+		addScraperOptions, err := createAddScraperOptions(ctx, params.Logger, intervalConfig, scraperFactories, resourceScraperFactories)
+		if err != nil {
+			return nil, err
+		}
+
+		receiver, err := scraperhelper.NewScraperControllerReceiver(
+			&intervalConfig.ScraperControllerSettings,
+			params.Logger,
+			consumer,
+			addScraperOptions...,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		receivers = append(receivers, receiver)
+	}
+
+	if len(receivers) == 1 {
+		return receivers[0], nil
+	}
+	return &multiReceiver{receivers: receivers}, nil
+}
+
+// splitConfigByCollectionInterval splits the configuration of the receiver
+// into one configuration for each distinct collection interval of the
+// scrapers, sorted by interval. The scrapers not overriding the collection
+// interval use the collection interval of the receiver.
+func splitConfigByCollectionInterval(cfg *Config) []*Config {
+	configs := map[time.Duration]*Config{}
+	for key, scraperCfg := range cfg.Scrapers {
+		interval := scraperCfg.CollectionInterval()
+		if interval == 0 {
+			interval = cfg.CollectionInterval
+		}
+
+		intervalConfig, ok := configs[interval]
+		if !ok {
+			intervalConfig = &Config{
+				ScraperControllerSettings: cfg.ScraperControllerSettings,
+				Scrapers:                  map[string]internal.Config{},
+			}
+			intervalConfig.CollectionInterval = interval
+			configs[interval] = intervalConfig
+		}
+		intervalConfig.Scrapers[key] = scraperCfg
+	}
+
+	if len(configs) == 0 {
+		return []*Config{cfg}
+	}
+
+	intervals := make([]time.Duration, 0, len(configs))
+	for interval := range configs {
+		intervals = append(intervals, interval)
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+
+	sorted := make([]*Config, 0, len(intervals))
+	for _, interval := range intervals {
+		sorted = append(sorted, configs[interval])
+	}
+	return sorted
 }
 
 func createAddScraperOptions(
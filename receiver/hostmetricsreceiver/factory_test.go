@@ -18,15 +18,21 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 var creationParams = component.ReceiverCreateParams{Logger: zap.NewNop()}
@@ -64,3 +70,54 @@ func TestCreateReceiver_ScraperKeyConfigError(t *testing.T) {
 	_, err := factory.CreateMetricsReceiver(context.Background(), creationParams, cfg, consumertest.NewMetricsNop())
 	assert.EqualError(t, err, fmt.Sprintf("host metrics scraper factory not found for key: %q", errorKey))
 }
+
+func TestSplitConfigByCollectionInterval(t *testing.T) {
+	cfg := &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{CollectionInterval: time.Minute},
+		Scrapers: map[string]internal.Config{
+			"default": &mockConfig{},
+			"slow":    &mockConfig{ConfigSettings: internal.ConfigSettings{CollectionIntervalVal: 5 * time.Minute}},
+			"fast":    &mockConfig{ConfigSettings: internal.ConfigSettings{CollectionIntervalVal: 10 * time.Second}},
+			"same":    &mockConfig{ConfigSettings: internal.ConfigSettings{CollectionIntervalVal: time.Minute}},
+		},
+	}
+
+	configs := splitConfigByCollectionInterval(cfg)
+	assert.Len(t, configs, 3)
+
+	assert.Equal(t, 10*time.Second, configs[0].CollectionInterval)
+	assert.Equal(t, map[string]internal.Config{"fast": cfg.Scrapers["fast"]}, configs[0].Scrapers)
+
+	assert.Equal(t, time.Minute, configs[1].CollectionInterval)
+	assert.Equal(t, map[string]internal.Config{"default": cfg.Scrapers["default"], "same": cfg.Scrapers["same"]}, configs[1].Scrapers)
+
+	assert.Equal(t, 5*time.Minute, configs[2].CollectionInterval)
+	assert.Equal(t, map[string]internal.Config{"slow": cfg.Scrapers["slow"]}, configs[2].Scrapers)
+}
+
+func TestCreateReceiver_ScraperCollectionInterval(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Scrapers = map[string]internal.Config{
+		"cpu":        (&cpuscraper.Factory{}).CreateDefaultConfig(),
+		"filesystem": &filesystemscraper.Config{ConfigSettings: internal.ConfigSettings{CollectionIntervalVal: 5 * time.Minute}},
+	}
+
+	mReceiver, err := factory.CreateMetricsReceiver(context.Background(), creationParams, cfg, consumertest.NewMetricsNop())
+	assert.NoError(t, err)
+	require.IsType(t, &multiReceiver{}, mReceiver)
+	assert.Len(t, mReceiver.(*multiReceiver).receivers, 2)
+
+	assert.NoError(t, mReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, mReceiver.Shutdown(context.Background()))
+}
+
+func TestCreateReceiver_InvalidResourceDetector(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Scrapers = map[string]internal.Config{"cpu": (&cpuscraper.Factory{}).CreateDefaultConfig()}
+	cfg.ResourceAttributes.Detectors = []string{"unknown"}
+
+	_, err := factory.CreateMetricsReceiver(context.Background(), creationParams, cfg, consumertest.NewMetricsNop())
+	assert.EqualError(t, err, `unknown resource detector "unknown"`)
+}
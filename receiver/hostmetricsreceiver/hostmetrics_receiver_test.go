@@ -34,12 +34,15 @@ import (
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/loadscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/memoryscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/uptimescraper"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
@@ -55,6 +58,7 @@ var standardMetrics = []string{
 	"system.disk.pending_operations",
 	"system.filesystem.usage",
 	"system.memory.usage",
+	"system.memory.utilization",
 	"system.network.connections",
 	"system.network.dropped",
 	"system.network.errors",
@@ -62,13 +66,25 @@ var standardMetrics = []string{
 	"system.network.packets",
 	"system.paging.operations",
 	"system.paging.usage",
+	"system.uptime",
+	"system.boot_time",
 }
 
 var resourceMetrics = []string{
 	"process.cpu.time",
 	"process.memory.physical_usage",
 	"process.memory.virtual_usage",
+	"process.memory.utilization",
 	"process.disk.io",
+	"process.disk.operations",
+	"process.open_file_descriptors",
+	"process.threads",
+	"process.create_time",
+}
+
+var systemSpecificResourceMetrics = map[string][]string{
+	"linux":   {"process.memory.swap_usage", "process.open_file_descriptors.limit"},
+	"windows": {"process.memory.private_usage"},
 }
 
 var systemSpecificMetrics = map[string][]string{
@@ -77,17 +93,21 @@ var systemSpecificMetrics = map[string][]string{
 	"freebsd": {"system.filesystem.inodes.usage", "system.paging.faults", "system.processes.count"},
 	"openbsd": {"system.filesystem.inodes.usage", "system.paging.faults", "system.processes.created", "system.processes.count"},
 	"solaris": {"system.filesystem.inodes.usage", "system.paging.faults"},
+	"windows": {"system.memory.committed", "system.memory.commit_limit"},
 }
 
 var factories = map[string]internal.ScraperFactory{
 	cpuscraper.TypeStr:        &cpuscraper.Factory{},
 	diskscraper.TypeStr:       &diskscraper.Factory{},
 	filesystemscraper.TypeStr: &filesystemscraper.Factory{},
+	gpuscraper.TypeStr:        &gpuscraper.Factory{},
 	loadscraper.TypeStr:       &loadscraper.Factory{},
 	memoryscraper.TypeStr:     &memoryscraper.Factory{},
 	networkscraper.TypeStr:    &networkscraper.Factory{},
 	pagingscraper.TypeStr:     &pagingscraper.Factory{},
 	processesscraper.TypeStr:  &processesscraper.Factory{},
+	sensorsscraper.TypeStr:    &sensorsscraper.Factory{},
+	uptimescraper.TypeStr:     &uptimescraper.Factory{},
 }
 
 var resourceFactories = map[string]internal.ResourceScraperFactory{
@@ -113,6 +133,7 @@ func TestGatherMetrics_EndToEnd(t *testing.T) {
 			networkscraper.TypeStr:    &networkscraper.Config{},
 			pagingscraper.TypeStr:     &pagingscraper.Config{},
 			processesscraper.TypeStr:  &processesscraper.Config{},
+			uptimescraper.TypeStr:     &uptimescraper.Config{},
 		},
 	}
 
@@ -173,8 +194,9 @@ func assertIncludesExpectedMetrics(t *testing.T, got pdata.Metrics) {
 		return
 	}
 
-	assert.Equal(t, len(resourceMetrics), len(returnedResourceMetrics))
-	for _, expected := range resourceMetrics {
+	expectedResourceMetrics := append(resourceMetrics, systemSpecificResourceMetrics[runtime.GOOS]...)
+	assert.Equal(t, len(expectedResourceMetrics), len(returnedResourceMetrics))
+	for _, expected := range expectedResourceMetrics {
 		assert.Contains(t, returnedResourceMetrics, expected)
 	}
 }
@@ -202,7 +224,9 @@ func appendMapInto(m1 map[string]struct{}, m2 map[string]struct{}) {
 const mockTypeStr = "mock"
 const mockResourceTypeStr = "mockresource"
 
-type mockConfig struct{}
+type mockConfig struct {
+	internal.ConfigSettings
+}
 
 type mockFactory struct{ mock.Mock }
 type mockScraper struct{ mock.Mock }
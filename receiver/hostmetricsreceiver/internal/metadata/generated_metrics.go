@@ -55,57 +55,114 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	ProcessCPUTime              MetricIntf
-	ProcessDiskIo               MetricIntf
-	ProcessMemoryPhysicalUsage  MetricIntf
-	ProcessMemoryVirtualUsage   MetricIntf
-	SystemCPULoadAverage15m     MetricIntf
-	SystemCPULoadAverage1m      MetricIntf
-	SystemCPULoadAverage5m      MetricIntf
-	SystemCPUTime               MetricIntf
-	SystemDiskIo                MetricIntf
-	SystemDiskIoTime            MetricIntf
-	SystemDiskMerged            MetricIntf
-	SystemDiskOperationTime     MetricIntf
-	SystemDiskOperations        MetricIntf
-	SystemDiskPendingOperations MetricIntf
-	SystemDiskWeightedIoTime    MetricIntf
-	SystemFilesystemInodesUsage MetricIntf
-	SystemFilesystemUsage       MetricIntf
-	SystemMemoryUsage           MetricIntf
-	SystemNetworkConnections    MetricIntf
-	SystemNetworkDropped        MetricIntf
-	SystemNetworkErrors         MetricIntf
-	SystemNetworkIo             MetricIntf
-	SystemNetworkPackets        MetricIntf
-	SystemPagingFaults          MetricIntf
-	SystemPagingOperations      MetricIntf
-	SystemPagingUsage           MetricIntf
-	SystemProcessesCount        MetricIntf
-	SystemProcessesCreated      MetricIntf
+	CgroupCPULimit                  MetricIntf
+	CgroupCPUPeriods                MetricIntf
+	CgroupCPUThrottledPeriods       MetricIntf
+	CgroupCPUThrottledTime          MetricIntf
+	CgroupMemoryLimit               MetricIntf
+	CgroupMemoryUsage               MetricIntf
+	ProcessCPUTime                  MetricIntf
+	ProcessCreateTime               MetricIntf
+	ProcessDiskIo                   MetricIntf
+	ProcessDiskOperations           MetricIntf
+	ProcessMemoryPhysicalUsage      MetricIntf
+	ProcessMemoryPrivateUsage       MetricIntf
+	ProcessMemorySwapUsage          MetricIntf
+	ProcessMemoryUtilization        MetricIntf
+	ProcessMemoryVirtualUsage       MetricIntf
+	ProcessOpenFileDescriptors      MetricIntf
+	ProcessOpenFileDescriptorsLimit MetricIntf
+	ProcessThreads                  MetricIntf
+	SystemBootTime                  MetricIntf
+	SystemCPULoadAverage15m         MetricIntf
+	SystemCPULoadAverage15mPerCPU   MetricIntf
+	SystemCPULoadAverage1m          MetricIntf
+	SystemCPULoadAverage1mPerCPU    MetricIntf
+	SystemCPULoadAverage5m          MetricIntf
+	SystemCPULoadAverage5mPerCPU    MetricIntf
+	SystemCPUTime                   MetricIntf
+	SystemCPUUtilization            MetricIntf
+	SystemDiskIo                    MetricIntf
+	SystemDiskIoTime                MetricIntf
+	SystemDiskMerged                MetricIntf
+	SystemDiskOperationLatency      MetricIntf
+	SystemDiskOperationTime         MetricIntf
+	SystemDiskOperations            MetricIntf
+	SystemDiskPendingOperations     MetricIntf
+	SystemDiskWeightedIoTime        MetricIntf
+	SystemFilesystemInodesUsage     MetricIntf
+	SystemFilesystemUsage           MetricIntf
+	SystemGpuMemoryProcessUsage     MetricIntf
+	SystemGpuMemoryUsage            MetricIntf
+	SystemGpuTemperature            MetricIntf
+	SystemGpuUtilization            MetricIntf
+	SystemMemoryCommitLimit         MetricIntf
+	SystemMemoryCommitted           MetricIntf
+	SystemMemoryUsage               MetricIntf
+	SystemMemoryUtilization         MetricIntf
+	SystemNetworkConnections        MetricIntf
+	SystemNetworkDropped            MetricIntf
+	SystemNetworkErrors             MetricIntf
+	SystemNetworkIo                 MetricIntf
+	SystemNetworkPackets            MetricIntf
+	SystemPagingFaults              MetricIntf
+	SystemPagingOperations          MetricIntf
+	SystemPagingUsage               MetricIntf
+	SystemProcessesCount            MetricIntf
+	SystemProcessesCreated          MetricIntf
+	SystemSensorsFanSpeed           MetricIntf
+	SystemSensorsTemperature        MetricIntf
+	SystemUptime                    MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
+		"cgroup.cpu.limit",
+		"cgroup.cpu.periods",
+		"cgroup.cpu.throttled_periods",
+		"cgroup.cpu.throttled_time",
+		"cgroup.memory.limit",
+		"cgroup.memory.usage",
 		"process.cpu.time",
+		"process.create_time",
 		"process.disk.io",
+		"process.disk.operations",
 		"process.memory.physical_usage",
+		"process.memory.private_usage",
+		"process.memory.swap_usage",
+		"process.memory.utilization",
 		"process.memory.virtual_usage",
+		"process.open_file_descriptors",
+		"process.open_file_descriptors.limit",
+		"process.threads",
+		"system.boot_time",
 		"system.cpu.load_average.15m",
+		"system.cpu.load_average.15m_per_cpu",
 		"system.cpu.load_average.1m",
+		"system.cpu.load_average.1m_per_cpu",
 		"system.cpu.load_average.5m",
+		"system.cpu.load_average.5m_per_cpu",
 		"system.cpu.time",
+		"system.cpu.utilization",
 		"system.disk.io",
 		"system.disk.io_time",
 		"system.disk.merged",
+		"system.disk.operation_latency",
 		"system.disk.operation_time",
 		"system.disk.operations",
 		"system.disk.pending_operations",
 		"system.disk.weighted_io_time",
 		"system.filesystem.inodes.usage",
 		"system.filesystem.usage",
+		"system.gpu.memory.process_usage",
+		"system.gpu.memory.usage",
+		"system.gpu.temperature",
+		"system.gpu.utilization",
+		"system.memory.commit_limit",
+		"system.memory.committed",
 		"system.memory.usage",
+		"system.memory.utilization",
 		"system.network.connections",
 		"system.network.dropped",
 		"system.network.errors",
@@ -116,38 +173,71 @@ func (m *metricStruct) Names() []string {
 		"system.paging.usage",
 		"system.processes.count",
 		"system.processes.created",
+		"system.sensors.fan_speed",
+		"system.sensors.temperature",
+		"system.uptime",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"process.cpu.time":               Metrics.ProcessCPUTime,
-	"process.disk.io":                Metrics.ProcessDiskIo,
-	"process.memory.physical_usage":  Metrics.ProcessMemoryPhysicalUsage,
-	"process.memory.virtual_usage":   Metrics.ProcessMemoryVirtualUsage,
-	"system.cpu.load_average.15m":    Metrics.SystemCPULoadAverage15m,
-	"system.cpu.load_average.1m":     Metrics.SystemCPULoadAverage1m,
-	"system.cpu.load_average.5m":     Metrics.SystemCPULoadAverage5m,
-	"system.cpu.time":                Metrics.SystemCPUTime,
-	"system.disk.io":                 Metrics.SystemDiskIo,
-	"system.disk.io_time":            Metrics.SystemDiskIoTime,
-	"system.disk.merged":             Metrics.SystemDiskMerged,
-	"system.disk.operation_time":     Metrics.SystemDiskOperationTime,
-	"system.disk.operations":         Metrics.SystemDiskOperations,
-	"system.disk.pending_operations": Metrics.SystemDiskPendingOperations,
-	"system.disk.weighted_io_time":   Metrics.SystemDiskWeightedIoTime,
-	"system.filesystem.inodes.usage": Metrics.SystemFilesystemInodesUsage,
-	"system.filesystem.usage":        Metrics.SystemFilesystemUsage,
-	"system.memory.usage":            Metrics.SystemMemoryUsage,
-	"system.network.connections":     Metrics.SystemNetworkConnections,
-	"system.network.dropped":         Metrics.SystemNetworkDropped,
-	"system.network.errors":          Metrics.SystemNetworkErrors,
-	"system.network.io":              Metrics.SystemNetworkIo,
-	"system.network.packets":         Metrics.SystemNetworkPackets,
-	"system.paging.faults":           Metrics.SystemPagingFaults,
-	"system.paging.operations":       Metrics.SystemPagingOperations,
-	"system.paging.usage":            Metrics.SystemPagingUsage,
-	"system.processes.count":         Metrics.SystemProcessesCount,
-	"system.processes.created":       Metrics.SystemProcessesCreated,
+	"cgroup.cpu.limit":                    Metrics.CgroupCPULimit,
+	"cgroup.cpu.periods":                  Metrics.CgroupCPUPeriods,
+	"cgroup.cpu.throttled_periods":        Metrics.CgroupCPUThrottledPeriods,
+	"cgroup.cpu.throttled_time":           Metrics.CgroupCPUThrottledTime,
+	"cgroup.memory.limit":                 Metrics.CgroupMemoryLimit,
+	"cgroup.memory.usage":                 Metrics.CgroupMemoryUsage,
+	"process.cpu.time":                    Metrics.ProcessCPUTime,
+	"process.create_time":                 Metrics.ProcessCreateTime,
+	"process.disk.io":                     Metrics.ProcessDiskIo,
+	"process.disk.operations":             Metrics.ProcessDiskOperations,
+	"process.memory.physical_usage":       Metrics.ProcessMemoryPhysicalUsage,
+	"process.memory.private_usage":        Metrics.ProcessMemoryPrivateUsage,
+	"process.memory.swap_usage":           Metrics.ProcessMemorySwapUsage,
+	"process.memory.utilization":          Metrics.ProcessMemoryUtilization,
+	"process.memory.virtual_usage":        Metrics.ProcessMemoryVirtualUsage,
+	"process.open_file_descriptors":       Metrics.ProcessOpenFileDescriptors,
+	"process.open_file_descriptors.limit": Metrics.ProcessOpenFileDescriptorsLimit,
+	"process.threads":                     Metrics.ProcessThreads,
+	"system.boot_time":                    Metrics.SystemBootTime,
+	"system.cpu.load_average.15m":         Metrics.SystemCPULoadAverage15m,
+	"system.cpu.load_average.15m_per_cpu": Metrics.SystemCPULoadAverage15mPerCPU,
+	"system.cpu.load_average.1m":          Metrics.SystemCPULoadAverage1m,
+	"system.cpu.load_average.1m_per_cpu":  Metrics.SystemCPULoadAverage1mPerCPU,
+	"system.cpu.load_average.5m":          Metrics.SystemCPULoadAverage5m,
+	"system.cpu.load_average.5m_per_cpu":  Metrics.SystemCPULoadAverage5mPerCPU,
+	"system.cpu.time":                     Metrics.SystemCPUTime,
+	"system.cpu.utilization":              Metrics.SystemCPUUtilization,
+	"system.disk.io":                      Metrics.SystemDiskIo,
+	"system.disk.io_time":                 Metrics.SystemDiskIoTime,
+	"system.disk.merged":                  Metrics.SystemDiskMerged,
+	"system.disk.operation_latency":       Metrics.SystemDiskOperationLatency,
+	"system.disk.operation_time":          Metrics.SystemDiskOperationTime,
+	"system.disk.operations":              Metrics.SystemDiskOperations,
+	"system.disk.pending_operations":      Metrics.SystemDiskPendingOperations,
+	"system.disk.weighted_io_time":        Metrics.SystemDiskWeightedIoTime,
+	"system.filesystem.inodes.usage":      Metrics.SystemFilesystemInodesUsage,
+	"system.filesystem.usage":             Metrics.SystemFilesystemUsage,
+	"system.gpu.memory.process_usage":     Metrics.SystemGpuMemoryProcessUsage,
+	"system.gpu.memory.usage":             Metrics.SystemGpuMemoryUsage,
+	"system.gpu.temperature":              Metrics.SystemGpuTemperature,
+	"system.gpu.utilization":              Metrics.SystemGpuUtilization,
+	"system.memory.commit_limit":          Metrics.SystemMemoryCommitLimit,
+	"system.memory.committed":             Metrics.SystemMemoryCommitted,
+	"system.memory.usage":                 Metrics.SystemMemoryUsage,
+	"system.memory.utilization":           Metrics.SystemMemoryUtilization,
+	"system.network.connections":          Metrics.SystemNetworkConnections,
+	"system.network.dropped":              Metrics.SystemNetworkDropped,
+	"system.network.errors":               Metrics.SystemNetworkErrors,
+	"system.network.io":                   Metrics.SystemNetworkIo,
+	"system.network.packets":              Metrics.SystemNetworkPackets,
+	"system.paging.faults":                Metrics.SystemPagingFaults,
+	"system.paging.operations":            Metrics.SystemPagingOperations,
+	"system.paging.usage":                 Metrics.SystemPagingUsage,
+	"system.processes.count":              Metrics.SystemProcessesCount,
+	"system.processes.created":            Metrics.SystemProcessesCreated,
+	"system.sensors.fan_speed":            Metrics.SystemSensorsFanSpeed,
+	"system.sensors.temperature":          Metrics.SystemSensorsTemperature,
+	"system.uptime":                       Metrics.SystemUptime,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -156,40 +246,132 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 
 func (m *metricStruct) FactoriesByName() map[string]func() pdata.Metric {
 	return map[string]func() pdata.Metric{
-		Metrics.ProcessCPUTime.Name():              Metrics.ProcessCPUTime.New,
-		Metrics.ProcessDiskIo.Name():               Metrics.ProcessDiskIo.New,
-		Metrics.ProcessMemoryPhysicalUsage.Name():  Metrics.ProcessMemoryPhysicalUsage.New,
-		Metrics.ProcessMemoryVirtualUsage.Name():   Metrics.ProcessMemoryVirtualUsage.New,
-		Metrics.SystemCPULoadAverage15m.Name():     Metrics.SystemCPULoadAverage15m.New,
-		Metrics.SystemCPULoadAverage1m.Name():      Metrics.SystemCPULoadAverage1m.New,
-		Metrics.SystemCPULoadAverage5m.Name():      Metrics.SystemCPULoadAverage5m.New,
-		Metrics.SystemCPUTime.Name():               Metrics.SystemCPUTime.New,
-		Metrics.SystemDiskIo.Name():                Metrics.SystemDiskIo.New,
-		Metrics.SystemDiskIoTime.Name():            Metrics.SystemDiskIoTime.New,
-		Metrics.SystemDiskMerged.Name():            Metrics.SystemDiskMerged.New,
-		Metrics.SystemDiskOperationTime.Name():     Metrics.SystemDiskOperationTime.New,
-		Metrics.SystemDiskOperations.Name():        Metrics.SystemDiskOperations.New,
-		Metrics.SystemDiskPendingOperations.Name(): Metrics.SystemDiskPendingOperations.New,
-		Metrics.SystemDiskWeightedIoTime.Name():    Metrics.SystemDiskWeightedIoTime.New,
-		Metrics.SystemFilesystemInodesUsage.Name(): Metrics.SystemFilesystemInodesUsage.New,
-		Metrics.SystemFilesystemUsage.Name():       Metrics.SystemFilesystemUsage.New,
-		Metrics.SystemMemoryUsage.Name():           Metrics.SystemMemoryUsage.New,
-		Metrics.SystemNetworkConnections.Name():    Metrics.SystemNetworkConnections.New,
-		Metrics.SystemNetworkDropped.Name():        Metrics.SystemNetworkDropped.New,
-		Metrics.SystemNetworkErrors.Name():         Metrics.SystemNetworkErrors.New,
-		Metrics.SystemNetworkIo.Name():             Metrics.SystemNetworkIo.New,
-		Metrics.SystemNetworkPackets.Name():        Metrics.SystemNetworkPackets.New,
-		Metrics.SystemPagingFaults.Name():          Metrics.SystemPagingFaults.New,
-		Metrics.SystemPagingOperations.Name():      Metrics.SystemPagingOperations.New,
-		Metrics.SystemPagingUsage.Name():           Metrics.SystemPagingUsage.New,
-		Metrics.SystemProcessesCount.Name():        Metrics.SystemProcessesCount.New,
-		Metrics.SystemProcessesCreated.Name():      Metrics.SystemProcessesCreated.New,
+		Metrics.CgroupCPULimit.Name():                  Metrics.CgroupCPULimit.New,
+		Metrics.CgroupCPUPeriods.Name():                Metrics.CgroupCPUPeriods.New,
+		Metrics.CgroupCPUThrottledPeriods.Name():       Metrics.CgroupCPUThrottledPeriods.New,
+		Metrics.CgroupCPUThrottledTime.Name():          Metrics.CgroupCPUThrottledTime.New,
+		Metrics.CgroupMemoryLimit.Name():               Metrics.CgroupMemoryLimit.New,
+		Metrics.CgroupMemoryUsage.Name():               Metrics.CgroupMemoryUsage.New,
+		Metrics.ProcessCPUTime.Name():                  Metrics.ProcessCPUTime.New,
+		Metrics.ProcessCreateTime.Name():               Metrics.ProcessCreateTime.New,
+		Metrics.ProcessDiskIo.Name():                   Metrics.ProcessDiskIo.New,
+		Metrics.ProcessDiskOperations.Name():           Metrics.ProcessDiskOperations.New,
+		Metrics.ProcessMemoryPhysicalUsage.Name():      Metrics.ProcessMemoryPhysicalUsage.New,
+		Metrics.ProcessMemoryPrivateUsage.Name():       Metrics.ProcessMemoryPrivateUsage.New,
+		Metrics.ProcessMemorySwapUsage.Name():          Metrics.ProcessMemorySwapUsage.New,
+		Metrics.ProcessMemoryUtilization.Name():        Metrics.ProcessMemoryUtilization.New,
+		Metrics.ProcessMemoryVirtualUsage.Name():       Metrics.ProcessMemoryVirtualUsage.New,
+		Metrics.ProcessOpenFileDescriptors.Name():      Metrics.ProcessOpenFileDescriptors.New,
+		Metrics.ProcessOpenFileDescriptorsLimit.Name(): Metrics.ProcessOpenFileDescriptorsLimit.New,
+		Metrics.ProcessThreads.Name():                  Metrics.ProcessThreads.New,
+		Metrics.SystemBootTime.Name():                  Metrics.SystemBootTime.New,
+		Metrics.SystemCPULoadAverage15m.Name():         Metrics.SystemCPULoadAverage15m.New,
+		Metrics.SystemCPULoadAverage15mPerCPU.Name():   Metrics.SystemCPULoadAverage15mPerCPU.New,
+		Metrics.SystemCPULoadAverage1m.Name():          Metrics.SystemCPULoadAverage1m.New,
+		Metrics.SystemCPULoadAverage1mPerCPU.Name():    Metrics.SystemCPULoadAverage1mPerCPU.New,
+		Metrics.SystemCPULoadAverage5m.Name():          Metrics.SystemCPULoadAverage5m.New,
+		Metrics.SystemCPULoadAverage5mPerCPU.Name():    Metrics.SystemCPULoadAverage5mPerCPU.New,
+		Metrics.SystemCPUTime.Name():                   Metrics.SystemCPUTime.New,
+		Metrics.SystemCPUUtilization.Name():            Metrics.SystemCPUUtilization.New,
+		Metrics.SystemDiskIo.Name():                    Metrics.SystemDiskIo.New,
+		Metrics.SystemDiskIoTime.Name():                Metrics.SystemDiskIoTime.New,
+		Metrics.SystemDiskMerged.Name():                Metrics.SystemDiskMerged.New,
+		Metrics.SystemDiskOperationLatency.Name():      Metrics.SystemDiskOperationLatency.New,
+		Metrics.SystemDiskOperationTime.Name():         Metrics.SystemDiskOperationTime.New,
+		Metrics.SystemDiskOperations.Name():            Metrics.SystemDiskOperations.New,
+		Metrics.SystemDiskPendingOperations.Name():     Metrics.SystemDiskPendingOperations.New,
+		Metrics.SystemDiskWeightedIoTime.Name():        Metrics.SystemDiskWeightedIoTime.New,
+		Metrics.SystemFilesystemInodesUsage.Name():     Metrics.SystemFilesystemInodesUsage.New,
+		Metrics.SystemFilesystemUsage.Name():           Metrics.SystemFilesystemUsage.New,
+		Metrics.SystemGpuMemoryProcessUsage.Name():     Metrics.SystemGpuMemoryProcessUsage.New,
+		Metrics.SystemGpuMemoryUsage.Name():            Metrics.SystemGpuMemoryUsage.New,
+		Metrics.SystemGpuTemperature.Name():            Metrics.SystemGpuTemperature.New,
+		Metrics.SystemGpuUtilization.Name():            Metrics.SystemGpuUtilization.New,
+		Metrics.SystemMemoryCommitLimit.Name():         Metrics.SystemMemoryCommitLimit.New,
+		Metrics.SystemMemoryCommitted.Name():           Metrics.SystemMemoryCommitted.New,
+		Metrics.SystemMemoryUsage.Name():               Metrics.SystemMemoryUsage.New,
+		Metrics.SystemMemoryUtilization.Name():         Metrics.SystemMemoryUtilization.New,
+		Metrics.SystemNetworkConnections.Name():        Metrics.SystemNetworkConnections.New,
+		Metrics.SystemNetworkDropped.Name():            Metrics.SystemNetworkDropped.New,
+		Metrics.SystemNetworkErrors.Name():             Metrics.SystemNetworkErrors.New,
+		Metrics.SystemNetworkIo.Name():                 Metrics.SystemNetworkIo.New,
+		Metrics.SystemNetworkPackets.Name():            Metrics.SystemNetworkPackets.New,
+		Metrics.SystemPagingFaults.Name():              Metrics.SystemPagingFaults.New,
+		Metrics.SystemPagingOperations.Name():          Metrics.SystemPagingOperations.New,
+		Metrics.SystemPagingUsage.Name():               Metrics.SystemPagingUsage.New,
+		Metrics.SystemProcessesCount.Name():            Metrics.SystemProcessesCount.New,
+		Metrics.SystemProcessesCreated.Name():          Metrics.SystemProcessesCreated.New,
+		Metrics.SystemSensorsFanSpeed.Name():           Metrics.SystemSensorsFanSpeed.New,
+		Metrics.SystemSensorsTemperature.Name():        Metrics.SystemSensorsTemperature.New,
+		Metrics.SystemUptime.Name():                    Metrics.SystemUptime.New,
 	}
 }
 
 // Metrics contains a set of methods for each metric that help with
 // manipulating those metrics.
 var Metrics = &metricStruct{
+	&metricImpl{
+		"cgroup.cpu.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("cgroup.cpu.limit")
+			metric.SetDescription("Number of CPUs the control group of the collector is allowed to use (Linux only).")
+			metric.SetUnit("{cpus}")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
+	&metricImpl{
+		"cgroup.cpu.periods",
+		func(metric pdata.Metric) {
+			metric.SetName("cgroup.cpu.periods")
+			metric.SetDescription("Number of CPU bandwidth enforcement periods elapsed for the control group of the collector (Linux only).")
+			metric.SetUnit("{periods}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"cgroup.cpu.throttled_periods",
+		func(metric pdata.Metric) {
+			metric.SetName("cgroup.cpu.throttled_periods")
+			metric.SetDescription("Number of CPU bandwidth enforcement periods the control group of the collector was throttled in (Linux only).")
+			metric.SetUnit("{periods}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"cgroup.cpu.throttled_time",
+		func(metric pdata.Metric) {
+			metric.SetName("cgroup.cpu.throttled_time")
+			metric.SetDescription("Total time the control group of the collector was throttled for (Linux only).")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeDoubleSum)
+			metric.DoubleSum().SetIsMonotonic(true)
+			metric.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"cgroup.memory.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("cgroup.memory.limit")
+			metric.SetDescription("Memory limit of the control group of the collector (Linux only).")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"cgroup.memory.usage",
+		func(metric pdata.Metric) {
+			metric.SetName("cgroup.memory.usage")
+			metric.SetDescription("Memory used by the control group of the collector (Linux only).")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(false)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"process.cpu.time",
 		func(metric pdata.Metric) {
@@ -201,6 +383,15 @@ var Metrics = &metricStruct{
 			metric.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"process.create_time",
+		func(metric pdata.Metric) {
+			metric.SetName("process.create_time")
+			metric.SetDescription("Time the process was started, in milliseconds since the Unix epoch. A change of this value indicates the process was restarted.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
 	&metricImpl{
 		"process.disk.io",
 		func(metric pdata.Metric) {
@@ -212,28 +403,110 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"process.disk.operations",
+		func(metric pdata.Metric) {
+			metric.SetName("process.disk.operations")
+			metric.SetDescription("Disk operations count.")
+			metric.SetUnit("{operations}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"process.memory.physical_usage",
 		func(metric pdata.Metric) {
 			metric.SetName("process.memory.physical_usage")
-			metric.SetDescription("The amount of physical memory in use.")
+			metric.SetDescription("The amount of physical memory in use. On Windows, this is the working set of the process.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(false)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"process.memory.private_usage",
+		func(metric pdata.Metric) {
+			metric.SetName("process.memory.private_usage")
+			metric.SetDescription("The amount of private memory committed by the process, also known as private bytes (Windows only).")
 			metric.SetUnit("By")
 			metric.SetDataType(pdata.MetricDataTypeIntSum)
 			metric.IntSum().SetIsMonotonic(false)
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"process.memory.swap_usage",
+		func(metric pdata.Metric) {
+			metric.SetName("process.memory.swap_usage")
+			metric.SetDescription("The amount of memory of the process swapped out to disk (Linux only).")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(false)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"process.memory.utilization",
+		func(metric pdata.Metric) {
+			metric.SetName("process.memory.utilization")
+			metric.SetDescription("Percentage of the physical memory of the host used by the process.")
+			metric.SetUnit("%")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"process.memory.virtual_usage",
 		func(metric pdata.Metric) {
 			metric.SetName("process.memory.virtual_usage")
-			metric.SetDescription("Virtual memory size.")
+			metric.SetDescription("Virtual memory size. On Windows, this is the commit charge of the process.")
 			metric.SetUnit("By")
 			metric.SetDataType(pdata.MetricDataTypeIntSum)
 			metric.IntSum().SetIsMonotonic(false)
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"process.open_file_descriptors",
+		func(metric pdata.Metric) {
+			metric.SetName("process.open_file_descriptors")
+			metric.SetDescription("Number of file descriptors in use by the process. On Windows, this is the number of open handles.")
+			metric.SetUnit("{descriptors}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(false)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"process.open_file_descriptors.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("process.open_file_descriptors.limit")
+			metric.SetDescription("Maximum number of file descriptors the process can open (Linux only).")
+			metric.SetUnit("{descriptors}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"process.threads",
+		func(metric pdata.Metric) {
+			metric.SetName("process.threads")
+			metric.SetDescription("Number of threads in use by the process.")
+			metric.SetUnit("{threads}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(false)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"system.boot_time",
+		func(metric pdata.Metric) {
+			metric.SetName("system.boot_time")
+			metric.SetDescription("Time the system was booted, in seconds since the Unix epoch. A change of this value indicates the system was rebooted.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
 	&metricImpl{
 		"system.cpu.load_average.15m",
 		func(metric pdata.Metric) {
@@ -243,6 +516,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
 		},
 	},
+	&metricImpl{
+		"system.cpu.load_average.15m_per_cpu",
+		func(metric pdata.Metric) {
+			metric.SetName("system.cpu.load_average.15m_per_cpu")
+			metric.SetDescription("Average CPU Load over 15 minutes divided by the number of logical CPUs.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"system.cpu.load_average.1m",
 		func(metric pdata.Metric) {
@@ -252,6 +534,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
 		},
 	},
+	&metricImpl{
+		"system.cpu.load_average.1m_per_cpu",
+		func(metric pdata.Metric) {
+			metric.SetName("system.cpu.load_average.1m_per_cpu")
+			metric.SetDescription("Average CPU Load over 1 minute divided by the number of logical CPUs.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"system.cpu.load_average.5m",
 		func(metric pdata.Metric) {
@@ -261,6 +552,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
 		},
 	},
+	&metricImpl{
+		"system.cpu.load_average.5m_per_cpu",
+		func(metric pdata.Metric) {
+			metric.SetName("system.cpu.load_average.5m_per_cpu")
+			metric.SetDescription("Average CPU Load over 5 minutes divided by the number of logical CPUs.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"system.cpu.time",
 		func(metric pdata.Metric) {
@@ -272,6 +572,15 @@ var Metrics = &metricStruct{
 			metric.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"system.cpu.utilization",
+		func(metric pdata.Metric) {
+			metric.SetName("system.cpu.utilization")
+			metric.SetDescription("Fraction of CPU time spent in each state since the previous scrape.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"system.disk.io",
 		func(metric pdata.Metric) {
@@ -305,6 +614,15 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"system.disk.operation_latency",
+		func(metric pdata.Metric) {
+			metric.SetName("system.disk.operation_latency")
+			metric.SetDescription("Average time taken by the disk operations completed since the previous scrape.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"system.disk.operation_time",
 		func(metric pdata.Metric) {
@@ -371,6 +689,64 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"system.gpu.memory.process_usage",
+		func(metric pdata.Metric) {
+			metric.SetName("system.gpu.memory.process_usage")
+			metric.SetDescription("Bytes of GPU memory used by each process running compute work on the GPU.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"system.gpu.memory.usage",
+		func(metric pdata.Metric) {
+			metric.SetName("system.gpu.memory.usage")
+			metric.SetDescription("Bytes of GPU memory in use.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(false)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"system.gpu.temperature",
+		func(metric pdata.Metric) {
+			metric.SetName("system.gpu.temperature")
+			metric.SetDescription("Temperature of the GPU die.")
+			metric.SetUnit("Cel")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
+	&metricImpl{
+		"system.gpu.utilization",
+		func(metric pdata.Metric) {
+			metric.SetName("system.gpu.utilization")
+			metric.SetDescription("Fraction of time the GPU was executing kernels over the last sample period of the driver.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
+	&metricImpl{
+		"system.memory.commit_limit",
+		func(metric pdata.Metric) {
+			metric.SetName("system.memory.commit_limit")
+			metric.SetDescription("Maximum bytes of memory the system can commit without extending the paging files (Windows only).")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"system.memory.committed",
+		func(metric pdata.Metric) {
+			metric.SetName("system.memory.committed")
+			metric.SetDescription("Bytes of memory committed by the system, backed by the physical memory or the paging files (Windows only).")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(false)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"system.memory.usage",
 		func(metric pdata.Metric) {
@@ -382,6 +758,15 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"system.memory.utilization",
+		func(metric pdata.Metric) {
+			metric.SetName("system.memory.utilization")
+			metric.SetDescription("Fraction of memory in each state.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"system.network.connections",
 		func(metric pdata.Metric) {
@@ -492,6 +877,33 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"system.sensors.fan_speed",
+		func(metric pdata.Metric) {
+			metric.SetName("system.sensors.fan_speed")
+			metric.SetDescription("Rotation speed reported by each fan sensor (Linux only).")
+			metric.SetUnit("{rpm}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"system.sensors.temperature",
+		func(metric pdata.Metric) {
+			metric.SetName("system.sensors.temperature")
+			metric.SetDescription("Temperature reported by each hardware sensor.")
+			metric.SetUnit("Cel")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
+	&metricImpl{
+		"system.uptime",
+		func(metric pdata.Metric) {
+			metric.SetName("system.uptime")
+			metric.SetDescription("Time elapsed since the system was booted.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 }
 
 // M contains a set of methods for each metric that help with
@@ -518,6 +930,12 @@ var Labels = struct {
 	FilesystemState string
 	// FilesystemType (Filesystem type, such as, "ext4", "tmpfs", etc.)
 	FilesystemType string
+	// Gpu (UUID of the GPU.)
+	Gpu string
+	// GpuMemoryState (Breakdown of GPU memory usage by type.)
+	GpuMemoryState string
+	// GpuPid (Identifier of the process using the GPU.)
+	GpuPid string
 	// MemState (Breakdown of memory usage by type.)
 	MemState string
 	// NetworkDevice (Name of the network interface.)
@@ -538,10 +956,14 @@ var Labels = struct {
 	PagingType string
 	// ProcessDirection (Direction of flow of bytes (read or write).)
 	ProcessDirection string
+	// ProcessLimit (Type of the limit (soft or hard).)
+	ProcessLimit string
 	// ProcessState (Breakdown of CPU usage by type.)
 	ProcessState string
 	// ProcessesStatus (Breakdown status of the processes.)
 	ProcessesStatus string
+	// SensorsSensor (Name of the hardware sensor.)
+	SensorsSensor string
 }{
 	"cpu",
 	"state",
@@ -552,6 +974,9 @@ var Labels = struct {
 	"mountpoint",
 	"state",
 	"type",
+	"gpu",
+	"state",
+	"pid",
 	"state",
 	"device",
 	"direction",
@@ -562,8 +987,10 @@ var Labels = struct {
 	"state",
 	"type",
 	"direction",
+	"limit",
 	"state",
 	"status",
+	"sensor",
 }
 
 // L contains the possible metric labels that can be used. L is an alias for
@@ -611,6 +1038,15 @@ var LabelFilesystemState = struct {
 	"used",
 }
 
+// LabelGpuMemoryState are the possible values that the label "gpu.memory.state" can have.
+var LabelGpuMemoryState = struct {
+	Free string
+	Used string
+}{
+	"free",
+	"used",
+}
+
 // LabelMemState are the possible values that the label "mem.state" can have.
 var LabelMemState = struct {
 	Buffered          string
@@ -684,6 +1120,15 @@ var LabelProcessDirection = struct {
 	"write",
 }
 
+// LabelProcessLimit are the possible values that the label "process.limit" can have.
+var LabelProcessLimit = struct {
+	Soft string
+	Hard string
+}{
+	"soft",
+	"hard",
+}
+
 // LabelProcessState are the possible values that the label "process.state" can have.
 var LabelProcessState = struct {
 	System string
@@ -699,7 +1144,9 @@ var LabelProcessState = struct {
 var LabelProcessesStatus = struct {
 	Blocked string
 	Running string
+	Zombie  string
 }{
 	"blocked",
 	"running",
+	"zombie",
 }
@@ -16,6 +16,7 @@ package internal
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -48,8 +49,17 @@ type ResourceScraperFactory interface {
 
 // Config is the configuration of a scraper.
 type Config interface {
+	// CollectionInterval returns the interval at which the scraper is called,
+	// zero if the scraper is called at the collection interval of the receiver.
+	CollectionInterval() time.Duration
 }
 
 // ConfigSettings provides common settings for scraper configuration.
 type ConfigSettings struct {
+	CollectionIntervalVal time.Duration `mapstructure:"collection_interval"`
+}
+
+// CollectionInterval gets the collection interval of the scraper.
+func (cs *ConfigSettings) CollectionInterval() time.Duration {
+	return cs.CollectionIntervalVal
 }
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const (
+	cpuLimitMetricsLen      = 1
+	cpuThrottlingMetricsLen = 3
+	memoryLimitMetricsLen   = 1
+	memoryUsageMetricsLen   = 1
+
+	metricsLen = cpuLimitMetricsLen + cpuThrottlingMetricsLen + memoryLimitMetricsLen + memoryUsageMetricsLen
+)
+
+// cgroupStats reads the resource limits and usage of the control groups of the collector.
+// Each method also returns whether the value is defined for the control groups.
+type cgroupStats interface {
+	cpuQuota() (float64, bool, error)
+	cpuThrottling() (cpuThrottling, bool, error)
+	memoryQuota() (int64, bool, error)
+	memoryUsage() (int64, bool, error)
+}
+
+// cpuThrottling is the CPU bandwidth throttling statistics of the control groups.
+type cpuThrottling struct {
+	periods          int64
+	throttledPeriods int64
+	throttledTime    time.Duration
+}
+
+// scraper for Cgroup Metrics
+type scraper struct {
+	config    *Config
+	startTime pdata.Timestamp
+	stats     cgroupStats
+
+	// for mocking
+	newStats func() (cgroupStats, error)
+}
+
+// newCgroupScraper creates a Cgroup Scraper
+func newCgroupScraper(_ context.Context, cfg *Config) *scraper {
+	return &scraper{config: cfg, newStats: newCgroupStats}
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	stats, err := s.newStats()
+	if err != nil {
+		return fmt.Errorf("error reading the control groups of the collector: %w", err)
+	}
+
+	s.stats = stats
+	s.startTime = pdata.TimestampFromTime(time.Now())
+	return nil
+}
+
+func (s *scraper) scrape(_ context.Context) (pdata.MetricSlice, error) {
+	metrics := pdata.NewMetricSlice()
+
+	var errs scrapererror.ScrapeErrors
+
+	now := pdata.TimestampFromTime(time.Now())
+
+	if quota, defined, err := s.stats.cpuQuota(); err != nil {
+		errs.AddPartial(cpuLimitMetricsLen, fmt.Errorf("error reading cgroup cpu limit: %w", err))
+	} else if defined {
+		initializeDoubleGaugeMetric(appendMetric(metrics), metadata.Metrics.CgroupCPULimit, now, quota)
+	}
+
+	if throttling, defined, err := s.stats.cpuThrottling(); err != nil {
+		errs.AddPartial(cpuThrottlingMetricsLen, fmt.Errorf("error reading cgroup cpu throttling: %w", err))
+	} else if defined {
+		initializeIntSumMetric(appendMetric(metrics), metadata.Metrics.CgroupCPUPeriods, s.startTime, now, throttling.periods)
+		initializeIntSumMetric(appendMetric(metrics), metadata.Metrics.CgroupCPUThrottledPeriods, s.startTime, now, throttling.throttledPeriods)
+		initializeThrottledTimeMetric(appendMetric(metrics), s.startTime, now, throttling.throttledTime)
+	}
+
+	if quota, defined, err := s.stats.memoryQuota(); err != nil {
+		errs.AddPartial(memoryLimitMetricsLen, fmt.Errorf("error reading cgroup memory limit: %w", err))
+	} else if defined {
+		initializeIntGaugeMetric(appendMetric(metrics), metadata.Metrics.CgroupMemoryLimit, now, quota)
+	}
+
+	if usage, defined, err := s.stats.memoryUsage(); err != nil {
+		errs.AddPartial(memoryUsageMetricsLen, fmt.Errorf("error reading cgroup memory usage: %w", err))
+	} else if defined {
+		initializeIntSumMetric(appendMetric(metrics), metadata.Metrics.CgroupMemoryUsage, 0, now, usage)
+	}
+
+	return metrics, errs.Combine()
+}
+
+func appendMetric(metrics pdata.MetricSlice) pdata.Metric {
+	metrics.Resize(metrics.Len() + 1)
+	return metrics.At(metrics.Len() - 1)
+}
+
+func initializeDoubleGaugeMetric(metric pdata.Metric, metricIntf metadata.MetricIntf, now pdata.Timestamp, value float64) {
+	metricIntf.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(1)
+	ddps.At(0).SetTimestamp(now)
+	ddps.At(0).SetValue(value)
+}
+
+func initializeIntGaugeMetric(metric pdata.Metric, metricIntf metadata.MetricIntf, now pdata.Timestamp, value int64) {
+	metricIntf.Init(metric)
+
+	idps := metric.IntGauge().DataPoints()
+	idps.Resize(1)
+	idps.At(0).SetTimestamp(now)
+	idps.At(0).SetValue(value)
+}
+
+func initializeIntSumMetric(metric pdata.Metric, metricIntf metadata.MetricIntf, startTime, now pdata.Timestamp, value int64) {
+	metricIntf.Init(metric)
+
+	idps := metric.IntSum().DataPoints()
+	idps.Resize(1)
+	idps.At(0).SetStartTime(startTime)
+	idps.At(0).SetTimestamp(now)
+	idps.At(0).SetValue(value)
+}
+
+func initializeThrottledTimeMetric(metric pdata.Metric, startTime, now pdata.Timestamp, throttledTime time.Duration) {
+	metadata.Metrics.CgroupCPUThrottledTime.Init(metric)
+
+	ddps := metric.DoubleSum().DataPoints()
+	ddps.Resize(1)
+	ddps.At(0).SetStartTime(startTime)
+	ddps.At(0).SetTimestamp(now)
+	ddps.At(0).SetValue(throttledTime.Seconds())
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package cgroupscraper
+
+import (
+	"go.opentelemetry.io/collector/internal/cgroups"
+)
+
+// linuxCgroupStats reads the stats of the cgroup v1 or v2 control groups of the collector.
+type linuxCgroupStats struct {
+	stats cgroups.Stats
+}
+
+func newCgroupStats() (cgroupStats, error) {
+	stats, err := cgroups.NewStatsForCurrentProcess()
+	if err != nil {
+		return nil, err
+	}
+	return &linuxCgroupStats{stats: stats}, nil
+}
+
+func (s *linuxCgroupStats) cpuQuota() (float64, bool, error) {
+	return s.stats.CPUQuota()
+}
+
+func (s *linuxCgroupStats) cpuThrottling() (cpuThrottling, bool, error) {
+	throttling, defined, err := s.stats.CPUThrottling()
+	if err != nil || !defined {
+		return cpuThrottling{}, defined, err
+	}
+	return cpuThrottling{
+		periods:          throttling.Periods,
+		throttledPeriods: throttling.ThrottledPeriods,
+		throttledTime:    throttling.ThrottledTime,
+	}, true, nil
+}
+
+func (s *linuxCgroupStats) memoryQuota() (int64, bool, error) {
+	return s.stats.MemoryQuota()
+}
+
+func (s *linuxCgroupStats) memoryUsage() (int64, bool, error) {
+	return s.stats.MemoryUsage()
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+type cgroupStatsMock struct {
+	quota           float64
+	quotaDefined    bool
+	quotaErr        error
+	throttling      cpuThrottling
+	throttlingErr   error
+	memoryLimit     int64
+	memoryLimitErr  error
+	memoryUsed      int64
+	memoryUsageErr  error
+	memoryUndefined bool
+}
+
+func (m *cgroupStatsMock) cpuQuota() (float64, bool, error) {
+	return m.quota, m.quotaDefined, m.quotaErr
+}
+
+func (m *cgroupStatsMock) cpuThrottling() (cpuThrottling, bool, error) {
+	return m.throttling, m.quotaDefined, m.throttlingErr
+}
+
+func (m *cgroupStatsMock) memoryQuota() (int64, bool, error) {
+	return m.memoryLimit, !m.memoryUndefined, m.memoryLimitErr
+}
+
+func (m *cgroupStatsMock) memoryUsage() (int64, bool, error) {
+	return m.memoryUsed, !m.memoryUndefined, m.memoryUsageErr
+}
+
+func TestScrape(t *testing.T) {
+	type testCase struct {
+		name            string
+		stats           *cgroupStatsMock
+		expectedMetrics []pdata.Metric
+		expectedErr     string
+		expectedFailed  int
+	}
+
+	testCases := []testCase{
+		{
+			name: "Limits defined",
+			stats: &cgroupStatsMock{
+				quota:        2,
+				quotaDefined: true,
+				throttling:   cpuThrottling{periods: 100, throttledPeriods: 10, throttledTime: 1500 * time.Millisecond},
+				memoryLimit:  1024,
+				memoryUsed:   512,
+			},
+			expectedMetrics: []pdata.Metric{
+				metadata.Metrics.CgroupCPULimit.New(),
+				metadata.Metrics.CgroupCPUPeriods.New(),
+				metadata.Metrics.CgroupCPUThrottledPeriods.New(),
+				metadata.Metrics.CgroupCPUThrottledTime.New(),
+				metadata.Metrics.CgroupMemoryLimit.New(),
+				metadata.Metrics.CgroupMemoryUsage.New(),
+			},
+		},
+		{
+			name:            "Limits undefined",
+			stats:           &cgroupStatsMock{memoryUndefined: true},
+			expectedMetrics: []pdata.Metric{},
+		},
+		{
+			name: "Errors",
+			stats: &cgroupStatsMock{
+				quotaDefined:   true,
+				quotaErr:       errors.New("err1"),
+				throttlingErr:  errors.New("err2"),
+				memoryLimitErr: errors.New("err3"),
+				memoryUsed:     512,
+			},
+			expectedMetrics: []pdata.Metric{
+				metadata.Metrics.CgroupMemoryUsage.New(),
+			},
+			expectedErr: "[error reading cgroup cpu limit: err1; " +
+				"error reading cgroup cpu throttling: err2; " +
+				"error reading cgroup memory limit: err3]",
+			expectedFailed: cpuLimitMetricsLen + cpuThrottlingMetricsLen + memoryLimitMetricsLen,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper := newCgroupScraper(context.Background(), &Config{})
+			scraper.newStats = func() (cgroupStats, error) { return test.stats, nil }
+
+			err := scraper.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err, "Failed to initialize cgroup scraper: %v", err)
+
+			metrics, err := scraper.scrape(context.Background())
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+
+				isPartial := scrapererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, test.expectedFailed, err.(scrapererror.PartialScrapeError).Failed)
+				}
+			} else {
+				require.NoError(t, err, "Failed to scrape metrics: %v", err)
+			}
+
+			require.Equal(t, len(test.expectedMetrics), metrics.Len())
+			for i, expected := range test.expectedMetrics {
+				internal.AssertDescriptorEqual(t, expected, metrics.At(i))
+			}
+		})
+	}
+}
+
+func TestScrape_MetricValues(t *testing.T) {
+	scraper := newCgroupScraper(context.Background(), &Config{})
+	scraper.newStats = func() (cgroupStats, error) {
+		return &cgroupStatsMock{
+			quota:        1.5,
+			quotaDefined: true,
+			throttling:   cpuThrottling{periods: 100, throttledPeriods: 10, throttledTime: 1500 * time.Millisecond},
+			memoryLimit:  1024,
+			memoryUsed:   512,
+		}, nil
+	}
+
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize cgroup scraper: %v", err)
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err, "Failed to scrape metrics: %v", err)
+	require.Equal(t, metricsLen, metrics.Len())
+
+	assert.Equal(t, 1.5, metrics.At(0).DoubleGauge().DataPoints().At(0).Value())
+	assert.EqualValues(t, 100, metrics.At(1).IntSum().DataPoints().At(0).Value())
+	assert.EqualValues(t, 10, metrics.At(2).IntSum().DataPoints().At(0).Value())
+	assert.Equal(t, 1.5, metrics.At(3).DoubleSum().DataPoints().At(0).Value())
+	internal.AssertDoubleSumMetricStartTimeEquals(t, metrics.At(3), scraper.startTime)
+	assert.EqualValues(t, 1024, metrics.At(4).IntGauge().DataPoints().At(0).Value())
+	assert.EqualValues(t, 512, metrics.At(5).IntSum().DataPoints().At(0).Value())
+}
+
+func TestStart_Error(t *testing.T) {
+	scraper := newCgroupScraper(context.Background(), &Config{})
+	scraper.newStats = func() (cgroupStats, error) { return nil, errors.New("err1") }
+
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "error reading the control groups of the collector: err1")
+}
+
+func TestScrape_CurrentProcess(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("skipping test on %v", runtime.GOOS)
+	}
+
+	scraper := newCgroupScraper(context.Background(), &Config{})
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize cgroup scraper: %v", err)
+
+	_, err = scraper.scrape(context.Background())
+	assert.NoError(t, err)
+}
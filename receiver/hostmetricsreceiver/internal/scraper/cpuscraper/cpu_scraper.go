@@ -27,12 +27,19 @@ import (
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
 
-const metricsLen = 1
+const (
+	cpuTimeMetricsLen        = 1
+	cpuUtilizationMetricsLen = 1
+
+	metricsLen = cpuTimeMetricsLen + cpuUtilizationMetricsLen
+)
 
 // scraper for CPU Metrics
 type scraper struct {
 	config    *Config
 	startTime pdata.Timestamp
+	// the CPU times of the previous scrape, to compute the CPU utilization
+	previousTimes map[string]cpu.TimesStat
 
 	// for mocking
 	bootTime func() (uint64, error)
@@ -63,11 +70,53 @@ func (s *scraper) scrape(_ context.Context) (pdata.MetricSlice, error) {
 		return metrics, scrapererror.NewPartialScrapeError(err, metricsLen)
 	}
 
-	metrics.Resize(metricsLen)
+	metrics.Resize(cpuTimeMetricsLen)
 	initializeCPUTimeMetric(metrics.At(0), s.startTime, now, cpuTimes)
+
+	// the CPU utilization is only reported from the second scrape
+	utilizations := s.getCPUUtilizations(cpuTimes)
+	if len(utilizations) > 0 {
+		metrics.Resize(cpuTimeMetricsLen + cpuUtilizationMetricsLen)
+		initializeCPUUtilizationMetric(metrics.At(1), now, utilizations)
+	}
 	return metrics, nil
 }
 
+// getCPUUtilizations returns the fraction of time spent in each state by the
+// CPUs since the previous scrape, and records the current CPU times.
+func (s *scraper) getCPUUtilizations(cpuTimes []cpu.TimesStat) []cpu.TimesStat {
+	utilizations := make([]cpu.TimesStat, 0, len(cpuTimes))
+	currentTimes := make(map[string]cpu.TimesStat, len(cpuTimes))
+	for _, cpuTime := range cpuTimes {
+		currentTimes[cpuTime.CPU] = cpuTime
+		if previousTime, ok := s.previousTimes[cpuTime.CPU]; ok {
+			utilizations = append(utilizations, getCPUUtilization(previousTime, cpuTime))
+		}
+	}
+
+	s.previousTimes = currentTimes
+	return utilizations
+}
+
+func getCPUUtilization(previous, current cpu.TimesStat) cpu.TimesStat {
+	utilization := cpu.TimesStat{CPU: current.CPU}
+
+	elapsed := current.Total() - previous.Total()
+	if elapsed <= 0 {
+		return utilization
+	}
+
+	utilization.User = (current.User - previous.User) / elapsed
+	utilization.System = (current.System - previous.System) / elapsed
+	utilization.Idle = (current.Idle - previous.Idle) / elapsed
+	utilization.Nice = (current.Nice - previous.Nice) / elapsed
+	utilization.Iowait = (current.Iowait - previous.Iowait) / elapsed
+	utilization.Irq = (current.Irq - previous.Irq) / elapsed
+	utilization.Softirq = (current.Softirq - previous.Softirq) / elapsed
+	utilization.Steal = (current.Steal - previous.Steal) / elapsed
+	return utilization
+}
+
 func initializeCPUTimeMetric(metric pdata.Metric, startTime, now pdata.Timestamp, cpuTimes []cpu.TimesStat) {
 	metadata.Metrics.SystemCPUTime.Init(metric)
 
@@ -78,6 +127,17 @@ func initializeCPUTimeMetric(metric pdata.Metric, startTime, now pdata.Timestamp
 	}
 }
 
+func initializeCPUUtilizationMetric(metric pdata.Metric, now pdata.Timestamp, utilizations []cpu.TimesStat) {
+	metadata.Metrics.SystemCPUUtilization.Init(metric)
+
+	// the utilization data points share the labels of the CPU time ones, without start time
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(len(utilizations) * cpuStatesLen)
+	for i, utilization := range utilizations {
+		appendCPUTimeStateDataPoints(ddps, i*cpuStatesLen, 0, now, utilization)
+	}
+}
+
 const gopsCPUTotal string = "cpu-total"
 
 func initializeCPUTimeDataPoint(dataPoint pdata.DoubleDataPoint, startTime, now pdata.Timestamp, cpuLabel string, stateLabel string, value float64) {
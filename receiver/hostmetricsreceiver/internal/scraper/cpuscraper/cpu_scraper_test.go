@@ -86,14 +86,15 @@ func TestScrape(t *testing.T) {
 				isPartial := scrapererror.IsPartialScrapeError(err)
 				assert.True(t, isPartial)
 				if isPartial {
-					assert.Equal(t, 1, err.(scrapererror.PartialScrapeError).Failed)
+					assert.Equal(t, metricsLen, err.(scrapererror.PartialScrapeError).Failed)
 				}
 
 				return
 			}
 			require.NoError(t, err, "Failed to scrape metrics: %v", err)
 
-			assert.Equal(t, 1, metrics.Len())
+			// the CPU utilization is only reported from the second scrape
+			assert.Equal(t, cpuTimeMetricsLen, metrics.Len())
 
 			assertCPUMetricValid(t, metrics.At(0), metadata.Metrics.SystemCPUTime.New(), test.expectedStartTime)
 
@@ -102,10 +103,48 @@ func TestScrape(t *testing.T) {
 			}
 
 			internal.AssertSameTimeStampForAllMetrics(t, metrics)
+
+			metrics, err = scraper.scrape(context.Background())
+			require.NoError(t, err, "Failed to scrape metrics: %v", err)
+
+			assert.Equal(t, metricsLen, metrics.Len())
+			assertCPUUtilizationMetricValid(t, metrics.At(1))
 		})
 	}
 }
 
+func TestScrape_CPUUtilization(t *testing.T) {
+	scraper := newCPUScraper(context.Background(), &Config{})
+	times := [][]cpu.TimesStat{
+		{{CPU: "cpu0", User: 10, System: 5, Idle: 85}, {CPU: "cpu1", User: 20, System: 10, Idle: 70}},
+		{{CPU: "cpu0", User: 15, System: 10, Idle: 175}, {CPU: "cpu1", User: 20, System: 10, Idle: 70}},
+	}
+	scrapes := 0
+	scraper.times = func(bool) ([]cpu.TimesStat, error) {
+		scrapes++
+		return times[scrapes-1], nil
+	}
+
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize cpu scraper: %v", err)
+
+	_, err = scraper.scrape(context.Background())
+	require.NoError(t, err, "Failed to scrape metrics: %v", err)
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err, "Failed to scrape metrics: %v", err)
+	require.Equal(t, metricsLen, metrics.Len())
+
+	ddps := metrics.At(1).DoubleGauge().DataPoints()
+	require.Equal(t, 2*cpuStatesLen, ddps.Len())
+	// cpu0 spent 5s in user, 5s in system and 90s in idle state between both scrapes
+	assert.Equal(t, 0.05, ddps.At(0).Value())
+	assert.Equal(t, 0.05, ddps.At(1).Value())
+	assert.Equal(t, 0.9, ddps.At(2).Value())
+	// cpu1 did not report any CPU time between both scrapes
+	assert.Equal(t, 0.0, ddps.At(cpuStatesLen+0).Value())
+	assert.Equal(t, 0.0, ddps.At(cpuStatesLen+2).Value())
+}
+
 func assertCPUMetricValid(t *testing.T, metric pdata.Metric, descriptor pdata.Metric, startTime pdata.Timestamp) {
 	internal.AssertDescriptorEqual(t, descriptor, metric)
 	if startTime != 0 {
@@ -119,6 +158,18 @@ func assertCPUMetricValid(t *testing.T, metric pdata.Metric, descriptor pdata.Me
 	internal.AssertDoubleSumMetricLabelHasValue(t, metric, 3, metadata.Labels.CPUState, metadata.LabelCPUState.Interrupt)
 }
 
+func assertCPUUtilizationMetricValid(t *testing.T, metric pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemCPUUtilization.New(), metric)
+	ddps := metric.DoubleGauge().DataPoints()
+	assert.GreaterOrEqual(t, ddps.Len(), 4*runtime.NumCPU())
+	internal.AssertDoubleGaugeMetricLabelExists(t, metric, 0, metadata.Labels.Cpu)
+	internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 0, metadata.Labels.CPUState, metadata.LabelCPUState.User)
+	for i := 0; i < ddps.Len(); i++ {
+		assert.GreaterOrEqual(t, ddps.At(i).Value(), 0.0)
+		assert.LessOrEqual(t, ddps.At(i).Value(), 1.0)
+	}
+}
+
 func assertCPUMetricHasLinuxSpecificStateLabels(t *testing.T, metric pdata.Metric) {
 	internal.AssertDoubleSumMetricLabelHasValue(t, metric, 4, metadata.Labels.CPUState, metadata.LabelCPUState.Nice)
 	internal.AssertDoubleSumMetricLabelHasValue(t, metric, 5, metadata.Labels.CPUState, metadata.LabelCPUState.Softirq)
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package diskscraper
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+)
+
+const operationLatencyMetricsLen = 1
+
+// operationStats stores the cumulative number of operations completed by a
+// device and the time spent on them, in seconds.
+type operationStats struct {
+	reads     int64
+	writes    int64
+	readTime  float64
+	writeTime float64
+}
+
+// operationLatency stores the average time taken by the read and write
+// operations of a device, in seconds.
+type operationLatency struct {
+	read  float64
+	write float64
+}
+
+// latencyTracker computes the average latency of the operations completed by
+// each device since the previous scrape.
+type latencyTracker struct {
+	previous map[string]operationStats
+}
+
+// update records the current operation stats of the devices and returns the
+// latency of the devices that were already present at the previous update.
+func (t *latencyTracker) update(current map[string]operationStats) map[string]operationLatency {
+	latencies := make(map[string]operationLatency, len(current))
+	for device, stats := range current {
+		previous, ok := t.previous[device]
+		// skip the devices seen for the first time and the ones whose counters were reset
+		if !ok || stats.reads < previous.reads || stats.writes < previous.writes {
+			continue
+		}
+
+		latencies[device] = operationLatency{
+			read:  averageLatency(stats.reads-previous.reads, stats.readTime-previous.readTime),
+			write: averageLatency(stats.writes-previous.writes, stats.writeTime-previous.writeTime),
+		}
+	}
+
+	t.previous = current
+	return latencies
+}
+
+func averageLatency(operations int64, time float64) float64 {
+	if operations == 0 || time < 0 {
+		return 0
+	}
+	return time / float64(operations)
+}
+
+// appendDiskOperationLatencyMetric appends the latency metric when the latency
+// of at least one device is known.
+func appendDiskOperationLatencyMetric(metrics pdata.MetricSlice, now pdata.Timestamp, latencies map[string]operationLatency) {
+	if len(latencies) == 0 {
+		return
+	}
+
+	startIdx := metrics.Len()
+	metrics.Resize(startIdx + operationLatencyMetricsLen)
+	metric := metrics.At(startIdx)
+	metadata.Metrics.SystemDiskOperationLatency.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(2 * len(latencies))
+
+	idx := 0
+	for device, latency := range latencies {
+		initializeDiskLatencyDataPoint(ddps.At(idx+0), now, device, metadata.LabelDiskDirection.Read, latency.read)
+		initializeDiskLatencyDataPoint(ddps.At(idx+1), now, device, metadata.LabelDiskDirection.Write, latency.write)
+		idx += 2
+	}
+}
+
+func initializeDiskLatencyDataPoint(dataPoint pdata.DoubleDataPoint, now pdata.Timestamp, deviceLabel string, directionLabel string, value float64) {
+	labelsMap := dataPoint.LabelsMap()
+	labelsMap.Insert(metadata.Labels.DiskDevice, deviceLabel)
+	labelsMap.Insert(metadata.Labels.DiskDirection, directionLabel)
+	dataPoint.SetTimestamp(now)
+	dataPoint.SetValue(value)
+}
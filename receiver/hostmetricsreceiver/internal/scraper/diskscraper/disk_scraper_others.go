@@ -33,7 +33,7 @@ import (
 
 const (
 	standardMetricsLen = 5
-	metricsLen         = standardMetricsLen + systemSpecificMetricsLen
+	metricsLen         = standardMetricsLen + operationLatencyMetricsLen + systemSpecificMetricsLen
 )
 
 // scraper for Disk Metrics
@@ -42,6 +42,7 @@ type scraper struct {
 	startTime pdata.Timestamp
 	includeFS filterset.FilterSet
 	excludeFS filterset.FilterSet
+	latency   *latencyTracker
 
 	// for mocking
 	bootTime   func() (uint64, error)
@@ -50,7 +51,7 @@ type scraper struct {
 
 // newDiskScraper creates a Disk Scraper
 func newDiskScraper(_ context.Context, cfg *Config) (*scraper, error) {
-	scraper := &scraper{config: cfg, bootTime: host.BootTime, ioCounters: disk.IOCounters}
+	scraper := &scraper{config: cfg, latency: &latencyTracker{}, bootTime: host.BootTime, ioCounters: disk.IOCounters}
 
 	var err error
 
@@ -94,7 +95,7 @@ func (s *scraper) scrape(_ context.Context) (pdata.MetricSlice, error) {
 	ioCounters = s.filterByDevice(ioCounters)
 
 	if len(ioCounters) > 0 {
-		metrics.Resize(metricsLen)
+		metrics.Resize(standardMetricsLen + systemSpecificMetricsLen)
 		initializeDiskIOMetric(metrics.At(0), s.startTime, now, ioCounters)
 		initializeDiskOperationsMetric(metrics.At(1), s.startTime, now, ioCounters)
 		initializeDiskIOTimeMetric(metrics.At(2), s.startTime, now, ioCounters)
@@ -103,6 +104,8 @@ func (s *scraper) scrape(_ context.Context) (pdata.MetricSlice, error) {
 		appendSystemSpecificMetrics(metrics, 5, s.startTime, now, ioCounters)
 	}
 
+	appendDiskOperationLatencyMetric(metrics, now, s.latency.update(getOperationStats(ioCounters)))
+
 	return metrics, nil
 }
 
@@ -174,6 +177,19 @@ func initializeDiskPendingOperationsMetric(metric pdata.Metric, now pdata.Timest
 	}
 }
 
+func getOperationStats(ioCounters map[string]disk.IOCountersStat) map[string]operationStats {
+	stats := make(map[string]operationStats, len(ioCounters))
+	for device, ioCounter := range ioCounters {
+		stats[device] = operationStats{
+			reads:     int64(ioCounter.ReadCount),
+			writes:    int64(ioCounter.WriteCount),
+			readTime:  float64(ioCounter.ReadTime) / 1e3,
+			writeTime: float64(ioCounter.WriteTime) / 1e3,
+		}
+	}
+	return stats
+}
+
 func initializeInt64DataPoint(dataPoint pdata.IntDataPoint, startTime, now pdata.Timestamp, deviceLabel string, directionLabel string, value int64) {
 	labelsMap := dataPoint.LabelsMap()
 	labelsMap.Insert(metadata.Labels.DiskDevice, deviceLabel)
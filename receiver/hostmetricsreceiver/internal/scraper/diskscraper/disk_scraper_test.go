@@ -103,7 +103,8 @@ func TestScrape(t *testing.T) {
 				return
 			}
 
-			assert.Equal(t, metricsLen, metrics.Len())
+			// the operation latency is only reported from the second scrape
+			assert.Equal(t, metricsLen-operationLatencyMetricsLen, metrics.Len())
 
 			assertInt64DiskMetricValid(t, metrics.At(0), metadata.Metrics.SystemDiskIo.New(), test.expectedStartTime)
 			assertInt64DiskMetricValid(t, metrics.At(1), metadata.Metrics.SystemDiskOperations.New(), test.expectedStartTime)
@@ -117,10 +118,33 @@ func TestScrape(t *testing.T) {
 			}
 
 			internal.AssertSameTimeStampForAllMetrics(t, metrics)
+
+			metrics, err = scraper.scrape(context.Background())
+			require.NoError(t, err, "Failed to scrape metrics: %v", err)
+
+			assert.Equal(t, metricsLen, metrics.Len())
+			assertDiskOperationLatencyMetricValid(t, metrics.At(metricsLen-1))
 		})
 	}
 }
 
+func TestLatencyTracker(t *testing.T) {
+	tracker := &latencyTracker{}
+
+	latencies := tracker.update(map[string]operationStats{
+		"sda": {reads: 10, writes: 20, readTime: 1, writeTime: 4},
+		"sdb": {reads: 10, writes: 20, readTime: 1, writeTime: 4},
+	})
+	assert.Empty(t, latencies)
+
+	latencies = tracker.update(map[string]operationStats{
+		"sda": {reads: 20, writes: 20, readTime: 1.5, writeTime: 4},
+		"sdb": {reads: 5, writes: 30, readTime: 0.5, writeTime: 5},
+		"sdc": {reads: 10, writes: 20, readTime: 1, writeTime: 4},
+	})
+	assert.Equal(t, map[string]operationLatency{"sda": {read: 0.05, write: 0}}, latencies)
+}
+
 func assertInt64DiskMetricValid(t *testing.T, metric pdata.Metric, expectedDescriptor pdata.Metric, startTime pdata.Timestamp) {
 	internal.AssertDescriptorEqual(t, expectedDescriptor, metric)
 	if startTime != 0 {
@@ -158,3 +182,11 @@ func assertDiskPendingOperationsMetricValid(t *testing.T, metric pdata.Metric) {
 	assert.GreaterOrEqual(t, metric.IntSum().DataPoints().Len(), 1)
 	internal.AssertIntSumMetricLabelExists(t, metric, 0, "device")
 }
+
+func assertDiskOperationLatencyMetricValid(t *testing.T, metric pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemDiskOperationLatency.New(), metric)
+	assert.GreaterOrEqual(t, metric.DoubleGauge().DataPoints().Len(), 2)
+	internal.AssertDoubleGaugeMetricLabelExists(t, metric, 0, "device")
+	internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 0, "direction", "read")
+	internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 1, "direction", "write")
+}
@@ -30,7 +30,8 @@ import (
 )
 
 const (
-	metricsLen = 5
+	standardMetricsLen = 5
+	metricsLen         = standardMetricsLen + operationLatencyMetricsLen
 
 	logicalDisk = "LogicalDisk"
 
@@ -54,6 +55,7 @@ type scraper struct {
 	startTime pdata.Timestamp
 	includeFS filterset.FilterSet
 	excludeFS filterset.FilterSet
+	latency   *latencyTracker
 
 	perfCounterScraper perfcounters.PerfCounterScraper
 
@@ -63,7 +65,7 @@ type scraper struct {
 
 // newDiskScraper creates a Disk Scraper
 func newDiskScraper(_ context.Context, cfg *Config) (*scraper, error) {
-	scraper := &scraper{config: cfg, perfCounterScraper: &perfcounters.PerfLibScraper{}, bootTime: host.BootTime}
+	scraper := &scraper{config: cfg, latency: &latencyTracker{}, perfCounterScraper: &perfcounters.PerfLibScraper{}, bootTime: host.BootTime}
 
 	var err error
 
@@ -119,7 +121,7 @@ func (s *scraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
 	}
 
 	if len(logicalDiskCounterValues) > 0 {
-		metrics.Resize(metricsLen)
+		metrics.Resize(standardMetricsLen)
 		initializeDiskIOMetric(metrics.At(0), s.startTime, now, logicalDiskCounterValues)
 		initializeDiskOperationsMetric(metrics.At(1), s.startTime, now, logicalDiskCounterValues)
 		initializeDiskIOTimeMetric(metrics.At(2), s.startTime, now, logicalDiskCounterValues)
@@ -127,6 +129,8 @@ func (s *scraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
 		initializeDiskPendingOperationsMetric(metrics.At(4), now, logicalDiskCounterValues)
 	}
 
+	appendDiskOperationLatencyMetric(metrics, now, s.latency.update(getOperationStats(logicalDiskCounterValues)))
+
 	return metrics, nil
 }
 
@@ -184,6 +188,19 @@ func initializeDiskPendingOperationsMetric(metric pdata.Metric, now pdata.Timest
 	}
 }
 
+func getOperationStats(logicalDiskCounterValues []*perfcounters.CounterValues) map[string]operationStats {
+	stats := make(map[string]operationStats, len(logicalDiskCounterValues))
+	for _, logicalDiskCounter := range logicalDiskCounterValues {
+		stats[logicalDiskCounter.InstanceName] = operationStats{
+			reads:     logicalDiskCounter.Values[readsPerSec],
+			writes:    logicalDiskCounter.Values[writesPerSec],
+			readTime:  float64(logicalDiskCounter.Values[avgDiskSecsPerRead]) / 1e7,
+			writeTime: float64(logicalDiskCounter.Values[avgDiskSecsPerWrite]) / 1e7,
+		}
+	}
+	return stats
+}
+
 func initializeInt64DataPoint(dataPoint pdata.IntDataPoint, startTime, now pdata.Timestamp, deviceLabel string, directionLabel string, value int64) {
 	labelsMap := dataPoint.LabelsMap()
 	labelsMap.Insert(metadata.Labels.DiskDevice, deviceLabel)
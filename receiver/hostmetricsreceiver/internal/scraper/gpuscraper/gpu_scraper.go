@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpuscraper
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const metricsLen = 4
+
+// gpuDevice holds the statistics of a GPU, the statistics not supported by
+// the GPU are nil.
+type gpuDevice struct {
+	uuid        string
+	utilization *float64
+	memory      *gpuMemory
+	temperature *float64
+	processes   []gpuProcess
+}
+
+type gpuMemory struct {
+	used uint64
+	free uint64
+}
+
+// gpuProcess is a process running compute work on a GPU.
+type gpuProcess struct {
+	pid        uint32
+	usedMemory uint64
+}
+
+// scraper for GPU Metrics
+type scraper struct {
+	config *Config
+
+	// for mocking
+	initGPUs     func() error
+	shutdownGPUs func() error
+	devices      func() ([]gpuDevice, error)
+}
+
+// newGPUScraper creates a GPU Scraper
+func newGPUScraper(_ context.Context, cfg *Config) *scraper {
+	return &scraper{config: cfg, initGPUs: initNVML, shutdownGPUs: shutdownNVML, devices: getNVMLDevices}
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	if err := s.initGPUs(); err != nil {
+		return fmt.Errorf("error initializing NVML: %w", err)
+	}
+	return nil
+}
+
+func (s *scraper) shutdown(context.Context) error {
+	return s.shutdownGPUs()
+}
+
+func (s *scraper) scrape(_ context.Context) (pdata.MetricSlice, error) {
+	metrics := pdata.NewMetricSlice()
+
+	now := pdata.TimestampFromTime(time.Now())
+	devices, err := s.devices()
+
+	// the devices that could be read are returned along with the error of the
+	// devices that could not be read
+	if err != nil && len(devices) == 0 {
+		return metrics, scrapererror.NewPartialScrapeError(fmt.Errorf("error reading GPUs: %w", err), metricsLen)
+	}
+
+	appendUtilizationMetric(metrics, now, devices)
+	appendMemoryUsageMetric(metrics, now, devices)
+	appendMemoryProcessUsageMetric(metrics, now, devices)
+	appendTemperatureMetric(metrics, now, devices)
+
+	if err != nil {
+		return metrics, scrapererror.NewPartialScrapeError(fmt.Errorf("error reading GPUs: %w", err), 0)
+	}
+	return metrics, nil
+}
+
+func appendUtilizationMetric(metrics pdata.MetricSlice, now pdata.Timestamp, devices []gpuDevice) {
+	var utilizations []gpuValue
+	for _, device := range devices {
+		if device.utilization != nil {
+			utilizations = append(utilizations, gpuValue{uuid: device.uuid, value: *device.utilization})
+		}
+	}
+	if len(utilizations) == 0 {
+		return
+	}
+
+	metric := appendMetric(metrics)
+	metadata.Metrics.SystemGpuUtilization.Init(metric)
+	initializeGPUValueDataPoints(metric.DoubleGauge().DataPoints(), now, utilizations)
+}
+
+func appendMemoryUsageMetric(metrics pdata.MetricSlice, now pdata.Timestamp, devices []gpuDevice) {
+	var memories []gpuDevice
+	for _, device := range devices {
+		if device.memory != nil {
+			memories = append(memories, device)
+		}
+	}
+	if len(memories) == 0 {
+		return
+	}
+
+	metric := appendMetric(metrics)
+	metadata.Metrics.SystemGpuMemoryUsage.Init(metric)
+
+	idps := metric.IntSum().DataPoints()
+	idps.Resize(2 * len(memories))
+	for i, device := range memories {
+		initializeMemoryUsageDataPoint(idps.At(2*i+0), now, device.uuid, metadata.LabelGpuMemoryState.Used, device.memory.used)
+		initializeMemoryUsageDataPoint(idps.At(2*i+1), now, device.uuid, metadata.LabelGpuMemoryState.Free, device.memory.free)
+	}
+}
+
+func initializeMemoryUsageDataPoint(dataPoint pdata.IntDataPoint, now pdata.Timestamp, uuid string, stateLabel string, value uint64) {
+	labelsMap := dataPoint.LabelsMap()
+	labelsMap.Insert(metadata.Labels.Gpu, uuid)
+	labelsMap.Insert(metadata.Labels.GpuMemoryState, stateLabel)
+	dataPoint.SetTimestamp(now)
+	dataPoint.SetValue(int64(value))
+}
+
+func appendMemoryProcessUsageMetric(metrics pdata.MetricSlice, now pdata.Timestamp, devices []gpuDevice) {
+	processesLen := 0
+	for _, device := range devices {
+		processesLen += len(device.processes)
+	}
+	if processesLen == 0 {
+		return
+	}
+
+	metric := appendMetric(metrics)
+	metadata.Metrics.SystemGpuMemoryProcessUsage.Init(metric)
+
+	idps := metric.IntGauge().DataPoints()
+	idps.Resize(processesLen)
+	idx := 0
+	for _, device := range devices {
+		for _, process := range device.processes {
+			dataPoint := idps.At(idx)
+			labelsMap := dataPoint.LabelsMap()
+			labelsMap.Insert(metadata.Labels.Gpu, device.uuid)
+			labelsMap.Insert(metadata.Labels.GpuPid, strconv.FormatUint(uint64(process.pid), 10))
+			dataPoint.SetTimestamp(now)
+			dataPoint.SetValue(int64(process.usedMemory))
+			idx++
+		}
+	}
+}
+
+func appendTemperatureMetric(metrics pdata.MetricSlice, now pdata.Timestamp, devices []gpuDevice) {
+	var temperatures []gpuValue
+	for _, device := range devices {
+		if device.temperature != nil {
+			temperatures = append(temperatures, gpuValue{uuid: device.uuid, value: *device.temperature})
+		}
+	}
+	if len(temperatures) == 0 {
+		return
+	}
+
+	metric := appendMetric(metrics)
+	metadata.Metrics.SystemGpuTemperature.Init(metric)
+	initializeGPUValueDataPoints(metric.DoubleGauge().DataPoints(), now, temperatures)
+}
+
+// gpuValue is a value reported for a GPU.
+type gpuValue struct {
+	uuid  string
+	value float64
+}
+
+func initializeGPUValueDataPoints(ddps pdata.DoubleDataPointSlice, now pdata.Timestamp, values []gpuValue) {
+	ddps.Resize(len(values))
+	for i, value := range values {
+		ddp := ddps.At(i)
+		ddp.LabelsMap().Insert(metadata.Labels.Gpu, value.uuid)
+		ddp.SetTimestamp(now)
+		ddp.SetValue(value.value)
+	}
+}
+
+// appendMetric appends an empty metric to the metrics and returns it, the
+// metrics are only appended if at least one GPU supports them.
+func appendMetric(metrics pdata.MetricSlice) pdata.Metric {
+	idx := metrics.Len()
+	metrics.Resize(idx + 1)
+	return metrics.At(idx)
+}
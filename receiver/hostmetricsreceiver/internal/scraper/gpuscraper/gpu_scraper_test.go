@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpuscraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const (
+	testUUID0 = "GPU-6fc3b2f4-5c1a-4b8e-9f0d-2a7e1c9b3d40"
+	testUUID1 = "GPU-0b2c4d6e-8f10-4a3b-9c5d-7e9f1a3b5c7d"
+)
+
+func float64Ptr(value float64) *float64 {
+	return &value
+}
+
+var testDevices = []gpuDevice{
+	{
+		uuid:        testUUID0,
+		utilization: float64Ptr(0.75),
+		memory:      &gpuMemory{used: 4 << 30, free: 12 << 30},
+		temperature: float64Ptr(67),
+		processes: []gpuProcess{
+			{pid: 4242, usedMemory: 3 << 30},
+			{pid: 4243, usedMemory: 1 << 30},
+		},
+	},
+	{
+		// e.g. a GPU not supporting the utilization and temperature queries
+		uuid:   testUUID1,
+		memory: &gpuMemory{used: 0, free: 16 << 30},
+	},
+}
+
+func TestScrape(t *testing.T) {
+	type testCase struct {
+		name                  string
+		devicesFunc           func() ([]gpuDevice, error)
+		expectedMetricCount   int
+		expectedErr           string
+		expectedFailedMetrics int
+	}
+
+	testCases := []testCase{
+		{
+			name:                "Standard",
+			devicesFunc:         func() ([]gpuDevice, error) { return testDevices, nil },
+			expectedMetricCount: metricsLen,
+		},
+		{
+			name:                "No Devices",
+			devicesFunc:         func() ([]gpuDevice, error) { return nil, nil },
+			expectedMetricCount: 0,
+		},
+		{
+			name:                  "Devices Error",
+			devicesFunc:           func() ([]gpuDevice, error) { return nil, errors.New("err1") },
+			expectedErr:           "error reading GPUs: err1",
+			expectedFailedMetrics: metricsLen,
+		},
+		{
+			name:                  "Device Error",
+			devicesFunc:           func() ([]gpuDevice, error) { return testDevices, errors.New("err2") },
+			expectedMetricCount:   metricsLen,
+			expectedErr:           "error reading GPUs: err2",
+			expectedFailedMetrics: 0,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper := newGPUScraper(context.Background(), &Config{})
+			scraper.initGPUs = func() error { return nil }
+			scraper.shutdownGPUs = func() error { return nil }
+			scraper.devices = test.devicesFunc
+
+			err := scraper.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err, "Failed to initialize gpu scraper: %v", err)
+			defer func() { assert.NoError(t, scraper.shutdown(context.Background())) }()
+
+			metrics, err := scraper.scrape(context.Background())
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+
+				isPartial := scrapererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, test.expectedFailedMetrics, err.(scrapererror.PartialScrapeError).Failed)
+				}
+			} else {
+				require.NoError(t, err, "Failed to scrape metrics: %v", err)
+			}
+
+			assert.Equal(t, test.expectedMetricCount, metrics.Len())
+			if metrics.Len() == metricsLen {
+				assertUtilizationMetricValid(t, metrics.At(0))
+				assertMemoryUsageMetricValid(t, metrics.At(1))
+				assertMemoryProcessUsageMetricValid(t, metrics.At(2))
+				assertTemperatureMetricValid(t, metrics.At(3))
+				internal.AssertSameTimeStampForAllMetrics(t, metrics)
+			}
+		})
+	}
+}
+
+func TestStart_Error(t *testing.T) {
+	scraper := newGPUScraper(context.Background(), &Config{})
+	scraper.initGPUs = func() error { return errors.New("err1") }
+
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "error initializing NVML: err1")
+}
+
+func assertUtilizationMetricValid(t *testing.T, metric pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemGpuUtilization.New(), metric)
+	require.Equal(t, 1, metric.DoubleGauge().DataPoints().Len())
+	internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 0, metadata.Labels.Gpu, testUUID0)
+	assert.Equal(t, 0.75, metric.DoubleGauge().DataPoints().At(0).Value())
+}
+
+func assertMemoryUsageMetricValid(t *testing.T, metric pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemGpuMemoryUsage.New(), metric)
+	idps := metric.IntSum().DataPoints()
+	require.Equal(t, 4, idps.Len())
+	internal.AssertIntSumMetricLabelHasValue(t, metric, 0, metadata.Labels.Gpu, testUUID0)
+	internal.AssertIntSumMetricLabelHasValue(t, metric, 0, metadata.Labels.GpuMemoryState, metadata.LabelGpuMemoryState.Used)
+	assert.Equal(t, int64(4<<30), idps.At(0).Value())
+	internal.AssertIntSumMetricLabelHasValue(t, metric, 1, metadata.Labels.GpuMemoryState, metadata.LabelGpuMemoryState.Free)
+	assert.Equal(t, int64(12<<30), idps.At(1).Value())
+	internal.AssertIntSumMetricLabelHasValue(t, metric, 2, metadata.Labels.Gpu, testUUID1)
+	internal.AssertIntSumMetricLabelHasValue(t, metric, 3, metadata.Labels.Gpu, testUUID1)
+}
+
+func assertMemoryProcessUsageMetricValid(t *testing.T, metric pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemGpuMemoryProcessUsage.New(), metric)
+	idps := metric.IntGauge().DataPoints()
+	require.Equal(t, 2, idps.Len())
+	internal.AssertIntGaugeMetricLabelHasValue(t, metric, 0, metadata.Labels.Gpu, testUUID0)
+	internal.AssertIntGaugeMetricLabelHasValue(t, metric, 0, metadata.Labels.GpuPid, "4242")
+	assert.Equal(t, int64(3<<30), idps.At(0).Value())
+	internal.AssertIntGaugeMetricLabelHasValue(t, metric, 1, metadata.Labels.GpuPid, "4243")
+	assert.Equal(t, int64(1<<30), idps.At(1).Value())
+}
+
+func assertTemperatureMetricValid(t *testing.T, metric pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemGpuTemperature.New(), metric)
+	require.Equal(t, 1, metric.DoubleGauge().DataPoints().Len())
+	internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 0, metadata.Labels.Gpu, testUUID0)
+	assert.Equal(t, 67.0, metric.DoubleGauge().DataPoints().At(0).Value())
+}
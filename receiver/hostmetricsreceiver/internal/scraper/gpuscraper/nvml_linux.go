@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build nvml,linux
+
+package gpuscraper
+
+/*
+#cgo LDFLAGS: -ldl
+
+#include <dlfcn.h>
+
+// The subset of the NVML API used by the scraper, the NVML library is loaded
+// at runtime so that the collector does not depend on the NVIDIA driver.
+typedef int nvmlReturn_t;
+typedef struct nvmlDevice_st *nvmlDevice_t;
+typedef struct { unsigned int gpu; unsigned int memory; } nvmlUtilization_t;
+typedef struct { unsigned long long total; unsigned long long free; unsigned long long used; } nvmlMemory_t;
+typedef struct { unsigned int pid; unsigned long long usedGpuMemory; } nvmlProcessInfo_t;
+
+#define NVML_SUCCESS 0
+#define NVML_ERROR_UNINITIALIZED 1
+#define NVML_ERROR_NOT_SUPPORTED 3
+#define NVML_ERROR_INSUFFICIENT_SIZE 7
+#define NVML_ERROR_LIBRARY_NOT_FOUND 12
+#define NVML_ERROR_FUNCTION_NOT_FOUND 13
+#define NVML_TEMPERATURE_GPU 0
+#define NVML_DEVICE_UUID_BUFFER_SIZE 96
+#define NVML_VALUE_NOT_AVAILABLE (~0ULL)
+
+static void *nvmlLibrary;
+static nvmlReturn_t (*nvmlInitFunc)(void);
+static nvmlReturn_t (*nvmlShutdownFunc)(void);
+static const char *(*nvmlErrorStringFunc)(nvmlReturn_t);
+static nvmlReturn_t (*nvmlDeviceGetCountFunc)(unsigned int *);
+static nvmlReturn_t (*nvmlDeviceGetHandleByIndexFunc)(unsigned int, nvmlDevice_t *);
+static nvmlReturn_t (*nvmlDeviceGetUUIDFunc)(nvmlDevice_t, char *, unsigned int);
+static nvmlReturn_t (*nvmlDeviceGetUtilizationRatesFunc)(nvmlDevice_t, nvmlUtilization_t *);
+static nvmlReturn_t (*nvmlDeviceGetMemoryInfoFunc)(nvmlDevice_t, nvmlMemory_t *);
+static nvmlReturn_t (*nvmlDeviceGetTemperatureFunc)(nvmlDevice_t, int, unsigned int *);
+static nvmlReturn_t (*nvmlDeviceGetComputeRunningProcessesFunc)(nvmlDevice_t, unsigned int *, nvmlProcessInfo_t *);
+
+static nvmlReturn_t nvmlLoad() {
+	nvmlLibrary = dlopen("libnvidia-ml.so.1", RTLD_LAZY);
+	if (nvmlLibrary == NULL) {
+		return NVML_ERROR_LIBRARY_NOT_FOUND;
+	}
+
+	nvmlInitFunc = dlsym(nvmlLibrary, "nvmlInit_v2");
+	nvmlShutdownFunc = dlsym(nvmlLibrary, "nvmlShutdown");
+	nvmlErrorStringFunc = dlsym(nvmlLibrary, "nvmlErrorString");
+	nvmlDeviceGetCountFunc = dlsym(nvmlLibrary, "nvmlDeviceGetCount_v2");
+	nvmlDeviceGetHandleByIndexFunc = dlsym(nvmlLibrary, "nvmlDeviceGetHandleByIndex_v2");
+	nvmlDeviceGetUUIDFunc = dlsym(nvmlLibrary, "nvmlDeviceGetUUID");
+	nvmlDeviceGetUtilizationRatesFunc = dlsym(nvmlLibrary, "nvmlDeviceGetUtilizationRates");
+	nvmlDeviceGetMemoryInfoFunc = dlsym(nvmlLibrary, "nvmlDeviceGetMemoryInfo");
+	nvmlDeviceGetTemperatureFunc = dlsym(nvmlLibrary, "nvmlDeviceGetTemperature");
+	nvmlDeviceGetComputeRunningProcessesFunc = dlsym(nvmlLibrary, "nvmlDeviceGetComputeRunningProcesses");
+	if (nvmlInitFunc == NULL || nvmlShutdownFunc == NULL || nvmlErrorStringFunc == NULL ||
+			nvmlDeviceGetCountFunc == NULL || nvmlDeviceGetHandleByIndexFunc == NULL ||
+			nvmlDeviceGetUUIDFunc == NULL || nvmlDeviceGetUtilizationRatesFunc == NULL ||
+			nvmlDeviceGetMemoryInfoFunc == NULL || nvmlDeviceGetTemperatureFunc == NULL ||
+			nvmlDeviceGetComputeRunningProcessesFunc == NULL) {
+		dlclose(nvmlLibrary);
+		nvmlLibrary = NULL;
+		return NVML_ERROR_FUNCTION_NOT_FOUND;
+	}
+
+	nvmlReturn_t result = nvmlInitFunc();
+	if (result != NVML_SUCCESS) {
+		dlclose(nvmlLibrary);
+		nvmlLibrary = NULL;
+	}
+	return result;
+}
+
+static nvmlReturn_t nvmlUnload() {
+	if (nvmlLibrary == NULL) {
+		return NVML_SUCCESS;
+	}
+
+	nvmlReturn_t result = nvmlShutdownFunc();
+	dlclose(nvmlLibrary);
+	nvmlLibrary = NULL;
+	return result;
+}
+
+static const char *nvmlError(nvmlReturn_t result) {
+	if (nvmlLibrary == NULL) {
+		return result == NVML_ERROR_LIBRARY_NOT_FOUND ? "NVML library not found" : "NVML not initialized";
+	}
+	return nvmlErrorStringFunc(result);
+}
+
+static nvmlReturn_t nvmlDeviceGetCount(unsigned int *count) {
+	return nvmlLibrary == NULL ? NVML_ERROR_UNINITIALIZED : nvmlDeviceGetCountFunc(count);
+}
+
+static nvmlReturn_t nvmlDeviceGetHandleByIndex(unsigned int index, nvmlDevice_t *device) {
+	return nvmlLibrary == NULL ? NVML_ERROR_UNINITIALIZED : nvmlDeviceGetHandleByIndexFunc(index, device);
+}
+
+static nvmlReturn_t nvmlDeviceGetUUID(nvmlDevice_t device, char *uuid, unsigned int length) {
+	return nvmlLibrary == NULL ? NVML_ERROR_UNINITIALIZED : nvmlDeviceGetUUIDFunc(device, uuid, length);
+}
+
+static nvmlReturn_t nvmlDeviceGetUtilizationRates(nvmlDevice_t device, nvmlUtilization_t *utilization) {
+	return nvmlLibrary == NULL ? NVML_ERROR_UNINITIALIZED : nvmlDeviceGetUtilizationRatesFunc(device, utilization);
+}
+
+static nvmlReturn_t nvmlDeviceGetMemoryInfo(nvmlDevice_t device, nvmlMemory_t *memory) {
+	return nvmlLibrary == NULL ? NVML_ERROR_UNINITIALIZED : nvmlDeviceGetMemoryInfoFunc(device, memory);
+}
+
+static nvmlReturn_t nvmlDeviceGetTemperature(nvmlDevice_t device, unsigned int *temperature) {
+	return nvmlLibrary == NULL ? NVML_ERROR_UNINITIALIZED : nvmlDeviceGetTemperatureFunc(device, NVML_TEMPERATURE_GPU, temperature);
+}
+
+static nvmlReturn_t nvmlDeviceGetComputeRunningProcesses(nvmlDevice_t device, unsigned int *count, nvmlProcessInfo_t *infos) {
+	return nvmlLibrary == NULL ? NVML_ERROR_UNINITIALIZED : nvmlDeviceGetComputeRunningProcessesFunc(device, count, infos);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// nvmlError is an error returned by NVML.
+type nvmlError C.nvmlReturn_t
+
+func (e nvmlError) Error() string {
+	return C.GoString(C.nvmlError(C.nvmlReturn_t(e)))
+}
+
+func nvmlResult(result C.nvmlReturn_t) error {
+	if result == C.NVML_SUCCESS {
+		return nil
+	}
+	return nvmlError(result)
+}
+
+// isNotSupported returns whether the result means that the GPU does not
+// support the requested statistic, which is then not reported.
+func isNotSupported(result C.nvmlReturn_t) bool {
+	return result == C.NVML_ERROR_NOT_SUPPORTED
+}
+
+// initNVML loads and initializes the NVML library shipped with the NVIDIA driver.
+func initNVML() error {
+	return nvmlResult(C.nvmlLoad())
+}
+
+// shutdownNVML shuts down and unloads the NVML library.
+func shutdownNVML() error {
+	return nvmlResult(C.nvmlUnload())
+}
+
+// getNVMLDevices returns the statistics of the GPUs reported by NVML, along
+// with the errors of the GPUs that could not be read.
+func getNVMLDevices() ([]gpuDevice, error) {
+	var count C.uint
+	if err := nvmlResult(C.nvmlDeviceGetCount(&count)); err != nil {
+		return nil, err
+	}
+
+	devices := make([]gpuDevice, 0, int(count))
+	var errs []error
+	for i := 0; i < int(count); i++ {
+		device, err := getNVMLDevice(C.uint(i))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading GPU %d: %w", i, err))
+			continue
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, consumererror.CombineErrors(errs)
+}
+
+func getNVMLDevice(index C.uint) (gpuDevice, error) {
+	var handle C.nvmlDevice_t
+	if err := nvmlResult(C.nvmlDeviceGetHandleByIndex(index, &handle)); err != nil {
+		return gpuDevice{}, err
+	}
+
+	var uuid [C.NVML_DEVICE_UUID_BUFFER_SIZE]C.char
+	if err := nvmlResult(C.nvmlDeviceGetUUID(handle, &uuid[0], C.NVML_DEVICE_UUID_BUFFER_SIZE)); err != nil {
+		return gpuDevice{}, err
+	}
+	device := gpuDevice{uuid: C.GoString(&uuid[0])}
+
+	var utilization C.nvmlUtilization_t
+	if result := C.nvmlDeviceGetUtilizationRates(handle, &utilization); !isNotSupported(result) {
+		if err := nvmlResult(result); err != nil {
+			return gpuDevice{}, err
+		}
+		value := float64(utilization.gpu) / 100
+		device.utilization = &value
+	}
+
+	var memory C.nvmlMemory_t
+	if result := C.nvmlDeviceGetMemoryInfo(handle, &memory); !isNotSupported(result) {
+		if err := nvmlResult(result); err != nil {
+			return gpuDevice{}, err
+		}
+		device.memory = &gpuMemory{used: uint64(memory.used), free: uint64(memory.free)}
+	}
+
+	var temperature C.uint
+	if result := C.nvmlDeviceGetTemperature(handle, &temperature); !isNotSupported(result) {
+		if err := nvmlResult(result); err != nil {
+			return gpuDevice{}, err
+		}
+		value := float64(temperature)
+		device.temperature = &value
+	}
+
+	processes, err := getNVMLProcesses(handle)
+	if err != nil {
+		return gpuDevice{}, err
+	}
+	device.processes = processes
+
+	return device, nil
+}
+
+func getNVMLProcesses(handle C.nvmlDevice_t) ([]gpuProcess, error) {
+	infos := make([]C.nvmlProcessInfo_t, 16)
+	for {
+		count := C.uint(len(infos))
+		result := C.nvmlDeviceGetComputeRunningProcesses(handle, &count, &infos[0])
+		if result == C.NVML_ERROR_INSUFFICIENT_SIZE {
+			// count holds the number of processes, which may still grow
+			// before the next call
+			infos = make([]C.nvmlProcessInfo_t, int(count)+16)
+			continue
+		}
+		if isNotSupported(result) {
+			return nil, nil
+		}
+		if err := nvmlResult(result); err != nil {
+			return nil, err
+		}
+
+		processes := make([]gpuProcess, 0, int(count))
+		for _, info := range infos[:count] {
+			// the memory used by the process is not always available, e.g.
+			// when the GPU is partitioned
+			if info.usedGpuMemory == C.NVML_VALUE_NOT_AVAILABLE {
+				continue
+			}
+			processes = append(processes, gpuProcess{pid: uint32(info.pid), usedMemory: uint64(info.usedGpuMemory)})
+		}
+		return processes, nil
+	}
+}
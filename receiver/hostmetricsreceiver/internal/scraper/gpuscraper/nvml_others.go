@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !nvml !linux
+
+package gpuscraper
+
+import "errors"
+
+var errNVMLNotSupported = errors.New("the collector was built without NVML support, it must be built with the nvml build tag on Linux")
+
+func initNVML() error {
+	return errNVMLNotSupported
+}
+
+func shutdownNVML() error {
+	return nil
+}
+
+func getNVMLDevices() ([]gpuDevice, error) {
+	return nil, errNVMLNotSupported
+}
@@ -19,4 +19,8 @@ import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
 // Config relating to Load Metric Scraper.
 type Config struct {
 	internal.ConfigSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// PerCPU specifies whether the load averages divided by the number of logical CPUs
+	// should also be reported.
+	PerCPU bool `mapstructure:"per_cpu"`
 }
@@ -16,8 +16,10 @@ package loadscraper
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/load"
 	"go.uber.org/zap"
 
@@ -27,7 +29,10 @@ import (
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
 
-const metricsLen = 3
+const (
+	loadMetricsLen       = 3
+	perCPULoadMetricsLen = 3
+)
 
 // scraper for Load Metrics
 type scraper struct {
@@ -35,12 +40,13 @@ type scraper struct {
 	config *Config
 
 	// for mocking
-	load func() (*load.AvgStat, error)
+	load     func() (*load.AvgStat, error)
+	cpuCount func(logical bool) (int, error)
 }
 
 // newLoadScraper creates a set of Load related metrics
 func newLoadScraper(_ context.Context, logger *zap.Logger, cfg *Config) *scraper {
-	return &scraper{logger: logger, config: cfg, load: getSampledLoadAverages}
+	return &scraper{logger: logger, config: cfg, load: getSampledLoadAverages, cpuCount: cpu.Counts}
 }
 
 // start
@@ -60,17 +66,43 @@ func (s *scraper) scrape(_ context.Context) (pdata.MetricSlice, error) {
 	now := pdata.TimestampFromTime(time.Now())
 	avgLoadValues, err := s.load()
 	if err != nil {
-		return metrics, scrapererror.NewPartialScrapeError(err, metricsLen)
+		return metrics, scrapererror.NewPartialScrapeError(err, s.metricsLen())
 	}
 
-	metrics.Resize(metricsLen)
+	metrics.Resize(loadMetricsLen)
 
 	initializeLoadMetric(metrics.At(0), metadata.Metrics.SystemCPULoadAverage1m, now, avgLoadValues.Load1)
 	initializeLoadMetric(metrics.At(1), metadata.Metrics.SystemCPULoadAverage5m, now, avgLoadValues.Load5)
 	initializeLoadMetric(metrics.At(2), metadata.Metrics.SystemCPULoadAverage15m, now, avgLoadValues.Load15)
+
+	if !s.config.PerCPU {
+		return metrics, nil
+	}
+
+	cpuCount, err := s.cpuCount(true)
+	if err == nil && cpuCount <= 0 {
+		err = fmt.Errorf("invalid number of logical CPUs %d", cpuCount)
+	}
+	if err != nil {
+		return metrics, scrapererror.NewPartialScrapeError(fmt.Errorf("error reading number of logical CPUs: %w", err), perCPULoadMetricsLen)
+	}
+
+	metrics.Resize(loadMetricsLen + perCPULoadMetricsLen)
+
+	initializeLoadMetric(metrics.At(3), metadata.Metrics.SystemCPULoadAverage1mPerCPU, now, avgLoadValues.Load1/float64(cpuCount))
+	initializeLoadMetric(metrics.At(4), metadata.Metrics.SystemCPULoadAverage5mPerCPU, now, avgLoadValues.Load5/float64(cpuCount))
+	initializeLoadMetric(metrics.At(5), metadata.Metrics.SystemCPULoadAverage15mPerCPU, now, avgLoadValues.Load15/float64(cpuCount))
 	return metrics, nil
 }
 
+// metricsLen returns the number of metrics reported by the scraper
+func (s *scraper) metricsLen() int {
+	if s.config.PerCPU {
+		return loadMetricsLen + perCPULoadMetricsLen
+	}
+	return loadMetricsLen
+}
+
 func initializeLoadMetric(metric pdata.Metric, metricDescriptor metadata.MetricIntf, now pdata.Timestamp, value float64) {
 	metricDescriptor.Init(metric)
 	idps := metric.DoubleGauge().DataPoints()
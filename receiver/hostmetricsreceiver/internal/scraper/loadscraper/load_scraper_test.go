@@ -33,28 +33,65 @@ import (
 
 func TestScrape(t *testing.T) {
 	type testCase struct {
-		name        string
-		loadFunc    func() (*load.AvgStat, error)
-		expectedErr string
+		name           string
+		config         Config
+		loadFunc       func() (*load.AvgStat, error)
+		cpuCountFunc   func(logical bool) (int, error)
+		expectedErr    string
+		expectedFailed int
+		expectedLen    int
 	}
 
 	testCases := []testCase{
 		{
-			name: "Standard",
+			name:        "Standard",
+			expectedLen: loadMetricsLen,
 		},
 		{
-			name:        "Load Error",
-			loadFunc:    func() (*load.AvgStat, error) { return nil, errors.New("err1") },
-			expectedErr: "err1",
+			name:        "Per CPU",
+			config:      Config{PerCPU: true},
+			expectedLen: loadMetricsLen + perCPULoadMetricsLen,
+		},
+		{
+			name:           "Load Error",
+			loadFunc:       func() (*load.AvgStat, error) { return nil, errors.New("err1") },
+			expectedErr:    "err1",
+			expectedFailed: loadMetricsLen,
+		},
+		{
+			name:           "Per CPU Load Error",
+			config:         Config{PerCPU: true},
+			loadFunc:       func() (*load.AvgStat, error) { return nil, errors.New("err1") },
+			expectedErr:    "err1",
+			expectedFailed: loadMetricsLen + perCPULoadMetricsLen,
+		},
+		{
+			name:           "CPU Count Error",
+			config:         Config{PerCPU: true},
+			cpuCountFunc:   func(bool) (int, error) { return 0, errors.New("err2") },
+			expectedErr:    "error reading number of logical CPUs: err2",
+			expectedFailed: perCPULoadMetricsLen,
+			expectedLen:    loadMetricsLen,
+		},
+		{
+			name:           "Invalid CPU Count",
+			config:         Config{PerCPU: true},
+			cpuCountFunc:   func(bool) (int, error) { return 0, nil },
+			expectedErr:    "error reading number of logical CPUs: invalid number of logical CPUs 0",
+			expectedFailed: perCPULoadMetricsLen,
+			expectedLen:    loadMetricsLen,
 		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			scraper := newLoadScraper(context.Background(), zap.NewNop(), &Config{})
+			scraper := newLoadScraper(context.Background(), zap.NewNop(), &test.config)
 			if test.loadFunc != nil {
 				scraper.load = test.loadFunc
 			}
+			if test.cpuCountFunc != nil {
+				scraper.cpuCount = test.cpuCountFunc
+			}
 
 			err := scraper.start(context.Background(), componenttest.NewNopHost())
 			require.NoError(t, err, "Failed to initialize load scraper: %v", err)
@@ -67,26 +104,51 @@ func TestScrape(t *testing.T) {
 				isPartial := scrapererror.IsPartialScrapeError(err)
 				assert.True(t, isPartial)
 				if isPartial {
-					assert.Equal(t, metricsLen, err.(scrapererror.PartialScrapeError).Failed)
+					assert.Equal(t, test.expectedFailed, err.(scrapererror.PartialScrapeError).Failed)
 				}
+			} else {
+				require.NoError(t, err, "Failed to scrape metrics: %v", err)
+			}
 
+			assert.Equal(t, test.expectedLen, metrics.Len())
+			if metrics.Len() == 0 {
 				return
 			}
-			require.NoError(t, err, "Failed to scrape metrics: %v", err)
-
-			// expect 3 metrics
-			assert.Equal(t, 3, metrics.Len())
 
 			// expect a single datapoint for 1m, 5m & 15m load metrics
 			assertMetricHasSingleDatapoint(t, metrics.At(0), metadata.Metrics.SystemCPULoadAverage1m.New())
 			assertMetricHasSingleDatapoint(t, metrics.At(1), metadata.Metrics.SystemCPULoadAverage5m.New())
 			assertMetricHasSingleDatapoint(t, metrics.At(2), metadata.Metrics.SystemCPULoadAverage15m.New())
 
+			if metrics.Len() > loadMetricsLen {
+				assertMetricHasSingleDatapoint(t, metrics.At(3), metadata.Metrics.SystemCPULoadAverage1mPerCPU.New())
+				assertMetricHasSingleDatapoint(t, metrics.At(4), metadata.Metrics.SystemCPULoadAverage5mPerCPU.New())
+				assertMetricHasSingleDatapoint(t, metrics.At(5), metadata.Metrics.SystemCPULoadAverage15mPerCPU.New())
+			}
+
 			internal.AssertSameTimeStampForAllMetrics(t, metrics)
 		})
 	}
 }
 
+func TestScrape_PerCPUValues(t *testing.T) {
+	scraper := newLoadScraper(context.Background(), zap.NewNop(), &Config{PerCPU: true})
+	scraper.load = func() (*load.AvgStat, error) { return &load.AvgStat{Load1: 8, Load5: 4, Load15: 2}, nil }
+	scraper.cpuCount = func(bool) (int, error) { return 4, nil }
+
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize load scraper: %v", err)
+	defer func() { assert.NoError(t, scraper.shutdown(context.Background())) }()
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err, "Failed to scrape metrics: %v", err)
+	require.Equal(t, loadMetricsLen+perCPULoadMetricsLen, metrics.Len())
+
+	assert.Equal(t, 2.0, metrics.At(3).DoubleGauge().DataPoints().At(0).Value())
+	assert.Equal(t, 1.0, metrics.At(4).DoubleGauge().DataPoints().At(0).Value())
+	assert.Equal(t, 0.5, metrics.At(5).DoubleGauge().DataPoints().At(0).Value())
+}
+
 func assertMetricHasSingleDatapoint(t *testing.T, metric pdata.Metric, descriptor pdata.Metric) {
 	internal.AssertDescriptorEqual(t, descriptor, metric)
 	assert.Equal(t, 1, metric.DoubleGauge().DataPoints().Len())
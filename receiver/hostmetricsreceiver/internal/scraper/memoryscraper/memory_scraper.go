@@ -16,6 +16,7 @@ package memoryscraper
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/shirou/gopsutil/mem"
@@ -25,7 +26,18 @@ import (
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
 
-const metricsLen = 1
+const (
+	memoryUsageMetricsLen       = 1
+	memoryUtilizationMetricsLen = 1
+
+	metricsLen = memoryUsageMetricsLen + memoryUtilizationMetricsLen + commitMetricsLen
+)
+
+// commitStat stores the memory committed by the system and its commit limit.
+type commitStat struct {
+	committed uint64
+	limit     uint64
+}
 
 // scraper for Memory Metrics
 type scraper struct {
@@ -33,26 +45,79 @@ type scraper struct {
 
 	// for mocking gopsutil mem.VirtualMemory
 	virtualMemory func() (*mem.VirtualMemoryStat, error)
+	// for mocking the system calls reading the committed memory
+	commitMemory func() (*commitStat, error)
 }
 
 // newMemoryScraper creates a Memory Scraper
 func newMemoryScraper(_ context.Context, cfg *Config) *scraper {
-	return &scraper{config: cfg, virtualMemory: mem.VirtualMemory}
+	return &scraper{config: cfg, virtualMemory: mem.VirtualMemory, commitMemory: getCommitStat}
 }
 
 // Scrape
 func (s *scraper) Scrape(_ context.Context) (pdata.MetricSlice, error) {
 	metrics := pdata.NewMetricSlice()
 
+	var errors scrapererror.ScrapeErrors
+
 	now := pdata.TimestampFromTime(time.Now())
+	err := s.scrapeAndAppendMemoryUsageMetrics(metrics, now)
+	if err != nil {
+		errors.AddPartial(memoryUsageMetricsLen+memoryUtilizationMetricsLen, err)
+	}
+
+	if commitMetricsLen > 0 {
+		err = s.scrapeAndAppendCommitMetrics(metrics, now)
+		if err != nil {
+			errors.AddPartial(commitMetricsLen, fmt.Errorf("error reading committed memory: %w", err))
+		}
+	}
+
+	return metrics, errors.Combine()
+}
+
+func (s *scraper) scrapeAndAppendMemoryUsageMetrics(metrics pdata.MetricSlice, now pdata.Timestamp) error {
 	memInfo, err := s.virtualMemory()
 	if err != nil {
-		return metrics, scrapererror.NewPartialScrapeError(err, metricsLen)
+		return err
+	}
+
+	idx := metrics.Len()
+	metrics.Resize(idx + memoryUsageMetricsLen + memoryUtilizationMetricsLen)
+	initializeMemoryUsageMetric(metrics.At(idx+0), now, memInfo)
+	initializeMemoryUtilizationMetric(metrics.At(idx+1), now, memInfo)
+	return nil
+}
+
+func (s *scraper) scrapeAndAppendCommitMetrics(metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	commit, err := s.commitMemory()
+	if err != nil {
+		return err
 	}
 
-	metrics.Resize(metricsLen)
-	initializeMemoryUsageMetric(metrics.At(0), now, memInfo)
-	return metrics, nil
+	idx := metrics.Len()
+	metrics.Resize(idx + commitMetricsLen)
+	initializeCommittedMetric(metrics.At(idx+0), now, commit.committed)
+	initializeCommitLimitMetric(metrics.At(idx+1), now, commit.limit)
+	return nil
+}
+
+func initializeCommittedMetric(metric pdata.Metric, now pdata.Timestamp, committed uint64) {
+	metadata.Metrics.SystemMemoryCommitted.Init(metric)
+
+	idps := metric.IntSum().DataPoints()
+	idps.Resize(1)
+	idps.At(0).SetTimestamp(now)
+	idps.At(0).SetValue(int64(committed))
+}
+
+func initializeCommitLimitMetric(metric pdata.Metric, now pdata.Timestamp, limit uint64) {
+	metadata.Metrics.SystemMemoryCommitLimit.Init(metric)
+
+	idps := metric.IntGauge().DataPoints()
+	idps.Resize(1)
+	idps.At(0).SetTimestamp(now)
+	idps.At(0).SetValue(int64(limit))
 }
 
 func initializeMemoryUsageMetric(metric pdata.Metric, now pdata.Timestamp, memInfo *mem.VirtualMemoryStat) {
@@ -63,9 +128,26 @@ func initializeMemoryUsageMetric(metric pdata.Metric, now pdata.Timestamp, memIn
 	appendMemoryUsageStateDataPoints(idps, now, memInfo)
 }
 
+func initializeMemoryUtilizationMetric(metric pdata.Metric, now pdata.Timestamp, memInfo *mem.VirtualMemoryStat) {
+	metadata.Metrics.SystemMemoryUtilization.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(memStatesLen)
+	appendMemoryUtilizationStateDataPoints(ddps, now, memInfo)
+}
+
 func initializeMemoryUsageDataPoint(dataPoint pdata.IntDataPoint, now pdata.Timestamp, stateLabel string, value int64) {
 	labelsMap := dataPoint.LabelsMap()
 	labelsMap.Insert(metadata.Labels.MemState, stateLabel)
 	dataPoint.SetTimestamp(now)
 	dataPoint.SetValue(value)
 }
+
+func initializeMemoryUtilizationDataPoint(dataPoint pdata.DoubleDataPoint, now pdata.Timestamp, stateLabel string, value uint64, total uint64) {
+	labelsMap := dataPoint.LabelsMap()
+	labelsMap.Insert(metadata.Labels.MemState, stateLabel)
+	dataPoint.SetTimestamp(now)
+	if total > 0 {
+		dataPoint.SetValue(float64(value) / float64(total))
+	}
+}
@@ -23,7 +23,12 @@ import (
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
 )
 
-const memStatesLen = 6
+const (
+	memStatesLen = 6
+
+	// commitMetricsLen is the number of committed memory metrics, only reported on Windows.
+	commitMetricsLen = 0
+)
 
 func appendMemoryUsageStateDataPoints(idps pdata.IntDataPointSlice, now pdata.Timestamp, memInfo *mem.VirtualMemoryStat) {
 	initializeMemoryUsageDataPoint(idps.At(0), now, metadata.LabelMemState.Used, int64(memInfo.Used))
@@ -33,3 +38,16 @@ func appendMemoryUsageStateDataPoints(idps pdata.IntDataPointSlice, now pdata.Ti
 	initializeMemoryUsageDataPoint(idps.At(4), now, metadata.LabelMemState.SlabReclaimable, int64(memInfo.SReclaimable))
 	initializeMemoryUsageDataPoint(idps.At(5), now, metadata.LabelMemState.SlabUnreclaimable, int64(memInfo.SUnreclaim))
 }
+
+func appendMemoryUtilizationStateDataPoints(ddps pdata.DoubleDataPointSlice, now pdata.Timestamp, memInfo *mem.VirtualMemoryStat) {
+	initializeMemoryUtilizationDataPoint(ddps.At(0), now, metadata.LabelMemState.Used, memInfo.Used, memInfo.Total)
+	initializeMemoryUtilizationDataPoint(ddps.At(1), now, metadata.LabelMemState.Free, memInfo.Free, memInfo.Total)
+	initializeMemoryUtilizationDataPoint(ddps.At(2), now, metadata.LabelMemState.Buffered, memInfo.Buffers, memInfo.Total)
+	initializeMemoryUtilizationDataPoint(ddps.At(3), now, metadata.LabelMemState.Cached, memInfo.Cached, memInfo.Total)
+	initializeMemoryUtilizationDataPoint(ddps.At(4), now, metadata.LabelMemState.SlabReclaimable, memInfo.SReclaimable, memInfo.Total)
+	initializeMemoryUtilizationDataPoint(ddps.At(5), now, metadata.LabelMemState.SlabUnreclaimable, memInfo.SUnreclaim, memInfo.Total)
+}
+
+func getCommitStat() (*commitStat, error) {
+	return nil, nil
+}
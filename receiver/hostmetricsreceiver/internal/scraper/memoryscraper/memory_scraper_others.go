@@ -23,10 +23,25 @@ import (
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
 )
 
-const memStatesLen = 3
+const (
+	memStatesLen = 3
+
+	// commitMetricsLen is the number of committed memory metrics, only reported on Windows.
+	commitMetricsLen = 0
+)
 
 func appendMemoryUsageStateDataPoints(idps pdata.IntDataPointSlice, now pdata.Timestamp, memInfo *mem.VirtualMemoryStat) {
 	initializeMemoryUsageDataPoint(idps.At(0), now, metadata.LabelMemState.Used, int64(memInfo.Used))
 	initializeMemoryUsageDataPoint(idps.At(1), now, metadata.LabelMemState.Free, int64(memInfo.Free))
 	initializeMemoryUsageDataPoint(idps.At(2), now, metadata.LabelMemState.Inactive, int64(memInfo.Inactive))
 }
+
+func appendMemoryUtilizationStateDataPoints(ddps pdata.DoubleDataPointSlice, now pdata.Timestamp, memInfo *mem.VirtualMemoryStat) {
+	initializeMemoryUtilizationDataPoint(ddps.At(0), now, metadata.LabelMemState.Used, memInfo.Used, memInfo.Total)
+	initializeMemoryUtilizationDataPoint(ddps.At(1), now, metadata.LabelMemState.Free, memInfo.Free, memInfo.Total)
+	initializeMemoryUtilizationDataPoint(ddps.At(2), now, metadata.LabelMemState.Inactive, memInfo.Inactive, memInfo.Total)
+}
+
+func getCommitStat() (*commitStat, error) {
+	return nil, nil
+}
@@ -34,7 +34,9 @@ func TestScrape(t *testing.T) {
 	type testCase struct {
 		name              string
 		virtualMemoryFunc func() (*mem.VirtualMemoryStat, error)
+		commitMemoryFunc  func() (*commitStat, error)
 		expectedErr       string
+		expectedFailed    int
 	}
 
 	testCases := []testCase{
@@ -45,15 +47,28 @@ func TestScrape(t *testing.T) {
 			name:              "Error",
 			virtualMemoryFunc: func() (*mem.VirtualMemoryStat, error) { return nil, errors.New("err1") },
 			expectedErr:       "err1",
+			expectedFailed:    memoryUsageMetricsLen + memoryUtilizationMetricsLen,
 		},
 	}
 
+	if runtime.GOOS == "windows" {
+		testCases = append(testCases, testCase{
+			name:             "Commit Error",
+			commitMemoryFunc: func() (*commitStat, error) { return nil, errors.New("err2") },
+			expectedErr:      "error reading committed memory: err2",
+			expectedFailed:   commitMetricsLen,
+		})
+	}
+
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			scraper := newMemoryScraper(context.Background(), &Config{})
 			if test.virtualMemoryFunc != nil {
 				scraper.virtualMemory = test.virtualMemoryFunc
 			}
+			if test.commitMemoryFunc != nil {
+				scraper.commitMemory = test.commitMemoryFunc
+			}
 
 			metrics, err := scraper.Scrape(context.Background())
 			if test.expectedErr != "" {
@@ -62,20 +77,24 @@ func TestScrape(t *testing.T) {
 				isPartial := scrapererror.IsPartialScrapeError(err)
 				assert.True(t, isPartial)
 				if isPartial {
-					assert.Equal(t, metricsLen, err.(scrapererror.PartialScrapeError).Failed)
+					assert.Equal(t, test.expectedFailed, err.(scrapererror.PartialScrapeError).Failed)
 				}
 
+				assert.Equal(t, metricsLen-test.expectedFailed, metrics.Len())
 				return
 			}
 			require.NoError(t, err, "Failed to scrape metrics: %v", err)
 
-			assert.Equal(t, 1, metrics.Len())
+			assert.Equal(t, metricsLen, metrics.Len())
 
 			assertMemoryUsageMetricValid(t, metrics.At(0), metadata.Metrics.SystemMemoryUsage.New())
+			assertMemoryUtilizationMetricValid(t, metrics.At(1))
 
 			if runtime.GOOS == "linux" {
 				assertMemoryUsageMetricHasLinuxSpecificStateLabels(t, metrics.At(0))
-			} else if runtime.GOOS != "windows" {
+			} else if runtime.GOOS == "windows" {
+				assertCommitMetricsValid(t, metrics.At(2), metrics.At(3))
+			} else {
 				internal.AssertIntSumMetricLabelHasValue(t, metrics.At(0), 2, metadata.Labels.MemState, metadata.LabelMemState.Inactive)
 			}
 
@@ -84,6 +103,22 @@ func TestScrape(t *testing.T) {
 	}
 }
 
+func TestScrape_CommitMetricValues(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skipf("skipping test on %v", runtime.GOOS)
+	}
+
+	scraper := newMemoryScraper(context.Background(), &Config{})
+	scraper.commitMemory = func() (*commitStat, error) { return &commitStat{committed: 1024, limit: 4096}, nil }
+
+	metrics, err := scraper.Scrape(context.Background())
+	require.NoError(t, err, "Failed to scrape metrics: %v", err)
+	require.Equal(t, metricsLen, metrics.Len())
+
+	assert.EqualValues(t, 1024, metrics.At(2).IntSum().DataPoints().At(0).Value())
+	assert.EqualValues(t, 4096, metrics.At(3).IntGauge().DataPoints().At(0).Value())
+}
+
 func assertMemoryUsageMetricValid(t *testing.T, metric pdata.Metric, descriptor pdata.Metric) {
 	internal.AssertDescriptorEqual(t, descriptor, metric)
 	assert.GreaterOrEqual(t, metric.IntSum().DataPoints().Len(), 2)
@@ -91,9 +126,42 @@ func assertMemoryUsageMetricValid(t *testing.T, metric pdata.Metric, descriptor
 	internal.AssertIntSumMetricLabelHasValue(t, metric, 1, metadata.Labels.MemState, metadata.LabelMemState.Free)
 }
 
+func TestScrape_MemoryUtilization(t *testing.T) {
+	scraper := newMemoryScraper(context.Background(), &Config{})
+	scraper.virtualMemory = func() (*mem.VirtualMemoryStat, error) {
+		return &mem.VirtualMemoryStat{Total: 1000, Used: 250, Free: 500, Available: 750}, nil
+	}
+
+	metrics, err := scraper.Scrape(context.Background())
+	require.NoError(t, err, "Failed to scrape metrics: %v", err)
+
+	ddps := metrics.At(1).DoubleGauge().DataPoints()
+	assert.Equal(t, 0.25, ddps.At(0).Value())
+	if runtime.GOOS == "windows" {
+		// on Windows, the available memory is reported as free
+		assert.Equal(t, 0.75, ddps.At(1).Value())
+	} else {
+		assert.Equal(t, 0.5, ddps.At(1).Value())
+	}
+}
+
+func assertMemoryUtilizationMetricValid(t *testing.T, metric pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemMemoryUtilization.New(), metric)
+	assert.GreaterOrEqual(t, metric.DoubleGauge().DataPoints().Len(), 2)
+	internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 0, metadata.Labels.MemState, metadata.LabelMemState.Used)
+	internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 1, metadata.Labels.MemState, metadata.LabelMemState.Free)
+}
+
 func assertMemoryUsageMetricHasLinuxSpecificStateLabels(t *testing.T, metric pdata.Metric) {
 	internal.AssertIntSumMetricLabelHasValue(t, metric, 2, metadata.Labels.MemState, metadata.LabelMemState.Buffered)
 	internal.AssertIntSumMetricLabelHasValue(t, metric, 3, metadata.Labels.MemState, metadata.LabelMemState.Cached)
 	internal.AssertIntSumMetricLabelHasValue(t, metric, 4, metadata.Labels.MemState, metadata.LabelMemState.SlabReclaimable)
 	internal.AssertIntSumMetricLabelHasValue(t, metric, 5, metadata.Labels.MemState, metadata.LabelMemState.SlabUnreclaimable)
 }
+
+func assertCommitMetricsValid(t *testing.T, committed pdata.Metric, limit pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemMemoryCommitted.New(), committed)
+	assert.Greater(t, committed.IntSum().DataPoints().At(0).Value(), int64(0))
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemMemoryCommitLimit.New(), limit)
+	assert.GreaterOrEqual(t, limit.IntGauge().DataPoints().At(0).Value(), committed.IntSum().DataPoints().At(0).Value())
+}
@@ -17,15 +17,62 @@
 package memoryscraper
 
 import (
+	"unsafe"
+
 	"github.com/shirou/gopsutil/mem"
+	"golang.org/x/sys/windows"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
 )
 
-const memStatesLen = 2
+const (
+	memStatesLen = 2
+
+	// commitMetricsLen is the number of committed memory metrics, only reported on Windows.
+	commitMetricsLen = 2
+)
 
 func appendMemoryUsageStateDataPoints(idps pdata.IntDataPointSlice, now pdata.Timestamp, memInfo *mem.VirtualMemoryStat) {
 	initializeMemoryUsageDataPoint(idps.At(0), now, metadata.LabelMemState.Used, int64(memInfo.Used))
 	initializeMemoryUsageDataPoint(idps.At(1), now, metadata.LabelMemState.Free, int64(memInfo.Available))
 }
+
+func appendMemoryUtilizationStateDataPoints(ddps pdata.DoubleDataPointSlice, now pdata.Timestamp, memInfo *mem.VirtualMemoryStat) {
+	initializeMemoryUtilizationDataPoint(ddps.At(0), now, metadata.LabelMemState.Used, memInfo.Used, memInfo.Total)
+	initializeMemoryUtilizationDataPoint(ddps.At(1), now, metadata.LabelMemState.Free, memInfo.Available, memInfo.Total)
+}
+
+var procGetPerformanceInfo = windows.NewLazySystemDLL("psapi.dll").NewProc("GetPerformanceInfo")
+
+// system type as defined in https://docs.microsoft.com/en-us/windows/win32/api/psapi/ns-psapi-performance_information
+type performanceInformation struct {
+	cb                uint32
+	commitTotal       uintptr
+	commitLimit       uintptr
+	commitPeak        uintptr
+	physicalTotal     uintptr
+	physicalAvailable uintptr
+	systemCache       uintptr
+	kernelTotal       uintptr
+	kernelPaged       uintptr
+	kernelNonpaged    uintptr
+	pageSize          uintptr
+	handleCount       uint32
+	processCount      uint32
+	threadCount       uint32
+}
+
+func getCommitStat() (*commitStat, error) {
+	var perfInfo performanceInformation
+	perfInfo.cb = uint32(unsafe.Sizeof(perfInfo))
+	if ret, _, err := procGetPerformanceInfo.Call(uintptr(unsafe.Pointer(&perfInfo)), uintptr(perfInfo.cb)); ret == 0 {
+		return nil, err
+	}
+
+	// the commit values are expressed in pages
+	return &commitStat{
+		committed: uint64(perfInfo.commitTotal) * uint64(perfInfo.pageSize),
+		limit:     uint64(perfInfo.commitLimit) * uint64(perfInfo.pageSize),
+	}, nil
+}
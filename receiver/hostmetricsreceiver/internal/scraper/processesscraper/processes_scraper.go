@@ -31,13 +31,17 @@ type scraper struct {
 
 	// for mocking gopsutil load.Misc
 	misc getMiscStats
+	// for mocking the count of zombie processes
+	zombies getZombieCount
 }
 
 type getMiscStats func() (*load.MiscStat, error)
 
+type getZombieCount func() (int64, error)
+
 // newProcessesScraper creates a set of Processes related metrics
 func newProcessesScraper(_ context.Context, cfg *Config) *scraper {
-	return &scraper{config: cfg, misc: load.Misc}
+	return &scraper{config: cfg, misc: load.Misc, zombies: zombieCount}
 }
 
 func (s *scraper) start(context.Context, component.Host) error {
@@ -52,6 +56,6 @@ func (s *scraper) start(context.Context, component.Host) error {
 
 func (s *scraper) scrape(_ context.Context) (pdata.MetricSlice, error) {
 	metrics := pdata.NewMetricSlice()
-	err := appendSystemSpecificProcessesMetrics(metrics, 0, s.misc)
+	err := appendSystemSpecificProcessesMetrics(metrics, 0, s.misc, s.zombies)
 	return metrics, err
 }
@@ -18,6 +18,6 @@ package processesscraper
 
 import "go.opentelemetry.io/collector/consumer/pdata"
 
-func appendSystemSpecificProcessesMetrics(metrics pdata.MetricSlice, startIndex int, miscFunc getMiscStats) error {
+func appendSystemSpecificProcessesMetrics(metrics pdata.MetricSlice, startIndex int, miscFunc getMiscStats, zombiesFunc getZombieCount) error {
 	return nil
 }
@@ -35,6 +35,7 @@ func TestScrape(t *testing.T) {
 	type testCase struct {
 		name        string
 		miscFunc    func() (*load.MiscStat, error)
+		zombiesFunc func() (int64, error)
 		expectedErr string
 	}
 
@@ -49,6 +50,14 @@ func TestScrape(t *testing.T) {
 		},
 	}
 
+	if runtime.GOOS == "linux" {
+		testCases = append(testCases, testCase{
+			name:        "Zombies Error",
+			zombiesFunc: func() (int64, error) { return 0, errors.New("err2") },
+			expectedErr: "error reading zombie processes: err2",
+		})
+	}
+
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			expectProcessesCountMetric := (runtime.GOOS == "linux" || runtime.GOOS == "openbsd" || runtime.GOOS == "darwin" || runtime.GOOS == "freebsd")
@@ -58,6 +67,9 @@ func TestScrape(t *testing.T) {
 			if test.miscFunc != nil {
 				scraper.misc = test.miscFunc
 			}
+			if test.zombiesFunc != nil {
+				scraper.zombies = test.zombiesFunc
+			}
 
 			err := scraper.start(context.Background(), componenttest.NewNopHost())
 			require.NoError(t, err, "Failed to initialize processes scraper: %v", err)
@@ -72,7 +84,18 @@ func TestScrape(t *testing.T) {
 				expectedMetricCount++
 			}
 
-			if (expectProcessesCountMetric || expectProcessesCreatedMetric) && test.expectedErr != "" {
+			expectZombiesDataPoint := runtime.GOOS == "linux" && test.zombiesFunc == nil
+
+			switch {
+			case test.zombiesFunc != nil:
+				// the zombie processes failure is partial, the other metrics are still scraped
+				assert.EqualError(t, err, test.expectedErr)
+				isPartial := scrapererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, 0, err.(scrapererror.PartialScrapeError).Failed)
+				}
+			case (expectProcessesCountMetric || expectProcessesCreatedMetric) && test.expectedErr != "":
 				assert.EqualError(t, err, test.expectedErr)
 
 				isPartial := scrapererror.IsPartialScrapeError(err)
@@ -82,13 +105,14 @@ func TestScrape(t *testing.T) {
 				}
 
 				return
+			default:
+				require.NoError(t, err, "Failed to scrape metrics: %v", err)
 			}
-			require.NoError(t, err, "Failed to scrape metrics: %v", err)
 
 			assert.Equal(t, expectedMetricCount, metrics.Len())
 
 			if expectProcessesCountMetric {
-				assertProcessesCountMetricValid(t, metrics.At(0))
+				assertProcessesCountMetricValid(t, metrics.At(0), expectZombiesDataPoint)
 			}
 			if expectProcessesCreatedMetric {
 				assertProcessesCreatedMetricValid(t, metrics.At(1))
@@ -99,9 +123,14 @@ func TestScrape(t *testing.T) {
 	}
 }
 
-func assertProcessesCountMetricValid(t *testing.T, metric pdata.Metric) {
+func assertProcessesCountMetricValid(t *testing.T, metric pdata.Metric, expectZombies bool) {
 	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemProcessesCount.New(), metric)
-	assert.Equal(t, 2, metric.IntSum().DataPoints().Len())
+	if expectZombies {
+		assert.Equal(t, 3, metric.IntSum().DataPoints().Len())
+		internal.AssertIntSumMetricLabelHasValue(t, metric, 2, "status", "zombie")
+	} else {
+		assert.Equal(t, 2, metric.IntSum().DataPoints().Len())
+	}
 	internal.AssertIntSumMetricLabelHasValue(t, metric, 0, "status", "running")
 	internal.AssertIntSumMetricLabelHasValue(t, metric, 1, "status", "blocked")
 }
@@ -17,6 +17,7 @@
 package processesscraper
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/shirou/gopsutil/load"
@@ -31,26 +32,42 @@ const (
 	unixMetricsLen         = standardUnixMetricsLen + unixSystemSpecificMetricsLen
 )
 
-func appendSystemSpecificProcessesMetrics(metrics pdata.MetricSlice, startIndex int, miscFunc getMiscStats) error {
+func appendSystemSpecificProcessesMetrics(metrics pdata.MetricSlice, startIndex int, miscFunc getMiscStats, zombiesFunc getZombieCount) error {
 	now := pdata.TimestampFromTime(time.Now())
 	misc, err := miscFunc()
 	if err != nil {
 		return scrapererror.NewPartialScrapeError(err, unixMetricsLen)
 	}
 
+	// the zombie processes are only counted on platforms exposing the state of
+	// each process, their data point is omitted if they cannot be counted
+	var zombies *int64
+	if zombieDataPointsLen > 0 {
+		count, zombiesErr := zombiesFunc()
+		if zombiesErr != nil {
+			err = scrapererror.NewPartialScrapeError(fmt.Errorf("error reading zombie processes: %w", zombiesErr), 0)
+		} else {
+			zombies = &count
+		}
+	}
+
 	metrics.Resize(startIndex + unixMetricsLen)
-	initializeProcessesCountMetric(metrics.At(startIndex+0), now, misc)
+	initializeProcessesCountMetric(metrics.At(startIndex+0), now, misc, zombies)
 	appendUnixSystemSpecificProcessesMetrics(metrics, startIndex+1, now, misc)
-	return nil
+	return err
 }
 
-func initializeProcessesCountMetric(metric pdata.Metric, now pdata.Timestamp, misc *load.MiscStat) {
+func initializeProcessesCountMetric(metric pdata.Metric, now pdata.Timestamp, misc *load.MiscStat, zombies *int64) {
 	metadata.Metrics.SystemProcessesCount.Init(metric)
 
 	ddps := metric.IntSum().DataPoints()
 	ddps.Resize(2)
 	initializeProcessesCountDataPoint(ddps.At(0), now, metadata.LabelProcessesStatus.Running, int64(misc.ProcsRunning))
 	initializeProcessesCountDataPoint(ddps.At(1), now, metadata.LabelProcessesStatus.Blocked, int64(misc.ProcsBlocked))
+	if zombies != nil {
+		ddps.Resize(3)
+		initializeProcessesCountDataPoint(ddps.At(2), now, metadata.LabelProcessesStatus.Zombie, *zombies)
+	}
 }
 
 func initializeProcessesCountDataPoint(dataPoint pdata.IntDataPoint, now pdata.Timestamp, statusLabel string, value int64) {
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package processesscraper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const zombieDataPointsLen = 1
+
+// zombieCount returns the number of zombie processes, read from the state of
+// each process in /proc/<pid>/stat. The proc filesystem mounted at HOST_PROC
+// is read when set, the same as gopsutil.
+func zombieCount() (int64, error) {
+	procPath := os.Getenv("HOST_PROC")
+	if procPath == "" {
+		procPath = "/proc"
+	}
+
+	dir, err := os.Open(procPath)
+	if err != nil {
+		return 0, err
+	}
+	defer dir.Close()
+
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return 0, err
+	}
+
+	var zombies int64
+	for _, name := range names {
+		if _, err := strconv.Atoi(name); err != nil {
+			continue
+		}
+
+		stat, err := ioutil.ReadFile(filepath.Join(procPath, name, "stat"))
+		if err != nil {
+			// the process exited since the directory was listed
+			continue
+		}
+
+		if parseProcessState(string(stat)) == "Z" {
+			zombies++
+		}
+	}
+
+	return zombies, nil
+}
+
+// parseProcessState returns the state of the process from the content of
+// /proc/<pid>/stat. The state follows the command name, which is enclosed in
+// parentheses and may itself contain spaces and parentheses.
+func parseProcessState(stat string) string {
+	i := strings.LastIndexByte(stat, ')')
+	if i < 0 {
+		return ""
+	}
+
+	fields := strings.Fields(stat[i+1:])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
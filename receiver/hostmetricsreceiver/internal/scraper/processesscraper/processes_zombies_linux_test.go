@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package processesscraper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProcessState(t *testing.T) {
+	testCases := []struct {
+		name     string
+		stat     string
+		expected string
+	}{
+		{
+			name:     "Sleeping",
+			stat:     "1 (systemd) S 0 1 1 0 -1 4194560 47566 1185787 103 1205",
+			expected: "S",
+		},
+		{
+			name:     "Zombie",
+			stat:     "4242 (sh) Z 4200 4242 4200 0 -1 4227084 0 0 0 0",
+			expected: "Z",
+		},
+		{
+			name:     "Command With Spaces And Parentheses",
+			stat:     "4243 (my (odd) cmd) Z 4200 4243 4200 0 -1 4227084 0 0 0 0",
+			expected: "Z",
+		},
+		{
+			name:     "Invalid",
+			stat:     "4244",
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseProcessState(test.stat))
+		})
+	}
+}
@@ -23,15 +23,64 @@ import (
 type Config struct {
 	internal.ConfigSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
-	// Include specifies a filter on the process names that should be included from the generated metrics.
-	// Exclude specifies a filter on the process names that should be excluded from the generated metrics.
+	// Include specifies a filter on the processes that should be included from the generated metrics.
+	// Exclude specifies a filter on the processes that should be excluded from the generated metrics.
 	// If neither `include` or `exclude` are set, process metrics will be generated for all processes.
 	Include MatchConfig `mapstructure:"include"`
 	Exclude MatchConfig `mapstructure:"exclude"`
+
+	// RedactCommandLines specifies the parts of the command lines to redact before they are
+	// reported in the process.command_line attribute, e.g. passwords and tokens passed as arguments.
+	RedactCommandLines []RedactConfig `mapstructure:"redact_command_lines"`
+
+	// TolerateMetadataErrors reports the processes whose executable name or path cannot be read,
+	// e.g. due to permission errors, with the metadata that could be read instead of skipping them.
+	TolerateMetadataErrors bool `mapstructure:"tolerate_metadata_errors"`
+
+	// Top limits the metrics to the processes using the most CPU or memory.
+	Top TopConfig `mapstructure:"top"`
+
+	// EnvironmentVariables are the names of the environment variables whose values are
+	// reported in the process.env.<name> attributes of the processes setting them (Linux only).
+	EnvironmentVariables []string `mapstructure:"environment_variables"`
+}
+
+// RedactConfig replaces the parts of the command lines matching a regular expression.
+type RedactConfig struct {
+	// Pattern is the regular expression matching the parts of the command lines to redact.
+	Pattern string `mapstructure:"pattern"`
+
+	// Replacement is the text replacing the matches of the pattern, it can reference the
+	// submatches of the pattern, e.g. `$1`. If empty, the matches are replaced with `***`.
+	Replacement string `mapstructure:"replacement"`
 }
 
+// MatchConfig matches the processes whose properties match all the configured
+// properties. A property matches if it matches any of its values.
 type MatchConfig struct {
+	// Config specifies how the names, command lines and users are matched.
 	filterset.Config `mapstructure:",squash"`
 
+	// Names are the executable names to match.
 	Names []string `mapstructure:"names"`
+
+	// CommandLines are the command lines to match.
+	CommandLines []string `mapstructure:"command_lines"`
+
+	// Pids are the process IDs to match, they are always matched exactly.
+	Pids []int32 `mapstructure:"pids"`
+
+	// Users are the owners of the processes to match.
+	Users []string `mapstructure:"users"`
+}
+
+// TopConfig limits the metrics to the processes using the most resources, the
+// metrics of the other processes are aggregated.
+type TopConfig struct {
+	// Count is the number of processes to report, if 0 all the processes are reported.
+	Count int `mapstructure:"count"`
+
+	// SortBy is the resource used to rank the processes, either `cpu` (the CPU time used
+	// since the previous scrape) or `memory` (the physical memory in use). Defaults to `cpu`.
+	SortBy string `mapstructure:"sort_by"`
 }
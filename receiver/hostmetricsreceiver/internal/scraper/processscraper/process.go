@@ -30,9 +30,12 @@ import (
 
 type processMetadata struct {
 	pid        int32
+	parentPid  int32
 	executable *executableMetadata
 	command    *commandMetadata
 	username   string
+	container  *containerMetadata
+	env        []environmentVariable
 	handle     processHandle
 }
 
@@ -47,22 +50,47 @@ type commandMetadata struct {
 	commandLineSlice []string
 }
 
+// line returns the command line of the process.
+func (m *commandMetadata) line() string {
+	if m.commandLineSlice != nil {
+		// TODO insert slice here once this is supported by the data model
+		// (see https://github.com/open-telemetry/opentelemetry-collector/pull/1142)
+		return strings.Join(m.commandLineSlice, " ")
+	}
+	return m.commandLine
+}
+
 func (m *processMetadata) initializeResource(resource pdata.Resource) {
 	attr := resource.Attributes()
-	attr.InitEmptyWithCapacity(6)
+	attr.InitEmptyWithCapacity(9 + len(m.env))
 	m.insertPid(attr)
+	m.insertParentPid(attr)
 	m.insertExecutable(attr)
 	m.insertCommand(attr)
 	m.insertUsername(attr)
+	m.insertContainer(attr)
+	m.insertEnvironment(attr)
 }
 
 func (m *processMetadata) insertPid(attr pdata.AttributeMap) {
 	attr.InsertInt(conventions.AttributeProcessID, int64(m.pid))
 }
 
+func (m *processMetadata) insertParentPid(attr pdata.AttributeMap) {
+	if m.parentPid == 0 {
+		return
+	}
+
+	attr.InsertInt(conventions.AttributeProcessParentPID, int64(m.parentPid))
+}
+
 func (m *processMetadata) insertExecutable(attr pdata.AttributeMap) {
-	attr.InsertString(conventions.AttributeProcessExecutableName, m.executable.name)
-	attr.InsertString(conventions.AttributeProcessExecutablePath, m.executable.path)
+	if m.executable.name != "" {
+		attr.InsertString(conventions.AttributeProcessExecutableName, m.executable.name)
+	}
+	if m.executable.path != "" {
+		attr.InsertString(conventions.AttributeProcessExecutablePath, m.executable.path)
+	}
 }
 
 func (m *processMetadata) insertCommand(attr pdata.AttributeMap) {
@@ -71,13 +99,7 @@ func (m *processMetadata) insertCommand(attr pdata.AttributeMap) {
 	}
 
 	attr.InsertString(conventions.AttributeProcessCommand, m.command.command)
-	if m.command.commandLineSlice != nil {
-		// TODO insert slice here once this is supported by the data model
-		// (see https://github.com/open-telemetry/opentelemetry-collector/pull/1142)
-		attr.InsertString(conventions.AttributeProcessCommandLine, strings.Join(m.command.commandLineSlice, " "))
-	} else {
-		attr.InsertString(conventions.AttributeProcessCommandLine, m.command.commandLine)
-	}
+	attr.InsertString(conventions.AttributeProcessCommandLine, m.command.line())
 }
 
 func (m *processMetadata) insertUsername(attr pdata.AttributeMap) {
@@ -88,6 +110,23 @@ func (m *processMetadata) insertUsername(attr pdata.AttributeMap) {
 	attr.InsertString(conventions.AttributeProcessOwner, m.username)
 }
 
+func (m *processMetadata) insertContainer(attr pdata.AttributeMap) {
+	if m.container == nil {
+		return
+	}
+
+	attr.InsertString(conventions.AttributeContainerID, m.container.id)
+	if m.container.podUID != "" {
+		attr.InsertString(conventions.AttributeK8sPodUID, m.container.podUID)
+	}
+}
+
+func (m *processMetadata) insertEnvironment(attr pdata.AttributeMap) {
+	for _, variable := range m.env {
+		attr.InsertString(environmentAttributePrefix+variable.name, variable.value)
+	}
+}
+
 // processHandles provides a wrapper around []*process.Process
 // to support testing
 
@@ -101,11 +140,16 @@ type processHandle interface {
 	Name() (string, error)
 	Exe() (string, error)
 	Username() (string, error)
+	Ppid() (int32, error)
 	Cmdline() (string, error)
 	CmdlineSlice() ([]string, error)
 	Times() (*cpu.TimesStat, error)
 	MemoryInfo() (*process.MemoryInfoStat, error)
 	IOCounters() (*process.IOCountersStat, error)
+	NumFDs() (int32, error)
+	NumThreads() (int32, error)
+	CreateTime() (int64, error)
+	Rlimit() ([]process.RlimitStat, error)
 }
 
 type gopsProcessHandles struct {
@@ -117,7 +161,7 @@ func (p *gopsProcessHandles) Pid(index int) int32 {
 }
 
 func (p *gopsProcessHandles) At(index int) processHandle {
-	return p.handles[index]
+	return newProcessHandle(p.handles[index])
 }
 
 func (p *gopsProcessHandles) Len() int {
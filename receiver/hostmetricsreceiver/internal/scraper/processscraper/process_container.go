@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package processscraper
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+)
+
+// containerMetadata stores the container a process runs in, as derived from
+// the paths of its control groups.
+type containerMetadata struct {
+	id     string
+	podUID string
+}
+
+var (
+	// containerIDPattern matches the cgroup path segments holding a container id,
+	// e.g. "<id>" (cgroupfs driver), "docker-<id>.scope", "cri-containerd-<id>.scope",
+	// "crio-<id>.scope" or "libpod-<id>.scope" (systemd driver).
+	containerIDPattern = regexp.MustCompile(`^(?:[a-z-]+-)?([0-9a-f]{64})(?:\.scope)?$`)
+
+	// podUIDPattern matches the cgroup path segments holding a Kubernetes pod UID,
+	// e.g. "pod<uid>" (cgroupfs driver) or "kubepods-besteffort-pod<uid>.slice"
+	// (systemd driver, where the dashes of the uid are replaced by underscores).
+	podUIDPattern = regexp.MustCompile(`(?:^|-)pod([0-9a-f]{8}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{12})(?:\.slice)?$`)
+)
+
+// parseProcessContainer parses the content of /proc/<pid>/cgroup and returns the
+// container the process runs in, or nil if the process does not run in a container.
+func parseProcessContainer(cgroup string) *containerMetadata {
+	scanner := bufio.NewScanner(strings.NewReader(cgroup))
+	for scanner.Scan() {
+		// each line has the format hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		if container := parseContainerPath(fields[2]); container != nil {
+			return container
+		}
+	}
+
+	return nil
+}
+
+func parseContainerPath(path string) *containerMetadata {
+	container := &containerMetadata{}
+	for _, segment := range strings.Split(path, "/") {
+		if match := containerIDPattern.FindStringSubmatch(segment); match != nil {
+			container.id = match[1]
+		} else if match := podUIDPattern.FindStringSubmatch(segment); match != nil {
+			container.podUID = strings.ReplaceAll(match[1], "_", "-")
+		}
+	}
+
+	if container.id == "" {
+		return nil
+	}
+	return container
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processscraper
+
+import "strings"
+
+// environmentAttributePrefix is the prefix of the resource attributes holding
+// the values of the environment variables of the processes.
+const environmentAttributePrefix = "process.env."
+
+// environmentVariable stores the value of an environment variable of a process.
+type environmentVariable struct {
+	name  string
+	value string
+}
+
+// parseProcessEnvironment parses the content of /proc/<pid>/environ and returns
+// the values of the given environment variables, in the given order. The
+// variables not set for the process are omitted.
+func parseProcessEnvironment(environ string, names []string) []environmentVariable {
+	values := make(map[string]string, len(names))
+	for _, entry := range strings.Split(environ, "\x00") {
+		// each entry has the format name=value
+		fields := strings.SplitN(entry, "=", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		values[fields[0]] = fields[1]
+	}
+
+	var environment []environmentVariable
+	for _, name := range names {
+		if value, ok := values[name]; ok {
+			environment = append(environment, environmentVariable{name: name, value: value})
+		}
+	}
+	return environment
+}
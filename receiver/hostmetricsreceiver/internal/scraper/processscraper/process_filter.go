@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processscraper
+
+import (
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+// processFilter matches the processes whose properties match all the configured properties.
+type processFilter struct {
+	names        filterset.FilterSet
+	commandLines filterset.FilterSet
+	pids         map[int32]bool
+	users        filterset.FilterSet
+}
+
+// newProcessFilter creates a filter from the config, it returns nil if no property is configured.
+func newProcessFilter(cfg *MatchConfig) (*processFilter, error) {
+	if len(cfg.Names) == 0 && len(cfg.CommandLines) == 0 && len(cfg.Pids) == 0 && len(cfg.Users) == 0 {
+		return nil, nil
+	}
+
+	filter := &processFilter{}
+	var err error
+	if len(cfg.Names) > 0 {
+		if filter.names, err = filterset.CreateFilterSet(cfg.Names, &cfg.Config); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.CommandLines) > 0 {
+		if filter.commandLines, err = filterset.CreateFilterSet(cfg.CommandLines, &cfg.Config); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.Pids) > 0 {
+		filter.pids = make(map[int32]bool, len(cfg.Pids))
+		for _, pid := range cfg.Pids {
+			filter.pids[pid] = true
+		}
+	}
+	if len(cfg.Users) > 0 {
+		if filter.users, err = filterset.CreateFilterSet(cfg.Users, &cfg.Config); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+// matchesName returns whether the process matches the properties known before
+// reading its command line and owner.
+func (f *processFilter) matchesName(pid int32, name string) bool {
+	if f.names != nil && !f.names.Matches(name) {
+		return false
+	}
+	return f.pids == nil || f.pids[pid]
+}
+
+// matches returns whether the process matches all the properties.
+func (f *processFilter) matches(md *processMetadata) bool {
+	if !f.matchesName(md.pid, md.executable.name) {
+		return false
+	}
+	if f.commandLines != nil && (md.command == nil || !f.commandLines.Matches(md.command.line())) {
+		return false
+	}
+	return f.users == nil || f.users.Matches(md.username)
+}
+
+// needsDetails returns whether matching requires the command line or the owner of the process.
+func (f *processFilter) needsDetails() bool {
+	return f.commandLines != nil || f.users != nil
+}
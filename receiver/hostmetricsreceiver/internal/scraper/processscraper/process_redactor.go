@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processscraper
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const defaultRedactReplacement = "***"
+
+// commandLineRedactor replaces the sensitive parts of the command lines.
+type commandLineRedactor struct {
+	patterns     []*regexp.Regexp
+	replacements []string
+}
+
+// newCommandLineRedactor creates a redactor from the config, it returns nil if no pattern is configured.
+func newCommandLineRedactor(cfgs []RedactConfig) (*commandLineRedactor, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	redactor := &commandLineRedactor{
+		patterns:     make([]*regexp.Regexp, 0, len(cfgs)),
+		replacements: make([]string, 0, len(cfgs)),
+	}
+	for _, cfg := range cfgs {
+		pattern, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", cfg.Pattern, err)
+		}
+
+		replacement := cfg.Replacement
+		if replacement == "" {
+			replacement = defaultRedactReplacement
+		}
+
+		redactor.patterns = append(redactor.patterns, pattern)
+		redactor.replacements = append(redactor.replacements, replacement)
+	}
+	return redactor, nil
+}
+
+// redact replaces the command line of the command with its redacted command line.
+func (r *commandLineRedactor) redact(command *commandMetadata) {
+	line := command.line()
+	for i, pattern := range r.patterns {
+		line = pattern.ReplaceAllString(line, r.replacements[i])
+	}
+
+	command.commandLine = line
+	command.commandLineSlice = nil
+}
@@ -16,58 +16,74 @@ package processscraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/host"
+	"github.com/shirou/gopsutil/mem"
 	"github.com/shirou/gopsutil/process"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
-	"go.opentelemetry.io/collector/internal/processor/filterset"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
 
 const (
 	cpuMetricsLen    = 1
-	memoryMetricsLen = 2
-	diskMetricsLen   = 1
+	memoryMetricsLen = 2 + platformMemoryMetricsLen
+	diskMetricsLen   = 2
 
-	metricsLen = cpuMetricsLen + memoryMetricsLen + diskMetricsLen
+	memoryUtilizationMetricsLen = 1
+
+	fileDescriptorsMetricsLen = 1 + fileDescriptorsLimitMetricsLen
+	threadsMetricsLen         = 1
+	createTimeMetricsLen      = 1
+
+	metricsLen = cpuMetricsLen + memoryMetricsLen + memoryUtilizationMetricsLen + diskMetricsLen + fileDescriptorsMetricsLen + threadsMetricsLen + createTimeMetricsLen
 )
 
 // scraper for Process Metrics
 type scraper struct {
 	config    *Config
 	startTime pdata.Timestamp
-	includeFS filterset.FilterSet
-	excludeFS filterset.FilterSet
+	include   *processFilter
+	exclude   *processFilter
+	redactor  *commandLineRedactor
+	top       *processTop
 
 	// for mocking
 	bootTime          func() (uint64, error)
 	getProcessHandles func() (processHandles, error)
+	virtualMemory     func() (*mem.VirtualMemoryStat, error)
 }
 
 // newProcessScraper creates a Process Scraper
 func newProcessScraper(cfg *Config) (*scraper, error) {
-	scraper := &scraper{config: cfg, bootTime: host.BootTime, getProcessHandles: getProcessHandlesInternal}
+	scraper := &scraper{config: cfg, bootTime: host.BootTime, getProcessHandles: getProcessHandlesInternal, virtualMemory: mem.VirtualMemory}
 
 	var err error
 
-	if len(cfg.Include.Names) > 0 {
-		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Names, &cfg.Include.Config)
-		if err != nil {
-			return nil, fmt.Errorf("error creating process include filters: %w", err)
-		}
+	scraper.include, err = newProcessFilter(&cfg.Include)
+	if err != nil {
+		return nil, fmt.Errorf("error creating process include filters: %w", err)
 	}
 
-	if len(cfg.Exclude.Names) > 0 {
-		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Names, &cfg.Exclude.Config)
-		if err != nil {
-			return nil, fmt.Errorf("error creating process exclude filters: %w", err)
-		}
+	scraper.exclude, err = newProcessFilter(&cfg.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("error creating process exclude filters: %w", err)
+	}
+
+	scraper.redactor, err = newCommandLineRedactor(cfg.RedactCommandLines)
+	if err != nil {
+		return nil, fmt.Errorf("error creating command line redaction: %w", err)
+	}
+
+	scraper.top, err = newProcessTop(&cfg.Top)
+	if err != nil {
+		return nil, fmt.Errorf("error creating process top: %w", err)
 	}
 
 	return scraper, nil
@@ -98,6 +114,22 @@ func (s *scraper) scrape(_ context.Context) (pdata.ResourceMetricsSlice, error)
 		errs.AddPartial(partialErr.Failed, partialErr)
 	}
 
+	var other *otherProcesses
+	if s.top != nil {
+		metadata, other = s.top.selectTop(metadata)
+	}
+
+	// the memory utilization of the processes is relative to the total memory of the host
+	var totalMemory uint64
+	if len(metadata) > 0 {
+		memInfo, err := s.virtualMemory()
+		if err != nil {
+			errs.AddPartial(len(metadata)*memoryUtilizationMetricsLen, fmt.Errorf("error reading total memory: %w", err))
+		} else {
+			totalMemory = memInfo.Total
+		}
+	}
+
 	rms.Resize(len(metadata))
 	for i, md := range metadata {
 		rm := rms.At(i)
@@ -113,13 +145,34 @@ func (s *scraper) scrape(_ context.Context) (pdata.ResourceMetricsSlice, error)
 			errs.AddPartial(cpuMetricsLen, fmt.Errorf("error reading cpu times for process %q (pid %v): %w", md.executable.name, md.pid, err))
 		}
 
-		if err = scrapeAndAppendMemoryUsageMetrics(metrics, now, md.handle); err != nil {
-			errs.AddPartial(memoryMetricsLen, fmt.Errorf("error reading memory info for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		if err = scrapeAndAppendMemoryUsageMetrics(metrics, now, md.handle, totalMemory); err != nil {
+			failed := memoryMetricsLen
+			if totalMemory > 0 {
+				failed += memoryUtilizationMetricsLen
+			}
+			errs.AddPartial(failed, fmt.Errorf("error reading memory info for process %q (pid %v): %w", md.executable.name, md.pid, err))
 		}
 
 		if err = scrapeAndAppendDiskIOMetric(metrics, s.startTime, now, md.handle); err != nil {
 			errs.AddPartial(diskMetricsLen, fmt.Errorf("error reading disk usage for process %q (pid %v): %w", md.executable.name, md.pid, err))
 		}
+
+		if err = scrapeAndAppendFileDescriptorsMetrics(metrics, now, md.handle); err != nil {
+			errs.AddPartial(fileDescriptorsMetricsLen, fmt.Errorf("error reading file descriptors for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		}
+
+		if err = scrapeAndAppendThreadsMetric(metrics, now, md.handle); err != nil {
+			errs.AddPartial(threadsMetricsLen, fmt.Errorf("error reading threads for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		}
+
+		if err = scrapeAndAppendCreateTimeMetric(metrics, now, md.handle); err != nil {
+			errs.AddPartial(createTimeMetricsLen, fmt.Errorf("error reading create time for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		}
+	}
+
+	if other != nil {
+		rms.Resize(len(metadata) + 1)
+		other.initializeResourceMetrics(rms.At(len(metadata)), s.startTime, pdata.TimestampFromTime(time.Now()))
 	}
 
 	return rms, errs.Combine()
@@ -142,36 +195,68 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 		pid := handles.Pid(i)
 		handle := handles.At(i)
 
+		// the processes whose executable cannot be read are skipped, unless the
+		// metadata errors are tolerated
 		executable, err := getProcessExecutable(handle)
-		if err != nil {
+		if err != nil && !s.config.TolerateMetadataErrors {
 			errs.AddPartial(1, fmt.Errorf("error reading process name for pid %v: %w", pid, err))
 			continue
 		}
 
-		// filter processes by name
-		if (s.includeFS != nil && !s.includeFS.Matches(executable.name)) ||
-			(s.excludeFS != nil && s.excludeFS.Matches(executable.name)) {
+		// filter processes by name and pid before reading their command line and owner
+		if (s.include != nil && !s.include.matchesName(pid, executable.name)) ||
+			(s.exclude != nil && !s.exclude.needsDetails() && s.exclude.matchesName(pid, executable.name)) {
 			continue
 		}
 
-		command, err := getProcessCommand(handle)
-		if err != nil {
-			errs.AddPartial(0, fmt.Errorf("error reading command for process %q (pid %v): %w", executable.name, pid, err))
-		}
-
-		username, err := handle.Username()
-		if err != nil {
-			errs.AddPartial(0, fmt.Errorf("error reading username for process %q (pid %v): %w", executable.name, pid, err))
-		}
+		command, commandErr := getProcessCommand(handle)
+		username, usernameErr := handle.Username()
+		parentPid, parentPidErr := handle.Ppid()
+		container, containerErr := getProcessContainer(handle)
 
 		md := &processMetadata{
 			pid:        pid,
+			parentPid:  parentPid,
 			executable: executable,
 			command:    command,
 			username:   username,
+			container:  container,
 			handle:     handle,
 		}
 
+		// filter processes by command line and owner
+		if (s.include != nil && !s.include.matches(md)) ||
+			(s.exclude != nil && s.exclude.matches(md)) {
+			continue
+		}
+
+		// redact the command line once the processes are filtered by their actual command line
+		if s.redactor != nil && command != nil {
+			s.redactor.redact(command)
+		}
+
+		// the environment variables are only read for the processes that are reported
+		var envErr error
+		if len(s.config.EnvironmentVariables) > 0 {
+			md.env, envErr = getProcessEnvironment(handle, s.config.EnvironmentVariables)
+		}
+
+		if commandErr != nil {
+			errs.AddPartial(0, fmt.Errorf("error reading command for process %q (pid %v): %w", executable.name, pid, commandErr))
+		}
+		if usernameErr != nil {
+			errs.AddPartial(0, fmt.Errorf("error reading username for process %q (pid %v): %w", executable.name, pid, usernameErr))
+		}
+		if parentPidErr != nil {
+			errs.AddPartial(0, fmt.Errorf("error reading parent pid for process %q (pid %v): %w", executable.name, pid, parentPidErr))
+		}
+		if containerErr != nil {
+			errs.AddPartial(0, fmt.Errorf("error reading container for process %q (pid %v): %w", executable.name, pid, containerErr))
+		}
+		if envErr != nil {
+			errs.AddPartial(0, fmt.Errorf("error reading environment variables for process %q (pid %v): %w", executable.name, pid, envErr))
+		}
+
 		metadata = append(metadata, md)
 	}
 
@@ -198,19 +283,40 @@ func initializeCPUTimeMetric(metric pdata.Metric, startTime, now pdata.Timestamp
 	appendCPUTimeStateDataPoints(ddps, startTime, now, times)
 }
 
-func scrapeAndAppendMemoryUsageMetrics(metrics pdata.MetricSlice, now pdata.Timestamp, handle processHandle) error {
+func scrapeAndAppendMemoryUsageMetrics(metrics pdata.MetricSlice, now pdata.Timestamp, handle processHandle, totalMemory uint64) error {
 	mem, err := handle.MemoryInfo()
 	if err != nil {
 		return err
 	}
 
+	platformUsages, err := getPlatformMemoryUsages(handle, mem)
+	if err != nil {
+		return err
+	}
+
 	startIdx := metrics.Len()
-	metrics.Resize(startIdx + memoryMetricsLen)
+	metricsLen := memoryMetricsLen
+	if totalMemory > 0 {
+		metricsLen += memoryUtilizationMetricsLen
+	}
+	metrics.Resize(startIdx + metricsLen)
 	initializeMemoryUsageMetric(metrics.At(startIdx+0), metadata.Metrics.ProcessMemoryPhysicalUsage, now, int64(mem.RSS))
 	initializeMemoryUsageMetric(metrics.At(startIdx+1), metadata.Metrics.ProcessMemoryVirtualUsage, now, int64(mem.VMS))
+	for i, usage := range platformUsages {
+		initializeMemoryUsageMetric(metrics.At(startIdx+2+i), usage.metric, now, usage.value)
+	}
+	if totalMemory > 0 {
+		initializeMemoryUtilizationMetric(metrics.At(startIdx+memoryMetricsLen), now, 100*float64(mem.RSS)/float64(totalMemory))
+	}
 	return nil
 }
 
+// memoryUsage is the value of a memory usage metric only reported on some platforms.
+type memoryUsage struct {
+	metric metadata.MetricIntf
+	value  int64
+}
+
 func initializeMemoryUsageMetric(metric pdata.Metric, metricIntf metadata.MetricIntf, now pdata.Timestamp, usage int64) {
 	metricIntf.Init(metric)
 
@@ -224,6 +330,15 @@ func initializeMemoryUsageDataPoint(dataPoint pdata.IntDataPoint, now pdata.Time
 	dataPoint.SetValue(usage)
 }
 
+func initializeMemoryUtilizationMetric(metric pdata.Metric, now pdata.Timestamp, utilization float64) {
+	metadata.Metrics.ProcessMemoryUtilization.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(1)
+	ddps.At(0).SetTimestamp(now)
+	ddps.At(0).SetValue(utilization)
+}
+
 func scrapeAndAppendDiskIOMetric(metrics pdata.MetricSlice, startTime, now pdata.Timestamp, handle processHandle) error {
 	io, err := handle.IOCounters()
 	if err != nil {
@@ -232,7 +347,8 @@ func scrapeAndAppendDiskIOMetric(metrics pdata.MetricSlice, startTime, now pdata
 
 	startIdx := metrics.Len()
 	metrics.Resize(startIdx + diskMetricsLen)
-	initializeDiskIOMetric(metrics.At(startIdx), startTime, now, io)
+	initializeDiskIOMetric(metrics.At(startIdx+0), startTime, now, io)
+	initializeDiskOperationsMetric(metrics.At(startIdx+1), startTime, now, io)
 	return nil
 }
 
@@ -245,6 +361,15 @@ func initializeDiskIOMetric(metric pdata.Metric, startTime, now pdata.Timestamp,
 	initializeDiskIODataPoint(idps.At(1), startTime, now, int64(io.WriteBytes), metadata.LabelProcessDirection.Write)
 }
 
+func initializeDiskOperationsMetric(metric pdata.Metric, startTime, now pdata.Timestamp, io *process.IOCountersStat) {
+	metadata.Metrics.ProcessDiskOperations.Init(metric)
+
+	idps := metric.IntSum().DataPoints()
+	idps.Resize(2)
+	initializeDiskIODataPoint(idps.At(0), startTime, now, int64(io.ReadCount), metadata.LabelProcessDirection.Read)
+	initializeDiskIODataPoint(idps.At(1), startTime, now, int64(io.WriteCount), metadata.LabelProcessDirection.Write)
+}
+
 func initializeDiskIODataPoint(dataPoint pdata.IntDataPoint, startTime, now pdata.Timestamp, value int64, directionLabel string) {
 	labelsMap := dataPoint.LabelsMap()
 	labelsMap.Insert(metadata.Labels.ProcessDirection, directionLabel)
@@ -252,3 +377,106 @@ func initializeDiskIODataPoint(dataPoint pdata.IntDataPoint, startTime, now pdat
 	dataPoint.SetTimestamp(now)
 	dataPoint.SetValue(value)
 }
+
+func scrapeAndAppendFileDescriptorsMetrics(metrics pdata.MetricSlice, now pdata.Timestamp, handle processHandle) error {
+	fds, err := handle.NumFDs()
+	if err != nil {
+		return err
+	}
+
+	var limit *process.RlimitStat
+	if fileDescriptorsLimitMetricsLen > 0 {
+		if limit, err = getFileDescriptorsLimit(handle); err != nil {
+			return err
+		}
+	}
+
+	startIdx := metrics.Len()
+	metrics.Resize(startIdx + fileDescriptorsMetricsLen)
+	initializeOpenFileDescriptorsMetric(metrics.At(startIdx), now, int64(fds))
+	if limit != nil {
+		initializeFileDescriptorsLimitMetric(metrics.At(startIdx+1), now, limit)
+	}
+	return nil
+}
+
+func getFileDescriptorsLimit(handle processHandle) (*process.RlimitStat, error) {
+	limits, err := handle.Rlimit()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range limits {
+		if limits[i].Resource == process.RLIMIT_NOFILE {
+			return &limits[i], nil
+		}
+	}
+	return nil, errors.New("open files limit not found")
+}
+
+func initializeOpenFileDescriptorsMetric(metric pdata.Metric, now pdata.Timestamp, fds int64) {
+	metadata.Metrics.ProcessOpenFileDescriptors.Init(metric)
+
+	idps := metric.IntSum().DataPoints()
+	idps.Resize(1)
+	idps.At(0).SetTimestamp(now)
+	idps.At(0).SetValue(fds)
+}
+
+func initializeFileDescriptorsLimitMetric(metric pdata.Metric, now pdata.Timestamp, limit *process.RlimitStat) {
+	metadata.Metrics.ProcessOpenFileDescriptorsLimit.Init(metric)
+
+	idps := metric.IntGauge().DataPoints()
+	idps.Resize(2)
+	initializeFileDescriptorsLimitDataPoint(idps.At(0), now, int64(limit.Soft), metadata.LabelProcessLimit.Soft)
+	initializeFileDescriptorsLimitDataPoint(idps.At(1), now, int64(limit.Hard), metadata.LabelProcessLimit.Hard)
+}
+
+func initializeFileDescriptorsLimitDataPoint(dataPoint pdata.IntDataPoint, now pdata.Timestamp, value int64, limitLabel string) {
+	labelsMap := dataPoint.LabelsMap()
+	labelsMap.Insert(metadata.Labels.ProcessLimit, limitLabel)
+	dataPoint.SetTimestamp(now)
+	dataPoint.SetValue(value)
+}
+
+func scrapeAndAppendThreadsMetric(metrics pdata.MetricSlice, now pdata.Timestamp, handle processHandle) error {
+	threads, err := handle.NumThreads()
+	if err != nil {
+		return err
+	}
+
+	startIdx := metrics.Len()
+	metrics.Resize(startIdx + threadsMetricsLen)
+	initializeThreadsMetric(metrics.At(startIdx), now, int64(threads))
+	return nil
+}
+
+func initializeThreadsMetric(metric pdata.Metric, now pdata.Timestamp, threads int64) {
+	metadata.Metrics.ProcessThreads.Init(metric)
+
+	idps := metric.IntSum().DataPoints()
+	idps.Resize(1)
+	idps.At(0).SetTimestamp(now)
+	idps.At(0).SetValue(threads)
+}
+
+func scrapeAndAppendCreateTimeMetric(metrics pdata.MetricSlice, now pdata.Timestamp, handle processHandle) error {
+	createTime, err := handle.CreateTime()
+	if err != nil {
+		return err
+	}
+
+	startIdx := metrics.Len()
+	metrics.Resize(startIdx + createTimeMetricsLen)
+	initializeCreateTimeMetric(metrics.At(startIdx), now, createTime)
+	return nil
+}
+
+func initializeCreateTimeMetric(metric pdata.Metric, now pdata.Timestamp, createTime int64) {
+	metadata.Metrics.ProcessCreateTime.Init(metric)
+
+	idps := metric.IntGauge().DataPoints()
+	idps.Resize(1)
+	idps.At(0).SetTimestamp(now)
+	idps.At(0).SetValue(createTime)
+}
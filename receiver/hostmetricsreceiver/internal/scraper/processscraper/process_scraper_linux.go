@@ -17,13 +17,27 @@
 package processscraper
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
 	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/process"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
 )
 
-const cpuStatesLen = 3
+const (
+	cpuStatesLen = 3
+
+	// fileDescriptorsLimitMetricsLen is the number of metrics about the limit of open file descriptors.
+	fileDescriptorsLimitMetricsLen = 1
+
+	// platformMemoryMetricsLen is the number of memory usage metrics only reported on Linux.
+	platformMemoryMetricsLen = 1
+)
 
 func appendCPUTimeStateDataPoints(ddps pdata.DoubleDataPointSlice, startTime, now pdata.Timestamp, cpuTime *cpu.TimesStat) {
 	initializeCPUTimeDataPoint(ddps.At(0), startTime, now, cpuTime.User, metadata.LabelProcessState.User)
@@ -39,15 +53,21 @@ func initializeCPUTimeDataPoint(dataPoint pdata.DoubleDataPoint, startTime, now
 	dataPoint.SetValue(value)
 }
 
+func getPlatformMemoryUsages(_ processHandle, mem *process.MemoryInfoStat) ([]memoryUsage, error) {
+	return []memoryUsage{{metric: metadata.Metrics.ProcessMemorySwapUsage, value: int64(mem.Swap)}}, nil
+}
+
+// getProcessExecutable returns the executable of the process, on error the
+// returned executable holds the properties that could be read.
 func getProcessExecutable(proc processHandle) (*executableMetadata, error) {
 	name, err := proc.Name()
 	if err != nil {
-		return nil, err
+		return &executableMetadata{}, err
 	}
 
 	exe, err := proc.Exe()
 	if err != nil {
-		return nil, err
+		return &executableMetadata{name: name}, err
 	}
 
 	executable := &executableMetadata{name: name, path: exe}
@@ -68,3 +88,79 @@ func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	command := &commandMetadata{command: cmd, commandLineSlice: cmdline}
 	return command, nil
 }
+
+// cgroupHandle is implemented by the process handles able to report the control groups of the process.
+type cgroupHandle interface {
+	Cgroup() (string, error)
+}
+
+// getProcessContainer returns the container the process runs in, or nil if the
+// process does not run in a container.
+func getProcessContainer(handle processHandle) (*containerMetadata, error) {
+	cgHandle, ok := handle.(cgroupHandle)
+	if !ok {
+		return nil, nil
+	}
+
+	cgroup, err := cgHandle.Cgroup()
+	if err != nil {
+		return nil, err
+	}
+	return parseProcessContainer(cgroup), nil
+}
+
+// environHandle is implemented by the process handles able to report the environment variables of the process.
+type environHandle interface {
+	Environ() (string, error)
+}
+
+// getProcessEnvironment returns the values of the given environment variables
+// of the process.
+func getProcessEnvironment(handle processHandle, names []string) ([]environmentVariable, error) {
+	envHandle, ok := handle.(environHandle)
+	if !ok {
+		return nil, nil
+	}
+
+	environ, err := envHandle.Environ()
+	if err != nil {
+		return nil, err
+	}
+	return parseProcessEnvironment(environ, names), nil
+}
+
+// linuxProcessHandle reports the control groups and the environment variables of the process.
+type linuxProcessHandle struct {
+	*process.Process
+}
+
+func newProcessHandle(proc *process.Process) processHandle {
+	return linuxProcessHandle{proc}
+}
+
+// Cgroup returns the content of /proc/<pid>/cgroup.
+func (p linuxProcessHandle) Cgroup() (string, error) {
+	return p.readProcFile("cgroup")
+}
+
+// Environ returns the content of /proc/<pid>/environ, the environment variables
+// of the process separated by null bytes.
+func (p linuxProcessHandle) Environ() (string, error) {
+	return p.readProcFile("environ")
+}
+
+// readProcFile returns the content of the given file of the process in the proc
+// filesystem, the proc filesystem mounted at HOST_PROC is read when set, the
+// same as gopsutil.
+func (p linuxProcessHandle) readProcFile(name string) (string, error) {
+	procPath := os.Getenv("HOST_PROC")
+	if procPath == "" {
+		procPath = "/proc"
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(procPath, strconv.Itoa(int(p.Pid)), name))
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
@@ -18,19 +18,44 @@ package processscraper
 
 import (
 	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/process"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
-const cpuStatesLen = 0
+const (
+	cpuStatesLen = 0
+
+	// fileDescriptorsLimitMetricsLen is the number of metrics about the limit of open file descriptors.
+	fileDescriptorsLimitMetricsLen = 0
+
+	// platformMemoryMetricsLen is the number of memory usage metrics only reported on some platforms.
+	platformMemoryMetricsLen = 0
+)
 
 func appendCPUTimeStateDataPoints(ddps pdata.DoubleDataPointSlice, startTime, now pdata.Timestamp, cpuTime *cpu.TimesStat) {
 }
 
-func getProcessExecutable(processHandle) (*executableMetadata, error) {
+func getPlatformMemoryUsages(processHandle, *process.MemoryInfoStat) ([]memoryUsage, error) {
 	return nil, nil
 }
 
+func getProcessExecutable(processHandle) (*executableMetadata, error) {
+	return &executableMetadata{}, nil
+}
+
 func getProcessCommand(processHandle) (*commandMetadata, error) {
 	return nil, nil
 }
+
+func getProcessContainer(processHandle) (*containerMetadata, error) {
+	return nil, nil
+}
+
+func getProcessEnvironment(processHandle, []string) ([]environmentVariable, error) {
+	return nil, nil
+}
+
+func newProcessHandle(proc *process.Process) processHandle {
+	return proc
+}
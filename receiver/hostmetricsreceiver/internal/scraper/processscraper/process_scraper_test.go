@@ -19,9 +19,11 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/mem"
 	"github.com/shirou/gopsutil/process"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -74,7 +76,21 @@ func TestScrape(t *testing.T) {
 	assertCPUTimeMetricValid(t, resourceMetrics, expectedStartTime)
 	assertMemoryUsageMetricValid(t, metadata.Metrics.ProcessMemoryPhysicalUsage.New(), resourceMetrics)
 	assertMemoryUsageMetricValid(t, metadata.Metrics.ProcessMemoryVirtualUsage.New(), resourceMetrics)
+	assertMemoryUsageMetricValid(t, metadata.Metrics.ProcessMemoryUtilization.New(), resourceMetrics)
+	switch runtime.GOOS {
+	case "linux":
+		assertMemoryUsageMetricValid(t, metadata.Metrics.ProcessMemorySwapUsage.New(), resourceMetrics)
+	case "windows":
+		assertMemoryUsageMetricValid(t, metadata.Metrics.ProcessMemoryPrivateUsage.New(), resourceMetrics)
+	}
 	assertDiskIOMetricValid(t, resourceMetrics, expectedStartTime)
+	assertDiskOperationsMetricValid(t, resourceMetrics, expectedStartTime)
+	assertOpenFileDescriptorsMetricValid(t, resourceMetrics)
+	if runtime.GOOS == "linux" {
+		assertFileDescriptorsLimitMetricValid(t, resourceMetrics)
+	}
+	assertThreadsMetricValid(t, resourceMetrics)
+	assertCreateTimeMetricValid(t, resourceMetrics)
 	assertSameTimeStampForAllMetricsWithinResource(t, resourceMetrics)
 }
 
@@ -118,6 +134,39 @@ func assertDiskIOMetricValid(t *testing.T, resourceMetrics pdata.ResourceMetrics
 	internal.AssertIntSumMetricLabelHasValue(t, diskIOMetric, 1, "direction", "write")
 }
 
+func assertDiskOperationsMetricValid(t *testing.T, resourceMetrics pdata.ResourceMetricsSlice, startTime pdata.Timestamp) {
+	diskOperationsMetric := getMetric(t, metadata.Metrics.ProcessDiskOperations.New(), resourceMetrics)
+	internal.AssertDescriptorEqual(t, metadata.Metrics.ProcessDiskOperations.New(), diskOperationsMetric)
+	if startTime != 0 {
+		internal.AssertIntSumMetricStartTimeEquals(t, diskOperationsMetric, startTime)
+	}
+	internal.AssertIntSumMetricLabelHasValue(t, diskOperationsMetric, 0, "direction", "read")
+	internal.AssertIntSumMetricLabelHasValue(t, diskOperationsMetric, 1, "direction", "write")
+}
+
+func assertOpenFileDescriptorsMetricValid(t *testing.T, resourceMetrics pdata.ResourceMetricsSlice) {
+	openFileDescriptorsMetric := getMetric(t, metadata.Metrics.ProcessOpenFileDescriptors.New(), resourceMetrics)
+	internal.AssertDescriptorEqual(t, metadata.Metrics.ProcessOpenFileDescriptors.New(), openFileDescriptorsMetric)
+}
+
+func assertFileDescriptorsLimitMetricValid(t *testing.T, resourceMetrics pdata.ResourceMetricsSlice) {
+	fileDescriptorsLimitMetric := getMetric(t, metadata.Metrics.ProcessOpenFileDescriptorsLimit.New(), resourceMetrics)
+	internal.AssertDescriptorEqual(t, metadata.Metrics.ProcessOpenFileDescriptorsLimit.New(), fileDescriptorsLimitMetric)
+	internal.AssertIntGaugeMetricLabelHasValue(t, fileDescriptorsLimitMetric, 0, "limit", "soft")
+	internal.AssertIntGaugeMetricLabelHasValue(t, fileDescriptorsLimitMetric, 1, "limit", "hard")
+}
+
+func assertThreadsMetricValid(t *testing.T, resourceMetrics pdata.ResourceMetricsSlice) {
+	threadsMetric := getMetric(t, metadata.Metrics.ProcessThreads.New(), resourceMetrics)
+	internal.AssertDescriptorEqual(t, metadata.Metrics.ProcessThreads.New(), threadsMetric)
+}
+
+func assertCreateTimeMetricValid(t *testing.T, resourceMetrics pdata.ResourceMetricsSlice) {
+	createTimeMetric := getMetric(t, metadata.Metrics.ProcessCreateTime.New(), resourceMetrics)
+	internal.AssertDescriptorEqual(t, metadata.Metrics.ProcessCreateTime.New(), createTimeMetric)
+	assert.Less(t, int64(0), createTimeMetric.IntGauge().DataPoints().At(0).Value())
+}
+
 func assertSameTimeStampForAllMetricsWithinResource(t *testing.T, resourceMetrics pdata.ResourceMetricsSlice) {
 	for i := 0; i < resourceMetrics.Len(); i++ {
 		ilms := resourceMetrics.At(i).InstrumentationLibraryMetrics()
@@ -158,6 +207,18 @@ func TestScrapeMetrics_NewError(t *testing.T) {
 	_, err = newProcessScraper(&Config{Exclude: MatchConfig{Names: []string{"test"}}})
 	require.Error(t, err)
 	require.Regexp(t, "^error creating process exclude filters:", err.Error())
+
+	_, err = newProcessScraper(&Config{RedactCommandLines: []RedactConfig{{Pattern: "("}}})
+	require.Error(t, err)
+	require.Regexp(t, "^error creating command line redaction:", err.Error())
+
+	_, err = newProcessScraper(&Config{Top: TopConfig{Count: -1}})
+	require.Error(t, err)
+	require.Regexp(t, "^error creating process top:", err.Error())
+
+	_, err = newProcessScraper(&Config{Top: TopConfig{Count: 1, SortBy: "disk"}})
+	require.Error(t, err)
+	require.Regexp(t, "^error creating process top:", err.Error())
 }
 
 func TestScrapeMetrics_GetProcessesError(t *testing.T) {
@@ -179,9 +240,13 @@ func TestScrapeMetrics_GetProcessesError(t *testing.T) {
 
 type processHandlesMock struct {
 	handles []*processHandleMock
+	pids    []int32
 }
 
 func (p *processHandlesMock) Pid(index int) int32 {
+	if p.pids != nil {
+		return p.pids[index]
+	}
 	return 1
 }
 
@@ -212,6 +277,11 @@ func (p *processHandleMock) Username() (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (p *processHandleMock) Ppid() (int32, error) {
+	args := p.MethodCalled("Ppid")
+	return args.Get(0).(int32), args.Error(1)
+}
+
 func (p *processHandleMock) Cmdline() (string, error) {
 	args := p.MethodCalled("Cmdline")
 	return args.String(0), args.Error(1)
@@ -232,19 +302,61 @@ func (p *processHandleMock) MemoryInfo() (*process.MemoryInfoStat, error) {
 	return args.Get(0).(*process.MemoryInfoStat), args.Error(1)
 }
 
+func (p *processHandleMock) PrivateUsage() (uint64, error) {
+	args := p.MethodCalled("PrivateUsage")
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (p *processHandleMock) Cgroup() (string, error) {
+	args := p.MethodCalled("Cgroup")
+	return args.String(0), args.Error(1)
+}
+
+func (p *processHandleMock) Environ() (string, error) {
+	args := p.MethodCalled("Environ")
+	return args.String(0), args.Error(1)
+}
+
 func (p *processHandleMock) IOCounters() (*process.IOCountersStat, error) {
 	args := p.MethodCalled("IOCounters")
 	return args.Get(0).(*process.IOCountersStat), args.Error(1)
 }
 
+func (p *processHandleMock) NumFDs() (int32, error) {
+	args := p.MethodCalled("NumFDs")
+	return args.Get(0).(int32), args.Error(1)
+}
+
+func (p *processHandleMock) NumThreads() (int32, error) {
+	args := p.MethodCalled("NumThreads")
+	return args.Get(0).(int32), args.Error(1)
+}
+
+func (p *processHandleMock) CreateTime() (int64, error) {
+	args := p.MethodCalled("CreateTime")
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (p *processHandleMock) Rlimit() ([]process.RlimitStat, error) {
+	args := p.MethodCalled("Rlimit")
+	return args.Get(0).([]process.RlimitStat), args.Error(1)
+}
+
 func newDefaultHandleMock() *processHandleMock {
 	handleMock := &processHandleMock{}
 	handleMock.On("Username").Return("username", nil)
+	handleMock.On("Ppid").Return(int32(2), nil)
 	handleMock.On("Cmdline").Return("cmdline", nil)
 	handleMock.On("CmdlineSlice").Return([]string{"cmdline"}, nil)
 	handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
 	handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
+	handleMock.On("PrivateUsage").Return(uint64(0), nil)
+	handleMock.On("Cgroup").Return("0::/", nil)
 	handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+	handleMock.On("NumFDs").Return(int32(0), nil)
+	handleMock.On("NumThreads").Return(int32(0), nil)
+	handleMock.On("CreateTime").Return(int64(0), nil)
+	handleMock.On("Rlimit").Return([]process.RlimitStat{{Resource: process.RLIMIT_NOFILE}}, nil)
 	return handleMock
 }
 
@@ -340,6 +452,624 @@ func TestScrapeMetrics_Filtered(t *testing.T) {
 	}
 }
 
+func TestScrapeMetrics_FilteredByProperties(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	type processInfo struct {
+		name        string
+		pid         int32
+		commandLine string
+		user        string
+	}
+	processes := []processInfo{
+		{name: "postgres", pid: 10, commandLine: "postgres -D /data", user: "postgres"},
+		{name: "postgres", pid: 11, commandLine: "postgres: checkpointer", user: "postgres"},
+		{name: "nginx", pid: 20, commandLine: "nginx -g daemon off;", user: "root"},
+		{name: "bash", pid: 30, commandLine: "bash", user: "root"},
+	}
+
+	testCases := []struct {
+		name         string
+		include      MatchConfig
+		exclude      MatchConfig
+		expectedPids []int64
+	}{
+		{
+			name:         "Include Command Lines",
+			include:      MatchConfig{CommandLines: []string{"-D"}, Config: filterset.Config{MatchType: filterset.Regexp}},
+			expectedPids: []int64{10},
+		},
+		{
+			name:         "Include Pids",
+			include:      MatchConfig{Pids: []int32{11, 30}},
+			expectedPids: []int64{11, 30},
+		},
+		{
+			name:         "Include Users",
+			include:      MatchConfig{Users: []string{"root"}, Config: filterset.Config{MatchType: filterset.Strict}},
+			expectedPids: []int64{20, 30},
+		},
+		{
+			name:         "Include All Properties",
+			include:      MatchConfig{Names: []string{"postgres"}, Users: []string{"postgres"}, Pids: []int32{11, 20}, Config: filterset.Config{MatchType: filterset.Strict}},
+			expectedPids: []int64{11},
+		},
+		{
+			name:         "Exclude Users",
+			exclude:      MatchConfig{Users: []string{"root"}, Config: filterset.Config{MatchType: filterset.Strict}},
+			expectedPids: []int64{10, 11},
+		},
+		{
+			name:         "Exclude Name And Command Line",
+			exclude:      MatchConfig{Names: []string{"postgres"}, CommandLines: []string{"checkpointer"}, Config: filterset.Config{MatchType: filterset.Regexp}},
+			expectedPids: []int64{10, 20, 30},
+		},
+		{
+			name:         "Include Users & Exclude Pids",
+			include:      MatchConfig{Users: []string{"root"}, Config: filterset.Config{MatchType: filterset.Strict}},
+			exclude:      MatchConfig{Pids: []int32{30}},
+			expectedPids: []int64{20},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper, err := newProcessScraper(&Config{Include: test.include, Exclude: test.exclude})
+			require.NoError(t, err, "Failed to create process scraper: %v", err)
+			err = scraper.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+			handles := &processHandlesMock{}
+			for _, p := range processes {
+				handleMock := &processHandleMock{}
+				handleMock.On("Name").Return(p.name, nil)
+				handleMock.On("Exe").Return(p.name, nil)
+				handleMock.On("Username").Return(p.user, nil)
+				handleMock.On("Ppid").Return(int32(0), nil)
+				handleMock.On("Cmdline").Return(p.commandLine, nil)
+				handleMock.On("CmdlineSlice").Return(strings.Split(p.commandLine, " "), nil)
+				handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
+				handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
+				handleMock.On("PrivateUsage").Return(uint64(0), nil)
+				handleMock.On("Cgroup").Return("0::/", nil)
+				handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+				handleMock.On("NumFDs").Return(int32(0), nil)
+				handleMock.On("NumThreads").Return(int32(0), nil)
+				handleMock.On("CreateTime").Return(int64(0), nil)
+				handleMock.On("Rlimit").Return([]process.RlimitStat{{Resource: process.RLIMIT_NOFILE}}, nil)
+				handles.handles = append(handles.handles, handleMock)
+				handles.pids = append(handles.pids, p.pid)
+			}
+			scraper.getProcessHandles = func() (processHandles, error) {
+				return handles, nil
+			}
+
+			resourceMetrics, err := scraper.scrape(context.Background())
+			require.NoError(t, err)
+
+			pids := make([]int64, 0, resourceMetrics.Len())
+			for i := 0; i < resourceMetrics.Len(); i++ {
+				pid, _ := resourceMetrics.At(i).Resource().Attributes().Get(conventions.AttributeProcessID)
+				pids = append(pids, pid.IntVal())
+			}
+			assert.Equal(t, test.expectedPids, pids)
+		})
+	}
+}
+
+func TestScrapeMetrics_FilteredSkipsDetails(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper, err := newProcessScraper(&Config{Exclude: MatchConfig{Names: []string{"test"}, Config: filterset.Config{MatchType: filterset.Strict}}})
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	// The processes excluded by name are skipped before reading their command line and owner,
+	// so the errors reading them are not reported.
+	handleMock := &processHandleMock{}
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("test", nil)
+	handleMock.On("Username").Return("", errors.New("err1"))
+	handleMock.On("Cmdline").Return("", errors.New("err2"))
+	handleMock.On("CmdlineSlice").Return([]string(nil), errors.New("err2"))
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+
+	resourceMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, resourceMetrics.Len())
+	handleMock.AssertNotCalled(t, "Username")
+}
+
+func TestScrapeMetrics_MemoryUtilization(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper, err := newProcessScraper(&Config{})
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	handleMock := newUsageHandleMock("test", 0, 256)
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+	scraper.virtualMemory = func() (*mem.VirtualMemoryStat, error) {
+		return &mem.VirtualMemoryStat{Total: 1024}, nil
+	}
+
+	resourceMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	utilizationMetric := getMetric(t, metadata.Metrics.ProcessMemoryUtilization.New(), resourceMetrics)
+	assert.Equal(t, 25.0, utilizationMetric.DoubleGauge().DataPoints().At(0).Value())
+
+	// the memory utilization is not reported when the total memory of the host cannot be read
+	scraper.virtualMemory = func() (*mem.VirtualMemoryStat, error) {
+		return nil, errors.New("err1")
+	}
+
+	resourceMetrics, err = scraper.scrape(context.Background())
+	assert.EqualError(t, err, "error reading total memory: err1")
+	isPartial := scrapererror.IsPartialScrapeError(err)
+	assert.True(t, isPartial)
+	if isPartial {
+		assert.Equal(t, memoryUtilizationMetricsLen, err.(scrapererror.PartialScrapeError).Failed)
+	}
+	require.Equal(t, 1, resourceMetrics.Len())
+	assert.Equal(t, metricsLen-memoryUtilizationMetricsLen, resourceMetrics.At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+}
+
+func TestScrapeMetrics_ResourceAttributes(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper, err := newProcessScraper(&Config{})
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	handleMock := newDefaultHandleMock()
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("test", nil)
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+
+	resourceMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, resourceMetrics.Len())
+	owner, ok := resourceMetrics.At(0).Resource().Attributes().Get(conventions.AttributeProcessOwner)
+	require.True(t, ok)
+	assert.Equal(t, "username", owner.StringVal())
+	parentPid, ok := resourceMetrics.At(0).Resource().Attributes().Get(conventions.AttributeProcessParentPID)
+	require.True(t, ok)
+	assert.EqualValues(t, 2, parentPid.IntVal())
+}
+
+func TestScrapeMetrics_RedactCommandLines(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	config := &Config{
+		Include: MatchConfig{CommandLines: []string{"--db-password=secret"}, Config: filterset.Config{MatchType: filterset.Regexp}},
+		RedactCommandLines: []RedactConfig{
+			{Pattern: `(--db-password=)\S+`, Replacement: "${1}<redacted>"},
+			{Pattern: `token \S+`},
+		},
+	}
+	scraper, err := newProcessScraper(config)
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	handleMock := &processHandleMock{}
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("test", nil)
+	handleMock.On("Username").Return("username", nil)
+	handleMock.On("Ppid").Return(int32(0), nil)
+	handleMock.On("Cmdline").Return("test --db-password=secret --token abc", nil)
+	handleMock.On("CmdlineSlice").Return([]string{"test", "--db-password=secret", "--token", "abc"}, nil)
+	handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
+	handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
+	handleMock.On("PrivateUsage").Return(uint64(0), nil)
+	handleMock.On("Cgroup").Return("0::/", nil)
+	handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+	handleMock.On("NumFDs").Return(int32(0), nil)
+	handleMock.On("NumThreads").Return(int32(0), nil)
+	handleMock.On("CreateTime").Return(int64(0), nil)
+	handleMock.On("Rlimit").Return([]process.RlimitStat{{Resource: process.RLIMIT_NOFILE}}, nil)
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+
+	// the processes are filtered on their actual command line, but only the redacted one is reported
+	resourceMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, resourceMetrics.Len())
+	commandLine, ok := resourceMetrics.At(0).Resource().Attributes().Get(conventions.AttributeProcessCommandLine)
+	require.True(t, ok)
+	assert.Equal(t, "test --db-password=<redacted> --***", commandLine.StringVal())
+}
+
+func TestScrapeMetrics_Container(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("skipping test on %v", runtime.GOOS)
+	}
+
+	type testCase struct {
+		name           string
+		cgroup         string
+		cgroupError    error
+		expectedID     string
+		expectedPodUID string
+		expectedError  string
+	}
+
+	testCases := []testCase{
+		{
+			name:       "Docker container",
+			cgroup:     "12:memory:/docker/" + testContainerID + "\n0::/docker/" + testContainerID + "\n",
+			expectedID: testContainerID,
+		},
+		{
+			name:           "Kubernetes pod",
+			cgroup:         "0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod0f5d2e4c_8b7a_4d1e_9c3f_2a6b8e1d7c45.slice/cri-containerd-" + testContainerID + ".scope\n",
+			expectedID:     testContainerID,
+			expectedPodUID: "0f5d2e4c-8b7a-4d1e-9c3f-2a6b8e1d7c45",
+		},
+		{
+			name:   "No container",
+			cgroup: "0::/user.slice/user-1000.slice/session-1.scope\n",
+		},
+		{
+			name:          "Cgroup Error",
+			cgroupError:   errors.New("err1"),
+			expectedError: `error reading container for process "test" (pid 1): err1`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper, err := newProcessScraper(&Config{})
+			require.NoError(t, err, "Failed to create process scraper: %v", err)
+			err = scraper.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+			handleMock := &processHandleMock{}
+			handleMock.On("Name").Return("test", nil)
+			handleMock.On("Exe").Return("test", nil)
+			handleMock.On("Username").Return("username", nil)
+			handleMock.On("Ppid").Return(int32(0), nil)
+			handleMock.On("CmdlineSlice").Return([]string{"test"}, nil)
+			handleMock.On("Cgroup").Return(test.cgroup, test.cgroupError)
+			handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
+			handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
+			handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+			handleMock.On("NumFDs").Return(int32(0), nil)
+			handleMock.On("NumThreads").Return(int32(0), nil)
+			handleMock.On("CreateTime").Return(int64(0), nil)
+			handleMock.On("Rlimit").Return([]process.RlimitStat{{Resource: process.RLIMIT_NOFILE}}, nil)
+			scraper.getProcessHandles = func() (processHandles, error) {
+				return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+			}
+
+			resourceMetrics, err := scraper.scrape(context.Background())
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, 1, resourceMetrics.Len())
+			attr := resourceMetrics.At(0).Resource().Attributes()
+			id, ok := attr.Get(conventions.AttributeContainerID)
+			assert.Equal(t, test.expectedID != "", ok)
+			assert.Equal(t, test.expectedID, id.StringVal())
+			podUID, ok := attr.Get(conventions.AttributeK8sPodUID)
+			assert.Equal(t, test.expectedPodUID != "", ok)
+			assert.Equal(t, test.expectedPodUID, podUID.StringVal())
+		})
+	}
+}
+
+const testContainerID = "3f4b2a1c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a"
+
+func TestParseProcessContainer(t *testing.T) {
+	type testCase struct {
+		name     string
+		cgroup   string
+		expected *containerMetadata
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Docker cgroupfs driver",
+			cgroup:   "11:cpu,cpuacct:/docker/" + testContainerID,
+			expected: &containerMetadata{id: testContainerID},
+		},
+		{
+			name:     "Docker systemd driver",
+			cgroup:   "0::/system.slice/docker-" + testContainerID + ".scope",
+			expected: &containerMetadata{id: testContainerID},
+		},
+		{
+			name:     "Podman",
+			cgroup:   "0::/machine.slice/libpod-" + testContainerID + ".scope/container",
+			expected: &containerMetadata{id: testContainerID},
+		},
+		{
+			name:     "Kubernetes cgroupfs driver",
+			cgroup:   "1:name=systemd:/kubepods/burstable/pod0f5d2e4c-8b7a-4d1e-9c3f-2a6b8e1d7c45/" + testContainerID,
+			expected: &containerMetadata{id: testContainerID, podUID: "0f5d2e4c-8b7a-4d1e-9c3f-2a6b8e1d7c45"},
+		},
+		{
+			name:     "Kubernetes CRI-O",
+			cgroup:   "0::/kubepods.slice/kubepods-pod0f5d2e4c_8b7a_4d1e_9c3f_2a6b8e1d7c45.slice/crio-" + testContainerID + ".scope",
+			expected: &containerMetadata{id: testContainerID, podUID: "0f5d2e4c-8b7a-4d1e-9c3f-2a6b8e1d7c45"},
+		},
+		{
+			name:   "Host process",
+			cgroup: "12:pids:/user.slice/user-1000.slice\n0::/init.scope",
+		},
+		{
+			name:   "Invalid format",
+			cgroup: "invalid",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseProcessContainer(test.cgroup))
+		})
+	}
+}
+
+func TestScrapeMetrics_Environment(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("skipping test on %v", runtime.GOOS)
+	}
+
+	type testCase struct {
+		name          string
+		variables     []string
+		environ       string
+		environError  error
+		expectedEnv   map[string]string
+		expectedError string
+	}
+
+	testCases := []testCase{
+		{
+			name:        "No Variables",
+			environ:     "SERVICE_NAME=test\x00",
+			expectedEnv: map[string]string{},
+		},
+		{
+			name:        "Variables",
+			variables:   []string{"DEPLOYMENT_ID", "SERVICE_NAME", "MISSING"},
+			environ:     "PATH=/usr/bin\x00SERVICE_NAME=test\x00DEPLOYMENT_ID=blue=1\x00",
+			expectedEnv: map[string]string{"process.env.DEPLOYMENT_ID": "blue=1", "process.env.SERVICE_NAME": "test"},
+		},
+		{
+			name:          "Environ Error",
+			variables:     []string{"SERVICE_NAME"},
+			environError:  errors.New("err1"),
+			expectedEnv:   map[string]string{},
+			expectedError: `error reading environment variables for process "test" (pid 1): err1`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper, err := newProcessScraper(&Config{EnvironmentVariables: test.variables})
+			require.NoError(t, err, "Failed to create process scraper: %v", err)
+			err = scraper.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+			handleMock := &processHandleMock{}
+			handleMock.On("Name").Return("test", nil)
+			handleMock.On("Exe").Return("test", nil)
+			handleMock.On("Username").Return("username", nil)
+			handleMock.On("Ppid").Return(int32(0), nil)
+			handleMock.On("CmdlineSlice").Return([]string{"test"}, nil)
+			handleMock.On("Cgroup").Return("0::/", nil)
+			handleMock.On("Environ").Return(test.environ, test.environError)
+			handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
+			handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
+			handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+			handleMock.On("NumFDs").Return(int32(0), nil)
+			handleMock.On("NumThreads").Return(int32(0), nil)
+			handleMock.On("CreateTime").Return(int64(0), nil)
+			handleMock.On("Rlimit").Return([]process.RlimitStat{{Resource: process.RLIMIT_NOFILE}}, nil)
+			scraper.getProcessHandles = func() (processHandles, error) {
+				return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+			}
+
+			resourceMetrics, err := scraper.scrape(context.Background())
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, 1, resourceMetrics.Len())
+			env := map[string]string{}
+			resourceMetrics.At(0).Resource().Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+				if strings.HasPrefix(k, "process.env.") {
+					env[k] = v.StringVal()
+				}
+			})
+			assert.Equal(t, test.expectedEnv, env)
+
+			if len(test.variables) == 0 {
+				handleMock.AssertNotCalled(t, "Environ")
+			}
+		})
+	}
+}
+
+func TestParseProcessEnvironment(t *testing.T) {
+	environ := "PATH=/usr/bin\x00SERVICE_NAME=test\x00EMPTY=\x00INVALID\x00DEPLOYMENT_ID=blue"
+	expected := []environmentVariable{
+		{name: "DEPLOYMENT_ID", value: "blue"},
+		{name: "EMPTY", value: ""},
+		{name: "SERVICE_NAME", value: "test"},
+	}
+	assert.Equal(t, expected, parseProcessEnvironment(environ, []string{"DEPLOYMENT_ID", "EMPTY", "INVALID", "SERVICE_NAME", "MISSING"}))
+	assert.Nil(t, parseProcessEnvironment(environ, []string{"MISSING"}))
+}
+
+func TestScrapeMetrics_Top(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	type testCase struct {
+		name          string
+		sortBy        string
+		expectedNames [][]string
+	}
+
+	// the CPU times of the processes at each scrape, and their physical memory usage
+	names := []string{"a", "b", "c"}
+	times := [][]float64{{10, 5, 1}, {11, 10, 2}}
+	rss := []uint64{100, 300, 200}
+
+	testCases := []testCase{
+		{
+			name:          "CPU",
+			expectedNames: [][]string{{"a", "other"}, {"b", "other"}},
+		},
+		{
+			name:          "Memory",
+			sortBy:        "memory",
+			expectedNames: [][]string{{"b", "other"}, {"b", "other"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper, err := newProcessScraper(&Config{Top: TopConfig{Count: 1, SortBy: test.sortBy}})
+			require.NoError(t, err, "Failed to create process scraper: %v", err)
+			err = scraper.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+			var resourceMetrics pdata.ResourceMetricsSlice
+			for i, expectedNames := range test.expectedNames {
+				handles := &processHandlesMock{}
+				for j, name := range names {
+					handles.handles = append(handles.handles, newUsageHandleMock(name, times[i][j], rss[j]))
+					handles.pids = append(handles.pids, int32(j+1))
+				}
+				scraper.getProcessHandles = func() (processHandles, error) { return handles, nil }
+
+				resourceMetrics, err = scraper.scrape(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, len(expectedNames), resourceMetrics.Len())
+				for k, expectedName := range expectedNames {
+					name, ok := resourceMetrics.At(k).Resource().Attributes().Get(conventions.AttributeProcessExecutableName)
+					require.True(t, ok)
+					assert.Equal(t, expectedName, name.StringVal())
+				}
+			}
+
+			// the processes out of the top are aggregated
+			other := getMetricSlice(t, resourceMetrics.At(1))
+			require.Equal(t, otherMetricsLen, other.Len())
+			internal.AssertDescriptorEqual(t, metadata.Metrics.ProcessMemoryPhysicalUsage.New(), other.At(1))
+			assert.EqualValues(t, 300, other.At(1).IntSum().DataPoints().At(0).Value())
+			internal.AssertDescriptorEqual(t, metadata.Metrics.ProcessMemoryVirtualUsage.New(), other.At(2))
+			assert.EqualValues(t, 600, other.At(2).IntSum().DataPoints().At(0).Value())
+		})
+	}
+}
+
+func TestScrapeMetrics_TopOtherCPUTime(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper, err := newProcessScraper(&Config{Top: TopConfig{Count: 1, SortBy: "memory"}})
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	// the CPU times and physical memory usage of the processes at each scrape,
+	// the process with the most CPU time moves in and out of the top
+	names := []string{"a", "b"}
+	times := [][]float64{{5, 100}, {6, 102}, {9, 110}}
+	rss := [][]uint64{{300, 100}, {100, 300}, {300, 100}}
+	expectedTop := []string{"a", "b", "a"}
+	// only the CPU time spent between two scrapes by the processes out of the top
+	// is added, so the aggregated CPU time doesn't decrease
+	expectedOtherUserTimes := []float64{0, 1, 9}
+
+	for i := range times {
+		handles := &processHandlesMock{}
+		for j, name := range names {
+			handles.handles = append(handles.handles, newUsageHandleMock(name, times[i][j], rss[i][j]))
+			handles.pids = append(handles.pids, int32(j+1))
+		}
+		scraper.getProcessHandles = func() (processHandles, error) { return handles, nil }
+
+		resourceMetrics, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, resourceMetrics.Len())
+		name, ok := resourceMetrics.At(0).Resource().Attributes().Get(conventions.AttributeProcessExecutableName)
+		require.True(t, ok)
+		assert.Equal(t, expectedTop[i], name.StringVal())
+
+		other := getMetricSlice(t, resourceMetrics.At(1))
+		internal.AssertDescriptorEqual(t, metadata.Metrics.ProcessCPUTime.New(), other.At(0))
+		userTime := other.At(0).DoubleSum().DataPoints().At(0)
+		internal.AssertDoubleSumMetricLabelHasValue(t, other.At(0), 0, metadata.Labels.ProcessState, metadata.LabelProcessState.User)
+		assert.Equal(t, expectedOtherUserTimes[i], userTime.Value())
+	}
+}
+
+func newUsageHandleMock(name string, user float64, rss uint64) *processHandleMock {
+	handleMock := &processHandleMock{}
+	handleMock.On("Name").Return(name, nil)
+	handleMock.On("Exe").Return(name, nil)
+	handleMock.On("Username").Return("username", nil)
+	handleMock.On("Ppid").Return(int32(0), nil)
+	handleMock.On("Cmdline").Return("cmdline", nil)
+	handleMock.On("CmdlineSlice").Return([]string{"cmdline"}, nil)
+	handleMock.On("Times").Return(&cpu.TimesStat{User: user}, nil)
+	handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{RSS: rss, VMS: 2 * rss}, nil)
+	handleMock.On("PrivateUsage").Return(uint64(0), nil)
+	handleMock.On("Cgroup").Return("0::/", nil)
+	handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+	handleMock.On("NumFDs").Return(int32(0), nil)
+	handleMock.On("NumThreads").Return(int32(0), nil)
+	handleMock.On("CreateTime").Return(int64(0), nil)
+	handleMock.On("Rlimit").Return([]process.RlimitStat{{Resource: process.RLIMIT_NOFILE}}, nil)
+	return handleMock
+}
+
+func TestScrapeMetrics_TolerateMetadataErrors(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper, err := newProcessScraper(&Config{TolerateMetadataErrors: true})
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	// the process is reported with its name even though its path cannot be read
+	handleMock := newDefaultHandleMock()
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("", errors.New("permission denied"))
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+
+	resourceMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, resourceMetrics.Len())
+	attr := resourceMetrics.At(0).Resource().Attributes()
+	name, ok := attr.Get(conventions.AttributeProcessExecutableName)
+	require.True(t, ok)
+	assert.Equal(t, "test", name.StringVal())
+	_, ok = attr.Get(conventions.AttributeProcessExecutablePath)
+	assert.False(t, ok)
+	assert.Equal(t, metricsLen, getMetricSlice(t, resourceMetrics.At(0)).Len())
+}
+
 func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 	skipTestOnUnsupportedOS(t)
 
@@ -349,10 +1079,15 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 		nameError       error
 		exeError        error
 		usernameError   error
+		ppidError       error
 		cmdlineError    error
 		timesError      error
 		memoryInfoError error
 		ioCountersError error
+		numFDsError     error
+		rlimitError     error
+		numThreadsError error
+		createTimeError error
 		expectedError   string
 	}
 
@@ -378,6 +1113,11 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 			usernameError: errors.New("err3"),
 			expectedError: `error reading username for process "test" (pid 1): err3`,
 		},
+		{
+			name:          "Ppid Error",
+			ppidError:     errors.New("err10"),
+			expectedError: `error reading parent pid for process "test" (pid 1): err10`,
+		},
 		{
 			name:          "Times Error",
 			timesError:    errors.New("err4"),
@@ -393,18 +1133,47 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 			ioCountersError: errors.New("err6"),
 			expectedError:   `error reading disk usage for process "test" (pid 1): err6`,
 		},
+		{
+			name:          "Num FDs Error",
+			numFDsError:   errors.New("err7"),
+			expectedError: `error reading file descriptors for process "test" (pid 1): err7`,
+		},
+		{
+			name:          "Rlimit Error",
+			osFilter:      "windows",
+			rlimitError:   errors.New("err8"),
+			expectedError: `error reading file descriptors for process "test" (pid 1): err8`,
+		},
+		{
+			name:            "Num Threads Error",
+			numThreadsError: errors.New("err9"),
+			expectedError:   `error reading threads for process "test" (pid 1): err9`,
+		},
+		{
+			name:            "Create Time Error",
+			createTimeError: errors.New("err11"),
+			expectedError:   `error reading create time for process "test" (pid 1): err11`,
+		},
 		{
 			name:            "Multiple Errors",
 			cmdlineError:    errors.New("err2"),
 			usernameError:   errors.New("err3"),
+			ppidError:       errors.New("err10"),
 			timesError:      errors.New("err4"),
 			memoryInfoError: errors.New("err5"),
 			ioCountersError: errors.New("err6"),
+			numFDsError:     errors.New("err7"),
+			numThreadsError: errors.New("err9"),
+			createTimeError: errors.New("err11"),
 			expectedError: `[[error reading command for process "test" (pid 1): err2; ` +
-				`error reading username for process "test" (pid 1): err3]; ` +
+				`error reading username for process "test" (pid 1): err3; ` +
+				`error reading parent pid for process "test" (pid 1): err10]; ` +
 				`error reading cpu times for process "test" (pid 1): err4; ` +
 				`error reading memory info for process "test" (pid 1): err5; ` +
-				`error reading disk usage for process "test" (pid 1): err6]`,
+				`error reading disk usage for process "test" (pid 1): err6; ` +
+				`error reading file descriptors for process "test" (pid 1): err7; ` +
+				`error reading threads for process "test" (pid 1): err9; ` +
+				`error reading create time for process "test" (pid 1): err11]`,
 		},
 	}
 
@@ -428,11 +1197,18 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 			handleMock.On("Name").Return("test", test.nameError)
 			handleMock.On("Exe").Return("test", test.exeError)
 			handleMock.On("Username").Return(username, test.usernameError)
+			handleMock.On("Ppid").Return(int32(0), test.ppidError)
 			handleMock.On("Cmdline").Return("cmdline", test.cmdlineError)
 			handleMock.On("CmdlineSlice").Return([]string{"cmdline"}, test.cmdlineError)
 			handleMock.On("Times").Return(&cpu.TimesStat{}, test.timesError)
 			handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, test.memoryInfoError)
+			handleMock.On("PrivateUsage").Return(uint64(0), nil)
+			handleMock.On("Cgroup").Return("0::/", nil)
 			handleMock.On("IOCounters").Return(&process.IOCountersStat{}, test.ioCountersError)
+			handleMock.On("NumFDs").Return(int32(0), test.numFDsError)
+			handleMock.On("NumThreads").Return(int32(0), test.numThreadsError)
+			handleMock.On("CreateTime").Return(int64(0), test.createTimeError)
+			handleMock.On("Rlimit").Return([]process.RlimitStat{{Resource: process.RLIMIT_NOFILE}}, test.rlimitError)
 
 			scraper.getProcessHandles = func() (processHandles, error) {
 				return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
@@ -442,7 +1218,11 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 
 			md := pdata.NewMetrics()
 			resourceMetrics.MoveAndAppendTo(md.ResourceMetrics())
-			expectedResourceMetricsLen, expectedMetricsLen := getExpectedLengthOfReturnedMetrics(test.nameError, test.exeError, test.timesError, test.memoryInfoError, test.ioCountersError)
+			fdError := test.numFDsError
+			if fdError == nil {
+				fdError = test.rlimitError
+			}
+			expectedResourceMetricsLen, expectedMetricsLen := getExpectedLengthOfReturnedMetrics(test.nameError, test.exeError, test.timesError, test.memoryInfoError, test.ioCountersError, fdError, test.numThreadsError, test.createTimeError)
 			assert.Equal(t, expectedResourceMetricsLen, md.ResourceMetrics().Len())
 			assert.Equal(t, expectedMetricsLen, md.MetricCount())
 
@@ -450,14 +1230,14 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 			isPartial := scrapererror.IsPartialScrapeError(err)
 			assert.True(t, isPartial)
 			if isPartial {
-				expectedFailures := getExpectedScrapeFailures(test.nameError, test.exeError, test.timesError, test.memoryInfoError, test.ioCountersError)
+				expectedFailures := getExpectedScrapeFailures(test.nameError, test.exeError, test.timesError, test.memoryInfoError, test.ioCountersError, fdError, test.numThreadsError, test.createTimeError)
 				assert.Equal(t, expectedFailures, err.(scrapererror.PartialScrapeError).Failed)
 			}
 		})
 	}
 }
 
-func getExpectedLengthOfReturnedMetrics(nameError, exeError, timeError, memError, diskError error) (int, int) {
+func getExpectedLengthOfReturnedMetrics(nameError, exeError, timeError, memError, diskError, fdError, threadsError, createTimeError error) (int, int) {
 	if nameError != nil || exeError != nil {
 		return 0, 0
 	}
@@ -467,16 +1247,25 @@ func getExpectedLengthOfReturnedMetrics(nameError, exeError, timeError, memError
 		expectedLen += cpuMetricsLen
 	}
 	if memError == nil {
-		expectedLen += memoryMetricsLen
+		expectedLen += memoryMetricsLen + memoryUtilizationMetricsLen
 	}
 	if diskError == nil {
 		expectedLen += diskMetricsLen
 	}
+	if fdError == nil {
+		expectedLen += fileDescriptorsMetricsLen
+	}
+	if threadsError == nil {
+		expectedLen += threadsMetricsLen
+	}
+	if createTimeError == nil {
+		expectedLen += createTimeMetricsLen
+	}
 	return 1, expectedLen
 }
 
-func getExpectedScrapeFailures(nameError, exeError, timeError, memError, diskError error) int {
-	expectedResourceMetricsLen, expectedMetricsLen := getExpectedLengthOfReturnedMetrics(nameError, exeError, timeError, memError, diskError)
+func getExpectedScrapeFailures(nameError, exeError, timeError, memError, diskError, fdError, threadsError, createTimeError error) int {
+	expectedResourceMetricsLen, expectedMetricsLen := getExpectedLengthOfReturnedMetrics(nameError, exeError, timeError, memError, diskError, fdError, threadsError, createTimeError)
 	if expectedResourceMetricsLen == 0 {
 		return 1
 	}
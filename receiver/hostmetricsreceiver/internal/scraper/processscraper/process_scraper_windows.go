@@ -17,16 +17,29 @@
 package processscraper
 
 import (
+	"errors"
 	"path/filepath"
 	"regexp"
+	"unsafe"
 
 	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/process"
+	"golang.org/x/sys/windows"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
 )
 
-const cpuStatesLen = 2
+const (
+	cpuStatesLen = 2
+
+	// fileDescriptorsLimitMetricsLen is the number of metrics about the limit of open file descriptors,
+	// processes have no such limit on Windows.
+	fileDescriptorsLimitMetricsLen = 0
+
+	// platformMemoryMetricsLen is the number of memory usage metrics only reported on Windows.
+	platformMemoryMetricsLen = 1
+)
 
 func appendCPUTimeStateDataPoints(ddps pdata.DoubleDataPointSlice, startTime, now pdata.Timestamp, cpuTime *cpu.TimesStat) {
 	initializeCPUTimeDataPoint(ddps.At(0), startTime, now, cpuTime.User, metadata.LabelProcessState.User)
@@ -41,10 +54,36 @@ func initializeCPUTimeDataPoint(dataPoint pdata.DoubleDataPoint, startTime, now
 	dataPoint.SetValue(value)
 }
 
+// privateUsageHandle is implemented by the process handles able to report the private bytes of the process.
+type privateUsageHandle interface {
+	PrivateUsage() (uint64, error)
+}
+
+func getPlatformMemoryUsages(handle processHandle, _ *process.MemoryInfoStat) ([]memoryUsage, error) {
+	privateHandle, ok := handle.(privateUsageHandle)
+	if !ok {
+		return nil, errors.New("private memory usage not supported")
+	}
+
+	private, err := privateHandle.PrivateUsage()
+	if err != nil {
+		return nil, err
+	}
+	return []memoryUsage{{metric: metadata.Metrics.ProcessMemoryPrivateUsage, value: int64(private)}}, nil
+}
+
+// getProcessExecutable returns the executable of the process, on error the
+// returned executable holds the properties that could be read.
 func getProcessExecutable(proc processHandle) (*executableMetadata, error) {
 	exe, err := proc.Exe()
 	if err != nil {
-		return nil, err
+		// the name is read from the snapshot of the processes, which does not
+		// require access to the process
+		name, nameErr := proc.Name()
+		if nameErr != nil {
+			return &executableMetadata{}, err
+		}
+		return &executableMetadata{name: name}, err
 	}
 
 	name := filepath.Base(exe)
@@ -70,3 +109,70 @@ func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	command := &commandMetadata{command: cmd, commandLine: cmdline}
 	return command, nil
 }
+
+func getProcessContainer(processHandle) (*containerMetadata, error) {
+	return nil, nil
+}
+
+func getProcessEnvironment(processHandle, []string) ([]environmentVariable, error) {
+	return nil, nil
+}
+
+var (
+	procGetProcessHandleCount = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetProcessHandleCount")
+	procGetProcessMemoryInfo  = windows.NewLazySystemDLL("psapi.dll").NewProc("GetProcessMemoryInfo")
+)
+
+// processMemoryCountersEx mirrors the PROCESS_MEMORY_COUNTERS_EX structure.
+type processMemoryCountersEx struct {
+	CB                         uint32
+	PageFaultCount             uint32
+	PeakWorkingSetSize         uintptr
+	WorkingSetSize             uintptr
+	QuotaPeakPagedPoolUsage    uintptr
+	QuotaPagedPoolUsage        uintptr
+	QuotaPeakNonPagedPoolUsage uintptr
+	QuotaNonPagedPoolUsage     uintptr
+	PagefileUsage              uintptr
+	PeakPagefileUsage          uintptr
+	PrivateUsage               uintptr
+}
+
+// windowsProcessHandle reports the number of open handles of the process as its
+// number of open file descriptors, and the private bytes of the process.
+type windowsProcessHandle struct {
+	*process.Process
+}
+
+func newProcessHandle(proc *process.Process) processHandle {
+	return windowsProcessHandle{proc}
+}
+
+func (p windowsProcessHandle) NumFDs() (int32, error) {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(p.Pid))
+	if err != nil {
+		return 0, err
+	}
+	defer windows.CloseHandle(handle)
+
+	var count uint32
+	if ret, _, err := procGetProcessHandleCount.Call(uintptr(handle), uintptr(unsafe.Pointer(&count))); ret == 0 {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (p windowsProcessHandle) PrivateUsage() (uint64, error) {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(p.Pid))
+	if err != nil {
+		return 0, err
+	}
+	defer windows.CloseHandle(handle)
+
+	var counters processMemoryCountersEx
+	counters.CB = uint32(unsafe.Sizeof(counters))
+	if ret, _, err := procGetProcessMemoryInfo.Call(uintptr(handle), uintptr(unsafe.Pointer(&counters)), uintptr(counters.CB)); ret == 0 {
+		return 0, err
+	}
+	return uint64(counters.PrivateUsage), nil
+}
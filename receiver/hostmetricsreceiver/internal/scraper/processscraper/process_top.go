@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processscraper
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/process"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	topSortByCPU    = "cpu"
+	topSortByMemory = "memory"
+
+	// otherProcessesName is the executable name of the resource aggregating the processes out of the top.
+	otherProcessesName = "other"
+
+	otherMetricsLen = cpuMetricsLen + 2
+)
+
+// processTop selects the processes using the most CPU or memory.
+type processTop struct {
+	count  int
+	sortBy string
+
+	// cpuTimes are the CPU times of the processes at the previous scrape, by pid.
+	cpuTimes map[int32]cpu.TimesStat
+	// otherTimes is the CPU time spent by the processes out of the top since the
+	// first scrape. Only the time spent between two scrapes of a process is added,
+	// so the aggregated CPU time stays monotonic when the processes move in or
+	// out of the top, or start and exit.
+	otherTimes cpu.TimesStat
+}
+
+// newProcessTop creates a top from the config, it returns nil if the count is not configured.
+func newProcessTop(cfg *TopConfig) (*processTop, error) {
+	if cfg.Count == 0 {
+		return nil, nil
+	}
+	if cfg.Count < 0 {
+		return nil, fmt.Errorf("invalid count %d, must be positive", cfg.Count)
+	}
+
+	sortBy := cfg.SortBy
+	if sortBy == "" {
+		sortBy = topSortByCPU
+	}
+	if sortBy != topSortByCPU && sortBy != topSortByMemory {
+		return nil, fmt.Errorf("invalid sort_by %q, must be %q or %q", sortBy, topSortByCPU, topSortByMemory)
+	}
+
+	return &processTop{count: cfg.Count, sortBy: sortBy, cpuTimes: map[int32]cpu.TimesStat{}}, nil
+}
+
+// processUsage is the CPU and memory usage of a process, times is the CPU time
+// spent since the previous scrape.
+type processUsage struct {
+	md    *processMetadata
+	times *cpu.TimesStat
+	mem   *process.MemoryInfoStat
+	rank  float64
+}
+
+// otherProcesses is the aggregated usage of the processes out of the top.
+type otherProcesses struct {
+	times cpu.TimesStat
+	rss   uint64
+	vms   uint64
+}
+
+// selectTop returns the processes in the top and the aggregated usage of the other
+// processes, or nil if all the processes are in the top.
+func (t *processTop) selectTop(mds []*processMetadata) ([]*processMetadata, *otherProcesses) {
+	usages := make([]processUsage, len(mds))
+	cpuTimes := make(map[int32]cpu.TimesStat, len(mds))
+	for i, md := range mds {
+		usage := processUsage{md: md}
+
+		// the errors are reported when scraping the metrics of the processes in the top
+		if times, err := md.handle.Times(); err == nil {
+			cpuTimes[md.pid] = *times
+			if previous, ok := t.cpuTimes[md.pid]; ok {
+				usage.times = timesSince(times, &previous)
+			}
+			if t.sortBy == topSortByCPU {
+				usage.rank = times.User + times.System
+				if usage.times != nil {
+					usage.rank = usage.times.User + usage.times.System
+				}
+			}
+		}
+		if mem, err := md.handle.MemoryInfo(); err == nil {
+			usage.mem = mem
+			if t.sortBy == topSortByMemory {
+				usage.rank = float64(mem.RSS)
+			}
+		}
+
+		usages[i] = usage
+	}
+	t.cpuTimes = cpuTimes
+
+	if len(usages) <= t.count {
+		return mds, nil
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool { return usages[i].rank > usages[j].rank })
+
+	top := make([]*processMetadata, t.count)
+	for i := range top {
+		top[i] = usages[i].md
+	}
+
+	other := &otherProcesses{}
+	for _, usage := range usages[t.count:] {
+		if usage.times != nil {
+			t.otherTimes.User += usage.times.User
+			t.otherTimes.System += usage.times.System
+			t.otherTimes.Iowait += usage.times.Iowait
+		}
+		if usage.mem != nil {
+			other.rss += usage.mem.RSS
+			other.vms += usage.mem.VMS
+		}
+	}
+	other.times = t.otherTimes
+	return top, other
+}
+
+// timesSince returns the CPU times spent since the previous times of a process,
+// or nil if the times went backwards, e.g. because the pid was reused.
+func timesSince(times, previous *cpu.TimesStat) *cpu.TimesStat {
+	if times.User < previous.User || times.System < previous.System || times.Iowait < previous.Iowait {
+		return nil
+	}
+	return &cpu.TimesStat{
+		User:   times.User - previous.User,
+		System: times.System - previous.System,
+		Iowait: times.Iowait - previous.Iowait,
+	}
+}
+
+func (o *otherProcesses) initializeResourceMetrics(rm pdata.ResourceMetrics, startTime, now pdata.Timestamp) {
+	attr := rm.Resource().Attributes()
+	attr.InitEmptyWithCapacity(1)
+	attr.InsertString(conventions.AttributeProcessExecutableName, otherProcessesName)
+
+	ilms := rm.InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+
+	metrics.Resize(otherMetricsLen)
+	initializeCPUTimeMetric(metrics.At(0), startTime, now, &o.times)
+	initializeMemoryUsageMetric(metrics.At(1), metadata.Metrics.ProcessMemoryPhysicalUsage, now, int64(o.rss))
+	initializeMemoryUsageMetric(metrics.At(2), metadata.Metrics.ProcessMemoryVirtualUsage, now, int64(o.vms))
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package sensorsscraper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// getFanStats returns the rotation speed of the fans reported by the Linux
+// hwmon sensors. The sys filesystem mounted at HOST_SYS is read when set, the
+// same as gopsutil.
+func getFanStats() ([]fanStat, error) {
+	sysPath := os.Getenv("HOST_SYS")
+	if sysPath == "" {
+		sysPath = "/sys"
+	}
+
+	files, err := filepath.Glob(filepath.Join(sysPath, "class", "hwmon", "hwmon*", "fan*_input"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		// some drivers expose the sensors in the intermediate device directory
+		files, err = filepath.Glob(filepath.Join(sysPath, "class", "hwmon", "hwmon*", "device", "fan*_input"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fans := make([]fanStat, 0, len(files))
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		fan := strings.TrimSuffix(filepath.Base(file), "_input")
+
+		name, err := ioutil.ReadFile(filepath.Join(dir, "name"))
+		if err != nil {
+			return nil, err
+		}
+
+		// the label of the fan is optional, e.g. "CPU Fan"
+		if label, err := ioutil.ReadFile(filepath.Join(dir, fan+"_label")); err == nil {
+			fan = strings.Join(strings.Fields(strings.ToLower(string(label))), "")
+		}
+
+		value, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		rpm, err := strconv.ParseInt(strings.TrimSpace(string(value)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		fans = append(fans, fanStat{sensor: strings.TrimSpace(string(name)) + "_" + fan, rpm: rpm})
+	}
+
+	return fans, nil
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package sensorsscraper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFanStats(t *testing.T) {
+	sysPath, err := ioutil.TempDir("", "sensors")
+	require.NoError(t, err)
+	defer os.RemoveAll(sysPath)
+
+	hwmonPath := filepath.Join(sysPath, "class", "hwmon", "hwmon0")
+	require.NoError(t, os.MkdirAll(hwmonPath, 0700))
+	writeFile := func(name, content string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(hwmonPath, name), []byte(content), 0600))
+	}
+	writeFile("name", "nct6775\n")
+	writeFile("fan1_input", "1200\n")
+	writeFile("fan1_label", "CPU Fan\n")
+	writeFile("fan2_input", "0\n")
+	writeFile("fan2_min", "300\n")
+
+	os.Setenv("HOST_SYS", sysPath)
+	defer os.Unsetenv("HOST_SYS")
+
+	fans, err := getFanStats()
+	require.NoError(t, err)
+	assert.Equal(t, []fanStat{
+		{sensor: "nct6775_cpufan", rpm: 1200},
+		{sensor: "nct6775_fan2", rpm: 0},
+	}, fans)
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensorsscraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/host"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const (
+	temperatureMetricsLen = 1
+	fanSpeedMetricsLen    = 1
+)
+
+// hwmonAttributes are the suffixes of the Linux hwmon temperature attributes
+// reported by gopsutil that are not readings, e.g. the alarm thresholds.
+var hwmonAttributes = map[string]bool{
+	"alarm":         true,
+	"beep":          true,
+	"crit":          true,
+	"critalarm":     true,
+	"crithyst":      true,
+	"emergency":     true,
+	"emergencyhyst": true,
+	"enable":        true,
+	"fault":         true,
+	"highest":       true,
+	"lcrit":         true,
+	"lcritalarm":    true,
+	"lowest":        true,
+	"max":           true,
+	"maxalarm":      true,
+	"maxhyst":       true,
+	"min":           true,
+	"minalarm":      true,
+	"offset":        true,
+	"resethistory":  true,
+	"type":          true,
+}
+
+// fanStat is the rotation speed of a fan.
+type fanStat struct {
+	sensor string
+	rpm    int64
+}
+
+// scraper for Sensors Metrics
+type scraper struct {
+	config *Config
+
+	// for mocking
+	temperatures func() ([]host.TemperatureStat, error)
+	fans         func() ([]fanStat, error)
+}
+
+// newSensorsScraper creates a Sensors Scraper
+func newSensorsScraper(_ context.Context, cfg *Config) *scraper {
+	return &scraper{config: cfg, temperatures: host.SensorsTemperatures, fans: getFanStats}
+}
+
+func (s *scraper) scrape(_ context.Context) (pdata.MetricSlice, error) {
+	metrics := pdata.NewMetricSlice()
+
+	var errs scrapererror.ScrapeErrors
+
+	err := s.scrapeAndAppendTemperatureMetric(metrics)
+	if partialErr, isPartial := err.(scrapererror.PartialScrapeError); isPartial {
+		errs.AddPartial(partialErr.Failed, partialErr)
+	} else if err != nil {
+		errs.AddPartial(temperatureMetricsLen, err)
+	}
+
+	err = s.scrapeAndAppendFanSpeedMetric(metrics)
+	if err != nil {
+		errs.AddPartial(fanSpeedMetricsLen, err)
+	}
+
+	return metrics, errs.Combine()
+}
+
+func (s *scraper) scrapeAndAppendTemperatureMetric(metrics pdata.MetricSlice) error {
+	now := pdata.TimestampFromTime(time.Now())
+	temperatures, err := s.temperatures()
+
+	// gopsutil returns the temperatures that could be read along with warnings
+	// for the sensors that could not be read
+	var warnings *host.Warnings
+	if err != nil && !(errors.As(err, &warnings) && len(temperatures) > 0) {
+		return fmt.Errorf("error reading temperatures: %w", err)
+	}
+
+	readings := getTemperatureReadings(temperatures)
+	if len(readings) > 0 {
+		idx := metrics.Len()
+		metrics.Resize(idx + temperatureMetricsLen)
+		initializeTemperatureMetric(metrics.At(idx), now, readings)
+	}
+
+	if warnings != nil {
+		return scrapererror.NewPartialScrapeError(fmt.Errorf("error reading temperatures: %v", warnings.List), 0)
+	}
+	return nil
+}
+
+// getTemperatureReadings returns the current temperatures reported by gopsutil,
+// discarding the thresholds reported for the Linux hwmon sensors, and naming
+// each sensor without the "_input" suffix of its hwmon attribute.
+func getTemperatureReadings(temperatures []host.TemperatureStat) []host.TemperatureStat {
+	readings := make([]host.TemperatureStat, 0, len(temperatures))
+	for _, temperature := range temperatures {
+		sensor := temperature.SensorKey
+		if i := strings.LastIndexByte(sensor, '_'); i >= 0 {
+			suffix := sensor[i+1:]
+			if hwmonAttributes[suffix] {
+				continue
+			}
+			if suffix == "input" {
+				sensor = sensor[:i]
+			}
+		}
+
+		readings = append(readings, host.TemperatureStat{SensorKey: sensor, Temperature: temperature.Temperature})
+	}
+	return readings
+}
+
+func initializeTemperatureMetric(metric pdata.Metric, now pdata.Timestamp, temperatures []host.TemperatureStat) {
+	metadata.Metrics.SystemSensorsTemperature.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(len(temperatures))
+	for i, temperature := range temperatures {
+		ddp := ddps.At(i)
+		ddp.LabelsMap().Insert(metadata.Labels.SensorsSensor, temperature.SensorKey)
+		ddp.SetTimestamp(now)
+		ddp.SetValue(temperature.Temperature)
+	}
+}
+
+func (s *scraper) scrapeAndAppendFanSpeedMetric(metrics pdata.MetricSlice) error {
+	now := pdata.TimestampFromTime(time.Now())
+	fans, err := s.fans()
+	if err != nil {
+		return fmt.Errorf("error reading fan speeds: %w", err)
+	}
+
+	if len(fans) > 0 {
+		idx := metrics.Len()
+		metrics.Resize(idx + fanSpeedMetricsLen)
+		initializeFanSpeedMetric(metrics.At(idx), now, fans)
+	}
+	return nil
+}
+
+func initializeFanSpeedMetric(metric pdata.Metric, now pdata.Timestamp, fans []fanStat) {
+	metadata.Metrics.SystemSensorsFanSpeed.Init(metric)
+
+	idps := metric.IntGauge().DataPoints()
+	idps.Resize(len(fans))
+	for i, fan := range fans {
+		idp := idps.At(i)
+		idp.LabelsMap().Insert(metadata.Labels.SensorsSensor, fan.sensor)
+		idp.SetTimestamp(now)
+		idp.SetValue(fan.rpm)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensorsscraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+var (
+	testTemperatures = []host.TemperatureStat{
+		{SensorKey: "coretemp_core0_input", Temperature: 45},
+		{SensorKey: "coretemp_core0_max", Temperature: 80},
+		{SensorKey: "coretemp_core0_crit", Temperature: 100},
+		{SensorKey: "coretemp_core0_critalarm", Temperature: 0},
+		{SensorKey: "acpitz", Temperature: 27.8},
+	}
+	testFans = []fanStat{
+		{sensor: "nct6775_cpufan", rpm: 1200},
+	}
+)
+
+func TestScrape(t *testing.T) {
+	type testCase struct {
+		name                  string
+		temperaturesFunc      func() ([]host.TemperatureStat, error)
+		fansFunc              func() ([]fanStat, error)
+		expectedMetricCount   int
+		expectedErr           string
+		expectedFailedMetrics int
+	}
+
+	testCases := []testCase{
+		{
+			name:                "Standard",
+			expectedMetricCount: 2,
+		},
+		{
+			name:                "No Fans",
+			fansFunc:            func() ([]fanStat, error) { return nil, nil },
+			expectedMetricCount: 1,
+		},
+		{
+			name:                  "Temperatures Error",
+			temperaturesFunc:      func() ([]host.TemperatureStat, error) { return nil, errors.New("err1") },
+			expectedMetricCount:   1,
+			expectedErr:           "error reading temperatures: err1",
+			expectedFailedMetrics: 1,
+		},
+		{
+			name: "Temperatures Warnings",
+			temperaturesFunc: func() ([]host.TemperatureStat, error) {
+				return testTemperatures, &host.Warnings{List: []error{errors.New("err2")}}
+			},
+			expectedMetricCount:   2,
+			expectedErr:           "error reading temperatures: [err2]",
+			expectedFailedMetrics: 0,
+		},
+		{
+			name:                  "Fans Error",
+			fansFunc:              func() ([]fanStat, error) { return nil, errors.New("err3") },
+			expectedMetricCount:   1,
+			expectedErr:           "error reading fan speeds: err3",
+			expectedFailedMetrics: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper := newSensorsScraper(context.Background(), &Config{})
+			scraper.temperatures = func() ([]host.TemperatureStat, error) { return testTemperatures, nil }
+			scraper.fans = func() ([]fanStat, error) { return testFans, nil }
+			if test.temperaturesFunc != nil {
+				scraper.temperatures = test.temperaturesFunc
+			}
+			if test.fansFunc != nil {
+				scraper.fans = test.fansFunc
+			}
+
+			metrics, err := scraper.scrape(context.Background())
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+
+				isPartial := scrapererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, test.expectedFailedMetrics, err.(scrapererror.PartialScrapeError).Failed)
+				}
+			} else {
+				require.NoError(t, err, "Failed to scrape metrics: %v", err)
+			}
+
+			assert.Equal(t, test.expectedMetricCount, metrics.Len())
+			for i := 0; i < metrics.Len(); i++ {
+				switch metrics.At(i).Name() {
+				case metadata.Metrics.SystemSensorsTemperature.Name():
+					assertTemperatureMetricValid(t, metrics.At(i))
+				case metadata.Metrics.SystemSensorsFanSpeed.Name():
+					assertFanSpeedMetricValid(t, metrics.At(i))
+				default:
+					assert.Failf(t, "unexpected metric", "%s", metrics.At(i).Name())
+				}
+			}
+
+			internal.AssertSameTimeStampForAllMetrics(t, metrics)
+		})
+	}
+}
+
+func assertTemperatureMetricValid(t *testing.T, metric pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemSensorsTemperature.New(), metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	require.Equal(t, 2, ddps.Len())
+	internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 0, metadata.Labels.SensorsSensor, "coretemp_core0")
+	assert.Equal(t, 45.0, ddps.At(0).Value())
+	internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 1, metadata.Labels.SensorsSensor, "acpitz")
+	assert.Equal(t, 27.8, ddps.At(1).Value())
+}
+
+func assertFanSpeedMetricValid(t *testing.T, metric pdata.Metric) {
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemSensorsFanSpeed.New(), metric)
+
+	idps := metric.IntGauge().DataPoints()
+	require.Equal(t, 1, idps.Len())
+	internal.AssertIntGaugeMetricLabelHasValue(t, metric, 0, metadata.Labels.SensorsSensor, "nct6775_cpufan")
+	assert.Equal(t, int64(1200), idps.At(0).Value())
+}
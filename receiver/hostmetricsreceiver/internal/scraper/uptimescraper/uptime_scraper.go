@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uptimescraper
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirou/gopsutil/host"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const metricsLen = 2
+
+// scraper for Uptime Metrics
+type scraper struct {
+	config *Config
+
+	// for mocking
+	bootTime func() (uint64, error)
+}
+
+// newUptimeScraper creates an Uptime Scraper
+func newUptimeScraper(_ context.Context, cfg *Config) *scraper {
+	return &scraper{config: cfg, bootTime: host.BootTime}
+}
+
+func (s *scraper) scrape(_ context.Context) (pdata.MetricSlice, error) {
+	metrics := pdata.NewMetricSlice()
+
+	now := time.Now()
+	bootTime, err := s.bootTime()
+	if err != nil {
+		return metrics, scrapererror.NewPartialScrapeError(err, metricsLen)
+	}
+
+	metrics.Resize(metricsLen)
+	initializeUptimeMetric(metrics.At(0), pdata.TimestampFromTime(now), now.Sub(time.Unix(int64(bootTime), 0)))
+	initializeBootTimeMetric(metrics.At(1), pdata.TimestampFromTime(now), bootTime)
+	return metrics, nil
+}
+
+func initializeUptimeMetric(metric pdata.Metric, now pdata.Timestamp, uptime time.Duration) {
+	metadata.Metrics.SystemUptime.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(1)
+	ddps.At(0).SetTimestamp(now)
+	ddps.At(0).SetValue(uptime.Seconds())
+}
+
+func initializeBootTimeMetric(metric pdata.Metric, now pdata.Timestamp, bootTime uint64) {
+	metadata.Metrics.SystemBootTime.Init(metric)
+
+	idps := metric.IntGauge().DataPoints()
+	idps.Resize(1)
+	idps.At(0).SetTimestamp(now)
+	idps.At(0).SetValue(int64(bootTime))
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uptimescraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+func TestScrape(t *testing.T) {
+	type testCase struct {
+		name         string
+		bootTimeFunc func() (uint64, error)
+		expectedErr  string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Standard",
+		},
+		{
+			name:         "Boot Time Error",
+			bootTimeFunc: func() (uint64, error) { return 0, errors.New("err1") },
+			expectedErr:  "err1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper := newUptimeScraper(context.Background(), &Config{})
+			if test.bootTimeFunc != nil {
+				scraper.bootTime = test.bootTimeFunc
+			}
+
+			metrics, err := scraper.scrape(context.Background())
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+
+				isPartial := scrapererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, metricsLen, err.(scrapererror.PartialScrapeError).Failed)
+				}
+
+				return
+			}
+			require.NoError(t, err, "Failed to scrape metrics: %v", err)
+
+			assert.Equal(t, metricsLen, metrics.Len())
+
+			internal.AssertDescriptorEqual(t, metadata.Metrics.SystemUptime.New(), metrics.At(0))
+			assert.Greater(t, metrics.At(0).DoubleGauge().DataPoints().At(0).Value(), 0.0)
+			internal.AssertDescriptorEqual(t, metadata.Metrics.SystemBootTime.New(), metrics.At(1))
+			assert.Greater(t, metrics.At(1).IntGauge().DataPoints().At(0).Value(), int64(0))
+		})
+	}
+}
+
+func TestScrape_Uptime(t *testing.T) {
+	bootTime := time.Now().Add(-time.Hour)
+
+	scraper := newUptimeScraper(context.Background(), &Config{})
+	scraper.bootTime = func() (uint64, error) { return uint64(bootTime.Unix()), nil }
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err, "Failed to scrape metrics: %v", err)
+
+	assert.InDelta(t, time.Hour.Seconds(), metrics.At(0).DoubleGauge().DataPoints().At(0).Value(), 2)
+	assert.Equal(t, bootTime.Unix(), metrics.At(1).IntGauge().DataPoints().At(0).Value())
+}
@@ -53,6 +53,12 @@ func AssertDoubleSumMetricLabelHasValue(t *testing.T, metric pdata.Metric, index
 	assert.Equal(t, expectedVal, val)
 }
 
+func AssertDoubleGaugeMetricLabelHasValue(t *testing.T, metric pdata.Metric, index int, labelName string, expectedVal string) {
+	val, ok := metric.DoubleGauge().DataPoints().At(index).LabelsMap().Get(labelName)
+	assert.Truef(t, ok, "Missing label %q in metric %q", labelName, metric.Name())
+	assert.Equal(t, expectedVal, val)
+}
+
 func AssertIntSumMetricLabelExists(t *testing.T, metric pdata.Metric, index int, labelName string) {
 	_, ok := metric.IntSum().DataPoints().At(index).LabelsMap().Get(labelName)
 	assert.Truef(t, ok, "Missing label %q in metric %q", labelName, metric.Name())
@@ -63,6 +69,11 @@ func AssertDoubleSumMetricLabelExists(t *testing.T, metric pdata.Metric, index i
 	assert.Truef(t, ok, "Missing label %q in metric %q", labelName, metric.Name())
 }
 
+func AssertDoubleGaugeMetricLabelExists(t *testing.T, metric pdata.Metric, index int, labelName string) {
+	_, ok := metric.DoubleGauge().DataPoints().At(index).LabelsMap().Get(labelName)
+	assert.Truef(t, ok, "Missing label %q in metric %q", labelName, metric.Name())
+}
+
 func AssertIntSumMetricStartTimeEquals(t *testing.T, metric pdata.Metric, startTime pdata.Timestamp) {
 	idps := metric.IntSum().DataPoints()
 	for i := 0; i < idps.Len(); i++ {
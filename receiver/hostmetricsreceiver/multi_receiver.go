@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// multiReceiver starts and shuts down the scraper controllers of the scrapers
+// configured with different collection intervals as a single receiver.
+type multiReceiver struct {
+	receivers []component.Receiver
+}
+
+var _ component.MetricsReceiver = (*multiReceiver)(nil)
+
+// Start starts all the scraper controllers.
+func (mr *multiReceiver) Start(ctx context.Context, host component.Host) error {
+	for _, receiver := range mr.receivers {
+		if err := receiver.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown shuts down all the scraper controllers, including the ones not
+// started if one of them failed to start.
+func (mr *multiReceiver) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, receiver := range mr.receivers {
+		if err := receiver.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.CombineErrors(errs)
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/resourcedetection"
+)
+
+// resourceConsumer inserts the resource attributes of the host into all the
+// scraped metrics before passing them to the next consumer. The attributes
+// are detected when the first metrics are consumed, so that the requests to
+// the cloud metadata services do not delay the start of the collector.
+type resourceConsumer struct {
+	next   consumer.MetricsConsumer
+	logger *zap.Logger
+	config ResourceAttributesConfig
+
+	detectOnce sync.Once
+	attributes pdata.AttributeMap
+}
+
+var _ consumer.MetricsConsumer = (*resourceConsumer)(nil)
+
+func newResourceConsumer(next consumer.MetricsConsumer, logger *zap.Logger, config ResourceAttributesConfig) *resourceConsumer {
+	return &resourceConsumer{next: next, logger: logger, config: config}
+}
+
+// ConsumeMetrics inserts the resource attributes of the host, the attributes
+// already set by the scrapers are kept.
+func (rc *resourceConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	rc.detectOnce.Do(func() {
+		var err error
+		rc.attributes, err = resourcedetection.Detect(context.Background(), rc.config.Detectors, rc.config.Timeout)
+		if err != nil {
+			rc.logger.Warn("Failed to detect some resource attributes of the host", zap.Error(err))
+		}
+	})
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		resourceAttributes := rms.At(i).Resource().Attributes()
+		rc.attributes.ForEach(func(k string, v pdata.AttributeValue) {
+			resourceAttributes.Insert(k, v)
+		})
+	}
+
+	return rc.next.ConsumeMetrics(ctx, md)
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestResourceConsumer(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	rc := newResourceConsumer(sink, zap.NewNop(), ResourceAttributesConfig{Detectors: []string{"system"}, Timeout: time.Second})
+
+	md := pdata.NewMetrics()
+	rms := md.ResourceMetrics()
+	rms.Resize(2)
+	rms.At(1).Resource().Attributes().InsertString(conventions.AttributeHostName, "scraped")
+
+	require.NoError(t, rc.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+
+	got := sink.AllMetrics()[0].ResourceMetrics()
+	require.Equal(t, 2, got.Len())
+	for i := 0; i < got.Len(); i++ {
+		_, ok := got.At(i).Resource().Attributes().Get(conventions.AttributeOSType)
+		assert.True(t, ok)
+	}
+
+	// the attributes set by the scrapers are kept
+	hostName, ok := got.At(1).Resource().Attributes().Get(conventions.AttributeHostName)
+	require.True(t, ok)
+	assert.Equal(t, "scraped", hostName.StringVal())
+}
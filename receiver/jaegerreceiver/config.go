@@ -15,6 +15,8 @@
 package jaegerreceiver
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -33,8 +35,13 @@ const (
 
 // RemoteSamplingConfig defines config key for remote sampling fetch endpoint
 type RemoteSamplingConfig struct {
-	HostEndpoint                  string `mapstructure:"host_endpoint"`
-	StrategyFile                  string `mapstructure:"strategy_file"`
+	HostEndpoint string `mapstructure:"host_endpoint"`
+	// StrategyFile is the path or the http(s) URL of the sampling strategies served
+	// by the receiver, instead of proxying the requests to the remote collector.
+	StrategyFile string `mapstructure:"strategy_file"`
+	// StrategyReloadInterval is the interval at which the strategy file is reloaded,
+	// 0 disables the reload.
+	StrategyReloadInterval        time.Duration `mapstructure:"strategy_reload_interval"`
 	configgrpc.GRPCClientSettings `mapstructure:",squash"`
 }
 
@@ -197,7 +197,7 @@ func createTraceReceiver(
 
 	if remoteSamplingConfig != nil {
 		config.RemoteSamplingClientSettings = remoteSamplingConfig.GRPCClientSettings
-		if len(config.RemoteSamplingClientSettings.Endpoint) == 0 {
+		if len(config.RemoteSamplingClientSettings.Endpoint) == 0 && len(remoteSamplingConfig.StrategyFile) == 0 {
 			config.RemoteSamplingClientSettings.Endpoint = defaultGRPCBindEndpoint
 		}
 
@@ -211,14 +211,13 @@ func createTraceReceiver(
 			}
 		}
 
-		// strategies are served over grpc so if grpc is not enabled and strategies are present return an error
-		if len(remoteSamplingConfig.StrategyFile) != 0 {
-			if config.CollectorGRPCPort == 0 {
-				return nil, fmt.Errorf("strategy file requires the GRPC protocol to be enabled")
-			}
-
-			config.RemoteSamplingStrategyFile = remoteSamplingConfig.StrategyFile
+		if remoteSamplingConfig.StrategyReloadInterval < 0 {
+			return nil, fmt.Errorf("strategy reload interval must not be negative")
 		}
+
+		// strategies of the file are served by the agent HTTP endpoint and, if enabled, over grpc
+		config.RemoteSamplingStrategyFile = remoteSamplingConfig.StrategyFile
+		config.RemoteSamplingStrategyReloadInterval = remoteSamplingConfig.StrategyReloadInterval
 	}
 
 	if (rCfg.Protocols.GRPC == nil && rCfg.Protocols.ThriftHTTP == nil && rCfg.Protocols.ThriftBinary == nil && rCfg.Protocols.ThriftCompact == nil) ||
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -319,7 +320,7 @@ func TestRemoteSamplingConfigPropagation(t *testing.T) {
 	assert.Equal(t, strategyFile, r.(*jReceiver).config.RemoteSamplingStrategyFile)
 }
 
-func TestRemoteSamplingFileRequiresGRPC(t *testing.T) {
+func TestRemoteSamplingFileWithoutGRPC(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
 	rCfg := cfg.(*Config)
@@ -330,7 +331,26 @@ func TestRemoteSamplingFileRequiresGRPC(t *testing.T) {
 		Endpoint: defaultThriftCompactBindEndpoint,
 	}
 	rCfg.RemoteSampling = &RemoteSamplingConfig{
-		StrategyFile: "strategies.json",
+		StrategyFile:           "strategies.json",
+		StrategyReloadInterval: time.Minute,
+	}
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	r, err := factory.CreateTracesReceiver(context.Background(), params, cfg, nil)
+
+	require.NoError(t, err, "create trace receiver should not error")
+	assert.Equal(t, "", r.(*jReceiver).config.RemoteSamplingClientSettings.Endpoint, "strategies should not be proxied")
+	assert.Equal(t, defaultAgentRemoteSamplingHTTPPort, r.(*jReceiver).config.AgentHTTPPort, "agent http port should be default")
+	assert.Equal(t, time.Minute, r.(*jReceiver).config.RemoteSamplingStrategyReloadInterval)
+}
+
+func TestRemoteSamplingNegativeReloadInterval(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	rCfg := cfg.(*Config)
+
+	rCfg.RemoteSampling = &RemoteSamplingConfig{
+		StrategyFile:           "strategies.json",
+		StrategyReloadInterval: -time.Second,
 	}
 	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
 	_, err := factory.CreateTracesReceiver(context.Background(), params, cfg, nil)
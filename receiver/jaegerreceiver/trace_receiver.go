@@ -16,12 +16,14 @@ package jaegerreceiver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"mime"
 	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	apacheThrift "github.com/apache/thrift/lib/go/thrift"
 	"github.com/gorilla/mux"
@@ -33,6 +35,7 @@ import (
 	"github.com/jaegertracing/jaeger/cmd/agent/app/servers/thriftudp"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/handler"
 	collectorSampling "github.com/jaegertracing/jaeger/cmd/collector/app/sampling"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	staticStrategyStore "github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/jaegertracing/jaeger/thrift-gen/agent"
@@ -69,6 +72,8 @@ type configuration struct {
 	AgentHTTPPort                int
 	RemoteSamplingClientSettings configgrpc.GRPCClientSettings
 	RemoteSamplingStrategyFile   string
+
+	RemoteSamplingStrategyReloadInterval time.Duration
 }
 
 // Receiver type is used to receive spans that were originally intended to be sent to Jaeger.
@@ -88,7 +93,9 @@ type jReceiver struct {
 	grpc            *grpc.Server
 	collectorServer *http.Server
 
-	agentSamplingManager *jSamplingConfig.SamplingManager
+	strategyStore strategystore.StrategyStore
+
+	agentSamplingManager configmanager.ClientConfigManager
 	agentProcessors      []processors.Processor
 	agentServer          *http.Server
 
@@ -194,6 +201,10 @@ func (jr *jReceiver) Start(_ context.Context, host component.Host) error {
 
 	var err = componenterror.ErrAlreadyStarted
 	jr.startOnce.Do(func() {
+		if err = jr.startStrategyStore(); err != nil {
+			return
+		}
+
 		if err = jr.startAgent(host); err != nil && err != componenterror.ErrAlreadyStarted {
 			return
 		}
@@ -234,6 +245,10 @@ func (jr *jReceiver) Shutdown(context.Context) error {
 			jr.grpc.Stop()
 			jr.grpc = nil
 		}
+		if closer, ok := jr.strategyStore.(interface{ Close() }); ok {
+			closer.Close()
+		}
+		jr.strategyStore = nil
 		err = consumererror.CombineErrors(errs)
 	})
 
@@ -251,6 +266,7 @@ func consumeTraces(ctx context.Context, batch *jaeger.Batch, consumer consumer.T
 var _ agent.Agent = (*agentHandler)(nil)
 var _ api_v2.CollectorServiceServer = (*jReceiver)(nil)
 var _ configmanager.ClientConfigManager = (*jReceiver)(nil)
+var _ configmanager.ClientConfigManager = strategyStoreManager{}
 
 type agentHandler struct {
 	name         string
@@ -289,6 +305,16 @@ func (jr *jReceiver) GetBaggageRestrictions(ctx context.Context, serviceName str
 	return br, nil
 }
 
+// strategyStoreManager serves the sampling strategies of a strategy store,
+// without proxying the requests to a remote collector.
+type strategyStoreManager struct {
+	strategystore.StrategyStore
+}
+
+func (strategyStoreManager) GetBaggageRestrictions(context.Context, string) ([]*baggage.BaggageRestriction, error) {
+	return nil, errors.New("baggage not implemented")
+}
+
 func (jr *jReceiver) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
 	if c, ok := client.FromGRPC(ctx); ok {
 		ctx = client.NewContext(ctx, c)
@@ -343,8 +369,10 @@ func (jr *jReceiver) startAgent(_ component.Host) error {
 		go processor.Serve()
 	}
 
-	// Start upstream grpc client before serving sampling endpoints over HTTP
-	if jr.config.RemoteSamplingClientSettings.Endpoint != "" {
+	// Serve the strategies of the strategy file, or start upstream grpc client, before serving sampling endpoints over HTTP
+	if jr.config.RemoteSamplingStrategyFile != "" {
+		jr.agentSamplingManager = strategyStoreManager{jr.strategyStore}
+	} else if jr.config.RemoteSamplingClientSettings.Endpoint != "" {
 		grpcOpts, err := jr.config.RemoteSamplingClientSettings.ToDialOptions()
 		if err != nil {
 			jr.logger.Error("Error creating grpc dial options for remote sampling endpoint", zap.Error(err))
@@ -372,6 +400,23 @@ func (jr *jReceiver) startAgent(_ component.Host) error {
 	return nil
 }
 
+// startStrategyStore loads the sampling strategies served over grpc and by the agent HTTP endpoint.
+func (jr *jReceiver) startStrategyStore() error {
+	if jr.config.RemoteSamplingStrategyFile == "" && !jr.collectorGRPCEnabled() {
+		return nil
+	}
+
+	ss, err := staticStrategyStore.NewStrategyStore(staticStrategyStore.Options{
+		StrategiesFile: jr.config.RemoteSamplingStrategyFile,
+		ReloadInterval: jr.config.RemoteSamplingStrategyReloadInterval,
+	}, jr.logger)
+	if err != nil {
+		return fmt.Errorf("failed to create collector strategy store: %v", err)
+	}
+	jr.strategyStore = ss
+	return nil
+}
+
 func (jr *jReceiver) buildProcessor(address string, cfg ServerConfigUDP, factory apacheThrift.TProtocolFactory, a agent.Agent) (processors.Processor, error) {
 	handler := agent.NewAgentProcessor(a)
 	transport, err := thriftudp.NewTUDPServerTransport(address)
@@ -486,14 +531,7 @@ func (jr *jReceiver) startCollector(host component.Host) error {
 
 		api_v2.RegisterCollectorServiceServer(jr.grpc, jr)
 
-		// init and register sampling strategy store
-		ss, gerr := staticStrategyStore.NewStrategyStore(staticStrategyStore.Options{
-			StrategiesFile: jr.config.RemoteSamplingStrategyFile,
-		}, jr.logger)
-		if gerr != nil {
-			return fmt.Errorf("failed to create collector strategy store: %v", gerr)
-		}
-		api_v2.RegisterSamplingManagerServer(jr.grpc, collectorSampling.NewGRPCHandler(ss))
+		api_v2.RegisterSamplingManagerServer(jr.grpc, collectorSampling.NewGRPCHandler(jr.strategyStore))
 
 		go func() {
 			if err := jr.grpc.Serve(gln); err != nil {
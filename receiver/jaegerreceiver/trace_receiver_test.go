@@ -24,7 +24,9 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -474,6 +476,51 @@ func TestSamplingFailsOnBadFile(t *testing.T) {
 	assert.Error(t, jr.Start(context.Background(), componenttest.NewNopHost()))
 }
 
+func TestSamplingStrategyFileHTTP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strategies")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	strategyFile := path.Join(dir, "strategies.json")
+	require.NoError(t, ioutil.WriteFile(strategyFile, []byte(`{"default_strategy": {"type": "probabilistic", "param": 0.5}}`), 0600))
+
+	port := testutil.GetAvailablePort(t)
+	config := &configuration{
+		AgentHTTPPort:                        int(port),
+		RemoteSamplingStrategyFile:           strategyFile,
+		RemoteSamplingStrategyReloadInterval: 10 * time.Millisecond,
+	}
+	sink := new(consumertest.TracesSink)
+
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	jr := newJaegerReceiver(jaegerReceiver, config, sink, params)
+	defer jr.Shutdown(context.Background())
+
+	require.NoError(t, jr.Start(context.Background(), componenttest.NewNopHost()))
+
+	getStrategy := func() string {
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d/sampling?service=foo", port))
+		if err != nil {
+			return ""
+		}
+		defer resp.Body.Close()
+		bodyBytes, err := ioutil.ReadAll(resp.Body)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			return ""
+		}
+		return string(bodyBytes)
+	}
+	assert.Eventually(t, func() bool {
+		return strings.Contains(getStrategy(), `"probabilisticSampling":{"samplingRate":0.5}`)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The modified strategy file is reloaded.
+	require.NoError(t, ioutil.WriteFile(strategyFile, []byte(`{"service_strategies": [{"service": "foo", "type": "ratelimiting", "param": 5}]}`), 0600))
+	assert.Eventually(t, func() bool {
+		return strings.Contains(getStrategy(), `"rateLimitingSampling":{"maxTracesPerSecond":5}`)
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
 func TestSamplingStrategiesMutualTLS(t *testing.T) {
 	caPath := path.Join(".", "testdata", "ca.crt")
 	serverCertPath := path.Join(".", "testdata", "server.crt")
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const clusterScraperName = "cluster"
+
+// The values of the k8s.pod.phase metric.
+var podPhaseValues = map[corev1.PodPhase]int64{
+	corev1.PodPending:   1,
+	corev1.PodRunning:   2,
+	corev1.PodSucceeded: 3,
+	corev1.PodFailed:    4,
+	corev1.PodUnknown:   5,
+}
+
+// The values of the k8s.node.condition_* metrics.
+var nodeConditionValues = map[corev1.ConditionStatus]int64{
+	corev1.ConditionTrue:    1,
+	corev1.ConditionFalse:   0,
+	corev1.ConditionUnknown: -1,
+}
+
+// clusterScraper scrapes the metrics of the objects of the cluster from the caches of informers watching the
+// Kubernetes API.
+type clusterScraper struct {
+	config     *Config
+	logger     *zap.Logger
+	makeClient makeClient
+
+	deployments appslisters.DeploymentLister
+	nodes       corelisters.NodeLister
+	pods        corelisters.PodLister
+	synced      []cache.InformerSynced
+	stopCh      chan struct{}
+}
+
+func newClusterScraper(config *Config, logger *zap.Logger, makeClient makeClient) *clusterScraper {
+	return &clusterScraper{
+		config:     config,
+		logger:     logger,
+		makeClient: makeClient,
+	}
+}
+
+func (cs *clusterScraper) start(context.Context, component.Host) error {
+	client, err := cs.makeClient(cs.config.AuthType)
+	if err != nil {
+		return err
+	}
+
+	factory := informers.NewSharedInformerFactory(client, 0)
+	deployments := factory.Apps().V1().Deployments()
+	nodes := factory.Core().V1().Nodes()
+	pods := factory.Core().V1().Pods()
+	cs.deployments = deployments.Lister()
+	cs.nodes = nodes.Lister()
+	cs.pods = pods.Lister()
+	cs.synced = []cache.InformerSynced{
+		deployments.Informer().HasSynced,
+		nodes.Informer().HasSynced,
+		pods.Informer().HasSynced,
+	}
+
+	cs.stopCh = make(chan struct{})
+	factory.Start(cs.stopCh)
+	return nil
+}
+
+func (cs *clusterScraper) shutdown(context.Context) error {
+	if cs.stopCh != nil {
+		close(cs.stopCh)
+	}
+	return nil
+}
+
+// scrape returns the metrics of the deployments, the nodes and the pods, none until the caches are synchronized with
+// the Kubernetes API.
+func (cs *clusterScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, error) {
+	rms := pdata.NewResourceMetricsSlice()
+	for _, synced := range cs.synced {
+		if !synced() {
+			cs.logger.Debug("Waiting for the synchronization of the Kubernetes objects")
+			return rms, nil
+		}
+	}
+	now := pdata.TimestampFromTime(time.Now())
+
+	deployments, err := cs.deployments.List(labels.Everything())
+	if err != nil {
+		return rms, err
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].Namespace+"/"+deployments[i].Name < deployments[j].Namespace+"/"+deployments[j].Name
+	})
+	for _, deployment := range deployments {
+		appendDeploymentMetrics(rms, deployment, now)
+	}
+
+	nodes, err := cs.nodes.List(labels.Everything())
+	if err != nil {
+		return rms, err
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	for _, node := range nodes {
+		appendNodeMetrics(rms, node, cs.config.NodeConditionsToReport, now)
+	}
+
+	pods, err := cs.pods.List(labels.Everything())
+	if err != nil {
+		return rms, err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Namespace+"/"+pods[i].Name < pods[j].Namespace+"/"+pods[j].Name
+	})
+	for _, pod := range pods {
+		appendPodMetrics(rms, pod, now)
+	}
+	return rms, nil
+}
+
+func appendDeploymentMetrics(rms pdata.ResourceMetricsSlice, deployment *appsv1.Deployment, now pdata.Timestamp) {
+	metrics := appendResourceMetrics(rms,
+		conventions.AttributeK8sNamespace, deployment.Namespace,
+		conventions.AttributeK8sDeployment, deployment.Name,
+		conventions.AttributeK8sDeploymentUID, string(deployment.UID))
+
+	// The number of desired replicas defaults to 1.
+	desired := int64(1)
+	if deployment.Spec.Replicas != nil {
+		desired = int64(*deployment.Spec.Replicas)
+	}
+	appendIntGauge(metrics, "k8s.deployment.desired", "Number of desired pods in this deployment", desired, now)
+	appendIntGauge(metrics, "k8s.deployment.available", "Total number of available pods (ready for at least minReadySeconds) targeted by this deployment", int64(deployment.Status.AvailableReplicas), now)
+}
+
+func appendNodeMetrics(rms pdata.ResourceMetricsSlice, node *corev1.Node, conditionsToReport []string, now pdata.Timestamp) {
+	metrics := appendResourceMetrics(rms,
+		conventions.AttributeK8sNodeName, node.Name,
+		conventions.AttributeK8sNodeUID, string(node.UID))
+
+	for _, conditionType := range conditionsToReport {
+		for _, condition := range node.Status.Conditions {
+			if string(condition.Type) != conditionType {
+				continue
+			}
+			value, ok := nodeConditionValues[condition.Status]
+			if !ok {
+				value = nodeConditionValues[corev1.ConditionUnknown]
+			}
+			appendIntGauge(metrics, "k8s.node.condition_"+toSnakeCase(conditionType),
+				"Condition "+conditionType+" of the node (1: true, 0: false, -1: unknown)", value, now)
+		}
+	}
+}
+
+func appendPodMetrics(rms pdata.ResourceMetricsSlice, pod *corev1.Pod, now pdata.Timestamp) {
+	attrs := []string{
+		conventions.AttributeK8sNamespace, pod.Namespace,
+		conventions.AttributeK8sPod, pod.Name,
+		conventions.AttributeK8sPodUID, string(pod.UID),
+	}
+	if pod.Spec.NodeName != "" {
+		attrs = append(attrs, conventions.AttributeK8sNodeName, pod.Spec.NodeName)
+	}
+	metrics := appendResourceMetrics(rms, attrs...)
+
+	value, ok := podPhaseValues[pod.Status.Phase]
+	if !ok {
+		value = podPhaseValues[corev1.PodUnknown]
+	}
+	appendIntGauge(metrics, "k8s.pod.phase", "Current phase of the pod (1: Pending, 2: Running, 3: Succeeded, 4: Failed, 5: Unknown)", value, now)
+}
+
+// appendResourceMetrics appends resource metrics with the attributes, given as key and value pairs, and returns their
+// metric slice.
+func appendResourceMetrics(rms pdata.ResourceMetricsSlice, attrs ...string) pdata.MetricSlice {
+	rms.Resize(rms.Len() + 1)
+	rm := rms.At(rms.Len() - 1)
+	resourceAttrs := rm.Resource().Attributes()
+	for i := 0; i+1 < len(attrs); i += 2 {
+		resourceAttrs.InsertString(attrs[i], attrs[i+1])
+	}
+	ilms := rm.InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	return ilms.At(0).Metrics()
+}
+
+func appendIntGauge(metrics pdata.MetricSlice, name, description string, value int64, now pdata.Timestamp) {
+	metrics.Resize(metrics.Len() + 1)
+	metric := metrics.At(metrics.Len() - 1)
+	metric.SetName(name)
+	metric.SetDescription(description)
+	metric.SetUnit("1")
+	metric.SetDataType(pdata.MetricDataTypeIntGauge)
+	dps := metric.IntGauge().DataPoints()
+	dps.Resize(1)
+	dps.At(0).SetTimestamp(now)
+	dps.At(0).SetValue(value)
+}
+
+// toSnakeCase converts a condition type, e.g. "MemoryPressure", to snake case, e.g. "memory_pressure".
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func fakeClient(objects ...runtime.Object) (*fake.Clientset, makeClient) {
+	client := fake.NewSimpleClientset(objects...)
+	return client, func(string) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// gaugeValues returns the values of the gauges of the resource metrics.
+func gaugeValues(rm pdata.ResourceMetrics) map[string]int64 {
+	values := make(map[string]int64)
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		values[metrics.At(i).Name()] = metrics.At(i).IntGauge().DataPoints().At(0).Value()
+	}
+	return values
+}
+
+func TestClusterScraper(t *testing.T) {
+	replicas := int32(3)
+	_, makeClient := fakeClient(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "deployment-uid"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: 2},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", UID: "node-uid"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionUnknown},
+				{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1", UID: "pod-uid"},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+	cfg := createDefaultConfig().(*Config)
+	cfg.NodeConditionsToReport = []string{"Ready", "MemoryPressure"}
+	cs := newClusterScraper(cfg, zap.NewNop(), makeClient)
+	require.NoError(t, cs.start(context.Background(), componenttest.NewNopHost()))
+	defer cs.shutdown(context.Background())
+
+	var rms pdata.ResourceMetricsSlice
+	require.Eventually(t, func() bool {
+		var err error
+		rms, err = cs.scrape(context.Background())
+		require.NoError(t, err)
+		return rms.Len() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	deployment := rms.At(0)
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.namespace.name":  pdata.NewAttributeValueString("default"),
+		"k8s.deployment.name": pdata.NewAttributeValueString("web"),
+		"k8s.deployment.uid":  pdata.NewAttributeValueString("deployment-uid"),
+	}, attributesMap(deployment.Resource().Attributes()))
+	assert.Equal(t, map[string]int64{
+		"k8s.deployment.desired":   3,
+		"k8s.deployment.available": 2,
+	}, gaugeValues(deployment))
+
+	node := rms.At(1)
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.node.name": pdata.NewAttributeValueString("node-1"),
+		"k8s.node.uid":  pdata.NewAttributeValueString("node-uid"),
+	}, attributesMap(node.Resource().Attributes()))
+	assert.Equal(t, map[string]int64{
+		"k8s.node.condition_ready":           1,
+		"k8s.node.condition_memory_pressure": -1,
+	}, gaugeValues(node))
+
+	pod := rms.At(2)
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.namespace.name": pdata.NewAttributeValueString("default"),
+		"k8s.pod.name":       pdata.NewAttributeValueString("web-1"),
+		"k8s.pod.uid":        pdata.NewAttributeValueString("pod-uid"),
+		"k8s.node.name":      pdata.NewAttributeValueString("node-1"),
+	}, attributesMap(pod.Resource().Attributes()))
+	assert.Equal(t, map[string]int64{"k8s.pod.phase": 2}, gaugeValues(pod))
+}
+
+func attributesMap(attrs pdata.AttributeMap) map[string]pdata.AttributeValue {
+	m := make(map[string]pdata.AttributeValue)
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = v
+	})
+	return m
+}
+
+func TestToSnakeCase(t *testing.T) {
+	assert.Equal(t, "ready", toSnakeCase("Ready"))
+	assert.Equal(t, "memory_pressure", toSnakeCase("MemoryPressure"))
+	assert.Equal(t, "network_unavailable", toSnakeCase("NetworkUnavailable"))
+}
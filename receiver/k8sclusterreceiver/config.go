@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// Config defines configuration for the Kubernetes cluster receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+
+	// AuthType is how the receiver authenticates to the Kubernetes API: "serviceAccount", with the service account of
+	// the pod it runs in, or "kubeConfig", with the kubeconfig files of the KUBECONFIG environment variable, or
+	// ~/.kube/config if it is not set.
+	AuthType string `mapstructure:"auth_type"`
+
+	// NodeConditionsToReport is the conditions of the nodes reported as metrics, e.g. "Ready" is reported as
+	// k8s.node.condition_ready.
+	NodeConditionsToReport []string `mapstructure:"node_conditions_to_report"`
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	transport = "http"
+	format    = "k8s_event"
+)
+
+// The attributes of the log records of the events.
+const (
+	attributeEventName                = "k8s.event.name"
+	attributeEventUID                 = "k8s.event.uid"
+	attributeEventReason              = "k8s.event.reason"
+	attributeEventAction              = "k8s.event.action"
+	attributeEventCount               = "k8s.event.count"
+	attributeEventReportingController = "k8s.event.reporting_controller"
+	attributeObjectKind               = "k8s.object.kind"
+	attributeObjectName               = "k8s.object.name"
+	attributeObjectUID                = "k8s.object.uid"
+	attributeObjectAPIVersion         = "k8s.object.api_version"
+	attributeObjectFieldPath          = "k8s.object.fieldpath"
+)
+
+// eventsReceiver converts the Kubernetes events to logs.
+type eventsReceiver struct {
+	config     *Config
+	logger     *zap.Logger
+	next       consumer.LogsConsumer
+	makeClient makeClient
+
+	// startTime is the time the receiver started at, the older events are ignored.
+	startTime time.Time
+	stopCh    chan struct{}
+}
+
+func newEventsReceiver(config *Config, logger *zap.Logger, next consumer.LogsConsumer, makeClient makeClient) *eventsReceiver {
+	return &eventsReceiver{
+		config:     config,
+		logger:     logger,
+		next:       next,
+		makeClient: makeClient,
+	}
+}
+
+func (r *eventsReceiver) Start(context.Context, component.Host) error {
+	client, err := r.makeClient(r.config.AuthType)
+	if err != nil {
+		return err
+	}
+	r.startTime = time.Now()
+
+	factory := informers.NewSharedInformerFactory(client, 0)
+	factory.Core().V1().Events().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if event, ok := obj.(*corev1.Event); ok {
+				r.handleEvent(event)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldEvent, ok := oldObj.(*corev1.Event)
+			if !ok {
+				return
+			}
+			// An event occurring again is updated with an incremented count.
+			if newEvent, ok := newObj.(*corev1.Event); ok && newEvent.Count > oldEvent.Count {
+				r.handleEvent(newEvent)
+			}
+		},
+	})
+
+	r.stopCh = make(chan struct{})
+	factory.Start(r.stopCh)
+	return nil
+}
+
+func (r *eventsReceiver) Shutdown(context.Context) error {
+	if r.stopCh != nil {
+		close(r.stopCh)
+	}
+	return nil
+}
+
+func (r *eventsReceiver) handleEvent(event *corev1.Event) {
+	// The events listed when the receiver starts occurred before.
+	if eventTime(event).Before(r.startTime) {
+		return
+	}
+
+	ctx := obsreport.ReceiverContext(context.Background(), r.config.Name(), transport)
+	ctx = obsreport.StartLogsReceiveOp(ctx, r.config.Name(), transport)
+	err := r.next.ConsumeLogs(ctx, eventToLogs(event))
+	obsreport.EndLogsReceiveOp(ctx, format, 1, err)
+	if err != nil {
+		r.logger.Debug("Failed to consume Kubernetes event", zap.String("event", event.Name), zap.Error(err))
+	}
+}
+
+// eventTime returns the time of the last occurrence of the event.
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// eventToLogs converts the event to a log record, whose resource is the object the event is about.
+func eventToLogs(event *corev1.Event) pdata.Logs {
+	logs := pdata.NewLogs()
+	rls := logs.ResourceLogs()
+	rls.Resize(1)
+	rl := rls.At(0)
+
+	resourceAttrs := rl.Resource().Attributes()
+	object := event.InvolvedObject
+	insertNonEmptyString(resourceAttrs, conventions.AttributeK8sNamespace, object.Namespace)
+	insertNonEmptyString(resourceAttrs, attributeObjectKind, object.Kind)
+	insertNonEmptyString(resourceAttrs, attributeObjectName, object.Name)
+	insertNonEmptyString(resourceAttrs, attributeObjectUID, string(object.UID))
+	insertNonEmptyString(resourceAttrs, attributeObjectAPIVersion, object.APIVersion)
+	insertNonEmptyString(resourceAttrs, attributeObjectFieldPath, object.FieldPath)
+
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(1)
+	lrs := ills.At(0).Logs()
+	lrs.Resize(1)
+	lr := lrs.At(0)
+
+	lr.SetTimestamp(pdata.TimestampFromTime(eventTime(event)))
+	lr.SetName(event.Reason)
+	lr.SetSeverityText(event.Type)
+	switch event.Type {
+	case corev1.EventTypeNormal:
+		lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	case corev1.EventTypeWarning:
+		lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	}
+	lr.Body().SetStringVal(event.Message)
+
+	attrs := lr.Attributes()
+	insertNonEmptyString(attrs, attributeEventName, event.Name)
+	insertNonEmptyString(attrs, attributeEventUID, string(event.UID))
+	insertNonEmptyString(attrs, attributeEventReason, event.Reason)
+	insertNonEmptyString(attrs, attributeEventAction, event.Action)
+	if event.Count > 0 {
+		attrs.InsertInt(attributeEventCount, int64(event.Count))
+	}
+	reportingController := event.ReportingController
+	if reportingController == "" {
+		reportingController = event.Source.Component
+	}
+	insertNonEmptyString(attrs, attributeEventReportingController, reportingController)
+	return logs
+}
+
+func insertNonEmptyString(attrs pdata.AttributeMap, key, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
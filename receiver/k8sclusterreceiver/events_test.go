@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestEventsReceiver(t *testing.T) {
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+	client, makeClient := fakeClient(&corev1.Event{
+		ObjectMeta:    metav1.ObjectMeta{Namespace: "default", Name: "old"},
+		LastTimestamp: old,
+	})
+	next := new(consumertest.LogsSink)
+	r := newEventsReceiver(createDefaultConfig().(*Config), zap.NewNop(), next, makeClient)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer r.Shutdown(context.Background())
+
+	now := metav1.NewTime(time.Now().Add(time.Second).Truncate(time.Second))
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1.16", UID: "event-uid"},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:       "Pod",
+			Namespace:  "default",
+			Name:       "web-1",
+			UID:        "pod-uid",
+			APIVersion: "v1",
+			FieldPath:  "spec.containers{web}",
+		},
+		Reason:        "BackOff",
+		Message:       "Back-off restarting failed container",
+		Type:          corev1.EventTypeWarning,
+		Count:         1,
+		LastTimestamp: now,
+		Source:        corev1.EventSource{Component: "kubelet"},
+	}
+	_, err := client.CoreV1().Events("default").Create(context.Background(), event, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return next.LogRecordsCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	rl := next.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.namespace.name":     pdata.NewAttributeValueString("default"),
+		"k8s.object.kind":        pdata.NewAttributeValueString("Pod"),
+		"k8s.object.name":        pdata.NewAttributeValueString("web-1"),
+		"k8s.object.uid":         pdata.NewAttributeValueString("pod-uid"),
+		"k8s.object.api_version": pdata.NewAttributeValueString("v1"),
+		"k8s.object.fieldpath":   pdata.NewAttributeValueString("spec.containers{web}"),
+	}, attributesMap(rl.Resource().Attributes()))
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampFromTime(now.Time), lr.Timestamp())
+	assert.Equal(t, "BackOff", lr.Name())
+	assert.Equal(t, "Warning", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberWARN, lr.SeverityNumber())
+	assert.Equal(t, "Back-off restarting failed container", lr.Body().StringVal())
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.event.name":                 pdata.NewAttributeValueString("web-1.16"),
+		"k8s.event.uid":                  pdata.NewAttributeValueString("event-uid"),
+		"k8s.event.reason":               pdata.NewAttributeValueString("BackOff"),
+		"k8s.event.count":                pdata.NewAttributeValueInt(1),
+		"k8s.event.reporting_controller": pdata.NewAttributeValueString("kubelet"),
+	}, attributesMap(lr.Attributes()))
+
+	// The event occurs again.
+	event.Count = 2
+	_, err = client.CoreV1().Events("default").Update(context.Background(), event, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return next.LogRecordsCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestEventTime(t *testing.T) {
+	t1 := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Minute)
+	assert.Equal(t, t2, eventTime(&corev1.Event{
+		FirstTimestamp: metav1.NewTime(t1),
+		LastTimestamp:  metav1.NewTime(t2),
+	}))
+	assert.Equal(t, t2, eventTime(&corev1.Event{EventTime: metav1.NewMicroTime(t2)}))
+	assert.Equal(t, t1, eventTime(&corev1.Event{FirstTimestamp: metav1.NewTime(t1)}))
+	assert.Equal(t, t1, eventTime(&corev1.Event{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(t1)}}))
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// This file implements factory for the Kubernetes cluster receiver.
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "k8s_cluster"
+
+	defaultCollectionInterval = 10 * time.Second
+)
+
+var defaultNodeConditionsToReport = []string{"Ready"}
+
+// NewFactory creates a factory for the Kubernetes cluster receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
+}
+
+func createDefaultConfig() configmodels.Receiver {
+	scs := scraperhelper.DefaultScraperControllerSettings(typeStr)
+	scs.CollectionInterval = defaultCollectionInterval
+	return &Config{
+		ScraperControllerSettings: scs,
+		AuthType:                  authTypeServiceAccount,
+		NodeConditionsToReport:    defaultNodeConditionsToReport,
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.MetricsConsumer,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	if err := validateAuthType(rCfg.AuthType); err != nil {
+		return nil, err
+	}
+	cs := newClusterScraper(rCfg, params.Logger, newClient)
+	return scraperhelper.NewScraperControllerReceiver(
+		&rCfg.ScraperControllerSettings,
+		params.Logger,
+		consumer,
+		scraperhelper.AddResourceMetricsScraper(scraperhelper.NewResourceMetricsScraper(
+			clusterScraperName,
+			cs.scrape,
+			scraperhelper.WithStart(cs.start),
+			scraperhelper.WithShutdown(cs.shutdown))))
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	rCfg := cfg.(*Config)
+	if err := validateAuthType(rCfg.AuthType); err != nil {
+		return nil, err
+	}
+	return newEventsReceiver(rCfg, params.Logger, consumer, newClient), nil
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateReceiver(t *testing.T) {
+	factory := NewFactory()
+	require.Equal(t, configmodels.Type("k8s_cluster"), factory.Type())
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	cfg := factory.CreateDefaultConfig()
+	mr, err := factory.CreateMetricsReceiver(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, mr)
+	lr, err := factory.CreateLogsReceiver(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, lr)
+
+	// The receivers cannot start outside of a cluster.
+	assert.Error(t, mr.Start(context.Background(), nil))
+	assert.Error(t, lr.Start(context.Background(), nil))
+
+	cfg.(*Config).AuthType = "token"
+	_, err = factory.CreateMetricsReceiver(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.EqualError(t, err, `invalid auth_type "token", expecting "serviceAccount" or "kubeConfig"`)
+	_, err = factory.CreateLogsReceiver(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.EqualError(t, err, `invalid auth_type "token", expecting "serviceAccount" or "kubeConfig"`)
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlreceiver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// client queries the status of a MySQL server.
+type client interface {
+	// globalStatus returns the global status variables of the server, by name.
+	globalStatus(ctx context.Context) (map[string]string, error)
+	// replicaStatus returns the replication channels of the server, each one by column, none when the server is not a
+	// replica. The NULL columns are empty.
+	replicaStatus(ctx context.Context) ([]map[string]string, error)
+	Close() error
+}
+
+// makeClient creates the client of the configured server.
+type makeClient func(cfg *Config) (client, error)
+
+type mySQLClient struct {
+	db *sql.DB
+}
+
+var _ client = (*mySQLClient)(nil)
+
+func newClient(cfg *Config) (client, error) {
+	mc := mysql.NewConfig()
+	mc.User = cfg.Username
+	mc.Passwd = cfg.Password
+	mc.Net = cfg.Transport
+	mc.Addr = strings.TrimPrefix(cfg.Endpoint, "unix://")
+	if strings.HasPrefix(cfg.Endpoint, "unix://") {
+		mc.Net = "unix"
+	}
+
+	// The driver only accepts the TLS configurations registered under a name, and clones them when the connector is
+	// created: the configuration is registered just for the creation, under the unique name of the receiver.
+	if cfg.TLSSetting != nil {
+		tlsCfg, err := cfg.TLSSetting.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		if tlsCfg != nil {
+			mc.TLSConfig = "otelcol-" + cfg.Name()
+			if err = mysql.RegisterTLSConfig(mc.TLSConfig, tlsCfg); err != nil {
+				return nil, err
+			}
+			defer mysql.DeregisterTLSConfig(mc.TLSConfig)
+		}
+	}
+
+	connector, err := mysql.NewConnector(mc)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(connector)
+	// The queries of a scrape are sequential.
+	db.SetMaxOpenConns(1)
+	return &mySQLClient{db: db}, nil
+}
+
+func (c *mySQLClient) globalStatus(ctx context.Context) (map[string]string, error) {
+	rows, err := c.db.QueryContext(ctx, "SHOW GLOBAL STATUS")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the global status: %w", err)
+	}
+	defer rows.Close()
+
+	status := make(map[string]string)
+	for rows.Next() {
+		var name, value string
+		if err = rows.Scan(&name, &value); err != nil {
+			return nil, fmt.Errorf("failed to read the global status: %w", err)
+		}
+		status[name] = value
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the global status: %w", err)
+	}
+	return status, nil
+}
+
+func (c *mySQLClient) replicaStatus(ctx context.Context) ([]map[string]string, error) {
+	rows, err := c.db.QueryContext(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the replica status: %w", err)
+	}
+	defer rows.Close()
+
+	// The columns depend on the version of the server.
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the replica status: %w", err)
+	}
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	var channels []map[string]string
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to read the replica status: %w", err)
+		}
+		channel := make(map[string]string, len(columns))
+		for i, column := range columns {
+			channel[column] = string(values[i])
+		}
+		channels = append(channels, channel)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the replica status: %w", err)
+	}
+	return channels, nil
+}
+
+func (c *mySQLClient) Close() error {
+	return c.db.Close()
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		tlsSetting *configtls.TLSClientSetting
+		wantErr    bool
+	}{
+		{
+			name: "plaintext",
+		},
+		{
+			name: "tls",
+			tlsSetting: &configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: "../../config/configtls/testdata/testCA.pem"},
+			},
+		},
+		{
+			name: "invalid ca file",
+			tlsSetting: &configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: "../../config/configtls/testdata/testCA-bad.txt"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.TLSSetting = tt.tlsSetting
+			c, err := newClient(cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, c.Close())
+		})
+	}
+}
+
+func TestNewClientUnixSocket(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "unix:///var/run/mysqld/mysqld.sock"
+	c, err := newClient(cfg)
+	require.NoError(t, err)
+	assert.NoError(t, c.Close())
+}
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlreceiver
+
+import (
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// Config defines configuration for the MySQL receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+
+	// NetAddr is the address of the server: a "host:port" endpoint with the "tcp" transport, or the path of a Unix
+	// domain socket with the "unix" transport.
+	confignet.NetAddr `mapstructure:",squash"`
+
+	// Username and Password are the credentials of the user the receiver connects as.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// TLSSetting configures the TLS connections to the server, which are not encrypted when it is nil.
+	TLSSetting *configtls.TLSClientSetting `mapstructure:"tls_settings"`
+}
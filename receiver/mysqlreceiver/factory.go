@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlreceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// This file implements factory for the MySQL receiver.
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "mysql"
+
+	defaultEndpoint           = "localhost:3306"
+	defaultTransport          = "tcp"
+	defaultCollectionInterval = 10 * time.Second
+)
+
+// NewFactory creates a factory for the MySQL receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithMetrics(createMetricsReceiver))
+}
+
+func createDefaultConfig() configmodels.Receiver {
+	scs := scraperhelper.DefaultScraperControllerSettings(typeStr)
+	scs.CollectionInterval = defaultCollectionInterval
+	return &Config{
+		ScraperControllerSettings: scs,
+		NetAddr: confignet.NetAddr{
+			Endpoint:  defaultEndpoint,
+			Transport: defaultTransport,
+		},
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.MetricsConsumer,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	if rCfg.Transport != "tcp" && rCfg.Transport != "unix" {
+		return nil, fmt.Errorf("invalid transport %q, expecting %q or %q", rCfg.Transport, "tcp", "unix")
+	}
+
+	ms := newMySQLScraper(rCfg, newClient)
+	return scraperhelper.NewScraperControllerReceiver(
+		&rCfg.ScraperControllerSettings,
+		params.Logger,
+		consumer,
+		scraperhelper.AddResourceMetricsScraper(scraperhelper.NewResourceMetricsScraper(
+			typeStr,
+			ms.scrape,
+			scraperhelper.WithStart(ms.start),
+			scraperhelper.WithShutdown(ms.shutdown))))
+}
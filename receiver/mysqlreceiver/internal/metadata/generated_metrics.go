@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Type is the component type name.
+const Type configmodels.Type = "mysqlreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	MysqlBufferPoolOperations MetricIntf
+	MysqlBufferPoolPages      MetricIntf
+	MysqlCommands             MetricIntf
+	MysqlConnections          MetricIntf
+	MysqlQueries              MetricIntf
+	MysqlReplicaLag           MetricIntf
+	MysqlSlowQueries          MetricIntf
+	MysqlThreads              MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"mysql.buffer_pool.operations",
+		"mysql.buffer_pool.pages",
+		"mysql.commands",
+		"mysql.connections",
+		"mysql.queries",
+		"mysql.replica.lag",
+		"mysql.slow_queries",
+		"mysql.threads",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"mysql.buffer_pool.operations": Metrics.MysqlBufferPoolOperations,
+	"mysql.buffer_pool.pages":      Metrics.MysqlBufferPoolPages,
+	"mysql.commands":               Metrics.MysqlCommands,
+	"mysql.connections":            Metrics.MysqlConnections,
+	"mysql.queries":                Metrics.MysqlQueries,
+	"mysql.replica.lag":            Metrics.MysqlReplicaLag,
+	"mysql.slow_queries":           Metrics.MysqlSlowQueries,
+	"mysql.threads":                Metrics.MysqlThreads,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+func (m *metricStruct) FactoriesByName() map[string]func() pdata.Metric {
+	return map[string]func() pdata.Metric{
+		Metrics.MysqlBufferPoolOperations.Name(): Metrics.MysqlBufferPoolOperations.New,
+		Metrics.MysqlBufferPoolPages.Name():      Metrics.MysqlBufferPoolPages.New,
+		Metrics.MysqlCommands.Name():             Metrics.MysqlCommands.New,
+		Metrics.MysqlConnections.Name():          Metrics.MysqlConnections.New,
+		Metrics.MysqlQueries.Name():              Metrics.MysqlQueries.New,
+		Metrics.MysqlReplicaLag.Name():           Metrics.MysqlReplicaLag.New,
+		Metrics.MysqlSlowQueries.Name():          Metrics.MysqlSlowQueries.New,
+		Metrics.MysqlThreads.Name():              Metrics.MysqlThreads.New,
+	}
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"mysql.buffer_pool.operations",
+		func(metric pdata.Metric) {
+			metric.SetName("mysql.buffer_pool.operations")
+			metric.SetDescription("Total number of operations on the InnoDB buffer pool. The reads are the read requests which could not be satisfied from the buffer pool and were read from the disk.")
+			metric.SetUnit("{operations}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mysql.buffer_pool.pages",
+		func(metric pdata.Metric) {
+			metric.SetName("mysql.buffer_pool.pages")
+			metric.SetDescription("Current number of pages of the InnoDB buffer pool by state. The dirty pages are data pages which have not been flushed to the disk yet.")
+			metric.SetUnit("{pages}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"mysql.commands",
+		func(metric pdata.Metric) {
+			metric.SetName("mysql.commands")
+			metric.SetDescription("Total number of statements executed by command.")
+			metric.SetUnit("{commands}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mysql.connections",
+		func(metric pdata.Metric) {
+			metric.SetName("mysql.connections")
+			metric.SetDescription("Total number of connection attempts, successful or not, to the server.")
+			metric.SetUnit("{connections}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mysql.queries",
+		func(metric pdata.Metric) {
+			metric.SetName("mysql.queries")
+			metric.SetDescription("Total number of statements executed by the server, including the statements of the stored programs.")
+			metric.SetUnit("{queries}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mysql.replica.lag",
+		func(metric pdata.Metric) {
+			metric.SetName("mysql.replica.lag")
+			metric.SetDescription("Number of seconds the replica is behind its source, reported when the server is a replica whose SQL thread is running.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"mysql.slow_queries",
+		func(metric pdata.Metric) {
+			metric.SetName("mysql.slow_queries")
+			metric.SetDescription("Total number of queries which took more than long_query_time seconds.")
+			metric.SetUnit("{queries}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mysql.threads",
+		func(metric pdata.Metric) {
+			metric.SetName("mysql.threads")
+			metric.SetDescription("Current number of threads by state. The running threads are the connected threads which are not sleeping.")
+			metric.SetUnit("{threads}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// Command (The command of the statements.)
+	Command string
+	// Operation (The operation on the buffer pool.)
+	Operation string
+	// PageState (The state of the buffer pool pages.)
+	PageState string
+	// ThreadState (The state of the threads.)
+	ThreadState string
+}{
+	"command",
+	"operation",
+	"state",
+	"state",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
+
+// LabelCommand are the possible values that the label "command" can have.
+var LabelCommand = struct {
+	Delete string
+	Insert string
+	Select string
+	Update string
+}{
+	"delete",
+	"insert",
+	"select",
+	"update",
+}
+
+// LabelOperation are the possible values that the label "operation" can have.
+var LabelOperation = struct {
+	ReadRequests  string
+	Reads         string
+	WriteRequests string
+}{
+	"read_requests",
+	"reads",
+	"write_requests",
+}
+
+// LabelPageState are the possible values that the label "page_state" can have.
+var LabelPageState = struct {
+	Data  string
+	Dirty string
+	Free  string
+	Misc  string
+}{
+	"data",
+	"dirty",
+	"free",
+	"misc",
+}
+
+// LabelThreadState are the possible values that the label "thread_state" can have.
+var LabelThreadState = struct {
+	Cached    string
+	Connected string
+	Running   string
+}{
+	"cached",
+	"connected",
+	"running",
+}
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlreceiver
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/mysqlreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+// attributeInstanceEndpoint is the resource attribute identifying the server the metrics are scraped from.
+const attributeInstanceEndpoint = "mysql.instance.endpoint"
+
+// statusVariable is a global status variable reported as a data point, with the value of the label of the metric.
+type statusVariable struct {
+	labelValue string
+	name       string
+}
+
+// statusMetric is a metric whose data points are global status variables.
+type statusMetric struct {
+	descriptor metadata.MetricIntf
+	label      string
+	variables  []statusVariable
+}
+
+var statusMetrics = []statusMetric{
+	{metadata.Metrics.MysqlBufferPoolOperations, metadata.Labels.Operation, []statusVariable{
+		{metadata.LabelOperation.ReadRequests, "Innodb_buffer_pool_read_requests"},
+		{metadata.LabelOperation.Reads, "Innodb_buffer_pool_reads"},
+		{metadata.LabelOperation.WriteRequests, "Innodb_buffer_pool_write_requests"},
+	}},
+	{metadata.Metrics.MysqlBufferPoolPages, metadata.Labels.PageState, []statusVariable{
+		{metadata.LabelPageState.Data, "Innodb_buffer_pool_pages_data"},
+		{metadata.LabelPageState.Dirty, "Innodb_buffer_pool_pages_dirty"},
+		{metadata.LabelPageState.Free, "Innodb_buffer_pool_pages_free"},
+		{metadata.LabelPageState.Misc, "Innodb_buffer_pool_pages_misc"},
+	}},
+	{metadata.Metrics.MysqlCommands, metadata.Labels.Command, []statusVariable{
+		{metadata.LabelCommand.Delete, "Com_delete"},
+		{metadata.LabelCommand.Insert, "Com_insert"},
+		{metadata.LabelCommand.Select, "Com_select"},
+		{metadata.LabelCommand.Update, "Com_update"},
+	}},
+	{metadata.Metrics.MysqlConnections, "", []statusVariable{{"", "Connections"}}},
+	{metadata.Metrics.MysqlQueries, "", []statusVariable{{"", "Queries"}}},
+	{metadata.Metrics.MysqlSlowQueries, "", []statusVariable{{"", "Slow_queries"}}},
+	{metadata.Metrics.MysqlThreads, metadata.Labels.ThreadState, []statusVariable{
+		{metadata.LabelThreadState.Cached, "Threads_cached"},
+		{metadata.LabelThreadState.Connected, "Threads_connected"},
+		{metadata.LabelThreadState.Running, "Threads_running"},
+	}},
+}
+
+type mySQLScraper struct {
+	cfg        *Config
+	makeClient makeClient
+	client     client
+}
+
+func newMySQLScraper(cfg *Config, makeClient makeClient) *mySQLScraper {
+	return &mySQLScraper{cfg: cfg, makeClient: makeClient}
+}
+
+func (ms *mySQLScraper) start(context.Context, component.Host) error {
+	client, err := ms.makeClient(ms.cfg)
+	if err != nil {
+		return err
+	}
+	ms.client = client
+	return nil
+}
+
+func (ms *mySQLScraper) shutdown(context.Context) error {
+	if ms.client == nil {
+		return nil
+	}
+	return ms.client.Close()
+}
+
+func (ms *mySQLScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	rms := pdata.NewResourceMetricsSlice()
+	status, err := ms.client.globalStatus(ctx)
+	if err != nil {
+		return rms, err
+	}
+
+	rms.Resize(1)
+	rm := rms.At(0)
+	rm.Resource().Attributes().InsertString(attributeInstanceEndpoint, ms.cfg.Endpoint)
+	ilms := rm.InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+
+	now := time.Now()
+	// The cumulative metrics are counted since the server started.
+	var startTime pdata.Timestamp
+	if uptime, err := strconv.ParseInt(status["Uptime"], 10, 64); err == nil {
+		startTime = pdata.TimestampFromTime(now.Add(-time.Duration(uptime) * time.Second))
+	}
+	timestamp := pdata.TimestampFromTime(now)
+
+	for _, sm := range statusMetrics {
+		metric := pdata.NewMetric()
+		sm.descriptor.Init(metric)
+		for _, variable := range sm.variables {
+			// The variables of the disabled features, e.g. the InnoDB storage engine, are missing.
+			value, err := strconv.ParseInt(status[variable.name], 10, 64)
+			if err != nil {
+				continue
+			}
+			appendDataPoint(metric, startTime, timestamp, sm.label, variable.labelValue, value)
+		}
+		if dataPointCount(metric) > 0 {
+			metrics.Append(metric)
+		}
+	}
+
+	var errs scrapererror.ScrapeErrors
+	channels, err := ms.client.replicaStatus(ctx)
+	if err != nil {
+		errs.AddPartial(1, err)
+	} else if lag, ok := replicaLag(channels); ok {
+		metric := pdata.NewMetric()
+		metadata.Metrics.MysqlReplicaLag.Init(metric)
+		appendDataPoint(metric, startTime, timestamp, "", "", lag)
+		metrics.Append(metric)
+	}
+	return rms, errs.Combine()
+}
+
+// replicaLag returns the largest lag of the replication channels whose SQL thread is running, the column being named
+// after the source since MySQL 8.0.22.
+func replicaLag(channels []map[string]string) (int64, bool) {
+	var lag int64
+	found := false
+	for _, channel := range channels {
+		for _, column := range []string{"Seconds_Behind_Master", "Seconds_Behind_Source"} {
+			value, err := strconv.ParseInt(channel[column], 10, 64)
+			if err != nil {
+				continue
+			}
+			if !found || value > lag {
+				lag = value
+			}
+			found = true
+		}
+	}
+	return lag, found
+}
+
+func appendDataPoint(metric pdata.Metric, startTime, timestamp pdata.Timestamp, label, labelValue string, value int64) {
+	var dps pdata.IntDataPointSlice
+	if metric.DataType() == pdata.MetricDataTypeIntSum {
+		dps = metric.IntSum().DataPoints()
+	} else {
+		dps = metric.IntGauge().DataPoints()
+		startTime = 0
+	}
+	dps.Resize(dps.Len() + 1)
+	dp := dps.At(dps.Len() - 1)
+	if label != "" {
+		dp.LabelsMap().Insert(label, labelValue)
+	}
+	dp.SetStartTime(startTime)
+	dp.SetTimestamp(timestamp)
+	dp.SetValue(value)
+}
+
+func dataPointCount(metric pdata.Metric) int {
+	if metric.DataType() == pdata.MetricDataTypeIntSum {
+		return metric.IntSum().DataPoints().Len()
+	}
+	return metric.IntGauge().DataPoints().Len()
+}
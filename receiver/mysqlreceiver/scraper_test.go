@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+type fakeClient struct {
+	status     map[string]string
+	statusErr  error
+	channels   []map[string]string
+	replicaErr error
+	closed     bool
+}
+
+func (c *fakeClient) globalStatus(context.Context) (map[string]string, error) {
+	return c.status, c.statusErr
+}
+
+func (c *fakeClient) replicaStatus(context.Context) ([]map[string]string, error) {
+	return c.channels, c.replicaErr
+}
+
+func (c *fakeClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+var testStatus = map[string]string{
+	"Uptime":                            "3600",
+	"Connections":                       "1024",
+	"Queries":                           "32768",
+	"Slow_queries":                      "12",
+	"Com_delete":                        "10",
+	"Com_insert":                        "200",
+	"Com_select":                        "30000",
+	"Com_update":                        "400",
+	"Threads_cached":                    "8",
+	"Threads_connected":                 "25",
+	"Threads_running":                   "3",
+	"Innodb_buffer_pool_pages_data":     "7000",
+	"Innodb_buffer_pool_pages_dirty":    "42",
+	"Innodb_buffer_pool_pages_free":     "1000",
+	"Innodb_buffer_pool_pages_misc":     "192",
+	"Innodb_buffer_pool_read_requests":  "900000",
+	"Innodb_buffer_pool_reads":          "1500",
+	"Innodb_buffer_pool_write_requests": "60000",
+	"Aborted_clients":                   "1",
+}
+
+func startTestScraper(t *testing.T, c *fakeClient) *mySQLScraper {
+	ms := newMySQLScraper(createDefaultConfig().(*Config), func(*Config) (client, error) {
+		return c, nil
+	})
+	require.NoError(t, ms.start(context.Background(), componenttest.NewNopHost()))
+	return ms
+}
+
+// metricValues returns the values of the metrics, by name and label value.
+func metricValues(metrics pdata.MetricSlice) map[string]int64 {
+	values := make(map[string]int64)
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		var dps pdata.IntDataPointSlice
+		if metric.DataType() == pdata.MetricDataTypeIntSum {
+			dps = metric.IntSum().DataPoints()
+		} else {
+			dps = metric.IntGauge().DataPoints()
+		}
+		for j := 0; j < dps.Len(); j++ {
+			key := metric.Name()
+			dps.At(j).LabelsMap().ForEach(func(_ string, v string) {
+				key += "/" + v
+			})
+			values[key] = dps.At(j).Value()
+		}
+	}
+	return values
+}
+
+func TestScrape(t *testing.T) {
+	c := &fakeClient{
+		status: testStatus,
+		channels: []map[string]string{
+			{"Channel_Name": "", "Seconds_Behind_Master": "5"},
+			{"Channel_Name": "analytics", "Seconds_Behind_Master": "12"},
+			{"Channel_Name": "stopped", "Seconds_Behind_Master": ""},
+		},
+	}
+	ms := startTestScraper(t, c)
+
+	rms, err := ms.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, rms.Len())
+	endpoint, ok := rms.At(0).Resource().Attributes().Get(attributeInstanceEndpoint)
+	require.True(t, ok)
+	assert.Equal(t, "localhost:3306", endpoint.StringVal())
+
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Equal(t, map[string]int64{
+		"mysql.buffer_pool.operations/read_requests":  900000,
+		"mysql.buffer_pool.operations/reads":          1500,
+		"mysql.buffer_pool.operations/write_requests": 60000,
+		"mysql.buffer_pool.pages/data":                7000,
+		"mysql.buffer_pool.pages/dirty":               42,
+		"mysql.buffer_pool.pages/free":                1000,
+		"mysql.buffer_pool.pages/misc":                192,
+		"mysql.commands/delete":                       10,
+		"mysql.commands/insert":                       200,
+		"mysql.commands/select":                       30000,
+		"mysql.commands/update":                       400,
+		"mysql.connections":                           1024,
+		"mysql.queries":                               32768,
+		"mysql.slow_queries":                          12,
+		"mysql.threads/cached":                        8,
+		"mysql.threads/connected":                     25,
+		"mysql.threads/running":                       3,
+		"mysql.replica.lag":                           12,
+	}, metricValues(metrics))
+
+	// The cumulative metrics start when the server started.
+	connections := metrics.At(3).IntSum().DataPoints().At(0)
+	assert.Equal(t, "mysql.connections", metrics.At(3).Name())
+	assert.Equal(t, 3600*1e9, float64(connections.Timestamp()-connections.StartTime()))
+
+	require.NoError(t, ms.shutdown(context.Background()))
+	assert.True(t, c.closed)
+}
+
+func TestScrapeMissingVariables(t *testing.T) {
+	// Without InnoDB, nor replication.
+	ms := startTestScraper(t, &fakeClient{status: map[string]string{
+		"Connections":       "1",
+		"Queries":           "2",
+		"Threads_connected": "1",
+	}})
+
+	rms, err := ms.scrape(context.Background())
+	require.NoError(t, err)
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Equal(t, map[string]int64{
+		"mysql.connections":       1,
+		"mysql.queries":           2,
+		"mysql.threads/connected": 1,
+	}, metricValues(metrics))
+	assert.Equal(t, pdata.Timestamp(0), metrics.At(0).IntSum().DataPoints().At(0).StartTime())
+}
+
+func TestScrapeErrors(t *testing.T) {
+	ms := startTestScraper(t, &fakeClient{statusErr: errors.New("access denied")})
+	rms, err := ms.scrape(context.Background())
+	assert.EqualError(t, err, "access denied")
+	assert.Equal(t, 0, rms.Len())
+
+	ms = startTestScraper(t, &fakeClient{status: testStatus, replicaErr: errors.New("access denied")})
+	rms, err = ms.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.NotContains(t, metricValues(metrics), "mysql.replica.lag")
+	assert.Contains(t, metricValues(metrics), "mysql.connections")
+}
+
+func TestStartError(t *testing.T) {
+	ms := newMySQLScraper(createDefaultConfig().(*Config), func(*Config) (client, error) {
+		return nil, errors.New("invalid config")
+	})
+	assert.EqualError(t, ms.start(context.Background(), componenttest.NewNopHost()), "invalid config")
+	assert.NoError(t, ms.shutdown(context.Background()))
+}
+
+func TestReplicaLag(t *testing.T) {
+	_, ok := replicaLag(nil)
+	assert.False(t, ok)
+
+	lag, ok := replicaLag([]map[string]string{{"Seconds_Behind_Source": "0"}})
+	assert.True(t, ok)
+	assert.Equal(t, int64(0), lag)
+}
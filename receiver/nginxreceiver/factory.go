@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxreceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// This file implements factory for the nginx receiver.
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "nginx"
+
+	apiStubStatus = "stub_status"
+	apiPlus       = "plus"
+
+	defaultEndpoint           = "http://localhost:80/status"
+	defaultCollectionInterval = 10 * time.Second
+	defaultTimeout            = 10 * time.Second
+)
+
+// NewFactory creates a factory for the nginx receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithMetrics(createMetricsReceiver))
+}
+
+func createDefaultConfig() configmodels.Receiver {
+	scs := scraperhelper.DefaultScraperControllerSettings(typeStr)
+	scs.CollectionInterval = defaultCollectionInterval
+	return &Config{
+		ScraperControllerSettings: scs,
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: defaultEndpoint,
+			Timeout:  defaultTimeout,
+		},
+		API: apiStubStatus,
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.MetricsConsumer,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	if rCfg.API != apiStubStatus && rCfg.API != apiPlus {
+		return nil, fmt.Errorf("invalid api %q, expecting %q or %q", rCfg.API, apiStubStatus, apiPlus)
+	}
+
+	ns := newNginxScraper(rCfg)
+	return scraperhelper.NewScraperControllerReceiver(
+		&rCfg.ScraperControllerSettings,
+		params.Logger,
+		consumer,
+		scraperhelper.AddMetricsScraper(scraperhelper.NewMetricsScraper(
+			typeStr,
+			ns.scrape,
+			scraperhelper.WithStart(ns.start))))
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/nginxreceiver/internal/metadata"
+)
+
+// maxResponseSize is the maximum size of the responses of the status APIs.
+const maxResponseSize = 1024 * 1024
+
+// status is the status of the server, common to both APIs.
+type status struct {
+	requests int64
+	accepted int64
+	handled  int64
+	// current is the number of current connections by state, among the states reported by the API.
+	current map[string]int64
+}
+
+type nginxScraper struct {
+	cfg       *Config
+	client    *http.Client
+	startTime pdata.Timestamp
+}
+
+func newNginxScraper(cfg *Config) *nginxScraper {
+	return &nginxScraper{cfg: cfg}
+}
+
+func (ns *nginxScraper) start(context.Context, component.Host) error {
+	client, err := ns.cfg.ToClient()
+	if err != nil {
+		return err
+	}
+	ns.client = client
+	ns.startTime = pdata.TimestampFromTime(time.Now())
+	return nil
+}
+
+func (ns *nginxScraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	metrics := pdata.NewMetricSlice()
+
+	var st *status
+	var err error
+	if ns.cfg.API == apiPlus {
+		st, err = ns.fetchPlusStatus(ctx)
+	} else {
+		st, err = ns.fetchStubStatus(ctx)
+	}
+	if err != nil {
+		return metrics, err
+	}
+
+	now := pdata.TimestampFromTime(time.Now())
+	metrics.Resize(4)
+	initializeSumMetric(metrics.At(0), metadata.Metrics.NginxRequests, ns.startTime, now, st.requests)
+	initializeSumMetric(metrics.At(1), metadata.Metrics.NginxConnectionsAccepted, ns.startTime, now, st.accepted)
+	initializeSumMetric(metrics.At(2), metadata.Metrics.NginxConnectionsHandled, ns.startTime, now, st.handled)
+	initializeConnectionsCurrentMetric(metrics.At(3), now, st.current)
+	return metrics, nil
+}
+
+func initializeSumMetric(metric pdata.Metric, descriptor metadata.MetricIntf, startTime, now pdata.Timestamp, value int64) {
+	descriptor.Init(metric)
+	dps := metric.IntSum().DataPoints()
+	dps.Resize(1)
+	dps.At(0).SetStartTime(startTime)
+	dps.At(0).SetTimestamp(now)
+	dps.At(0).SetValue(value)
+}
+
+func initializeConnectionsCurrentMetric(metric pdata.Metric, now pdata.Timestamp, current map[string]int64) {
+	metadata.Metrics.NginxConnectionsCurrent.Init(metric)
+	dps := metric.IntGauge().DataPoints()
+	for _, state := range []string{
+		metadata.LabelState.Active,
+		metadata.LabelState.Reading,
+		metadata.LabelState.Writing,
+		metadata.LabelState.Waiting,
+	} {
+		value, ok := current[state]
+		if !ok {
+			continue
+		}
+		dps.Resize(dps.Len() + 1)
+		dp := dps.At(dps.Len() - 1)
+		dp.LabelsMap().Insert(metadata.Labels.State, state)
+		dp.SetTimestamp(now)
+		dp.SetValue(value)
+	}
+}
+
+// fetchStubStatus fetches and parses the page of the ngx_http_stub_status_module:
+//
+//	Active connections: 291
+//	server accepts handled requests
+//	 16630948 16630948 31070465
+//	Reading: 6 Writing: 179 Waiting: 106
+func (ns *nginxScraper) fetchStubStatus(ctx context.Context) (*status, error) {
+	body, err := ns.get(ctx, ns.cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return parseStubStatus(string(body))
+}
+
+func parseStubStatus(body string) (*status, error) {
+	fields := strings.Fields(body)
+	expected := []string{"Active", "connections:", "", "server", "accepts", "handled", "requests", "", "", "",
+		"Reading:", "", "Writing:", "", "Waiting:", ""}
+	if len(fields) != len(expected) {
+		return nil, fmt.Errorf("unexpected stub_status page: %q", body)
+	}
+	var values []int64
+	for i, field := range fields {
+		if expected[i] != "" {
+			if field != expected[i] {
+				return nil, fmt.Errorf("unexpected stub_status page: %q", body)
+			}
+			continue
+		}
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected stub_status page: %q", body)
+		}
+		values = append(values, value)
+	}
+	return &status{
+		accepted: values[1],
+		handled:  values[2],
+		requests: values[3],
+		current: map[string]int64{
+			metadata.LabelState.Active:  values[0],
+			metadata.LabelState.Reading: values[4],
+			metadata.LabelState.Writing: values[5],
+			metadata.LabelState.Waiting: values[6],
+		},
+	}, nil
+}
+
+// plusConnections is the response of the /connections endpoint of the NGINX Plus API.
+type plusConnections struct {
+	Accepted int64 `json:"accepted"`
+	Dropped  int64 `json:"dropped"`
+	Active   int64 `json:"active"`
+	Idle     int64 `json:"idle"`
+}
+
+// plusRequests is the response of the /http/requests endpoint of the NGINX Plus API.
+type plusRequests struct {
+	Total int64 `json:"total"`
+}
+
+// fetchPlusStatus fetches the status from the NGINX Plus API. The connections of the API are either active or idle:
+// the active connections of stub_status include the idle ones, which are its waiting connections.
+func (ns *nginxScraper) fetchPlusStatus(ctx context.Context) (*status, error) {
+	endpoint := strings.TrimSuffix(ns.cfg.Endpoint, "/")
+	var connections plusConnections
+	if err := ns.getJSON(ctx, endpoint+"/connections", &connections); err != nil {
+		return nil, err
+	}
+	var requests plusRequests
+	if err := ns.getJSON(ctx, endpoint+"/http/requests", &requests); err != nil {
+		return nil, err
+	}
+	return &status{
+		requests: requests.Total,
+		accepted: connections.Accepted,
+		handled:  connections.Accepted - connections.Dropped,
+		current: map[string]int64{
+			metadata.LabelState.Active:  connections.Active + connections.Idle,
+			metadata.LabelState.Waiting: connections.Idle,
+		},
+	}, nil
+}
+
+func (ns *nginxScraper) getJSON(ctx context.Context, url string, v interface{}) error {
+	body, err := ns.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid response of %s: %w", url, err)
+	}
+	return nil
+}
+
+func (ns *nginxScraper) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ns.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status of %s: %s", url, resp.Status)
+	}
+	return body, nil
+}
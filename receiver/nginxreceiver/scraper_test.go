@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/nginxreceiver/internal/metadata"
+)
+
+const stubStatus = `Active connections: 291 
+server accepts handled requests
+ 16630948 16630947 31070465 
+Reading: 6 Writing: 179 Waiting: 106 
+`
+
+func newTestScraper(t *testing.T, endpoint, api string) *nginxScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.API = api
+	ns := newNginxScraper(cfg)
+	require.NoError(t, ns.start(context.Background(), componenttest.NewNopHost()))
+	return ns
+}
+
+// metricValues returns the values of the metrics, by name and by state for nginx.connections_current.
+func metricValues(metrics pdata.MetricSlice) map[string]int64 {
+	values := make(map[string]int64)
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		switch metric.DataType() {
+		case pdata.MetricDataTypeIntSum:
+			values[metric.Name()] = metric.IntSum().DataPoints().At(0).Value()
+		case pdata.MetricDataTypeIntGauge:
+			dps := metric.IntGauge().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				state, _ := dps.At(j).LabelsMap().Get(metadata.Labels.State)
+				values[metric.Name()+"/"+state] = dps.At(j).Value()
+			}
+		}
+	}
+	return values
+}
+
+func TestScrapeStubStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/status", r.URL.Path)
+		_, _ = w.Write([]byte(stubStatus))
+	}))
+	defer server.Close()
+
+	ns := newTestScraper(t, server.URL+"/status", apiStubStatus)
+	metrics, err := ns.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		"nginx.requests":                    31070465,
+		"nginx.connections_accepted":        16630948,
+		"nginx.connections_handled":         16630947,
+		"nginx.connections_current/active":  291,
+		"nginx.connections_current/reading": 6,
+		"nginx.connections_current/writing": 179,
+		"nginx.connections_current/waiting": 106,
+	}, metricValues(metrics))
+
+	requests := metrics.At(0).IntSum()
+	assert.True(t, requests.IsMonotonic())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, requests.AggregationTemporality())
+	assert.Equal(t, ns.startTime, requests.DataPoints().At(0).StartTime())
+}
+
+func TestScrapePlus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/6/connections":
+			_, _ = w.Write([]byte(`{"accepted":4968119,"dropped":2,"active":5,"idle":117}`))
+		case "/api/6/http/requests":
+			_, _ = w.Write([]byte(`{"total":10624511,"current":4}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ns := newTestScraper(t, server.URL+"/api/6/", apiPlus)
+	metrics, err := ns.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		"nginx.requests":                    10624511,
+		"nginx.connections_accepted":        4968119,
+		"nginx.connections_handled":         4968117,
+		"nginx.connections_current/active":  122,
+		"nginx.connections_current/waiting": 117,
+	}, metricValues(metrics))
+}
+
+func TestScrapeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		api     string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "unexpected status",
+			api:  apiStubStatus,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			wantErr: "unexpected status of ",
+		},
+		{
+			name: "invalid stub_status page",
+			api:  apiStubStatus,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("<html>Welcome to nginx!</html>"))
+			},
+			wantErr: "unexpected stub_status page",
+		},
+		{
+			name: "invalid plus response",
+			api:  apiPlus,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("[]"))
+			},
+			wantErr: "invalid response of ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			ns := newTestScraper(t, server.URL, tt.api)
+			metrics, err := ns.scrape(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, 0, metrics.Len())
+		})
+	}
+}
+
+func TestParseStubStatus(t *testing.T) {
+	_, err := parseStubStatus("Active connections: 1\nserver accepts handled requests\n 1 1 x\nReading: 0 Writing: 1 Waiting: 0\n")
+	assert.Error(t, err)
+	_, err = parseStubStatus("Active connections: 1\nserver accepts handled requests\n 1 1 1\nReading: 0 Writing: 1 Sleeping: 0\n")
+	assert.Error(t, err)
+}
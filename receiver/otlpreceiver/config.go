@@ -22,7 +22,25 @@ import (
 
 type Protocols struct {
 	GRPC *configgrpc.GRPCServerSettings `mapstructure:"grpc"`
-	HTTP *confighttp.HTTPServerSettings `mapstructure:"http"`
+	HTTP *HTTPConfig                    `mapstructure:"http"`
+}
+
+// HTTPConfig defines configuration for the OTLP/HTTP protocol.
+type HTTPConfig struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// TracesURLPath is the URL path the traces are received on, instead of /v1/traces.
+	TracesURLPath string `mapstructure:"traces_url_path"`
+
+	// MetricsURLPath is the URL path the metrics are received on, instead of /v1/metrics.
+	MetricsURLPath string `mapstructure:"metrics_url_path"`
+
+	// LogsURLPath is the URL path the logs are received on, instead of /v1/logs.
+	LogsURLPath string `mapstructure:"logs_url_path"`
+
+	// MaxRequestBodySizeMiB limits the size (in MiB) of the request bodies, after their decompression.
+	// Defaults to 20, there is no limit if 0.
+	MaxRequestBodySizeMiB int64 `mapstructure:"max_request_body_size_mib"`
 }
 
 // Config defines configuration for OTLP receiver.
@@ -150,14 +150,21 @@ func TestLoadConfig(t *testing.T) {
 					},
 					ReadBufferSize: 512 * 1024,
 				},
-				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint: "0.0.0.0:55681",
-					TLSSetting: &configtls.TLSServerSetting{
-						TLSSetting: configtls.TLSSetting{
-							CertFile: "test.crt",
-							KeyFile:  "test.key",
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: "0.0.0.0:55681",
+						TLSSetting: &configtls.TLSServerSetting{
+							TLSSetting: configtls.TLSSetting{
+								CertFile: "test.crt",
+								KeyFile:  "test.key",
+							},
 						},
 					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
+
+					MaxRequestBodySizeMiB: defaultMaxRequestBodySizeMiB,
 				},
 			},
 		})
@@ -169,9 +176,16 @@ func TestLoadConfig(t *testing.T) {
 				NameVal: "otlp/cors",
 			},
 			Protocols: Protocols{
-				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint:    "0.0.0.0:55681",
-					CorsOrigins: []string{"https://*.test.com", "https://test.com"},
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint:    "0.0.0.0:55681",
+						CorsOrigins: []string{"https://*.test.com", "https://test.com"},
+					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
+
+					MaxRequestBodySizeMiB: defaultMaxRequestBodySizeMiB,
 				},
 			},
 		})
@@ -183,10 +197,17 @@ func TestLoadConfig(t *testing.T) {
 				NameVal: "otlp/corsheader",
 			},
 			Protocols: Protocols{
-				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint:    "0.0.0.0:55681",
-					CorsOrigins: []string{"https://*.test.com", "https://test.com"},
-					CorsHeaders: []string{"ExampleHeader"},
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint:    "0.0.0.0:55681",
+						CorsOrigins: []string{"https://*.test.com", "https://test.com"},
+						CorsHeaders: []string{"ExampleHeader"},
+					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
+
+					MaxRequestBodySizeMiB: defaultMaxRequestBodySizeMiB,
 				},
 			},
 		})
@@ -205,9 +226,15 @@ func TestLoadConfig(t *testing.T) {
 					},
 					ReadBufferSize: 512 * 1024,
 				},
-				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint: "/tmp/http_otlp.sock",
-					// Transport: "unix",
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: "unix:///tmp/http_otlp.sock",
+					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
+
+					MaxRequestBodySizeMiB: defaultMaxRequestBodySizeMiB,
 				},
 			},
 		})
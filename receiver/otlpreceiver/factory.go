@@ -42,6 +42,13 @@ const (
 	defaultGRPCEndpoint = "0.0.0.0:4317"
 	defaultHTTPEndpoint = "0.0.0.0:55681"
 	legacyGRPCEndpoint  = "0.0.0.0:55680"
+
+	defaultTracesURLPath  = "/v1/traces"
+	defaultMetricsURLPath = "/v1/metrics"
+	defaultLogsURLPath    = "/v1/logs"
+
+	// defaultMaxRequestBodySizeMiB protects the receiver from the decompression bombs.
+	defaultMaxRequestBodySizeMiB = 20
 )
 
 func NewFactory() component.ReceiverFactory {
@@ -70,8 +77,15 @@ func createDefaultConfig() configmodels.Receiver {
 				// We almost write 0 bytes, so no need to tune WriteBufferSize.
 				ReadBufferSize: 512 * 1024,
 			},
-			HTTP: &confighttp.HTTPServerSettings{
-				Endpoint: defaultHTTPEndpoint,
+			HTTP: &HTTPConfig{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: defaultHTTPEndpoint,
+				},
+				TracesURLPath:  defaultTracesURLPath,
+				MetricsURLPath: defaultMetricsURLPath,
+				LogsURLPath:    defaultLogsURLPath,
+
+				MaxRequestBodySizeMiB: defaultMaxRequestBodySizeMiB,
 			},
 		},
 	}
@@ -71,8 +71,10 @@ func TestCreateTraceReceiver(t *testing.T) {
 			Transport: "tcp",
 		},
 	}
-	defaultHTTPSettings := &confighttp.HTTPServerSettings{
-		Endpoint: testutil.GetAvailableLocalAddress(t),
+	defaultHTTPSettings := &HTTPConfig{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
 	}
 
 	tests := []struct {
@@ -118,9 +120,10 @@ func TestCreateTraceReceiver(t *testing.T) {
 				},
 				Protocols: Protocols{
 					GRPC: defaultGRPCSettings,
-					HTTP: &confighttp.HTTPServerSettings{
-						Endpoint: "localhost:112233",
-					},
+					HTTP: &HTTPConfig{
+						HTTPServerSettings: confighttp.HTTPServerSettings{
+							Endpoint: "localhost:112233",
+						}},
 				},
 			},
 			wantErr: true,
@@ -156,8 +159,10 @@ func TestCreateMetricReceiver(t *testing.T) {
 			Transport: "tcp",
 		},
 	}
-	defaultHTTPSettings := &confighttp.HTTPServerSettings{
-		Endpoint: testutil.GetAvailableLocalAddress(t),
+	defaultHTTPSettings := &HTTPConfig{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
 	}
 
 	tests := []struct {
@@ -203,9 +208,10 @@ func TestCreateMetricReceiver(t *testing.T) {
 				},
 				Protocols: Protocols{
 					GRPC: defaultGRPCSettings,
-					HTTP: &confighttp.HTTPServerSettings{
-						Endpoint: "327.0.0.1:1122",
-					},
+					HTTP: &HTTPConfig{
+						HTTPServerSettings: confighttp.HTTPServerSettings{
+							Endpoint: "327.0.0.1:1122",
+						}},
 				},
 			},
 			wantErr: true,
@@ -241,8 +247,10 @@ func TestCreateLogReceiver(t *testing.T) {
 			Transport: "tcp",
 		},
 	}
-	defaultHTTPSettings := &confighttp.HTTPServerSettings{
-		Endpoint: testutil.GetAvailableLocalAddress(t),
+	defaultHTTPSettings := &HTTPConfig{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
 	}
 
 	tests := []struct {
@@ -292,9 +300,10 @@ func TestCreateLogReceiver(t *testing.T) {
 				},
 				Protocols: Protocols{
 					GRPC: defaultGRPCSettings,
-					HTTP: &confighttp.HTTPServerSettings{
-						Endpoint: "327.0.0.1:1122",
-					},
+					HTTP: &HTTPConfig{
+						HTTPServerSettings: confighttp.HTTPServerSettings{
+							Endpoint: "327.0.0.1:1122",
+						}},
 				},
 			},
 			wantStartErr: true,
@@ -309,9 +318,10 @@ func TestCreateLogReceiver(t *testing.T) {
 				},
 				Protocols: Protocols{
 					GRPC: defaultGRPCSettings,
-					HTTP: &confighttp.HTTPServerSettings{
-						Endpoint: "327.0.0.1:1122",
-					},
+					HTTP: &HTTPConfig{
+						HTTPServerSettings: confighttp.HTTPServerSettings{
+							Endpoint: "327.0.0.1:1122",
+						}},
 				},
 			},
 			wantErr: true,
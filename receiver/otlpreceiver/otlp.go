@@ -43,6 +43,7 @@ type otlpReceiver struct {
 	cfg        *Config
 	serverGRPC *grpc.Server
 	gatewayMux *gatewayruntime.ServeMux
+	httpMux    http.Handler
 	serverHTTP *http.Server
 
 	traceReceiver   *trace.Receiver
@@ -84,6 +85,11 @@ func newOtlpReceiver(cfg *Config, logger *zap.Logger) (*otlpReceiver, error) {
 			gatewayruntime.WithMarshalerOption("application/x-protobuf", &xProtobufMarshaler{}),
 			gatewayruntime.WithMarshalerOption(gatewayruntime.MIMEWildcard, jsonpb),
 		)
+		httpMux, err := newURLPathHandler(r.gatewayMux, cfg.HTTP)
+		if err != nil {
+			return nil, err
+		}
+		r.httpMux = httpMux
 	}
 
 	return r, nil
@@ -107,7 +113,7 @@ func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, host
 func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host component.Host) error {
 	r.logger.Info("Starting HTTP server on endpoint " + cfg.Endpoint)
 	var hln net.Listener
-	hln, err := r.cfg.HTTP.ToListener()
+	hln, err := cfg.ToListener()
 	if err != nil {
 		return err
 	}
@@ -141,10 +147,11 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 	}
 	if r.cfg.HTTP != nil {
 		r.serverHTTP = r.cfg.HTTP.ToServer(
-			r.gatewayMux,
+			r.httpMux,
 			confighttp.WithErrorHandler(errorHandler),
+			confighttp.WithMaxRequestBodySize(r.cfg.HTTP.MaxRequestBodySizeMiB*1024*1024),
 		)
-		err = r.startHTTPServer(r.cfg.HTTP, host)
+		err = r.startHTTPServer(&r.cfg.HTTP.HTTPServerSettings, host)
 		if err != nil {
 			return err
 		}
@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"runtime"
 	"testing"
 	"time"
 
@@ -240,6 +241,101 @@ func testHTTPJSONRequest(t *testing.T, url string, sink *internalconsumertest.Er
 
 }
 
+func TestJsonHttpMetricsAndLogs(t *testing.T) {
+	metricsJSON := []byte(`{
+	  "resourceMetrics": [{
+	    "resource": {"attributes": [{"key": "host.name", "value": {"stringValue": "testHost"}}]},
+	    "instrumentationLibraryMetrics": [{
+	      "metrics": [{
+	        "name": "testMetric",
+	        "doubleGauge": {"dataPoints": [{"timeUnixNano": "1544712660000000000", "value": 1.5}]}
+	      }]
+	    }]
+	  }]
+	}`)
+	logsJSON := []byte(`{
+	  "resourceLogs": [{
+	    "resource": {"attributes": [{"key": "host.name", "value": {"stringValue": "testHost"}}]},
+	    "instrumentationLibraryLogs": [{
+	      "logs": [{
+	        "timeUnixNano": "1544712660000000000",
+	        "traceId": "5B8EFFF798038103D269B633813FC60C",
+	        "spanId": "EEE19B7EC3C1B173",
+	        "body": {"stringValue": "testLog"}
+	      }]
+	    }]
+	  }]
+	}`)
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP.Endpoint = addr
+	cfg.GRPC = nil
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	mr, err := factory.CreateMetricsReceiver(context.Background(), params, cfg, metricsSink)
+	require.NoError(t, err)
+	_, err = factory.CreateLogsReceiver(context.Background(), params, cfg, logsSink)
+	require.NoError(t, err)
+
+	require.NoError(t, mr.Start(context.Background(), componenttest.NewNopHost()))
+	defer mr.Shutdown(context.Background())
+
+	resp, err := http.Post(fmt.Sprintf("http://%s/v1/metrics", addr), "application/json", bytes.NewReader(metricsJSON))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	metric := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "testMetric", metric.Name())
+	assert.Equal(t, 1.5, metric.DoubleGauge().DataPoints().At(0).Value())
+
+	resp, err = http.Post(fmt.Sprintf("http://%s/v1/logs", addr), "application/json", bytes.NewReader(logsJSON))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, logsSink.AllLogs(), 1)
+	lr := logsSink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "testLog", lr.Body().StringVal())
+	assert.Equal(t, "5b8efff798038103d269b633813fc60c", lr.TraceID().HexString())
+}
+
+func TestHTTPCors(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.CorsOrigins = []string{"https://*.example.com"}
+	cfg.HTTP.CorsHeaders = []string{"X-Custom"}
+	cfg.GRPC = nil
+	ocr := newReceiver(t, factory, cfg, new(consumertest.TracesSink), nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	preflight := func(origin string) *http.Response {
+		req, err := http.NewRequest(http.MethodOptions, fmt.Sprintf("http://%s/v1/traces", addr), nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "Content-Type, X-Custom")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	resp := preflight("https://app.example.com")
+	assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Headers"), "X-Custom")
+
+	resp = preflight("https://app.other.com")
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
 func TestJsonMarshaling(t *testing.T) {
 	m := jsonpb.Marshaler{}
 	json, err := m.MarshalToString(&resourceSpansOtlp)
@@ -530,6 +626,136 @@ func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 	}
 }
 
+func TestUnixDomainSockets(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	grpcSocket := testutil.TempSocketName(t)
+	httpSocket := testutil.TempSocketName(t)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.GRPC.NetAddr.Endpoint = "unix://" + grpcSocket
+	cfg.HTTP.Endpoint = "unix://" + httpSocket
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	cc, err := grpc.Dial("unix://"+grpcSocket, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+	_, err = collectortrace.NewTraceServiceClient(cc).Export(context.Background(), createSingleSpanTrace())
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", httpSocket)
+		},
+	}}
+	resp, err := client.Post("http://localhost/v1/traces", "application/json", bytes.NewReader(traceJSON))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, 2, sink.SpansCount())
+}
+
+func TestHTTPCustomURLPaths(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.TracesURLPath = "/otlp/v1/traces"
+	cfg.GRPC = nil
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	post := func(path string) int {
+		resp, err := http.Post(fmt.Sprintf("http://%s%s", addr, path), "application/json", bytes.NewReader(traceJSON))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, post("/otlp/v1/traces"))
+	assert.Equal(t, 1, sink.SpansCount())
+	assert.Equal(t, http.StatusNotFound, post("/v1/traces"))
+	assert.Equal(t, 1, sink.SpansCount())
+}
+
+func TestHTTPInvalidURLPaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*HTTPConfig)
+	}{
+		{
+			name:   "relative",
+			modify: func(cfg *HTTPConfig) { cfg.LogsURLPath = "logs" },
+		},
+		{
+			name:   "duplicate",
+			modify: func(cfg *HTTPConfig) { cfg.LogsURLPath = "/otlp"; cfg.MetricsURLPath = "/otlp" },
+		},
+		{
+			name:   "defaultOfAnotherSignal",
+			modify: func(cfg *HTTPConfig) { cfg.LogsURLPath = defaultTracesURLPath },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			tt.modify(cfg.HTTP)
+			_, err := newOtlpReceiver(cfg, zap.NewNop())
+			assert.Error(t, err)
+		})
+	}
+
+	// Swapping the paths of two signals is valid.
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.HTTP.TracesURLPath = defaultLogsURLPath
+	cfg.HTTP.LogsURLPath = defaultTracesURLPath
+	_, err := newOtlpReceiver(cfg, zap.NewNop())
+	assert.NoError(t, err)
+}
+
+func TestHTTPMaxRequestBodySize(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.MaxRequestBodySizeMiB = 1
+	cfg.GRPC = nil
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	// A body of 2 MiB once decompressed, padded with whitespaces.
+	body, err := compressGzip(append(traceJSON, bytes.Repeat([]byte(" "), 2*1024*1024)...))
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s/v1/traces", addr), body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, 0, sink.SpansCount())
+
+	resp, err = http.Post(fmt.Sprintf("http://%s/v1/traces", addr), "application/json", bytes.NewReader(traceJSON))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func TestGRPCNewPortAlreadyUsed(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	ln, err := net.Listen("tcp", addr)
@@ -710,14 +936,15 @@ func TestHTTPInvalidTLSCredentials(t *testing.T) {
 			NameVal: "IncorrectTLS",
 		},
 		Protocols: Protocols{
-			HTTP: &confighttp.HTTPServerSettings{
-				Endpoint: testutil.GetAvailableLocalAddress(t),
-				TLSSetting: &configtls.TLSServerSetting{
-					TLSSetting: configtls.TLSSetting{
-						CertFile: "willfail",
+			HTTP: &HTTPConfig{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: testutil.GetAvailableLocalAddress(t),
+					TLSSetting: &configtls.TLSServerSetting{
+						TLSSetting: configtls.TLSSetting{
+							CertFile: "willfail",
+						},
 					},
-				},
-			},
+				}},
 		},
 	}
 
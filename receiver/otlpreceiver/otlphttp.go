@@ -16,7 +16,10 @@ package otlpreceiver
 
 import (
 	"bytes"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
@@ -74,3 +77,62 @@ func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusC
 	w.WriteHeader(statusCode)
 	w.Write(msg)
 }
+
+// urlPathHandler serves the requests sent to the configured URL paths with the handlers registered on the default
+// ones, the default paths that are overridden are not served.
+type urlPathHandler struct {
+	next     http.Handler
+	rewrites map[string]string
+}
+
+func newURLPathHandler(next http.Handler, cfg *HTTPConfig) (http.Handler, error) {
+	paths := []struct{ configured, defaultPath string }{
+		{cfg.TracesURLPath, defaultTracesURLPath},
+		{cfg.MetricsURLPath, defaultMetricsURLPath},
+		{cfg.LogsURLPath, defaultLogsURLPath},
+	}
+	h := &urlPathHandler{next: next, rewrites: map[string]string{}}
+	used := map[string]bool{}
+	for i := range paths {
+		if paths[i].configured == "" {
+			paths[i].configured = paths[i].defaultPath
+		}
+		p := paths[i]
+		if !strings.HasPrefix(p.configured, "/") {
+			return nil, fmt.Errorf("invalid URL path %q: must start with /", p.configured)
+		}
+		if used[p.configured] {
+			return nil, fmt.Errorf("invalid URL path %q: used by several signals", p.configured)
+		}
+		used[p.configured] = true
+		if p.configured != p.defaultPath {
+			h.rewrites[p.configured] = p.defaultPath
+		}
+	}
+	// An empty target marks the overridden default paths that are not used by another signal, they are not served.
+	for _, p := range paths {
+		if p.configured != p.defaultPath && !used[p.defaultPath] {
+			h.rewrites[p.defaultPath] = ""
+		}
+	}
+	return h, nil
+}
+
+func (h *urlPathHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	target, ok := h.rewrites[r.URL.Path]
+	if !ok {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	if target == "" {
+		http.NotFound(w, r)
+		return
+	}
+	r2 := new(http.Request)
+	*r2 = *r
+	r2.URL = new(url.URL)
+	*r2.URL = *r.URL
+	r2.URL.Path = target
+	r2.URL.RawPath = ""
+	h.next.ServeHTTP(w, r2)
+}
@@ -31,6 +31,12 @@ type Config struct {
 	UseStartTimeMetric            bool           `mapstructure:"use_start_time_metric"`
 	StartTimeMetricRegex          string         `mapstructure:"start_time_metric_regex"`
 
+	// ConfigFile is the path of a Prometheus configuration file, e.g. prometheus.yml, used instead of config and
+	// reloaded when it changes.
+	ConfigFile string `mapstructure:"config_file"`
+	// ConfigReloadInterval is the interval between the checks for changes of ConfigFile.
+	ConfigReloadInterval time.Duration `mapstructure:"config_reload_interval"`
+
 	// ConfigPlaceholder is just an entry to make the configuration pass a check
 	// that requires that all keys present in the config actually exist on the
 	// structure, ie.: it will error if an unknown key is present.
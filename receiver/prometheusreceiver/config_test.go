@@ -58,6 +58,32 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, r1.StartTimeMetricRegex, "^(.+_)*process_start_time_seconds$")
 }
 
+func TestLoadConfigFile(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config_file.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	r := cfg.Receivers["prometheus"].(*Config)
+	assert.Nil(t, r.PrometheusConfig)
+	assert.Equal(t, "./testdata/prometheus.yml", r.ConfigFile)
+	assert.Equal(t, 30*time.Second, r.ConfigReloadInterval)
+
+	// The configuration file isn't subject to the env variable substitution of the collector configuration.
+	promCfg, _, err := loadConfigFile(r.ConfigFile)
+	require.NoError(t, err)
+	scrapeConfig := promCfg.ScrapeConfigs[0]
+	assert.Equal(t, "federate", scrapeConfig.JobName)
+	assert.True(t, scrapeConfig.HonorLabels)
+	assert.True(t, scrapeConfig.HonorTimestamps)
+	assert.Len(t, scrapeConfig.MetricRelabelConfigs, 2)
+	assert.Equal(t, "$1-east", scrapeConfig.MetricRelabelConfigs[1].Replacement)
+}
+
 func TestLoadConfigWithEnvVar(t *testing.T) {
 	const jobname = "JobName"
 	const jobnamevar = "JOBNAME"
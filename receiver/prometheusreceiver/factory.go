@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	_ "github.com/prometheus/prometheus/discovery/install" // init() of this package registers service discovery impl.
 	"github.com/spf13/viper"
@@ -37,10 +38,14 @@ const (
 
 	// The key for Prometheus scraping configs.
 	prometheusConfigKey = "config"
+
+	defaultConfigReloadInterval = time.Minute
 )
 
 var (
-	errNilScrapeConfig = errors.New("expecting a non-nil ScrapeConfig")
+	errNilScrapeConfig           = errors.New("expecting a non-nil ScrapeConfig")
+	errConfigAndConfigFile       = errors.New("config and config_file cannot be both set")
+	errNonPositiveReloadInterval = errors.New("config_reload_interval must be positive")
 )
 
 func NewFactory() component.ReceiverFactory {
@@ -90,6 +95,7 @@ func createDefaultConfig() configmodels.Receiver {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
+		ConfigReloadInterval: defaultConfigReloadInterval,
 	}
 }
 
@@ -100,7 +106,14 @@ func createMetricsReceiver(
 	nextConsumer consumer.MetricsConsumer,
 ) (component.MetricsReceiver, error) {
 	config := cfg.(*Config)
-	if config.PrometheusConfig == nil || len(config.PrometheusConfig.ScrapeConfigs) == 0 {
+	if config.ConfigFile != "" {
+		if config.PrometheusConfig != nil {
+			return nil, errConfigAndConfigFile
+		}
+		if config.ConfigReloadInterval <= 0 {
+			return nil, errNonPositiveReloadInterval
+		}
+	} else if config.PrometheusConfig == nil || len(config.PrometheusConfig.ScrapeConfigs) == 0 {
 		return nil, errNilScrapeConfig
 	}
 	return newPrometheusReceiver(params.Logger, config, nextConsumer), nil
@@ -19,6 +19,7 @@ import (
 	"path"
 	"testing"
 
+	promcfg "github.com/prometheus/prometheus/config"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 
@@ -45,6 +46,26 @@ func TestCreateReceiver(t *testing.T) {
 	assert.Nil(t, mReceiver)
 }
 
+func TestCreateReceiverConfigFile(t *testing.T) {
+	creationParams := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ConfigFile = path.Join(".", "testdata", "prometheus.yml")
+	mReceiver, err := createMetricsReceiver(context.Background(), creationParams, cfg, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, mReceiver)
+
+	cfg.ConfigReloadInterval = 0
+	mReceiver, err = createMetricsReceiver(context.Background(), creationParams, cfg, nil)
+	assert.Equal(t, err, errNonPositiveReloadInterval)
+	assert.Nil(t, mReceiver)
+
+	cfg.PrometheusConfig = &promcfg.Config{}
+	mReceiver, err = createMetricsReceiver(context.Background(), creationParams, cfg, nil)
+	assert.Equal(t, err, errConfigAndConfigFile)
+	assert.Nil(t, mReceiver)
+}
+
 func TestFactoryCanParseServiceDiscoveryConfigs(t *testing.T) {
 	factories, err := componenttest.ExampleComponents()
 	assert.NoError(t, err)
@@ -57,8 +57,8 @@ var errNoStartTimeMetrics = errors.New("process_start_time_seconds metric is mis
 type transaction struct {
 	id                   int64
 	ctx                  context.Context
-	isNew                bool
 	sink                 consumer.MetricsConsumer
+	samples              []sample
 	job                  string
 	instance             string
 	jobsMap              *JobsMap
@@ -72,11 +72,17 @@ type transaction struct {
 	logger               *zap.Logger
 }
 
+// sample is a data point added to a transaction.
+type sample struct {
+	ls labels.Labels
+	t  int64
+	v  float64
+}
+
 func newTransaction(ctx context.Context, jobsMap *JobsMap, useStartTimeMetric bool, startTimeMetricRegex string, receiverName string, ms *metadataService, sink consumer.MetricsConsumer, logger *zap.Logger) *transaction {
 	return &transaction{
 		id:                   atomic.AddInt64(&idSeq, 1),
 		ctx:                  ctx,
-		isNew:                true,
 		sink:                 sink,
 		jobsMap:              jobsMap,
 		useStartTimeMetric:   useStartTimeMetric,
@@ -106,12 +112,16 @@ func (tr *transaction) Add(ls labels.Labels, t int64, v float64) (uint64, error)
 	default:
 	}
 
-	if tr.isNew {
-		if err := tr.initTransaction(ls); err != nil {
-			return 0, err
+	// The samples are buffered until the commit, as the target of the transaction is only known once the scrapeLoop
+	// adds the samples reporting the scrape: it adds them last, always with the job and instance labels of the
+	// target, while honor_labels or metric_relabel_configs may override those of the scraped samples.
+	if job, instance := ls.Get(model.JobLabel), ls.Get(model.InstanceLabel); job != "" && instance != "" {
+		if tr.job == "" || isInternalMetric(ls.Get(model.MetricNameLabel)) {
+			tr.job, tr.instance = job, instance
 		}
 	}
-	return 0, tr.metricBuilder.AddDataPoint(ls, t, v)
+	tr.samples = append(tr.samples, sample{ls: ls, t: t, v: v})
+	return 0, nil
 }
 
 // always returns error since caching is not supported by Add() function
@@ -119,33 +129,54 @@ func (tr *transaction) AddFast(_ uint64, _ int64, _ float64) error {
 	return storage.ErrNotFound
 }
 
-func (tr *transaction) initTransaction(ls labels.Labels) error {
-	job, instance := ls.Get(model.JobLabel), ls.Get(model.InstanceLabel)
-	if job == "" || instance == "" {
+func (tr *transaction) initTransaction() error {
+	if tr.job == "" || tr.instance == "" {
 		return errNoJobInstance
 	}
-	// discover the binding target when this method is called for the first time during a transaction
-	mc, err := tr.ms.Get(job, instance)
+	// discover the binding target of the transaction
+	mc, err := tr.ms.Get(tr.job, tr.instance)
 	if err != nil {
 		return err
 	}
-	if tr.jobsMap != nil {
-		tr.job = job
-		tr.instance = instance
-	}
-	tr.node, tr.resource = createNodeAndResource(job, instance, mc.SharedLabels().Get(model.SchemeLabel))
+	tr.node, tr.resource = createNodeAndResource(tr.job, tr.instance, mc.SharedLabels().Get(model.SchemeLabel))
 	tr.metricBuilder = newMetricBuilder(mc, tr.useStartTimeMetric, tr.startTimeMetricRegex, tr.logger)
-	tr.isNew = false
+	for _, s := range tr.samples {
+		if err := tr.metricBuilder.AddDataPoint(tr.exportLabels(s.ls), s.t, s.v); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// exportLabels keeps the job and instance labels of a sample which differ from those of the target, e.g. set by
+// honor_labels or metric_relabel_configs, as the exported_job and exported_instance labels, as the job and instance
+// of the target become the resource of the metrics.
+func (tr *transaction) exportLabels(ls labels.Labels) labels.Labels {
+	job, instance := ls.Get(model.JobLabel), ls.Get(model.InstanceLabel)
+	exportJob, exportInstance := job != "" && job != tr.job, instance != "" && instance != tr.instance
+	if !exportJob && !exportInstance {
+		return ls
+	}
+	lb := labels.NewBuilder(ls)
+	if exportJob {
+		lb.Set(model.ExportedLabelPrefix+model.JobLabel, job)
+	}
+	if exportInstance {
+		lb.Set(model.ExportedLabelPrefix+model.InstanceLabel, instance)
+	}
+	return lb.Labels()
+}
+
 // submit metrics data to consumers
 func (tr *transaction) Commit() error {
-	if tr.isNew {
+	if len(tr.samples) == 0 {
 		// In a situation like not able to connect to the remote server, scrapeloop will still commit even if it had
-		// never added any data points, that the transaction has not been initialized.
+		// never added any data points.
 		return nil
 	}
+	if err := tr.initTransaction(); err != nil {
+		return err
+	}
 
 	ctx := obsreport.StartMetricsReceiveOp(tr.ctx, tr.receiverName, transport)
 	metrics, _, _, err := tr.metricBuilder.Build()
@@ -81,8 +81,11 @@ func Test_transaction(t *testing.T) {
 	t.Run("Add One No Target", func(t *testing.T) {
 		nomc := consumertest.NewMetricsNop()
 		tr := newTransaction(context.Background(), nil, true, "", rn, ms, nomc, testLogger)
-		if _, got := tr.Add(badLabels, time.Now().Unix()*1000, 1.0); got == nil {
-			t.Errorf("expecting error from Add() but got nil")
+		if _, got := tr.Add(badLabels, time.Now().Unix()*1000, 1.0); got != nil {
+			t.Errorf("expecting error == nil from Add() but got: %v\n", got)
+		}
+		if got := tr.Commit(); got == nil {
+			t.Errorf("expecting error from Commit() but got nil")
 		}
 	})
 
@@ -93,8 +96,11 @@ func Test_transaction(t *testing.T) {
 	t.Run("Add One Job not found", func(t *testing.T) {
 		nomc := consumertest.NewMetricsNop()
 		tr := newTransaction(context.Background(), nil, true, "", rn, ms, nomc, testLogger)
-		if _, got := tr.Add(jobNotFoundLb, time.Now().Unix()*1000, 1.0); got == nil {
-			t.Errorf("expecting error from Add() but got nil")
+		if _, got := tr.Add(jobNotFoundLb, time.Now().Unix()*1000, 1.0); got != nil {
+			t.Errorf("expecting error == nil from Add() but got: %v\n", got)
+		}
+		if got := tr.Commit(); got == nil {
+			t.Errorf("expecting error from Commit() but got nil")
 		}
 	})
 
@@ -107,7 +113,12 @@ func Test_transaction(t *testing.T) {
 		if _, got := tr.Add(goodLabels, time.Now().Unix()*1000, 1.0); got != nil {
 			t.Errorf("expecting error == nil from Add() but got: %v\n", got)
 		}
-		tr.metricBuilder.startTime = 1.0 // set to a non-zero value
+		startTimeLabels := labels.Labels([]labels.Label{{Name: "instance", Value: "localhost:8080"},
+			{Name: "job", Value: "test"},
+			{Name: "__name__", Value: "process_start_time_seconds"}})
+		if _, got := tr.Add(startTimeLabels, time.Now().Unix()*1000, 1.0); got != nil {
+			t.Errorf("expecting error == nil from Add() but got: %v\n", got)
+		}
 		if got := tr.Commit(); got != nil {
 			t.Errorf("expecting nil from Commit() but got err %v", got)
 		}
@@ -137,7 +148,6 @@ func Test_transaction(t *testing.T) {
 		if _, got := tr.Add(goodLabels, time.Now().Unix()*1000, 1.0); got != nil {
 			t.Errorf("expecting error == nil from Add() but got: %v\n", got)
 		}
-		tr.metricBuilder.startTime = 0 // zero value means the start time metric is missing
 		got := tr.Commit()
 		if got == nil {
 			t.Error("expecting error from Commit() but got nil")
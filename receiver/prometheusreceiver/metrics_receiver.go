@@ -15,9 +15,14 @@
 package prometheusreceiver
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"time"
 
+	"github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery"
 	"github.com/prometheus/prometheus/scrape"
 	"go.uber.org/zap"
@@ -33,6 +38,9 @@ type pReceiver struct {
 	consumer   consumer.MetricsConsumer
 	cancelFunc context.CancelFunc
 
+	// configContent is the content of the configuration file, if any, last applied.
+	configContent []byte
+
 	logger *zap.Logger
 }
 
@@ -52,22 +60,17 @@ func (r *pReceiver) Start(ctx context.Context, host component.Host) error {
 	discoveryCtx, cancel := context.WithCancel(context.Background())
 	r.cancelFunc = cancel
 
+	promCfg := r.cfg.PrometheusConfig
+	if r.cfg.ConfigFile != "" {
+		var err error
+		if promCfg, r.configContent, err = loadConfigFile(r.cfg.ConfigFile); err != nil {
+			return err
+		}
+	}
+
 	logger := internal.NewZapToGokitLogAdapter(r.logger)
 
 	discoveryManager := discovery.NewManager(discoveryCtx, logger)
-	discoveryCfg := make(map[string]discovery.Configs)
-	for _, scrapeConfig := range r.cfg.PrometheusConfig.ScrapeConfigs {
-		discoveryCfg[scrapeConfig.JobName] = scrapeConfig.ServiceDiscoveryConfigs
-	}
-	if err := discoveryManager.ApplyConfig(discoveryCfg); err != nil {
-		return err
-	}
-	go func() {
-		if err := discoveryManager.Run(); err != nil {
-			r.logger.Error("Discovery manager failed", zap.Error(err))
-			host.ReportFatalError(err)
-		}
-	}()
 
 	var jobsMap *internal.JobsMap
 	if !r.cfg.UseStartTimeMetric {
@@ -77,18 +80,98 @@ func (r *pReceiver) Start(ctx context.Context, host component.Host) error {
 
 	scrapeManager := scrape.NewManager(logger, ocaStore)
 	ocaStore.SetScrapeManager(scrapeManager)
-	if err := scrapeManager.ApplyConfig(r.cfg.PrometheusConfig); err != nil {
+	if err := applyConfig(promCfg, discoveryManager, scrapeManager); err != nil {
 		return err
 	}
+	go func() {
+		if err := discoveryManager.Run(); err != nil {
+			r.logger.Error("Discovery manager failed", zap.Error(err))
+			host.ReportFatalError(err)
+		}
+	}()
 	go func() {
 		if err := scrapeManager.Run(discoveryManager.SyncCh()); err != nil {
 			r.logger.Error("Scrape manager failed", zap.Error(err))
 			host.ReportFatalError(err)
 		}
 	}()
+
+	if r.cfg.ConfigFile != "" {
+		go r.reloadConfigFile(discoveryCtx, discoveryManager, scrapeManager)
+	}
 	return nil
 }
 
+// applyConfig applies the scrape configs to the managers, which only restart the scraping of the modified jobs.
+func applyConfig(promCfg *config.Config, discoveryManager *discovery.Manager, scrapeManager *scrape.Manager) error {
+	discoveryCfg := make(map[string]discovery.Configs)
+	for _, scrapeConfig := range promCfg.ScrapeConfigs {
+		discoveryCfg[scrapeConfig.JobName] = scrapeConfig.ServiceDiscoveryConfigs
+	}
+	if err := discoveryManager.ApplyConfig(discoveryCfg); err != nil {
+		return err
+	}
+	return scrapeManager.ApplyConfig(promCfg)
+}
+
+// loadConfigFile loads the Prometheus configuration file.
+func loadConfigFile(filename string) (*config.Config, []byte, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("prometheus receiver failed to read %s: %w", filename, err)
+	}
+	promCfg, err := parseConfigFile(filename, content)
+	return promCfg, content, err
+}
+
+// parseConfigFile parses the content of the Prometheus configuration file, whose relative paths are relative to its
+// directory, like Prometheus does.
+func parseConfigFile(filename string, content []byte) (*config.Config, error) {
+	promCfg, err := config.Load(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("prometheus receiver failed to parse %s: %w", filename, err)
+	}
+	if len(promCfg.ScrapeConfigs) == 0 {
+		return nil, errNilScrapeConfig
+	}
+	promCfg.SetDirectory(filepath.Dir(filename))
+	return promCfg, nil
+}
+
+// reloadConfigFile applies the configuration file every time it changes, until the context is cancelled. An invalid
+// configuration is reported and ignored, the scraping goes on with the previous one.
+func (r *pReceiver) reloadConfigFile(ctx context.Context, discoveryManager *discovery.Manager, scrapeManager *scrape.Manager) {
+	ticker := time.NewTicker(r.cfg.ConfigReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		content, err := ioutil.ReadFile(r.cfg.ConfigFile)
+		if err != nil {
+			r.logger.Warn("Failed to read the Prometheus configuration file", zap.Error(err))
+			continue
+		}
+		if bytes.Equal(content, r.configContent) {
+			continue
+		}
+		promCfg, err := parseConfigFile(r.cfg.ConfigFile, content)
+		if err == nil {
+			err = applyConfig(promCfg, discoveryManager, scrapeManager)
+		}
+		if err != nil {
+			r.logger.Error("Failed to reload the Prometheus configuration file", zap.String("file", r.cfg.ConfigFile), zap.Error(err))
+		} else {
+			r.logger.Info("Reloaded the Prometheus configuration file", zap.String("file", r.cfg.ConfigFile))
+		}
+		// An invalid content is only reported once.
+		r.configContent = content
+	}
+}
+
 // Shutdown stops and cancels the underlying Prometheus scrapers.
 func (r *pReceiver) Shutdown(context.Context) error {
 	r.cancelFunc()
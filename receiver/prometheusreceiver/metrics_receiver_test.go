@@ -17,21 +17,26 @@ package prometheusreceiver
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	promcfg "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/pkg/relabel"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -1180,3 +1185,123 @@ func testEndToEndRegex(t *testing.T, targets []*testData, useStartTimeMetric boo
 		target.validateFunc(t, target, results[target.name])
 	}
 }
+
+var honorLabelsPage = `
+# HELP node_load1 1m load average.
+# TYPE node_load1 gauge
+node_load1{job="node",instance="10.0.0.1:9100"} 0.5 1600000000000
+node_load1 0.7
+`
+
+// TestHonorLabels validates that the scraped job and instance labels, kept with honor_labels or set by
+// metric_relabel_configs, don't prevent from identifying the target, and become the exported_job and
+// exported_instance labels.
+func TestHonorLabels(t *testing.T) {
+	td := &testData{
+		name:  "target1",
+		pages: []mockPrometheusResponse{{code: 200, data: honorLabelsPage}},
+	}
+	mp, cfg, err := setupMockPrometheus(td)
+	require.NoError(t, err)
+	defer mp.Close()
+	rc := relabel.DefaultRelabelConfig
+	rc.TargetLabel = "cluster"
+	rc.Replacement = "east"
+	cfg.ScrapeConfigs[0].HonorLabels = true
+	cfg.ScrapeConfigs[0].MetricRelabelConfigs = []*relabel.Config{&rc}
+
+	cms := new(consumertest.MetricsSink)
+	rcvr := newPrometheusReceiver(logger, &Config{PrometheusConfig: cfg}, cms)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	defer rcvr.Shutdown(context.Background())
+	mp.wg.Wait()
+
+	var mds []internaldata.MetricsData
+	for _, md := range cms.AllMetrics() {
+		mds = append(mds, internaldata.MetricsToOC(md)...)
+	}
+	require.NotEmpty(t, mds)
+	doCompare("node", t, td.node, mds[0].Node)
+	doCompare("resource", t, td.resource, mds[0].Resource)
+	require.Len(t, mds[0].Metrics, 1)
+
+	metric := mds[0].Metrics[0]
+	assert.Equal(t, "node_load1", metric.MetricDescriptor.Name)
+	var keys []string
+	for _, k := range metric.MetricDescriptor.LabelKeys {
+		keys = append(keys, k.Key)
+	}
+	assert.Equal(t, []string{"cluster", "exported_instance", "exported_job"}, keys)
+	require.Len(t, metric.Timeseries, 2)
+	assert.Equal(t, []*metricspb.LabelValue{
+		{Value: "east", HasValue: true},
+		{Value: "10.0.0.1:9100", HasValue: true},
+		{Value: "node", HasValue: true},
+	}, metric.Timeseries[0].LabelValues)
+	// The timestamp of the sample is honored.
+	assert.Equal(t, &timestamppb.Timestamp{Seconds: 1600000000}, metric.Timeseries[0].Points[0].Timestamp)
+	assert.Equal(t, []*metricspb.LabelValue{
+		{Value: "east", HasValue: true},
+		{Value: "", HasValue: false},
+		{Value: "", HasValue: false},
+	}, metric.Timeseries[1].LabelValues)
+}
+
+var configFilePage = `
+# HELP node_load1 1m load average.
+# TYPE node_load1 gauge
+node_load1 0.5
+`
+
+// TestConfigFileReload validates that the scrape configs of the configuration file are reloaded when it changes.
+func TestConfigFileReload(t *testing.T) {
+	mp := newMockPrometheus(map[string][]mockPrometheusResponse{
+		"/target1/metrics": {{code: 200, data: configFilePage}},
+		"/target2/metrics": {{code: 200, data: configFilePage}},
+	})
+	defer mp.Close()
+	u, err := url.Parse(mp.srv.URL)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "prometheusreceiver")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "prometheus.yml")
+	writeConfigFile := func(job string) {
+		content := fmt.Sprintf(`
+scrape_configs:
+  - job_name: %s
+    scrape_interval: 1s
+    metrics_path: /%s/metrics
+    static_configs:
+      - targets: ['%s']
+`, job, job, u.Host)
+		require.NoError(t, ioutil.WriteFile(configFile, []byte(content), 0600))
+	}
+	writeConfigFile("target1")
+
+	cms := new(consumertest.MetricsSink)
+	rcvr := newPrometheusReceiver(logger, &Config{ConfigFile: configFile, ConfigReloadInterval: 100 * time.Millisecond}, cms)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	defer rcvr.Shutdown(context.Background())
+
+	jobs := func() map[string]bool {
+		jobs := make(map[string]bool)
+		for _, md := range cms.AllMetrics() {
+			for _, ocmd := range internaldata.MetricsToOC(md) {
+				jobs[ocmd.Node.ServiceInfo.Name] = true
+			}
+		}
+		return jobs
+	}
+	require.Eventually(t, func() bool { return jobs()["target1"] }, 10*time.Second, 100*time.Millisecond)
+
+	writeConfigFile("target2")
+	require.Eventually(t, func() bool { return jobs()["target2"] }, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestConfigFileStartError(t *testing.T) {
+	rcvr := newPrometheusReceiver(logger, &Config{ConfigFile: "does-not-exist.yml", ConfigReloadInterval: time.Second}, consumertest.NewMetricsNop())
+	assert.Error(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, rcvr.Shutdown(context.Background()))
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsarreceiver
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for Pulsar receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+	// The URL of the Pulsar service (default pulsar://localhost:6650)
+	ServiceURL string `mapstructure:"service_url"`
+	// The name of the pulsar topic to consume from (default otlp_spans for traces, otlp_metrics for metrics,
+	// otlp_logs for logs)
+	Topic string `mapstructure:"topic"`
+	// Encoding of the messages (default "otlp_proto")
+	Encoding string `mapstructure:"encoding"`
+	// The subscription that receiver will be consuming messages from (default "otel-collector")
+	Subscription string `mapstructure:"subscription"`
+	// The type of the subscription: "shared", the messages being dispatched to the consumers of the subscription
+	// in a round-robin way, or "key_shared", the messages with the same key being dispatched to the same consumer,
+	// in order (default "shared")
+	SubscriptionType string `mapstructure:"subscription_type"`
+
+	// The path of the trusted TLS certificate file of the pulsar+ssl:// service URLs
+	TLSTrustCertsFile string `mapstructure:"tls_trust_certs_file"`
+	// Whether the certificate of the service is accepted even if it is not trusted
+	TLSAllowInsecureConnection bool `mapstructure:"tls_allow_insecure_connection"`
+
+	Authentication Authentication `mapstructure:"auth"`
+}
+
+// Authentication defines the authentication of the receiver to the Pulsar service.
+type Authentication struct {
+	// Token is the JSON Web Token the receiver authenticates with.
+	Token string `mapstructure:"token"`
+	// TLS configures the client certificate the receiver authenticates with.
+	TLS *TLSAuthentication `mapstructure:"tls"`
+}
+
+// TLSAuthentication defines the client certificate of the TLS authentication.
+type TLSAuthentication struct {
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsarreceiver
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(cfg.Receivers))
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[typeStr])
+
+	r := cfg.Receivers["pulsar/secure"].(*Config)
+	assert.Equal(t, &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			NameVal: "pulsar/secure",
+			TypeVal: typeStr,
+		},
+		ServiceURL:        "pulsar+ssl://pulsar.example.com:6651",
+		Topic:             "persistent://telemetry/otel/spans",
+		Encoding:          "otlp_proto",
+		Subscription:      "collectors",
+		SubscriptionType:  "key_shared",
+		TLSTrustCertsFile: "/etc/otel/ca.pem",
+		Authentication: Authentication{
+			Token: "secret",
+		},
+	}, r)
+}
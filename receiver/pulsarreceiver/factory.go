@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsarreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const (
+	typeStr                 = "pulsar"
+	defaultServiceURL       = "pulsar://localhost:6650"
+	defaultTracesTopic      = "otlp_spans"
+	defaultMetricsTopic     = "otlp_metrics"
+	defaultLogsTopic        = "otlp_logs"
+	defaultEncoding         = "otlp_proto"
+	defaultSubscription     = "otel-collector"
+	defaultSubscriptionType = subscriptionShared
+
+	subscriptionShared    = "shared"
+	subscriptionKeyShared = "key_shared"
+)
+
+// NewFactory creates Pulsar receiver factory.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithTraces(createTraceReceiver),
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
+}
+
+func createDefaultConfig() configmodels.Receiver {
+	return &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		ServiceURL:       defaultServiceURL,
+		Topic:            "",
+		Encoding:         defaultEncoding,
+		Subscription:     defaultSubscription,
+		SubscriptionType: defaultSubscriptionType,
+	}
+}
+
+func createTraceReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.TracesConsumer,
+) (component.TracesReceiver, error) {
+	c := cfg.(*Config)
+	return newReceiver(*c, params, defaultTracesTopic, tracesConsumeFunc(c.Name(), nextConsumer))
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.MetricsConsumer,
+) (component.MetricsReceiver, error) {
+	c := cfg.(*Config)
+	return newReceiver(*c, params, defaultMetricsTopic, metricsConsumeFunc(c.Name(), nextConsumer))
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	c := cfg.(*Config)
+	return newReceiver(*c, params, defaultLogsTopic, logsConsumeFunc(c.Name(), nextConsumer))
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsarreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.Equal(t, defaultServiceURL, cfg.ServiceURL)
+	assert.Equal(t, "", cfg.Topic)
+	assert.Equal(t, defaultSubscription, cfg.Subscription)
+	assert.Equal(t, defaultSubscriptionType, cfg.SubscriptionType)
+}
+
+func TestCreateReceivers(t *testing.T) {
+	f := NewFactory()
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	t.Run("traces", func(t *testing.T) {
+		r, err := f.CreateTracesReceiver(context.Background(), params, createDefaultConfig(), consumertest.NewTracesNop())
+		require.NoError(t, err)
+		assert.Equal(t, defaultTracesTopic, r.(*pulsarConsumer).consumerOptions.Topic)
+	})
+	t.Run("metrics", func(t *testing.T) {
+		r, err := f.CreateMetricsReceiver(context.Background(), params, createDefaultConfig(), consumertest.NewMetricsNop())
+		require.NoError(t, err)
+		assert.Equal(t, defaultMetricsTopic, r.(*pulsarConsumer).consumerOptions.Topic)
+	})
+	t.Run("logs", func(t *testing.T) {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Topic = "logs"
+		r, err := f.CreateLogsReceiver(context.Background(), params, cfg, consumertest.NewLogsNop())
+		require.NoError(t, err)
+		assert.Equal(t, "logs", r.(*pulsarConsumer).consumerOptions.Topic)
+	})
+}
+
+func TestCreateReceiver_error(t *testing.T) {
+	f := NewFactory()
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "encoding",
+			modify:  func(cfg *Config) { cfg.Encoding = "jaeger_proto" },
+			wantErr: "unrecognized encoding",
+		},
+		{
+			name:    "subscription_type",
+			modify:  func(cfg *Config) { cfg.SubscriptionType = "exclusive" },
+			wantErr: `invalid subscription_type "exclusive", expecting "shared" or "key_shared"`,
+		},
+		{
+			name: "auth",
+			modify: func(cfg *Config) {
+				cfg.Authentication = Authentication{
+					Token: "secret",
+					TLS:   &TLSAuthentication{CertFile: "cert.pem", KeyFile: "key.pem"},
+				}
+			},
+			wantErr: errAmbiguousAuth.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			r, err := f.CreateTracesReceiver(context.Background(), params, cfg, consumertest.NewTracesNop())
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, r)
+		})
+	}
+}
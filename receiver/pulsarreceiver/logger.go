@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsarreceiver
+
+import (
+	"github.com/apache/pulsar-client-go/pulsar/log"
+	"go.uber.org/zap"
+)
+
+// zapLogger writes the logs of the pulsar client to the logger of the receiver.
+type zapLogger struct {
+	logger *zap.SugaredLogger
+}
+
+var _ log.Logger = (*zapLogger)(nil)
+
+func newLogger(logger *zap.Logger) log.Logger {
+	return &zapLogger{logger: logger.Sugar()}
+}
+
+func (l *zapLogger) SubLogger(fields log.Fields) log.Logger {
+	return &zapLogger{logger: l.with(fields)}
+}
+
+func (l *zapLogger) WithFields(fields log.Fields) log.Entry {
+	return &zapLogger{logger: l.with(fields)}
+}
+
+func (l *zapLogger) WithField(name string, value interface{}) log.Entry {
+	return &zapLogger{logger: l.logger.With(name, value)}
+}
+
+func (l *zapLogger) WithError(err error) log.Entry {
+	return &zapLogger{logger: l.logger.With(zap.Error(err))}
+}
+
+func (l *zapLogger) with(fields log.Fields) *zap.SugaredLogger {
+	args := make([]interface{}, 0, 2*len(fields))
+	for name, value := range fields {
+		args = append(args, name, value)
+	}
+	return l.logger.With(args...)
+}
+
+func (l *zapLogger) Debug(args ...interface{}) {
+	l.logger.Debug(args...)
+}
+
+func (l *zapLogger) Info(args ...interface{}) {
+	l.logger.Info(args...)
+}
+
+func (l *zapLogger) Warn(args ...interface{}) {
+	l.logger.Warn(args...)
+}
+
+func (l *zapLogger) Error(args ...interface{}) {
+	l.logger.Error(args...)
+}
+
+func (l *zapLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Debugf(format, args...)
+}
+
+func (l *zapLogger) Infof(format string, args ...interface{}) {
+	l.logger.Infof(format, args...)
+}
+
+func (l *zapLogger) Warnf(format string, args ...interface{}) {
+	l.logger.Warnf(format, args...)
+}
+
+func (l *zapLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Errorf(format, args...)
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsarreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	transport = "pulsar"
+)
+
+var (
+	errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
+	errAmbiguousAuth        = errors.New("only one of the token and tls authentications can be configured")
+)
+
+// consumeFunc unmarshals the payload of a message and passes the data to the next consumer. The unmarshalling
+// errors are permanent.
+type consumeFunc func(ctx context.Context, payload []byte) error
+
+// pulsarConsumer consumes and handles the messages of a pulsar topic.
+type pulsarConsumer struct {
+	name              string
+	clientOptions     pulsar.ClientOptions
+	consumerOptions   pulsar.ConsumerOptions
+	newClient         func(pulsar.ClientOptions) (pulsar.Client, error)
+	consume           consumeFunc
+	client            pulsar.Client
+	consumer          pulsar.Consumer
+	cancelConsumeLoop context.CancelFunc
+	done              sync.WaitGroup
+
+	logger *zap.Logger
+}
+
+var _ component.Receiver = (*pulsarConsumer)(nil)
+
+func newReceiver(config Config, params component.ReceiverCreateParams, defaultTopic string, consume consumeFunc) (*pulsarConsumer, error) {
+	if config.Encoding != defaultEncoding {
+		return nil, errUnrecognizedEncoding
+	}
+	var subscriptionType pulsar.SubscriptionType
+	switch config.SubscriptionType {
+	case subscriptionShared:
+		subscriptionType = pulsar.Shared
+	case subscriptionKeyShared:
+		subscriptionType = pulsar.KeyShared
+	default:
+		return nil, fmt.Errorf("invalid subscription_type %q, expecting %q or %q",
+			config.SubscriptionType, subscriptionShared, subscriptionKeyShared)
+	}
+	auth, err := newAuthentication(config.Authentication)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := config.Topic
+	if topic == "" {
+		topic = defaultTopic
+	}
+	return &pulsarConsumer{
+		name: config.Name(),
+		clientOptions: pulsar.ClientOptions{
+			URL:                        config.ServiceURL,
+			Authentication:             auth,
+			TLSTrustCertsFilePath:      config.TLSTrustCertsFile,
+			TLSAllowInsecureConnection: config.TLSAllowInsecureConnection,
+			Logger:                     newLogger(params.Logger),
+		},
+		consumerOptions: pulsar.ConsumerOptions{
+			Topic:            topic,
+			SubscriptionName: config.Subscription,
+			Type:             subscriptionType,
+		},
+		newClient: pulsar.NewClient,
+		consume:   consume,
+		logger:    params.Logger,
+	}, nil
+}
+
+func newAuthentication(config Authentication) (pulsar.Authentication, error) {
+	switch {
+	case config.Token != "" && config.TLS != nil:
+		return nil, errAmbiguousAuth
+	case config.Token != "":
+		return pulsar.NewAuthenticationToken(config.Token), nil
+	case config.TLS != nil:
+		return pulsar.NewAuthenticationTLS(config.TLS.CertFile, config.TLS.KeyFile), nil
+	}
+	return nil, nil
+}
+
+func (c *pulsarConsumer) Start(context.Context, component.Host) error {
+	client, err := c.newClient(c.clientOptions)
+	if err != nil {
+		return err
+	}
+	pulsarConsumer, err := client.Subscribe(c.consumerOptions)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("failed to subscribe to %s: %w", c.consumerOptions.Topic, err)
+	}
+	c.client = client
+	c.consumer = pulsarConsumer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancelConsumeLoop = cancel
+	c.done.Add(1)
+	go c.consumeLoop(ctx)
+	return nil
+}
+
+func (c *pulsarConsumer) consumeLoop(ctx context.Context) {
+	defer c.done.Done()
+	for {
+		message, err := c.consumer.Receive(ctx)
+		if err != nil {
+			// check if context was cancelled, signaling that the consumer should stop
+			if ctx.Err() == nil {
+				c.logger.Error("Error from consumer", zap.Error(err))
+			}
+			return
+		}
+		c.logger.Debug("Pulsar message received",
+			zap.String("topic", message.Topic()),
+			zap.String("key", message.Key()),
+			zap.Time("publish_time", message.PublishTime()))
+
+		ctx := obsreport.ReceiverContext(ctx, c.name, transport)
+		err = c.consume(ctx, message.Payload())
+		switch {
+		case err == nil:
+			c.consumer.Ack(message)
+		case consumererror.IsPermanent(err):
+			// The message would fail again: it is dropped.
+			c.logger.Error("Failed to handle message, dropping it", zap.String("topic", message.Topic()), zap.Error(err))
+			c.consumer.Ack(message)
+		default:
+			// The message is redelivered after the redelivery delay, possibly to another consumer of the subscription.
+			c.logger.Debug("Failed to handle message, redelivering it", zap.String("topic", message.Topic()), zap.Error(err))
+			c.consumer.Nack(message)
+		}
+	}
+}
+
+func (c *pulsarConsumer) Shutdown(context.Context) error {
+	if c.cancelConsumeLoop == nil {
+		return nil
+	}
+	c.cancelConsumeLoop()
+	c.done.Wait()
+	c.consumer.Close()
+	c.client.Close()
+	return nil
+}
+
+func tracesConsumeFunc(name string, nextConsumer consumer.TracesConsumer) consumeFunc {
+	return func(ctx context.Context, payload []byte) error {
+		ctx = obsreport.StartTraceDataReceiveOp(ctx, name, transport)
+		traces := pdata.NewTraces()
+		if err := traces.FromOtlpProtoBytes(payload); err != nil {
+			obsreport.EndTraceDataReceiveOp(ctx, defaultEncoding, 0, err)
+			return consumererror.Permanent(err)
+		}
+		err := nextConsumer.ConsumeTraces(ctx, traces)
+		obsreport.EndTraceDataReceiveOp(ctx, defaultEncoding, traces.SpanCount(), err)
+		return err
+	}
+}
+
+func metricsConsumeFunc(name string, nextConsumer consumer.MetricsConsumer) consumeFunc {
+	return func(ctx context.Context, payload []byte) error {
+		ctx = obsreport.StartMetricsReceiveOp(ctx, name, transport)
+		metrics := pdata.NewMetrics()
+		if err := metrics.FromOtlpProtoBytes(payload); err != nil {
+			obsreport.EndMetricsReceiveOp(ctx, defaultEncoding, 0, err)
+			return consumererror.Permanent(err)
+		}
+		_, dataPointCount := metrics.MetricAndDataPointCount()
+		err := nextConsumer.ConsumeMetrics(ctx, metrics)
+		obsreport.EndMetricsReceiveOp(ctx, defaultEncoding, dataPointCount, err)
+		return err
+	}
+}
+
+func logsConsumeFunc(name string, nextConsumer consumer.LogsConsumer) consumeFunc {
+	return func(ctx context.Context, payload []byte) error {
+		ctx = obsreport.StartLogsReceiveOp(ctx, name, transport)
+		logs := pdata.NewLogs()
+		if err := logs.FromOtlpProtoBytes(payload); err != nil {
+			obsreport.EndLogsReceiveOp(ctx, defaultEncoding, 0, err)
+			return consumererror.Permanent(err)
+		}
+		err := nextConsumer.ConsumeLogs(ctx, logs)
+		obsreport.EndLogsReceiveOp(ctx, defaultEncoding, logs.LogRecordCount(), err)
+		return err
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsarreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+type fakeMessage struct {
+	pulsar.Message
+	payload []byte
+}
+
+func (m *fakeMessage) Topic() string          { return defaultTracesTopic }
+func (m *fakeMessage) Key() string            { return "" }
+func (m *fakeMessage) PublishTime() time.Time { return time.Now() }
+func (m *fakeMessage) Payload() []byte        { return m.payload }
+
+// fakeConsumer delivers the messages of a channel, recording the acknowledgments.
+type fakeConsumer struct {
+	pulsar.Consumer
+	messages chan pulsar.Message
+
+	mu     sync.Mutex
+	acked  []pulsar.Message
+	nacked []pulsar.Message
+	closed bool
+}
+
+func (c *fakeConsumer) Receive(ctx context.Context) (pulsar.Message, error) {
+	select {
+	case message := <-c.messages:
+		return message, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *fakeConsumer) Ack(message pulsar.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.acked = append(c.acked, message)
+}
+
+func (c *fakeConsumer) Nack(message pulsar.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nacked = append(c.nacked, message)
+}
+
+func (c *fakeConsumer) Close() {
+	c.closed = true
+}
+
+func (c *fakeConsumer) acknowledgments() (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.acked), len(c.nacked)
+}
+
+type fakeClient struct {
+	pulsar.Client
+	consumer     *fakeConsumer
+	subscribeErr error
+	options      pulsar.ConsumerOptions
+	closed       bool
+}
+
+func (c *fakeClient) Subscribe(options pulsar.ConsumerOptions) (pulsar.Consumer, error) {
+	c.options = options
+	if c.subscribeErr != nil {
+		return nil, c.subscribeErr
+	}
+	return c.consumer, nil
+}
+
+func (c *fakeClient) Close() {
+	c.closed = true
+}
+
+func newTestReceiver(t *testing.T, client *fakeClient, consume consumeFunc) *pulsarConsumer {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SubscriptionType = subscriptionKeyShared
+	r, err := newReceiver(*cfg, component.ReceiverCreateParams{Logger: zap.NewNop()}, defaultTracesTopic, consume)
+	require.NoError(t, err)
+	r.newClient = func(options pulsar.ClientOptions) (pulsar.Client, error) {
+		assert.Equal(t, defaultServiceURL, options.URL)
+		return client, nil
+	}
+	return r
+}
+
+func TestReceiveTraces(t *testing.T) {
+	client := &fakeClient{consumer: &fakeConsumer{messages: make(chan pulsar.Message)}}
+	sink := new(consumertest.TracesSink)
+	r := newTestReceiver(t, client, tracesConsumeFunc(typeStr, sink))
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, pulsar.ConsumerOptions{
+		Topic:            defaultTracesTopic,
+		SubscriptionName: defaultSubscription,
+		Type:             pulsar.KeyShared,
+	}, client.options)
+
+	payload, err := testdata.GenerateTraceDataOneSpan().ToOtlpProtoBytes()
+	require.NoError(t, err)
+	client.consumer.messages <- &fakeMessage{payload: payload}
+	// An invalid message is dropped.
+	client.consumer.messages <- &fakeMessage{payload: []byte("invalid")}
+	client.consumer.messages <- &fakeMessage{payload: payload}
+
+	require.Eventually(t, func() bool {
+		acked, _ := client.consumer.acknowledgments()
+		return acked == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, 2, sink.SpansCount())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.True(t, client.consumer.closed)
+	assert.True(t, client.closed)
+}
+
+func TestReceiveMetricsAndLogs(t *testing.T) {
+	metricsPayload, err := testdata.GenerateMetricsOneMetric().ToOtlpProtoBytes()
+	require.NoError(t, err)
+	metricsSink := new(consumertest.MetricsSink)
+	require.NoError(t, metricsConsumeFunc(typeStr, metricsSink)(context.Background(), metricsPayload))
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+
+	logsPayload, err := testdata.GenerateLogDataOneLog().ToOtlpProtoBytes()
+	require.NoError(t, err)
+	logsSink := new(consumertest.LogsSink)
+	require.NoError(t, logsConsumeFunc(typeStr, logsSink)(context.Background(), logsPayload))
+	assert.Equal(t, 1, logsSink.LogRecordsCount())
+}
+
+func TestConsumeError(t *testing.T) {
+	client := &fakeClient{consumer: &fakeConsumer{messages: make(chan pulsar.Message)}}
+	r := newTestReceiver(t, client, tracesConsumeFunc(typeStr, consumertest.NewTracesErr(errors.New("queue is full"))))
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	payload, err := testdata.GenerateTraceDataOneSpan().ToOtlpProtoBytes()
+	require.NoError(t, err)
+	client.consumer.messages <- &fakeMessage{payload: payload}
+
+	// The message is redelivered.
+	require.Eventually(t, func() bool {
+		_, nacked := client.consumer.acknowledgments()
+		return nacked == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	acked, _ := client.consumer.acknowledgments()
+	assert.Equal(t, 0, acked)
+}
+
+func TestStartError(t *testing.T) {
+	client := &fakeClient{subscribeErr: errors.New("topic not found")}
+	r := newTestReceiver(t, client, tracesConsumeFunc(typeStr, consumertest.NewTracesNop()))
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()),
+		"failed to subscribe to otlp_spans: topic not found")
+	assert.True(t, client.closed)
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// watchObserversConfigKey is the key of the types of the observers watched.
+	watchObserversConfigKey = "watch_observers"
+	// receiversConfigKey is the key of the receiver templates.
+	receiversConfigKey = "receivers"
+)
+
+// Config defines configuration for the receiver creator.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// WatchObservers are the types of the observer extensions, e.g. "k8s_observer", whose endpoints are matched
+	// against the rules of the receiver templates.
+	WatchObservers []configmodels.Type `mapstructure:"watch_observers"`
+
+	// receiverTemplates are the templates of the receivers created, by full name, e.g. "redis/1".
+	receiverTemplates map[string]receiverTemplate
+}
+
+// receiverTemplate is the template of the receivers created for the endpoints matching its rule.
+type receiverTemplate struct {
+	// Rule is the expression matching the endpoints the receiver is created for, e.g. `type == "port" && port == 6379`.
+	Rule string `mapstructure:"rule"`
+
+	// Config is the configuration of the receiver, whose string values can contain `expressions` evaluated with the
+	// endpoint. The endpoint of the receiver is the target of the endpoint if the configuration doesn't set it.
+	Config map[string]interface{} `mapstructure:"config"`
+
+	// ResourceAttributes are added to the resources of the data of the receiver, in addition to the attributes
+	// identifying the endpoint. Their values can contain `expressions` too.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+
+	fullName     string
+	receiverType configmodels.Type
+	rule         *rule
+}
+
+// customUnmarshaler decodes the receiver templates, whose configuration is decoded when the receivers are created.
+func customUnmarshaler(componentViperSection *viper.Viper, intoCfg interface{}) error {
+	if componentViperSection == nil {
+		return nil
+	}
+	cfg := intoCfg.(*Config)
+
+	for key := range componentViperSection.AllSettings() {
+		if key != watchObserversConfigKey && key != receiversConfigKey {
+			return fmt.Errorf("unknown key %q", key)
+		}
+	}
+	if err := componentViperSection.Unmarshal(cfg); err != nil {
+		return err
+	}
+
+	receiversSection, err := config.ViperSubExact(componentViperSection, receiversConfigKey)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", receiversConfigKey, err)
+	}
+	cfg.receiverTemplates = make(map[string]receiverTemplate)
+	for fullName := range receiversSection.AllSettings() {
+		templateSection, err := config.ViperSubExact(receiversSection, fullName)
+		if err != nil {
+			return fmt.Errorf("invalid receiver %q: %w", fullName, err)
+		}
+		template, err := newReceiverTemplate(fullName, templateSection)
+		if err != nil {
+			return fmt.Errorf("invalid receiver %q: %w", fullName, err)
+		}
+		cfg.receiverTemplates[fullName] = template
+	}
+	return nil
+}
+
+func newReceiverTemplate(fullName string, templateSection *viper.Viper) (receiverTemplate, error) {
+	template := receiverTemplate{
+		fullName:     fullName,
+		receiverType: configmodels.Type(strings.SplitN(fullName, "/", 2)[0]),
+	}
+	if err := templateSection.UnmarshalExact(&template); err != nil {
+		return template, err
+	}
+	if template.Rule == "" {
+		return template, fmt.Errorf("rule is required")
+	}
+	var err error
+	if template.rule, err = newRule(template.Rule); err != nil {
+		return template, err
+	}
+	return template, nil
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(cfg.Receivers))
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[typeStr])
+
+	r := cfg.Receivers["receiver_creator/1"].(*Config)
+	assert.Equal(t, configmodels.ReceiverSettings{
+		NameVal: "receiver_creator/1",
+		TypeVal: typeStr,
+	}, r.ReceiverSettings)
+	assert.Equal(t, []configmodels.Type{"k8s_observer", "host_observer"}, r.WatchObservers)
+	require.Len(t, r.receiverTemplates, 2)
+
+	redis := r.receiverTemplates["examplereceiver/1"]
+	assert.Equal(t, "examplereceiver/1", redis.fullName)
+	assert.Equal(t, configmodels.Type("examplereceiver"), redis.receiverType)
+	assert.Equal(t, `type == "port" && port == 6379`, redis.Rule)
+	assert.Equal(t, map[string]interface{}{"extra": "`pod.name`"}, redis.Config)
+	assert.Equal(t, map[string]string{"app": "redis"}, redis.ResourceAttributes)
+	assert.NotNil(t, redis.rule)
+
+	nginx := r.receiverTemplates["examplereceiver/2"]
+	assert.Equal(t, `type == "hostport" && process_name == "nginx"`, nginx.Rule)
+	assert.Equal(t, map[string]interface{}{"endpoint": "`endpoint`"}, nginx.Config)
+}
+
+func TestLoadInvalidConfig(t *testing.T) {
+	tests := []struct {
+		file string
+		err  string
+	}{
+		{file: "invalid_rule.yaml", err: "invalid rule"},
+		{file: "missing_rule.yaml", err: "rule is required"},
+		{file: "unknown_key.yaml", err: `unknown key "unknown"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			factories, err := componenttest.ExampleComponents()
+			assert.NoError(t, err)
+			factories.Receivers[typeStr] = NewFactory()
+
+			_, err = configtest.LoadConfigFile(t, path.Join(".", "testdata", tt.file), factories)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
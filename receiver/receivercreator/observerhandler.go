@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+// observerHandler creates and starts the receivers of the templates matching
+// the endpoints added by the observers, and shuts them down once their
+// endpoint is removed.
+type observerHandler struct {
+	params       component.ReceiverCreateParams
+	config       *Config
+	host         component.Host
+	nextConsumer consumer.MetricsConsumer
+
+	mu sync.Mutex
+	// receivers are the receivers started for each endpoint.
+	receivers map[observer.EndpointID][]component.Receiver
+}
+
+var _ observer.Notify = (*observerHandler)(nil)
+
+func newObserverHandler(params component.ReceiverCreateParams, config *Config, host component.Host, nextConsumer consumer.MetricsConsumer) *observerHandler {
+	return &observerHandler{
+		params:       params,
+		config:       config,
+		host:         host,
+		nextConsumer: nextConsumer,
+		receivers:    make(map[observer.EndpointID][]component.Receiver),
+	}
+}
+
+// OnAdd implements observer.Notify.
+func (h *observerHandler) OnAdd(added []observer.Endpoint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, endpoint := range added {
+		if _, ok := h.receivers[endpoint.ID]; ok {
+			continue
+		}
+		h.receivers[endpoint.ID] = h.startReceivers(endpoint)
+	}
+}
+
+// OnRemove implements observer.Notify.
+func (h *observerHandler) OnRemove(removed []observer.Endpoint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, endpoint := range removed {
+		h.shutdownReceivers(endpoint.ID)
+	}
+}
+
+// OnChange implements observer.Notify: the receivers of the changed endpoints
+// are recreated, as the templates may not match them anymore or be expanded
+// differently.
+func (h *observerHandler) OnChange(changed []observer.Endpoint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, endpoint := range changed {
+		h.shutdownReceivers(endpoint.ID)
+		h.receivers[endpoint.ID] = h.startReceivers(endpoint)
+	}
+}
+
+// shutdown shuts down all the receivers.
+func (h *observerHandler) shutdown() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var errs []error
+	for id, receivers := range h.receivers {
+		for _, r := range receivers {
+			if err := r.Shutdown(context.Background()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		delete(h.receivers, id)
+	}
+	return consumererror.CombineErrors(errs)
+}
+
+func (h *observerHandler) shutdownReceivers(id observer.EndpointID) {
+	for _, r := range h.receivers[id] {
+		if err := r.Shutdown(context.Background()); err != nil {
+			h.params.Logger.Error("Failed to shut down the receiver", zap.String("endpoint", string(id)), zap.Error(err))
+		}
+	}
+	delete(h.receivers, id)
+}
+
+// startReceivers starts the receivers of the templates matching the endpoint.
+// The receivers which can't be started are logged, so that the other endpoints
+// are still collected.
+func (h *observerHandler) startReceivers(endpoint observer.Endpoint) []component.Receiver {
+	env := endpoint.Env()
+	var receivers []component.Receiver
+	for _, template := range h.config.receiverTemplates {
+		logger := h.params.Logger.With(zap.String("receiver", template.fullName), zap.String("endpoint", string(endpoint.ID)))
+		matches, err := template.rule.matches(env)
+		if err != nil {
+			logger.Debug("Failed to evaluate the rule", zap.Error(err))
+			continue
+		}
+		if !matches {
+			continue
+		}
+
+		r, err := h.startReceiver(template, endpoint, env)
+		if err != nil {
+			logger.Error("Failed to start the receiver", zap.Error(err))
+			continue
+		}
+		logger.Info("Started the receiver", zap.String("target", endpoint.Target))
+		receivers = append(receivers, r)
+	}
+	return receivers
+}
+
+func (h *observerHandler) startReceiver(template receiverTemplate, endpoint observer.Endpoint, env observer.EndpointEnv) (component.Receiver, error) {
+	factory, ok := h.host.GetFactory(component.KindReceiver, template.receiverType).(component.ReceiverFactory)
+	if !ok {
+		return nil, fmt.Errorf("unknown receiver type %q", template.receiverType)
+	}
+
+	userConfig, err := expandConfig(template.Config, env)
+	if err != nil {
+		return nil, err
+	}
+	cfg := factory.CreateDefaultConfig()
+	if _, ok := userConfig["endpoint"]; !ok && hasEndpointKey(cfg) {
+		userConfig["endpoint"] = endpoint.Target
+	}
+	v := config.NewViper()
+	if err = v.MergeConfigMap(userConfig); err != nil {
+		return nil, err
+	}
+	if err = unmarshal(factory, v, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	cfg.SetName(fmt.Sprintf("%s/%s", template.fullName, endpoint.ID))
+
+	attrs, err := resourceAttributes(template, endpoint, env)
+	if err != nil {
+		return nil, err
+	}
+	params := component.ReceiverCreateParams{
+		Logger:               h.params.Logger.With(zap.String("component_kind", "receiver"), zap.String("component_name", cfg.Name())),
+		ApplicationStartInfo: h.params.ApplicationStartInfo,
+	}
+	r, err := factory.CreateMetricsReceiver(context.Background(), params, cfg, &resourceEnhancer{next: h.nextConsumer, attrs: attrs})
+	if err != nil {
+		return nil, err
+	}
+	if err = r.Start(context.Background(), h.host); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// unmarshal decodes the configuration of a receiver like the configuration of
+// the collector.
+func unmarshal(factory component.ReceiverFactory, v *viper.Viper, cfg interface{}) error {
+	if fu, ok := factory.(component.ConfigUnmarshaler); ok {
+		return fu.Unmarshal(v, cfg)
+	}
+	return v.UnmarshalExact(cfg)
+}
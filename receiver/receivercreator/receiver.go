@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+// receiverCreator creates the receivers of its templates for the endpoints of
+// the watched observers.
+type receiverCreator struct {
+	params       component.ReceiverCreateParams
+	config       *Config
+	nextConsumer consumer.MetricsConsumer
+
+	handler     *observerHandler
+	observables []observer.Observable
+}
+
+var _ component.MetricsReceiver = (*receiverCreator)(nil)
+
+func newReceiverCreator(params component.ReceiverCreateParams, config *Config, nextConsumer consumer.MetricsConsumer) *receiverCreator {
+	return &receiverCreator{
+		params:       params,
+		config:       config,
+		nextConsumer: nextConsumer,
+	}
+}
+
+// Start subscribes to the watched observers.
+func (rc *receiverCreator) Start(_ context.Context, host component.Host) error {
+	for _, template := range rc.config.receiverTemplates {
+		if _, ok := host.GetFactory(component.KindReceiver, template.receiverType).(component.ReceiverFactory); !ok {
+			return fmt.Errorf("unknown receiver type %q of receiver %q", template.receiverType, template.fullName)
+		}
+	}
+
+	watched := make(map[configmodels.Type]bool)
+	for _, t := range rc.config.WatchObservers {
+		watched[t] = true
+	}
+	var observables []observer.Observable
+	for entity, ext := range host.GetExtensions() {
+		if !watched[entity.Type()] {
+			continue
+		}
+		observable, ok := ext.(observer.Observable)
+		if !ok {
+			return fmt.Errorf("extension %q is not an observer", entity.Name())
+		}
+		observables = append(observables, observable)
+	}
+	if len(observables) == 0 {
+		return fmt.Errorf("no observer extension of the types %v is enabled", rc.config.WatchObservers)
+	}
+
+	rc.handler = newObserverHandler(rc.params, rc.config, host, rc.nextConsumer)
+	rc.observables = observables
+	for _, observable := range observables {
+		observable.ListAndWatch(rc.handler)
+	}
+	return nil
+}
+
+// Shutdown unsubscribes from the observers and shuts down the receivers created.
+func (rc *receiverCreator) Shutdown(context.Context) error {
+	if rc.handler == nil {
+		return nil
+	}
+	for _, observable := range rc.observables {
+		observable.Unsubscribe(rc.handler)
+	}
+	return rc.handler.shutdown()
+}
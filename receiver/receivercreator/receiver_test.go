@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/extension/observer"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+type mockObserver struct {
+	notify observer.Notify
+}
+
+var _ observer.Observable = (*mockObserver)(nil)
+
+func (m *mockObserver) Start(context.Context, component.Host) error { return nil }
+
+func (m *mockObserver) Shutdown(context.Context) error { return nil }
+
+func (m *mockObserver) ListAndWatch(notify observer.Notify) { m.notify = notify }
+
+func (m *mockObserver) Unsubscribe(observer.Notify) { m.notify = nil }
+
+// nopExtension is an extension which isn't an observer.
+type nopExtension struct{}
+
+func (nopExtension) Start(context.Context, component.Host) error { return nil }
+
+func (nopExtension) Shutdown(context.Context) error { return nil }
+
+// recordingFactory records the configurations of the receivers created.
+type recordingFactory struct {
+	componenttest.ExampleReceiverFactory
+	configs []*componenttest.ExampleReceiver
+}
+
+func (f *recordingFactory) CreateMetricsReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.MetricsConsumer,
+) (component.MetricsReceiver, error) {
+	f.configs = append(f.configs, cfg.(*componenttest.ExampleReceiver))
+	return f.ExampleReceiverFactory.CreateMetricsReceiver(ctx, params, cfg, nextConsumer)
+}
+
+type mockHost struct {
+	component.Host
+	factory    *recordingFactory
+	extensions map[configmodels.NamedEntity]component.Extension
+}
+
+func newMockHost(extensions map[configmodels.NamedEntity]component.Extension) *mockHost {
+	return &mockHost{Host: componenttest.NewNopHost(), factory: &recordingFactory{}, extensions: extensions}
+}
+
+func (h *mockHost) GetFactory(kind component.Kind, componentType configmodels.Type) component.Factory {
+	if kind == component.KindReceiver && componentType == "examplereceiver" {
+		return h.factory
+	}
+	return nil
+}
+
+func (h *mockHost) GetExtensions() map[configmodels.NamedEntity]component.Extension {
+	return h.extensions
+}
+
+func observerSettings(typeStr configmodels.Type) configmodels.NamedEntity {
+	return &configmodels.ExtensionSettings{TypeVal: typeStr, NameVal: string(typeStr)}
+}
+
+func newTestReceiverCreator(t *testing.T, templates ...receiverTemplate) (*receiverCreator, *consumertest.MetricsSink) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.WatchObservers = []configmodels.Type{"k8s_observer"}
+	for _, template := range templates {
+		var err error
+		template.receiverType = "examplereceiver"
+		template.rule, err = newRule(template.Rule)
+		require.NoError(t, err)
+		cfg.receiverTemplates[template.fullName] = template
+	}
+	sink := new(consumertest.MetricsSink)
+	return newReceiverCreator(component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, sink), sink
+}
+
+var redisEndpoint = observer.Endpoint{
+	ID:     "redis-1/uid/redis(6379)",
+	Target: "10.0.0.1:6379",
+	Details: &observer.Port{
+		Name:      "redis",
+		Pod:       observer.Pod{Name: "redis-1", UID: "uid", Namespace: "default"},
+		Port:      6379,
+		Transport: observer.ProtocolTCP,
+	},
+}
+
+func TestReceiverCreator(t *testing.T) {
+	rc, sink := newTestReceiverCreator(t,
+		receiverTemplate{
+			fullName:           "examplereceiver/redis",
+			Rule:               `type == "port" && port == 6379`,
+			Config:             map[string]interface{}{"extra": "`pod.name`"},
+			ResourceAttributes: map[string]string{"app": "redis", "port": "`port`"},
+		},
+		receiverTemplate{
+			fullName: "examplereceiver/nginx",
+			Rule:     `type == "port" && port == 80`,
+		},
+	)
+	obs := &mockObserver{}
+	host := newMockHost(map[configmodels.NamedEntity]component.Extension{
+		observerSettings("k8s_observer"):  obs,
+		observerSettings("host_observer"): &mockObserver{},
+	})
+	require.NoError(t, rc.Start(context.Background(), host))
+	require.NotNil(t, obs.notify)
+
+	obs.notify.OnAdd([]observer.Endpoint{redisEndpoint})
+	receivers := rc.handler.receivers[redisEndpoint.ID]
+	require.Len(t, receivers, 1)
+	r := receivers[0].(*componenttest.ExampleReceiverProducer)
+	assert.True(t, r.Started)
+
+	// The receiver configuration is expanded with the endpoint.
+	require.NoError(t, r.MetricsConsumer.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+	require.Len(t, sink.AllMetrics(), 1)
+	attrs := sink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes()
+	assert.Equal(t, map[string]string{
+		"resource-attr":      "resource-attr-val-1",
+		"k8s.pod.name":       "redis-1",
+		"k8s.pod.uid":        "uid",
+		"k8s.namespace.name": "default",
+		"app":                "redis",
+		"port":               "6379",
+	}, attributesMap(attrs))
+
+	// Adding the endpoint again doesn't create another receiver.
+	obs.notify.OnAdd([]observer.Endpoint{redisEndpoint})
+	assert.Len(t, rc.handler.receivers[redisEndpoint.ID], 1)
+
+	obs.notify.OnRemove([]observer.Endpoint{redisEndpoint})
+	assert.True(t, r.Stopped)
+	assert.Empty(t, rc.handler.receivers)
+
+	require.NoError(t, rc.Shutdown(context.Background()))
+	assert.Nil(t, obs.notify)
+}
+
+func TestReceiverCreatorConfig(t *testing.T) {
+	rc, _ := newTestReceiverCreator(t, receiverTemplate{
+		fullName: "examplereceiver/redis",
+		Rule:     `type == "port"`,
+		Config:   map[string]interface{}{"extra": "`pod.name`"},
+	})
+	obs := &mockObserver{}
+	host := newMockHost(map[configmodels.NamedEntity]component.Extension{observerSettings("k8s_observer"): obs})
+	require.NoError(t, rc.Start(context.Background(), host))
+	defer rc.Shutdown(context.Background())
+
+	obs.notify.OnAdd([]observer.Endpoint{redisEndpoint})
+	require.Len(t, rc.handler.receivers[redisEndpoint.ID], 1)
+
+	require.Len(t, host.factory.configs, 1)
+	cfg := host.factory.configs[0]
+	assert.Equal(t, "examplereceiver/redis/redis-1/uid/redis(6379)", cfg.Name())
+	assert.Equal(t, "10.0.0.1:6379", cfg.Endpoint)
+	assert.Equal(t, "redis-1", cfg.ExtraSetting)
+}
+
+func TestReceiverCreatorChange(t *testing.T) {
+	rc, _ := newTestReceiverCreator(t, receiverTemplate{
+		fullName: "examplereceiver/redis",
+		Rule:     `type == "port" && pod.namespace == "default"`,
+	})
+	obs := &mockObserver{}
+	host := newMockHost(map[configmodels.NamedEntity]component.Extension{observerSettings("k8s_observer"): obs})
+	require.NoError(t, rc.Start(context.Background(), host))
+	defer rc.Shutdown(context.Background())
+
+	obs.notify.OnAdd([]observer.Endpoint{redisEndpoint})
+	r := rc.handler.receivers[redisEndpoint.ID][0].(*componenttest.ExampleReceiverProducer)
+
+	changed := redisEndpoint
+	port := *redisEndpoint.Details.(*observer.Port)
+	port.Pod.Namespace = "other"
+	changed.Details = &port
+	obs.notify.OnChange([]observer.Endpoint{changed})
+	assert.True(t, r.Stopped)
+	assert.Empty(t, rc.handler.receivers[redisEndpoint.ID])
+}
+
+func TestReceiverCreatorStartErrors(t *testing.T) {
+	rc, _ := newTestReceiverCreator(t)
+	err := rc.Start(context.Background(), newMockHost(nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no observer extension")
+
+	rc, _ = newTestReceiverCreator(t)
+	err = rc.Start(context.Background(), newMockHost(map[configmodels.NamedEntity]component.Extension{
+		observerSettings("k8s_observer"): nopExtension{},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not an observer")
+
+	rc, _ = newTestReceiverCreator(t, receiverTemplate{fullName: "examplereceiver", Rule: `type == "pod"`})
+	rc.config.receiverTemplates["examplereceiver"] = receiverTemplate{fullName: "unknown", receiverType: "unknown"}
+	err = rc.Start(context.Background(), newMockHost(map[configmodels.NamedEntity]component.Extension{
+		observerSettings("k8s_observer"): &mockObserver{},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown receiver type "unknown"`)
+}
+
+func attributesMap(attrs pdata.AttributeMap) map[string]string {
+	m := make(map[string]string)
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = v.StringVal()
+	})
+	return m
+}
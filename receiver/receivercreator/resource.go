@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/extension/observer"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// endpointResourceAttributes returns the resource attributes identifying the
+// endpoint.
+func endpointResourceAttributes(endpoint observer.Endpoint) map[string]string {
+	switch details := endpoint.Details.(type) {
+	case *observer.Pod:
+		return podResourceAttributes(details)
+	case *observer.Port:
+		return podResourceAttributes(&details.Pod)
+	case *observer.Container:
+		return map[string]string{
+			conventions.AttributeContainerName:  details.Name,
+			conventions.AttributeContainerID:    details.ContainerID,
+			conventions.AttributeContainerImage: details.Image,
+		}
+	default:
+		return map[string]string{}
+	}
+}
+
+func podResourceAttributes(pod *observer.Pod) map[string]string {
+	return map[string]string{
+		conventions.AttributeK8sPod:       pod.Name,
+		conventions.AttributeK8sPodUID:    pod.UID,
+		conventions.AttributeK8sNamespace: pod.Namespace,
+	}
+}
+
+// resourceAttributes returns the resource attributes of the data of the
+// receiver created from the template for the endpoint.
+func resourceAttributes(template receiverTemplate, endpoint observer.Endpoint, env observer.EndpointEnv) (map[string]string, error) {
+	attrs := endpointResourceAttributes(endpoint)
+	for key, value := range template.ResourceAttributes {
+		expanded, err := expandString(value, env)
+		if err != nil {
+			return nil, fmt.Errorf("resource attribute %s: %w", key, err)
+		}
+		attrs[key] = fmt.Sprint(expanded)
+	}
+	return attrs, nil
+}
+
+// resourceEnhancer adds the attributes to the resources of the metrics, unless
+// they are already set by the receiver.
+type resourceEnhancer struct {
+	next  consumer.MetricsConsumer
+	attrs map[string]string
+}
+
+var _ consumer.MetricsConsumer = (*resourceEnhancer)(nil)
+
+func (r *resourceEnhancer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		attrs := rms.At(i).Resource().Attributes()
+		for key, value := range r.attrs {
+			attrs.Insert(key, pdata.NewAttributeValueString(value))
+		}
+	}
+	return r.next.ConsumeMetrics(ctx, md)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"fmt"
+
+	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/vm"
+
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+// rule matches the endpoints whose environment satisfies its expression.
+type rule struct {
+	program *vm.Program
+}
+
+func newRule(ruleStr string) (*rule, error) {
+	program, err := expr.Compile(ruleStr, expr.AllowUndefinedVariables(), expr.AsBool())
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule %q: %w", ruleStr, err)
+	}
+	return &rule{program: program}, nil
+}
+
+// matches returns whether the rule matches the endpoint environment. The
+// variables of the other endpoint types are undefined, so the rules typically
+// start with the endpoint type, e.g. `type == "port" && pod.name == "redis"`.
+func (r *rule) matches(env observer.EndpointEnv) (bool, error) {
+	result, err := expr.Run(r.program, map[string]interface{}(env))
+	if err != nil {
+		return false, err
+	}
+	return result.(bool), nil
+}
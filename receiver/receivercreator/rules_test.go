@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+func TestRule(t *testing.T) {
+	port := observer.Endpoint{
+		ID:     "redis-1/uid/redis(6379)",
+		Target: "10.0.0.1:6379",
+		Details: &observer.Port{
+			Name:      "redis",
+			Pod:       observer.Pod{Name: "redis-1", UID: "uid", Namespace: "default", Labels: map[string]string{"app": "redis"}},
+			Port:      6379,
+			Transport: observer.ProtocolTCP,
+		},
+	}
+	hostPort := observer.Endpoint{
+		ID:      "(nginx)127.0.0.1-80-TCP-42",
+		Target:  "127.0.0.1:80",
+		Details: &observer.HostPort{ProcessName: "nginx", Port: 80, Transport: observer.ProtocolTCP},
+	}
+
+	tests := []struct {
+		rule     string
+		endpoint observer.Endpoint
+		matches  bool
+	}{
+		{rule: `type == "port" && port == 6379`, endpoint: port, matches: true},
+		{rule: `type == "port" && pod.labels["app"] == "redis"`, endpoint: port, matches: true},
+		{rule: `type == "port" && port == 6379`, endpoint: hostPort, matches: false},
+		{rule: `type == "hostport" && process_name == "nginx"`, endpoint: hostPort, matches: true},
+		{rule: `type == "hostport" && process_name == "nginx"`, endpoint: port, matches: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := newRule(tt.rule)
+			require.NoError(t, err)
+			matches, err := r.matches(tt.endpoint.Env())
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, matches)
+		})
+	}
+}
+
+func TestInvalidRule(t *testing.T) {
+	_, err := newRule(`type ==`)
+	assert.Error(t, err)
+
+	_, err = newRule(`port + 1`)
+	assert.Error(t, err)
+}
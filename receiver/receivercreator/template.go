@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/antonmedv/expr"
+
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+// expandConfig returns a copy of the configuration whose string values have
+// their `expressions` evaluated with the endpoint environment.
+func expandConfig(config map[string]interface{}, env observer.EndpointEnv) (map[string]interface{}, error) {
+	expanded := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		var err error
+		if expanded[key], err = expandValue(value, env); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return expanded, nil
+}
+
+func expandValue(value interface{}, env observer.EndpointEnv) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return expandString(v, env)
+	case map[string]interface{}:
+		return expandConfig(v, env)
+	case []interface{}:
+		expanded := make([]interface{}, len(v))
+		for i, item := range v {
+			var err error
+			if expanded[i], err = expandValue(item, env); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	default:
+		return value, nil
+	}
+}
+
+// expandString evaluates the `expressions` of a string. A string made of a
+// single expression is replaced by its value, e.g. a number, while the values
+// of the expressions of the other strings are formatted into them.
+func expandString(s string, env observer.EndpointEnv) (interface{}, error) {
+	parts := strings.Split(s, "`")
+	if len(parts) == 1 {
+		return s, nil
+	}
+	if len(parts)%2 == 0 {
+		return nil, fmt.Errorf("unbalanced backquotes in %q", s)
+	}
+	if len(parts) == 3 && parts[0] == "" && parts[2] == "" {
+		return evaluate(parts[1], env)
+	}
+
+	var sb strings.Builder
+	for i, part := range parts {
+		if i%2 == 0 {
+			sb.WriteString(part)
+			continue
+		}
+		value, err := evaluate(part, env)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			fmt.Fprint(&sb, value)
+		}
+	}
+	return sb.String(), nil
+}
+
+func evaluate(expression string, env observer.EndpointEnv) (interface{}, error) {
+	program, err := expr.Compile(expression, expr.AllowUndefinedVariables())
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", expression, err)
+	}
+	value, err := expr.Run(program, map[string]interface{}(env))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %q: %w", expression, err)
+	}
+	return value, nil
+}
+
+// hasEndpointKey returns whether the configuration struct has an "endpoint"
+// key, directly or in its squashed structs.
+func hasEndpointKey(config interface{}) bool {
+	return hasKey(reflect.TypeOf(config), "endpoint")
+}
+
+func hasKey(t reflect.Type, key string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("mapstructure"), ",")
+		if tag[0] == key {
+			return true
+		}
+		if tag[0] == "" && len(tag) > 1 && tag[1] == "squash" && hasKey(field.Type, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/observer"
+)
+
+func TestExpandConfig(t *testing.T) {
+	env := observer.Endpoint{
+		ID:      "(nginx)127.0.0.1-80-TCP-42",
+		Target:  "127.0.0.1:80",
+		Details: &observer.HostPort{ProcessName: "nginx", Port: 80, Transport: observer.ProtocolTCP},
+	}.Env()
+
+	expanded, err := expandConfig(map[string]interface{}{
+		"endpoint": "http://`endpoint`/nginx_status",
+		"port":     "`port`",
+		"static":   "value",
+		"nested": map[string]interface{}{
+			"name": "`process_name`",
+		},
+		"list":     []interface{}{"`transport`", 1},
+		"interval": 10,
+	}, env)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "http://127.0.0.1:80/nginx_status",
+		"port":     uint16(80),
+		"static":   "value",
+		"nested": map[string]interface{}{
+			"name": "nginx",
+		},
+		"list":     []interface{}{"TCP", 1},
+		"interval": 10,
+	}, expanded)
+}
+
+func TestExpandStringErrors(t *testing.T) {
+	env := observer.EndpointEnv{"port": 80}
+
+	_, err := expandString("`port", env)
+	assert.Error(t, err)
+
+	_, err = expandString("`port +`", env)
+	assert.Error(t, err)
+}
+
+func TestHasEndpointKey(t *testing.T) {
+	assert.True(t, hasEndpointKey((&componenttest.ExampleReceiverFactory{}).CreateDefaultConfig()))
+	assert.False(t, hasEndpointKey(createDefaultConfig()))
+}
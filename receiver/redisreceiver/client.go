@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// client runs commands against a Redis server.
+type client interface {
+	// info returns the reply of the INFO command for all the sections.
+	info(ctx context.Context) (string, error)
+	// clusterInfo returns the reply of the CLUSTER INFO command.
+	clusterInfo(ctx context.Context) (string, error)
+	Close() error
+}
+
+// makeClient creates the client of the configured server.
+type makeClient func(cfg *Config) (client, error)
+
+// redisError is an error reply of the server.
+type redisError string
+
+func (e redisError) Error() string {
+	return string(e)
+}
+
+// redisClient is a minimal client of the RESP protocol, connecting on the first command, and again on the command
+// following a failure of the connection.
+type redisClient struct {
+	cfg    *Config
+	tlsCfg *tls.Config
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+var _ client = (*redisClient)(nil)
+
+func newClient(cfg *Config) (client, error) {
+	c := &redisClient{cfg: cfg}
+	if cfg.TLSSetting != nil {
+		tlsCfg, err := cfg.TLSSetting.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		if tlsCfg != nil && tlsCfg.ServerName == "" && !strings.HasPrefix(cfg.Endpoint, "unix://") {
+			tlsCfg.ServerName, _, _ = net.SplitHostPort(cfg.Endpoint)
+		}
+		c.tlsCfg = tlsCfg
+	}
+	return c, nil
+}
+
+func (c *redisClient) info(ctx context.Context) (string, error) {
+	return c.do(ctx, "INFO", "ALL")
+}
+
+func (c *redisClient) clusterInfo(ctx context.Context) (string, error) {
+	return c.do(ctx, "CLUSTER", "INFO")
+}
+
+func (c *redisClient) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+func (c *redisClient) do(ctx context.Context, args ...string) (string, error) {
+	if c.conn == nil {
+		if err := c.connect(ctx); err != nil {
+			return "", err
+		}
+	}
+	reply, err := c.roundTrip(ctx, args...)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		// The state of the connection is unknown.
+		_ = c.Close()
+	}
+	return reply, err
+}
+
+func (c *redisClient) connect(ctx context.Context) error {
+	network, address := c.cfg.Transport, c.cfg.Endpoint
+	if strings.HasPrefix(address, "unix://") {
+		network, address = "unix", strings.TrimPrefix(address, "unix://")
+	}
+	dialer := net.Dialer{Timeout: c.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return err
+	}
+	if c.tlsCfg != nil {
+		conn = tls.Client(conn, c.tlsCfg)
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+
+	if c.cfg.Password == "" {
+		return nil
+	}
+	args := []string{"AUTH", c.cfg.Password}
+	if c.cfg.Username != "" {
+		args = []string{"AUTH", c.cfg.Username, c.cfg.Password}
+	}
+	if _, err = c.roundTrip(ctx, args...); err != nil {
+		_ = c.Close()
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+	return nil
+}
+
+func (c *redisClient) roundTrip(ctx context.Context, args ...string) (string, error) {
+	deadline := time.Now().Add(c.cfg.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, sb.String()); err != nil {
+		return "", err
+	}
+	return readReply(c.reader)
+}
+
+// readReply reads a simple string, error, integer or bulk string reply, a null bulk string being empty.
+func readReply(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	if line == "" {
+		return "", errors.New("invalid reply: empty line")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", redisError(line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid reply: %q", line)
+		}
+		if size < 0 {
+			return "", nil
+		}
+		buf := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:size]), nil
+	default:
+		return "", fmt.Errorf("unsupported reply: %q", line)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer is a server of the RESP protocol replying to the commands with the raw replies of a handler.
+type fakeServer struct {
+	listener net.Listener
+	handler  func(args []string) string
+
+	mu       sync.Mutex
+	commands [][]string
+	conns    int
+}
+
+func newFakeServer(t *testing.T, handler func(args []string) string) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{listener: listener, handler: handler}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns++
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, args)
+		s.mu.Unlock()
+		reply := s.handler(args)
+		if reply == "" {
+			// Closes the connection.
+			return
+		}
+		if _, err = io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if line, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func bulkString(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+func newTestClient(t *testing.T, s *fakeServer, username, password string) client {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = s.listener.Addr().String()
+	cfg.Username = username
+	cfg.Password = password
+	c, err := newClient(cfg)
+	require.NoError(t, err)
+	return c
+}
+
+func TestClientAuth(t *testing.T) {
+	s := newFakeServer(t, func(args []string) string {
+		switch args[0] {
+		case "AUTH":
+			if args[len(args)-1] != "secret" {
+				return "-WRONGPASS invalid username-password pair\r\n"
+			}
+			return "+OK\r\n"
+		case "INFO":
+			return bulkString("# Server\r\nredis_version:6.0.9\r\n")
+		case "CLUSTER":
+			return "-ERR This instance has cluster support disabled\r\n"
+		}
+		return "-ERR unknown command\r\n"
+	})
+	defer s.listener.Close()
+
+	c := newTestClient(t, s, "otel", "secret")
+	reply, err := c.info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "# Server\r\nredis_version:6.0.9\r\n", reply)
+
+	// The error replies do not close the connection.
+	_, err = c.clusterInfo(context.Background())
+	assert.EqualError(t, err, "ERR This instance has cluster support disabled")
+	_, err = c.info(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+
+	s.mu.Lock()
+	assert.Equal(t, [][]string{
+		{"AUTH", "otel", "secret"},
+		{"INFO", "ALL"},
+		{"CLUSTER", "INFO"},
+		{"INFO", "ALL"},
+	}, s.commands)
+	assert.Equal(t, 1, s.conns)
+	s.mu.Unlock()
+
+	c = newTestClient(t, s, "", "wrong")
+	_, err = c.info(context.Background())
+	assert.EqualError(t, err, "failed to authenticate: WRONGPASS invalid username-password pair")
+}
+
+func TestClientReconnect(t *testing.T) {
+	var mu sync.Mutex
+	closeNext := true
+	s := newFakeServer(t, func(args []string) string {
+		mu.Lock()
+		defer mu.Unlock()
+		if closeNext {
+			closeNext = false
+			return ""
+		}
+		return bulkString("uptime_in_seconds:10\r\n")
+	})
+	defer s.listener.Close()
+
+	c := newTestClient(t, s, "", "")
+	_, err := c.info(context.Background())
+	assert.Error(t, err)
+	reply, err := c.info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "uptime_in_seconds:10\r\n", reply)
+
+	s.mu.Lock()
+	assert.Equal(t, 2, s.conns)
+	s.mu.Unlock()
+}
+
+func TestReadReply(t *testing.T) {
+	tests := []struct {
+		reply   string
+		want    string
+		wantErr string
+	}{
+		{reply: "+OK\r\n", want: "OK"},
+		{reply: ":42\r\n", want: "42"},
+		{reply: "$5\r\nhello\r\n", want: "hello"},
+		{reply: "$-1\r\n", want: ""},
+		{reply: "-ERR failure\r\n", wantErr: "ERR failure"},
+		{reply: "$x\r\n", wantErr: `invalid reply: "$x"`},
+		{reply: "*1\r\n", wantErr: `unsupported reply: "*1"`},
+		{reply: "\r\n", wantErr: "invalid reply: empty line"},
+		{reply: "$5\r\nhel", wantErr: "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reply, func(t *testing.T) {
+			reply, err := readReply(bufio.NewReader(strings.NewReader(tt.reply)))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, reply)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateReceiver(t *testing.T) {
+	factory := NewFactory()
+	require.Equal(t, configmodels.Type("redis"), factory.Type())
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	cfg := factory.CreateDefaultConfig()
+	r, err := factory.CreateMetricsReceiver(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, r)
+
+	cfg.(*Config).Transport = "udp"
+	_, err = factory.CreateMetricsReceiver(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.EqualError(t, err, `invalid transport "udp", expecting "tcp" or "unix"`)
+
+	cfg = factory.CreateDefaultConfig()
+	cfg.(*Config).Timeout = 0
+	_, err = factory.CreateMetricsReceiver(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.Equal(t, errNonPositiveTimeout, err)
+}
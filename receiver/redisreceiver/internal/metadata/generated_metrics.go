@@ -0,0 +1,433 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Type is the component type name.
+const Type configmodels.Type = "redisreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	RedisClientsBlocked               MetricIntf
+	RedisClientsConnected             MetricIntf
+	RedisClusterKnownNodes            MetricIntf
+	RedisClusterSlots                 MetricIntf
+	RedisCommands                     MetricIntf
+	RedisCommandsInstantaneousRate    MetricIntf
+	RedisCommandsProcessed            MetricIntf
+	RedisConnectionsReceived          MetricIntf
+	RedisConnectionsRejected          MetricIntf
+	RedisDbExpires                    MetricIntf
+	RedisDbKeys                       MetricIntf
+	RedisKeysEvicted                  MetricIntf
+	RedisKeysExpired                  MetricIntf
+	RedisKeyspaceHits                 MetricIntf
+	RedisKeyspaceMisses               MetricIntf
+	RedisMemoryFragmentationRatio     MetricIntf
+	RedisMemoryPeak                   MetricIntf
+	RedisMemoryRss                    MetricIntf
+	RedisMemoryUsed                   MetricIntf
+	RedisReplicationConnectedReplicas MetricIntf
+	RedisReplicationMasterLinkUp      MetricIntf
+	RedisReplicationOffset            MetricIntf
+	RedisUptime                       MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"redis.clients.blocked",
+		"redis.clients.connected",
+		"redis.cluster.known_nodes",
+		"redis.cluster.slots",
+		"redis.commands",
+		"redis.commands.instantaneous_rate",
+		"redis.commands.processed",
+		"redis.connections.received",
+		"redis.connections.rejected",
+		"redis.db.expires",
+		"redis.db.keys",
+		"redis.keys.evicted",
+		"redis.keys.expired",
+		"redis.keyspace.hits",
+		"redis.keyspace.misses",
+		"redis.memory.fragmentation_ratio",
+		"redis.memory.peak",
+		"redis.memory.rss",
+		"redis.memory.used",
+		"redis.replication.connected_replicas",
+		"redis.replication.master_link.up",
+		"redis.replication.offset",
+		"redis.uptime",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"redis.clients.blocked":                Metrics.RedisClientsBlocked,
+	"redis.clients.connected":              Metrics.RedisClientsConnected,
+	"redis.cluster.known_nodes":            Metrics.RedisClusterKnownNodes,
+	"redis.cluster.slots":                  Metrics.RedisClusterSlots,
+	"redis.commands":                       Metrics.RedisCommands,
+	"redis.commands.instantaneous_rate":    Metrics.RedisCommandsInstantaneousRate,
+	"redis.commands.processed":             Metrics.RedisCommandsProcessed,
+	"redis.connections.received":           Metrics.RedisConnectionsReceived,
+	"redis.connections.rejected":           Metrics.RedisConnectionsRejected,
+	"redis.db.expires":                     Metrics.RedisDbExpires,
+	"redis.db.keys":                        Metrics.RedisDbKeys,
+	"redis.keys.evicted":                   Metrics.RedisKeysEvicted,
+	"redis.keys.expired":                   Metrics.RedisKeysExpired,
+	"redis.keyspace.hits":                  Metrics.RedisKeyspaceHits,
+	"redis.keyspace.misses":                Metrics.RedisKeyspaceMisses,
+	"redis.memory.fragmentation_ratio":     Metrics.RedisMemoryFragmentationRatio,
+	"redis.memory.peak":                    Metrics.RedisMemoryPeak,
+	"redis.memory.rss":                     Metrics.RedisMemoryRss,
+	"redis.memory.used":                    Metrics.RedisMemoryUsed,
+	"redis.replication.connected_replicas": Metrics.RedisReplicationConnectedReplicas,
+	"redis.replication.master_link.up":     Metrics.RedisReplicationMasterLinkUp,
+	"redis.replication.offset":             Metrics.RedisReplicationOffset,
+	"redis.uptime":                         Metrics.RedisUptime,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+func (m *metricStruct) FactoriesByName() map[string]func() pdata.Metric {
+	return map[string]func() pdata.Metric{
+		Metrics.RedisClientsBlocked.Name():               Metrics.RedisClientsBlocked.New,
+		Metrics.RedisClientsConnected.Name():             Metrics.RedisClientsConnected.New,
+		Metrics.RedisClusterKnownNodes.Name():            Metrics.RedisClusterKnownNodes.New,
+		Metrics.RedisClusterSlots.Name():                 Metrics.RedisClusterSlots.New,
+		Metrics.RedisCommands.Name():                     Metrics.RedisCommands.New,
+		Metrics.RedisCommandsInstantaneousRate.Name():    Metrics.RedisCommandsInstantaneousRate.New,
+		Metrics.RedisCommandsProcessed.Name():            Metrics.RedisCommandsProcessed.New,
+		Metrics.RedisConnectionsReceived.Name():          Metrics.RedisConnectionsReceived.New,
+		Metrics.RedisConnectionsRejected.Name():          Metrics.RedisConnectionsRejected.New,
+		Metrics.RedisDbExpires.Name():                    Metrics.RedisDbExpires.New,
+		Metrics.RedisDbKeys.Name():                       Metrics.RedisDbKeys.New,
+		Metrics.RedisKeysEvicted.Name():                  Metrics.RedisKeysEvicted.New,
+		Metrics.RedisKeysExpired.Name():                  Metrics.RedisKeysExpired.New,
+		Metrics.RedisKeyspaceHits.Name():                 Metrics.RedisKeyspaceHits.New,
+		Metrics.RedisKeyspaceMisses.Name():               Metrics.RedisKeyspaceMisses.New,
+		Metrics.RedisMemoryFragmentationRatio.Name():     Metrics.RedisMemoryFragmentationRatio.New,
+		Metrics.RedisMemoryPeak.Name():                   Metrics.RedisMemoryPeak.New,
+		Metrics.RedisMemoryRss.Name():                    Metrics.RedisMemoryRss.New,
+		Metrics.RedisMemoryUsed.Name():                   Metrics.RedisMemoryUsed.New,
+		Metrics.RedisReplicationConnectedReplicas.Name(): Metrics.RedisReplicationConnectedReplicas.New,
+		Metrics.RedisReplicationMasterLinkUp.Name():      Metrics.RedisReplicationMasterLinkUp.New,
+		Metrics.RedisReplicationOffset.Name():            Metrics.RedisReplicationOffset.New,
+		Metrics.RedisUptime.Name():                       Metrics.RedisUptime.New,
+	}
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"redis.clients.blocked",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.clients.blocked")
+			metric.SetDescription("Current number of clients blocked by a blocking command, e.g. BLPOP.")
+			metric.SetUnit("{clients}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.clients.connected",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.clients.connected")
+			metric.SetDescription("Current number of client connections, excluding the connections of the replicas.")
+			metric.SetUnit("{clients}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.cluster.known_nodes",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.cluster.known_nodes")
+			metric.SetDescription("Number of nodes known by the server in cluster mode, including the nodes in handshake state.")
+			metric.SetUnit("{nodes}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.cluster.slots",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.cluster.slots")
+			metric.SetDescription("Number of the hash slots of the cluster by state, reported in cluster mode. The slots in the ok, pfail and fail states are assigned slots.")
+			metric.SetUnit("{slots}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.commands",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.commands")
+			metric.SetDescription("Total number of calls by command.")
+			metric.SetUnit("{calls}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"redis.commands.instantaneous_rate",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.commands.instantaneous_rate")
+			metric.SetDescription("Number of commands processed per second, sampled over the last few seconds by the server.")
+			metric.SetUnit("{commands}/s")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.commands.processed",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.commands.processed")
+			metric.SetDescription("Total number of commands processed by the server.")
+			metric.SetUnit("{commands}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"redis.connections.received",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.connections.received")
+			metric.SetDescription("Total number of connections accepted by the server.")
+			metric.SetUnit("{connections}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"redis.connections.rejected",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.connections.rejected")
+			metric.SetDescription("Total number of connections rejected because of the maxclients limit.")
+			metric.SetUnit("{connections}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"redis.db.expires",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.db.expires")
+			metric.SetDescription("Current number of keys with an expiration by logical database.")
+			metric.SetUnit("{keys}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.db.keys",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.db.keys")
+			metric.SetDescription("Current number of keys by logical database.")
+			metric.SetUnit("{keys}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.keys.evicted",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.keys.evicted")
+			metric.SetDescription("Total number of keys evicted because of the maxmemory limit.")
+			metric.SetUnit("{keys}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"redis.keys.expired",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.keys.expired")
+			metric.SetDescription("Total number of keys removed because they expired.")
+			metric.SetUnit("{keys}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"redis.keyspace.hits",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.keyspace.hits")
+			metric.SetDescription("Total number of successful lookups of keys.")
+			metric.SetUnit("{lookups}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"redis.keyspace.misses",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.keyspace.misses")
+			metric.SetDescription("Total number of failed lookups of keys.")
+			metric.SetUnit("{lookups}")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"redis.memory.fragmentation_ratio",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.memory.fragmentation_ratio")
+			metric.SetDescription("Ratio of the resident memory to the allocated memory.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
+	&metricImpl{
+		"redis.memory.peak",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.memory.peak")
+			metric.SetDescription("Largest number of bytes allocated by the allocator of the server since it started.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.memory.rss",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.memory.rss")
+			metric.SetDescription("Number of bytes of the server process resident in memory, as seen by the operating system.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.memory.used",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.memory.used")
+			metric.SetDescription("Number of bytes allocated by the allocator of the server.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.replication.connected_replicas",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.replication.connected_replicas")
+			metric.SetDescription("Current number of connected replicas.")
+			metric.SetUnit("{replicas}")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.replication.master_link.up",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.replication.master_link.up")
+			metric.SetDescription("Whether the link of a replica to its master is up, 1, or down, 0.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.replication.offset",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.replication.offset")
+			metric.SetDescription("Replication offset of the server, in bytes of the replication stream.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"redis.uptime",
+		func(metric pdata.Metric) {
+			metric.SetName("redis.uptime")
+			metric.SetDescription("Number of seconds since the server started.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// Command (The command, or the command and its subcommand separated by a "|".)
+	Command string
+	// Db (The logical database, e.g. "0".)
+	Db string
+	// SlotState (The state of the hash slots.)
+	SlotState string
+}{
+	"command",
+	"db",
+	"state",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
+
+// LabelSlotState are the possible values that the label "slot_state" can have.
+var LabelSlotState = struct {
+	Assigned string
+	Ok       string
+	Pfail    string
+	Fail     string
+}{
+	"assigned",
+	"ok",
+	"pfail",
+	"fail",
+}
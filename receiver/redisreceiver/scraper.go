@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/redisreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+// The resource attributes identifying the server the metrics are scraped from.
+const (
+	attributeInstanceEndpoint = "redis.instance.endpoint"
+	attributeVersion          = "redis.version"
+	attributeRole             = "redis.role"
+)
+
+// infoField is a field of the INFO or CLUSTER INFO replies reported as a data point, with the value of the label of
+// the metric.
+type infoField struct {
+	labelValue string
+	name       string
+}
+
+// infoMetric is a metric whose data points are integer fields.
+type infoMetric struct {
+	descriptor metadata.MetricIntf
+	label      string
+	fields     []infoField
+}
+
+func singleField(descriptor metadata.MetricIntf, name string) infoMetric {
+	return infoMetric{descriptor, "", []infoField{{"", name}}}
+}
+
+var infoMetrics = []infoMetric{
+	singleField(metadata.Metrics.RedisUptime, "uptime_in_seconds"),
+	singleField(metadata.Metrics.RedisClientsConnected, "connected_clients"),
+	singleField(metadata.Metrics.RedisClientsBlocked, "blocked_clients"),
+	singleField(metadata.Metrics.RedisConnectionsReceived, "total_connections_received"),
+	singleField(metadata.Metrics.RedisConnectionsRejected, "rejected_connections"),
+	singleField(metadata.Metrics.RedisMemoryUsed, "used_memory"),
+	singleField(metadata.Metrics.RedisMemoryRss, "used_memory_rss"),
+	singleField(metadata.Metrics.RedisMemoryPeak, "used_memory_peak"),
+	singleField(metadata.Metrics.RedisCommandsProcessed, "total_commands_processed"),
+	singleField(metadata.Metrics.RedisCommandsInstantaneousRate, "instantaneous_ops_per_sec"),
+	singleField(metadata.Metrics.RedisKeyspaceHits, "keyspace_hits"),
+	singleField(metadata.Metrics.RedisKeyspaceMisses, "keyspace_misses"),
+	singleField(metadata.Metrics.RedisKeysExpired, "expired_keys"),
+	singleField(metadata.Metrics.RedisKeysEvicted, "evicted_keys"),
+	singleField(metadata.Metrics.RedisReplicationConnectedReplicas, "connected_slaves"),
+	singleField(metadata.Metrics.RedisReplicationOffset, "master_repl_offset"),
+}
+
+var clusterInfoMetrics = []infoMetric{
+	{metadata.Metrics.RedisClusterSlots, metadata.Labels.SlotState, []infoField{
+		{metadata.LabelSlotState.Assigned, "cluster_slots_assigned"},
+		{metadata.LabelSlotState.Ok, "cluster_slots_ok"},
+		{metadata.LabelSlotState.Pfail, "cluster_slots_pfail"},
+		{metadata.LabelSlotState.Fail, "cluster_slots_fail"},
+	}},
+	singleField(metadata.Metrics.RedisClusterKnownNodes, "cluster_known_nodes"),
+}
+
+type redisScraper struct {
+	cfg        *Config
+	makeClient makeClient
+	client     client
+}
+
+func newRedisScraper(cfg *Config, makeClient makeClient) *redisScraper {
+	return &redisScraper{cfg: cfg, makeClient: makeClient}
+}
+
+func (rs *redisScraper) start(context.Context, component.Host) error {
+	client, err := rs.makeClient(rs.cfg)
+	if err != nil {
+		return err
+	}
+	rs.client = client
+	return nil
+}
+
+func (rs *redisScraper) shutdown(context.Context) error {
+	if rs.client == nil {
+		return nil
+	}
+	return rs.client.Close()
+}
+
+func (rs *redisScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	rms := pdata.NewResourceMetricsSlice()
+	reply, err := rs.client.info(ctx)
+	if err != nil {
+		return rms, err
+	}
+	info := parseInfo(reply)
+
+	rms.Resize(1)
+	rm := rms.At(0)
+	attrs := rm.Resource().Attributes()
+	attrs.InsertString(attributeInstanceEndpoint, rs.cfg.Endpoint)
+	if version, ok := info["redis_version"]; ok {
+		attrs.InsertString(attributeVersion, version)
+	}
+	if role, ok := info["role"]; ok {
+		attrs.InsertString(attributeRole, role)
+	}
+	ilms := rm.InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+
+	now := time.Now()
+	// The cumulative metrics are counted since the server started.
+	var startTime pdata.Timestamp
+	if uptime, err := strconv.ParseInt(info["uptime_in_seconds"], 10, 64); err == nil {
+		startTime = pdata.TimestampFromTime(now.Add(-time.Duration(uptime) * time.Second))
+	}
+	timestamp := pdata.TimestampFromTime(now)
+
+	appendInfoMetrics(metrics, infoMetrics, info, startTime, timestamp)
+
+	if ratio, err := strconv.ParseFloat(info["mem_fragmentation_ratio"], 64); err == nil {
+		metric := pdata.NewMetric()
+		metadata.Metrics.RedisMemoryFragmentationRatio.Init(metric)
+		dps := metric.DoubleGauge().DataPoints()
+		dps.Resize(1)
+		dps.At(0).SetTimestamp(timestamp)
+		dps.At(0).SetValue(ratio)
+		metrics.Append(metric)
+	}
+
+	if info["role"] == "slave" {
+		var up int64
+		if info["master_link_status"] == "up" {
+			up = 1
+		}
+		metric := pdata.NewMetric()
+		metadata.Metrics.RedisReplicationMasterLinkUp.Init(metric)
+		appendDataPoint(metric, startTime, timestamp, "", "", up)
+		metrics.Append(metric)
+	}
+
+	appendSectionMetric(metrics, metadata.Metrics.RedisCommands, metadata.Labels.Command, info, "cmdstat_", "calls",
+		startTime, timestamp)
+	appendSectionMetric(metrics, metadata.Metrics.RedisDbKeys, metadata.Labels.Db, info, "db", "keys",
+		startTime, timestamp)
+	appendSectionMetric(metrics, metadata.Metrics.RedisDbExpires, metadata.Labels.Db, info, "db", "expires",
+		startTime, timestamp)
+
+	var errs scrapererror.ScrapeErrors
+	if info["cluster_enabled"] == "1" {
+		reply, err = rs.client.clusterInfo(ctx)
+		if err != nil {
+			errs.AddPartial(len(clusterInfoMetrics), err)
+		} else {
+			appendInfoMetrics(metrics, clusterInfoMetrics, parseInfo(reply), startTime, timestamp)
+		}
+	}
+	return rms, errs.Combine()
+}
+
+// parseInfo parses the "field:value" lines of the INFO and CLUSTER INFO replies, skipping the "# Section" headers.
+func parseInfo(reply string) map[string]string {
+	info := make(map[string]string)
+	for _, line := range strings.Split(reply, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.IndexByte(line, ':'); i > 0 {
+			info[line[:i]] = line[i+1:]
+		}
+	}
+	return info
+}
+
+// parseSubfields parses the values of the keyspace and commandstats sections, e.g. "keys=1,expires=0,avg_ttl=0".
+func parseSubfields(value string) map[string]string {
+	subfields := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if i := strings.IndexByte(pair, '='); i > 0 {
+			subfields[pair[:i]] = pair[i+1:]
+		}
+	}
+	return subfields
+}
+
+func appendInfoMetrics(metrics pdata.MetricSlice, infoMetrics []infoMetric, info map[string]string, startTime, timestamp pdata.Timestamp) {
+	for _, im := range infoMetrics {
+		metric := pdata.NewMetric()
+		im.descriptor.Init(metric)
+		for _, field := range im.fields {
+			// The fields depend on the version and the configuration of the server.
+			value, err := strconv.ParseInt(info[field.name], 10, 64)
+			if err != nil {
+				continue
+			}
+			appendDataPoint(metric, startTime, timestamp, im.label, field.labelValue, value)
+		}
+		if dataPointCount(metric) > 0 {
+			metrics.Append(metric)
+		}
+	}
+}
+
+// appendSectionMetric appends the metric of a subfield of the fields whose name has the prefix, the rest of the name
+// being the value of the label, e.g. "0" for the "keys" subfield of "db0:keys=1,expires=0,avg_ttl=0".
+func appendSectionMetric(metrics pdata.MetricSlice, descriptor metadata.MetricIntf, label string, info map[string]string,
+	prefix, subfield string, startTime, timestamp pdata.Timestamp) {
+	var names []string
+	for name := range info {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	metric := pdata.NewMetric()
+	descriptor.Init(metric)
+	for _, name := range names {
+		value, err := strconv.ParseInt(parseSubfields(info[name])[subfield], 10, 64)
+		if err != nil {
+			continue
+		}
+		appendDataPoint(metric, startTime, timestamp, label, strings.TrimPrefix(name, prefix), value)
+	}
+	if dataPointCount(metric) > 0 {
+		metrics.Append(metric)
+	}
+}
+
+func appendDataPoint(metric pdata.Metric, startTime, timestamp pdata.Timestamp, label, labelValue string, value int64) {
+	var dps pdata.IntDataPointSlice
+	if metric.DataType() == pdata.MetricDataTypeIntSum {
+		dps = metric.IntSum().DataPoints()
+	} else {
+		dps = metric.IntGauge().DataPoints()
+		startTime = 0
+	}
+	dps.Resize(dps.Len() + 1)
+	dp := dps.At(dps.Len() - 1)
+	if label != "" {
+		dp.LabelsMap().Insert(label, labelValue)
+	}
+	dp.SetStartTime(startTime)
+	dp.SetTimestamp(timestamp)
+	dp.SetValue(value)
+}
+
+func dataPointCount(metric pdata.Metric) int {
+	if metric.DataType() == pdata.MetricDataTypeIntSum {
+		return metric.IntSum().DataPoints().Len()
+	}
+	return metric.IntGauge().DataPoints().Len()
+}
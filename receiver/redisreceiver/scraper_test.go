@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+type fakeClient struct {
+	infoReply        string
+	infoErr          error
+	clusterInfoReply string
+	clusterInfoErr   error
+	closed           bool
+}
+
+func (c *fakeClient) info(context.Context) (string, error) {
+	return c.infoReply, c.infoErr
+}
+
+func (c *fakeClient) clusterInfo(context.Context) (string, error) {
+	return c.clusterInfoReply, c.clusterInfoErr
+}
+
+func (c *fakeClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func lines(lines ...string) string {
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+var testInfo = lines(
+	"# Server",
+	"redis_version:6.0.9",
+	"uptime_in_seconds:7200",
+	"",
+	"# Clients",
+	"connected_clients:12",
+	"blocked_clients:1",
+	"",
+	"# Memory",
+	"used_memory:1048576",
+	"used_memory_rss:2097152",
+	"used_memory_peak:3145728",
+	"mem_fragmentation_ratio:2.00",
+	"",
+	"# Stats",
+	"total_connections_received:345",
+	"total_commands_processed:67890",
+	"instantaneous_ops_per_sec:42",
+	"rejected_connections:0",
+	"expired_keys:7",
+	"evicted_keys:3",
+	"keyspace_hits:5000",
+	"keyspace_misses:250",
+	"",
+	"# Replication",
+	"role:master",
+	"connected_slaves:2",
+	"master_repl_offset:123456",
+	"",
+	"# Commandstats",
+	"cmdstat_get:calls=60000,usec=120000,usec_per_call=2.00",
+	"cmdstat_set:calls=7000,usec=21000,usec_per_call=3.00",
+	"",
+	"# Cluster",
+	"cluster_enabled:0",
+	"",
+	"# Keyspace",
+	"db0:keys=100,expires=10,avg_ttl=3600",
+	"db3:keys=5,expires=0,avg_ttl=0",
+)
+
+func startTestScraper(t *testing.T, c *fakeClient) *redisScraper {
+	rs := newRedisScraper(createDefaultConfig().(*Config), func(*Config) (client, error) {
+		return c, nil
+	})
+	require.NoError(t, rs.start(context.Background(), componenttest.NewNopHost()))
+	return rs
+}
+
+// metricValues returns the values of the metrics, by name and label value.
+func metricValues(metrics pdata.MetricSlice) map[string]float64 {
+	values := make(map[string]float64)
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		var dps pdata.IntDataPointSlice
+		switch metric.DataType() {
+		case pdata.MetricDataTypeDoubleGauge:
+			values[metric.Name()] = metric.DoubleGauge().DataPoints().At(0).Value()
+			continue
+		case pdata.MetricDataTypeIntSum:
+			dps = metric.IntSum().DataPoints()
+		default:
+			dps = metric.IntGauge().DataPoints()
+		}
+		for j := 0; j < dps.Len(); j++ {
+			key := metric.Name()
+			dps.At(j).LabelsMap().ForEach(func(_ string, v string) {
+				key += "/" + v
+			})
+			values[key] = float64(dps.At(j).Value())
+		}
+	}
+	return values
+}
+
+func TestScrape(t *testing.T) {
+	c := &fakeClient{infoReply: testInfo}
+	rs := startTestScraper(t, c)
+
+	rms, err := rs.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, rms.Len())
+	attrs := rms.At(0).Resource().Attributes()
+	for name, want := range map[string]string{
+		attributeInstanceEndpoint: "localhost:6379",
+		attributeVersion:          "6.0.9",
+		attributeRole:             "master",
+	} {
+		value, ok := attrs.Get(name)
+		require.True(t, ok)
+		assert.Equal(t, want, value.StringVal())
+	}
+
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Equal(t, map[string]float64{
+		"redis.uptime":                         7200,
+		"redis.clients.connected":              12,
+		"redis.clients.blocked":                1,
+		"redis.connections.received":           345,
+		"redis.connections.rejected":           0,
+		"redis.memory.used":                    1048576,
+		"redis.memory.rss":                     2097152,
+		"redis.memory.peak":                    3145728,
+		"redis.memory.fragmentation_ratio":     2,
+		"redis.commands.processed":             67890,
+		"redis.commands.instantaneous_rate":    42,
+		"redis.commands/get":                   60000,
+		"redis.commands/set":                   7000,
+		"redis.keyspace.hits":                  5000,
+		"redis.keyspace.misses":                250,
+		"redis.keys.expired":                   7,
+		"redis.keys.evicted":                   3,
+		"redis.replication.connected_replicas": 2,
+		"redis.replication.offset":             123456,
+		"redis.db.keys/0":                      100,
+		"redis.db.keys/3":                      5,
+		"redis.db.expires/0":                   10,
+		"redis.db.expires/3":                   0,
+	}, metricValues(metrics))
+
+	// The cumulative metrics start when the server started.
+	uptime := metrics.At(0).IntSum().DataPoints().At(0)
+	assert.Equal(t, "redis.uptime", metrics.At(0).Name())
+	assert.Equal(t, 7200*1e9, float64(uptime.Timestamp()-uptime.StartTime()))
+
+	require.NoError(t, rs.shutdown(context.Background()))
+	assert.True(t, c.closed)
+}
+
+func TestScrapeReplica(t *testing.T) {
+	rs := startTestScraper(t, &fakeClient{infoReply: lines(
+		"role:slave",
+		"master_link_status:down",
+		"connected_slaves:0",
+	)})
+
+	rms, err := rs.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{
+		"redis.replication.connected_replicas": 0,
+		"redis.replication.master_link.up":     0,
+	}, metricValues(rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()))
+}
+
+func TestScrapeCluster(t *testing.T) {
+	c := &fakeClient{
+		infoReply: lines("cluster_enabled:1", "db0:keys=10,expires=0,avg_ttl=0"),
+		clusterInfoReply: lines(
+			"cluster_state:ok",
+			"cluster_slots_assigned:16384",
+			"cluster_slots_ok:16380",
+			"cluster_slots_pfail:4",
+			"cluster_slots_fail:0",
+			"cluster_known_nodes:6",
+			"cluster_size:3",
+		),
+	}
+	rs := startTestScraper(t, c)
+
+	rms, err := rs.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{
+		"redis.db.keys/0":              10,
+		"redis.db.expires/0":           0,
+		"redis.cluster.slots/assigned": 16384,
+		"redis.cluster.slots/ok":       16380,
+		"redis.cluster.slots/pfail":    4,
+		"redis.cluster.slots/fail":     0,
+		"redis.cluster.known_nodes":    6,
+	}, metricValues(rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()))
+
+	c.clusterInfoErr = errors.New("i/o timeout")
+	rms, err = rs.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Equal(t, map[string]float64{
+		"redis.db.keys/0":    10,
+		"redis.db.expires/0": 0,
+	}, metricValues(rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()))
+}
+
+func TestScrapeError(t *testing.T) {
+	rs := startTestScraper(t, &fakeClient{infoErr: errors.New("connection refused")})
+	rms, err := rs.scrape(context.Background())
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, 0, rms.Len())
+}
+
+func TestParseInfo(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"redis_version":       "6.0.9",
+		"executable":          "/usr/bin/redis-server",
+		"cmdstat_client|list": "calls=1,usec=10,usec_per_call=10.00",
+	}, parseInfo(lines(
+		"# Server",
+		"redis_version:6.0.9",
+		"executable:/usr/bin/redis-server",
+		"",
+		"# Commandstats",
+		"cmdstat_client|list:calls=1,usec=10,usec_per_call=10.00",
+		"invalid",
+	)))
+}
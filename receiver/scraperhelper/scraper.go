@@ -56,12 +56,27 @@ var _ BaseScraper = (*baseScraper)(nil)
 type baseScraper struct {
 	component.Component
 	name string
+
+	// consecutiveFailures is the number of consecutive scrapes that returned
+	// an error, the scrapes of a scraper are never called concurrently.
+	consecutiveFailures int
 }
 
-func (b baseScraper) Name() string {
+func (b *baseScraper) Name() string {
 	return b.name
 }
 
+// recordScrapeResult updates and records the number of consecutive scrapes
+// that returned an error.
+func (b *baseScraper) recordScrapeResult(ctx context.Context, err error) {
+	if err != nil {
+		b.consecutiveFailures++
+	} else {
+		b.consecutiveFailures = 0
+	}
+	obsreport.RecordConsecutiveScrapeFailures(ctx, b.consecutiveFailures)
+}
+
 // WithStart sets the function that will be called on startup.
 func WithStart(start componenthelper.Start) ScraperOption {
 	return func(s *componenthelper.ComponentSettings) {
@@ -107,11 +122,12 @@ func NewMetricsScraper(
 	return ms
 }
 
-func (ms metricsScraper) Scrape(ctx context.Context, receiverName string) (pdata.MetricSlice, error) {
-	ctx = obsreport.ScraperContext(ctx, receiverName, ms.Name())
-	ctx = obsreport.StartMetricsScrapeOp(ctx, receiverName, ms.Name())
+func (ms *metricsScraper) Scrape(ctx context.Context, receiverName string) (pdata.MetricSlice, error) {
+	scraperCtx := obsreport.ScraperContext(ctx, receiverName, ms.Name())
+	ctx = obsreport.StartMetricsScrapeOp(scraperCtx, receiverName, ms.Name())
 	metrics, err := ms.ScrapeMetrics(ctx)
 	obsreport.EndMetricsScrapeOp(ctx, metrics.Len(), err)
+	ms.recordScrapeResult(scraperCtx, err)
 	return metrics, err
 }
 
@@ -146,11 +162,12 @@ func NewResourceMetricsScraper(
 	return rms
 }
 
-func (rms resourceMetricsScraper) Scrape(ctx context.Context, receiverName string) (pdata.ResourceMetricsSlice, error) {
-	ctx = obsreport.ScraperContext(ctx, receiverName, rms.Name())
-	ctx = obsreport.StartMetricsScrapeOp(ctx, receiverName, rms.Name())
+func (rms *resourceMetricsScraper) Scrape(ctx context.Context, receiverName string) (pdata.ResourceMetricsSlice, error) {
+	scraperCtx := obsreport.ScraperContext(ctx, receiverName, rms.Name())
+	ctx = obsreport.StartMetricsScrapeOp(scraperCtx, receiverName, rms.Name())
 	resourceMetrics, err := rms.ScrapeResourceMetrics(ctx)
 	obsreport.EndMetricsScrapeOp(ctx, metricCount(resourceMetrics), err)
+	rms.recordScrapeResult(scraperCtx, err)
 	return resourceMetrics, err
 }
 
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+func TestScrapeConsecutiveFailures(t *testing.T) {
+	done, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer done()
+
+	scrapeErrs := []error{
+		errors.New("err1"),
+		scrapererror.NewPartialScrapeError(errors.New("err2"), 1),
+		nil,
+		errors.New("err3"),
+	}
+	expectedFailures := []int64{1, 2, 0, 1}
+
+	var scrapeErr error
+	metricsScraper := NewMetricsScraper("scraper", func(context.Context) (pdata.MetricSlice, error) {
+		return singleMetric(), scrapeErr
+	})
+	resourceMetricsScraper := NewResourceMetricsScraper("resource_scraper", func(context.Context) (pdata.ResourceMetricsSlice, error) {
+		return singleResourceMetric(), scrapeErr
+	})
+
+	for i, err := range scrapeErrs {
+		scrapeErr = err
+
+		_, err = metricsScraper.Scrape(context.Background(), "receiver")
+		assert.Equal(t, scrapeErr, err)
+		obsreporttest.CheckScraperConsecutiveFailuresViews(t, "receiver", "scraper", expectedFailures[i])
+
+		_, err = resourceMetricsScraper.Scrape(context.Background(), "receiver")
+		assert.Equal(t, scrapeErr, err)
+		obsreporttest.CheckScraperConsecutiveFailuresViews(t, "receiver", "resource_scraper", expectedFailures[i])
+	}
+
+	obsreporttest.CheckScraperDurationViews(t, "receiver", "scraper", int64(len(scrapeErrs)))
+	obsreporttest.CheckScraperDurationViews(t, "receiver", "resource_scraper", int64(len(scrapeErrs)))
+}
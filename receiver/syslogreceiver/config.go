@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines configuration for the syslog receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Protocol is the format of the received messages, "rfc5424" or "rfc3164".
+	Protocol string `mapstructure:"protocol"`
+
+	// Location is the IANA name of the time zone of the RFC 3164 timestamps, which have none, e.g. "Europe/Paris".
+	Location string `mapstructure:"location"`
+
+	// UDP configures the reception of one message per datagram. It is disabled if nil.
+	UDP *UDPConfig `mapstructure:"udp"`
+
+	// TCP configures the reception of messages framed with octet counting or terminated by a newline, as described in
+	// RFC 6587. It is disabled if nil.
+	TCP *TCPConfig `mapstructure:"tcp"`
+}
+
+// UDPConfig defines configuration for the reception of messages over UDP.
+type UDPConfig struct {
+	// Endpoint is the "host:port" address to listen on.
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+// TCPConfig defines configuration for the reception of messages over TCP.
+type TCPConfig struct {
+	confignet.TCPAddr `mapstructure:",squash"`
+
+	// TLSSetting configures the TLS termination of the connections, as described in RFC 5425. They are not encrypted
+	// if nil.
+	TLSSetting *configtls.TLSServerSetting `mapstructure:"tls_settings"`
+
+	// MaxMessageSize is the maximum size in bytes of the messages, 64 KiB if zero. The connections sending bigger
+	// messages are closed.
+	MaxMessageSize int `mapstructure:"max_message_size"`
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// The attributes of the log records.
+const (
+	attributeFacility       = "syslog.facility"
+	attributePriority       = "syslog.priority"
+	attributeVersion        = "syslog.version"
+	attributeHostname       = "syslog.hostname"
+	attributeAppName        = "syslog.appname"
+	attributeProcID         = "syslog.proc_id"
+	attributeMsgID          = "syslog.msg_id"
+	attributeStructuredData = "syslog.structured_data"
+)
+
+// severities maps the syslog severities, the priority modulo 8, to the log severities.
+var severities = [8]struct {
+	text   string
+	number pdata.SeverityNumber
+}{
+	{"emerg", pdata.SeverityNumberFATAL},
+	{"alert", pdata.SeverityNumberERROR3},
+	{"crit", pdata.SeverityNumberERROR2},
+	{"err", pdata.SeverityNumberERROR},
+	{"warning", pdata.SeverityNumberWARN},
+	{"notice", pdata.SeverityNumberINFO2},
+	{"info", pdata.SeverityNumberINFO},
+	{"debug", pdata.SeverityNumberDEBUG},
+}
+
+// fillLogRecord fills lr with the message received at observed from peerIP, which is empty if unknown.
+func (m *message) fillLogRecord(lr pdata.LogRecord, observed time.Time, peerIP string) {
+	ts := m.timestamp
+	if ts.IsZero() {
+		ts = observed
+	}
+	lr.SetTimestamp(pdata.TimestampFromTime(ts))
+
+	severity := severities[m.priority%8]
+	lr.SetSeverityText(severity.text)
+	lr.SetSeverityNumber(severity.number)
+	lr.Body().SetStringVal(m.msg)
+
+	attrs := lr.Attributes()
+	attrs.InsertInt(attributeFacility, int64(m.priority/8))
+	attrs.InsertInt(attributePriority, int64(m.priority))
+	if m.version != 0 {
+		attrs.InsertInt(attributeVersion, int64(m.version))
+	}
+	insertNonEmptyString(attrs, attributeHostname, m.hostname)
+	insertNonEmptyString(attrs, attributeAppName, m.appName)
+	insertNonEmptyString(attrs, attributeProcID, m.procID)
+	insertNonEmptyString(attrs, attributeMsgID, m.msgID)
+	if len(m.structuredData) > 0 {
+		sd := pdata.NewAttributeValueMap()
+		for _, element := range m.structuredData {
+			params := pdata.NewAttributeValueMap()
+			for _, param := range element.params {
+				params.MapVal().InsertString(param.name, param.value)
+			}
+			sd.MapVal().Insert(element.id, params)
+		}
+		attrs.Insert(attributeStructuredData, sd)
+	}
+	insertNonEmptyString(attrs, conventions.AttributeNetPeerIP, peerIP)
+}
+
+func insertNonEmptyString(attrs pdata.AttributeMap, key, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateReceiver(t *testing.T) {
+	factory := NewFactory()
+	require.Equal(t, configmodels.Type("syslog"), factory.Type())
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "udp",
+			modify: func(cfg *Config) {
+				cfg.UDP = &UDPConfig{Endpoint: "localhost:0"}
+			},
+		},
+		{
+			name: "rfc3164",
+			modify: func(cfg *Config) {
+				cfg.Protocol = protocolRFC3164
+				cfg.Location = "America/New_York"
+				cfg.UDP = &UDPConfig{Endpoint: "localhost:0"}
+			},
+		},
+		{
+			name:    "no listener",
+			modify:  func(cfg *Config) {},
+			wantErr: errNoListener.Error(),
+		},
+		{
+			name: "unknown protocol",
+			modify: func(cfg *Config) {
+				cfg.Protocol = "rfc9999"
+				cfg.UDP = &UDPConfig{Endpoint: "localhost:0"}
+			},
+			wantErr: `unknown protocol "rfc9999", expecting "rfc5424" or "rfc3164"`,
+		},
+		{
+			name: "unknown location",
+			modify: func(cfg *Config) {
+				cfg.Location = "Nowhere/Atlantis"
+				cfg.UDP = &UDPConfig{Endpoint: "localhost:0"}
+			},
+			wantErr: "unknown time zone Nowhere/Atlantis",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+			r, err := factory.CreateLogsReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewLogsNop())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, r)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	protocolRFC5424 = "rfc5424"
+	protocolRFC3164 = "rfc3164"
+
+	// The nil value of the fields of the RFC 5424 messages.
+	nilValue = "-"
+)
+
+var (
+	errInvalidPriority      = errors.New("invalid priority")
+	errInvalidVersion       = errors.New("invalid version")
+	errMissingField         = errors.New("missing header field")
+	errInvalidSD            = errors.New("invalid structured data")
+	utf8BOM                 = []byte{0xef, 0xbb, 0xbf}
+	rfc3164TimestampLayouts = []string{"Jan _2 15:04:05", "Jan 02 15:04:05"}
+)
+
+// message is a parsed syslog message.
+type message struct {
+	priority int
+	// version is 0 for the RFC 3164 messages.
+	version int
+	// timestamp is the zero time if the message has none.
+	timestamp      time.Time
+	hostname       string
+	appName        string
+	procID         string
+	msgID          string
+	structuredData []sdElement
+	msg            string
+}
+
+// sdElement is an element of the structured data of an RFC 5424 message.
+type sdElement struct {
+	id     string
+	params []sdParam
+}
+
+type sdParam struct {
+	name  string
+	value string
+}
+
+// parser parses the messages of the configured protocol.
+type parser struct {
+	protocol string
+	// location is the time zone of the RFC 3164 timestamps.
+	location *time.Location
+}
+
+// parse parses a message, now being the time it is received at, used to complete the RFC 3164 timestamps that have no
+// year.
+func (p *parser) parse(data []byte, now time.Time) (*message, error) {
+	// The trailers of the non-transparent framing.
+	data = bytes.TrimRight(data, "\r\n\x00")
+	m := &message{}
+	rest, err := parsePriority(data, m)
+	if err != nil {
+		return nil, err
+	}
+	if p.protocol == protocolRFC3164 {
+		p.parseRFC3164(rest, now, m)
+		return m, nil
+	}
+	if err = parseRFC5424(rest, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// parsePriority parses the "<PRI>" prefix of the message and returns what follows it.
+func parsePriority(data []byte, m *message) ([]byte, error) {
+	end := bytes.IndexByte(data, '>')
+	if len(data) < 3 || data[0] != '<' || end < 2 || end > 4 {
+		return nil, errInvalidPriority
+	}
+	pri, err := strconv.Atoi(string(data[1:end]))
+	if err != nil || pri < 0 || pri > 191 {
+		return nil, errInvalidPriority
+	}
+	m.priority = pri
+	return data[end+1:], nil
+}
+
+// parseRFC5424 parses "VERSION SP TIMESTAMP SP HOSTNAME SP APP-NAME SP PROCID SP MSGID SP STRUCTURED-DATA [SP MSG]".
+func parseRFC5424(data []byte, m *message) error {
+	field, data := nextField(data)
+	version, err := strconv.Atoi(string(field))
+	if err != nil || version < 1 || version > 99 {
+		return errInvalidVersion
+	}
+	m.version = version
+
+	var fields [5][]byte
+	for i := range fields {
+		fields[i], data = nextField(data)
+		if len(fields[i]) == 0 {
+			return errMissingField
+		}
+	}
+	if ts := string(fields[0]); ts != nilValue {
+		if m.timestamp, err = time.Parse(time.RFC3339Nano, ts); err != nil {
+			return fmt.Errorf("invalid timestamp: %w", err)
+		}
+	}
+	m.hostname = nilToEmpty(fields[1])
+	m.appName = nilToEmpty(fields[2])
+	m.procID = nilToEmpty(fields[3])
+	m.msgID = nilToEmpty(fields[4])
+
+	if data, err = parseStructuredData(data, m); err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		if data[0] != ' ' {
+			return errInvalidSD
+		}
+		m.msg = string(bytes.TrimPrefix(data[1:], utf8BOM))
+	}
+	return nil
+}
+
+// parseStructuredData parses the "-" nil value or the "[SD-ID SD-PARAM...]..." elements and returns what follows
+// them.
+func parseStructuredData(data []byte, m *message) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errMissingField
+	}
+	if data[0] == '-' {
+		return data[1:], nil
+	}
+	for len(data) > 0 && data[0] == '[' {
+		end := bytes.IndexAny(data, " ]")
+		if end < 2 {
+			return nil, errInvalidSD
+		}
+		element := sdElement{id: string(data[1:end])}
+		data = data[end:]
+		for len(data) > 0 && data[0] == ' ' {
+			eq := bytes.IndexByte(data, '=')
+			if eq < 2 || eq+1 >= len(data) || data[eq+1] != '"' {
+				return nil, errInvalidSD
+			}
+			param := sdParam{name: string(data[1:eq])}
+			var err error
+			if param.value, data, err = parseParamValue(data[eq+2:]); err != nil {
+				return nil, err
+			}
+			element.params = append(element.params, param)
+		}
+		if len(data) == 0 || data[0] != ']' {
+			return nil, errInvalidSD
+		}
+		data = data[1:]
+		m.structuredData = append(m.structuredData, element)
+	}
+	if m.structuredData == nil {
+		return nil, errInvalidSD
+	}
+	return data, nil
+}
+
+// parseParamValue parses a parameter value up to its closing quote, unescaping the '"', '\' and ']' characters, and
+// returns what follows the quote.
+func parseParamValue(data []byte) (string, []byte, error) {
+	var value []byte
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			if i+1 < len(data) && (data[i+1] == '"' || data[i+1] == '\\' || data[i+1] == ']') {
+				i++
+			}
+		case '"':
+			return string(value), data[i+1:], nil
+		}
+		value = append(value, data[i])
+	}
+	return "", nil, errInvalidSD
+}
+
+// parseRFC3164 parses "TIMESTAMP SP HOSTNAME SP TAG[PID]: MSG". Following the RFC, the whole content is the message
+// when it does not start with a valid timestamp.
+func (p *parser) parseRFC3164(data []byte, now time.Time, m *message) {
+	rest, ok := p.parseRFC3164Timestamp(data, now, m)
+	if !ok {
+		m.msg = string(data)
+		return
+	}
+
+	var hostname []byte
+	hostname, rest = nextField(rest)
+	m.hostname = string(hostname)
+
+	// The tag is the name of the program, optionally followed by its PID between brackets, and ends with a colon.
+	tagEnd := bytes.IndexByte(rest, ':')
+	if tagEnd < 1 || bytes.IndexByte(rest[:tagEnd], ' ') >= 0 {
+		m.msg = string(rest)
+		return
+	}
+	tag := rest[:tagEnd]
+	if open := bytes.IndexByte(tag, '['); open > 0 && tag[len(tag)-1] == ']' {
+		m.procID = string(tag[open+1 : len(tag)-1])
+		tag = tag[:open]
+	}
+	m.appName = string(tag)
+	m.msg = string(bytes.TrimPrefix(rest[tagEnd+1:], []byte(" ")))
+}
+
+// parseRFC3164Timestamp parses the "Mmm dd hh:mm:ss" timestamp, or an RFC 3339 timestamp sent by the senders mixing
+// both formats, and returns what follows it.
+func (p *parser) parseRFC3164Timestamp(data []byte, now time.Time, m *message) ([]byte, bool) {
+	if field, rest := nextField(data); len(field) > 0 {
+		if ts, err := time.Parse(time.RFC3339Nano, string(field)); err == nil {
+			m.timestamp = ts
+			return rest, true
+		}
+	}
+
+	const layoutLen = len("Jan _2 15:04:05")
+	if len(data) < layoutLen+1 || data[layoutLen] != ' ' {
+		return nil, false
+	}
+	for _, layout := range rfc3164TimestampLayouts {
+		ts, err := time.ParseInLocation(layout, string(data[:layoutLen]), p.location)
+		if err != nil {
+			continue
+		}
+		// The timestamp has no year: it is the one of the current year, or of the previous year for the messages
+		// sent around the new year by a host whose clock is a bit ahead.
+		now = now.In(p.location)
+		ts = ts.AddDate(now.Year(), 0, 0)
+		if ts.After(now.AddDate(0, 0, 1)) {
+			ts = ts.AddDate(-1, 0, 0)
+		}
+		m.timestamp = ts
+		return data[layoutLen+1:], true
+	}
+	return nil, false
+}
+
+// nextField returns the content of data up to the next space, and what follows the space.
+func nextField(data []byte) ([]byte, []byte) {
+	if i := bytes.IndexByte(data, ' '); i >= 0 {
+		return data[:i], data[i+1:]
+	}
+	return data, nil
+}
+
+func nilToEmpty(field []byte) string {
+	if string(field) == nilValue {
+		return ""
+	}
+	return string(field)
+}
@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRFC5424(t *testing.T) {
+	p := &parser{protocol: protocolRFC5424, location: time.UTC}
+	tests := []struct {
+		name    string
+		data    string
+		want    *message
+		wantErr error
+	}{
+		{
+			name: "all fields",
+			data: `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 [exampleSDID@32473 iut="3" eventSource="Application"][examplePriority@32473 class="high"] An application event`,
+			want: &message{
+				priority:  165,
+				version:   1,
+				timestamp: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+				hostname:  "mymachine.example.com",
+				appName:   "evntslog",
+				procID:    "1234",
+				msgID:     "ID47",
+				structuredData: []sdElement{
+					{id: "exampleSDID@32473", params: []sdParam{{"iut", "3"}, {"eventSource", "Application"}}},
+					{id: "examplePriority@32473", params: []sdParam{{"class", "high"}}},
+				},
+				msg: "An application event",
+			},
+		},
+		{
+			name: "nil values",
+			data: "<34>1 - - - - - -",
+			want: &message{priority: 34, version: 1},
+		},
+		{
+			name: "BOM and trailer",
+			data: "<34>1 2003-10-11T22:14:15.003+02:00 mymachine su - ID47 - \xef\xbb\xbf'su root' failed\n",
+			want: &message{
+				priority:  34,
+				version:   1,
+				timestamp: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.FixedZone("", 2*3600)),
+				hostname:  "mymachine",
+				appName:   "su",
+				msgID:     "ID47",
+				msg:       "'su root' failed",
+			},
+		},
+		{
+			name: "escaped parameter value",
+			data: `<13>1 - host app - - [id@1 a="say \"hi\" \\ [x\]" b="\n"]`,
+			want: &message{
+				priority:       13,
+				version:        1,
+				hostname:       "host",
+				appName:        "app",
+				structuredData: []sdElement{{id: "id@1", params: []sdParam{{"a", `say "hi" \ [x]`}, {"b", `\n`}}}},
+			},
+		},
+		{
+			name:    "missing priority",
+			data:    "1 - - - - - -",
+			wantErr: errInvalidPriority,
+		},
+		{
+			name:    "priority out of range",
+			data:    "<192>1 - - - - - -",
+			wantErr: errInvalidPriority,
+		},
+		{
+			name:    "invalid version",
+			data:    "<34>one - - - - - -",
+			wantErr: errInvalidVersion,
+		},
+		{
+			name:    "missing field",
+			data:    "<34>1 - host app",
+			wantErr: errMissingField,
+		},
+		{
+			name:    "unterminated structured data",
+			data:    `<34>1 - - - - - [id@1 a="b"`,
+			wantErr: errInvalidSD,
+		},
+		{
+			name:    "unterminated parameter value",
+			data:    `<34>1 - - - - - [id@1 a="b]`,
+			wantErr: errInvalidSD,
+		},
+		{
+			name:    "invalid structured data",
+			data:    `<34>1 - - - - - msg`,
+			wantErr: errInvalidSD,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.parse([]byte(tt.data), time.Now())
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.timestamp.Equal(got.timestamp))
+			tt.want.timestamp = got.timestamp
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseRFC3164(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	p := &parser{protocol: protocolRFC3164, location: paris}
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		data    string
+		want    *message
+		wantErr error
+	}{
+		{
+			name: "tag and pid",
+			data: "<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8",
+			want: &message{
+				priority:  34,
+				timestamp: time.Date(2020, 10, 11, 22, 14, 15, 0, paris),
+				hostname:  "mymachine",
+				appName:   "su",
+				procID:    "123",
+				msg:       "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			name: "space padded day",
+			data: "<13>Feb  5 17:32:18 10.0.0.99 myapp: Use the BFG!\n",
+			want: &message{
+				priority:  13,
+				timestamp: time.Date(2021, 2, 5, 17, 32, 18, 0, paris),
+				hostname:  "10.0.0.99",
+				appName:   "myapp",
+				msg:       "Use the BFG!",
+			},
+		},
+		{
+			name: "no tag",
+			data: "<13>Mar  1 10:00:00 router link down on port 3",
+			want: &message{
+				priority:  13,
+				timestamp: time.Date(2021, 3, 1, 10, 0, 0, 0, paris),
+				hostname:  "router",
+				msg:       "link down on port 3",
+			},
+		},
+		{
+			name: "RFC 3339 timestamp",
+			data: "<13>2021-02-05T17:32:18.5Z host app: msg",
+			want: &message{
+				priority:  13,
+				timestamp: time.Date(2021, 2, 5, 17, 32, 18, 500000000, time.UTC),
+				hostname:  "host",
+				appName:   "app",
+				msg:       "msg",
+			},
+		},
+		{
+			name: "no timestamp",
+			data: "<189>123: *Mar  1 00:00:00: %SYS-5-CONFIG_I: Configured from console",
+			want: &message{
+				priority: 189,
+				msg:      "123: *Mar  1 00:00:00: %SYS-5-CONFIG_I: Configured from console",
+			},
+		},
+		{
+			name:    "invalid priority",
+			data:    "<abc>Oct 11 22:14:15 mymachine su: msg",
+			wantErr: errInvalidPriority,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.parse([]byte(tt.data), now)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.timestamp.Equal(got.timestamp), "got timestamp %v", got.timestamp)
+			tt.want.timestamp = got.timestamp
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	transportUDP = "udp"
+	transportTCP = "tcp"
+	format       = "syslog"
+
+	defaultMaxMessageSize = 64 * 1024
+	maxDatagramSize       = 64 * 1024
+
+	// Give the record channels a bit of buffer to batch the messages received
+	// while the next consumer is busy.
+	recordChannelLength = 100
+)
+
+var (
+	errNoListener      = errors.New("at least one of udp and tcp must be configured")
+	errMessageTooLong  = errors.New("message exceeds the maximum size")
+	errInvalidFraming  = errors.New("invalid octet counting frame")
+	errUnknownProtocol = errors.New("unknown protocol")
+)
+
+// record is a message waiting to be converted to a log record.
+type record struct {
+	msg      *message
+	observed time.Time
+	peerIP   string
+}
+
+type syslogReceiver struct {
+	config *Config
+	parser *parser
+	logger *zap.Logger
+	next   consumer.LogsConsumer
+
+	udpConn     net.PacketConn
+	tcpListener net.Listener
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+func newSyslogReceiver(logger *zap.Logger, config *Config, next consumer.LogsConsumer) (component.LogsReceiver, error) {
+	if config.UDP == nil && config.TCP == nil {
+		return nil, errNoListener
+	}
+	if config.Protocol != protocolRFC5424 && config.Protocol != protocolRFC3164 {
+		return nil, fmt.Errorf("%w %q, expecting %q or %q", errUnknownProtocol, config.Protocol, protocolRFC5424, protocolRFC3164)
+	}
+	location, err := time.LoadLocation(config.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	return &syslogReceiver{
+		config: config,
+		parser: &parser{protocol: config.Protocol, location: location},
+		logger: logger,
+		next:   next,
+	}, nil
+}
+
+func (r *syslogReceiver) Start(_ context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	if r.config.UDP != nil {
+		conn, err := net.ListenPacket("udp", r.config.UDP.Endpoint)
+		if err != nil {
+			r.close()
+			return err
+		}
+		r.udpConn = conn
+
+		recordCh := make(chan record, recordChannelLength)
+		r.startGoroutine(func() { r.consumeRecords(ctx, transportUDP, recordCh) })
+		r.startGoroutine(func() { r.readDatagrams(ctx, conn, recordCh) })
+	}
+
+	if r.config.TCP != nil {
+		listener, err := r.listenTCP()
+		if err != nil {
+			r.close()
+			return err
+		}
+		r.tcpListener = listener
+
+		recordCh := make(chan record, recordChannelLength)
+		r.startGoroutine(func() { r.consumeRecords(ctx, transportTCP, recordCh) })
+		r.startGoroutine(func() { r.acceptConnections(ctx, listener, recordCh) })
+	}
+
+	return nil
+}
+
+func (r *syslogReceiver) Shutdown(context.Context) error {
+	r.close()
+	r.wg.Wait()
+	return nil
+}
+
+func (r *syslogReceiver) close() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	if r.udpConn != nil {
+		_ = r.udpConn.Close()
+	}
+	if r.tcpListener != nil {
+		_ = r.tcpListener.Close()
+	}
+}
+
+func (r *syslogReceiver) startGoroutine(f func()) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		f()
+	}()
+}
+
+func (r *syslogReceiver) listenTCP() (net.Listener, error) {
+	listener, err := r.config.TCP.Listen()
+	if err != nil {
+		return nil, err
+	}
+	if r.config.TCP.TLSSetting != nil {
+		tlsCfg, err := r.config.TCP.TLSSetting.LoadTLSConfig()
+		if err != nil {
+			_ = listener.Close()
+			return nil, err
+		}
+		listener = tls.NewListener(listener, tlsCfg)
+	}
+	return listener, nil
+}
+
+func (r *syslogReceiver) readDatagrams(ctx context.Context, conn net.PacketConn, recordCh chan<- record) {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Error("Failed to read syslog datagram", zap.Error(err))
+			}
+			return
+		}
+		r.handleMessage(ctx, buf[:n], addr, recordCh)
+	}
+}
+
+func (r *syslogReceiver) acceptConnections(ctx context.Context, listener net.Listener, recordCh chan<- record) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Error("Failed to accept syslog connection", zap.Error(err))
+			}
+			return
+		}
+		r.startGoroutine(func() { r.handleConnection(ctx, conn, recordCh) })
+	}
+}
+
+func (r *syslogReceiver) handleConnection(ctx context.Context, conn net.Conn, recordCh chan<- record) {
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Unblock the reads on shutdown.
+	go func() {
+		<-connCtx.Done()
+		_ = conn.Close()
+	}()
+
+	maxSize := r.config.TCP.MaxMessageSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxMessageSize
+	}
+	reader := bufio.NewReaderSize(conn, maxSize)
+	for {
+		data, err := readFrame(reader, maxSize)
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				r.logger.Debug("Closing syslog connection", zap.Stringer("peer", conn.RemoteAddr()), zap.Error(err))
+			}
+			return
+		}
+		r.handleMessage(ctx, data, conn.RemoteAddr(), recordCh)
+	}
+}
+
+// readFrame reads a message framed with octet counting, "MSG-LEN SP SYSLOG-MSG", or terminated by a newline, as
+// described in RFC 6587. The returned slice is only valid until the next read.
+func readFrame(reader *bufio.Reader, maxSize int) ([]byte, error) {
+	first, err := reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if first[0] >= '1' && first[0] <= '9' {
+		lenField, err := reader.ReadSlice(' ')
+		if err != nil {
+			if err == bufio.ErrBufferFull {
+				return nil, errInvalidFraming
+			}
+			return nil, err
+		}
+		n, err := strconv.Atoi(string(lenField[:len(lenField)-1]))
+		if err != nil {
+			return nil, errInvalidFraming
+		}
+		if n > maxSize {
+			return nil, errMessageTooLong
+		}
+		data := make([]byte, n)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	data, err := reader.ReadSlice('\n')
+	switch {
+	case err == bufio.ErrBufferFull:
+		return nil, errMessageTooLong
+	case err == io.EOF && len(data) > 0:
+		// The last message of the connection may have no trailer.
+		return data, nil
+	case err != nil:
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *syslogReceiver) handleMessage(ctx context.Context, data []byte, addr net.Addr, recordCh chan<- record) {
+	now := time.Now()
+	msg, err := r.parser.parse(data, now)
+	if err != nil {
+		r.logger.Debug("Dropping invalid syslog message", zap.Stringer("peer", addr), zap.Error(err))
+		return
+	}
+
+	select {
+	case recordCh <- record{msg: msg, observed: now, peerIP: peerIP(addr)}:
+	case <-ctx.Done():
+	}
+}
+
+func peerIP(addr net.Addr) string {
+	switch a := addr.(type) {
+	case *net.UDPAddr:
+		return a.IP.String()
+	case *net.TCPAddr:
+		return a.IP.String()
+	}
+	return ""
+}
+
+// consumeRecords converts the records to logs and sends them to the next consumer, batching the records that wait in
+// the channel.
+func (r *syslogReceiver) consumeRecords(ctx context.Context, transport string, recordCh <-chan record) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case rec := <-recordCh:
+			records := fillBufferUntilChanEmpty(recordCh, []record{rec})
+
+			logs := pdata.NewLogs()
+			rls := logs.ResourceLogs()
+			rls.Resize(1)
+			ills := rls.At(0).InstrumentationLibraryLogs()
+			ills.Resize(1)
+			logSlice := ills.At(0).Logs()
+			logSlice.Resize(len(records))
+			for i, rec := range records {
+				rec.msg.fillLogRecord(logSlice.At(i), rec.observed, rec.peerIP)
+			}
+
+			obsCtx := obsreport.ReceiverContext(ctx, r.config.Name(), transport)
+			obsCtx = obsreport.StartLogsReceiveOp(obsCtx, r.config.Name(), transport)
+			err := r.next.ConsumeLogs(obsCtx, logs)
+			obsreport.EndLogsReceiveOp(obsCtx, format, len(records), err)
+		}
+	}
+}
+
+func fillBufferUntilChanEmpty(recordCh <-chan record, buf []record) []record {
+	for {
+		select {
+		case rec := <-recordCh:
+			buf = append(buf, rec)
+		default:
+			return buf
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func startReceiver(t *testing.T, modify func(cfg *Config)) (*syslogReceiver, *consumertest.LogsSink) {
+	cfg := createDefaultConfig().(*Config)
+	modify(cfg)
+	next := new(consumertest.LogsSink)
+	r, err := newSyslogReceiver(zap.NewNop(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, r.Shutdown(context.Background())) })
+	return r.(*syslogReceiver), next
+}
+
+func logRecords(next *consumertest.LogsSink) []pdata.LogRecord {
+	var records []pdata.LogRecord
+	for _, logs := range next.AllLogs() {
+		rls := logs.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			ills := rls.At(i).InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				lrs := ills.At(j).Logs()
+				for k := 0; k < lrs.Len(); k++ {
+					records = append(records, lrs.At(k))
+				}
+			}
+		}
+	}
+	return records
+}
+
+func waitForLogRecords(t *testing.T, next *consumertest.LogsSink, n int) []pdata.LogRecord {
+	require.Eventually(t, func() bool {
+		return next.LogRecordsCount() == n
+	}, 5*time.Second, 10*time.Millisecond)
+	return logRecords(next)
+}
+
+func TestUDP(t *testing.T) {
+	r, next := startReceiver(t, func(cfg *Config) {
+		cfg.UDP = &UDPConfig{Endpoint: "127.0.0.1:0"}
+	})
+
+	conn, err := net.Dial("udp", r.udpConn.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte(`<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 [exampleSDID@32473 iut="3" eventSource="Application"] An application event`))
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("invalid"))
+	require.NoError(t, err)
+
+	records := waitForLogRecords(t, next, 1)
+	lr := records[0]
+	assert.Equal(t, pdata.TimestampFromTime(time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC)), lr.Timestamp())
+	assert.Equal(t, "notice", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberINFO2, lr.SeverityNumber())
+	assert.Equal(t, "An application event", lr.Body().StringVal())
+
+	want := pdata.NewAttributeMap()
+	want.InsertInt(attributeFacility, 20)
+	want.InsertInt(attributePriority, 165)
+	want.InsertInt(attributeVersion, 1)
+	want.InsertString(attributeHostname, "mymachine.example.com")
+	want.InsertString(attributeAppName, "evntslog")
+	want.InsertString(attributeProcID, "1234")
+	want.InsertString(attributeMsgID, "ID47")
+	params := pdata.NewAttributeValueMap()
+	params.MapVal().InsertString("iut", "3")
+	params.MapVal().InsertString("eventSource", "Application")
+	sd := pdata.NewAttributeValueMap()
+	sd.MapVal().Insert("exampleSDID@32473", params)
+	want.Insert(attributeStructuredData, sd)
+	want.InsertString(conventions.AttributeNetPeerIP, "127.0.0.1")
+	assert.Equal(t, want.Sort(), lr.Attributes().Sort())
+}
+
+func TestTCPFraming(t *testing.T) {
+	r, next := startReceiver(t, func(cfg *Config) {
+		cfg.Protocol = protocolRFC3164
+		cfg.TCP = &TCPConfig{TCPAddr: confignet.TCPAddr{Endpoint: "127.0.0.1:0"}}
+	})
+
+	conn, err := net.Dial("tcp", r.tcpListener.Addr().String())
+	require.NoError(t, err)
+	messages := []string{
+		"<34>Oct 11 22:14:15 mymachine su: first\n",
+		octetCounted("<34>Oct 11 22:14:16 mymachine su: second"),
+		"<34>Oct 11 22:14:17 mymachine su: third\n",
+		"<34>Oct 11 22:14:18 mymachine su: fourth",
+	}
+	_, err = conn.Write([]byte(strings.Join(messages, "")))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	records := waitForLogRecords(t, next, 4)
+	for i, want := range []string{"first", "second", "third", "fourth"} {
+		assert.Equal(t, want, records[i].Body().StringVal())
+		appName, _ := records[i].Attributes().Get(attributeAppName)
+		assert.Equal(t, "su", appName.StringVal())
+	}
+}
+
+func TestTCPMaxMessageSize(t *testing.T) {
+	r, next := startReceiver(t, func(cfg *Config) {
+		cfg.TCP = &TCPConfig{TCPAddr: confignet.TCPAddr{Endpoint: "127.0.0.1:0"}, MaxMessageSize: 32}
+	})
+
+	for _, data := range []string{
+		octetCounted("<34>1 - - - - - - " + strings.Repeat("a", 32)),
+		"<34>1 - - - - - - " + strings.Repeat("a", 32) + "\n",
+	} {
+		conn, err := net.Dial("tcp", r.tcpListener.Addr().String())
+		require.NoError(t, err)
+		_, err = conn.Write([]byte("<34>1 - - - - - - ok\n" + data))
+		require.NoError(t, err)
+
+		// The connection is closed after the first message.
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, err = conn.Read(make([]byte, 1))
+		assert.Error(t, err)
+		assert.False(t, isTimeout(err), "connection not closed")
+		require.NoError(t, conn.Close())
+	}
+	waitForLogRecords(t, next, 2)
+}
+
+func octetCounted(msg string) string {
+	return strconv.Itoa(len(msg)) + " " + msg
+}
+
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+func TestTLS(t *testing.T) {
+	r, next := startReceiver(t, func(cfg *Config) {
+		cfg.TCP = &TCPConfig{
+			TCPAddr: confignet.TCPAddr{Endpoint: "127.0.0.1:0"},
+			TLSSetting: &configtls.TLSServerSetting{
+				TLSSetting: configtls.TLSSetting{
+					CertFile: "../../config/configtls/testdata/test-cert.pem",
+					KeyFile:  "../../config/configtls/testdata/test-key.pem",
+				},
+			},
+		}
+	})
+
+	conn, err := tls.Dial("tcp", r.tcpListener.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	_, err = conn.Write([]byte(octetCounted("<34>1 - - - - - - tls")))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	records := waitForLogRecords(t, next, 1)
+	assert.Equal(t, "tls", records[0].Body().StringVal())
+}
+
+func TestStartFailure(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.UDP = &UDPConfig{Endpoint: "127.0.0.1:0"}
+	cfg.TCP = &TCPConfig{TCPAddr: confignet.TCPAddr{Endpoint: listener.Addr().String()}}
+	r, err := newSyslogReceiver(zap.NewNop(), cfg, consumertest.NewLogsNop())
+	require.NoError(t, err)
+	assert.Error(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookreceiver
+
+import (
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the webhook receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Configures the receiver server protocol.
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// MaxRequestBodySizeMiB limits the size (in MiB) of the request bodies, after their decompression (default 10).
+	// There is no limit if 0.
+	MaxRequestBodySizeMiB int64 `mapstructure:"max_request_body_size_mib"`
+
+	// Webhooks configures the paths receiving the payloads of the webhooks.
+	Webhooks []WebhookConfig `mapstructure:"webhooks"`
+}
+
+// WebhookConfig configures a path receiving the payloads of a webhook.
+type WebhookConfig struct {
+	// Path is the URL path of the webhook, e.g. /github.
+	Path string `mapstructure:"path"`
+
+	// Signature configures the validation of the HMAC signature of the payloads, which are not validated when nil.
+	Signature *SignatureConfig `mapstructure:"signature"`
+
+	// Fields maps the names of attributes to fields of the payload, whose path is their keys separated by dots,
+	// with the indexes of the elements of the arrays, e.g. alerts.0.status.
+	Fields map[string]string `mapstructure:"fields"`
+
+	// Headers maps the names of attributes to headers of the requests, e.g. X-GitHub-Event.
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+// SignatureConfig configures the validation of the HMAC signature of the payloads.
+type SignatureConfig struct {
+	// Header is the request header of the hexadecimal signature, e.g. X-Hub-Signature-256.
+	Header string `mapstructure:"header"`
+	// Secret is the key of the HMAC, shared with the sender of the webhook.
+	Secret string `mapstructure:"secret"`
+	// Algorithm is the hash function of the HMAC: sha1, sha256 or sha512 (default sha256).
+	Algorithm string `mapstructure:"algorithm"`
+	// Prefix precedes the signature in the header, e.g. "sha256=".
+	Prefix string `mapstructure:"prefix"`
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookreceiver
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(cfg.Receivers))
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[typeStr])
+
+	r := cfg.Receivers["webhook/custom"].(*Config)
+	assert.Equal(t, &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			NameVal: "webhook/custom",
+			TypeVal: typeStr,
+		},
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: "0.0.0.0:9000",
+		},
+		MaxRequestBodySizeMiB: 25,
+		Webhooks: []WebhookConfig{
+			{
+				Path: "/github",
+				Signature: &SignatureConfig{
+					Header: "X-Hub-Signature-256",
+					Secret: "secret",
+					Prefix: "sha256=",
+				},
+				Fields: map[string]string{
+					"github.action":     "action",
+					"github.repository": "repository.full_name",
+				},
+				Headers: map[string]string{
+					"github.event": "X-GitHub-Event",
+				},
+			},
+			{
+				Path: "/alertmanager",
+				Fields: map[string]string{
+					"alertmanager.status":    "status",
+					"alertmanager.alertname": "commonLabels.alertname",
+				},
+			},
+		},
+	}, r)
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.Equal(t, defaultBindEndpoint, cfg.Endpoint)
+	assert.Equal(t, int64(defaultMaxRequestBodySizeMiB), cfg.MaxRequestBodySizeMiB)
+	assert.Empty(t, cfg.Webhooks)
+}
+
+func TestCreateLogsReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Webhooks = []WebhookConfig{
+		{Path: "/github", Signature: &SignatureConfig{Header: "X-Hub-Signature", Secret: "secret", Algorithm: "sha1"}},
+		{Path: "/alertmanager"},
+	}
+	r, err := NewFactory().CreateLogsReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewLogsNop())
+	require.NoError(t, err)
+	assert.Len(t, r.(*webhookReceiver).webhooks, 2)
+}
+
+func TestCreateLogsReceiver_error(t *testing.T) {
+	tests := []struct {
+		name     string
+		webhooks []WebhookConfig
+		wantErr  string
+	}{
+		{
+			name:    "no_webhooks",
+			wantErr: errNoWebhooks.Error(),
+		},
+		{
+			name:     "relative_path",
+			webhooks: []WebhookConfig{{Path: "github"}},
+			wantErr:  `webhook path "github" must start with /`,
+		},
+		{
+			name:     "duplicate_path",
+			webhooks: []WebhookConfig{{Path: "/github"}, {Path: "/github"}},
+			wantErr:  "duplicate webhook path /github",
+		},
+		{
+			name:     "signature_secret",
+			webhooks: []WebhookConfig{{Path: "/github", Signature: &SignatureConfig{Header: "X-Hub-Signature-256"}}},
+			wantErr:  "signature of webhook /github requires a header and a secret",
+		},
+		{
+			name: "signature_algorithm",
+			webhooks: []WebhookConfig{{
+				Path:      "/github",
+				Signature: &SignatureConfig{Header: "X-Hub-Signature", Secret: "secret", Algorithm: "md5"},
+			}},
+			wantErr: `invalid signature algorithm "md5" of webhook /github, expecting sha1, sha256 or sha512`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Webhooks = tt.webhooks
+			r, err := NewFactory().CreateLogsReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewLogsNop())
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, r)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookreceiver
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// lookupField returns the field of the payload at the path, the keys of the objects and the indexes of the
+// elements of the arrays, if any.
+func lookupField(payload interface{}, path []string) (interface{}, bool) {
+	value := payload
+	for _, key := range path {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			field, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			value = field
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// splitFieldPath splits the path of a field, its keys separated by dots.
+func splitFieldPath(path string) []string {
+	return strings.Split(path, ".")
+}
+
+// insertFields inserts the fields in the order of their keys.
+func insertFields(attributes pdata.AttributeMap, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attributes.Insert(k, jsonToAttributeValue(fields[k]))
+	}
+}
+
+func jsonToAttributeValue(value interface{}) pdata.AttributeValue {
+	switch v := value.(type) {
+	case string:
+		return pdata.NewAttributeValueString(v)
+	case bool:
+		return pdata.NewAttributeValueBool(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return pdata.NewAttributeValueInt(i)
+		}
+		f, _ := v.Float64()
+		return pdata.NewAttributeValueDouble(f)
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		for _, e := range v {
+			av.ArrayVal().Append(jsonToAttributeValue(e))
+		}
+		return av
+	case map[string]interface{}:
+		av := pdata.NewAttributeValueMap()
+		insertFields(av.MapVal(), v)
+		return av
+	default:
+		return pdata.NewAttributeValueNull()
+	}
+}
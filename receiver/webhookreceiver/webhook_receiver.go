@@ -0,0 +1,293 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookreceiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	transport = "http"
+	format    = "json"
+
+	attributeWebhookPath = "webhook.path"
+)
+
+var (
+	errNoWebhooks        = errors.New("no webhooks configured")
+	errInvalidSignature  = errors.New("invalid signature")
+	errNotJSON           = errors.New("payload is not JSON")
+	errNextConsumerError = errors.New("internal server error")
+)
+
+var hashFuncs = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// webhook receives the payloads of a path.
+type webhook struct {
+	path string
+
+	// The signature is validated when secret is not nil.
+	secret          []byte
+	hash            func() hash.Hash
+	signatureHeader string
+	signaturePrefix string
+
+	fieldAttributes  []string
+	fieldPaths       [][]string
+	headerAttributes []string
+	headers          []string
+}
+
+func newWebhook(cfg WebhookConfig) (*webhook, error) {
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return nil, fmt.Errorf("webhook path %q must start with /", cfg.Path)
+	}
+	w := &webhook{path: cfg.Path}
+	if s := cfg.Signature; s != nil {
+		if s.Header == "" || s.Secret == "" {
+			return nil, fmt.Errorf("signature of webhook %s requires a header and a secret", cfg.Path)
+		}
+		algorithm := s.Algorithm
+		if algorithm == "" {
+			algorithm = defaultAlgorithm
+		}
+		w.hash = hashFuncs[algorithm]
+		if w.hash == nil {
+			return nil, fmt.Errorf("invalid signature algorithm %q of webhook %s, expecting sha1, sha256 or sha512", s.Algorithm, cfg.Path)
+		}
+		w.secret = []byte(s.Secret)
+		w.signatureHeader = s.Header
+		w.signaturePrefix = s.Prefix
+	}
+
+	// The attributes are inserted in the order of their names.
+	for _, attribute := range sortedKeys(cfg.Fields) {
+		w.fieldAttributes = append(w.fieldAttributes, attribute)
+		w.fieldPaths = append(w.fieldPaths, splitFieldPath(cfg.Fields[attribute]))
+	}
+	for _, attribute := range sortedKeys(cfg.Headers) {
+		w.headerAttributes = append(w.headerAttributes, attribute)
+		w.headers = append(w.headers, cfg.Headers[attribute])
+	}
+	return w, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateSignature validates the HMAC signature of the body in the header of the request, if required.
+func (w *webhook) validateSignature(header http.Header, body []byte) error {
+	if w.secret == nil {
+		return nil
+	}
+	value := header.Get(w.signatureHeader)
+	if !strings.HasPrefix(value, w.signaturePrefix) {
+		return errInvalidSignature
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(value, w.signaturePrefix))
+	if err != nil {
+		return errInvalidSignature
+	}
+	mac := hmac.New(w.hash, w.secret)
+	_, _ = mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// toLogs converts the JSON payload to a log record whose body is the payload, with the attributes of the
+// configured fields and headers.
+func (w *webhook) toLogs(header http.Header, body []byte, timestamp time.Time) (pdata.Logs, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil || decoder.More() {
+		return pdata.NewLogs(), errNotJSON
+	}
+
+	logs := pdata.NewLogs()
+	rls := logs.ResourceLogs()
+	rls.Resize(1)
+	ills := rls.At(0).InstrumentationLibraryLogs()
+	ills.Resize(1)
+	logSlice := ills.At(0).Logs()
+	logSlice.Resize(1)
+	lr := logSlice.At(0)
+	lr.SetTimestamp(pdata.TimestampFromTime(timestamp))
+	jsonToAttributeValue(payload).CopyTo(lr.Body())
+
+	attributes := lr.Attributes()
+	attributes.InsertString(attributeWebhookPath, w.path)
+	for i, attribute := range w.fieldAttributes {
+		if field, ok := lookupField(payload, w.fieldPaths[i]); ok {
+			attributes.Insert(attribute, jsonToAttributeValue(field))
+		}
+	}
+	for i, attribute := range w.headerAttributes {
+		if value := header.Get(w.headers[i]); value != "" {
+			attributes.InsertString(attribute, value)
+		}
+	}
+	return logs, nil
+}
+
+// webhookReceiver receives the JSON payloads of webhooks as logs.
+type webhookReceiver struct {
+	instanceName string
+	config       *Config
+	webhooks     map[string]*webhook
+	nextConsumer consumer.LogsConsumer
+	server       *http.Server
+
+	logger *zap.Logger
+}
+
+var _ http.Handler = (*webhookReceiver)(nil)
+
+func newReceiver(config *Config, logger *zap.Logger, nextConsumer consumer.LogsConsumer) (*webhookReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if len(config.Webhooks) == 0 {
+		return nil, errNoWebhooks
+	}
+	webhooks := make(map[string]*webhook, len(config.Webhooks))
+	for _, cfg := range config.Webhooks {
+		if webhooks[cfg.Path] != nil {
+			return nil, fmt.Errorf("duplicate webhook path %s", cfg.Path)
+		}
+		w, err := newWebhook(cfg)
+		if err != nil {
+			return nil, err
+		}
+		webhooks[cfg.Path] = w
+	}
+	return &webhookReceiver{
+		instanceName: config.Name(),
+		config:       config,
+		webhooks:     webhooks,
+		nextConsumer: nextConsumer,
+		logger:       logger,
+	}, nil
+}
+
+// Start starts the HTTP server receiving the payloads.
+func (r *webhookReceiver) Start(_ context.Context, host component.Host) error {
+	listener, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return err
+	}
+	r.server = r.config.HTTPServerSettings.ToServer(r,
+		confighttp.WithMaxRequestBodySize(r.config.MaxRequestBodySizeMiB*1024*1024))
+	go func() {
+		if err := r.server.Serve(listener); err != http.ErrServerClosed {
+			host.ReportFatalError(err)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *webhookReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	return r.server.Close()
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	wh := r.webhooks[req.URL.Path]
+	if wh == nil {
+		http.NotFound(w, req)
+		return
+	}
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	if c, ok := client.FromHTTP(req); ok {
+		ctx = client.NewContext(ctx, c)
+	}
+	ctx = obsreport.ReceiverContext(ctx, r.instanceName, transport)
+	ctx = obsreport.StartLogsReceiveOp(ctx, r.instanceName, transport)
+
+	body, err := ioutil.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		obsreport.EndLogsReceiveOp(ctx, format, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = wh.validateSignature(req.Header, body); err != nil {
+		obsreport.EndLogsReceiveOp(ctx, format, 0, err)
+		r.logger.Debug("Rejected webhook payload", zap.String("path", wh.path), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	logs, err := wh.toLogs(req.Header, body, time.Now())
+	if err != nil {
+		obsreport.EndLogsReceiveOp(ctx, format, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = r.nextConsumer.ConsumeLogs(ctx, logs)
+	obsreport.EndLogsReceiveOp(ctx, format, logs.LogRecordCount(), err)
+	if err != nil {
+		// The senders of the webhooks usually retry the deliveries which failed with a server error.
+		http.Error(w, errNextConsumerError.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
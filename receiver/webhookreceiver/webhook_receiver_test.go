@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookreceiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+const githubPayload = `{"action":"opened","repository":{"full_name":"open-telemetry/opentelemetry-collector"},"labels":[{"name":"bug"}]}`
+
+func startReceiver(t *testing.T, next consumer.LogsConsumer) (*webhookReceiver, string) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.MaxRequestBodySizeMiB = 1
+	cfg.Webhooks = []WebhookConfig{
+		{
+			Path:      "/github",
+			Signature: &SignatureConfig{Header: "X-Hub-Signature-256", Secret: "secret", Prefix: "sha256="},
+			Fields: map[string]string{
+				"github.action":     "action",
+				"github.repository": "repository.full_name",
+				"github.label":      "labels.0.name",
+				"github.missing":    "labels.1.name",
+			},
+			Headers: map[string]string{
+				"github.event":    "X-GitHub-Event",
+				"github.delivery": "X-GitHub-Delivery",
+			},
+		},
+		{Path: "/alertmanager"},
+	}
+	r, err := newReceiver(cfg, zap.NewNop(), next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, r.Shutdown(context.Background())) })
+	return r, "http://" + cfg.Endpoint
+}
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func post(t *testing.T, url string, body []byte, header map[string]string) int {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode
+}
+
+func TestReceiveWebhook(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	_, url := startReceiver(t, sink)
+
+	before := time.Now()
+	status := post(t, url+"/github", []byte(githubPayload), map[string]string{
+		"X-Hub-Signature-256": sign(githubPayload),
+		"X-GitHub-Event":      "issues",
+	})
+	require.Equal(t, http.StatusOK, status)
+
+	require.Len(t, sink.AllLogs(), 1)
+	logs := sink.AllLogs()[0]
+	require.Equal(t, 1, logs.LogRecordCount())
+	lr := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.True(t, lr.Timestamp() >= pdata.TimestampFromTime(before))
+
+	body := lr.Body()
+	require.Equal(t, pdata.AttributeValueMAP, body.Type())
+	action, _ := body.MapVal().Get("action")
+	assert.Equal(t, "opened", action.StringVal())
+	labels, _ := body.MapVal().Get("labels")
+	assert.Equal(t, 1, labels.ArrayVal().Len())
+
+	attributes := map[string]string{}
+	lr.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		attributes[k] = v.StringVal()
+	})
+	assert.Equal(t, map[string]string{
+		"webhook.path":      "/github",
+		"github.action":     "opened",
+		"github.repository": "open-telemetry/opentelemetry-collector",
+		"github.label":      "bug",
+		"github.event":      "issues",
+	}, attributes)
+}
+
+func TestReceiveWebhook_arrayPayload(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	_, url := startReceiver(t, sink)
+
+	require.Equal(t, http.StatusOK, post(t, url+"/alertmanager", []byte(`[1, 2.5]`), nil))
+	require.Len(t, sink.AllLogs(), 1)
+	body := sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body()
+	require.Equal(t, pdata.AttributeValueARRAY, body.Type())
+	assert.Equal(t, int64(1), body.ArrayVal().At(0).IntVal())
+	assert.Equal(t, 2.5, body.ArrayVal().At(1).DoubleVal())
+}
+
+func TestReceiveWebhook_rejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       []byte
+		header     map[string]string
+		wantStatus int
+	}{
+		{
+			name:       "unknown_path",
+			path:       "/gitlab",
+			body:       []byte(`{}`),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "method",
+			method:     http.MethodGet,
+			path:       "/alertmanager",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "missing_signature",
+			path:       "/github",
+			body:       []byte(githubPayload),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid_signature",
+			path:       "/github",
+			body:       []byte(githubPayload),
+			header:     map[string]string{"X-Hub-Signature-256": sign(`{}`)},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "signature_prefix",
+			path:       "/github",
+			body:       []byte(githubPayload),
+			header:     map[string]string{"X-Hub-Signature-256": sign(githubPayload)[len("sha256="):]},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "not_json",
+			path:       "/alertmanager",
+			body:       []byte(`{"status":`),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "trailing_data",
+			path:       "/alertmanager",
+			body:       []byte(`{} {}`),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "too_large",
+			path:       "/alertmanager",
+			body:       append([]byte(`{}`), bytes.Repeat([]byte(" "), 2*1024*1024)...),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			_, url := startReceiver(t, sink)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req, err := http.NewRequest(method, url+tt.path, bytes.NewReader(tt.body))
+			require.NoError(t, err)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, 0, sink.LogRecordsCount())
+		})
+	}
+}
+
+func TestReceiveWebhook_consumerError(t *testing.T) {
+	_, url := startReceiver(t, consumertest.NewLogsErr(errors.New("queue is full")))
+	assert.Equal(t, http.StatusInternalServerError, post(t, url+"/alertmanager", []byte(`{"status":"firing"}`), nil))
+}
+
+func TestStart_error(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:-1"
+	cfg.Webhooks = []WebhookConfig{{Path: "/alertmanager"}}
+	r, err := newReceiver(cfg, zap.NewNop(), consumertest.NewLogsNop())
+	require.NoError(t, err)
+	assert.Error(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
@@ -29,4 +29,26 @@ type Config struct {
 	// If enabled the zipkin receiver will attempt to parse string tags/binary annotations into int/bool/float.
 	// Disabled by default
 	ParseStringTags bool `mapstructure:"parse_string_tags"`
+
+	// MaxRequestBodySizeMiB limits the size (in MiB) of the request bodies, after their decompression.
+	// There is no limit if 0.
+	MaxRequestBodySizeMiB int64 `mapstructure:"max_request_body_size_mib"`
+
+	// MaxConcurrentRequests limits the number of requests processed at once, the
+	// requests exceeding it are refused. There is no limit if 0.
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
+
+	// RateLimit limits the rate of the requests of each client IP address.
+	// There is no limit if not set.
+	RateLimit *RateLimit `mapstructure:"rate_limit"`
+}
+
+// RateLimit is the rate limit of the requests of a client.
+type RateLimit struct {
+	// RequestsPerSecond is the number of requests per second.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+
+	// Burst is the number of requests which can be sent at once after an idle
+	// period. If not set, it is equal to the rate, and at least 1.
+	Burst int `mapstructure:"burst"`
 }
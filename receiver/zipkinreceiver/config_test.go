@@ -38,7 +38,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 3)
+	assert.Equal(t, len(cfg.Receivers), 4)
 
 	r0 := cfg.Receivers["zipkin"]
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
@@ -67,4 +67,22 @@ func TestLoadConfig(t *testing.T) {
 			},
 			ParseStringTags: true,
 		})
+
+	r3 := cfg.Receivers["zipkin/limits"].(*Config)
+	assert.Equal(t, r3,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "zipkin/limits",
+			},
+			HTTPServerSettings: confighttp.HTTPServerSettings{
+				Endpoint: "0.0.0.0:9411",
+			},
+			MaxRequestBodySizeMiB: 5,
+			MaxConcurrentRequests: 100,
+			RateLimit: &RateLimit{
+				RequestsPerSecond: 50,
+				Burst:             100,
+			},
+		})
 }
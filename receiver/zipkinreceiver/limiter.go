@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkinreceiver
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// bucket is the token bucket of a client.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// limiter is a set of token buckets, one per client, refilled at the rate up
+// to the burst. Each request takes a token from the bucket of its client.
+type limiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newLimiter(rateLimit *RateLimit) *limiter {
+	burst := float64(rateLimit.Burst)
+	if burst <= 0 {
+		burst = math.Max(rateLimit.RequestsPerSecond, 1)
+	}
+	return &limiter{
+		rate:    rateLimit.RequestsPerSecond,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// allow takes a token from the bucket of the client if it isn't empty, or
+// returns false and the time until the bucket has a token otherwise.
+func (l *limiter) allow(client string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(client, now)
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// refillDuration is the time an empty bucket takes to be full.
+func (l *limiter) refillDuration() time.Duration {
+	return time.Duration(l.burst / l.rate * float64(time.Second))
+}
+
+// bucket returns the refilled bucket of the client.
+func (l *limiter) bucket(client string, now time.Time) *bucket {
+	l.removeFullBuckets(now)
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+		return b
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.tokens+elapsed.Seconds()*l.rate, l.burst)
+		b.last = now
+	}
+	return b
+}
+
+// removeFullBuckets removes the buckets which were refilled to the burst since
+// they were last used, so that the clients which stopped sending requests
+// don't use memory. The buckets are checked at most once per refill duration.
+func (l *limiter) removeFullBuckets(now time.Time) {
+	refill := l.refillDuration()
+	if now.Sub(l.lastSweep) < refill {
+		return
+	}
+	for client, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, client)
+		}
+	}
+	l.lastSweep = now
+}
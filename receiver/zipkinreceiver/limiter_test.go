@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkinreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(&RateLimit{RequestsPerSecond: 2, Burst: 3})
+	now := time.Unix(1000, 0)
+
+	// The burst is available at once.
+	for i := 0; i < 3; i++ {
+		ok, _ := l.allow("a", now)
+		assert.True(t, ok)
+	}
+	ok, retryAfter := l.allow("a", now)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// The clients have separate buckets.
+	ok, _ = l.allow("b", now)
+	assert.True(t, ok)
+
+	// The bucket is refilled at the rate.
+	now = now.Add(250 * time.Millisecond)
+	ok, retryAfter = l.allow("a", now)
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, retryAfter)
+	now = now.Add(250 * time.Millisecond)
+	ok, _ = l.allow("a", now)
+	assert.True(t, ok)
+
+	// The bucket is refilled up to the burst.
+	now = now.Add(time.Minute)
+	ok, _ = l.allow("a", now)
+	assert.True(t, ok)
+	assert.InDelta(t, 2, l.buckets["a"].tokens, 1e-9)
+}
+
+func TestLimiter_DefaultBurst(t *testing.T) {
+	l := newLimiter(&RateLimit{RequestsPerSecond: 10})
+	assert.Equal(t, 10.0, l.burst)
+	assert.Equal(t, time.Second, l.refillDuration())
+
+	l = newLimiter(&RateLimit{RequestsPerSecond: 0.1})
+	assert.Equal(t, 1.0, l.burst)
+	assert.Equal(t, 10*time.Second, l.refillDuration())
+}
+
+func TestLimiter_RemoveFullBuckets(t *testing.T) {
+	l := newLimiter(&RateLimit{RequestsPerSecond: 10})
+	now := time.Unix(1000, 0)
+	l.allow("a", now)
+	l.allow("b", now)
+
+	now = now.Add(2 * time.Second)
+	l.allow("b", now)
+	assert.Len(t, l.buckets, 1)
+	assert.Contains(t, l.buckets, "b")
+}
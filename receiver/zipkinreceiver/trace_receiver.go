@@ -22,10 +22,13 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	jaegerzipkin "github.com/jaegertracing/jaeger/model/converter/thrift/zipkin"
 	zipkinmodel "github.com/openzipkin/zipkin-go/model"
@@ -34,6 +37,7 @@ import (
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/obsreport"
@@ -49,6 +53,12 @@ const (
 
 var errNextConsumerRespBody = []byte(`"Internal Server Error"`)
 
+var (
+	errNegativeMaxRequestBodySize    = errors.New("max_request_body_size_mib must not be negative")
+	errNegativeMaxConcurrentRequests = errors.New("max_concurrent_requests must not be negative")
+	errNonPositiveRequestsPerSecond  = errors.New("rate_limit requests_per_second must be positive")
+)
+
 // ZipkinReceiver type is used to handle spans received in the Zipkin format.
 type ZipkinReceiver struct {
 	// mu protects the fields of this struct
@@ -63,6 +73,11 @@ type ZipkinReceiver struct {
 	stopOnce  sync.Once
 	server    *http.Server
 	config    *Config
+
+	// inflight holds a token per request being processed, if they are limited.
+	inflight chan struct{}
+	// limiter limits the rate of the requests of each client, if set.
+	limiter *limiter
 }
 
 var _ http.Handler = (*ZipkinReceiver)(nil)
@@ -72,12 +87,27 @@ func New(config *Config, nextConsumer consumer.TracesConsumer) (*ZipkinReceiver,
 	if nextConsumer == nil {
 		return nil, componenterror.ErrNilNextConsumer
 	}
+	if config.MaxRequestBodySizeMiB < 0 {
+		return nil, errNegativeMaxRequestBodySize
+	}
+	if config.MaxConcurrentRequests < 0 {
+		return nil, errNegativeMaxConcurrentRequests
+	}
+	if config.RateLimit != nil && config.RateLimit.RequestsPerSecond <= 0 {
+		return nil, errNonPositiveRequestsPerSecond
+	}
 
 	zr := &ZipkinReceiver{
 		nextConsumer: nextConsumer,
 		instanceName: config.Name(),
 		config:       config,
 	}
+	if config.MaxConcurrentRequests > 0 {
+		zr.inflight = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+	if config.RateLimit != nil {
+		zr.limiter = newLimiter(config.RateLimit)
+	}
 	return zr, nil
 }
 
@@ -95,7 +125,8 @@ func (zr *ZipkinReceiver) Start(ctx context.Context, host component.Host) error
 	zr.startOnce.Do(func() {
 		err = nil
 		zr.host = host
-		zr.server = zr.config.HTTPServerSettings.ToServer(zr)
+		zr.server = zr.config.HTTPServerSettings.ToServer(zr,
+			confighttp.WithMaxRequestBodySize(zr.config.MaxRequestBodySizeMiB*1024*1024))
 		var listener net.Listener
 		listener, err = zr.config.HTTPServerSettings.ToListener()
 		if err != nil {
@@ -173,7 +204,8 @@ func (zr *ZipkinReceiver) Shutdown(context.Context) error {
 // a compression such as "gzip", "deflate", "zlib", is found, the body will
 // be uncompressed accordingly or return the body untouched if otherwise.
 // Clients such as Zipkin-Java do this behavior e.g.
-//    send "Content-Encoding":"gzip" of the JSON content.
+//
+//	send "Content-Encoding":"gzip" of the JSON content.
 func processBodyIfNecessary(req *http.Request) io.Reader {
 	switch req.Header.Get("Content-Encoding") {
 	default:
@@ -213,6 +245,11 @@ const (
 // The ZipkinReceiver receives spans from endpoint /api/v2 as JSON,
 // unmarshals them and sends them along to the nextConsumer.
 func (zr *ZipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !zr.admit(w, r) {
+		return
+	}
+	defer zr.release()
+
 	ctx := r.Context()
 	if c, ok := client.FromHTTP(r); ok {
 		ctx = client.NewContext(ctx, c)
@@ -226,12 +263,21 @@ func (zr *ZipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx = obsreport.StartTraceDataReceiveOp(ctx, zr.instanceName, transportTag)
 
 	pr := processBodyIfNecessary(r)
-	slurp, _ := ioutil.ReadAll(pr)
+	slurp, readErr := ioutil.ReadAll(pr)
 	if c, ok := pr.(io.Closer); ok {
 		_ = c.Close()
 	}
 	_ = r.Body.Close()
 
+	if readErr != nil {
+		status := http.StatusBadRequest
+		if maxSize := zr.config.MaxRequestBodySizeMiB * 1024 * 1024; maxSize > 0 && int64(len(slurp)) >= maxSize {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, readErr.Error(), status)
+		return
+	}
+
 	var td pdata.Traces
 	var err error
 	if asZipkinv1 {
@@ -265,6 +311,44 @@ func (zr *ZipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusAccepted)
 }
 
+// admit refuses the request with 429 Too Many Requests when its client exceeds
+// the rate limit, or with 503 Service Unavailable when too many requests are
+// processed at once. The admitted requests must be released.
+func (zr *ZipkinReceiver) admit(w http.ResponseWriter, r *http.Request) bool {
+	if zr.limiter != nil {
+		if ok, retryAfter := zr.limiter.allow(clientIP(r), time.Now()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return false
+		}
+	}
+	if zr.inflight != nil {
+		select {
+		case zr.inflight <- struct{}{}:
+		default:
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return false
+		}
+	}
+	return true
+}
+
+func (zr *ZipkinReceiver) release() {
+	if zr.inflight != nil {
+		<-zr.inflight
+	}
+}
+
+// clientIP returns the IP address of the client of the request. The
+// X-Forwarded-For header isn't used, since the clients can set it.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func transportType(r *http.Request) string {
 	v1 := r.URL != nil && strings.Contains(r.URL.Path, "api/v1/spans")
 	if v1 {
@@ -521,6 +521,141 @@ func compressZlib(body []byte) (*bytes.Buffer, error) {
 	return &buf, nil
 }
 
+func TestNewInvalidLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr error
+	}{
+		{
+			name:    "negative max request body size",
+			modify:  func(cfg *Config) { cfg.MaxRequestBodySizeMiB = -1 },
+			wantErr: errNegativeMaxRequestBodySize,
+		},
+		{
+			name:    "negative max concurrent requests",
+			modify:  func(cfg *Config) { cfg.MaxConcurrentRequests = -1 },
+			wantErr: errNegativeMaxConcurrentRequests,
+		},
+		{
+			name:    "zero requests per second",
+			modify:  func(cfg *Config) { cfg.RateLimit = &RateLimit{Burst: 10} },
+			wantErr: errNonPositiveRequestsPerSecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			got, err := New(cfg, consumertest.NewTracesNop())
+			require.Equal(t, tt.wantErr, err)
+			require.Nil(t, got)
+		})
+	}
+}
+
+func TestReceiverRateLimit(t *testing.T) {
+	body, err := ioutil.ReadFile("../../translator/trace/zipkin/testdata/zipkin_v2_single.json")
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.RateLimit = &RateLimit{RequestsPerSecond: 1, Burst: 2}
+	zr, err := New(cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+
+	post := func(remoteAddr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/api/v2/spans", bytes.NewBuffer(body))
+		r.Header.Add("content-type", "application/json")
+		r.RemoteAddr = remoteAddr
+		req := httptest.NewRecorder()
+		zr.ServeHTTP(req, r)
+		return req
+	}
+
+	// The burst of the client is admitted, then its requests are refused.
+	assert.Equal(t, http.StatusAccepted, post("192.0.2.1:1234").Code)
+	assert.Equal(t, http.StatusAccepted, post("192.0.2.1:5678").Code)
+	req := post("192.0.2.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, req.Code)
+	assert.Equal(t, "1", req.Header().Get("Retry-After"))
+
+	// The other clients have their own limit.
+	assert.Equal(t, http.StatusAccepted, post("192.0.2.2:1234").Code)
+}
+
+func TestReceiverMaxConcurrentRequests(t *testing.T) {
+	body, err := ioutil.ReadFile("../../translator/trace/zipkin/testdata/zipkin_v2_single.json")
+	require.NoError(t, err)
+
+	next := &zipkinMockTraceConsumer{
+		ch: make(chan pdata.Traces),
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxConcurrentRequests = 1
+	zr, err := New(cfg, next)
+	require.NoError(t, err)
+
+	post := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/api/v2/spans", bytes.NewBuffer(body))
+		r.Header.Add("content-type", "application/json")
+		req := httptest.NewRecorder()
+		zr.ServeHTTP(req, r)
+		return req
+	}
+
+	// The first request is blocked by the consumer.
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- post()
+	}()
+	require.Eventually(t, func() bool {
+		return len(zr.inflight) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, http.StatusServiceUnavailable, post().Code)
+
+	<-next.ch
+	assert.Equal(t, http.StatusAccepted, (<-done).Code)
+	assert.Len(t, zr.inflight, 0)
+}
+
+func TestReceiverMaxRequestBodySize(t *testing.T) {
+	body, err := ioutil.ReadFile("../../translator/trace/zipkin/testdata/zipkin_v2_single.json")
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.MaxRequestBodySizeMiB = 1
+	zr, err := New(cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	require.NoError(t, zr.Start(context.Background(), componenttest.NewNopHost()))
+	defer zr.Shutdown(context.Background())
+
+	post := func(body []byte, encoding string) int {
+		r, err := http.NewRequest("POST", fmt.Sprintf("http://%s/api/v2/spans", cfg.Endpoint), bytes.NewBuffer(body))
+		require.NoError(t, err)
+		r.Header.Add("Content-Type", "application/json")
+		if encoding != "" {
+			r.Header.Add("Content-Encoding", encoding)
+		}
+		resp, err := http.DefaultClient.Do(r)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusAccepted, post(body, ""))
+
+	large := append(bytes.Repeat([]byte(" "), 2*1024*1024), body...)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(large, ""))
+
+	// The size is limited after the decompression.
+	compressed, err := compressGzip(large)
+	require.NoError(t, err)
+	require.Less(t, compressed.Len(), 1024*1024)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(compressed.Bytes(), "gzip"))
+}
+
 type zipkinMockTraceConsumer struct {
 	ch  chan pdata.Traces
 	err error
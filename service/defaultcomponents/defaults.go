@@ -18,7 +18,12 @@ package defaultcomponents
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/carbonexporter"
+	"go.opentelemetry.io/collector/exporter/clickhouseexporter"
+	"go.opentelemetry.io/collector/exporter/elasticsearchexporter"
+	"go.opentelemetry.io/collector/exporter/failoverexporter"
 	"go.opentelemetry.io/collector/exporter/fileexporter"
+	"go.opentelemetry.io/collector/exporter/influxdbexporter"
 	"go.opentelemetry.io/collector/exporter/jaegerexporter"
 	"go.opentelemetry.io/collector/exporter/kafkaexporter"
 	"go.opentelemetry.io/collector/exporter/loggingexporter"
@@ -27,25 +32,61 @@ import (
 	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/exporter/prometheusexporter"
 	"go.opentelemetry.io/collector/exporter/prometheusremotewriteexporter"
+	"go.opentelemetry.io/collector/exporter/redisexporter"
+	"go.opentelemetry.io/collector/exporter/s3exporter"
 	"go.opentelemetry.io/collector/exporter/zipkinexporter"
+	"go.opentelemetry.io/collector/extension/adminextension"
+	"go.opentelemetry.io/collector/extension/ballastextension"
 	"go.opentelemetry.io/collector/extension/fluentbitextension"
 	"go.opentelemetry.io/collector/extension/healthcheckextension"
+	"go.opentelemetry.io/collector/extension/observer/dockerobserver"
+	"go.opentelemetry.io/collector/extension/observer/hostobserver"
+	"go.opentelemetry.io/collector/extension/observer/k8sobserver"
 	"go.opentelemetry.io/collector/extension/pprofextension"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
+	"go.opentelemetry.io/collector/processor/alwayssampleprocessor"
+	"go.opentelemetry.io/collector/processor/anonymizationprocessor"
 	"go.opentelemetry.io/collector/processor/attributesprocessor"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/processor/cardinalitylimitprocessor"
+	"go.opentelemetry.io/collector/processor/cumulativetodeltaprocessor"
+	"go.opentelemetry.io/collector/processor/deltatocumulativeprocessor"
 	"go.opentelemetry.io/collector/processor/filterprocessor"
+	"go.opentelemetry.io/collector/processor/geoipprocessor"
+	"go.opentelemetry.io/collector/processor/logdedupprocessor"
+	"go.opentelemetry.io/collector/processor/logparserprocessor"
 	"go.opentelemetry.io/collector/processor/memorylimiter"
+	"go.opentelemetry.io/collector/processor/metricstarttimeprocessor"
+	"go.opentelemetry.io/collector/processor/metricstransformprocessor"
 	"go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+	"go.opentelemetry.io/collector/processor/ratelimitprocessor"
+	"go.opentelemetry.io/collector/processor/redactionprocessor"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor"
 	"go.opentelemetry.io/collector/processor/resourceprocessor"
+	"go.opentelemetry.io/collector/processor/routingprocessor"
+	"go.opentelemetry.io/collector/processor/schemaprocessor"
+	"go.opentelemetry.io/collector/processor/spandedupprocessor"
+	"go.opentelemetry.io/collector/processor/spanmetricsprocessor"
 	"go.opentelemetry.io/collector/processor/spanprocessor"
+	"go.opentelemetry.io/collector/processor/spanstatusprocessor"
+	"go.opentelemetry.io/collector/processor/transformprocessor"
+	"go.opentelemetry.io/collector/receiver/amqpreceiver"
+	"go.opentelemetry.io/collector/receiver/filelogreceiver"
 	"go.opentelemetry.io/collector/receiver/fluentforwardreceiver"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
 	"go.opentelemetry.io/collector/receiver/jaegerreceiver"
+	"go.opentelemetry.io/collector/receiver/k8sclusterreceiver"
 	"go.opentelemetry.io/collector/receiver/kafkareceiver"
+	"go.opentelemetry.io/collector/receiver/mysqlreceiver"
+	"go.opentelemetry.io/collector/receiver/nginxreceiver"
 	"go.opentelemetry.io/collector/receiver/opencensusreceiver"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver"
 	"go.opentelemetry.io/collector/receiver/prometheusreceiver"
+	"go.opentelemetry.io/collector/receiver/pulsarreceiver"
+	"go.opentelemetry.io/collector/receiver/receivercreator"
+	"go.opentelemetry.io/collector/receiver/redisreceiver"
+	"go.opentelemetry.io/collector/receiver/syslogreceiver"
+	"go.opentelemetry.io/collector/receiver/webhookreceiver"
 	"go.opentelemetry.io/collector/receiver/zipkinreceiver"
 )
 
@@ -62,6 +103,11 @@ func Components() (
 		pprofextension.NewFactory(),
 		zpagesextension.NewFactory(),
 		fluentbitextension.NewFactory(),
+		ballastextension.NewFactory(),
+		adminextension.NewFactory(),
+		hostobserver.NewFactory(),
+		k8sobserver.NewFactory(),
+		dockerobserver.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -76,6 +122,16 @@ func Components() (
 		otlpreceiver.NewFactory(),
 		hostmetricsreceiver.NewFactory(),
 		kafkareceiver.NewFactory(),
+		syslogreceiver.NewFactory(),
+		filelogreceiver.NewFactory(),
+		k8sclusterreceiver.NewFactory(),
+		nginxreceiver.NewFactory(),
+		mysqlreceiver.NewFactory(),
+		redisreceiver.NewFactory(),
+		pulsarreceiver.NewFactory(),
+		amqpreceiver.NewFactory(),
+		webhookreceiver.NewFactory(),
+		receivercreator.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -92,6 +148,13 @@ func Components() (
 		otlpexporter.NewFactory(),
 		otlphttpexporter.NewFactory(),
 		kafkaexporter.NewFactory(),
+		elasticsearchexporter.NewFactory(),
+		clickhouseexporter.NewFactory(),
+		failoverexporter.NewFactory(),
+		s3exporter.NewFactory(),
+		influxdbexporter.NewFactory(),
+		carbonexporter.NewFactory(),
+		redisexporter.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -105,6 +168,25 @@ func Components() (
 		probabilisticsamplerprocessor.NewFactory(),
 		spanprocessor.NewFactory(),
 		filterprocessor.NewFactory(),
+		spanmetricsprocessor.NewFactory(),
+		resourcedetectionprocessor.NewFactory(),
+		transformprocessor.NewFactory(),
+		redactionprocessor.NewFactory(),
+		routingprocessor.NewFactory(),
+		metricstransformprocessor.NewFactory(),
+		cumulativetodeltaprocessor.NewFactory(),
+		deltatocumulativeprocessor.NewFactory(),
+		logdedupprocessor.NewFactory(),
+		ratelimitprocessor.NewFactory(),
+		spanstatusprocessor.NewFactory(),
+		anonymizationprocessor.NewFactory(),
+		geoipprocessor.NewFactory(),
+		schemaprocessor.NewFactory(),
+		cardinalitylimitprocessor.NewFactory(),
+		logparserprocessor.NewFactory(),
+		spandedupprocessor.NewFactory(),
+		metricstarttimeprocessor.NewFactory(),
+		alwayssampleprocessor.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -31,6 +31,11 @@ func TestDefaultComponents(t *testing.T) {
 		"pprof",
 		"zpages",
 		"fluentbit",
+		"memory_ballast",
+		"admin",
+		"host_observer",
+		"k8s_observer",
+		"docker_observer",
 	}
 	expectedReceivers := []configmodels.Type{
 		"jaeger",
@@ -41,6 +46,16 @@ func TestDefaultComponents(t *testing.T) {
 		"hostmetrics",
 		"fluentforward",
 		"kafka",
+		"syslog",
+		"filelog",
+		"k8s_cluster",
+		"nginx",
+		"mysql",
+		"redis",
+		"pulsar",
+		"amqp",
+		"webhook",
+		"receiver_creator",
 	}
 	expectedProcessors := []configmodels.Type{
 		"attributes",
@@ -50,6 +65,25 @@ func TestDefaultComponents(t *testing.T) {
 		"probabilistic_sampler",
 		"span",
 		"filter",
+		"spanmetrics",
+		"resourcedetection",
+		"transform",
+		"redaction",
+		"routing",
+		"metricstransform",
+		"cumulativetodelta",
+		"deltatocumulative",
+		"logdedup",
+		"ratelimit",
+		"spanstatus",
+		"anonymization",
+		"geoip",
+		"schema",
+		"cardinalitylimit",
+		"logparser",
+		"spandedup",
+		"metricstarttime",
+		"alwayssample",
 	}
 	expectedExporters := []configmodels.Type{
 		"opencensus",
@@ -62,6 +96,13 @@ func TestDefaultComponents(t *testing.T) {
 		"otlp",
 		"otlphttp",
 		"kafka",
+		"elasticsearch",
+		"clickhouse",
+		"failover",
+		"s3",
+		"influxdb",
+		"carbon",
+		"redis",
 	}
 
 	factories, err := Components()
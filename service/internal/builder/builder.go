@@ -17,12 +17,17 @@ package builder
 import (
 	"flag"
 	"fmt"
+	"time"
 )
 
 const (
 	// flags
-	configCfg      = "config"
-	memBallastFlag = "mem-ballast-size-mib"
+	configCfg = "config"
+
+	// MemBallastFlag is the deprecated flag to set the size of the memory ballast.
+	MemBallastFlag = "mem-ballast-size-mib"
+
+	componentStartTimeoutCfg = "component-start-timeout"
 
 	kindLogKey        = "component_kind"
 	kindLogsReceiver  = "receiver"
@@ -34,16 +39,20 @@ const (
 )
 
 var (
-	configFile     *string
-	memBallastSize *uint
+	configFile            *string
+	memBallastSize        *uint
+	componentStartTimeout *time.Duration
 )
 
 // Flags adds flags related to basic building of the collector application to the given flagset.
 func Flags(flags *flag.FlagSet) {
 	configFile = flags.String(configCfg, "", "Path to the config file")
-	memBallastSize = flags.Uint(memBallastFlag, 0,
-		fmt.Sprintf("Flag to specify size of memory (MiB) ballast to set. Ballast is not used when this is not specified. "+
-			"default settings: 0"))
+	memBallastSize = flags.Uint(MemBallastFlag, 0,
+		fmt.Sprintf("Deprecated, use the memory_ballast extension instead. Flag to specify size of memory (MiB) ballast to set. "+
+			"Ballast is not used when this is not specified. default settings: 0"))
+	componentStartTimeout = flags.Duration(componentStartTimeoutCfg, 0,
+		"Maximum time each receiver, processor, exporter and extension can take to start, "+
+			"the collector fails to start if it is exceeded. Disabled by default.")
 }
 
 // GetConfigFile gets the config file from the config file flag.
@@ -55,3 +64,12 @@ func GetConfigFile() string {
 func MemBallastSize() int {
 	return int(*memBallastSize)
 }
+
+// ComponentStartTimeout returns the maximum time a component can take to start,
+// zero if the timeout is disabled.
+func ComponentStartTimeout() time.Duration {
+	if componentStartTimeout == nil {
+		return 0
+	}
+	return *componentStartTimeout
+}
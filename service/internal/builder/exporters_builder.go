@@ -46,6 +46,17 @@ func (bexp *builtExporter) Start(ctx context.Context, host component.Host) error
 	return consumererror.CombineErrors(errors)
 }
 
+func (bexp *builtExporter) startTask(ctx context.Context, host component.Host, name string, deps []*startTask) *startTask {
+	return newStartTask(deps, func() error {
+		bexp.logger.Info("Exporter is starting...")
+		if err := startComponent(ctx, host, bexp, kindLogsExporter, name); err != nil {
+			return err
+		}
+		bexp.logger.Info("Exporter started.")
+		return nil
+	})
+}
+
 // Shutdown the trace component and the metrics component of an exporter.
 func (bexp *builtExporter) Shutdown(ctx context.Context) error {
 	var errors []error
@@ -86,17 +97,13 @@ func (bexp *builtExporter) getLogExporter() component.LogsExporter {
 // Exporters is a map of exporters created from exporter configs.
 type Exporters map[configmodels.Exporter]*builtExporter
 
-// StartAll starts all exporters.
+// StartAll starts all exporters concurrently.
 func (exps Exporters) StartAll(ctx context.Context, host component.Host) error {
-	for _, exp := range exps {
-		exp.logger.Info("Exporter is starting...")
-
-		if err := exp.Start(ctx, host); err != nil {
-			return err
-		}
-		exp.logger.Info("Exporter started.")
+	tasks := make([]*startTask, 0, len(exps))
+	for cfg, exp := range exps {
+		tasks = append(tasks, exp.startTask(ctx, host, cfg.Name(), nil))
 	}
-	return nil
+	return waitAll(tasks)
 }
 
 // ShutdownAll stops all exporters.
@@ -164,7 +171,7 @@ func BuildExporters(
 	exporters := make(Exporters)
 	// BuildExporters exporters based on configuration and required input data types.
 	for _, cfg := range eb.config.Exporters {
-		componentLogger := eb.logger.With(zap.String(typeLogKey, string(cfg.Type())), zap.String(nameLogKey, cfg.Name()))
+		componentLogger := withComponentLevel(eb.logger, eb.config, kindLogsExporter, cfg.Name()).With(zap.String(typeLogKey, string(cfg.Type())), zap.String(nameLogKey, cfg.Name()))
 		exp, err := eb.buildExporter(context.Background(), componentLogger, eb.appInfo, cfg, exporterInputDataTypes)
 		if err != nil {
 			return nil, err
@@ -47,18 +47,21 @@ var _ component.Extension = (*builtExtension)(nil)
 // Exporters is a map of exporters created from exporter configs.
 type Extensions map[configmodels.Extension]*builtExtension
 
-// StartAll starts all exporters.
+// StartAll starts all extensions concurrently.
 func (exts Extensions) StartAll(ctx context.Context, host component.Host) error {
-	for _, ext := range exts {
-		ext.logger.Info("Extension is starting...")
-
-		if err := ext.Start(ctx, host); err != nil {
-			return err
-		}
-
-		ext.logger.Info("Extension started.")
+	tasks := make([]*startTask, 0, len(exts))
+	for cfg, ext := range exts {
+		ext, name := ext, cfg.Name()
+		tasks = append(tasks, newStartTask(nil, func() error {
+			ext.logger.Info("Extension is starting...")
+			if err := startComponent(ctx, host, ext, kindLogExtension, name); err != nil {
+				return err
+			}
+			ext.logger.Info("Extension started.")
+			return nil
+		}))
 	}
-	return nil
+	return waitAll(tasks)
 }
 
 // ShutdownAll stops all exporters.
@@ -134,7 +137,7 @@ func BuildExtensions(
 			return nil, fmt.Errorf("extension %q is not configured", extName)
 		}
 
-		componentLogger := eb.logger.With(zap.String(typeLogKey, string(extCfg.Type())), zap.String(nameLogKey, extCfg.Name()))
+		componentLogger := withComponentLevel(eb.logger, eb.config, kindLogExtension, extCfg.Name()).With(zap.String(typeLogKey, string(extCfg.Type())), zap.String(nameLogKey, extCfg.Name()))
 		ext, err := eb.buildExtension(componentLogger, eb.appInfo, extCfg)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// levelCore is a zapcore.Core that enables entries according to its own level,
+// regardless of the level of the wrapped core. This allows components to log at
+// a lower level than the rest of the application as long as the wrapped core
+// is enabled for that level.
+type levelCore struct {
+	zapcore.Core
+	level zapcore.LevelEnabler
+}
+
+// WrapCoreWithLevel returns a zap.Option that filters the entries of the logger
+// according to the given level. If the logger was already wrapped the previous
+// level is replaced.
+func WrapCoreWithLevel(level zapcore.LevelEnabler) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if lc, ok := core.(*levelCore); ok {
+			core = lc.Core
+		}
+		return &levelCore{Core: core, level: level}
+	})
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// withComponentLevel returns the logger for the component of the given kind and
+// name, honoring the logging level configured for the component, if any.
+func withComponentLevel(logger *zap.Logger, config *configmodels.Config, kind string, fullName string) *zap.Logger {
+	levelStr, ok := config.Service.Telemetry.Logs.ComponentLevels[kind+"/"+fullName]
+	if !ok {
+		return logger
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(levelStr)); err != nil {
+		// The level is validated when the configuration is loaded.
+		return logger
+	}
+	return logger.WithOptions(WrapCoreWithLevel(level))
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+func TestWithComponentLevel(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core).WithOptions(WrapCoreWithLevel(zapcore.InfoLevel))
+
+	config := &configmodels.Config{
+		Service: configmodels.Service{
+			Telemetry: configmodels.ServiceTelemetry{
+				Logs: configmodels.ServiceTelemetryLogs{
+					ComponentLevels: map[string]string{
+						"exporter/myexporter": "debug",
+						"receiver/myreceiver": "error",
+					},
+				},
+			},
+		},
+	}
+
+	logger.Debug("application debug")
+	logger.Info("application info")
+
+	exporterLogger := withComponentLevel(logger, config, kindLogsExporter, "myexporter").With(zap.String(nameLogKey, "myexporter"))
+	exporterLogger.Debug("exporter debug")
+
+	receiverLogger := withComponentLevel(logger, config, kindLogsReceiver, "myreceiver")
+	receiverLogger.Warn("receiver warn")
+	receiverLogger.Error("receiver error")
+
+	processorLogger := withComponentLevel(logger, config, kindLogsProcessor, "myprocessor")
+	processorLogger.Debug("processor debug")
+	processorLogger.Info("processor info")
+
+	var messages []string
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"application info", "exporter debug", "receiver error", "processor info"}, messages)
+}
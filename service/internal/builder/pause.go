@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// pauseSwitch pauses the data flowing through a pipeline or out of a receiver.
+// The data is dropped while paused, instead of refused, so the other pipelines
+// attached to the same receivers are not affected by the pause.
+type pauseSwitch struct {
+	paused int32
+}
+
+func (ps *pauseSwitch) setPaused(paused bool) {
+	if paused {
+		atomic.StoreInt32(&ps.paused, 1)
+	} else {
+		atomic.StoreInt32(&ps.paused, 0)
+	}
+}
+
+func (ps *pauseSwitch) isPaused() bool {
+	return atomic.LoadInt32(&ps.paused) == 1
+}
+
+type pausableTracesConsumer struct {
+	*pauseSwitch
+	nextConsumer consumer.TracesConsumer
+}
+
+func (pc pausableTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if pc.isPaused() {
+		return nil
+	}
+	return pc.nextConsumer.ConsumeTraces(ctx, td)
+}
+
+type pausableMetricsConsumer struct {
+	*pauseSwitch
+	nextConsumer consumer.MetricsConsumer
+}
+
+func (pc pausableMetricsConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if pc.isPaused() {
+		return nil
+	}
+	return pc.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+type pausableLogsConsumer struct {
+	*pauseSwitch
+	nextConsumer consumer.LogsConsumer
+}
+
+func (pc pausableLogsConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if pc.isPaused() {
+		return nil
+	}
+	return pc.nextConsumer.ConsumeLogs(ctx, ld)
+}
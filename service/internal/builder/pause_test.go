@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestPause(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+
+	allExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	pipelines, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors)
+	require.NoError(t, err)
+	receivers, err := BuildReceivers(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, pipelines, factories.Receivers)
+	require.NoError(t, err)
+
+	// The "examplereceiver/multi" sends to the "traces" pipeline, exporting to "exampleexporter",
+	// and to the "traces/2" pipeline, exporting to "exampleexporter" and "exampleexporter/2".
+	producer := receivers[cfg.Receivers["examplereceiver/multi"]].receiver.(*componenttest.ExampleReceiverProducer)
+	exporter := allExporters[cfg.Exporters["exampleexporter"]].getTraceExporter().(*componenttest.ExampleExporterConsumer)
+	exporter2 := allExporters[cfg.Exporters["exampleexporter/2"]].getTraceExporter().(*componenttest.ExampleExporterConsumer)
+
+	assert.False(t, pipelines.SetPaused("nosuchpipeline", true))
+	assert.False(t, receivers.SetPaused("nosuchreceiver", true))
+
+	// The paused pipeline drops the data, the other pipeline still gets it.
+	assert.True(t, pipelines.SetPaused("traces/2", true))
+	assert.True(t, pipelines.PausedStates()["traces/2"])
+	assert.False(t, pipelines.PausedStates()["traces"])
+	assert.NoError(t, producer.TraceConsumer.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Len(t, exporter.Traces, 1)
+	assert.Len(t, exporter2.Traces, 0)
+
+	assert.True(t, pipelines.SetPaused("traces/2", false))
+	assert.NoError(t, producer.TraceConsumer.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Len(t, exporter.Traces, 3)
+	assert.Len(t, exporter2.Traces, 1)
+
+	// The paused receiver drops all the data it receives.
+	assert.True(t, receivers.SetPaused("examplereceiver/multi", true))
+	assert.True(t, receivers.PausedStates()["examplereceiver/multi"])
+	assert.NoError(t, producer.TraceConsumer.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Len(t, exporter.Traces, 3)
+	assert.Len(t, exporter2.Traces, 1)
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// errPipelineReloading is returned while the pipeline is rebuilt by a configuration
+// reload, the senders are expected to retry.
+var errPipelineReloading = errors.New("pipeline is reloading")
+
+// pipelineEntry is the first consumer of a pipeline, the one receivers send data to.
+// The entry is kept when the pipeline is rebuilt by a configuration reload, only the
+// processors and exporters behind it are replaced, so the receivers sending data to
+// the pipeline keep running.
+type pipelineEntry struct {
+	pauseSwitch
+
+	// mu is read locked while the data is consumed, so reloading can wait for
+	// the data in flight to leave the pipeline.
+	mu        sync.RWMutex
+	reloading bool
+	tc        consumer.TracesConsumer
+	mc        consumer.MetricsConsumer
+	lc        consumer.LogsConsumer
+}
+
+func newPipelineEntry(tc consumer.TracesConsumer, mc consumer.MetricsConsumer, lc consumer.LogsConsumer) *pipelineEntry {
+	return &pipelineEntry{tc: tc, mc: mc, lc: lc}
+}
+
+// startReload refuses the new data and waits for the data in flight to be consumed.
+func (pe *pipelineEntry) startReload() {
+	pe.mu.Lock()
+	pe.reloading = true
+	pe.mu.Unlock()
+}
+
+// finishReload sends the data to the consumers of the rebuilt pipeline.
+func (pe *pipelineEntry) finishReload(tc consumer.TracesConsumer, mc consumer.MetricsConsumer, lc consumer.LogsConsumer) {
+	pe.mu.Lock()
+	pe.tc, pe.mc, pe.lc = tc, mc, lc
+	pe.reloading = false
+	pe.mu.Unlock()
+}
+
+func (pe *pipelineEntry) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if pe.isPaused() {
+		return nil
+	}
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+	if pe.reloading {
+		return errPipelineReloading
+	}
+	return pe.tc.ConsumeTraces(ctx, td)
+}
+
+func (pe *pipelineEntry) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if pe.isPaused() {
+		return nil
+	}
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+	if pe.reloading {
+		return errPipelineReloading
+	}
+	return pe.mc.ConsumeMetrics(ctx, md)
+}
+
+func (pe *pipelineEntry) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if pe.isPaused() {
+		return nil
+	}
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+	if pe.reloading {
+		return errPipelineReloading
+	}
+	return pe.lc.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestPipelineReload(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+
+	oldExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	oldPipelines, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, oldExporters, factories.Processors)
+	require.NoError(t, err)
+	receivers, err := BuildReceivers(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, oldPipelines, factories.Receivers)
+	require.NoError(t, err)
+
+	producer := receivers[cfg.Receivers["examplereceiver/2"]].receiver.(*componenttest.ExampleReceiverProducer)
+	oldExporter := oldExporters[cfg.Exporters["exampleexporter/2"]].getTraceExporter().(*componenttest.ExampleExporterConsumer)
+	assert.True(t, oldPipelines.SetPaused("metrics/3", true))
+
+	// The data is refused while the pipelines are reloading.
+	oldPipelines.StartReload()
+	assert.Equal(t, errPipelineReloading, producer.TraceConsumer.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+
+	newExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	newPipelines, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, newExporters, factories.Processors)
+	require.NoError(t, err)
+	newPipelines.FinishReload(oldPipelines)
+
+	// The receivers built for the previous pipelines send the data to the rebuilt ones,
+	// which keep the paused state of the previous pipelines.
+	newExporter := newExporters[cfg.Exporters["exampleexporter/2"]].getTraceExporter().(*componenttest.ExampleExporterConsumer)
+	assert.NoError(t, producer.TraceConsumer.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Len(t, oldExporter.Traces, 0)
+	assert.Len(t, newExporter.Traces, 1)
+	assert.Same(t, oldPipelines[cfg.Service.Pipelines["traces/2"]].firstTC, newPipelines[cfg.Service.Pipelines["traces/2"]].firstTC)
+	assert.True(t, newPipelines.PausedStates()["metrics/3"])
+}
@@ -40,29 +40,109 @@ type builtPipeline struct {
 	// can mutate the TraceData or MetricsData input argument.
 	MutatesConsumedData bool
 
-	processors []component.Processor
-}
+	processors     []component.Processor
+	processorNames []string
 
-// BuiltPipelines is a map of build pipelines created from pipeline configs.
-type BuiltPipelines map[*configmodels.Pipeline]*builtPipeline
+	entry *pipelineEntry
+}
 
-func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Host) error {
-	for _, bp := range bps {
+func (bp *builtPipeline) startTask(ctx context.Context, host component.Host, deps []*startTask) *startTask {
+	return newStartTask(deps, func() error {
 		bp.logger.Info("Pipeline is starting...")
 		// Start in reverse order, starting from the back of processors pipeline.
 		// This is important so that processors that are earlier in the pipeline and
 		// reference processors that are later in the pipeline do not start sending
 		// data to later pipelines which are not yet started.
 		for i := len(bp.processors) - 1; i >= 0; i-- {
-			if err := bp.processors[i].Start(ctx, host); err != nil {
+			if err := startComponent(ctx, host, bp.processors[i], kindLogsProcessor, bp.processorNames[i]); err != nil {
 				return err
 			}
 		}
 		bp.logger.Info("Pipeline is started.")
+		return nil
+	})
+}
+
+// BuiltPipelines is a map of build pipelines created from pipeline configs.
+type BuiltPipelines map[*configmodels.Pipeline]*builtPipeline
+
+// TracesConsumer returns the first consumer of the traces pipeline with the given
+// name, or nil if there is no such pipeline.
+func (bps BuiltPipelines) TracesConsumer(name string) consumer.TracesConsumer {
+	for cfg, bp := range bps {
+		if cfg.Name == name && cfg.InputType == configmodels.TracesDataType {
+			return bp.firstTC
+		}
+	}
+	return nil
+}
+
+// MetricsConsumer returns the first consumer of the metrics pipeline with the given
+// name, or nil if there is no such pipeline.
+func (bps BuiltPipelines) MetricsConsumer(name string) consumer.MetricsConsumer {
+	for cfg, bp := range bps {
+		if cfg.Name == name && cfg.InputType == configmodels.MetricsDataType {
+			return bp.firstMC
+		}
 	}
 	return nil
 }
 
+// SetPaused pauses or resumes the pipeline with the given name, the data sent to
+// a paused pipeline is dropped. Returns false if there is no such pipeline.
+func (bps BuiltPipelines) SetPaused(name string, paused bool) bool {
+	for cfg, bp := range bps {
+		if cfg.Name == name {
+			bp.entry.setPaused(paused)
+			bp.logger.Info("Pipeline paused state changed.", zap.Bool("paused", paused))
+			return true
+		}
+	}
+	return false
+}
+
+// PausedStates returns whether each pipeline is paused, by pipeline name.
+func (bps BuiltPipelines) PausedStates() map[string]bool {
+	states := make(map[string]bool, len(bps))
+	for cfg, bp := range bps {
+		states[cfg.Name] = bp.entry.isPaused()
+	}
+	return states
+}
+
+// StartReload prepares the pipelines to be rebuilt by a configuration reload. The
+// pipelines refuse the new data, and the data in flight is consumed before returning.
+func (bps BuiltPipelines) StartReload() {
+	for _, bp := range bps {
+		bp.entry.startReload()
+	}
+}
+
+// FinishReload makes the rebuilt pipelines take over the entries of the previous
+// pipelines with the same name, so the receivers sending data to the previous
+// pipelines send it to the rebuilt pipelines from now on.
+func (bps BuiltPipelines) FinishReload(previous BuiltPipelines) {
+	for cfg, bp := range bps {
+		for prevCfg, prevBp := range previous {
+			if prevCfg.Name != cfg.Name || prevBp == bp {
+				continue
+			}
+			prevBp.entry.finishReload(bp.entry.tc, bp.entry.mc, bp.entry.lc)
+			bp.entry = prevBp.entry
+			bp.firstTC, bp.firstMC, bp.firstLC = prevBp.firstTC, prevBp.firstMC, prevBp.firstLC
+		}
+	}
+}
+
+// StartProcessors starts the processors of all pipelines, the pipelines are started concurrently.
+func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Host) error {
+	tasks := make([]*startTask, 0, len(bps))
+	for _, bp := range bps {
+		tasks = append(tasks, bp.startTask(ctx, host, nil))
+	}
+	return waitAll(tasks)
+}
+
 func (bps BuiltPipelines) ShutdownProcessors(ctx context.Context) error {
 	var errs []error
 	for _, bp := range bps {
@@ -149,10 +229,11 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 		// it becomes the next for the previous one (previous in the pipeline,
 		// which we will build in the next loop iteration).
 		var err error
-		componentLogger := pb.logger.With(zap.String(kindLogKey, kindLogsProcessor), zap.String(typeLogKey, string(procCfg.Type())), zap.String(nameLogKey, procCfg.Name()))
+		componentLogger := withComponentLevel(pb.logger, pb.config, kindLogsProcessor, procCfg.Name()).With(zap.String(kindLogKey, kindLogsProcessor), zap.String(typeLogKey, string(procCfg.Type())), zap.String(nameLogKey, procCfg.Name()))
 		creationParams := component.ProcessorCreateParams{
 			Logger:               componentLogger,
 			ApplicationStartInfo: pb.appInfo,
+			PipelineName:         pipelineCfg.Name,
 		}
 
 		switch pipelineCfg.InputType {
@@ -202,6 +283,19 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 		zap.String("pipeline_datatype", string(pipelineCfg.InputType)))
 	pipelineLogger.Info("Pipeline is enabled.")
 
+	// The receivers send the data to the entry of the pipeline, which can pause
+	// the pipeline and is kept when the pipeline is rebuilt.
+	entry := newPipelineEntry(tc, mc, lc)
+	if tc != nil {
+		tc = entry
+	}
+	if mc != nil {
+		mc = entry
+	}
+	if lc != nil {
+		lc = entry
+	}
+
 	bp := &builtPipeline{
 		pipelineLogger,
 		tc,
@@ -209,6 +303,8 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 		lc,
 		mutatesConsumedData,
 		processors,
+		pipelineCfg.Processors,
+		entry,
 	}
 
 	return bp, nil
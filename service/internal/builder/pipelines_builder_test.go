@@ -256,6 +256,44 @@ func testPipeline(t *testing.T, pipelineName string, exporterNames []string) {
 	assert.NoError(t, err)
 }
 
+func TestBuiltPipelines_TracesConsumer(t *testing.T) {
+	factories := createExampleFactories()
+
+	for _, dataType := range []string{"traces", "logs"} {
+		cfg := createExampleConfig(dataType)
+		allExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
+		require.NoError(t, err)
+		pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors)
+		require.NoError(t, err)
+
+		if dataType == "traces" {
+			assert.Equal(t, pipelineProcessors[cfg.Service.Pipelines["traces"]].firstTC, pipelineProcessors.TracesConsumer("traces"))
+		} else {
+			assert.Nil(t, pipelineProcessors.TracesConsumer(dataType))
+		}
+		assert.Nil(t, pipelineProcessors.TracesConsumer("nosuchpipeline"))
+	}
+}
+
+func TestBuiltPipelines_MetricsConsumer(t *testing.T) {
+	factories := createExampleFactories()
+
+	for _, dataType := range []string{"metrics", "traces"} {
+		cfg := createExampleConfig(dataType)
+		allExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
+		require.NoError(t, err)
+		pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors)
+		require.NoError(t, err)
+
+		if dataType == "metrics" {
+			assert.Equal(t, pipelineProcessors[cfg.Service.Pipelines["metrics"]].firstMC, pipelineProcessors.MetricsConsumer("metrics"))
+		} else {
+			assert.Nil(t, pipelineProcessors.MetricsConsumer(dataType))
+		}
+		assert.Nil(t, pipelineProcessors.MetricsConsumer("nosuchpipeline"))
+	}
+}
+
 func TestProcessorsBuilder_ErrorOnUnsupportedProcessor(t *testing.T) {
 	factories, err := componenttest.ExampleComponents()
 	assert.NoError(t, err)
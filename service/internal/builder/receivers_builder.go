@@ -36,6 +36,7 @@ var errUnusedReceiver = errors.New("receiver defined but not used by any pipelin
 type builtReceiver struct {
 	logger   *zap.Logger
 	receiver component.Receiver
+	pause    *pauseSwitch
 }
 
 // Start the receiver.
@@ -43,6 +44,17 @@ func (rcv *builtReceiver) Start(ctx context.Context, host component.Host) error
 	return rcv.receiver.Start(ctx, host)
 }
 
+func (rcv *builtReceiver) startTask(ctx context.Context, host component.Host, name string, deps []*startTask) *startTask {
+	return newStartTask(deps, func() error {
+		rcv.logger.Info("Receiver is starting...")
+		if err := startComponent(ctx, host, rcv, kindLogsReceiver, name); err != nil {
+			return err
+		}
+		rcv.logger.Info("Receiver started.")
+		return nil
+	})
+}
+
 // Stop the receiver.
 func (rcv *builtReceiver) Shutdown(ctx context.Context) error {
 	return rcv.receiver.Shutdown(ctx)
@@ -64,17 +76,35 @@ func (rcvs Receivers) ShutdownAll(ctx context.Context) error {
 	return consumererror.CombineErrors(errs)
 }
 
-// StartAll starts all receivers.
-func (rcvs Receivers) StartAll(ctx context.Context, host component.Host) error {
-	for _, rcv := range rcvs {
-		rcv.logger.Info("Receiver is starting...")
-
-		if err := rcv.Start(ctx, host); err != nil {
-			return err
+// SetPaused pauses or resumes the receiver with the given name, the data received
+// by a paused receiver is dropped. Returns false if there is no such receiver.
+func (rcvs Receivers) SetPaused(name string, paused bool) bool {
+	for cfg, rcv := range rcvs {
+		if cfg.Name() == name {
+			rcv.pause.setPaused(paused)
+			rcv.logger.Info("Receiver paused state changed.", zap.Bool("paused", paused))
+			return true
 		}
-		rcv.logger.Info("Receiver started.")
 	}
-	return nil
+	return false
+}
+
+// PausedStates returns whether each receiver is paused, by receiver name.
+func (rcvs Receivers) PausedStates() map[string]bool {
+	states := make(map[string]bool, len(rcvs))
+	for cfg, rcv := range rcvs {
+		states[cfg.Name()] = rcv.pause.isPaused()
+	}
+	return states
+}
+
+// StartAll starts all receivers concurrently.
+func (rcvs Receivers) StartAll(ctx context.Context, host component.Host) error {
+	tasks := make([]*startTask, 0, len(rcvs))
+	for cfg, rcv := range rcvs {
+		tasks = append(tasks, rcv.startTask(ctx, host, cfg.Name(), nil))
+	}
+	return waitAll(tasks)
 }
 
 // receiversBuilder builds receivers from config.
@@ -98,7 +128,7 @@ func BuildReceivers(
 
 	receivers := make(Receivers)
 	for _, cfg := range rb.config.Receivers {
-		logger := rb.logger.With(zap.String(typeLogKey, string(cfg.Type())), zap.String(nameLogKey, cfg.Name()))
+		logger := withComponentLevel(rb.logger, rb.config, kindLogsReceiver, cfg.Name()).With(zap.String(typeLogKey, string(cfg.Type())), zap.String(nameLogKey, cfg.Name()))
 		rcv, err := rb.buildReceiver(context.Background(), logger, rb.appInfo, cfg)
 		if err != nil {
 			if err == errUnusedReceiver {
@@ -180,15 +210,15 @@ func (rb *receiversBuilder) attachReceiverToPipelines(
 
 	switch dataType {
 	case configmodels.TracesDataType:
-		junction := buildFanoutTraceConsumer(builtPipelines)
+		junction := pausableTracesConsumer{rcv.pause, buildFanoutTraceConsumer(builtPipelines)}
 		createdReceiver, err = factory.CreateTracesReceiver(ctx, creationParams, config, junction)
 
 	case configmodels.MetricsDataType:
-		junction := buildFanoutMetricConsumer(builtPipelines)
+		junction := pausableMetricsConsumer{rcv.pause, buildFanoutMetricConsumer(builtPipelines)}
 		createdReceiver, err = factory.CreateMetricsReceiver(ctx, creationParams, config, junction)
 
 	case configmodels.LogsDataType:
-		junction := buildFanoutLogConsumer(builtPipelines)
+		junction := pausableLogsConsumer{rcv.pause, buildFanoutLogConsumer(builtPipelines)}
 		createdReceiver, err = factory.CreateLogsReceiver(ctx, creationParams, config, junction)
 
 	default:
@@ -247,6 +277,7 @@ func (rb *receiversBuilder) buildReceiver(ctx context.Context, logger *zap.Logge
 	}
 	rcv := &builtReceiver{
 		logger: logger,
+		pause:  &pauseSwitch{},
 	}
 
 	// Now we have list of pipelines broken down by data type. Iterate for each data type.
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// errDependencyNotStarted is the result of the components that are not started
+// because a component they send data to failed to start. It is not reported,
+// the error of the component that failed is.
+var errDependencyNotStarted = errors.New("not started because a downstream component failed to start")
+
+// startTask is the asynchronous start of a component, which begins once the
+// components it depends on are started.
+type startTask struct {
+	done chan struct{}
+	err  error
+}
+
+// newStartTask runs start once all the deps are successfully done.
+func newStartTask(deps []*startTask, start func() error) *startTask {
+	st := &startTask{done: make(chan struct{})}
+	go func() {
+		defer close(st.done)
+		for _, dep := range deps {
+			if dep.wait() != nil {
+				st.err = errDependencyNotStarted
+				return
+			}
+		}
+		st.err = start()
+	}()
+	return st
+}
+
+func (st *startTask) wait() error {
+	<-st.done
+	return st.err
+}
+
+// waitAll waits for all the tasks and returns their combined errors.
+func waitAll(tasks []*startTask) error {
+	var errs []error
+	for _, st := range tasks {
+		if err := st.wait(); err != nil && err != errDependencyNotStarted {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.CombineErrors(errs)
+}
+
+// startComponent starts the component, failing if it takes longer than the
+// ComponentStartTimeout. The component keeps starting in the background after
+// the timeout, but the collector doesn't wait for it.
+func startComponent(ctx context.Context, host component.Host, comp component.Component, kind string, name string) error {
+	timeout := ComponentStartTimeout()
+	if timeout <= 0 {
+		return comp.Start(ctx, host)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- comp.Start(ctx, host)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-errCh:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("%s %q did not start within %v", kind, name, timeout)
+	}
+}
+
+// StartPipelines starts the exporters, processors and receivers of the pipelines
+// concurrently. A component starts as soon as all the components it sends data to
+// are started, so a slow component only delays the components that depend on it.
+// If a component fails to start, the components that depend on it are not started.
+func StartPipelines(
+	ctx context.Context,
+	host component.Host,
+	config *configmodels.Config,
+	exporters Exporters,
+	pipelines BuiltPipelines,
+	receivers Receivers,
+) error {
+	var tasks []*startTask
+
+	exporterTasks := make(map[string]*startTask, len(exporters))
+	for cfg, exp := range exporters {
+		st := exp.startTask(ctx, host, cfg.Name(), nil)
+		exporterTasks[cfg.Name()] = st
+		tasks = append(tasks, st)
+	}
+
+	pipelineTasks := make(map[string]*startTask, len(pipelines))
+	for cfg, bp := range pipelines {
+		deps := make([]*startTask, 0, len(cfg.Exporters))
+		for _, name := range cfg.Exporters {
+			deps = append(deps, exporterTasks[name])
+		}
+		st := bp.startTask(ctx, host, deps)
+		pipelineTasks[cfg.Name] = st
+		tasks = append(tasks, st)
+	}
+
+	for cfg, rcv := range receivers {
+		var deps []*startTask
+		for _, pipeline := range config.Service.Pipelines {
+			for _, name := range pipeline.Receivers {
+				if name == cfg.Name() {
+					deps = append(deps, pipelineTasks[pipeline.Name])
+				}
+			}
+		}
+		tasks = append(tasks, rcv.startTask(ctx, host, cfg.Name(), deps))
+	}
+
+	return waitAll(tasks)
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// startTestComponent blocks in Start until unblock is closed, if set.
+type startTestComponent struct {
+	unblock  chan struct{}
+	startErr error
+	started  int32
+}
+
+func (c *startTestComponent) Start(context.Context, component.Host) error {
+	if c.unblock != nil {
+		<-c.unblock
+	}
+	if c.startErr != nil {
+		return c.startErr
+	}
+	atomic.StoreInt32(&c.started, 1)
+	return nil
+}
+
+func (c *startTestComponent) Shutdown(context.Context) error {
+	return nil
+}
+
+func (c *startTestComponent) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{}
+}
+
+func (c *startTestComponent) isStarted() bool {
+	return atomic.LoadInt32(&c.started) == 1
+}
+
+// startTestGraph has two independent pipelines, "a" and "b", each with its own
+// receiver, processor and exporter.
+type startTestGraph struct {
+	config     *configmodels.Config
+	exporters  Exporters
+	pipelines  BuiltPipelines
+	receivers  Receivers
+	components map[string]*startTestComponent
+}
+
+func newStartTestGraph() *startTestGraph {
+	g := &startTestGraph{
+		config:     &configmodels.Config{Service: configmodels.Service{Pipelines: configmodels.Pipelines{}}},
+		exporters:  Exporters{},
+		pipelines:  BuiltPipelines{},
+		receivers:  Receivers{},
+		components: map[string]*startTestComponent{},
+	}
+	for _, name := range []string{"a", "b"} {
+		exp, proc, rcv := &startTestComponent{}, &startTestComponent{}, &startTestComponent{}
+		g.components["exporter/"+name] = exp
+		g.components["processor/"+name] = proc
+		g.components["receiver/"+name] = rcv
+
+		pipeline := &configmodels.Pipeline{
+			Name:       name,
+			InputType:  configmodels.TracesDataType,
+			Receivers:  []string{name},
+			Processors: []string{name},
+			Exporters:  []string{name},
+		}
+		g.config.Service.Pipelines[name] = pipeline
+		g.exporters[&configmodels.ExporterSettings{NameVal: name}] = &builtExporter{
+			logger:        zap.NewNop(),
+			expByDataType: map[configmodels.DataType]component.Exporter{configmodels.TracesDataType: exp},
+		}
+		g.pipelines[pipeline] = &builtPipeline{
+			logger:         zap.NewNop(),
+			processors:     []component.Processor{proc},
+			processorNames: []string{name},
+		}
+		g.receivers[&configmodels.ReceiverSettings{NameVal: name}] = &builtReceiver{
+			logger:   zap.NewNop(),
+			receiver: rcv,
+		}
+	}
+	return g
+}
+
+func (g *startTestGraph) start() error {
+	return StartPipelines(context.Background(), componenttest.NewNopHost(), g.config, g.exporters, g.pipelines, g.receivers)
+}
+
+func TestStartPipelines_Independent(t *testing.T) {
+	g := newStartTestGraph()
+	slowExporter := g.components["exporter/a"]
+	slowExporter.unblock = make(chan struct{})
+
+	done := make(chan error)
+	go func() {
+		done <- g.start()
+	}()
+
+	// The pipeline "b" starts while the exporter of the pipeline "a" is starting.
+	assert.Eventually(t, func() bool {
+		return g.components["receiver/b"].isStarted()
+	}, time.Second, time.Millisecond)
+	assert.False(t, g.components["processor/a"].isStarted())
+	assert.False(t, g.components["receiver/a"].isStarted())
+
+	close(slowExporter.unblock)
+	require.NoError(t, <-done)
+	for name, comp := range g.components {
+		assert.True(t, comp.isStarted(), name)
+	}
+}
+
+func TestStartPipelines_Error(t *testing.T) {
+	g := newStartTestGraph()
+	startErr := errors.New("my_error")
+	g.components["exporter/a"].startErr = startErr
+
+	assert.Equal(t, startErr, g.start())
+	// The components that depend on the failed exporter are not started.
+	assert.False(t, g.components["processor/a"].isStarted())
+	assert.False(t, g.components["receiver/a"].isStarted())
+	assert.True(t, g.components["receiver/b"].isStarted())
+}
+
+func TestStartPipelines_Timeout(t *testing.T) {
+	timeout := 10 * time.Millisecond
+	componentStartTimeout = &timeout
+	defer func() { componentStartTimeout = nil }()
+
+	g := newStartTestGraph()
+	g.components["processor/b"].unblock = make(chan struct{})
+	defer close(g.components["processor/b"].unblock)
+
+	err := g.start()
+	require.Error(t, err)
+	assert.Equal(t, `processor "b" did not start within 10ms`, err.Error())
+	assert.True(t, g.components["receiver/a"].isStarted())
+	assert.False(t, g.components["receiver/b"].isStarted())
+}
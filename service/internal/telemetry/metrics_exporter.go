@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// MetricsExporter is an OpenTelemetry SDK metrics exporter that sends the
+// collector's own metrics to an internal metrics pipeline, as cumulative metrics
+// of a resource describing the collector.
+type MetricsExporter struct {
+	export.ExportKindSelector
+
+	appInfo      component.ApplicationStartInfo
+	nextConsumer consumer.MetricsConsumer
+}
+
+// NewMetricsExporter creates a MetricsExporter that sends the metrics to nextConsumer.
+func NewMetricsExporter(appInfo component.ApplicationStartInfo, nextConsumer consumer.MetricsConsumer) *MetricsExporter {
+	return &MetricsExporter{
+		ExportKindSelector: export.CumulativeExportKindSelector(),
+		appInfo:            appInfo,
+		nextConsumer:       nextConsumer,
+	}
+}
+
+// Export converts the records of the checkpoint set to metrics and sends them
+// to the pipeline. The records with the same name are points of the same metric,
+// the attributes of the resources of the records are added to the resource.
+func (me *MetricsExporter) Export(ctx context.Context, checkpointSet export.CheckpointSet) error {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	resAttrs := rm.Resource().Attributes()
+	resAttrs.InsertString(conventions.AttributeServiceName, me.appInfo.ExeName)
+	resAttrs.InsertString(conventions.AttributeServiceVersion, me.appInfo.Version)
+
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ilm := rm.InstrumentationLibraryMetrics().At(0)
+	ilm.InstrumentationLibrary().SetName(instrumentationLibraryName)
+	ilm.InstrumentationLibrary().SetVersion(me.appInfo.Version)
+	metrics := ilm.Metrics()
+
+	metricsByName := make(map[string]pdata.Metric)
+	err := checkpointSet.ForEach(me, func(record export.Record) error {
+		for iter := record.Resource().Iter(); iter.Next(); {
+			kv := iter.Label()
+			resAttrs.UpsertString(string(kv.Key), kv.Value.Emit())
+		}
+
+		desc := record.Descriptor()
+		metric, ok := metricsByName[desc.Name()]
+		if !ok {
+			metric = pdata.NewMetric()
+			metric.SetName(desc.Name())
+			metric.SetDescription(desc.Description())
+			metric.SetUnit(string(desc.Unit()))
+		}
+		appended, err := appendPoint(record, metric)
+		if err != nil || !appended || ok {
+			return err
+		}
+		metricsByName[desc.Name()] = metric
+		metrics.Append(metric)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if metrics.Len() == 0 {
+		return nil
+	}
+	return me.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+// appendPoint appends the point of the record to the metric, setting the data
+// type of the metric if it has none. It returns false if the aggregation of
+// the record is not supported or doesn't match the data type of the metric.
+func appendPoint(record export.Record, metric pdata.Metric) (bool, error) {
+	desc := record.Descriptor()
+	isInt := desc.NumberKind() == number.Int64Kind
+	start := pdata.TimestampFromTime(record.StartTime())
+	end := pdata.TimestampFromTime(record.EndTime())
+	labels := make(map[string]string, record.Labels().Len())
+	for iter := record.Labels().Iter(); iter.Next(); {
+		kv := iter.Label()
+		labels[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	// Histograms are also sums, they are checked first.
+	switch agg := record.Aggregation().(type) {
+	case aggregation.Histogram:
+		count, err := agg.Count()
+		if err != nil {
+			return false, err
+		}
+		sum, err := agg.Sum()
+		if err != nil {
+			return false, err
+		}
+		buckets, err := agg.Histogram()
+		if err != nil {
+			return false, err
+		}
+		if isInt {
+			if !setDataType(metric, pdata.MetricDataTypeIntHistogram) {
+				return false, nil
+			}
+			metric.IntHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+			points := metric.IntHistogram().DataPoints()
+			points.Resize(points.Len() + 1)
+			point := points.At(points.Len() - 1)
+			point.LabelsMap().InitFromMap(labels)
+			point.SetStartTime(start)
+			point.SetTimestamp(end)
+			point.SetCount(count)
+			point.SetSum(sum.AsInt64())
+			point.SetBucketCounts(buckets.Counts)
+			point.SetExplicitBounds(buckets.Boundaries)
+			return true, nil
+		}
+		if !setDataType(metric, pdata.MetricDataTypeDoubleHistogram) {
+			return false, nil
+		}
+		metric.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		points := metric.DoubleHistogram().DataPoints()
+		points.Resize(points.Len() + 1)
+		point := points.At(points.Len() - 1)
+		point.LabelsMap().InitFromMap(labels)
+		point.SetStartTime(start)
+		point.SetTimestamp(end)
+		point.SetCount(count)
+		point.SetSum(sum.AsFloat64())
+		point.SetBucketCounts(buckets.Counts)
+		point.SetExplicitBounds(buckets.Boundaries)
+		return true, nil
+
+	case aggregation.Sum:
+		sum, err := agg.Sum()
+		if err != nil {
+			return false, err
+		}
+		monotonic := desc.InstrumentKind().Monotonic()
+		if isInt {
+			if !setDataType(metric, pdata.MetricDataTypeIntSum) {
+				return false, nil
+			}
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+			metric.IntSum().SetIsMonotonic(monotonic)
+			points := metric.IntSum().DataPoints()
+			points.Resize(points.Len() + 1)
+			point := points.At(points.Len() - 1)
+			point.LabelsMap().InitFromMap(labels)
+			point.SetStartTime(start)
+			point.SetTimestamp(end)
+			point.SetValue(sum.AsInt64())
+			return true, nil
+		}
+		if !setDataType(metric, pdata.MetricDataTypeDoubleSum) {
+			return false, nil
+		}
+		metric.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		metric.DoubleSum().SetIsMonotonic(monotonic)
+		points := metric.DoubleSum().DataPoints()
+		points.Resize(points.Len() + 1)
+		point := points.At(points.Len() - 1)
+		point.LabelsMap().InitFromMap(labels)
+		point.SetStartTime(start)
+		point.SetTimestamp(end)
+		point.SetValue(sum.AsFloat64())
+		return true, nil
+
+	case aggregation.LastValue:
+		value, timestamp, err := agg.LastValue()
+		if err != nil {
+			return false, err
+		}
+		if isInt {
+			if !setDataType(metric, pdata.MetricDataTypeIntGauge) {
+				return false, nil
+			}
+			points := metric.IntGauge().DataPoints()
+			points.Resize(points.Len() + 1)
+			point := points.At(points.Len() - 1)
+			point.LabelsMap().InitFromMap(labels)
+			point.SetTimestamp(pdata.TimestampFromTime(timestamp))
+			point.SetValue(value.AsInt64())
+			return true, nil
+		}
+		if !setDataType(metric, pdata.MetricDataTypeDoubleGauge) {
+			return false, nil
+		}
+		points := metric.DoubleGauge().DataPoints()
+		points.Resize(points.Len() + 1)
+		point := points.At(points.Len() - 1)
+		point.LabelsMap().InitFromMap(labels)
+		point.SetTimestamp(pdata.TimestampFromTime(timestamp))
+		point.SetValue(value.AsFloat64())
+		return true, nil
+	}
+	return false, nil
+}
+
+// setDataType sets the data type of a metric without one, it returns false if
+// the metric already has a different data type.
+func setDataType(metric pdata.Metric, dataType pdata.MetricDataType) bool {
+	if metric.DataType() == pdata.MetricDataTypeNone {
+		metric.SetDataType(dataType)
+	}
+	return metric.DataType() == dataType
+}
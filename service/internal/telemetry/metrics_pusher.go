@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	"go.uber.org/zap"
+)
+
+// MetricsPusher periodically collects the metrics of an OpenTelemetry SDK
+// controller and exports its checkpoint set. Unlike a controller started with an
+// exporter, the controller can still be collected on demand by pull exporters,
+// like the Prometheus one, which get an error from a started controller.
+type MetricsPusher struct {
+	logger       *zap.Logger
+	controller   *controller.Controller
+	checkpointer export.Checkpointer
+	exporter     export.Exporter
+	interval     time.Duration
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewMetricsPusher creates a MetricsPusher exporting the metrics of the controller,
+// created with the given checkpointer, with the exporter every interval.
+func NewMetricsPusher(
+	logger *zap.Logger,
+	ctrl *controller.Controller,
+	checkpointer export.Checkpointer,
+	exporter export.Exporter,
+	interval time.Duration,
+) *MetricsPusher {
+	return &MetricsPusher{
+		logger:       logger,
+		controller:   ctrl,
+		checkpointer: checkpointer,
+		exporter:     exporter,
+		interval:     interval,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// Start starts pushing the metrics every interval.
+func (p *MetricsPusher) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.push()
+			case <-p.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops pushing the metrics, after pushing them one last time.
+func (p *MetricsPusher) Stop() {
+	close(p.stopCh)
+	p.wg.Wait()
+	p.push()
+}
+
+func (p *MetricsPusher) push() {
+	ctx, cancel := context.WithTimeout(context.Background(), p.interval)
+	defer cancel()
+
+	if err := p.controller.Collect(ctx); err != nil {
+		p.logger.Warn("Failed to collect the collector's own metrics", zap.Error(err))
+		return
+	}
+
+	checkpointSet := p.checkpointer.CheckpointSet()
+	checkpointSet.RLock()
+	defer checkpointSet.RUnlock()
+	if err := p.exporter.Export(ctx, checkpointSet); err != nil {
+		p.logger.Warn("Failed to push the collector's own metrics", zap.Error(err))
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.uber.org/zap"
+)
+
+func TestMetricsPusher(t *testing.T) {
+	ctrl, checkpointer := newTestController()
+	counter := metric.Must(ctrl.MeterProvider().Meter("test")).NewInt64Counter("test/pushed_count")
+	counter.Add(context.Background(), 1)
+
+	exporter := newRecordingExporter()
+	pusher := NewMetricsPusher(zap.NewNop(), ctrl, checkpointer, exporter, 10*time.Millisecond)
+	pusher.Start()
+
+	require.Eventually(t, func() bool {
+		_, ok := exporter.record("test/pushed_count")
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	// The controller can still be collected on demand while pushing.
+	require.NoError(t, ctrl.Collect(context.Background()))
+
+	counter.Add(context.Background(), 2)
+	pusher.Stop()
+
+	// The values recorded before stopping are pushed.
+	record, ok := exporter.record("test/pushed_count")
+	require.True(t, ok)
+	sum, err := record.Aggregation().(aggregation.Sum).Sum()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, sum.AsInt64())
+}
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/unit"
+)
+
+// openCensusBridge is an OpenTelemetry SDK metrics exporter that exports, along
+// with the records of the SDK, the metrics of the OpenCensus views still
+// registered by the components, read from the OpenCensus metric producers.
+type openCensusBridge struct {
+	export.Exporter
+	resource *resource.Resource
+}
+
+// WithOpenCensusMetrics wraps the exporter so it also exports the metrics of the
+// registered OpenCensus views, as cumulative records with the given resource.
+// The sums are exported as monotonic sums, the last values as gauges and the
+// distributions as histograms, the other OpenCensus metrics are skipped.
+func WithOpenCensusMetrics(exporter export.Exporter, res *resource.Resource) export.Exporter {
+	return &openCensusBridge{Exporter: exporter, resource: res}
+}
+
+// Export exports the records of the checkpoint set followed by the records of the
+// OpenCensus metrics.
+func (b *openCensusBridge) Export(ctx context.Context, checkpointSet export.CheckpointSet) error {
+	return b.Exporter.Export(ctx, &bridgedCheckpointSet{
+		CheckpointSet: checkpointSet,
+		records:       b.readOpenCensusRecords(),
+	})
+}
+
+func (b *openCensusBridge) readOpenCensusRecords() []export.Record {
+	var records []export.Record
+	for _, producer := range metricproducer.GlobalManager().GetAll() {
+		for _, m := range producer.Read() {
+			records = append(records, b.toRecords(m)...)
+		}
+	}
+	return records
+}
+
+// toRecords converts the last point of each time series of the OpenCensus
+// metric to a record.
+func (b *openCensusBridge) toRecords(m *metricdata.Metric) []export.Record {
+	var instrumentKind metric.InstrumentKind
+	var numberKind number.Kind
+	switch m.Descriptor.Type {
+	case metricdata.TypeCumulativeInt64:
+		instrumentKind, numberKind = metric.SumObserverInstrumentKind, number.Int64Kind
+	case metricdata.TypeCumulativeFloat64:
+		instrumentKind, numberKind = metric.SumObserverInstrumentKind, number.Float64Kind
+	case metricdata.TypeGaugeInt64:
+		instrumentKind, numberKind = metric.ValueObserverInstrumentKind, number.Int64Kind
+	case metricdata.TypeGaugeFloat64:
+		instrumentKind, numberKind = metric.ValueObserverInstrumentKind, number.Float64Kind
+	case metricdata.TypeCumulativeDistribution:
+		instrumentKind, numberKind = metric.ValueRecorderInstrumentKind, number.Float64Kind
+	default:
+		return nil
+	}
+	desc := metric.NewDescriptor(
+		m.Descriptor.Name,
+		instrumentKind,
+		numberKind,
+		metric.WithDescription(m.Descriptor.Description),
+		metric.WithUnit(unit.Unit(m.Descriptor.Unit)))
+
+	records := make([]export.Record, 0, len(m.TimeSeries))
+	for _, ts := range m.TimeSeries {
+		if len(ts.Points) == 0 {
+			continue
+		}
+		point := ts.Points[len(ts.Points)-1]
+		agg := toAggregation(point, instrumentKind == metric.ValueObserverInstrumentKind)
+		if agg == nil {
+			continue
+		}
+
+		labels := make([]attribute.KeyValue, 0, len(ts.LabelValues))
+		for i, value := range ts.LabelValues {
+			if value.Present && i < len(m.Descriptor.LabelKeys) {
+				labels = append(labels, attribute.String(m.Descriptor.LabelKeys[i].Key, value.Value))
+			}
+		}
+		labelSet := attribute.NewSet(labels...)
+
+		records = append(records, export.NewRecord(&desc, &labelSet, b.resource, agg, ts.StartTime, point.Time))
+	}
+	return records
+}
+
+// toAggregation returns the aggregation of the point, the sums of a gauge are
+// aggregated as last values.
+func toAggregation(point metricdata.Point, gauge bool) aggregation.Aggregation {
+	var value number.Number
+	switch v := point.Value.(type) {
+	case int64:
+		value = number.NewInt64Number(v)
+	case float64:
+		value = number.NewFloat64Number(v)
+	case *metricdata.Distribution:
+		if v.BucketOptions == nil {
+			return nil
+		}
+		buckets := aggregation.Buckets{
+			Boundaries: v.BucketOptions.Bounds,
+			Counts:     make([]uint64, len(v.Buckets)),
+		}
+		for i, bucket := range v.Buckets {
+			buckets.Counts[i] = uint64(bucket.Count)
+		}
+		return &ocHistogram{
+			count:   uint64(v.Count),
+			sum:     number.NewFloat64Number(v.Sum),
+			buckets: buckets,
+		}
+	default:
+		return nil
+	}
+	if gauge {
+		return &ocLastValue{value: value, time: point.Time}
+	}
+	return ocSum(value)
+}
+
+var (
+	_ aggregation.Sum       = ocSum(0)
+	_ aggregation.LastValue = (*ocLastValue)(nil)
+	_ aggregation.Histogram = (*ocHistogram)(nil)
+)
+
+// ocSum is the aggregation of an OpenCensus sum.
+type ocSum number.Number
+
+func (s ocSum) Kind() aggregation.Kind {
+	return aggregation.SumKind
+}
+
+func (s ocSum) Sum() (number.Number, error) {
+	return number.Number(s), nil
+}
+
+// ocLastValue is the aggregation of an OpenCensus last value.
+type ocLastValue struct {
+	value number.Number
+	time  time.Time
+}
+
+func (lv *ocLastValue) Kind() aggregation.Kind {
+	return aggregation.LastValueKind
+}
+
+func (lv *ocLastValue) LastValue() (number.Number, time.Time, error) {
+	return lv.value, lv.time, nil
+}
+
+// ocHistogram is the aggregation of an OpenCensus distribution.
+type ocHistogram struct {
+	count   uint64
+	sum     number.Number
+	buckets aggregation.Buckets
+}
+
+func (h *ocHistogram) Kind() aggregation.Kind {
+	return aggregation.HistogramKind
+}
+
+func (h *ocHistogram) Count() (uint64, error) {
+	return h.count, nil
+}
+
+func (h *ocHistogram) Sum() (number.Number, error) {
+	return h.sum, nil
+}
+
+func (h *ocHistogram) Histogram() (aggregation.Buckets, error) {
+	return h.buckets, nil
+}
+
+// bridgedCheckpointSet is a checkpoint set that iterates over the records of the
+// checkpoint set of the SDK followed by the records of the OpenCensus metrics.
+type bridgedCheckpointSet struct {
+	export.CheckpointSet
+	records []export.Record
+}
+
+func (cs *bridgedCheckpointSet) ForEach(selector export.ExportKindSelector, f func(export.Record) error) error {
+	if err := cs.CheckpointSet.ForEach(selector, f); err != nil {
+		return err
+	}
+	for _, record := range cs.records {
+		if err := f(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
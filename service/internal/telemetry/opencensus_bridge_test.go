@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.uber.org/zap"
+)
+
+// recordingExporter keeps the last record exported for each metric name.
+type recordingExporter struct {
+	export.ExportKindSelector
+
+	mu      sync.Mutex
+	exports int
+	records map[string]export.Record
+}
+
+func newRecordingExporter() *recordingExporter {
+	return &recordingExporter{
+		ExportKindSelector: export.CumulativeExportKindSelector(),
+		records:            make(map[string]export.Record),
+	}
+}
+
+func (e *recordingExporter) Export(_ context.Context, checkpointSet export.CheckpointSet) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.exports++
+	return checkpointSet.ForEach(e, func(record export.Record) error {
+		e.records[record.Descriptor().Name()] = record
+		return nil
+	})
+}
+
+func (e *recordingExporter) record(name string) (export.Record, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	record, ok := e.records[name]
+	return record, ok
+}
+
+func newTestController() (*controller.Controller, export.Checkpointer) {
+	checkpointer := processor.New(simple.NewWithHistogramDistribution(), export.CumulativeExportKindSelector(), processor.WithMemory(true))
+	return controller.New(checkpointer, controller.WithResource(resource.Empty()), controller.WithCollectPeriod(0)), checkpointer
+}
+
+func TestOpenCensusBridge(t *testing.T) {
+	keyComponent, err := tag.NewKey("component")
+	require.NoError(t, err)
+	mCount := stats.Int64("test/bridge_count", "Test count", stats.UnitDimensionless)
+	mSize := stats.Float64("test/bridge_size", "Test size", stats.UnitBytes)
+	mLatency := stats.Float64("test/bridge_latency", "Test latency", stats.UnitMilliseconds)
+	views := []*view.View{
+		{Measure: mCount, Aggregation: view.Sum(), TagKeys: []tag.Key{keyComponent}},
+		{Measure: mSize, Aggregation: view.LastValue()},
+		{Measure: mLatency, Aggregation: view.Distribution(10, 100)},
+	}
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	ctx, err := tag.New(context.Background(), tag.Upsert(keyComponent, "test"))
+	require.NoError(t, err)
+	stats.Record(ctx, mCount.M(3), mSize.M(1024), mLatency.M(5))
+	stats.Record(ctx, mCount.M(4), mSize.M(2048), mLatency.M(50))
+	// Wait for the recordings to be processed, the metric producer of the
+	// views reads them without synchronizing with the recordings.
+	_, err = view.RetrieveData(mLatency.Name())
+	require.NoError(t, err)
+
+	ctrl, checkpointer := newTestController()
+	metric.Must(ctrl.MeterProvider().Meter("test")).NewInt64Counter("test/sdk_count").Add(ctx, 7)
+
+	res := resource.NewWithAttributes(attribute.String("service.instance.id", "instance"))
+	exporter := newRecordingExporter()
+	pusher := NewMetricsPusher(zap.NewNop(), ctrl, checkpointer, WithOpenCensusMetrics(exporter, res), time.Hour)
+	pusher.Start()
+	pusher.Stop()
+
+	record, ok := exporter.record("test/sdk_count")
+	require.True(t, ok)
+	sdkSum, err := record.Aggregation().(aggregation.Sum).Sum()
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, sdkSum.AsInt64())
+
+	record, ok = exporter.record("test/bridge_count")
+	require.True(t, ok)
+	assert.Equal(t, metric.SumObserverInstrumentKind, record.Descriptor().InstrumentKind())
+	assert.Equal(t, "Test count", record.Descriptor().Description())
+	assert.Equal(t, res, record.Resource())
+	component, ok := record.Labels().Value("component")
+	require.True(t, ok)
+	assert.Equal(t, "test", component.AsString())
+	sum, err := record.Aggregation().(aggregation.Sum).Sum()
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, sum.AsInt64())
+
+	record, ok = exporter.record("test/bridge_size")
+	require.True(t, ok)
+	assert.Equal(t, metric.ValueObserverInstrumentKind, record.Descriptor().InstrumentKind())
+	assert.Equal(t, aggregation.LastValueKind, record.Aggregation().Kind())
+	lastValue, _, err := record.Aggregation().(aggregation.LastValue).LastValue()
+	require.NoError(t, err)
+	assert.Equal(t, 2048.0, lastValue.AsFloat64())
+
+	record, ok = exporter.record("test/bridge_latency")
+	require.True(t, ok)
+	assert.Equal(t, metric.ValueRecorderInstrumentKind, record.Descriptor().InstrumentKind())
+	histogram := record.Aggregation().(aggregation.Histogram)
+	count, err := histogram.Count()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	buckets, err := histogram.Histogram()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{10, 100}, buckets.Boundaries)
+	assert.Equal(t, []uint64{1, 1, 0}, buckets.Counts)
+}
@@ -28,7 +28,7 @@ import (
 // ProcessMetricsViews is a struct that contains views related to process metrics (cpu, mem, etc)
 type ProcessMetricsViews struct {
 	prevTimeUnixNano int64
-	ballastSizeBytes uint64
+	getBallastSize   func() uint64
 	views            []*view.View
 	done             chan struct{}
 	proc             *process.Process
@@ -107,11 +107,12 @@ var viewRSSMemory = &view.View{
 }
 
 // NewProcessMetricsViews creates a new set of ProcessMetrics (mem, cpu) that can be used to measure
-// basic information about this process.
-func NewProcessMetricsViews(ballastSizeBytes uint64) (*ProcessMetricsViews, error) {
+// basic information about this process. The size of the memory ballast returned by getBallastSize
+// is excluded from the memory measurements.
+func NewProcessMetricsViews(getBallastSize func() uint64) (*ProcessMetricsViews, error) {
 	pmv := &ProcessMetricsViews{
 		prevTimeUnixNano: time.Now().UnixNano(),
-		ballastSizeBytes: ballastSizeBytes,
+		getBallastSize:   getBallastSize,
 		views:            []*view.View{viewProcessUptime, viewAllocMem, viewTotalAllocMem, viewSysMem, viewCPUSeconds, viewRSSMemory},
 		done:             make(chan struct{}),
 	}
@@ -176,8 +177,9 @@ func (pmv *ProcessMetricsViews) updateViews() {
 
 func (pmv *ProcessMetricsViews) readMemStats(ms *runtime.MemStats) {
 	runtime.ReadMemStats(ms)
-	ms.Alloc -= pmv.ballastSizeBytes
-	ms.HeapAlloc -= pmv.ballastSizeBytes
-	ms.HeapSys -= pmv.ballastSizeBytes
-	ms.HeapInuse -= pmv.ballastSizeBytes
+	ballastSizeBytes := pmv.getBallastSize()
+	ms.Alloc -= ballastSizeBytes
+	ms.HeapAlloc -= ballastSizeBytes
+	ms.HeapSys -= ballastSizeBytes
+	ms.HeapInuse -= ballastSizeBytes
 }
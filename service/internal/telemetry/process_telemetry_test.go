@@ -26,7 +26,7 @@ import (
 func TestProcessTelemetry(t *testing.T) {
 	const ballastSizeBytes uint64 = 0
 
-	pmv, err := NewProcessMetricsViews(ballastSizeBytes)
+	pmv, err := NewProcessMetricsViews(func() uint64 { return ballastSizeBytes })
 	require.NoError(t, err)
 	assert.NotNil(t, pmv)
 
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"runtime"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// ServiceMetricsViews is a struct that contains views related to the service (build). The uptime
+// of the service is the uptime of the process, see ProcessMetricsViews.
+type ServiceMetricsViews struct {
+	info  component.ApplicationStartInfo
+	views []*view.View
+}
+
+var (
+	tagKeyVersion   = tag.MustNewKey("version")
+	tagKeyGitSha    = tag.MustNewKey("git_sha")
+	tagKeyGoVersion = tag.MustNewKey("go_version")
+)
+
+var mBuildInfo = stats.Int64(
+	"build_info",
+	"Build information of the collector, the value is always 1",
+	stats.UnitDimensionless)
+var viewBuildInfo = &view.View{
+	Name:        mBuildInfo.Name(),
+	Description: mBuildInfo.Description(),
+	Measure:     mBuildInfo,
+	Aggregation: view.LastValue(),
+	TagKeys:     []tag.Key{tagKeyVersion, tagKeyGitSha, tagKeyGoVersion},
+}
+
+// NewServiceMetricsViews creates a new set of ServiceMetrics (build info) that can be used to track
+// the deployed versions of the collector.
+func NewServiceMetricsViews(info component.ApplicationStartInfo) *ServiceMetricsViews {
+	return &ServiceMetricsViews{
+		info:  info,
+		views: []*view.View{viewBuildInfo},
+	}
+}
+
+// StartCollection records the build information.
+func (smv *ServiceMetricsViews) StartCollection() error {
+	ctx, err := tag.New(context.Background(),
+		tag.Upsert(tagKeyVersion, smv.info.Version),
+		tag.Upsert(tagKeyGitSha, smv.info.GitHash),
+		tag.Upsert(tagKeyGoVersion, runtime.Version()))
+	if err != nil {
+		return err
+	}
+	stats.Record(ctx, mBuildInfo.M(1))
+	return nil
+}
+
+// Views returns the views internal to the SMV.
+func (smv *ServiceMetricsViews) Views() []*view.View {
+	return smv.views
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestServiceTelemetry(t *testing.T) {
+	info := component.ApplicationStartInfo{Version: "1.2.3", GitHash: "abcdef"}
+	smv := NewServiceMetricsViews(info)
+
+	expectedViews := []string{
+		// Changing a metric name is a breaking change.
+		"build_info",
+	}
+	serviceViews := smv.Views()
+	require.Len(t, serviceViews, len(expectedViews))
+	for i, v := range serviceViews {
+		assert.Equal(t, expectedViews[i], v.Name)
+	}
+
+	require.NoError(t, view.Register(serviceViews...))
+	defer view.Unregister(serviceViews...)
+
+	require.NoError(t, smv.StartCollection())
+
+	rows, err := view.RetrieveData("build_info")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.ElementsMatch(t, []tag.Tag{
+		{Key: tagKeyVersion, Value: "1.2.3"},
+		{Key: tagKeyGitSha, Value: "abcdef"},
+		{Key: tagKeyGoVersion, Value: runtime.Version()},
+	}, rows[0].Tags)
+	assert.Equal(t, float64(1), rows[0].Data.(*view.LastValueData).Value)
+
+}
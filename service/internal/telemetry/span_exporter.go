@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	// instrumentationLibraryName is the name of the instrumentation library of the collector's own spans.
+	instrumentationLibraryName = "go.opentelemetry.io/collector"
+
+	spanExporterQueueSize     = 2048
+	spanExporterBatchSize     = 512
+	spanExporterFlushInterval = time.Second
+)
+
+// SpanExporter is an OpenCensus trace.Exporter that sends the collector's own spans
+// to an internal traces pipeline.
+//
+// The spans are sent with a context that carries a span which is never sampled, so
+// the operations of the internal pipeline are not traced, otherwise every export
+// would produce new spans to export. As a safeguard the spans of the exporters of the
+// internal pipeline are dropped too.
+type SpanExporter struct {
+	logger          *zap.Logger
+	appInfo         component.ApplicationStartInfo
+	nextConsumer    consumer.TracesConsumer
+	ignoredPrefixes []string
+
+	queue  chan *trace.SpanData
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSpanExporter creates a SpanExporter that sends the spans to nextConsumer. The spans
+// of the exporters listed in pipelineExporters are never sent.
+func NewSpanExporter(
+	logger *zap.Logger,
+	appInfo component.ApplicationStartInfo,
+	nextConsumer consumer.TracesConsumer,
+	pipelineExporters []string,
+) *SpanExporter {
+	ignoredPrefixes := make([]string, 0, len(pipelineExporters))
+	for _, name := range pipelineExporters {
+		ignoredPrefixes = append(ignoredPrefixes, "exporter/"+name+"/")
+	}
+	return &SpanExporter{
+		logger:          logger,
+		appInfo:         appInfo,
+		nextConsumer:    nextConsumer,
+		ignoredPrefixes: ignoredPrefixes,
+		queue:           make(chan *trace.SpanData, spanExporterQueueSize),
+		stopCh:          make(chan struct{}),
+	}
+}
+
+// Start starts sending the exported spans to the pipeline.
+func (se *SpanExporter) Start() {
+	se.wg.Add(1)
+	go se.run()
+}
+
+// Shutdown sends the queued spans to the pipeline and stops the SpanExporter.
+// The SpanExporter must be unregistered from OpenCensus before.
+func (se *SpanExporter) Shutdown() {
+	close(se.stopCh)
+	se.wg.Wait()
+}
+
+// ExportSpan implements trace.Exporter. The span is dropped if the queue is full,
+// the collector's own telemetry must not block the operations being traced.
+func (se *SpanExporter) ExportSpan(sd *trace.SpanData) {
+	for _, prefix := range se.ignoredPrefixes {
+		if strings.HasPrefix(sd.Name, prefix) {
+			return
+		}
+	}
+	select {
+	case se.queue <- sd:
+	default:
+	}
+}
+
+func (se *SpanExporter) run() {
+	defer se.wg.Done()
+
+	// Child spans with a local parent inherit the sampling decision of the parent.
+	_, unsampledSpan := trace.StartSpan(context.Background(), "internal-telemetry", trace.WithSampler(trace.NeverSample()))
+	ctx := trace.NewContext(context.Background(), unsampledSpan)
+
+	ticker := time.NewTicker(spanExporterFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*trace.SpanData, 0, spanExporterBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := se.nextConsumer.ConsumeTraces(ctx, se.spansToTraces(batch)); err != nil {
+			se.logger.Debug("Failed to send the collector's own spans", zap.Int("spans", len(batch)), zap.Error(err))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case sd := <-se.queue:
+			batch = append(batch, sd)
+			if len(batch) >= spanExporterBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-se.stopCh:
+			for {
+				select {
+				case sd := <-se.queue:
+					batch = append(batch, sd)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (se *SpanExporter) spansToTraces(spans []*trace.SpanData) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	resAttrs := rs.Resource().Attributes()
+	resAttrs.InsertString(conventions.AttributeServiceName, se.appInfo.ExeName)
+	resAttrs.InsertString(conventions.AttributeServiceVersion, se.appInfo.Version)
+
+	rs.InstrumentationLibrarySpans().Resize(1)
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	ils.InstrumentationLibrary().SetName(instrumentationLibraryName)
+	ils.InstrumentationLibrary().SetVersion(se.appInfo.Version)
+
+	ils.Spans().Resize(len(spans))
+	for i, sd := range spans {
+		spanDataToSpan(sd, ils.Spans().At(i))
+	}
+	return td
+}
+
+func spanDataToSpan(sd *trace.SpanData, span pdata.Span) {
+	span.SetTraceID(pdata.NewTraceID(sd.TraceID))
+	span.SetSpanID(pdata.NewSpanID(sd.SpanID))
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		span.SetParentSpanID(pdata.NewSpanID(sd.ParentSpanID))
+	}
+	span.SetName(sd.Name)
+	switch sd.SpanKind {
+	case trace.SpanKindServer:
+		span.SetKind(pdata.SpanKindSERVER)
+	case trace.SpanKindClient:
+		span.SetKind(pdata.SpanKindCLIENT)
+	default:
+		span.SetKind(pdata.SpanKindINTERNAL)
+	}
+	span.SetStartTime(pdata.TimestampFromTime(sd.StartTime))
+	span.SetEndTime(pdata.TimestampFromTime(sd.EndTime))
+	if sd.Status.Code != trace.StatusCodeOK {
+		span.Status().SetCode(pdata.StatusCodeError)
+		span.Status().SetMessage(sd.Status.Message)
+	}
+	insertAttributes(sd.Attributes, span.Attributes())
+	span.SetDroppedAttributesCount(uint32(sd.DroppedAttributeCount))
+
+	span.Events().Resize(len(sd.Annotations))
+	for i, annotation := range sd.Annotations {
+		event := span.Events().At(i)
+		event.SetName(annotation.Message)
+		event.SetTimestamp(pdata.TimestampFromTime(annotation.Time))
+		insertAttributes(annotation.Attributes, event.Attributes())
+	}
+	span.SetDroppedEventsCount(uint32(sd.DroppedAnnotationCount))
+}
+
+func insertAttributes(attributes map[string]interface{}, dest pdata.AttributeMap) {
+	for k, v := range attributes {
+		switch val := v.(type) {
+		case string:
+			dest.InsertString(k, val)
+		case bool:
+			dest.InsertBool(k, val)
+		case int64:
+			dest.InsertInt(k, val)
+		case float64:
+			dest.InsertDouble(k, val)
+		}
+	}
+}
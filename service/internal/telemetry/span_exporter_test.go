@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestSpanExporter(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	appInfo := component.ApplicationStartInfo{ExeName: "otelcol", Version: "1.0.0"}
+	se := NewSpanExporter(zap.NewNop(), appInfo, sink, []string{"otlp"})
+	se.Start()
+
+	now := time.Now()
+	se.ExportSpan(&trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		ParentSpanID: trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+		Name:         "processor/batch/traces",
+		StartTime:    now,
+		EndTime:      now.Add(time.Second),
+		Attributes:   map[string]interface{}{"str": "value", "int": int64(1), "bool": true, "double": 1.5},
+		Annotations:  []trace.Annotation{{Time: now, Message: "Start processing."}},
+		Status:       trace.Status{Code: trace.StatusCodeUnknown, Message: "my_error"},
+	})
+	// The spans of the exporters of the internal pipeline are dropped.
+	se.ExportSpan(&trace.SpanData{Name: "exporter/otlp/traces"})
+	se.Shutdown()
+
+	require.Equal(t, 1, sink.SpansCount())
+	rs := sink.AllTraces()[0].ResourceSpans().At(0)
+	serviceName, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+	require.True(t, ok)
+	assert.Equal(t, "otelcol", serviceName.StringVal())
+
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	assert.Equal(t, instrumentationLibraryName, ils.InstrumentationLibrary().Name())
+	span := ils.Spans().At(0)
+	assert.Equal(t, pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), span.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), span.SpanID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}), span.ParentSpanID())
+	assert.Equal(t, "processor/batch/traces", span.Name())
+	assert.Equal(t, pdata.SpanKindINTERNAL, span.Kind())
+	assert.Equal(t, pdata.TimestampFromTime(now), span.StartTime())
+	assert.Equal(t, pdata.TimestampFromTime(now.Add(time.Second)), span.EndTime())
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "my_error", span.Status().Message())
+	assert.Equal(t, 4, span.Attributes().Len())
+	require.Equal(t, 1, span.Events().Len())
+	assert.Equal(t, "Start processing.", span.Events().At(0).Name())
+}
+
+func TestSpanExporterNotTraced(t *testing.T) {
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+	defer trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(1e-4)})
+
+	var sampled []bool
+	next := consumertest.NewTracesErr(errors.New("my_error"))
+	se := NewSpanExporter(zap.NewNop(), component.ApplicationStartInfo{}, &sampledConsumer{next: next, sampled: &sampled}, nil)
+	se.Start()
+	se.ExportSpan(&trace.SpanData{Name: "receiver/otlp/TraceDataReceived"})
+	se.Shutdown()
+
+	// The operations of the internal pipeline are never sampled, even if every other operation is.
+	assert.Equal(t, []bool{false}, sampled)
+}
+
+type sampledConsumer struct {
+	next    consumer.TracesConsumer
+	sampled *[]bool
+}
+
+func (sc *sampledConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	_, span := trace.StartSpan(ctx, "exporter/test/traces")
+	defer span.End()
+	*sc.sampled = append(*sc.sampled, span.SpanContext().IsSampled())
+	return sc.next.ConsumeTraces(ctx, td)
+}
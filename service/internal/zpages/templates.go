@@ -94,7 +94,9 @@ type SummaryPipelinesTableRowData struct {
 	FullName            string
 	InputType           string
 	MutatesConsumedData bool
+	Paused              bool
 	Receivers           []string
+	PausedReceivers     map[string]bool
 	Processors          []string
 	Exporters           []string
 }
@@ -65,7 +65,9 @@ func TestNoCrash(t *testing.T) {
 				FullName:            "test",
 				InputType:           "metrics",
 				MutatesConsumedData: false,
+				Paused:              true,
 				Receivers:           []string{"oc"},
+				PausedReceivers:     map[string]bool{"oc": true},
 				Processors:          []string{"nop"},
 				Exporters:           []string{"oc"},
 			}},
@@ -85,3 +87,18 @@ func TestNoCrash(t *testing.T) {
 	assert.NotPanics(t, func() { WriteHTMLFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLFooter(buf) })
 }
+
+func TestPipelinesSummaryTablePausedReceivers(t *testing.T) {
+	buf := new(bytes.Buffer)
+	WriteHTMLPipelinesSummaryTable(buf, SummaryPipelinesTableData{
+		ComponentEndpoint: "pagez",
+		Rows: []SummaryPipelinesTableRowData{{
+			FullName:        "test",
+			InputType:       "traces",
+			Receivers:       []string{"otlp", "jaeger"},
+			PausedReceivers: map[string]bool{"jaeger": true},
+		}},
+	})
+	assert.Contains(t, buf.String(), ">jaeger</a> (paused)")
+	assert.NotContains(t, buf.String(), ">otlp</a> (paused)")
+}
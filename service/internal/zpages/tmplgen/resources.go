@@ -275,17 +275,18 @@ vuDEoocBiqjF/5RszGuV1uhFsCujl0bMC/Vz62vzZe1hY98DAAD//7qRGmLTAQAA
 	"/templates/pipelines_table.html": {
 		name:    "pipelines_table.html",
 		local:   "../templates/pipelines_table.html",
-		size:    1946,
+		size:    2183,
 		modtime: 0,
 		compressed: `
-H4sIAAAAAAAC/7SVwXLTMBCG7zyFxnRyIjVcU1scSpnhAMN0eAFZ2gRNlZVmJbdujd+dsWyrTp0LtL5k
-rOjX/tlv/8hFEJUB5sOjgTKrLCmgrXdCajzs2MeMv2OMsSLQ8DAsFJPWeCew/MSE0QcsDewDLyr+tTbm
-hzhCkVe8yIM6OcU3WHl3NXz+mS8W0oWBBAxAvcU3dHX49ejW9PheBxHAX1v09RHUFxHEim63IEHfA/kV
-PX6SleC9XdXkpnGWwqKRIp/i07YXgu1Kdnltj84iYLhB5azG0HWjgAQegF2QfdCooPkQH+OZW/vgR9kg
-3TK9Z3AP+Cyf7Y+5TdEW8u5Atka1Y+8BIOOzSmA8LI/ytgVUbDvb6VG1bW93OUW962Kn/wZxKpLC/Koq
-Z+L6X/XGgWbDRGeETkcDMo0GZD+a+CNSil+AjLUF+02wL7M+AF33+clpB0YjoDhCuQC6eZJTPpIA5Mn3
-dxpVSaNlxidFkQu+dK/oZSuAaj7VN0a1IUF0dZ6eIzvRc2QTvef/5yr4HNklPjd5Rn5Rcpbf2XbWJZhw
-QeMmXNC4hCvdNKvQgsYtacFoGWFFxSvCNl+lu3HQFXl8JfO/AQAA//9We3KLmgcAAA==
+H4sIAAAAAAAC/7RWTW/UMBC98ytGoapAYrtw3SbmUIrEAYQq/oBjzy5Rs2Nr7LTbhvx3lA+7SZMDULKH
+VZx9nud57421qZd5ieD8Q4lZkhvWyBtnpSrosIP3iXgFAJB67h/6hQZlSmclZR9AlsWBshL3XqS5+FyV
+5Td5xHSbi3Tr9WSXOKfc2cv++9d4MYPOCBSSR24pvpCt/I8HuybH18pLj+7KkKuOqD9JL1dk+y4rh3pF
+ghtUWNwhuzWbYKPQObMqyfXJGvazRtJtyGddn0nYZXBxZY7WEJK/Jm1NQb5pBgBLOiCcsbkvSOPpXffY
+7bkx926A9dANFHvAO6Qn+Oj3YTDi7Eh1e2BTkd7Ba0RMxKgSlg7nW0VdI2nYNM1Eqrpu6S7CLDVN1+nf
+iRiKxGl5UZWFeXhRvT7x/1RiyETSh2Ik8tRdVNFdVK27HW8chGdetJ9Uwk/GfZa0GWqaj4+2sFgWhCSP
+mM08OX9UIWIRgGry/rYgnfFAmYiASLdSzNiHsHWHhpFG8cTQb4Y3tnv/tovOUhs5P9dkCvzfmp+zZL5c
+tsGyCTZYNtGGp7tiFR8sm7kPNnAmIkAWjVhsZ10Fo1x4skEuPNkoV7z1VlELT3auFg6UiQiIRbH+LGzj
+Vbyne1y67f5/iN8DAHh1GAOHCAAA
 `,
 	},
 
@@ -16,11 +16,17 @@ package service
 
 import (
 	"flag"
+	"fmt"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/internal/version"
+	"go.opentelemetry.io/collector/service/internal/builder"
 )
 
 const (
@@ -44,9 +50,15 @@ func loggerFlags(flags *flag.FlagSet) {
 	loggerFormatPtr = flags.String(logFormatCfg, "console", "Format of logs to use (json, console)")
 }
 
-func newLogger(options []zap.Option) (*zap.Logger, error) {
+// newLogger creates the logger of the application. The settings of logsCfg take
+// precedence over the logging command line flags.
+func newLogger(options []zap.Option, logsCfg configmodels.ServiceTelemetryLogs) (*zap.Logger, error) {
+	levelStr := *loggerLevelPtr
+	if logsCfg.Level != "" {
+		levelStr = logsCfg.Level
+	}
 	var level zapcore.Level
-	err := (&level).UnmarshalText([]byte(*loggerLevelPtr))
+	err := (&level).UnmarshalText([]byte(levelStr))
 	if err != nil {
 		return nil, err
 	}
@@ -65,13 +77,119 @@ func newLogger(options []zap.Option) (*zap.Logger, error) {
 			conf = zap.NewDevelopmentConfig()
 		}
 	}
+	if logsCfg.Development {
+		conf = zap.NewDevelopmentConfig()
+	}
 
 	conf.Encoding = *loggerFormatPtr
+	if logsCfg.Encoding != "" {
+		conf.Encoding = logsCfg.Encoding
+	}
 	if conf.Encoding == "console" {
 		// Human-readable timestamps for console format of logs.
 		conf.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
+	if len(logsCfg.OutputPaths) > 0 {
+		conf.OutputPaths = logsCfg.OutputPaths
+	}
+	if len(logsCfg.ErrorOutputPaths) > 0 {
+		conf.ErrorOutputPaths = logsCfg.ErrorOutputPaths
+	}
+
+	// The core must be enabled for the lowest level used by any of the components,
+	// the level of the application is applied on top of it.
+	coreLevel := level
+	for _, componentLevelStr := range logsCfg.ComponentLevels {
+		var componentLevel zapcore.Level
+		if err = (&componentLevel).UnmarshalText([]byte(componentLevelStr)); err != nil {
+			return nil, err
+		}
+		if componentLevel < coreLevel {
+			coreLevel = componentLevel
+		}
+	}
+
+	var encoder zapcore.Encoder
+	switch conf.Encoding {
+	case "json":
+		encoder = zapcore.NewJSONEncoder(conf.EncoderConfig)
+	case "console":
+		encoder = zapcore.NewConsoleEncoder(conf.EncoderConfig)
+	default:
+		return nil, fmt.Errorf("unknown log encoding %q", conf.Encoding)
+	}
+
+	sink, err := openLogSinks(conf.OutputPaths, logsCfg.Rotation)
+	if err != nil {
+		return nil, err
+	}
+	errSink, _, err := zap.Open(conf.ErrorOutputPaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	core := zapcore.NewCore(encoder, sink, zap.NewAtomicLevelAt(coreLevel))
+	if sampling := newLogsSampling(conf.Sampling, logsCfg.Sampling); sampling != nil {
+		core = zapcore.NewSamplerWithOptions(core, sampling.Tick, sampling.Initial, sampling.Thereafter)
+	}
+
+	opts := []zap.Option{zap.ErrorOutput(errSink), zap.AddCaller(), builder.WrapCoreWithLevel(level)}
+	if conf.Development {
+		opts = append(opts, zap.Development(), zap.AddStacktrace(zapcore.WarnLevel))
+	} else {
+		opts = append(opts, zap.AddStacktrace(zapcore.ErrorLevel))
+	}
+	opts = append(opts, options...)
+
+	return zap.New(core, opts...), nil
+}
+
+// openLogSinks opens the given output paths. If rotation is set, file paths are
+// written through a rotating logger.
+func openLogSinks(paths []string, rotation *configmodels.LogsRotation) (zapcore.WriteSyncer, error) {
+	if rotation == nil {
+		sink, _, err := zap.Open(paths...)
+		return sink, err
+	}
+
+	var sinks []zapcore.WriteSyncer
+	for _, p := range paths {
+		if p == "stdout" || p == "stderr" || strings.Contains(p, "://") {
+			sink, _, err := zap.Open(p)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+			continue
+		}
+		sinks = append(sinks, zapcore.AddSync(&lumberjack.Logger{
+			Filename:   p,
+			MaxSize:    rotation.MaxSizeMiB,
+			MaxAge:     rotation.MaxAgeDays,
+			MaxBackups: rotation.MaxBackups,
+			LocalTime:  rotation.LocalTime,
+			Compress:   rotation.Compress,
+		}))
+	}
+	return zapcore.NewMultiWriteSyncer(sinks...), nil
+}
 
-	conf.Level.SetLevel(level)
-	return conf.Build(options...)
+// newLogsSampling returns the sampling to use, the settings from the configuration
+// take precedence over the default sampling of the logging profile. Returns nil
+// if sampling is disabled.
+func newLogsSampling(profile *zap.SamplingConfig, sampling *configmodels.LogsSampling) *configmodels.LogsSampling {
+	if sampling == nil {
+		if profile == nil {
+			return nil
+		}
+		return &configmodels.LogsSampling{Initial: profile.Initial, Thereafter: profile.Thereafter, Tick: time.Second}
+	}
+	if sampling.Initial == 0 {
+		return nil
+	}
+	ret := *sampling
+	if ret.Tick == 0 {
+		ret.Tick = time.Second
+	}
+	return &ret
 }
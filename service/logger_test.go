@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+func TestNewLogger_OutputFileWithRotation(t *testing.T) {
+	loggerFlags(new(flag.FlagSet))
+
+	logFile := filepath.Join(t.TempDir(), "otelcol.log")
+	logger, err := newLogger(nil, configmodels.ServiceTelemetryLogs{
+		Level:       "warn",
+		Encoding:    "json",
+		OutputPaths: []string{logFile},
+		Rotation:    &configmodels.LogsRotation{MaxSizeMiB: 1, MaxBackups: 1},
+		ComponentLevels: map[string]string{
+			"exporter/otlp": "debug",
+		},
+	})
+	require.NoError(t, err)
+
+	logger.Info("not logged")
+	logger.Warn("logged")
+	require.NoError(t, logger.Sync())
+
+	content, err := ioutil.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "not logged")
+	assert.Contains(t, string(content), `"logged"`)
+}
+
+func TestNewLogger_InvalidLevel(t *testing.T) {
+	loggerFlags(new(flag.FlagSet))
+
+	_, err := newLogger(nil, configmodels.ServiceTelemetryLogs{Level: "verbose"})
+	assert.Error(t, err)
+
+	_, err = newLogger(nil, configmodels.ServiceTelemetryLogs{ComponentLevels: map[string]string{"exporter/otlp": "verbose"}})
+	assert.Error(t, err)
+}
+
+func TestNewLogsSampling(t *testing.T) {
+	assert.Nil(t, newLogsSampling(nil, nil))
+	assert.Equal(t,
+		&configmodels.LogsSampling{Initial: 100, Thereafter: 100, Tick: time.Second},
+		newLogsSampling(&zap.SamplingConfig{Initial: 100, Thereafter: 100}, nil))
+	assert.Equal(t,
+		&configmodels.LogsSampling{Initial: 10, Thereafter: 20, Tick: time.Second},
+		newLogsSampling(&zap.SamplingConfig{Initial: 100, Thereafter: 100}, &configmodels.LogsSampling{Initial: 10, Thereafter: 20}))
+	assert.Nil(t, newLogsSampling(&zap.SamplingConfig{Initial: 100, Thereafter: 100}, &configmodels.LogsSampling{}))
+}
@@ -0,0 +1,303 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/service/internal/builder"
+)
+
+// configDiff holds the names of the components that must be rebuilt to apply a new
+// configuration, because their configuration or the components they send data to
+// changed. The components that are not listed keep running.
+type configDiff struct {
+	exporters map[string]bool
+	pipelines map[string]bool
+	receivers map[string]bool
+
+	// restartRequired lists the changed settings that are only applied on restart.
+	restartRequired []string
+}
+
+func (d configDiff) isEmpty() bool {
+	return len(d.exporters) == 0 && len(d.pipelines) == 0 && len(d.receivers) == 0
+}
+
+func diffConfigs(oldCfg, newCfg *configmodels.Config) configDiff {
+	diff := configDiff{
+		exporters: make(map[string]bool),
+		pipelines: make(map[string]bool),
+		receivers: make(map[string]bool),
+	}
+
+	if !reflect.DeepEqual(oldCfg.Service.Extensions, newCfg.Service.Extensions) || !reflect.DeepEqual(oldCfg.Extensions, newCfg.Extensions) {
+		diff.restartRequired = append(diff.restartRequired, "extensions")
+	}
+	if !reflect.DeepEqual(oldCfg.Service.Telemetry, newCfg.Service.Telemetry) {
+		diff.restartRequired = append(diff.restartRequired, "service::telemetry")
+	}
+
+	// An exporter is rebuilt if its configuration or the data types it exports changed.
+	for name := range unionKeys(oldCfg.Exporters, newCfg.Exporters) {
+		if !reflect.DeepEqual(oldCfg.Exporters[name], newCfg.Exporters[name]) ||
+			!reflect.DeepEqual(exporterDataTypes(oldCfg, name), exporterDataTypes(newCfg, name)) {
+			diff.exporters[name] = true
+		}
+	}
+
+	// A pipeline is rebuilt if its configuration, one of its processors or one of its
+	// exporters changed.
+	for name := range unionKeys(oldCfg.Service.Pipelines, newCfg.Service.Pipelines) {
+		oldPipeline, newPipeline := oldCfg.Service.Pipelines[name], newCfg.Service.Pipelines[name]
+		if oldPipeline == nil || newPipeline == nil || !reflect.DeepEqual(*oldPipeline, *newPipeline) {
+			diff.pipelines[name] = true
+			continue
+		}
+		for _, procName := range newPipeline.Processors {
+			if !reflect.DeepEqual(oldCfg.Processors[procName], newCfg.Processors[procName]) {
+				diff.pipelines[name] = true
+			}
+		}
+		for _, expName := range newPipeline.Exporters {
+			if diff.exporters[expName] {
+				diff.pipelines[name] = true
+			}
+		}
+	}
+
+	// A receiver is rebuilt if its configuration or the pipelines it sends data to
+	// changed. The receivers are not rebuilt when one of their pipelines is rebuilt.
+	for name := range unionKeys(oldCfg.Receivers, newCfg.Receivers) {
+		if !reflect.DeepEqual(oldCfg.Receivers[name], newCfg.Receivers[name]) ||
+			!reflect.DeepEqual(receiverPipelines(oldCfg, name), receiverPipelines(newCfg, name)) {
+			diff.receivers[name] = true
+		}
+	}
+
+	return diff
+}
+
+// unionKeys returns the keys of the two maps, which must be maps keyed by string.
+func unionKeys(a, b interface{}) map[string]bool {
+	keys := make(map[string]bool)
+	for _, m := range []reflect.Value{reflect.ValueOf(a), reflect.ValueOf(b)} {
+		for _, key := range m.MapKeys() {
+			keys[key.String()] = true
+		}
+	}
+	return keys
+}
+
+// exporterDataTypes returns the sorted data types of the pipelines using the exporter.
+func exporterDataTypes(cfg *configmodels.Config, exporterName string) []string {
+	types := make(map[string]bool)
+	for _, pipeline := range cfg.Service.Pipelines {
+		for _, name := range pipeline.Exporters {
+			if name == exporterName {
+				types[string(pipeline.InputType)] = true
+			}
+		}
+	}
+	return sortedKeys(types)
+}
+
+// receiverPipelines returns the sorted names of the pipelines the receiver sends data to.
+func receiverPipelines(cfg *configmodels.Config, receiverName string) []string {
+	pipelines := make(map[string]bool)
+	for _, pipeline := range cfg.Service.Pipelines {
+		for _, name := range pipeline.Receivers {
+			if name == receiverName {
+				pipelines[pipeline.Name] = true
+			}
+		}
+	}
+	return sortedKeys(pipelines)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// reloadConfiguration loads the configuration again and applies it, only rebuilding
+// the components that changed. An error leaves the pipelines in an inconsistent state,
+// so it is fatal.
+func (app *Application) reloadConfiguration(ctx context.Context) error {
+	app.logger.Info("Reloading configuration...")
+	newCfg, err := app.configFactory(app.v, app.rootCmd, app.factories)
+	if err != nil {
+		app.logger.Error("Cannot load configuration, keeping the current one", zap.Error(err))
+		return nil
+	}
+	if err = config.ValidateConfig(newCfg, app.logger); err != nil {
+		app.logger.Error("Invalid configuration, keeping the current one", zap.Error(err))
+		return nil
+	}
+
+	app.reloadMutex.Lock()
+	defer app.reloadMutex.Unlock()
+
+	diff := diffConfigs(app.config, newCfg)
+	if len(diff.restartRequired) > 0 {
+		app.logger.Warn("Configuration changes are only applied on restart", zap.Strings("sections", diff.restartRequired))
+	}
+	if diff.isEmpty() {
+		app.logger.Info("No pipeline changes to apply.")
+		app.config = newCfg
+		return nil
+	}
+
+	app.logger.Info("Applying configuration changes...",
+		zap.Strings("exporters", sortedKeys(diff.exporters)),
+		zap.Strings("pipelines", sortedKeys(diff.pipelines)),
+		zap.Strings("receivers", sortedKeys(diff.receivers)))
+	if err = app.applyConfigDiff(ctx, newCfg, diff); err != nil {
+		return fmt.Errorf("cannot apply configuration changes: %w", err)
+	}
+	app.logger.Info("Configuration reloaded.")
+	return nil
+}
+
+func (app *Application) applyConfigDiff(ctx context.Context, newCfg *configmodels.Config, diff configDiff) error {
+	oldCfg := app.config
+
+	// The changed pipelines stop accepting data and are drained into their
+	// exporters, then the changed exporters are shutdown.
+	oldPipelines := make(builder.BuiltPipelines)
+	for cfg, bp := range app.builtPipelines {
+		if diff.pipelines[cfg.Name] {
+			oldPipelines[cfg] = bp
+		}
+	}
+	oldPipelines.StartReload()
+	if err := oldPipelines.ShutdownProcessors(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown processors: %w", err)
+	}
+
+	oldExporters := make(builder.Exporters)
+	for name := range diff.exporters {
+		if cfg, ok := oldCfg.Exporters[name]; ok {
+			oldExporters[cfg] = app.builtExporters[cfg]
+		}
+	}
+	if err := oldExporters.ShutdownAll(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown exporters: %w", err)
+	}
+
+	// Build the changed exporters, and key the unchanged ones by their new configuration.
+	buildCfg := *newCfg
+	buildCfg.Exporters = make(configmodels.Exporters)
+	for name := range diff.exporters {
+		if cfg, ok := newCfg.Exporters[name]; ok {
+			buildCfg.Exporters[name] = cfg
+		}
+	}
+	newExporters, err := builder.BuildExporters(app.logger, app.info, &buildCfg, app.factories.Exporters)
+	if err != nil {
+		return fmt.Errorf("cannot build exporters: %w", err)
+	}
+	if err = newExporters.StartAll(ctx, app); err != nil {
+		return fmt.Errorf("cannot start exporters: %w", err)
+	}
+	for name, cfg := range newCfg.Exporters {
+		if !diff.exporters[name] {
+			newExporters[cfg] = app.builtExporters[oldCfg.Exporters[name]]
+		}
+	}
+
+	// Build the changed pipelines, they take over the entries of the previous
+	// pipelines with the same name so the unchanged receivers keep sending data.
+	buildCfg = *newCfg
+	buildCfg.Service.Pipelines = make(configmodels.Pipelines)
+	for name := range diff.pipelines {
+		if cfg, ok := newCfg.Service.Pipelines[name]; ok {
+			buildCfg.Service.Pipelines[name] = cfg
+		}
+	}
+	newPipelines, err := builder.BuildPipelines(app.logger, app.info, &buildCfg, newExporters, app.factories.Processors)
+	if err != nil {
+		return fmt.Errorf("cannot build pipelines: %w", err)
+	}
+	if err = newPipelines.StartProcessors(ctx, app); err != nil {
+		return fmt.Errorf("cannot start processors: %w", err)
+	}
+
+	// The receivers sending data to more than one pipeline only clone the data if one
+	// of the pipelines mutates it, so they are rebuilt if that changes.
+	for cfg, bp := range newPipelines {
+		prevCfg := oldCfg.Service.Pipelines[cfg.Name]
+		if prevCfg != nil && app.builtPipelines[prevCfg].MutatesConsumedData != bp.MutatesConsumedData {
+			for _, name := range cfg.Receivers {
+				diff.receivers[name] = true
+			}
+		}
+	}
+
+	oldReceivers := make(builder.Receivers)
+	for name := range diff.receivers {
+		if cfg, ok := oldCfg.Receivers[name]; ok && app.builtReceivers[cfg] != nil {
+			oldReceivers[cfg] = app.builtReceivers[cfg]
+		}
+	}
+	if err = oldReceivers.ShutdownAll(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown receivers: %w", err)
+	}
+
+	newPipelines.FinishReload(oldPipelines)
+	for name, cfg := range newCfg.Service.Pipelines {
+		if !diff.pipelines[name] {
+			newPipelines[cfg] = app.builtPipelines[oldCfg.Service.Pipelines[name]]
+		}
+	}
+
+	// Build the changed receivers, attached to all the pipelines.
+	buildCfg = *newCfg
+	buildCfg.Receivers = make(configmodels.Receivers)
+	for name := range diff.receivers {
+		if cfg, ok := newCfg.Receivers[name]; ok {
+			buildCfg.Receivers[name] = cfg
+		}
+	}
+	newReceivers, err := builder.BuildReceivers(app.logger, app.info, &buildCfg, newPipelines, app.factories.Receivers)
+	if err != nil {
+		return fmt.Errorf("cannot build receivers: %w", err)
+	}
+	if err = newReceivers.StartAll(ctx, app); err != nil {
+		return fmt.Errorf("cannot start receivers: %w", err)
+	}
+	for name, cfg := range newCfg.Receivers {
+		if rcv := app.builtReceivers[oldCfg.Receivers[name]]; !diff.receivers[name] && rcv != nil {
+			newReceivers[cfg] = rcv
+		}
+	}
+
+	app.config = newCfg
+	app.builtExporters = newExporters
+	app.builtPipelines = newPipelines
+	app.builtReceivers = newReceivers
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	loadConfig := func() *configmodels.Config {
+		cfg, err := configtest.LoadConfigFile(t, path.Join(".", "internal", "builder", "testdata", "pipelines_builder.yaml"), factories)
+		require.NoError(t, err)
+		return cfg
+	}
+
+	tests := []struct {
+		name              string
+		modify            func(cfg *configmodels.Config)
+		expectedExporters []string
+		expectedPipelines []string
+		expectedReceivers []string
+		restartRequired   []string
+	}{
+		{
+			name:   "unchanged",
+			modify: func(cfg *configmodels.Config) {},
+		},
+		{
+			name: "exporter",
+			modify: func(cfg *configmodels.Config) {
+				cfg.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting = "changed"
+			},
+			expectedExporters: []string{"exampleexporter/2"},
+			expectedPipelines: []string{"logs", "metrics/3", "traces/2"},
+		},
+		{
+			name: "exporter_data_types",
+			modify: func(cfg *configmodels.Config) {
+				cfg.Service.Pipelines["logs"].Exporters = []string{"exampleexporter"}
+			},
+			expectedExporters: []string{"exampleexporter", "exampleexporter/2"},
+			expectedPipelines: []string{"logs", "metrics", "metrics/2", "metrics/3", "traces", "traces/2"},
+		},
+		{
+			name: "processor",
+			modify: func(cfg *configmodels.Config) {
+				cfg.Processors["exampleprocessor"].(*componenttest.ExampleProcessorCfg).ExtraSetting = "changed"
+			},
+			expectedPipelines: []string{"traces", "traces/2"},
+		},
+		{
+			name: "receiver",
+			modify: func(cfg *configmodels.Config) {
+				cfg.Receivers["examplereceiver/2"].(*componenttest.ExampleReceiver).ExtraSetting = "changed"
+			},
+			expectedReceivers: []string{"examplereceiver/2"},
+		},
+		{
+			name: "receiver_pipelines",
+			modify: func(cfg *configmodels.Config) {
+				cfg.Service.Pipelines["metrics/3"].Receivers = []string{"examplereceiver/2"}
+			},
+			expectedPipelines: []string{"metrics/3"},
+			expectedReceivers: []string{"examplereceiver/2", "examplereceiver/3"},
+		},
+		{
+			name: "removed_pipeline",
+			modify: func(cfg *configmodels.Config) {
+				delete(cfg.Service.Pipelines, "logs")
+			},
+			expectedExporters: []string{"exampleexporter/2"},
+			expectedPipelines: []string{"logs", "metrics/3", "traces/2"},
+			expectedReceivers: []string{"examplereceiver/3"},
+		},
+		{
+			name: "telemetry",
+			modify: func(cfg *configmodels.Config) {
+				cfg.Service.Telemetry.Traces.SamplingRatio = 0.5
+			},
+			restartRequired: []string{"service::telemetry"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newCfg := loadConfig()
+			test.modify(newCfg)
+
+			diff := diffConfigs(loadConfig(), newCfg)
+			assert.ElementsMatch(t, test.expectedExporters, sortedKeys(diff.exporters))
+			assert.ElementsMatch(t, test.expectedPipelines, sortedKeys(diff.pipelines))
+			assert.ElementsMatch(t, test.expectedReceivers, sortedKeys(diff.receivers))
+			assert.Equal(t, test.restartRequired, diff.restartRequired)
+		})
+	}
+}
+
+func TestApplication_ReloadConfiguration(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	oldCfg, err := configtest.LoadConfigFile(t, path.Join(".", "internal", "builder", "testdata", "pipelines_builder.yaml"), factories)
+	require.NoError(t, err)
+	newCfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "otelcol-config-reload.yaml"), factories)
+	require.NoError(t, err)
+
+	app := &Application{
+		logger:    zap.NewNop(),
+		info:      component.DefaultApplicationStartInfo(),
+		factories: factories,
+		config:    oldCfg,
+		configFactory: func(*viper.Viper, *cobra.Command, component.Factories) (*configmodels.Config, error) {
+			return newCfg, nil
+		},
+	}
+	require.NoError(t, app.setupPipelines(context.Background()))
+	defer func() { assert.NoError(t, app.shutdownPipelines(context.Background())) }()
+	require.NoError(t, app.SetPipelinePaused("logs", true))
+
+	oldExporters, oldReceivers := app.builtExporters, app.builtReceivers
+	oldExporter := app.GetExporters()[configmodels.TracesDataType][oldCfg.Exporters["exampleexporter/2"]].(*componenttest.ExampleExporterConsumer)
+	require.NoError(t, app.reloadConfiguration(context.Background()))
+	assert.Same(t, newCfg, app.config)
+
+	// Only the changed exporter is rebuilt, all the receivers keep running.
+	assert.Same(t, oldExporters[oldCfg.Exporters["exampleexporter"]], app.builtExporters[newCfg.Exporters["exampleexporter"]])
+	assert.NotSame(t, oldExporters[oldCfg.Exporters["exampleexporter/2"]], app.builtExporters[newCfg.Exporters["exampleexporter/2"]])
+	for name, cfg := range newCfg.Receivers {
+		assert.Same(t, oldReceivers[oldCfg.Receivers[name]], app.builtReceivers[cfg], name)
+	}
+
+	// The paused state of the rebuilt pipelines is kept.
+	pipelines, _ := app.GetPausedStates()
+	assert.True(t, pipelines["logs"])
+
+	// The data sent by the receivers to a rebuilt pipeline reaches the rebuilt exporter.
+	require.NoError(t, app.builtPipelines.TracesConsumer("traces/2").ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+
+	newExporter := app.GetExporters()[configmodels.TracesDataType][newCfg.Exporters["exampleexporter/2"]].(*componenttest.ExampleExporterConsumer)
+	assert.Len(t, oldExporter.Traces, 0)
+	assert.Len(t, newExporter.Traces, 1)
+}
@@ -27,10 +27,16 @@ import (
 	"path"
 	"runtime"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -39,16 +45,29 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/ballastextension"
 	"go.opentelemetry.io/collector/internal/collector/telemetry"
+	"go.opentelemetry.io/collector/internal/iruntime"
 	"go.opentelemetry.io/collector/internal/version"
 	"go.opentelemetry.io/collector/service/internal/builder"
+	"go.opentelemetry.io/collector/service/internal/configz"
+	telemetry2 "go.opentelemetry.io/collector/service/internal/telemetry"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
+// defaultTelemetryMetricsInterval is the period between two pushes of the
+// collector's own metrics to their pipeline when no interval is configured.
+const defaultTelemetryMetricsInterval = 10 * time.Second
+
 const (
 	servicezPath   = "servicez"
 	pipelinezPath  = "pipelinez"
 	extensionzPath = "extensionz"
+	configzPath    = "configz"
+
+	// The pages registered by the zpages extension.
+	tracezPath = "tracez"
+	rpczPath   = "rpcz"
 )
 
 // State defines Application's state.
@@ -72,14 +91,21 @@ type Application struct {
 	rootCmd         *cobra.Command
 	v               *viper.Viper
 	logger          *zap.Logger
+	loggingOptions  []zap.Option
 	builtExporters  builder.Exporters
 	builtReceivers  builder.Receivers
 	builtPipelines  builder.BuiltPipelines
 	builtExtensions builder.Extensions
+	spanExporter    *telemetry2.SpanExporter
+	metricsPusher   *telemetry2.MetricsPusher
 	stateChannel    chan State
 
-	factories component.Factories
-	config    *configmodels.Config
+	factories     component.Factories
+	configFactory ConfigFactory
+	config        *configmodels.Config
+
+	// reloadMutex protects the built components while the configuration is reloaded.
+	reloadMutex sync.RWMutex
 
 	// stopTestChan is used to terminate the application in end to end tests.
 	stopTestChan chan struct{}
@@ -89,6 +115,14 @@ type Application struct {
 
 	// asyncErrorChannel is used to signal a fatal error from any component.
 	asyncErrorChannel chan error
+
+	// flagBallastSizeBytes is the size of the memory ballast allocated via the
+	// deprecated command line flag.
+	flagBallastSizeBytes uint64
+
+	// memoryBallast holds the memory_ballast extension, if configured. It is
+	// read concurrently by the process metrics of the application.
+	memoryBallast atomic.Value
 }
 
 // Command returns Application's root command.
@@ -229,6 +263,36 @@ func (app *Application) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, servicezPath), app.handleServicezRequest)
 	mux.HandleFunc(path.Join(pathPrefix, pipelinezPath), app.handlePipelinezRequest)
 	mux.HandleFunc(path.Join(pathPrefix, extensionzPath), app.handleExtensionzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, configzPath), app.handleConfigzRequest)
+}
+
+// SetPipelinePaused pauses or resumes the pipeline with the given name. The data
+// sent to a paused pipeline is dropped.
+func (app *Application) SetPipelinePaused(name string, paused bool) error {
+	app.reloadMutex.RLock()
+	defer app.reloadMutex.RUnlock()
+	if !app.builtPipelines.SetPaused(name, paused) {
+		return fmt.Errorf("pipeline %q does not exist", name)
+	}
+	return nil
+}
+
+// SetReceiverPaused pauses or resumes the receiver with the given name. The data
+// received by a paused receiver is dropped.
+func (app *Application) SetReceiverPaused(name string, paused bool) error {
+	app.reloadMutex.RLock()
+	defer app.reloadMutex.RUnlock()
+	if !app.builtReceivers.SetPaused(name, paused) {
+		return fmt.Errorf("receiver %q does not exist", name)
+	}
+	return nil
+}
+
+// GetPausedStates returns whether each pipeline and each receiver is paused, by name.
+func (app *Application) GetPausedStates() (pipelines map[string]bool, receivers map[string]bool) {
+	app.reloadMutex.RLock()
+	defer app.reloadMutex.RUnlock()
+	return app.builtPipelines.PausedStates(), app.builtReceivers.PausedStates()
 }
 
 func (app *Application) Shutdown() {
@@ -243,7 +307,8 @@ func (app *Application) Shutdown() {
 }
 
 func (app *Application) init(options []zap.Option) error {
-	l, err := newLogger(options)
+	app.loggingOptions = options
+	l, err := newLogger(options, configmodels.ServiceTelemetryLogs{})
 	if err != nil {
 		return fmt.Errorf("failed to get logger: %w", err)
 	}
@@ -251,10 +316,22 @@ func (app *Application) init(options []zap.Option) error {
 	return nil
 }
 
-func (app *Application) setupTelemetry(ballastSizeBytes uint64) error {
+// setupLogger replaces the logger created from the command line flags with one
+// that also honors the logs settings of the service telemetry configuration.
+func (app *Application) setupLogger() error {
+	l, err := newLogger(app.loggingOptions, app.config.Service.Telemetry.Logs)
+	if err != nil {
+		return fmt.Errorf("failed to get logger: %w", err)
+	}
+	_ = app.logger.Sync()
+	app.logger = l
+	return nil
+}
+
+func (app *Application) setupTelemetry() error {
 	app.logger.Info("Setting up own telemetry...")
 
-	err := applicationTelemetry.init(app.asyncErrorChannel, ballastSizeBytes, app.logger)
+	err := applicationTelemetry.init(app.asyncErrorChannel, app.info, app.getBallastSize, app.logger)
 	if err != nil {
 		return fmt.Errorf("failed to initialize telemetry: %w", err)
 	}
@@ -270,16 +347,30 @@ func (app *Application) runAndWaitForShutdownEvent() {
 	app.signalsChannel = make(chan os.Signal, 1)
 	signal.Notify(app.signalsChannel, os.Interrupt, syscall.SIGTERM)
 
+	// plug SIGHUP signal into a channel to reload the configuration.
+	reloadChannel := make(chan os.Signal, 1)
+	signal.Notify(reloadChannel, syscall.SIGHUP)
+	defer signal.Stop(reloadChannel)
+
 	// set the channel to stop testing.
 	app.stopTestChan = make(chan struct{})
 	app.stateChannel <- Running
-	select {
-	case err := <-app.asyncErrorChannel:
-		app.logger.Error("Asynchronous error received, terminating process", zap.Error(err))
-	case s := <-app.signalsChannel:
-		app.logger.Info("Received signal from OS", zap.String("signal", s.String()))
-	case <-app.stopTestChan:
-		app.logger.Info("Received stop test request")
+	for {
+		select {
+		case err := <-app.asyncErrorChannel:
+			app.logger.Error("Asynchronous error received, terminating process", zap.Error(err))
+		case s := <-app.signalsChannel:
+			app.logger.Info("Received signal from OS", zap.String("signal", s.String()))
+		case <-app.stopTestChan:
+			app.logger.Info("Received stop test request")
+		case <-reloadChannel:
+			if err := app.reloadConfiguration(context.Background()); err != nil {
+				app.logger.Error("Failed to reload configuration, terminating process", zap.Error(err))
+				break
+			}
+			continue
+		}
+		break
 	}
 	app.stateChannel <- Closing
 }
@@ -290,6 +381,7 @@ func (app *Application) setupConfigurationComponents(ctx context.Context, factor
 	}
 
 	app.logger.Info("Loading configuration...")
+	app.configFactory = factory
 	cfg, err := factory(app.v, app.rootCmd, app.factories)
 	if err != nil {
 		return fmt.Errorf("cannot load configuration: %w", err)
@@ -300,6 +392,9 @@ func (app *Application) setupConfigurationComponents(ctx context.Context, factor
 	}
 
 	app.config = cfg
+	if err = app.setupLogger(); err != nil {
+		return err
+	}
 	app.logger.Info("Applying configuration...")
 
 	err = app.setupExtensions(ctx)
@@ -321,6 +416,11 @@ func (app *Application) setupExtensions(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("cannot build builtExtensions: %w", err)
 	}
+	for _, ext := range app.builtExtensions.ToMap() {
+		if mb, ok := ext.(*ballastextension.MemoryBallast); ok {
+			app.memoryBallast.Store(mb)
+		}
+	}
 	app.logger.Info("Starting extensions...")
 	return app.builtExtensions.StartAll(ctx, app)
 }
@@ -336,12 +436,6 @@ func (app *Application) setupPipelines(ctx context.Context) error {
 		return fmt.Errorf("cannot build builtExporters: %w", err)
 	}
 
-	app.logger.Info("Starting exporters...")
-	err = app.builtExporters.StartAll(ctx, app)
-	if err != nil {
-		return fmt.Errorf("cannot start builtExporters: %w", err)
-	}
-
 	// Create pipelines and their processors and plug exporters to the
 	// end of the pipelines.
 	app.builtPipelines, err = builder.BuildPipelines(app.logger, app.info, app.config, app.builtExporters, app.factories.Processors)
@@ -349,24 +443,24 @@ func (app *Application) setupPipelines(ctx context.Context) error {
 		return fmt.Errorf("cannot build pipelines: %w", err)
 	}
 
-	app.logger.Info("Starting processors...")
-	err = app.builtPipelines.StartProcessors(ctx, app)
-	if err != nil {
-		return fmt.Errorf("cannot start processors: %w", err)
-	}
-
 	// Create receivers and plug them into the start of the pipelines.
 	app.builtReceivers, err = builder.BuildReceivers(app.logger, app.info, app.config, app.builtPipelines, app.factories.Receivers)
 	if err != nil {
 		return fmt.Errorf("cannot build receivers: %w", err)
 	}
 
-	app.logger.Info("Starting receivers...")
-	err = app.builtReceivers.StartAll(ctx, app)
+	// Independent pipelines are started concurrently, within a pipeline the
+	// components are started after the components they send data to.
+	app.logger.Info("Starting pipelines...")
+	err = builder.StartPipelines(ctx, app, app.config, app.builtExporters, app.builtPipelines, app.builtReceivers)
 	if err != nil {
-		return fmt.Errorf("cannot start receivers: %w", err)
+		return fmt.Errorf("cannot start pipelines: %w", err)
 	}
 
+	// The collector's own spans and metrics can be sent once the pipelines can accept data.
+	app.setupTraces()
+	app.setupMetrics()
+
 	return nil
 }
 
@@ -383,6 +477,9 @@ func (app *Application) shutdownPipelines(ctx context.Context) error {
 		errs = append(errs, fmt.Errorf("failed to stop receivers: %w", err))
 	}
 
+	app.shutdownTraces()
+	app.shutdownMetrics()
+
 	app.logger.Info("Stopping processors...")
 	err = app.builtPipelines.ShutdownProcessors(ctx)
 	if err != nil {
@@ -398,6 +495,75 @@ func (app *Application) shutdownPipelines(ctx context.Context) error {
 	return consumererror.CombineErrors(errs)
 }
 
+// setupTraces applies the traces settings of the service telemetry configuration,
+// sending the collector's own spans to the configured pipeline.
+func (app *Application) setupTraces() {
+	tracesCfg := app.config.Service.Telemetry.Traces
+	if tracesCfg.SamplingRatio > 0 {
+		trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(tracesCfg.SamplingRatio)})
+	}
+
+	if tracesCfg.Pipeline == "" {
+		return
+	}
+	tc := app.builtPipelines.TracesConsumer(tracesCfg.Pipeline)
+	if tc == nil {
+		return
+	}
+
+	app.logger.Info("Sending own traces to pipeline", zap.String("pipeline", tracesCfg.Pipeline))
+	app.spanExporter = telemetry2.NewSpanExporter(app.logger, app.info, tc, app.config.Service.Pipelines[tracesCfg.Pipeline].Exporters)
+	app.spanExporter.Start()
+	trace.RegisterExporter(app.spanExporter)
+}
+
+// shutdownTraces stops sending the collector's own spans, the queued spans are
+// sent to the pipeline before it is shutdown.
+func (app *Application) shutdownTraces() {
+	if app.spanExporter == nil {
+		return
+	}
+	trace.UnregisterExporter(app.spanExporter)
+	app.spanExporter.Shutdown()
+	app.spanExporter = nil
+}
+
+// setupMetrics applies the metrics settings of the service telemetry configuration,
+// periodically pushing the collector's own metrics to the configured pipeline.
+func (app *Application) setupMetrics() {
+	metricsCfg := app.config.Service.Telemetry.Metrics
+	if metricsCfg.Pipeline == "" {
+		return
+	}
+	mc := app.builtPipelines.MetricsConsumer(metricsCfg.Pipeline)
+	if mc == nil {
+		return
+	}
+
+	interval := metricsCfg.Interval
+	if interval == 0 {
+		interval = defaultTelemetryMetricsInterval
+	}
+	app.metricsPusher = applicationTelemetry.newMetricsPusher(app.logger, telemetry2.NewMetricsExporter(app.info, mc), interval)
+	if app.metricsPusher == nil {
+		app.logger.Warn("Own metrics are disabled, not sending them to pipeline", zap.String("pipeline", metricsCfg.Pipeline))
+		return
+	}
+
+	app.logger.Info("Sending own metrics to pipeline", zap.String("pipeline", metricsCfg.Pipeline), zap.Duration("interval", interval))
+	app.metricsPusher.Start()
+}
+
+// shutdownMetrics stops pushing the collector's own metrics, the last metrics
+// are sent to the pipeline before it is shutdown.
+func (app *Application) shutdownMetrics() {
+	if app.metricsPusher == nil {
+		return
+	}
+	app.metricsPusher.Stop()
+	app.metricsPusher = nil
+}
+
 func (app *Application) shutdownExtensions(ctx context.Context) error {
 	app.logger.Info("Stopping extensions...")
 	err := app.builtExtensions.ShutdownAll(ctx)
@@ -408,20 +574,33 @@ func (app *Application) shutdownExtensions(ctx context.Context) error {
 }
 
 func (app *Application) execute(ctx context.Context, factory ConfigFactory) error {
-	app.logger.Info("Starting "+app.info.LongName+"...",
+	// Inside of a container use the CPU quota of the container instead of the
+	// number of CPUs of the host.
+	maxProcs, err := iruntime.SetMaxProcs()
+	if err != nil {
+		app.logger.Warn("Failed to set GOMAXPROCS from the CPU quota", zap.Error(err))
+	}
+
+	fields := []zap.Field{
 		zap.String("Version", app.info.Version),
 		zap.String("GitHash", app.info.GitHash),
 		zap.Int("NumCPU", runtime.NumCPU()),
-	)
+		zap.Int("GOMAXPROCS", maxProcs),
+	}
+	if totalMemory, memErr := iruntime.TotalMemory(); memErr == nil {
+		fields = append(fields, zap.Int64("TotalMemory", totalMemory))
+	}
+	app.logger.Info("Starting "+app.info.LongName+"...", fields...)
 	app.stateChannel <- Starting
 
 	// Set memory ballast
 	ballast, ballastSizeBytes := app.createMemoryBallast()
+	app.flagBallastSizeBytes = ballastSizeBytes
 
 	app.asyncErrorChannel = make(chan error)
 
 	// Setup everything.
-	err := app.setupTelemetry(ballastSizeBytes)
+	err = app.setupTelemetry()
 	if err != nil {
 		return err
 	}
@@ -503,6 +682,21 @@ func (app *Application) handleServicezRequest(w http.ResponseWriter, r *http.Req
 		ComponentEndpoint: extensionzPath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Configuration",
+		ComponentEndpoint: configzPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Spans",
+		ComponentEndpoint: tracezPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "RPCs",
+		ComponentEndpoint: rpczPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Build And Runtime", Properties: version.RuntimeVar()})
 	zpages.WriteHTMLFooter(w)
 }
@@ -514,6 +708,9 @@ func (app *Application) handlePipelinezRequest(w http.ResponseWriter, r *http.Re
 	componentName := r.Form.Get(zComponentName)
 	componentKind := r.Form.Get(zComponentKind)
 	zpages.WriteHTMLHeader(w, zpages.HeaderData{Title: "Pipelines"})
+
+	app.reloadMutex.RLock()
+	defer app.reloadMutex.RUnlock()
 	zpages.WriteHTMLPipelinesSummaryTable(w, app.getPipelinesSummaryTableData())
 	if pipelineName != "" && componentName != "" && componentKind != "" {
 		fullName := componentName
@@ -523,7 +720,10 @@ func (app *Application) handlePipelinezRequest(w http.ResponseWriter, r *http.Re
 		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 			Name: componentKind + ": " + fullName,
 		})
-		// TODO: Add config + status info.
+		zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{
+			Name:       "State",
+			Properties: app.getComponentProperties(pipelineName, componentName, componentKind),
+		})
 	}
 	zpages.WriteHTMLFooter(w)
 }
@@ -543,18 +743,35 @@ func (app *Application) handleExtensionzRequest(w http.ResponseWriter, r *http.R
 	zpages.WriteHTMLFooter(w)
 }
 
+// handleConfigzRequest writes the effective configuration as YAML, with the secret
+// settings redacted.
+func (app *Application) handleConfigzRequest(w http.ResponseWriter, _ *http.Request) {
+	app.reloadMutex.RLock()
+	out, err := configz.ToYAML(app.config)
+	app.reloadMutex.RUnlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write(out)
+}
+
 func (app *Application) getPipelinesSummaryTableData() zpages.SummaryPipelinesTableData {
 	data := zpages.SummaryPipelinesTableData{
 		ComponentEndpoint: pipelinezPath,
 	}
 
+	pausedPipelines, pausedReceivers := app.builtPipelines.PausedStates(), app.builtReceivers.PausedStates()
 	data.Rows = make([]zpages.SummaryPipelinesTableRowData, 0, len(app.builtPipelines))
 	for c, p := range app.builtPipelines {
 		row := zpages.SummaryPipelinesTableRowData{
 			FullName:            c.Name,
 			InputType:           string(c.InputType),
 			MutatesConsumedData: p.MutatesConsumedData,
+			Paused:              pausedPipelines[c.Name],
 			Receivers:           c.Receivers,
+			PausedReceivers:     pausedReceivers,
 			Processors:          c.Processors,
 			Exporters:           c.Exporters,
 		}
@@ -567,6 +784,50 @@ func (app *Application) getPipelinesSummaryTableData() zpages.SummaryPipelinesTa
 	return data
 }
 
+// getComponentProperties returns the state of a component of a pipeline, as shown by
+// the pipelinez page.
+func (app *Application) getComponentProperties(pipelineName, componentName, componentKind string) [][2]string {
+	pipeline, ok := app.config.Service.Pipelines[pipelineName]
+	if !ok {
+		return nil
+	}
+	pausedPipelines, pausedReceivers := app.builtPipelines.PausedStates(), app.builtReceivers.PausedStates()
+	properties := [][2]string{
+		{"Pipeline", pipelineName},
+		{"PipelinePaused", strconv.FormatBool(pausedPipelines[pipelineName])},
+	}
+
+	switch componentKind {
+	case "receiver":
+		if cfg, ok := app.config.Receivers[componentName]; ok && hasName(pipeline.Receivers, componentName) {
+			properties = append(properties,
+				[2]string{"Type", string(cfg.Type())},
+				[2]string{"Paused", strconv.FormatBool(pausedReceivers[componentName])},
+				[2]string{"Pipelines", strings.Join(receiverPipelines(app.config, componentName), ", ")})
+		}
+	case "processor":
+		if cfg, ok := app.config.Processors[componentName]; ok && hasName(pipeline.Processors, componentName) {
+			properties = append(properties, [2]string{"Type", string(cfg.Type())})
+		}
+	case "exporter":
+		if cfg, ok := app.config.Exporters[componentName]; ok && hasName(pipeline.Exporters, componentName) {
+			properties = append(properties,
+				[2]string{"Type", string(cfg.Type())},
+				[2]string{"DataTypes", strings.Join(exporterDataTypes(app.config, componentName), ", ")})
+		}
+	}
+	return properties
+}
+
+func hasName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
 func getExtensionsSummaryTableData(host component.Host) zpages.SummaryExtensionsTableData {
 	data := zpages.SummaryExtensionsTableData{
 		ComponentEndpoint: extensionzPath,
@@ -585,9 +846,12 @@ func getExtensionsSummaryTableData(host component.Host) zpages.SummaryExtensions
 	return data
 }
 
+// createMemoryBallast allocates the memory ballast requested via the deprecated
+// command line flag. The memory_ballast extension should be used instead.
 func (app *Application) createMemoryBallast() ([]byte, uint64) {
 	ballastSizeMiB := builder.MemBallastSize()
 	if ballastSizeMiB > 0 {
+		app.logger.Warn("The --" + builder.MemBallastFlag + " flag is deprecated, use the memory_ballast extension instead")
 		ballastSizeBytes := uint64(ballastSizeMiB) * 1024 * 1024
 		ballast := make([]byte, ballastSizeBytes)
 		app.logger.Info("Using memory ballast", zap.Int("MiBs", ballastSizeMiB))
@@ -595,3 +859,13 @@ func (app *Application) createMemoryBallast() ([]byte, uint64) {
 	}
 	return nil, 0
 }
+
+// getBallastSize returns the current size of the memory ballast, in bytes.
+// The size of the memory_ballast extension, if configured, has precedence over
+// the ballast requested via the deprecated command line flag.
+func (app *Application) getBallastSize() uint64 {
+	if mb, ok := app.memoryBallast.Load().(*ballastextension.MemoryBallast); ok {
+		return mb.GetBallastSize()
+	}
+	return app.flagBallastSizeBytes
+}
@@ -22,6 +22,8 @@ import (
 	"flag"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -34,6 +36,13 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processorbasic "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -41,11 +50,14 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/extension/ballastextension"
 	"go.opentelemetry.io/collector/processor/attributesprocessor"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
 	"go.opentelemetry.io/collector/receiver/jaegerreceiver"
 	"go.opentelemetry.io/collector/service/defaultcomponents"
 	"go.opentelemetry.io/collector/service/internal/builder"
+	telemetry2 "go.opentelemetry.io/collector/service/internal/telemetry"
 	"go.opentelemetry.io/collector/testutil"
 )
 
@@ -102,7 +114,11 @@ func TestApplication_Start(t *testing.T) {
 
 type mockAppTelemetry struct{}
 
-func (tel *mockAppTelemetry) init(chan<- error, uint64, *zap.Logger) error {
+func (tel *mockAppTelemetry) init(chan<- error, component.ApplicationStartInfo, func() uint64, *zap.Logger) error {
+	return nil
+}
+
+func (tel *mockAppTelemetry) newMetricsPusher(*zap.Logger, export.Exporter, time.Duration) *telemetry2.MetricsPusher {
 	return nil
 }
 
@@ -197,6 +213,9 @@ func assertMetrics(t *testing.T, prefix string, metricsPort uint16, mandatoryLab
 	parsed, err := parser.TextToMetricFamilies(reader)
 	require.NoError(t, err)
 
+	// The build info is always present.
+	require.Contains(t, parsed, prefix+"_build_info")
+
 	for metricName, metricFamily := range parsed {
 		// require is used here so test fails with a single message.
 		require.True(
@@ -479,6 +498,192 @@ func TestApplication_GetExtensions(t *testing.T) {
 	<-appDone
 }
 
+func TestApplication_getBallastSize(t *testing.T) {
+	app := &Application{flagBallastSizeBytes: 10}
+	assert.Equal(t, uint64(10), app.getBallastSize())
+
+	factory := ballastextension.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*ballastextension.Config)
+	cfg.SizeMiB = 1
+	ext, err := factory.CreateExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, ext.Shutdown(context.Background())) }()
+
+	// The size of the memory_ballast extension has precedence over the flag.
+	app.memoryBallast.Store(ext)
+	assert.Equal(t, uint64(1024*1024), app.getBallastSize())
+}
+
+func TestApplication_setupTraces(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	configStr := `
+receivers:
+  examplereceiver:
+exporters:
+  exampleexporter:
+  exampleexporter/telemetry:
+service:
+  telemetry:
+    traces:
+      pipeline: traces/telemetry
+  pipelines:
+    traces:
+      receivers: [examplereceiver]
+      exporters: [exampleexporter]
+    traces/telemetry:
+      exporters: [exampleexporter/telemetry]
+`
+	v := config.NewViper()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(configStr)))
+	cfg, err := config.Load(v, factories)
+	require.NoError(t, err)
+	require.NoError(t, config.ValidateConfig(cfg, zap.NewNop()))
+
+	app := &Application{logger: zap.NewNop(), info: component.DefaultApplicationStartInfo(), factories: factories, config: cfg}
+	require.NoError(t, app.setupPipelines(context.Background()))
+	require.NotNil(t, app.spanExporter)
+
+	_, span := trace.StartSpan(context.Background(), "receiver/examplereceiver/TraceDataReceived", trace.WithSampler(trace.AlwaysSample()))
+	span.End()
+
+	// The queued spans are sent to the pipeline before it is shutdown.
+	require.NoError(t, app.shutdownPipelines(context.Background()))
+	assert.Nil(t, app.spanExporter)
+
+	exp := app.builtExporters.ToMapByDataType()[configmodels.TracesDataType][cfg.Exporters["exampleexporter/telemetry"]]
+	require.NotNil(t, exp)
+	consumer := exp.(*componenttest.ExampleExporterConsumer)
+	require.Len(t, consumer.Traces, 1)
+	assert.Equal(t, "receiver/examplereceiver/TraceDataReceived",
+		consumer.Traces[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
+}
+
+func TestApplication_setupMetrics(t *testing.T) {
+	checkpointer := processorbasic.New(simple.NewWithHistogramDistribution(), export.CumulativeExportKindSelector(), processorbasic.WithMemory(true))
+	ctrl := controller.New(checkpointer, controller.WithResource(resource.Empty()), controller.WithCollectPeriod(0))
+	preservedAppTelemetry := applicationTelemetry
+	applicationTelemetry = &appTelemetry{controller: ctrl, checkpointer: checkpointer, resource: resource.Empty()}
+	defer func() { applicationTelemetry = preservedAppTelemetry }()
+
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	configStr := `
+receivers:
+  examplereceiver:
+exporters:
+  exampleexporter:
+  exampleexporter/telemetry:
+service:
+  telemetry:
+    metrics:
+      pipeline: metrics/telemetry
+      interval: 1h
+  pipelines:
+    metrics:
+      receivers: [examplereceiver]
+      exporters: [exampleexporter]
+    metrics/telemetry:
+      exporters: [exampleexporter/telemetry]
+`
+	v := config.NewViper()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(configStr)))
+	cfg, err := config.Load(v, factories)
+	require.NoError(t, err)
+	require.NoError(t, config.ValidateConfig(cfg, zap.NewNop()))
+
+	app := &Application{logger: zap.NewNop(), info: component.DefaultApplicationStartInfo(), factories: factories, config: cfg}
+	require.NoError(t, app.setupPipelines(context.Background()))
+	require.NotNil(t, app.metricsPusher)
+
+	metric.Must(ctrl.MeterProvider().Meter("test")).NewInt64Counter("test/count").Add(context.Background(), 1)
+
+	// The last metrics are pushed to the pipeline before it is shutdown.
+	require.NoError(t, app.shutdownPipelines(context.Background()))
+	assert.Nil(t, app.metricsPusher)
+
+	exp := app.builtExporters.ToMapByDataType()[configmodels.MetricsDataType][cfg.Exporters["exampleexporter/telemetry"]]
+	require.NotNil(t, exp)
+	consumer := exp.(*componenttest.ExampleExporterConsumer)
+	require.NotEmpty(t, consumer.Metrics)
+	metrics := consumer.Metrics[len(consumer.Metrics)-1].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	var names []string
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	assert.Contains(t, names, "test/count")
+}
+
+func TestApplication_SetPaused(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "internal", "builder", "testdata", "pipelines_builder.yaml"), factories)
+	require.NoError(t, err)
+
+	app := &Application{logger: zap.NewNop(), info: component.DefaultApplicationStartInfo(), factories: factories, config: cfg}
+	require.NoError(t, app.setupPipelines(context.Background()))
+	defer func() { assert.NoError(t, app.shutdownPipelines(context.Background())) }()
+
+	require.NoError(t, app.SetPipelinePaused("logs", true))
+	require.NoError(t, app.SetReceiverPaused("examplereceiver/2", true))
+	assert.Error(t, app.SetPipelinePaused("nosuchpipeline", true))
+	assert.Error(t, app.SetReceiverPaused("nosuchreceiver", true))
+
+	pipelines, receivers := app.GetPausedStates()
+	assert.Equal(t, map[string]bool{"traces": false, "traces/2": false, "metrics": false, "metrics/2": false, "metrics/3": false, "logs": true}, pipelines)
+	assert.Equal(t, map[string]bool{"examplereceiver": false, "examplereceiver/2": true, "examplereceiver/3": false, "examplereceiver/multi": false}, receivers)
+}
+
+func TestApplication_Pipelinez(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "internal", "builder", "testdata", "pipelines_builder.yaml"), factories)
+	require.NoError(t, err)
+
+	app := &Application{logger: zap.NewNop(), info: component.DefaultApplicationStartInfo(), factories: factories, config: cfg}
+	require.NoError(t, app.setupPipelines(context.Background()))
+	defer func() { assert.NoError(t, app.shutdownPipelines(context.Background())) }()
+	require.NoError(t, app.SetReceiverPaused("examplereceiver/2", true))
+
+	mux := http.NewServeMux()
+	app.RegisterZPages(mux, "/debug")
+	get := func(target string) string {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		return rr.Body.String()
+	}
+
+	assert.Contains(t, get("/debug/servicez"), `<a href="tracez">`)
+	assert.Contains(t, get("/debug/configz"), "exampleexporter/2:")
+	assert.Contains(t, get("/debug/pipelinez"), ">examplereceiver/2</a> (paused)")
+
+	body := get("/debug/pipelinez?zpipelinename=traces/2&zcomponentname=examplereceiver/2&zcomponentkind=receiver")
+	assert.Contains(t, body, "receiver: examplereceiver/2")
+	assert.Contains(t, body, "<b>Paused</b>")
+	assert.Contains(t, body, "traces/2")
+
+	assert.Equal(t, [][2]string{
+		{"Pipeline", "traces/2"},
+		{"PipelinePaused", "false"},
+		{"Type", "exampleexporter"},
+		{"DataTypes", "logs, metrics, traces"},
+	}, app.getComponentProperties("traces/2", "exampleexporter/2", "exporter"))
+	assert.Equal(t, [][2]string{
+		{"Pipeline", "traces/2"},
+		{"PipelinePaused", "false"},
+		{"Type", "examplereceiver"},
+		{"Paused", "true"},
+		{"Pipelines", "traces/2"},
+	}, app.getComponentProperties("traces/2", "examplereceiver/2", "receiver"))
+	assert.Nil(t, app.getComponentProperties("nosuchpipeline", "exampleexporter", "exporter"))
+}
+
 func TestApplication_GetExporters(t *testing.T) {
 	app := createExampleApplication(t)
 
@@ -17,19 +17,33 @@ package service
 import (
 	"net/http"
 	"strings"
+	"time"
 	"unicode"
 
-	"contrib.go.opencensus.io/exporter/prometheus"
+	ocprom "contrib.go.opencensus.io/exporter/prometheus"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel/attribute"
+	otelprom "go.opentelemetry.io/otel/exporters/metric/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processorbasic "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/jaegerexporter"
 	"go.opentelemetry.io/collector/internal/collector/telemetry"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/processor/cardinalitylimitprocessor"
+	"go.opentelemetry.io/collector/processor/ratelimitprocessor"
 	fluentobserv "go.opentelemetry.io/collector/receiver/fluentforwardreceiver/observ"
 	"go.opentelemetry.io/collector/receiver/kafkareceiver"
 	telemetry2 "go.opentelemetry.io/collector/service/internal/telemetry"
@@ -40,16 +54,22 @@ import (
 var applicationTelemetry appTelemetryExporter = &appTelemetry{}
 
 type appTelemetryExporter interface {
-	init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger) error
+	init(asyncErrorChannel chan<- error, info component.ApplicationStartInfo, getBallastSize func() uint64, logger *zap.Logger) error
+	// newMetricsPusher returns a pusher of the application's metrics to the exporter,
+	// or nil if the metrics are disabled.
+	newMetricsPusher(logger *zap.Logger, exporter export.Exporter, interval time.Duration) *telemetry2.MetricsPusher
 	shutdown() error
 }
 
 type appTelemetry struct {
-	views  []*view.View
-	server *http.Server
+	views        []*view.View
+	server       *http.Server
+	controller   *controller.Controller
+	checkpointer export.Checkpointer
+	resource     *resource.Resource
 }
 
-func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger) error {
+func (tel *appTelemetry) init(asyncErrorChannel chan<- error, info component.ApplicationStartInfo, getBallastSize func() uint64, logger *zap.Logger) error {
 	level := configtelemetry.GetMetricsLevelFlagValue()
 	metricsAddr := telemetry.GetMetricsAddr()
 
@@ -57,19 +77,25 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 		return nil
 	}
 
-	processMetricsViews, err := telemetry2.NewProcessMetricsViews(ballastSizeBytes)
+	processMetricsViews, err := telemetry2.NewProcessMetricsViews(getBallastSize)
 	if err != nil {
 		return err
 	}
+	serviceMetricsViews := telemetry2.NewServiceMetricsViews(info)
+
+	obsreport.Configure(level)
 
 	var views []*view.View
 	views = append(views, batchprocessor.MetricViews()...)
+	views = append(views, ratelimitprocessor.MetricViews()...)
+	views = append(views, cardinalitylimitprocessor.MetricViews()...)
 	views = append(views, fluentobserv.MetricViews()...)
+	views = append(views, exporterhelper.MetricViews()...)
 	views = append(views, jaegerexporter.MetricViews()...)
 	views = append(views, kafkareceiver.MetricViews()...)
-	views = append(views, obsreport.Configure(level)...)
 	views = append(views, processMetricsViews.Views()...)
 	views = append(views, processor.MetricViews()...)
+	views = append(views, serviceMetricsViews.Views()...)
 
 	tel.views = views
 	if err = view.Register(views...); err != nil {
@@ -77,28 +103,59 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	}
 
 	processMetricsViews.StartCollection()
+	if err = serviceMetricsViews.StartCollection(); err != nil {
+		return err
+	}
 
-	// Until we can use a generic metrics exporter, default to Prometheus.
-	opts := prometheus.Options{
-		Namespace: telemetry.GetMetricsPrefix(),
+	// The metrics of obsreport are recorded with the OpenTelemetry SDK, the
+	// OpenCensus views still registered by the components are bridged: both are
+	// served by Prometheus from the same registry, and can be pushed to a
+	// pipeline.
+	registry := prometheus.NewRegistry()
+	prefix := telemetry.GetMetricsPrefix()
+	ocOpts := ocprom.Options{
+		Namespace: prefix,
+		Registry:  registry,
 	}
+	res := resource.Empty()
 
 	var instanceID string
 	if telemetry.GetAddInstanceID() {
 		instanceUUID, _ := uuid.NewRandom()
 		instanceID = instanceUUID.String()
-		opts.ConstLabels = map[string]string{
+		ocOpts.ConstLabels = map[string]string{
 			sanitizePrometheusKey(conventions.AttributeServiceInstance): instanceID,
 		}
+		res = resource.NewWithAttributes(attribute.String(conventions.AttributeServiceInstance, instanceID))
 	}
 
-	pe, err := prometheus.NewExporter(opts)
+	pe, err := ocprom.NewExporter(ocOpts)
 	if err != nil {
 		return err
 	}
 
 	view.RegisterExporter(pe)
 
+	checkpointer := processorbasic.New(obsreport.AggregatorSelector(), export.CumulativeExportKindSelector(), processorbasic.WithMemory(true))
+	ctrl := controller.New(
+		checkpointer,
+		controller.WithResource(res),
+		// Collect on every scrape and push.
+		controller.WithCollectPeriod(0))
+
+	var registerer prometheus.Registerer = registry
+	if prefix != "" {
+		registerer = prometheus.WrapRegistererWithPrefix(prefix+"_", registry)
+	}
+	if _, err = otelprom.NewExporter(otelprom.Config{Registerer: registerer, Gatherer: registry}, ctrl); err != nil {
+		return err
+	}
+
+	tel.controller = ctrl
+	tel.checkpointer = checkpointer
+	tel.resource = res
+	obsreport.SetMeterProvider(ctrl.MeterProvider())
+
 	logger.Info(
 		"Serving Prometheus metrics",
 		zap.String("address", metricsAddr),
@@ -107,7 +164,7 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	)
 
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", pe)
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
 
 	tel.server = &http.Server{
 		Addr:    metricsAddr,
@@ -124,8 +181,22 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	return nil
 }
 
+func (tel *appTelemetry) newMetricsPusher(logger *zap.Logger, exporter export.Exporter, interval time.Duration) *telemetry2.MetricsPusher {
+	if tel.controller == nil {
+		return nil
+	}
+	return telemetry2.NewMetricsPusher(
+		logger,
+		tel.controller,
+		tel.checkpointer,
+		telemetry2.WithOpenCensusMetrics(exporter, tel.resource),
+		interval)
+}
+
 func (tel *appTelemetry) shutdown() error {
 	view.Unregister(tel.views...)
+	obsreport.SetMeterProvider(metric.NoopMeterProvider{})
+	tel.controller = nil
 
 	if tel.server != nil {
 		return tel.server.Close()
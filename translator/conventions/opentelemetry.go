@@ -64,6 +64,7 @@ const (
 	AttributeProcessExecutablePath      = "process.executable.path"
 	AttributeProcessID                  = "process.pid"
 	AttributeProcessOwner               = "process.owner"
+	AttributeProcessParentPID           = "process.parent_pid"
 	AttributeServiceInstance            = "service.instance.id"
 	AttributeServiceName                = "service.name"
 	AttributeServiceNamespace           = "service.namespace"
@@ -167,6 +168,7 @@ func GetResourceSemanticConventionAttributeNames() []string {
 		AttributeProcessExecutablePath,
 		AttributeProcessID,
 		AttributeProcessOwner,
+		AttributeProcessParentPID,
 		AttributeServiceInstance,
 		AttributeServiceName,
 		AttributeServiceNamespace,
@@ -21,6 +21,7 @@ const (
 	AttributeMessageID               = "message.id"
 	AttributeMessageType             = "message.type"
 	AttributeMessageUncompressedSize = "message.uncompressed_size"
+	AttributeRPCGRPCStatusCode       = "rpc.grpc.status_code"
 	AttributeRPCMethod               = "rpc.method"
 	AttributeRPCService              = "rpc.service"
 	AttributeRPCSystem               = "rpc.system"